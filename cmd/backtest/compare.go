@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/simulation"
+	"solana-token-lab/internal/storage"
+)
+
+// compareStrategyTypes are the strategies --compare runs when --strategy is empty, in table order.
+var compareStrategyTypes = catalog.StrategyTypes()
+
+// compareScenarios are the scenarios every compared strategy runs under, in table order.
+var compareScenarios = domain.PredefinedScenarios
+
+// compareRow is one strategy × scenario cell of a comparison.
+type compareRow struct {
+	Strategy       string  `json:"strategy"`
+	Scenario       string  `json:"scenario"`
+	TradeID        string  `json:"trade_id,omitempty"`
+	Outcome        float64 `json:"net_outcome"`
+	OutcomeClass   string  `json:"outcome_class,omitempty"`
+	ExitReason     string  `json:"exit_reason,omitempty"`
+	HoldDurationMs int64   `json:"hold_duration_ms"`
+	Error          string  `json:"error,omitempty"` // simulation failed for this cell
+}
+
+// compareResult is the output of --compare for one candidate.
+type compareResult struct {
+	CandidateID    string       `json:"candidate_id"`
+	EntryEventType string       `json:"entry_event_type"`
+	Rows           []compareRow `json:"rows"`
+}
+
+// parseCompareStrategies parses a comma-separated strategy list; empty selects all.
+func parseCompareStrategies(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return compareStrategyTypes, nil
+	}
+	var types []string
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if err := catalog.CheckStrategyType(s); err != nil {
+			return nil, fmt.Errorf("invalid strategy: %w", err)
+		}
+		types = append(types, s)
+	}
+	return types, nil
+}
+
+// runCompare simulates every strategy under every scenario for one candidate.
+// Rows follow the order of strategies, then compareScenarios. A failed cell is
+// recorded in its row; only a missing candidate or cancellation aborts.
+func runCompare(ctx context.Context, runner *simulation.Runner, candidateID string, strategies []domain.StrategyConfig) (*compareResult, error) {
+	result := &compareResult{CandidateID: candidateID}
+	if len(strategies) > 0 {
+		result.EntryEventType = strategies[0].EntryEventType
+	}
+
+	for _, cfg := range strategies {
+		for _, scenario := range compareScenarios {
+			row := compareRow{Strategy: cfg.StrategyType, Scenario: scenario.ScenarioID}
+			trade, err := runner.Run(ctx, candidateID, cfg, scenario)
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				return nil, fmt.Errorf("candidate %s: %w", candidateID, err)
+			case ctx.Err() != nil:
+				return nil, ctx.Err()
+			case err != nil:
+				row.Error = err.Error()
+			default:
+				row.TradeID = trade.TradeID
+				row.Outcome = trade.Outcome
+				row.OutcomeClass = trade.OutcomeClass
+				row.ExitReason = trade.ExitReason
+				row.HoldDurationMs = trade.HoldDurationMs
+			}
+			result.Rows = append(result.Rows, row)
+		}
+	}
+	return result, nil
+}
+
+// printCompareTable writes the comparison as one aligned strategy × scenario table.
+func printCompareTable(w io.Writer, result *compareResult) {
+	fmt.Fprintf(w, "Candidate: %s (entry %s)\n\n", result.CandidateID, result.EntryEventType)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STRATEGY\tSCENARIO\tNET OUTCOME\tCLASS\tEXIT REASON\tHOLD")
+	for _, r := range result.Rows {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t-\t(error: %s)\n", r.Strategy, r.Scenario, r.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%+.2f%%\t%s\t%s\t%v\n",
+			r.Strategy, r.Scenario, r.Outcome*100, r.OutcomeClass, r.ExitReason,
+			time.Duration(r.HoldDurationMs)*time.Millisecond)
+	}
+	tw.Flush()
+}
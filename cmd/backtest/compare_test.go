@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/simulation"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// newCompareRunner returns a runner over one NEW_TOKEN candidate with rising prices
+// and steady liquidity.
+func newCompareRunner(t *testing.T) *simulation.Runner {
+	t.Helper()
+	ctx := context.Background()
+	candidates := memory.NewCandidateStore()
+	prices := memory.NewPriceTimeseriesStore()
+	liquidity := memory.NewLiquidityTimeseriesStore()
+
+	if err := candidates.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "cmp-1", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx1", Slot: 100, DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+	var pricePoints []*domain.PriceTimeseriesPoint
+	var liqPoints []*domain.LiquidityTimeseriesPoint
+	for i, p := range []float64{1.0, 1.1, 1.2, 1.3, 1.25, 1.4} {
+		ts := 1000000 + int64(i)*60000
+		pricePoints = append(pricePoints, &domain.PriceTimeseriesPoint{CandidateID: "cmp-1", TimestampMs: ts, Slot: int64(100 + i), Price: p})
+		liqPoints = append(liqPoints, &domain.LiquidityTimeseriesPoint{CandidateID: "cmp-1", TimestampMs: ts, Slot: int64(100 + i), Liquidity: 5000})
+	}
+	if err := prices.InsertBulk(ctx, pricePoints); err != nil {
+		t.Fatalf("insert prices: %v", err)
+	}
+	if err := liquidity.InsertBulk(ctx, liqPoints); err != nil {
+		t.Fatalf("insert liquidity: %v", err)
+	}
+
+	return simulation.NewRunner(simulation.RunnerOptions{
+		CandidateStore:       candidates,
+		PriceTimeseriesStore: prices,
+		LiqTimeseriesStore:   liquidity,
+	})
+}
+
+func compareConfigs(t *testing.T, spec string) []domain.StrategyConfig {
+	t.Helper()
+	types, err := parseCompareStrategies(spec)
+	if err != nil {
+		t.Fatalf("parseCompareStrategies: %v", err)
+	}
+	var configs []domain.StrategyConfig
+	for _, st := range types {
+		configs = append(configs, buildStrategyConfig(st, "NEW_TOKEN", 120000, 0.10, 0.10, 0, 0.30, "", 300000))
+	}
+	return configs
+}
+
+func TestRunCompare_TableOrderAndContents(t *testing.T) {
+	result, err := runCompare(context.Background(), newCompareRunner(t), "cmp-1", compareConfigs(t, ""))
+	if err != nil {
+		t.Fatalf("runCompare: %v", err)
+	}
+
+	if len(result.Rows) != len(compareStrategyTypes)*len(compareScenarios) {
+		t.Fatalf("expected %d rows, got %d", len(compareStrategyTypes)*len(compareScenarios), len(result.Rows))
+	}
+	for i, row := range result.Rows {
+		wantStrategy := compareStrategyTypes[i/len(compareScenarios)]
+		wantScenario := compareScenarios[i%len(compareScenarios)].ScenarioID
+		if row.Strategy != wantStrategy || row.Scenario != wantScenario {
+			t.Errorf("row %d = %s/%s, want %s/%s", i, row.Strategy, row.Scenario, wantStrategy, wantScenario)
+		}
+		if row.Error != "" {
+			t.Errorf("row %d: unexpected error %s", i, row.Error)
+		}
+	}
+
+	// Costs grow from optimistic to degraded, so net outcome falls for the same exit
+	timeExit := result.Rows[:len(compareScenarios)]
+	if timeExit[0].ExitReason != domain.ExitReasonTimeExit || timeExit[0].HoldDurationMs != 120000 {
+		t.Errorf("TIME_EXIT optimistic = %+v, want TIME_EXIT exit after 2m", timeExit[0])
+	}
+	if !(timeExit[0].Outcome > timeExit[3].Outcome) {
+		t.Errorf("optimistic outcome %v should exceed degraded %v", timeExit[0].Outcome, timeExit[3].Outcome)
+	}
+
+	var buf bytes.Buffer
+	printCompareTable(&buf, result)
+	out := buf.String()
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if !strings.HasPrefix(lines[2], "STRATEGY") || !strings.Contains(lines[2], "NET OUTCOME") {
+		t.Errorf("missing table header:\n%s", out)
+	}
+	if len(lines) != 3+len(result.Rows) {
+		t.Errorf("expected %d table lines, got %d:\n%s", 3+len(result.Rows), len(lines), out)
+	}
+	if !strings.Contains(lines[3], "TIME_EXIT") || !strings.Contains(lines[3], "optimistic") || !strings.Contains(lines[3], "2m0s") {
+		t.Errorf("first row = %q", lines[3])
+	}
+	// Columns are aligned: the scenario column starts at the same offset on every row
+	col := strings.Index(lines[3], "optimistic")
+	if strings.Index(lines[7], "optimistic") != col {
+		t.Errorf("scenario column not aligned:\n%s", out)
+	}
+}
+
+func TestRunCompare_SelectedStrategiesJSON(t *testing.T) {
+	result, err := runCompare(context.Background(), newCompareRunner(t), "cmp-1", compareConfigs(t, "trailing_stop"))
+	if err != nil {
+		t.Fatalf("runCompare: %v", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded struct {
+		CandidateID    string           `json:"candidate_id"`
+		EntryEventType string           `json:"entry_event_type"`
+		Rows           []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.CandidateID != "cmp-1" || decoded.EntryEventType != "NEW_TOKEN" || len(decoded.Rows) != len(compareScenarios) {
+		t.Fatalf("unexpected JSON: %s", data)
+	}
+	for _, key := range []string{"strategy", "scenario", "trade_id", "net_outcome", "exit_reason", "hold_duration_ms"} {
+		if _, ok := decoded.Rows[0][key]; !ok {
+			t.Errorf("row missing key %q: %v", key, decoded.Rows[0])
+		}
+	}
+	if decoded.Rows[0]["strategy"] != domain.StrategyTypeTrailingStop {
+		t.Errorf("strategy = %v", decoded.Rows[0]["strategy"])
+	}
+}
+
+func TestRunCompare_Errors(t *testing.T) {
+	_, err := parseCompareStrategies("TIME_EXIT,MOON")
+	if !errors.Is(err, domain.ErrNotInCatalog) {
+		t.Errorf("expected unknown strategy to be rejected by the catalog, got %v", err)
+	} else if !strings.Contains(err.Error(), "TIME_EXIT, TRAILING_STOP, LIQUIDITY_GUARD") {
+		t.Errorf("expected the error to list the catalog strategies, got %v", err)
+	}
+
+	_, err = runCompare(context.Background(), newCompareRunner(t), "missing", compareConfigs(t, "TIME_EXIT"))
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing candidate, got %v", err)
+	}
+}
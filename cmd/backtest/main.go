@@ -3,27 +3,36 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/pipeline"
+	"solana-token-lab/internal/reporting"
 	"solana-token-lab/internal/simulation"
 	"solana-token-lab/internal/storage"
-	chstore "solana-token-lab/internal/storage/clickhouse"
-	"solana-token-lab/internal/storage/memory"
-	pgstore "solana-token-lab/internal/storage/postgres"
+	"solana-token-lab/internal/storage/factory"
+	"solana-token-lab/internal/strategy"
+	"solana-token-lab/internal/tradeimport"
 )
 
+// catalog lists the strategies and scenarios --strategy, --scenario and
+// --compare accept.
+var catalog = domain.NewCatalog(pipeline.StrategyVersion)
+
 func main() {
 	// Parse flags
 	candidateID := flag.String("candidate-id", "", "Candidate ID to backtest (required)")
-	strategyType := flag.String("strategy", "", "Strategy: TIME_EXIT, TRAILING_STOP, LIQUIDITY_GUARD (required)")
+	strategyType := flag.String("strategy", "", "Strategy: TIME_EXIT, TRAILING_STOP, LIQUIDITY_GUARD (required; comma-separated list with --compare)")
 	scenarioName := flag.String("scenario", "realistic", "Scenario: optimistic, realistic, pessimistic, degraded")
 	entryEventType := flag.String("entry-event", "NEW_TOKEN", "Entry event type: NEW_TOKEN, ACTIVE_TOKEN")
 
@@ -31,43 +40,80 @@ func main() {
 	holdDurationMs := flag.Int64("hold-duration-ms", 300000, "Hold duration for TIME_EXIT (ms)")
 	trailPct := flag.Float64("trail-pct", 0.10, "Trail percentage for TRAILING_STOP")
 	initialStopPct := flag.Float64("initial-stop-pct", 0.10, "Initial stop for TRAILING_STOP")
+	activationGainPct := flag.Float64("activation-gain-pct", 0, "Gain over entry that arms the TRAILING_STOP trailing stop (0 = armed at entry)")
 	liquidityDropPct := flag.Float64("liquidity-drop-pct", 0.30, "Liquidity drop for LIQUIDITY_GUARD")
+	liquidityReference := flag.String("liquidity-reference", domain.LiquidityReferenceEntry, "Liquidity the LIQUIDITY_GUARD drop is measured from: entry, or peak (running max since entry)")
 	maxHoldMs := flag.Int64("max-hold-ms", 3600000, "Max hold duration (ms)")
 
 	// Storage
 	postgresDSN := flag.String("postgres-dsn", "", "PostgreSQL connection string")
 	clickhouseDSN := flag.String("clickhouse-dsn", "", "ClickHouse connection string")
 	useMemory := flag.Bool("use-memory", false, "Use in-memory storage")
+	fromRaw := flag.Bool("from-raw", false, "Build time series from raw swaps/liquidity events in PostgreSQL (no ClickHouse needed)")
 
 	// Output
 	outputJSON := flag.Bool("json", false, "Output as JSON")
+	compare := flag.Bool("compare", false, "Run all strategies (or those in --strategy) under all four scenarios for --candidate-id and print one table")
 	persistResult := flag.Bool("persist", false, "Persist trade record to storage")
 
+	// What-if analysis
+	whatIf := flag.String("whatif", "", "Re-simulate stored trades with the strategy flags as alternate exit: a trade ID, or a strategy ID / base type selecting all its trades")
+	whatIfExitReason := flag.String("whatif-exit-reason", "", "Only re-simulate trades with this exit reason (e.g. MAX_DURATION)")
+	whatIfOutput := flag.String("whatif-output", "whatif_analysis.csv", "What-if analysis CSV output path")
+
+	// Cost recomputation after scenario definition changes
+	recomputeCosts := flag.Bool("recompute-costs", false, "Re-cost stored trades with the current scenario definitions as a new scenario version (originals are kept)")
+	scenarioVersion := flag.Int("scenario-version", 0, "Scenario version to tag recomputed trades with (required with --recompute-costs, > --from-scenario-version)")
+	fromScenarioVersion := flag.Int("from-scenario-version", domain.BaseScenarioVersion, "Scenario version of the stored trades to recompute")
+
+	// Import of trades simulated elsewhere
+	importTrades := flag.String("import-trades", "", "Import an external simulator's trade_records.csv-compatible file and compute its aggregates")
+	importNamespace := flag.String("import-namespace", "", "Namespace of imported strategies, stored as EXT:<namespace>:<strategy_id> (required with --import-trades)")
+
 	flag.Parse()
 
 	// Setup logger
 	logger := log.New(os.Stderr, "[backtest] ", log.LstdFlags)
 
 	// Validate required flags
-	if *candidateID == "" {
-		logger.Fatal("--candidate-id is required")
-	}
-	if *strategyType == "" {
-		logger.Fatal("--strategy is required")
-	}
+	if *importTrades != "" {
+		if *importNamespace == "" {
+			logger.Fatal("--import-namespace is required with --import-trades")
+		}
+	} else if *recomputeCosts {
+		if *scenarioVersion <= *fromScenarioVersion {
+			logger.Fatalf("--scenario-version must be greater than --from-scenario-version (%d)", *fromScenarioVersion)
+		}
+	} else if *compare {
+		if *candidateID == "" {
+			logger.Fatal("--candidate-id is required with --compare")
+		}
+		if *whatIf != "" {
+			logger.Fatal("--compare cannot be combined with --whatif")
+		}
+		*entryEventType = strings.ToUpper(*entryEventType)
+		if *entryEventType != "NEW_TOKEN" && *entryEventType != "ACTIVE_TOKEN" {
+			logger.Fatalf("Invalid entry event type: %s. Must be NEW_TOKEN or ACTIVE_TOKEN", *entryEventType)
+		}
+	} else {
+		if *candidateID == "" && *whatIf == "" {
+			logger.Fatal("--candidate-id is required (or --whatif or --recompute-costs)")
+		}
+		if *strategyType == "" {
+			logger.Fatal("--strategy is required")
+		}
 
-	// Normalize strategy type
-	*strategyType = strings.ToUpper(*strategyType)
-	if *strategyType != domain.StrategyTypeTimeExit &&
-		*strategyType != domain.StrategyTypeTrailingStop &&
-		*strategyType != domain.StrategyTypeLiquidityGuard {
-		logger.Fatalf("Invalid strategy: %s. Must be TIME_EXIT, TRAILING_STOP, or LIQUIDITY_GUARD", *strategyType)
-	}
+		// Normalize strategy type
+		*strategyType = strings.ToUpper(*strategyType)
+		if err := catalog.CheckStrategyType(*strategyType); err != nil {
+			logger.Fatalf("Invalid strategy: %v", err)
+		}
 
-	// Normalize and validate entry event type
-	*entryEventType = strings.ToUpper(*entryEventType)
-	if *entryEventType != "NEW_TOKEN" && *entryEventType != "ACTIVE_TOKEN" {
-		logger.Fatalf("Invalid entry event type: %s. Must be NEW_TOKEN or ACTIVE_TOKEN", *entryEventType)
+		// Normalize and validate entry event type
+		*entryEventType = strings.ToUpper(*entryEventType)
+		if *entryEventType != "NEW_TOKEN" && *entryEventType != "ACTIVE_TOKEN" {
+			logger.Fatalf("Invalid entry event type: %s. Must be NEW_TOKEN or ACTIVE_TOKEN", *entryEventType)
+		}
 	}
 
 	// Create context with cancellation
@@ -84,39 +130,89 @@ func main() {
 	}()
 
 	// Create stores
-	var candidateStore storage.CandidateStore = memory.NewCandidateStore()
-	var priceStore storage.PriceTimeseriesStore = memory.NewPriceTimeseriesStore()
-	var liqStore storage.LiquidityTimeseriesStore = memory.NewLiquidityTimeseriesStore()
-	var tradeStore storage.TradeRecordStore = memory.NewTradeRecordStore()
-
+	storeCfg := factory.Config{
+		Backend:       factory.BackendMemory,
+		PostgresDSN:   *postgresDSN,
+		ClickHouseDSN: *clickhouseDSN,
+		Needs: factory.NeedCandidates | factory.NeedTrades | factory.NeedSwaps | factory.NeedLiquidityEvents |
+			factory.NeedPriceTimeseries | factory.NeedLiquidityTimeseries | factory.NeedStrategyAggregates,
+		SkipMigrations: true,
+	}
 	if !*useMemory {
 		// Require DSNs when not using memory
 		if *postgresDSN == "" {
 			logger.Fatal("--postgres-dsn is required when not using --use-memory (candidates and trade records)")
 		}
-		if *clickhouseDSN == "" {
-			logger.Fatal("--clickhouse-dsn is required when not using --use-memory (price/liquidity timeseries)")
+		if *clickhouseDSN == "" && !*fromRaw {
+			logger.Fatal("--clickhouse-dsn is required when not using --use-memory or --from-raw (price/liquidity timeseries)")
 		}
+		storeCfg.Backend = factory.BackendPostgres
 
-		// PostgreSQL for candidates and trade records
-		pool, err := pgstore.NewPool(ctx, *postgresDSN)
-		if err != nil {
-			logger.Fatalf("connect to postgres: %v", err)
+		// ClickHouse is not needed when building time series from raw events.
+		// Aggregates live there too; cmd/report computes them for the version instead
+		if *fromRaw {
+			storeCfg.Needs &^= factory.NeedPriceTimeseries | factory.NeedLiquidityTimeseries | factory.NeedStrategyAggregates
 		}
-		defer pool.Close()
+	}
+	stores, cleanup, err := factory.BuildStores(ctx, storeCfg)
+	if err != nil {
+		logger.Fatalf("create stores: %v", err)
+	}
+	defer cleanup()
+	candidateStore, tradeStore, aggStore := stores.Candidates, stores.Trades, stores.StrategyAggregates
+	priceStore, liqStore := stores.PriceTimeseries, stores.LiquidityTimeseries
+	swapStore, liqEventStore := stores.Swaps, stores.LiquidityEvents
+
+	if *importTrades != "" {
+		runImportTrades(ctx, logger, tradeStore, candidateStore, aggStore, *importTrades, *importNamespace)
+		return
+	}
+
+	if *recomputeCosts {
+		runRecomputeCosts(ctx, logger, tradeStore, candidateStore, aggStore, *fromScenarioVersion, *scenarioVersion)
+		return
+	}
+
+	// Create simulation runner
+	var tradeRecordStore storage.TradeRecordStore
+	if *persistResult {
+		tradeRecordStore = tradeStore
+	}
 
-		candidateStore = pgstore.NewCandidateStore(pool)
-		tradeStore = pgstore.NewTradeRecordStore(pool)
+	runner := simulation.NewRunner(simulation.RunnerOptions{
+		CandidateStore:       candidateStore,
+		PriceTimeseriesStore: priceStore,
+		LiqTimeseriesStore:   liqStore,
+		TradeRecordStore:     tradeRecordStore,
+		FromRaw:              *fromRaw,
+		SwapStore:            swapStore,
+		LiquidityEventStore:  liqEventStore,
+	})
 
-		// ClickHouse for time series
-		conn, err := chstore.NewConn(ctx, *clickhouseDSN)
+	if *compare {
+		types, err := parseCompareStrategies(*strategyType)
 		if err != nil {
-			logger.Fatalf("connect to clickhouse: %v", err)
+			logger.Fatal(err)
+		}
+		var configs []domain.StrategyConfig
+		for _, t := range types {
+			configs = append(configs, buildStrategyConfig(t, *entryEventType,
+				*holdDurationMs, *trailPct, *initialStopPct, *activationGainPct, *liquidityDropPct, *liquidityReference, *maxHoldMs))
 		}
-		defer conn.Close()
+		logger.Printf("Comparing %d strategies x %d scenarios: candidate=%s from_raw=%v",
+			len(configs), len(compareScenarios), *candidateID, *fromRaw)
 
-		priceStore = chstore.NewPriceTimeseriesStore(conn)
-		liqStore = chstore.NewLiquidityTimeseriesStore(conn)
+		result, err := runCompare(ctx, runner, *candidateID, configs)
+		if err != nil {
+			logger.Fatalf("compare failed: %v", err)
+		}
+		if *outputJSON {
+			output, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(output))
+		} else {
+			printCompareTable(os.Stdout, result)
+		}
+		return
 	}
 
 	// Build strategy config
@@ -126,34 +222,28 @@ func main() {
 		*holdDurationMs,
 		*trailPct,
 		*initialStopPct,
+		*activationGainPct,
 		*liquidityDropPct,
+		*liquidityReference,
 		*maxHoldMs,
 	)
 
 	// Get scenario config
-	scenarioConfig := getScenarioConfig(*scenarioName)
-	if scenarioConfig == nil {
-		logger.Fatalf("Invalid scenario: %s. Must be optimistic, realistic, pessimistic, or degraded", *scenarioName)
+	scenarioConfig, err := getScenarioConfig(*scenarioName)
+	if err != nil {
+		logger.Fatalf("Invalid scenario: %v", err)
 	}
 
-	// Create simulation runner
-	var tradeRecordStore storage.TradeRecordStore
-	if *persistResult {
-		tradeRecordStore = tradeStore
+	if *whatIf != "" {
+		runWhatIf(ctx, logger, runner, tradeStore, *whatIf, strings.ToUpper(*whatIfExitReason), strategyConfig, *whatIfOutput)
+		return
 	}
 
-	runner := simulation.NewRunner(simulation.RunnerOptions{
-		CandidateStore:       candidateStore,
-		PriceTimeseriesStore: priceStore,
-		LiqTimeseriesStore:   liqStore,
-		TradeRecordStore:     tradeRecordStore,
-	})
-
 	// Run simulation
-	logger.Printf("Running backtest: candidate=%s strategy=%s scenario=%s",
-		*candidateID, *strategyType, *scenarioName)
+	logger.Printf("Running backtest: candidate=%s strategy=%s scenario=%s from_raw=%v",
+		*candidateID, *strategyType, *scenarioName, *fromRaw)
 
-	trade, err := runner.Run(ctx, *candidateID, strategyConfig, *scenarioConfig)
+	trade, err := runner.Run(ctx, *candidateID, strategyConfig, scenarioConfig)
 	if err != nil {
 		logger.Fatalf("backtest failed: %v", err)
 	}
@@ -167,11 +257,186 @@ func main() {
 	}
 }
 
+// runWhatIf re-simulates the selected stored trades with cfg as the alternate exit strategy,
+// writes the per-trade comparison to outputPath and prints the delta distribution.
+func runWhatIf(ctx context.Context, logger *log.Logger, runner *simulation.Runner, tradeStore storage.TradeRecordStore,
+	selector, exitReason string, cfg domain.StrategyConfig, outputPath string) {
+	trades, err := selectWhatIfTrades(ctx, tradeStore, selector, exitReason)
+	if err != nil {
+		logger.Fatalf("load trades: %v", err)
+	}
+	if len(trades) == 0 {
+		logger.Fatalf("no trades match --whatif %q (exit reason %q)", selector, exitReason)
+	}
+
+	logger.Printf("Running what-if: %d trades, alternate strategy=%s", len(trades), cfg.StrategyType)
+
+	results, err := runner.WhatIfAll(ctx, trades, cfg, lookupScenario)
+	if err != nil {
+		logger.Fatalf("what-if failed: %v", err)
+	}
+
+	if err := os.WriteFile(outputPath, []byte(reporting.RenderWhatIfCSV(results)), 0644); err != nil {
+		logger.Fatalf("write %s: %v", outputPath, err)
+	}
+
+	sum := simulation.SummarizeWhatIf(results)
+	fmt.Println()
+	fmt.Println("=== What-If Analysis ===")
+	fmt.Printf("Trades:             %d\n", sum.Trades)
+	fmt.Printf("Improved:           %d\n", sum.Improved)
+	fmt.Printf("Worsened:           %d\n", sum.Worsened)
+	fmt.Printf("Unchanged:          %d\n", sum.Unchanged)
+	fmt.Println()
+	fmt.Println("Outcome Delta (alternate - original):")
+	fmt.Printf("  Mean:             %+.2f%%\n", sum.DeltaMean*100)
+	fmt.Printf("  Median:           %+.2f%%\n", sum.DeltaMedian*100)
+	fmt.Printf("  P10:              %+.2f%%\n", sum.DeltaP10*100)
+	fmt.Printf("  P90:              %+.2f%%\n", sum.DeltaP90*100)
+	fmt.Printf("  Min:              %+.2f%%\n", sum.DeltaMin*100)
+	fmt.Printf("  Max:              %+.2f%%\n", sum.DeltaMax*100)
+	fmt.Println()
+	fmt.Printf("Written to %s\n", outputPath)
+}
+
+// runRecomputeCosts re-costs the stored trades of scenario version from with the current
+// scenario definitions, stores them as version to next to the originals and computes
+// their strategy aggregates. A nil aggStore skips the aggregates.
+func runRecomputeCosts(ctx context.Context, logger *log.Logger, tradeStore storage.TradeRecordStore,
+	candidateStore storage.CandidateStore, aggStore storage.StrategyAggregateStore, from, to int) {
+	all, err := tradeStore.GetAll(ctx)
+	if err != nil {
+		logger.Fatalf("load trades: %v", err)
+	}
+	recomputed, err := simulation.RecomputeCosts(all, from, to, lookupScenario)
+	if err != nil {
+		logger.Fatalf("recompute costs: %v", err)
+	}
+	if len(recomputed) == 0 {
+		logger.Fatalf("no trades of scenario version %d", from)
+	}
+
+	if err := tradeStore.InsertBulk(ctx, recomputed); err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			logger.Fatalf("scenario version %d is already recomputed: %v", to, err)
+		}
+		logger.Fatalf("store recomputed trades: %v", err)
+	}
+	logger.Printf("Recomputed %d trades: scenario version %d -> %d", len(recomputed), from, to)
+
+	if aggStore == nil {
+		logger.Printf("Skipping aggregates without ClickHouse; run cmd/report --scenario-version %d", to)
+		return
+	}
+
+	// Aggregate every (strategy type, scenario) pair of the new version
+	keys := make(map[[2]string]struct{})
+	for _, t := range recomputed {
+		keys[[2]string{strategy.CanonicalType(t.StrategyID), t.ScenarioID}] = struct{}{}
+	}
+	sorted := make([][2]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+
+	agg := metrics.NewAggregator(tradeStore, aggStore, candidateStore)
+	stored := 0
+	for _, k := range sorted {
+		for _, entry := range []string{"NEW_TOKEN", "ACTIVE_TOKEN"} {
+			_, err := agg.ComputeAndStore(ctx, k[0], k[1], entry)
+			if errors.Is(err, metrics.ErrNoTrades) || errors.Is(err, storage.ErrDuplicateKey) {
+				continue
+			}
+			if err != nil {
+				logger.Fatalf("compute aggregate %s/%s/%s: %v", k[0], k[1], entry, err)
+			}
+			stored++
+		}
+	}
+	logger.Printf("Stored %d aggregates for scenario version %d", stored, to)
+}
+
+// runImportTrades imports the trades of an external simulator from path under
+// namespace and computes their strategy aggregates. A nil aggStore skips the aggregates.
+func runImportTrades(ctx context.Context, logger *log.Logger, tradeStore storage.TradeRecordStore,
+	candidateStore storage.CandidateStore, aggStore storage.StrategyAggregateStore, path, namespace string) {
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	result, err := tradeimport.NewImporter(tradeimport.Options{
+		CandidateStore: candidateStore,
+		TradeStore:     tradeStore,
+		AggregateStore: aggStore,
+		Namespace:      namespace,
+	}).Import(ctx, f)
+	if err != nil {
+		logger.Fatalf("import %s: %v", path, err)
+	}
+
+	logger.Printf("Imported %d of %d trades (%d already stored) for %s",
+		result.Imported, result.Rows, result.Duplicates, strings.Join(result.StrategyIDs, ", "))
+	if aggStore == nil {
+		logger.Printf("Skipping aggregates without ClickHouse; import again with --clickhouse-dsn to compute them")
+		return
+	}
+	logger.Printf("Stored %d aggregates", result.Aggregates)
+}
+
+// lookupScenario resolves a bare scenario_id to its current definition.
+func lookupScenario(scenarioID string) (domain.ScenarioConfig, bool) {
+	sc, err := getScenarioConfig(scenarioID)
+	return sc, err == nil
+}
+
+// selectWhatIfTrades returns the trade with ID selector, or else all trades whose
+// strategy ID or base type equals selector, optionally restricted to one exit reason.
+func selectWhatIfTrades(ctx context.Context, tradeStore storage.TradeRecordStore, selector, exitReason string) ([]*domain.TradeRecord, error) {
+	var candidates []*domain.TradeRecord
+	trade, err := tradeStore.GetByID(ctx, selector)
+	switch {
+	case err == nil:
+		candidates = []*domain.TradeRecord{trade}
+	case errors.Is(err, storage.ErrNotFound):
+		all, err := tradeStore.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range all {
+			if t.StrategyID == selector || strategy.CanonicalType(t.StrategyID) == strings.ToUpper(selector) {
+				candidates = append(candidates, t)
+			}
+		}
+	default:
+		return nil, err
+	}
+
+	if exitReason == "" {
+		return candidates, nil
+	}
+	var selected []*domain.TradeRecord
+	for _, t := range candidates {
+		if t.ExitReason == exitReason {
+			selected = append(selected, t)
+		}
+	}
+	return selected, nil
+}
+
 // buildStrategyConfig creates a StrategyConfig from CLI flags.
 func buildStrategyConfig(
 	strategyType, entryEventType string,
 	holdDurationMs int64,
-	trailPct, initialStopPct, liquidityDropPct float64,
+	trailPct, initialStopPct, activationGainPct, liquidityDropPct float64,
+	liquidityReference string,
 	maxHoldMs int64,
 ) domain.StrategyConfig {
 	cfg := domain.StrategyConfig{
@@ -185,9 +450,13 @@ func buildStrategyConfig(
 	case domain.StrategyTypeTrailingStop:
 		cfg.TrailPct = &trailPct
 		cfg.InitialStopPct = &initialStopPct
+		if activationGainPct != 0 {
+			cfg.ActivationGainPct = &activationGainPct
+		}
 		cfg.MaxHoldDurationMs = &maxHoldMs
 	case domain.StrategyTypeLiquidityGuard:
 		cfg.LiquidityDropPct = &liquidityDropPct
+		cfg.LiquidityReference = liquidityReference
 		cfg.MaxHoldDurationMs = &maxHoldMs
 	}
 
@@ -195,19 +464,8 @@ func buildStrategyConfig(
 }
 
 // getScenarioConfig returns the predefined scenario config by name.
-func getScenarioConfig(name string) *domain.ScenarioConfig {
-	switch strings.ToLower(name) {
-	case "optimistic":
-		return &domain.ScenarioConfigOptimistic
-	case "realistic":
-		return &domain.ScenarioConfigRealistic
-	case "pessimistic":
-		return &domain.ScenarioConfigPessimistic
-	case "degraded":
-		return &domain.ScenarioConfigDegraded
-	default:
-		return nil
-	}
+func getScenarioConfig(name string) (domain.ScenarioConfig, error) {
+	return catalog.Scenario(strings.ToLower(name))
 }
 
 // printTradeRecord outputs human-readable trade record.
@@ -256,4 +514,10 @@ func printTradeRecord(t *domain.TradeRecord) {
 	if t.MinLiquidity != nil {
 		fmt.Printf("  Min Liquidity:    %.2f\n", *t.MinLiquidity)
 	}
+	if t.PeakLiquidity != nil {
+		fmt.Printf("  Peak Liquidity:   %.2f\n", *t.PeakLiquidity)
+	}
+	if t.ArmedAt != nil {
+		fmt.Printf("  Trail Armed At:   %s\n", time.UnixMilli(*t.ArmedAt).Format(time.RFC3339Nano))
+	}
 }
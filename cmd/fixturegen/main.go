@@ -0,0 +1,54 @@
+// Package main generates a deterministic synthetic dataset and writes it as JSONL.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"solana-token-lab/internal/pipeline/fixturegen"
+)
+
+func main() {
+	defaults := fixturegen.DefaultParams()
+
+	candidates := flag.Int("candidates", defaults.Candidates, "Number of candidates to generate")
+	days := flag.Int("days", defaults.Days, "Days of discovery history")
+	rugRate := flag.Float64("rug-rate", defaults.RugRate, "Probability that a candidate is rugged (0..1)")
+	seed := flag.Int64("seed", defaults.Seed, "RNG seed")
+	activeShare := flag.Float64("active-share", defaults.ActiveShare, "Share of ACTIVE_TOKEN candidates (0..1)")
+	historyMinutes := flag.Int("history-minutes", defaults.HistoryMinutes, "Minutes of price/liquidity history per candidate")
+	output := flag.String("output", "", "Output file (default stdout)")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "[fixturegen] ", log.LstdFlags)
+
+	params := defaults
+	params.Candidates = *candidates
+	params.Days = *days
+	params.RugRate = *rugRate
+	params.Seed = *seed
+	params.ActiveShare = *activeShare
+	params.HistoryMinutes = *historyMinutes
+
+	ds, err := fixturegen.Generate(params)
+	if err != nil {
+		logger.Fatalf("generate: %v", err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			logger.Fatalf("create output: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := ds.WriteJSONL(out); err != nil {
+		logger.Fatalf("write: %v", err)
+	}
+	logger.Printf("Generated %d candidates, %d swaps, %d liquidity events",
+		len(ds.Candidates), len(ds.Swaps), len(ds.LiquidityEvents))
+}
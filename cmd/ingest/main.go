@@ -4,22 +4,26 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/ingestion"
 	"solana-token-lab/internal/observability"
 	"solana-token-lab/internal/solana"
 	"solana-token-lab/internal/storage"
-	"solana-token-lab/internal/storage/memory"
-	"solana-token-lab/internal/storage/migrations"
+	"solana-token-lab/internal/storage/factory"
+	fsstore "solana-token-lab/internal/storage/filesystem"
 	pgstore "solana-token-lab/internal/storage/postgres"
+	"solana-token-lab/internal/storage/tracked"
 )
 
 // DEX program aliases mapped to program IDs.
@@ -31,9 +35,12 @@ var dexAliases = map[string]string{
 
 func main() {
 	// Parse flags
-	mode := flag.String("mode", "live", "Ingestion mode: live, backfill, or replay")
+	mode := flag.String("mode", "live", "Ingestion mode: live, backfill, replay, or reparse")
 	rpcEndpoint := flag.String("rpc-endpoint", "", "Solana RPC HTTP endpoint")
 	wsEndpoint := flag.String("ws-endpoint", "", "Solana WebSocket endpoint")
+	wsEndpoints := flag.String("ws-endpoints", "", "Live: comma-separated failover WebSocket endpoints, switched to in order after --ws-endpoint when subscriptions starve")
+	wsMinRate := flag.Float64("ws-min-notifications-per-min", 0, "Live: switch the WebSocket endpoint (or reconnect) when a subscription receives fewer notifications per minute over --ws-starvation-threshold, and backfill the gap (0 disables)")
+	wsStarvation := flag.Duration("ws-starvation-threshold", ingestion.DefaultStarvationThreshold, "Live: window over which --ws-min-notifications-per-min is measured")
 	postgresDSN := flag.String("postgres-dsn", "", "PostgreSQL connection string")
 	fromSlot := flag.Int64("from-slot", 0, "Start slot for backfill")
 	toSlot := flag.Int64("to-slot", 0, "End slot for backfill")
@@ -42,14 +49,54 @@ func main() {
 	programs := flag.String("programs", "", "Comma-separated DEX program IDs to monitor")
 	dex := flag.String("dex", "raydium,pumpfun", "Comma-separated DEX aliases (raydium, pumpfun)")
 	checkInterval := flag.Duration("check-interval", 1*time.Hour, "ACTIVE_TOKEN detection interval")
+	replayInterval := flag.Duration("interval", 0, "Replay: step ACTIVE_TOKEN detection through the range at this interval, as live checks would (0 evaluates once at --to-time)")
+	replayProgress := flag.Duration("replay-progress-interval", 10*time.Second, "Replay: log progress at most this often (0 disables)")
 	useMemory := flag.Bool("use-memory", false, "Use in-memory storage instead of PostgreSQL")
 	metricsAddr := flag.String("metrics-addr", ":9090", "Prometheus metrics HTTP address (empty to disable)")
+	instrumentStores := flag.Bool("instrument-stores", true, "Record per-store latency and error metrics")
+	backfillStrategy := flag.String("backfill-strategy", string(ingestion.BackfillStrategySignatures), "Backfill strategy: signatures (per-program signature crawl) or blocks (slot-complete getBlock walk, needs --from-slot/--to-slot)")
+	rpcTimeout := flag.Duration("rpc-timeout", solana.DefaultTimeout, "Deadline of each RPC request attempt")
+	rpcSlowThreshold := flag.Duration("rpc-slow-threshold", 0, "Log RPC requests slower than this (0 disables)")
+	requireBuyImbalance := flag.Bool("active-require-buy-imbalance", false, "ACTIVE_TOKEN: only accept volume/swap spikes with more buy than sell volume in the last hour")
+	minUniqueTraders := flag.Int("active-min-unique-traders", 0, "ACTIVE_TOKEN: only accept volume/swap spikes with at least this many distinct traders in the last hour (0 disables)")
+	rawArchive := flag.String("raw-archive", "none", "Raw transaction archive: none, postgres, or fs (written by live/backfill, read by reparse)")
+	rawArchiveDir := flag.String("raw-archive-dir", "raw_transactions", "Directory of the fs raw transaction archive")
+	rawArchiveMaxBytes := flag.Int64("raw-archive-max-bytes", storage.DefaultRawTxMaxBytes, "Size cap of the raw transaction archive; oldest transactions are evicted first")
+	reparseDryRun := flag.Bool("reparse-dry-run", false, "Reparse: report changed rows without applying them")
+	backfillDryRun := flag.Bool("dry-run", false, "Backfill: estimate the requests, duration and stored rows of the range from a sample of its signatures, then exit without fetching transactions")
+	dryRunRPS := flag.Float64("dry-run-rps", 0, "Backfill --dry-run: requests per second the RPC plan allows, for the duration estimate (0 assumes the latency of the sample requests)")
+	mintBlacklist := flag.String("mint-blacklist", "", "File of mints (and authority:<address> lines) that never become candidates")
+	mintAllowlist := flag.String("mint-allowlist", "", "File of mints (and authority:<address> lines); if set, only these become candidates")
+	maxSwapsPerCandidate := flag.Int64("max-swaps-per-candidate", 0, "Live and backfill: store at most this many swap events per candidate in full, then downsample (0 disables)")
+	maxLiquidityPerCandidate := flag.Int64("max-liquidity-per-candidate", 0, "Live and backfill: store at most this many liquidity events per candidate in full, then downsample (0 disables)")
+	capSampleEvery := flag.Int64("cap-sample-every", ingestion.DefaultSampleEvery, "Live and backfill: past a per-candidate cap, store 1 in this many events")
+	capWindow := flag.Duration("cap-window", 48*time.Hour, "Live and backfill: observation window after discovery that per-candidate caps apply to")
+	refreshMetadata := flag.String("refresh-metadata", "", "Refetch and store the metadata of this mint, then exit (overrides --mode)")
 
 	flag.Parse()
 
 	// Setup logger
 	logger := log.New(os.Stdout, "[ingest] ", log.LstdFlags|log.Lshortfile)
 
+	rpcOpts := []solana.ClientOption{solana.WithRequestTimeout(*rpcTimeout)}
+	if *rpcSlowThreshold > 0 {
+		rpcOpts = append(rpcOpts, solana.WithSlowRequestLog(*rpcSlowThreshold, logger))
+	}
+
+	archiveCfg := rawArchiveConfig{kind: *rawArchive, dir: *rawArchiveDir, maxBytes: *rawArchiveMaxBytes}
+
+	activeConfig := discovery.DefaultActiveConfig()
+	activeConfig.RequirePositiveImbalance = *requireBuyImbalance
+	activeConfig.MinUniqueTraders = *minUniqueTraders
+
+	mintFilter, err := loadMintFilter(*mintBlacklist, *mintAllowlist, *rpcEndpoint, rpcOpts)
+	if err != nil {
+		logger.Fatalf("Failed to load mint lists: %v", err)
+	}
+	if hash := mintFilter.Hash(); hash != "" {
+		logger.Printf("Mint filter active (hash %s)", hash)
+	}
+
 	// Start metrics server if enabled
 	if *metricsAddr != "" {
 		go func() {
@@ -101,15 +148,33 @@ func main() {
 		}
 	}()
 
+	eventCaps := ingestion.EventCaps{
+		MaxSwaps:            *maxSwapsPerCandidate,
+		MaxLiquidityEvents:  *maxLiquidityPerCandidate,
+		SampleEvery:         *capSampleEvery,
+		ObservationWindowMs: capWindow.Milliseconds(),
+	}
+
 	// Run based on mode
-	var err error
-	switch *mode {
-	case "live":
-		err = runLive(ctx, logger, *rpcEndpoint, *wsEndpoint, *postgresDSN, programList, *checkInterval, *useMemory)
-	case "backfill":
-		err = runBackfill(ctx, logger, *rpcEndpoint, *postgresDSN, programList, *fromSlot, *toSlot, *fromTime, *toTime, *useMemory)
-	case "replay":
-		err = runReplay(ctx, logger, *postgresDSN, *fromTime, *toTime, *useMemory)
+	switch {
+	case *refreshMetadata != "":
+		err = runRefreshMetadata(ctx, logger, *rpcEndpoint, rpcOpts, *postgresDSN, *refreshMetadata, *useMemory, *instrumentStores)
+	case *mode == "live":
+		wsHealth := ingestion.WSHealthConfig{
+			MinNotificationsPerMinute: *wsMinRate,
+			StarvationThreshold:       *wsStarvation,
+		}
+		err = runLive(ctx, logger, *rpcEndpoint, rpcOpts, wsEndpointList(*wsEndpoint, *wsEndpoints), *postgresDSN, programList, *checkInterval, activeConfig, mintFilter, archiveCfg, eventCaps, wsHealth, *useMemory, *instrumentStores)
+	case *mode == "backfill":
+		if *backfillDryRun {
+			err = runBackfillDryRun(ctx, *rpcEndpoint, rpcOpts, programList, *backfillStrategy, *fromSlot, *toSlot, *fromTime, *toTime, *dryRunRPS)
+			break
+		}
+		err = runBackfill(ctx, logger, *rpcEndpoint, rpcOpts, *postgresDSN, programList, *backfillStrategy, *fromSlot, *toSlot, *fromTime, *toTime, mintFilter, archiveCfg, eventCaps, *useMemory, *instrumentStores)
+	case *mode == "replay":
+		err = runReplay(ctx, logger, *postgresDSN, *fromTime, *toTime, *replayInterval, *replayProgress, activeConfig, mintFilter, *useMemory, *instrumentStores)
+	case *mode == "reparse":
+		err = runReparse(ctx, logger, *rpcEndpoint, rpcOpts, *postgresDSN, archiveCfg, *reparseDryRun, *useMemory, *instrumentStores)
 	default:
 		logger.Fatalf("Unknown mode: %s", *mode)
 	}
@@ -125,6 +190,24 @@ func main() {
 	logger.Println("Shutdown complete")
 }
 
+// loadMintFilter builds the discovery mint filter from the list files.
+// Authorities are resolved over RPC when an endpoint is given.
+func loadMintFilter(blacklistPath, allowlistPath, rpcEndpoint string, rpcOpts []solana.ClientOption) (*discovery.MintFilter, error) {
+	blacklist, err := discovery.LoadMintList(blacklistPath)
+	if err != nil {
+		return nil, err
+	}
+	allowlist, err := discovery.LoadMintList(allowlistPath)
+	if err != nil {
+		return nil, err
+	}
+	filter := discovery.NewMintFilter(blacklist, allowlist).WithRecorder(observability.RecordFilteredDiscovery)
+	if rpcEndpoint != "" {
+		filter = filter.WithAuthorityResolver(ingestion.NewRPCMetadataSource(solana.NewHTTPClient(rpcEndpoint, rpcOpts...)))
+	}
+	return filter, nil
+}
+
 // resolvePrograms resolves program IDs from flags.
 func resolvePrograms(programs, dex string) []string {
 	result := make(map[string]bool)
@@ -157,29 +240,44 @@ func resolvePrograms(programs, dex string) []string {
 	return list
 }
 
+// wsEndpointList returns the primary WebSocket endpoint followed by the
+// comma-separated failover endpoints, or nil without a primary.
+func wsEndpointList(primary, failover string) []string {
+	if primary == "" {
+		return nil
+	}
+	endpoints := []string{primary}
+	for _, e := range strings.Split(failover, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints
+}
+
 // runLive runs continuous live ingestion.
-func runLive(ctx context.Context, logger *log.Logger, rpcEndpoint, wsEndpoint, postgresDSN string, programs []string, checkInterval time.Duration, useMemory bool) error {
+func runLive(ctx context.Context, logger *log.Logger, rpcEndpoint string, rpcOpts []solana.ClientOption, wsEndpoints []string, postgresDSN string, programs []string, checkInterval time.Duration, activeConfig discovery.ActiveTokenConfig, mintFilter *discovery.MintFilter, archiveCfg rawArchiveConfig, eventCaps ingestion.EventCaps, wsHealth ingestion.WSHealthConfig, useMemory, instrument bool) error {
 	if rpcEndpoint == "" {
 		return fmt.Errorf("--rpc-endpoint is required for live mode")
 	}
-	if wsEndpoint == "" {
+	if len(wsEndpoints) == 0 {
 		return fmt.Errorf("--ws-endpoint is required for live mode")
 	}
 
 	// Create RPC client
-	rpc := solana.NewHTTPClient(rpcEndpoint)
+	rpc := solana.NewHTTPClient(rpcEndpoint, rpcOpts...)
 
 	// Create SEPARATE WebSocket clients for swap and liquidity
 	// This is required because Helius deduplicates subscriptions to the same program
 	// on the same connection, returning the same subscription ID which causes
 	// the second subscriber to overwrite the first one's channel
-	wsSwap, err := solana.NewWSClient(ctx, wsEndpoint, nil)
+	wsSwap, err := solana.NewWSClientWithEndpoints(ctx, wsEndpoints, nil)
 	if err != nil {
 		return fmt.Errorf("create websocket client for swaps: %w", err)
 	}
 	defer wsSwap.Close()
 
-	wsLiquidity, err := solana.NewWSClient(ctx, wsEndpoint, nil)
+	wsLiquidity, err := solana.NewWSClientWithEndpoints(ctx, wsEndpoints, nil)
 	if err != nil {
 		return fmt.Errorf("create websocket client for liquidity: %w", err)
 	}
@@ -190,37 +288,60 @@ func runLive(ctx context.Context, logger *log.Logger, rpcEndpoint, wsEndpoint, p
 		return fmt.Errorf("--postgres-dsn is required for live mode (use --use-memory for in-memory storage)")
 	}
 
-	// Create stores (use interfaces)
-	var swapEventStore storage.SwapEventStore = memory.NewSwapEventStore()
-	var liquidityStore storage.LiquidityEventStore = memory.NewLiquidityEventStore()
-	var candidateStore storage.CandidateStore = memory.NewCandidateStore()
-	var metadataStore storage.TokenMetadataStore = memory.NewTokenMetadataStore()
-
-	if !useMemory {
-		pool, err := pgstore.NewPool(ctx, postgresDSN)
-		if err != nil {
-			return fmt.Errorf("connect to postgres: %w", err)
-		}
-		defer pool.Close()
-
-		if err := migrations.RunPostgresMigrations(ctx, pool); err != nil {
-			return fmt.Errorf("postgres migrations: %w", err)
-		}
+	// Create stores
+	stores, cleanup, err := openStores(ctx, postgresDSN, factory.NeedSwapEvents|factory.NeedLiquidityEvents|factory.NeedCandidates|
+		factory.NeedTokenMetadata|factory.NeedEventCounts|factory.NeedPoolLinks|factory.NeedFailedTx, useMemory, instrument)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	swapEventStore, liquidityStore, candidateStore := stores.SwapEvents, stores.LiquidityEvents, stores.Candidates
+	metadataStore, eventCountStore, poolLinkStore := stores.TokenMetadata, stores.EventCounts, stores.PoolLinks
 
-		swapEventStore = pgstore.NewSwapEventStore(pool)
-		liquidityStore = pgstore.NewLiquidityEventStore(pool)
-		candidateStore = pgstore.NewCandidateStore(pool)
-		metadataStore = pgstore.NewTokenMetadataStore(pool)
+	archive, err := openRawArchive(archiveCfg, stores.Postgres)
+	if err != nil {
+		return err
 	}
 
+	// Failed transactions are counted per program by every source
+	failedTx := ingestion.NewFailedTxTracker(stores.FailedTx).WithRecorder(observability.RecordTransactionObserved)
+
 	// Create sources with separate WebSocket clients
-	wsSwapSource := ingestion.NewWSSwapEventSource(wsSwap, rpc, programs)
-	wsLiquiditySource := ingestion.NewWSLiquidityEventSourceWithStore(wsLiquidity, rpc, programs, candidateStore)
+	wsSwapSource := ingestion.NewWSSwapEventSource(wsSwap, rpc, programs).WithArchive(archive).WithFailedTxTracker(failedTx)
+	wsLiquiditySource := ingestion.NewWSLiquidityEventSourceWithStore(wsLiquidity, rpc, programs, candidateStore).
+		WithArchive(archive).WithFailedTxTracker(failedTx)
 	metadataSource := ingestion.NewRPCMetadataSource(rpc)
 
-	// Create detectors
-	newTokenDetector := discovery.NewDetector(candidateStore)
-	activeDetector := discovery.NewActiveDetector(discovery.DefaultActiveConfig(), swapEventStore, candidateStore)
+	// Create detectors (live: stamp detection time and report discovery latency)
+	newTokenDetector := discovery.NewDetector(candidateStore).
+		WithLiveClock(time.Now, observability.RecordDiscoveryLatency).
+		WithMintFilter(mintFilter)
+	activeDetector := discovery.NewActiveDetector(activeConfig, swapEventStore, candidateStore).
+		WithLiveClock(time.Now, observability.RecordDiscoveryLatency).
+		WithMintFilter(mintFilter)
+
+	// Starved windows are backfilled over RPC after an endpoint switch
+	var gapBackfill ingestion.GapBackfillFunc
+	if wsHealth.Enabled() {
+		gapSwapSource := ingestion.NewRPCSwapEventSource(rpc, programs).WithArchive(archive).WithFailedTxTracker(failedTx)
+		gapLiquiditySource := ingestion.NewRPCLiquidityEventSource(rpc, programs, candidateStore).WithArchive(archive).
+			WithPoolLinks(poolLinkStore).WithFailedTxTracker(failedTx)
+		backfiller := ingestion.NewBackfiller(ingestion.BackfillOptions{
+			RPC:              rpc,
+			SwapSource:       gapSwapSource,
+			LiquiditySource:  gapLiquiditySource,
+			SwapEventStore:   swapEventStore,
+			LiquidityStore:   liquidityStore,
+			CandidateStore:   candidateStore,
+			NewTokenDetector: discovery.NewDetector(candidateStore).WithMintFilter(mintFilter),
+			FailedTx:         failedTx,
+			Logger:           logger,
+		})
+		gapBackfill = func(ctx context.Context, from, to time.Time) error {
+			_, err := backfiller.BackfillRange(ctx, from, to)
+			return err
+		}
+	}
 
 	// Create and run runner
 	runner := ingestion.NewRunner(ingestion.RunnerOptions{
@@ -233,8 +354,15 @@ func runLive(ctx context.Context, logger *log.Logger, rpcEndpoint, wsEndpoint, p
 		CandidateStore:    candidateStore,
 		NewTokenDetector:  newTokenDetector,
 		ActiveDetector:    activeDetector,
+		EventCaps:         eventCaps,
+		EventCountStore:   eventCountStore,
+		PoolLinkStore:     poolLinkStore,
+		FailedTx:          failedTx,
 		CheckInterval:     checkInterval,
 		Logger:            logger,
+		WSHealth:          wsHealth,
+		GapBackfill:       gapBackfill,
+		OnEndpointSwitch:  observability.RecordWSEndpointSwitch,
 	})
 
 	logger.Println("Starting live ingestion...")
@@ -242,46 +370,48 @@ func runLive(ctx context.Context, logger *log.Logger, rpcEndpoint, wsEndpoint, p
 }
 
 // runBackfill runs historical data backfill.
-func runBackfill(ctx context.Context, logger *log.Logger, rpcEndpoint, postgresDSN string, programs []string, fromSlot, toSlot int64, fromTimeStr, toTimeStr string, useMemory bool) error {
+func runBackfill(ctx context.Context, logger *log.Logger, rpcEndpoint string, rpcOpts []solana.ClientOption, postgresDSN string, programs []string, strategyName string, fromSlot, toSlot int64, fromTimeStr, toTimeStr string, mintFilter *discovery.MintFilter, archiveCfg rawArchiveConfig, eventCaps ingestion.EventCaps, useMemory, instrument bool) error {
 	if rpcEndpoint == "" {
 		return fmt.Errorf("--rpc-endpoint is required for backfill mode")
 	}
+	strategy, err := ingestion.ParseBackfillStrategy(strategyName)
+	if err != nil {
+		return err
+	}
+	if strategy == ingestion.BackfillStrategyBlocks && (fromSlot <= 0 || toSlot <= 0) {
+		return fmt.Errorf("--from-slot and --to-slot are required for --backfill-strategy=blocks")
+	}
 
 	// Create RPC client
-	rpc := solana.NewHTTPClient(rpcEndpoint)
+	rpc := solana.NewHTTPClient(rpcEndpoint, rpcOpts...)
 
 	// Require --postgres-dsn unless --use-memory is explicitly set
 	if !useMemory && postgresDSN == "" {
 		return fmt.Errorf("--postgres-dsn is required for backfill mode (use --use-memory for in-memory storage)")
 	}
 
-	// Create stores (use interfaces)
-	var swapEventStore storage.SwapEventStore = memory.NewSwapEventStore()
-	var liquidityStore storage.LiquidityEventStore = memory.NewLiquidityEventStore()
-	var candidateStore storage.CandidateStore = memory.NewCandidateStore()
-
-	if !useMemory {
-		pool, err := pgstore.NewPool(ctx, postgresDSN)
-		if err != nil {
-			return fmt.Errorf("connect to postgres: %w", err)
-		}
-		defer pool.Close()
-
-		if err := migrations.RunPostgresMigrations(ctx, pool); err != nil {
-			return fmt.Errorf("postgres migrations: %w", err)
-		}
+	// Create stores
+	stores, cleanup, err := openStores(ctx, postgresDSN, backfillNeeds, useMemory, instrument)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	stores = withDirtyTracking(stores, logger)
+	swapEventStore, liquidityStore, candidateStore := stores.SwapEvents, stores.LiquidityEvents, stores.Candidates
+	checkpointStore, eventCountStore := stores.SlotCheckpoints, stores.EventCounts
 
-		swapEventStore = pgstore.NewSwapEventStore(pool)
-		liquidityStore = pgstore.NewLiquidityEventStore(pool)
-		candidateStore = pgstore.NewCandidateStore(pool)
+	archive, err := openRawArchive(archiveCfg, stores.Postgres)
+	if err != nil {
+		return err
 	}
 
 	// Create sources
-	swapSource := ingestion.NewRPCSwapEventSource(rpc, programs)
-	liquiditySource := ingestion.NewRPCLiquidityEventSource(rpc, programs, candidateStore)
+	failedTx := ingestion.NewFailedTxTracker(stores.FailedTx).WithRecorder(observability.RecordTransactionObserved)
+	swapSource := ingestion.NewRPCSwapEventSource(rpc, programs).WithArchive(archive).WithFailedTxTracker(failedTx)
+	liquiditySource := ingestion.NewRPCLiquidityEventSource(rpc, programs, candidateStore).WithArchive(archive).WithFailedTxTracker(failedTx)
 
 	// Create detector
-	newTokenDetector := discovery.NewDetector(candidateStore)
+	newTokenDetector := discovery.NewDetector(candidateStore).WithMintFilter(mintFilter)
 
 	// Create backfiller
 	backfiller := ingestion.NewBackfiller(ingestion.BackfillOptions{
@@ -292,17 +422,26 @@ func runBackfill(ctx context.Context, logger *log.Logger, rpcEndpoint, postgresD
 		LiquidityStore:   liquidityStore,
 		CandidateStore:   candidateStore,
 		NewTokenDetector: newTokenDetector,
-		Logger:           logger,
+		Strategy:         strategy,
+		Checkpoints:      checkpointStore,
+		FailedTx:         failedTx,
+		EventCaps:        eventCaps,
+		EventCountStore:  eventCountStore,
+		OnProgress: func(p ingestion.BackfillProgressSnapshot) {
+			observability.UpdateBackfillProgress(p.SignaturesScanned, p.TransactionsFetched, p.EventsStored,
+				p.BlockTime, p.Fraction, p.ETA.Seconds())
+		},
+		Logger: logger,
 	})
 
 	// Determine time range
 	var from, to time.Time
-	var err error
+	var result *ingestion.BackfillResult
 
 	if fromSlot > 0 && toSlot > 0 {
 		// Use slot range
 		logger.Printf("Backfilling slot range: %d to %d", fromSlot, toSlot)
-		_, err = backfiller.BackfillSlotRange(ctx, fromSlot, toSlot)
+		result, err = backfiller.BackfillSlotRange(ctx, fromSlot, toSlot)
 	} else if fromTimeStr != "" {
 		// Use time range
 		from, err = time.Parse(time.RFC3339, fromTimeStr)
@@ -320,45 +459,181 @@ func runBackfill(ctx context.Context, logger *log.Logger, rpcEndpoint, postgresD
 		}
 
 		logger.Printf("Backfilling time range: %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
-		_, err = backfiller.BackfillRange(ctx, from, to)
+		result, err = backfiller.BackfillRange(ctx, from, to)
 	} else {
 		// Default: last 24 hours
 		logger.Println("No time range specified, backfilling last 24 hours")
-		_, err = backfiller.BackfillSince(ctx, time.Now().Add(-24*time.Hour))
+		result, err = backfiller.BackfillSince(ctx, time.Now().Add(-24*time.Hour))
+	}
+
+	if result != nil {
+		printBackfillSummary(os.Stdout, result)
 	}
 
 	return err
 }
 
-// runReplay runs discovery replay from stored events.
-func runReplay(ctx context.Context, logger *log.Logger, postgresDSN, fromTimeStr, toTimeStr string, useMemory bool) error {
-	// Require --postgres-dsn unless --use-memory is explicitly set
-	if !useMemory && postgresDSN == "" {
-		return fmt.Errorf("--postgres-dsn is required for replay mode (use --use-memory for in-memory storage)")
+// printBackfillSummary writes a per-program table and totals for a finished backfill.
+func printBackfillSummary(w io.Writer, result *ingestion.BackfillResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "PROGRAM\tSIGNATURES\tTRANSACTIONS\tFAILED TXS\tSWAP EVENTS\tLIQUIDITY EVENTS\t")
+	failed := 0
+	for _, p := range result.Programs {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t\n",
+			p.Program, p.SignaturesScanned, p.TransactionsFetched, p.FailedTransactions, p.SwapEvents, p.LiquidityEvents)
+		failed += p.FailedTransactions
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%d\t\t\t\n", result.SignaturesScanned, result.TransactionsFetched, failed)
+	tw.Flush()
+	if result.BlocksFetched > 0 || result.SlotsSkipped > 0 || result.SlotsAlreadyComplete > 0 {
+		fmt.Fprintf(w, "Blocks fetched: %d, skipped slots: %d, already complete: %d\n",
+			result.BlocksFetched, result.SlotsSkipped, result.SlotsAlreadyComplete)
 	}
 
-	// Create stores (use interfaces)
-	var swapEventStore storage.SwapEventStore = memory.NewSwapEventStore()
-	var candidateStore storage.CandidateStore = memory.NewCandidateStore()
+	fmt.Fprintf(w, "\nStored: %d swap events, %d liquidity events | %d candidates discovered | %d duplicates skipped | %d capped | %d errors | %v\n",
+		result.SwapEventsIngested, result.LiquidityEventsIngested, result.CandidatesDiscovered,
+		result.DuplicatesSkipped, result.CappedSwapEvents+result.CappedLiquidityEvents, result.Errors, result.Duration.Round(time.Millisecond))
+}
 
-	if !useMemory {
-		pool, err := pgstore.NewPool(ctx, postgresDSN)
-		if err != nil {
-			return fmt.Errorf("connect to postgres: %w", err)
-		}
-		defer pool.Close()
+// runBackfillDryRun prints the projected cost of a backfill of the range
+// runBackfill would cover, without opening stores or fetching transactions.
+func runBackfillDryRun(ctx context.Context, rpcEndpoint string, rpcOpts []solana.ClientOption, programs []string, strategyName string, fromSlot, toSlot int64, fromTimeStr, toTimeStr string, rps float64) error {
+	if rpcEndpoint == "" {
+		return fmt.Errorf("--rpc-endpoint is required for backfill mode")
+	}
+	strategy, err := ingestion.ParseBackfillStrategy(strategyName)
+	if err != nil {
+		return err
+	}
+	if strategy == ingestion.BackfillStrategyBlocks {
+		return fmt.Errorf("--dry-run estimates the %s strategy only", ingestion.BackfillStrategySignatures)
+	}
+
+	rpc := solana.NewHTTPClient(rpcEndpoint, rpcOpts...)
+	backfiller := ingestion.NewBackfiller(ingestion.BackfillOptions{
+		RPC:             rpc,
+		SwapSource:      ingestion.NewRPCSwapEventSource(rpc, programs),
+		LiquiditySource: ingestion.NewRPCLiquidityEventSource(rpc, programs, nil),
+	})
 
-		if err := migrations.RunPostgresMigrations(ctx, pool); err != nil {
-			return fmt.Errorf("postgres migrations: %w", err)
+	// The range runBackfill would cover
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+	switch {
+	case fromSlot > 0 && toSlot > 0:
+		bounds := make([]time.Time, 2)
+		for i, slot := range []int64{fromSlot, toSlot} {
+			blockTime, err := rpc.GetBlockTime(ctx, slot)
+			if err != nil {
+				return fmt.Errorf("get block time for slot %d: %w", slot, err)
+			}
+			if blockTime == nil {
+				return fmt.Errorf("no block time for slot %d", slot)
+			}
+			bounds[i] = time.Unix(*blockTime, 0)
+		}
+		from, to = bounds[0], bounds[1]
+	case fromTimeStr != "":
+		if from, err = time.Parse(time.RFC3339, fromTimeStr); err != nil {
+			return fmt.Errorf("parse from-time: %w", err)
 		}
+		if toTimeStr != "" {
+			if to, err = time.Parse(time.RFC3339, toTimeStr); err != nil {
+				return fmt.Errorf("parse to-time: %w", err)
+			}
+		}
+	}
+
+	est, err := backfiller.EstimateRange(ctx, from, to, ingestion.EstimateOptions{RequestsPerSecond: rps})
+	if err != nil {
+		return fmt.Errorf("estimate backfill: %w", err)
+	}
+	printBackfillEstimate(os.Stdout, est)
+	return nil
+}
+
+// printBackfillEstimate writes a per-program table and the projected totals of a backfill.
+func printBackfillEstimate(w io.Writer, est *ingestion.BackfillEstimate) {
+	fmt.Fprintf(w, "Backfill estimate for %s to %s (%s)\n\n",
+		est.From.UTC().Format(time.RFC3339), est.To.UTC().Format(time.RFC3339), est.To.Sub(est.From).Round(time.Minute))
 
-		swapEventStore = pgstore.NewSwapEventStore(pool)
-		candidateStore = pgstore.NewCandidateStore(pool)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "PROGRAM\tSCANS\tSIGNATURES/H\tWINDOW SIGNATURES\tFAILED\tNEWER SIGNATURES\t")
+	for _, p := range est.Programs {
+		fmt.Fprintf(tw, "%s\t%d\t%.0f\t%d\t%d\t%d\t\n",
+			p.Program, p.Scans, p.SignaturesPerHour, p.WindowSignatures, p.FailedSignatures, p.NewerSignatures)
 	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nRequests:  ~%d (%d getSignaturesForAddress pages, %d getTransaction), retries not included\n",
+		est.Requests, est.SignatureRequests, est.TransactionRequests)
+	if est.Duration > 0 {
+		fmt.Fprintf(w, "Duration:  ~%s at %.1f requests/s\n", est.Duration.Round(time.Minute), est.RequestsPerSecond)
+	}
+	fmt.Fprintf(w, "Rows:      ~%d swap events, up to %d liquidity events (one event per transaction)\n",
+		est.SwapRows, est.LiquidityRows)
+	fmt.Fprintf(w, "Estimated from %d sample requests; no transaction was fetched.\n", est.SampleRequests)
+}
+
+// openStores builds the stores of needs on PostgreSQL, or in memory with useMemory.
+func openStores(ctx context.Context, postgresDSN string, needs factory.Need, useMemory, instrument bool) (*factory.Stores, func(), error) {
+	cfg := factory.Config{
+		Backend:     factory.BackendPostgres,
+		PostgresDSN: postgresDSN,
+		Needs:       needs,
+		Instrument:  instrument,
+	}
+	if useMemory {
+		cfg.Backend = factory.BackendMemory
+	}
+	return factory.BuildStores(ctx, cfg)
+}
+
+// Stores opened by the backfill and reparse modes. Both write events late, so
+// they include what withDirtyTracking needs.
+const (
+	backfillNeeds = factory.NeedSwapEvents | factory.NeedLiquidityEvents | factory.NeedCandidates |
+		factory.NeedSlotCheckpoints | factory.NeedFailedTx | factory.NeedEventCounts |
+		factory.NeedTrades | factory.NeedDirtyCandidates
+	reparseNeeds = factory.NeedReparse | factory.NeedCandidates | factory.NeedTrades | factory.NeedDirtyCandidates
+)
+
+// withDirtyTracking wraps the event stores so that events stored or corrected
+// inside an existing trade window mark their candidate dirty for the next
+// incremental run. Stores that were not opened are left nil.
+func withDirtyTracking(s *factory.Stores, logger *log.Logger) *factory.Stores {
+	tracker := tracked.NewTracker(s.Trades, s.DirtyCandidates).WithCandidates(s.Candidates).WithLogger(logger)
+	out := *s
+	if s.SwapEvents != nil {
+		out.SwapEvents = tracked.NewSwapEventStore(s.SwapEvents, tracker)
+	}
+	if s.LiquidityEvents != nil {
+		out.LiquidityEvents = tracked.NewLiquidityEventStore(s.LiquidityEvents, tracker)
+	}
+	if s.Reparse != nil {
+		out.Reparse = tracked.NewReparseStore(s.Reparse, tracker)
+	}
+	return &out
+}
+
+// runReplay runs discovery replay from stored events.
+func runReplay(ctx context.Context, logger *log.Logger, postgresDSN, fromTimeStr, toTimeStr string, interval, progressInterval time.Duration, activeConfig discovery.ActiveTokenConfig, mintFilter *discovery.MintFilter, useMemory, instrument bool) error {
+	// Require --postgres-dsn unless --use-memory is explicitly set
+	if !useMemory && postgresDSN == "" {
+		return fmt.Errorf("--postgres-dsn is required for replay mode (use --use-memory for in-memory storage)")
+	}
+
+	// Create stores
+	stores, cleanup, err := openStores(ctx, postgresDSN, factory.NeedSwapEvents|factory.NeedCandidates, useMemory, instrument)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	swapEventStore, candidateStore := stores.SwapEvents, stores.Candidates
 
 	// Create detector
-	newTokenDetector := discovery.NewDetector(candidateStore)
-	activeDetector := discovery.NewActiveDetector(discovery.DefaultActiveConfig(), swapEventStore, candidateStore)
+	newTokenDetector := discovery.NewDetector(candidateStore).WithMintFilter(mintFilter)
+	activeDetector := discovery.NewActiveDetector(activeConfig, swapEventStore, candidateStore).WithMintFilter(mintFilter)
 
 	// Create replayer
 	replayer := ingestion.NewReplayer(ingestion.ReplayerOptions{
@@ -366,6 +641,8 @@ func runReplay(ctx context.Context, logger *log.Logger, postgresDSN, fromTimeStr
 		CandidateStore:   candidateStore,
 		NewTokenDetector: newTokenDetector,
 		ActiveDetector:   activeDetector,
+		ActiveInterval:   interval,
+		OnProgress:       replayProgressLogger(logger, progressInterval),
 		Logger:           logger,
 	})
 
@@ -399,9 +676,186 @@ func runReplay(ctx context.Context, logger *log.Logger, postgresDSN, fromTimeStr
 		return err
 	}
 
-	logger.Printf("Replay complete: %d events, %d NEW_TOKEN, %d ACTIVE_TOKEN in %v",
+	logger.Printf("Replay complete: %d events, %d NEW_TOKEN, %d ACTIVE_TOKEN (%d evaluations) in %v",
 		result.EventsProcessed, result.NewTokensDiscovered,
-		result.ActiveTokensDiscovered, result.Duration)
+		result.ActiveTokensDiscovered, result.ActiveEvaluations, result.Duration)
+	printReplayDaily(os.Stdout, result)
+
+	return nil
+}
+
+// replayProgressLogger returns a replay progress callback logging at most once
+// per interval, or nil when interval is 0.
+func replayProgressLogger(logger *log.Logger, interval time.Duration) func(ingestion.ReplayProgress) {
+	if interval <= 0 {
+		return nil
+	}
+	var last time.Time
+	return func(p ingestion.ReplayProgress) {
+		if now := time.Now(); now.Sub(last) >= interval {
+			last = now
+			logger.Printf("Replay progress: %d/%d events | at %s | %d NEW_TOKEN, %d ACTIVE_TOKEN so far",
+				p.EventsProcessed, p.EventsTotal, time.UnixMilli(p.SimulatedTime).UTC().Format(time.RFC3339),
+				p.NewTokens, p.ActiveTokens)
+		}
+	}
+}
+
+// printReplayDaily writes the per-day detection table of a finished replay.
+func printReplayDaily(w io.Writer, result *ingestion.ReplayResult) {
+	if len(result.Daily) == 0 {
+		fmt.Fprintln(w, "No detections in the replayed range")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tNEW_TOKEN\tACTIVE_TOKEN\t")
+	for _, d := range result.Daily {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t\n", d.Day.Format("2006-01-02"), d.NewTokens, d.ActiveTokens)
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t\n", result.NewTokensDiscovered, result.ActiveTokensDiscovered)
+	tw.Flush()
+}
+
+// rawArchiveConfig selects the raw transaction archive.
+type rawArchiveConfig struct {
+	kind     string // none, postgres, or fs
+	dir      string
+	maxBytes int64
+}
+
+// openRawArchive opens the configured raw transaction archive, nil for none.
+// pool is nil when running on in-memory storage.
+func openRawArchive(cfg rawArchiveConfig, pool *pgstore.Pool) (storage.RawTxStore, error) {
+	switch cfg.kind {
+	case "", "none":
+		return nil, nil
+	case "postgres":
+		if pool == nil {
+			return nil, fmt.Errorf("--raw-archive=postgres requires --postgres-dsn")
+		}
+		return pgstore.NewRawTxStore(pool, cfg.maxBytes), nil
+	case "fs":
+		return fsstore.NewRawTxStore(cfg.dir, cfg.maxBytes)
+	default:
+		return nil, fmt.Errorf("unknown raw archive %q (want none, postgres, or fs)", cfg.kind)
+	}
+}
+
+// runReparse re-parses the raw transaction archive and corrects stored events.
+func runReparse(ctx context.Context, logger *log.Logger, rpcEndpoint string, rpcOpts []solana.ClientOption, postgresDSN string, archiveCfg rawArchiveConfig, dryRun, useMemory, instrument bool) error {
+	if archiveCfg.kind == "" || archiveCfg.kind == "none" {
+		return fmt.Errorf("--raw-archive is required for reparse mode")
+	}
+	if !useMemory && postgresDSN == "" {
+		return fmt.Errorf("--postgres-dsn is required for reparse mode (use --use-memory for in-memory storage)")
+	}
+
+	// Create stores
+	stores, cleanup, err := openStores(ctx, postgresDSN, reparseNeeds, useMemory, instrument)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	stores = withDirtyTracking(stores, logger)
+	reparseStore, candidateStore := stores.Reparse, stores.Candidates
+
+	archive, err := openRawArchive(archiveCfg, stores.Postgres)
+	if err != nil {
+		return err
+	}
+
+	// RPC is optional: without it Raydium mints cannot be inferred
+	var rpc *solana.HTTPClient
+	if rpcEndpoint != "" {
+		rpc = solana.NewHTTPClient(rpcEndpoint, rpcOpts...)
+	} else {
+		logger.Println("No --rpc-endpoint: Raydium mints will not be inferred during reparse")
+	}
+
+	count, size, err := archive.Stats(ctx)
+	if err != nil {
+		return fmt.Errorf("raw archive stats: %w", err)
+	}
+	logger.Printf("Reparsing %d archived transactions (%d bytes), dry run: %v", count, size, dryRun)
+
+	reparser := ingestion.NewReparser(ingestion.ReparserOptions{
+		Archive: archive,
+		Store:   reparseStore,
+		Parse:   ingestion.NewTxParser(rpc, candidateStore),
+		DryRun:  dryRun,
+		Logger:  logger,
+	})
+	result, err := reparser.Run(ctx)
+	if result != nil {
+		printReparseReport(os.Stdout, result, dryRun)
+	}
+	return err
+}
+
+// runRefreshMetadata refetches the metadata of one mint and upserts it, for
+// environments without the server's POST /admin/metadata/refresh.
+func runRefreshMetadata(ctx context.Context, logger *log.Logger, rpcEndpoint string, rpcOpts []solana.ClientOption, postgresDSN, mint string, useMemory, instrument bool) error {
+	if rpcEndpoint == "" {
+		return fmt.Errorf("--rpc-endpoint is required for --refresh-metadata")
+	}
+	if !useMemory && postgresDSN == "" {
+		return fmt.Errorf("--postgres-dsn is required for --refresh-metadata (use --use-memory for in-memory storage)")
+	}
 
+	// Create stores
+	stores, cleanup, err := openStores(ctx, postgresDSN, factory.NeedCandidates|factory.NeedTokenMetadata, useMemory, instrument)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	candidateStore, metadataStore := stores.Candidates, stores.TokenMetadata
+
+	fetcher := ingestion.NewRPCMetadataSource(solana.NewHTTPClient(rpcEndpoint, rpcOpts...))
+	meta, err := ingestion.NewMetadataRefresher(fetcher, candidateStore, metadataStore, logger).
+		Refresh(ctx, ingestion.MetadataRefreshRequest{Mint: strings.TrimSpace(mint), Requester: "cmd/ingest"})
+	if err != nil {
+		return err
+	}
+	printMetadata(os.Stdout, meta)
 	return nil
 }
+
+// printMetadata writes the refreshed metadata of a mint.
+func printMetadata(w io.Writer, meta *domain.TokenMetadata) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "MINT\t%s\n", meta.Mint)
+	fmt.Fprintf(tw, "CANDIDATE\t%s\n", meta.CandidateID)
+	fmt.Fprintf(tw, "NAME\t%s\n", stringOrDash(meta.Name))
+	fmt.Fprintf(tw, "SYMBOL\t%s\n", stringOrDash(meta.Symbol))
+	fmt.Fprintf(tw, "URI\t%s\n", stringOrDash(meta.URI))
+	fmt.Fprintf(tw, "DECIMALS\t%d\n", meta.Decimals)
+	tw.Flush()
+}
+
+// stringOrDash returns *s, or "-" when s is nil.
+func stringOrDash(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
+
+// printReparseReport writes one row per changed event and the totals of a reparse.
+func printReparseReport(w io.Writer, result *ingestion.ReparseResult, dryRun bool) {
+	if len(result.Changes) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TABLE\tTX SIGNATURE\tINDEX\tCHANGE")
+		for _, c := range result.Changes {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", c.EventTable, c.TxSignature, c.EventIndex, c.Change)
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+
+	verb := "corrected"
+	if dryRun {
+		verb = "would be corrected (dry run)"
+	}
+	fmt.Fprintf(w, "Reparsed %d transactions: %d %s | %d added, %d changed, %d removed (reported only) | %d undecodable\n",
+		result.Transactions, result.Corrected, verb, result.Added, result.Changed, result.Removed, result.DecodeErrors)
+}
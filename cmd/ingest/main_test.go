@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/factory"
+)
+
+// openTrackedStores opens in-memory stores for needs with dirty tracking, with
+// candidate "c1" of mint "mint1" simulated over [1000, 5000].
+func openTrackedStores(t *testing.T, needs factory.Need) *factory.Stores {
+	t.Helper()
+	ctx := context.Background()
+
+	stores, cleanup, err := openStores(ctx, "", needs, true, false)
+	if err != nil {
+		t.Fatalf("openStores failed: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	if err := stores.Candidates.Insert(ctx, &domain.TokenCandidate{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "mint1"}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+	if err := stores.Trades.Insert(ctx, &domain.TradeRecord{TradeID: "t1", CandidateID: "c1", EntrySignalTime: 1000, ExitActualTime: 5000}); err != nil {
+		t.Fatalf("insert trade: %v", err)
+	}
+	return withDirtyTracking(stores, log.New(io.Discard, "", 0))
+}
+
+// assertDirty checks that exactly c1 is dirty from earliest.
+func assertDirty(t *testing.T, stores *factory.Stores, earliest int64) {
+	t.Helper()
+	marks, err := stores.DirtyCandidates.List(context.Background())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(marks) != 1 || marks[0].CandidateID != "c1" || marks[0].EarliestEventTime != earliest {
+		t.Errorf("expected c1 dirty from %d, got %+v", earliest, marks)
+	}
+}
+
+func TestBackfillStores_MarkLateEvents(t *testing.T) {
+	ctx := context.Background()
+	stores := openTrackedStores(t, backfillNeeds)
+
+	// The backfiller stores swap events in bulk and liquidity events per candidate
+	if err := stores.SwapEvents.InsertBulk(ctx, []*domain.SwapEvent{
+		{Mint: "mint1", TxSignature: "tx-after", Timestamp: 6000},
+		{Mint: "mint1", TxSignature: "tx-late", Timestamp: 4000},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+	if err := stores.LiquidityEvents.Insert(ctx, &domain.LiquidityEvent{
+		CandidateID: "c1", Pool: "pool1", Mint: "mint1", TxSignature: "tx-liq", Timestamp: 2000, EventType: domain.LiquidityEventRemove,
+	}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	assertDirty(t, stores, 2000)
+}
+
+func TestReparseStores_MarkCorrections(t *testing.T) {
+	ctx := context.Background()
+	stores := openTrackedStores(t, reparseNeeds)
+
+	if err := stores.Reparse.Apply(ctx, &storage.ReparseFix{
+		TxSignature:      "tx-added",
+		InsertSwapEvents: []*domain.SwapEvent{{Mint: "mint1", TxSignature: "tx-added", Timestamp: 3000}},
+		Corrections: []*storage.ReparseCorrection{{
+			EventTable: storage.EventTableSwapEvents, TxSignature: "tx-added", Change: storage.ReparseAdded, After: "{}", CorrectedAt: 9000,
+		}},
+	}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	assertDirty(t, stores, 3000)
+}
@@ -2,48 +2,94 @@
 // Executes: normalization → simulation → metrics → reporting
 //
 // Supports two modes:
-// - Production: reads from PostgreSQL, writes to ClickHouse (default)
-// - Fixtures: uses in-memory stores with demo data (--use-fixtures)
+// - Production: reads from PostgreSQL, writes to ClickHouse (when DSNs are given)
+// - Fixtures: uses in-memory stores with demo data (--use-fixtures, or no DSNs)
 package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"solana-token-lab/internal/decision"
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/observability"
 	"solana-token-lab/internal/orchestrator"
 	"solana-token-lab/internal/pipeline"
 	"solana-token-lab/internal/replay"
+	"solana-token-lab/internal/simulation"
 	"solana-token-lab/internal/storage"
-	"solana-token-lab/internal/storage/clickhouse"
-	"solana-token-lab/internal/storage/migrations"
-	"solana-token-lab/internal/storage/memory"
-	"solana-token-lab/internal/storage/postgres"
+	"solana-token-lab/internal/storage/factory"
 )
 
 func main() {
 	// Parse flags
 	outputDir := flag.String("output-dir", "docs", "Output directory for generated files")
 	verbose := flag.Bool("verbose", false, "Verbose output")
-	postgresDSN := flag.String("postgres-dsn", "", "PostgreSQL connection string")
-	clickhouseDSN := flag.String("clickhouse-dsn", "", "ClickHouse connection string")
-	useFixtures := flag.Bool("use-fixtures", false, "Use in-memory fixtures (demo mode)")
+	postgresDSN := flag.String("postgres-dsn", os.Getenv("POSTGRES_DSN"), "PostgreSQL connection string")
+	clickhouseDSN := flag.String("clickhouse-dsn", os.Getenv("CLICKHOUSE_DSN"), "ClickHouse connection string")
+	fixturesFlag := flag.Bool("use-fixtures", false, "Use in-memory fixtures (demo mode, default when no DSNs are given)")
+	aggregateBackend := flag.String("aggregate-backend", "go", "Aggregate computation backend: go or clickhouse")
+	instrumentStores := flag.Bool("instrument-stores", true, "Record per-store latency and error metrics")
+	replaceTrades := flag.Bool("replace-trades", false, "Overwrite existing trades whose re-simulated outcome changed and refresh aggregates")
+	incremental := flag.Bool("incremental", false, "Simulate only candidates without trades and candidates marked dirty by late events (PostgreSQL mode)")
+	observationWindow := flag.Duration("observation-window", 0, "Close candidates this long after discovery (e.g. 48h); only closed candidates count towards decisions (0 disables)")
+	segmentByDEX := flag.Bool("segment-by-dex", false, "Add aggregates per discovery DEX of the candidate, e.g. NEW_TOKEN:dex_raydium (go backend only)")
+	dedupTrades := flag.Bool("dedup-trades", false, "Add aggregates over trades deduplicated across candidates of the same mint, e.g. ACTIVE_TOKEN:dedup (go backend only)")
+	minDataPoints := flag.Int("min-data-points", orchestrator.DefaultMinDataPoints, "Skip TRAILING_STOP/LIQUIDITY_GUARD for candidates with fewer price/liquidity points in the hold window (0 disables)")
+	simWindowMargin := flag.Duration("sim-window-margin", time.Duration(simulation.DefaultWindowMarginMs)*time.Millisecond, "Load simulation time series only up to each strategy's max hold plus this margin (0 loads full series)")
+	determinismCheck := flag.Bool("determinism-check", false, "Simulate twice more into throwaway stores before the simulate phase and fail on the first trade that differs")
+	simCache := flag.Bool("sim-cache", false, "Skip simulations whose candidate events, strategy and scenario configs match a stored trade's input key")
+	phasesFlag := flag.String("phases", "all", "Comma-separated orchestrator phases to run: associate,normalize,simulate,sync,aggregate (missing inputs of skipped phases must already be stored)")
+	chartsTop := flag.Int("charts-top", 5, "Render price/liquidity charts for the top N candidates of the best strategy (0 disables)")
+	minLiveShare := flag.Float64("min-live-share", 0, "Require at least this percentage of candidates discovered via live ingestion for sufficiency (0 disables)")
+	minMetadataCoverage := flag.Float64("min-metadata-coverage", 0, "Require at least this percentage of candidates with token metadata for sufficiency (0 disables)")
+	sampleSize := flag.Int("sample-size", 0, "Replayability check and charts cover a deterministic sample of N candidates seeded by the data version (0 covers all)")
+	realtimeFeed := flag.Bool("realtime-price-feed", true, "Deployment runs real-time (WebSocket) swap feeds; strategies that need them are otherwise marked not implementable")
+	liquidityFeed := flag.Bool("liquidity-feed", true, "Deployment tracks pool liquidity in real time; LIQUIDITY_GUARD is otherwise marked not implementable")
+	artifactsFlag := flag.String("artifacts", pipeline.ArtifactProfileFull, "Report artifacts to write: a profile (minimal, standard, full) or a comma-separated list of artifacts; metadata.json and checksums.sha256 are always written")
+	var entryFilters []metrics.EntryFilter
+	flag.Func("entry-filter", "Add aggregates restricted by entry context, e.g. token_age_lt=10m,liquidity_gte=1000 (repeatable, go backend only)", func(spec string) error {
+		f, err := metrics.ParseEntryFilter(spec)
+		if err != nil {
+			return err
+		}
+		entryFilters = append(entryFilters, f)
+		return nil
+	})
 	flag.Parse()
 
 	// Validate flags
-	if !*useFixtures {
-		if *postgresDSN == "" || *clickhouseDSN == "" {
-			fmt.Fprintln(os.Stderr, "Error: --postgres-dsn and --clickhouse-dsn are required unless --use-fixtures is set")
-			flag.Usage()
-			os.Exit(1)
-		}
+	useFixtures, err := resolveFixturesMode(*postgresDSN, *clickhouseDSN, *fixturesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+	phases, err := orchestrator.ParsePhases(*phasesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --phases: %v\n", err)
+		os.Exit(1)
+	}
+	artifacts, err := pipeline.ParseArtifacts(*artifactsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --artifacts: %v\n", err)
+		os.Exit(1)
+	}
+	if *aggregateBackend != "go" && *aggregateBackend != "clickhouse" {
+		fmt.Fprintf(os.Stderr, "Error: unknown --aggregate-backend %q (expected go or clickhouse)\n", *aggregateBackend)
+		os.Exit(1)
+	}
+	if *aggregateBackend == "clickhouse" && useFixtures {
+		fmt.Fprintln(os.Stderr, "Error: --aggregate-backend=clickhouse cannot be used with --use-fixtures")
+		os.Exit(1)
 	}
 
 	// Create context with cancellation for graceful shutdown
@@ -60,7 +106,7 @@ func main() {
 	}()
 
 	// Create stores based on mode
-	stores, cleanup, err := createStores(ctx, *postgresDSN, *clickhouseDSN, *useFixtures)
+	stores, cleanup, err := createStores(ctx, *postgresDSN, *clickhouseDSN, useFixtures, *instrumentStores)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating stores: %v\n", err)
 		os.Exit(1)
@@ -68,7 +114,7 @@ func main() {
 	defer cleanup()
 
 	// Load fixture data only in fixtures mode
-	if *useFixtures {
+	if useFixtures {
 		if err := loadFixtureData(ctx, stores); err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading fixtures: %v\n", err)
 			os.Exit(1)
@@ -81,89 +127,160 @@ func main() {
 
 	// Phase 1-4: Run orchestrator (normalization → simulation → metrics)
 	fmt.Println("=== E2E Pipeline ===")
-	if *useFixtures {
+	if useFixtures {
 		fmt.Println("Mode: FIXTURES (in-memory demo data)")
 	} else {
 		fmt.Println("Mode: PRODUCTION (PostgreSQL + ClickHouse)")
 	}
 
-	orch := orchestrator.New(orchestrator.Options{
-		CandidateStore:           stores.candidateStore,
-		SwapStore:                stores.swapStore,
-		LiquidityEventStore:      stores.liquidityEventStore,
-		PriceTimeseriesStore:     stores.priceTimeseriesStore,
-		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
-		VolumeTimeseriesStore:    stores.volumeTimeseriesStore,
-		DerivedFeatureStore:      stores.derivedFeatureStore,
-		TradeRecordStore:         stores.tradeRecordStore,
-		StrategyAggregateStore:   stores.strategyAggregateStore,
+	orchOpts := orchestrator.Options{
+		CandidateStore:           stores.Candidates,
+		SwapStore:                stores.Swaps,
+		LiquidityEventStore:      stores.LiquidityEvents,
+		PriceTimeseriesStore:     stores.PriceTimeseries,
+		LiquidityTimeseriesStore: stores.LiquidityTimeseries,
+		VolumeTimeseriesStore:    stores.VolumeTimeseries,
+		DerivedFeatureStore:      stores.DerivedFeatures,
+		TradeRecordStore:         stores.Trades,
+		StrategyAggregateStore:   stores.StrategyAggregates,
+		TokenMetadataStore:       stores.TokenMetadata,
+		DirtyCandidateStore:      stores.DirtyCandidates,
+		EventCountStore:          stores.EventCounts,
 		StrategyConfigs:          strategyConfigs,
 		ScenarioConfigs:          scenarioConfigs,
+		EntryFilters:             entryFilters,
+		SegmentByDEX:             *segmentByDEX,
+		DedupTrades:              *dedupTrades,
+		DataRequirements:         orchestrator.DefaultDataRequirements(*minDataPoints),
+		ReplaceExistingTrades:    *replaceTrades,
+		Incremental:              *incremental,
+		ObservationWindowMs:      observationWindow.Milliseconds(),
+		Phases:                   phases,
+		DeterminismCheck:         *determinismCheck,
+		SimulationCache:          *simCache,
 		Verbose:                  *verbose,
-	})
+		SimulationWindowMarginMs: simWindowMargin.Milliseconds(),
+		OnTimeseriesLoad:         observability.RecordTimeseriesLoad,
+		OnSimulationProgress: func(p orchestrator.SimulationProgress) {
+			observability.UpdateSimulationProgress(p.CandidatesDone, p.CandidatesTotal, p.TradesWritten)
+		},
+	}
+	if *aggregateBackend == "clickhouse" {
+		orchOpts.TradeAggregateStore = stores.TradeAggregates
+	}
+	orch := orchestrator.New(orchOpts)
 
+	config := pipeline.ConfigFromFlags(flag.CommandLine)
+	manifest := pipeline.NewRunManifest(storage.RunKindPipeline, time.Now(), config, stores.Backends)
 	result, err := orch.Run(ctx)
+	// Phase summaries and the run manifest are written even for failed runs, to show where it stopped
+	if mkErr := os.MkdirAll(*outputDir, 0755); mkErr == nil {
+		if wErr := orchestrator.WriteRunSummary(filepath.Join(*outputDir, "orchestrator_run.json"), result); wErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: write orchestrator_run.json: %v\n", wErr)
+		}
+	}
+	pipeline.RecordOrchestratorRun(manifest, result, err)
+	if mErr := pipeline.WriteRunManifest(ctx, pipeline.DirWriter(*outputDir), stores.RunManifests, manifest); mErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: run manifest: %v\n", mErr)
+	}
 	if err != nil {
+		if errors.Is(err, context.Canceled) && result != nil {
+			// Completed candidates are stored; the next run skips them
+			fmt.Fprintf(os.Stderr, "Orchestrator cancelled: %v\n", err)
+			fmt.Fprintf(os.Stderr, "  %d trades persisted; re-run to resume\n", result.TradesCreated+result.TradesUpdated)
+			os.Exit(1)
+		}
 		fmt.Fprintf(os.Stderr, "Orchestrator error: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Printf("Orchestrator completed:\n")
 	fmt.Printf("  Candidates: %d\n", result.CandidatesProcessed)
-	fmt.Printf("  Trades: %d\n", result.TradesCreated)
-	fmt.Printf("  Aggregates: %d\n", result.AggregatesCreated)
+	if *observationWindow > 0 {
+		fmt.Printf("  Closed: %d (already closed %d)\n", result.CandidatesClosed, result.CandidatesAlreadyClosed)
+	}
+	fmt.Printf("  Trades: %d (skipped %d, updated %d)\n", result.TradesCreated, result.TradesSkipped, result.TradesUpdated)
+	if *simCache {
+		fmt.Printf("  Simulation cache: %d hits, %d misses\n", result.SimulationCacheHits, result.SimulationCacheMisses)
+	}
+	if *determinismCheck {
+		fmt.Printf("  Determinism check: %d trades identical across two runs\n", result.DeterminismCheckTrades)
+	}
+	if ts := result.TradeSync; ts != nil {
+		fmt.Printf("  Trade mirror: %d of %d changed trades synced (%d pending); %d stored, %d mirrored\n",
+			ts.Mirrored, ts.Read, ts.Pending, ts.SourceTrades, ts.MirrorTrades)
+	}
+	fmt.Printf("  Aggregates: %d (updated %d)\n", result.AggregatesCreated, result.AggregatesUpdated)
+	if len(result.Skipped) > 0 {
+		fmt.Printf("  Skipped (insufficient data, excluded from aggregates): %s\n", result.FormatSkippedByStrategy())
+	}
 	if len(result.Errors) > 0 {
 		fmt.Printf("  Errors: %d\n", len(result.Errors))
 		for _, e := range result.Errors {
 			fmt.Printf("    - %s\n", e)
 		}
 	}
+	if result.DecimalsInferred > 0 {
+		fmt.Printf("  Inferred decimals: %d candidates (flagged in integrity warnings)\n", result.DecimalsInferred)
+	}
 
 	// Phase 5: Reporting via Phase1Pipeline
 	fmt.Println("\n=== Phase 1 Reporting ===")
 
 	// Create aggregator (for missing candidate tracking)
 	aggregator := metrics.NewAggregator(
-		stores.tradeRecordStore,
-		stores.strategyAggregateStore,
-		stores.candidateStore,
+		stores.Trades,
+		stores.StrategyAggregates,
+		stores.Candidates,
 	)
 
-	// Define implementable strategies (all enabled after orchestration)
-	implementable := map[decision.StrategyKey]bool{
-		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:          true,
-		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN"}:       true,
-		{StrategyID: "TRAILING_STOP", EntryEventType: "NEW_TOKEN"}:      true,
-		{StrategyID: "TRAILING_STOP", EntryEventType: "ACTIVE_TOKEN"}:   true,
-		{StrategyID: "LIQUIDITY_GUARD", EntryEventType: "NEW_TOKEN"}:    true,
-		{StrategyID: "LIQUIDITY_GUARD", EntryEventType: "ACTIVE_TOKEN"}: true,
-	}
+	// Implementability of each strategy on the deployment described by the flags
+	implementability := decision.ResolveImplementability(decision.Deployment{
+		RealtimePriceFeed: *realtimeFeed,
+		LiquidityFeed:     *liquidityFeed,
+	})
 
 	// Create replay runner for replayability check
-	replayRunner := replay.NewRunner(stores.swapStore, stores.liquidityEventStore)
+	replayRunner := replay.NewRunner(stores.Swaps, stores.LiquidityEvents)
 
 	// Create pipeline with fixed clock for deterministic output
 	fixedTime := time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)
 	p := pipeline.NewPhase1Pipeline(
-		stores.candidateStore,
-		stores.tradeRecordStore,
-		stores.strategyAggregateStore,
-		implementable,
+		stores.Candidates,
+		stores.Trades,
+		stores.StrategyAggregates,
+		nil,
 		*outputDir,
-	).WithSufficiencyChecker(
-		stores.candidateStore,
-		stores.tradeRecordStore,
-		stores.swapStore,
-		stores.liquidityEventStore,
-		replayRunner,
-	).WithAggregator(aggregator).WithClock(func() time.Time { return fixedTime })
+	).WithImplementability(implementability).
+		WithSufficiencyChecker(
+			stores.Candidates,
+			stores.Trades,
+			stores.Swaps,
+			stores.LiquidityEvents,
+			replayRunner,
+		).WithAggregator(aggregator).
+		WithIntegrityErrors(result.Warnings).
+		WithClosedOnly(*observationWindow > 0).
+		WithClock(func() time.Time { return fixedTime }).
+		WithCharts(stores.Candidates, stores.PriceTimeseries, stores.LiquidityTimeseries, *chartsTop).
+		WithSampleSize(*sampleSize).
+		WithMinLiveShare(*minLiveShare).
+		WithMinMetadataCoverage(*minMetadataCoverage).
+		WithMetricsQueryTables(stores.MetricsTables).
+		WithArtifacts(artifacts).
+		WithRunManifest(stores.RunManifests, config, stores.Backends)
 
 	// Set data source based on mode
-	if *useFixtures {
+	if useFixtures {
 		p = p.WithDataSource("fixtures")
 	} else {
 		p = p.WithDBSource(*postgresDSN, *clickhouseDSN).
-			WithRawDataStores(stores.candidateStore, stores.priceTimeseriesStore, stores.liquidityTimeseriesStore)
+			WithRawDataStores(stores.Candidates, stores.PriceTimeseries, stores.LiquidityTimeseries).
+			WithFinalityStore(stores.Finality).
+			WithEventCountStore(stores.EventCounts).
+			WithMetadataStore(stores.TokenMetadata).
+			WithFailedTxStore(stores.FailedTx).
+			WithSufficiencyRunStore(stores.SufficiencyRuns)
 	}
 
 	// Run reporting pipeline
@@ -172,105 +289,88 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Println("\nE2E Pipeline completed successfully:")
-	fmt.Printf("  - %s/REPORT_PHASE1.md\n", *outputDir)
-	fmt.Printf("  - %s/strategy_aggregates.csv\n", *outputDir)
-	fmt.Printf("  - %s/trade_records.csv\n", *outputDir)
-	fmt.Printf("  - %s/scenario_outcomes.csv\n", *outputDir)
-	fmt.Printf("  - %s/DECISION_GATE_REPORT.md\n", *outputDir)
+	fmt.Printf("\nE2E Pipeline completed successfully (%s artifacts):\n", artifacts.Profile())
+	fmt.Printf("  - %s/orchestrator_run.json\n", *outputDir)
+	for _, name := range []string{
+		pipeline.ArtifactReport,
+		pipeline.ArtifactStrategyAggregates,
+		pipeline.ArtifactTradeRecords,
+		pipeline.ArtifactScenarioOutcomes,
+		pipeline.ArtifactDecision,
+	} {
+		if artifacts.Enabled(name) {
+			fmt.Printf("  - %s/%s\n", *outputDir, pipeline.ArtifactPath(name))
+		}
+	}
+	fmt.Printf("  - %s/%s/{%s,%s}/%s\n", *outputDir, pipeline.RunsDir, storage.RunKindPipeline, storage.RunKindReport, pipeline.RunManifestFile)
+	if *chartsTop > 0 && artifacts.Enabled(pipeline.ArtifactCharts) {
+		fmt.Printf("  - %s/charts/\n", *outputDir)
+	}
 }
 
-// allStores holds all stores required by the pipeline.
-type allStores struct {
-	candidateStore           storage.CandidateStore
-	swapStore                storage.SwapStore
-	liquidityEventStore      storage.LiquidityEventStore
-	priceTimeseriesStore     storage.PriceTimeseriesStore
-	liquidityTimeseriesStore storage.LiquidityTimeseriesStore
-	volumeTimeseriesStore    storage.VolumeTimeseriesStore
-	derivedFeatureStore      storage.DerivedFeatureStore
-	tradeRecordStore         storage.TradeRecordStore
-	strategyAggregateStore   storage.StrategyAggregateStore
-}
+// fixtureNeeds are the stores of a fixtures run. Stores without fixture data
+// (metadata, finality, event counts, ...) are left nil, so the checks reading
+// them are skipped rather than run against empty stores.
+const fixtureNeeds = factory.NeedCandidates | factory.NeedSwaps | factory.NeedLiquidityEvents |
+	factory.NeedPriceTimeseries | factory.NeedLiquidityTimeseries | factory.NeedVolumeTimeseries |
+	factory.NeedDerivedFeatures | factory.NeedTrades | factory.NeedStrategyAggregates
+
+// databaseNeeds are the stores of a PostgreSQL and ClickHouse run.
+const databaseNeeds = fixtureNeeds | factory.NeedTradeAggregates | factory.NeedTokenMetadata |
+	factory.NeedFinality | factory.NeedSufficiencyRuns | factory.NeedDirtyCandidates |
+	factory.NeedEventCounts | factory.NeedRunManifests | factory.NeedFailedTx
 
 // createStores creates all required stores based on mode.
 // Returns stores, cleanup function, and error.
-func createStores(ctx context.Context, postgresDSN, clickhouseDSN string, useFixtures bool) (*allStores, func(), error) {
-	if useFixtures {
-		return createMemoryStores(), func() {}, nil
+func createStores(ctx context.Context, postgresDSN, clickhouseDSN string, useFixtures, instrument bool) (*factory.Stores, func(), error) {
+	cfg := factory.Config{
+		Backend:       factory.BackendPostgres,
+		PostgresDSN:   postgresDSN,
+		ClickHouseDSN: clickhouseDSN,
+		Needs:         databaseNeeds,
+		Instrument:    instrument,
 	}
-
-	return createDBStores(ctx, postgresDSN, clickhouseDSN)
-}
-
-// createMemoryStores creates all in-memory stores for fixtures mode.
-func createMemoryStores() *allStores {
-	return &allStores{
-		candidateStore:           memory.NewCandidateStore(),
-		swapStore:                memory.NewSwapStore(),
-		liquidityEventStore:      memory.NewLiquidityEventStore(),
-		priceTimeseriesStore:     memory.NewPriceTimeseriesStore(),
-		liquidityTimeseriesStore: memory.NewLiquidityTimeseriesStore(),
-		volumeTimeseriesStore:    memory.NewVolumeTimeseriesStore(),
-		derivedFeatureStore:      memory.NewDerivedFeatureStore(),
-		tradeRecordStore:         memory.NewTradeRecordStore(),
-		strategyAggregateStore:   memory.NewStrategyAggregateStore(),
+	if useFixtures {
+		cfg.Backend = factory.BackendMemory
+		cfg.Needs = fixtureNeeds
 	}
+	return factory.BuildStores(ctx, cfg)
 }
 
-// createDBStores creates stores backed by PostgreSQL and ClickHouse.
-func createDBStores(ctx context.Context, postgresDSN, clickhouseDSN string) (*allStores, func(), error) {
-	// Connect to PostgreSQL
-	pgPool, err := postgres.NewPool(ctx, postgresDSN)
-	if err != nil {
-		return nil, nil, fmt.Errorf("connect to postgres: %w", err)
-	}
-
-	if err := migrations.RunPostgresMigrations(ctx, pgPool); err != nil {
-		pgPool.Close()
-		return nil, nil, fmt.Errorf("postgres migrations: %w", err)
-	}
-
-	// Connect to ClickHouse (after migrations)
-	chConn, err := migrations.RunClickhouseMigrations(ctx, clickhouseDSN)
-	if err != nil {
-		pgPool.Close()
-		return nil, nil, fmt.Errorf("clickhouse migrations: %w", err)
-	}
-
-	// Cleanup function
-	cleanup := func() {
-		chConn.Close()
-		pgPool.Close()
+// resolveFixturesMode decides whether to run on in-memory fixtures.
+// Without any DSN the pipeline falls back to fixtures, matching its behavior before
+// database support; a single DSN is a misconfiguration rather than a request for fixtures.
+func resolveFixturesMode(postgresDSN, clickhouseDSN string, useFixtures bool) (bool, error) {
+	if useFixtures {
+		return true, nil
 	}
-
-	stores := &allStores{
-		// PostgreSQL stores (source data + trade_records)
-		candidateStore:      postgres.NewCandidateStore(pgPool),
-		swapStore:           postgres.NewSwapStore(pgPool),
-		liquidityEventStore: postgres.NewLiquidityEventStore(pgPool),
-		tradeRecordStore:    postgres.NewTradeRecordStore(pgPool),
-
-		// ClickHouse stores (derived data)
-		priceTimeseriesStore:     clickhouse.NewPriceTimeseriesStore(chConn),
-		liquidityTimeseriesStore: clickhouse.NewLiquidityTimeseriesStore(chConn),
-		volumeTimeseriesStore:    clickhouse.NewVolumeTimeseriesStore(chConn),
-		derivedFeatureStore:      clickhouse.NewDerivedFeatureStore(chConn),
-		strategyAggregateStore:   clickhouse.NewStrategyAggregateStore(chConn),
+	switch {
+	case postgresDSN == "" && clickhouseDSN == "":
+		return true, nil
+	case postgresDSN == "" || clickhouseDSN == "":
+		return false, fmt.Errorf("--postgres-dsn and --clickhouse-dsn must be given together (or use --use-fixtures)")
+	default:
+		return false, nil
 	}
-
-	return stores, cleanup, nil
 }
 
 // loadFixtureData loads fixture data into stores (only for fixtures mode).
-func loadFixtureData(ctx context.Context, stores *allStores) error {
+// Stores are cleared first, so loading again never duplicates fixture rows or
+// leaves trades from a previous simulation next to fresh ones.
+func loadFixtureData(ctx context.Context, stores *factory.Stores) error {
+	for _, c := range stores.Clearables {
+		if err := c.Clear(ctx); err != nil {
+			return fmt.Errorf("clear stores: %w", err)
+		}
+	}
+
 	// Load candidates only (trades will be generated fresh by orchestrator simulation)
-	if err := pipeline.LoadCandidatesOnly(ctx, stores.candidateStore); err != nil {
+	if err := pipeline.LoadCandidatesOnly(ctx, stores.Candidates); err != nil {
 		return fmt.Errorf("load candidates: %w", err)
 	}
 
 	// Load swap and liquidity events (required for normalization)
-	if err := pipeline.LoadSwapsAndLiquidity(ctx, stores.swapStore, stores.liquidityEventStore); err != nil {
+	if err := pipeline.LoadSwapsAndLiquidity(ctx, stores.Swaps, stores.LiquidityEvents); err != nil {
 		return fmt.Errorf("load swaps/liquidity: %w", err)
 	}
 
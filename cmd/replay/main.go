@@ -12,9 +12,7 @@ import (
 	"time"
 
 	"solana-token-lab/internal/replay"
-	"solana-token-lab/internal/storage"
-	"solana-token-lab/internal/storage/memory"
-	pgstore "solana-token-lab/internal/storage/postgres"
+	"solana-token-lab/internal/storage/factory"
 )
 
 func main() {
@@ -50,22 +48,23 @@ func main() {
 	}()
 
 	// Create stores
-	var swapStore storage.SwapStore = memory.NewSwapStore()
-	var liquidityStore storage.LiquidityEventStore = memory.NewLiquidityEventStore()
-
+	storeCfg := factory.Config{
+		Backend:        factory.BackendMemory,
+		PostgresDSN:    *postgresDSN,
+		Needs:          factory.NeedSwaps | factory.NeedLiquidityEvents,
+		SkipMigrations: true,
+	}
 	if !*useMemory && *postgresDSN != "" {
-		pool, err := pgstore.NewPool(ctx, *postgresDSN)
-		if err != nil {
-			logger.Fatalf("connect to postgres: %v", err)
-		}
-		defer pool.Close()
-
-		swapStore = pgstore.NewSwapStore(pool)
-		liquidityStore = pgstore.NewLiquidityEventStore(pool)
+		storeCfg.Backend = factory.BackendPostgres
+	}
+	stores, cleanup, err := factory.BuildStores(ctx, storeCfg)
+	if err != nil {
+		logger.Fatalf("create stores: %v", err)
 	}
+	defer cleanup()
 
 	// Create replay runner
-	replayRunner := replay.NewRunner(swapStore, liquidityStore)
+	replayRunner := replay.NewRunner(stores.Swaps, stores.LiquidityEvents)
 
 	// Determine time range
 	var from, to int64
@@ -89,7 +88,6 @@ func main() {
 
 	// Run replay - deterministic behavior only
 	// Either use explicit time range (both bounds required) or replay all events
-	var err error
 	if from > 0 && to > 0 {
 		// Explicit time range - both bounds required for determinism
 		logger.Printf("Replaying candidate %s from %d to %d", *candidateID, from, to)
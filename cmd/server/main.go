@@ -7,10 +7,13 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
@@ -18,6 +21,7 @@ import (
 	"syscall"
 	"time"
 
+	"solana-token-lab/internal/api"
 	"solana-token-lab/internal/decision"
 	"solana-token-lab/internal/discovery"
 	"solana-token-lab/internal/domain"
@@ -27,12 +31,12 @@ import (
 	"solana-token-lab/internal/orchestrator"
 	"solana-token-lab/internal/pipeline"
 	"solana-token-lab/internal/replay"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/simulation"
 	"solana-token-lab/internal/solana"
 	"solana-token-lab/internal/storage"
-	chstore "solana-token-lab/internal/storage/clickhouse"
-	"solana-token-lab/internal/storage/migrations"
-	"solana-token-lab/internal/storage/memory"
-	pgstore "solana-token-lab/internal/storage/postgres"
+	"solana-token-lab/internal/storage/factory"
+	"solana-token-lab/internal/storage/tracked"
 )
 
 // DEX program aliases mapped to program IDs.
@@ -45,7 +49,9 @@ var dexAliases = map[string]string{
 type Server struct {
 	// Configuration
 	rpcEndpoint      string
+	rpcOpts          []solana.ClientOption
 	wsEndpoint       string
+	wsFailover       []string // endpoints switched to in order when subscriptions starve
 	postgresDSN      string
 	clickhouseDSN    string
 	useMemory        bool
@@ -55,8 +61,45 @@ type Server struct {
 	reportInterval   time.Duration
 	checkInterval    time.Duration
 
+	// Holder enrichment (0 interval disables)
+	holderInterval        time.Duration
+	holderActiveWindow    time.Duration
+	holderMinMintInterval time.Duration
+
+	// Finality verification (0 interval disables)
+	finalityInterval time.Duration
+	finalityLookback time.Duration
+
+	// PRE_POOL discovery from mint creation (disabled by default)
+	prePoolDiscovery bool
+	prePoolTTL       time.Duration
+
+	// Incremental pipeline runs: only new and dirty candidates are simulated
+	incremental bool
+
+	// Per-candidate caps on events stored during ingestion (disabled by default)
+	eventCaps ingestion.EventCaps
+
+	// WebSocket starvation probe with endpoint failover and gap backfill (disabled by default)
+	wsHealth ingestion.WSHealthConfig
+
+	// Sufficiency: minimum percentage of live-discovered candidates (0 disables)
+	minLiveShare float64
+
+	// Sufficiency: minimum percentage of candidates with token metadata (0 disables)
+	minMetadataCoverage float64
+
+	// Discovery mint blacklist/allowlist, shared with the detectors and changed via /admin/mint-filter
+	mintFilter *discovery.MintFilter
+
+	// On-demand metadata refresh for POST /admin/metadata/refresh
+	metadataRefresher *ingestion.MetadataRefresher
+
+	// Effective flag values, recorded (sanitized) in run manifests
+	config map[string]string
+
 	// Stores
-	stores *allStores
+	stores *factory.Stores
 
 	// Components
 	ingestionRunner *ingestion.Runner
@@ -66,30 +109,18 @@ type Server struct {
 	mu               sync.Mutex
 	lastPipelineRun  time.Time
 	lastReportRun    time.Time
+	nextPipelineRun  time.Time // next scheduled run, zero before the scheduler starts
+	nextReportRun    time.Time
 	pipelineRunning  bool
 	reportRunning    bool
 	ingestionStarted time.Time
+	latestPreview    *reporting.Preview // written after each successful pipeline run
 
 	// Stats
 	pipelineRuns int
 	reportRuns   int
 }
 
-// allStores holds all storage implementations.
-type allStores struct {
-	candidateStore           storage.CandidateStore
-	swapStore                storage.SwapStore
-	swapEventStore           storage.SwapEventStore
-	liquidityEventStore      storage.LiquidityEventStore
-	priceTimeseriesStore     storage.PriceTimeseriesStore
-	liquidityTimeseriesStore storage.LiquidityTimeseriesStore
-	volumeTimeseriesStore    storage.VolumeTimeseriesStore
-	derivedFeatureStore      storage.DerivedFeatureStore
-	tradeRecordStore         storage.TradeRecordStore
-	strategyAggregateStore   storage.StrategyAggregateStore
-	metadataStore            storage.TokenMetadataStore
-}
-
 func main() {
 	// Load .env file if exists
 	loadEnvFile()
@@ -97,6 +128,9 @@ func main() {
 	// Parse flags (env vars as defaults)
 	rpcEndpoint := flag.String("rpc-endpoint", os.Getenv("SOLANA_RPC_ENDPOINT"), "Solana RPC HTTP endpoint")
 	wsEndpoint := flag.String("ws-endpoint", os.Getenv("SOLANA_WS_ENDPOINT"), "Solana WebSocket endpoint")
+	wsEndpoints := flag.String("ws-endpoints", os.Getenv("SOLANA_WS_ENDPOINTS"), "Comma-separated failover WebSocket endpoints, switched to in order after --ws-endpoint when subscriptions starve")
+	wsMinRate := flag.Float64("ws-min-notifications-per-min", 0, "Switch the WebSocket endpoint (or reconnect) when a subscription receives fewer notifications per minute over --ws-starvation-threshold, and backfill the gap (0 disables)")
+	wsStarvation := flag.Duration("ws-starvation-threshold", ingestion.DefaultStarvationThreshold, "Window over which --ws-min-notifications-per-min is measured")
 	postgresDSN := flag.String("postgres-dsn", os.Getenv("POSTGRES_DSN"), "PostgreSQL connection string")
 	clickhouseDSN := flag.String("clickhouse-dsn", os.Getenv("CLICKHOUSE_DSN"), "ClickHouse connection string")
 	programs := flag.String("programs", "", "Comma-separated DEX program IDs to monitor")
@@ -107,12 +141,37 @@ func main() {
 	checkInterval := flag.Duration("check-interval", 1*time.Hour, "ACTIVE_TOKEN detection interval")
 	useMemory := flag.Bool("use-memory", false, "Use in-memory storage instead of PostgreSQL")
 	metricsAddr := flag.String("metrics-addr", ":9090", "Prometheus metrics HTTP address")
+	instrumentStores := flag.Bool("instrument-stores", true, "Record per-store latency and error metrics")
+	holderInterval := flag.Duration("holder-interval", 1*time.Hour, "Holder concentration snapshot interval (0 disables)")
+	holderActiveWindow := flag.Duration("holder-active-window", 24*time.Hour, "Only snapshot candidates discovered or traded within this window")
+	holderMinMintInterval := flag.Duration("holder-min-mint-interval", 500*time.Millisecond, "Minimum delay between holder RPC lookups")
+	finalityInterval := flag.Duration("finality-interval", 1*time.Minute, "Finality verification interval for ingested events (0 disables)")
+	finalityLookback := flag.Duration("finality-lookback", 30*time.Minute, "Only verify events ingested within this window")
+	rpcTimeout := flag.Duration("rpc-timeout", solana.DefaultTimeout, "Deadline of each RPC request attempt")
+	rpcSlowThreshold := flag.Duration("rpc-slow-threshold", 0, "Log RPC requests slower than this (0 disables)")
+	mintBlacklist := flag.String("mint-blacklist", "", "File of mints (and authority:<address> lines) that never become candidates")
+	mintAllowlist := flag.String("mint-allowlist", "", "File of mints (and authority:<address> lines); if set, only these become candidates")
+	prePoolDiscovery := flag.Bool("pre-pool-discovery", false, "Record mints at InitializeMint as provisional PRE_POOL discoveries")
+	prePoolTTL := flag.Duration("pre-pool-ttl", discovery.DefaultProvisionalTTL, "Expire provisional PRE_POOL mints without a pool after this long")
+	minLiveShare := flag.Float64("min-live-share", 0, "Require at least this percentage of candidates discovered via live ingestion for sufficiency (0 disables)")
+	minMetadataCoverage := flag.Float64("min-metadata-coverage", 0, "Require at least this percentage of candidates with token metadata for sufficiency (0 disables)")
+	incremental := flag.Bool("incremental", false, "Simulate only new candidates and candidates dirtied by late events")
+	maxSwapsPerCandidate := flag.Int64("max-swaps-per-candidate", 0, "Store at most this many swap events per candidate in full, then downsample (0 disables)")
+	maxLiquidityPerCandidate := flag.Int64("max-liquidity-per-candidate", 0, "Store at most this many liquidity events per candidate in full, then downsample (0 disables)")
+	capSampleEvery := flag.Int64("cap-sample-every", ingestion.DefaultSampleEvery, "Past a per-candidate cap, store 1 in this many events")
+	capWindow := flag.Duration("cap-window", 48*time.Hour, "Observation window after discovery that per-candidate caps apply to")
+	metadataRefreshLimit := flag.Int("metadata-refresh-limit", ingestion.DefaultMetadataRefreshLimit, "Maximum POST /admin/metadata/refresh calls per minute (0 disables the limit)")
 
 	flag.Parse()
 
 	// Setup logger
 	logger := log.New(os.Stdout, "[server] ", log.LstdFlags|log.Lshortfile)
 
+	rpcOpts := []solana.ClientOption{solana.WithRequestTimeout(*rpcTimeout)}
+	if *rpcSlowThreshold > 0 {
+		rpcOpts = append(rpcOpts, solana.WithSlowRequestLog(*rpcSlowThreshold, logger))
+	}
+
 	// Validate required flags
 	if *rpcEndpoint == "" {
 		logger.Fatal("--rpc-endpoint is required")
@@ -131,19 +190,39 @@ func main() {
 	}
 	logger.Printf("Monitoring DEX programs: %v", programList)
 
+	blacklist, err := discovery.LoadMintList(*mintBlacklist)
+	if err != nil {
+		logger.Fatalf("Failed to load mint blacklist: %v", err)
+	}
+	allowlist, err := discovery.LoadMintList(*mintAllowlist)
+	if err != nil {
+		logger.Fatalf("Failed to load mint allowlist: %v", err)
+	}
+	mintFilter := discovery.NewMintFilter(blacklist, allowlist).
+		WithAuthorityResolver(ingestion.NewRPCMetadataSource(solana.NewHTTPClient(*rpcEndpoint, rpcOpts...))).
+		WithRecorder(observability.RecordFilteredDiscovery)
+	if hash := mintFilter.Hash(); hash != "" {
+		logger.Printf("Mint filter: %d blacklisted, %d allowlisted entries (hash %s)",
+			len(blacklist.Mints)+len(blacklist.Authorities), len(allowlist.Mints)+len(allowlist.Authorities), hash)
+	}
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create stores
-	stores, cleanup, err := createStores(ctx, *postgresDSN, *clickhouseDSN, *useMemory)
+	stores, cleanup, err := createStores(ctx, *postgresDSN, *clickhouseDSN, *useMemory, *instrumentStores)
 	if err != nil {
 		logger.Fatalf("Failed to create stores: %v", err)
 	}
 	defer cleanup()
+	if *incremental {
+		stores = withDirtyTracking(stores, logger)
+	}
 
 	// Create server
 	server := &Server{
 		rpcEndpoint:      *rpcEndpoint,
+		rpcOpts:          rpcOpts,
 		wsEndpoint:       *wsEndpoint,
 		postgresDSN:      *postgresDSN,
 		clickhouseDSN:    *clickhouseDSN,
@@ -155,6 +234,41 @@ func main() {
 		checkInterval:    *checkInterval,
 		stores:           stores,
 		logger:           logger,
+		config:           pipeline.ConfigFromFlags(flag.CommandLine),
+
+		holderInterval:        *holderInterval,
+		holderActiveWindow:    *holderActiveWindow,
+		holderMinMintInterval: *holderMinMintInterval,
+
+		finalityInterval: *finalityInterval,
+		finalityLookback: *finalityLookback,
+
+		prePoolDiscovery: *prePoolDiscovery,
+		prePoolTTL:       *prePoolTTL,
+
+		incremental:         *incremental,
+		minLiveShare:        *minLiveShare,
+		minMetadataCoverage: *minMetadataCoverage,
+
+		eventCaps: ingestion.EventCaps{
+			MaxSwaps:            *maxSwapsPerCandidate,
+			MaxLiquidityEvents:  *maxLiquidityPerCandidate,
+			SampleEvery:         *capSampleEvery,
+			ObservationWindowMs: capWindow.Milliseconds(),
+		},
+
+		wsFailover: splitList(*wsEndpoints),
+		wsHealth: ingestion.WSHealthConfig{
+			MinNotificationsPerMinute: *wsMinRate,
+			StarvationThreshold:       *wsStarvation,
+		},
+
+		mintFilter: mintFilter,
+
+		metadataRefresher: ingestion.NewMetadataRefresher(
+			ingestion.NewRPCMetadataSource(solana.NewHTTPClient(*rpcEndpoint, rpcOpts...)),
+			stores.Candidates, stores.TokenMetadata, logger,
+		).WithRateLimit(*metadataRefreshLimit, time.Minute),
 	}
 
 	// Channel to signal completion
@@ -229,66 +343,57 @@ func resolvePrograms(programs, dex string) []string {
 	return list
 }
 
-// createStores creates all required stores.
-func createStores(ctx context.Context, postgresDSN, clickhouseDSN string, useMemory bool) (*allStores, func(), error) {
-	if useMemory {
-		stores := &allStores{
-			candidateStore:           memory.NewCandidateStore(),
-			swapStore:                memory.NewSwapStore(),
-			swapEventStore:           memory.NewSwapEventStore(),
-			liquidityEventStore:      memory.NewLiquidityEventStore(),
-			priceTimeseriesStore:     memory.NewPriceTimeseriesStore(),
-			liquidityTimeseriesStore: memory.NewLiquidityTimeseriesStore(),
-			volumeTimeseriesStore:    memory.NewVolumeTimeseriesStore(),
-			derivedFeatureStore:      memory.NewDerivedFeatureStore(),
-			tradeRecordStore:         memory.NewTradeRecordStore(),
-			strategyAggregateStore:   memory.NewStrategyAggregateStore(),
-			metadataStore:            memory.NewTokenMetadataStore(),
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
 		}
-		return stores, func() {}, nil
-	}
-
-	// PostgreSQL
-	pool, err := pgstore.NewPool(ctx, postgresDSN)
-	if err != nil {
-		return nil, nil, fmt.Errorf("connect to postgres: %w", err)
 	}
+	return list
+}
 
-	if err := migrations.RunPostgresMigrations(ctx, pool); err != nil {
-		pool.Close()
-		return nil, nil, fmt.Errorf("postgres migrations: %w", err)
+// resolveProgram maps a DEX alias to its program ID; other values are returned trimmed.
+func resolveProgram(program string) string {
+	program = strings.TrimSpace(program)
+	if id, ok := dexAliases[strings.ToLower(program)]; ok {
+		return id
 	}
+	return program
+}
 
-	// ClickHouse (after migrations)
-	chConn, err := migrations.RunClickhouseMigrations(ctx, clickhouseDSN)
-	if err != nil {
-		pool.Close()
-		return nil, nil, fmt.Errorf("clickhouse migrations: %w", err)
-	}
+// largeGapBackfill is the gap backfill length from which its estimated cost is logged first.
+const largeGapBackfill = time.Hour
 
-	stores := &allStores{
-		// PostgreSQL stores (source data + trade_records)
-		candidateStore:      pgstore.NewCandidateStore(pool),
-		swapStore:           pgstore.NewSwapStore(pool),
-		swapEventStore:      pgstore.NewSwapEventStore(pool),
-		liquidityEventStore: pgstore.NewLiquidityEventStore(pool),
-		tradeRecordStore:    pgstore.NewTradeRecordStore(pool),
-		metadataStore:       pgstore.NewTokenMetadataStore(pool),
+// serverNeeds are the stores the server reads and writes; trade aggregates
+// are only named in metrics_queries.sql, never read through a store.
+const serverNeeds = factory.NeedAll &^ (factory.NeedTradeAggregates | factory.NeedSlotCheckpoints | factory.NeedReparse)
 
-		// ClickHouse stores (analytics)
-		priceTimeseriesStore:     chstore.NewPriceTimeseriesStore(chConn),
-		liquidityTimeseriesStore: chstore.NewLiquidityTimeseriesStore(chConn),
-		volumeTimeseriesStore:    chstore.NewVolumeTimeseriesStore(chConn),
-		derivedFeatureStore:      chstore.NewDerivedFeatureStore(chConn),
-		strategyAggregateStore:   chstore.NewStrategyAggregateStore(chConn),
+// createStores creates all required stores.
+// If instrument is set, every store is wrapped with latency and error metrics.
+func createStores(ctx context.Context, postgresDSN, clickhouseDSN string, useMemory, instrument bool) (*factory.Stores, func(), error) {
+	cfg := factory.Config{
+		Backend:       factory.BackendPostgres,
+		PostgresDSN:   postgresDSN,
+		ClickHouseDSN: clickhouseDSN,
+		Needs:         serverNeeds,
+		Instrument:    instrument,
 	}
-
-	cleanup := func() {
-		chConn.Close()
-		pool.Close()
+	if useMemory {
+		cfg.Backend = factory.BackendMemory
 	}
+	return factory.BuildStores(ctx, cfg)
+}
 
-	return stores, cleanup, nil
+// withDirtyTracking wraps the swap and liquidity event stores so that events
+// stored inside an existing trade window mark their candidate dirty.
+func withDirtyTracking(s *factory.Stores, logger *log.Logger) *factory.Stores {
+	tracker := tracked.NewTracker(s.Trades, s.DirtyCandidates).WithLogger(logger)
+	out := *s
+	out.Swaps = tracked.NewSwapStore(s.Swaps, tracker)
+	out.LiquidityEvents = tracked.NewLiquidityEventStore(s.LiquidityEvents, tracker)
+	return &out
 }
 
 // Run starts the unified server with all components.
@@ -296,7 +401,7 @@ func (s *Server) Run(ctx context.Context) error {
 	s.logger.Println("Starting unified server...")
 
 	// Create error channel for goroutines
-	errCh := make(chan error, 3)
+	errCh := make(chan error, 5)
 
 	// Start ingestion in background
 	go func() {
@@ -306,6 +411,9 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Publish the sufficiency status persisted by earlier runs
+	s.recordLatestSufficiency(ctx)
+
 	// Start pipeline scheduler in background
 	go func() {
 		err := s.runPipelineScheduler(ctx)
@@ -322,6 +430,26 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Start holder enrichment in background
+	if s.holderInterval > 0 {
+		go func() {
+			err := s.runHolderEnrichment(ctx)
+			if err != nil && err != context.Canceled {
+				errCh <- fmt.Errorf("holder enrichment: %w", err)
+			}
+		}()
+	}
+
+	// Start finality verification in background
+	if s.finalityInterval > 0 {
+		go func() {
+			err := s.runFinalityVerification(ctx)
+			if err != nil && err != context.Canceled {
+				errCh <- fmt.Errorf("finality verification: %w", err)
+			}
+		}()
+	}
+
 	// Wait for context cancellation or error
 	select {
 	case <-ctx.Done():
@@ -331,51 +459,119 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 }
 
+// wsEndpoints returns the primary WebSocket endpoint followed by the failover ones.
+func (s *Server) wsEndpoints() []string {
+	return append([]string{s.wsEndpoint}, s.wsFailover...)
+}
+
 // runIngestion runs continuous data ingestion.
 func (s *Server) runIngestion(ctx context.Context) error {
 	s.logger.Println("Starting ingestion...")
 
 	// Create RPC client
-	rpc := solana.NewHTTPClient(s.rpcEndpoint)
+	rpc := solana.NewHTTPClient(s.rpcEndpoint, s.rpcOpts...)
 
 	// Create SEPARATE WebSocket clients for swap and liquidity
 	// This is required because Helius deduplicates subscriptions to the same program
 	// on the same connection, returning the same subscription ID which causes
 	// the second subscriber to overwrite the first one's channel
-	wsSwap, err := solana.NewWSClient(ctx, s.wsEndpoint, nil)
+	wsSwap, err := solana.NewWSClientWithEndpoints(ctx, s.wsEndpoints(), nil)
 	if err != nil {
 		return fmt.Errorf("create websocket client for swaps: %w", err)
 	}
 	defer wsSwap.Close()
 
-	wsLiquidity, err := solana.NewWSClient(ctx, s.wsEndpoint, nil)
+	wsLiquidity, err := solana.NewWSClientWithEndpoints(ctx, s.wsEndpoints(), nil)
 	if err != nil {
 		return fmt.Errorf("create websocket client for liquidity: %w", err)
 	}
 	defer wsLiquidity.Close()
 
+	// Failed transactions are counted per program by every source
+	failedTx := ingestion.NewFailedTxTracker(s.stores.FailedTx).WithRecorder(observability.RecordTransactionObserved)
+
 	// Create sources with separate WebSocket clients
-	wsSwapSource := ingestion.NewWSSwapEventSource(wsSwap, rpc, s.programs)
-	wsLiquiditySource := ingestion.NewWSLiquidityEventSourceWithStore(wsLiquidity, rpc, s.programs, s.stores.candidateStore)
+	wsSwapSource := ingestion.NewWSSwapEventSource(wsSwap, rpc, s.programs).WithFailedTxTracker(failedTx)
+	wsLiquiditySource := ingestion.NewWSLiquidityEventSourceWithStore(wsLiquidity, rpc, s.programs, s.stores.Candidates).
+		WithFailedTxTracker(failedTx)
 	metadataSource := ingestion.NewRPCMetadataSource(rpc)
 
 	// Create detectors
-	newTokenDetector := discovery.NewDetector(s.stores.candidateStore)
-	activeDetector := discovery.NewActiveDetector(discovery.DefaultActiveConfig(), s.stores.swapEventStore, s.stores.candidateStore)
+	newTokenDetector := discovery.NewDetector(s.stores.Candidates).
+		WithLiveClock(time.Now, observability.RecordDiscoveryLatency).
+		WithMintFilter(s.mintFilter)
+	activeDetector := discovery.NewActiveDetector(discovery.DefaultActiveConfig(), s.stores.SwapEvents, s.stores.Candidates).
+		WithLiveClock(time.Now, observability.RecordDiscoveryLatency).
+		WithMintFilter(s.mintFilter)
+
+	// PRE_POOL discovery subscribes to the token programs on its own connection
+	var wsMintSource *ingestion.WSMintCreationSource
+	var prePoolTracker *discovery.PrePoolTracker
+	if s.prePoolDiscovery {
+		wsMint, err := solana.NewWSClientWithEndpoints(ctx, s.wsEndpoints(), nil)
+		if err != nil {
+			return fmt.Errorf("create websocket client for mint creations: %w", err)
+		}
+		defer wsMint.Close()
+
+		wsMintSource = ingestion.NewWSMintCreationSource(wsMint, rpc)
+		prePoolTracker = discovery.NewPrePoolTracker(s.stores.ProvisionalMints, s.stores.Candidates, s.prePoolTTL).
+			WithLiveClock(time.Now).
+			WithMintFilter(s.mintFilter)
+	}
+
+	// Starved windows are backfilled over RPC after an endpoint switch
+	var gapBackfill ingestion.GapBackfillFunc
+	if s.wsHealth.Enabled() {
+		backfiller := ingestion.NewBackfiller(ingestion.BackfillOptions{
+			RPC:              rpc,
+			SwapSource:       ingestion.NewRPCSwapEventSource(rpc, s.programs).WithFailedTxTracker(failedTx),
+			LiquiditySource:  ingestion.NewRPCLiquidityEventSource(rpc, s.programs, s.stores.Candidates).WithPoolLinks(s.stores.PoolLinks).WithFailedTxTracker(failedTx),
+			SwapEventStore:   s.stores.SwapEvents,
+			LiquidityStore:   s.stores.LiquidityEvents,
+			CandidateStore:   s.stores.Candidates,
+			NewTokenDetector: discovery.NewDetector(s.stores.Candidates).WithMintFilter(s.mintFilter),
+			FailedTx:         failedTx,
+			Logger:           log.New(os.Stdout, "[gap-backfill] ", log.LstdFlags|log.Lshortfile),
+		})
+		gapBackfill = func(ctx context.Context, from, to time.Time) error {
+			// Log the cost of a long catch-up before starting it
+			if to.Sub(from) >= largeGapBackfill {
+				if est, err := backfiller.EstimateRange(ctx, from, to, ingestion.EstimateOptions{}); err != nil {
+					s.logger.Printf("Gap backfill estimate error: %v", err)
+				} else {
+					s.logger.Printf("Gap backfill of %s: ~%d requests (%d transactions), ~%s",
+						to.Sub(from).Round(time.Minute), est.Requests, est.TransactionRequests, est.Duration.Round(time.Minute))
+				}
+			}
+			_, err := backfiller.BackfillRange(ctx, from, to)
+			return err
+		}
+	}
 
 	// Create runner
 	runner := ingestion.NewRunner(ingestion.RunnerOptions{
 		WSSwapSource:      wsSwapSource,
 		WSLiquiditySource: wsLiquiditySource,
+		WSMintSource:      wsMintSource,
 		MetadataSource:    metadataSource,
-		SwapEventStore:    s.stores.swapEventStore,
-		LiquidityStore:    s.stores.liquidityEventStore,
-		MetadataStore:     s.stores.metadataStore,
-		CandidateStore:    s.stores.candidateStore,
+		SwapEventStore:    s.stores.SwapEvents,
+		LiquidityStore:    s.stores.LiquidityEvents,
+		MetadataStore:     s.stores.TokenMetadata,
+		CandidateStore:    s.stores.Candidates,
+		Watchlist:         s.stores.Watchlist,
 		NewTokenDetector:  newTokenDetector,
 		ActiveDetector:    activeDetector,
+		PrePoolTracker:    prePoolTracker,
+		EventCaps:         s.eventCaps,
+		EventCountStore:   s.stores.EventCounts,
+		PoolLinkStore:     s.stores.PoolLinks,
+		FailedTx:          failedTx,
 		CheckInterval:     s.checkInterval,
 		Logger:            log.New(os.Stdout, "[ingestion] ", log.LstdFlags|log.Lshortfile),
+		WSHealth:          s.wsHealth,
+		GapBackfill:       gapBackfill,
+		OnEndpointSwitch:  observability.RecordWSEndpointSwitch,
 	})
 
 	s.mu.Lock()
@@ -387,33 +583,80 @@ func (s *Server) runIngestion(ctx context.Context) error {
 	return runner.Run(ctx)
 }
 
+// runHolderEnrichment snapshots holder concentration for recently active candidates on schedule.
+func (s *Server) runHolderEnrichment(ctx context.Context) error {
+	s.logger.Printf("Starting holder enrichment (interval: %v, active window: %v)...", s.holderInterval, s.holderActiveWindow)
+
+	enricher := ingestion.NewHolderEnricher(ingestion.HolderEnricherOptions{
+		Source:          solana.NewHTTPClient(s.rpcEndpoint, s.rpcOpts...),
+		CandidateStore:  s.stores.Candidates,
+		SwapEventStore:  s.stores.SwapEvents,
+		SnapshotStore:   s.stores.HolderSnapshots,
+		Watchlist:       s.stores.Watchlist,
+		Interval:        s.holderInterval,
+		ActiveWindow:    s.holderActiveWindow,
+		MinMintInterval: s.holderMinMintInterval,
+		Logger:          log.New(os.Stdout, "[holders] ", log.LstdFlags|log.Lshortfile),
+	})
+
+	return enricher.Run(ctx)
+}
+
+// runFinalityVerification removes events whose transactions never finalized, on schedule.
+func (s *Server) runFinalityVerification(ctx context.Context) error {
+	s.logger.Printf("Starting finality verification (interval: %v, lookback: %v)...", s.finalityInterval, s.finalityLookback)
+
+	verifier := ingestion.NewFinalityVerifier(ingestion.FinalityVerifierOptions{
+		Source:   solana.NewHTTPClient(s.rpcEndpoint, s.rpcOpts...),
+		Store:    s.stores.Finality,
+		Interval: s.finalityInterval,
+		Lookback: s.finalityLookback,
+		Logger:   log.New(os.Stdout, "[finality] ", log.LstdFlags|log.Lshortfile),
+	})
+
+	return verifier.Run(ctx)
+}
+
 // runPipelineScheduler runs pipeline on schedule.
 func (s *Server) runPipelineScheduler(ctx context.Context) error {
 	s.logger.Printf("Starting pipeline scheduler (interval: %v)...", s.pipelineInterval)
 
 	// Run immediately on start
-	s.runPipeline(ctx)
+	s.runPipeline(ctx, domain.ScenarioPresetMonitoring)
 
 	ticker := time.NewTicker(s.pipelineInterval)
 	defer ticker.Stop()
+	s.scheduleNext(&s.nextPipelineRun, s.pipelineInterval)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			s.runPipeline(ctx)
+			s.scheduleNext(&s.nextPipelineRun, s.pipelineInterval)
+			s.runPipeline(ctx, domain.ScenarioPresetMonitoring)
 		}
 	}
 }
 
-// runPipeline executes the processing pipeline.
-func (s *Server) runPipeline(ctx context.Context) {
+// scheduleNext records when a scheduler runs next, for /status.
+func (s *Server) scheduleNext(next *time.Time, after time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*next = time.Now().Add(after)
+}
+
+// errPipelineBusy is returned by runPipeline while another run is in progress.
+var errPipelineBusy = errors.New("pipeline already running")
+
+// runPipeline executes the processing pipeline, simulating the scenarios of
+// preset: monitoring for scheduled runs, decision before reports.
+func (s *Server) runPipeline(ctx context.Context, preset string) error {
 	s.mu.Lock()
 	if s.pipelineRunning {
 		s.mu.Unlock()
 		s.logger.Println("Pipeline already running, skipping...")
-		return
+		return errPipelineBusy
 	}
 	s.pipelineRunning = true
 	s.mu.Unlock()
@@ -426,36 +669,104 @@ func (s *Server) runPipeline(ctx context.Context) {
 		s.mu.Unlock()
 	}()
 
-	s.logger.Println("Running pipeline...")
+	s.logger.Printf("Running pipeline (%s scenarios)...", preset)
 	start := time.Now()
+	config := maps.Clone(s.config)
+	config["scenario-preset"] = preset
+	manifest := pipeline.NewRunManifest(storage.RunKindPipeline, start, config, s.stores.Backends)
 
 	// Create orchestrator
 	orch := orchestrator.New(orchestrator.Options{
-		CandidateStore:           s.stores.candidateStore,
-		SwapStore:                s.stores.swapStore,
-		LiquidityEventStore:      s.stores.liquidityEventStore,
-		PriceTimeseriesStore:     s.stores.priceTimeseriesStore,
-		LiquidityTimeseriesStore: s.stores.liquidityTimeseriesStore,
-		VolumeTimeseriesStore:    s.stores.volumeTimeseriesStore,
-		DerivedFeatureStore:      s.stores.derivedFeatureStore,
-		TradeRecordStore:         s.stores.tradeRecordStore,
-		StrategyAggregateStore:   s.stores.strategyAggregateStore,
+		CandidateStore:           s.stores.Candidates,
+		SwapStore:                s.stores.Swaps,
+		LiquidityEventStore:      s.stores.LiquidityEvents,
+		PriceTimeseriesStore:     s.stores.PriceTimeseries,
+		LiquidityTimeseriesStore: s.stores.LiquidityTimeseries,
+		VolumeTimeseriesStore:    s.stores.VolumeTimeseries,
+		DerivedFeatureStore:      s.stores.DerivedFeatures,
+		TradeRecordStore:         s.stores.Trades,
+		StrategyAggregateStore:   s.stores.StrategyAggregates,
+		DirtyCandidateStore:      s.stores.DirtyCandidates,
+		EventCountStore:          s.stores.EventCounts,
+		Incremental:              s.incremental,
 		StrategyConfigs:          createStrategyConfigs(),
 		ScenarioConfigs:          createScenarioConfigs(),
+		ScenarioPreset:           preset,
+		DataRequirements:         orchestrator.DefaultDataRequirements(orchestrator.DefaultMinDataPoints),
+		SimulationWindowMarginMs: simulation.DefaultWindowMarginMs,
+		OnTimeseriesLoad:         observability.RecordTimeseriesLoad,
 		Verbose:                  true,
+		OnSimulationProgress: func(p orchestrator.SimulationProgress) {
+			observability.UpdateSimulationProgress(p.CandidatesDone, p.CandidatesTotal, p.TradesWritten)
+		},
 	})
 
 	result, err := orch.Run(ctx)
+	pipeline.RecordOrchestratorRun(manifest, result, err)
+	if mErr := pipeline.WriteRunManifest(ctx, pipeline.DirWriter(s.outputDir), s.stores.RunManifests, manifest); mErr != nil {
+		s.logger.Printf("Run manifest error: %v", mErr)
+	}
 	if err != nil {
 		s.logger.Printf("Pipeline error: %v", err)
 		observability.RecordPipelineRun("orchestrator", "error", time.Since(start).Seconds())
-		return
+		return err
 	}
 
 	s.logger.Printf("Pipeline completed in %v: %d candidates, %d trades, %d aggregates",
 		time.Since(start), result.CandidatesProcessed, result.TradesCreated, result.AggregatesCreated)
 
 	observability.RecordPipelineRun("orchestrator", "success", time.Since(start).Seconds())
+
+	s.writePreview(ctx)
+	return nil
+}
+
+// runFullPipeline runs the pipeline with the decision preset, so the report
+// that follows has the full scenario matrix. A scheduled run in progress is
+// waited for rather than skipped.
+func (s *Server) runFullPipeline(ctx context.Context) error {
+	for {
+		err := s.runPipeline(ctx, domain.ScenarioPresetDecision)
+		if !errors.Is(err, errPipelineBusy) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(30 * time.Second):
+		}
+	}
+}
+
+// writePreview renders the report preview of the aggregates just written to
+// <outputDir>/preview/ and serves it at /api/v1/preview, so fresh results are
+// visible before the next scheduled report. Failures are logged only.
+func (s *Server) writePreview(ctx context.Context) {
+	preview, err := reporting.NewGenerator(
+		s.stores.Candidates,
+		s.stores.Trades,
+		s.stores.StrategyAggregates,
+	).GeneratePreview(ctx)
+	if err != nil {
+		s.logger.Printf("Report preview error: %v", err)
+		return
+	}
+	if err := pipeline.WritePreview(pipeline.DirWriter(s.outputDir), preview); err != nil {
+		s.logger.Printf("Report preview error: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.latestPreview = preview
+	s.mu.Unlock()
+	s.logger.Printf("Report preview written to %s/%s/", s.outputDir, pipeline.PreviewDir)
+}
+
+// preview returns the latest report preview, or nil before the first one.
+func (s *Server) preview() *reporting.Preview {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latestPreview
 }
 
 // runReportScheduler runs report generation on schedule.
@@ -463,6 +774,7 @@ func (s *Server) runReportScheduler(ctx context.Context) error {
 	s.logger.Printf("Starting report scheduler (interval: %v)...", s.reportInterval)
 
 	// Wait for first pipeline run before generating reports
+	s.scheduleNext(&s.nextReportRun, s.pipelineInterval+1*time.Minute)
 	time.Sleep(s.pipelineInterval + 1*time.Minute)
 
 	// Run immediately after first pipeline
@@ -470,19 +782,27 @@ func (s *Server) runReportScheduler(ctx context.Context) error {
 
 	ticker := time.NewTicker(s.reportInterval)
 	defer ticker.Stop()
+	s.scheduleNext(&s.nextReportRun, s.reportInterval)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
+			s.scheduleNext(&s.nextReportRun, s.reportInterval)
 			s.runReport(ctx)
 		}
 	}
 }
 
-// runReport generates reports.
+// runReport generates reports after a full pipeline run. If that run fails the
+// report is generated from the stored aggregates; strategies missing a scenario
+// the decision gate needs are reported as insufficient data.
 func (s *Server) runReport(ctx context.Context) {
+	if err := s.runFullPipeline(ctx); err != nil {
+		s.logger.Printf("Full pipeline run before report failed: %v", err)
+	}
+
 	s.mu.Lock()
 	if s.reportRunning {
 		s.mu.Unlock()
@@ -518,56 +838,83 @@ func (s *Server) runReport(ctx context.Context) {
 
 	// Create aggregator
 	aggregator := metrics.NewAggregator(
-		s.stores.tradeRecordStore,
-		s.stores.strategyAggregateStore,
-		s.stores.candidateStore,
+		s.stores.Trades,
+		s.stores.StrategyAggregates,
+		s.stores.Candidates,
 	)
 
-	// Define implementable strategies
-	implementable := map[decision.StrategyKey]bool{
-		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:          true,
-		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN"}:       true,
-		{StrategyID: "TRAILING_STOP", EntryEventType: "NEW_TOKEN"}:      true,
-		{StrategyID: "TRAILING_STOP", EntryEventType: "ACTIVE_TOKEN"}:   true,
-		{StrategyID: "LIQUIDITY_GUARD", EntryEventType: "NEW_TOKEN"}:    true,
-		{StrategyID: "LIQUIDITY_GUARD", EntryEventType: "ACTIVE_TOKEN"}: true,
-	}
+	// Implementability follows the feeds this server runs: ingestion subscribes
+	// to swaps and liquidity over the same WebSocket endpoint
+	implementability := decision.ResolveImplementability(decision.Deployment{
+		RealtimePriceFeed: s.wsEndpoint != "",
+		LiquidityFeed:     s.wsEndpoint != "",
+	})
 
 	// Create replay runner
-	replayRunner := replay.NewRunner(s.stores.swapStore, s.stores.liquidityEventStore)
+	replayRunner := replay.NewRunner(s.stores.Swaps, s.stores.LiquidityEvents)
 
 	// Create pipeline
 	p := pipeline.NewPhase1Pipeline(
-		s.stores.candidateStore,
-		s.stores.tradeRecordStore,
-		s.stores.strategyAggregateStore,
-		implementable,
+		s.stores.Candidates,
+		s.stores.Trades,
+		s.stores.StrategyAggregates,
+		nil,
 		s.outputDir,
-	).WithSufficiencyChecker(
-		s.stores.candidateStore,
-		s.stores.tradeRecordStore,
-		s.stores.swapStore,
-		s.stores.liquidityEventStore,
-		replayRunner,
-	).WithAggregator(aggregator)
+	).WithImplementability(implementability).
+		WithSufficiencyChecker(
+			s.stores.Candidates,
+			s.stores.Trades,
+			s.stores.Swaps,
+			s.stores.LiquidityEvents,
+			replayRunner,
+		).WithSwapEventStore(s.stores.SwapEvents).
+		WithFinalityStore(s.stores.Finality).
+		WithEventCountStore(s.stores.EventCounts).
+		WithMetadataStore(s.stores.TokenMetadata).
+		WithFailedTxStore(s.stores.FailedTx).
+		WithSufficiencyRunStore(s.stores.SufficiencyRuns).
+		WithAggregator(aggregator).
+		WithMintFilterHash(s.mintFilter.Hash()).
+		WithMinLiveShare(s.minLiveShare).
+		WithMinMetadataCoverage(s.minMetadataCoverage).
+		WithMetricsQueryTables(s.stores.MetricsTables).
+		// Report runs gate the decision: they publish every artifact. Interval
+		// pipeline runs write only the preview (see writePreview).
+		WithArtifacts(pipeline.ArtifactProfile(pipeline.ArtifactProfileFull)).
+		WithRunManifest(s.stores.RunManifests, s.config, s.stores.Backends)
 
 	// Set data source based on mode
 	if s.useMemory {
 		p = p.WithDataSource("unified-server")
 	} else {
 		p = p.WithDBSource(s.postgresDSN, s.clickhouseDSN).
-			WithRawDataStores(s.stores.candidateStore, s.stores.priceTimeseriesStore, s.stores.liquidityTimeseriesStore)
+			WithRawDataStores(s.stores.Candidates, s.stores.PriceTimeseries, s.stores.LiquidityTimeseries)
 	}
 
 	// Run reporting pipeline
-	if err := p.Run(ctx); err != nil {
+	report, err := p.RunReport(ctx)
+	if err != nil {
 		s.logger.Printf("Report generation error: %v", err)
 		return
 	}
+	observability.RecordReportOutcome(report)
+	s.recordLatestSufficiency(ctx)
 
 	s.logger.Printf("Reports generated in %v to %s/", time.Since(start), s.outputDir)
 }
 
+// recordLatestSufficiency publishes the latest stored sufficiency run as metrics.
+func (s *Server) recordLatestSufficiency(ctx context.Context) {
+	run, err := s.stores.SufficiencyRuns.GetLatest(ctx)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.logger.Printf("Sufficiency metrics error: %v", err)
+		}
+		return
+	}
+	observability.RecordSufficiencyRun(run)
+}
+
 // startHTTPServer starts the HTTP server for health/metrics/status.
 func (s *Server) startHTTPServer(addr string) {
 	mux := http.NewServeMux()
@@ -584,8 +931,58 @@ func (s *Server) startHTTPServer(addr string) {
 	// Status endpoint
 	mux.HandleFunc("/status", s.handleStatus)
 
+	// Read-only REST API: candidates, dossiers, trades, aggregates, candidate stream, report preview, catalog
+	apiHandler := api.NewHandler(api.Stores{
+		Candidates:          s.stores.Candidates,
+		Trades:              s.stores.Trades,
+		Aggregates:          s.stores.StrategyAggregates,
+		Watchlist:           s.stores.Watchlist,
+		HolderSnapshots:     s.stores.HolderSnapshots,
+		Metadata:            s.stores.TokenMetadata,
+		Swaps:               s.stores.Swaps,
+		LiquidityEvents:     s.stores.LiquidityEvents,
+		PriceTimeseries:     s.stores.PriceTimeseries,
+		LiquidityTimeseries: s.stores.LiquidityTimeseries,
+		Sufficiency:         s.stores.SufficiencyRuns,
+		SwapEvents:          s.stores.SwapEvents,
+		RunManifests:        s.stores.RunManifests,
+	}).WithPreview(s.preview).
+		WithCatalog(domain.NewCatalog(pipeline.StrategyVersion))
+	apiHandler.Register(mux)
+
+	// Candidate details with latest holder snapshot (pre-/api/v1 path)
+	mux.Handle("GET /candidates/{id}", apiHandler.CandidateHandler())
+
+	// Watchlist administration
+	mux.HandleFunc("GET /admin/watchlist", s.handleWatchlistList)
+	mux.HandleFunc("POST /admin/watchlist", s.handleWatchlistAdd)
+	mux.HandleFunc("DELETE /admin/watchlist/{mint}", s.handleWatchlistRemove)
+
+	// Runtime DEX program management
+	mux.HandleFunc("POST /admin/programs", s.handleProgramAdd)
+	mux.HandleFunc("DELETE /admin/programs/{program}", s.handleProgramRemove)
+
+	// Discovery mint blacklist/allowlist, effective for the next discovery
+	mux.HandleFunc("GET /admin/mint-filter", s.handleMintFilterGet)
+	mux.HandleFunc("POST /admin/mint-filter/{list}", s.handleMintFilterAdd)
+	mux.HandleFunc("DELETE /admin/mint-filter/{list}/{address}", s.handleMintFilterRemove)
+
+	// Force a metadata refetch for one token, rate-limited and audit-logged
+	mux.HandleFunc("POST /admin/metadata/refresh", s.handleMetadataRefresh)
+
+	// Soft-delete junk candidates, audit-logged; trades stay but leave checks and metrics
+	mux.HandleFunc("GET /admin/candidates/deletions", s.handleCandidateDeletions)
+	mux.HandleFunc("POST /admin/candidates/{id}/delete", s.handleCandidateSoftDelete)
+
+	// Request metrics, request IDs, access logs, panic recovery and body limits for every route
+	handler := api.Middleware(mux, api.MiddlewareOptions{
+		Logger:   s.logger,
+		Record:   observability.RecordHTTPRequest,
+		InFlight: observability.AddHTTPInFlight,
+	})
+
 	s.logger.Printf("Starting HTTP server on %s", addr)
-	if err := http.ListenAndServe(addr, mux); err != nil && err != http.ErrServerClosed {
+	if err := http.ListenAndServe(addr, handler); err != nil && err != http.ErrServerClosed {
 		s.logger.Printf("HTTP server error: %v", err)
 	}
 }
@@ -597,14 +994,70 @@ type StatusResponse struct {
 	IngestionStarted time.Time `json:"ingestion_started"`
 	LastPipelineRun  time.Time `json:"last_pipeline_run,omitempty"`
 	LastReportRun    time.Time `json:"last_report_run,omitempty"`
+	NextPipelineRun  time.Time `json:"next_pipeline_run,omitempty"`
+	NextReportRun    time.Time `json:"next_report_run,omitempty"`
 	PipelineRuns     int       `json:"pipeline_runs"`
 	ReportRuns       int       `json:"report_runs"`
 	PipelineRunning  bool      `json:"pipeline_running"`
 	ReportRunning    bool      `json:"report_running"`
+	DirtyCandidates  int       `json:"dirty_candidates"` // awaiting re-simulation
+
+	// API paths of the manifests of the latest pipeline and report runs
+	LatestPipelineManifest string `json:"latest_pipeline_manifest,omitempty"`
+	LatestReportManifest   string `json:"latest_report_manifest,omitempty"`
+
+	Programs []ProgramStatusResponse `json:"programs,omitempty"`
+
+	// Ingestion counters since startup, including the WS health probe
+	Ingestion *IngestionStatusResponse `json:"ingestion,omitempty"`
+
+	// Schema version detected at startup per database
+	Schema []SchemaStatusResponse `json:"schema,omitempty"`
+}
+
+// SchemaStatusResponse holds the schema check of one database.
+type SchemaStatusResponse struct {
+	Backend  string `json:"backend"`
+	Version  string `json:"version"`          // last migration applied
+	Expected string `json:"expected_version"` // last migration of this binary
+}
+
+// ProgramStatusResponse holds ingestion counters for one DEX program.
+type ProgramStatusResponse struct {
+	Program       string `json:"program"`
+	Active        bool   `json:"active"`
+	Events        int64  `json:"events"`
+	Candidates    int64  `json:"candidates"`
+	ParseFailures int64  `json:"parse_failures"`
+	Duplicates    int64  `json:"duplicates_suppressed"`
+	Transactions  int64  `json:"transactions"`            // observed, failed ones included
+	FailedTxs     int64  `json:"failed_transactions"`     // failed on chain, skipped
+	LastEventAt   int64  `json:"last_event_at,omitempty"` // Unix ms when the latest event was received
+}
+
+// IngestionStatusResponse holds ingestion counters since startup.
+type IngestionStatusResponse struct {
+	SwapEvents             int64 `json:"swap_events"`
+	LiquidityEvents        int64 `json:"liquidity_events"`
+	NewTokens              int64 `json:"new_tokens_discovered"`
+	ActiveTokens           int64 `json:"active_tokens_discovered"`
+	WSHealthProbe          bool  `json:"ws_health_probe"`      // starvation probe enabled
+	WSEndpointSwitches     int64 `json:"ws_endpoint_switches"` // failed ones included
+	WSEndpointSwitchErrors int64 `json:"ws_endpoint_switch_errors"`
+	GapBackfills           int64 `json:"gap_backfills"`
+	GapBackfillErrors      int64 `json:"gap_backfill_errors"`
 }
 
 // handleStatus returns server status as JSON.
 func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	// Queried before taking the lock so a slow store does not block the pipeline
+	dirty, err := s.stores.DirtyCandidates.Count(r.Context())
+	if err != nil {
+		s.logger.Printf("Dirty candidate count error: %v", err)
+	}
+	pipelineManifest := s.latestManifestPath(r.Context(), storage.RunKindPipeline)
+	reportManifest := s.latestManifestPath(r.Context(), storage.RunKindReport)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -614,16 +1067,295 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 		IngestionStarted: s.ingestionStarted,
 		LastPipelineRun:  s.lastPipelineRun,
 		LastReportRun:    s.lastReportRun,
+		NextPipelineRun:  s.nextPipelineRun,
+		NextReportRun:    s.nextReportRun,
 		PipelineRuns:     s.pipelineRuns,
 		ReportRuns:       s.reportRuns,
 		PipelineRunning:  s.pipelineRunning,
 		ReportRunning:    s.reportRunning,
+		DirtyCandidates:  dirty,
+
+		LatestPipelineManifest: pipelineManifest,
+		LatestReportManifest:   reportManifest,
+	}
+	for _, status := range s.stores.Schema {
+		resp.Schema = append(resp.Schema, SchemaStatusResponse{
+			Backend:  status.Backend,
+			Version:  status.Version,
+			Expected: status.Expected,
+		})
+	}
+	if s.ingestionRunner != nil {
+		for _, ps := range s.ingestionRunner.ProgramStats() {
+			resp.Programs = append(resp.Programs, ProgramStatusResponse{
+				Program:       ps.Program,
+				Active:        ps.Active,
+				Events:        ps.Events,
+				Candidates:    ps.Candidates,
+				ParseFailures: ps.ParseFailures,
+				Duplicates:    ps.Duplicates,
+				Transactions:  ps.Transactions,
+				FailedTxs:     ps.FailedTxs,
+				LastEventAt:   ps.LastEventAt,
+			})
+		}
+		stats := s.ingestionRunner.Stats()
+		resp.Ingestion = &IngestionStatusResponse{
+			SwapEvents:             stats.SwapEventsProcessed,
+			LiquidityEvents:        stats.LiquidityEventsProcessed,
+			NewTokens:              stats.NewTokensDiscovered,
+			ActiveTokens:           stats.ActiveTokensDiscovered,
+			WSHealthProbe:          s.wsHealth.Enabled(),
+			WSEndpointSwitches:     stats.WSEndpointSwitches,
+			WSEndpointSwitchErrors: stats.WSEndpointSwitchErrors,
+			GapBackfills:           stats.GapBackfills,
+			GapBackfillErrors:      stats.GapBackfillErrors,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// latestManifestPath returns the API path of the manifest of the latest run of
+// kind, empty before the first run.
+func (s *Server) latestManifestPath(ctx context.Context, kind string) string {
+	m, err := s.stores.RunManifests.GetLatest(ctx, kind)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.logger.Printf("Latest %s manifest error: %v", kind, err)
+		}
+		return ""
+	}
+	return "/api/v1/runs/" + url.PathEscape(m.RunID)
+}
+
+// WatchlistEntryRequest is the JSON body for POST /admin/watchlist.
+type WatchlistEntryRequest struct {
+	Mint   string `json:"mint"`
+	Reason string `json:"reason"`
+}
+
+// WatchlistEntryResponse is a single watchlist entry in admin responses.
+type WatchlistEntryResponse struct {
+	Mint    string `json:"mint"`
+	Reason  string `json:"reason"`
+	AddedAt int64  `json:"added_at"`
+}
+
+// handleWatchlistList returns all watchlisted mints ordered by mint.
+func (s *Server) handleWatchlistList(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.stores.Watchlist.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]WatchlistEntryResponse, len(entries))
+	for i, e := range entries {
+		resp[i] = WatchlistEntryResponse{Mint: e.Mint, Reason: e.Reason, AddedAt: e.AddedAt}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
+// handleWatchlistAdd puts a mint on the watchlist.
+func (s *Server) handleWatchlistAdd(w http.ResponseWriter, r *http.Request) {
+	var req WatchlistEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	req.Mint = strings.TrimSpace(req.Mint)
+	if req.Mint == "" {
+		http.Error(w, "mint is required", http.StatusBadRequest)
+		return
+	}
+
+	entry := &domain.WatchlistEntry{
+		Mint:    req.Mint,
+		Reason:  req.Reason,
+		AddedAt: time.Now().UnixMilli(),
+	}
+	if err := s.stores.Watchlist.Add(r.Context(), entry); err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			http.Error(w, "mint already watchlisted", http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.logger.Printf("Watchlist: added %s (%s)", entry.Mint, entry.Reason)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(WatchlistEntryResponse{Mint: entry.Mint, Reason: entry.Reason, AddedAt: entry.AddedAt})
+}
+
+// handleWatchlistRemove takes a mint off the watchlist.
+func (s *Server) handleWatchlistRemove(w http.ResponseWriter, r *http.Request) {
+	mint := r.PathValue("mint")
+	if err := s.stores.Watchlist.Remove(r.Context(), mint); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			http.Error(w, "mint not watchlisted", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.logger.Printf("Watchlist: removed %s", mint)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ProgramRequest is the JSON body for POST /admin/programs.
+// Program is a program ID or a DEX alias (raydium, pumpfun).
+type ProgramRequest struct {
+	Program string `json:"program"`
+}
+
+// runner returns the ingestion runner, or nil if ingestion has not started yet.
+func (s *Server) runner() *ingestion.Runner {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ingestionRunner
+}
+
+// handleProgramAdd starts ingesting a DEX program without a restart.
+func (s *Server) handleProgramAdd(w http.ResponseWriter, r *http.Request) {
+	var req ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	program := resolveProgram(req.Program)
+	if program == "" {
+		http.Error(w, "program is required", http.StatusBadRequest)
+		return
+	}
+
+	runner := s.runner()
+	if runner == nil {
+		http.Error(w, "ingestion not running", http.StatusServiceUnavailable)
+		return
+	}
+	if err := runner.AddProgram(r.Context(), program); err != nil {
+		if errors.Is(err, ingestion.ErrProgramMonitored) {
+			http.Error(w, "program already monitored", http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ProgramRequest{Program: program})
+}
+
+// handleProgramRemove stops ingesting a DEX program. Stored data is kept.
+func (s *Server) handleProgramRemove(w http.ResponseWriter, r *http.Request) {
+	program := resolveProgram(r.PathValue("program"))
+
+	runner := s.runner()
+	if runner == nil {
+		http.Error(w, "ingestion not running", http.StatusServiceUnavailable)
+		return
+	}
+	if err := runner.RemoveProgram(r.Context(), program); err != nil {
+		if errors.Is(err, ingestion.ErrProgramNotMonitored) {
+			http.Error(w, "program not monitored", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MintFilterResponse is the JSON response for GET /admin/mint-filter.
+type MintFilterResponse struct {
+	Blacklist discovery.MintList        `json:"blacklist"`
+	Allowlist discovery.MintList        `json:"allowlist"`
+	Hash      string                    `json:"hash"`
+	Filtered  discovery.MintFilterStats `json:"filtered"`
+}
+
+// MintFilterEntryRequest is the JSON body for POST /admin/mint-filter/{list}.
+// Authority marks Address as a token authority rather than a mint.
+type MintFilterEntryRequest struct {
+	Address   string `json:"address"`
+	Authority bool   `json:"authority"`
+}
+
+// handleMintFilterGet returns the mint lists, their hash and filtered discovery counts.
+func (s *Server) handleMintFilterGet(w http.ResponseWriter, r *http.Request) {
+	blacklist, allowlist := s.mintFilter.Lists()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MintFilterResponse{
+		Blacklist: blacklist,
+		Allowlist: allowlist,
+		Hash:      s.mintFilter.Hash(),
+		Filtered:  s.mintFilter.Stats(),
+	})
+}
+
+// handleMintFilterAdd puts a mint or authority on the blacklist or allowlist.
+func (s *Server) handleMintFilterAdd(w http.ResponseWriter, r *http.Request) {
+	var req MintFilterEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	req.Address = strings.TrimSpace(req.Address)
+	if req.Address == "" {
+		http.Error(w, "address is required", http.StatusBadRequest)
+		return
+	}
+
+	list := r.PathValue("list")
+	added, err := s.mintFilter.Add(list, req.Address, req.Authority)
+	if err != nil {
+		if errors.Is(err, discovery.ErrUnknownMintList) {
+			http.Error(w, "list must be blacklist or allowlist", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !added {
+		http.Error(w, "address already listed", http.StatusConflict)
+		return
+	}
+	s.logger.Printf("Mint filter: added %s to %s (authority=%v)", req.Address, list, req.Authority)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(req)
+}
+
+// handleMintFilterRemove takes a mint off a list, or an authority with ?authority=true.
+func (s *Server) handleMintFilterRemove(w http.ResponseWriter, r *http.Request) {
+	list, address := r.PathValue("list"), r.PathValue("address")
+	authority := r.URL.Query().Get("authority") == "true"
+
+	removed, err := s.mintFilter.Remove(list, address, authority)
+	if err != nil {
+		if errors.Is(err, discovery.ErrUnknownMintList) {
+			http.Error(w, "list must be blacklist or allowlist", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !removed {
+		http.Error(w, "address not listed", http.StatusNotFound)
+		return
+	}
+	s.logger.Printf("Mint filter: removed %s from %s (authority=%v)", address, list, authority)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // createStrategyConfigs returns all strategy configurations to simulate.
 func createStrategyConfigs() []domain.StrategyConfig {
 	// TIME_EXIT: 5 minute hold duration
@@ -718,3 +1450,167 @@ func loadEnvFile() {
 		}
 	}
 }
+
+// MetadataRefreshRequest is the JSON body for POST /admin/metadata/refresh.
+// CandidateID takes precedence over Mint.
+type MetadataRefreshRequest struct {
+	Mint        string `json:"mint,omitempty"`
+	CandidateID string `json:"candidate_id,omitempty"`
+}
+
+// MetadataResponse is the refreshed metadata returned by POST /admin/metadata/refresh.
+type MetadataResponse struct {
+	CandidateID     string   `json:"candidate_id"`
+	Mint            string   `json:"mint"`
+	Name            *string  `json:"name,omitempty"`
+	Symbol          *string  `json:"symbol,omitempty"`
+	URI             *string  `json:"uri,omitempty"`
+	Decimals        int      `json:"decimals"`
+	Supply          *float64 `json:"supply,omitempty"`
+	MintAuthority   *string  `json:"mint_authority,omitempty"`
+	FreezeAuthority *string  `json:"freeze_authority,omitempty"`
+	FetchedAt       int64    `json:"fetched_at"`
+}
+
+// AdminErrorResponse is the structured error of POST /admin/metadata/refresh
+// and the candidate soft-delete endpoints.
+type AdminErrorResponse struct {
+	Error   string `json:"error"` // invalid_request, unknown_mint, not_found, already_deleted, rate_limited, fetch_failed, internal
+	Message string `json:"message"`
+}
+
+// handleMetadataRefresh refetches the metadata of a mint or candidate and upserts it.
+func (s *Server) handleMetadataRefresh(w http.ResponseWriter, r *http.Request) {
+	var req MetadataRefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAdminError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body")
+		return
+	}
+	req.Mint = strings.TrimSpace(req.Mint)
+	req.CandidateID = strings.TrimSpace(req.CandidateID)
+	if req.Mint == "" && req.CandidateID == "" {
+		writeAdminError(w, http.StatusBadRequest, "invalid_request", "mint or candidate_id is required")
+		return
+	}
+
+	meta, err := s.metadataRefresher.Refresh(r.Context(), ingestion.MetadataRefreshRequest{
+		Mint:        req.Mint,
+		CandidateID: req.CandidateID,
+		Requester:   r.RemoteAddr,
+	})
+	switch {
+	case errors.Is(err, ingestion.ErrUnknownMint):
+		writeAdminError(w, http.StatusNotFound, "unknown_mint", err.Error())
+		return
+	case errors.Is(err, ingestion.ErrRefreshRateLimited):
+		w.Header().Set("Retry-After", "60")
+		writeAdminError(w, http.StatusTooManyRequests, "rate_limited", err.Error())
+		return
+	case errors.Is(err, ingestion.ErrMetadataFetch):
+		writeAdminError(w, http.StatusBadGateway, "fetch_failed", err.Error())
+		return
+	case err != nil:
+		writeAdminError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MetadataResponse{
+		CandidateID:     meta.CandidateID,
+		Mint:            meta.Mint,
+		Name:            meta.Name,
+		Symbol:          meta.Symbol,
+		URI:             meta.URI,
+		Decimals:        meta.Decimals,
+		Supply:          meta.Supply,
+		MintAuthority:   meta.MintAuthority,
+		FreezeAuthority: meta.FreezeAuthority,
+		FetchedAt:       meta.FetchedAt,
+	})
+}
+
+// CandidateDeleteRequest is the JSON body for POST /admin/candidates/{id}/delete.
+type CandidateDeleteRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CandidateDeletionResponse is one candidate_deletions audit entry.
+type CandidateDeletionResponse struct {
+	CandidateID string `json:"candidate_id"`
+	DeletedAt   int64  `json:"deleted_at"`
+	Reason      string `json:"reason"`
+	Requester   string `json:"requester"`
+}
+
+// handleCandidateSoftDelete soft-deletes a candidate. Its row and trades stay for
+// foreign keys and old reports; default reads, checks and metrics skip it.
+func (s *Server) handleCandidateSoftDelete(w http.ResponseWriter, r *http.Request) {
+	var req CandidateDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAdminError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		writeAdminError(w, http.StatusBadRequest, "invalid_request", "reason is required")
+		return
+	}
+
+	deletion := &storage.CandidateDeletion{
+		CandidateID: r.PathValue("id"),
+		DeletedAt:   time.Now().UnixMilli(),
+		Reason:      req.Reason,
+		Requester:   r.RemoteAddr,
+	}
+	err := s.stores.Candidates.SoftDelete(r.Context(), deletion)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		writeAdminError(w, http.StatusNotFound, "not_found", "candidate not found")
+		return
+	case errors.Is(err, storage.ErrDuplicateKey):
+		writeAdminError(w, http.StatusConflict, "already_deleted", "candidate is already deleted")
+		return
+	case errors.Is(err, storage.ErrInvalidInput):
+		writeAdminError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	case err != nil:
+		writeAdminError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	s.logger.Printf("Candidate soft-delete: requester=%q candidate_id=%q reason=%q",
+		deletion.Requester, deletion.CandidateID, deletion.Reason)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toCandidateDeletionResponse(deletion))
+}
+
+// handleCandidateDeletions lists the soft-delete audit log, oldest first.
+func (s *Server) handleCandidateDeletions(w http.ResponseWriter, r *http.Request) {
+	deletions, err := s.stores.Candidates.GetDeletions(r.Context())
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	resp := make([]CandidateDeletionResponse, 0, len(deletions))
+	for _, d := range deletions {
+		resp = append(resp, toCandidateDeletionResponse(d))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func toCandidateDeletionResponse(d *storage.CandidateDeletion) CandidateDeletionResponse {
+	return CandidateDeletionResponse{
+		CandidateID: d.CandidateID,
+		DeletedAt:   d.DeletedAt,
+		Reason:      d.Reason,
+		Requester:   d.Requester,
+	}
+}
+
+// writeAdminError writes a structured JSON error.
+func writeAdminError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(AdminErrorResponse{Error: code, Message: message})
+}
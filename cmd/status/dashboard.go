@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"solana-token-lab/pkg/client"
+)
+
+// clearScreen moves the cursor home and clears the terminal before a frame.
+const clearScreen = "\033[H\033[2J"
+
+// snapshot is one poll of the server.
+type snapshot struct {
+	Server    string
+	At        time.Time
+	Status    *client.Status
+	StatusErr error           // /status failed: the server is unreachable or unhealthy
+	Preview   *client.Preview // nil before the first pipeline run
+	Report    *client.Run     // latest report run, nil before the first one
+	Errors    []string        // failures of the optional endpoints
+}
+
+// fetchSnapshot polls /status, the report preview and the latest report run.
+// A missing preview or report run is not an error.
+func fetchSnapshot(ctx context.Context, c *client.Client, now time.Time) *snapshot {
+	snap := &snapshot{At: now}
+	snap.Status, snap.StatusErr = c.GetStatus(ctx)
+	if snap.StatusErr != nil {
+		return snap
+	}
+
+	preview, err := c.GetPreview(ctx)
+	switch {
+	case err == nil:
+		snap.Preview = preview
+	case !errors.Is(err, client.ErrNotFound):
+		snap.Errors = append(snap.Errors, fmt.Sprintf("preview: %v", err))
+	}
+	report, err := c.GetLatestRun(ctx, "report")
+	switch {
+	case err == nil:
+		snap.Report = report
+	case !errors.Is(err, client.ErrNotFound):
+		snap.Errors = append(snap.Errors, fmt.Sprintf("latest report run: %v", err))
+	}
+	return snap
+}
+
+// render writes one dashboard frame. prev, the previous poll, gives event rates;
+// without it rates are shown as "-". On a terminal the screen is cleared first,
+// otherwise frames are separated by a blank line.
+func render(w io.Writer, cur, prev *snapshot, tty bool) error {
+	var b strings.Builder
+	if tty {
+		b.WriteString(clearScreen)
+	} else if prev != nil {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "solana-token-lab status  %s  %s\n", cur.Server, formatTime(cur.At))
+	if cur.StatusErr != nil {
+		fmt.Fprintf(&b, "\nServer unreachable: %v\n", cur.StatusErr)
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	st := cur.Status
+
+	fmt.Fprintf(&b, "\nServer:    %s, up %s (ingestion since %s)\n", st.Status, st.Uptime, formatTime(st.IngestionStarted))
+	fmt.Fprintf(&b, "Pipeline:  %s\n", formatSchedule(st.LastPipelineRun, st.NextPipelineRun, st.PipelineRuns, st.PipelineRunning, cur.At))
+	fmt.Fprintf(&b, "Report:    %s\n", formatSchedule(st.LastReportRun, st.NextReportRun, st.ReportRuns, st.ReportRunning, cur.At))
+	fmt.Fprintf(&b, "Decision:  %s\n", formatDecision(cur.Report))
+	fmt.Fprintf(&b, "Dirty:     %d candidates awaiting re-simulation\n", st.DirtyCandidates)
+	if p := cur.Preview; p != nil {
+		fmt.Fprintf(&b, "Preview:   %d NEW_TOKEN, %d ACTIVE_TOKEN candidates", p.ExecutiveSummary.NewTokenCount, p.ExecutiveSummary.ActiveTokenCount)
+		if p.ExecutiveSummary.BestStrategy != "" {
+			fmt.Fprintf(&b, "; best %s/%s, realistic win rate %.1f%%",
+				p.ExecutiveSummary.BestStrategy, p.ExecutiveSummary.BestEntryType, 100*p.ExecutiveSummary.WinRateRealistic)
+		}
+		fmt.Fprintf(&b, " (as of %s)\n", formatTime(p.GeneratedAt))
+	} else {
+		b.WriteString("Preview:   none yet\n")
+	}
+
+	if ing := st.Ingestion; ing != nil {
+		var prevIng *client.IngestionStatus
+		if prev != nil && prev.Status != nil {
+			prevIng = prev.Status.Ingestion
+		}
+		events := ing.SwapEvents + ing.LiquidityEvents
+		rate := "-"
+		if prevIng != nil {
+			rate = formatRate(events-prevIng.SwapEvents-prevIng.LiquidityEvents, cur.At.Sub(prev.At))
+		}
+		fmt.Fprintf(&b, "\nIngestion: %d swap + %d liquidity events (%s/min), discovered %d NEW_TOKEN, %d ACTIVE_TOKEN since start\n",
+			ing.SwapEvents, ing.LiquidityEvents, rate, ing.NewTokens, ing.ActiveTokens)
+		if ing.WSHealthProbe {
+			fmt.Fprintf(&b, "WS health: %d endpoint switches (%d failed), %d gap backfills (%d failed)\n",
+				ing.WSEndpointSwitches, ing.WSEndpointSwitchErrors, ing.GapBackfills, ing.GapBackfillErrors)
+		} else {
+			b.WriteString("WS health: probe disabled\n")
+		}
+	} else {
+		b.WriteString("\nIngestion: not running\n")
+	}
+
+	if len(st.Programs) > 0 {
+		b.WriteString("\n")
+		prevEvents := make(map[string]int64)
+		if prev != nil && prev.Status != nil {
+			for _, p := range prev.Status.Programs {
+				prevEvents[p.Program] = p.Events
+			}
+		}
+		tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "PROGRAM\tACTIVE\tEVENTS\tEVENTS/MIN\tLAST EVENT\tCANDIDATES\tPARSE FAILURES\tFAILED TXS")
+		for _, p := range st.Programs {
+			rate := "-"
+			if n, ok := prevEvents[p.Program]; ok {
+				rate = formatRate(p.Events-n, cur.At.Sub(prev.At))
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%d\t%d\t%d/%d\n",
+				p.Program, yesNo(p.Active), p.Events, rate, formatAge(p.LastEventAt, cur.At),
+				p.Candidates, p.ParseFailures, p.FailedTxs, p.Transactions)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(st.Schema) > 0 {
+		parts := make([]string, len(st.Schema))
+		for i, s := range st.Schema {
+			parts[i] = s.Backend + " " + s.Version
+			if s.Version != s.Expected {
+				parts[i] += " (expected " + s.Expected + ")"
+			}
+		}
+		fmt.Fprintf(&b, "\nSchema:    %s\n", strings.Join(parts, "; "))
+	}
+	for _, e := range cur.Errors {
+		fmt.Fprintf(&b, "Warning: %s\n", e)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatSchedule describes a scheduler: last run, run count and next run.
+func formatSchedule(last, next time.Time, runs int, running bool, now time.Time) string {
+	var b strings.Builder
+	if last.IsZero() {
+		b.WriteString("last never")
+	} else {
+		fmt.Fprintf(&b, "last %s (%s ago)", formatTime(last), now.Sub(last).Round(time.Second))
+	}
+	fmt.Fprintf(&b, ", %d runs", runs)
+	if !next.IsZero() {
+		if d := next.Sub(now); d > 0 {
+			fmt.Fprintf(&b, ", next %s (in %s)", formatTime(next), d.Round(time.Second))
+		} else {
+			fmt.Fprintf(&b, ", next %s (due)", formatTime(next))
+		}
+	}
+	if running {
+		b.WriteString(" [running]")
+	}
+	return b.String()
+}
+
+// formatDecision describes the decision of the latest report run.
+func formatDecision(report *client.Run) string {
+	if report == nil {
+		return "no report yet"
+	}
+	decision := report.Decision
+	if decision == "" {
+		decision = "unknown"
+	}
+	return fmt.Sprintf("%s (report of %s)", decision, formatTime(time.UnixMilli(report.FinishedAt)))
+}
+
+// formatRate returns delta events over elapsed as a per-minute rate.
+func formatRate(delta int64, elapsed time.Duration) string {
+	if elapsed <= 0 || delta < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", float64(delta)/elapsed.Minutes())
+}
+
+// formatAge returns how long before now the Unix ms ts was, "never" for 0.
+func formatAge(ts int64, now time.Time) string {
+	if ts == 0 {
+		return "never"
+	}
+	age := now.Sub(time.UnixMilli(ts))
+	if age < 0 {
+		age = 0
+	}
+	return age.Round(time.Second).String() + " ago"
+}
+
+// formatTime formats t in UTC, "-" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05Z")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
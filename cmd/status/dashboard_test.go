@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/pkg/client"
+)
+
+var testNow = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// cannedStatus is a /status payload of a server with live ingestion.
+func cannedStatus(events int64) *client.Status {
+	return &client.Status{
+		Status:           "running",
+		Uptime:           "2h0m0s",
+		IngestionStarted: testNow.Add(-2 * time.Hour),
+		LastPipelineRun:  testNow.Add(-10 * time.Minute),
+		NextPipelineRun:  testNow.Add(50 * time.Minute),
+		PipelineRuns:     2,
+		NextReportRun:    testNow.Add(-time.Minute),
+		DirtyCandidates:  3,
+		Programs: []client.ProgramStatus{
+			{Program: "pumpfun", Active: true, Events: events, Candidates: 4, Transactions: 100, FailedTxs: 7, LastEventAt: testNow.Add(-30 * time.Second).UnixMilli()},
+			{Program: "raydium", Active: false},
+		},
+		Ingestion: &client.IngestionStatus{
+			SwapEvents: events, LiquidityEvents: 10, NewTokens: 4, ActiveTokens: 1,
+			WSHealthProbe: true, WSEndpointSwitches: 1, GapBackfills: 2,
+		},
+		Schema: []client.SchemaStatus{
+			{Backend: "postgres", Version: "040", Expected: "040"},
+			{Backend: "clickhouse", Version: "009", Expected: "010"},
+		},
+	}
+}
+
+func cannedSnapshot(at time.Time, events int64) *snapshot {
+	return &snapshot{
+		Server: "http://lab:9090",
+		At:     at,
+		Status: cannedStatus(events),
+		Preview: &client.Preview{
+			GeneratedAt: testNow.Add(-10 * time.Minute),
+			ExecutiveSummary: client.PreviewSummary{
+				BestStrategy: "TIME_EXIT", BestEntryType: "NEW_TOKEN", WinRateRealistic: 0.425,
+				NewTokenCount: 12, ActiveTokenCount: 5,
+			},
+		},
+		Report: &client.Run{Kind: "report", Decision: "GO", FinishedAt: testNow.Add(-time.Hour).UnixMilli()},
+	}
+}
+
+func TestRender_Plain(t *testing.T) {
+	var b strings.Builder
+	if err := render(&b, cannedSnapshot(testNow, 500), nil, false); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := b.String()
+
+	if strings.Contains(out, "\033") {
+		t.Errorf("plain output contains escape sequences:\n%q", out)
+	}
+	for _, want := range []string{
+		"solana-token-lab status  http://lab:9090  2024-03-01 12:00:00Z",
+		"Server:    running, up 2h0m0s (ingestion since 2024-03-01 10:00:00Z)",
+		"Pipeline:  last 2024-03-01 11:50:00Z (10m0s ago), 2 runs, next 2024-03-01 12:50:00Z (in 50m0s)",
+		"Report:    last never, 0 runs, next 2024-03-01 11:59:00Z (due)",
+		"Decision:  GO (report of 2024-03-01 11:00:00Z)",
+		"Dirty:     3 candidates awaiting re-simulation",
+		"Preview:   12 NEW_TOKEN, 5 ACTIVE_TOKEN candidates; best TIME_EXIT/NEW_TOKEN, realistic win rate 42.5%",
+		"Ingestion: 500 swap + 10 liquidity events (-/min), discovered 4 NEW_TOKEN, 1 ACTIVE_TOKEN since start",
+		"WS health: 1 endpoint switches (0 failed), 2 gap backfills (0 failed)",
+		"Schema:    postgres 040; clickhouse 009 (expected 010)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	rows := programRows(out)
+	if got := strings.Fields(rows["pumpfun"]); strings.Join(got, " ") != "pumpfun yes 500 - 30s ago 4 0 7/100" {
+		t.Errorf("pumpfun row = %q", rows["pumpfun"])
+	}
+	if got := strings.Fields(rows["raydium"]); strings.Join(got, " ") != "raydium no 0 - never 0 0 0/0" {
+		t.Errorf("raydium row = %q", rows["raydium"])
+	}
+}
+
+func TestRender_RatesFromPreviousPoll(t *testing.T) {
+	prev := cannedSnapshot(testNow.Add(-30*time.Second), 400)
+	cur := cannedSnapshot(testNow, 500)
+
+	var b strings.Builder
+	if err := render(&b, cur, prev, false); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := b.String()
+
+	// A later plain frame is separated from the previous one by a blank line
+	if !strings.HasPrefix(out, "\nsolana-token-lab status") {
+		t.Errorf("plain frame should start with a blank line: %q", out[:40])
+	}
+	if !strings.Contains(out, "events (200.0/min)") {
+		t.Errorf("expected a total rate of 200.0/min:\n%s", out)
+	}
+	if got := strings.Fields(programRows(out)["pumpfun"])[3]; got != "200.0" {
+		t.Errorf("pumpfun rate = %s, want 200.0", got)
+	}
+	if got := strings.Fields(programRows(out)["raydium"])[3]; got != "0.0" {
+		t.Errorf("raydium rate = %s, want 0.0", got)
+	}
+}
+
+func TestRender_Terminal(t *testing.T) {
+	var b strings.Builder
+	if err := render(&b, cannedSnapshot(testNow, 500), cannedSnapshot(testNow.Add(-time.Minute), 500), true); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if out := b.String(); !strings.HasPrefix(out, clearScreen+"solana-token-lab status") {
+		t.Errorf("terminal frame should clear the screen first: %q", out[:40])
+	}
+}
+
+func TestRender_Sparse(t *testing.T) {
+	// A server started without ingestion, before its first pipeline and report runs
+	snap := &snapshot{Server: "http://lab:9090", At: testNow, Status: &client.Status{Status: "running", Uptime: "1m0s"}}
+	var b strings.Builder
+	if err := render(&b, snap, nil, false); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"Pipeline:  last never, 0 runs\n",
+		"Decision:  no report yet",
+		"Preview:   none yet",
+		"Ingestion: not running",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "PROGRAM") || strings.Contains(out, "Schema:") {
+		t.Errorf("empty sections should be left out:\n%s", out)
+	}
+}
+
+func TestRender_Unreachable(t *testing.T) {
+	snap := &snapshot{Server: "http://lab:9090", At: testNow, StatusErr: context.DeadlineExceeded}
+	var b strings.Builder
+	if err := render(&b, snap, cannedSnapshot(testNow.Add(-time.Minute), 500), false); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "Server unreachable: context deadline exceeded") {
+		t.Errorf("expected the unreachable message:\n%s", out)
+	}
+	if strings.Contains(out, "Pipeline:") {
+		t.Errorf("an unreachable server should show no stale state:\n%s", out)
+	}
+}
+
+func TestFetchSnapshot(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(cannedStatus(500))
+	})
+	mux.HandleFunc("GET /api/v1/preview", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no preview yet", http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /api/v1/runs/latest", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("kind") != "report" {
+			t.Errorf("latest run kind = %q, want report", r.URL.Query().Get("kind"))
+		}
+		_ = json.NewEncoder(w).Encode(client.Run{Kind: "report", Decision: "NO-GO"})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	snap := fetchSnapshot(context.Background(), client.New(srv.URL, client.WithMaxRetries(0)), testNow)
+	if snap.StatusErr != nil {
+		t.Fatalf("status: %v", snap.StatusErr)
+	}
+	if snap.Status.DirtyCandidates != 3 || len(snap.Status.Programs) != 2 || snap.Status.Programs[0].LastEventAt == 0 {
+		t.Errorf("status not decoded: %+v", snap.Status)
+	}
+	if snap.Preview != nil {
+		t.Errorf("a missing preview should leave Preview nil")
+	}
+	if snap.Report == nil || snap.Report.Decision != "NO-GO" {
+		t.Errorf("report = %+v, want the NO-GO run", snap.Report)
+	}
+	if len(snap.Errors) != 0 {
+		t.Errorf("a missing preview is not an error: %v", snap.Errors)
+	}
+
+	srv.Close()
+	if snap := fetchSnapshot(context.Background(), client.New(srv.URL, client.WithMaxRetries(0)), testNow); snap.StatusErr == nil {
+		t.Errorf("expected an error from a stopped server")
+	}
+}
+
+// programRows returns the rows of the programs table keyed by program.
+func programRows(out string) map[string]string {
+	rows := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		if f := strings.Fields(line); len(f) > 0 && (f[0] == "pumpfun" || f[0] == "raydium") {
+			rows[f[0]] = line
+		}
+	}
+	return rows
+}
@@ -0,0 +1,75 @@
+// Command status shows a live dashboard of a running cmd/server in the
+// terminal. It polls only the server's public HTTP endpoints (/status, the
+// report preview and the latest report run), so it also works remotely.
+//
+// On a terminal the dashboard is redrawn in place every --interval; when the
+// output is not a terminal (a pipe or a log file) each refresh is printed as
+// plain text, one frame after the other.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"solana-token-lab/pkg/client"
+)
+
+func main() {
+	serverURL := flag.String("server", "http://localhost:9090", "Base URL of cmd/server's HTTP endpoints (its --metrics-addr)")
+	interval := flag.Duration("interval", 5*time.Second, "Refresh interval")
+	once := flag.Bool("once", false, "Print the dashboard once and exit")
+	plain := flag.Bool("plain", false, "Print plain text frames even on a terminal")
+	timeout := flag.Duration("timeout", 10*time.Second, "Timeout of each poll")
+	flag.Parse()
+
+	if *interval <= 0 {
+		fmt.Fprintln(os.Stderr, "--interval must be positive")
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	c := client.New(*serverURL, client.WithMaxRetries(0))
+	tty := !*plain && isTerminal(os.Stdout)
+
+	var prev *snapshot
+	for {
+		pollCtx, cancelPoll := context.WithTimeout(ctx, *timeout)
+		cur := fetchSnapshot(pollCtx, c, time.Now())
+		cancelPoll()
+		if ctx.Err() != nil {
+			return
+		}
+		cur.Server = *serverURL
+
+		if err := render(os.Stdout, cur, prev, tty); err != nil {
+			fmt.Fprintf(os.Stderr, "render: %v\n", err)
+			os.Exit(1)
+		}
+		if *once {
+			if cur.StatusErr != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		prev = cur
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// isTerminal reports whether f is a character device, i.e. an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
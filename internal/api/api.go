@@ -0,0 +1,146 @@
+// Package api serves the read-only /api/v1 REST endpoints over the storage layer:
+// candidates, dossiers, trades, aggregates, the latest data sufficiency checks,
+// a live candidate stream (SSE), the latest report preview, the strategy and
+// scenario catalog and the run manifests of pipeline and report runs.
+// cmd/server mounts it next to its health, status and admin endpoints and wraps
+// them all in Middleware;
+// pkg/client is the Go client for it.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
+)
+
+// Version is the API version reported in VersionHeader on every /api/v1 response.
+// It changes only on incompatible changes to paths or response shapes.
+const Version = "1"
+
+// VersionHeader carries Version.
+const VersionHeader = "X-API-Version"
+
+// Page size limits for list endpoints.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// defaultStreamInterval is how often the candidate stream polls for new candidates.
+const defaultStreamInterval = 2 * time.Second
+
+// Stores are the sources the API reads from. Candidates, Trades and Aggregates
+// are required; a nil optional store leaves its fields or sections empty.
+type Stores struct {
+	Candidates          storage.CandidateStore
+	Trades              storage.TradeRecordStore
+	Aggregates          storage.StrategyAggregateStore
+	Watchlist           storage.WatchlistStore
+	HolderSnapshots     storage.TokenHolderSnapshotStore
+	Metadata            storage.TokenMetadataStore
+	Swaps               storage.SwapStore
+	LiquidityEvents     storage.LiquidityEventStore
+	PriceTimeseries     storage.PriceTimeseriesStore
+	LiquidityTimeseries storage.LiquidityTimeseriesStore
+	Sufficiency         storage.SufficiencyRunStore
+	SwapEvents          storage.SwapEventStore
+	RunManifests        storage.RunManifestStore
+}
+
+// Handler serves the /api/v1 endpoints.
+type Handler struct {
+	stores         Stores
+	streamInterval time.Duration
+	now            func() time.Time
+	preview        func() *reporting.Preview // optional, see WithPreview
+	catalog        *domain.Catalog           // optional, see WithCatalog
+}
+
+// NewHandler creates a Handler over the given stores.
+func NewHandler(stores Stores) *Handler {
+	return &Handler{
+		stores:         stores,
+		streamInterval: defaultStreamInterval,
+		now:            time.Now,
+	}
+}
+
+// WithStreamInterval sets the polling interval of the candidate stream.
+func (h *Handler) WithStreamInterval(d time.Duration) *Handler {
+	h.streamInterval = d
+	return h
+}
+
+// Register adds the /api/v1 routes to mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.Handle("GET /api/v1/version", versioned(h.getVersion))
+	mux.Handle("GET /api/v1/candidates", versioned(h.listCandidates))
+	mux.Handle("GET /api/v1/candidates/stream", versioned(h.streamCandidates))
+	mux.Handle("GET /api/v1/candidates/{id}", versioned(h.getCandidate))
+	mux.Handle("GET /api/v1/candidates/{id}/dossier", versioned(h.getDossier))
+	mux.Handle("GET /api/v1/trades", versioned(h.listTrades))
+	mux.Handle("GET /api/v1/aggregates", versioned(h.listAggregates))
+	mux.Handle("GET /api/v1/sufficiency/latest", versioned(h.getLatestSufficiency))
+	mux.Handle("GET /api/v1/preview", versioned(h.getPreview))
+	mux.Handle("GET /api/v1/catalog", versioned(h.getCatalog))
+	mux.Handle("GET /api/v1/runs", versioned(h.listRuns))
+	mux.Handle("GET /api/v1/runs/latest", versioned(h.getLatestRun))
+	mux.Handle("GET /api/v1/runs/{id}", versioned(h.getRun))
+}
+
+// CandidateHandler serves a single candidate by the {id} path value.
+// Kept for the pre-/api/v1 GET /candidates/{id} route.
+func (h *Handler) CandidateHandler() http.Handler {
+	return versioned(h.getCandidate)
+}
+
+// VersionResponse is the JSON response of GET /api/v1/version.
+type VersionResponse struct {
+	Version string `json:"version"`
+}
+
+func (h *Handler) getVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, VersionResponse{Version: Version})
+}
+
+// versioned sets VersionHeader before calling fn.
+func versioned(fn http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(VersionHeader, Version)
+		fn(w, r)
+	})
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeStoreError maps storage.ErrNotFound to 404 and anything else to 500.
+func writeStoreError(w http.ResponseWriter, err error, notFound string) {
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, notFound, http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// pageSize parses the limit query parameter.
+func pageSize(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return DefaultPageSize, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, errors.New("limit must be a positive integer")
+	}
+	return min(n, MaxPageSize), nil
+}
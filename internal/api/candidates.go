@@ -0,0 +1,305 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
+	"solana-token-lab/internal/storage"
+)
+
+// CandidateResponse is a candidate with its watchlist flag and latest holder snapshot.
+type CandidateResponse struct {
+	CandidateID    string                  `json:"candidate_id"`
+	Source         domain.Source           `json:"source"`
+	Mint           string                  `json:"mint"`
+	Pool           *string                 `json:"pool,omitempty"`
+	TxSignature    string                  `json:"tx_signature"`
+	EventIndex     int                     `json:"event_index"`
+	Slot           int64                   `json:"slot"`
+	DiscoveredAt   int64                   `json:"discovered_at"`
+	Status         domain.CandidateStatus  `json:"status,omitempty"`
+	ClosedAt       *int64                  `json:"closed_at,omitempty"`
+	DeletedAt      *int64                  `json:"deleted_at,omitempty"` // soft-deleted: only served by ID
+	DeletedReason  string                  `json:"deleted_reason,omitempty"`
+	Watchlisted    bool                    `json:"watchlisted"`
+	HolderSnapshot *HolderSnapshotResponse `json:"holder_snapshot,omitempty"`
+}
+
+// HolderSnapshotResponse is the latest holder concentration snapshot for a candidate.
+type HolderSnapshotResponse struct {
+	SnapshotAt    int64   `json:"snapshot_at"`
+	HolderCount   int     `json:"holder_count"`
+	Top10Pct      float64 `json:"top10_pct"`
+	TotalSupply   float64 `json:"total_supply"`
+	LargestHolder string  `json:"largest_holder,omitempty"`
+}
+
+// CandidatePage is one page of GET /api/v1/candidates.
+// NextCursor is empty on the last page.
+type CandidatePage struct {
+	Candidates []CandidateResponse `json:"candidates"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// candidateResponse converts a candidate row; list and stream responses carry
+// no holder snapshot. watchlisted is the set from watchlistedMints.
+func candidateResponse(c *domain.TokenCandidate, watchlisted map[string]bool) CandidateResponse {
+	return CandidateResponse{
+		CandidateID:   c.CandidateID,
+		Source:        c.Source,
+		Mint:          c.Mint,
+		Pool:          c.Pool,
+		TxSignature:   c.TxSignature,
+		EventIndex:    c.EventIndex,
+		Slot:          c.Slot,
+		DiscoveredAt:  c.DiscoveredAt,
+		Status:        c.Status,
+		ClosedAt:      c.ClosedAt,
+		DeletedAt:     c.DeletedAt,
+		DeletedReason: c.DeletedReason,
+		Watchlisted:   watchlisted[c.Mint],
+	}
+}
+
+// watchlistedMints returns the watchlisted mints, read once per response page
+// rather than per candidate. Nil without a watchlist store.
+func (h *Handler) watchlistedMints(ctx context.Context) (map[string]bool, error) {
+	if h.stores.Watchlist == nil {
+		return nil, nil
+	}
+	entries, err := h.stores.Watchlist.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mints := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		mints[e.Mint] = true
+	}
+	return mints, nil
+}
+
+// listCandidates serves GET /api/v1/candidates?source=&limit=&cursor=.
+// Candidates are ordered by candidate_id; cursor is the last candidate_id of the previous page.
+func (h *Handler) listCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	limit, err := pageSize(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sources := []domain.Source{domain.SourceNewToken, domain.SourceActiveToken}
+	if raw := q.Get("source"); raw != "" {
+		source := domain.Source(raw)
+		if !source.IsValid() {
+			http.Error(w, "source must be NEW_TOKEN or ACTIVE_TOKEN", http.StatusBadRequest)
+			return
+		}
+		sources = []domain.Source{source}
+	}
+
+	var all []*domain.TokenCandidate
+	for _, source := range sources {
+		cs, err := h.stores.Candidates.GetBySource(ctx, source)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		all = append(all, cs...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CandidateID < all[j].CandidateID })
+
+	cursor := q.Get("cursor")
+	start := sort.Search(len(all), func(i int) bool { return all[i].CandidateID > cursor })
+	end := min(start+limit, len(all))
+
+	watchlisted, err := h.watchlistedMints(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	page := CandidatePage{Candidates: make([]CandidateResponse, 0, end-start)}
+	for _, c := range all[start:end] {
+		page.Candidates = append(page.Candidates, candidateResponse(c, watchlisted))
+	}
+	if end < len(all) {
+		page.NextCursor = all[end-1].CandidateID
+	}
+
+	writeJSON(w, page)
+}
+
+// getCandidate serves a candidate with its watchlist flag and latest holder snapshot.
+func (h *Handler) getCandidate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	candidate, err := h.stores.Candidates.GetByID(ctx, id)
+	if err != nil {
+		writeStoreError(w, err, "candidate not found")
+		return
+	}
+
+	resp := candidateResponse(candidate, nil)
+
+	if h.stores.Watchlist != nil {
+		switch _, err := h.stores.Watchlist.Get(ctx, candidate.Mint); {
+		case err == nil:
+			resp.Watchlisted = true
+		case !errors.Is(err, storage.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if h.stores.HolderSnapshots != nil {
+		snap, err := h.stores.HolderSnapshots.GetLatest(ctx, id)
+		switch {
+		case err == nil:
+			resp.HolderSnapshot = &HolderSnapshotResponse{
+				SnapshotAt:    snap.SnapshotAt,
+				HolderCount:   snap.HolderCount,
+				Top10Pct:      snap.Top10Pct,
+				TotalSupply:   snap.TotalSupply,
+				LargestHolder: snap.LargestHolder,
+			}
+		case !errors.Is(err, storage.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+// getDossier serves everything known about a candidate.
+// Sections whose store fails are listed in the warnings array.
+func (h *Handler) getDossier(w http.ResponseWriter, r *http.Request) {
+	builder := dossier.NewBuilder(dossier.Stores{
+		Candidates:          h.stores.Candidates,
+		Metadata:            h.stores.Metadata,
+		Swaps:               h.stores.Swaps,
+		LiquidityEvents:     h.stores.LiquidityEvents,
+		PriceTimeseries:     h.stores.PriceTimeseries,
+		LiquidityTimeseries: h.stores.LiquidityTimeseries,
+		Trades:              h.stores.Trades,
+		SwapEvents:          h.stores.SwapEvents,
+	})
+
+	d, err := builder.Build(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeStoreError(w, err, "candidate not found")
+		return
+	}
+
+	writeJSON(w, d)
+}
+
+// streamCandidates serves GET /api/v1/candidates/stream?since= as Server-Sent Events.
+// Every candidate discovered at or after since (Unix ms, default now) is sent once as
+// a "candidate" event, in (discovered_at, candidate_id) order, until the client disconnects.
+// The store is polled, so candidates appear with up to one stream interval of delay.
+func (h *Handler) streamCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	since := h.now().UnixMilli()
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 0 {
+			http.Error(w, "since must be a Unix timestamp in milliseconds", http.StatusBadRequest)
+			return
+		}
+		since = v
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	// Candidates at the cursor timestamp already sent; a later poll may return more
+	// candidates with the same discovered_at
+	cursor := since
+	sent := make(map[string]bool)
+
+	ticker := time.NewTicker(h.streamInterval)
+	defer ticker.Stop()
+
+	for {
+		cs, err := h.stores.Candidates.GetByTimeRange(ctx, cursor, math.MaxInt64)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", strconv.Quote(err.Error()))
+			flusher.Flush()
+			return
+		}
+		sort.Slice(cs, func(i, j int) bool {
+			if cs[i].DiscoveredAt != cs[j].DiscoveredAt {
+				return cs[i].DiscoveredAt < cs[j].DiscoveredAt
+			}
+			return cs[i].CandidateID < cs[j].CandidateID
+		})
+
+		// The watchlist is read once per poll with new candidates
+		var watchlisted map[string]bool
+		watchlistRead := false
+		for _, c := range cs {
+			if sent[c.CandidateID] {
+				continue
+			}
+			if !watchlistRead {
+				if watchlisted, err = h.watchlistedMints(ctx); err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", strconv.Quote(err.Error()))
+					flusher.Flush()
+					return
+				}
+				watchlistRead = true
+			}
+			if c.DiscoveredAt > cursor {
+				cursor = c.DiscoveredAt
+				clear(sent)
+			}
+			sent[c.CandidateID] = true
+
+			data, err := json.Marshal(candidateResponse(c, watchlisted))
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: candidate\nid: %s\ndata: %s\n\n", c.CandidateID, data)
+		}
+		if len(cs) > 0 {
+			flusher.Flush()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
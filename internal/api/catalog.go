@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+
+	"solana-token-lab/internal/domain"
+)
+
+// WithCatalog sets the strategy and scenario catalog served at GET /api/v1/catalog.
+func (h *Handler) WithCatalog(c *domain.Catalog) *Handler {
+	h.catalog = c
+	return h
+}
+
+// getCatalog serves GET /api/v1/catalog: the strategies, parameter defaults and
+// scenario constants this build supports, 404 if no catalog was set.
+func (h *Handler) getCatalog(w http.ResponseWriter, r *http.Request) {
+	if h.catalog == nil {
+		http.Error(w, "no catalog", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, h.catalog)
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+)
+
+// RequestIDHeader carries the ID of a request, echoed on every response served
+// through Middleware.
+const RequestIDHeader = "X-Request-ID"
+
+// DefaultMaxBodyBytes limits request bodies when MiddlewareOptions.MaxBodyBytes is 0.
+const DefaultMaxBodyBytes = 1 << 20
+
+// unmatchedRoute labels requests no mux pattern matched, keeping the route label bounded.
+const unmatchedRoute = "unmatched"
+
+// DefaultQuietPaths are excluded from access logs unless MiddlewareOptions.QuietPaths is set.
+var DefaultQuietPaths = []string{"/metrics", "/health"}
+
+// validRequestID accepts a client-supplied request ID; anything else is replaced.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RecordFunc receives one observation per request: the matched mux pattern
+// (e.g. "GET /api/v1/trades"), the response status and the duration.
+type RecordFunc func(route string, status int, seconds float64)
+
+// MiddlewareOptions configure Middleware. All fields are optional.
+type MiddlewareOptions struct {
+	Logger       *log.Logger     // access and panic logs; nil disables logging
+	Record       RecordFunc      // request metrics
+	InFlight     func(delta int) // in-flight requests gauge
+	MaxBodyBytes int64           // 0 uses DefaultMaxBodyBytes, negative disables the limit
+	QuietPaths   []string        // paths not access-logged; nil uses DefaultQuietPaths
+}
+
+// Middleware wraps a mux with request metrics, request IDs, access logs,
+// panic recovery and a request body size limit.
+//
+// Every response carries RequestIDHeader: the client's X-Request-ID if it is
+// a plain token of up to 64 characters, a generated one otherwise. The ID is
+// available to handlers via RequestID. A panicking handler is logged with its
+// stack and answered with 500 naming the ID, unless it already started the
+// response. Bodies over the limit are rejected with 413 when Content-Length
+// announces it, and fail on read otherwise.
+func Middleware(next http.Handler, opts MiddlewareOptions) http.Handler {
+	maxBody := opts.MaxBodyBytes
+	if maxBody == 0 {
+		maxBody = DefaultMaxBodyBytes
+	}
+	quietPaths := opts.QuietPaths
+	if quietPaths == nil {
+		quietPaths = DefaultQuietPaths
+	}
+	quiet := make(map[string]bool, len(quietPaths))
+	for _, p := range quietPaths {
+		quiet[p] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		if opts.InFlight != nil {
+			opts.InFlight(1)
+			defer opts.InFlight(-1)
+		}
+
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id))
+
+		rw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				if opts.Logger != nil {
+					opts.Logger.Printf("[http] PANIC id=%s %s %s: %v\n%s", id, r.Method, r.URL.Path, p, debug.Stack())
+				}
+				if !rw.wroteHeader {
+					http.Error(rw, fmt.Sprintf("internal server error (request %s)", id), http.StatusInternalServerError)
+				}
+			}
+
+			route := r.Pattern
+			if route == "" {
+				route = unmatchedRoute
+			}
+			elapsed := time.Since(start)
+			if opts.Record != nil {
+				opts.Record(route, rw.status(), elapsed.Seconds())
+			}
+			if opts.Logger != nil && !quiet[r.URL.Path] {
+				opts.Logger.Printf("[http] id=%s method=%s path=%s route=%q status=%d bytes=%d duration=%s remote=%s",
+					id, r.Method, r.URL.Path, route, rw.status(), rw.bytes, elapsed.Round(time.Microsecond), r.RemoteAddr)
+			}
+		}()
+
+		if maxBody > 0 {
+			if r.ContentLength > maxBody {
+				http.Error(rw, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(rw, r.Body, maxBody)
+		}
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// RequestID returns the ID Middleware assigned to the request of ctx, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// newRequestID returns 16 random bytes, hex encoded.
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// statusWriter records the status and size of a response. It passes Flush
+// through for the candidate stream and exposes the underlying writer to
+// http.ResponseController.
+type statusWriter struct {
+	http.ResponseWriter
+	code        int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.code = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *statusWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// status returns the response status, 200 if the handler wrote nothing.
+func (w *statusWriter) status() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
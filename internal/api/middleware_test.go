@@ -0,0 +1,222 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recorded is one RecordFunc observation.
+type recorded struct {
+	route  string
+	status int
+}
+
+// middlewareServer serves a small mux through Middleware, capturing its
+// metrics and logs.
+type middlewareServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	records  []recorded
+	inFlight int
+	logs     bytes.Buffer
+}
+
+func newMiddlewareServer(t *testing.T, maxBody int64) *middlewareServer {
+	t.Helper()
+	s := &middlewareServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/things/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(RequestID(r.Context())))
+	})
+	mux.HandleFunc("GET /boom", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	mux.HandleFunc("POST /echo", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	s.Server = httptest.NewServer(Middleware(mux, MiddlewareOptions{
+		Logger: log.New(&syncWriter{mu: &s.mu, w: &s.logs}, "", 0),
+		Record: func(route string, status int, _ float64) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.records = append(s.records, recorded{route, status})
+		},
+		InFlight: func(delta int) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.inFlight += delta
+		},
+		MaxBodyBytes: maxBody,
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// syncWriter serializes writes to w under mu.
+type syncWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+func (s *middlewareServer) do(t *testing.T, method, path string, body io.Reader, header http.Header) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL+path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return resp, string(b)
+}
+
+func (s *middlewareServer) lastRecord() recorded {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records[len(s.records)-1]
+}
+
+func TestMiddleware_RecordsRouteAndStatus(t *testing.T) {
+	s := newMiddlewareServer(t, 0)
+
+	s.do(t, "GET", "/api/v1/things/42", nil, nil)
+	if got, want := s.lastRecord(), (recorded{"GET /api/v1/things/{id}", 200}); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	// Unmatched paths share one label rather than one per path
+	s.do(t, "GET", "/nope/123", nil, nil)
+	if got, want := s.lastRecord(), (recorded{"unmatched", 404}); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight != 0 {
+		t.Errorf("expected in-flight back to 0, got %d", s.inFlight)
+	}
+}
+
+func TestMiddleware_RequestID(t *testing.T) {
+	s := newMiddlewareServer(t, 0)
+
+	resp, body := s.do(t, "GET", "/api/v1/things/1", nil, nil)
+	id := resp.Header.Get(RequestIDHeader)
+	if len(id) != 32 {
+		t.Fatalf("expected a generated 32-char request ID, got %q", id)
+	}
+	if body != id {
+		t.Errorf("expected handler to see request ID %q, got %q", id, body)
+	}
+
+	resp, _ = s.do(t, "GET", "/api/v1/things/1", nil, http.Header{RequestIDHeader: {"upstream-abc.1"}})
+	if got := resp.Header.Get(RequestIDHeader); got != "upstream-abc.1" {
+		t.Errorf("expected client request ID echoed, got %q", got)
+	}
+
+	resp, _ = s.do(t, "GET", "/api/v1/things/1", nil, http.Header{RequestIDHeader: {"bad id\twith spaces"}})
+	if got := resp.Header.Get(RequestIDHeader); got == "bad id\twith spaces" || len(got) != 32 {
+		t.Errorf("expected invalid client request ID replaced, got %q", got)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !strings.Contains(s.logs.String(), "id="+id) {
+		t.Errorf("expected access log with id=%s, got:\n%s", id, s.logs.String())
+	}
+}
+
+func TestMiddleware_PanicReturns500(t *testing.T) {
+	s := newMiddlewareServer(t, 0)
+
+	resp, body := s.do(t, "GET", "/boom", nil, nil)
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", resp.StatusCode)
+	}
+	id := resp.Header.Get(RequestIDHeader)
+	if id == "" || !strings.Contains(body, id) {
+		t.Errorf("expected body to name request ID %q, got %q", id, body)
+	}
+	if got, want := s.lastRecord(), (recorded{"GET /boom", 500}); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	// The server keeps serving
+	resp, _ = s.do(t, "GET", "/api/v1/things/1", nil, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 after panic, got %d", resp.StatusCode)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !strings.Contains(s.logs.String(), "PANIC id="+id) {
+		t.Errorf("expected panic logged with request ID, got:\n%s", s.logs.String())
+	}
+}
+
+func TestMiddleware_BodyLimit(t *testing.T) {
+	s := newMiddlewareServer(t, 8)
+
+	resp, _ := s.do(t, "POST", "/echo", strings.NewReader("small"), nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 within limit, got %d", resp.StatusCode)
+	}
+
+	// Announced by Content-Length: rejected before the handler
+	resp, _ = s.do(t, "POST", "/echo", strings.NewReader("way over the limit"), nil)
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 over limit, got %d", resp.StatusCode)
+	}
+
+	// Unknown length: the handler's read fails
+	resp, _ = s.do(t, "POST", "/echo", io.MultiReader(strings.NewReader("way over "), strings.NewReader("the limit")), nil)
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 on chunked body over limit, got %d", resp.StatusCode)
+	}
+}
+
+func TestMiddleware_QuietPaths(t *testing.T) {
+	s := newMiddlewareServer(t, 0)
+
+	s.do(t, "GET", "/health", nil, nil)
+	s.do(t, "GET", "/api/v1/things/7", nil, nil)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	logs := s.logs.String()
+	if strings.Contains(logs, "path=/health") {
+		t.Errorf("expected /health not access-logged, got:\n%s", logs)
+	}
+	if !strings.Contains(logs, "path=/api/v1/things/7") {
+		t.Errorf("expected API request access-logged, got:\n%s", logs)
+	}
+	// Quiet paths are still measured
+	if len(s.records) != 2 || s.records[0].route != "/health" {
+		t.Errorf("expected /health recorded, got %+v", s.records)
+	}
+}
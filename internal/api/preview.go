@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"solana-token-lab/internal/reporting"
+)
+
+// WithPreview sets the source of GET /api/v1/preview. latest returns the most
+// recent report preview, or nil before the first one.
+func (h *Handler) WithPreview(latest func() *reporting.Preview) *Handler {
+	h.preview = latest
+	return h
+}
+
+// getPreview serves GET /api/v1/preview: the non-authoritative summary written
+// after the latest pipeline run, 404 before the first one.
+func (h *Handler) getPreview(w http.ResponseWriter, r *http.Request) {
+	var p *reporting.Preview
+	if h.preview != nil {
+		p = h.preview()
+	}
+	if p == nil {
+		http.Error(w, "no report preview yet", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, p)
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"maps"
+	"net/http"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RunBackendResponse is a storage backend of a run.
+type RunBackendResponse struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// RunResponse is the manifest of one pipeline or report run. Config holds the
+// effective configuration with credentials redacted; Errors holds at most
+// storage.MaxRunManifestErrors errors and ErrorCount is the full count.
+type RunResponse struct {
+	RunID       string               `json:"run_id"`
+	Kind        string               `json:"kind"`
+	StartedAt   int64                `json:"started_at"`
+	FinishedAt  int64                `json:"finished_at"`
+	GitCommit   string               `json:"git_commit"`
+	Config      map[string]string    `json:"config"`
+	Backends    []RunBackendResponse `json:"backends"`
+	Candidates  int                  `json:"candidates"`
+	Trades      int                  `json:"trades"`
+	Aggregates  int                  `json:"aggregates"`
+	DataVersion string               `json:"data_version,omitempty"`
+	Decision    string               `json:"decision,omitempty"`
+	Errors      []string             `json:"errors"`
+	ErrorCount  int                  `json:"error_count"`
+}
+
+// RunListResponse is the JSON response of GET /api/v1/runs, newest run first.
+type RunListResponse struct {
+	Runs []RunResponse `json:"runs"`
+}
+
+func runResponse(m *storage.RunManifest) RunResponse {
+	resp := RunResponse{
+		RunID:       m.RunID,
+		Kind:        m.Kind,
+		StartedAt:   m.StartedAt,
+		FinishedAt:  m.FinishedAt,
+		GitCommit:   m.GitCommit,
+		Config:      maps.Clone(m.Config),
+		Backends:    make([]RunBackendResponse, len(m.Backends)),
+		Candidates:  m.Candidates,
+		Trades:      m.Trades,
+		Aggregates:  m.Aggregates,
+		DataVersion: m.DataVersion,
+		Decision:    m.Decision,
+		Errors:      append([]string{}, m.Errors...),
+		ErrorCount:  m.ErrorCount,
+	}
+	if resp.Config == nil {
+		resp.Config = map[string]string{}
+	}
+	for i, b := range m.Backends {
+		resp.Backends[i] = RunBackendResponse(b)
+	}
+	return resp
+}
+
+// runKind parses the optional kind query parameter.
+func runKind(r *http.Request) (string, bool) {
+	switch kind := r.URL.Query().Get("kind"); kind {
+	case "", storage.RunKindPipeline, storage.RunKindReport:
+		return kind, true
+	default:
+		return "", false
+	}
+}
+
+// listRuns serves GET /api/v1/runs: the latest run manifests, newest first,
+// optionally filtered by kind.
+//
+// Query parameters:
+//   - kind: pipeline or report
+//   - limit: page size (default DefaultPageSize, max MaxPageSize)
+func (h *Handler) listRuns(w http.ResponseWriter, r *http.Request) {
+	kind, ok := runKind(r)
+	if !ok {
+		http.Error(w, "kind must be pipeline or report", http.StatusBadRequest)
+		return
+	}
+	limit, err := pageSize(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := RunListResponse{Runs: []RunResponse{}}
+	if h.stores.RunManifests != nil {
+		runs, err := h.stores.RunManifests.List(r.Context(), kind, limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, m := range runs {
+			resp.Runs = append(resp.Runs, runResponse(m))
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// getLatestRun serves GET /api/v1/runs/latest: the manifest of the most recent
+// run, optionally of the given kind, 404 before the first run.
+func (h *Handler) getLatestRun(w http.ResponseWriter, r *http.Request) {
+	kind, ok := runKind(r)
+	if !ok {
+		http.Error(w, "kind must be pipeline or report", http.StatusBadRequest)
+		return
+	}
+	if h.stores.RunManifests == nil {
+		http.Error(w, "run manifests are not recorded", http.StatusNotFound)
+		return
+	}
+	m, err := h.stores.RunManifests.GetLatest(r.Context(), kind)
+	if err != nil {
+		writeStoreError(w, err, "no run recorded")
+		return
+	}
+	writeJSON(w, runResponse(m))
+}
+
+// getRun serves GET /api/v1/runs/{id}: the manifest of one run.
+func (h *Handler) getRun(w http.ResponseWriter, r *http.Request) {
+	if h.stores.RunManifests == nil {
+		http.Error(w, "run manifests are not recorded", http.StatusNotFound)
+		return
+	}
+	m, err := h.stores.RunManifests.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeStoreError(w, err, "run not found")
+		return
+	}
+	writeJSON(w, runResponse(m))
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+
+	"solana-token-lab/internal/storage"
+)
+
+// SufficiencyCheckResponse is one data sufficiency check.
+type SufficiencyCheckResponse struct {
+	Name      string `json:"name"`
+	Threshold string `json:"threshold"`
+	Actual    string `json:"actual"`
+	Pass      bool   `json:"pass"`
+}
+
+// SufficiencyRunResponse is the JSON response of GET /api/v1/sufficiency/latest.
+// Errors holds at most storage.MaxSufficiencyRunErrors integrity errors;
+// ErrorCount is the full count.
+type SufficiencyRunResponse struct {
+	RunAt       int64                      `json:"run_at"`
+	DataVersion string                     `json:"data_version"`
+	AllPass     bool                       `json:"all_pass"`
+	Checks      []SufficiencyCheckResponse `json:"checks"`
+	Errors      []string                   `json:"errors"`
+	ErrorCount  int                        `json:"error_count"`
+}
+
+func sufficiencyRunResponse(run *storage.SufficiencyRun) SufficiencyRunResponse {
+	resp := SufficiencyRunResponse{
+		RunAt:       run.RunAt,
+		DataVersion: run.DataVersion,
+		AllPass:     run.AllPass,
+		Checks:      make([]SufficiencyCheckResponse, len(run.Checks)),
+		Errors:      append([]string{}, run.Errors...),
+		ErrorCount:  run.ErrorCount,
+	}
+	for i, c := range run.Checks {
+		resp.Checks[i] = SufficiencyCheckResponse(c)
+	}
+	return resp
+}
+
+// getLatestSufficiency serves GET /api/v1/sufficiency/latest: the checks of the
+// most recent pipeline run, 404 before the first run.
+func (h *Handler) getLatestSufficiency(w http.ResponseWriter, r *http.Request) {
+	if h.stores.Sufficiency == nil {
+		http.Error(w, "sufficiency runs are not recorded", http.StatusNotFound)
+		return
+	}
+	run, err := h.stores.Sufficiency.GetLatest(r.Context())
+	if err != nil {
+		writeStoreError(w, err, "no sufficiency run recorded")
+		return
+	}
+	writeJSON(w, sufficiencyRunResponse(run))
+}
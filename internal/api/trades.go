@@ -0,0 +1,226 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TradeResponse is a simulated trade record.
+type TradeResponse struct {
+	TradeID     string `json:"trade_id"`
+	CandidateID string `json:"candidate_id"`
+	StrategyID  string `json:"strategy_id"`
+	ScenarioID  string `json:"scenario_id"`
+
+	EntrySignalTime  int64    `json:"entry_signal_time"`
+	EntrySignalPrice float64  `json:"entry_signal_price"`
+	EntryActualTime  int64    `json:"entry_actual_time"`
+	EntryActualPrice float64  `json:"entry_actual_price"`
+	EntryLiquidity   *float64 `json:"entry_liquidity,omitempty"`
+	PositionSize     float64  `json:"position_size"`
+	PositionValue    float64  `json:"position_value"`
+
+	ExitSignalTime  int64   `json:"exit_signal_time"`
+	ExitSignalPrice float64 `json:"exit_signal_price"`
+	ExitActualTime  int64   `json:"exit_actual_time"`
+	ExitActualPrice float64 `json:"exit_actual_price"`
+	ExitReason      string  `json:"exit_reason"`
+
+	TotalCostSOL float64 `json:"total_cost_sol"`
+	TotalCostPct float64 `json:"total_cost_pct"`
+
+	GrossReturn  float64 `json:"gross_return"`
+	Outcome      float64 `json:"outcome"`
+	OutcomeClass string  `json:"outcome_class"`
+
+	HoldDurationMs int64    `json:"hold_duration_ms"`
+	PeakPrice      *float64 `json:"peak_price,omitempty"`
+	MinLiquidity   *float64 `json:"min_liquidity,omitempty"`
+	PeakLiquidity  *float64 `json:"peak_liquidity,omitempty"`
+	ArmedAt        *int64   `json:"armed_at,omitempty"`
+}
+
+// TradePage is one page of GET /api/v1/trades.
+// NextCursor is empty on the last page.
+type TradePage struct {
+	Trades     []TradeResponse `json:"trades"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// AggregateResponse is a strategy aggregate.
+type AggregateResponse struct {
+	StrategyID     string `json:"strategy_id"`
+	ScenarioID     string `json:"scenario_id"`
+	EntryEventType string `json:"entry_event_type"`
+
+	TotalTrades  int     `json:"total_trades"`
+	TotalTokens  int     `json:"total_tokens"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinRate      float64 `json:"win_rate"`
+	TokenWinRate float64 `json:"token_win_rate"`
+
+	OutcomeMean   float64 `json:"outcome_mean"`
+	OutcomeMedian float64 `json:"outcome_median"`
+	OutcomeP10    float64 `json:"outcome_p10"`
+	OutcomeP25    float64 `json:"outcome_p25"`
+	OutcomeP75    float64 `json:"outcome_p75"`
+	OutcomeP90    float64 `json:"outcome_p90"`
+	OutcomeMin    float64 `json:"outcome_min"`
+	OutcomeMax    float64 `json:"outcome_max"`
+	OutcomeStddev float64 `json:"outcome_stddev"`
+
+	MaxDrawdown          float64 `json:"max_drawdown"`
+	MaxConsecutiveLosses int     `json:"max_consecutive_losses"`
+
+	OutcomeRealistic   *float64 `json:"outcome_realistic,omitempty"`
+	OutcomePessimistic *float64 `json:"outcome_pessimistic,omitempty"`
+	OutcomeDegraded    *float64 `json:"outcome_degraded,omitempty"`
+
+	DataEndExits int     `json:"data_end_exits"`
+	DataEndShare float64 `json:"data_end_share"`
+
+	ComputedAt int64 `json:"computed_at,omitempty"`
+}
+
+func tradeResponse(t *domain.TradeRecord) TradeResponse {
+	return TradeResponse{
+		TradeID:          t.TradeID,
+		CandidateID:      t.CandidateID,
+		StrategyID:       t.StrategyID,
+		ScenarioID:       t.ScenarioID,
+		EntrySignalTime:  t.EntrySignalTime,
+		EntrySignalPrice: t.EntrySignalPrice,
+		EntryActualTime:  t.EntryActualTime,
+		EntryActualPrice: t.EntryActualPrice,
+		EntryLiquidity:   t.EntryLiquidity,
+		PositionSize:     t.PositionSize,
+		PositionValue:    t.PositionValue,
+		ExitSignalTime:   t.ExitSignalTime,
+		ExitSignalPrice:  t.ExitSignalPrice,
+		ExitActualTime:   t.ExitActualTime,
+		ExitActualPrice:  t.ExitActualPrice,
+		ExitReason:       t.ExitReason,
+		TotalCostSOL:     t.TotalCostSOL,
+		TotalCostPct:     t.TotalCostPct,
+		GrossReturn:      t.GrossReturn,
+		Outcome:          t.Outcome,
+		OutcomeClass:     t.OutcomeClass,
+		HoldDurationMs:   t.HoldDurationMs,
+		PeakPrice:        t.PeakPrice,
+		MinLiquidity:     t.MinLiquidity,
+		PeakLiquidity:    t.PeakLiquidity,
+		ArmedAt:          t.ArmedAt,
+	}
+}
+
+func aggregateResponse(a *domain.StrategyAggregate) AggregateResponse {
+	return AggregateResponse{
+		StrategyID:           a.StrategyID,
+		ScenarioID:           a.ScenarioID,
+		EntryEventType:       a.EntryEventType,
+		TotalTrades:          a.TotalTrades,
+		TotalTokens:          a.TotalTokens,
+		Wins:                 a.Wins,
+		Losses:               a.Losses,
+		WinRate:              a.WinRate,
+		TokenWinRate:         a.TokenWinRate,
+		OutcomeMean:          a.OutcomeMean,
+		OutcomeMedian:        a.OutcomeMedian,
+		OutcomeP10:           a.OutcomeP10,
+		OutcomeP25:           a.OutcomeP25,
+		OutcomeP75:           a.OutcomeP75,
+		OutcomeP90:           a.OutcomeP90,
+		OutcomeMin:           a.OutcomeMin,
+		OutcomeMax:           a.OutcomeMax,
+		OutcomeStddev:        a.OutcomeStddev,
+		MaxDrawdown:          a.MaxDrawdown,
+		MaxConsecutiveLosses: a.MaxConsecutiveLosses,
+		OutcomeRealistic:     a.OutcomeRealistic,
+		OutcomePessimistic:   a.OutcomePessimistic,
+		OutcomeDegraded:      a.OutcomeDegraded,
+		DataEndExits:         a.DataEndExits,
+		DataEndShare:         a.DataEndShare,
+		ComputedAt:           a.ComputedAt,
+	}
+}
+
+// listTrades serves GET /api/v1/trades?candidate_id=&limit=&cursor=.
+// Trades are ordered by trade_id; cursor is the last trade_id of the previous page.
+func (h *Handler) listTrades(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	limit, err := pageSize(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cursor := q.Get("cursor")
+
+	// One extra row tells whether another page follows
+	var trades []*domain.TradeRecord
+	if candidateID := q.Get("candidate_id"); candidateID != "" {
+		all, err := h.stores.Trades.GetByCandidateID(ctx, candidateID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].TradeID < all[j].TradeID })
+		start := sort.Search(len(all), func(i int) bool { return all[i].TradeID > cursor })
+		trades = all[start:min(start+limit+1, len(all))]
+	} else {
+		trades, err = h.stores.Trades.GetPage(ctx, cursor, limit+1)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	page := TradePage{Trades: make([]TradeResponse, 0, min(len(trades), limit))}
+	for _, t := range trades[:min(len(trades), limit)] {
+		page.Trades = append(page.Trades, tradeResponse(t))
+	}
+	if len(trades) > limit {
+		page.NextCursor = trades[limit-1].TradeID
+	}
+
+	writeJSON(w, page)
+}
+
+// listAggregates serves GET /api/v1/aggregates?strategy_id=&scenario_id=&entry_event_type=&cohort=&computed_after=
+// with the semantics of storage.AggregateFilter. Aggregates are not paged.
+func (h *Handler) listAggregates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := storage.AggregateFilter{
+		StrategyID:     q.Get("strategy_id"),
+		ScenarioID:     q.Get("scenario_id"),
+		EntryEventType: q.Get("entry_event_type"),
+		Cohort:         q.Get("cohort"),
+	}
+	if raw := q.Get("computed_after"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			http.Error(w, "computed_after must be a Unix timestamp in milliseconds", http.StatusBadRequest)
+			return
+		}
+		filter.ComputedAfter = v
+	}
+
+	aggs, err := h.stores.Aggregates.Find(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]AggregateResponse, len(aggs))
+	for i, a := range aggs {
+		resp[i] = aggregateResponse(a)
+	}
+	writeJSON(w, resp)
+}
@@ -65,7 +65,7 @@ func TestRunner_CollectsResults(t *testing.T) {
 	_ = swapStore.InsertBulk(ctx, swaps)
 
 	liquidity := []*domain.LiquidityEvent{
-		{CandidateID: "c1", Slot: 150, TxSignature: "tx1.5", EventIndex: 0, Timestamp: 1500},
+		{CandidateID: "c1", Slot: 150, TxSignature: "tx1.5", EventIndex: 0, Timestamp: 1500, EventType: domain.LiquidityEventAdd},
 	}
 	_ = liquidityStore.InsertBulk(ctx, liquidity)
 
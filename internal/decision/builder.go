@@ -2,7 +2,9 @@ package decision
 
 import (
 	"errors"
+	"fmt"
 	"sort"
+	"strings"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/reporting"
@@ -11,9 +13,44 @@ import (
 // ErrNoRealisticScenario is returned when no realistic scenario data is found.
 var ErrNoRealisticScenario = errors.New("no realistic scenario data found")
 
-// ErrMissingPessimisticScenario is returned when pessimistic scenario data is missing.
-// Per spec, full scenario matrix is required for valid decision.
-var ErrMissingPessimisticScenario = errors.New("missing pessimistic scenario data")
+// ErrMissingScenario is returned when a strategy lacks one of RequiredScenarios.
+// The error names the missing scenarios and the strategy; reports treat it as
+// insufficient data.
+var ErrMissingScenario = errors.New("missing scenario data")
+
+// RequiredScenarios are the scenarios the gate evaluates (per DECISION_GATE.md):
+// realistic, and pessimistic for the stability check. Both presets of the
+// pipeline simulate them; the report's scenario matrix needs the decision preset.
+var RequiredScenarios = []string{domain.ScenarioRealistic, domain.ScenarioPessimistic}
+
+// checkScenarios returns ErrMissingScenario naming the required scenarios of key
+// absent from have.
+func checkScenarios(key StrategyKey, have map[string]bool) error {
+	var missing []string
+	for _, id := range RequiredScenarios {
+		if !have[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s for %s/%s (run the pipeline with the %s scenario preset)",
+		ErrMissingScenario, strings.Join(missing, ", "), key.StrategyID, key.EntryEventType, domain.ScenarioPresetDecision)
+}
+
+// scenariosByStrategy indexes the scenarios present in the report by strategy.
+func scenariosByStrategy(report *reporting.Report) map[StrategyKey]map[string]bool {
+	scenarios := make(map[StrategyKey]map[string]bool)
+	for _, m := range report.StrategyMetrics {
+		k := StrategyKey{StrategyID: m.StrategyID, EntryEventType: m.EntryEventType}
+		if scenarios[k] == nil {
+			scenarios[k] = make(map[string]bool)
+		}
+		scenarios[k][m.ScenarioID] = true
+	}
+	return scenarios
+}
 
 // StrategyKey identifies a strategy for implementability lookup.
 type StrategyKey struct {
@@ -59,6 +96,10 @@ func (b *Builder) Build(report *reporting.Report, strategyID, entryEventType str
 	if realisticMetric == nil {
 		return nil, ErrStrategyNotFound
 	}
+	key := StrategyKey{StrategyID: strategyID, EntryEventType: entryEventType}
+	if err := checkScenarios(key, scenariosByStrategy(report)[key]); err != nil {
+		return nil, err
+	}
 
 	// Find corresponding pessimistic scenario for stability check (per DECISION_GATE.md)
 	var pessimisticMean, pessimisticMedian float64
@@ -84,8 +125,8 @@ func (b *Builder) Build(report *reporting.Report, strategyID, entryEventType str
 	}
 
 	// Look up implementability from explicit map
-	key := StrategyKey{StrategyID: strategyID, EntryEventType: entryEventType}
 	implementable := b.implementable[key] // defaults to false if not in map
+	sensitivity := sensitivityRows(report)[key]
 
 	// Build DecisionInput
 	input := &DecisionInput{
@@ -105,6 +146,15 @@ func (b *Builder) Build(report *reporting.Report, strategyID, entryEventType str
 		// Strategy implementability from explicit map
 		StrategyImplementable: implementable,
 
+		// Low-confidence scenario rows do not satisfy the gate
+		LowConfidence: sensitivity.LowConfidence,
+		TotalTrades:   sensitivity.TotalTrades,
+
+		RealisticWinRate: realisticMetric.WinRate,
+		RealisticTrades:  realisticMetric.TotalTrades,
+
+		DataEndShare: realisticMetric.DataEndShare,
+
 		// Context
 		StrategyID:     realisticMetric.StrategyID,
 		EntryEventType: realisticMetric.EntryEventType,
@@ -143,6 +193,8 @@ func (b *Builder) BuildAll(report *reporting.Report) ([]*DecisionInput, error) {
 	if len(realisticMetrics) == 0 {
 		return nil, ErrNoRealisticScenario
 	}
+	sensitivity := sensitivityRows(report)
+	scenarios := scenariosByStrategy(report)
 
 	// Extract and sort keys for deterministic output order
 	keys := make([]StrategyKey, 0, len(realisticMetrics))
@@ -160,12 +212,14 @@ func (b *Builder) BuildAll(report *reporting.Report) ([]*DecisionInput, error) {
 	for _, k := range keys {
 		realistic := realisticMetrics[k]
 
-		// Get pessimistic metrics for stability check (per DECISION_GATE.md)
-		// Missing pessimistic scenario is treated as insufficient data
-		pessimistic, hasPessimistic := pessimisticMetrics[k]
-		if !hasPessimistic {
-			return nil, ErrMissingPessimisticScenario
+		// Every scenario of the matrix is required; a missing one is treated as
+		// insufficient data
+		if err := checkScenarios(k, scenarios[k]); err != nil {
+			return nil, err
 		}
+
+		// Get pessimistic metrics for stability check (per DECISION_GATE.md)
+		pessimistic := pessimisticMetrics[k]
 		pessimisticMean := pessimistic.OutcomeMean
 		pessimisticMedian := pessimistic.OutcomeMedian
 
@@ -192,6 +246,11 @@ func (b *Builder) BuildAll(report *reporting.Report) ([]*DecisionInput, error) {
 			OutcomeP75:            realistic.OutcomeP75,
 			OutcomeP90:            realistic.OutcomeP90,
 			StrategyImplementable: implementable,
+			LowConfidence:         sensitivity[k].LowConfidence,
+			TotalTrades:           sensitivity[k].TotalTrades,
+			RealisticWinRate:      realistic.WinRate,
+			RealisticTrades:       realistic.TotalTrades,
+			DataEndShare:          realistic.DataEndShare,
 			StrategyID:            realistic.StrategyID,
 			EntryEventType:        realistic.EntryEventType,
 			ScenarioID:            realistic.ScenarioID,
@@ -207,3 +266,14 @@ func (b *Builder) BuildAll(report *reporting.Report) ([]*DecisionInput, error) {
 
 	return inputs, nil
 }
+
+// sensitivityRows indexes the report's scenario sensitivity rows by strategy.
+// Reports without rows (older report.json) yield zero rows, which are not low
+// confidence.
+func sensitivityRows(report *reporting.Report) map[StrategyKey]reporting.ScenarioSensitivityRow {
+	rows := make(map[StrategyKey]reporting.ScenarioSensitivityRow, len(report.ScenarioSensitivity))
+	for _, s := range report.ScenarioSensitivity {
+		rows[StrategyKey{StrategyID: s.StrategyID, EntryEventType: s.EntryEventType}] = s
+	}
+	return rows
+}
@@ -0,0 +1,93 @@
+package decision
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/reporting"
+)
+
+func TestBuildAll_LowConfidenceIsInsufficientData(t *testing.T) {
+	// Both strategies pass every criterion; only the trade counts differ
+	metrics := func(strategyID string, trades int) []reporting.StrategyMetricRow {
+		return []reporting.StrategyMetricRow{
+			{StrategyID: strategyID, EntryEventType: "NEW_TOKEN", ScenarioID: domain.ScenarioRealistic, TotalTrades: trades,
+				TokenWinRate: 0.2, OutcomeMean: 0.08, OutcomeMedian: 0.05, OutcomeP10: -0.02, OutcomeP25: 0.02, OutcomeP75: 0.1, OutcomeP90: 0.15},
+			{StrategyID: strategyID, EntryEventType: "NEW_TOKEN", ScenarioID: domain.ScenarioPessimistic, TotalTrades: trades,
+				OutcomeMean: 0.04, OutcomeMedian: 0.03},
+		}
+	}
+	report := &reporting.Report{
+		StrategyMetrics: append(metrics("TIME_EXIT", 900), metrics("TRAILING_STOP", 3)...),
+		ScenarioSensitivity: []reporting.ScenarioSensitivityRow{
+			{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN", TotalTrades: 900},
+			{StrategyID: "TRAILING_STOP", EntryEventType: "NEW_TOKEN", TotalTrades: 3, LowConfidence: true},
+		},
+	}
+	builder := NewBuilder(map[StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:     true,
+		{StrategyID: "TRAILING_STOP", EntryEventType: "NEW_TOKEN"}: true,
+	})
+
+	inputs, err := builder.BuildAll(report)
+	if err != nil {
+		t.Fatalf("BuildAll failed: %v", err)
+	}
+	want := map[string]Decision{"TIME_EXIT": DecisionGO, "TRAILING_STOP": DecisionInsufficientData}
+	for _, input := range inputs {
+		result, err := NewEvaluator().Evaluate(*input)
+		if err != nil {
+			t.Fatalf("Evaluate failed: %v", err)
+		}
+		if result.Decision != want[input.StrategyID] {
+			t.Errorf("%s: expected %s, got %s", input.StrategyID, want[input.StrategyID], result.Decision)
+		}
+		if input.StrategyID == "TRAILING_STOP" {
+			if len(result.GOCriteria) != 5 || len(result.NOGOChecks) != 4 {
+				t.Errorf("criteria should still be reported, got %d GO and %d NO-GO", len(result.GOCriteria), len(result.NOGOChecks))
+			}
+			if md := RenderMarkdown(result); !strings.Contains(md, "only 3 trades (low confidence)") {
+				t.Errorf("markdown should explain the decision:\n%s", md)
+			}
+		}
+	}
+
+	// Build agrees with BuildAll
+	input, err := builder.Build(report, "TRAILING_STOP", "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if !input.LowConfidence || input.TotalTrades != 3 {
+		t.Errorf("Build: expected low confidence with 3 trades, got %+v", input)
+	}
+}
+
+func TestBuild_MissingScenarioFailsClearly(t *testing.T) {
+	// A report built from monitoring runs only, minus pessimistic for TRAILING_STOP
+	report := &reporting.Report{
+		StrategyMetrics: []reporting.StrategyMetricRow{
+			{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN", ScenarioID: domain.ScenarioRealistic, TokenWinRate: 0.2},
+			{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN", ScenarioID: domain.ScenarioPessimistic},
+			{StrategyID: "TRAILING_STOP", EntryEventType: "NEW_TOKEN", ScenarioID: domain.ScenarioRealistic, TokenWinRate: 0.2},
+		},
+	}
+	builder := NewBuilder(nil)
+
+	_, err := builder.BuildAll(report)
+	if !errors.Is(err, ErrMissingScenario) {
+		t.Fatalf("BuildAll: expected ErrMissingScenario, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "pessimistic for TRAILING_STOP/NEW_TOKEN") || !strings.Contains(msg, domain.ScenarioPresetDecision) {
+		t.Errorf("error should name the scenario, strategy and preset: %q", msg)
+	}
+
+	// Build used to fall back to zero pessimistic outcomes
+	if _, err := builder.Build(report, "TRAILING_STOP", "NEW_TOKEN"); !errors.Is(err, ErrMissingScenario) {
+		t.Errorf("Build: expected ErrMissingScenario, got %v", err)
+	}
+	if _, err := builder.Build(report, "TIME_EXIT", "NEW_TOKEN"); err != nil {
+		t.Errorf("Build of a complete strategy failed: %v", err)
+	}
+}
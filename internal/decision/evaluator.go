@@ -3,14 +3,39 @@ package decision
 import "fmt"
 
 // Evaluator evaluates decision criteria.
-type Evaluator struct{}
+type Evaluator struct {
+	maxDataEndPct float64 // 0: DATA_END exits count like any other
+
+	// Win rate precision sample size guidance aims for; 0 margin disables it
+	targetMargin float64
+	confidence   float64
+}
 
 // NewEvaluator creates a new decision evaluator.
 func NewEvaluator() *Evaluator {
-	return &Evaluator{}
+	return &Evaluator{targetMargin: DefaultTargetMargin, confidence: DefaultConfidence}
+}
+
+// WithMaxDataEndPct adds a NO-GO trigger for strategies whose realistic trades
+// exit on DATA_END more than pct percent of the time: their outcomes rest on
+// the last price of dead tokens rather than on the strategy's exit. 0 disables.
+func (e *Evaluator) WithMaxDataEndPct(pct float64) *Evaluator {
+	e.maxDataEndPct = pct
+	return e
+}
+
+// WithSampleSizeTarget sets the precision sample size guidance aims for: the
+// realistic win rate within ±margin (0-1) at confidence (0-1). A decision
+// other than GO resting on a less precise win rate tells how many more trades
+// reach it. A margin of 0 disables the guidance.
+func (e *Evaluator) WithSampleSizeTarget(margin, confidence float64) *Evaluator {
+	e.targetMargin = margin
+	e.confidence = confidence
+	return e
 }
 
 // Evaluate produces DecisionResult from DecisionInput.
+// INSUFFICIENT_DATA if the input is low confidence; criteria are still reported.
 // GO if ALL criteria pass and NO NO-GO triggers.
 // NO-GO if ANY criterion fails or ANY trigger fires.
 // Returns error if input validation fails.
@@ -40,15 +65,28 @@ func (e *Evaluator) Evaluate(input DecisionInput) (*DecisionResult, error) {
 	}
 
 	decision := DecisionGO
-	if !allGOPass || anyNOGOTriggered {
+	switch {
+	case input.LowConfidence:
+		decision = DecisionInsufficientData
+	case !allGOPass || anyNOGOTriggered:
 		decision = DecisionNOGO
 	}
 
-	return &DecisionResult{
-		Decision:   decision,
-		GOCriteria: goCriteria,
-		NOGOChecks: nogoChecks,
-	}, nil
+	result := &DecisionResult{
+		Decision:    decision,
+		GOCriteria:  goCriteria,
+		NOGOChecks:  nogoChecks,
+		TotalTrades: input.TotalTrades,
+	}
+	// INSUFFICIENT_DATA comes from too few trades; a NO-GO is attributed to the
+	// sample size while the win rate is less precise than the target
+	if decision != DecisionGO && e.targetMargin > 0 {
+		g := NewSampleSizeGuidance(input.RealisticWinRate, input.RealisticTrades, e.targetMargin, e.confidence)
+		if g.AdditionalTrades > 0 {
+			result.SampleSize = &g
+		}
+	}
+	return result, nil
 }
 
 // evaluateGOCriteria evaluates the 5 GO criteria.
@@ -111,7 +149,8 @@ func (e *Evaluator) evaluateGOCriteria(input DecisionInput) []CriterionResult {
 	return criteria
 }
 
-// evaluateNOGOTriggers evaluates the 4 NO-GO triggers.
+// evaluateNOGOTriggers evaluates the 4 NO-GO triggers, plus the DATA_END cap
+// when configured.
 // Pass=true means NOT triggered, Pass=false means triggered.
 func (e *Evaluator) evaluateNOGOTriggers(input DecisionInput) []CriterionResult {
 	checks := make([]CriterionResult, 4)
@@ -153,5 +192,16 @@ func (e *Evaluator) evaluateNOGOTriggers(input DecisionInput) []CriterionResult
 		Pass:      !triggered4,
 	}
 
+	// 5. Outcomes rest on truncated data: DATA_END share above the cap triggers NO-GO
+	if e.maxDataEndPct > 0 {
+		dataEndPct := input.DataEndShare * 100
+		checks = append(checks, CriterionResult{
+			Name:      "Outcomes rest on truncated price data",
+			Threshold: fmt.Sprintf("DATA_END exits > %.2f%%", e.maxDataEndPct),
+			Actual:    fmt.Sprintf("%.2f%%", dataEndPct),
+			Pass:      dataEndPct <= e.maxDataEndPct,
+		})
+	}
+
 	return checks
 }
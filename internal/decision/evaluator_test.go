@@ -207,6 +207,54 @@ func TestEvaluate_NOGO_NotImplementable(t *testing.T) {
 	}
 }
 
+func TestEvaluate_DataEndCap(t *testing.T) {
+	input := DecisionInput{
+		PositiveOutcomePct:    10.0,
+		MedianOutcome:         0.05,
+		RealisticMean:         0.08,
+		RealisticMedian:       0.05,
+		PessimisticMean:       0.04,
+		PessimisticMedian:     0.03,
+		OutcomeP25:            0.02,
+		OutcomeP50:            0.05,
+		OutcomeP75:            0.10,
+		StrategyImplementable: true,
+		DataEndShare:          0.25, // a quarter of the trades exited on dead tokens
+		StrategyID:            "TEST",
+		ScenarioID:            domain.ScenarioRealistic,
+	}
+
+	// Without a cap DATA_END exits count like any other
+	result, err := NewEvaluator().Evaluate(input)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result.Decision != DecisionGO || len(result.NOGOChecks) != 4 {
+		t.Errorf("expected GO with 4 NO-GO checks, got %s with %d", result.Decision, len(result.NOGOChecks))
+	}
+
+	// Capped below the share: NO-GO
+	result, err = NewEvaluator().WithMaxDataEndPct(20).Evaluate(input)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result.Decision != DecisionNOGO || len(result.NOGOChecks) != 5 || result.NOGOChecks[4].Pass {
+		t.Errorf("expected NO-GO from the DATA_END trigger, got %s: %+v", result.Decision, result.NOGOChecks)
+	}
+	if result.NOGOChecks[4].Actual != "25.00%" {
+		t.Errorf("unexpected DATA_END actual: %s", result.NOGOChecks[4].Actual)
+	}
+
+	// Capped above the share: GO
+	result, err = NewEvaluator().WithMaxDataEndPct(30).Evaluate(input)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result.Decision != DecisionGO {
+		t.Errorf("expected GO under the cap, got %s", result.Decision)
+	}
+}
+
 func TestEvaluate_Deterministic(t *testing.T) {
 	evaluator := NewEvaluator()
 
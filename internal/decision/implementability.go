@@ -0,0 +1,99 @@
+package decision
+
+import (
+	"sort"
+
+	"solana-token-lab/internal/domain"
+)
+
+// Requirement is a capability a deployment must provide for a strategy to be
+// traded live.
+type Requirement string
+
+const (
+	// RequirementPriceFeed needs real-time swap prices: every entry is taken
+	// on a live discovery signal and exits are priced from the swap stream.
+	RequirementPriceFeed Requirement = "realtime_price_feed"
+	// RequirementLiquidityFeed needs pool liquidity tracked in real time.
+	RequirementLiquidityFeed Requirement = "liquidity_feed"
+)
+
+// StrategyRequirements is the implementability registry: what each strategy
+// needs from the deployment, for every entry event type.
+var StrategyRequirements = map[string][]Requirement{
+	domain.StrategyTypeTimeExit:       {RequirementPriceFeed},
+	domain.StrategyTypeTrailingStop:   {RequirementPriceFeed},
+	domain.StrategyTypeLiquidityGuard: {RequirementPriceFeed, RequirementLiquidityFeed},
+}
+
+// entryEventTypes are the entry event types every strategy is evaluated for.
+var entryEventTypes = []string{string(domain.SourceNewToken), string(domain.SourceActiveToken)}
+
+// Deployment describes the capabilities of the deployment a decision is made for.
+type Deployment struct {
+	RealtimePriceFeed bool // WebSocket swap feeds enabled
+	LiquidityFeed     bool // liquidity event tracking enabled
+}
+
+// Provides reports whether the deployment satisfies r.
+func (d Deployment) Provides(r Requirement) bool {
+	switch r {
+	case RequirementPriceFeed:
+		return d.RealtimePriceFeed
+	case RequirementLiquidityFeed:
+		return d.LiquidityFeed
+	}
+	return false
+}
+
+// ImplementabilityEntry is the resolved implementability of one strategy and entry event type.
+type ImplementabilityEntry struct {
+	StrategyID     string        `json:"strategy_id"`
+	EntryEventType string        `json:"entry_event_type"`
+	Implementable  bool          `json:"implementable"`
+	Requirements   []Requirement `json:"requirements"`
+	Missing        []Requirement `json:"missing,omitempty"` // requirements the deployment does not provide
+}
+
+// Implementability is the resolved matrix of every registered strategy and
+// entry event type, ordered by strategy ID then entry event type.
+type Implementability []ImplementabilityEntry
+
+// ResolveImplementability evaluates StrategyRequirements against d.
+func ResolveImplementability(d Deployment) Implementability {
+	strategies := make([]string, 0, len(StrategyRequirements))
+	for id := range StrategyRequirements {
+		strategies = append(strategies, id)
+	}
+	sort.Strings(strategies)
+
+	matrix := make(Implementability, 0, len(strategies)*len(entryEventTypes))
+	for _, id := range strategies {
+		reqs := StrategyRequirements[id]
+		var missing []Requirement
+		for _, r := range reqs {
+			if !d.Provides(r) {
+				missing = append(missing, r)
+			}
+		}
+		for _, entry := range entryEventTypes {
+			matrix = append(matrix, ImplementabilityEntry{
+				StrategyID:     id,
+				EntryEventType: entry,
+				Implementable:  len(missing) == 0,
+				Requirements:   reqs,
+				Missing:        missing,
+			})
+		}
+	}
+	return matrix
+}
+
+// Map returns the matrix as the lookup NewBuilder takes.
+func (m Implementability) Map() map[StrategyKey]bool {
+	out := make(map[StrategyKey]bool, len(m))
+	for _, e := range m {
+		out[StrategyKey{StrategyID: e.StrategyID, EntryEventType: e.EntryEventType}] = e.Implementable
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package decision
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func TestResolveImplementability(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment Deployment
+		want       map[string]bool // strategy -> implementable, for both entry types
+	}{
+		{
+			name:       "all feeds",
+			deployment: Deployment{RealtimePriceFeed: true, LiquidityFeed: true},
+			want: map[string]bool{
+				domain.StrategyTypeTimeExit:       true,
+				domain.StrategyTypeTrailingStop:   true,
+				domain.StrategyTypeLiquidityGuard: true,
+			},
+		},
+		{
+			name:       "no liquidity tracking",
+			deployment: Deployment{RealtimePriceFeed: true},
+			want: map[string]bool{
+				domain.StrategyTypeTimeExit:       true,
+				domain.StrategyTypeTrailingStop:   true,
+				domain.StrategyTypeLiquidityGuard: false,
+			},
+		},
+		{
+			name:       "no real-time feeds",
+			deployment: Deployment{LiquidityFeed: true},
+			want: map[string]bool{
+				domain.StrategyTypeTimeExit:       false,
+				domain.StrategyTypeTrailingStop:   false,
+				domain.StrategyTypeLiquidityGuard: false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix := ResolveImplementability(tt.deployment)
+			if len(matrix) != len(StrategyRequirements)*2 {
+				t.Fatalf("expected every strategy for both entry types, got %d entries", len(matrix))
+			}
+			lookup := matrix.Map()
+			for strategy, want := range tt.want {
+				for _, entry := range []string{"NEW_TOKEN", "ACTIVE_TOKEN"} {
+					if got := lookup[StrategyKey{StrategyID: strategy, EntryEventType: entry}]; got != want {
+						t.Errorf("%s/%s: implementable = %v, want %v", strategy, entry, got, want)
+					}
+				}
+			}
+			for _, e := range matrix {
+				if e.Implementable != (len(e.Missing) == 0) {
+					t.Errorf("%s/%s: implementable=%v with missing %v", e.StrategyID, e.EntryEventType, e.Implementable, e.Missing)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveImplementability_ReportsMissing(t *testing.T) {
+	for _, e := range ResolveImplementability(Deployment{RealtimePriceFeed: true}) {
+		if e.StrategyID == domain.StrategyTypeLiquidityGuard &&
+			(len(e.Missing) != 1 || e.Missing[0] != RequirementLiquidityFeed) {
+			t.Errorf("expected liquidity feed missing for %s/%s, got %v", e.StrategyID, e.EntryEventType, e.Missing)
+		}
+	}
+}
+
+// TestBinariesUseRegistry fails when a binary defines its own implementability
+// map instead of resolving the registry, so all binaries decide alike.
+func TestBinariesUseRegistry(t *testing.T) {
+	root := filepath.Join("..", "..", "cmd")
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			mt, ok := n.(*ast.MapType)
+			if !ok {
+				return true
+			}
+			if sel, ok := mt.Key.(*ast.SelectorExpr); ok && sel.Sel.Name == "StrategyKey" {
+				t.Errorf("%s: defines a map keyed by decision.StrategyKey; use decision.ResolveImplementability", fset.Position(mt.Pos()))
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk cmd: %v", err)
+	}
+}
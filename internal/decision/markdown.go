@@ -2,6 +2,7 @@ package decision
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -59,10 +60,25 @@ func RenderMarkdown(result *DecisionResult) string {
 	}
 	sb.WriteString(fmt.Sprintf("NO-GO Triggers: %d/%d triggered\n\n", nogoTriggered, len(result.NOGOChecks)))
 
+	// Trades still needed for a trustworthy win rate
+	if g := result.SampleSize; g != nil {
+		sb.WriteString("## Data Needed\n\n")
+		sb.WriteString(fmt.Sprintf("| Win Rate | Trades | Margin (%.0f%% CI) | Target | Trades Needed | Additional Trades |\n", g.Confidence*100))
+		sb.WriteString("|----------|--------|---------------|--------|---------------|-------------------|\n")
+		margin := "n/a"
+		if !math.IsInf(g.Margin, 1) {
+			margin = fmt.Sprintf("±%.2f%%", g.Margin*100)
+		}
+		sb.WriteString(fmt.Sprintf("| %.2f%% | %d | %s | ±%.2f%% | %d | %d |\n\n",
+			g.WinRate*100, g.Trades, margin, g.TargetMargin*100, g.RequiredTrades, g.AdditionalTrades))
+	}
+
 	// Summary
 	sb.WriteString("## Summary\n\n")
 	if result.Decision == DecisionGO {
 		sb.WriteString("All GO criteria passed and no NO-GO triggers fired.\n")
+	} else if result.Decision == DecisionInsufficientData {
+		sb.WriteString(fmt.Sprintf("Insufficient data: the scenario outcomes are backed by only %d trades (low confidence), so the criteria above do not count.\n", result.TotalTrades))
 	} else {
 		sb.WriteString("Decision is NO-GO due to:\n")
 		for _, c := range result.GOCriteria {
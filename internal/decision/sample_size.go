@@ -0,0 +1,73 @@
+package decision
+
+import "math"
+
+// Default precision the sample size guidance aims for: the realistic win rate
+// known to within ±5 percentage points at 95% confidence.
+const (
+	DefaultTargetMargin = 0.05
+	DefaultConfidence   = 0.95
+)
+
+// ZScore returns the two-sided standard normal quantile of confidence (0-1),
+// e.g. 1.96 for 0.95.
+func ZScore(confidence float64) float64 {
+	return math.Sqrt2 * math.Erfinv(confidence)
+}
+
+// winRateVariance returns p(1-p), or the worst case 0.25 when there are no
+// trades or the observed rate is 0 or 1, where the normal approximation
+// would claim a certainty the sample cannot give.
+func winRateVariance(winRate float64, trades int) float64 {
+	v := winRate * (1 - winRate)
+	if trades <= 0 || v <= 0 {
+		return 0.25
+	}
+	return v
+}
+
+// WinRateMargin returns the half-width of the normal-approximation confidence
+// interval of a win rate observed over trades: z * sqrt(p(1-p) / n).
+// Without trades the margin is infinite.
+func WinRateMargin(winRate float64, trades int, confidence float64) float64 {
+	if trades <= 0 {
+		return math.Inf(1)
+	}
+	return ZScore(confidence) * math.Sqrt(winRateVariance(winRate, trades)/float64(trades))
+}
+
+// RequiredTrades returns the trades needed to estimate winRate within ±margin
+// at confidence: ceil(z² p(1-p) / margin²).
+func RequiredTrades(winRate float64, trades int, margin, confidence float64) int {
+	z := ZScore(confidence)
+	return int(math.Ceil(z * z * winRateVariance(winRate, trades) / (margin * margin)))
+}
+
+// SampleSizeGuidance tells how many more trades a strategy needs before its
+// realistic win rate reaches the target precision.
+type SampleSizeGuidance struct {
+	WinRate          float64 // observed trade-level win rate (0-1)
+	Trades           int     // trades behind WinRate
+	Margin           float64 // current half-width of the confidence interval
+	TargetMargin     float64 // half-width aimed for
+	Confidence       float64 // confidence level of both margins (0-1)
+	RequiredTrades   int     // trades giving TargetMargin
+	AdditionalTrades int     // RequiredTrades - Trades, 0 if already reached
+}
+
+// NewSampleSizeGuidance computes the guidance for a win rate observed over
+// trades and a target margin at confidence.
+func NewSampleSizeGuidance(winRate float64, trades int, targetMargin, confidence float64) SampleSizeGuidance {
+	g := SampleSizeGuidance{
+		WinRate:        winRate,
+		Trades:         trades,
+		Margin:         WinRateMargin(winRate, trades, confidence),
+		TargetMargin:   targetMargin,
+		Confidence:     confidence,
+		RequiredTrades: RequiredTrades(winRate, trades, targetMargin, confidence),
+	}
+	if g.RequiredTrades > trades {
+		g.AdditionalTrades = g.RequiredTrades - trades
+	}
+	return g
+}
@@ -0,0 +1,107 @@
+package decision
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func TestZScore(t *testing.T) {
+	for _, tt := range []struct{ confidence, want float64 }{
+		{0.90, 1.644854},
+		{0.95, 1.959964},
+		{0.99, 2.575829},
+	} {
+		if got := ZScore(tt.confidence); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("ZScore(%v) = %v, want %v", tt.confidence, got, tt.want)
+		}
+	}
+}
+
+func TestNewSampleSizeGuidance(t *testing.T) {
+	// margin = z * sqrt(p(1-p)/n), required = ceil(z² p(1-p) / target²)
+	tests := []struct {
+		name                 string
+		winRate              float64
+		trades               int
+		target, confidence   float64
+		margin               float64
+		required, additional int
+	}{
+		// 1.96 * sqrt(0.25/100) = 0.0980; 3.8415 * 0.25 / 0.0025 = 384.15
+		{"even", 0.5, 100, 0.05, 0.95, 0.097998, 385, 285},
+		// 1.96 * sqrt(0.21/100) = 0.0898; 3.8415 * 0.21 / 0.0025 = 322.68
+		{"thirty percent", 0.3, 100, 0.05, 0.95, 0.089817, 323, 223},
+		// 1.96 * sqrt(0.24/400) = 0.0480; 3.8415 * 0.24 / 0.0025 = 368.78
+		{"precise enough", 0.6, 400, 0.05, 0.95, 0.048009, 369, 0},
+		// 2.5758 * sqrt(0.09/50) = 0.1093; 6.6349 * 0.09 / 0.0009 = 663.49
+		{"99 percent", 0.1, 50, 0.03, 0.99, 0.109283, 664, 614},
+		// No win observed: worst case p(1-p) = 0.25; 1.96 * sqrt(0.25/20) = 0.2191
+		{"no wins", 0, 20, 0.05, 0.95, 0.219131, 385, 365},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewSampleSizeGuidance(tt.winRate, tt.trades, tt.target, tt.confidence)
+			if math.Abs(g.Margin-tt.margin) > 1e-6 {
+				t.Errorf("margin = %v, want %v", g.Margin, tt.margin)
+			}
+			if g.RequiredTrades != tt.required || g.AdditionalTrades != tt.additional {
+				t.Errorf("required %d (+%d), want %d (+%d)", g.RequiredTrades, g.AdditionalTrades, tt.required, tt.additional)
+			}
+		})
+	}
+
+	g := NewSampleSizeGuidance(0, 0, 0.05, 0.95)
+	if !math.IsInf(g.Margin, 1) || g.RequiredTrades != 385 || g.AdditionalTrades != 385 {
+		t.Errorf("without trades expected an infinite margin and 385 trades, got %+v", g)
+	}
+}
+
+func TestEvaluate_SampleSizeGuidance(t *testing.T) {
+	input := DecisionInput{
+		PositiveOutcomePct:    3.0, // NO-GO
+		MedianOutcome:         0.01,
+		RealisticMedian:       0.01,
+		PessimisticMedian:     0.01,
+		OutcomeP25:            0.01,
+		StrategyImplementable: true,
+		RealisticWinRate:      0.3,
+		RealisticTrades:       100,
+		StrategyID:            "TIME_EXIT",
+		EntryEventType:        "NEW_TOKEN",
+		ScenarioID:            domain.ScenarioRealistic,
+	}
+
+	result, err := NewEvaluator().Evaluate(input)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result.Decision != DecisionNOGO || result.SampleSize == nil || result.SampleSize.AdditionalTrades != 223 {
+		t.Fatalf("expected NO-GO with 223 more trades needed, got %s %+v", result.Decision, result.SampleSize)
+	}
+	md := RenderMarkdown(result)
+	if !strings.Contains(md, "| 30.00% | 100 | ±8.98% | ±5.00% | 323 | 223 |") {
+		t.Errorf("markdown missing data needed row:\n%s", md)
+	}
+
+	// A precise enough win rate is not a sample size problem
+	input.RealisticTrades = 1000
+	if result, _ := NewEvaluator().Evaluate(input); result.SampleSize != nil {
+		t.Errorf("expected no guidance with 1000 trades, got %+v", result.SampleSize)
+	}
+
+	// Disabled
+	input.RealisticTrades = 100
+	if result, _ := NewEvaluator().WithSampleSizeTarget(0, 0).Evaluate(input); result.SampleSize != nil {
+		t.Errorf("expected no guidance when disabled, got %+v", result.SampleSize)
+	}
+
+	// GO needs no guidance
+	input.PositiveOutcomePct = 10
+	result, _ = NewEvaluator().Evaluate(input)
+	if result.Decision != DecisionGO || result.SampleSize != nil || strings.Contains(RenderMarkdown(result), "Data Needed") {
+		t.Errorf("expected GO without guidance, got %s %+v", result.Decision, result.SampleSize)
+	}
+}
@@ -51,6 +51,20 @@ type DecisionInput struct {
 	// Strategy implementability (true if strategy exists and delay within scenario)
 	StrategyImplementable bool
 
+	// Scenario medians backed by too few trades (see reporting.ScenarioSensitivityRow):
+	// the strategy is INSUFFICIENT_DATA whatever the criteria say
+	LowConfidence bool
+	TotalTrades   int
+
+	// Realistic trade-level win rate (0-1) and the trades behind it, for the
+	// sample size guidance of a decision other than GO
+	RealisticWinRate float64
+	RealisticTrades  int
+
+	// Share (0-1) of realistic trades forced out at the end of their price
+	// data (DATA_END), capped with Evaluator.WithMaxDataEndPct
+	DataEndShare float64
+
 	// Strategy type + entry event type for context in report
 	StrategyID     string
 	EntryEventType string
@@ -90,7 +104,12 @@ type CriterionResult struct {
 
 // DecisionResult contains the final decision with checklist.
 type DecisionResult struct {
-	Decision   Decision
-	GOCriteria []CriterionResult // 5 GO criteria
-	NOGOChecks []CriterionResult // 4 NO-GO triggers
+	Decision    Decision
+	GOCriteria  []CriterionResult // 5 GO criteria
+	NOGOChecks  []CriterionResult // 4 NO-GO triggers, 5 with a DATA_END cap
+	TotalTrades int               // trades behind the scenario medians, for INSUFFICIENT_DATA
+
+	// Trades still needed for the target win rate precision, set when the
+	// decision is not GO and the win rate is less precise than the target
+	SampleSize *SampleSizeGuidance
 }
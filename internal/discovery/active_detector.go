@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math"
 	"sync"
+	"time"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/idhash"
@@ -24,6 +25,17 @@ type ActiveTokenConfig struct {
 	KLiq      float64 // liquidity spike threshold (default 2.0)
 	Window1h  int64   // 1-hour window in ms (3600000)
 	Window24h int64   // 24-hour window in ms (86400000)
+
+	// RequirePositiveImbalance makes a volume/swaps spike qualify only if the
+	// 1h window has more buy than sell volume. Swaps without a side are ignored,
+	// so a window with no sided swaps never qualifies.
+	RequirePositiveImbalance bool
+
+	// MinUniqueTraders makes a volume/swaps spike qualify only if at least this
+	// many distinct traders swapped in the 1h window, so one wallet churning
+	// volume cannot trigger detection. Swaps without a trader are not counted.
+	// 0 disables the check.
+	MinUniqueTraders int
 }
 
 // DefaultActiveConfig returns default configuration per spec.
@@ -43,6 +55,8 @@ type ActiveTokenDetector struct {
 	swapEventStore      storage.SwapEventStore
 	candidateStore      storage.CandidateStore
 	liquidityEventStore storage.LiquidityEventStore // optional, for liquidity spike detection
+	clock               *liveClock                  // optional, set for live ingestion only
+	mintFilter          *MintFilter                 // optional, blacklist/allowlist
 	seenMints           map[string]bool
 	seenMintsMu         sync.RWMutex // protects seenMints from concurrent access
 }
@@ -67,6 +81,19 @@ func (d *ActiveTokenDetector) WithLiquidityStore(store storage.LiquidityEventSto
 	return d
 }
 
+// WithLiveClock marks this detector as live: candidates get DetectedAt from now
+// and, if record is non-nil, their discovery latency is reported.
+func (d *ActiveTokenDetector) WithLiveClock(now func() time.Time, record LatencyRecorder) *ActiveTokenDetector {
+	d.clock = &liveClock{now: now, record: record}
+	return d
+}
+
+// WithMintFilter restricts which mints may become candidates.
+func (d *ActiveTokenDetector) WithMintFilter(f *MintFilter) *ActiveTokenDetector {
+	d.mintFilter = f
+	return d
+}
+
 // DetectAt evaluates all mints with activity in last 24h at the given timestamp.
 // Returns discovered ACTIVE_TOKEN candidates.
 func (d *ActiveTokenDetector) DetectAt(ctx context.Context, evalTimestamp int64) ([]*domain.TokenCandidate, error) {
@@ -145,6 +172,12 @@ func (d *ActiveTokenDetector) EvaluateMint(ctx context.Context, mint string, eva
 		return nil, nil
 	}
 
+	// Only spiking mints are checked, so the filter counts would-be candidates
+	allowed, err := d.mintFilter.Allow(ctx, mint)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
 	// Determine trigger event: prefer swap if available, otherwise use liquidity event
 	var candidate *domain.TokenCandidate
 	if triggerSwap != nil {
@@ -154,6 +187,7 @@ func (d *ActiveTokenDetector) EvaluateMint(ctx context.Context, mint string, eva
 	} else {
 		return nil, nil
 	}
+	d.clock.stamp(candidate)
 
 	// Try to insert
 	err = d.candidateStore.Insert(ctx, candidate)
@@ -170,6 +204,7 @@ func (d *ActiveTokenDetector) EvaluateMint(ctx context.Context, mint string, eva
 	d.seenMintsMu.Lock()
 	d.seenMints[mint] = true
 	d.seenMintsMu.Unlock()
+	d.clock.observe(candidate)
 	return candidate, nil
 }
 
@@ -218,11 +253,52 @@ func (d *ActiveTokenDetector) checkSwapSpikes(swaps24h []*domain.SwapEvent, star
 		return false, false, nil
 	}
 
+	if d.config.RequirePositiveImbalance && swapImbalance(swaps24h, start1h, evalTimestamp) <= 0 {
+		return false, false, nil
+	}
+
+	if d.config.MinUniqueTraders > 0 && UniqueTraders(swaps24h, start1h, evalTimestamp) < d.config.MinUniqueTraders {
+		return false, false, nil
+	}
+
 	// Find triggering swap
 	triggerSwap := d.findTriggerSwap(swaps24h, start1h, evalTimestamp)
 	return volumeSpike, swapsSpike, triggerSwap
 }
 
+// swapImbalance returns (buy - sell) / (buy + sell) of swap volume in [start, end),
+// or 0 when no swap in the window has a side.
+func swapImbalance(swaps []*domain.SwapEvent, start, end int64) float64 {
+	var buy, sell float64
+	for _, swap := range swaps {
+		if swap.Timestamp < start || swap.Timestamp >= end {
+			continue
+		}
+		switch swap.Side {
+		case domain.SwapSideBuy:
+			buy += swap.AmountOut
+		case domain.SwapSideSell:
+			sell += swap.AmountOut
+		}
+	}
+	if buy+sell == 0 {
+		return 0
+	}
+	return (buy - sell) / (buy + sell)
+}
+
+// UniqueTraders returns the number of distinct non-empty traders among swaps in [start, end).
+func UniqueTraders(swaps []*domain.SwapEvent, start, end int64) int {
+	seen := make(map[string]struct{})
+	for _, swap := range swaps {
+		if swap.Trader == "" || swap.Timestamp < start || swap.Timestamp >= end {
+			continue
+		}
+		seen[swap.Trader] = struct{}{}
+	}
+	return len(seen)
+}
+
 // findTriggerSwap finds the swap that triggered the spike.
 // Uses max timestamp with deterministic tie-breaker per NORMALIZATION_SPEC.md.
 func (d *ActiveTokenDetector) findTriggerSwap(swaps24h []*domain.SwapEvent, start1h, evalTimestamp int64) *domain.SwapEvent {
@@ -366,6 +442,7 @@ func (d *ActiveTokenDetector) createCandidateFromSwap(swap *domain.SwapEvent) *d
 		EventIndex:   swap.EventIndex,
 		Slot:         swap.Slot,
 		DiscoveredAt: swap.Timestamp,
+		DEX:          swap.DEX,
 	}
 }
 
@@ -390,6 +467,7 @@ func (d *ActiveTokenDetector) createCandidateFromLiquidity(evt *domain.Liquidity
 		EventIndex:   evt.EventIndex,
 		Slot:         evt.Slot,
 		DiscoveredAt: evt.Timestamp,
+		DEX:          evt.DEX,
 	}
 }
 
@@ -290,6 +290,58 @@ func TestActiveDetector_WindowBoundaries(t *testing.T) {
 	}
 }
 
+func TestActiveDetector_SpikeAtWindowEnd_CountedNextEvaluation(t *testing.T) {
+	swapEventStore := memory.NewSwapEventStore()
+	candidateStore := memory.NewCandidateStore()
+	ctx := context.Background()
+
+	evalTime := int64(86400000)
+
+	// Low baseline volume across the 24h window
+	for i := 0; i < 24; i++ {
+		_ = swapEventStore.Insert(ctx, &domain.SwapEvent{
+			Mint:        "MintG",
+			TxSignature: "tx" + string(rune('a'+i)),
+			EventIndex:  0,
+			Slot:        int64(100 + i),
+			Timestamp:   int64(i) * Window1hMs,
+			AmountOut:   10.0,
+		})
+	}
+
+	// Spike lands exactly on evalTime: outside [start, evalTime)
+	_ = swapEventStore.Insert(ctx, &domain.SwapEvent{
+		Mint:        "MintG",
+		TxSignature: "txSpikeAtEnd",
+		EventIndex:  0,
+		Slot:        200,
+		Timestamp:   evalTime,
+		AmountOut:   100.0,
+	})
+
+	detector := NewActiveDetector(DefaultActiveConfig(), swapEventStore, candidateStore)
+
+	candidates, err := detector.DetectAt(ctx, evalTime)
+	if err != nil {
+		t.Fatalf("DetectAt failed: %v", err)
+	}
+	if len(candidates) != 0 {
+		t.Fatalf("Expected 0 candidates (spike at exclusive end), got %d", len(candidates))
+	}
+
+	// One millisecond later the spike is inside the window
+	candidates, err = detector.DetectAt(ctx, evalTime+1)
+	if err != nil {
+		t.Fatalf("DetectAt failed: %v", err)
+	}
+	if len(candidates) != 1 {
+		t.Fatalf("Expected 1 candidate, got %d", len(candidates))
+	}
+	if candidates[0].TxSignature != "txSpikeAtEnd" {
+		t.Errorf("Expected trigger txSpikeAtEnd, got %s", candidates[0].TxSignature)
+	}
+}
+
 func TestActiveDetector_TriggeringSwap(t *testing.T) {
 	swapEventStore := memory.NewSwapEventStore()
 	candidateStore := memory.NewCandidateStore()
@@ -652,3 +704,111 @@ func TestActiveDetector_LiquiditySpikeWithMint_NoCandidateID(t *testing.T) {
 		t.Errorf("Expected mint %s, got %s", mint, candidate.Mint)
 	}
 }
+
+func TestActiveDetector_RequirePositiveImbalance(t *testing.T) {
+	evalTime := int64(86400000)
+
+	// Same volume spike as TestActiveDetector_VolumeSpikeTriggered; the 1h window
+	// holds the spike swap plus a smaller opposite-side swap.
+	setup := func(spikeSide, otherSide string) *memory.SwapEventStore {
+		store := memory.NewSwapEventStore()
+		ctx := context.Background()
+		for i := 0; i < 24; i++ {
+			_ = store.Insert(ctx, &domain.SwapEvent{
+				Mint: "MintA", TxSignature: "tx" + string(rune('a'+i)), Slot: int64(100 + i),
+				Timestamp: int64(i * 3600000), AmountOut: 10.0,
+			})
+		}
+		_ = store.Insert(ctx, &domain.SwapEvent{
+			Mint: "MintA", TxSignature: "txSpike", Slot: 200,
+			Timestamp: evalTime - 1000, AmountOut: 100.0, Side: spikeSide,
+		})
+		_ = store.Insert(ctx, &domain.SwapEvent{
+			Mint: "MintA", TxSignature: "txOther", Slot: 199,
+			Timestamp: evalTime - 2000, AmountOut: 40.0, Side: otherSide,
+		})
+		return store
+	}
+
+	tests := []struct {
+		name       string
+		spikeSide  string
+		otherSide  string
+		requireImb bool
+		want       int
+	}{
+		{"buy-dominated spike passes gate", domain.SwapSideBuy, domain.SwapSideSell, true, 1},
+		{"sell-dominated spike rejected", domain.SwapSideSell, domain.SwapSideBuy, true, 0},
+		{"unsided spike rejected", "", "", true, 0},
+		{"gate disabled ignores sides", domain.SwapSideSell, domain.SwapSideBuy, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultActiveConfig()
+			config.RequirePositiveImbalance = tt.requireImb
+			detector := NewActiveDetector(config, setup(tt.spikeSide, tt.otherSide), memory.NewCandidateStore())
+
+			candidates, err := detector.DetectAt(context.Background(), evalTime)
+			if err != nil {
+				t.Fatalf("DetectAt failed: %v", err)
+			}
+			if len(candidates) != tt.want {
+				t.Errorf("expected %d candidates, got %d", tt.want, len(candidates))
+			}
+		})
+	}
+}
+
+func TestActiveDetector_MinUniqueTraders(t *testing.T) {
+	evalTime := int64(86400000)
+
+	// Identical volume in every case: 24 hourly swaps of history, then 5 swaps
+	// of 30 in the last hour spread over the given traders.
+	setup := func(traders []string) *memory.SwapEventStore {
+		store := memory.NewSwapEventStore()
+		ctx := context.Background()
+		for i := 0; i < 24; i++ {
+			_ = store.Insert(ctx, &domain.SwapEvent{
+				Mint: "MintA", TxSignature: "tx" + string(rune('a'+i)), Slot: int64(100 + i),
+				Timestamp: int64(i * 3600000), AmountOut: 10.0,
+			})
+		}
+		for i := 0; i < 5; i++ {
+			_ = store.Insert(ctx, &domain.SwapEvent{
+				Mint: "MintA", TxSignature: "txSpike" + string(rune('a'+i)), Slot: int64(200 + i),
+				Timestamp: evalTime - int64(i+1)*1000, AmountOut: 30.0, Trader: traders[i%len(traders)],
+			})
+		}
+		return store
+	}
+
+	tests := []struct {
+		name       string
+		traders    []string
+		minTraders int
+		want       int
+	}{
+		{"single trader rejected", []string{"wallet1"}, 3, 0},
+		{"many traders detected", []string{"w1", "w2", "w3", "w4", "w5"}, 3, 1},
+		{"exactly at threshold detected", []string{"w1", "w2", "w3"}, 3, 1},
+		{"unattributed swaps rejected", []string{""}, 1, 0},
+		{"gate disabled ignores traders", []string{"wallet1"}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultActiveConfig()
+			config.MinUniqueTraders = tt.minTraders
+			detector := NewActiveDetector(config, setup(tt.traders), memory.NewCandidateStore())
+
+			candidates, err := detector.DetectAt(context.Background(), evalTime)
+			if err != nil {
+				t.Fatalf("DetectAt failed: %v", err)
+			}
+			if len(candidates) != tt.want {
+				t.Errorf("expected %d candidates, got %d", tt.want, len(candidates))
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package discovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/solana"
+)
+
+var updateCorpus = flag.Bool("update", false, "rewrite the expectations of the parser conformance corpus")
+
+// corpusDir holds the parser conformance corpus: <case>.tx.json is a
+// getTransaction response, <case>.expected.json the events DEXParser extracts
+// from it. See testdata/conformance/README.md.
+const corpusDir = "testdata/conformance"
+
+// corpusExpectation is the content of a <case>.expected.json file.
+type corpusExpectation struct {
+	Description string            `json:"description"`
+	Swaps       []corpusSwap      `json:"swaps"`
+	Liquidity   []corpusLiquidity `json:"liquidity"`
+}
+
+// corpusSwap is a SwapEvent without the fields copied from the transaction
+// (signature, slot, timestamp), which the harness checks separately.
+type corpusSwap struct {
+	EventIndex int     `json:"event_index"`
+	DEX        string  `json:"dex"`
+	Mint       string  `json:"mint"`
+	Pool       string  `json:"pool,omitempty"`
+	Side       string  `json:"side,omitempty"`
+	AmountOut  float64 `json:"amount_out"`
+	Trader     string  `json:"trader,omitempty"`
+}
+
+// corpusLiquidity is a LiquidityEvent without the fields copied from the transaction.
+type corpusLiquidity struct {
+	EventIndex  int    `json:"event_index"`
+	DEX         string `json:"dex"`
+	EventType   string `json:"event_type"`
+	Mint        string `json:"mint"`
+	Pool        string `json:"pool,omitempty"`
+	AmountToken uint64 `json:"amount_token"`
+	AmountQuote uint64 `json:"amount_quote"`
+	PoolInit    bool   `json:"pool_init,omitempty"`
+	Migration   bool   `json:"migration,omitempty"`
+}
+
+// TestParserConformance runs DEXParser over every corpus transaction, the way
+// ingestion does (ParseSwapEventsV2 and ParseLiquidityEventsV2 with the
+// message's account keys), and diffs the events against the expectations.
+//
+// After an intended parser change, regenerate the expectations with
+//
+//	go test ./internal/discovery -run TestParserConformance -update -v
+//
+// which logs the diff of every changed case, and review git diff testdata/conformance.
+func TestParserConformance(t *testing.T) {
+	txFiles, err := filepath.Glob(filepath.Join(corpusDir, "*.tx.json"))
+	if err != nil {
+		t.Fatalf("list corpus: %v", err)
+	}
+	if len(txFiles) == 0 {
+		t.Fatalf("no corpus transactions in %s", corpusDir)
+	}
+
+	parser := NewDEXParser()
+	for _, txFile := range txFiles {
+		name := strings.TrimSuffix(filepath.Base(txFile), ".tx.json")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(txFile)
+			if err != nil {
+				t.Fatalf("read transaction: %v", err)
+			}
+			tx, err := solana.DecodeTransactionJSON(data)
+			if err != nil {
+				t.Fatalf("%s: %v", txFile, err)
+			}
+
+			expectedFile := filepath.Join(corpusDir, name+".expected.json")
+			var want corpusExpectation
+			wantData, err := os.ReadFile(expectedFile)
+			switch {
+			case err == nil:
+				if err := json.Unmarshal(wantData, &want); err != nil {
+					t.Fatalf("%s: %v", expectedFile, err)
+				}
+			case !os.IsNotExist(err) || !*updateCorpus:
+				t.Fatalf("read expectations: %v", err)
+			}
+			if want.Description == "" && !*updateCorpus {
+				t.Errorf("%s: every case needs a description", expectedFile)
+			}
+
+			got := parseCorpusTx(t, parser, tx)
+			got.Description = want.Description
+			gotData := encodeExpectation(t, got)
+
+			if *updateCorpus {
+				if bytes.Equal(gotData, wantData) {
+					return
+				}
+				t.Logf("updating %s:\n%s", expectedFile, lineDiff(string(wantData), string(gotData)))
+				if err := os.WriteFile(expectedFile, gotData, 0644); err != nil {
+					t.Fatalf("write expectations: %v", err)
+				}
+				return
+			}
+			if !bytes.Equal(gotData, encodeExpectation(t, want)) {
+				t.Errorf("parsed events differ from %s (- expected, + parsed):\n%s", expectedFile, lineDiff(string(wantData), string(gotData)))
+			}
+		})
+	}
+}
+
+// parseCorpusTx parses tx and checks the fields every event copies from it.
+func parseCorpusTx(t *testing.T, parser *DEXParser, tx *solana.Transaction) corpusExpectation {
+	t.Helper()
+	var logs, accountKeys []string
+	if tx.Meta != nil {
+		logs = tx.Meta.LogMessages
+	}
+	if tx.Message != nil {
+		accountKeys = tx.Message.AccountKeys
+	}
+	timestamp := tx.BlockTime * 1000
+
+	got := corpusExpectation{Swaps: []corpusSwap{}, Liquidity: []corpusLiquidity{}}
+	for _, e := range parser.ParseSwapEventsV2(logs, accountKeys, tx.Signature, tx.Slot, timestamp) {
+		if e.TxSignature != tx.Signature || e.Slot != tx.Slot || e.Timestamp != timestamp {
+			t.Errorf("swap %d: signature/slot/timestamp %s/%d/%d, want %s/%d/%d",
+				e.EventIndex, e.TxSignature, e.Slot, e.Timestamp, tx.Signature, tx.Slot, timestamp)
+		}
+		s := corpusSwap{EventIndex: e.EventIndex, DEX: e.DEX, Mint: e.Mint, Side: e.Side, AmountOut: e.AmountOut, Trader: e.Trader}
+		if e.Pool != nil {
+			s.Pool = *e.Pool
+		}
+		got.Swaps = append(got.Swaps, s)
+	}
+	for _, e := range parser.ParseLiquidityEventsV2(logs, accountKeys, tx.Signature, tx.Slot, timestamp) {
+		if e.TxSignature != tx.Signature || e.Slot != tx.Slot || e.Timestamp != timestamp {
+			t.Errorf("liquidity event %d: signature/slot/timestamp %s/%d/%d, want %s/%d/%d",
+				e.EventIndex, e.TxSignature, e.Slot, e.Timestamp, tx.Signature, tx.Slot, timestamp)
+		}
+		got.Liquidity = append(got.Liquidity, corpusLiquidity{
+			EventIndex: e.EventIndex, DEX: e.DEX, EventType: e.EventType, Mint: e.Mint, Pool: e.Pool,
+			AmountToken: e.AmountToken, AmountQuote: e.AmountQuote, PoolInit: e.PoolInit, Migration: e.Migration,
+		})
+	}
+	return got
+}
+
+// encodeExpectation renders an expectations file: indented, one field per
+// line, so that regenerated expectations diff readably.
+func encodeExpectation(t *testing.T, e corpusExpectation) []byte {
+	t.Helper()
+	if e.Swaps == nil {
+		e.Swaps = []corpusSwap{}
+	}
+	if e.Liquidity == nil {
+		e.Liquidity = []corpusLiquidity{}
+	}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		t.Fatalf("encode expectations: %v", err)
+	}
+	return append(data, '\n')
+}
+
+// lineDiff returns a line diff of a and b: unchanged lines prefixed "  ",
+// lines only in a "- " and lines only in b "+ ".
+func lineDiff(a, b string) string {
+	x, y := strings.Split(a, "\n"), strings.Split(b, "\n")
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(&out, "  %s\n", x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&out, "- %s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(&out, "+ %s\n", y[j])
+			j++
+		}
+	}
+	return out.String()
+}
@@ -3,17 +3,22 @@ package discovery
 import (
 	"context"
 	"errors"
+	"sort"
+	"time"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/idhash"
 	"solana-token-lab/internal/storage"
 )
 
-// NewTokenDetector detects first swaps for new mints.
+// NewTokenDetector detects new mints from their pool creation or, when no
+// pool creation is observed, their first swap.
 type NewTokenDetector struct {
 	seenMints      map[string]bool
 	candidateStore storage.CandidateStore
 	progressStore  storage.DiscoveryProgressStore // optional, for persistence
+	clock          *liveClock                     // optional, set for live ingestion only
+	mintFilter     *MintFilter                    // optional, blacklist/allowlist
 }
 
 // NewDetector creates a new NEW_TOKEN detector.
@@ -31,6 +36,21 @@ func (d *NewTokenDetector) WithProgressStore(store storage.DiscoveryProgressStor
 	return d
 }
 
+// WithLiveClock marks this detector as live: candidates get DetectedAt from now
+// and, if record is non-nil, their discovery latency is reported.
+// Leave unset for backfill and replay.
+func (d *NewTokenDetector) WithLiveClock(now func() time.Time, record LatencyRecorder) *NewTokenDetector {
+	d.clock = &liveClock{now: now, record: record}
+	return d
+}
+
+// WithMintFilter restricts which mints may become candidates.
+// Filtered mints are not marked seen, so they qualify once the lists allow them.
+func (d *NewTokenDetector) WithMintFilter(f *MintFilter) *NewTokenDetector {
+	d.mintFilter = f
+	return d
+}
+
 // LoadState loads previously seen mints from persistent storage.
 // Call this at startup to resume from previous state.
 func (d *NewTokenDetector) LoadState(ctx context.Context) error {
@@ -82,7 +102,7 @@ func (d *NewTokenDetector) SaveProgress(ctx context.Context, slot uint64, signat
 	})
 }
 
-// ProcessEvent checks if swap is first for mint, creates candidate if so.
+// ProcessEvent checks if the event is the first for its mint, creates candidate if so.
 // Returns the created candidate, or nil if mint was already seen.
 // Returns error if storage operation fails (except ErrDuplicateKey which is handled).
 func (d *NewTokenDetector) ProcessEvent(ctx context.Context, event *SwapEvent) (*domain.TokenCandidate, error) {
@@ -116,7 +136,12 @@ func (d *NewTokenDetector) ProcessEvent(ctx context.Context, event *SwapEvent) (
 		return nil, nil
 	}
 
-	// First swap for this mint — create candidate
+	allowed, err := d.mintFilter.Allow(ctx, event.Mint)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	// First pool creation or swap for this mint — create candidate
 	candidateID := idhash.ComputeCandidateID(
 		event.Mint,
 		event.Pool,
@@ -135,7 +160,9 @@ func (d *NewTokenDetector) ProcessEvent(ctx context.Context, event *SwapEvent) (
 		EventIndex:   event.EventIndex,
 		Slot:         event.Slot,
 		DiscoveredAt: event.Timestamp,
+		DEX:          event.DEX,
 	}
+	d.clock.stamp(candidate)
 
 	// Try to insert
 	err = d.candidateStore.Insert(ctx, candidate)
@@ -155,6 +182,7 @@ func (d *NewTokenDetector) ProcessEvent(ctx context.Context, event *SwapEvent) (
 		return nil, err
 	}
 
+	d.clock.observe(candidate)
 	return candidate, nil
 }
 
@@ -190,6 +218,82 @@ func (d *NewTokenDetector) ProcessEvents(ctx context.Context, events []*SwapEven
 	return candidates, nil
 }
 
+// ProcessPoolCreation creates a NEW_TOKEN candidate triggered by a pool-creation
+// event, so the candidate's tx/slot are those of the pool creation rather than of
+// the first swap. Events other than pool creations are ignored.
+// Returns the created candidate, or nil if the mint was already seen.
+func (d *NewTokenDetector) ProcessPoolCreation(ctx context.Context, event *LiquidityEvent) (*domain.TokenCandidate, error) {
+	if !event.PoolInit || event.Mint == "" {
+		return nil, nil
+	}
+	return d.ProcessEvent(ctx, poolCreationTrigger(event))
+}
+
+// ProcessEventsWithPools processes pool creations and swaps together, returning
+// discovered candidates. Within a slot, pool creations are processed before swaps,
+// so a pool creation is preferred as the trigger over a swap in the same slot;
+// otherwise events are taken in (slot, tx_signature, event_index) order.
+func (d *NewTokenDetector) ProcessEventsWithPools(ctx context.Context, swaps []*SwapEvent, liquidity []*LiquidityEvent) ([]*domain.TokenCandidate, error) {
+	type trigger struct {
+		event    *SwapEvent
+		poolInit bool
+	}
+	triggers := make([]trigger, 0, len(swaps)+len(liquidity))
+	for _, e := range liquidity {
+		if e.PoolInit && e.Mint != "" {
+			triggers = append(triggers, trigger{event: poolCreationTrigger(e), poolInit: true})
+		}
+	}
+	for _, e := range swaps {
+		triggers = append(triggers, trigger{event: e})
+	}
+
+	sort.SliceStable(triggers, func(i, j int) bool {
+		a, b := triggers[i].event, triggers[j].event
+		if a.Slot != b.Slot {
+			return a.Slot < b.Slot
+		}
+		if triggers[i].poolInit != triggers[j].poolInit {
+			return triggers[i].poolInit
+		}
+		if a.TxSignature != b.TxSignature {
+			return a.TxSignature < b.TxSignature
+		}
+		return a.EventIndex < b.EventIndex
+	})
+
+	var candidates []*domain.TokenCandidate
+	for _, t := range triggers {
+		candidate, err := d.ProcessEvent(ctx, t.event)
+		if err != nil {
+			return candidates, err
+		}
+		if candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, nil
+}
+
+// poolCreationTrigger adapts a pool-creation event to the detector's trigger shape.
+// candidate_id derivation is unchanged: it hashes the pool creation's position.
+func poolCreationTrigger(e *LiquidityEvent) *SwapEvent {
+	t := &SwapEvent{
+		Mint:        e.Mint,
+		TxSignature: e.TxSignature,
+		EventIndex:  e.EventIndex,
+		Slot:        e.Slot,
+		Timestamp:   e.Timestamp,
+		DEX:         e.DEX,
+	}
+	if e.Pool != "" {
+		pool := e.Pool
+		t.Pool = &pool
+	}
+	return t
+}
+
 // Reset clears the in-memory seen mints cache.
 // Useful for replay scenarios where we want to re-detect from storage state.
 // Note: Does NOT clear persistent storage.
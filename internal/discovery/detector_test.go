@@ -444,3 +444,77 @@ func TestDetector_NoPersistence_Fallback(t *testing.T) {
 		t.Fatal("Expected candidate to be created")
 	}
 }
+
+func TestDetector_PoolCreationPreferredOverSwap(t *testing.T) {
+	store := memory.NewCandidateStore()
+	detector := NewDetector(store)
+	ctx := context.Background()
+
+	pool := "PoolAddr123"
+	swaps := []*SwapEvent{
+		// Same slot as the pool creation, tx sorts earlier
+		{Mint: "MintA", Pool: &pool, TxSignature: "aaSwap", EventIndex: 0, Slot: 100, Timestamp: 1000},
+		{Mint: "MintA", Pool: &pool, TxSignature: "zzSwap", EventIndex: 0, Slot: 101, Timestamp: 1400},
+		// No pool creation observed: first swap triggers
+		{Mint: "MintB", Pool: &pool, TxSignature: "swapB", EventIndex: 2, Slot: 102, Timestamp: 1800, DEX: domain.DEXPumpFun},
+	}
+	liquidity := []*LiquidityEvent{
+		{Pool: pool, Mint: "MintA", EventType: domain.LiquidityEventAdd, TxSignature: "initTx", EventIndex: 3, Slot: 100, Timestamp: 1000, PoolInit: true, DEX: domain.DEXRaydium},
+		// Ordinary add must not trigger
+		{Pool: pool, Mint: "MintC", EventType: domain.LiquidityEventAdd, TxSignature: "addTx", EventIndex: 1, Slot: 99, Timestamp: 900},
+	}
+
+	candidates, err := detector.ProcessEventsWithPools(ctx, swaps, liquidity)
+	if err != nil {
+		t.Fatalf("ProcessEventsWithPools failed: %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(candidates))
+	}
+
+	a := candidates[0]
+	if a.Mint != "MintA" || a.TxSignature != "initTx" || a.EventIndex != 3 || a.Slot != 100 {
+		t.Errorf("expected pool creation as trigger, got %+v", a)
+	}
+	expectedID := idhash.ComputeCandidateID("MintA", &pool, domain.SourceNewToken, "initTx", 3, 100)
+	if a.CandidateID != expectedID {
+		t.Errorf("CandidateID mismatch: expected %s, got %s", expectedID, a.CandidateID)
+	}
+
+	if b := candidates[1]; b.Mint != "MintB" || b.TxSignature != "swapB" {
+		t.Errorf("expected first swap as trigger for MintB, got %+v", b)
+	}
+
+	// Candidates inherit the DEX of their triggering event
+	if candidates[0].DEX != domain.DEXRaydium || candidates[1].DEX != domain.DEXPumpFun {
+		t.Errorf("expected DEX raydium, pumpfun; got %q, %q", candidates[0].DEX, candidates[1].DEX)
+	}
+}
+
+func TestDetector_ProcessPoolCreation_IgnoresNonInit(t *testing.T) {
+	detector := NewDetector(memory.NewCandidateStore())
+	ctx := context.Background()
+
+	for _, e := range []*LiquidityEvent{
+		{Pool: "P", Mint: "MintA", EventType: domain.LiquidityEventAdd, TxSignature: "tx", Slot: 1},
+		{Pool: "P", EventType: domain.LiquidityEventAdd, TxSignature: "tx", Slot: 1, PoolInit: true},
+	} {
+		candidate, err := detector.ProcessPoolCreation(ctx, e)
+		if err != nil {
+			t.Fatalf("ProcessPoolCreation failed: %v", err)
+		}
+		if candidate != nil {
+			t.Errorf("expected no candidate for %+v", e)
+		}
+	}
+
+	candidate, err := detector.ProcessPoolCreation(ctx, &LiquidityEvent{
+		Pool: "P", Mint: "MintA", EventType: domain.LiquidityEventAdd, TxSignature: "tx", Slot: 1, PoolInit: true,
+	})
+	if err != nil || candidate == nil {
+		t.Fatalf("expected candidate, got %v (err %v)", candidate, err)
+	}
+	if candidate.Pool == nil || *candidate.Pool != "P" {
+		t.Errorf("expected pool P, got %v", candidate.Pool)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/mr-tron/base58"
+
+	"solana-token-lab/internal/domain"
 )
 
 // Known DEX program IDs.
@@ -78,6 +80,15 @@ func (p *DEXParser) ParseSwapEventsV2(logs []string, accountKeys []string, txSig
 		}
 	}
 
+	// The fee payer signs and pays for the tx, so it stands in for the trader
+	if len(accountKeys) > 0 {
+		for _, e := range allEvents {
+			if e.Trader == "" {
+				e.Trader = accountKeys[0]
+			}
+		}
+	}
+
 	// Sort by event_index for deterministic ordering
 	sort.Slice(allEvents, func(i, j int) bool {
 		return allEvents[i].EventIndex < allEvents[j].EventIndex
@@ -142,15 +153,18 @@ func (p *DEXParser) ParseLiquidityEventsV2(logs []string, accountKeys []string,
 
 // LiquidityEvent represents a liquidity add/remove event.
 type LiquidityEvent struct {
-	Pool         string
-	Mint         string
-	EventType    string // "add" or "remove"
-	AmountToken  uint64
-	AmountQuote  uint64
-	TxSignature  string
-	EventIndex   int
-	Slot         int64
-	Timestamp    int64
+	Pool        string
+	Mint        string
+	EventType   string // "add" or "remove"
+	AmountToken uint64
+	AmountQuote uint64
+	TxSignature string
+	EventIndex  int
+	Slot        int64
+	Timestamp   int64
+	PoolInit    bool   // pool creation ("add" of the initial reserves)
+	Migration   bool   // pump.fun bonding curve migration ("remove" of the curve reserves)
+	DEX         string // domain.DEXRaydium / domain.DEXPumpFun, "" if unknown
 }
 
 // RaydiumParser parses Raydium AMM v4 swap events.
@@ -256,7 +270,9 @@ func (p *RaydiumParser) ParseSwapEventsV2(logs []string, accountKeys []string, t
 			EventIndex:  i, // Use actual log index per DISCOVERY_SPEC.md
 			Slot:        slot,
 			Timestamp:   timestamp,
+			DEX:         domain.DEXRaydium,
 			AmountOut:   amountOut,
+			Side:        raydiumSwapSide(data),
 		}
 		if pool != "" {
 			event.Pool = &pool
@@ -322,6 +338,22 @@ func (p *RaydiumParser) extractPoolAndMint(accountKeys []string, rayLogData []by
 	return pool, mint
 }
 
+// raydiumSwapSide derives the swap side from the ray_log input/output mints:
+// WSOL in is a buy of the token, WSOL out is a sell. Returns "" when the log
+// is too short or neither side is WSOL.
+func raydiumSwapSide(rayLogData []byte) string {
+	if len(rayLogData) < 97 {
+		return ""
+	}
+	switch {
+	case base58Encode(rayLogData[33:65]) == WSOL:
+		return domain.SwapSideBuy
+	case base58Encode(rayLogData[65:97]) == WSOL:
+		return domain.SwapSideSell
+	}
+	return ""
+}
+
 // ParseLiquidityEvents parses Raydium liquidity events from logs.
 func (p *RaydiumParser) ParseLiquidityEvents(logs []string, txSig string, slot int64, timestamp int64) []*LiquidityEvent {
 	return p.ParseLiquidityEventsV2(logs, nil, txSig, slot, timestamp)
@@ -343,6 +375,14 @@ func (p *RaydiumParser) ParseLiquidityEventsV2(logs []string, accountKeys []stri
 			continue
 		}
 
+		// Pool creation carries its own account layout and reserves
+		if len(data) > 0 && data[0] == raydiumLogInit {
+			if event := p.parsePoolInit(logs, accountKeys, data, txSig, i, slot, timestamp); event != nil {
+				events = append(events, event)
+			}
+			continue
+		}
+
 		// Check if this is a liquidity log (add/remove)
 		eventType := p.getLiquidityEventType(data)
 		if eventType == "" {
@@ -381,6 +421,7 @@ func (p *RaydiumParser) ParseLiquidityEventsV2(logs []string, accountKeys []stri
 			EventIndex:  i, // Use actual log index per DISCOVERY_SPEC.md
 			Slot:        slot,
 			Timestamp:   timestamp,
+			DEX:         domain.DEXRaydium,
 		}
 
 		events = append(events, event)
@@ -389,6 +430,84 @@ func (p *RaydiumParser) ParseLiquidityEventsV2(logs []string, accountKeys []stri
 	return events
 }
 
+// Raydium AMM v4 pool initialization.
+//
+// initialize and initialize2 both emit an InitLog ray_log:
+//
+//	log_type(1)=0x00 + time(8) + pc_decimals(1) + coin_decimals(1) +
+//	pc_lot_size(8) + coin_lot_size(8) + pc_amount(8) + coin_amount(8) + market(32)
+//
+// The instruction account layouts differ by one leading account:
+// initialize2 accounts: 4 AMM ID (pool), 8 coin mint, 9 pc mint
+// initialize accounts:  3 AMM ID (pool), 7 coin mint, 8 pc mint
+const (
+	raydiumLogInit        = 0x00
+	raydiumInitLogLen     = 75
+	raydiumInitPcAmount   = 27
+	raydiumInitCoinAmount = 35
+
+	raydiumInit2PoolIndex     = 4
+	raydiumInit2CoinMintIndex = 8
+	raydiumInit2PcMintIndex   = 9
+)
+
+// raydiumInitialize2Log marks the initialize2 instruction in program logs.
+const raydiumInitialize2Log = "initialize2:"
+
+// parsePoolInit builds the pool-creation event from an InitLog at log index i.
+// Returns nil if the log is truncated or the pool/mints cannot be located.
+func (p *RaydiumParser) parsePoolInit(logs []string, accountKeys []string, data []byte, txSig string, i int, slot, timestamp int64) *LiquidityEvent {
+	if len(data) < raydiumInitLogLen {
+		return nil
+	}
+
+	poolIdx, coinIdx, pcIdx := raydiumInit2PoolIndex, raydiumInit2CoinMintIndex, raydiumInit2PcMintIndex
+	if !containsLog(logs[:i], raydiumInitialize2Log) {
+		// Legacy initialize: one fewer leading account
+		poolIdx, coinIdx, pcIdx = poolIdx-1, coinIdx-1, pcIdx-1
+	}
+	if len(accountKeys) <= pcIdx {
+		return nil
+	}
+
+	pool, coinMint, pcMint := accountKeys[poolIdx], accountKeys[coinIdx], accountKeys[pcIdx]
+	coinAmount := readUint64LE(data, raydiumInitCoinAmount)
+	pcAmount := readUint64LE(data, raydiumInitPcAmount)
+
+	// The token side is the non-WSOL mint
+	mint, amountToken, amountQuote := coinMint, coinAmount, pcAmount
+	if coinMint == WSOL {
+		mint, amountToken, amountQuote = pcMint, pcAmount, coinAmount
+	}
+	if mint == WSOL {
+		return nil
+	}
+
+	return &LiquidityEvent{
+		Pool:        pool,
+		Mint:        mint,
+		EventType:   domain.LiquidityEventAdd,
+		AmountToken: amountToken,
+		AmountQuote: amountQuote,
+		TxSignature: txSig,
+		EventIndex:  i,
+		Slot:        slot,
+		Timestamp:   timestamp,
+		DEX:         domain.DEXRaydium,
+		PoolInit:    true,
+	}
+}
+
+// containsLog reports whether any log line contains substr.
+func containsLog(logs []string, substr string) bool {
+	for _, log := range logs {
+		if strings.Contains(log, substr) {
+			return true
+		}
+	}
+	return false
+}
+
 // getLiquidityEventType determines if ray_log represents a liquidity event.
 func (p *RaydiumParser) getLiquidityEventType(data []byte) string {
 	if len(data) < 1 {
@@ -498,7 +617,9 @@ func (p *PumpFunParser) ParseSwapEvents(logs []string, txSig string, slot int64,
 				EventIndex:  i,
 				Slot:        slot,
 				Timestamp:   timestamp,
+				DEX:         domain.DEXPumpFun,
 				AmountOut:   pendingAmount,
+				Side:        pumpFunSide(isBuy),
 			}
 
 			events = append(events, event)
@@ -608,7 +729,9 @@ func (p *PumpFunParser) ParseSwapEventsV2(logs []string, accountKeys []string, t
 				EventIndex:  i,
 				Slot:        slot,
 				Timestamp:   timestamp,
+				DEX:         domain.DEXPumpFun,
 				AmountOut:   pendingAmount,
+				Side:        pumpFunSide(isBuy),
 			}
 
 			events = append(events, event)
@@ -618,6 +741,14 @@ func (p *PumpFunParser) ParseSwapEventsV2(logs []string, accountKeys []string, t
 	return events
 }
 
+// pumpFunSide maps the matched Buy/Sell instruction to a swap side.
+func pumpFunSide(isBuy bool) string {
+	if isBuy {
+		return domain.SwapSideBuy
+	}
+	return domain.SwapSideSell
+}
+
 // ParseLiquidityEvents parses pump.fun liquidity events from logs.
 // pump.fun uses a bonding curve model - "Create" initializes liquidity,
 // and liquidity is migrated when the token "graduates" to Raydium.
@@ -664,6 +795,7 @@ func (p *PumpFunParser) ParseLiquidityEvents(logs []string, txSig string, slot i
 				EventIndex:  eventIdx,
 				Slot:        slot,
 				Timestamp:   timestamp,
+				DEX:         domain.DEXPumpFun,
 			}
 			events = append(events, event)
 			eventIdx++
@@ -678,6 +810,8 @@ func (p *PumpFunParser) ParseLiquidityEvents(logs []string, txSig string, slot i
 				EventIndex:  eventIdx,
 				Slot:        slot,
 				Timestamp:   timestamp,
+				DEX:         domain.DEXPumpFun,
+				Migration:   true,
 			}
 			events = append(events, event)
 			eventIdx++
@@ -1,7 +1,13 @@
 package discovery
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"testing"
+
+	"github.com/mr-tron/base58"
+
+	"solana-token-lab/internal/domain"
 )
 
 func TestDEXParser_ParseSwapEvents_Empty(t *testing.T) {
@@ -113,6 +119,16 @@ func TestPumpFunParser_ParseSwapEvents_MultipleTrades(t *testing.T) {
 	if events[0].EventIndex >= events[1].EventIndex {
 		t.Errorf("expected events to be ordered by index")
 	}
+
+	if events[0].Side != domain.SwapSideBuy || events[1].Side != domain.SwapSideSell {
+		t.Errorf("expected sides buy, sell; got %q, %q", events[0].Side, events[1].Side)
+	}
+
+	for _, e := range events {
+		if e.DEX != domain.DEXPumpFun {
+			t.Errorf("expected DEX %q, got %q", domain.DEXPumpFun, e.DEX)
+		}
+	}
 }
 
 func TestPumpFunParser_ParseSwapEvents_NoPumpFun(t *testing.T) {
@@ -243,6 +259,33 @@ func TestLiquidityEvent(t *testing.T) {
 	}
 }
 
+func TestLiquidityEventTypes_AreCanonical(t *testing.T) {
+	raydium := NewRaydiumParser()
+	types := []string{
+		raydium.getLiquidityEventType([]byte{0x03}),
+		raydium.getLiquidityEventType([]byte{0x04}),
+	}
+	logs := []string{
+		"Program " + PumpFun + " invoke [1]",
+		"Program log: Instruction: Create",
+		"Program log: Instruction: Migrate",
+		"Program " + PumpFun + " success",
+	}
+	for _, e := range NewPumpFunParser().ParseLiquidityEvents(logs, "sig", 1, 1000) {
+		types = append(types, e.EventType)
+	}
+	if len(types) != 4 {
+		t.Fatalf("expected 4 event types, got %v", types)
+	}
+
+	for _, eventType := range types {
+		got, err := domain.NormalizeLiquidityEventType(eventType)
+		if err != nil || got != eventType {
+			t.Errorf("parser output %q normalizes to %q, %v; want itself", eventType, got, err)
+		}
+	}
+}
+
 func TestRaydiumParser_EventIndex_MatchesLogPosition(t *testing.T) {
 	parser := NewRaydiumParser()
 
@@ -266,14 +309,14 @@ func TestRaydiumParser_EventIndex_MatchesLogPosition(t *testing.T) {
 
 	// Create logs with ray_log at specific non-consecutive positions (0, 3, 7)
 	logs := []string{
-		rayLogEntry,                    // index 0 - swap
-		"Program log: some other log",  // index 1
-		"Program log: another log",     // index 2
-		rayLogEntry,                    // index 3 - swap
-		"Program log: not swap",        // index 4
-		"Program log: random",          // index 5
-		"Program log: filler",          // index 6
-		rayLogEntry,                    // index 7 - swap
+		rayLogEntry,                   // index 0 - swap
+		"Program log: some other log", // index 1
+		"Program log: another log",    // index 2
+		rayLogEntry,                   // index 3 - swap
+		"Program log: not swap",       // index 4
+		"Program log: random",         // index 5
+		"Program log: filler",         // index 6
+		rayLogEntry,                   // index 7 - swap
 	}
 
 	// Need account keys for mint extraction
@@ -297,6 +340,9 @@ func TestRaydiumParser_EventIndex_MatchesLogPosition(t *testing.T) {
 			t.Errorf("event %d: expected EventIndex %d, got %d",
 				i, expectedIndices[i], event.EventIndex)
 		}
+		if event.DEX != domain.DEXRaydium {
+			t.Errorf("event %d: expected DEX %q, got %q", i, domain.DEXRaydium, event.DEX)
+		}
 	}
 }
 
@@ -332,7 +378,152 @@ func TestPumpFunParser_EventIndex_MatchesLogPosition(t *testing.T) {
 	}
 }
 
+func TestDEXParser_ParseSwapEventsV2_TraderIsFeePayer(t *testing.T) {
+	parser := NewDEXParser()
+	logs := []string{
+		"Program 6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P invoke [1]",
+		"Program log: mint=TOKEN1",
+		"Program log: Instruction: Buy",
+		"Program 6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P success",
+	}
+
+	events := parser.ParseSwapEventsV2(logs, []string{"FeePayer111", "Other222"}, "txSig", 100, 1000)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Trader != "FeePayer111" {
+		t.Errorf("expected trader FeePayer111, got %q", events[0].Trader)
+	}
+
+	events = parser.ParseSwapEventsV2(logs, nil, "txSig", 100, 1000)
+	if len(events) != 1 || events[0].Trader != "" {
+		t.Errorf("expected unattributed event without account keys, got %+v", events)
+	}
+}
+
 // Helper to encode bytes to base64
 func encodeBase64(data []byte) string {
 	return "CQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABTbzExMTExMTExMTExMTExMTExMTExMTExMTExMVRlc3RNaW50MTExMTExMTExMTExMTExMTExMTExMTEAAAAAAAAAAAAAAAAAAAAA"
 }
+
+func TestRaydiumSwapSide(t *testing.T) {
+	wsol, err := base58.Decode(WSOL)
+	if err != nil {
+		t.Fatalf("decode WSOL: %v", err)
+	}
+	token := make([]byte, 32)
+	token[0] = 7
+
+	rayLog := func(input, output []byte) []byte {
+		data := make([]byte, 113)
+		data[0] = 0x09
+		copy(data[33:65], input)
+		copy(data[65:97], output)
+		return data
+	}
+
+	if side := raydiumSwapSide(rayLog(wsol, token)); side != domain.SwapSideBuy {
+		t.Errorf("WSOL in: expected buy, got %q", side)
+	}
+	if side := raydiumSwapSide(rayLog(token, wsol)); side != domain.SwapSideSell {
+		t.Errorf("WSOL out: expected sell, got %q", side)
+	}
+	if side := raydiumSwapSide(rayLog(token, token)); side != "" {
+		t.Errorf("no WSOL leg: expected unknown side, got %q", side)
+	}
+	if side := raydiumSwapSide([]byte{0x09}); side != "" {
+		t.Errorf("short ray_log: expected unknown side, got %q", side)
+	}
+}
+
+func TestRaydiumParser_PoolInit(t *testing.T) {
+	parser := NewRaydiumParser()
+
+	initLog := func(pcAmount, coinAmount uint64) string {
+		data := make([]byte, raydiumInitLogLen)
+		data[0] = raydiumLogInit
+		binary.LittleEndian.PutUint64(data[raydiumInitPcAmount:], pcAmount)
+		binary.LittleEndian.PutUint64(data[raydiumInitCoinAmount:], coinAmount)
+		return "Program log: ray_log: " + base64.StdEncoding.EncodeToString(data)
+	}
+	keys := func(pool, coin, pc string, offset int) []string {
+		k := make([]string, 12)
+		for i := range k {
+			k[i] = "Account" + string(rune('A'+i))
+		}
+		k[raydiumInit2PoolIndex-offset] = pool
+		k[raydiumInit2CoinMintIndex-offset] = coin
+		k[raydiumInit2PcMintIndex-offset] = pc
+		return k
+	}
+
+	tests := []struct {
+		name        string
+		logs        []string
+		accountKeys []string
+		wantToken   uint64
+		wantQuote   uint64
+	}{
+		{
+			name: "initialize2 coin token",
+			logs: []string{
+				"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 invoke [1]",
+				"Program log: initialize2: InitializeInstruction2 { nonce: 254, open_time: 0 }",
+				initLog(5_000_000_000, 1_000_000),
+			},
+			accountKeys: keys("PoolInit2", "TokenMint", WSOL, 0),
+			wantToken:   1_000_000,
+			wantQuote:   5_000_000_000,
+		},
+		{
+			name: "initialize2 coin WSOL",
+			logs: []string{
+				"Program log: initialize2: InitializeInstruction2 { nonce: 254, open_time: 0 }",
+				initLog(1_000_000, 5_000_000_000),
+			},
+			accountKeys: keys("PoolInit2", WSOL, "TokenMint", 0),
+			wantToken:   1_000_000,
+			wantQuote:   5_000_000_000,
+		},
+		{
+			name:        "legacy initialize",
+			logs:        []string{initLog(5_000_000_000, 1_000_000)},
+			accountKeys: keys("PoolInit2", "TokenMint", WSOL, 1),
+			wantToken:   1_000_000,
+			wantQuote:   5_000_000_000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := parser.ParseLiquidityEventsV2(tt.logs, tt.accountKeys, "initSig", 100, 1000)
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			e := events[0]
+			if !e.PoolInit || e.EventType != domain.LiquidityEventAdd {
+				t.Errorf("expected pool-init add, got PoolInit=%v EventType=%q", e.PoolInit, e.EventType)
+			}
+			if e.DEX != domain.DEXRaydium {
+				t.Errorf("expected DEX %q, got %q", domain.DEXRaydium, e.DEX)
+			}
+			if e.Pool != "PoolInit2" || e.Mint != "TokenMint" {
+				t.Errorf("unexpected pool/mint: %s/%s", e.Pool, e.Mint)
+			}
+			if e.AmountToken != tt.wantToken || e.AmountQuote != tt.wantQuote {
+				t.Errorf("amounts: got token=%d quote=%d, want %d/%d", e.AmountToken, e.AmountQuote, tt.wantToken, tt.wantQuote)
+			}
+			if e.EventIndex != len(tt.logs)-1 {
+				t.Errorf("expected EventIndex %d, got %d", len(tt.logs)-1, e.EventIndex)
+			}
+		})
+	}
+
+	// Truncated InitLog and missing account keys are skipped
+	if events := parser.ParseLiquidityEventsV2([]string{"ray_log: AA=="}, keys("P", "T", WSOL, 0), "s", 1, 1); len(events) != 0 {
+		t.Errorf("truncated InitLog: expected 0 events, got %d", len(events))
+	}
+	if events := parser.ParseLiquidityEventsV2([]string{initLog(1, 1)}, nil, "s", 1, 1); len(events) != 0 {
+		t.Errorf("no account keys: expected 0 events, got %d", len(events))
+	}
+}
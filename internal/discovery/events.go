@@ -9,4 +9,7 @@ type SwapEvent struct {
 	Slot        int64   // Solana slot number
 	Timestamp   int64   // Unix timestamp in milliseconds
 	AmountOut   float64 // Output amount (token amount for volume calculations)
+	Side        string  // domain.SwapSideBuy / domain.SwapSideSell, "" if the parser cannot tell
+	DEX         string  // domain.DEXRaydium / domain.DEXPumpFun, "" if unknown
+	Trader      string  // Fee payer (accountKeys[0]), "" if account keys are unavailable
 }
@@ -0,0 +1,46 @@
+package discovery
+
+import (
+	"time"
+
+	"solana-token-lab/internal/domain"
+)
+
+// LatencyRecorder receives the discovery latency of a live-detected candidate.
+type LatencyRecorder func(source domain.Source, latency time.Duration)
+
+// liveClock stamps DetectedAt on candidates produced from live ingestion.
+// Detectors without one (backfill, replay) leave DetectedAt nil, so their
+// candidates stay deterministic and are excluded from latency statistics.
+type liveClock struct {
+	now    func() time.Time
+	record LatencyRecorder // optional
+}
+
+// stamp sets DetectedAt on c. No-op without a clock.
+func (l *liveClock) stamp(c *domain.TokenCandidate) {
+	if l == nil {
+		return
+	}
+	detectedAt := l.now().UnixMilli()
+	c.DetectedAt = &detectedAt
+}
+
+// observe reports the latency of a stamped candidate once it is stored.
+func (l *liveClock) observe(c *domain.TokenCandidate) {
+	if l == nil || l.record == nil {
+		return
+	}
+	if latency, ok := DiscoveryLatency(c); ok {
+		l.record(c.Source, latency)
+	}
+}
+
+// DiscoveryLatency returns how long after the discovery event's block time the
+// candidate was detected. ok is false for candidates without DetectedAt.
+func DiscoveryLatency(c *domain.TokenCandidate) (time.Duration, bool) {
+	if c.DetectedAt == nil {
+		return 0, false
+	}
+	return time.Duration(*c.DetectedAt-c.DiscoveredAt) * time.Millisecond, true
+}
@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+type recordedLatency struct {
+	source  domain.Source
+	latency time.Duration
+}
+
+func TestDetector_LiveClockStampsDetectedAt(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(1704067212500)
+	var recorded []recordedLatency
+
+	detector := NewDetector(memory.NewCandidateStore()).WithLiveClock(
+		func() time.Time { return now },
+		func(source domain.Source, latency time.Duration) {
+			recorded = append(recorded, recordedLatency{source, latency})
+		},
+	)
+
+	candidate, err := detector.ProcessEvent(ctx, &SwapEvent{
+		Mint:        "MintLive",
+		TxSignature: "TxLive",
+		Slot:        100,
+		Timestamp:   1704067200000,
+	})
+	if err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if candidate.DetectedAt == nil || *candidate.DetectedAt != now.UnixMilli() {
+		t.Fatalf("DetectedAt = %v, want %d", candidate.DetectedAt, now.UnixMilli())
+	}
+
+	want := recordedLatency{domain.SourceNewToken, 12500 * time.Millisecond}
+	if len(recorded) != 1 || recorded[0] != want {
+		t.Errorf("recorded = %v, want [%v]", recorded, want)
+	}
+
+	// Duplicate mint: no new candidate, nothing recorded.
+	if _, err := detector.ProcessEvent(ctx, &SwapEvent{
+		Mint:        "MintLive",
+		TxSignature: "TxLive2",
+		Slot:        101,
+		Timestamp:   1704067201000,
+	}); err != nil {
+		t.Fatalf("ProcessEvent (dup) failed: %v", err)
+	}
+	if len(recorded) != 1 {
+		t.Errorf("expected no latency for duplicate mint, got %v", recorded)
+	}
+}
+
+func TestDetector_WithoutClockLeavesDetectedAtNil(t *testing.T) {
+	detector := NewDetector(memory.NewCandidateStore())
+
+	candidate, err := detector.ProcessEvent(context.Background(), &SwapEvent{
+		Mint:        "MintBackfill",
+		TxSignature: "TxBackfill",
+		Slot:        100,
+		Timestamp:   1704067200000,
+	})
+	if err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if candidate.DetectedAt != nil {
+		t.Errorf("expected nil DetectedAt for backfill, got %d", *candidate.DetectedAt)
+	}
+	if _, ok := DiscoveryLatency(candidate); ok {
+		t.Error("DiscoveryLatency should report !ok without DetectedAt")
+	}
+}
+
+func TestActiveDetector_LiveClockRecordsLatency(t *testing.T) {
+	swapEventStore := memory.NewSwapEventStore()
+	ctx := context.Background()
+
+	evalTime := int64(86400000)
+	for i := 0; i < 24; i++ {
+		_ = swapEventStore.Insert(ctx, &domain.SwapEvent{
+			Mint:        "MintA",
+			TxSignature: "tx" + string(rune('a'+i)),
+			Slot:        int64(100 + i),
+			Timestamp:   int64(i * 3600000),
+			AmountOut:   10.0,
+		})
+	}
+	_ = swapEventStore.Insert(ctx, &domain.SwapEvent{
+		Mint:        "MintA",
+		TxSignature: "txSpike",
+		Slot:        200,
+		Timestamp:   evalTime - 1000,
+		AmountOut:   100.0,
+	})
+
+	now := time.UnixMilli(evalTime + 2000)
+	var recorded []recordedLatency
+	detector := NewActiveDetector(DefaultActiveConfig(), swapEventStore, memory.NewCandidateStore()).WithLiveClock(
+		func() time.Time { return now },
+		func(source domain.Source, latency time.Duration) {
+			recorded = append(recorded, recordedLatency{source, latency})
+		},
+	)
+
+	candidates, err := detector.DetectAt(ctx, evalTime)
+	if err != nil {
+		t.Fatalf("DetectAt failed: %v", err)
+	}
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %d", len(candidates))
+	}
+
+	latency, ok := DiscoveryLatency(candidates[0])
+	if !ok {
+		t.Fatal("expected DetectedAt on live ACTIVE_TOKEN candidate")
+	}
+	if len(recorded) != 1 || recorded[0] != (recordedLatency{domain.SourceActiveToken, latency}) {
+		t.Errorf("recorded = %v, want [{ACTIVE_TOKEN %v}]", recorded, latency)
+	}
+}
@@ -0,0 +1,348 @@
+package discovery
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mint list names.
+const (
+	MintBlacklist = "blacklist"
+	MintAllowlist = "allowlist"
+)
+
+// Reasons a mint is filtered out.
+const (
+	FilterBlacklisted    = "blacklisted"
+	FilterNotAllowlisted = "not_allowlisted"
+)
+
+// ErrUnknownMintList is returned for a list name other than MintBlacklist or MintAllowlist.
+var ErrUnknownMintList = errors.New("unknown mint list")
+
+// authorityPrefix marks an authority address in a mint list file.
+const authorityPrefix = "authority:"
+
+// MintList is a set of mints and token authority addresses.
+type MintList struct {
+	Mints       []string `json:"mints"`
+	Authorities []string `json:"authorities"`
+}
+
+// ReadMintList parses a mint list: one address per line, "authority:<address>"
+// for an authority, blank lines and lines starting with # ignored.
+func ReadMintList(r io.Reader) (MintList, error) {
+	var list MintList
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if addr, ok := strings.CutPrefix(line, authorityPrefix); ok {
+			list.Authorities = append(list.Authorities, strings.TrimSpace(addr))
+		} else {
+			list.Mints = append(list.Mints, line)
+		}
+	}
+	return list, scanner.Err()
+}
+
+// LoadMintList reads a mint list file. An empty path yields an empty list.
+func LoadMintList(path string) (MintList, error) {
+	if path == "" {
+		return MintList{}, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return MintList{}, fmt.Errorf("open mint list: %w", err)
+	}
+	defer f.Close()
+
+	list, err := ReadMintList(f)
+	if err != nil {
+		return MintList{}, fmt.Errorf("read mint list %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// AuthorityResolver returns the authority addresses of a mint
+// (e.g. its Metaplex update authority) for authority-based filtering.
+type AuthorityResolver interface {
+	Authorities(ctx context.Context, mint string) ([]string, error)
+}
+
+// FilterRecorder is notified once per mint filtered out under the current lists.
+type FilterRecorder func(reason string)
+
+// mintSet is the lookup form of a MintList.
+type mintSet struct {
+	mints       map[string]bool
+	authorities map[string]bool
+}
+
+func newMintSet(list MintList) mintSet {
+	s := mintSet{mints: make(map[string]bool), authorities: make(map[string]bool)}
+	for _, m := range list.Mints {
+		if m != "" {
+			s.mints[m] = true
+		}
+	}
+	for _, a := range list.Authorities {
+		if a != "" {
+			s.authorities[a] = true
+		}
+	}
+	return s
+}
+
+func (s mintSet) empty() bool {
+	return len(s.mints) == 0 && len(s.authorities) == 0
+}
+
+func (s mintSet) list() MintList {
+	return MintList{Mints: sortedKeys(s.mints), Authorities: sortedKeys(s.authorities)}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MintFilterStats counts mints filtered out of discovery, by reason.
+type MintFilterStats struct {
+	Blacklisted    int64 `json:"blacklisted"`
+	NotAllowlisted int64 `json:"not_allowlisted"`
+}
+
+// MintFilter decides which mints may become candidates. A blacklisted mint, or a
+// mint with a blacklisted authority, never qualifies. If the allowlist is
+// non-empty, only allowlisted mints, or mints with an allowlisted authority, qualify.
+//
+// Lists can be changed while detectors use the filter; decisions made under the
+// previous lists are dropped. Authorities are only resolved when a list contains
+// authorities, and are treated as unknown without a resolver.
+// A nil *MintFilter allows every mint.
+type MintFilter struct {
+	resolver AuthorityResolver // optional
+	record   FilterRecorder    // optional
+
+	mu        sync.RWMutex
+	blacklist mintSet
+	allowlist mintSet
+	version   int               // incremented on every list change
+	rejected  map[string]string // mint -> reason, under the current lists
+	stats     MintFilterStats
+}
+
+// NewMintFilter creates a filter from the given lists.
+func NewMintFilter(blacklist, allowlist MintList) *MintFilter {
+	return &MintFilter{
+		blacklist: newMintSet(blacklist),
+		allowlist: newMintSet(allowlist),
+		rejected:  make(map[string]string),
+	}
+}
+
+// WithAuthorityResolver enables authority-based matching.
+func (f *MintFilter) WithAuthorityResolver(r AuthorityResolver) *MintFilter {
+	f.resolver = r
+	return f
+}
+
+// WithRecorder reports filtered mints, e.g. to metrics.
+func (f *MintFilter) WithRecorder(record FilterRecorder) *MintFilter {
+	f.record = record
+	return f
+}
+
+// Allow reports whether mint may become a candidate.
+// Returns an error if its authorities are needed but cannot be resolved.
+func (f *MintFilter) Allow(ctx context.Context, mint string) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	f.mu.RLock()
+	_, rejected := f.rejected[mint]
+	blacklist, allowlist, version := f.blacklist, f.allowlist, f.version
+	f.mu.RUnlock()
+	if rejected {
+		return false, nil
+	}
+
+	reason, err := f.check(ctx, mint, blacklist, allowlist)
+	if err != nil || reason == "" {
+		return err == nil, err
+	}
+
+	f.mu.Lock()
+	// Lists changed while resolving: the decision no longer applies.
+	stale := f.version != version
+	if !stale {
+		if _, ok := f.rejected[mint]; !ok {
+			f.rejected[mint] = reason
+			if reason == FilterBlacklisted {
+				f.stats.Blacklisted++
+			} else {
+				f.stats.NotAllowlisted++
+			}
+			if f.record != nil {
+				f.record(reason)
+			}
+		}
+	}
+	f.mu.Unlock()
+	if stale {
+		return f.Allow(ctx, mint)
+	}
+	return false, nil
+}
+
+// check returns why mint is filtered out, or "" if it qualifies.
+func (f *MintFilter) check(ctx context.Context, mint string, blacklist, allowlist mintSet) (string, error) {
+	if blacklist.mints[mint] {
+		return FilterBlacklisted, nil
+	}
+	allowedByMint := allowlist.empty() || allowlist.mints[mint]
+
+	var authorities []string
+	if f.resolver != nil && (len(blacklist.authorities) > 0 || (!allowedByMint && len(allowlist.authorities) > 0)) {
+		var err error
+		authorities, err = f.resolver.Authorities(ctx, mint)
+		if err != nil {
+			return "", fmt.Errorf("resolve authorities of %s: %w", mint, err)
+		}
+	}
+
+	for _, a := range authorities {
+		if blacklist.authorities[a] {
+			return FilterBlacklisted, nil
+		}
+	}
+	if allowedByMint {
+		return "", nil
+	}
+	for _, a := range authorities {
+		if allowlist.authorities[a] {
+			return "", nil
+		}
+	}
+	return FilterNotAllowlisted, nil
+}
+
+// Add puts an address on a list. Returns false if it was already listed.
+func (f *MintFilter) Add(list, address string, authority bool) (bool, error) {
+	return f.update(list, func(l *MintList) bool {
+		entries := &l.Mints
+		if authority {
+			entries = &l.Authorities
+		}
+		for _, e := range *entries {
+			if e == address {
+				return false
+			}
+		}
+		*entries = append(*entries, address)
+		return true
+	})
+}
+
+// Remove takes an address off a list. Returns false if it was not listed.
+func (f *MintFilter) Remove(list, address string, authority bool) (bool, error) {
+	return f.update(list, func(l *MintList) bool {
+		entries := &l.Mints
+		if authority {
+			entries = &l.Authorities
+		}
+		for i, e := range *entries {
+			if e == address {
+				*entries = append((*entries)[:i], (*entries)[i+1:]...)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// update applies change to a copy of the named list and installs it if changed.
+func (f *MintFilter) update(list string, change func(*MintList) bool) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var target *mintSet
+	switch list {
+	case MintBlacklist:
+		target = &f.blacklist
+	case MintAllowlist:
+		target = &f.allowlist
+	default:
+		return false, ErrUnknownMintList
+	}
+
+	l := target.list()
+	if !change(&l) {
+		return false, nil
+	}
+	*target = newMintSet(l)
+	f.version++
+	f.rejected = make(map[string]string)
+	return true, nil
+}
+
+// Lists returns the current blacklist and allowlist, sorted.
+func (f *MintFilter) Lists() (blacklist, allowlist MintList) {
+	if f == nil {
+		return MintList{}, MintList{}
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.blacklist.list(), f.allowlist.list()
+}
+
+// Hash identifies the current lists for reproducibility: the hex SHA256 of their
+// sorted entries, or "" if both lists are empty.
+func (f *MintFilter) Hash() string {
+	blacklist, allowlist := f.Lists()
+	if len(blacklist.Mints)+len(blacklist.Authorities)+len(allowlist.Mints)+len(allowlist.Authorities) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, l := range []struct {
+		name string
+		list MintList
+	}{{MintBlacklist, blacklist}, {MintAllowlist, allowlist}} {
+		fmt.Fprintf(h, "%s\n", l.name)
+		for _, m := range l.list.Mints {
+			fmt.Fprintf(h, "%s\n", m)
+		}
+		for _, a := range l.list.Authorities {
+			fmt.Fprintf(h, "%s%s\n", authorityPrefix, a)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Stats returns the number of mints filtered out so far.
+func (f *MintFilter) Stats() MintFilterStats {
+	if f == nil {
+		return MintFilterStats{}
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.stats
+}
@@ -0,0 +1,179 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/storage/memory"
+)
+
+// staticAuthorities resolves authorities from a map and counts lookups.
+type staticAuthorities struct {
+	byMint  map[string][]string
+	lookups int
+	err     error
+}
+
+func (s *staticAuthorities) Authorities(_ context.Context, mint string) ([]string, error) {
+	s.lookups++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.byMint[mint], nil
+}
+
+func mustAllow(t *testing.T, f *MintFilter, mint string, want bool) {
+	t.Helper()
+	got, err := f.Allow(context.Background(), mint)
+	if err != nil {
+		t.Fatalf("Allow(%s): %v", mint, err)
+	}
+	if got != want {
+		t.Errorf("Allow(%s) = %v, want %v", mint, got, want)
+	}
+}
+
+func TestMintFilter_Blacklist(t *testing.T) {
+	f := NewMintFilter(MintList{Mints: []string{"USDC"}}, MintList{})
+
+	mustAllow(t, f, "USDC", false)
+	mustAllow(t, f, "USDC", false)
+	mustAllow(t, f, "MintA", true)
+
+	if stats := f.Stats(); stats.Blacklisted != 1 || stats.NotAllowlisted != 0 {
+		t.Errorf("expected 1 blacklisted mint counted once, got %+v", stats)
+	}
+
+	// Runtime removal takes effect on the next check
+	if removed, err := f.Remove(MintBlacklist, "USDC", false); err != nil || !removed {
+		t.Fatalf("Remove: %v %v", removed, err)
+	}
+	mustAllow(t, f, "USDC", true)
+}
+
+func TestMintFilter_Allowlist(t *testing.T) {
+	var recorded []string
+	f := NewMintFilter(MintList{Mints: []string{"MintB"}}, MintList{Mints: []string{"MintA", "MintB"}}).
+		WithRecorder(func(reason string) { recorded = append(recorded, reason) })
+
+	mustAllow(t, f, "MintA", true)
+	mustAllow(t, f, "MintB", false) // blacklist wins over allowlist
+	mustAllow(t, f, "MintC", false)
+
+	if stats := f.Stats(); stats.Blacklisted != 1 || stats.NotAllowlisted != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if strings.Join(recorded, ",") != FilterBlacklisted+","+FilterNotAllowlisted {
+		t.Errorf("unexpected recorded reasons: %v", recorded)
+	}
+
+	if added, err := f.Add(MintAllowlist, "MintC", false); err != nil || !added {
+		t.Fatalf("Add: %v %v", added, err)
+	}
+	mustAllow(t, f, "MintC", true)
+
+	// Emptying the allowlist lets every non-blacklisted mint through
+	for _, m := range []string{"MintA", "MintB", "MintC"} {
+		if _, err := f.Remove(MintAllowlist, m, false); err != nil {
+			t.Fatalf("Remove: %v", err)
+		}
+	}
+	mustAllow(t, f, "MintD", true)
+
+	if _, err := f.Add("graylist", "MintA", false); !errors.Is(err, ErrUnknownMintList) {
+		t.Errorf("expected ErrUnknownMintList, got %v", err)
+	}
+}
+
+func TestMintFilter_AuthorityMatch(t *testing.T) {
+	resolver := &staticAuthorities{byMint: map[string][]string{
+		"ScamMint": {"ScamFactory"},
+		"GoodMint": {"TrustedDeployer"},
+		"Other":    {"Someone"},
+	}}
+	f := NewMintFilter(
+		MintList{Authorities: []string{"ScamFactory"}},
+		MintList{Mints: []string{"ListedMint"}, Authorities: []string{"TrustedDeployer"}},
+	).WithAuthorityResolver(resolver)
+
+	mustAllow(t, f, "ScamMint", false)
+	mustAllow(t, f, "GoodMint", true)
+	mustAllow(t, f, "ListedMint", true)
+	mustAllow(t, f, "Other", false)
+
+	if stats := f.Stats(); stats.Blacklisted != 1 || stats.NotAllowlisted != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// Rejected mints are not resolved again under the same lists
+	lookups := resolver.lookups
+	mustAllow(t, f, "ScamMint", false)
+	if resolver.lookups != lookups {
+		t.Errorf("expected cached rejection, got %d more lookups", resolver.lookups-lookups)
+	}
+
+	resolver.err = errors.New("rpc down")
+	if _, err := f.Allow(context.Background(), "NewMint"); err == nil {
+		t.Error("expected resolver error to be returned")
+	}
+}
+
+func TestMintFilter_Hash(t *testing.T) {
+	if h := NewMintFilter(MintList{}, MintList{}).Hash(); h != "" {
+		t.Errorf("expected empty hash without lists, got %q", h)
+	}
+
+	a := NewMintFilter(MintList{Mints: []string{"M1", "M2"}}, MintList{Authorities: []string{"A1"}})
+	b := NewMintFilter(MintList{Mints: []string{"M2", "M1"}}, MintList{Authorities: []string{"A1"}})
+	if a.Hash() == "" || a.Hash() != b.Hash() {
+		t.Errorf("expected equal non-empty hashes regardless of order: %q %q", a.Hash(), b.Hash())
+	}
+
+	if _, err := b.Add(MintAllowlist, "A1", false); err != nil {
+		t.Fatal(err)
+	}
+	if a.Hash() == b.Hash() {
+		t.Error("expected hash to change with the lists")
+	}
+}
+
+func TestReadMintList(t *testing.T) {
+	list, err := ReadMintList(strings.NewReader("# scams\nMintA\n\n  authority: FactoryX \nMintB\n"))
+	if err != nil {
+		t.Fatalf("ReadMintList: %v", err)
+	}
+	if strings.Join(list.Mints, ",") != "MintA,MintB" || strings.Join(list.Authorities, ",") != "FactoryX" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+}
+
+func TestDetector_MintFilter(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewCandidateStore()
+	filter := NewMintFilter(MintList{Mints: []string{"Blocked"}}, MintList{})
+	detector := NewDetector(store).WithMintFilter(filter)
+
+	blocked := &SwapEvent{Mint: "Blocked", TxSignature: "tx1", Slot: 1, Timestamp: 1000}
+	c, err := detector.ProcessEvent(ctx, blocked)
+	if err != nil || c != nil {
+		t.Fatalf("expected blacklisted mint to be skipped, got %v %v", c, err)
+	}
+	c, err = detector.ProcessEvent(ctx, &SwapEvent{Mint: "Open", TxSignature: "tx2", Slot: 2, Timestamp: 2000})
+	if err != nil || c == nil {
+		t.Fatalf("expected candidate for unlisted mint, got %v %v", c, err)
+	}
+
+	// Unblocking at runtime lets the next event of the mint through
+	if _, err := filter.Remove(MintBlacklist, "Blocked", false); err != nil {
+		t.Fatal(err)
+	}
+	c, err = detector.ProcessEvent(ctx, &SwapEvent{Mint: "Blocked", TxSignature: "tx3", Slot: 3, Timestamp: 3000})
+	if err != nil || c == nil {
+		t.Fatalf("expected candidate after removal from blacklist, got %v %v", c, err)
+	}
+	if c.TxSignature != "tx3" {
+		t.Errorf("expected candidate triggered by tx3, got %s", c.TxSignature)
+	}
+}
@@ -0,0 +1,119 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// CandidateOrigin is the ingestion origin of a candidate's events.
+type CandidateOrigin struct {
+	// Origin of the discovery event (the stored event of the candidate's
+	// tx_signature), domain.OriginUnknown when it is not stored.
+	Origin string
+
+	// Events counts the swap events of the mint within an hour either side of
+	// discovery and the liquidity events of the candidate; LiveEvents those of
+	// them ingested by the live WebSocket sources.
+	Events     int
+	LiveEvents int
+}
+
+// LiveShare returns the share of the candidate's events ingested live, in [0, 1].
+// Zero without events.
+func (o CandidateOrigin) LiveShare() float64 {
+	if o.Events == 0 {
+		return 0
+	}
+	return float64(o.LiveEvents) / float64(o.Events)
+}
+
+// ResolveCandidateOrigin returns the ingestion origin of c's events. Either
+// store may be nil, in which case its events are not considered.
+func ResolveCandidateOrigin(ctx context.Context, c *domain.TokenCandidate, swapEvents storage.SwapEventStore, liquidity storage.LiquidityEventStore) (CandidateOrigin, error) {
+	out := CandidateOrigin{Origin: domain.OriginUnknown}
+	observe := func(txSignature, origin string) {
+		origin = domain.NormalizeOrigin(origin)
+		out.Events++
+		if origin == domain.OriginLiveWS {
+			out.LiveEvents++
+		}
+		if txSignature == c.TxSignature && out.Origin == domain.OriginUnknown {
+			out.Origin = origin
+		}
+	}
+
+	if swapEvents != nil {
+		swaps, err := swapEvents.GetByMintTimeRange(ctx, c.Mint, c.DiscoveredAt-Window1hMs, c.DiscoveredAt+Window1hMs)
+		if err != nil {
+			return out, fmt.Errorf("swap events of %s: %w", c.Mint, err)
+		}
+		for _, e := range swaps {
+			observe(e.TxSignature, e.Origin)
+		}
+	}
+	if liquidity != nil {
+		events, err := liquidity.GetByCandidateID(ctx, c.CandidateID)
+		if err != nil {
+			return out, fmt.Errorf("liquidity events of %s: %w", c.CandidateID, err)
+		}
+		for _, e := range events {
+			observe(e.TxSignature, e.Origin)
+		}
+	}
+	return out, nil
+}
+
+// OriginSummary counts candidates by the origin of their discovery event.
+type OriginSummary struct {
+	Candidates int
+	ByOrigin   map[string]int // keyed by domain origin, including domain.OriginUnknown
+
+	// Median per-candidate share of live events, over candidates with events.
+	LiveShareMedian float64
+}
+
+// LiveCandidateShare returns the share of candidates discovered from a live
+// event, in [0, 1]. Zero without candidates.
+func (s *OriginSummary) LiveCandidateShare() float64 {
+	if s.Candidates == 0 {
+		return 0
+	}
+	return float64(s.ByOrigin[domain.OriginLiveWS]) / float64(s.Candidates)
+}
+
+// Known reports whether any candidate has a known discovery origin.
+func (s *OriginSummary) Known() bool {
+	return s.ByOrigin[domain.OriginUnknown] < s.Candidates
+}
+
+// SummarizeOrigins resolves the origin of every candidate (see ResolveCandidateOrigin).
+func SummarizeOrigins(ctx context.Context, candidates []*domain.TokenCandidate, swapEvents storage.SwapEventStore, liquidity storage.LiquidityEventStore) (*OriginSummary, error) {
+	summary := &OriginSummary{
+		Candidates: len(candidates),
+		ByOrigin:   make(map[string]int),
+	}
+	var shares []float64
+	for _, c := range candidates {
+		o, err := ResolveCandidateOrigin(ctx, c, swapEvents, liquidity)
+		if err != nil {
+			return nil, err
+		}
+		summary.ByOrigin[o.Origin]++
+		if o.Events > 0 {
+			shares = append(shares, o.LiveShare())
+		}
+	}
+	if len(shares) > 0 {
+		sort.Float64s(shares)
+		mid := len(shares) / 2
+		summary.LiveShareMedian = shares[mid]
+		if len(shares)%2 == 0 {
+			summary.LiveShareMedian = (shares[mid-1] + shares[mid]) / 2
+		}
+	}
+	return summary, nil
+}
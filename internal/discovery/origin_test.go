@@ -0,0 +1,105 @@
+package discovery
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestSummarizeOrigins_MixedOrigins(t *testing.T) {
+	ctx := context.Background()
+	swapEvents := memory.NewSwapEventStore()
+	liquidity := memory.NewLiquidityEventStore()
+
+	candidates := []*domain.TokenCandidate{
+		{CandidateID: "c1", Mint: "m1", TxSignature: "tx_c1", DiscoveredAt: 1_000_000},
+		{CandidateID: "c2", Mint: "m2", TxSignature: "tx_c2", DiscoveredAt: 1_000_000},
+		{CandidateID: "c3", Mint: "m3", TxSignature: "tx_c3", DiscoveredAt: 1_000_000},
+		{CandidateID: "c4", Mint: "m4", TxSignature: "tx_c4", DiscoveredAt: 1_000_000},
+	}
+
+	if err := swapEvents.InsertBulk(ctx, []*domain.SwapEvent{
+		// c1: discovered live, one backfilled swap later on
+		{Mint: "m1", TxSignature: "tx_c1", Timestamp: 1_000_000, Origin: domain.OriginLiveWS},
+		{Mint: "m1", TxSignature: "s1", Timestamp: 1_000_100, Origin: domain.OriginLiveWS},
+		{Mint: "m1", TxSignature: "s2", Timestamp: 1_000_200, Origin: domain.OriginBackfill},
+		{Mint: "m1", TxSignature: "s3", Timestamp: 1_000_300, Origin: domain.OriginLiveWS},
+		// c2: discovered by the backfill
+		{Mint: "m2", TxSignature: "tx_c2", Timestamp: 1_000_000, Origin: domain.OriginBackfill},
+		// c4: a pre-origin row, stored as unknown
+		{Mint: "m4", TxSignature: "tx_c4", Timestamp: 1_000_000},
+		// outside c1's window: ignored
+		{Mint: "m1", TxSignature: "late", Timestamp: 1_000_000 + 2*Window1hMs, Origin: domain.OriginBackfill},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+	// c3: discovered from a replayed pool creation; no swaps
+	if err := liquidity.Insert(ctx, &domain.LiquidityEvent{
+		CandidateID: "c3", Pool: "p3", Mint: "m3", TxSignature: "tx_c3", Timestamp: 1_000_000,
+		EventType: domain.LiquidityEventAdd, Origin: domain.OriginReplay,
+	}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	c1, err := ResolveCandidateOrigin(ctx, candidates[0], swapEvents, liquidity)
+	if err != nil {
+		t.Fatalf("ResolveCandidateOrigin failed: %v", err)
+	}
+	if c1.Origin != domain.OriginLiveWS || c1.Events != 4 || c1.LiveEvents != 3 {
+		t.Errorf("c1: got origin=%s events=%d live=%d, want live_ws 4 3", c1.Origin, c1.Events, c1.LiveEvents)
+	}
+
+	summary, err := SummarizeOrigins(ctx, candidates, swapEvents, liquidity)
+	if err != nil {
+		t.Fatalf("SummarizeOrigins failed: %v", err)
+	}
+	want := map[string]int{
+		domain.OriginLiveWS:   1,
+		domain.OriginBackfill: 1,
+		domain.OriginReplay:   1,
+		domain.OriginUnknown:  1,
+	}
+	for origin, n := range want {
+		if summary.ByOrigin[origin] != n {
+			t.Errorf("ByOrigin[%s] = %d, want %d", origin, summary.ByOrigin[origin], n)
+		}
+	}
+	if got := summary.LiveCandidateShare(); got != 0.25 {
+		t.Errorf("LiveCandidateShare = %v, want 0.25", got)
+	}
+	if !summary.Known() {
+		t.Error("expected known origins")
+	}
+	// Per-candidate live shares: 0.75, 0, 0, 0
+	if summary.LiveShareMedian != 0 {
+		t.Errorf("LiveShareMedian = %v, want 0", summary.LiveShareMedian)
+	}
+
+	// Only the live and backfilled candidates: median of 0.75 and 0
+	summary, err = SummarizeOrigins(ctx, candidates[:2], swapEvents, liquidity)
+	if err != nil {
+		t.Fatalf("SummarizeOrigins failed: %v", err)
+	}
+	if math.Abs(summary.LiveShareMedian-0.375) > 1e-9 {
+		t.Errorf("LiveShareMedian = %v, want 0.375", summary.LiveShareMedian)
+	}
+}
+
+func TestSummarizeOrigins_UnstoredDiscoveryEvent(t *testing.T) {
+	ctx := context.Background()
+	candidates := []*domain.TokenCandidate{{CandidateID: "c1", Mint: "m1", TxSignature: "tx_c1", DiscoveredAt: 1000}}
+
+	summary, err := SummarizeOrigins(ctx, candidates, memory.NewSwapEventStore(), nil)
+	if err != nil {
+		t.Fatalf("SummarizeOrigins failed: %v", err)
+	}
+	if summary.ByOrigin[domain.OriginUnknown] != 1 || summary.Known() {
+		t.Errorf("expected the candidate to be unknown, got %v", summary.ByOrigin)
+	}
+	if summary.LiveCandidateShare() != 0 {
+		t.Errorf("expected no live share, got %v", summary.LiveCandidateShare())
+	}
+}
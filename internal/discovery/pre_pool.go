@@ -0,0 +1,122 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DefaultProvisionalTTL is how long a provisional mint waits for a pool before it expires.
+const DefaultProvisionalTTL = 24 * time.Hour
+
+// MintCreationEvent is an SPL Token InitializeMint/InitializeMint2 seen on chain.
+type MintCreationEvent struct {
+	Mint        string // initialized mint address
+	TxSignature string // mint creation transaction signature
+	Slot        int64  // Solana slot number
+	Timestamp   int64  // block time in milliseconds
+}
+
+// PrePoolTracker records mints at creation as provisional PRE_POOL discoveries,
+// links them to their NEW_TOKEN candidate once a pool appears, and expires the
+// ones that never get a pool. Provisional mints are not candidates: the
+// NEW_TOKEN detector still decides discovery, so simulations are unaffected.
+type PrePoolTracker struct {
+	store          storage.ProvisionalMintStore
+	candidateStore storage.CandidateStore
+	ttl            time.Duration
+	now            func() time.Time // optional, set for live ingestion only
+	mintFilter     *MintFilter      // optional, blacklist/allowlist
+}
+
+// NewPrePoolTracker creates a tracker. A zero ttl uses DefaultProvisionalTTL.
+func NewPrePoolTracker(store storage.ProvisionalMintStore, candidateStore storage.CandidateStore, ttl time.Duration) *PrePoolTracker {
+	if ttl == 0 {
+		ttl = DefaultProvisionalTTL
+	}
+	return &PrePoolTracker{
+		store:          store,
+		candidateStore: candidateStore,
+		ttl:            ttl,
+	}
+}
+
+// WithLiveClock marks this tracker as live: provisional mints get DetectedAt from now.
+func (t *PrePoolTracker) WithLiveClock(now func() time.Time) *PrePoolTracker {
+	t.now = now
+	return t
+}
+
+// WithMintFilter skips mints the blacklist/allowlist would keep from becoming candidates.
+func (t *PrePoolTracker) WithMintFilter(f *MintFilter) *PrePoolTracker {
+	t.mintFilter = f
+	return t
+}
+
+// ProcessMintCreation records a provisional mint. Returns the record, or nil if
+// the mint is already tracked, already a candidate or filtered out.
+func (t *PrePoolTracker) ProcessMintCreation(ctx context.Context, event *MintCreationEvent) (*domain.ProvisionalMint, error) {
+	if event.Mint == "" {
+		return nil, nil
+	}
+
+	// A mint that already has a candidate had its pool first (or was replayed)
+	existing, err := t.candidateStore.GetByMint(ctx, event.Mint)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, nil
+	}
+
+	allowed, err := t.mintFilter.Allow(ctx, event.Mint)
+	if err != nil || !allowed {
+		return nil, err
+	}
+
+	m := &domain.ProvisionalMint{
+		Mint:        event.Mint,
+		TxSignature: event.TxSignature,
+		Slot:        event.Slot,
+		CreatedAt:   event.Timestamp,
+		Status:      domain.ProvisionalMintPending,
+	}
+	if t.now != nil {
+		detectedAt := t.now().UnixMilli()
+		m.DetectedAt = &detectedAt
+	}
+
+	if err := t.store.Insert(ctx, m); err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// Upgrade links a NEW_TOKEN candidate to its pending provisional mint.
+// Returns the upgraded record, or nil if the mint was not pending (never seen
+// at creation, already upgraded or expired) or c is not a NEW_TOKEN candidate.
+func (t *PrePoolTracker) Upgrade(ctx context.Context, c *domain.TokenCandidate) (*domain.ProvisionalMint, error) {
+	if c.Source != domain.SourceNewToken {
+		return nil, nil
+	}
+	if err := t.store.Upgrade(ctx, c.Mint, c.CandidateID, c.DiscoveredAt); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t.store.GetByMint(ctx, c.Mint)
+}
+
+// ExpireAt expires provisional mints still pending ttl after their creation,
+// evaluated at now (Unix ms). Returns how many were expired.
+// Callers pass event time rather than wall-clock time to stay deterministic.
+func (t *PrePoolTracker) ExpireAt(ctx context.Context, now int64) (int, error) {
+	return t.store.ExpirePending(ctx, now-t.ttl.Milliseconds(), now)
+}
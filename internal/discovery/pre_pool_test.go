@@ -0,0 +1,120 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestPrePoolTracker_UpgradeOnPoolCreation(t *testing.T) {
+	ctx := context.Background()
+	provisional := memory.NewProvisionalMintStore()
+	candidates := memory.NewCandidateStore()
+	tracker := NewPrePoolTracker(provisional, candidates, time.Hour).
+		WithLiveClock(func() time.Time { return time.UnixMilli(1_000_400) })
+	detector := NewDetector(candidates)
+
+	m, err := tracker.ProcessMintCreation(ctx, &MintCreationEvent{Mint: "MintA", TxSignature: "txMint", Slot: 100, Timestamp: 1_000_000})
+	if err != nil {
+		t.Fatalf("ProcessMintCreation: %v", err)
+	}
+	if m == nil || m.Status != domain.ProvisionalMintPending || m.DetectedAt == nil || *m.DetectedAt != 1_000_400 {
+		t.Fatalf("expected a pending provisional mint, got %+v", m)
+	}
+
+	// Seeing the creation again records nothing new
+	if again, _ := tracker.ProcessMintCreation(ctx, &MintCreationEvent{Mint: "MintA", TxSignature: "txMint", Slot: 100, Timestamp: 1_000_000}); again != nil {
+		t.Errorf("expected duplicate creation ignored, got %+v", again)
+	}
+
+	// The pool appears: the NEW_TOKEN detector still creates the candidate
+	candidate, err := detector.ProcessPoolCreation(ctx, &LiquidityEvent{
+		Pool: "PoolA", Mint: "MintA", TxSignature: "txPool", Slot: 250, Timestamp: 1_060_000, PoolInit: true,
+	})
+	if err != nil || candidate == nil {
+		t.Fatalf("expected NEW_TOKEN candidate, got %+v (err %v)", candidate, err)
+	}
+
+	upgraded, err := tracker.Upgrade(ctx, candidate)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if upgraded == nil || upgraded.Status != domain.ProvisionalMintUpgraded ||
+		upgraded.CandidateID == nil || *upgraded.CandidateID != candidate.CandidateID {
+		t.Fatalf("expected mint linked to %s, got %+v", candidate.CandidateID, upgraded)
+	}
+	// The earliest timestamp is kept for latency analysis
+	if upgraded.CreatedAt != 1_000_000 || candidate.DiscoveredAt != 1_060_000 {
+		t.Errorf("unexpected timestamps: created %d, discovered %d", upgraded.CreatedAt, candidate.DiscoveredAt)
+	}
+	if delay, ok := upgraded.PoolDelayMs(); !ok || delay != 60_000 {
+		t.Errorf("expected 60s from creation to pool, got %d (ok=%v)", delay, ok)
+	}
+
+	// Upgrading is one-off, and mints never seen at creation are left alone
+	if again, err := tracker.Upgrade(ctx, candidate); err != nil || again != nil {
+		t.Errorf("expected second upgrade to be a no-op, got %+v (err %v)", again, err)
+	}
+	other := &domain.TokenCandidate{CandidateID: "c-other", Source: domain.SourceNewToken, Mint: "MintB", DiscoveredAt: 2_000_000}
+	if got, err := tracker.Upgrade(ctx, other); err != nil || got != nil {
+		t.Errorf("expected no provisional mint for MintB, got %+v (err %v)", got, err)
+	}
+
+	// A mint that is already a candidate is not recorded as provisional
+	if late, _ := tracker.ProcessMintCreation(ctx, &MintCreationEvent{Mint: "MintA", TxSignature: "txOther", Slot: 300, Timestamp: 1_100_000}); late != nil {
+		t.Errorf("expected existing candidate mint skipped, got %+v", late)
+	}
+}
+
+func TestPrePoolTracker_ExpiresMintsWithoutPool(t *testing.T) {
+	ctx := context.Background()
+	provisional := memory.NewProvisionalMintStore()
+	candidates := memory.NewCandidateStore()
+	tracker := NewPrePoolTracker(provisional, candidates, time.Hour)
+
+	hour := time.Hour.Milliseconds()
+	for _, e := range []*MintCreationEvent{
+		{Mint: "Stale", TxSignature: "tx1", Slot: 1, Timestamp: 0},
+		{Mint: "Pooled", TxSignature: "tx2", Slot: 2, Timestamp: 10},
+		{Mint: "Fresh", TxSignature: "tx3", Slot: 3, Timestamp: hour},
+	} {
+		if _, err := tracker.ProcessMintCreation(ctx, e); err != nil {
+			t.Fatalf("ProcessMintCreation: %v", err)
+		}
+	}
+	pooled := &domain.TokenCandidate{CandidateID: "c-pooled", Source: domain.SourceNewToken, Mint: "Pooled", DiscoveredAt: 20}
+	if _, err := tracker.Upgrade(ctx, pooled); err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+
+	// An hour and a bit after the first creations: only Stale expires
+	n, err := tracker.ExpireAt(ctx, hour+5)
+	if err != nil {
+		t.Fatalf("ExpireAt: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 expired, got %d", n)
+	}
+	for mint, want := range map[string]domain.ProvisionalMintStatus{
+		"Stale":  domain.ProvisionalMintExpired,
+		"Pooled": domain.ProvisionalMintUpgraded,
+		"Fresh":  domain.ProvisionalMintPending,
+	} {
+		m, err := provisional.GetByMint(ctx, mint)
+		if err != nil {
+			t.Fatalf("GetByMint %s: %v", mint, err)
+		}
+		if m.Status != want {
+			t.Errorf("%s: expected %s, got %s", mint, want, m.Status)
+		}
+	}
+
+	// A pool arriving after expiry still makes a candidate, but no link
+	stale := &domain.TokenCandidate{CandidateID: "c-stale", Source: domain.SourceNewToken, Mint: "Stale", DiscoveredAt: 2 * hour}
+	if got, err := tracker.Upgrade(ctx, stale); err != nil || got != nil {
+		t.Errorf("expected expired mint not upgraded, got %+v (err %v)", got, err)
+	}
+}
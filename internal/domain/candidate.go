@@ -1,5 +1,16 @@
 package domain
 
+// CandidateStatus is the observation state of a token candidate.
+type CandidateStatus string
+
+const (
+	// CandidateStatusOpen means the observation window is still running.
+	CandidateStatusOpen CandidateStatus = "OPEN"
+	// CandidateStatusClosed means the window has elapsed and the candidate's
+	// trades and replay fingerprint are final.
+	CandidateStatusClosed CandidateStatus = "CLOSED"
+)
+
 // TokenCandidate represents a discovered token candidate for analysis.
 // Corresponds to token_candidates table in PostgreSQL.
 type TokenCandidate struct {
@@ -11,5 +22,42 @@ type TokenCandidate struct {
 	EventIndex   int     // index of discovery event within transaction
 	Slot         int64   // Solana slot number
 	DiscoveredAt int64   // Unix timestamp in milliseconds
+	DetectedAt   *int64  // wall-clock detection time (ms), nil for backfilled/replayed candidates
+	DEX          string  // DEX of the triggering event, "" if unknown
 	CreatedAt    int64   // record creation timestamp (ms)
+
+	Status            CandidateStatus // OPEN | CLOSED; empty is treated as OPEN
+	ClosedAt          *int64          // closure time (ms), nil while open
+	ReplayFingerprint string          // fingerprint of the in-window events, set at closure
+
+	DeletedAt     *int64 // soft-delete time (ms), nil unless removed as junk
+	DeletedReason string // why the candidate was soft-deleted
+}
+
+// IsClosed reports whether the candidate's observation window has been closed.
+func (c *TokenCandidate) IsClosed() bool {
+	return c.Status == CandidateStatusClosed
+}
+
+// IsDeleted reports whether the candidate was soft-deleted. Deleted candidates
+// keep their row and trades but are excluded from default reads, simulation,
+// aggregates and sufficiency checks.
+func (c *TokenCandidate) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// WindowEnd returns the end (inclusive, Unix ms) of an observation window of
+// windowMs starting at DiscoveredAt.
+func (c *TokenCandidate) WindowEnd(windowMs int64) int64 {
+	return c.DiscoveredAt + windowMs
+}
+
+// GroupByMint links candidates over the same mint, e.g. a token discovered as
+// NEW_TOKEN and later flagged ACTIVE_TOKEN. Each group keeps the input order.
+func GroupByMint(candidates []*TokenCandidate) map[string][]*TokenCandidate {
+	groups := make(map[string][]*TokenCandidate)
+	for _, c := range candidates {
+		groups[c.Mint] = append(groups[c.Mint], c)
+	}
+	return groups
 }
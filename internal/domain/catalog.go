@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrNotInCatalog is returned for a strategy type, entry event type or scenario
+// this build does not support.
+var ErrNotInCatalog = errors.New("not in catalog")
+
+// Catalog is the machine-readable list of the strategies and execution scenarios
+// this build supports. It is served at GET /api/v1/catalog and written as
+// catalog.json by the Phase 1 pipeline.
+type Catalog struct {
+	StrategyVersion string         `json:"strategy_version"`
+	EntryEventTypes []string       `json:"entry_event_types"`
+	Strategies      []StrategySpec `json:"strategies"`
+	Scenarios       []ScenarioSpec `json:"scenarios"`
+}
+
+// StrategySpec describes one strategy type and its parameters.
+type StrategySpec struct {
+	Type       string          `json:"type"`
+	Parameters []ParameterSpec `json:"parameters"`
+}
+
+// ParameterSpec describes one StrategyConfig parameter.
+type ParameterSpec struct {
+	Name     string   `json:"name"` // snake_case StrategyConfig field
+	Type     string   `json:"type"` // "int64" | "float64" | "string"
+	Unit     string   `json:"unit"` // "ms" | "fraction", empty for strings
+	Required bool     `json:"required"`
+	Default  float64  `json:"default"`          // value simulated by the Phase 1 pipeline
+	Values   []string `json:"values,omitempty"` // allowed values of a string parameter, default first
+}
+
+// ScenarioSpec is a predefined ScenarioConfig with its sandwich model calibration.
+type ScenarioSpec struct {
+	ScenarioID     string       `json:"scenario_id"`
+	DelayMs        int64        `json:"delay_ms"`
+	SlippagePct    float64      `json:"slippage_pct"`
+	FeeSOL         float64      `json:"fee_sol"`
+	PriorityFeeSOL float64      `json:"priority_fee_sol"`
+	MEVPenaltyPct  float64      `json:"mev_penalty_pct"`
+	SandwichModel  SandwichSpec `json:"sandwich_model"`
+}
+
+// SandwichSpec is the JSON form of a SandwichModel.
+type SandwichSpec struct {
+	BaseProbability      float64 `json:"base_probability"`
+	ProbabilityPerImpact float64 `json:"probability_per_impact"`
+	MaxProbability       float64 `json:"max_probability"`
+	LossPerImpact        float64 `json:"loss_per_impact"`
+	MaxLossFraction      float64 `json:"max_loss_fraction"`
+}
+
+// StrategyConfig parameter names.
+const (
+	ParamHoldDurationMs     = "hold_duration_ms"
+	ParamTrailPct           = "trail_pct"
+	ParamInitialStopPct     = "initial_stop_pct"
+	ParamActivationGainPct  = "activation_gain_pct"
+	ParamLiquidityDropPct   = "liquidity_drop_pct"
+	ParamLiquidityReference = "liquidity_reference"
+	ParamMaxHoldDurationMs  = "max_hold_duration_ms"
+)
+
+// StrategySpecs lists the supported strategy types with their parameters, in
+// STRATEGY_CATALOG.md order. Defaults are the values the Phase 1 pipeline simulates.
+var StrategySpecs = []StrategySpec{
+	{
+		Type: StrategyTypeTimeExit,
+		Parameters: []ParameterSpec{
+			{Name: ParamHoldDurationMs, Type: "int64", Unit: "ms", Required: true, Default: 300000},
+		},
+	},
+	{
+		Type: StrategyTypeTrailingStop,
+		Parameters: []ParameterSpec{
+			{Name: ParamTrailPct, Type: "float64", Unit: "fraction", Required: true, Default: 0.10},
+			{Name: ParamInitialStopPct, Type: "float64", Unit: "fraction", Required: true, Default: 0.10},
+			{Name: ParamActivationGainPct, Type: "float64", Unit: "fraction", Default: 0},
+			{Name: ParamMaxHoldDurationMs, Type: "int64", Unit: "ms", Required: true, Default: 3600000},
+		},
+	},
+	{
+		Type: StrategyTypeLiquidityGuard,
+		Parameters: []ParameterSpec{
+			{Name: ParamLiquidityDropPct, Type: "float64", Unit: "fraction", Required: true, Default: 0.30},
+			{Name: ParamLiquidityReference, Type: "string", Values: []string{LiquidityReferenceEntry, LiquidityReferencePeak}},
+			{Name: ParamMaxHoldDurationMs, Type: "int64", Unit: "ms", Required: true, Default: 1800000},
+		},
+	},
+}
+
+// PredefinedScenarios lists the predefined scenario configurations, from the
+// most to the least favorable.
+var PredefinedScenarios = []ScenarioConfig{
+	ScenarioConfigOptimistic,
+	ScenarioConfigRealistic,
+	ScenarioConfigPessimistic,
+	ScenarioConfigDegraded,
+}
+
+// NewCatalog builds the catalog of this build, tagged with strategyVersion.
+func NewCatalog(strategyVersion string) *Catalog {
+	c := &Catalog{
+		StrategyVersion: strategyVersion,
+		EntryEventTypes: []string{string(SourceNewToken), string(SourceActiveToken)},
+		Strategies:      StrategySpecs,
+	}
+	for _, sc := range PredefinedScenarios {
+		sw, _ := SandwichModelFor(sc.ScenarioID)
+		c.Scenarios = append(c.Scenarios, ScenarioSpec{
+			ScenarioID:     sc.ScenarioID,
+			DelayMs:        sc.DelayMs,
+			SlippagePct:    sc.SlippagePct,
+			FeeSOL:         sc.FeeSOL,
+			PriorityFeeSOL: sc.PriorityFeeSOL,
+			MEVPenaltyPct:  sc.MEVPenaltyPct,
+			SandwichModel:  SandwichSpec(sw),
+		})
+	}
+	return c
+}
+
+// Strategy returns the spec of a strategy type.
+func (c *Catalog) Strategy(strategyType string) (StrategySpec, bool) {
+	for _, s := range c.Strategies {
+		if s.Type == strategyType {
+			return s, true
+		}
+	}
+	return StrategySpec{}, false
+}
+
+// StrategyTypes returns the supported strategy types in catalog order.
+func (c *Catalog) StrategyTypes() []string {
+	types := make([]string, len(c.Strategies))
+	for i, s := range c.Strategies {
+		types[i] = s.Type
+	}
+	return types
+}
+
+// Scenario returns the configuration of a predefined scenario. The sandwich
+// model is not set: predefined scenarios use the flat MEV penalty.
+func (c *Catalog) Scenario(scenarioID string) (ScenarioConfig, error) {
+	var ids []string
+	for _, s := range c.Scenarios {
+		if s.ScenarioID == scenarioID {
+			return ScenarioConfig{
+				ScenarioID:     s.ScenarioID,
+				DelayMs:        s.DelayMs,
+				SlippagePct:    s.SlippagePct,
+				FeeSOL:         s.FeeSOL,
+				PriorityFeeSOL: s.PriorityFeeSOL,
+				MEVPenaltyPct:  s.MEVPenaltyPct,
+			}, nil
+		}
+		ids = append(ids, s.ScenarioID)
+	}
+	return ScenarioConfig{}, fmt.Errorf("scenario %q %w %s (supported: %s)",
+		scenarioID, ErrNotInCatalog, c.StrategyVersion, strings.Join(ids, ", "))
+}
+
+// CheckStrategyType returns an error wrapping ErrNotInCatalog, listing the
+// supported types, unless strategyType is in the catalog.
+func (c *Catalog) CheckStrategyType(strategyType string) error {
+	if _, ok := c.Strategy(strategyType); !ok {
+		return fmt.Errorf("strategy type %q %w %s (supported: %s)",
+			strategyType, ErrNotInCatalog, c.StrategyVersion, strings.Join(c.StrategyTypes(), ", "))
+	}
+	return nil
+}
+
+// ValidateStrategyConfig checks cfg against the catalog: a supported strategy
+// and entry event type, and every required parameter set.
+func (c *Catalog) ValidateStrategyConfig(cfg StrategyConfig) error {
+	if err := c.CheckStrategyType(cfg.StrategyType); err != nil {
+		return err
+	}
+	spec, _ := c.Strategy(cfg.StrategyType)
+	if !slices.Contains(c.EntryEventTypes, cfg.EntryEventType) {
+		return fmt.Errorf("entry event type %q %w %s (supported: %s)",
+			cfg.EntryEventType, ErrNotInCatalog, c.StrategyVersion, strings.Join(c.EntryEventTypes, ", "))
+	}
+	for _, p := range spec.Parameters {
+		if p.Required && !cfg.hasParameter(p.Name) {
+			return fmt.Errorf("%s requires %s per catalog %s", spec.Type, p.Name, c.StrategyVersion)
+		}
+	}
+	return nil
+}
+
+// DefaultConfig returns the StrategyConfig of spec with every required parameter
+// at its default. Optional parameters are left unset, which selects their default.
+func (s StrategySpec) DefaultConfig(entryEventType string) StrategyConfig {
+	cfg := StrategyConfig{StrategyType: s.Type, EntryEventType: entryEventType}
+	for _, p := range s.Parameters {
+		if !p.Required {
+			continue
+		}
+		switch p.Name {
+		case ParamHoldDurationMs:
+			v := int64(p.Default)
+			cfg.HoldDurationMs = &v
+		case ParamTrailPct:
+			v := p.Default
+			cfg.TrailPct = &v
+		case ParamInitialStopPct:
+			v := p.Default
+			cfg.InitialStopPct = &v
+		case ParamLiquidityDropPct:
+			v := p.Default
+			cfg.LiquidityDropPct = &v
+		case ParamMaxHoldDurationMs:
+			v := int64(p.Default)
+			cfg.MaxHoldDurationMs = &v
+		}
+	}
+	return cfg
+}
+
+// hasParameter reports whether the named parameter is set.
+func (cfg StrategyConfig) hasParameter(name string) bool {
+	switch name {
+	case ParamHoldDurationMs:
+		return cfg.HoldDurationMs != nil
+	case ParamTrailPct:
+		return cfg.TrailPct != nil
+	case ParamInitialStopPct:
+		return cfg.InitialStopPct != nil
+	case ParamActivationGainPct:
+		return cfg.ActivationGainPct != nil
+	case ParamLiquidityDropPct:
+		return cfg.LiquidityDropPct != nil
+	case ParamLiquidityReference:
+		return cfg.LiquidityReference != ""
+	case ParamMaxHoldDurationMs:
+		return cfg.MaxHoldDurationMs != nil
+	default:
+		return false
+	}
+}
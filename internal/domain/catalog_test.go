@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCatalog_ValidateStrategyConfig(t *testing.T) {
+	catalog := NewCatalog("v1.0.0")
+
+	// A strategy entry as a config file would carry it
+	var cfg StrategyConfig
+	if err := json.Unmarshal([]byte(`{"StrategyType": "MOMENTUM", "EntryEventType": "NEW_TOKEN"}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	err := catalog.ValidateStrategyConfig(cfg)
+	if !errors.Is(err, ErrNotInCatalog) {
+		t.Fatalf("expected ErrNotInCatalog, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "v1.0.0") || !strings.Contains(err.Error(), "TIME_EXIT, TRAILING_STOP, LIQUIDITY_GUARD") {
+		t.Errorf("expected the error to cite the catalog, got %v", err)
+	}
+
+	trailing, _ := catalog.Strategy(StrategyTypeTrailingStop)
+	cfg = trailing.DefaultConfig("NEW_TOKEN")
+	if err := catalog.ValidateStrategyConfig(cfg); err != nil {
+		t.Errorf("defaults should validate: %v", err)
+	}
+	cfg.InitialStopPct = nil
+	if err := catalog.ValidateStrategyConfig(cfg); err == nil || !strings.Contains(err.Error(), ParamInitialStopPct) {
+		t.Errorf("expected missing %s to be rejected, got %v", ParamInitialStopPct, err)
+	}
+
+	cfg = trailing.DefaultConfig("PRE_POOL")
+	if err := catalog.ValidateStrategyConfig(cfg); !errors.Is(err, ErrNotInCatalog) {
+		t.Errorf("expected PRE_POOL entry to be rejected, got %v", err)
+	}
+}
+
+func TestCatalog_Scenarios(t *testing.T) {
+	catalog := NewCatalog("v1.0.0")
+	if len(catalog.Scenarios) != len(PredefinedScenarios) {
+		t.Fatalf("expected %d scenarios, got %d", len(PredefinedScenarios), len(catalog.Scenarios))
+	}
+	for _, want := range PredefinedScenarios {
+		got, err := catalog.Scenario(want.ScenarioID)
+		if err != nil {
+			t.Fatalf("Scenario(%s): %v", want.ScenarioID, err)
+		}
+		if got != want {
+			t.Errorf("Scenario(%s) = %+v, want %+v", want.ScenarioID, got, want)
+		}
+	}
+	if catalog.Scenarios[1].SandwichModel.MaxLossFraction != SandwichModelRealistic.MaxLossFraction {
+		t.Errorf("expected the realistic sandwich calibration, got %+v", catalog.Scenarios[1].SandwichModel)
+	}
+	if _, err := catalog.Scenario("typical"); !errors.Is(err, ErrNotInCatalog) {
+		t.Errorf("expected unknown scenario to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+// DEX labels identify the program an event was parsed from.
+// An empty label means the DEX is unknown (e.g. rows ingested before labeling).
+const (
+	DEXRaydium = "raydium"
+	DEXPumpFun = "pumpfun"
+)
+
+// KnownDEXes lists the DEX labels assigned by the parsers, in report order.
+var KnownDEXes = []string{DEXPumpFun, DEXRaydium}
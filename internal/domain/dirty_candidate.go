@@ -0,0 +1,11 @@
+package domain
+
+// DirtyCandidate marks a candidate whose stored trades may be stale: events with
+// timestamps inside one of its trade windows were stored after it was simulated
+// (backfill repair, deferred liquidity association, late live ingestion).
+// Corresponds to dirty_candidates table in PostgreSQL.
+type DirtyCandidate struct {
+	CandidateID       string // PRIMARY KEY
+	EarliestEventTime int64  // earliest timestamp of the late events (ms)
+	MarkedAt          int64  // when the candidate was last marked (ms)
+}
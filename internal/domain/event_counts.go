@@ -0,0 +1,26 @@
+package domain
+
+// CandidateEventCounts records, for one candidate, how many swap and liquidity
+// events ingestion saw inside its observation window and how many it stored
+// once the per-candidate caps applied. Past a cap only 1 event in SampleEvery
+// is stored (all liquidity removals are kept), so Seen is the true total.
+// Corresponds to candidate_event_counts table in PostgreSQL.
+type CandidateEventCounts struct {
+	CandidateID     string // PRIMARY KEY
+	SwapsSeen       int64  // swap events observed in the window
+	SwapsStored     int64  // swap events stored
+	LiquiditySeen   int64  // liquidity events observed in the window
+	LiquidityStored int64  // liquidity events stored
+	SampleEvery     int64  // downsampling factor applied past a cap
+	UpdatedAt       int64  // last update (ms)
+}
+
+// Downsampled reports whether any event of the candidate was dropped by a cap.
+func (c *CandidateEventCounts) Downsampled() bool {
+	return c.SwapsStored < c.SwapsSeen || c.LiquidityStored < c.LiquiditySeen
+}
+
+// DroppedEvents returns the number of events seen but not stored.
+func (c *CandidateEventCounts) DroppedEvents() int64 {
+	return c.SwapsSeen - c.SwapsStored + c.LiquiditySeen - c.LiquidityStored
+}
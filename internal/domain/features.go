@@ -13,4 +13,6 @@ type DerivedFeaturePoint struct {
 	TokenLifetimeMs        int64    // time since first swap (ms)
 	LastSwapIntervalMs     *int64   // time since previous swap, NULL if first row
 	LastLiqEventIntervalMs *int64   // time since previous liquidity event, NULL if none
+	RollingImbalance       *float64 // buy/sell imbalance over the trailing window, NULL if no sided volume
+	PoolChange             bool     // first point after the candidate's liquidity moved to another pool
 }
@@ -0,0 +1,16 @@
+package domain
+
+// TokenHolderSnapshot is a point-in-time holder concentration estimate for a candidate mint.
+// Corresponds to token_holder_snapshots table in PostgreSQL.
+//
+// Derived from getTokenLargestAccounts, which returns at most 20 accounts,
+// so HolderCount is a lower bound capped at 20.
+type TokenHolderSnapshot struct {
+	CandidateID   string  // FK to token_candidates
+	Mint          string  // token mint address
+	SnapshotAt    int64   // when the snapshot was taken (ms)
+	HolderCount   int     // non-empty accounts among the largest accounts (lower bound)
+	Top10Pct      float64 // share of supply held by the 10 largest accounts, 0-100
+	TotalSupply   float64 // total supply in UI units (raw / 10^decimals)
+	LargestHolder string  // address of the largest token account
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 // LiquidityEvent represents a liquidity add/remove event.
 // Corresponds to liquidity_events table in PostgreSQL.
 type LiquidityEvent struct {
@@ -15,7 +20,18 @@ type LiquidityEvent struct {
 	AmountToken    float64 // token amount added/removed
 	AmountQuote    float64 // quote currency (SOL/USDC) amount
 	LiquidityAfter float64 // total pool liquidity after event
+	Estimated      bool    // LiquidityAfter estimated from cumulative deltas, not measured
+	DEX            string  // DEX the event was parsed from, "" if unknown
+	Origin         string  // OriginLiveWS / OriginBackfill / OriginReplay, stored as OriginUnknown when empty
 	CreatedAt      int64   // record creation timestamp (ms)
+
+	// PoolInit marks the pool-creation event (initial reserves). Set by the
+	// parser for live NEW_TOKEN detection; not persisted.
+	PoolInit bool
+
+	// Migration marks a pump.fun bonding curve migration, the graduation of
+	// the mint to a DEX pool. Set by the parser for pool linking; not persisted.
+	Migration bool
 }
 
 // Liquidity event type constants
@@ -23,3 +39,36 @@ const (
 	LiquidityEventAdd    = "add"
 	LiquidityEventRemove = "remove"
 )
+
+// liquidityEventTypeSynonyms maps lower-cased parser outputs to the canonical types.
+var liquidityEventTypeSynonyms = map[string]string{
+	LiquidityEventAdd:    LiquidityEventAdd,
+	"deposit":            LiquidityEventAdd,
+	"add_liquidity":      LiquidityEventAdd,
+	"addliquidity":       LiquidityEventAdd,
+	LiquidityEventRemove: LiquidityEventRemove,
+	"withdraw":           LiquidityEventRemove,
+	"remove_liquidity":   LiquidityEventRemove,
+	"removeliquidity":    LiquidityEventRemove,
+}
+
+// UnknownLiquidityEventTypeError is returned for an event type that is neither
+// an add nor a remove.
+type UnknownLiquidityEventTypeError struct {
+	EventType string
+}
+
+func (e *UnknownLiquidityEventTypeError) Error() string {
+	return fmt.Sprintf("unknown liquidity event type %q", e.EventType)
+}
+
+// NormalizeLiquidityEventType returns the canonical type (LiquidityEventAdd or
+// LiquidityEventRemove) of t, ignoring case, surrounding space and known synonyms
+// such as "Deposit" or "withdraw". Any other value is an
+// *UnknownLiquidityEventTypeError.
+func NormalizeLiquidityEventType(t string) (string, error) {
+	if canonical, ok := liquidityEventTypeSynonyms[strings.ToLower(strings.TrimSpace(t))]; ok {
+		return canonical, nil
+	}
+	return "", &UnknownLiquidityEventTypeError{EventType: t}
+}
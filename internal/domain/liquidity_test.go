@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeLiquidityEventType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"add", LiquidityEventAdd},
+		{"remove", LiquidityEventRemove},
+		{"ADD", LiquidityEventAdd},
+		{" Remove ", LiquidityEventRemove},
+		{"Deposit", LiquidityEventAdd},
+		{"withdraw", LiquidityEventRemove},
+		{"add_liquidity", LiquidityEventAdd},
+		{"RemoveLiquidity", LiquidityEventRemove},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeLiquidityEventType(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeLiquidityEventType(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeLiquidityEventType_RejectsUnknown(t *testing.T) {
+	for _, in := range []string{"", "swap", "ad", "burn"} {
+		_, err := NormalizeLiquidityEventType(in)
+		var unknown *UnknownLiquidityEventTypeError
+		if !errors.As(err, &unknown) || unknown.EventType != in {
+			t.Errorf("NormalizeLiquidityEventType(%q): expected UnknownLiquidityEventTypeError, got %v", in, err)
+		}
+	}
+}
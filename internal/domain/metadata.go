@@ -3,12 +3,16 @@ package domain
 // TokenMetadata represents token metadata from on-chain.
 // Corresponds to token_metadata table in PostgreSQL.
 type TokenMetadata struct {
-	CandidateID string   // PK + FK to token_candidates
-	Mint        string   // token mint address
-	Name        *string  // token name (nullable)
-	Symbol      *string  // token symbol (nullable)
-	Decimals    int      // token decimals
-	Supply      *float64 // total supply (nullable)
-	FetchedAt   int64    // when metadata was fetched (ms)
-	CreatedAt   int64    // record creation timestamp (ms)
+	CandidateID     string   // PK + FK to token_candidates
+	Mint            string   // token mint address
+	Name            *string  // token name (nullable)
+	Symbol          *string  // token symbol (nullable)
+	URI             *string  // Metaplex metadata URI (nullable)
+	Decimals        int      // token decimals
+	Supply          *float64 // total supply (nullable)
+	MintAuthority   *string  // mint authority, nil if revoked or unknown
+	FreezeAuthority *string  // freeze authority, nil if revoked or unknown
+	FirstFetchedAt  int64    // when metadata was first fetched (ms), preserved across refreshes
+	FetchedAt       int64    // when metadata was last fetched (ms)
+	CreatedAt       int64    // record creation timestamp (ms)
 }
@@ -0,0 +1,21 @@
+package domain
+
+// Ingestion origins record how an event reached storage. A report built mostly
+// from backfilled history does not exercise the live pipeline.
+const (
+	OriginUnknown  = "unknown"  // rows stored before origins were recorded
+	OriginLiveWS   = "live_ws"  // WebSocket subscription
+	OriginBackfill = "backfill" // RPC backfill of a past range
+	OriginReplay   = "replay"   // reparsed from the raw transaction archive
+)
+
+// KnownOrigins lists the ingestion origins, in report order.
+var KnownOrigins = []string{OriginLiveWS, OriginBackfill, OriginReplay, OriginUnknown}
+
+// NormalizeOrigin returns origin, or OriginUnknown when it is empty.
+func NormalizeOrigin(origin string) string {
+	if origin == "" {
+		return OriginUnknown
+	}
+	return origin
+}
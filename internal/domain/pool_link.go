@@ -0,0 +1,29 @@
+package domain
+
+// Reasons a pool was linked to a candidate.
+const (
+	PoolLinkDiscovery    = "discovery"     // the pool the candidate was discovered in
+	PoolLinkPoolCreation = "pool_creation" // a later pool created for the mint
+	PoolLinkGraduation   = "graduation"    // the pool a pump.fun bonding curve migrated to
+)
+
+// PoolLink attributes a pool to the canonical candidate of its mint over a
+// validity window, so the events of every pool a token trades in (a pump.fun
+// bonding curve, then the Raydium pool it graduates to) belong to one candidate.
+// Corresponds to pool_links table in PostgreSQL.
+type PoolLink struct {
+	Pool        string // PRIMARY KEY: a pool belongs to one mint
+	Mint        string // token mint address
+	CandidateID string // canonical candidate of the mint
+	DEX         string // DEX of the pool, "" if unknown
+	Reason      string // PoolLinkDiscovery / PoolLinkPoolCreation / PoolLinkGraduation
+	TxSignature string // event that opened the link
+	ValidFrom   int64  // Unix ms, inclusive
+	ValidTo     *int64 // Unix ms, exclusive; nil while the pool is live
+	CreatedAt   int64  // record creation timestamp (ms)
+}
+
+// Covers reports whether the link attributes an event at timestamp (ms).
+func (l *PoolLink) Covers(timestamp int64) bool {
+	return timestamp >= l.ValidFrom && (l.ValidTo == nil || timestamp < *l.ValidTo)
+}
@@ -0,0 +1,38 @@
+package domain
+
+// ProvisionalMintStatus is the state of a provisional (PRE_POOL) mint.
+type ProvisionalMintStatus string
+
+const (
+	// ProvisionalMintPending means no pool has been seen for the mint yet.
+	ProvisionalMintPending ProvisionalMintStatus = "PENDING"
+	// ProvisionalMintUpgraded means the mint became a NEW_TOKEN candidate.
+	ProvisionalMintUpgraded ProvisionalMintStatus = "UPGRADED"
+	// ProvisionalMintExpired means no pool appeared within the provisional TTL.
+	ProvisionalMintExpired ProvisionalMintStatus = "EXPIRED"
+)
+
+// ProvisionalMint is a SourcePrePool discovery: a mint seen at its SPL Token
+// InitializeMint/InitializeMint2 instruction, before any DEX activity.
+// It is not a TokenCandidate and is never simulated; when a pool appears, the
+// NEW_TOKEN candidate is linked to it and CreatedAt keeps the earliest timestamp.
+// Corresponds to provisional_mints table in PostgreSQL.
+type ProvisionalMint struct {
+	Mint        string                // PRIMARY KEY, token mint address
+	TxSignature string                // mint creation transaction signature
+	Slot        int64                 // Solana slot number
+	CreatedAt   int64                 // block time of the mint creation (ms)
+	DetectedAt  *int64                // wall-clock detection time (ms), nil for backfilled mints
+	Status      ProvisionalMintStatus // PENDING | UPGRADED | EXPIRED
+	CandidateID *string               // NEW_TOKEN candidate, set on upgrade
+	ResolvedAt  *int64                // candidate discovered_at on upgrade, expiry time on expiry (ms)
+}
+
+// PoolDelayMs returns the time from mint creation to NEW_TOKEN discovery.
+// ok is false unless the mint was upgraded.
+func (m *ProvisionalMint) PoolDelayMs() (delay int64, ok bool) {
+	if m.Status != ProvisionalMintUpgraded || m.ResolvedAt == nil {
+		return 0, false
+	}
+	return *m.ResolvedAt - m.CreatedAt, true
+}
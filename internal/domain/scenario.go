@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"strconv"
+	"strings"
+)
+
 // ScenarioConfig represents execution scenario parameters.
 // From EXECUTION_SCENARIOS.md.
 type ScenarioConfig struct {
@@ -9,6 +14,45 @@ type ScenarioConfig struct {
 	FeeSOL         float64 // base transaction fee in SOL
 	PriorityFeeSOL float64 // priority fee in SOL
 	MEVPenaltyPct  float64 // MEV penalty percentage
+
+	// Sandwich replaces the flat MEVPenaltyPct with a size-dependent sandwich
+	// model on entry fills. Nil (the default) keeps the flat penalty.
+	Sandwich *SandwichModel
+}
+
+// SandwichModel prices sandwich attacks on an entry fill by its price impact,
+// the ratio of position value to pool liquidity at entry. A trade is sandwiched
+// with probability
+//
+//	min(MaxProbability, BaseProbability + ProbabilityPerImpact * impact)
+//
+// and then loses min(MaxLossFraction, LossPerImpact * impact) of its position
+// value. Whether a given trade is sandwiched is decided deterministically from
+// its trade_id, so re-simulation reproduces the same cost.
+type SandwichModel struct {
+	BaseProbability      float64 // probability for a negligible trade
+	ProbabilityPerImpact float64 // added probability per unit of impact
+	MaxProbability       float64 // probability cap
+	LossPerImpact        float64 // loss fraction of position value per unit of impact
+	MaxLossFraction      float64 // loss fraction cap
+}
+
+// Impact returns positionValue / liquidity, or 0 if liquidity is unknown or not positive.
+func (m SandwichModel) Impact(positionValue float64, liquidity *float64) float64 {
+	if liquidity == nil || *liquidity <= 0 {
+		return 0
+	}
+	return positionValue / *liquidity
+}
+
+// Probability returns the probability of being sandwiched at the given impact.
+func (m SandwichModel) Probability(impact float64) float64 {
+	return min(m.MaxProbability, m.BaseProbability+m.ProbabilityPerImpact*impact)
+}
+
+// LossFraction returns the fraction of position value lost when sandwiched at the given impact.
+func (m SandwichModel) LossFraction(impact float64) float64 {
+	return min(m.MaxLossFraction, m.LossPerImpact*impact)
 }
 
 // Scenario ID constants
@@ -19,6 +63,56 @@ const (
 	ScenarioDegraded    = "degraded"
 )
 
+// Scenario presets select which scenarios a pipeline run simulates.
+const (
+	ScenarioPresetMonitoring = "monitoring" // realistic and pessimistic, for routine runs
+	ScenarioPresetDecision   = "decision"   // the full matrix, required by decision reports
+)
+
+// ScenarioPresetIDs returns the scenario IDs of a preset, or false if the preset is unknown.
+func ScenarioPresetIDs(preset string) ([]string, bool) {
+	switch preset {
+	case ScenarioPresetMonitoring:
+		return []string{ScenarioRealistic, ScenarioPessimistic}, true
+	case ScenarioPresetDecision:
+		return []string{ScenarioOptimistic, ScenarioRealistic, ScenarioPessimistic, ScenarioDegraded}, true
+	default:
+		return nil, false
+	}
+}
+
+// BaseScenarioVersion is the version of the scenario definitions trades were first
+// simulated with. Trades of the base version keep the bare scenario_id.
+const BaseScenarioVersion = 1
+
+// scenarioVersionSep separates a scenario_id from its definitions version.
+const scenarioVersionSep = "@v"
+
+// VersionedScenarioID returns the scenario_id under which trades costed with the
+// given version of the scenario definitions are stored, e.g. "realistic@v2".
+// Versions up to BaseScenarioVersion return scenarioID unchanged, so recomputed
+// trades get their own trade_ids and aggregates and never replace the originals.
+func VersionedScenarioID(scenarioID string, version int) string {
+	if version <= BaseScenarioVersion {
+		return scenarioID
+	}
+	return scenarioID + scenarioVersionSep + strconv.Itoa(version)
+}
+
+// SplitScenarioID splits a stored scenario_id into the scenario and the version of
+// its definitions. Unversioned IDs are BaseScenarioVersion.
+func SplitScenarioID(id string) (scenarioID string, version int) {
+	i := strings.LastIndex(id, scenarioVersionSep)
+	if i < 0 {
+		return id, BaseScenarioVersion
+	}
+	v, err := strconv.Atoi(id[i+len(scenarioVersionSep):])
+	if err != nil || v <= BaseScenarioVersion {
+		return id, BaseScenarioVersion
+	}
+	return id[:i], v
+}
+
 // Predefined scenario configurations from EXECUTION_SCENARIOS.md
 var (
 	ScenarioConfigOptimistic = ScenarioConfig{
@@ -57,3 +151,58 @@ var (
 		MEVPenaltyPct:  5.0,
 	}
 )
+
+// Sandwich model calibrations for the predefined scenarios. They are opt-in:
+// the predefined ScenarioConfigs keep the flat MEVPenaltyPct. At an impact of
+// 1% of pool liquidity each model's expected loss is close to its scenario's
+// flat penalty (realistic: 35% of entries lose 3%, about 1%); smaller trades
+// pay less and larger ones more.
+var (
+	SandwichModelOptimistic = SandwichModel{
+		BaseProbability:      0.01,
+		ProbabilityPerImpact: 5,
+		MaxProbability:       0.25,
+		LossPerImpact:        1,
+		MaxLossFraction:      0.05,
+	}
+
+	SandwichModelRealistic = SandwichModel{
+		BaseProbability:      0.05,
+		ProbabilityPerImpact: 30,
+		MaxProbability:       0.8,
+		LossPerImpact:        3,
+		MaxLossFraction:      0.15,
+	}
+
+	SandwichModelPessimistic = SandwichModel{
+		BaseProbability:      0.15,
+		ProbabilityPerImpact: 60,
+		MaxProbability:       0.95,
+		LossPerImpact:        4,
+		MaxLossFraction:      0.25,
+	}
+
+	SandwichModelDegraded = SandwichModel{
+		BaseProbability:      0.3,
+		ProbabilityPerImpact: 100,
+		MaxProbability:       1,
+		LossPerImpact:        5,
+		MaxLossFraction:      0.4,
+	}
+)
+
+// SandwichModelFor returns the calibrated sandwich model of a predefined scenario.
+func SandwichModelFor(scenarioID string) (SandwichModel, bool) {
+	switch scenarioID {
+	case ScenarioOptimistic:
+		return SandwichModelOptimistic, true
+	case ScenarioRealistic:
+		return SandwichModelRealistic, true
+	case ScenarioPessimistic:
+		return SandwichModelPessimistic, true
+	case ScenarioDegraded:
+		return SandwichModelDegraded, true
+	default:
+		return SandwichModel{}, false
+	}
+}
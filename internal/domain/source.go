@@ -6,6 +6,10 @@ type Source string
 const (
 	SourceNewToken    Source = "NEW_TOKEN"
 	SourceActiveToken Source = "ACTIVE_TOKEN"
+
+	// SourcePrePool marks a ProvisionalMint: a mint seen at creation, before any
+	// pool. It is not a candidate source; see IsValid.
+	SourcePrePool Source = "PRE_POOL"
 )
 
 // String returns the string representation of Source.
@@ -13,7 +17,7 @@ func (s Source) String() string {
 	return string(s)
 }
 
-// IsValid checks if the source is a valid value.
+// IsValid checks if the source is a valid TokenCandidate source.
 func (s Source) IsValid() bool {
 	return s == SourceNewToken || s == SourceActiveToken
 }
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 // StrategyAggregate represents per-strategy aggregate metrics.
 // Corresponds to strategy_aggregates table in SIMULATION_SPEC.md.
 type StrategyAggregate struct {
@@ -34,6 +36,13 @@ type StrategyAggregate struct {
 	OutcomeRealistic   *float64 // baseline (Realistic scenario)
 	OutcomePessimistic *float64 // Pessimistic scenario
 	OutcomeDegraded    *float64 // Degraded scenario
+
+	// Trades forced out at the end of their price data (ExitReasonDataEnd),
+	// and their share of TotalTrades. Their outcomes rest on the last price seen.
+	DataEndExits int
+	DataEndShare float64
+
+	ComputedAt int64 // when the aggregate was computed (ms), 0 if unknown
 }
 
 // StrategyConfig represents strategy configuration parameters.
@@ -45,11 +54,13 @@ type StrategyConfig struct {
 	HoldDurationMs *int64
 
 	// TRAILING_STOP parameters
-	TrailPct       *float64
-	InitialStopPct *float64
+	TrailPct          *float64
+	InitialStopPct    *float64
+	ActivationGainPct *float64 // arm the trailing stop only past this gain over entry (nil or 0: armed at entry)
 
 	// LIQUIDITY_GUARD parameters
-	LiquidityDropPct *float64
+	LiquidityDropPct   *float64
+	LiquidityReference string // "entry" (default) | "peak"
 
 	// Common parameters
 	MaxHoldDurationMs *int64
@@ -61,3 +72,24 @@ const (
 	StrategyTypeTrailingStop   = "TRAILING_STOP"
 	StrategyTypeLiquidityGuard = "LIQUIDITY_GUARD"
 )
+
+// LIQUIDITY_GUARD reference modes: the liquidity the drop is measured from.
+const (
+	LiquidityReferenceEntry = "entry" // liquidity at the entry signal
+	LiquidityReferencePeak  = "peak"  // running max liquidity since entry
+)
+
+// ExternalStrategyPrefix starts the strategy_id of trades imported from an
+// external simulator, keeping them apart from the strategies simulated here.
+const ExternalStrategyPrefix = "EXT:"
+
+// ExternalStrategyID namespaces the strategy ID of an imported trade:
+// "EXT:<namespace>:<strategyID>".
+func ExternalStrategyID(namespace, strategyID string) string {
+	return ExternalStrategyPrefix + namespace + ":" + strategyID
+}
+
+// IsExternalStrategyID reports whether id was assigned by ExternalStrategyID.
+func IsExternalStrategyID(id string) bool {
+	return strings.HasPrefix(id, ExternalStrategyPrefix)
+}
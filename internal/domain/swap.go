@@ -13,6 +13,7 @@ type Swap struct {
 	AmountIn    float64 // input token amount
 	AmountOut   float64 // output token amount
 	Price       float64 // execution price
+	DEX         string  // DEX the swap was parsed from, "" if unknown
 	CreatedAt   int64   // record creation timestamp (ms)
 }
 
@@ -10,4 +10,13 @@ type SwapEvent struct {
 	Slot        int64   // Solana slot number
 	Timestamp   int64   // Unix timestamp in milliseconds
 	AmountOut   float64 // output amount for volume calculations
+	Side        string  // SwapSideBuy / SwapSideSell, "" when the parser cannot tell
+	DEX         string  // DEXRaydium / DEXPumpFun, "" when unknown
+	Trader      string  // fee payer of the swap tx, "" when unknown (pre-028 rows)
+	Origin      string  // OriginLiveWS / OriginBackfill / OriginReplay, stored as OriginUnknown when empty
+
+	// Transaction fees from the tx meta, shared by every event of the tx.
+	// Nil when the transaction was not fetched (WS fallback, pre-020 rows).
+	FeeLamports         *int64 // total fee paid (base + priority)
+	PriorityFeeLamports *int64 // ComputeBudget prioritization fee
 }
@@ -25,13 +25,14 @@ type LiquidityTimeseriesPoint struct {
 // VolumeTimeseriesPoint represents volume aggregated by time intervals.
 // Corresponds to volume_timeseries table in ClickHouse.
 type VolumeTimeseriesPoint struct {
-	CandidateID     string  // token candidate identifier
-	TimestampMs     int64   // interval start timestamp (ms)
-	IntervalSeconds int     // aggregation interval: 60, 300, 3600
-	Volume          float64 // total volume in interval
-	SwapCount       int     // number of swaps in interval
-	BuyVolume       float64 // buy-side volume
-	SellVolume      float64 // sell-side volume
+	CandidateID      string  // token candidate identifier
+	TimestampMs      int64   // interval start timestamp (ms)
+	IntervalSeconds  int     // aggregation interval: 60, 300, 3600
+	Volume           float64 // total volume in interval
+	SwapCount        int     // number of swaps in interval
+	BuyVolume        float64 // buy-side volume
+	SellVolume       float64 // sell-side volume
+	BuySellImbalance float64 // (buy - sell) / (buy + sell), 0 without sided volume
 }
 
 // Supported volume aggregation intervals (in seconds)
@@ -17,6 +17,10 @@ type TradeRecord struct {
 	PositionSize     float64  // base units (default 1.0)
 	PositionValue    float64  // entry_actual_price * position_size
 
+	// EntryContext is the market state at EntrySignalTime (nil for trades
+	// simulated before it was recorded).
+	EntryContext *EntryContext
+
 	// Exit
 	ExitSignalTime  int64   // exit trigger timestamp (ms)
 	ExitSignalPrice float64 // price at exit trigger
@@ -31,6 +35,10 @@ type TradeRecord struct {
 	TotalCostSOL float64 // sum of all costs
 	TotalCostPct float64 // as % of position
 
+	// CostBreakdown itemizes how the scenario produced the costs above
+	// (nil for trades simulated before it was recorded).
+	CostBreakdown *CostBreakdown
+
 	// Outcome
 	GrossReturn  float64 // before costs
 	Outcome      float64 // after costs
@@ -40,6 +48,54 @@ type TradeRecord struct {
 	HoldDurationMs int64    // actual hold time (ms)
 	PeakPrice      *float64 // max price during hold (for trailing stop)
 	MinLiquidity   *float64 // min liquidity during hold
+	PeakLiquidity  *float64 // max liquidity during hold (liquidity guard in peak mode)
+	ArmedAt        *int64   // when the trailing stop armed (ms); nil if it never armed or has no activation gain
+
+	// InputKey identifies the inputs the trade was simulated from (see
+	// simulation.InputKey); "" when simulated without the simulation cache.
+	InputKey string
+}
+
+// EntryContext snapshots the market at a trade's entry signal. It is computed
+// from time series points at or before EntrySignalTime only, so it is safe to
+// use for entry filtering without look-ahead.
+// Persisted as JSONB in trade_records.entry_context.
+type EntryContext struct {
+	Liquidity      *float64 `json:"liquidity"`        // pool liquidity at signal (nullable)
+	Volume1h       float64  `json:"volume_1h"`        // volume in the hour ending at the signal
+	SwapsPerMinute float64  `json:"swaps_per_minute"` // swap rate over that hour, or over the token's life if shorter
+	SwapsPrior     int      `json:"swaps_prior"`      // swaps up to and including the signal
+	TokenAgeMs     int64    `json:"token_age_ms"`     // signal time minus first observed swap
+
+	// SampleEvery is set when ingestion stored only 1 in SampleEvery of the
+	// candidate's events past a per-candidate cap (see CandidateEventCounts):
+	// the swap counts above and the simulated time series are sparser than the
+	// market. It describes the whole observation window, so it is not for entry filtering.
+	SampleEvery int64 `json:"sample_every,omitempty"`
+}
+
+// CostBreakdown is the audit trail of a trade's execution costs under its scenario.
+// Fees and MEV sum to TotalCostSOL. Slippage is not part of TotalCostSOL: it is
+// already priced into EntryActualPrice/ExitActualPrice and thus GrossReturn, and is
+// itemized here only so its share of the total drag is visible.
+// Persisted as JSONB in trade_records.cost_breakdown.
+type CostBreakdown struct {
+	EntrySlippageSOL float64 `json:"entry_slippage_sol"`   // (entry_actual_price - entry_signal_price) * position_size
+	ExitSlippageSOL  float64 `json:"exit_slippage_sol"`    // (exit_signal_price - exit_actual_price) * position_size
+	NetworkFeeSOL    float64 `json:"network_fee_sol"`      // base fee, entry + exit
+	PriorityFeeSOL   float64 `json:"priority_fee_sol"`     // priority fee, entry + exit
+	MEVSOL           float64 `json:"mev_sol"`              // MEV penalty, or realized sandwich loss
+	Sandwiched       bool    `json:"sandwiched,omitempty"` // entry sandwiched under ScenarioConfig.Sandwich
+}
+
+// ChargedSOL returns the components counted in TotalCostSOL (fees and MEV).
+func (b *CostBreakdown) ChargedSOL() float64 {
+	return b.NetworkFeeSOL + b.PriorityFeeSOL + b.MEVSOL
+}
+
+// SlippageSOL returns the slippage cost embedded in the execution prices.
+func (b *CostBreakdown) SlippageSOL() float64 {
+	return b.EntrySlippageSOL + b.ExitSlippageSOL
 }
 
 // Exit reason codes
@@ -49,8 +105,15 @@ const (
 	ExitReasonTrailingStop  = "TRAILING_STOP"
 	ExitReasonMaxDuration   = "MAX_DURATION"
 	ExitReasonLiquidityDrop = "LIQUIDITY_DROP"
+	ExitReasonDataEnd       = "DATA_END" // price data ended before the intended exit; exited at the last point
 )
 
+// IsDataEnd reports whether the trade was forced out at the end of its price
+// data rather than by the strategy's own exit rule.
+func (t *TradeRecord) IsDataEnd() bool {
+	return t.ExitReason == ExitReasonDataEnd
+}
+
 // Outcome class constants
 const (
 	OutcomeClassWin  = "WIN"
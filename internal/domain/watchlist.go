@@ -0,0 +1,9 @@
+package domain
+
+// WatchlistEntry flags a mint for denser data collection and extended observation.
+// Corresponds to watchlist table in PostgreSQL.
+type WatchlistEntry struct {
+	Mint    string // token mint address
+	Reason  string // why the mint is watched, e.g. "manual" or "rug_detector"
+	AddedAt int64  // when the mint was added (ms)
+}
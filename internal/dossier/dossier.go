@@ -0,0 +1,358 @@
+// Package dossier assembles everything known about a single candidate into
+// one JSON document for support and debugging.
+package dossier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/replay"
+	"solana-token-lab/internal/storage"
+)
+
+// Stores are the sources a dossier is assembled from. Candidates is required;
+// a nil optional store leaves its section empty.
+type Stores struct {
+	Candidates          storage.CandidateStore
+	Metadata            storage.TokenMetadataStore
+	Swaps               storage.SwapStore
+	LiquidityEvents     storage.LiquidityEventStore
+	PriceTimeseries     storage.PriceTimeseriesStore
+	LiquidityTimeseries storage.LiquidityTimeseriesStore
+	Trades              storage.TradeRecordStore
+	SwapEvents          storage.SwapEventStore
+}
+
+// Dossier is the full picture of one candidate.
+// Sections that could not be loaded are nil and explained in Warnings.
+type Dossier struct {
+	Candidate         *Candidate      `json:"candidate"`
+	Metadata          *Metadata       `json:"metadata,omitempty"`
+	Swaps             *EventSummary   `json:"swaps,omitempty"`
+	LiquidityEvents   *EventSummary   `json:"liquidity_events,omitempty"`
+	Traders           *TraderSummary  `json:"traders,omitempty"`
+	LatestPrice       *PricePoint     `json:"latest_price,omitempty"`
+	LatestLiquidity   *LiquidityPoint `json:"latest_liquidity,omitempty"`
+	Trades            []Trade         `json:"trades"`
+	ReplayFingerprint string          `json:"replay_fingerprint,omitempty"`
+	Warnings          []string        `json:"warnings"`
+}
+
+// Candidate is the token_candidates row.
+type Candidate struct {
+	CandidateID  string        `json:"candidate_id"`
+	Source       domain.Source `json:"source"`
+	Mint         string        `json:"mint"`
+	Pool         *string       `json:"pool,omitempty"`
+	TxSignature  string        `json:"tx_signature"`
+	EventIndex   int           `json:"event_index"`
+	Slot         int64         `json:"slot"`
+	DiscoveredAt int64         `json:"discovered_at"`
+	DetectedAt   *int64        `json:"detected_at,omitempty"`
+
+	Status   domain.CandidateStatus `json:"status,omitempty"`
+	ClosedAt *int64                 `json:"closed_at,omitempty"`
+}
+
+// Metadata is the token_metadata row.
+type Metadata struct {
+	Name            *string  `json:"name,omitempty"`
+	Symbol          *string  `json:"symbol,omitempty"`
+	URI             *string  `json:"uri,omitempty"`
+	Decimals        int      `json:"decimals"`
+	Supply          *float64 `json:"supply,omitempty"`
+	MintAuthority   *string  `json:"mint_authority,omitempty"`
+	FreezeAuthority *string  `json:"freeze_authority,omitempty"`
+	FetchedAt       int64    `json:"fetched_at"`
+}
+
+// EventSummary counts a candidate's raw events and their time range.
+type EventSummary struct {
+	Count     int   `json:"count"`
+	FirstTime int64 `json:"first_time,omitempty"`
+	LastTime  int64 `json:"last_time,omitempty"`
+}
+
+// TraderSummary counts the distinct traders (swap tx fee payers) of the
+// candidate's mint from discovery swap events.
+type TraderSummary struct {
+	UniqueTraders            int `json:"unique_traders"`              // over all stored swap events of the mint
+	UniqueTradersAtDiscovery int `json:"unique_traders_at_discovery"` // within an hour either side of discovery
+	UnattributedSwaps        int `json:"unattributed_swaps"`          // swap events without a trader
+}
+
+// PricePoint is the most recent price timeseries point.
+type PricePoint struct {
+	TimestampMs int64   `json:"timestamp_ms"`
+	Price       float64 `json:"price"`
+}
+
+// LiquidityPoint is the most recent liquidity timeseries point.
+type LiquidityPoint struct {
+	TimestampMs int64   `json:"timestamp_ms"`
+	Liquidity   float64 `json:"liquidity"`
+}
+
+// Trade is a simulated trade of the candidate under one strategy/scenario.
+type Trade struct {
+	TradeID         string  `json:"trade_id"`
+	StrategyID      string  `json:"strategy_id"`
+	ScenarioID      string  `json:"scenario_id"`
+	EntryActualTime int64   `json:"entry_actual_time"`
+	ExitActualTime  int64   `json:"exit_actual_time"`
+	ExitReason      string  `json:"exit_reason"`
+	Outcome         float64 `json:"outcome"`
+	OutcomeClass    string  `json:"outcome_class"`
+}
+
+// Builder assembles dossiers from Stores.
+type Builder struct {
+	stores Stores
+}
+
+// NewBuilder creates a Builder over the given stores.
+func NewBuilder(stores Stores) *Builder {
+	return &Builder{stores: stores}
+}
+
+// Build assembles the dossier for candidateID. The candidate row must load:
+// its absence returns storage.ErrNotFound and any other failure an error.
+// The remaining sections are loaded concurrently and a failing section is
+// reported in Warnings instead of failing the whole dossier.
+func (b *Builder) Build(ctx context.Context, candidateID string) (*Dossier, error) {
+	c, err := b.stores.Candidates.GetByID(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dossier{
+		Candidate: &Candidate{
+			CandidateID:  c.CandidateID,
+			Source:       c.Source,
+			Mint:         c.Mint,
+			Pool:         c.Pool,
+			TxSignature:  c.TxSignature,
+			EventIndex:   c.EventIndex,
+			Slot:         c.Slot,
+			DiscoveredAt: c.DiscoveredAt,
+			DetectedAt:   c.DetectedAt,
+			Status:       c.Status,
+			ClosedAt:     c.ClosedAt,
+		},
+		Trades:   []Trade{},
+		Warnings: []string{},
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		swaps     []*domain.Swap
+		liquidity []*domain.LiquidityEvent
+		swapsOK   bool
+		liqOK     bool
+	)
+	section := func(name string, load func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := load(); err != nil {
+				mu.Lock()
+				d.Warnings = append(d.Warnings, fmt.Sprintf("%s: %v", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	if s := b.stores.Metadata; s != nil {
+		section("metadata", func() error {
+			m, err := s.GetByID(ctx, candidateID)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return nil
+				}
+				return err
+			}
+			mu.Lock()
+			d.Metadata = &Metadata{
+				Name:            m.Name,
+				Symbol:          m.Symbol,
+				URI:             m.URI,
+				Decimals:        m.Decimals,
+				Supply:          m.Supply,
+				MintAuthority:   m.MintAuthority,
+				FreezeAuthority: m.FreezeAuthority,
+				FetchedAt:       m.FetchedAt,
+			}
+			mu.Unlock()
+			return nil
+		})
+	}
+	if s := b.stores.Swaps; s != nil {
+		section("swaps", func() error {
+			events, err := s.GetByCandidateID(ctx, candidateID)
+			if err != nil {
+				return err
+			}
+			times := make([]int64, len(events))
+			for i, e := range events {
+				times[i] = e.Timestamp
+			}
+			summary := summarize(times)
+			mu.Lock()
+			d.Swaps, swaps, swapsOK = summary, events, true
+			mu.Unlock()
+			return nil
+		})
+	}
+	if s := b.stores.LiquidityEvents; s != nil {
+		section("liquidity_events", func() error {
+			events, err := s.GetByCandidateID(ctx, candidateID)
+			if err != nil {
+				return err
+			}
+			times := make([]int64, len(events))
+			for i, e := range events {
+				times[i] = e.Timestamp
+			}
+			summary := summarize(times)
+			mu.Lock()
+			d.LiquidityEvents, liquidity, liqOK = summary, events, true
+			mu.Unlock()
+			return nil
+		})
+	}
+	if s := b.stores.SwapEvents; s != nil {
+		section("traders", func() error {
+			events, err := s.GetByMintTimeRange(ctx, c.Mint, 0, math.MaxInt64)
+			if err != nil {
+				return err
+			}
+			summary := &TraderSummary{
+				UniqueTraders: discovery.UniqueTraders(events, 0, math.MaxInt64),
+				UniqueTradersAtDiscovery: discovery.UniqueTraders(events,
+					c.DiscoveredAt-discovery.Window1hMs, c.DiscoveredAt+discovery.Window1hMs),
+			}
+			for _, e := range events {
+				if e.Trader == "" {
+					summary.UnattributedSwaps++
+				}
+			}
+			mu.Lock()
+			d.Traders = summary
+			mu.Unlock()
+			return nil
+		})
+	}
+	if s := b.stores.PriceTimeseries; s != nil {
+		section("price_timeseries", func() error {
+			points, err := s.GetByCandidateID(ctx, candidateID)
+			if err != nil || len(points) == 0 {
+				return err
+			}
+			last := points[len(points)-1]
+			mu.Lock()
+			d.LatestPrice = &PricePoint{TimestampMs: last.TimestampMs, Price: last.Price}
+			mu.Unlock()
+			return nil
+		})
+	}
+	if s := b.stores.LiquidityTimeseries; s != nil {
+		section("liquidity_timeseries", func() error {
+			points, err := s.GetByCandidateID(ctx, candidateID)
+			if err != nil || len(points) == 0 {
+				return err
+			}
+			last := points[len(points)-1]
+			mu.Lock()
+			d.LatestLiquidity = &LiquidityPoint{TimestampMs: last.TimestampMs, Liquidity: last.Liquidity}
+			mu.Unlock()
+			return nil
+		})
+	}
+	if s := b.stores.Trades; s != nil {
+		section("trades", func() error {
+			records, err := s.GetByCandidateID(ctx, candidateID)
+			if err != nil {
+				return err
+			}
+			trades := make([]Trade, 0, len(records))
+			for _, t := range records {
+				trades = append(trades, Trade{
+					TradeID:         t.TradeID,
+					StrategyID:      t.StrategyID,
+					ScenarioID:      t.ScenarioID,
+					EntryActualTime: t.EntryActualTime,
+					ExitActualTime:  t.ExitActualTime,
+					ExitReason:      t.ExitReason,
+					Outcome:         t.Outcome,
+					OutcomeClass:    t.OutcomeClass,
+				})
+			}
+			sort.Slice(trades, func(i, j int) bool {
+				if trades[i].StrategyID != trades[j].StrategyID {
+					return trades[i].StrategyID < trades[j].StrategyID
+				}
+				if trades[i].ScenarioID != trades[j].ScenarioID {
+					return trades[i].ScenarioID < trades[j].ScenarioID
+				}
+				return trades[i].TradeID < trades[j].TradeID
+			})
+			mu.Lock()
+			d.Trades = trades
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	wg.Wait()
+
+	// Warnings arrive in completion order; sort them so output is stable.
+	sort.Strings(d.Warnings)
+
+	// The fingerprint covers the full replay stream, so it is only meaningful
+	// when both event sections loaded.
+	if swapsOK && liqOK {
+		d.ReplayFingerprint = ReplayFingerprint(replay.MergeEvents(swaps, liquidity))
+	}
+
+	return d, nil
+}
+
+// summarize counts event timestamps and their range.
+func summarize(times []int64) *EventSummary {
+	s := &EventSummary{Count: len(times)}
+	for i, ts := range times {
+		if i == 0 || ts < s.FirstTime {
+			s.FirstTime = ts
+		}
+		if ts > s.LastTime {
+			s.LastTime = ts
+		}
+	}
+	return s
+}
+
+// ReplayFingerprint returns the SHA256 of a replay event stream in replay order.
+// Two stores holding the same events for a candidate yield the same fingerprint.
+func ReplayFingerprint(events []*replay.Event) string {
+	h := sha256.New()
+	for _, e := range events {
+		fmt.Fprintf(h, "%s|%d|%s|%d|%d", e.Type, e.Slot, e.TxSignature, e.EventIndex, e.Timestamp)
+		switch {
+		case e.Swap != nil:
+			fmt.Fprintf(h, "|%s|%.10f|%.10f|%.10f", e.Swap.Side, e.Swap.AmountIn, e.Swap.AmountOut, e.Swap.Price)
+		case e.Liquidity != nil:
+			fmt.Fprintf(h, "|%s|%.10f|%.10f|%.10f", e.Liquidity.EventType, e.Liquidity.AmountToken, e.Liquidity.AmountQuote, e.Liquidity.LiquidityAfter)
+		}
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,148 @@
+package dossier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// failingPriceStore simulates a ClickHouse outage.
+type failingPriceStore struct {
+	storage.PriceTimeseriesStore
+}
+
+func (failingPriceStore) GetByCandidateID(context.Context, string) ([]*domain.PriceTimeseriesPoint, error) {
+	return nil, errors.New("clickhouse unavailable")
+}
+
+func strPtr(s string) *string { return &s }
+
+func seedStores(t *testing.T) Stores {
+	t.Helper()
+	ctx := context.Background()
+
+	stores := Stores{
+		Candidates:          memory.NewCandidateStore(),
+		Metadata:            memory.NewTokenMetadataStore(),
+		Swaps:               memory.NewSwapStore(),
+		LiquidityEvents:     memory.NewLiquidityEventStore(),
+		PriceTimeseries:     memory.NewPriceTimeseriesStore(),
+		LiquidityTimeseries: memory.NewLiquidityTimeseriesStore(),
+		Trades:              memory.NewTradeRecordStore(),
+		SwapEvents:          memory.NewSwapEventStore(),
+	}
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	must(stores.Candidates.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "c1", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx0", Slot: 10, DiscoveredAt: 1000,
+	}))
+	must(stores.Metadata.Insert(ctx, &domain.TokenMetadata{CandidateID: "c1", Mint: "mint1", Symbol: strPtr("TKN"), Decimals: 6}))
+	must(stores.Swaps.InsertBulk(ctx, []*domain.Swap{
+		{CandidateID: "c1", TxSignature: "tx1", Slot: 11, Timestamp: 2000, Side: domain.SwapSideBuy, AmountIn: 1, AmountOut: 10, Price: 0.1},
+		{CandidateID: "c1", TxSignature: "tx2", Slot: 12, Timestamp: 3000, Side: domain.SwapSideSell, AmountIn: 5, AmountOut: 1, Price: 0.2},
+	}))
+	must(stores.LiquidityEvents.InsertBulk(ctx, []*domain.LiquidityEvent{
+		{CandidateID: "c1", Pool: "pool1", Mint: "mint1", TxSignature: "tx0", Slot: 10, Timestamp: 1000, EventType: "add", AmountQuote: 50, LiquidityAfter: 100},
+	}))
+	must(stores.PriceTimeseries.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+		{CandidateID: "c1", TimestampMs: 2000, Price: 0.1},
+		{CandidateID: "c1", TimestampMs: 3000, Price: 0.2},
+	}))
+	must(stores.LiquidityTimeseries.InsertBulk(ctx, []*domain.LiquidityTimeseriesPoint{
+		{CandidateID: "c1", TimestampMs: 1000, Liquidity: 100},
+	}))
+	must(stores.SwapEvents.InsertBulk(ctx, []*domain.SwapEvent{
+		{Mint: "mint1", TxSignature: "tx1", Timestamp: 2000, Trader: "wallet1"},
+		{Mint: "mint1", TxSignature: "tx2", Timestamp: 3000, Trader: "wallet2"},
+		{Mint: "mint1", TxSignature: "tx3", Timestamp: 4000, Trader: "wallet1"},
+		{Mint: "mint1", TxSignature: "tx4", Timestamp: 5000},
+		{Mint: "mint1", TxSignature: "tx5", Timestamp: 1000 + 2*3600000, Trader: "wallet3"},
+		{Mint: "mint2", TxSignature: "tx6", Timestamp: 2000, Trader: "wallet4"},
+	}))
+	must(stores.Trades.InsertBulk(ctx, []*domain.TradeRecord{
+		{TradeID: "t2", CandidateID: "c1", StrategyID: "TRAILING_STOP", ScenarioID: "realistic", OutcomeClass: "LOSS"},
+		{TradeID: "t1", CandidateID: "c1", StrategyID: "TIME_EXIT", ScenarioID: "realistic", Outcome: 0.5, OutcomeClass: "WIN"},
+	}))
+	return stores
+}
+
+func TestBuild_AllSections(t *testing.T) {
+	d, err := NewBuilder(seedStores(t)).Build(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if d.Candidate.Mint != "mint1" {
+		t.Errorf("unexpected candidate: %+v", d.Candidate)
+	}
+	if d.Metadata == nil || *d.Metadata.Symbol != "TKN" {
+		t.Errorf("expected metadata with symbol TKN, got %+v", d.Metadata)
+	}
+	if d.Swaps == nil || d.Swaps.Count != 2 || d.Swaps.FirstTime != 2000 || d.Swaps.LastTime != 3000 {
+		t.Errorf("unexpected swap summary: %+v", d.Swaps)
+	}
+	if d.LiquidityEvents == nil || d.LiquidityEvents.Count != 1 {
+		t.Errorf("unexpected liquidity summary: %+v", d.LiquidityEvents)
+	}
+	if d.LatestPrice == nil || d.LatestPrice.Price != 0.2 {
+		t.Errorf("expected latest price 0.2, got %+v", d.LatestPrice)
+	}
+	if d.LatestLiquidity == nil || d.LatestLiquidity.Liquidity != 100 {
+		t.Errorf("expected latest liquidity 100, got %+v", d.LatestLiquidity)
+	}
+	if d.Traders == nil || d.Traders.UniqueTraders != 3 || d.Traders.UniqueTradersAtDiscovery != 2 || d.Traders.UnattributedSwaps != 1 {
+		t.Errorf("unexpected trader summary: %+v", d.Traders)
+	}
+	if len(d.Trades) != 2 || d.Trades[0].TradeID != "t1" {
+		t.Errorf("expected trades ordered by strategy, got %+v", d.Trades)
+	}
+	if len(d.ReplayFingerprint) != 64 {
+		t.Errorf("expected sha256 fingerprint, got %q", d.ReplayFingerprint)
+	}
+	if len(d.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", d.Warnings)
+	}
+
+	again, err := NewBuilder(seedStores(t)).Build(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if again.ReplayFingerprint != d.ReplayFingerprint {
+		t.Error("fingerprint is not deterministic")
+	}
+}
+
+func TestBuild_FailingSectionBecomesWarning(t *testing.T) {
+	stores := seedStores(t)
+	stores.PriceTimeseries = failingPriceStore{}
+
+	d, err := NewBuilder(stores).Build(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if d.LatestPrice != nil {
+		t.Errorf("expected no latest price, got %+v", d.LatestPrice)
+	}
+	if len(d.Warnings) != 1 || d.Warnings[0] != "price_timeseries: clickhouse unavailable" {
+		t.Errorf("unexpected warnings: %v", d.Warnings)
+	}
+	if d.Swaps == nil || d.LatestLiquidity == nil || len(d.Trades) != 2 {
+		t.Error("expected remaining sections to load")
+	}
+}
+
+func TestBuild_CandidateNotFound(t *testing.T) {
+	_, err := NewBuilder(seedStores(t)).Build(context.Background(), "missing")
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/clickhouse"
+	"solana-token-lab/internal/storage/migrations"
+	"solana-token-lab/internal/storage/postgres"
+)
+
+// dbStores are the production stores backed by the test containers,
+// wired the same way as cmd/pipeline.
+type dbStores struct {
+	postgresDSN   string
+	clickhouseDSN string
+
+	pgPool *postgres.Pool
+
+	candidateStore           storage.CandidateStore
+	swapStore                storage.SwapStore
+	swapEventStore           storage.SwapEventStore
+	liquidityEventStore      storage.LiquidityEventStore
+	tradeRecordStore         storage.TradeRecordStore
+	priceTimeseriesStore     storage.PriceTimeseriesStore
+	liquidityTimeseriesStore storage.LiquidityTimeseriesStore
+	volumeTimeseriesStore    storage.VolumeTimeseriesStore
+	derivedFeatureStore      storage.DerivedFeatureStore
+	strategyAggregateStore   storage.StrategyAggregateStore
+	tradeAggregateStore      storage.TradeAggregateStore
+}
+
+// setupDatabases starts PostgreSQL and ClickHouse containers, applies the embedded
+// migrations and returns the stores. The test is skipped when Docker is unavailable.
+// Containers are terminated when the test ends.
+func setupDatabases(t *testing.T) *dbStores {
+	t.Helper()
+
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	pgDSN := startPostgres(t, ctx)
+	chDSN := startClickhouse(t, ctx)
+
+	pgPool, err := postgres.NewPool(ctx, pgDSN)
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	t.Cleanup(pgPool.Close)
+	if err := migrations.RunPostgresMigrations(ctx, pgPool); err != nil {
+		t.Fatalf("postgres migrations: %v", err)
+	}
+
+	chConn, err := migrations.RunClickhouseMigrations(ctx, chDSN)
+	if err != nil {
+		t.Fatalf("clickhouse migrations: %v", err)
+	}
+	t.Cleanup(func() { _ = chConn.Close() })
+
+	return &dbStores{
+		postgresDSN:   pgDSN,
+		clickhouseDSN: chDSN,
+		pgPool:        pgPool,
+
+		candidateStore:      postgres.NewCandidateStore(pgPool),
+		swapStore:           postgres.NewSwapStore(pgPool),
+		swapEventStore:      postgres.NewSwapEventStore(pgPool),
+		liquidityEventStore: postgres.NewLiquidityEventStore(pgPool),
+		tradeRecordStore:    postgres.NewTradeRecordStore(pgPool),
+
+		priceTimeseriesStore:     clickhouse.NewPriceTimeseriesStore(chConn),
+		liquidityTimeseriesStore: clickhouse.NewLiquidityTimeseriesStore(chConn),
+		volumeTimeseriesStore:    clickhouse.NewVolumeTimeseriesStore(chConn),
+		derivedFeatureStore:      clickhouse.NewDerivedFeatureStore(chConn),
+		strategyAggregateStore:   clickhouse.NewStrategyAggregateStore(chConn),
+		tradeAggregateStore:      clickhouse.NewTradeAggregateStore(chConn),
+	}
+}
+
+// startPostgres starts a PostgreSQL container and returns its DSN.
+func startPostgres(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	container, err := tcpostgres.Run(ctx, "postgres:15-alpine",
+		tcpostgres.WithDatabase("e2e"),
+		tcpostgres.WithUsername("e2e"),
+		tcpostgres.WithPassword("e2e"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	if err != nil {
+		t.Fatalf("start postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Logf("terminate postgres container: %v", err)
+		}
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("postgres connection string: %v", err)
+	}
+	return dsn
+}
+
+// startClickhouse starts a ClickHouse container and returns a DSN for the e2e database.
+// The database itself is created by the migrations.
+func startClickhouse(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "clickhouse/clickhouse-server:24.1-alpine",
+			ExposedPorts: []string{"9000/tcp"},
+			WaitingFor: wait.ForAll(
+				wait.ForLog("Application: Ready for connections").
+					WithStartupTimeout(60*time.Second),
+				wait.ForListeningPort("9000/tcp"),
+			),
+			Env: map[string]string{
+				"CLICKHOUSE_USER":     "default",
+				"CLICKHOUSE_PASSWORD": "",
+			},
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("start clickhouse container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Logf("terminate clickhouse container: %v", err)
+		}
+	})
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatalf("clickhouse host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "9000")
+	if err != nil {
+		t.Fatalf("clickhouse port: %v", err)
+	}
+	return fmt.Sprintf("clickhouse://%s:%s/e2e", host, port.Port())
+}
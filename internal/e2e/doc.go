@@ -0,0 +1,11 @@
+// Package e2e holds the end-to-end integration test of the full Phase 1 flow:
+// live ingestion → discovery → normalization → simulation → reporting.
+//
+// The tests run against real PostgreSQL and ClickHouse containers and a fake
+// Solana RPC/WebSocket server that replays recorded Raydium transactions from
+// testdata. They are gated by the e2e build tag and skipped when Docker is
+// unavailable:
+//
+//	make test-e2e
+//	go test -tags e2e -count=1 ./internal/e2e/...
+package e2e
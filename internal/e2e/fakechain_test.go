@@ -0,0 +1,243 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// subscribeSettle is how long the fake chain waits after confirming a
+// logsSubscribe before replaying. The client registers the notification
+// channel only after it has read the confirmation.
+const subscribeSettle = 250 * time.Millisecond
+
+// fakeChain serves a fixture over Solana JSON-RPC (HTTP) and logsSubscribe (WebSocket)
+// on one address. Every logs subscription replays the fixture once, in slot order.
+type fakeChain struct {
+	fixture *fixture
+	raw     map[string]json.RawMessage // signature -> recorded getTransaction result
+	server  *httptest.Server
+
+	mu      sync.Mutex
+	fetches map[string]int // getTransaction calls per signature
+	nextSub int64
+}
+
+// newFakeChain starts the fake chain; it is closed when the test ends.
+func newFakeChain(t *testing.T, f *fixture) *fakeChain {
+	t.Helper()
+
+	c := &fakeChain{
+		fixture: f,
+		raw:     make(map[string]json.RawMessage, len(f.txs)),
+		fetches: make(map[string]int),
+	}
+	for i, tx := range f.txs {
+		c.raw[tx.Signature] = f.Transactions[i]
+	}
+
+	c.server = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+// RPCURL is the JSON-RPC endpoint.
+func (c *fakeChain) RPCURL() string {
+	return c.server.URL
+}
+
+// WSURL is the WebSocket endpoint.
+func (c *fakeChain) WSURL() string {
+	return "ws" + strings.TrimPrefix(c.server.URL, "http")
+}
+
+// allFetched reports whether every recorded transaction was fetched at least n times.
+func (c *fakeChain) allFetched(n int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tx := range c.fixture.txs {
+		if c.fetches[tx.Signature] < n {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *fakeChain) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		c.serveWS(w, r)
+		return
+	}
+	c.serveRPC(w, r)
+}
+
+// rpcRequest is an incoming JSON-RPC request.
+type rpcRequest struct {
+	ID     uint64            `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (c *fakeChain) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "getTransaction":
+		var sig string
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &sig)
+		}
+		c.mu.Lock()
+		c.fetches[sig]++
+		c.mu.Unlock()
+		if raw, ok := c.raw[sig]; ok {
+			resp["result"] = raw
+		} else {
+			resp["result"] = nil
+		}
+
+	case "getBlockTime":
+		var slot int64
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &slot)
+		}
+		resp["result"] = nil
+		for _, tx := range c.fixture.txs {
+			if tx.Slot == slot {
+				resp["result"] = tx.BlockTime
+				break
+			}
+		}
+
+	case "getSlot":
+		var slot int64
+		if n := len(c.fixture.txs); n > 0 {
+			slot = c.fixture.txs[n-1].Slot
+		}
+		resp["result"] = slot
+
+	case "getAccountInfo":
+		// Account state is not recorded: mint/pool inference and reserve seeding fall back
+		resp["result"] = map[string]interface{}{"context": map[string]int64{"slot": 0}, "value": nil}
+
+	default:
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+var upgrader = websocket.Upgrader{}
+
+func (c *fakeChain) serveWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+	write := func(v interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(v)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	for {
+		var req rpcRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		switch req.Method {
+		case "logsSubscribe":
+			var filter struct {
+				Mentions []string `json:"mentions"`
+			}
+			if len(req.Params) > 0 {
+				_ = json.Unmarshal(req.Params[0], &filter)
+			}
+
+			c.mu.Lock()
+			c.nextSub++
+			subID := c.nextSub
+			c.mu.Unlock()
+
+			if err := write(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": subID}); err != nil {
+				return
+			}
+			go c.replay(subID, filter.Mentions, write, done)
+
+		case "logsUnsubscribe":
+			if err := write(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": true}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// replay sends a logsNotification for every fixture transaction mentioning one of mentions.
+func (c *fakeChain) replay(subID int64, mentions []string, write func(interface{}) error, done <-chan struct{}) {
+	select {
+	case <-time.After(subscribeSettle):
+	case <-done:
+		return
+	}
+
+	for _, tx := range c.fixture.txs {
+		if !mentionsAny(tx.Logs, mentions) {
+			continue
+		}
+		notif := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "logsNotification",
+			"params": map[string]interface{}{
+				"subscription": subID,
+				"result": map[string]interface{}{
+					"context": map[string]int64{"slot": tx.Slot},
+					"value": map[string]interface{}{
+						"signature": tx.Signature,
+						"err":       nil,
+						"logs":      tx.Logs,
+					},
+				},
+			},
+		}
+		if err := write(notif); err != nil {
+			return
+		}
+	}
+}
+
+// mentionsAny reports whether any log line mentions one of the addresses.
+// An empty filter matches everything.
+func mentionsAny(logs, addresses []string) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+	for _, l := range logs {
+		for _, a := range addresses {
+			if strings.Contains(l, a) {
+				return true
+			}
+		}
+	}
+	return false
+}
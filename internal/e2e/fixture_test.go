@@ -0,0 +1,159 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// fixtureFile is a recorded Raydium AMM v4 window: three pool creations
+// (initialize2) followed by 20 swaps per pool over ~30 minutes.
+const fixtureFile = "raydium_window.json"
+
+// fixture is a recorded chain window.
+type fixture struct {
+	// Transactions are raw getTransaction results, ordered by (slot, signature).
+	Transactions []json.RawMessage `json:"transactions"`
+
+	// PricedSwaps carry the swap amounts and execution price of every swap
+	// transaction. Live ingestion stores discovery swap events only, so the
+	// per-candidate swaps table is filled from these.
+	PricedSwaps []pricedSwap `json:"priced_swaps"`
+
+	txs []recordedTx // decoded Transactions, same order
+}
+
+// recordedTx is the part of a getTransaction result the fake chain needs.
+type recordedTx struct {
+	Signature string
+	Slot      int64
+	BlockTime int64
+	Logs      []string
+}
+
+// pricedSwap is one swap of the fixture window.
+type pricedSwap struct {
+	Signature  string  `json:"signature"`
+	Mint       string  `json:"mint"`
+	EventIndex int     `json:"event_index"`
+	Side       string  `json:"side"`
+	AmountIn   float64 `json:"amount_in"`
+	AmountOut  float64 `json:"amount_out"`
+	Price      float64 `json:"price"`
+}
+
+// loadFixture reads testdata/<name>.
+func loadFixture(t *testing.T, name string) *fixture {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+
+	for _, raw := range f.Transactions {
+		var tx struct {
+			Slot      int64 `json:"slot"`
+			BlockTime int64 `json:"blockTime"`
+			Meta      struct {
+				LogMessages []string `json:"logMessages"`
+			} `json:"meta"`
+			Transaction struct {
+				Signatures []string `json:"signatures"`
+			} `json:"transaction"`
+		}
+		if err := json.Unmarshal(raw, &tx); err != nil {
+			t.Fatalf("decode fixture transaction: %v", err)
+		}
+		if len(tx.Transaction.Signatures) == 0 {
+			t.Fatalf("fixture transaction at slot %d has no signature", tx.Slot)
+		}
+		f.txs = append(f.txs, recordedTx{
+			Signature: tx.Transaction.Signatures[0],
+			Slot:      tx.Slot,
+			BlockTime: tx.BlockTime,
+			Logs:      tx.Meta.LogMessages,
+		})
+	}
+
+	return &f
+}
+
+// poolCreations returns the signatures of the initialize2 transactions.
+func (f *fixture) poolCreations() []string {
+	var sigs []string
+	for _, tx := range f.txs {
+		for _, l := range tx.Logs {
+			if strings.Contains(l, "initialize2:") {
+				sigs = append(sigs, tx.Signature)
+				break
+			}
+		}
+	}
+	return sigs
+}
+
+// tx returns the recorded transaction with the given signature.
+func (f *fixture) tx(signature string) (recordedTx, bool) {
+	for _, tx := range f.txs {
+		if tx.Signature == signature {
+			return tx, true
+		}
+	}
+	return recordedTx{}, false
+}
+
+// swapSource serves the fixture's priced swaps as an ingestion.SwapSource,
+// resolving a candidate to its mint through candidates.
+type swapSource struct {
+	fixture    *fixture
+	candidates storage.CandidateStore
+}
+
+// Fetch returns the priced swaps of the candidate's mint within [from, to].
+func (s *swapSource) Fetch(ctx context.Context, candidateID string, from, to int64) ([]*domain.Swap, error) {
+	c, err := s.candidates.GetByID(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var swaps []*domain.Swap
+	for _, ps := range s.fixture.PricedSwaps {
+		if ps.Mint != c.Mint {
+			continue
+		}
+		tx, ok := s.fixture.tx(ps.Signature)
+		if !ok {
+			continue
+		}
+		ts := tx.BlockTime * 1000
+		if ts < from || ts > to {
+			continue
+		}
+		swaps = append(swaps, &domain.Swap{
+			CandidateID: candidateID,
+			TxSignature: ps.Signature,
+			EventIndex:  ps.EventIndex,
+			Slot:        tx.Slot,
+			Timestamp:   ts,
+			Side:        ps.Side,
+			AmountIn:    ps.AmountIn,
+			AmountOut:   ps.AmountOut,
+			Price:       ps.Price,
+		})
+	}
+	return swaps, nil
+}
@@ -0,0 +1,312 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/decision"
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/ingestion"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/orchestrator"
+	"solana-token-lab/internal/pipeline"
+	"solana-token-lab/internal/replay"
+	"solana-token-lab/internal/solana"
+)
+
+// ingestWindow bounds how long live ingestion may take to consume the fixture.
+const ingestWindow = 60 * time.Second
+
+// observationMs is the per-candidate swap window filled after discovery.
+const observationMs = int64(2 * 60 * 60 * 1000)
+
+func TestE2E_IngestToReport(t *testing.T) {
+	db := setupDatabases(t)
+	f := loadFixture(t, fixtureFile)
+	chain := newFakeChain(t, f)
+	ctx := context.Background()
+
+	// 1. Live ingestion and discovery over the recorded window
+	runIngestion(t, ctx, chain, f, db)
+
+	candidates, err := db.candidateStore.GetBySource(ctx, domain.SourceNewToken)
+	if err != nil {
+		t.Fatalf("get candidates: %v", err)
+	}
+	creations := f.poolCreations()
+	if len(candidates) != len(creations) {
+		t.Fatalf("expected %d NEW_TOKEN candidates, got %d", len(creations), len(candidates))
+	}
+	bySig := make(map[string]bool, len(creations))
+	for _, sig := range creations {
+		bySig[sig] = true
+	}
+	for _, c := range candidates {
+		if !bySig[c.TxSignature] {
+			t.Errorf("candidate %s discovered at %s, not at a pool creation", c.CandidateID, c.TxSignature)
+		}
+		events, err := db.liquidityEventStore.GetByCandidateID(ctx, c.CandidateID)
+		if err != nil {
+			t.Fatalf("get liquidity events: %v", err)
+		}
+		if len(events) != 1 || !events[0].PoolInit || !events[0].Estimated {
+			t.Errorf("candidate %s: expected one estimated pool-init liquidity event, got %d", c.CandidateID, len(events))
+		}
+	}
+
+	// 2. Per-candidate swaps
+	manager := ingestion.NewManager(ingestion.ManagerOptions{
+		SwapSource: &swapSource{fixture: f, candidates: db.candidateStore},
+		SwapStore:  db.swapStore,
+	})
+	totalSwaps := 0
+	for _, c := range candidates {
+		n, err := manager.IngestSwaps(ctx, c.CandidateID, c.DiscoveredAt, c.DiscoveredAt+observationMs)
+		if err != nil {
+			t.Fatalf("ingest swaps for %s: %v", c.CandidateID, err)
+		}
+		totalSwaps += n
+	}
+	if totalSwaps != len(f.PricedSwaps) {
+		t.Errorf("expected %d swaps ingested, got %d", len(f.PricedSwaps), totalSwaps)
+	}
+
+	// 3. Normalization, simulation and aggregation
+	strategies := newTokenStrategies()
+	scenarios := []domain.ScenarioConfig{
+		domain.ScenarioConfigOptimistic,
+		domain.ScenarioConfigRealistic,
+		domain.ScenarioConfigPessimistic,
+		domain.ScenarioConfigDegraded,
+	}
+	result, err := orchestrator.New(orchestrator.Options{
+		CandidateStore:           db.candidateStore,
+		SwapStore:                db.swapStore,
+		LiquidityEventStore:      db.liquidityEventStore,
+		PriceTimeseriesStore:     db.priceTimeseriesStore,
+		LiquidityTimeseriesStore: db.liquidityTimeseriesStore,
+		VolumeTimeseriesStore:    db.volumeTimeseriesStore,
+		DerivedFeatureStore:      db.derivedFeatureStore,
+		TradeRecordStore:         db.tradeRecordStore,
+		StrategyAggregateStore:   db.strategyAggregateStore,
+		TradeAggregateStore:      db.tradeAggregateStore,
+		StrategyConfigs:          strategies,
+		ScenarioConfigs:          scenarios,
+		DeterminismCheck:         true,
+	}).Run(ctx)
+	if err != nil {
+		t.Fatalf("orchestrator: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("orchestrator errors: %v", result.Errors)
+	}
+	if result.CandidatesProcessed != len(candidates) {
+		t.Errorf("expected %d candidates processed, got %d", len(candidates), result.CandidatesProcessed)
+	}
+
+	trades, err := db.tradeRecordStore.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("get trades: %v", err)
+	}
+	if want := len(candidates) * len(strategies) * len(scenarios); len(trades) != want {
+		t.Errorf("expected %d trades, got %d", want, len(trades))
+	}
+
+	// 4. Reporting, twice: artifacts must verify and be reproducible
+	first := runReport(t, ctx, db)
+	second := runReport(t, ctx, db)
+
+	for _, dir := range []string{first, second} {
+		results, err := pipeline.VerifyChecksums(dir)
+		if err != nil {
+			t.Fatalf("verify checksums in %s: %v", dir, err)
+		}
+		if !pipeline.ChecksumsOK(results) {
+			t.Errorf("checksum mismatch in %s: %+v", dir, results)
+		}
+	}
+	if a, b := readFile(t, first, pipeline.ChecksumsFile), readFile(t, second, pipeline.ChecksumsFile); a != b {
+		t.Errorf("checksum manifests differ between identical runs:\n%s\n---\n%s", a, b)
+	}
+
+	meta := readMetadata(t, first)
+	// Three candidates are far below the sample-size requirement
+	if meta.Decision != string(decision.DecisionInsufficientData) {
+		t.Errorf("expected decision %s, got %s", decision.DecisionInsufficientData, meta.Decision)
+	}
+	if meta.DataVersion == "" {
+		t.Error("metadata.json has no data_version")
+	}
+	if meta.StrategyCount != len(strategies) {
+		t.Errorf("expected strategy_count %d, got %d", len(strategies), meta.StrategyCount)
+	}
+	if again := readMetadata(t, second); again.DataVersion != meta.DataVersion || again.Decision != meta.Decision {
+		t.Errorf("metadata differs between identical runs: %+v vs %+v", meta, again)
+	}
+}
+
+// runIngestion runs the live ingestion runner against the fake chain until every
+// recorded transaction was consumed by both the swap and the liquidity source.
+func runIngestion(t *testing.T, ctx context.Context, chain *fakeChain, f *fixture, db *dbStores) {
+	t.Helper()
+
+	rpc := solana.NewHTTPClient(chain.RPCURL())
+	swapWS, err := solana.NewWSClient(ctx, chain.WSURL(), nil)
+	if err != nil {
+		t.Fatalf("connect swap ws: %v", err)
+	}
+	defer swapWS.Close()
+	liqWS, err := solana.NewWSClient(ctx, chain.WSURL(), nil)
+	if err != nil {
+		t.Fatalf("connect liquidity ws: %v", err)
+	}
+	defer liqWS.Close()
+
+	programs := []string{discovery.RaydiumAMMV4}
+	runner := ingestion.NewRunner(ingestion.RunnerOptions{
+		WSSwapSource:      ingestion.NewWSSwapEventSource(swapWS, rpc, programs),
+		WSLiquiditySource: ingestion.NewWSLiquidityEventSourceWithStore(liqWS, rpc, programs, db.candidateStore),
+		SwapEventStore:    db.swapEventStore,
+		LiquidityStore:    db.liquidityEventStore,
+		CandidateStore:    db.candidateStore,
+		NewTokenDetector:  discovery.NewDetector(db.candidateStore),
+		SlotLagWindow:     1,
+		FlushInterval:     100 * time.Millisecond,
+		Logger:            log.New(io.Discard, "", 0),
+	})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(runCtx) }()
+
+	// Each source fetches every notified transaction once
+	deadline := time.After(ingestWindow)
+	for !chain.allFetched(2) {
+		select {
+		case err := <-done:
+			t.Fatalf("runner exited early: %v", err)
+		case <-deadline:
+			t.Fatalf("fixture not consumed within %v", ingestWindow)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	// Let the last fetched events reach the runner before shutdown flushes the buffers
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("runner: %v", err)
+	}
+
+	stats := runner.Stats()
+	if want := int64(len(f.PricedSwaps)); stats.SwapEventsProcessed != want {
+		t.Errorf("expected %d swap events stored, got %d", want, stats.SwapEventsProcessed)
+	}
+	if want := int64(len(f.poolCreations())); stats.LiquidityEventsProcessed != want {
+		t.Errorf("expected %d liquidity events stored, got %d", want, stats.LiquidityEventsProcessed)
+	}
+	if want := int64(len(f.poolCreations())); stats.NewTokensDiscovered != want {
+		t.Errorf("expected %d NEW_TOKEN discoveries, got %d", want, stats.NewTokensDiscovered)
+	}
+}
+
+// runReport runs the Phase 1 reporting pipeline into a fresh directory, wired like cmd/pipeline.
+func runReport(t *testing.T, ctx context.Context, db *dbStores) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:       true,
+		{StrategyID: "TRAILING_STOP", EntryEventType: "NEW_TOKEN"}:   true,
+		{StrategyID: "LIQUIDITY_GUARD", EntryEventType: "NEW_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	p := pipeline.NewPhase1Pipeline(
+		db.candidateStore,
+		db.tradeRecordStore,
+		db.strategyAggregateStore,
+		implementable,
+		dir,
+	).WithSufficiencyChecker(
+		db.candidateStore,
+		db.tradeRecordStore,
+		db.swapStore,
+		db.liquidityEventStore,
+		replay.NewRunner(db.swapStore, db.liquidityEventStore),
+	).WithAggregator(metrics.NewAggregator(db.tradeRecordStore, db.strategyAggregateStore, db.candidateStore)).
+		WithClock(func() time.Time { return fixedTime }).
+		WithDBSource(db.postgresDSN, db.clickhouseDSN).
+		WithRawDataStores(db.candidateStore, db.priceTimeseriesStore, db.liquidityTimeseriesStore)
+
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("reporting pipeline: %v", err)
+	}
+	return dir
+}
+
+// newTokenStrategies are the NEW_TOKEN strategy configs of cmd/pipeline.
+func newTokenStrategies() []domain.StrategyConfig {
+	holdDuration := int64(300000)
+	trailPct, initialStopPct := 0.10, 0.10
+	maxHoldTrailing := int64(3600000)
+	liquidityDropPct := 0.30
+	maxHoldLiquidity := int64(1800000)
+
+	return []domain.StrategyConfig{
+		{
+			StrategyType:   domain.StrategyTypeTimeExit,
+			EntryEventType: "NEW_TOKEN",
+			HoldDurationMs: &holdDuration,
+		},
+		{
+			StrategyType:      domain.StrategyTypeTrailingStop,
+			EntryEventType:    "NEW_TOKEN",
+			TrailPct:          &trailPct,
+			InitialStopPct:    &initialStopPct,
+			MaxHoldDurationMs: &maxHoldTrailing,
+		},
+		{
+			StrategyType:      domain.StrategyTypeLiquidityGuard,
+			EntryEventType:    "NEW_TOKEN",
+			LiquidityDropPct:  &liquidityDropPct,
+			MaxHoldDurationMs: &maxHoldLiquidity,
+		},
+	}
+}
+
+// reportMetadata is the part of metadata.json the test checks.
+type reportMetadata struct {
+	Decision      string `json:"decision"`
+	DataVersion   string `json:"data_version"`
+	StrategyCount int    `json:"strategy_count"`
+}
+
+func readMetadata(t *testing.T, dir string) reportMetadata {
+	t.Helper()
+	var m reportMetadata
+	if err := json.Unmarshal([]byte(readFile(t, dir, "metadata.json")), &m); err != nil {
+		t.Fatalf("decode metadata.json: %v", err)
+	}
+	return m
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(data)
+}
@@ -0,0 +1,86 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/storage/factory"
+	"solana-token-lab/internal/storage/migrations"
+)
+
+// TestE2E_SchemaCompatibility checks the startup schema check of the store
+// factory against migrated databases, before and after dropping columns.
+func TestE2E_SchemaCompatibility(t *testing.T) {
+	db := setupDatabases(t)
+	ctx := context.Background()
+
+	cfg := factory.Config{
+		Backend:        factory.BackendPostgres,
+		PostgresDSN:    db.postgresDSN,
+		ClickHouseDSN:  db.clickhouseDSN,
+		Needs:          factory.NeedAll,
+		SkipMigrations: true,
+	}
+	pgSchema, err := migrations.ExpectedPostgresSchema()
+	if err != nil {
+		t.Fatalf("expected postgres schema: %v", err)
+	}
+	chSchema, err := migrations.ExpectedClickhouseSchema()
+	if err != nil {
+		t.Fatalf("expected clickhouse schema: %v", err)
+	}
+
+	// Up to date
+	stores, cleanup, err := factory.BuildStores(ctx, cfg)
+	if err != nil {
+		t.Fatalf("BuildStores on a migrated database: %v", err)
+	}
+	cleanup()
+	if len(stores.Schema) != 2 {
+		t.Fatalf("expected postgres and clickhouse schema status, got %+v", stores.Schema)
+	}
+	if s := stores.Schema[0]; !s.Compatible() || s.Version != pgSchema.Latest() {
+		t.Errorf("expected postgres at %s, got %+v", pgSchema.Latest(), s)
+	}
+	if s := stores.Schema[1]; !s.Compatible() || s.Version != chSchema.Latest() {
+		t.Errorf("expected clickhouse at %s, got %+v", chSchema.Latest(), s)
+	}
+
+	// A column added by a later migration is missing
+	if _, err := db.pgPool.Exec(ctx, "ALTER TABLE token_candidates DROP COLUMN deleted_reason"); err != nil {
+		t.Fatalf("drop column: %v", err)
+	}
+	_, _, err = factory.BuildStores(ctx, cfg)
+	if !errors.Is(err, factory.ErrIncompatibleSchema) {
+		t.Fatalf("expected ErrIncompatibleSchema, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "037_token_candidates_soft_delete.sql") || !strings.Contains(err.Error(), "token_candidates.deleted_reason") {
+		t.Errorf("error should name the migration and column: %v", err)
+	}
+
+	// A read-only binary proceeds and sees the gap
+	cfg.ReadOnly = true
+	stores, cleanup, err = factory.BuildStores(ctx, cfg)
+	if err != nil {
+		t.Fatalf("read-only BuildStores with a missing added column: %v", err)
+	}
+	cleanup()
+	if s := stores.Schema[0]; s.Compatible() || s.Version != "036_run_manifests.sql" {
+		t.Errorf("expected postgres detected at 036_run_manifests.sql, got %+v", s)
+	}
+
+	// Migrating restores the column
+	cfg.SkipMigrations, cfg.ReadOnly = false, false
+	stores, cleanup, err = factory.BuildStores(ctx, cfg)
+	if err != nil {
+		t.Fatalf("BuildStores with migrations: %v", err)
+	}
+	cleanup()
+	if !stores.Schema[0].Compatible() {
+		t.Errorf("expected a compatible schema after migrating, got %+v", stores.Schema[0])
+	}
+}
@@ -0,0 +1,81 @@
+package idhash
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// VersionCounts counts IDs per version; Malformed counts strings that are not IDs.
+type VersionCounts struct {
+	ByVersion map[Version]int
+	Malformed int
+}
+
+// Mixed reports whether IDs of more than one version were counted.
+func (c VersionCounts) Mixed() bool {
+	return len(c.ByVersion) > 1
+}
+
+func (c *VersionCounts) add(id string) {
+	v, err := VersionOf(id)
+	if err != nil {
+		c.Malformed++
+		return
+	}
+	if c.ByVersion == nil {
+		c.ByVersion = make(map[Version]int)
+	}
+	c.ByVersion[v]++
+}
+
+// CountVersions counts the versions of ids.
+func CountVersions(ids []string) VersionCounts {
+	var c VersionCounts
+	for _, id := range ids {
+		c.add(id)
+	}
+	return c
+}
+
+// VersionAudit reports the ID versions found in the stores, e.g. before and after
+// moving derivations to a new version.
+type VersionAudit struct {
+	Candidates VersionCounts // candidate_id
+	Trades     VersionCounts // trade_id
+	// TradeCandidates counts the candidate_id each trade references; it differs
+	// from Candidates when trades still point at candidates of another version.
+	TradeCandidates VersionCounts
+}
+
+// Mixed reports whether any ID kind appears under more than one version.
+func (a *VersionAudit) Mixed() bool {
+	return a.Candidates.Mixed() || a.Trades.Mixed() || a.TradeCandidates.Mixed()
+}
+
+// AuditStores scans every candidate, soft-deleted ones included, and every trade
+// and counts their ID versions.
+func AuditStores(ctx context.Context, candidates storage.CandidateStore, trades storage.TradeRecordStore) (*VersionAudit, error) {
+	audit := &VersionAudit{}
+	for _, source := range []domain.Source{domain.SourceNewToken, domain.SourceActiveToken} {
+		// Soft-deleted candidates keep their trades, which TradeCandidates counts
+		cs, err := candidates.GetBySource(ctx, source, storage.IncludeDeleted())
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cs {
+			audit.Candidates.add(c.CandidateID)
+		}
+	}
+
+	all, err := trades.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range all {
+		audit.Trades.add(t.TradeID)
+		audit.TradeCandidates.add(t.CandidateID)
+	}
+	return audit, nil
+}
@@ -1,14 +1,12 @@
 package idhash
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
-	"fmt"
+	"strconv"
 
 	"solana-token-lab/internal/domain"
 )
 
-// ComputeCandidateID computes a deterministic candidate_id using SHA256.
+// ComputeCandidateID computes a deterministic candidate_id with CurrentVersion.
 // Formula: SHA256(mint|pool|source|tx_signature|event_index|slot)
 // Returns hex-encoded hash (64 characters).
 func ComputeCandidateID(
@@ -19,20 +17,34 @@ func ComputeCandidateID(
 	eventIndex int,
 	slot int64,
 ) string {
+	return mustDerive(candidateFields(mint, pool, source, txSignature, eventIndex, slot)...)
+}
+
+// CandidateIDVersion computes a candidate_id with the formula of version v.
+// Returns ErrUnknownVersion for a version without a derivation.
+func CandidateIDVersion(
+	v Version,
+	mint string,
+	pool *string,
+	source domain.Source,
+	txSignature string,
+	eventIndex int,
+	slot int64,
+) (string, error) {
+	return derive(v, candidateFields(mint, pool, source, txSignature, eventIndex, slot)...)
+}
+
+func candidateFields(mint string, pool *string, source domain.Source, txSignature string, eventIndex int, slot int64) []string {
 	poolStr := ""
 	if pool != nil {
 		poolStr = *pool
 	}
-
-	data := fmt.Sprintf("%s|%s|%s|%s|%d|%d",
+	return []string{
 		mint,
 		poolStr,
 		string(source),
 		txSignature,
-		eventIndex,
-		slot,
-	)
-
-	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
+		strconv.Itoa(eventIndex),
+		strconv.FormatInt(slot, 10),
+	}
 }
@@ -0,0 +1,72 @@
+package idhash
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sha256Allowed lists the files outside idhash that may hash with SHA256, none of
+// them deriving candidate or trade IDs. A new entry needs the same justification.
+var sha256Allowed = map[string]string{
+	"internal/discovery/mint_filter.go": "mint list hash",
+	"internal/dossier/dossier.go":       "replay fingerprint",
+	"internal/ingestion/rpc_sources.go": "program derived addresses",
+	"internal/pipeline/checksums.go":    "artifact checksums",
+	"internal/pipeline/phase1.go":       "data and config versions",
+	"internal/reporting/chart_test.go":  "chart golden hash",
+	"internal/sampling/sampling.go":     "sample ranking",
+	"internal/simulation/cache.go":      "simulation cache keys",
+}
+
+// TestIDsDerivedOnlyByIdhash fails when a file outside idhash starts hashing with
+// SHA256, so candidate and trade IDs cannot be built behind idhash's versioning.
+func TestIDsDerivedOnlyByIdhash(t *testing.T) {
+	root := filepath.Join("..", "..")
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); name == ".git" || name == "vendor" || name == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(rel, "internal/idhash/") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, imp := range f.Imports {
+			pkg, _ := strconv.Unquote(imp.Path.Value)
+			if pkg != "crypto/sha256" {
+				continue
+			}
+			if _, ok := sha256Allowed[rel]; !ok {
+				t.Errorf("%s imports crypto/sha256: derive candidate/trade IDs with idhash, or add the file to sha256Allowed", rel)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk repository: %v", err)
+	}
+}
@@ -1,12 +1,8 @@
 package idhash
 
-import (
-	"crypto/sha256"
-	"encoding/hex"
-	"fmt"
-)
+import "strconv"
 
-// ComputeTradeID computes a deterministic trade_id using SHA256.
+// ComputeTradeID computes a deterministic trade_id with CurrentVersion.
 // Formula: SHA256(candidate_id|strategy_id|scenario_id|entry_signal_time)
 // Returns hex-encoded hash (64 characters).
 func ComputeTradeID(
@@ -15,13 +11,21 @@ func ComputeTradeID(
 	scenarioID string,
 	entrySignalTime int64,
 ) string {
-	data := fmt.Sprintf("%s|%s|%s|%d",
-		candidateID,
-		strategyID,
-		scenarioID,
-		entrySignalTime,
-	)
+	return mustDerive(tradeFields(candidateID, strategyID, scenarioID, entrySignalTime)...)
+}
+
+// TradeIDVersion computes a trade_id with the formula of version v.
+// Returns ErrUnknownVersion for a version without a derivation.
+func TradeIDVersion(
+	v Version,
+	candidateID string,
+	strategyID string,
+	scenarioID string,
+	entrySignalTime int64,
+) (string, error) {
+	return derive(v, tradeFields(candidateID, strategyID, scenarioID, entrySignalTime)...)
+}
 
-	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
+func tradeFields(candidateID, strategyID, scenarioID string, entrySignalTime int64) []string {
+	return []string{candidateID, strategyID, scenarioID, strconv.FormatInt(entrySignalTime, 10)}
 }
@@ -0,0 +1,14 @@
+package idhash
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// UnitInterval maps an id and a purpose label to a deterministic value in [0, 1).
+// Formula: top 53 bits of SHA256(id|purpose) divided by 2^53.
+// Different purposes give independent draws for the same id.
+func UnitInterval(id, purpose string) float64 {
+	hash := sha256.Sum256([]byte(id + "|" + purpose))
+	return float64(binary.BigEndian.Uint64(hash[:8])>>11) / (1 << 53)
+}
@@ -0,0 +1,28 @@
+package idhash
+
+import "testing"
+
+func TestUnitInterval(t *testing.T) {
+	if UnitInterval("trade-1", "sandwich") != UnitInterval("trade-1", "sandwich") {
+		t.Error("expected the same draw for the same id and purpose")
+	}
+	if UnitInterval("trade-1", "sandwich") == UnitInterval("trade-1", "other") {
+		t.Error("expected independent draws for different purposes")
+	}
+
+	// Roughly uniform over [0, 1)
+	const n = 10000
+	var below float64
+	for i := 0; i < n; i++ {
+		u := UnitInterval(ComputeTradeID("cand", "TIME_EXIT", "realistic", int64(i)), "sandwich")
+		if u < 0 || u >= 1 {
+			t.Fatalf("draw %v outside [0, 1)", u)
+		}
+		if u < 0.25 {
+			below++
+		}
+	}
+	if share := below / n; share < 0.23 || share > 0.27 {
+		t.Errorf("expected ~25%% of draws below 0.25, got %.3f", share)
+	}
+}
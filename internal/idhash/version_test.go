@@ -0,0 +1,161 @@
+package idhash
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestV1MatchesOriginalFormula(t *testing.T) {
+	pool := "Pool"
+	legacy := func(data string) string {
+		hash := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(hash[:])
+	}
+
+	candidateID := ComputeCandidateID("Mint", &pool, domain.SourceNewToken, "Tx", 2, 1000)
+	if want := legacy("Mint|Pool|NEW_TOKEN|Tx|2|1000"); candidateID != want {
+		t.Errorf("candidate_id changed: got %s, want %s", candidateID, want)
+	}
+	if noPool := ComputeCandidateID("Mint", nil, domain.SourceNewToken, "Tx", 2, 1000); noPool != legacy("Mint||NEW_TOKEN|Tx|2|1000") {
+		t.Errorf("candidate_id without pool changed: %s", noPool)
+	}
+
+	tradeID := ComputeTradeID(candidateID, "TIME_EXIT", domain.ScenarioRealistic, 1000)
+	if want := legacy(fmt.Sprintf("%s|TIME_EXIT|realistic|1000", candidateID)); tradeID != want {
+		t.Errorf("trade_id changed: got %s, want %s", tradeID, want)
+	}
+
+	// Pinned so that a formula change cannot also update the expectation
+	if tradeID := ComputeTradeID("c1", "TIME_EXIT", "realistic", 0); tradeID != legacy("c1|TIME_EXIT|realistic|0") {
+		t.Errorf("trade_id changed: %s", tradeID)
+	}
+}
+
+func TestVersionedConstructors(t *testing.T) {
+	pool := "Pool"
+	v1, err := CandidateIDVersion(V1, "Mint", &pool, domain.SourceActiveToken, "Tx", 0, 5)
+	if err != nil {
+		t.Fatalf("CandidateIDVersion(V1): %v", err)
+	}
+	if v1 != ComputeCandidateID("Mint", &pool, domain.SourceActiveToken, "Tx", 0, 5) {
+		t.Error("V1 candidate_id differs from ComputeCandidateID")
+	}
+
+	v2, err := CandidateIDVersion(V2, "Mint", &pool, domain.SourceActiveToken, "Tx", 0, 5)
+	if err != nil {
+		t.Fatalf("CandidateIDVersion(V2): %v", err)
+	}
+	if !strings.HasPrefix(v2, "v2:") || strings.TrimPrefix(v2, "v2:") == v1 {
+		t.Errorf("expected a distinct v2-prefixed id, got %s (v1 %s)", v2, v1)
+	}
+	again, _ := CandidateIDVersion(V2, "Mint", &pool, domain.SourceActiveToken, "Tx", 0, 5)
+	if again != v2 {
+		t.Error("V2 candidate_id not deterministic")
+	}
+
+	t1, _ := TradeIDVersion(V1, v1, "TIME_EXIT", "realistic", 5)
+	t2, _ := TradeIDVersion(V2, v1, "TIME_EXIT", "realistic", 5)
+	if t1 != ComputeTradeID(v1, "TIME_EXIT", "realistic", 5) || t1 == t2 || !strings.HasPrefix(t2, "v2:") {
+		t.Errorf("unexpected trade ids: v1 %s, v2 %s", t1, t2)
+	}
+
+	if _, err := TradeIDVersion(Version(9), "c", "s", "r", 0); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("expected ErrUnknownVersion, got %v", err)
+	}
+}
+
+func TestParseID(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	v2, _ := TradeIDVersion(V2, "c", "s", "r", 0)
+
+	tests := []struct {
+		id      string
+		version Version
+		wantErr bool
+	}{
+		{id: hash, version: V1},
+		{id: "v1:" + hash, version: V1},
+		{id: v2, version: V2},
+		{id: ComputeTradeID("c", "s", "r", 0), version: V1},
+		{id: "v3:" + hash, version: 3},
+		{id: "x2:" + hash, wantErr: true},
+		{id: "v0:" + hash, wantErr: true},
+		{id: "v2:" + hash[:10], wantErr: true},
+		{id: strings.Repeat("zz", 32), wantErr: true},
+		{id: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		v, h, err := ParseID(tt.id)
+		if tt.wantErr {
+			if !errors.Is(err, ErrMalformedID) {
+				t.Errorf("ParseID(%q): expected ErrMalformedID, got %v", tt.id, err)
+			}
+			continue
+		}
+		if err != nil || v != tt.version || len(h) != 64 {
+			t.Errorf("ParseID(%q) = %v, %q, %v; want version %v", tt.id, v, h, err, tt.version)
+		}
+	}
+}
+
+func TestAuditStores(t *testing.T) {
+	ctx := context.Background()
+	candidates := memory.NewCandidateStore()
+	trades := memory.NewTradeRecordStore()
+
+	c1 := ComputeCandidateID("M1", nil, domain.SourceNewToken, "tx1", 0, 1)
+	c2, _ := CandidateIDVersion(V2, "M2", nil, domain.SourceActiveToken, "tx2", 0, 2)
+	for _, c := range []*domain.TokenCandidate{
+		{CandidateID: c1, Source: domain.SourceNewToken, Mint: "M1", TxSignature: "tx1", Slot: 1},
+		{CandidateID: c2, Source: domain.SourceActiveToken, Mint: "M2", TxSignature: "tx2", Slot: 2},
+	} {
+		if err := candidates.Insert(ctx, c); err != nil {
+			t.Fatalf("Insert candidate: %v", err)
+		}
+	}
+	t2, _ := TradeIDVersion(V2, c1, "TIME_EXIT", "realistic", 1)
+	for _, tr := range []*domain.TradeRecord{
+		{TradeID: ComputeTradeID(c1, "TIME_EXIT", "realistic", 1), CandidateID: c1, StrategyID: "TIME_EXIT", ScenarioID: "realistic"},
+		{TradeID: t2, CandidateID: c1, StrategyID: "TIME_EXIT", ScenarioID: "pessimistic"},
+		{TradeID: "legacy-id", CandidateID: c2, StrategyID: "TIME_EXIT", ScenarioID: "realistic"},
+	} {
+		if err := trades.Insert(ctx, tr); err != nil {
+			t.Fatalf("Insert trade: %v", err)
+		}
+	}
+
+	// A soft-deleted candidate keeps its trades and is still audited
+	if err := candidates.SoftDelete(ctx, &storage.CandidateDeletion{CandidateID: c2, DeletedAt: 3, Reason: "test token"}); err != nil {
+		t.Fatalf("SoftDelete: %v", err)
+	}
+
+	audit, err := AuditStores(ctx, candidates, trades)
+	if err != nil {
+		t.Fatalf("AuditStores: %v", err)
+	}
+	if !audit.Mixed() {
+		t.Error("expected mixed versions")
+	}
+	if audit.Candidates.ByVersion[V1] != 1 || audit.Candidates.ByVersion[V2] != 1 {
+		t.Errorf("unexpected candidate counts: %+v", audit.Candidates)
+	}
+	if audit.Trades.ByVersion[V1] != 1 || audit.Trades.ByVersion[V2] != 1 || audit.Trades.Malformed != 1 {
+		t.Errorf("unexpected trade counts: %+v", audit.Trades)
+	}
+	if audit.TradeCandidates.ByVersion[V1] != 2 || audit.TradeCandidates.ByVersion[V2] != 1 {
+		t.Errorf("unexpected referenced candidate counts: %+v", audit.TradeCandidates)
+	}
+
+	if single := CountVersions([]string{c1, ComputeCandidateID("M3", nil, domain.SourceNewToken, "tx3", 0, 3)}); single.Mixed() {
+		t.Errorf("expected a single version, got %+v", single)
+	}
+}
@@ -0,0 +1,39 @@
+package ingestion
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+)
+
+// archiveTx stores tx in the raw transaction archive, if one is configured.
+// blockTime (seconds) is the time the events were stamped with, so a reparse
+// reproduces their timestamps. Archiving is best-effort: failures are logged
+// and never stop ingestion.
+func archiveTx(ctx context.Context, store storage.RawTxStore, tx *solana.Transaction, blockTime int64) {
+	if store == nil || tx == nil {
+		return
+	}
+
+	archived := *tx
+	archived.BlockTime = blockTime
+	data, err := solana.EncodeTransactionJSON(&archived)
+	if err != nil {
+		log.Printf("WARN: archive tx %s: %v", tx.Signature, err)
+		return
+	}
+
+	err = store.Put(ctx, &storage.RawTransaction{
+		TxSignature: tx.Signature,
+		Slot:        tx.Slot,
+		BlockTime:   blockTime,
+		Data:        data,
+		ArchivedAt:  time.Now().UnixMilli(),
+	})
+	if err != nil {
+		log.Printf("WARN: archive tx %s: %v", tx.Signature, err)
+	}
+}
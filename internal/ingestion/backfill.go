@@ -22,7 +22,15 @@ type Backfiller struct {
 	liquidityStore   storage.LiquidityEventStore
 	candidateStore   storage.CandidateStore
 	newTokenDetector *discovery.NewTokenDetector
+	strategy         BackfillStrategy
+	programs         []string
+	checkpoints      storage.SlotCheckpointStore
+	blockChunkSlots  int64
 	batchSize        int
+	progressInterval time.Duration
+	onProgress       func(BackfillProgressSnapshot)
+	failedTx         *FailedTxTracker
+	capper           *eventCapper // nil without event caps
 	logger           *log.Logger
 }
 
@@ -35,7 +43,16 @@ type BackfillOptions struct {
 	LiquidityStore   storage.LiquidityEventStore
 	CandidateStore   storage.CandidateStore
 	NewTokenDetector *discovery.NewTokenDetector
+	Strategy         BackfillStrategy            // Default: signatures
+	Programs         []string                    // blocks strategy: programs to match (default: swap source programs)
+	Checkpoints      storage.SlotCheckpointStore // blocks strategy, optional: per-slot completion, skipped on resume
+	BlockChunkSlots  int64                       // blocks strategy: slots per flush and checkpoint write (default: 100)
 	BatchSize        int
+	ProgressInterval time.Duration                  // Default: 30s - how often progress is logged
+	OnProgress       func(BackfillProgressSnapshot) // optional: called with each progress log and once at the end
+	FailedTx         *FailedTxTracker               // optional: counts the blocks strategy's transactions, flushed when a backfill ends
+	EventCaps        EventCaps                      // optional: per-candidate event caps, applied to candidates in CandidateStore
+	EventCountStore  storage.EventCountStore        // optional: persists the true event totals under EventCaps
 	Logger           *log.Logger
 }
 
@@ -46,11 +63,31 @@ func NewBackfiller(opts BackfillOptions) *Backfiller {
 		batchSize = 1000
 	}
 
+	progressInterval := opts.ProgressInterval
+	if progressInterval == 0 {
+		progressInterval = 30 * time.Second
+	}
+
 	logger := opts.Logger
 	if logger == nil {
 		logger = log.Default()
 	}
 
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = BackfillStrategySignatures
+	}
+
+	programs := opts.Programs
+	if len(programs) == 0 && opts.SwapSource != nil {
+		programs = opts.SwapSource.Programs()
+	}
+
+	blockChunkSlots := opts.BlockChunkSlots
+	if blockChunkSlots <= 0 {
+		blockChunkSlots = DefaultBlockChunkSlots
+	}
+
 	return &Backfiller{
 		rpc:              opts.RPC,
 		swapSource:       opts.SwapSource,
@@ -59,19 +96,37 @@ func NewBackfiller(opts BackfillOptions) *Backfiller {
 		liquidityStore:   opts.LiquidityStore,
 		candidateStore:   opts.CandidateStore,
 		newTokenDetector: opts.NewTokenDetector,
+		strategy:         strategy,
+		programs:         programs,
+		checkpoints:      opts.Checkpoints,
+		blockChunkSlots:  blockChunkSlots,
 		batchSize:        batchSize,
+		progressInterval: progressInterval,
+		onProgress:       opts.OnProgress,
+		failedTx:         opts.FailedTx,
+		capper:           newEventCapper(opts.EventCaps, opts.EventCountStore),
 		logger:           logger,
 	}
 }
 
 // BackfillResult contains statistics from a backfill operation.
 type BackfillResult struct {
-	SwapEventsIngested       int
-	LiquidityEventsIngested  int
-	CandidatesDiscovered     int
-	DuplicatesSkipped        int
-	Errors                   int
-	Duration                 time.Duration
+	SignaturesScanned       int
+	TransactionsFetched     int
+	SwapEventsIngested      int
+	LiquidityEventsIngested int
+	CandidatesDiscovered    int
+	DuplicatesSkipped       int
+	CappedSwapEvents        int // dropped by the per-candidate event caps
+	CappedLiquidityEvents   int // dropped by the per-candidate event caps
+	Errors                  int
+	Duration                time.Duration
+	Programs                []ProgramProgress // per-program RPC counters, sorted by program
+
+	// Blocks strategy only
+	BlocksFetched        int
+	SlotsSkipped         int // slots without a produced block
+	SlotsAlreadyComplete int // slots checkpointed by an earlier run
 }
 
 // BackfillSince backfills data from a given timestamp until now.
@@ -81,7 +136,14 @@ func (b *Backfiller) BackfillSince(ctx context.Context, since time.Time) (*Backf
 }
 
 // BackfillRange backfills data for a specific time range.
+// Progress is logged every progress interval; the result carries the final counters
+// even when the backfill fails part way. The blocks strategy needs a slot range
+// (see BackfillSlotRange).
 func (b *Backfiller) BackfillRange(ctx context.Context, from, to time.Time) (*BackfillResult, error) {
+	if b.strategy == BackfillStrategyBlocks {
+		return nil, fmt.Errorf("%s backfill strategy requires a slot range", b.strategy)
+	}
+
 	start := time.Now()
 	result := &BackfillResult{}
 
@@ -90,6 +152,24 @@ func (b *Backfiller) BackfillRange(ctx context.Context, from, to time.Time) (*Ba
 
 	b.logger.Printf("Starting backfill from %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
 
+	progress := b.attachProgress(fromMs, toMs)
+	stopReporter := b.startProgressReporter(progress)
+	defer func() {
+		stopReporter()
+		b.detachProgress()
+
+		b.flushFailedTx(context.WithoutCancel(ctx))
+
+		final := progress.Snapshot()
+		result.SignaturesScanned = final.SignaturesScanned
+		result.TransactionsFetched = final.TransactionsFetched
+		result.Programs = final.Programs
+		result.Duration = time.Since(start)
+		if b.onProgress != nil {
+			b.onProgress(final)
+		}
+	}()
+
 	// Fetch swap events
 	if b.swapSource != nil {
 		swapEvents, err := b.swapSource.Fetch(ctx, fromMs, toMs)
@@ -99,53 +179,148 @@ func (b *Backfiller) BackfillRange(ctx context.Context, from, to time.Time) (*Ba
 
 		b.logger.Printf("Fetched %d swap events", len(swapEvents))
 
-		// Store swap events in batches
-		stored, dupes, errs := b.storeSwapEvents(ctx, swapEvents)
-		result.SwapEventsIngested += stored
-		result.DuplicatesSkipped += dupes
-		result.Errors += errs
-
-		// Run NEW_TOKEN detection on all events
+		// Run NEW_TOKEN detection on all events first so the event caps
+		// apply to the candidates discovered here
 		if b.newTokenDetector != nil {
 			discovered := b.runDiscovery(ctx, swapEvents)
 			result.CandidatesDiscovered += discovered
 		}
+
+		// Store swap events in batches
+		stored, dupes, capped, errs := b.storeSwapEvents(ctx, swapEvents)
+		progress.AddStored(stored)
+		result.SwapEventsIngested += stored
+		result.DuplicatesSkipped += dupes
+		result.CappedSwapEvents += capped
+		result.Errors += errs
 	}
 
 	// Fetch liquidity events (if configured)
 	if b.liquiditySource != nil && b.liquidityStore != nil {
-		// For backfill, fetch without candidateID filter - get all events
-		liqEvents, err := b.liquiditySource.Fetch(ctx, "", fromMs, toMs)
+		// One scan per program for all candidates; unattributed events are kept
+		// for deferred association
+		batch, err := b.liquiditySource.FetchBatch(ctx, fromMs, toMs)
 		if err != nil {
 			b.logger.Printf("Error fetching liquidity events: %v", err)
 		} else {
-			b.logger.Printf("Fetched %d liquidity events", len(liqEvents))
+			b.logger.Printf("Fetched %d liquidity events for %d candidates", len(batch.Events), len(batch.ByCandidate))
 
 			// Store liquidity events in batches
-			stored, dupes, errs := b.storeLiquidityEvents(ctx, liqEvents)
+			stored, dupes, capped, errs := b.storeLiquidityEvents(ctx, batch.Events)
+			progress.AddStored(stored)
 			result.LiquidityEventsIngested += stored
 			result.DuplicatesSkipped += dupes
+			result.CappedLiquidityEvents += capped
 			result.Errors += errs
 		}
 	}
 
 	result.Duration = time.Since(start)
-	b.logger.Printf("Backfill complete: %d swaps, %d liquidity, %d candidates, %d dupes, %d errors in %v",
+	b.logger.Printf("Backfill complete: %d swaps, %d liquidity, %d candidates, %d dupes, %d capped, %d errors in %v",
 		result.SwapEventsIngested, result.LiquidityEventsIngested, result.CandidatesDiscovered,
-		result.DuplicatesSkipped, result.Errors, result.Duration)
+		result.DuplicatesSkipped, result.CappedSwapEvents+result.CappedLiquidityEvents, result.Errors, result.Duration)
 
 	return result, nil
 }
 
+// flushFailedTx stores the failed transaction tallies of the backfill.
+func (b *Backfiller) flushFailedTx(ctx context.Context) {
+	if err := b.failedTx.Flush(ctx); err != nil {
+		b.logger.Printf("Error storing failed transaction tallies: %v", err)
+	}
+}
+
+// attachProgress creates a tracker for [fromMs, toMs) and attaches it to the configured sources.
+func (b *Backfiller) attachProgress(fromMs, toMs int64) *BackfillProgress {
+	scans := 0
+	if b.swapSource != nil {
+		scans += len(b.swapSource.Programs())
+	}
+	if b.liquiditySource != nil && b.liquidityStore != nil {
+		scans += len(b.liquiditySource.Programs())
+	}
+
+	progress := NewBackfillProgress(fromMs, toMs, scans, nil)
+	if b.swapSource != nil {
+		b.swapSource.SetProgress(progress)
+	}
+	if b.liquiditySource != nil {
+		b.liquiditySource.SetProgress(progress)
+	}
+	return progress
+}
+
+// detachProgress removes the tracker from the sources.
+func (b *Backfiller) detachProgress() {
+	if b.swapSource != nil {
+		b.swapSource.SetProgress(nil)
+	}
+	if b.liquiditySource != nil {
+		b.liquiditySource.SetProgress(nil)
+	}
+}
+
+// startProgressReporter logs progress every progress interval until the returned stop func is called.
+func (b *Backfiller) startProgressReporter(progress *BackfillProgress) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(b.progressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				snap := progress.Snapshot()
+				b.logger.Print(formatBackfillProgress(snap))
+				if b.onProgress != nil {
+					b.onProgress(snap)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// formatBackfillProgress renders a one-line progress report.
+func formatBackfillProgress(snap BackfillProgressSnapshot) string {
+	position := "-"
+	if snap.BlockTime > 0 {
+		position = time.Unix(snap.BlockTime, 0).UTC().Format(time.RFC3339)
+	}
+	eta := "unknown"
+	if snap.ETA > 0 {
+		eta = snap.ETA.Round(time.Second).String()
+	}
+	return fmt.Sprintf("Backfill progress: %.1f%% | %d signatures, %d transactions, %d events stored | at %s, target %s | elapsed %v, ETA %s",
+		snap.Fraction*100, snap.SignaturesScanned, snap.TransactionsFetched, snap.EventsStored,
+		position, time.Unix(snap.TargetTime, 0).UTC().Format(time.RFC3339),
+		snap.Elapsed.Round(time.Second), eta)
+}
+
 // BackfillSlotRange backfills data for a specific slot range.
+// The signatures strategy backfills the time range spanned by the slots; the
+// blocks strategy walks every slot in [fromSlot, toSlot].
 func (b *Backfiller) BackfillSlotRange(ctx context.Context, fromSlot, toSlot int64) (*BackfillResult, error) {
 	// Get block times for slot range
-	fromTime, err := b.rpc.GetBlockTime(ctx, fromSlot)
+	fromTime, err := retryRPC(ctx, "GetBlockTime", func() (*int64, error) {
+		return b.rpc.GetBlockTime(ctx, fromSlot)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("get block time for slot %d: %w", fromSlot, err)
 	}
 
-	toTime, err := b.rpc.GetBlockTime(ctx, toSlot)
+	toTime, err := retryRPC(ctx, "GetBlockTime", func() (*int64, error) {
+		return b.rpc.GetBlockTime(ctx, toSlot)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("get block time for slot %d: %w", toSlot, err)
 	}
@@ -157,13 +332,26 @@ func (b *Backfiller) BackfillSlotRange(ctx context.Context, fromSlot, toSlot int
 	from := time.Unix(*fromTime, 0)
 	to := time.Unix(*toTime, 0)
 
+	if b.strategy == BackfillStrategyBlocks {
+		return b.backfillBlocks(ctx, fromSlot, toSlot, from, to)
+	}
 	return b.BackfillRange(ctx, from, to)
 }
 
-// storeSwapEvents stores swap events in batches, handling duplicates.
-func (b *Backfiller) storeSwapEvents(ctx context.Context, events []*domain.SwapEvent) (stored, dupes, errs int) {
-	if b.swapEventStore == nil {
-		return 0, 0, 0
+// storeSwapEvents stores swap events in batches, handling duplicates. Events
+// past a per-candidate cap are dropped and counted as capped.
+func (b *Backfiller) storeSwapEvents(ctx context.Context, events []*domain.SwapEvent) (stored, dupes, capped, errs int) {
+	if b.swapEventStore == nil || len(events) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	if b.capper != nil {
+		from, to := events[0].Timestamp, events[0].Timestamp
+		for _, e := range events {
+			from, to = min(from, e.Timestamp), max(to, e.Timestamp)
+		}
+		b.loadEventCaps(ctx, from, to)
+		defer b.persistEventCounts(ctx, to)
 	}
 
 	for i := 0; i < len(events); i += b.batchSize {
@@ -172,14 +360,33 @@ func (b *Backfiller) storeSwapEvents(ctx context.Context, events []*domain.SwapE
 			end = len(events)
 		}
 
-		batch := events[i:end]
+		// Admit in order, counting kept events as stored so the next
+		// admission sees them; rejected ones are reverted below
+		batch := make([]*domain.SwapEvent, 0, end-i)
+		caps := make([][]*candidateCap, 0, end-i)
+		for _, event := range events[i:end] {
+			c, keep := b.capper.admitSwap(event)
+			if !keep {
+				capped++
+				continue
+			}
+			b.capper.stored(c, true)
+			batch = append(batch, event)
+			caps = append(caps, c)
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
 		err := b.swapEventStore.InsertBulk(ctx, batch)
 		if err != nil {
 			if errors.Is(err, storage.ErrDuplicateKey) {
 				// Insert one by one to find which are duplicates
-				for _, event := range batch {
+				for j, event := range batch {
 					if err := b.swapEventStore.Insert(ctx, event); err != nil {
+						b.capper.unstored(caps[j], true)
 						if errors.Is(err, storage.ErrDuplicateKey) {
+							b.capper.unseen(caps[j], true)
 							dupes++
 						} else {
 							errs++
@@ -189,6 +396,9 @@ func (b *Backfiller) storeSwapEvents(ctx context.Context, events []*domain.SwapE
 					}
 				}
 			} else {
+				for _, c := range caps {
+					b.capper.unstored(c, true)
+				}
 				errs += len(batch)
 				b.logger.Printf("Error storing batch: %v", err)
 			}
@@ -197,13 +407,23 @@ func (b *Backfiller) storeSwapEvents(ctx context.Context, events []*domain.SwapE
 		}
 	}
 
-	return stored, dupes, errs
+	return stored, dupes, capped, errs
 }
 
 // storeLiquidityEvents stores liquidity events in batches, handling duplicates.
-func (b *Backfiller) storeLiquidityEvents(ctx context.Context, events []*domain.LiquidityEvent) (stored, dupes, errs int) {
-	if b.liquidityStore == nil {
-		return 0, 0, 0
+// Events past a per-candidate cap are dropped and counted as capped.
+func (b *Backfiller) storeLiquidityEvents(ctx context.Context, events []*domain.LiquidityEvent) (stored, dupes, capped, errs int) {
+	if b.liquidityStore == nil || len(events) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	if b.capper != nil {
+		from, to := events[0].Timestamp, events[0].Timestamp
+		for _, e := range events {
+			from, to = min(from, e.Timestamp), max(to, e.Timestamp)
+		}
+		b.loadEventCaps(ctx, from, to)
+		defer b.persistEventCounts(ctx, to)
 	}
 
 	for i := 0; i < len(events); i += b.batchSize {
@@ -212,14 +432,33 @@ func (b *Backfiller) storeLiquidityEvents(ctx context.Context, events []*domain.
 			end = len(events)
 		}
 
-		batch := events[i:end]
+		// Admit in order, counting kept events as stored so the next
+		// admission sees them; rejected ones are reverted below
+		batch := make([]*domain.LiquidityEvent, 0, end-i)
+		caps := make([][]*candidateCap, 0, end-i)
+		for _, event := range events[i:end] {
+			c, keep := b.capper.admitLiquidity(event)
+			if !keep {
+				capped++
+				continue
+			}
+			b.capper.stored(c, false)
+			batch = append(batch, event)
+			caps = append(caps, c)
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
 		err := b.liquidityStore.InsertBulk(ctx, batch)
 		if err != nil {
 			if errors.Is(err, storage.ErrDuplicateKey) {
 				// Insert one by one to find which are duplicates
-				for _, event := range batch {
+				for j, event := range batch {
 					if err := b.liquidityStore.Insert(ctx, event); err != nil {
+						b.capper.unstored(caps[j], false)
 						if errors.Is(err, storage.ErrDuplicateKey) {
+							b.capper.unseen(caps[j], false)
 							dupes++
 						} else {
 							errs++
@@ -229,6 +468,9 @@ func (b *Backfiller) storeLiquidityEvents(ctx context.Context, events []*domain.
 					}
 				}
 			} else {
+				for _, c := range caps {
+					b.capper.unstored(c, false)
+				}
 				errs += len(batch)
 				b.logger.Printf("Error storing liquidity batch: %v", err)
 			}
@@ -237,7 +479,22 @@ func (b *Backfiller) storeLiquidityEvents(ctx context.Context, events []*domain.
 		}
 	}
 
-	return stored, dupes, errs
+	return stored, dupes, capped, errs
+}
+
+// loadEventCaps tracks the candidates whose observation window overlaps [from, to].
+func (b *Backfiller) loadEventCaps(ctx context.Context, from, to int64) {
+	if err := b.capper.loadRange(ctx, b.candidateStore, from, to); err != nil {
+		b.logger.Printf("Error loading event caps: %v", err)
+	}
+}
+
+// persistEventCounts stores the per-candidate event totals under event caps,
+// up to eventTime.
+func (b *Backfiller) persistEventCounts(ctx context.Context, eventTime int64) {
+	if err := b.capper.persist(ctx, eventTime, time.Now().UnixMilli()); err != nil {
+		b.logger.Printf("Error storing event counts: %v", err)
+	}
 }
 
 // runDiscovery runs NEW_TOKEN detection on swap events.
@@ -258,6 +515,7 @@ func (b *Backfiller) runDiscovery(ctx context.Context, events []*domain.SwapEven
 			EventIndex:  e.EventIndex,
 			Slot:        e.Slot,
 			Timestamp:   e.Timestamp,
+			DEX:         e.DEX,
 		}
 	}
 
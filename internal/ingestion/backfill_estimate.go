@@ -0,0 +1,292 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"solana-token-lab/internal/solana"
+)
+
+// DefaultEstimateSamples is the number of points across the window at which
+// EstimateRange samples the signature density of each program.
+const DefaultEstimateSamples = 4
+
+// slotDuration is the target slot time, used to map a time to an approximate slot.
+const slotDuration = 400 * time.Millisecond
+
+// anchorSearchSlots is how many slots past the approximate slot of a sample point
+// are searched for a produced block.
+const anchorSearchSlots = 100
+
+// EstimateOptions configures EstimateRange.
+type EstimateOptions struct {
+	Samples           int     // sample points across the window (default: DefaultEstimateSamples)
+	RequestsPerSecond float64 // rate the duration assumes; 0 uses the latency of the sample requests
+}
+
+// ProgramEstimate is the projected signature history of one program.
+type ProgramEstimate struct {
+	Program           string
+	Scans             int     // signature scans of the program, one per source monitoring it
+	WindowSignatures  int64   // signatures in [from, to)
+	FailedSignatures  int64   // of WindowSignatures, failed: not fetched
+	NewerSignatures   int64   // signatures after to, paged through before the window
+	SignaturesPerHour float64 // mean over the window
+}
+
+// BackfillEstimate is the projected cost of BackfillRange over a window,
+// extrapolated from a sample of the signature history (see EstimateRange).
+type BackfillEstimate struct {
+	From, To time.Time
+	Programs []ProgramEstimate // sorted by program
+
+	SignatureRequests   int64 // getSignaturesForAddress pages
+	TransactionRequests int64 // getTransaction calls
+	Requests            int64 // SignatureRequests + TransactionRequests; retries and pool inference not included
+	SampleRequests      int   // requests the estimate made
+
+	RequestsPerSecond float64       // rate Duration assumes
+	Duration          time.Duration // Requests at RequestsPerSecond
+
+	// Stored rows, assuming one event per fetched transaction. Liquidity
+	// events are an upper bound: most transactions of a DEX program are swaps.
+	SwapRows      int64
+	LiquidityRows int64
+}
+
+// EstimateRange projects the requests, duration and stored rows of
+// BackfillRange(from, to) without fetching any transaction. For each program it
+// reads one getSignaturesForAddress page at opts.Samples points spread across
+// the window, each anchored at a block near the point, and extrapolates the
+// signature density of the window from them. The signatures strategy only.
+func (b *Backfiller) EstimateRange(ctx context.Context, from, to time.Time, opts EstimateOptions) (*BackfillEstimate, error) {
+	if b.strategy == BackfillStrategyBlocks {
+		return nil, fmt.Errorf("%s backfill strategy cannot be estimated from signatures", b.strategy)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("empty backfill window %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = DefaultEstimateSamples
+	}
+
+	// Every source scans each of its programs over the whole signature history
+	// newer than the window
+	type scanSource struct {
+		programs  []string
+		overshoot time.Duration
+		swaps     bool
+	}
+	var sources []scanSource
+	if b.swapSource != nil {
+		sources = append(sources, scanSource{b.swapSource.Programs(), b.swapSource.overshoot, true})
+	}
+	if b.liquiditySource != nil {
+		sources = append(sources, scanSource{b.liquiditySource.Programs(), b.liquiditySource.overshoot, false})
+	}
+	var programs []string
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		for _, p := range src.programs {
+			if !seen[p] {
+				seen[p] = true
+				programs = append(programs, p)
+			}
+		}
+	}
+	if len(programs) == 0 {
+		return nil, errors.New("no programs to estimate")
+	}
+	sort.Strings(programs)
+
+	s := &densitySampler{rpc: b.rpc}
+	seg := to.Sub(from) / time.Duration(samples)
+	segSec := int64(seg / time.Second)
+	if segSec < 1 {
+		segSec = 1
+	}
+
+	nowSlot, nowSec, err := s.reference(ctx)
+	if err != nil {
+		return nil, err
+	}
+	anchors := make([]blockAnchor, samples)
+	for i := range anchors {
+		at := from.Add(seg * time.Duration(i+1))
+		slot := nowSlot - int64(float64(nowSec-at.Unix())*float64(time.Second)/float64(slotDuration))
+		// Blocks after the reference slot are not produced yet
+		slot = max(min(slot, nowSlot-anchorSearchSlots), 0)
+		if anchors[i], err = s.anchor(ctx, slot); err != nil {
+			return nil, fmt.Errorf("anchor sample %d at %s: %w", i+1, at.Format(time.RFC3339), err)
+		}
+	}
+
+	est := &BackfillEstimate{From: from, To: to}
+	density := make(map[string][]float64) // program -> signatures per second of each segment
+	for _, program := range programs {
+		pe := ProgramEstimate{Program: program}
+		var window, failed float64
+		for _, a := range anchors {
+			n, f, err := s.segment(ctx, program, a, segSec)
+			if err != nil {
+				return nil, fmt.Errorf("sample %s: %w", program, err)
+			}
+			window += n
+			failed += f
+			density[program] = append(density[program], n/float64(segSec))
+		}
+		// The segments tile the window; scale for its remainder
+		scale := to.Sub(from).Seconds() / float64(segSec*int64(samples))
+		pe.WindowSignatures = int64(math.Round(window * scale))
+		pe.FailedSignatures = int64(math.Round(failed * scale))
+		if after := nowSec - to.Unix(); after > 0 {
+			pe.NewerSignatures = int64(math.Round(density[program][samples-1] * float64(after)))
+		}
+		pe.SignaturesPerHour = float64(pe.WindowSignatures) / to.Sub(from).Hours()
+		est.Programs = append(est.Programs, pe)
+	}
+
+	for _, src := range sources {
+		for _, program := range src.programs {
+			i := sort.SearchStrings(programs, program)
+			pe := &est.Programs[i]
+			pe.Scans++
+
+			// Pagination runs until a page lies entirely before the window minus the overshoot
+			overshoot := int64(math.Round(density[program][0] * src.overshoot.Seconds()))
+			scanned := pe.NewerSignatures + pe.WindowSignatures + overshoot
+			est.SignatureRequests += scanned/signaturePageLimit + 1
+
+			fetched := pe.WindowSignatures - pe.FailedSignatures
+			est.TransactionRequests += fetched
+			if src.swaps {
+				est.SwapRows += fetched
+			} else {
+				est.LiquidityRows += fetched
+			}
+		}
+	}
+	est.Requests = est.SignatureRequests + est.TransactionRequests
+	est.SampleRequests = s.requests
+
+	est.RequestsPerSecond = opts.RequestsPerSecond
+	if est.RequestsPerSecond <= 0 && s.elapsed > 0 {
+		// Scans are sequential: one request at a time at the sampled latency
+		est.RequestsPerSecond = float64(s.requests) / s.elapsed.Seconds()
+	}
+	if est.RequestsPerSecond > 0 {
+		est.Duration = time.Duration(float64(est.Requests) / est.RequestsPerSecond * float64(time.Second))
+	}
+	return est, nil
+}
+
+// blockAnchor is a block whose first signature starts a sample page.
+type blockAnchor struct {
+	slot      int64
+	blockTime int64
+	signature string
+}
+
+// densitySampler makes the requests of EstimateRange and times them.
+type densitySampler struct {
+	rpc      *solana.HTTPClient
+	requests int
+	elapsed  time.Duration
+}
+
+// timedRPC runs one timed request, retrying transient failures.
+func timedRPC[T any](ctx context.Context, s *densitySampler, desc string, fn func() (T, error)) (T, error) {
+	return retryRPC(ctx, desc, func() (T, error) {
+		start := time.Now()
+		v, err := fn()
+		s.requests++
+		s.elapsed += time.Since(start)
+		return v, err
+	})
+}
+
+// reference returns a recent slot and its block time, which map times to slots.
+func (s *densitySampler) reference(ctx context.Context) (int64, int64, error) {
+	slot, err := timedRPC(ctx, s, "GetSlot", func() (int64, error) {
+		return s.rpc.GetSlot(ctx)
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("get slot: %w", err)
+	}
+	blockTime, err := timedRPC(ctx, s, "GetBlockTime", func() (*int64, error) {
+		return s.rpc.GetBlockTime(ctx, slot)
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("get block time for slot %d: %w", slot, err)
+	}
+	if blockTime == nil {
+		return 0, 0, fmt.Errorf("no block time for slot %d", slot)
+	}
+	return slot, *blockTime, nil
+}
+
+// anchor returns the first produced block with a signature at or after slot.
+func (s *densitySampler) anchor(ctx context.Context, slot int64) (blockAnchor, error) {
+	slots, err := timedRPC(ctx, s, "GetBlocks", func() ([]int64, error) {
+		return s.rpc.GetBlocks(ctx, slot, slot+anchorSearchSlots)
+	})
+	if err != nil {
+		return blockAnchor{}, fmt.Errorf("get blocks from slot %d: %w", slot, err)
+	}
+	for _, produced := range slots {
+		block, err := timedRPC(ctx, s, "GetBlockSignatures", func() (*solana.BlockSignatures, error) {
+			return s.rpc.GetBlockSignatures(ctx, produced)
+		})
+		if err != nil {
+			return blockAnchor{}, fmt.Errorf("get block %d: %w", produced, err)
+		}
+		if block.BlockTime != nil && len(block.Signatures) > 0 {
+			return blockAnchor{slot: produced, blockTime: *block.BlockTime, signature: block.Signatures[0]}, nil
+		}
+	}
+	return blockAnchor{}, fmt.Errorf("no block with transactions in slots %d to %d", slot, slot+anchorSearchSlots)
+}
+
+// segment estimates the signatures, and the failed ones among them, of program
+// in the segSec seconds before the anchor from one page of its history. A page
+// reaching past the segment (or the start of the history) is counted; a full
+// page within the segment is extrapolated over it.
+func (s *densitySampler) segment(ctx context.Context, program string, a blockAnchor, segSec int64) (sigs, failed float64, err error) {
+	page, err := timedRPC(ctx, s, "GetSignaturesForAddress "+program, func() ([]solana.SignatureInfo, error) {
+		return s.rpc.GetSignaturesForAddress(ctx, program, &solana.SignaturesOpts{Before: a.signature, Limit: signaturePageLimit})
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("get signatures before slot %d: %w", a.slot, err)
+	}
+
+	start := a.blockTime - segSec
+	oldest := a.blockTime
+	var inSegment, failedInSegment, pageFailed int
+	for _, sig := range page {
+		if sig.Err != nil {
+			pageFailed++
+		}
+		if sig.BlockTime == nil {
+			continue
+		}
+		oldest = min(oldest, *sig.BlockTime)
+		if *sig.BlockTime >= start && *sig.BlockTime < a.blockTime {
+			inSegment++
+			if sig.Err != nil {
+				failedInSegment++
+			}
+		}
+	}
+	if len(page) < signaturePageLimit || oldest < start {
+		return float64(inSegment), float64(failedInSegment), nil
+	}
+
+	span := max(a.blockTime-oldest, 1)
+	sigs = float64(len(page)) * float64(segSec) / float64(span)
+	return sigs, sigs * float64(pageFailed) / float64(len(page)), nil
+}
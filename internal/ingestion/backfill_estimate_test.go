@@ -0,0 +1,205 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/solana"
+)
+
+// densityRPC is a chain of 400ms slots from genesis to nowSlot, every third slot
+// skipped, where each program has a fixed signature density:
+//   - hot: 2 signatures every second, those of every 10th second failed
+//   - cold: 1 signature every 10th second
+//
+// Serving getTransaction fails the test: an estimate fetches no transaction.
+type densityRPC struct {
+	genesis int64
+	nowSlot int64
+}
+
+const (
+	hotProgram  = "HotProgram"
+	coldProgram = "ColdProgram"
+)
+
+func (f *densityRPC) blockTime(slot int64) int64 {
+	return f.genesis + slot*2/5
+}
+
+// signatures returns the page of program's history before second start.
+func (f *densityRPC) signatures(program string, start int64, limit int) []map[string]interface{} {
+	page := []map[string]interface{}{}
+	for x := start - 1; x >= f.genesis && len(page) < limit; x-- {
+		switch program {
+		case hotProgram:
+			for k := 0; k < 2 && len(page) < limit; k++ {
+				sig := map[string]interface{}{"signature": fmt.Sprintf("hot-%d-%d", x, k), "slot": 1, "blockTime": x}
+				if x%10 == 0 {
+					sig["err"] = map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}
+				}
+				page = append(page, sig)
+			}
+		case coldProgram:
+			if x%10 == 0 {
+				page = append(page, map[string]interface{}{"signature": fmt.Sprintf("cold-%d", x), "slot": 1, "blockTime": x})
+			}
+		}
+	}
+	return page
+}
+
+func (f *densityRPC) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getSlot":
+			result = f.nowSlot
+		case "getBlockTime":
+			var slot int64
+			_ = json.Unmarshal(req.Params[0], &slot)
+			result = f.blockTime(slot)
+		case "getBlocks":
+			var start, end int64
+			_ = json.Unmarshal(req.Params[0], &start)
+			_ = json.Unmarshal(req.Params[1], &end)
+			slots := []int64{}
+			for s := start; s <= end && s <= f.nowSlot; s++ {
+				if s%3 != 0 {
+					slots = append(slots, s)
+				}
+			}
+			result = slots
+		case "getBlock":
+			var slot int64
+			_ = json.Unmarshal(req.Params[0], &slot)
+			result = map[string]interface{}{
+				"blockTime":  f.blockTime(slot),
+				"signatures": []string{fmt.Sprintf("block-%d", slot)},
+			}
+		case "getSignaturesForAddress":
+			var program string
+			var opts struct {
+				Before string `json:"before"`
+				Limit  int    `json:"limit"`
+			}
+			_ = json.Unmarshal(req.Params[0], &program)
+			_ = json.Unmarshal(req.Params[1], &opts)
+			var slot int64
+			if _, err := fmt.Sscanf(opts.Before, "block-%d", &slot); err != nil {
+				t.Errorf("page not anchored at a block: before=%q", opts.Before)
+			}
+			result = f.signatures(program, f.blockTime(slot), opts.Limit)
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestBackfiller_EstimateRange(t *testing.T) {
+	// Two days of chain; the window is the 6 hours ending an hour ago
+	fake := &densityRPC{genesis: 1_700_000_000, nowSlot: 2 * 86400 * 5 / 2}
+	srv := fake.serve(t)
+	defer srv.Close()
+
+	now := time.Unix(fake.blockTime(fake.nowSlot), 0)
+	to := now.Add(-time.Hour)
+	from := to.Add(-6 * time.Hour)
+
+	rpc := solana.NewHTTPClient(srv.URL)
+	b := NewBackfiller(BackfillOptions{
+		RPC:             rpc,
+		SwapSource:      NewRPCSwapEventSource(rpc, []string{hotProgram, coldProgram}),
+		LiquiditySource: NewRPCLiquidityEventSource(rpc, []string{hotProgram}, nil),
+	})
+
+	est, err := b.EstimateRange(context.Background(), from, to, EstimateOptions{RequestsPerSecond: 10})
+	if err != nil {
+		t.Fatalf("EstimateRange: %v", err)
+	}
+
+	// hot: pages of 1000 signatures cover 500s of its 5400s segments and are
+	// extrapolated; cold: each page covers its whole segment and is counted
+	want := []ProgramEstimate{
+		{Program: coldProgram, Scans: 1, WindowSignatures: 2160, NewerSignatures: 360, SignaturesPerHour: 360},
+		{Program: hotProgram, Scans: 2, WindowSignatures: 43200, FailedSignatures: 4320, NewerSignatures: 7200, SignaturesPerHour: 7200},
+	}
+	if len(est.Programs) != len(want) {
+		t.Fatalf("programs = %+v", est.Programs)
+	}
+	for i := range want {
+		if est.Programs[i] != want[i] {
+			t.Errorf("program %d = %+v, want %+v", i, est.Programs[i], want[i])
+		}
+	}
+
+	// Pages per scan: (newer + window + 2 minutes of overshoot) / 1000 + 1
+	//   hot  (7200 + 43200 + 240) / 1000 + 1 = 51, twice
+	//   cold (360 + 2160 + 12) / 1000 + 1    = 3
+	if est.SignatureRequests != 105 {
+		t.Errorf("SignatureRequests = %d, want 105", est.SignatureRequests)
+	}
+	// Successful window signatures of every scan
+	if est.TransactionRequests != 2*38880+2160 {
+		t.Errorf("TransactionRequests = %d, want %d", est.TransactionRequests, 2*38880+2160)
+	}
+	if est.Requests != 105+2*38880+2160 {
+		t.Errorf("Requests = %d", est.Requests)
+	}
+	if est.SwapRows != 38880+2160 || est.LiquidityRows != 38880 {
+		t.Errorf("rows = %d swap, %d liquidity", est.SwapRows, est.LiquidityRows)
+	}
+	if want := time.Duration(est.Requests) * time.Second / 10; est.Duration != want {
+		t.Errorf("Duration = %v, want %v at 10 requests/s", est.Duration, want)
+	}
+	// getSlot, getBlockTime, then per sample getBlocks and getBlock, and a page per program
+	if est.SampleRequests != 2+4*2+4*2 {
+		t.Errorf("SampleRequests = %d, want 18", est.SampleRequests)
+	}
+}
+
+func TestBackfiller_EstimateRangeSampledLatency(t *testing.T) {
+	fake := &densityRPC{genesis: 1_700_000_000, nowSlot: 86400 * 5 / 2}
+	srv := fake.serve(t)
+	defer srv.Close()
+
+	now := time.Unix(fake.blockTime(fake.nowSlot), 0)
+	rpc := solana.NewHTTPClient(srv.URL)
+	b := NewBackfiller(BackfillOptions{RPC: rpc, SwapSource: NewRPCSwapEventSource(rpc, []string{coldProgram})})
+
+	est, err := b.EstimateRange(context.Background(), now.Add(-time.Hour), now, EstimateOptions{Samples: 2})
+	if err != nil {
+		t.Fatalf("EstimateRange: %v", err)
+	}
+	if est.RequestsPerSecond <= 0 || est.Duration <= 0 {
+		t.Errorf("expected a rate and duration from the sample latency, got %v and %v", est.RequestsPerSecond, est.Duration)
+	}
+	if est.Programs[0].WindowSignatures != 360 || est.Programs[0].NewerSignatures != 0 {
+		t.Errorf("unexpected estimate %+v", est.Programs[0])
+	}
+
+	blocks := NewBackfiller(BackfillOptions{RPC: rpc, SwapSource: NewRPCSwapEventSource(rpc, []string{coldProgram}), Strategy: BackfillStrategyBlocks})
+	if _, err := blocks.EstimateRange(context.Background(), now.Add(-time.Hour), now, EstimateOptions{}); err == nil {
+		t.Error("expected an error for the blocks strategy")
+	}
+}
@@ -0,0 +1,260 @@
+package ingestion
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// etaWindow is how far back throughput samples are kept for ETA estimation.
+const etaWindow = 5 * time.Minute
+
+// ProgramProgress holds backfill counters for one DEX program.
+type ProgramProgress struct {
+	Program             string
+	SignaturesScanned   int
+	TransactionsFetched int
+	FailedTransactions  int // failed on chain, skipped without fetching
+	SwapEvents          int
+	LiquidityEvents     int
+}
+
+// BackfillProgressSnapshot is a point-in-time view of a running backfill.
+type BackfillProgressSnapshot struct {
+	Elapsed             time.Duration
+	SignaturesScanned   int
+	TransactionsFetched int
+	EventsStored        int
+	BlockTime           int64         // block time (unix seconds) reached by the active scan, 0 before the first transaction
+	TargetTime          int64         // range start (unix seconds); scans walk backwards towards it
+	Fraction            float64       // 0..1 across all program scans
+	ETA                 time.Duration // 0 until throughput is known
+	Programs            []ProgramProgress
+}
+
+// progressSample is one (time, covered seconds) point for throughput estimation.
+type progressSample struct {
+	at      time.Time
+	covered int64
+}
+
+// BackfillProgress tracks a backfill across RPC sources. Safe for concurrent use.
+// RPC sources scan each program from the newest signature backwards, so progress
+// within a scan is measured as the block time distance from the range end.
+// A nil *BackfillProgress ignores all updates.
+type BackfillProgress struct {
+	mu sync.Mutex
+
+	fromSec, toSec int64
+	scans          int   // expected program scans (swap + liquidity)
+	finishedScans  int   // scans completed
+	blockTime      int64 // oldest block time in the active scan
+	reached        int64 // newest block time reached by a forward scan (blocks strategy)
+
+	eventsStored int
+	programs     map[string]*ProgramProgress
+
+	started time.Time
+	samples []progressSample
+	clock   func() time.Time
+}
+
+// NewBackfillProgress creates a tracker for [from, to) in milliseconds over the given number of program scans.
+func NewBackfillProgress(from, to int64, scans int, clock func() time.Time) *BackfillProgress {
+	if clock == nil {
+		clock = time.Now
+	}
+	if scans < 1 {
+		scans = 1
+	}
+	return &BackfillProgress{
+		fromSec:  from / 1000,
+		toSec:    to / 1000,
+		scans:    scans,
+		programs: make(map[string]*ProgramProgress),
+		started:  clock(),
+		clock:    clock,
+	}
+}
+
+// program returns the counters for a program, creating them on first use. Caller holds mu.
+func (p *BackfillProgress) program(name string) *ProgramProgress {
+	pp, ok := p.programs[name]
+	if !ok {
+		pp = &ProgramProgress{Program: name}
+		p.programs[name] = pp
+	}
+	return pp
+}
+
+// AddSignatures records a page of signatures scanned for a program.
+func (p *BackfillProgress) AddSignatures(program string, n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program(program).SignaturesScanned += n
+}
+
+// AddTransaction records a fetched transaction and the block time it was at (0 if unknown).
+func (p *BackfillProgress) AddTransaction(program string, blockTime int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program(program).TransactionsFetched++
+	if blockTime > 0 && (p.blockTime == 0 || blockTime < p.blockTime) {
+		p.blockTime = blockTime
+	}
+}
+
+// AddFailedTransaction records an in-window transaction of a program that
+// failed on chain and was skipped.
+func (p *BackfillProgress) AddFailedTransaction(program string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program(program).FailedTransactions++
+}
+
+// AdvanceTo records that a forward scan (blocks strategy) has covered the range
+// up to blockTime. Forward progress takes precedence over backward scans.
+func (p *BackfillProgress) AdvanceTo(blockTime int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if blockTime > p.reached {
+		p.reached = blockTime
+	}
+}
+
+// AddSwapEvents records swap events parsed for a program.
+func (p *BackfillProgress) AddSwapEvents(program string, n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program(program).SwapEvents += n
+}
+
+// AddLiquidityEvents records liquidity events parsed for a program.
+func (p *BackfillProgress) AddLiquidityEvents(program string, n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program(program).LiquidityEvents += n
+}
+
+// AddStored records events persisted to storage.
+func (p *BackfillProgress) AddStored(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.eventsStored += n
+}
+
+// FinishScan marks the active program scan as complete.
+func (p *BackfillProgress) FinishScan() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finishedScans < p.scans {
+		p.finishedScans++
+	}
+	p.blockTime = 0
+	p.reached = 0
+}
+
+// covered returns seconds of range covered across all scans. Caller holds mu.
+func (p *BackfillProgress) covered() int64 {
+	span := p.toSec - p.fromSec
+	if span <= 0 {
+		return 0
+	}
+	total := int64(p.finishedScans) * span
+	if p.reached > 0 && p.finishedScans < p.scans {
+		active := p.reached - p.fromSec
+		if active < 0 {
+			active = 0
+		}
+		if active > span {
+			active = span
+		}
+		return total + active
+	}
+	if p.blockTime > 0 && p.finishedScans < p.scans {
+		active := p.toSec - p.blockTime
+		if active < 0 {
+			active = 0
+		}
+		if active > span {
+			active = span
+		}
+		total += active
+	}
+	return total
+}
+
+// Snapshot returns current counters, position and an ETA based on throughput
+// over the last few minutes of snapshots.
+func (p *BackfillProgress) Snapshot() BackfillProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock()
+	covered := p.covered()
+
+	// Record sample and drop those outside the ETA window (keep at least one older point)
+	p.samples = append(p.samples, progressSample{at: now, covered: covered})
+	for len(p.samples) > 2 && now.Sub(p.samples[1].at) >= etaWindow {
+		p.samples = p.samples[1:]
+	}
+
+	snap := BackfillProgressSnapshot{
+		Elapsed:      now.Sub(p.started),
+		EventsStored: p.eventsStored,
+		BlockTime:    p.blockTime,
+		TargetTime:   p.fromSec,
+	}
+	if p.reached > 0 {
+		snap.BlockTime = p.reached
+	}
+
+	total := int64(p.scans) * (p.toSec - p.fromSec)
+	if total > 0 {
+		snap.Fraction = float64(covered) / float64(total)
+	}
+
+	oldest := p.samples[0]
+	if dt := now.Sub(oldest.at); dt > 0 && covered > oldest.covered && covered < total {
+		rate := float64(covered-oldest.covered) / dt.Seconds()
+		snap.ETA = time.Duration(float64(total-covered) / rate * float64(time.Second))
+	}
+
+	names := make([]string, 0, len(p.programs))
+	for name := range p.programs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pp := *p.programs[name]
+		snap.SignaturesScanned += pp.SignaturesScanned
+		snap.TransactionsFetched += pp.TransactionsFetched
+		snap.Programs = append(snap.Programs, pp)
+	}
+
+	return snap
+}
@@ -0,0 +1,237 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// fakeBackfillRPC serves one page of signatures for the pump.fun program and
+// a pump.fun Buy transaction for every signature.
+func fakeBackfillRPC(t *testing.T, sigs []map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getSignaturesForAddress":
+			var params []json.RawMessage
+			_ = json.Unmarshal(req.Params, &params)
+			var opts struct {
+				Before string `json:"before"`
+			}
+			if len(params) > 1 {
+				_ = json.Unmarshal(params[1], &opts)
+			}
+			if opts.Before != "" {
+				result = []interface{}{} // single page
+			} else {
+				result = sigs
+			}
+		case "getTransaction":
+			var params []json.RawMessage
+			_ = json.Unmarshal(req.Params, &params)
+			var sig string
+			_ = json.Unmarshal(params[0], &sig)
+			result = map[string]interface{}{
+				"slot":      int64(100),
+				"blockTime": int64(1700000500),
+				"meta": map[string]interface{}{
+					"err": nil,
+					"fee": 25000,
+					"logMessages": []string{
+						"Program " + discovery.PumpFun + " invoke [1]",
+						"Program log: mint=Mint" + sig,
+						"Program log: Instruction: Buy",
+						"Program " + discovery.PumpFun + " success",
+					},
+				},
+				"transaction": map[string]interface{}{
+					"message": map[string]interface{}{"accountKeys": []string{}},
+				},
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestBackfiller_BackfillRange_ResultCounts(t *testing.T) {
+	from := time.Unix(1700000000, 0)
+	to := time.Unix(1700001000, 0)
+
+	sigs := []map[string]interface{}{
+		{"signature": "sigFuture", "slot": 103, "blockTime": int64(1700001500)}, // after range
+		{"signature": "sigA", "slot": 102, "blockTime": int64(1700000800)},
+		{"signature": "sigFailed", "slot": 101, "blockTime": int64(1700000600), "err": map[string]interface{}{"InstructionError": 1}},
+		{"signature": "sigB", "slot": 100, "blockTime": int64(1700000400)},
+		{"signature": "sigOld", "slot": 99, "blockTime": int64(1699999000)}, // before range: stops scan
+	}
+	server := fakeBackfillRPC(t, sigs)
+	defer server.Close()
+
+	rpc := solana.NewHTTPClient(server.URL)
+	swapEventStore := memory.NewSwapEventStore()
+
+	var snapshots []BackfillProgressSnapshot
+	backfiller := NewBackfiller(BackfillOptions{
+		RPC:            rpc,
+		SwapSource:     NewRPCSwapEventSource(rpc, []string{discovery.PumpFun}),
+		SwapEventStore: swapEventStore,
+		OnProgress:     func(p BackfillProgressSnapshot) { snapshots = append(snapshots, p) },
+		Logger:         log.New(io.Discard, "", 0),
+	})
+
+	result, err := backfiller.BackfillRange(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("BackfillRange failed: %v", err)
+	}
+
+	if result.SignaturesScanned != 5 {
+		t.Errorf("expected 5 signatures scanned, got %d", result.SignaturesScanned)
+	}
+	if result.TransactionsFetched != 2 {
+		t.Errorf("expected 2 transactions fetched (failed and out-of-range skipped), got %d", result.TransactionsFetched)
+	}
+	if result.SwapEventsIngested != 2 {
+		t.Errorf("expected 2 swap events ingested, got %d", result.SwapEventsIngested)
+	}
+	if len(result.Programs) != 1 {
+		t.Fatalf("expected 1 program, got %d", len(result.Programs))
+	}
+	prog := result.Programs[0]
+	if prog.Program != discovery.PumpFun || prog.SignaturesScanned != 5 || prog.TransactionsFetched != 2 || prog.SwapEvents != 2 {
+		t.Errorf("unexpected program progress: %+v", prog)
+	}
+
+	// Final snapshot is reported once the run ends
+	if len(snapshots) == 0 {
+		t.Fatal("expected final progress snapshot")
+	}
+	final := snapshots[len(snapshots)-1]
+	if final.EventsStored != 2 || final.Fraction != 1 {
+		t.Errorf("expected final snapshot with 2 stored and full coverage, got %+v", final)
+	}
+
+	// Events were persisted
+	mints, err := swapEventStore.GetDistinctMintsByTimeRange(context.Background(), from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		t.Fatalf("GetDistinctMintsByTimeRange failed: %v", err)
+	}
+	if len(mints) != 2 {
+		t.Errorf("expected 2 distinct mints stored, got %d", len(mints))
+	}
+
+	// Fee telemetry is carried from the transaction meta
+	events, err := swapEventStore.GetByTimeRange(context.Background(), from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		t.Fatalf("GetByTimeRange failed: %v", err)
+	}
+	for _, e := range events {
+		if e.FeeLamports == nil || *e.FeeLamports != 25000 {
+			t.Errorf("expected fee 25000 on %s, got %v", e.TxSignature, e.FeeLamports)
+		}
+		if e.PriorityFeeLamports == nil || *e.PriorityFeeLamports != 0 {
+			t.Errorf("expected zero priority fee without ComputeBudget instructions on %s, got %v", e.TxSignature, e.PriorityFeeLamports)
+		}
+	}
+}
+
+func TestBackfillProgress_FractionAndETA(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+
+	// 1000s range over 2 program scans = 2000s to cover
+	p := NewBackfillProgress(1000000, 2000000, 2, clock)
+
+	snap := p.Snapshot()
+	if snap.Fraction != 0 || snap.ETA != 0 {
+		t.Errorf("expected empty progress, got %+v", snap)
+	}
+
+	// After 10s the first scan reached block time 1800 (200s covered)
+	now = now.Add(10 * time.Second)
+	p.AddSignatures("prog1", 50)
+	p.AddTransaction("prog1", 1900)
+	p.AddTransaction("prog1", 1800)
+
+	snap = p.Snapshot()
+	if snap.Fraction != 0.1 {
+		t.Errorf("expected 10%% progress, got %f", snap.Fraction)
+	}
+	// 200s covered in 10s = 20 covered/s; 1800 remaining = 90s
+	if snap.ETA != 90*time.Second {
+		t.Errorf("expected ETA 90s, got %v", snap.ETA)
+	}
+	if snap.BlockTime != 1800 || snap.TargetTime != 1000 {
+		t.Errorf("unexpected position: %d -> %d", snap.BlockTime, snap.TargetTime)
+	}
+
+	// First scan finishes; second scan starts from the range end
+	now = now.Add(10 * time.Second)
+	p.FinishScan()
+	p.AddTransaction("prog2", 2000)
+
+	snap = p.Snapshot()
+	if snap.Fraction != 0.5 {
+		t.Errorf("expected 50%% progress, got %f", snap.Fraction)
+	}
+	if snap.TransactionsFetched != 3 || snap.SignaturesScanned != 50 || len(snap.Programs) != 2 {
+		t.Errorf("unexpected totals: %+v", snap)
+	}
+
+	p.FinishScan()
+	snap = p.Snapshot()
+	if snap.Fraction != 1 || snap.ETA != 0 {
+		t.Errorf("expected completed progress, got fraction %f ETA %v", snap.Fraction, snap.ETA)
+	}
+}
+
+func TestBackfillProgress_NilSafe(t *testing.T) {
+	var p *BackfillProgress
+	p.AddSignatures("prog", 1)
+	p.AddTransaction("prog", 1)
+	p.AddSwapEvents("prog", 1)
+	p.AddLiquidityEvents("prog", 1)
+	p.AddStored(1)
+	p.FinishScan()
+}
+
+func TestFormatBackfillProgress(t *testing.T) {
+	line := formatBackfillProgress(BackfillProgressSnapshot{
+		Fraction:            0.25,
+		SignaturesScanned:   10,
+		TransactionsFetched: 4,
+		EventsStored:        3,
+		TargetTime:          1700000000,
+		ETA:                 90 * time.Second,
+	})
+	want := fmt.Sprintf("Backfill progress: 25.0%% | 10 signatures, 4 transactions, 3 events stored | at -, target %s | elapsed 0s, ETA 1m30s",
+		time.Unix(1700000000, 0).UTC().Format(time.RFC3339))
+	if line != want {
+		t.Errorf("unexpected line:\n got %s\nwant %s", line, want)
+	}
+}
@@ -0,0 +1,269 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+)
+
+// BackfillStrategy selects how a backfill enumerates transactions.
+type BackfillStrategy string
+
+const (
+	// BackfillStrategySignatures pages getSignaturesForAddress per program.
+	// Cheap, but providers may truncate signature history.
+	BackfillStrategySignatures BackfillStrategy = "signatures"
+
+	// BackfillStrategyBlocks walks every slot with getBlocks + getBlock and keeps
+	// transactions mentioning a configured program. Slot-complete at the cost of
+	// bandwidth, with per-slot checkpoints.
+	BackfillStrategyBlocks BackfillStrategy = "blocks"
+)
+
+// DefaultBlockChunkSlots is how many slots the blocks strategy processes between
+// event flushes and checkpoint writes.
+const DefaultBlockChunkSlots = 100
+
+// ParseBackfillStrategy parses a --backfill-strategy value.
+func ParseBackfillStrategy(s string) (BackfillStrategy, error) {
+	switch BackfillStrategy(s) {
+	case BackfillStrategySignatures, BackfillStrategyBlocks:
+		return BackfillStrategy(s), nil
+	}
+	return "", fmt.Errorf("unknown backfill strategy %q (want %s or %s)", s, BackfillStrategySignatures, BackfillStrategyBlocks)
+}
+
+// backfillBlocks walks [fromSlot, toSlot] in ascending chunks so discovery sees
+// events in chain order. Slots checkpointed by an earlier run are not fetched
+// again. from and to are the block times of the bounds, used for progress only.
+func (b *Backfiller) backfillBlocks(ctx context.Context, fromSlot, toSlot int64, from, to time.Time) (*BackfillResult, error) {
+	start := time.Now()
+	result := &BackfillResult{}
+
+	b.logger.Printf("Starting block backfill of slots %d to %d (%s to %s)",
+		fromSlot, toSlot, from.Format(time.RFC3339), to.Format(time.RFC3339))
+
+	progress := NewBackfillProgress(from.UnixMilli(), to.UnixMilli(), 1, nil)
+	if b.swapSource != nil {
+		b.swapSource.SetProgress(progress)
+	}
+	if b.liquiditySource != nil {
+		b.liquiditySource.SetProgress(progress)
+	}
+	stopReporter := b.startProgressReporter(progress)
+	defer func() {
+		stopReporter()
+		b.detachProgress()
+
+		b.flushFailedTx(context.WithoutCancel(ctx))
+
+		final := progress.Snapshot()
+		result.TransactionsFetched = final.TransactionsFetched
+		result.Programs = final.Programs
+		result.Duration = time.Since(start)
+		if b.onProgress != nil {
+			b.onProgress(final)
+		}
+	}()
+
+	for chunkStart := fromSlot; chunkStart <= toSlot; chunkStart += b.blockChunkSlots {
+		chunkEnd := chunkStart + b.blockChunkSlots - 1
+		if chunkEnd > toSlot {
+			chunkEnd = toSlot
+		}
+		if err := b.backfillBlockChunk(ctx, chunkStart, chunkEnd, progress, result); err != nil {
+			return result, err
+		}
+	}
+	progress.FinishScan()
+
+	result.Duration = time.Since(start)
+	b.logger.Printf("Block backfill complete: %d blocks, %d skipped slots, %d already complete, %d swaps, %d liquidity, %d candidates, %d dupes, %d capped, %d errors in %v",
+		result.BlocksFetched, result.SlotsSkipped, result.SlotsAlreadyComplete,
+		result.SwapEventsIngested, result.LiquidityEventsIngested, result.CandidatesDiscovered,
+		result.DuplicatesSkipped, result.CappedSwapEvents+result.CappedLiquidityEvents, result.Errors, result.Duration)
+
+	return result, nil
+}
+
+// backfillBlockChunk processes the pending slots of [start, end], stores their
+// events and checkpoints them. Slots are only checkpointed if every event was stored.
+func (b *Backfiller) backfillBlockChunk(ctx context.Context, start, end int64, progress *BackfillProgress, result *BackfillResult) error {
+	pending, err := b.pendingSlots(ctx, start, end)
+	if err != nil {
+		return fmt.Errorf("load checkpoints: %w", err)
+	}
+	result.SlotsAlreadyComplete += int(end-start+1) - len(pending)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	produced, err := retryRPC(ctx, "GetBlocks", func() ([]int64, error) {
+		return b.rpc.GetBlocks(ctx, start, end)
+	})
+	if err != nil {
+		return fmt.Errorf("get blocks %d-%d: %w", start, end, err)
+	}
+	hasBlock := make(map[int64]bool, len(produced))
+	for _, slot := range produced {
+		hasBlock[slot] = true
+	}
+
+	var swaps []*domain.SwapEvent
+	var liqs []*domain.LiquidityEvent
+	var checkpoints []*storage.SlotCheckpoint
+
+	for _, slot := range pending {
+		if !hasBlock[slot] {
+			checkpoints = append(checkpoints, &storage.SlotCheckpoint{Slot: slot, Skipped: true})
+			result.SlotsSkipped++
+			continue
+		}
+
+		block, err := retryRPC(ctx, fmt.Sprintf("GetBlock %d", slot), func() (*solana.Block, error) {
+			return b.rpc.GetBlock(ctx, slot)
+		})
+		if errors.Is(err, solana.ErrNotFound) {
+			// Listed by getBlocks but gone from the node (skipped after a fork)
+			checkpoints = append(checkpoints, &storage.SlotCheckpoint{Slot: slot, Skipped: true})
+			result.SlotsSkipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("get block %d: %w", slot, err)
+		}
+		result.BlocksFetched++
+
+		if block.BlockTime == nil {
+			// Events need a timestamp; leave the slot as a gap to retry later
+			b.logger.Printf("Block %d has no block time, leaving slot unchecked", slot)
+			result.Errors++
+			continue
+		}
+		blockTime := *block.BlockTime
+
+		relevant := 0
+		for i := range block.Transactions {
+			tx := &block.Transactions[i]
+			program := b.matchProgram(tx)
+			if program == "" || tx.Meta == nil {
+				continue
+			}
+			if tx.Meta.Err != nil {
+				progress.AddFailedTransaction(program)
+				b.failedTx.observe(failedTxSourceRPCBlocks, program, blockTime, true)
+				continue
+			}
+			b.failedTx.observe(failedTxSourceRPCBlocks, program, blockTime, false)
+			relevant++
+			progress.AddTransaction(program, blockTime)
+			archiveTx(ctx, b.archive(), tx, blockTime)
+
+			if b.swapSource != nil {
+				swaps = append(swaps, b.swapSource.parseTx(ctx, program, tx, blockTime)...)
+			}
+			if b.liquiditySource != nil && b.liquidityStore != nil {
+				events := b.liquiditySource.resolveByMint(ctx, b.liquiditySource.parseTx(ctx, tx, blockTime))
+				progress.AddLiquidityEvents(program, len(events))
+				liqs = append(liqs, events...)
+			}
+		}
+		progress.AdvanceTo(blockTime)
+		checkpoints = append(checkpoints, &storage.SlotCheckpoint{Slot: slot, Transactions: relevant})
+	}
+
+	SortSwapEvents(swaps)
+	if len(swaps) > 0 && b.newTokenDetector != nil {
+		result.CandidatesDiscovered += b.runDiscovery(ctx, swaps)
+	}
+
+	stored, dupes, capped, errs := b.storeSwapEvents(ctx, swaps)
+	progress.AddStored(stored)
+	result.SwapEventsIngested += stored
+	result.DuplicatesSkipped += dupes
+	result.CappedSwapEvents += capped
+	result.Errors += errs
+	failed := errs
+
+	SortLiquidityEvents(liqs)
+	stored, dupes, capped, errs = b.storeLiquidityEvents(ctx, liqs)
+	progress.AddStored(stored)
+	result.LiquidityEventsIngested += stored
+	result.DuplicatesSkipped += dupes
+	result.CappedLiquidityEvents += capped
+	result.Errors += errs
+	failed += errs
+
+	if b.checkpoints == nil {
+		return nil
+	}
+	if failed > 0 {
+		b.logger.Printf("Slots %d-%d not checkpointed: %d events failed to store", start, end, failed)
+		return nil
+	}
+	if err := b.checkpoints.MarkComplete(ctx, checkpoints); err != nil {
+		return fmt.Errorf("checkpoint slots %d-%d: %w", start, end, err)
+	}
+	return nil
+}
+
+// pendingSlots returns the slots of [start, end] without a checkpoint, ascending.
+func (b *Backfiller) pendingSlots(ctx context.Context, start, end int64) ([]int64, error) {
+	gaps := []storage.SlotRange{{From: start, To: end}}
+	if b.checkpoints != nil {
+		var err error
+		gaps, err = b.checkpoints.GetGaps(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var slots []int64
+	for _, g := range gaps {
+		for slot := g.From; slot <= g.To; slot++ {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+// matchProgram returns the first configured program the transaction mentions,
+// either as an account key or in an invoke log line, or "" if none.
+func (b *Backfiller) matchProgram(tx *solana.Transaction) string {
+	for _, program := range b.programs {
+		if tx.Message != nil {
+			for _, key := range tx.Message.AccountKeys {
+				if key == program {
+					return program
+				}
+			}
+		}
+		if tx.Meta != nil {
+			invoke := "Program " + program + " invoke"
+			for _, line := range tx.Meta.LogMessages {
+				if strings.HasPrefix(line, invoke) {
+					return program
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// archive returns the raw transaction archive of the backfill sources, if any.
+// Blocks are fetched once for both sources, so each transaction is archived once.
+func (b *Backfiller) archive() storage.RawTxStore {
+	if b.swapSource != nil && b.swapSource.archive != nil {
+		return b.swapSource.archive
+	}
+	if b.liquiditySource != nil {
+		return b.liquiditySource.archive
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// blockRPC serves getBlocks/getBlock/getBlockTime from a fixed set of blocks
+// and counts requests per method.
+type blockRPC struct {
+	blocks map[int64][]map[string]interface{} // slot -> transactions; absent slots are skipped
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *blockRPC) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		f.mu.Lock()
+		f.calls[req.Method]++
+		f.mu.Unlock()
+
+		var slot int64
+		if len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &slot)
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getBlockTime":
+			result = 1700000000 + slot
+		case "getBlocks":
+			var end int64
+			_ = json.Unmarshal(req.Params[1], &end)
+			produced := []int64{}
+			for s := slot; s <= end; s++ {
+				if _, ok := f.blocks[s]; ok {
+					produced = append(produced, s)
+				}
+			}
+			result = produced
+		case "getBlock":
+			result = map[string]interface{}{
+				"blockTime":    1700000000 + slot,
+				"transactions": f.blocks[slot],
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+// blockTx builds a block transaction invoking program with a pump.fun-style Buy log.
+func blockTx(sig, program string, failed bool) map[string]interface{} {
+	meta := map[string]interface{}{
+		"err": nil,
+		"logMessages": []string{
+			"Program " + program + " invoke [1]",
+			"Program log: mint=Mint" + sig,
+			"Program log: Instruction: Buy",
+			"Program " + program + " success",
+		},
+	}
+	if failed {
+		meta["err"] = map[string]interface{}{"InstructionError": 1}
+	}
+	return map[string]interface{}{
+		"transaction": map[string]interface{}{
+			"signatures": []string{sig},
+			"message":    map[string]interface{}{"accountKeys": []string{"payer", program}},
+		},
+		"meta": meta,
+	}
+}
+
+func TestBackfiller_BlocksStrategy(t *testing.T) {
+	const otherProgram = "Vote111111111111111111111111111111111111111"
+	fake := &blockRPC{
+		blocks: map[int64][]map[string]interface{}{
+			100: {
+				blockTx("sigA", discovery.PumpFun, false),
+				blockTx("sigVote", otherProgram, false),
+				blockTx("sigFailed", discovery.PumpFun, true),
+			},
+			101: {
+				blockTx("sigOther", otherProgram, false),
+				blockTx("sigB", discovery.PumpFun, false),
+			},
+			// 102 skipped
+		},
+		calls: make(map[string]int),
+	}
+	server := fake.serve(t)
+	defer server.Close()
+
+	rpc := solana.NewHTTPClient(server.URL)
+	swapEventStore := memory.NewSwapEventStore()
+	checkpoints := memory.NewSlotCheckpointStore()
+	newBackfiller := func() *Backfiller {
+		return NewBackfiller(BackfillOptions{
+			RPC:             rpc,
+			SwapSource:      NewRPCSwapEventSource(rpc, []string{discovery.PumpFun}),
+			SwapEventStore:  swapEventStore,
+			Strategy:        BackfillStrategyBlocks,
+			Checkpoints:     checkpoints,
+			BlockChunkSlots: 2,
+			Logger:          log.New(io.Discard, "", 0),
+		})
+	}
+
+	ctx := context.Background()
+	result, err := newBackfiller().BackfillSlotRange(ctx, 100, 102)
+	if err != nil {
+		t.Fatalf("BackfillSlotRange failed: %v", err)
+	}
+
+	if result.BlocksFetched != 2 || result.SlotsSkipped != 1 || result.SlotsAlreadyComplete != 0 {
+		t.Errorf("unexpected slot counters: %+v", result)
+	}
+	if result.TransactionsFetched != 2 {
+		t.Errorf("expected 2 relevant transactions (failed and other programs skipped), got %d", result.TransactionsFetched)
+	}
+	if result.SwapEventsIngested != 2 {
+		t.Errorf("expected 2 swap events ingested, got %d", result.SwapEventsIngested)
+	}
+	if fake.calls["getSignaturesForAddress"] != 0 || fake.calls["getTransaction"] != 0 {
+		t.Errorf("blocks strategy must not use signature crawling: %v", fake.calls)
+	}
+
+	mints, err := swapEventStore.GetDistinctMintsByTimeRange(ctx, 1700000000000, 1700001000000)
+	if err != nil {
+		t.Fatalf("GetDistinctMintsByTimeRange failed: %v", err)
+	}
+	if !reflect.DeepEqual(mints, []string{"MintsigA", "MintsigB"}) {
+		t.Errorf("expected only relevant transactions parsed, got mints %v", mints)
+	}
+
+	// Coverage is recorded per slot, including the skipped one
+	completed, err := checkpoints.GetCompleted(ctx, 100, 102)
+	if err != nil {
+		t.Fatalf("GetCompleted failed: %v", err)
+	}
+	want := []*storage.SlotCheckpoint{
+		{Slot: 100, Transactions: 1},
+		{Slot: 101, Transactions: 1},
+		{Slot: 102, Skipped: true},
+	}
+	if !reflect.DeepEqual(completed, want) {
+		t.Errorf("expected checkpoints %+v, got %+v", want, completed)
+	}
+	gaps, err := checkpoints.GetGaps(ctx, 100, 102)
+	if err != nil {
+		t.Fatalf("GetGaps failed: %v", err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("expected no gaps, got %+v", gaps)
+	}
+
+	// A re-run skips checkpointed slots entirely
+	blocksBefore := fake.calls["getBlock"]
+	result, err = newBackfiller().BackfillSlotRange(ctx, 100, 102)
+	if err != nil {
+		t.Fatalf("second BackfillSlotRange failed: %v", err)
+	}
+	if result.SlotsAlreadyComplete != 3 || result.BlocksFetched != 0 {
+		t.Errorf("expected all slots resumed from checkpoints, got %+v", result)
+	}
+	if fake.calls["getBlock"] != blocksBefore {
+		t.Errorf("expected no getBlock calls on resume, got %d more", fake.calls["getBlock"]-blocksBefore)
+	}
+}
+
+func TestBackfiller_BlocksStrategyRequiresSlotRange(t *testing.T) {
+	backfiller := NewBackfiller(BackfillOptions{Strategy: BackfillStrategyBlocks, Logger: log.New(io.Discard, "", 0)})
+	if _, err := backfiller.BackfillSince(context.Background(), time.Unix(0, 0)); err == nil {
+		t.Error("expected an error for a time-range backfill with the blocks strategy")
+	}
+}
+
+func TestParseBackfillStrategy(t *testing.T) {
+	for _, s := range []string{"signatures", "blocks"} {
+		if got, err := ParseBackfillStrategy(s); err != nil || string(got) != s {
+			t.Errorf("ParseBackfillStrategy(%q) = %q, %v", s, got, err)
+		}
+	}
+	if _, err := ParseBackfillStrategy("slots"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
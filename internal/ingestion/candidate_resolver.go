@@ -2,8 +2,10 @@ package ingestion
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
+	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
 )
 
@@ -30,3 +32,79 @@ func resolveCandidateIDByMint(ctx context.Context, store storage.CandidateStore,
 
 	return candidates[0].CandidateID, nil
 }
+
+// candidateIndex maps mints and pools to the earliest discovered candidate, with
+// the same tie-break as resolveCandidateIDByMint, so events of many candidates can
+// be attributed without a store query per event.
+type candidateIndex struct {
+	byMint map[string]*domain.TokenCandidate
+	byPool map[string]*domain.TokenCandidate
+	links  map[string]*domain.PoolLink // pool links by pool key, nil unless loaded
+}
+
+// loadCandidateIndex indexes every candidate of store.
+func loadCandidateIndex(ctx context.Context, store storage.CandidateStore) (*candidateIndex, error) {
+	idx := &candidateIndex{
+		byMint: make(map[string]*domain.TokenCandidate),
+		byPool: make(map[string]*domain.TokenCandidate),
+	}
+	for _, source := range []domain.Source{domain.SourceNewToken, domain.SourceActiveToken} {
+		candidates, err := store.GetBySource(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("get %s candidates: %w", source, err)
+		}
+		for _, c := range candidates {
+			indexEarliest(idx.byMint, c.Mint, c)
+			if c.Pool != nil {
+				indexEarliest(idx.byPool, *c.Pool, c)
+			}
+		}
+	}
+	return idx, nil
+}
+
+func indexEarliest(m map[string]*domain.TokenCandidate, key string, c *domain.TokenCandidate) {
+	if key == "" {
+		return
+	}
+	prev, ok := m[key]
+	if !ok || c.DiscoveredAt < prev.DiscoveredAt ||
+		(c.DiscoveredAt == prev.DiscoveredAt && c.CandidateID < prev.CandidateID) {
+		m[key] = c
+	}
+}
+
+// resolve returns the candidate ID for an event's mint, falling back to its pool,
+// or "" when neither belongs to a known candidate.
+func (idx *candidateIndex) resolve(mint, pool string) string {
+	if c, ok := idx.byMint[mint]; ok && mint != "" {
+		return c.CandidateID
+	}
+	if c, ok := idx.byPool[pool]; ok && pool != "" {
+		return c.CandidateID
+	}
+	return ""
+}
+
+// loadLinks indexes every pool link of store, so resolveEvent attributes the
+// pools of migrated tokens to their canonical candidate.
+func (idx *candidateIndex) loadLinks(ctx context.Context, store storage.PoolLinkStore) error {
+	links, err := store.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get pool links: %w", err)
+	}
+	idx.links = make(map[string]*domain.PoolLink, len(links))
+	for _, link := range links {
+		idx.links[link.Pool] = link
+	}
+	return nil
+}
+
+// resolveEvent returns the candidate ID for a liquidity event: the candidate its
+// pool is linked to at the event time, else resolve by mint and pool.
+func (idx *candidateIndex) resolveEvent(e *domain.LiquidityEvent) string {
+	if link, ok := idx.links[linkPool(e.DEX, e.Pool, e.Mint)]; ok && link.Covers(e.Timestamp) {
+		return link.CandidateID
+	}
+	return idx.resolve(e.Mint, e.Pool)
+}
@@ -0,0 +1,76 @@
+package ingestion
+
+import "time"
+
+// Defaults for the redelivery suppression window of the WS sources.
+const (
+	DefaultDedupTTL  = 2 * time.Minute
+	DefaultDedupSize = 10000
+)
+
+// notificationKey identifies a notification: its transaction on one program subscription.
+type notificationKey struct {
+	program   string
+	signature string
+}
+
+// recentNotifications remembers the notifications a WS source processed
+// recently, so exact redeliveries (around reconnects, or while two commitment
+// levels overlap) are dropped before the transaction is fetched and parsed.
+// Entries expire after ttl and the oldest are evicted beyond size; store
+// uniqueness remains the backstop for older redeliveries.
+// Not safe for concurrent use: each source checks it from its notification loop.
+type recentNotifications struct {
+	ttl   time.Duration
+	size  int
+	now   func() time.Time
+	seen  map[notificationKey]time.Time
+	order []notificationKey // insertion order, oldest first
+}
+
+// newRecentNotifications creates a window. A zero ttl or size disables it.
+func newRecentNotifications(ttl time.Duration, size int) *recentNotifications {
+	if ttl <= 0 || size <= 0 {
+		return nil
+	}
+	return &recentNotifications{
+		ttl:  ttl,
+		size: size,
+		now:  time.Now,
+		seen: make(map[notificationKey]time.Time),
+	}
+}
+
+// duplicate reports whether the notification of signature on program was seen
+// within the window, recording it otherwise. A nil window never reports duplicates.
+func (r *recentNotifications) duplicate(program, signature string) bool {
+	if r == nil || signature == "" {
+		return false
+	}
+	now := r.now()
+	r.evict(now)
+
+	key := notificationKey{program: program, signature: signature}
+	if _, ok := r.seen[key]; ok {
+		return true
+	}
+	r.seen[key] = now
+	r.order = append(r.order, key)
+	if len(r.order) > r.size {
+		delete(r.seen, r.order[0])
+		r.order = r.order[1:]
+	}
+	return false
+}
+
+// evict drops the entries older than ttl.
+func (r *recentNotifications) evict(now time.Time) {
+	for len(r.order) > 0 {
+		oldest := r.order[0]
+		if now.Sub(r.seen[oldest]) < r.ttl {
+			return
+		}
+		delete(r.seen, oldest)
+		r.order = r.order[1:]
+	}
+}
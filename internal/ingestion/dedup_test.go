@@ -0,0 +1,106 @@
+package ingestion
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestRecentNotifications_TTLAndSize(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := newRecentNotifications(time.Minute, 2)
+	r.now = func() time.Time { return now }
+
+	if r.duplicate("progA", "sig1") {
+		t.Fatal("first delivery reported as duplicate")
+	}
+	if !r.duplicate("progA", "sig1") {
+		t.Error("redelivery within the window not reported")
+	}
+	if r.duplicate("progB", "sig1") {
+		t.Error("same signature on another subscription reported as duplicate")
+	}
+
+	// Size bound: sig2 evicts the oldest entry (progA/sig1)
+	if r.duplicate("progA", "sig2") {
+		t.Fatal("first delivery of sig2 reported as duplicate")
+	}
+	if r.duplicate("progA", "sig1") {
+		t.Error("evicted entry reported as duplicate")
+	}
+
+	// TTL: everything expires after a minute
+	now = now.Add(time.Minute)
+	if r.duplicate("progA", "sig2") {
+		t.Error("expired entry reported as duplicate")
+	}
+
+	disabled := newRecentNotifications(0, 10)
+	if disabled.duplicate("progA", "sig1") || disabled.duplicate("progA", "sig1") {
+		t.Error("disabled window reported a duplicate")
+	}
+}
+
+func TestWSSwapEventSource_SuppressesRedelivery(t *testing.T) {
+	var fetches atomic.Int64
+	rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID int `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		fetches.Add(1)
+		result := map[string]interface{}{
+			"slot":        int64(100),
+			"blockTime":   int64(1700000500),
+			"meta":        map[string]interface{}{"err": nil},
+			"transaction": map[string]interface{}{"message": map[string]interface{}{"accountKeys": []string{}}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer rpcServer.Close()
+
+	ws := newFakeLogSubscriber()
+	swapEvents := memory.NewSwapEventStore()
+	candidateStore := memory.NewCandidateStore()
+	r := NewRunner(RunnerOptions{
+		WSSwapSource:     NewWSSwapEventSource(ws, solana.NewHTTPClient(rpcServer.URL), []string{"progA"}),
+		SwapEventStore:   swapEvents,
+		CandidateStore:   candidateStore,
+		NewTokenDetector: discovery.NewDetector(candidateStore).WithProgressStore(memory.NewDiscoveryProgressStore()),
+		SlotLagWindow:    1,
+		Logger:           log.New(io.Discard, "", 0),
+	})
+
+	_, stop := startRunner(t, r)
+	waitFor(t, "subscription", func() bool {
+		subscribed, _ := ws.calls()
+		return len(subscribed) == 1
+	})
+
+	notif := solana.LogNotification{Signature: "sigA1", Slot: 100, Logs: pumpFunBuyLogs("MintA1")}
+	ws.send(t, "progA", notif)
+	ws.send(t, "progA", notif)
+
+	waitFor(t, "suppression", func() bool {
+		stats := r.ProgramStats()
+		return len(stats) == 1 && stats[0].Events == 1 && stats[0].Duplicates == 1
+	})
+	stop()
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected one transaction fetch, got %d", n)
+	}
+	if stats := r.ProgramStats(); stats[0].Events != 1 || stats[0].Duplicates != 1 {
+		t.Errorf("expected 1 event and 1 suppressed duplicate, got %+v", stats[0])
+	}
+}
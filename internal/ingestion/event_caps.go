@@ -0,0 +1,243 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DefaultSampleEvery is the downsampling factor past a per-candidate event cap.
+const DefaultSampleEvery = 10
+
+// EventCaps bounds the events stored per candidate inside its observation window.
+// Up to a cap every event is stored; past it only 1 in SampleEvery, chosen by
+// arrival order so a replay of the same stream keeps the same events. Liquidity
+// removals are always stored. Events before discovery or after the window are
+// never capped. A redelivery rejected by the store as a duplicate is not
+// counted; one dropped past a cap is, so leave the WS sources' dedup window on
+// (see WSSwapEventSource.WithDedupWindow). Capping is disabled unless
+// ObservationWindowMs and a cap are set.
+type EventCaps struct {
+	MaxSwaps            int64 // swap events stored in full per candidate, 0 for no cap
+	MaxLiquidityEvents  int64 // liquidity events stored in full per candidate, 0 for no cap
+	SampleEvery         int64 // downsampling factor past a cap (default: DefaultSampleEvery)
+	ObservationWindowMs int64 // window after DiscoveredAt the caps apply to
+}
+
+// Enabled reports whether the caps apply.
+func (c EventCaps) Enabled() bool {
+	return c.ObservationWindowMs > 0 && (c.MaxSwaps > 0 || c.MaxLiquidityEvents > 0)
+}
+
+// candidateCap is the capping state of one candidate inside its window.
+type candidateCap struct {
+	candidate *domain.TokenCandidate
+	counts    domain.CandidateEventCounts
+	dirty     bool // counts changed since the last persist
+}
+
+// eventCapper enforces EventCaps for the runner and the backfiller and keeps
+// the true event totals of each candidate, persisted to store on every flush.
+// Not safe for concurrent use: the runner calls it from its event loop, the
+// backfiller from its store path.
+type eventCapper struct {
+	caps   EventCaps
+	store  storage.EventCountStore    // optional: counts are kept in memory only without it
+	byMint map[string][]*candidateCap // tracked candidates per mint
+}
+
+// newEventCapper creates a capper, or returns nil when caps are disabled.
+// A nil capper keeps every event.
+func newEventCapper(caps EventCaps, store storage.EventCountStore) *eventCapper {
+	if !caps.Enabled() {
+		return nil
+	}
+	if caps.SampleEvery <= 0 {
+		caps.SampleEvery = DefaultSampleEvery
+	}
+	return &eventCapper{
+		caps:   caps,
+		store:  store,
+		byMint: make(map[string][]*candidateCap),
+	}
+}
+
+// load tracks the candidates whose window is still open at now, e.g. after a restart.
+func (c *eventCapper) load(ctx context.Context, candidates storage.CandidateStore, now int64) error {
+	return c.loadRange(ctx, candidates, now, now)
+}
+
+// loadRange tracks the candidates whose window overlaps [from, to], e.g. before
+// a backfill stores the events of that range.
+func (c *eventCapper) loadRange(ctx context.Context, candidates storage.CandidateStore, from, to int64) error {
+	if c == nil || candidates == nil {
+		return nil
+	}
+	open, err := candidates.GetByTimeRange(ctx, from-c.caps.ObservationWindowMs, to)
+	if err != nil {
+		return fmt.Errorf("load candidates for event caps: %w", err)
+	}
+	for _, cand := range open {
+		if err := c.track(ctx, cand); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// track starts capping the events of a candidate, resuming from its stored counts.
+// Tracking a candidate twice is a no-op.
+func (c *eventCapper) track(ctx context.Context, cand *domain.TokenCandidate) error {
+	if c == nil {
+		return nil
+	}
+	for _, cc := range c.byMint[cand.Mint] {
+		if cc.candidate.CandidateID == cand.CandidateID {
+			return nil
+		}
+	}
+
+	cc := &candidateCap{
+		candidate: cand,
+		counts:    domain.CandidateEventCounts{CandidateID: cand.CandidateID},
+	}
+	if c.store != nil {
+		stored, err := c.store.GetByCandidateID(ctx, cand.CandidateID)
+		switch {
+		case err == nil:
+			cc.counts = *stored
+		case !errors.Is(err, storage.ErrNotFound):
+			return fmt.Errorf("load event counts of %s: %w", cand.CandidateID, err)
+		}
+	}
+	cc.counts.SampleEvery = c.caps.SampleEvery
+	c.byMint[cand.Mint] = append(c.byMint[cand.Mint], cc)
+	return nil
+}
+
+// inWindow returns the tracked candidates of mint whose window contains ts.
+func (c *eventCapper) inWindow(mint string, ts int64) []*candidateCap {
+	var result []*candidateCap
+	for _, cc := range c.byMint[mint] {
+		if ts >= cc.candidate.DiscoveredAt && ts <= cc.candidate.WindowEnd(c.caps.ObservationWindowMs) {
+			result = append(result, cc)
+		}
+	}
+	return result
+}
+
+// admitSwap counts a swap event against the candidates whose window contains it
+// and reports whether to store it. The event is kept while any of them is under
+// the cap or samples it. Pass the returned candidates to stored once the event
+// is stored, or to unseen if it turns out to be stored already.
+func (c *eventCapper) admitSwap(e *domain.SwapEvent) ([]*candidateCap, bool) {
+	if c == nil {
+		return nil, true
+	}
+	caps := c.inWindow(e.Mint, e.Timestamp)
+	keep := len(caps) == 0
+	for _, cc := range caps {
+		cc.counts.SwapsSeen++
+		cc.dirty = true
+		if c.keep(c.caps.MaxSwaps, cc.counts.SwapsSeen, cc.counts.SwapsStored) {
+			keep = true
+		}
+	}
+	return caps, keep
+}
+
+// admitLiquidity is admitSwap for liquidity events; removals are always kept.
+func (c *eventCapper) admitLiquidity(e *domain.LiquidityEvent) ([]*candidateCap, bool) {
+	if c == nil {
+		return nil, true
+	}
+	caps := c.inWindow(e.Mint, e.Timestamp)
+	keep := len(caps) == 0 || e.EventType == domain.LiquidityEventRemove
+	for _, cc := range caps {
+		cc.counts.LiquiditySeen++
+		cc.dirty = true
+		if c.keep(c.caps.MaxLiquidityEvents, cc.counts.LiquiditySeen, cc.counts.LiquidityStored) {
+			keep = true
+		}
+	}
+	return caps, keep
+}
+
+// keep reports whether the seen-th event of a candidate is stored: every event
+// while fewer than limit are stored, then the first overflow event and every
+// SampleEvery-th after it.
+func (c *eventCapper) keep(limit, seen, stored int64) bool {
+	if limit <= 0 || stored < limit {
+		return true
+	}
+	overflow := seen - limit
+	return overflow > 0 && (overflow-1)%c.caps.SampleEvery == 0
+}
+
+// stored records that an admitted event was stored.
+func (c *eventCapper) stored(caps []*candidateCap, swap bool) {
+	for _, cc := range caps {
+		if swap {
+			cc.counts.SwapsStored++
+		} else {
+			cc.counts.LiquidityStored++
+		}
+	}
+}
+
+// unstored reverts stored for an event the store rejected.
+func (c *eventCapper) unstored(caps []*candidateCap, swap bool) {
+	for _, cc := range caps {
+		if swap {
+			cc.counts.SwapsStored--
+		} else {
+			cc.counts.LiquidityStored--
+		}
+	}
+}
+
+// unseen reverts the admission of an event that was already stored and counted.
+func (c *eventCapper) unseen(caps []*candidateCap, swap bool) {
+	for _, cc := range caps {
+		if swap {
+			cc.counts.SwapsSeen--
+		} else {
+			cc.counts.LiquiditySeen--
+		}
+	}
+}
+
+// persist writes the changed counts to the store, then stops tracking candidates
+// whose window ended before eventTime.
+func (c *eventCapper) persist(ctx context.Context, eventTime, now int64) error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for mint, caps := range c.byMint {
+		kept := caps[:0]
+		for _, cc := range caps {
+			if cc.dirty && c.store != nil {
+				cc.counts.UpdatedAt = now
+				if err := c.store.Upsert(ctx, &cc.counts); err != nil {
+					errs = append(errs, fmt.Errorf("store event counts of %s: %w", cc.candidate.CandidateID, err))
+					kept = append(kept, cc)
+					continue
+				}
+			}
+			cc.dirty = false
+			if eventTime <= cc.candidate.WindowEnd(c.caps.ObservationWindowMs) {
+				kept = append(kept, cc)
+			}
+		}
+		if len(kept) == 0 {
+			delete(c.byMint, mint)
+		} else {
+			c.byMint[mint] = kept
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,199 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func newCappedRunner(swapStore *memory.SwapEventStore, liqStore *memory.LiquidityEventStore, counts *memory.EventCountStore) *Runner {
+	return NewRunner(RunnerOptions{
+		SwapEventStore:  swapStore,
+		LiquidityStore:  liqStore,
+		EventCaps:       EventCaps{MaxSwaps: 3, MaxLiquidityEvents: 2, SampleEvery: 2, ObservationWindowMs: 10000},
+		EventCountStore: counts,
+		Logger:          log.New(io.Discard, "", 0),
+	})
+}
+
+func TestRunner_EventCaps(t *testing.T) {
+	ctx := context.Background()
+	swapStore := memory.NewSwapEventStore()
+	liqStore := memory.NewLiquidityEventStore()
+	counts := memory.NewEventCountStore()
+	runner := newCappedRunner(swapStore, liqStore, counts)
+
+	cand := &domain.TokenCandidate{CandidateID: "cand-1", Mint: "mint1", DiscoveredAt: 1000}
+	runner.trackCandidate(ctx, cand)
+
+	swap := func(i int, ts int64) *domain.SwapEvent {
+		return &domain.SwapEvent{Mint: "mint1", TxSignature: fmt.Sprintf("tx%02d", i), Slot: int64(i), Timestamp: ts}
+	}
+
+	// Before discovery and after the window: stored and not counted
+	runner.handleSwapEvent(ctx, swap(0, 500))
+	runner.handleSwapEvent(ctx, swap(99, 20000))
+
+	// 10 swaps in the window: 3 under the cap, then every 2nd from the first overflow
+	for i := 1; i <= 10; i++ {
+		runner.handleSwapEvent(ctx, swap(i, 1000+int64(i)*100))
+		if i == 3 {
+			// A redelivered swap rejected by the store is not counted twice
+			runner.handleSwapEvent(ctx, swap(2, 1200))
+		}
+	}
+
+	events, err := swapStore.GetByMintTimeRange(ctx, "mint1", 0, 30000)
+	require.NoError(t, err)
+	var kept []string
+	for _, e := range events {
+		kept = append(kept, e.TxSignature)
+	}
+	assert.Equal(t, []string{"tx00", "tx01", "tx02", "tx03", "tx04", "tx06", "tx08", "tx10", "tx99"}, kept)
+
+	// Liquidity cap 2: removals are always kept, adds past the cap are sampled
+	types := []string{"add", "add", "add", "remove", "add", "add", "remove"}
+	for i, typ := range types {
+		runner.handleLiquidityEvent(ctx, &domain.LiquidityEvent{
+			CandidateID: "cand-1", Pool: "pool1", Mint: "mint1", EventType: typ,
+			TxSignature: fmt.Sprintf("liq%d", i+1), Slot: int64(i + 1), Timestamp: 1000 + int64(i+1)*100,
+		})
+	}
+	liq, err := liqStore.GetByCandidateID(ctx, "cand-1")
+	require.NoError(t, err)
+	var keptLiq []string
+	for _, e := range liq {
+		keptLiq = append(keptLiq, e.TxSignature)
+	}
+	assert.Equal(t, []string{"liq1", "liq2", "liq3", "liq4", "liq5", "liq7"}, keptLiq)
+
+	stats := runner.Stats()
+	assert.Equal(t, int64(3), stats.CappedSwapEvents)
+	assert.Equal(t, int64(1), stats.CappedLiquidityEvents)
+	assert.Equal(t, int64(1), stats.DuplicateSwapEvents)
+
+	runner.persistEventCounts(ctx)
+	got, err := counts.GetByCandidateID(ctx, "cand-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), got.SwapsSeen)
+	assert.Equal(t, int64(7), got.SwapsStored)
+	assert.Equal(t, int64(7), got.LiquiditySeen)
+	assert.Equal(t, int64(6), got.LiquidityStored)
+	assert.Equal(t, int64(2), got.SampleEvery)
+	assert.True(t, got.Downsampled())
+	assert.Equal(t, int64(4), got.DroppedEvents())
+}
+
+func TestRunner_EventCapsResumeFromStoredCounts(t *testing.T) {
+	ctx := context.Background()
+	swapStore := memory.NewSwapEventStore()
+	counts := memory.NewEventCountStore()
+	require.NoError(t, counts.Upsert(ctx, &domain.CandidateEventCounts{
+		CandidateID: "cand-1", SwapsSeen: 10, SwapsStored: 7, SampleEvery: 2,
+	}))
+
+	// After a restart the overflow sequence continues: the 11th swap is dropped, the 12th kept
+	runner := newCappedRunner(swapStore, memory.NewLiquidityEventStore(), counts)
+	runner.trackCandidate(ctx, &domain.TokenCandidate{CandidateID: "cand-1", Mint: "mint1", DiscoveredAt: 1000})
+	runner.handleSwapEvent(ctx, &domain.SwapEvent{Mint: "mint1", TxSignature: "tx11", Slot: 11, Timestamp: 2100})
+	runner.handleSwapEvent(ctx, &domain.SwapEvent{Mint: "mint1", TxSignature: "tx12", Slot: 12, Timestamp: 2200})
+
+	events, err := swapStore.GetByMintTimeRange(ctx, "mint1", 0, 30000)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "tx12", events[0].TxSignature)
+
+	runner.persistEventCounts(ctx)
+	got, err := counts.GetByCandidateID(ctx, "cand-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), got.SwapsSeen)
+	assert.Equal(t, int64(8), got.SwapsStored)
+}
+
+func TestEventCaps_Disabled(t *testing.T) {
+	assert.Nil(t, newEventCapper(EventCaps{MaxSwaps: 10}, nil), "no observation window")
+	assert.Nil(t, newEventCapper(EventCaps{ObservationWindowMs: 1000}, nil), "no cap")
+
+	c := newEventCapper(EventCaps{MaxSwaps: 10, ObservationWindowMs: 1000}, nil)
+	require.NotNil(t, c)
+	assert.Equal(t, int64(DefaultSampleEvery), c.caps.SampleEvery)
+}
+
+func TestBackfiller_EventCaps(t *testing.T) {
+	ctx := context.Background()
+	swapStore := memory.NewSwapEventStore()
+	liqStore := memory.NewLiquidityEventStore()
+	candidates := memory.NewCandidateStore()
+	counts := memory.NewEventCountStore()
+	require.NoError(t, candidates.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "cand-1", Source: domain.SourceNewToken, Mint: "mint1", DiscoveredAt: 1000,
+	}))
+
+	backfiller := NewBackfiller(BackfillOptions{
+		SwapEventStore:  swapStore,
+		LiquidityStore:  liqStore,
+		CandidateStore:  candidates,
+		EventCaps:       EventCaps{MaxSwaps: 3, MaxLiquidityEvents: 2, SampleEvery: 2, ObservationWindowMs: 10000},
+		EventCountStore: counts,
+		BatchSize:       4,
+		Logger:          log.New(io.Discard, "", 0),
+	})
+
+	swap := func(i int, ts int64) *domain.SwapEvent {
+		return &domain.SwapEvent{Mint: "mint1", TxSignature: fmt.Sprintf("tx%02d", i), Slot: int64(i), Timestamp: ts}
+	}
+	// Stored by the live feed already: rejected as a duplicate and not counted
+	require.NoError(t, swapStore.Insert(ctx, swap(2, 1200)))
+
+	// Before discovery and after the window: stored and not counted
+	swaps := []*domain.SwapEvent{swap(0, 500)}
+	for i := 1; i <= 10; i++ {
+		swaps = append(swaps, swap(i, 1000+int64(i)*100))
+	}
+	swaps = append(swaps, swap(99, 20000))
+
+	stored, dupes, capped, errs := backfiller.storeSwapEvents(ctx, swaps)
+	assert.Equal(t, 8, stored)
+	assert.Equal(t, 1, dupes)
+	assert.Equal(t, 3, capped)
+	assert.Zero(t, errs)
+
+	events, err := swapStore.GetByMintTimeRange(ctx, "mint1", 0, 30000)
+	require.NoError(t, err)
+	var kept []string
+	for _, e := range events {
+		kept = append(kept, e.TxSignature)
+	}
+	assert.Equal(t, []string{"tx00", "tx01", "tx02", "tx03", "tx04", "tx05", "tx07", "tx09", "tx99"}, kept)
+
+	// Liquidity cap 2: removals are always kept, adds past the cap are sampled
+	var liqs []*domain.LiquidityEvent
+	for i, typ := range []string{"add", "add", "add", "remove", "add", "add", "remove"} {
+		liqs = append(liqs, &domain.LiquidityEvent{
+			CandidateID: "cand-1", Pool: "pool1", Mint: "mint1", EventType: typ,
+			TxSignature: fmt.Sprintf("liq%d", i+1), Slot: int64(i + 1), Timestamp: 1000 + int64(i+1)*100,
+		})
+	}
+	stored, dupes, capped, errs = backfiller.storeLiquidityEvents(ctx, liqs)
+	assert.Equal(t, 6, stored)
+	assert.Zero(t, dupes)
+	assert.Equal(t, 1, capped)
+	assert.Zero(t, errs)
+
+	// Counts are persisted after each store pass
+	got, err := counts.GetByCandidateID(ctx, "cand-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), got.SwapsSeen)
+	assert.Equal(t, int64(6), got.SwapsStored)
+	assert.Equal(t, int64(7), got.LiquiditySeen)
+	assert.Equal(t, int64(6), got.LiquidityStored)
+	assert.True(t, got.Downsampled())
+}
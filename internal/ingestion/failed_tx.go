@@ -0,0 +1,156 @@
+package ingestion
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"solana-token-lab/internal/storage"
+)
+
+// Ingestion sources of the RPC failed transaction tallies. Live sources use
+// the name of their program feed ("ws-swap", "ws-liquidity").
+const (
+	failedTxSourceRPCSwap      = "rpc-swap"
+	failedTxSourceRPCLiquidity = "rpc-liquidity"
+	failedTxSourceRPCBlocks    = "rpc-blocks"
+)
+
+// failedTxKey identifies a pending hourly tally.
+type failedTxKey struct {
+	program   string
+	source    string
+	hourStart int64
+}
+
+// FailedTxTracker counts the transactions ingestion sources observe per
+// program and how many of them failed on chain. Failed transactions carry no
+// events and are skipped, so a surge of them (congestion, a broken pool) is
+// otherwise invisible. Counts are kept as totals for ProgramStats, passed to
+// an optional recorder for metrics and, with a store, tallied per hour for
+// the report's observed failure rate.
+//
+// Safe for concurrent use. A nil *FailedTxTracker ignores all updates.
+type FailedTxTracker struct {
+	store  storage.FailedTxStore // nil keeps the totals only
+	record func(source, program string, failed bool)
+	now    func() time.Time
+
+	mu       sync.Mutex
+	observed programCounter
+	failed   programCounter
+	pending  map[failedTxKey]*storage.FailedTxTally // not yet flushed to store
+}
+
+// NewFailedTxTracker creates a tracker tallying into store, which may be nil.
+func NewFailedTxTracker(store storage.FailedTxStore) *FailedTxTracker {
+	return &FailedTxTracker{
+		store:   store,
+		now:     time.Now,
+		pending: make(map[failedTxKey]*storage.FailedTxTally),
+	}
+}
+
+// WithRecorder calls record with every observed transaction, e.g. for metrics.
+func (t *FailedTxTracker) WithRecorder(record func(source, program string, failed bool)) *FailedTxTracker {
+	t.record = record
+	return t
+}
+
+// WithClock sets the clock that places live transactions, which arrive
+// without a block time, in their tally hour.
+func (t *FailedTxTracker) WithClock(now func() time.Time) *FailedTxTracker {
+	t.now = now
+	return t
+}
+
+// observe records a transaction of program seen by source. blockTime is in
+// Unix seconds; 0 places the transaction at the current time.
+func (t *FailedTxTracker) observe(source, program string, blockTime int64, failed bool) {
+	if t == nil {
+		return
+	}
+	t.observed.inc(program)
+	if failed {
+		t.failed.inc(program)
+	}
+	if t.record != nil {
+		t.record(source, program, failed)
+	}
+	if t.store == nil {
+		return
+	}
+
+	ts := blockTime * 1000
+	if blockTime == 0 {
+		ts = t.now().UnixMilli()
+	}
+	key := failedTxKey{program: program, source: source, hourStart: storage.FailedTxHour(ts)}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tally, ok := t.pending[key]
+	if !ok {
+		tally = &storage.FailedTxTally{Program: program, Source: source, HourStart: key.hourStart}
+		t.pending[key] = tally
+	}
+	tally.Transactions++
+	if failed {
+		tally.Failed++
+	}
+}
+
+// Counts returns the transactions observed and the failed ones per program,
+// across every source sharing the tracker.
+func (t *FailedTxTracker) Counts() (observed, failed map[string]int64) {
+	if t == nil {
+		return map[string]int64{}, map[string]int64{}
+	}
+	return t.observed.snapshot(), t.failed.snapshot()
+}
+
+// Flush adds the hourly tallies observed since the last flush to the store.
+// Tallies that fail to store are kept for the next flush. Without a store it
+// does nothing.
+func (t *FailedTxTracker) Flush(ctx context.Context) error {
+	if t == nil || t.store == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[failedTxKey]*storage.FailedTxTally)
+	t.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	tallies := make([]*storage.FailedTxTally, 0, len(pending))
+	for _, tally := range pending {
+		tallies = append(tallies, tally)
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		a, b := tallies[i], tallies[j]
+		if a.HourStart != b.HourStart {
+			return a.HourStart < b.HourStart
+		}
+		if a.Program != b.Program {
+			return a.Program < b.Program
+		}
+		return a.Source < b.Source
+	})
+	if err := t.store.Add(ctx, tallies); err != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for key, tally := range pending {
+			if current, ok := t.pending[key]; ok {
+				tally.Transactions += current.Transactions
+				tally.Failed += current.Failed
+			}
+			t.pending[key] = tally
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func failedSigInfo(signature string, blockTime interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"signature": signature,
+		"slot":      1,
+		"blockTime": blockTime,
+		"err":       map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}},
+	}
+}
+
+func TestSignatureScan_CountsFailedTransactions(t *testing.T) {
+	fake := &pagedRPC{
+		pages: map[string][]map[string]interface{}{
+			"": {
+				sigInfo("ok1", 4100),
+				failedSigInfo("bad1", 4000),
+				failedSigInfo("bad2", 3500),
+				failedSigInfo("bad3", nil), // no block time: cannot be placed in the window
+				sigInfo("ok2", 3100),
+				failedSigInfo("bad4", 2000), // before the window
+			},
+		},
+		blockTimes: map[string]int64{"ok1": 4100, "ok2": 3100},
+	}
+	server := fake.serve(t)
+	defer server.Close()
+
+	type call struct {
+		source, program string
+		failed          bool
+	}
+	var calls []call
+	store := memory.NewFailedTxStore()
+	tracker := NewFailedTxTracker(store).WithRecorder(func(source, program string, failed bool) {
+		calls = append(calls, call{source, program, failed})
+	})
+	progress := NewBackfillProgress(3000, 4200, 1, time.Now)
+
+	scan := &signatureScan{
+		rpc:       solana.NewHTTPClient(server.URL),
+		program:   discovery.PumpFun,
+		fromSec:   3000,
+		toSec:     4200,
+		overshoot: 60 * time.Second,
+		progress:  progress,
+		failedTx:  tracker,
+		source:    failedTxSourceRPCSwap,
+	}
+	if err := scan.run(context.Background(), func(*solana.Transaction, int64) error { return nil }); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	observed, failed := tracker.Counts()
+	if observed[discovery.PumpFun] != 4 || failed[discovery.PumpFun] != 2 {
+		t.Errorf("expected 4 observed and 2 failed, got %d and %d", observed[discovery.PumpFun], failed[discovery.PumpFun])
+	}
+	if len(calls) != 4 || calls[0] != (call{failedTxSourceRPCSwap, discovery.PumpFun, false}) {
+		t.Errorf("expected 4 recorder calls starting with ok1, got %+v", calls)
+	}
+	if snap := progress.Snapshot(); len(snap.Programs) != 1 || snap.Programs[0].FailedTransactions != 2 {
+		t.Errorf("expected 2 failed transactions in progress, got %+v", snap.Programs)
+	}
+
+	if err := tracker.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	got, err := store.GetRange(context.Background(), 0, 2*storage.FailedTxHourMs)
+	if err != nil {
+		t.Fatalf("GetRange: %v", err)
+	}
+	want := []*storage.FailedTxTally{
+		{Program: discovery.PumpFun, Source: failedTxSourceRPCSwap, HourStart: 0, Transactions: 2, Failed: 1},
+		{Program: discovery.PumpFun, Source: failedTxSourceRPCSwap, HourStart: storage.FailedTxHourMs, Transactions: 2, Failed: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected tallies %+v, got %+v", want, got)
+	}
+}
+
+// failingFailedTxStore rejects the first Add and accumulates afterwards.
+type failingFailedTxStore struct {
+	*memory.FailedTxStore
+	failures int
+}
+
+func (s *failingFailedTxStore) Add(ctx context.Context, tallies []*storage.FailedTxTally) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("store unavailable")
+	}
+	return s.FailedTxStore.Add(ctx, tallies)
+}
+
+func TestFailedTxTracker_FlushKeepsTalliesOnError(t *testing.T) {
+	ctx := context.Background()
+	store := &failingFailedTxStore{FailedTxStore: memory.NewFailedTxStore(), failures: 1}
+	now := time.UnixMilli(storage.FailedTxHourMs + 1234)
+	tracker := NewFailedTxTracker(store).WithClock(func() time.Time { return now })
+
+	tracker.observe("ws-swap", "progA", 0, true)
+	tracker.observe("ws-swap", "progA", 0, false)
+	if err := tracker.Flush(ctx); err == nil {
+		t.Fatal("expected Flush to fail")
+	}
+
+	tracker.observe("ws-swap", "progA", 0, false)
+	if err := tracker.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	// Nothing pending: a further flush stores nothing twice
+	if err := tracker.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	got, err := store.GetRange(ctx, 0, 2*storage.FailedTxHourMs)
+	if err != nil {
+		t.Fatalf("GetRange: %v", err)
+	}
+	want := []*storage.FailedTxTally{
+		{Program: "progA", Source: "ws-swap", HourStart: storage.FailedTxHourMs, Transactions: 3, Failed: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected tallies %+v, got %+v", want, got)
+	}
+}
+
+func TestFailedTxTracker_NilSafe(t *testing.T) {
+	var tracker *FailedTxTracker
+	tracker.observe("ws-swap", "progA", 0, true)
+	if err := tracker.Flush(context.Background()); err != nil {
+		t.Errorf("Flush: %v", err)
+	}
+	if observed, failed := tracker.Counts(); len(observed) != 0 || len(failed) != 0 {
+		t.Errorf("expected empty counts, got %v and %v", observed, failed)
+	}
+}
+
+func TestRunner_ProgramStatsFailedTransactions(t *testing.T) {
+	rpcServer := fakeTransactionRPC(t)
+	defer rpcServer.Close()
+
+	ws := newFakeLogSubscriber()
+	candidateStore := memory.NewCandidateStore()
+	tracker := NewFailedTxTracker(nil)
+	r := NewRunner(RunnerOptions{
+		WSSwapSource: NewWSSwapEventSource(ws, solana.NewHTTPClient(rpcServer.URL), []string{"progA"}).
+			WithFailedTxTracker(tracker),
+		SwapEventStore:   memory.NewSwapEventStore(),
+		CandidateStore:   candidateStore,
+		NewTokenDetector: discovery.NewDetector(candidateStore).WithProgressStore(memory.NewDiscoveryProgressStore()),
+		FailedTx:         tracker,
+		SlotLagWindow:    1,
+		Logger:           log.New(io.Discard, "", 0),
+	})
+
+	_, stop := startRunner(t, r)
+	waitFor(t, "subscription", func() bool {
+		subscribed, _ := ws.calls()
+		return len(subscribed) == 1
+	})
+
+	failedNotif := solana.LogNotification{Signature: "sigF1", Slot: 100, Err: "InstructionError", Logs: pumpFunBuyLogs("MintF1")}
+	ws.send(t, "progA", failedNotif)
+	ws.send(t, "progA", failedNotif) // redelivery is not counted twice
+	ws.send(t, "progA", solana.LogNotification{Signature: "sigA1", Slot: 101, Logs: pumpFunBuyLogs("MintA1")})
+
+	waitFor(t, "event", func() bool {
+		stats := r.ProgramStats()
+		return len(stats) == 1 && stats[0].Events == 1
+	})
+	stop()
+
+	stats := r.ProgramStats()
+	if stats[0].Transactions != 2 || stats[0].FailedTxs != 1 {
+		t.Errorf("expected 2 transactions and 1 failed, got %+v", stats[0])
+	}
+}
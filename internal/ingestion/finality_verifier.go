@@ -0,0 +1,185 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+)
+
+// SignatureStatusSource reports transaction statuses.
+// Implemented by *solana.HTTPClient.
+type SignatureStatusSource interface {
+	GetSignatureStatuses(ctx context.Context, signatures []string) ([]*solana.SignatureStatus, error)
+}
+
+// FinalityVerifier periodically checks recently ingested transactions at finalized
+// commitment. Events are ingested at confirmed commitment, so a skipped or forked
+// slot can leave events whose transactions never finalize; those are removed and
+// audited through the FinalityStore.
+type FinalityVerifier struct {
+	source    SignatureStatusSource
+	store     storage.FinalityStore
+	interval  time.Duration
+	lookback  time.Duration
+	minAge    time.Duration
+	batchSize int
+	clock     func() time.Time
+	logger    *log.Logger
+}
+
+// FinalityVerifierOptions contains configuration for creating a FinalityVerifier.
+type FinalityVerifierOptions struct {
+	Source   SignatureStatusSource
+	Store    storage.FinalityStore
+	Interval time.Duration // Default: 1m - time between verification passes
+	Lookback time.Duration // Default: 30m - only events newer than this are checked
+	// MinAge is how old an event must be before a missing transaction counts as dropped.
+	// Finalization normally lags confirmation by ~13s. Default: 1m.
+	MinAge    time.Duration
+	BatchSize int              // Default: solana.MaxSignatureStatuses - signatures per RPC call
+	Clock     func() time.Time // Default: time.Now
+	Logger    *log.Logger
+}
+
+// FinalityResult summarizes one verification pass.
+type FinalityResult struct {
+	Checked       int // signatures looked up
+	Finalized     int // signatures confirmed at finalized commitment
+	Dropped       int // signatures unknown to the chain, their events removed
+	EventsRemoved int // events deleted (one correction each)
+	Pending       int // signatures seen but not yet finalized, checked again next pass
+}
+
+// NewFinalityVerifier creates a new finality verifier.
+func NewFinalityVerifier(opts FinalityVerifierOptions) *FinalityVerifier {
+	interval := opts.Interval
+	if interval == 0 {
+		interval = 1 * time.Minute
+	}
+
+	lookback := opts.Lookback
+	if lookback == 0 {
+		lookback = 30 * time.Minute
+	}
+
+	minAge := opts.MinAge
+	if minAge == 0 {
+		minAge = 1 * time.Minute
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > solana.MaxSignatureStatuses {
+		batchSize = solana.MaxSignatureStatuses
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &FinalityVerifier{
+		source:    opts.Source,
+		store:     opts.Store,
+		interval:  interval,
+		lookback:  lookback,
+		minAge:    minAge,
+		batchSize: batchSize,
+		clock:     clock,
+		logger:    logger,
+	}
+}
+
+// Run performs a verification pass immediately and then every interval.
+// It blocks until context is cancelled.
+func (v *FinalityVerifier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := v.RunOnce(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			v.logger.Printf("Finality verification error: %v", err)
+		} else {
+			v.logger.Printf("Finality verification: %d checked, %d finalized, %d dropped (%d events removed), %d pending",
+				res.Checked, res.Finalized, res.Dropped, res.EventsRemoved, res.Pending)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce checks every unverified signature with events in [now-Lookback, now-MinAge).
+// Younger events are left for a later pass, since their transactions may simply not
+// have finalized yet. RPC and store failures abort the pass; finished batches are kept.
+func (v *FinalityVerifier) RunOnce(ctx context.Context) (*FinalityResult, error) {
+	now := v.clock()
+	nowMs := now.UnixMilli()
+
+	pending, err := v.store.GetUnverified(ctx, nowMs-v.lookback.Milliseconds(), nowMs-v.minAge.Milliseconds(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("get unverified signatures: %w", err)
+	}
+
+	res := &FinalityResult{}
+	for start := 0; start < len(pending); start += v.batchSize {
+		end := start + v.batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		signatures := make([]string, 0, end-start)
+		for _, p := range pending[start:end] {
+			signatures = append(signatures, p.TxSignature)
+		}
+
+		statuses, err := v.source.GetSignatureStatuses(ctx, signatures)
+		if err != nil {
+			return res, fmt.Errorf("get signature statuses: %w", err)
+		}
+		if len(statuses) != len(signatures) {
+			return res, fmt.Errorf("get signature statuses: %d statuses for %d signatures", len(statuses), len(signatures))
+		}
+		res.Checked += len(signatures)
+
+		var finalized []string
+		for i, status := range statuses {
+			switch {
+			case status == nil:
+				corrections, err := v.store.RemoveDropped(ctx, signatures[i], nowMs)
+				if err != nil {
+					return res, fmt.Errorf("remove dropped transaction %s: %w", signatures[i], err)
+				}
+				v.logger.Printf("Finality verification: transaction %s (slot %d) never finalized, removed %d events",
+					signatures[i], pending[start+i].Slot, len(corrections))
+				res.Dropped++
+				res.EventsRemoved += len(corrections)
+			case status.Finalized():
+				finalized = append(finalized, signatures[i])
+			default:
+				res.Pending++
+			}
+		}
+
+		if err := v.store.MarkFinalized(ctx, finalized, nowMs); err != nil {
+			return res, fmt.Errorf("mark finalized: %w", err)
+		}
+		res.Finalized += len(finalized)
+	}
+
+	return res, nil
+}
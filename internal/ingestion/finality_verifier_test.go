@@ -0,0 +1,139 @@
+package ingestion
+
+import (
+	"context"
+	"io"
+	"log"
+	"math"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// fakeStatusSource answers getSignatureStatuses from a fixed map; unknown signatures are nil.
+type fakeStatusSource struct {
+	statuses map[string]*solana.SignatureStatus
+	batches  [][]string
+}
+
+func (f *fakeStatusSource) GetSignatureStatuses(_ context.Context, signatures []string) ([]*solana.SignatureStatus, error) {
+	f.batches = append(f.batches, signatures)
+	result := make([]*solana.SignatureStatus, len(signatures))
+	for i, sig := range signatures {
+		result[i] = f.statuses[sig]
+	}
+	return result, nil
+}
+
+func TestFinalityVerifier_RemovesDroppedTransaction(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(10_000_000)
+	nowMs := now.UnixMilli()
+
+	swaps := memory.NewSwapStore()
+	swapEvents := memory.NewSwapEventStore()
+	liquidity := memory.NewLiquidityEventStore()
+	store := memory.NewFinalityStore(swaps, swapEvents, liquidity)
+
+	swap := func(sig string, ts int64) *domain.Swap {
+		return &domain.Swap{CandidateID: "c1", TxSignature: sig, Slot: ts / 400, Timestamp: ts, Side: domain.SwapSideBuy, AmountIn: 1, AmountOut: 10, Price: 0.1}
+	}
+	old := nowMs - 5*time.Minute.Milliseconds()
+	if err := swaps.InsertBulk(ctx, []*domain.Swap{
+		swap("final", old),
+		swap("phantom", old+1000),
+		swap("confirmed", old+2000),
+		swap("fresh", nowMs-10*time.Second.Milliseconds()), // younger than MinAge
+		swap("ancient", nowMs-2*time.Hour.Milliseconds()),  // outside Lookback
+	}); err != nil {
+		t.Fatalf("insert swaps: %v", err)
+	}
+	if err := swapEvents.Insert(ctx, &domain.SwapEvent{Mint: "mintA", TxSignature: "phantom", Slot: 1, Timestamp: old + 1000, AmountOut: 10}); err != nil {
+		t.Fatalf("insert swap event: %v", err)
+	}
+
+	// "phantom" was confirmed but its slot was skipped: unknown at finalized level
+	source := &fakeStatusSource{statuses: map[string]*solana.SignatureStatus{
+		"final":     {Slot: 1, ConfirmationStatus: solana.CommitmentFinalized},
+		"confirmed": {Slot: 2, ConfirmationStatus: solana.CommitmentConfirmed},
+		"fresh":     {Slot: 3, ConfirmationStatus: solana.CommitmentFinalized},
+	}}
+
+	verifier := NewFinalityVerifier(FinalityVerifierOptions{
+		Source:    source,
+		Store:     store,
+		BatchSize: 2,
+		Clock:     func() time.Time { return now },
+		Logger:    log.New(io.Discard, "", 0),
+	})
+
+	res, err := verifier.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+
+	want := FinalityResult{Checked: 3, Finalized: 1, Dropped: 1, EventsRemoved: 2, Pending: 1}
+	if *res != want {
+		t.Errorf("result = %+v, want %+v", *res, want)
+	}
+	if len(source.batches) != 2 {
+		t.Errorf("expected 2 RPC batches of at most 2 signatures, got %v", source.batches)
+	}
+
+	// Phantom swap and swap event are gone; everything else stays
+	remaining, err := swaps.GetByCandidateID(ctx, "c1")
+	if err != nil {
+		t.Fatalf("GetByCandidateID: %v", err)
+	}
+	for _, s := range remaining {
+		if s.TxSignature == "phantom" {
+			t.Error("phantom swap still stored")
+		}
+	}
+	if len(remaining) != 4 {
+		t.Errorf("expected 4 remaining swaps, got %d", len(remaining))
+	}
+	events, err := swapEvents.GetByTimeRange(ctx, 0, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("GetByTimeRange: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected phantom swap event removed, got %d events", len(events))
+	}
+
+	// Audit rows record what was removed
+	audit, err := store.GetCorrections(ctx, nowMs, nowMs+1)
+	if err != nil {
+		t.Fatalf("GetCorrections: %v", err)
+	}
+	if len(audit) != 2 {
+		t.Fatalf("expected 2 audit rows, got %d", len(audit))
+	}
+	if audit[0].EventTable != storage.EventTableSwapEvents || audit[1].EventTable != storage.EventTableSwaps ||
+		audit[1].TxSignature != "phantom" || audit[1].CandidateID != "c1" || audit[1].DetectedAt != nowMs {
+		t.Errorf("unexpected audit rows: %+v, %+v", *audit[0], *audit[1])
+	}
+
+	// confirmed, fresh and ancient are still unverified
+	count, err := store.CountUnverified(ctx)
+	if err != nil {
+		t.Fatalf("CountUnverified: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 unverified events, got %d", count)
+	}
+
+	// A second pass only re-checks the still-confirmed transaction
+	source.batches = nil
+	res, err = verifier.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("second RunOnce: %v", err)
+	}
+	if res.Checked != 1 || res.Pending != 1 {
+		t.Errorf("second pass = %+v, want 1 checked, 1 pending", *res)
+	}
+}
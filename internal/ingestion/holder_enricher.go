@@ -0,0 +1,306 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+)
+
+// TokenHolderSource provides holder distribution data for a mint.
+// Implemented by *solana.HTTPClient.
+type TokenHolderSource interface {
+	GetTokenLargestAccounts(ctx context.Context, mint string) ([]solana.TokenAccountBalance, error)
+	GetTokenSupply(ctx context.Context, mint string) (*solana.TokenAmount, error)
+}
+
+// HolderEnricher periodically snapshots holder concentration for recently active candidates.
+type HolderEnricher struct {
+	source          TokenHolderSource
+	candidateStore  storage.CandidateStore
+	swapEventStore  storage.SwapEventStore
+	snapshotStore   storage.TokenHolderSnapshotStore
+	watchlist       storage.WatchlistStore
+	interval        time.Duration
+	activeWindow    time.Duration
+	minMintInterval time.Duration
+	clock           func() time.Time
+	logger          *log.Logger
+}
+
+// HolderEnricherOptions contains configuration for creating a HolderEnricher.
+type HolderEnricherOptions struct {
+	Source         TokenHolderSource
+	CandidateStore storage.CandidateStore
+	SwapEventStore storage.SwapEventStore // optional: adds candidates with recent swaps
+	SnapshotStore  storage.TokenHolderSnapshotStore
+	Watchlist      storage.WatchlistStore // optional: watchlisted mints are snapshotted regardless of ActiveWindow
+	Interval       time.Duration          // Default: 1h - time between enrichment passes
+	ActiveWindow   time.Duration          // Default: 24h - only candidates discovered or traded within this window
+	// MinMintInterval is the minimum delay between mints (two RPC calls each).
+	// Default: 500ms.
+	MinMintInterval time.Duration
+	Clock           func() time.Time // Default: time.Now
+	Logger          *log.Logger
+}
+
+// NewHolderEnricher creates a new holder enricher.
+func NewHolderEnricher(opts HolderEnricherOptions) *HolderEnricher {
+	interval := opts.Interval
+	if interval == 0 {
+		interval = 1 * time.Hour
+	}
+
+	activeWindow := opts.ActiveWindow
+	if activeWindow == 0 {
+		activeWindow = 24 * time.Hour
+	}
+
+	minMintInterval := opts.MinMintInterval
+	if minMintInterval == 0 {
+		minMintInterval = 500 * time.Millisecond
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &HolderEnricher{
+		source:          opts.Source,
+		candidateStore:  opts.CandidateStore,
+		swapEventStore:  opts.SwapEventStore,
+		snapshotStore:   opts.SnapshotStore,
+		watchlist:       opts.Watchlist,
+		interval:        interval,
+		activeWindow:    activeWindow,
+		minMintInterval: minMintInterval,
+		clock:           clock,
+		logger:          logger,
+	}
+}
+
+// Run performs an enrichment pass immediately and then every interval.
+// It blocks until context is cancelled.
+func (e *HolderEnricher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := e.RunOnce(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			e.logger.Printf("Holder enrichment error: %v", err)
+		} else {
+			e.logger.Printf("Holder enrichment stored %d snapshots", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce snapshots every active candidate once and returns the number of snapshots stored.
+// Per-mint RPC failures are logged and skipped; store failures abort the pass.
+func (e *HolderEnricher) RunOnce(ctx context.Context) (int, error) {
+	now := e.clock()
+	nowMs := now.UnixMilli()
+
+	byMint, err := e.activeCandidates(ctx, nowMs-e.activeWindow.Milliseconds(), nowMs)
+	if err != nil {
+		return 0, err
+	}
+
+	mints := make([]string, 0, len(byMint))
+	for mint := range byMint {
+		mints = append(mints, mint)
+	}
+	sort.Strings(mints)
+
+	stored := 0
+	for i, mint := range mints {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return stored, ctx.Err()
+			case <-time.After(e.minMintInterval):
+			}
+		}
+
+		accounts, err := e.source.GetTokenLargestAccounts(ctx, mint)
+		if err != nil {
+			e.logger.Printf("Holder enrichment: largest accounts for %s: %v", mint, err)
+			continue
+		}
+		supply, err := e.source.GetTokenSupply(ctx, mint)
+		if err != nil {
+			e.logger.Printf("Holder enrichment: supply for %s: %v", mint, err)
+			continue
+		}
+
+		base, err := ComputeHolderSnapshot(accounts, supply)
+		if err != nil {
+			e.logger.Printf("Holder enrichment: %s: %v", mint, err)
+			continue
+		}
+
+		for _, candidateID := range byMint[mint] {
+			snap := *base
+			snap.CandidateID = candidateID
+			snap.Mint = mint
+			snap.SnapshotAt = nowMs
+
+			if err := e.snapshotStore.Insert(ctx, &snap); err != nil {
+				if errors.Is(err, storage.ErrDuplicateKey) {
+					continue
+				}
+				return stored, fmt.Errorf("store holder snapshot for %s: %w", candidateID, err)
+			}
+			stored++
+		}
+	}
+
+	return stored, nil
+}
+
+// activeCandidates returns candidate IDs grouped by mint for candidates discovered
+// in [start, end], whose mint has swap events in [start, end), or whose mint is watchlisted.
+// Candidate IDs within a mint are sorted for deterministic snapshot order.
+func (e *HolderEnricher) activeCandidates(ctx context.Context, start, end int64) (map[string][]string, error) {
+	seen := make(map[string]bool)
+	byMint := make(map[string][]string)
+
+	add := func(c *domain.TokenCandidate) {
+		if seen[c.CandidateID] {
+			return
+		}
+		seen[c.CandidateID] = true
+		byMint[c.Mint] = append(byMint[c.Mint], c.CandidateID)
+	}
+
+	discovered, err := e.candidateStore.GetByTimeRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("get recently discovered candidates: %w", err)
+	}
+	for _, c := range discovered {
+		add(c)
+	}
+
+	if e.swapEventStore != nil {
+		mints, err := e.swapEventStore.GetDistinctMintsByTimeRange(ctx, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("get recently traded mints: %w", err)
+		}
+		for _, mint := range mints {
+			candidates, err := e.candidateStore.GetByMint(ctx, mint)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return nil, fmt.Errorf("get candidates for mint %s: %w", mint, err)
+			}
+			for _, c := range candidates {
+				add(c)
+			}
+		}
+	}
+
+	if e.watchlist != nil {
+		entries, err := e.watchlist.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list watchlist: %w", err)
+		}
+		for _, entry := range entries {
+			candidates, err := e.candidateStore.GetByMint(ctx, entry.Mint)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return nil, fmt.Errorf("get candidates for watchlisted mint %s: %w", entry.Mint, err)
+			}
+			for _, c := range candidates {
+				add(c)
+			}
+		}
+	}
+
+	for mint := range byMint {
+		sort.Strings(byMint[mint])
+	}
+
+	return byMint, nil
+}
+
+// topHolderCount is the number of largest accounts summed for concentration.
+const topHolderCount = 10
+
+// ComputeHolderSnapshot derives holder concentration from the largest token accounts
+// and total supply. CandidateID, Mint and SnapshotAt are left for the caller.
+//
+// HolderCount counts non-empty accounts in the list, so it is a lower bound
+// capped by the RPC page size (20). Top10Pct is computed on raw amounts to avoid
+// decimal rounding; it is 0 when supply is 0.
+func ComputeHolderSnapshot(accounts []solana.TokenAccountBalance, supply *solana.TokenAmount) (*domain.TokenHolderSnapshot, error) {
+	if supply == nil {
+		return nil, fmt.Errorf("missing token supply")
+	}
+
+	supplyRaw, err := strconv.ParseUint(supply.Amount, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse supply %q: %w", supply.Amount, err)
+	}
+
+	type holder struct {
+		address string
+		amount  uint64
+	}
+	holders := make([]holder, 0, len(accounts))
+	for _, acc := range accounts {
+		amount, err := strconv.ParseUint(acc.Amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse amount %q for %s: %w", acc.Amount, acc.Address, err)
+		}
+		if amount == 0 {
+			continue
+		}
+		holders = append(holders, holder{address: acc.Address, amount: amount})
+	}
+
+	// RPC returns largest first; sort anyway so ties are deterministic
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].amount != holders[j].amount {
+			return holders[i].amount > holders[j].amount
+		}
+		return holders[i].address < holders[j].address
+	})
+
+	snap := &domain.TokenHolderSnapshot{
+		HolderCount: len(holders),
+		TotalSupply: float64(supplyRaw) / math.Pow(10, float64(supply.Decimals)),
+	}
+	if len(holders) > 0 {
+		snap.LargestHolder = holders[0].address
+	}
+
+	if supplyRaw > 0 {
+		var top float64
+		for i := 0; i < len(holders) && i < topHolderCount; i++ {
+			top += float64(holders[i].amount)
+		}
+		snap.Top10Pct = top / float64(supplyRaw) * 100
+	}
+
+	return snap, nil
+}
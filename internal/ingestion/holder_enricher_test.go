@@ -0,0 +1,194 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// fakeHolderSource returns crafted account lists per mint.
+type fakeHolderSource struct {
+	accounts map[string][]solana.TokenAccountBalance
+	supply   map[string]*solana.TokenAmount
+	calls    []string
+}
+
+func (f *fakeHolderSource) GetTokenLargestAccounts(_ context.Context, mint string) ([]solana.TokenAccountBalance, error) {
+	f.calls = append(f.calls, mint)
+	accounts, ok := f.accounts[mint]
+	if !ok {
+		return nil, errors.New("account lookup failed")
+	}
+	return accounts, nil
+}
+
+func (f *fakeHolderSource) GetTokenSupply(_ context.Context, mint string) (*solana.TokenAmount, error) {
+	return f.supply[mint], nil
+}
+
+func balance(address, amount string) solana.TokenAccountBalance {
+	return solana.TokenAccountBalance{Address: address, TokenAmount: solana.TokenAmount{Amount: amount, Decimals: 6}}
+}
+
+func TestComputeHolderSnapshot_Concentration(t *testing.T) {
+	// 12 holders holding 10..120, plus one empty account
+	var accounts []solana.TokenAccountBalance
+	for i := 12; i >= 1; i-- {
+		accounts = append(accounts, balance("acct"+string(rune('A'+i)), strconv.Itoa(i*10)))
+	}
+	accounts = append(accounts, balance("empty", "0"))
+
+	snap, err := ComputeHolderSnapshot(accounts, &solana.TokenAmount{Amount: "1000", Decimals: 1})
+	if err != nil {
+		t.Fatalf("ComputeHolderSnapshot failed: %v", err)
+	}
+
+	if snap.HolderCount != 12 {
+		t.Errorf("expected 12 holders (empty account excluded), got %d", snap.HolderCount)
+	}
+	// Top 10 = 120+110+...+30 = 750
+	if math.Abs(snap.Top10Pct-75.0) > 1e-9 {
+		t.Errorf("expected top10 75%%, got %f", snap.Top10Pct)
+	}
+	if snap.TotalSupply != 100.0 {
+		t.Errorf("expected total supply 100.0, got %f", snap.TotalSupply)
+	}
+	if snap.LargestHolder != "acct"+string(rune('A'+12)) {
+		t.Errorf("unexpected largest holder %s", snap.LargestHolder)
+	}
+}
+
+func TestComputeHolderSnapshot_FewHoldersAndZeroSupply(t *testing.T) {
+	accounts := []solana.TokenAccountBalance{balance("b", "250"), balance("a", "250")}
+
+	snap, err := ComputeHolderSnapshot(accounts, &solana.TokenAmount{Amount: "1000", Decimals: 0})
+	if err != nil {
+		t.Fatalf("ComputeHolderSnapshot failed: %v", err)
+	}
+	if snap.HolderCount != 2 || snap.Top10Pct != 50.0 {
+		t.Errorf("expected 2 holders at 50%%, got %d at %f", snap.HolderCount, snap.Top10Pct)
+	}
+	// Ties broken by address
+	if snap.LargestHolder != "a" {
+		t.Errorf("expected largest holder a, got %s", snap.LargestHolder)
+	}
+
+	snap, err = ComputeHolderSnapshot(nil, &solana.TokenAmount{Amount: "0", Decimals: 6})
+	if err != nil {
+		t.Fatalf("ComputeHolderSnapshot failed: %v", err)
+	}
+	if snap.HolderCount != 0 || snap.Top10Pct != 0 || snap.LargestHolder != "" {
+		t.Errorf("expected empty snapshot, got %+v", snap)
+	}
+
+	if _, err := ComputeHolderSnapshot(accounts, &solana.TokenAmount{Amount: "not-a-number"}); err == nil {
+		t.Error("expected error for malformed supply")
+	}
+}
+
+func TestHolderEnricher_RunOnce_ActiveCandidatesOnly(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(100 * 3600000)
+	nowMs := now.UnixMilli()
+
+	candidateStore := memory.NewCandidateStore()
+	swapEventStore := memory.NewSwapEventStore()
+	snapshotStore := memory.NewTokenHolderSnapshotStore()
+
+	// recent: discovered within window; traded: old but swapped recently; stale: neither
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "recent", Source: domain.SourceNewToken, Mint: "mintRecent", TxSignature: "tx1", DiscoveredAt: nowMs - 3600000})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "traded", Source: domain.SourceNewToken, Mint: "mintTraded", TxSignature: "tx2", DiscoveredAt: nowMs - 72*3600000})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "stale", Source: domain.SourceNewToken, Mint: "mintStale", TxSignature: "tx3", DiscoveredAt: nowMs - 72*3600000})
+	_ = swapEventStore.Insert(ctx, &domain.SwapEvent{Mint: "mintTraded", TxSignature: "swap1", Slot: 1, Timestamp: nowMs - 60000})
+	_ = swapEventStore.Insert(ctx, &domain.SwapEvent{Mint: "mintStale", TxSignature: "swap2", Slot: 2, Timestamp: nowMs - 48*3600000})
+
+	source := &fakeHolderSource{
+		accounts: map[string][]solana.TokenAccountBalance{
+			"mintRecent": {balance("r1", "600"), balance("r2", "400")},
+			"mintTraded": {balance("t1", "100")},
+			"mintStale":  {balance("s1", "100")},
+		},
+		supply: map[string]*solana.TokenAmount{
+			"mintRecent": {Amount: "1000", Decimals: 6},
+			"mintTraded": {Amount: "400", Decimals: 6},
+			"mintStale":  {Amount: "100", Decimals: 6},
+		},
+	}
+
+	enricher := NewHolderEnricher(HolderEnricherOptions{
+		Source:          source,
+		CandidateStore:  candidateStore,
+		SwapEventStore:  swapEventStore,
+		SnapshotStore:   snapshotStore,
+		ActiveWindow:    24 * time.Hour,
+		MinMintInterval: time.Nanosecond,
+		Clock:           func() time.Time { return now },
+	})
+
+	n, err := enricher.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 snapshots, got %d", n)
+	}
+	if len(source.calls) != 2 || source.calls[0] != "mintRecent" || source.calls[1] != "mintTraded" {
+		t.Errorf("expected RPC calls for mintRecent and mintTraded only, got %v", source.calls)
+	}
+
+	snap, err := snapshotStore.GetLatest(ctx, "traded")
+	if err != nil {
+		t.Fatalf("GetLatest failed: %v", err)
+	}
+	if snap.Top10Pct != 25.0 || snap.HolderCount != 1 || snap.SnapshotAt != nowMs || snap.Mint != "mintTraded" {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+
+	if _, err := snapshotStore.GetLatest(ctx, "stale"); err == nil {
+		t.Error("expected no snapshot for inactive candidate")
+	}
+}
+
+func TestHolderEnricher_RunOnce_SkipsFailedMint(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(100 * 3600000)
+
+	candidateStore := memory.NewCandidateStore()
+	snapshotStore := memory.NewTokenHolderSnapshotStore()
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "a", Source: domain.SourceNewToken, Mint: "mintA", TxSignature: "tx1", DiscoveredAt: now.UnixMilli()})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "b", Source: domain.SourceNewToken, Mint: "mintB", TxSignature: "tx2", DiscoveredAt: now.UnixMilli()})
+
+	source := &fakeHolderSource{
+		accounts: map[string][]solana.TokenAccountBalance{"mintB": {balance("b1", "10")}},
+		supply:   map[string]*solana.TokenAmount{"mintB": {Amount: "10", Decimals: 0}},
+	}
+
+	enricher := NewHolderEnricher(HolderEnricherOptions{
+		Source:          source,
+		CandidateStore:  candidateStore,
+		SnapshotStore:   snapshotStore,
+		MinMintInterval: time.Nanosecond,
+		Clock:           func() time.Time { return now },
+	})
+
+	n, err := enricher.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 snapshot (mintA failed), got %d", n)
+	}
+
+	// Same clock: re-running is idempotent
+	n, err = enricher.RunOnce(ctx)
+	if err != nil || n != 0 {
+		t.Errorf("expected duplicate snapshot to be skipped, got n=%d err=%v", n, err)
+	}
+}
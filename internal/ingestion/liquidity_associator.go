@@ -0,0 +1,122 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"solana-token-lab/internal/storage"
+)
+
+// LiquidityAssociator attaches liquidity events stored without a candidate ID
+// (deferred association) to candidates discovered after the event was ingested.
+type LiquidityAssociator struct {
+	liquidityStore storage.LiquidityEventStore
+	candidateStore storage.CandidateStore
+	links          *poolLinker
+	logger         *log.Logger
+}
+
+// NewLiquidityAssociator creates a new association job. Logger defaults to log.Default().
+func NewLiquidityAssociator(liquidityStore storage.LiquidityEventStore, candidateStore storage.CandidateStore, logger *log.Logger) *LiquidityAssociator {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LiquidityAssociator{
+		liquidityStore: liquidityStore,
+		candidateStore: candidateStore,
+		logger:         logger,
+	}
+}
+
+// WithPoolLinks makes Run attribute events to the candidate their pool is
+// linked to at the event time, ahead of the mint and discovery pool.
+func (a *LiquidityAssociator) WithPoolLinks(store storage.PoolLinkStore) *LiquidityAssociator {
+	a.links = newPoolLinker(store, a.candidateStore)
+	return a
+}
+
+// AssociationResult contains statistics from an association pass.
+type AssociationResult struct {
+	Scanned  int // events without candidate ID at the start of the pass
+	Repaired int // events associated with a candidate
+	Orphaned int // events still without candidate ID
+}
+
+// Run associates every unassociated liquidity event whose mint or pool belongs to a known candidate.
+// Pool links win over mint matches, which win over discovery pool matches; among several candidates for a mint the earliest
+// discovered is used. Re-running is idempotent: associated events are no longer scanned.
+func (a *LiquidityAssociator) Run(ctx context.Context) (*AssociationResult, error) {
+	events, err := a.liquidityStore.GetUnassociated(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get unassociated liquidity events: %w", err)
+	}
+
+	result := &AssociationResult{Scanned: len(events)}
+	if len(events) == 0 {
+		return result, nil
+	}
+
+	byMint := make(map[string]string)
+	var byPool map[string]string
+
+	for _, e := range events {
+		candidateID, err := a.links.resolve(ctx, e.DEX, e.Pool, e.Mint, e.Timestamp)
+		if err != nil {
+			return result, err
+		}
+
+		if candidateID == "" && e.Mint != "" {
+			id, cached := byMint[e.Mint]
+			if !cached {
+				id, err = resolveCandidateIDByMint(ctx, a.candidateStore, e.Mint)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return result, fmt.Errorf("resolve candidate for mint %s: %w", e.Mint, err)
+				}
+				byMint[e.Mint] = id
+			}
+			candidateID = id
+		}
+
+		if candidateID == "" && e.Pool != "" {
+			if byPool == nil {
+				byPool, err = a.candidatesByPool(ctx)
+				if err != nil {
+					return result, err
+				}
+			}
+			candidateID = byPool[e.Pool]
+		}
+
+		if candidateID == "" {
+			result.Orphaned++
+			continue
+		}
+
+		if err := a.liquidityStore.UpdateCandidateID(ctx, e, candidateID); err != nil {
+			// Associated concurrently by another pass
+			if errors.Is(err, storage.ErrNotFound) {
+				a.logger.Printf("Liquidity association skipped for %s/%d: %v", e.TxSignature, e.EventIndex, err)
+				continue
+			}
+			return result, fmt.Errorf("associate liquidity event %s/%d: %w", e.TxSignature, e.EventIndex, err)
+		}
+		result.Repaired++
+	}
+
+	return result, nil
+}
+
+// candidatesByPool maps pool address to the earliest discovered candidate for that pool.
+func (a *LiquidityAssociator) candidatesByPool(ctx context.Context) (map[string]string, error) {
+	idx, err := loadCandidateIndex(ctx, a.candidateStore)
+	if err != nil {
+		return nil, err
+	}
+	byPool := make(map[string]string, len(idx.byPool))
+	for pool, c := range idx.byPool {
+		byPool[pool] = c.CandidateID
+	}
+	return byPool, nil
+}
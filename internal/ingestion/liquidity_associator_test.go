@@ -0,0 +1,101 @@
+package ingestion
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestLiquidityAssociator_RepairOrphanedAndRerun(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	liquidityStore := memory.NewLiquidityEventStore()
+
+	pool := "poolB"
+	candidates := []*domain.TokenCandidate{
+		{CandidateID: "candA-late", Source: domain.SourceActiveToken, Mint: "mintA", TxSignature: "d2", DiscoveredAt: 2000},
+		{CandidateID: "candA", Source: domain.SourceNewToken, Mint: "mintA", TxSignature: "d1", DiscoveredAt: 1000},
+		{CandidateID: "candB", Source: domain.SourceNewToken, Mint: "mintB", Pool: &pool, TxSignature: "d3", DiscoveredAt: 1500},
+	}
+	for _, c := range candidates {
+		if err := candidateStore.Insert(ctx, c); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+	}
+
+	events := []*domain.LiquidityEvent{
+		{Mint: "mintA", TxSignature: "sig1", Timestamp: 100, EventType: domain.LiquidityEventAdd},
+		{Pool: "poolB", TxSignature: "sig2", Timestamp: 200, EventType: domain.LiquidityEventAdd},                // pool-only
+		{Mint: "mintX", Pool: "poolX", TxSignature: "sig3", Timestamp: 300, EventType: domain.LiquidityEventAdd}, // unknown token
+	}
+	for _, e := range events {
+		if err := liquidityStore.Insert(ctx, e); err != nil {
+			t.Fatalf("insert liquidity event: %v", err)
+		}
+	}
+
+	associator := NewLiquidityAssociator(liquidityStore, candidateStore, log.New(io.Discard, "", 0))
+
+	result, err := associator.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Scanned != 3 || result.Repaired != 2 || result.Orphaned != 1 {
+		t.Errorf("expected 3 scanned, 2 repaired, 1 orphaned, got %+v", result)
+	}
+
+	// Earliest candidate for the mint wins
+	assoc, _ := liquidityStore.GetByCandidateID(ctx, "candA")
+	if len(assoc) != 1 || assoc[0].TxSignature != "sig1" {
+		t.Errorf("expected sig1 associated with candA, got %d events", len(assoc))
+	}
+	assoc, _ = liquidityStore.GetByCandidateID(ctx, "candB")
+	if len(assoc) != 1 || assoc[0].TxSignature != "sig2" {
+		t.Errorf("expected sig2 associated with candB by pool, got %d events", len(assoc))
+	}
+
+	// Re-run only sees the remaining orphan
+	result, err = associator.Run(ctx)
+	if err != nil {
+		t.Fatalf("second Run failed: %v", err)
+	}
+	if result.Scanned != 1 || result.Repaired != 0 || result.Orphaned != 1 {
+		t.Errorf("expected idempotent re-run with 1 orphan, got %+v", result)
+	}
+
+	// Candidate for the orphan appears later
+	if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "candX", Source: domain.SourceActiveToken, Mint: "mintX", TxSignature: "d4", DiscoveredAt: 3000,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+
+	result, err = associator.Run(ctx)
+	if err != nil {
+		t.Fatalf("third Run failed: %v", err)
+	}
+	if result.Repaired != 1 || result.Orphaned != 0 {
+		t.Errorf("expected late candidate to repair last orphan, got %+v", result)
+	}
+
+	orphans, _ := liquidityStore.GetUnassociated(ctx)
+	if len(orphans) != 0 {
+		t.Errorf("expected no orphans left, got %d", len(orphans))
+	}
+}
+
+func TestLiquidityAssociator_Empty(t *testing.T) {
+	associator := NewLiquidityAssociator(memory.NewLiquidityEventStore(), memory.NewCandidateStore(), log.New(io.Discard, "", 0))
+
+	result, err := associator.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Scanned != 0 || result.Repaired != 0 || result.Orphaned != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
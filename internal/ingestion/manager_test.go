@@ -174,9 +174,9 @@ func TestManager_IngestLiquidityEvents_Ordering(t *testing.T) {
 	// Create unordered events (slot order differs from timestamp order)
 	// Manager must sort these before InsertBulk, otherwise validating store fails
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", Slot: 300, TxSignature: "tx3", EventIndex: 0, Timestamp: 1000}, // slot 300, ts 1000
-		{CandidateID: "c1", Slot: 100, TxSignature: "tx1", EventIndex: 0, Timestamp: 3000}, // slot 100, ts 3000
-		{CandidateID: "c1", Slot: 200, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000}, // slot 200, ts 2000
+		{CandidateID: "c1", Slot: 300, TxSignature: "tx3", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd}, // slot 300, ts 1000
+		{CandidateID: "c1", Slot: 100, TxSignature: "tx1", EventIndex: 0, Timestamp: 3000, EventType: domain.LiquidityEventAdd}, // slot 100, ts 3000
+		{CandidateID: "c1", Slot: 200, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000, EventType: domain.LiquidityEventAdd}, // slot 200, ts 2000
 	}
 
 	source := stub.NewStubLiquidityEventSource(events)
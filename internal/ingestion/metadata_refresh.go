@@ -0,0 +1,182 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// Errors returned by MetadataRefresher.Refresh.
+var (
+	// ErrUnknownMint means neither the mint nor the candidate ID belongs to a candidate.
+	ErrUnknownMint = errors.New("mint has no candidate")
+	// ErrMetadataFetch means the metadata source failed or returned no metadata.
+	ErrMetadataFetch = errors.New("metadata fetch failed")
+	// ErrRefreshRateLimited means the refresh rate limit is exhausted.
+	ErrRefreshRateLimited = errors.New("metadata refresh rate limited")
+)
+
+// DefaultMetadataRefreshLimit is the default number of on-demand metadata
+// refreshes allowed per minute.
+const DefaultMetadataRefreshLimit = 10
+
+// MetadataRefreshRequest names the token to refresh by mint or candidate ID.
+// Requester identifies the caller in the audit log.
+type MetadataRefreshRequest struct {
+	Mint        string
+	CandidateID string
+	Requester   string
+}
+
+// MetadataRefresher re-fetches the metadata of one token on demand, e.g. when
+// its symbol is wrong or missing, and upserts it. Every call is audit-logged.
+type MetadataRefresher struct {
+	fetcher        MetadataFetcher
+	candidateStore storage.CandidateStore
+	metadataStore  storage.TokenMetadataStore
+	logger         *log.Logger
+	now            func() time.Time
+
+	// Optional rate limit, see WithRateLimit
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	recent []time.Time // refresh times within the window, oldest first
+}
+
+// NewMetadataRefresher creates a refresher. Logger defaults to log.Default().
+func NewMetadataRefresher(fetcher MetadataFetcher, candidateStore storage.CandidateStore, metadataStore storage.TokenMetadataStore, logger *log.Logger) *MetadataRefresher {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &MetadataRefresher{
+		fetcher:        fetcher,
+		candidateStore: candidateStore,
+		metadataStore:  metadataStore,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+// WithRateLimit allows at most limit refreshes per window; further calls fail
+// with ErrRefreshRateLimited. A limit of 0 disables the rate limit.
+func (m *MetadataRefresher) WithRateLimit(limit int, window time.Duration) *MetadataRefresher {
+	m.limit = limit
+	m.window = window
+	return m
+}
+
+// WithClock sets the clock of the rate limit.
+func (m *MetadataRefresher) WithClock(now func() time.Time) *MetadataRefresher {
+	m.now = now
+	return m
+}
+
+// Refresh fetches the metadata of the requested token and upserts it. The
+// existing row keeps its candidate; a token without one gets the row of its
+// mint's first candidate. A candidate ID takes precedence over a mint.
+func (m *MetadataRefresher) Refresh(ctx context.Context, req MetadataRefreshRequest) (*domain.TokenMetadata, error) {
+	meta, err := m.refresh(ctx, req)
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+	m.logger.Printf("Metadata refresh: requester=%q mint=%q candidate_id=%q result=%q",
+		req.Requester, req.Mint, req.CandidateID, result)
+	return meta, err
+}
+
+func (m *MetadataRefresher) refresh(ctx context.Context, req MetadataRefreshRequest) (*domain.TokenMetadata, error) {
+	if !m.allow() {
+		return nil, ErrRefreshRateLimited
+	}
+
+	mint, candidateID, err := m.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := m.fetcher.Fetch(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMetadataFetch, err)
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("%w: no metadata for %s", ErrMetadataFetch, mint)
+	}
+
+	meta.CandidateID = candidateID
+	if err := upsertMintMetadata(ctx, m.metadataStore, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// resolve returns the mint to fetch and the candidate that owns its metadata
+// unless a row exists.
+func (m *MetadataRefresher) resolve(ctx context.Context, req MetadataRefreshRequest) (string, string, error) {
+	if req.CandidateID != "" {
+		c, err := m.candidateStore.GetByID(ctx, req.CandidateID)
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", "", ErrUnknownMint
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("get candidate %s: %w", req.CandidateID, err)
+		}
+		return c.Mint, c.CandidateID, nil
+	}
+
+	candidates, err := m.candidateStore.GetByMint(ctx, req.Mint)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return "", "", fmt.Errorf("get candidates for mint %s: %w", req.Mint, err)
+	}
+	if len(candidates) == 0 {
+		return "", "", ErrUnknownMint
+	}
+	return req.Mint, candidates[0].CandidateID, nil
+}
+
+// allow records a refresh and reports whether it is within the rate limit.
+func (m *MetadataRefresher) allow() bool {
+	if m.limit <= 0 {
+		return true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	kept := m.recent[:0]
+	for _, t := range m.recent {
+		if now.Sub(t) < m.window {
+			kept = append(kept, t)
+		}
+	}
+	m.recent = kept
+	if len(m.recent) >= m.limit {
+		return false
+	}
+	m.recent = append(m.recent, now)
+	return true
+}
+
+// upsertMintMetadata stores metadata once per mint: an existing row keeps its
+// candidate, otherwise the row is created for meta.CandidateID.
+func upsertMintMetadata(ctx context.Context, store storage.TokenMetadataStore, meta *domain.TokenMetadata) error {
+	existing, err := store.GetByMint(ctx, meta.Mint)
+	switch {
+	case err == nil:
+		meta.CandidateID = existing.CandidateID
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("get metadata for mint %s: %w", meta.Mint, err)
+	}
+
+	if err := store.Upsert(ctx, meta); err != nil {
+		return fmt.Errorf("store metadata for %s: %w", meta.Mint, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package ingestion
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestMetadataRefresher_Refresh(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	metadataStore := memory.NewTokenMetadataStore()
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx1"})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "c2", Source: domain.SourceNewToken, Mint: "mintFail", TxSignature: "tx2"})
+	wrong := "WRONG"
+	_ = metadataStore.Insert(ctx, &domain.TokenMetadata{CandidateID: "c1", Mint: "mint1", Symbol: &wrong, FetchedAt: 1000})
+
+	var audit bytes.Buffer
+	fetcher := &fakeMetadataFetcher{fail: map[string]bool{"mintFail": true}}
+	refresher := NewMetadataRefresher(fetcher, candidateStore, metadataStore, log.New(&audit, "", 0))
+
+	t.Run("success by mint", func(t *testing.T) {
+		meta, err := refresher.Refresh(ctx, MetadataRefreshRequest{Mint: "mint1", Requester: "10.0.0.1"})
+		if err != nil {
+			t.Fatalf("Refresh failed: %v", err)
+		}
+		if meta.CandidateID != "c1" || *meta.Symbol != "SYM" {
+			t.Errorf("expected refreshed metadata of c1, got %+v", meta)
+		}
+		stored, err := metadataStore.GetByMint(ctx, "mint1")
+		if err != nil {
+			t.Fatalf("GetByMint failed: %v", err)
+		}
+		if *stored.Symbol != "SYM" {
+			t.Errorf("expected stored symbol SYM, got %s", *stored.Symbol)
+		}
+	})
+
+	t.Run("success by candidate ID", func(t *testing.T) {
+		fetcher.calls = nil
+		if _, err := refresher.Refresh(ctx, MetadataRefreshRequest{CandidateID: "c1"}); err != nil {
+			t.Fatalf("Refresh failed: %v", err)
+		}
+		if len(fetcher.calls) != 1 || fetcher.calls[0] != "mint1" {
+			t.Errorf("expected a fetch of mint1, got %v", fetcher.calls)
+		}
+	})
+
+	t.Run("fetch failure", func(t *testing.T) {
+		_, err := refresher.Refresh(ctx, MetadataRefreshRequest{Mint: "mintFail"})
+		if !errors.Is(err, ErrMetadataFetch) {
+			t.Fatalf("expected ErrMetadataFetch, got %v", err)
+		}
+		if _, err := metadataStore.GetByMint(ctx, "mintFail"); err == nil {
+			t.Error("expected no metadata stored after a failed fetch")
+		}
+	})
+
+	t.Run("unknown mint", func(t *testing.T) {
+		fetcher.calls = nil
+		if _, err := refresher.Refresh(ctx, MetadataRefreshRequest{Mint: "mintX"}); !errors.Is(err, ErrUnknownMint) {
+			t.Errorf("expected ErrUnknownMint, got %v", err)
+		}
+		if _, err := refresher.Refresh(ctx, MetadataRefreshRequest{CandidateID: "missing"}); !errors.Is(err, ErrUnknownMint) {
+			t.Errorf("expected ErrUnknownMint for an unknown candidate, got %v", err)
+		}
+		if len(fetcher.calls) != 0 {
+			t.Errorf("expected no fetches for unknown tokens, got %v", fetcher.calls)
+		}
+	})
+
+	if !strings.Contains(audit.String(), `requester="10.0.0.1" mint="mint1" candidate_id="" result="ok"`) {
+		t.Errorf("expected an audit line for the first refresh, got:\n%s", audit.String())
+	}
+	if n := strings.Count(audit.String(), "Metadata refresh:"); n != 5 {
+		t.Errorf("expected 5 audited refreshes, got %d", n)
+	}
+}
+
+func TestMetadataRefresher_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx1"})
+
+	now := time.Unix(0, 0)
+	refresher := NewMetadataRefresher(&fakeMetadataFetcher{}, candidateStore, memory.NewTokenMetadataStore(), log.New(&bytes.Buffer{}, "", 0)).
+		WithRateLimit(2, time.Minute).
+		WithClock(func() time.Time { return now })
+
+	req := MetadataRefreshRequest{Mint: "mint1"}
+	for i := 0; i < 2; i++ {
+		if _, err := refresher.Refresh(ctx, req); err != nil {
+			t.Fatalf("refresh %d: %v", i, err)
+		}
+	}
+	if _, err := refresher.Refresh(ctx, req); !errors.Is(err, ErrRefreshRateLimited) {
+		t.Fatalf("expected ErrRefreshRateLimited, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := refresher.Refresh(ctx, req); err != nil {
+		t.Errorf("expected a refresh after the window, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package ingestion
+
+import (
+	"context"
+	"log"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+)
+
+// WSMintCreationSource provides real-time SPL Token mint creations via WebSocket
+// subscription to the token programs. Feeds PRE_POOL discovery.
+type WSMintCreationSource struct {
+	feed *programFeed
+	rpc  *solana.HTTPClient // For fetching full transaction data
+}
+
+// NewWSMintCreationSource creates a mint creation source subscribed to the
+// SPL Token and Token-2022 programs.
+func NewWSMintCreationSource(ws LogSubscriber, rpc *solana.HTTPClient) *WSMintCreationSource {
+	return &WSMintCreationSource{
+		feed: newProgramFeed(ws, "ws-mint", []string{solana.TokenProgramID, solana.Token2022ProgramID}),
+		rpc:  rpc,
+	}
+}
+
+// Subscribe returns a channel of mint creation events from live WebSocket subscription.
+// The channel is closed when the context is cancelled.
+func (s *WSMintCreationSource) Subscribe(ctx context.Context) (<-chan *discovery.MintCreationEvent, error) {
+	merged, err := s.feed.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsCh := make(chan *discovery.MintCreationEvent, 100)
+
+	go func() {
+		defer close(eventsCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n, ok := <-merged:
+				if !ok {
+					return
+				}
+				s.processMintNotification(ctx, eventsCh, n.notif)
+			}
+		}
+	}()
+
+	return eventsCh, nil
+}
+
+// processMintNotification processes a single token program log notification.
+// The token programs log every transfer, so only notifications whose logs show
+// a mint initialization are fetched.
+func (s *WSMintCreationSource) processMintNotification(ctx context.Context, eventsCh chan<- *discovery.MintCreationEvent, notif solana.LogNotification) {
+	if notif.Err != nil || !solana.LogsInitializeMint(notif.Logs) {
+		return
+	}
+
+	// The mint address is only in the instruction accounts, so the transaction is required
+	tx, err := retryGetTransaction(ctx, s.rpc, notif.Signature)
+	if err != nil || tx == nil {
+		log.Printf("WARN: RPC fetch failed for mint creation tx %s after %d retries: %v", notif.Signature, maxRetries, err)
+		return
+	}
+
+	timestamp, _ := resolveBlockTimestamp(ctx, s.rpc, notif.Slot, tx.BlockTime)
+
+	for _, mi := range solana.ParseMintInitializations(tx) {
+		event := &discovery.MintCreationEvent{
+			Mint:        mi.Mint,
+			TxSignature: notif.Signature,
+			Slot:        notif.Slot,
+			Timestamp:   timestamp,
+		}
+		select {
+		case eventsCh <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
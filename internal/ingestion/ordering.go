@@ -4,6 +4,7 @@ import (
 	"errors"
 	"sort"
 
+	"solana-token-lab/internal/discovery"
 	"solana-token-lab/internal/domain"
 )
 
@@ -141,3 +142,17 @@ func compareSwapEvents(a, b *domain.SwapEvent) int {
 	}
 	return 0
 }
+
+// sortMintCreations orders mint creations by (slot ASC, tx_signature ASC, mint ASC).
+func sortMintCreations(events []*discovery.MintCreationEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Slot != b.Slot {
+			return a.Slot < b.Slot
+		}
+		if a.TxSignature != b.TxSignature {
+			return a.TxSignature < b.TxSignature
+		}
+		return a.Mint < b.Mint
+	})
+}
@@ -0,0 +1,160 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// pumpFunCurvePrefix keys the bonding curve of a mint in pool links. pump.fun
+// logs carry no curve address, so its events are linked by mint instead.
+const pumpFunCurvePrefix = "pumpfun:"
+
+// linkPool returns the pool key an event or candidate is linked under, or ""
+// when it cannot be linked.
+func linkPool(dex, pool, mint string) string {
+	if pool != "" {
+		return pool
+	}
+	if dex == domain.DEXPumpFun && mint != "" {
+		return pumpFunCurvePrefix + mint
+	}
+	return ""
+}
+
+// poolLinker keeps the pool links of candidates (see domain.PoolLink) so the
+// events of every pool a token trades in are attributed to one candidate:
+//   - a new candidate links its discovery pool,
+//   - a pump.fun migration closes the mint's bonding curve link (graduation),
+//   - a pool creation for a mint with a candidate links the new pool, as a
+//     graduation when the mint's bonding curve has migrated.
+//
+// A nil *poolLinker links nothing and resolves nothing.
+type poolLinker struct {
+	store          storage.PoolLinkStore
+	candidateStore storage.CandidateStore
+}
+
+// newPoolLinker returns nil without a store.
+func newPoolLinker(store storage.PoolLinkStore, candidateStore storage.CandidateStore) *poolLinker {
+	if store == nil {
+		return nil
+	}
+	return &poolLinker{store: store, candidateStore: candidateStore}
+}
+
+// linkCandidate links the discovery pool of a new candidate from its
+// discovery time. A pool already linked keeps its link.
+func (l *poolLinker) linkCandidate(ctx context.Context, c *domain.TokenCandidate) error {
+	if l == nil {
+		return nil
+	}
+	var pool string
+	if c.Pool != nil {
+		pool = *c.Pool
+	}
+	return l.insert(ctx, &domain.PoolLink{
+		Pool:        linkPool(c.DEX, pool, c.Mint),
+		Mint:        c.Mint,
+		CandidateID: c.CandidateID,
+		DEX:         c.DEX,
+		Reason:      domain.PoolLinkDiscovery,
+		TxSignature: c.TxSignature,
+		ValidFrom:   c.DiscoveredAt,
+	})
+}
+
+// observe updates the links from a liquidity event: migrations close the
+// bonding curve, pool creations for known mints open a link.
+func (l *poolLinker) observe(ctx context.Context, e *domain.LiquidityEvent) error {
+	if l == nil || e.Mint == "" {
+		return nil
+	}
+
+	if e.Migration {
+		err := l.store.Close(ctx, pumpFunCurvePrefix+e.Mint, e.Timestamp)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("close bonding curve link of %s: %w", e.Mint, err)
+		}
+		return nil
+	}
+
+	if !e.PoolInit || e.Pool == "" {
+		return nil
+	}
+	candidateID, err := resolveCandidateIDByMint(ctx, l.candidateStore, e.Mint)
+	if err != nil {
+		return fmt.Errorf("resolve candidate for mint %s: %w", e.Mint, err)
+	}
+	if candidateID == "" {
+		// No candidate yet: pool creation discovers it and links the pool
+		return nil
+	}
+
+	reason, err := l.poolCreationReason(ctx, e.Mint)
+	if err != nil {
+		return err
+	}
+	return l.insert(ctx, &domain.PoolLink{
+		Pool:        e.Pool,
+		Mint:        e.Mint,
+		CandidateID: candidateID,
+		DEX:         e.DEX,
+		Reason:      reason,
+		TxSignature: e.TxSignature,
+		ValidFrom:   e.Timestamp,
+	})
+}
+
+// poolCreationReason classifies a new pool of a mint: a graduation if the
+// mint's bonding curve has migrated, otherwise a plain pool creation.
+func (l *poolLinker) poolCreationReason(ctx context.Context, mint string) (string, error) {
+	links, err := l.store.GetByMint(ctx, mint)
+	if err != nil {
+		return "", fmt.Errorf("get pool links of %s: %w", mint, err)
+	}
+	for _, link := range links {
+		if link.DEX == domain.DEXPumpFun && link.ValidTo != nil {
+			return domain.PoolLinkGraduation, nil
+		}
+	}
+	return domain.PoolLinkPoolCreation, nil
+}
+
+// resolve returns the candidate the pool of an event is linked to at its
+// timestamp, or "" when no link covers it.
+func (l *poolLinker) resolve(ctx context.Context, dex, pool, mint string, timestamp int64) (string, error) {
+	if l == nil {
+		return "", nil
+	}
+	key := linkPool(dex, pool, mint)
+	if key == "" {
+		return "", nil
+	}
+	link, err := l.store.GetByPool(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get pool link of %s: %w", key, err)
+	}
+	if !link.Covers(timestamp) {
+		return "", nil
+	}
+	return link.CandidateID, nil
+}
+
+func (l *poolLinker) insert(ctx context.Context, link *domain.PoolLink) error {
+	if link.Pool == "" {
+		return nil
+	}
+	link.CreatedAt = time.Now().UnixMilli()
+	if err := l.store.Insert(ctx, link); err != nil && !errors.Is(err, storage.ErrDuplicateKey) {
+		return fmt.Errorf("link pool %s: %w", link.Pool, err)
+	}
+	return nil
+}
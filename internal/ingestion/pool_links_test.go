@@ -0,0 +1,105 @@
+package ingestion
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// TestRunner_PoolMigration follows a token from its pump.fun bonding curve to
+// the Raydium pool it graduates to: every liquidity event, including a deposit
+// whose mint the Raydium parser got wrong, lands on the pump.fun candidate.
+func TestRunner_PoolMigration(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	liqStore := memory.NewLiquidityEventStore()
+	linkStore := memory.NewPoolLinkStore()
+	runner := NewRunner(RunnerOptions{
+		SwapEventStore:   memory.NewSwapEventStore(),
+		LiquidityStore:   liqStore,
+		CandidateStore:   candidateStore,
+		PoolLinkStore:    linkStore,
+		NewTokenDetector: discovery.NewDetector(candidateStore),
+		Logger:           log.New(io.Discard, "", 0),
+	})
+
+	// A pump.fun swap discovers the candidate on the bonding curve
+	runner.handleSwapEvent(ctx, &domain.SwapEvent{Mint: "mint1", TxSignature: "buy1", Slot: 1, Timestamp: 1000, DEX: domain.DEXPumpFun})
+	candidates, err := candidateStore.GetByMint(ctx, "mint1")
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	candidateID := candidates[0].CandidateID
+
+	buffer := func(slot int64, events ...*domain.LiquidityEvent) {
+		runner.liquidityBuffer[slot] = append(runner.liquidityBuffer[slot], events...)
+		runner.processSlot(ctx, slot)
+	}
+	buffer(2, &domain.LiquidityEvent{Mint: "mint1", EventType: "add", TxSignature: "curve-add", Slot: 2, Timestamp: 2000,
+		DEX: domain.DEXPumpFun, LiquidityAfter: 40})
+	// Migration: the curve is drained and the Raydium pool created in one transaction
+	buffer(5,
+		&domain.LiquidityEvent{Mint: "mint1", EventType: "remove", TxSignature: "migrate", EventIndex: 0, Slot: 5, Timestamp: 5000,
+			DEX: domain.DEXPumpFun, Migration: true},
+		&domain.LiquidityEvent{Pool: "ray-pool", Mint: "mint1", EventType: "add", TxSignature: "migrate", EventIndex: 1, Slot: 5, Timestamp: 5000,
+			DEX: domain.DEXRaydium, PoolInit: true, LiquidityAfter: 80},
+	)
+	buffer(6, &domain.LiquidityEvent{Pool: "ray-pool", Mint: "wallet1", EventType: "add", TxSignature: "deposit", Slot: 6, Timestamp: 6000,
+		DEX: domain.DEXRaydium, LiquidityAfter: 90})
+
+	// The pool creation did not discover a second candidate
+	candidates, err = candidateStore.GetByMint(ctx, "mint1")
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+
+	events, err := liqStore.GetByCandidateID(ctx, candidateID)
+	require.NoError(t, err)
+	var attributed []string
+	for _, e := range events {
+		attributed = append(attributed, e.TxSignature)
+	}
+	assert.Equal(t, []string{"curve-add", "migrate", "migrate", "deposit"}, attributed)
+
+	links, err := linkStore.GetByMint(ctx, "mint1")
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, "pumpfun:mint1", links[0].Pool)
+	assert.Equal(t, domain.PoolLinkDiscovery, links[0].Reason)
+	require.NotNil(t, links[0].ValidTo)
+	assert.Equal(t, int64(5000), *links[0].ValidTo)
+	assert.Equal(t, "ray-pool", links[1].Pool)
+	assert.Equal(t, domain.PoolLinkGraduation, links[1].Reason)
+	assert.Equal(t, candidateID, links[1].CandidateID)
+	assert.Nil(t, links[1].ValidTo)
+}
+
+func TestPoolLinker_ResolveOutsideWindow(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewPoolLinkStore()
+	linker := newPoolLinker(store, memory.NewCandidateStore())
+
+	pool := "pool-a"
+	require.NoError(t, linker.linkCandidate(ctx, &domain.TokenCandidate{
+		CandidateID: "cand-a", Mint: "mint-a", Pool: &pool, DEX: domain.DEXRaydium, DiscoveredAt: 1000,
+	}))
+	require.NoError(t, store.Close(ctx, pool, 3000))
+
+	for ts, want := range map[int64]string{999: "", 1000: "cand-a", 2999: "cand-a", 3000: ""} {
+		got, err := linker.resolve(ctx, domain.DEXRaydium, pool, "other-mint", ts)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "timestamp %d", ts)
+	}
+
+	// A nil linker resolves nothing
+	var none *poolLinker
+	got, err := none.resolve(ctx, domain.DEXRaydium, pool, "mint-a", 2000)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
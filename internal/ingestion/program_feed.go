@@ -0,0 +1,250 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/solana"
+)
+
+var (
+	// ErrProgramMonitored is returned when adding a program that is already subscribed.
+	ErrProgramMonitored = errors.New("program already monitored")
+	// ErrProgramNotMonitored is returned when removing a program that is not subscribed.
+	ErrProgramNotMonitored = errors.New("program not monitored")
+)
+
+// LogSubscriber is the WebSocket capability the live sources depend on.
+// *solana.WSClientImpl implements it.
+type LogSubscriber interface {
+	SubscribeLogs(ctx context.Context, filter solana.LogsFilter) (<-chan solana.LogNotification, error)
+	UnsubscribeLogs(ctx context.Context, ch <-chan solana.LogNotification) error
+}
+
+// programNotification is a log notification tagged with the program whose
+// subscription delivered it.
+type programNotification struct {
+	program string
+	notif   solana.LogNotification
+}
+
+// programSubscription is one live per-program logs subscription.
+type programSubscription struct {
+	ch     <-chan solana.LogNotification
+	cancel context.CancelFunc // stops forwarding into the merged stream
+}
+
+// programFeed merges per-program log subscriptions into one stream and lets
+// programs be added or removed while the stream is consumed.
+// Some providers only support one address per subscription, hence one per program.
+type programFeed struct {
+	ws   LogSubscriber
+	name string // log prefix, e.g. "ws-swap"
+
+	mu       sync.Mutex
+	ctx      context.Context // subscription context; nil until start
+	programs []string        // monitored programs in subscription order
+	subs     map[string]*programSubscription
+	merged   chan programNotification
+	received programCounter // notifications delivered per program, for the WS health probe
+}
+
+func newProgramFeed(ws LogSubscriber, name string, programs []string) *programFeed {
+	return &programFeed{
+		ws:       ws,
+		name:     name,
+		programs: append([]string(nil), programs...),
+		subs:     make(map[string]*programSubscription),
+	}
+}
+
+// start subscribes every configured program and returns the merged stream.
+func (f *programFeed) start(ctx context.Context) (<-chan programNotification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ctx = ctx
+	f.merged = make(chan programNotification, 1000)
+	for _, program := range f.programs {
+		if err := f.subscribeLocked(program); err != nil {
+			return nil, err
+		}
+	}
+	return f.merged, nil
+}
+
+// subscribeLocked opens the subscription for program and forwards it into merged.
+func (f *programFeed) subscribeLocked(program string) error {
+	ch, err := f.ws.SubscribeLogs(f.ctx, solana.LogsFilter{
+		Mentions: []string{program},
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe %s: %w", program, err)
+	}
+
+	subCtx, cancel := context.WithCancel(f.ctx)
+	f.subs[program] = &programSubscription{ch: ch, cancel: cancel}
+	merged := f.merged
+
+	go func() {
+		for {
+			select {
+			case <-subCtx.Done():
+				return
+			case notif, ok := <-ch:
+				if !ok {
+					return
+				}
+				f.received.inc(program)
+				select {
+				case merged <- programNotification{program: program, notif: notif}:
+				case <-subCtx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	log.Printf("[%s] Subscribed to program: %s", f.name, program)
+	return nil
+}
+
+// add starts monitoring program. Before start it only extends the program list.
+func (f *programFeed) add(program string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.indexLocked(program) >= 0 {
+		return fmt.Errorf("%s: %w", program, ErrProgramMonitored)
+	}
+	if f.ctx != nil {
+		if err := f.subscribeLocked(program); err != nil {
+			return err
+		}
+	}
+	f.programs = append(f.programs, program)
+	return nil
+}
+
+// remove stops monitoring program. Notifications already merged are dropped
+// by consumers checking active.
+func (f *programFeed) remove(ctx context.Context, program string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.indexLocked(program)
+	if i < 0 {
+		return fmt.Errorf("%s: %w", program, ErrProgramNotMonitored)
+	}
+	f.programs = append(f.programs[:i], f.programs[i+1:]...)
+
+	sub, ok := f.subs[program]
+	if !ok {
+		return nil
+	}
+	delete(f.subs, program)
+	sub.cancel()
+	if err := f.ws.UnsubscribeLogs(ctx, sub.ch); err != nil {
+		return fmt.Errorf("unsubscribe %s: %w", program, err)
+	}
+	log.Printf("[%s] Unsubscribed from program: %s", f.name, program)
+	return nil
+}
+
+// active reports whether program is currently monitored.
+func (f *programFeed) active(program string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.indexLocked(program) >= 0
+}
+
+// list returns the monitored programs in subscription order.
+func (f *programFeed) list() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.programs...)
+}
+
+func (f *programFeed) indexLocked(program string) int {
+	for i, p := range f.programs {
+		if p == program {
+			return i
+		}
+	}
+	return -1
+}
+
+// programCounter counts occurrences per program. It is safe for concurrent use.
+type programCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *programCounter) inc(program string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[program]++
+}
+
+// snapshot returns a copy of the counts.
+func (c *programCounter) snapshot() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]int64, len(c.counts))
+	for p, n := range c.counts {
+		out[p] = n
+	}
+	return out
+}
+
+// programClock records the latest time seen per program. It is safe for concurrent use.
+type programClock struct {
+	mu    sync.Mutex
+	times map[string]int64
+}
+
+// observe records ts (Unix ms) for program if it is later than the time recorded.
+func (c *programClock) observe(program string, ts int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.times == nil {
+		c.times = make(map[string]int64)
+	}
+	if ts > c.times[program] {
+		c.times[program] = ts
+	}
+}
+
+// snapshot returns a copy of the times.
+func (c *programClock) snapshot() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]int64, len(c.times))
+	for p, ts := range c.times {
+		out[p] = ts
+	}
+	return out
+}
+
+// sortedPrograms returns the keys of the given sets, sorted and deduplicated.
+func sortedPrograms(sets ...map[string]int64) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, set := range sets {
+		for p := range set {
+			if !seen[p] {
+				seen[p] = true
+				out = append(out, p)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
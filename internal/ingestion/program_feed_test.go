@@ -0,0 +1,221 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// fakeLogSubscriber hands out one channel per subscribed program and records unsubscriptions.
+type fakeLogSubscriber struct {
+	mu           sync.Mutex
+	channels     map[string]chan solana.LogNotification
+	subscribed   []string
+	unsubscribed []string
+}
+
+func newFakeLogSubscriber() *fakeLogSubscriber {
+	return &fakeLogSubscriber{channels: make(map[string]chan solana.LogNotification)}
+}
+
+func (f *fakeLogSubscriber) SubscribeLogs(_ context.Context, filter solana.LogsFilter) (<-chan solana.LogNotification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	program := filter.Mentions[0]
+	ch := make(chan solana.LogNotification, 10)
+	f.channels[program] = ch
+	f.subscribed = append(f.subscribed, program)
+	return ch, nil
+}
+
+func (f *fakeLogSubscriber) UnsubscribeLogs(_ context.Context, ch <-chan solana.LogNotification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for program, c := range f.channels {
+		if (<-chan solana.LogNotification)(c) == ch {
+			f.unsubscribed = append(f.unsubscribed, program)
+			delete(f.channels, program)
+		}
+	}
+	return nil
+}
+
+func (f *fakeLogSubscriber) send(t *testing.T, program string, notif solana.LogNotification) {
+	t.Helper()
+	f.mu.Lock()
+	ch, ok := f.channels[program]
+	f.mu.Unlock()
+	if !ok {
+		t.Fatalf("no subscription for %s", program)
+	}
+	ch <- notif
+}
+
+func (f *fakeLogSubscriber) calls() (subscribed, unsubscribed []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.subscribed...), append([]string(nil), f.unsubscribed...)
+}
+
+// fakeTransactionRPC answers getTransaction with a confirmed, logless transaction.
+func fakeTransactionRPC(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		if req.Method != "getTransaction" {
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		result := map[string]interface{}{
+			"slot":      int64(100),
+			"blockTime": int64(1700000500),
+			"meta":      map[string]interface{}{"err": nil},
+			"transaction": map[string]interface{}{
+				"message": map[string]interface{}{"accountKeys": []string{}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func pumpFunBuyLogs(mint string) []string {
+	logs := []string{"Program " + discovery.PumpFun + " invoke [1]"}
+	if mint != "" {
+		logs = append(logs, "Program log: mint="+mint)
+	}
+	return append(logs, "Program log: Instruction: Buy", "Program "+discovery.PumpFun+" success")
+}
+
+// startRunner runs r in the background and returns a stop function that waits for Run to return.
+func startRunner(t *testing.T, r *Runner) (context.Context, func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	return ctx, func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("runner did not stop")
+		}
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRunner_AddRemoveProgram_SubscriptionLifecycle(t *testing.T) {
+	ws := newFakeLogSubscriber()
+	r := NewRunner(RunnerOptions{
+		WSSwapSource: NewWSSwapEventSource(ws, nil, []string{"progA"}),
+		Logger:       log.New(io.Discard, "", 0),
+	})
+
+	ctx, stop := startRunner(t, r)
+	defer stop()
+
+	waitFor(t, "initial subscription", func() bool {
+		subscribed, _ := ws.calls()
+		return len(subscribed) == 1
+	})
+
+	if err := r.AddProgram(ctx, "progB"); err != nil {
+		t.Fatalf("add progB: %v", err)
+	}
+	if err := r.AddProgram(ctx, "progB"); !errors.Is(err, ErrProgramMonitored) {
+		t.Errorf("re-add progB: expected ErrProgramMonitored, got %v", err)
+	}
+	if err := r.RemoveProgram(ctx, "progA"); err != nil {
+		t.Fatalf("remove progA: %v", err)
+	}
+	if err := r.RemoveProgram(ctx, "progA"); !errors.Is(err, ErrProgramNotMonitored) {
+		t.Errorf("re-remove progA: expected ErrProgramNotMonitored, got %v", err)
+	}
+
+	subscribed, unsubscribed := ws.calls()
+	if !reflect.DeepEqual(subscribed, []string{"progA", "progB"}) {
+		t.Errorf("subscribed = %v, want [progA progB]", subscribed)
+	}
+	if !reflect.DeepEqual(unsubscribed, []string{"progA"}) {
+		t.Errorf("unsubscribed = %v, want [progA]", unsubscribed)
+	}
+
+	stats := r.ProgramStats()
+	if len(stats) != 1 || stats[0].Program != "progB" || !stats[0].Active {
+		t.Errorf("expected only active progB, got %+v", stats)
+	}
+}
+
+func TestRunner_ProgramStatsAttribution(t *testing.T) {
+	rpcServer := fakeTransactionRPC(t)
+	defer rpcServer.Close()
+
+	ws := newFakeLogSubscriber()
+	candidateStore := memory.NewCandidateStore()
+	r := NewRunner(RunnerOptions{
+		WSSwapSource:     NewWSSwapEventSource(ws, solana.NewHTTPClient(rpcServer.URL), []string{"progA", "progB"}),
+		SwapEventStore:   memory.NewSwapEventStore(),
+		CandidateStore:   candidateStore,
+		NewTokenDetector: discovery.NewDetector(candidateStore).WithProgressStore(memory.NewDiscoveryProgressStore()),
+		SlotLagWindow:    1,
+		Logger:           log.New(io.Discard, "", 0),
+	})
+
+	_, stop := startRunner(t, r)
+
+	waitFor(t, "subscriptions", func() bool {
+		subscribed, _ := ws.calls()
+		return len(subscribed) == 2
+	})
+
+	ws.send(t, "progA", solana.LogNotification{Signature: "sigA1", Slot: 100, Logs: pumpFunBuyLogs("MintA1")})
+	ws.send(t, "progA", solana.LogNotification{Signature: "sigA2", Slot: 110, Logs: pumpFunBuyLogs("MintA2")})
+	ws.send(t, "progB", solana.LogNotification{Signature: "sigB1", Slot: 105, Logs: pumpFunBuyLogs("")})
+
+	waitFor(t, "events", func() bool {
+		stats := r.ProgramStats()
+		return len(stats) == 2 && stats[0].Events == 2 && stats[1].ParseFailures == 1
+	})
+	stop() // flushes buffered slots
+
+	got := r.ProgramStats()
+	if len(got) != 2 || got[0].LastEventAt == 0 || got[1].LastEventAt != 0 {
+		t.Fatalf("expected a last event time for progA only, got %+v", got)
+	}
+	got[0].LastEventAt = 0
+	want := []ProgramStats{
+		{Program: "progA", Active: true, Events: 2, Candidates: 2},
+		{Program: "progB", Active: true, ParseFailures: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProgramStats() = %+v, want %+v", got, want)
+	}
+}
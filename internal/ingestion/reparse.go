@@ -0,0 +1,337 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+)
+
+// TxParser extracts the swap and liquidity events of one transaction.
+type TxParser func(ctx context.Context, tx *solana.Transaction) ([]*domain.SwapEvent, []*domain.LiquidityEvent)
+
+// NewTxParser returns the parser used by the RPC sources: the current DEXParser
+// plus Raydium pool/mint inference and candidate resolution by mint. Events are
+// labeled domain.OriginReplay.
+// rpc may be nil, in which case Raydium mints are not inferred; candidates may be
+// nil, in which case liquidity events are left unassociated.
+func NewTxParser(rpc *solana.HTTPClient, candidates storage.CandidateStore) TxParser {
+	swaps := NewRPCSwapEventSource(rpc, nil)
+	liquidity := NewRPCLiquidityEventSource(rpc, nil, candidates)
+	return func(ctx context.Context, tx *solana.Transaction) ([]*domain.SwapEvent, []*domain.LiquidityEvent) {
+		if tx.Meta == nil || tx.Meta.Err != nil {
+			return nil, nil
+		}
+		swapEvents := swaps.parseTx(ctx, "", tx, tx.BlockTime)
+		for _, e := range swapEvents {
+			e.Origin = domain.OriginReplay
+		}
+		liqEvents := liquidity.resolveByMint(ctx, liquidity.parseTx(ctx, tx, tx.BlockTime))
+		for _, e := range liqEvents {
+			e.Origin = domain.OriginReplay
+		}
+		return swapEvents, liqEvents
+	}
+}
+
+// DefaultReparsePageSize is the number of archived transactions read per page.
+const DefaultReparsePageSize = 500
+
+// Reparser re-runs the parser over the raw transaction archive and corrects the
+// stored swap_events and liquidity_events of each archived transaction.
+// Events are matched by (tx_signature, event_index). A stored event whose parsed
+// fields differ is replaced (CHANGED), a parsed event with no stored counterpart
+// is inserted (ADDED), and a stored event the parser no longer produces is only
+// reported (REMOVED), since a parser regression should not silently delete data.
+// Derived swaps are not touched.
+type Reparser struct {
+	archive  storage.RawTxStore
+	store    storage.ReparseStore
+	parse    TxParser
+	pageSize int
+	dryRun   bool
+	clock    func() time.Time
+	logger   *log.Logger
+}
+
+// ReparserOptions contains configuration for creating a Reparser.
+type ReparserOptions struct {
+	Archive  storage.RawTxStore
+	Store    storage.ReparseStore
+	Parse    TxParser // Default: NewTxParser(nil, nil)
+	PageSize int      // Default: DefaultReparsePageSize
+	DryRun   bool     // report differences without applying them
+	Clock    func() time.Time
+	Logger   *log.Logger
+}
+
+// ReparseResult summarizes a reparse run.
+type ReparseResult struct {
+	Transactions int // archived transactions read
+	Corrected    int // transactions with an ADDED or CHANGED event (not applied on a dry run)
+	DecodeErrors int // archived transactions that could not be decoded
+	Added        int
+	Changed      int
+	Removed      int // reported only
+
+	// Changes lists every difference found, in archive order.
+	Changes []*storage.ReparseCorrection
+}
+
+// NewReparser creates a new reparser.
+func NewReparser(opts ReparserOptions) *Reparser {
+	parse := opts.Parse
+	if parse == nil {
+		parse = NewTxParser(nil, nil)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultReparsePageSize
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &Reparser{
+		archive:  opts.Archive,
+		store:    opts.Store,
+		parse:    parse,
+		pageSize: pageSize,
+		dryRun:   opts.DryRun,
+		clock:    clock,
+		logger:   logger,
+	}
+}
+
+// Run reparses every archived transaction, ordered by (slot, tx_signature).
+// Store failures abort the run; transactions corrected so far stay corrected.
+func (r *Reparser) Run(ctx context.Context) (*ReparseResult, error) {
+	res := &ReparseResult{}
+	correctedAt := r.clock().UnixMilli()
+
+	afterSlot, afterSig := int64(0), ""
+	for {
+		page, err := r.archive.GetPage(ctx, afterSlot, afterSig, r.pageSize)
+		if err != nil {
+			return res, fmt.Errorf("read raw tx archive: %w", err)
+		}
+		if len(page) == 0 {
+			return res, nil
+		}
+
+		for _, raw := range page {
+			if err := ctx.Err(); err != nil {
+				return res, err
+			}
+			res.Transactions++
+
+			tx, err := solana.DecodeTransactionJSON(raw.Data)
+			if err != nil {
+				r.logger.Printf("Reparse: skip archived tx %s: %v", raw.TxSignature, err)
+				res.DecodeErrors++
+				continue
+			}
+			tx.Signature = raw.TxSignature
+
+			fix, err := r.diff(ctx, tx, correctedAt)
+			if err != nil {
+				return res, err
+			}
+			for _, c := range fix.Corrections {
+				switch c.Change {
+				case storage.ReparseAdded:
+					res.Added++
+				case storage.ReparseChanged:
+					res.Changed++
+				case storage.ReparseRemoved:
+					res.Removed++
+				}
+			}
+			res.Changes = append(res.Changes, fix.Corrections...)
+
+			if len(fix.InsertSwapEvents) == 0 && len(fix.InsertLiquidity) == 0 {
+				continue
+			}
+			res.Corrected++
+			if r.dryRun {
+				continue
+			}
+			fix.Corrections = appliedCorrections(fix.Corrections)
+			if err := r.store.Apply(ctx, fix); err != nil {
+				return res, fmt.Errorf("apply reparse of %s: %w", tx.Signature, err)
+			}
+		}
+
+		last := page[len(page)-1]
+		afterSlot, afterSig = last.Slot, last.TxSignature
+	}
+}
+
+// diff compares the parsed events of tx with the stored ones.
+func (r *Reparser) diff(ctx context.Context, tx *solana.Transaction, correctedAt int64) (*storage.ReparseFix, error) {
+	storedSwaps, storedLiq, err := r.store.GetTxEvents(ctx, tx.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("get stored events of %s: %w", tx.Signature, err)
+	}
+	parsedSwaps, parsedLiq := r.parse(ctx, tx)
+
+	fix := &storage.ReparseFix{TxSignature: tx.Signature}
+	correction := func(table string, index int, change string, before, after interface{}) {
+		fix.Corrections = append(fix.Corrections, &storage.ReparseCorrection{
+			EventTable:  table,
+			TxSignature: tx.Signature,
+			EventIndex:  index,
+			Change:      change,
+			Before:      eventJSON(before),
+			After:       eventJSON(after),
+			CorrectedAt: correctedAt,
+		})
+	}
+
+	swapsByIndex := make(map[int]*domain.SwapEvent, len(storedSwaps))
+	for _, e := range storedSwaps {
+		if _, ok := swapsByIndex[e.EventIndex]; !ok {
+			swapsByIndex[e.EventIndex] = e
+		}
+	}
+	for _, parsed := range parsedSwaps {
+		stored, ok := swapsByIndex[parsed.EventIndex]
+		delete(swapsByIndex, parsed.EventIndex)
+		switch {
+		case !ok:
+			fix.InsertSwapEvents = append(fix.InsertSwapEvents, parsed)
+			correction(storage.EventTableSwapEvents, parsed.EventIndex, storage.ReparseAdded, nil, parsed)
+		case !sameSwapEvent(stored, parsed):
+			// A corrected event keeps the origin it was ingested with
+			corrected := *parsed
+			corrected.Origin = stored.Origin
+			fix.ReplaceSwapEvents = append(fix.ReplaceSwapEvents, stored)
+			fix.InsertSwapEvents = append(fix.InsertSwapEvents, &corrected)
+			correction(storage.EventTableSwapEvents, parsed.EventIndex, storage.ReparseChanged, stored, &corrected)
+		}
+	}
+	for _, stored := range storedSwaps {
+		if swapsByIndex[stored.EventIndex] == stored {
+			correction(storage.EventTableSwapEvents, stored.EventIndex, storage.ReparseRemoved, stored, nil)
+		}
+	}
+
+	liqByIndex := make(map[int]*domain.LiquidityEvent, len(storedLiq))
+	for _, e := range storedLiq {
+		if _, ok := liqByIndex[e.EventIndex]; !ok {
+			liqByIndex[e.EventIndex] = e
+		}
+	}
+	for _, parsed := range parsedLiq {
+		stored, ok := liqByIndex[parsed.EventIndex]
+		delete(liqByIndex, parsed.EventIndex)
+		switch {
+		case !ok:
+			fix.InsertLiquidity = append(fix.InsertLiquidity, parsed)
+			correction(storage.EventTableLiquidityEvents, parsed.EventIndex, storage.ReparseAdded, nil, parsed)
+		case !sameLiquidityEvent(stored, parsed):
+			corrected := carryLiquidityState(stored, parsed)
+			fix.ReplaceLiquidity = append(fix.ReplaceLiquidity, stored)
+			fix.InsertLiquidity = append(fix.InsertLiquidity, corrected)
+			correction(storage.EventTableLiquidityEvents, parsed.EventIndex, storage.ReparseChanged, stored, corrected)
+		}
+	}
+	for _, stored := range storedLiq {
+		if liqByIndex[stored.EventIndex] == stored {
+			correction(storage.EventTableLiquidityEvents, stored.EventIndex, storage.ReparseRemoved, stored, nil)
+		}
+	}
+
+	return fix, nil
+}
+
+// sameSwapEvent compares the fields a swap event gets from parsing.
+func sameSwapEvent(a, b *domain.SwapEvent) bool {
+	return a.Mint == b.Mint &&
+		derefString(a.Pool) == derefString(b.Pool) &&
+		a.Slot == b.Slot &&
+		a.Timestamp == b.Timestamp &&
+		a.AmountOut == b.AmountOut &&
+		a.Side == b.Side &&
+		a.DEX == b.DEX &&
+		equalInt64Ptr(a.FeeLamports, b.FeeLamports) &&
+		equalInt64Ptr(a.PriorityFeeLamports, b.PriorityFeeLamports)
+}
+
+// sameLiquidityEvent compares the fields a liquidity event gets from parsing.
+// Candidate association and LiquidityAfter are assigned after parsing and ignored.
+func sameLiquidityEvent(a, b *domain.LiquidityEvent) bool {
+	return a.Pool == b.Pool &&
+		a.Mint == b.Mint &&
+		a.Slot == b.Slot &&
+		a.Timestamp == b.Timestamp &&
+		a.EventType == b.EventType &&
+		a.AmountToken == b.AmountToken &&
+		a.AmountQuote == b.AmountQuote &&
+		a.DEX == b.DEX
+}
+
+// carryLiquidityState returns parsed with the state assigned after parsing taken from stored:
+// the reserve-derived LiquidityAfter, the ingestion origin, and the candidate association
+// if the mint is unchanged.
+func carryLiquidityState(stored, parsed *domain.LiquidityEvent) *domain.LiquidityEvent {
+	corrected := *parsed
+	corrected.LiquidityAfter = stored.LiquidityAfter
+	corrected.Estimated = stored.Estimated
+	corrected.Origin = stored.Origin
+	if corrected.CandidateID == "" && stored.Mint == parsed.Mint {
+		corrected.CandidateID = stored.CandidateID
+	}
+	return &corrected
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// appliedCorrections drops REMOVED differences, which are reported but not audited
+// since nothing is changed for them.
+func appliedCorrections(cs []*storage.ReparseCorrection) []*storage.ReparseCorrection {
+	applied := make([]*storage.ReparseCorrection, 0, len(cs))
+	for _, c := range cs {
+		if c.Change != storage.ReparseRemoved {
+			applied = append(applied, c)
+		}
+	}
+	return applied
+}
+
+// eventJSON renders an event for the correction audit, "" for nil.
+func eventJSON(e interface{}) string {
+	if e == nil {
+		return ""
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,196 @@
+package ingestion
+
+import (
+	"context"
+	"io"
+	"log"
+	"math"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// pumpFunTx builds a successful transaction invoking pump.fun with the given log lines.
+func pumpFunTx(sig string, slot int64, lines ...string) *solana.Transaction {
+	logs := []string{"Program " + discovery.PumpFun + " invoke [1]"}
+	logs = append(logs, lines...)
+	logs = append(logs, "Program "+discovery.PumpFun+" success")
+	return &solana.Transaction{
+		Slot:      slot,
+		Signature: sig,
+		BlockTime: 1700000000 + slot,
+		Meta:      &solana.TransactionMeta{LogMessages: logs, Fee: 5000},
+		Message:   &solana.TransactionMessage{AccountKeys: []string{"payer", discovery.PumpFun}},
+	}
+}
+
+// brokenParser simulates a parser bug: swap amounts and sides are lost and
+// liquidity events are not recognized.
+func brokenParser(parse TxParser) TxParser {
+	return func(ctx context.Context, tx *solana.Transaction) ([]*domain.SwapEvent, []*domain.LiquidityEvent) {
+		swaps, _ := parse(ctx, tx)
+		for _, e := range swaps {
+			e.AmountOut = 0
+			e.Side = ""
+		}
+		return swaps, nil
+	}
+}
+
+func TestReparser_CorrectsEventsStoredByBrokenParser(t *testing.T) {
+	ctx := context.Background()
+
+	archive := memory.NewRawTxStore(0)
+	swapEvents := memory.NewSwapEventStore()
+	liquidity := memory.NewLiquidityEventStore()
+	store := memory.NewReparseStore(swapEvents, liquidity)
+
+	txs := []*solana.Transaction{
+		pumpFunTx("sigBuy", 100, "Program log: mint=MintA", "Program log: amount=500", "Program log: Instruction: Buy"),
+		pumpFunTx("sigCreate", 101, "Program log: mint=MintB", "Program log: Instruction: Create",
+			"Program log: amount=70", "Program log: Instruction: Sell"),
+	}
+
+	// Ingest with the broken parser, archiving the raw transactions
+	fixed := NewTxParser(nil, nil)
+	broken := brokenParser(fixed)
+	for _, tx := range txs {
+		archiveTx(ctx, archive, tx, tx.BlockTime)
+		swaps, liqs := broken(ctx, tx)
+		if err := swapEvents.InsertBulk(ctx, swaps); err != nil {
+			t.Fatalf("insert swap events: %v", err)
+		}
+		if err := liquidity.InsertBulk(ctx, liqs); err != nil {
+			t.Fatalf("insert liquidity events: %v", err)
+		}
+	}
+	if count, _, _ := archive.Stats(ctx); count != 2 {
+		t.Fatalf("expected 2 archived transactions, got %d", count)
+	}
+
+	now := time.UnixMilli(5_000_000)
+	reparser := NewReparser(ReparserOptions{
+		Archive:  archive,
+		Store:    store,
+		Parse:    fixed,
+		PageSize: 1,
+		Clock:    func() time.Time { return now },
+		Logger:   log.New(io.Discard, "", 0),
+	})
+
+	// A dry run reports the differences without touching the store
+	dry := NewReparser(ReparserOptions{Archive: archive, Store: store, Parse: fixed, DryRun: true, Logger: log.New(io.Discard, "", 0)})
+	dryRes, err := dry.Run(ctx)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if dryRes.Changed != 2 || dryRes.Added != 1 || dryRes.Corrected != 2 {
+		t.Errorf("unexpected dry run result: %+v", dryRes)
+	}
+	if corrections, _ := store.GetCorrections(ctx, 0, math.MaxInt64); len(corrections) != 0 {
+		t.Errorf("dry run recorded %d corrections", len(corrections))
+	}
+
+	res, err := reparser.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Transactions != 2 || res.Corrected != 2 || res.Changed != 2 || res.Added != 1 || res.Removed != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	// Stored events now match the fixed parser output
+	for _, tx := range txs {
+		wantSwaps, wantLiq := fixed(ctx, tx)
+		gotSwaps, gotLiq, err := store.GetTxEvents(ctx, tx.Signature)
+		if err != nil {
+			t.Fatalf("GetTxEvents: %v", err)
+		}
+		if len(gotSwaps) != len(wantSwaps) {
+			t.Fatalf("%s: expected %d swap events, got %d", tx.Signature, len(wantSwaps), len(gotSwaps))
+		}
+		for i := range wantSwaps {
+			if !sameSwapEvent(gotSwaps[i], wantSwaps[i]) {
+				t.Errorf("%s: swap event %d = %+v, want %+v", tx.Signature, i, gotSwaps[i], wantSwaps[i])
+			}
+		}
+		if len(gotLiq) != len(wantLiq) {
+			t.Fatalf("%s: expected %d liquidity events, got %d", tx.Signature, len(wantLiq), len(gotLiq))
+		}
+	}
+
+	all, err := swapEvents.GetByTimeRange(ctx, 0, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("GetByTimeRange: %v", err)
+	}
+	if len(all) != 2 || all[0].AmountOut != 500 || all[0].Side != domain.SwapSideBuy || all[1].Side != domain.SwapSideSell {
+		t.Errorf("unexpected corrected swap events: %+v %+v", all[0], all[1])
+	}
+
+	corrections, err := store.GetCorrections(ctx, 0, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("GetCorrections: %v", err)
+	}
+	if len(corrections) != 3 {
+		t.Fatalf("expected 3 recorded corrections, got %d", len(corrections))
+	}
+	for _, c := range corrections {
+		if c.CorrectedAt != now.UnixMilli() || c.After == "" {
+			t.Errorf("unexpected correction: %+v", c)
+		}
+		if c.Change == storage.ReparseChanged && c.Before == "" {
+			t.Errorf("CHANGED correction without before image: %+v", c)
+		}
+	}
+
+	// A second run finds nothing left to correct
+	again, err := reparser.Run(ctx)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if again.Corrected != 0 || len(again.Changes) != 0 {
+		t.Errorf("expected no changes on second run, got %+v", again)
+	}
+}
+
+func TestReparser_ReportsRemovedWithoutDeleting(t *testing.T) {
+	ctx := context.Background()
+
+	archive := memory.NewRawTxStore(0)
+	swapEvents := memory.NewSwapEventStore()
+	store := memory.NewReparseStore(swapEvents, memory.NewLiquidityEventStore())
+
+	tx := pumpFunTx("sigBuy", 100, "Program log: mint=MintA", "Program log: Instruction: Buy")
+	archiveTx(ctx, archive, tx, tx.BlockTime)
+	stale := &domain.SwapEvent{Mint: "MintA", TxSignature: "sigBuy", EventIndex: 9, Slot: 100, Timestamp: tx.BlockTime * 1000}
+	if err := swapEvents.Insert(ctx, stale); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	res, err := NewReparser(ReparserOptions{Archive: archive, Store: store, Logger: log.New(io.Discard, "", 0)}).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Removed != 1 || res.Added != 1 {
+		t.Errorf("expected 1 removed and 1 added, got %+v", res)
+	}
+
+	swaps, _, err := store.GetTxEvents(ctx, "sigBuy")
+	if err != nil {
+		t.Fatalf("GetTxEvents: %v", err)
+	}
+	if len(swaps) != 2 {
+		t.Errorf("expected the stale event kept next to the added one, got %d events", len(swaps))
+	}
+	corrections, _ := store.GetCorrections(ctx, 0, math.MaxInt64)
+	for _, c := range corrections {
+		if c.Change == storage.ReparseRemoved {
+			t.Errorf("REMOVED difference should not be recorded: %+v", c)
+		}
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"solana-token-lab/internal/discovery"
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/timeutil"
 )
 
 // Replayer replays discovery from stored events without RPC dependency.
@@ -17,7 +19,9 @@ type Replayer struct {
 	candidateStore   storage.CandidateStore
 	newTokenDetector *discovery.NewTokenDetector
 	activeDetector   *discovery.ActiveTokenDetector
+	activeInterval   time.Duration
 	batchSize        int
+	onProgress       func(ReplayProgress)
 	logger           *log.Logger
 }
 
@@ -27,8 +31,13 @@ type ReplayerOptions struct {
 	CandidateStore   storage.CandidateStore
 	NewTokenDetector *discovery.NewTokenDetector
 	ActiveDetector   *discovery.ActiveTokenDetector
-	BatchSize        int
+	BatchSize        int                  // events per NEW_TOKEN chunk (default: 10000)
+	OnProgress       func(ReplayProgress) // optional: called after each chunk and ACTIVE_TOKEN evaluation
 	Logger           *log.Logger
+
+	// ActiveInterval makes ReplayFull step ACTIVE_TOKEN detection through the range
+	// at this interval (see ReplayActiveTokenSteps). Zero evaluates once at the end.
+	ActiveInterval time.Duration
 }
 
 // NewReplayer creates a new discovery replayer.
@@ -48,7 +57,9 @@ func NewReplayer(opts ReplayerOptions) *Replayer {
 		candidateStore:   opts.CandidateStore,
 		newTokenDetector: opts.NewTokenDetector,
 		activeDetector:   opts.ActiveDetector,
+		activeInterval:   opts.ActiveInterval,
 		batchSize:        batchSize,
+		onProgress:       opts.OnProgress,
 		logger:           logger,
 	}
 }
@@ -58,12 +69,74 @@ type ReplayResult struct {
 	EventsProcessed      int
 	NewTokensDiscovered  int
 	ActiveTokensDiscovered int
+	ActiveEvaluations    int // ACTIVE_TOKEN DetectAt calls
 	Duration             time.Duration
+
+	// Daily counts the detections per UTC day of their discovered_at, oldest
+	// first. Days without detections are left out.
+	Daily []DailyDetections
+}
+
+// DailyDetections counts the candidates discovered on one UTC day.
+type DailyDetections struct {
+	Day          time.Time // UTC midnight
+	NewTokens    int
+	ActiveTokens int
+}
+
+// ReplayProgress is reported to ReplayerOptions.OnProgress as a replay advances.
+// During ReplayFull the counts accumulate over both passes.
+type ReplayProgress struct {
+	EventsProcessed int   // stored events run through NEW_TOKEN detection
+	EventsTotal     int   // stored events in the range
+	SimulatedTime   int64 // Unix ms: last processed event, or ACTIVE_TOKEN evaluation time
+	NewTokens       int
+	ActiveTokens    int
+}
+
+// addDaily counts candidates into the per-day breakdown, keeping it sorted.
+func addDaily(daily []DailyDetections, candidates []*domain.TokenCandidate) []DailyDetections {
+	for _, c := range candidates {
+		d := DailyDetections{Day: timeutil.TruncateToUTCDay(time.UnixMilli(c.DiscoveredAt))}
+		if c.Source == domain.SourceActiveToken {
+			d.ActiveTokens = 1
+		} else {
+			d.NewTokens = 1
+		}
+		daily = mergeDaily(daily, d)
+	}
+	return daily
+}
+
+// mergeDaily adds the counts of one day into the breakdown, keeping it sorted.
+func mergeDaily(daily []DailyDetections, d DailyDetections) []DailyDetections {
+	i := sort.Search(len(daily), func(i int) bool { return !daily[i].Day.Before(d.Day) })
+	if i == len(daily) || !daily[i].Day.Equal(d.Day) {
+		daily = append(daily, DailyDetections{})
+		copy(daily[i+1:], daily[i:])
+		daily[i] = DailyDetections{Day: d.Day}
+	}
+	daily[i].NewTokens += d.NewTokens
+	daily[i].ActiveTokens += d.ActiveTokens
+	return daily
+}
+
+// report passes a progress snapshot to the callback, if any.
+func (r *Replayer) report(p *ReplayProgress) {
+	if r.onProgress != nil {
+		r.onProgress(*p)
+	}
 }
 
 // ReplayDiscovery replays NEW_TOKEN discovery from stored events.
 // This runs without any RPC dependency - purely from storage.
 func (r *Replayer) ReplayDiscovery(ctx context.Context, from, to int64) (*ReplayResult, error) {
+	return r.replayDiscovery(ctx, from, to, &ReplayProgress{})
+}
+
+// replayDiscovery runs NEW_TOKEN detection in chunks of batchSize events,
+// reporting progress after each.
+func (r *Replayer) replayDiscovery(ctx context.Context, from, to int64, progress *ReplayProgress) (*ReplayResult, error) {
 	start := time.Now()
 	result := &ReplayResult{}
 
@@ -96,17 +169,32 @@ func (r *Replayer) ReplayDiscovery(ctx context.Context, from, to int64) (*Replay
 			EventIndex:  e.EventIndex,
 			Slot:        e.Slot,
 			Timestamp:   e.Timestamp,
+			DEX:         e.DEX,
 		}
 	}
 
-	// Run NEW_TOKEN detection
+	// Run NEW_TOKEN detection chunk by chunk; the detector keeps its state
+	// across chunks, so the result equals a single pass
 	if r.newTokenDetector != nil {
-		candidates, err := r.newTokenDetector.ProcessEvents(ctx, discoveryEvents)
-		if err != nil {
-			return result, fmt.Errorf("process events: %w", err)
+		progress.EventsTotal = len(discoveryEvents)
+		for lo := 0; lo < len(discoveryEvents); lo += r.batchSize {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			chunk := discoveryEvents[lo:min(lo+r.batchSize, len(discoveryEvents))]
+			candidates, err := r.newTokenDetector.ProcessEvents(ctx, chunk)
+			if err != nil {
+				return result, fmt.Errorf("process events: %w", err)
+			}
+			result.NewTokensDiscovered += len(candidates)
+			result.Daily = addDaily(result.Daily, candidates)
+
+			progress.EventsProcessed += len(chunk)
+			progress.SimulatedTime = chunk[len(chunk)-1].Timestamp
+			progress.NewTokens += len(candidates)
+			r.report(progress)
 		}
-		result.NewTokensDiscovered = len(candidates)
-		r.logger.Printf("NEW_TOKEN discovery: %d candidates", len(candidates))
+		r.logger.Printf("NEW_TOKEN discovery: %d candidates", result.NewTokensDiscovered)
 	}
 
 	result.Duration = time.Since(start)
@@ -117,6 +205,10 @@ func (r *Replayer) ReplayDiscovery(ctx context.Context, from, to int64) (*Replay
 
 // ReplayActiveTokenDetection replays ACTIVE_TOKEN spike detection at a specific timestamp.
 func (r *Replayer) ReplayActiveTokenDetection(ctx context.Context, timestamp int64) (*ReplayResult, error) {
+	return r.replayActiveTokenDetection(ctx, timestamp, &ReplayProgress{})
+}
+
+func (r *Replayer) replayActiveTokenDetection(ctx context.Context, timestamp int64, progress *ReplayProgress) (*ReplayResult, error) {
 	start := time.Now()
 	result := &ReplayResult{}
 
@@ -132,34 +224,111 @@ func (r *Replayer) ReplayActiveTokenDetection(ctx context.Context, timestamp int
 	}
 
 	result.ActiveTokensDiscovered = len(candidates)
+	result.ActiveEvaluations = 1
+	result.Daily = addDaily(result.Daily, candidates)
 	result.Duration = time.Since(start)
 
+	progress.SimulatedTime = timestamp
+	progress.ActiveTokens += len(candidates)
+	r.report(progress)
+
 	r.logger.Printf("ACTIVE_TOKEN detection: %d candidates in %v", len(candidates), result.Duration)
 
 	return result, nil
 }
 
+// ReplayActiveTokenSteps runs ACTIVE_TOKEN detection retroactively over stored
+// events, calling DetectAt at from+interval, from+2*interval, ... and finally at to,
+// as a live detector checking every interval would have. Each evaluation only sees
+// events before its evaluation time, and candidates take their timestamps from the
+// triggering events, so the result is the same as live detection at those times.
+// The detector must not have a live clock, which would stamp wall-clock DetectedAt.
+func (r *Replayer) ReplayActiveTokenSteps(ctx context.Context, from, to int64, interval time.Duration) (*ReplayResult, error) {
+	return r.replayActiveTokenSteps(ctx, from, to, interval, &ReplayProgress{})
+}
+
+func (r *Replayer) replayActiveTokenSteps(ctx context.Context, from, to int64, interval time.Duration, progress *ReplayProgress) (*ReplayResult, error) {
+	start := time.Now()
+	result := &ReplayResult{}
+
+	if r.activeDetector == nil {
+		return result, fmt.Errorf("no active detector configured")
+	}
+	step := interval.Milliseconds()
+	if step <= 0 {
+		return result, fmt.Errorf("invalid active detection interval %v", interval)
+	}
+
+	r.logger.Printf("Replaying ACTIVE_TOKEN detection from %d to %d every %v", from, to, interval)
+
+	// Start from an empty cache: mints seen before are re-checked against the store
+	r.activeDetector.Reset()
+
+	for evalTime := from + step; ; evalTime += step {
+		if evalTime > to {
+			evalTime = to
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		candidates, err := r.activeDetector.DetectAt(ctx, evalTime)
+		if err != nil {
+			return result, fmt.Errorf("detect at %d: %w", evalTime, err)
+		}
+		result.ActiveTokensDiscovered += len(candidates)
+		result.ActiveEvaluations++
+		result.Daily = addDaily(result.Daily, candidates)
+
+		progress.SimulatedTime = evalTime
+		progress.ActiveTokens += len(candidates)
+		r.report(progress)
+
+		if evalTime >= to {
+			break
+		}
+	}
+
+	result.Duration = time.Since(start)
+	r.logger.Printf("ACTIVE_TOKEN detection: %d candidates over %d evaluations in %v",
+		result.ActiveTokensDiscovered, result.ActiveEvaluations, result.Duration)
+
+	return result, nil
+}
+
 // ReplayFull replays both NEW_TOKEN and ACTIVE_TOKEN discovery for a time range.
+// Daily holds the detections of both.
 func (r *Replayer) ReplayFull(ctx context.Context, from, to int64) (*ReplayResult, error) {
 	start := time.Now()
 	result := &ReplayResult{}
+	progress := &ReplayProgress{}
 
 	// First, replay NEW_TOKEN discovery
-	newResult, err := r.ReplayDiscovery(ctx, from, to)
+	newResult, err := r.replayDiscovery(ctx, from, to, progress)
 	if err != nil {
 		return result, fmt.Errorf("replay new token: %w", err)
 	}
 	result.EventsProcessed = newResult.EventsProcessed
 	result.NewTokensDiscovered = newResult.NewTokensDiscovered
+	result.Daily = newResult.Daily
 
-	// Then, run ACTIVE_TOKEN detection at the end of the range
+	// Then, run ACTIVE_TOKEN detection through the range, or at its end
 	if r.activeDetector != nil {
-		activeResult, err := r.ReplayActiveTokenDetection(ctx, to)
+		var activeResult *ReplayResult
+		if r.activeInterval > 0 {
+			activeResult, err = r.replayActiveTokenSteps(ctx, from, to, r.activeInterval, progress)
+		} else {
+			activeResult, err = r.replayActiveTokenDetection(ctx, to, progress)
+		}
 		if err != nil {
 			// Log but don't fail - ACTIVE_TOKEN detection is supplementary
 			r.logger.Printf("Warning: ACTIVE_TOKEN detection failed: %v", err)
 		} else {
 			result.ActiveTokensDiscovered = activeResult.ActiveTokensDiscovered
+			result.ActiveEvaluations = activeResult.ActiveEvaluations
+			for _, d := range activeResult.Daily {
+				result.Daily = mergeDaily(result.Daily, d)
+			}
 		}
 	}
 
@@ -237,6 +406,7 @@ func ReplayDiscoverySimple(ctx context.Context, store storage.SwapEventStore, de
 			EventIndex:  e.EventIndex,
 			Slot:        e.Slot,
 			Timestamp:   e.Timestamp,
+			DEX:         e.DEX,
 		}
 	}
 
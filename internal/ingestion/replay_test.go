@@ -0,0 +1,219 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+const replayT0 int64 = 1700000000000
+
+// activityStream returns swaps of mint every 10 minutes over [startH, endH) hours
+// after replayT0, then a burst of 30 large swaps at burstH (skipped if negative).
+func activityStream(mint string, startH, endH, burstH int64) []*domain.SwapEvent {
+	hour := time.Hour.Milliseconds()
+	var events []*domain.SwapEvent
+	add := func(ts int64, amount float64) {
+		events = append(events, &domain.SwapEvent{
+			Mint:        mint,
+			TxSignature: fmt.Sprintf("%s-%d", mint, len(events)),
+			Slot:        (ts - replayT0) / 400,
+			Timestamp:   ts,
+			AmountOut:   amount,
+		})
+	}
+	for ts := replayT0 + startH*hour; ts < replayT0+endH*hour; ts += 10 * time.Minute.Milliseconds() {
+		add(ts, 1)
+	}
+	if burstH >= 0 {
+		for i := int64(0); i < 30; i++ {
+			add(replayT0+burstH*hour+i*20000, 10)
+		}
+	}
+	return events
+}
+
+func activeCandidates(t *testing.T, store *memory.CandidateStore) []*domain.TokenCandidate {
+	t.Helper()
+	candidates, err := store.GetBySource(context.Background(), domain.SourceActiveToken)
+	if err != nil {
+		t.Fatalf("GetBySource: %v", err)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].CandidateID < candidates[j].CandidateID })
+	return candidates
+}
+
+func TestReplayer_ActiveTokenStepsMatchLive(t *testing.T) {
+	ctx := context.Background()
+	hour := time.Hour.Milliseconds()
+	from, to := replayT0, replayT0+30*hour
+
+	var events []*domain.SwapEvent
+	events = append(events, activityStream("burst-early", 0, 6, 6)...)
+	events = append(events, activityStream("steady", 0, 30, -1)...)
+	events = append(events, activityStream("burst-late", 10, 13, 13)...)
+	SortSwapEvents(events)
+
+	// Simulated live run: events are stored as they arrive and checked every hour
+	liveSwaps := memory.NewSwapEventStore()
+	liveCandidates := memory.NewCandidateStore()
+	live := discovery.NewActiveDetector(discovery.DefaultActiveConfig(), liveSwaps, liveCandidates)
+	next := 0
+	for evalTime := from + hour; evalTime <= to; evalTime += hour {
+		for ; next < len(events) && events[next].Timestamp <= evalTime; next++ {
+			if err := liveSwaps.Insert(ctx, events[next]); err != nil {
+				t.Fatalf("Insert: %v", err)
+			}
+		}
+		if _, err := live.DetectAt(ctx, evalTime); err != nil {
+			t.Fatalf("live DetectAt(%d): %v", evalTime, err)
+		}
+	}
+
+	// Retroactive run over the fully stored stream
+	swaps := memory.NewSwapEventStore()
+	if err := swaps.InsertBulk(ctx, events); err != nil {
+		t.Fatalf("InsertBulk: %v", err)
+	}
+	candidates := memory.NewCandidateStore()
+	replayer := NewReplayer(ReplayerOptions{
+		SwapEventStore: swaps,
+		CandidateStore: candidates,
+		ActiveDetector: discovery.NewActiveDetector(discovery.DefaultActiveConfig(), swaps, candidates),
+		Logger:         log.New(io.Discard, "", 0),
+	})
+	result, err := replayer.ReplayActiveTokenSteps(ctx, from, to, time.Hour)
+	if err != nil {
+		t.Fatalf("ReplayActiveTokenSteps: %v", err)
+	}
+	if result.ActiveEvaluations != 30 || result.ActiveTokensDiscovered != 2 {
+		t.Errorf("expected 2 candidates over 30 evaluations, got %+v", result)
+	}
+
+	want := activeCandidates(t, liveCandidates)
+	got := activeCandidates(t, candidates)
+	if len(want) != 2 {
+		t.Fatalf("expected the live run to detect both bursts, got %d candidates", len(want))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stepped candidates differ from live:\ngot:  %+v\nwant: %+v", got, want)
+	}
+	for _, c := range got {
+		if c.DetectedAt != nil {
+			t.Errorf("candidate %s: expected no wall-clock DetectedAt, got %d", c.Mint, *c.DetectedAt)
+		}
+	}
+
+	// A single evaluation at the end of the range misses both bursts
+	endOnly := memory.NewCandidateStore()
+	single := NewReplayer(ReplayerOptions{
+		SwapEventStore: swaps,
+		CandidateStore: endOnly,
+		ActiveDetector: discovery.NewActiveDetector(discovery.DefaultActiveConfig(), swaps, endOnly),
+		Logger:         log.New(io.Discard, "", 0),
+	})
+	if result, err := single.ReplayActiveTokenDetection(ctx, to); err != nil || result.ActiveTokensDiscovered != 0 {
+		t.Errorf("expected no candidates from the end-of-range check, got %+v, %v", result, err)
+	}
+}
+
+func TestReplayer_ActiveTokenStepsClampToRangeEnd(t *testing.T) {
+	ctx := context.Background()
+	swaps := memory.NewSwapEventStore()
+	candidates := memory.NewCandidateStore()
+	replayer := NewReplayer(ReplayerOptions{
+		SwapEventStore: swaps,
+		CandidateStore: candidates,
+		ActiveDetector: discovery.NewActiveDetector(discovery.DefaultActiveConfig(), swaps, candidates),
+		Logger:         log.New(io.Discard, "", 0),
+	})
+
+	// 2.5 intervals: steps at +1h, +2h and the range end
+	result, err := replayer.ReplayActiveTokenSteps(ctx, replayT0, replayT0+5*time.Hour.Milliseconds()/2, time.Hour)
+	if err != nil {
+		t.Fatalf("ReplayActiveTokenSteps: %v", err)
+	}
+	if result.ActiveEvaluations != 3 {
+		t.Errorf("expected 3 evaluations, got %d", result.ActiveEvaluations)
+	}
+
+	if _, err := replayer.ReplayActiveTokenSteps(ctx, replayT0, replayT0+1, 0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
+
+func TestReplayer_ReplayFullDailyBreakdown(t *testing.T) {
+	ctx := context.Background()
+	hour := time.Hour.Milliseconds()
+	from, to := replayT0, replayT0+48*hour // 2023-11-14 22:13 UTC to 2023-11-16 22:13 UTC
+
+	swaps := memory.NewSwapEventStore()
+	var events []*domain.SwapEvent
+	events = append(events, activityStream("day0", 0, 1, -1)...)
+	events = append(events, activityStream("day1a", 3, 4, -1)...)
+	events = append(events, activityStream("day1b", 20, 21, -1)...)
+	events = append(events, activityStream("day2", 30, 31, -1)...)
+	events = append(events, activityStream("day2b", 26, 27, -1)...)
+	events = append(events, activityStream("burst", 0, 40, 40)...) // ACTIVE_TOKEN on day 2
+	for _, e := range events {
+		if err := swaps.Insert(ctx, e); err != nil {
+			t.Fatalf("Insert: %v", err)
+		}
+	}
+
+	candidates := memory.NewCandidateStore()
+	var progress []ReplayProgress
+	replayer := NewReplayer(ReplayerOptions{
+		SwapEventStore: swaps,
+		CandidateStore: candidates,
+		// Blacklisting keeps burst from NEW_TOKEN, so ACTIVE_TOKEN can pick it up
+		NewTokenDetector: discovery.NewDetector(candidates).
+			WithMintFilter(discovery.NewMintFilter(discovery.MintList{Mints: []string{"burst"}}, discovery.MintList{})),
+		ActiveDetector: discovery.NewActiveDetector(discovery.DefaultActiveConfig(), swaps, candidates),
+		ActiveInterval: time.Hour,
+		BatchSize:      10,
+		OnProgress:     func(p ReplayProgress) { progress = append(progress, p) },
+		Logger:         log.New(io.Discard, "", 0),
+	})
+
+	result, err := replayer.ReplayFull(ctx, from, to)
+	if err != nil {
+		t.Fatalf("ReplayFull: %v", err)
+	}
+	day := func(d int) time.Time { return time.Date(2023, 11, 14+d, 0, 0, 0, 0, time.UTC) }
+	want := []DailyDetections{
+		{Day: day(0), NewTokens: 1},
+		{Day: day(1), NewTokens: 2},
+		{Day: day(2), NewTokens: 2, ActiveTokens: 1},
+	}
+	if !reflect.DeepEqual(result.Daily, want) {
+		t.Errorf("Daily = %+v, want %+v", result.Daily, want)
+	}
+	if result.NewTokensDiscovered != 5 || result.ActiveTokensDiscovered != 1 {
+		t.Errorf("expected 5 NEW_TOKEN and 1 ACTIVE_TOKEN, got %+v", result)
+	}
+
+	// One callback per 10-event chunk, then one per hourly evaluation
+	chunks := (len(events) + 9) / 10
+	if len(progress) != chunks+48 {
+		t.Fatalf("expected %d progress callbacks, got %d", chunks+48, len(progress))
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i].SimulatedTime < progress[i-1].SimulatedTime && i != chunks {
+			t.Errorf("simulated time went back at callback %d: %d after %d", i, progress[i].SimulatedTime, progress[i-1].SimulatedTime)
+		}
+	}
+	last := progress[len(progress)-1]
+	if last != (ReplayProgress{EventsProcessed: len(events), EventsTotal: len(events), SimulatedTime: to, NewTokens: 5, ActiveTokens: 1}) {
+		t.Errorf("unexpected final progress %+v", last)
+	}
+}
@@ -0,0 +1,167 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/mr-tron/base58"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+)
+
+// reserveSeeder returns the current token and quote reserves of a pool.
+// ok is false when the reserves cannot be determined.
+type reserveSeeder func(ctx context.Context, pool string) (token, quote float64, ok bool, err error)
+
+// poolReserves is the running reserve estimate of one pool.
+type poolReserves struct {
+	token float64
+	quote float64
+}
+
+// reserveEstimator fills LiquidityAfter for live liquidity events whose pool
+// reserves are not measured, by keeping a running per-pool reserve estimate.
+//
+// A pool is seeded from its creation event, otherwise from a one-shot vault
+// lookup (seed), otherwise from its first observed add. Subsequent adds and
+// removes apply their amounts as deltas. Swaps also move reserves but are not
+// seen here, so the estimate drifts for actively traded pools.
+type reserveEstimator struct {
+	seed reserveSeeder // nil disables vault lookups
+
+	mu       sync.Mutex
+	reserves map[string]*poolReserves
+	seeded   map[string]bool // pools whose vault lookup was attempted
+}
+
+// newReserveEstimator creates an estimator. seed may be nil.
+func newReserveEstimator(seed reserveSeeder) *reserveEstimator {
+	return &reserveEstimator{
+		seed:     seed,
+		reserves: make(map[string]*poolReserves),
+		seeded:   make(map[string]bool),
+	}
+}
+
+// apply updates the pool estimate with e and sets e.LiquidityAfter and e.Estimated.
+// Events that already carry a measured LiquidityAfter, or have no pool, are left as is.
+func (r *reserveEstimator) apply(ctx context.Context, e *domain.LiquidityEvent) {
+	if e.Pool == "" || e.LiquidityAfter != 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res, known := r.reserves[e.Pool]
+	if !known {
+		res = r.seedPool(ctx, e)
+		if res == nil {
+			return
+		}
+		r.reserves[e.Pool] = res
+	} else {
+		switch e.EventType {
+		case domain.LiquidityEventAdd:
+			res.token += e.AmountToken
+			res.quote += e.AmountQuote
+		case domain.LiquidityEventRemove:
+			res.token = max(res.token-e.AmountToken, 0)
+			res.quote = max(res.quote-e.AmountQuote, 0)
+		default:
+			return
+		}
+	}
+
+	e.LiquidityAfter = res.token + res.quote
+	e.Estimated = true
+}
+
+// seedPool returns the reserves after e for a pool seen for the first time,
+// or nil if they cannot be determined yet. Called with r.mu held.
+func (r *reserveEstimator) seedPool(ctx context.Context, e *domain.LiquidityEvent) *poolReserves {
+	// Pool creation deposits the initial reserves
+	if e.PoolInit {
+		return &poolReserves{token: e.AmountToken, quote: e.AmountQuote}
+	}
+
+	// The vault balances are read after the event landed, so they already include it
+	if r.seed != nil && !r.seeded[e.Pool] {
+		r.seeded[e.Pool] = true
+		token, quote, ok, err := r.seed(ctx, e.Pool)
+		if err == nil && ok {
+			return &poolReserves{token: token, quote: quote}
+		}
+	}
+
+	if e.EventType == domain.LiquidityEventAdd {
+		return &poolReserves{token: e.AmountToken, quote: e.AmountQuote}
+	}
+	return nil
+}
+
+// Raydium AMM v4 AmmInfo account offsets of the vault addresses.
+const (
+	raydiumAmmCoinVaultOffset = 336
+	raydiumAmmPcVaultOffset   = 368
+)
+
+// rpcVaultSeeder reads Raydium AMM v4 pool reserves from the pool's vault token accounts.
+func rpcVaultSeeder(rpc *solana.HTTPClient) reserveSeeder {
+	return func(ctx context.Context, pool string) (float64, float64, bool, error) {
+		info, err := rpc.GetAccountInfo(ctx, pool)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if info == nil || info.Data == "" {
+			return 0, 0, false, nil
+		}
+		data, err := base64.StdEncoding.DecodeString(info.Data)
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("decode amm account: %w", err)
+		}
+		if len(data) < raydiumAmmPcVaultOffset+32 {
+			return 0, 0, false, nil
+		}
+
+		var token, quote float64
+		var haveQuote, haveToken bool
+		for _, offset := range []int{raydiumAmmCoinVaultOffset, raydiumAmmPcVaultOffset} {
+			vault := base58.Encode(data[offset : offset+32])
+			mint, amount, err := fetchTokenAccountBalance(ctx, rpc, vault)
+			if err != nil {
+				return 0, 0, false, err
+			}
+			if mint == wsolMint {
+				quote, haveQuote = amount, true
+			} else if mint != "" {
+				token, haveToken = amount, true
+			}
+		}
+		return token, quote, haveToken && haveQuote, nil
+	}
+}
+
+// fetchTokenAccountBalance returns the mint and raw amount of an SPL token account.
+// Token account layout: mint(32) | owner(32) | amount(8) | ...
+func fetchTokenAccountBalance(ctx context.Context, rpc *solana.HTTPClient, tokenAccount string) (string, float64, error) {
+	info, err := rpc.GetAccountInfo(ctx, tokenAccount)
+	if err != nil {
+		return "", 0, err
+	}
+	if info == nil || info.Data == "" {
+		return "", 0, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(info.Data)
+	if err != nil {
+		return "", 0, fmt.Errorf("decode token account data: %w", err)
+	}
+	if len(data) < 72 {
+		return "", 0, fmt.Errorf("token account data too short: %d", len(data))
+	}
+	return base58.Encode(data[:32]), float64(binary.LittleEndian.Uint64(data[64:72])), nil
+}
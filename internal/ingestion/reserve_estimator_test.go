@@ -0,0 +1,84 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func TestReserveEstimator_CumulativeDeltas(t *testing.T) {
+	ctx := context.Background()
+
+	var seedCalls []string
+	seed := func(_ context.Context, pool string) (float64, float64, bool, error) {
+		seedCalls = append(seedCalls, pool)
+		if pool == "poolVault" {
+			return 1000, 50, true, nil
+		}
+		return 0, 0, false, errors.New("rpc unavailable")
+	}
+	est := newReserveEstimator(seed)
+
+	liq := func(pool, eventType string, token, quote float64) *domain.LiquidityEvent {
+		return &domain.LiquidityEvent{Pool: pool, EventType: eventType, AmountToken: token, AmountQuote: quote}
+	}
+
+	tests := []struct {
+		name  string
+		event *domain.LiquidityEvent
+		want  float64
+		est   bool
+	}{
+		// Vault lookup seeds the pool; the event is already included in the balances
+		{"vault seed", liq("poolVault", domain.LiquidityEventAdd, 100, 5), 1050, true},
+		{"add", liq("poolVault", domain.LiquidityEventAdd, 200, 10), 1260, true},
+		{"remove", liq("poolVault", domain.LiquidityEventRemove, 300, 15), 945, true},
+		{"remove clamps at zero", liq("poolVault", domain.LiquidityEventRemove, 5000, 100), 0, true},
+
+		// Failed lookup on a remove leaves the event unestimated
+		{"unseeded remove", liq("poolAdd", domain.LiquidityEventRemove, 10, 1), 0, false},
+		// First add seeds the pool
+		{"first add seed", liq("poolAdd", domain.LiquidityEventAdd, 400, 20), 420, true},
+		{"add after add seed", liq("poolAdd", domain.LiquidityEventAdd, 100, 5), 525, true},
+
+		// Measured values and pool-less events are left alone
+		{"measured", &domain.LiquidityEvent{Pool: "poolAdd", EventType: domain.LiquidityEventAdd, AmountToken: 1, LiquidityAfter: 77}, 77, false},
+		{"no pool", liq("", domain.LiquidityEventAdd, 1, 1), 0, false},
+	}
+
+	for _, tt := range tests {
+		est.apply(ctx, tt.event)
+		if tt.event.LiquidityAfter != tt.want || tt.event.Estimated != tt.est {
+			t.Errorf("%s: got LiquidityAfter=%v Estimated=%v, want %v/%v",
+				tt.name, tt.event.LiquidityAfter, tt.event.Estimated, tt.want, tt.est)
+		}
+	}
+
+	// One vault lookup per pool, even after it failed
+	if len(seedCalls) != 2 || seedCalls[0] != "poolVault" || seedCalls[1] != "poolAdd" {
+		t.Errorf("expected one seed call per pool, got %v", seedCalls)
+	}
+}
+
+func TestReserveEstimator_PoolInitSeedsWithoutLookup(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	est := newReserveEstimator(func(context.Context, string) (float64, float64, bool, error) {
+		calls++
+		return 0, 0, false, nil
+	})
+
+	init := &domain.LiquidityEvent{Pool: "p", EventType: domain.LiquidityEventAdd, AmountToken: 1000, AmountQuote: 10, PoolInit: true}
+	est.apply(ctx, init)
+	remove := &domain.LiquidityEvent{Pool: "p", EventType: domain.LiquidityEventRemove, AmountToken: 500, AmountQuote: 5}
+	est.apply(ctx, remove)
+
+	if init.LiquidityAfter != 1010 || remove.LiquidityAfter != 505 || !remove.Estimated {
+		t.Errorf("unexpected estimates: init=%v remove=%v", init.LiquidityAfter, remove.LiquidityAfter)
+	}
+	if calls != 0 {
+		t.Errorf("expected no vault lookup for a created pool, got %d", calls)
+	}
+}
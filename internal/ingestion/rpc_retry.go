@@ -0,0 +1,64 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"solana-token-lab/internal/solana"
+)
+
+const (
+	maxRetries     = 3
+	baseRetryDelay = 500 * time.Millisecond
+)
+
+// retryRPC calls fn until it succeeds or fails with an error that is not
+// transient (see solana.IsRetryable), backing off exponentially between
+// attempts: 500ms, 1s, 2s. Context cancellation is returned as is.
+func retryRPC[T any](ctx context.Context, desc string, fn func() (T, error)) (T, error) {
+	var zero T
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		v, err := fn()
+		if err == nil {
+			return v, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		// Not found, invalid params and the like will not change on retry
+		if !solana.IsRetryable(err) {
+			return zero, err
+		}
+		if attempt == maxRetries-1 {
+			break
+		}
+
+		delay := baseRetryDelay * time.Duration(1<<attempt)
+		log.Printf("[rpc] Retry %d/%d for %s after %v: %v", attempt+1, maxRetries, desc, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+	return zero, lastErr
+}
+
+// retryGetTransaction fetches a transaction, retrying transient RPC failures.
+// A transaction the node reports as unavailable is returned as nil, like a
+// transaction that does not exist.
+func retryGetTransaction(ctx context.Context, rpc *solana.HTTPClient, signature string) (*solana.Transaction, error) {
+	tx, err := retryRPC(ctx, "GetTransaction "+signature, func() (*solana.Transaction, error) {
+		return rpc.GetTransaction(ctx, signature)
+	})
+	if errors.Is(err, solana.ErrNotFound) {
+		return nil, nil
+	}
+	return tx, err
+}
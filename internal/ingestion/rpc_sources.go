@@ -24,17 +24,54 @@ type RPCSwapEventSource struct {
 	rpc      *solana.HTTPClient
 	parser   *discovery.DEXParser
 	programs []string // DEX program IDs to monitor
+	progress *BackfillProgress
+	archive  storage.RawTxStore // optional raw transaction archive
+	failedTx *FailedTxTracker   // optional failed transaction counts
+
+	// overshoot is how far before the window start pagination continues
+	overshoot time.Duration
 }
 
 // NewRPCSwapEventSource creates a new RPC-based swap event source.
 func NewRPCSwapEventSource(rpc *solana.HTTPClient, programs []string) *RPCSwapEventSource {
 	return &RPCSwapEventSource{
-		rpc:      rpc,
-		parser:   discovery.NewDEXParser(),
-		programs: programs,
+		rpc:       rpc,
+		parser:    discovery.NewDEXParser(),
+		programs:  programs,
+		overshoot: DefaultSignatureOvershoot,
 	}
 }
 
+// WithOvershoot sets how far before the window start signature pagination continues.
+func (s *RPCSwapEventSource) WithOvershoot(d time.Duration) *RPCSwapEventSource {
+	s.overshoot = d
+	return s
+}
+
+// WithArchive stores every fetched transaction in archive for audit and reparse.
+func (s *RPCSwapEventSource) WithArchive(archive storage.RawTxStore) *RPCSwapEventSource {
+	s.archive = archive
+	return s
+}
+
+// WithFailedTxTracker counts the in-window transactions of every scan in
+// tracker, failed ones included, under the source name "rpc-swap".
+func (s *RPCSwapEventSource) WithFailedTxTracker(tracker *FailedTxTracker) *RPCSwapEventSource {
+	s.failedTx = tracker
+	return s
+}
+
+// SetProgress attaches a backfill progress tracker (nil detaches).
+// Must not be called while Fetch is running.
+func (s *RPCSwapEventSource) SetProgress(p *BackfillProgress) {
+	s.progress = p
+}
+
+// Programs returns the DEX program IDs scanned by Fetch.
+func (s *RPCSwapEventSource) Programs() []string {
+	return s.programs
+}
+
 // Fetch returns swap events for a time range [from, to) in milliseconds.
 // It queries each program for signatures and fetches transactions.
 func (s *RPCSwapEventSource) Fetch(ctx context.Context, from, to int64) ([]*domain.SwapEvent, error) {
@@ -42,6 +79,7 @@ func (s *RPCSwapEventSource) Fetch(ctx context.Context, from, to int64) ([]*doma
 
 	for _, program := range s.programs {
 		events, err := s.fetchForProgram(ctx, program, from, to)
+		s.progress.FinishScan()
 		if err != nil {
 			return nil, fmt.Errorf("fetch for program %s: %w", program, err)
 		}
@@ -61,119 +99,105 @@ func (s *RPCSwapEventSource) fetchForProgram(ctx context.Context, program string
 	toSec := to / 1000
 
 	var allEvents []*domain.SwapEvent
-	var before string
 
-	for {
-		// Get signatures for the program
-		opts := &solana.SignaturesOpts{
-			Limit: 1000,
-		}
-		if before != "" {
-			opts.Before = before
-		}
+	scan := &signatureScan{
+		rpc:       s.rpc,
+		program:   program,
+		fromSec:   fromSec,
+		toSec:     toSec,
+		overshoot: s.overshoot,
+		progress:  s.progress,
+		failedTx:  s.failedTx,
+		source:    failedTxSourceRPCSwap,
+	}
+	err := scan.run(ctx, func(tx *solana.Transaction, blockTime int64) error {
+		archiveTx(ctx, s.archive, tx, blockTime)
+		allEvents = append(allEvents, s.parseTx(ctx, program, tx, blockTime)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		sigs, err := s.rpc.GetSignaturesForAddress(ctx, program, opts)
-		if err != nil {
-			return nil, fmt.Errorf("get signatures: %w", err)
-		}
+	return allEvents, nil
+}
 
-		if len(sigs) == 0 {
-			break
+// parseTx extracts the swap events of one successful transaction attributed to program.
+// Events whose mint cannot be resolved are dropped.
+func (s *RPCSwapEventSource) parseTx(ctx context.Context, program string, tx *solana.Transaction, blockTime int64) []*domain.SwapEvent {
+	// Parse swap events from logs using V2 parser with account keys
+	timestamp := blockTime * 1000 // Convert to milliseconds
+
+	// Get account keys from transaction message
+	var accountKeys []string
+	if tx.Message != nil {
+		accountKeys = tx.Message.AccountKeys
+	}
+
+	// Use V2 parser to extract mint/pool from account keys
+	swapEvents := s.parser.ParseSwapEventsV2(
+		tx.Meta.LogMessages,
+		accountKeys,
+		tx.Signature,
+		tx.Slot,
+		timestamp,
+	)
+
+	inferredPool, inferredMint := "", ""
+	if needsRaydiumInference(tx.Meta.LogMessages, swapEvents) {
+		pool, mint, err := inferRaydiumPoolAndMint(ctx, s.rpc, accountKeys)
+		if err == nil {
+			inferredPool = pool
+			inferredMint = mint
 		}
+	}
 
-		// Process each signature
-		for _, sig := range sigs {
-			// Skip if outside time range
-			if sig.BlockTime == nil {
-				continue
-			}
-			blockTime := *sig.BlockTime
-			if blockTime < fromSec || blockTime >= toSec {
-				// If we've gone past the time range, stop
-				if blockTime < fromSec {
-					return allEvents, nil
-				}
-				continue
-			}
-
-			// Skip failed transactions
-			if sig.Err != nil {
-				continue
-			}
-
-			// Fetch full transaction
-			tx, err := s.rpc.GetTransaction(ctx, sig.Signature)
-			if err != nil {
-				return nil, fmt.Errorf("get transaction %s: %w", sig.Signature, err)
-			}
-
-			if tx == nil || tx.Meta == nil {
-				continue
-			}
-
-			// Parse swap events from logs using V2 parser with account keys
-			timestamp := blockTime * 1000 // Convert to milliseconds
-
-			// Get account keys from transaction message
-			var accountKeys []string
-			if tx.Message != nil {
-				accountKeys = tx.Message.AccountKeys
-			}
+	feeLamports, priorityFeeLamports := txFees(tx)
 
-			// Use V2 parser to extract mint/pool from account keys
-			swapEvents := s.parser.ParseSwapEventsV2(
-				tx.Meta.LogMessages,
-				accountKeys,
-				tx.Signature,
-				tx.Slot,
-				timestamp,
-			)
-
-			inferredPool, inferredMint := "", ""
-			if needsRaydiumInference(tx.Meta.LogMessages, swapEvents) {
-				pool, mint, err := inferRaydiumPoolAndMint(ctx, s.rpc, accountKeys)
-				if err == nil {
-					inferredPool = pool
-					inferredMint = mint
-				}
-			}
-
-			// Convert to domain.SwapEvent
-			for _, se := range swapEvents {
-				if se.Mint == "" && inferredMint != "" {
-					se.Mint = inferredMint
-				}
-				if se.Pool == nil && inferredPool != "" {
-					pool := inferredPool
-					se.Pool = &pool
-				}
-				if se.Mint == "" {
-					continue // Skip events without mint
-				}
-				event := &domain.SwapEvent{
-					Mint:        se.Mint,
-					Pool:        se.Pool,
-					TxSignature: se.TxSignature,
-					EventIndex:  se.EventIndex,
-					Slot:        se.Slot,
-					Timestamp:   se.Timestamp,
-					AmountOut:   se.AmountOut,
-				}
-				allEvents = append(allEvents, event)
-			}
+	// Convert to domain.SwapEvent
+	var events []*domain.SwapEvent
+	for _, se := range swapEvents {
+		if se.Mint == "" && inferredMint != "" {
+			se.Mint = inferredMint
 		}
-
-		// Paginate
-		before = sigs[len(sigs)-1].Signature
-
-		// Check if we should continue
-		lastSig := sigs[len(sigs)-1]
-		if lastSig.BlockTime != nil && *lastSig.BlockTime < fromSec {
-			break
+		if se.Pool == nil && inferredPool != "" {
+			pool := inferredPool
+			se.Pool = &pool
+		}
+		if se.Mint == "" {
+			continue // Skip events without mint
+		}
+		event := &domain.SwapEvent{
+			Mint:        se.Mint,
+			Pool:        se.Pool,
+			TxSignature: se.TxSignature,
+			EventIndex:  se.EventIndex,
+			Slot:        se.Slot,
+			Timestamp:   se.Timestamp,
+			AmountOut:   se.AmountOut,
+			Side:        se.Side,
+			DEX:         se.DEX,
+			Trader:      se.Trader,
+			Origin:      domain.OriginBackfill,
+
+			FeeLamports:         feeLamports,
+			PriorityFeeLamports: priorityFeeLamports,
 		}
+		events = append(events, event)
+		s.progress.AddSwapEvents(program, 1)
 	}
+	return events
+}
 
-	return allEvents, nil
+// txFees returns the fee paid and the ComputeBudget priority fee of tx, in lamports.
+// Both are nil when the transaction meta is missing.
+func txFees(tx *solana.Transaction) (fee, priorityFee *int64) {
+	if tx == nil || tx.Meta == nil {
+		return nil, nil
+	}
+	total := int64(tx.Meta.Fee)
+	priority := int64(solana.ParseComputeBudget(tx.Message).PriorityFeeLamports())
+	return &total, &priority
 }
 
 // RPCLiquidityEventSource fetches liquidity events from Solana RPC.
@@ -182,6 +206,11 @@ type RPCLiquidityEventSource struct {
 	parser   *discovery.DEXParser
 	programs []string
 	candidates storage.CandidateStore
+	progress *BackfillProgress
+	archive  storage.RawTxStore
+	overshoot time.Duration
+	links    storage.PoolLinkStore
+	failedTx *FailedTxTracker
 }
 
 // NewRPCLiquidityEventSource creates a new RPC-based liquidity event source.
@@ -196,19 +225,95 @@ func NewRPCLiquidityEventSource(rpc *solana.HTTPClient, programs []string, store
 		parser:     discovery.NewDEXParser(),
 		programs:   programs,
 		candidates: candidateStore,
+		overshoot:  DefaultSignatureOvershoot,
 	}
 }
 
+// WithPoolLinks makes FetchBatch attribute events to the candidate their pool
+// is linked to, ahead of the mint and discovery pool.
+func (s *RPCLiquidityEventSource) WithPoolLinks(links storage.PoolLinkStore) *RPCLiquidityEventSource {
+	s.links = links
+	return s
+}
+
+// WithOvershoot sets how far before the window start signature pagination continues.
+func (s *RPCLiquidityEventSource) WithOvershoot(d time.Duration) *RPCLiquidityEventSource {
+	s.overshoot = d
+	return s
+}
+
+// WithArchive stores every fetched transaction in archive for audit and reparse.
+func (s *RPCLiquidityEventSource) WithArchive(archive storage.RawTxStore) *RPCLiquidityEventSource {
+	s.archive = archive
+	return s
+}
+
+// WithFailedTxTracker is RPCSwapEventSource.WithFailedTxTracker for liquidity
+// scans, under the source name "rpc-liquidity".
+func (s *RPCLiquidityEventSource) WithFailedTxTracker(tracker *FailedTxTracker) *RPCLiquidityEventSource {
+	s.failedTx = tracker
+	return s
+}
+
+// SetProgress attaches a backfill progress tracker (nil detaches).
+// Must not be called while Fetch is running.
+func (s *RPCLiquidityEventSource) SetProgress(p *BackfillProgress) {
+	s.progress = p
+}
+
+// Programs returns the DEX program IDs scanned by Fetch.
+func (s *RPCLiquidityEventSource) Programs() []string {
+	return s.programs
+}
+
 // Fetch returns liquidity events for a candidate within time range.
+// A candidate with a known pool is served from the pool's own signature history;
+// otherwise every program is scanned and events are filtered by the candidate's
+// mint. Without a candidateID this is FetchBatch(...).Events.
 func (s *RPCLiquidityEventSource) Fetch(ctx context.Context, candidateID string, from, to int64) ([]*domain.LiquidityEvent, error) {
-	var allEvents []*domain.LiquidityEvent
+	if candidateID == "" {
+		batch, err := s.FetchBatch(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return batch.Events, nil
+	}
 
-	for _, program := range s.programs {
-		events, err := s.fetchForProgram(ctx, program, candidateID, from, to)
+	// Without a candidate store the mint cannot be verified: return nothing rather
+	// than attribute other tokens' events to the candidate
+	if s.candidates == nil {
+		return nil, nil
+	}
+	candidate, err := s.candidates.GetByID(ctx, candidateID)
+	if err != nil || candidate == nil {
+		return nil, nil
+	}
+
+	var allEvents []*domain.LiquidityEvent
+	if candidate.Pool != nil && *candidate.Pool != "" {
+		pool := *candidate.Pool
+		events, err := s.scan(ctx, pool, pool, from, to, func(le *domain.LiquidityEvent) bool {
+			return matchesCandidate(le, candidate)
+		})
+		s.progress.FinishScan()
 		if err != nil {
-			return nil, fmt.Errorf("fetch for program %s: %w", program, err)
+			return nil, fmt.Errorf("fetch for pool %s: %w", pool, err)
 		}
-		allEvents = append(allEvents, events...)
+		allEvents = events
+	} else {
+		for _, program := range s.programs {
+			events, err := s.scan(ctx, program, program, from, to, func(le *domain.LiquidityEvent) bool {
+				return le.Mint == candidate.Mint
+			})
+			s.progress.FinishScan()
+			if err != nil {
+				return nil, fmt.Errorf("fetch for program %s: %w", program, err)
+			}
+			allEvents = append(allEvents, events...)
+		}
+	}
+	for _, e := range allEvents {
+		e.CandidateID = candidateID
 	}
 
 	// Sort for deterministic ordering
@@ -217,149 +322,175 @@ func (s *RPCLiquidityEventSource) Fetch(ctx context.Context, candidateID string,
 	return allEvents, nil
 }
 
-// fetchForProgram fetches liquidity events for a single program.
-func (s *RPCLiquidityEventSource) fetchForProgram(ctx context.Context, program, candidateID string, from, to int64) ([]*domain.LiquidityEvent, error) {
-	fromSec := from / 1000
-	toSec := to / 1000
-
-	// If candidateID is provided, resolve its mint for filtering
-	// If candidate store is nil and candidateID is provided, we cannot verify mint - skip to avoid misassignment
-	var filterMint string
-	if candidateID != "" {
-		if s.candidates == nil {
-			// Cannot resolve mint without candidate store - skip filtering by candidateID
-			// to avoid attributing all events to wrong candidate
-			return nil, nil
-		}
-		candidate, err := s.candidates.GetByID(ctx, candidateID)
-		if err == nil && candidate != nil {
-			filterMint = candidate.Mint
-		} else {
-			// Candidate not found - cannot filter correctly
-			return nil, nil
-		}
+// matchesCandidate reports whether an event fetched from the candidate's pool
+// belongs to it: by mint, or by pool when the mint could not be parsed.
+func matchesCandidate(le *domain.LiquidityEvent, c *domain.TokenCandidate) bool {
+	if le.Mint != "" {
+		return le.Mint == c.Mint
 	}
+	return c.Pool != nil && le.Pool == *c.Pool
+}
 
-	var allEvents []*domain.LiquidityEvent
-	var before string
+// LiquidityBatch holds the liquidity events of a program-level scan.
+type LiquidityBatch struct {
+	// Events are all parsed events in deterministic order; CandidateID is empty
+	// for events of unknown tokens (deferred association).
+	Events []*domain.LiquidityEvent
+	// ByCandidate groups the attributed events by candidate ID.
+	ByCandidate map[string][]*domain.LiquidityEvent
+}
 
-	for {
-		opts := &solana.SignaturesOpts{
-			Limit: 1000,
+// FetchBatch scans every program once over [from, to) and attributes each event to
+// the candidate its pool is linked to (see WithPoolLinks), else the earliest
+// discovered candidate of its mint, falling back to its pool. It
+// replaces one Fetch per candidate, which would download the same transactions
+// once per candidate.
+func (s *RPCLiquidityEventSource) FetchBatch(ctx context.Context, from, to int64) (*LiquidityBatch, error) {
+	idx := &candidateIndex{}
+	if s.candidates != nil {
+		var err error
+		if idx, err = loadCandidateIndex(ctx, s.candidates); err != nil {
+			return nil, err
 		}
-		if before != "" {
-			opts.Before = before
+	}
+	if s.links != nil {
+		if err := idx.loadLinks(ctx, s.links); err != nil {
+			return nil, err
 		}
+	}
 
-		sigs, err := s.rpc.GetSignaturesForAddress(ctx, program, opts)
+	batch := &LiquidityBatch{ByCandidate: make(map[string][]*domain.LiquidityEvent)}
+	for _, program := range s.programs {
+		events, err := s.scan(ctx, program, program, from, to, nil)
+		s.progress.FinishScan()
 		if err != nil {
-			return nil, fmt.Errorf("get signatures: %w", err)
+			return nil, fmt.Errorf("fetch for program %s: %w", program, err)
 		}
+		batch.Events = append(batch.Events, events...)
+	}
 
-		if len(sigs) == 0 {
-			break
+	SortLiquidityEvents(batch.Events)
+	for _, e := range batch.Events {
+		e.CandidateID = idx.resolveEvent(e)
+		if e.CandidateID != "" {
+			batch.ByCandidate[e.CandidateID] = append(batch.ByCandidate[e.CandidateID], e)
 		}
+	}
+	return batch, nil
+}
 
-		for _, sig := range sigs {
-			if sig.BlockTime == nil {
-				continue
-			}
-			blockTime := *sig.BlockTime
-			if blockTime < fromSec || blockTime >= toSec {
-				if blockTime < fromSec {
-					return allEvents, nil
-				}
-				continue
-			}
-
-			if sig.Err != nil {
-				continue
-			}
-
-			tx, err := s.rpc.GetTransaction(ctx, sig.Signature)
-			if err != nil {
-				return nil, fmt.Errorf("get transaction %s: %w", sig.Signature, err)
-			}
+// scan walks the signature history of address over [from, to) milliseconds and
+// returns the events keep accepts (all when keep is nil), unattributed. Progress
+// is recorded under program.
+func (s *RPCLiquidityEventSource) scan(ctx context.Context, program, address string, from, to int64, keep func(*domain.LiquidityEvent) bool) ([]*domain.LiquidityEvent, error) {
+	var allEvents []*domain.LiquidityEvent
 
-			if tx == nil || tx.Meta == nil {
+	sc := &signatureScan{
+		rpc:       s.rpc,
+		program:   program,
+		address:   address,
+		fromSec:   from / 1000,
+		toSec:     to / 1000,
+		overshoot: s.overshoot,
+		progress:  s.progress,
+		failedTx:  s.failedTx,
+		source:    failedTxSourceRPCLiquidity,
+	}
+	err := sc.run(ctx, func(tx *solana.Transaction, blockTime int64) error {
+		archiveTx(ctx, s.archive, tx, blockTime)
+		for _, e := range s.parseTx(ctx, tx, blockTime) {
+			if keep != nil && !keep(e) {
 				continue
 			}
+			allEvents = append(allEvents, e)
+			s.progress.AddLiquidityEvents(program, 1)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			timestamp := blockTime * 1000
-
-			// Get account keys for V2 parser
-			var accountKeys []string
-			if tx.Message != nil {
-				accountKeys = tx.Message.AccountKeys
-			}
+	return allEvents, nil
+}
 
-			// Use V2 parser to extract Pool/Mint from account keys
-			liqEvents := s.parser.ParseLiquidityEventsV2(
-				tx.Meta.LogMessages,
-				accountKeys,
-				tx.Signature,
-				tx.Slot,
-				timestamp,
-			)
-
-			inferredPool, inferredMint := "", ""
-			if needsRaydiumInference(tx.Meta.LogMessages, nil) {
-				pool, mint, err := inferRaydiumPoolAndMint(ctx, s.rpc, accountKeys)
-				if err == nil {
-					inferredPool = pool
-					inferredMint = mint
-				}
-			}
+// parseTx extracts the liquidity events of one successful transaction, without
+// candidate IDs, with canonical event types. Events with neither a mint nor a
+// pool are dropped: they could never be associated. So are events of an unknown
+// type, which no query would select.
+func (s *RPCLiquidityEventSource) parseTx(ctx context.Context, tx *solana.Transaction, blockTime int64) []*domain.LiquidityEvent {
+	timestamp := blockTime * 1000
+
+	// Get account keys for V2 parser
+	var accountKeys []string
+	if tx.Message != nil {
+		accountKeys = tx.Message.AccountKeys
+	}
+
+	// Use V2 parser to extract Pool/Mint from account keys
+	liqEvents := s.parser.ParseLiquidityEventsV2(
+		tx.Meta.LogMessages,
+		accountKeys,
+		tx.Signature,
+		tx.Slot,
+		timestamp,
+	)
+
+	inferredPool, inferredMint := "", ""
+	if needsRaydiumInference(tx.Meta.LogMessages, nil) {
+		pool, mint, err := inferRaydiumPoolAndMint(ctx, s.rpc, accountKeys)
+		if err == nil {
+			inferredPool = pool
+			inferredMint = mint
+		}
+	}
 
-			for _, le := range liqEvents {
-				if le.Mint == "" && inferredMint != "" {
-					le.Mint = inferredMint
-				}
-				if le.Pool == "" && inferredPool != "" {
-					le.Pool = inferredPool
-				}
-
-				// If we have a filter mint, skip events that don't match
-				if filterMint != "" && le.Mint != filterMint {
-					continue
-				}
-
-				// Try to resolve candidate ID, but allow events without it for deferred association
-				resolvedCandidateID := candidateID
-				if resolvedCandidateID == "" && s.candidates != nil && le.Mint != "" {
-					id, err := resolveCandidateIDByMint(ctx, s.candidates, le.Mint)
-					if err == nil {
-						resolvedCandidateID = id
-					}
-				}
-				// Skip events without mint/pool - we need at least one for deferred association
-				if le.Mint == "" && le.Pool == "" {
-					continue
-				}
-				event := &domain.LiquidityEvent{
-					CandidateID: resolvedCandidateID, // May be empty for deferred association
-					Pool:        le.Pool,
-					Mint:        le.Mint,
-					EventType:   le.EventType,
-					AmountToken: float64(le.AmountToken),
-					AmountQuote: float64(le.AmountQuote),
-					TxSignature: le.TxSignature,
-					EventIndex:  le.EventIndex,
-					Slot:        le.Slot,
-					Timestamp:   le.Timestamp,
-				}
-				allEvents = append(allEvents, event)
-			}
+	var events []*domain.LiquidityEvent
+	for _, le := range liqEvents {
+		if le.Mint == "" && inferredMint != "" {
+			le.Mint = inferredMint
+		}
+		if le.Pool == "" && inferredPool != "" {
+			le.Pool = inferredPool
 		}
+		if le.Mint == "" && le.Pool == "" {
+			continue
+		}
+		eventType, err := domain.NormalizeLiquidityEventType(le.EventType)
+		if err != nil {
+			continue
+		}
+		events = append(events, &domain.LiquidityEvent{
+			Pool:        le.Pool,
+			Mint:        le.Mint,
+			EventType:   eventType,
+			AmountToken: float64(le.AmountToken),
+			AmountQuote: float64(le.AmountQuote),
+			TxSignature: le.TxSignature,
+			EventIndex:  le.EventIndex,
+			Slot:        le.Slot,
+			Timestamp:   le.Timestamp,
+			PoolInit:    le.PoolInit,
+			Migration:   le.Migration,
+			DEX:         le.DEX,
+			Origin:      domain.OriginBackfill,
+		})
+	}
+	return events
+}
 
-		before = sigs[len(sigs)-1].Signature
-		lastSig := sigs[len(sigs)-1]
-		if lastSig.BlockTime != nil && *lastSig.BlockTime < fromSec {
-			break
+// resolveByMint sets the candidate ID of each event to the earliest discovered
+// candidate of its mint, leaving it empty when none is known.
+func (s *RPCLiquidityEventSource) resolveByMint(ctx context.Context, events []*domain.LiquidityEvent) []*domain.LiquidityEvent {
+	if s.candidates == nil {
+		return events
+	}
+	for _, e := range events {
+		if id, err := resolveCandidateIDByMint(ctx, s.candidates, e.Mint); err == nil {
+			e.CandidateID = id
 		}
 	}
-
-	return allEvents, nil
+	return events
 }
 
 // RPCMetadataSource fetches token metadata from Solana RPC.
@@ -414,6 +545,49 @@ func (s *RPCMetadataSource) Fetch(ctx context.Context, mint string) (*domain.Tok
 	return meta, nil
 }
 
+// Authorities returns the Metaplex update authority and the SPL mint authority
+// of mint, where set, for authority-based discovery filtering.
+func (s *RPCMetadataSource) Authorities(ctx context.Context, mint string) ([]string, error) {
+	var authorities []string
+
+	if metadataPDA := s.deriveMetadataPDA(mint); metadataPDA != "" {
+		metaInfo, err := s.rpc.GetAccountInfo(ctx, metadataPDA)
+		if err != nil {
+			return nil, fmt.Errorf("get metadata account info: %w", err)
+		}
+		if metaInfo != nil {
+			if updateAuthority := parseUpdateAuthority(metaInfo.Data); updateAuthority != "" {
+				authorities = append(authorities, updateAuthority)
+			}
+		}
+	}
+
+	mintInfo, err := s.rpc.GetAccountInfo(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("get mint account info: %w", err)
+	}
+	if mintInfo != nil {
+		meta := &domain.TokenMetadata{}
+		if err := s.parseMintData(mintInfo.Data, meta); err == nil && meta.MintAuthority != nil {
+			authorities = append(authorities, *meta.MintAuthority)
+		}
+	}
+
+	return authorities, nil
+}
+
+var _ discovery.AuthorityResolver = (*RPCMetadataSource)(nil)
+
+// parseUpdateAuthority returns the update authority of Metaplex metadata account data
+// (offset 1, after the key byte), or "" if the data is not MetadataV1.
+func parseUpdateAuthority(data string) string {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(decoded) < 33 || decoded[0] != 4 {
+		return ""
+	}
+	return base58.Encode(decoded[1:33])
+}
+
 // parseMintData parses SPL Token Mint account data.
 // SPL Token Mint layout (82 bytes):
 // - mintAuthority: Option<Pubkey> (36 bytes: 4 + 32)
@@ -444,9 +618,23 @@ func (s *RPCMetadataSource) parseMintData(data string, meta *domain.TokenMetadat
 	supplyFloat := float64(supply) / math.Pow(10, float64(decimals))
 	meta.Supply = &supplyFloat
 
+	// Authorities: COption<Pubkey> at offsets 0 and 46
+	meta.MintAuthority = parseCOptionPubkey(decoded[0:36])
+	meta.FreezeAuthority = parseCOptionPubkey(decoded[46:82])
+
 	return nil
 }
 
+// parseCOptionPubkey decodes an SPL COption<Pubkey> (u32 tag + 32 bytes).
+// Returns nil when the option is None.
+func parseCOptionPubkey(data []byte) *string {
+	if binary.LittleEndian.Uint32(data[0:4]) == 0 {
+		return nil
+	}
+	key := base58.Encode(data[4:36])
+	return &key
+}
+
 // deriveMetadataPDA derives the Metaplex metadata PDA for a given mint.
 // Seeds: ["metadata", metaplex_program_id, mint]
 func (s *RPCMetadataSource) deriveMetadataPDA(mint string) string {
@@ -532,9 +720,25 @@ func (s *RPCMetadataSource) parseMetaplexData(data string, meta *domain.TokenMet
 		return
 	}
 	symbol := strings.TrimRight(string(decoded[offset:offset+int(symbolLen)]), "\x00")
+	offset += int(symbolLen)
 	if symbol != "" {
 		meta.Symbol = &symbol
 	}
+
+	// Parse uri
+	if offset+4 > len(decoded) {
+		return
+	}
+	uriLen := binary.LittleEndian.Uint32(decoded[offset:])
+	offset += 4
+
+	if uriLen > 400 || offset+int(uriLen) > len(decoded) {
+		return
+	}
+	uri := strings.TrimRight(string(decoded[offset:offset+int(uriLen)]), "\x00")
+	if uri != "" {
+		meta.URI = &uri
+	}
 }
 
 // derivePDA derives a Program Derived Address using the Solana algorithm.
@@ -0,0 +1,188 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// countingRPC serves one page of signatures per address and a pump.fun Create
+// transaction per signature, counting signature queries by address and fetches by
+// signature.
+type countingRPC struct {
+	sigs  map[string][]string // address -> signatures
+	mints map[string]string   // signature -> created mint
+
+	mu          sync.Mutex
+	sigQueries  map[string]int
+	txDownloads map[string]int
+}
+
+func (f *countingRPC) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+	f.sigQueries = make(map[string]int)
+	f.txDownloads = make(map[string]int)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getSignaturesForAddress":
+			var address string
+			_ = json.Unmarshal(req.Params[0], &address)
+			var opts struct {
+				Before string `json:"before"`
+			}
+			if len(req.Params) > 1 {
+				_ = json.Unmarshal(req.Params[1], &opts)
+			}
+			f.mu.Lock()
+			f.sigQueries[address]++
+			f.mu.Unlock()
+			page := []map[string]interface{}{}
+			if opts.Before == "" {
+				for _, sig := range f.sigs[address] {
+					page = append(page, sigInfo(sig, 1500))
+				}
+			}
+			result = page
+		case "getTransaction":
+			var sig string
+			_ = json.Unmarshal(req.Params[0], &sig)
+			f.mu.Lock()
+			f.txDownloads[sig]++
+			f.mu.Unlock()
+			result = map[string]interface{}{
+				"slot":      int64(1),
+				"blockTime": int64(1500),
+				"meta": map[string]interface{}{
+					"err": nil,
+					"logMessages": []string{
+						"Program " + discovery.PumpFun + " invoke [1]",
+						"Program log: mint=" + f.mints[sig],
+						"Program log: Instruction: Create",
+						"Program " + discovery.PumpFun + " success",
+					},
+				},
+				"transaction": map[string]interface{}{
+					"message": map[string]interface{}{"accountKeys": []string{}},
+				},
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+// liquidityFixture has candidate A with a known pool, candidate B without one, and
+// a program history that also contains a token no candidate belongs to.
+func liquidityFixture(t *testing.T) (*countingRPC, *memory.CandidateStore) {
+	t.Helper()
+	fake := &countingRPC{
+		sigs: map[string][]string{
+			discovery.PumpFun: {"createA", "createB", "createX"},
+			"PoolA":           {"createA"},
+		},
+		mints: map[string]string{"createA": "MintA", "createB": "MintB", "createX": "MintX"},
+	}
+
+	store := memory.NewCandidateStore()
+	pool := "PoolA"
+	for _, c := range []*domain.TokenCandidate{
+		{CandidateID: "cand-a", Source: domain.SourceNewToken, Mint: "MintA", Pool: &pool, TxSignature: "a", DiscoveredAt: 1},
+		{CandidateID: "cand-b", Source: domain.SourceNewToken, Mint: "MintB", TxSignature: "b", DiscoveredAt: 2},
+	} {
+		if err := store.Insert(context.Background(), c); err != nil {
+			t.Fatalf("Insert: %v", err)
+		}
+	}
+	return fake, store
+}
+
+func TestRPCLiquidityEventSource_FetchUsesPoolHistory(t *testing.T) {
+	fake, store := liquidityFixture(t)
+	server := fake.serve(t)
+	defer server.Close()
+
+	source := NewRPCLiquidityEventSource(solana.NewHTTPClient(server.URL), []string{discovery.PumpFun}, store)
+
+	events, err := source.Fetch(context.Background(), "cand-a", 1000*1000, 2000*1000)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(events) != 1 || events[0].Mint != "MintA" || events[0].CandidateID != "cand-a" {
+		t.Fatalf("expected the MintA create attributed to cand-a, got %+v", events)
+	}
+	if fake.sigQueries[discovery.PumpFun] != 0 || fake.sigQueries["PoolA"] == 0 {
+		t.Errorf("expected pool-scoped signature queries only, got %v", fake.sigQueries)
+	}
+	if len(fake.txDownloads) != 1 {
+		t.Errorf("expected only the pool's transaction downloaded, got %v", fake.txDownloads)
+	}
+
+	// Without a pool the candidate falls back to the program scan
+	events, err = source.Fetch(context.Background(), "cand-b", 1000*1000, 2000*1000)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(events) != 1 || events[0].Mint != "MintB" || events[0].CandidateID != "cand-b" {
+		t.Fatalf("expected the MintB create attributed to cand-b, got %+v", events)
+	}
+	if fake.sigQueries[discovery.PumpFun] == 0 {
+		t.Error("expected a program scan for a candidate without a pool")
+	}
+}
+
+func TestRPCLiquidityEventSource_FetchBatchDownloadsOnce(t *testing.T) {
+	fake, store := liquidityFixture(t)
+	server := fake.serve(t)
+	defer server.Close()
+
+	source := NewRPCLiquidityEventSource(solana.NewHTTPClient(server.URL), []string{discovery.PumpFun}, store)
+
+	batch, err := source.FetchBatch(context.Background(), 1000*1000, 2000*1000)
+	if err != nil {
+		t.Fatalf("FetchBatch: %v", err)
+	}
+
+	for sig, n := range fake.txDownloads {
+		if n != 1 {
+			t.Errorf("%s downloaded %d times", sig, n)
+		}
+	}
+	if len(fake.txDownloads) != 3 || fake.sigQueries["PoolA"] != 0 {
+		t.Errorf("expected one program scan, got queries %v and downloads %v", fake.sigQueries, fake.txDownloads)
+	}
+
+	if len(batch.Events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(batch.Events))
+	}
+	if len(batch.ByCandidate["cand-a"]) != 1 || len(batch.ByCandidate["cand-b"]) != 1 || len(batch.ByCandidate) != 2 {
+		t.Errorf("unexpected demultiplexing: %v", batch.ByCandidate)
+	}
+	for _, e := range batch.Events {
+		if e.Mint == "MintX" && e.CandidateID != "" {
+			t.Errorf("unknown token attributed to %s", e.CandidateID)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package ingestion
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"solana-token-lab/internal/discovery"
@@ -15,41 +17,81 @@ import (
 type Runner struct {
 	wsSwapSource      *WSSwapEventSource
 	wsLiquiditySource *WSLiquidityEventSource
+	wsMintSource      *WSMintCreationSource
 	metadataSource    *RPCMetadataSource
 	swapEventStore    storage.SwapEventStore
 	liquidityStore    storage.LiquidityEventStore
 	metadataStore     storage.TokenMetadataStore
 	candidateStore    storage.CandidateStore
+	watchlist         storage.WatchlistStore
 	newTokenDetector  *discovery.NewTokenDetector
 	activeDetector    *discovery.ActiveTokenDetector
+	prePool           *discovery.PrePoolTracker
+	capper            *eventCapper // nil without event caps
+	links             *poolLinker  // nil without a pool link store
+	failedTx          *FailedTxTracker
 	checkInterval     time.Duration // Interval for ACTIVE_TOKEN detection
 	slotLagWindow     int64         // Number of slots to buffer for ordering
 	flushInterval     time.Duration // Interval for periodic buffer flush
+	watchlistInterval time.Duration // Interval for watchlisted metadata refresh
 	logger            *log.Logger
 
 	// Slot-based buffer for deterministic ordering
 	// Events are grouped by slot and processed when slot is finalized
 	swapBuffer      map[int64][]*domain.SwapEvent
 	liquidityBuffer map[int64][]*domain.LiquidityEvent
+	mintBuffer      map[int64][]*discovery.MintCreationEvent
 	highestSlot     int64 // Highest slot seen
 	lastEventTime   int64 // Timestamp of last processed event (for deterministic detection)
+
+	// Program that delivered each buffered live swap event, for per-program attribution
+	eventProgram map[*domain.SwapEvent]string
+
+	statsMu sync.Mutex
+	stats   RunnerStats
+
+	// Per-program counters; kept after a program is removed
+	programEvents     programCounter
+	programCandidates programCounter
+	programLastEvent  programClock // wall-clock receipt time of the latest event
+
+	// WS health probe: one per live source, empty when disabled
+	wsHealth         WSHealthConfig
+	probes           []*wsHealthProbe
+	gapBackfill      GapBackfillFunc
+	onEndpointSwitch func(source string, err error)
+	gapBackfills     sync.WaitGroup
+	gapBackfillMu    sync.Mutex // serializes gapBackfill calls
 }
 
 // RunnerOptions contains configuration for creating a Runner.
 type RunnerOptions struct {
 	WSSwapSource      *WSSwapEventSource
 	WSLiquiditySource *WSLiquidityEventSource
+	WSMintSource      *WSMintCreationSource // optional: mint creations for PRE_POOL discovery
 	MetadataSource    *RPCMetadataSource
 	SwapEventStore    storage.SwapEventStore
 	LiquidityStore    storage.LiquidityEventStore
 	MetadataStore     storage.TokenMetadataStore
 	CandidateStore    storage.CandidateStore
+	Watchlist         storage.WatchlistStore // optional: enables frequent metadata refresh for watchlisted mints
 	NewTokenDetector  *discovery.NewTokenDetector
 	ActiveDetector    *discovery.ActiveTokenDetector
+	PrePoolTracker    *discovery.PrePoolTracker // optional: records and upgrades PRE_POOL mints
+	EventCaps         EventCaps                 // optional: per-candidate caps on stored events
+	EventCountStore   storage.EventCountStore   // optional: persists the true event totals under EventCaps
+	PoolLinkStore     storage.PoolLinkStore     // optional: attributes every pool of a mint to its candidate
+	FailedTx          *FailedTxTracker          // optional: the live sources' tracker, flushed with buffered events
 	CheckInterval     time.Duration
 	SlotLagWindow     int64         // Default: 5 slots - wait this many slots before processing
 	FlushInterval     time.Duration // Default: 5s - force flush buffered events periodically
+	WatchlistInterval time.Duration // Default: 10m - metadata refresh interval for watchlisted mints
 	Logger            *log.Logger
+
+	// Optional: switch the endpoint of a live source whose subscriptions starve
+	WSHealth         WSHealthConfig
+	GapBackfill      GapBackfillFunc                // optional: backfills the starved window after a switch; calls never overlap
+	OnEndpointSwitch func(source string, err error) // optional: called with each switch, e.g. for metrics
 }
 
 // NewRunner creates a new ingestion runner.
@@ -69,28 +111,66 @@ func NewRunner(opts RunnerOptions) *Runner {
 		flushInterval = 5 * time.Second // Force flush every 5 seconds
 	}
 
+	watchlistInterval := opts.WatchlistInterval
+	if watchlistInterval == 0 {
+		watchlistInterval = 10 * time.Minute
+	}
+
 	logger := opts.Logger
 	if logger == nil {
 		logger = log.Default()
 	}
 
-	return &Runner{
+	r := &Runner{
 		wsSwapSource:      opts.WSSwapSource,
 		wsLiquiditySource: opts.WSLiquiditySource,
+		wsMintSource:      opts.WSMintSource,
 		metadataSource:    opts.MetadataSource,
 		swapEventStore:    opts.SwapEventStore,
 		liquidityStore:    opts.LiquidityStore,
 		metadataStore:     opts.MetadataStore,
 		candidateStore:    opts.CandidateStore,
+		watchlist:         opts.Watchlist,
 		newTokenDetector:  opts.NewTokenDetector,
 		activeDetector:    opts.ActiveDetector,
+		prePool:           opts.PrePoolTracker,
+		capper:            newEventCapper(opts.EventCaps, opts.EventCountStore),
+		links:             newPoolLinker(opts.PoolLinkStore, opts.CandidateStore),
+		failedTx:          opts.FailedTx,
 		checkInterval:     checkInterval,
 		slotLagWindow:     slotLagWindow,
 		flushInterval:     flushInterval,
+		watchlistInterval: watchlistInterval,
 		logger:            logger,
 		swapBuffer:        make(map[int64][]*domain.SwapEvent),
 		liquidityBuffer:   make(map[int64][]*domain.LiquidityEvent),
+		mintBuffer:        make(map[int64][]*discovery.MintCreationEvent),
+		eventProgram:      make(map[*domain.SwapEvent]string),
+		wsHealth:          opts.WSHealth.withDefaults(),
+		gapBackfill:       opts.GapBackfill,
+		onEndpointSwitch:  opts.OnEndpointSwitch,
 	}
+	if opts.WSHealth.Enabled() {
+		for _, feed := range r.liveFeeds() {
+			r.probes = append(r.probes, newWSHealthProbe(r.wsHealth, feed))
+		}
+	}
+	return r
+}
+
+// liveFeeds returns the program feeds of the configured live sources.
+func (r *Runner) liveFeeds() []*programFeed {
+	var feeds []*programFeed
+	if r.wsSwapSource != nil {
+		feeds = append(feeds, r.wsSwapSource.feed)
+	}
+	if r.wsLiquiditySource != nil {
+		feeds = append(feeds, r.wsLiquiditySource.feed)
+	}
+	if r.wsMintSource != nil && r.prePool != nil {
+		feeds = append(feeds, r.wsMintSource.feed)
+	}
+	return feeds
 }
 
 // Run starts continuous ingestion and discovery.
@@ -99,10 +179,10 @@ func (r *Runner) Run(ctx context.Context) error {
 	r.logger.Println("Starting ingestion runner...")
 
 	// Subscribe to swap events
-	var swapEventsCh <-chan *domain.SwapEvent
+	var swapEventsCh <-chan programSwapEvent
 	if r.wsSwapSource != nil {
 		var err error
-		swapEventsCh, err = r.wsSwapSource.Subscribe(ctx)
+		swapEventsCh, err = r.wsSwapSource.subscribe(ctx)
 		if err != nil {
 			return err
 		}
@@ -110,16 +190,32 @@ func (r *Runner) Run(ctx context.Context) error {
 	}
 
 	// Subscribe to liquidity events
-	var liquidityEventsCh <-chan *domain.LiquidityEvent
+	var liquidityEventsCh <-chan programLiquidityEvent
 	if r.wsLiquiditySource != nil {
 		var err error
-		liquidityEventsCh, err = r.wsLiquiditySource.Subscribe(ctx)
+		liquidityEventsCh, err = r.wsLiquiditySource.subscribe(ctx)
 		if err != nil {
 			return err
 		}
 		r.logger.Println("Subscribed to liquidity events")
 	}
 
+	// Subscribe to mint creations; without a tracker they would have nowhere to go
+	var mintEventsCh <-chan *discovery.MintCreationEvent
+	if r.wsMintSource != nil && r.prePool != nil {
+		var err error
+		mintEventsCh, err = r.wsMintSource.Subscribe(ctx)
+		if err != nil {
+			return err
+		}
+		r.logger.Println("Subscribed to mint creations")
+	}
+
+	// Resume capping the candidates still inside their observation window
+	if err := r.capper.load(ctx, r.candidateStore, time.Now().UnixMilli()); err != nil {
+		return err
+	}
+
 	// Start ACTIVE_TOKEN detection ticker
 	ticker := time.NewTicker(r.checkInterval)
 	defer ticker.Stop()
@@ -129,29 +225,62 @@ func (r *Runner) Run(ctx context.Context) error {
 	flushTicker := time.NewTicker(r.flushInterval)
 	defer flushTicker.Stop()
 
+	// Watchlisted mints get metadata refreshed on their own, shorter cadence
+	watchlistTicker := time.NewTicker(r.watchlistInterval)
+	defer watchlistTicker.Stop()
+
+	// Starved subscriptions are checked only with the WS health probe enabled.
+	// Gap backfills still running when Run returns are cancelled and awaited.
+	var healthTick <-chan time.Time
+	if len(r.probes) > 0 {
+		healthTicker := time.NewTicker(r.wsHealth.CheckInterval)
+		defer healthTicker.Stop()
+		healthTick = healthTicker.C
+	}
+	backfillCtx, cancelBackfills := context.WithCancel(ctx)
+	defer r.gapBackfills.Wait()
+	defer cancelBackfills()
+
 	r.logger.Printf("Runner started, ACTIVE_TOKEN check interval: %v, slot lag window: %d, flush interval: %v", r.checkInterval, r.slotLagWindow, r.flushInterval)
 
 	for {
 		select {
 		case <-ctx.Done():
-			// Flush all remaining events before shutdown
-			r.flushAllSlots(ctx)
-			r.logger.Println("Runner stopping...")
+			// Flush all remaining events before shutdown; ctx is already
+			// cancelled, so the final writes must not inherit it
+			r.flushAllSlots(context.WithoutCancel(ctx))
+			r.persistEventCounts(context.WithoutCancel(ctx))
+			r.flushFailedTx(context.WithoutCancel(ctx))
+			stats := r.Stats()
+			r.logger.Printf("Runner stopping: %d swap and %d liquidity events stored, %d/%d duplicates skipped",
+				stats.SwapEventsProcessed, stats.LiquidityEventsProcessed, stats.DuplicateSwapEvents, stats.DuplicateLiquidityEvents)
 			return ctx.Err()
 
-		case event, ok := <-swapEventsCh:
+		case e, ok := <-swapEventsCh:
 			if !ok {
 				r.logger.Println("Swap events channel closed")
 				return errors.New("swap events channel closed")
 			}
-			r.bufferSwapEvent(ctx, event)
+			r.programEvents.inc(e.program)
+			r.programLastEvent.observe(e.program, time.Now().UnixMilli())
+			r.eventProgram[e.event] = e.program
+			r.bufferSwapEvent(ctx, e.event)
 
-		case event, ok := <-liquidityEventsCh:
+		case e, ok := <-liquidityEventsCh:
 			if !ok {
 				r.logger.Println("Liquidity events channel closed")
 				return errors.New("liquidity events channel closed")
 			}
-			r.bufferLiquidityEvent(ctx, event)
+			r.programEvents.inc(e.program)
+			r.programLastEvent.observe(e.program, time.Now().UnixMilli())
+			r.bufferLiquidityEvent(ctx, e.event)
+
+		case e, ok := <-mintEventsCh:
+			if !ok {
+				r.logger.Println("Mint creation channel closed")
+				return errors.New("mint creation channel closed")
+			}
+			r.bufferMintCreation(ctx, e)
 
 		case <-flushTicker.C:
 			// Periodic flush: process finalized slots (respects slotLagWindow)
@@ -159,9 +288,19 @@ func (r *Runner) Run(ctx context.Context) error {
 			// while maintaining slot-ordering guarantees.
 			// flushAllSlots() is only used on shutdown when ordering no longer matters.
 			r.processFinalizedSlots(ctx)
+			r.persistEventCounts(ctx)
+			r.flushFailedTx(ctx)
 
 		case <-ticker.C:
 			r.runActiveTokenDetection(ctx)
+			r.runLiquidityAssociation(ctx)
+			r.runProvisionalExpiry(ctx)
+
+		case <-watchlistTicker.C:
+			r.runWatchlistRefresh(ctx)
+
+		case <-healthTick:
+			r.checkWSHealth(ctx, backfillCtx, time.Now())
 		}
 	}
 }
@@ -196,6 +335,21 @@ func (r *Runner) bufferLiquidityEvent(ctx context.Context, event *domain.Liquidi
 	}
 }
 
+// bufferMintCreation adds event to slot-based buffer and processes finalized slots.
+func (r *Runner) bufferMintCreation(ctx context.Context, event *discovery.MintCreationEvent) {
+	slot := event.Slot
+	r.mintBuffer[slot] = append(r.mintBuffer[slot], event)
+
+	// Update highest slot and process finalized slots
+	if slot > r.highestSlot {
+		r.highestSlot = slot
+		r.processFinalizedSlots(ctx)
+	} else if slot <= r.highestSlot-r.slotLagWindow {
+		// Late event for already-finalized slot: process immediately
+		r.processSlot(ctx, slot)
+	}
+}
+
 // processFinalizedSlots processes all slots that are finalized (behind current by lag window).
 func (r *Runner) processFinalizedSlots(ctx context.Context) {
 	finalizedSlot := r.highestSlot - r.slotLagWindow
@@ -211,17 +365,13 @@ func (r *Runner) processFinalizedSlots(ctx context.Context) {
 		}
 	}
 	for slot := range r.liquidityBuffer {
-		if slot <= finalizedSlot {
-			found := false
-			for _, s := range slotsToProcess {
-				if s == slot {
-					found = true
-					break
-				}
-			}
-			if !found {
-				slotsToProcess = append(slotsToProcess, slot)
-			}
+		if slot <= finalizedSlot && !containsInt64(slotsToProcess, slot) {
+			slotsToProcess = append(slotsToProcess, slot)
+		}
+	}
+	for slot := range r.mintBuffer {
+		if slot <= finalizedSlot && !containsInt64(slotsToProcess, slot) {
+			slotsToProcess = append(slotsToProcess, slot)
 		}
 	}
 
@@ -236,6 +386,25 @@ func (r *Runner) processFinalizedSlots(ctx context.Context) {
 
 // processSlot processes all events for a single slot with deterministic ordering.
 func (r *Runner) processSlot(ctx context.Context, slot int64) {
+	// Mint creations precede any pool for the mint, even within a slot
+	if events, ok := r.mintBuffer[slot]; ok {
+		sortMintCreations(events)
+		for _, event := range events {
+			r.handleMintCreation(ctx, event)
+		}
+		delete(r.mintBuffer, slot)
+	}
+
+	// Pool creations trigger NEW_TOKEN ahead of swaps in the same slot
+	if events, ok := r.liquidityBuffer[slot]; ok {
+		SortLiquidityEvents(events)
+		for _, event := range events {
+			if event.PoolInit {
+				r.detectPoolCreation(ctx, event)
+			}
+		}
+	}
+
 	// Process swap events for this slot
 	if events, ok := r.swapBuffer[slot]; ok && len(events) > 0 {
 		// Sort by (tx_signature, event_index) within slot
@@ -264,14 +433,12 @@ func (r *Runner) flushAllSlots(ctx context.Context) {
 		allSlots = append(allSlots, slot)
 	}
 	for slot := range r.liquidityBuffer {
-		found := false
-		for _, s := range allSlots {
-			if s == slot {
-				found = true
-				break
-			}
+		if !containsInt64(allSlots, slot) {
+			allSlots = append(allSlots, slot)
 		}
-		if !found {
+	}
+	for slot := range r.mintBuffer {
+		if !containsInt64(allSlots, slot) {
 			allSlots = append(allSlots, slot)
 		}
 	}
@@ -283,6 +450,16 @@ func (r *Runner) flushAllSlots(ctx context.Context) {
 	}
 }
 
+// containsInt64 reports whether s contains v.
+func containsInt64(s []int64, v int64) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
 // sortInt64s sorts a slice of int64 in ascending order.
 func sortInt64s(s []int64) {
 	for i := 0; i < len(s)-1; i++ {
@@ -296,20 +473,16 @@ func sortInt64s(s []int64) {
 
 // handleSwapEvent processes a single swap event.
 func (r *Runner) handleSwapEvent(ctx context.Context, event *domain.SwapEvent) {
+	program := r.eventProgram[event]
+	delete(r.eventProgram, event)
+
 	// Update last event time for deterministic ACTIVE_TOKEN detection
 	if event.Timestamp > r.lastEventTime {
 		r.lastEventTime = event.Timestamp
 	}
 
 	// Store the swap event
-	if r.swapEventStore != nil {
-		if err := r.swapEventStore.Insert(ctx, event); err != nil {
-			if !errors.Is(err, storage.ErrDuplicateKey) {
-				r.logger.Printf("Error storing swap event: %v", err)
-			}
-			// Duplicate is expected, not an error
-		}
-	}
+	stored := r.storeSwapEvent(ctx, event)
 
 	// Run NEW_TOKEN detection
 	if r.newTokenDetector != nil {
@@ -320,6 +493,7 @@ func (r *Runner) handleSwapEvent(ctx context.Context, event *domain.SwapEvent) {
 			EventIndex:  event.EventIndex,
 			Slot:        event.Slot,
 			Timestamp:   event.Timestamp,
+			DEX:         event.DEX,
 		}
 
 		candidate, err := r.newTokenDetector.ProcessEvent(ctx, swapEvent)
@@ -330,6 +504,18 @@ func (r *Runner) handleSwapEvent(ctx context.Context, event *domain.SwapEvent) {
 
 		if candidate != nil {
 			r.logger.Printf("NEW_TOKEN discovered: %s (mint=%s)", candidate.CandidateID, candidate.Mint)
+			r.updateStats(func(st *RunnerStats) { st.NewTokensDiscovered++ })
+			if program != "" {
+				r.programCandidates.inc(program)
+			}
+			r.upgradeProvisionalMint(ctx, candidate)
+
+			// The discovery swap was stored before the candidate existed; count it now
+			r.trackCandidate(ctx, candidate)
+			if stored {
+				caps, _ := r.capper.admitSwap(event)
+				r.capper.stored(caps, true)
+			}
 
 			// Fetch and store metadata for new tokens
 			r.ingestMetadata(ctx, candidate.CandidateID, candidate.Mint)
@@ -337,6 +523,77 @@ func (r *Runner) handleSwapEvent(ctx context.Context, event *domain.SwapEvent) {
 	}
 }
 
+// detectPoolCreation runs NEW_TOKEN detection on a pool-creation event.
+func (r *Runner) detectPoolCreation(ctx context.Context, event *domain.LiquidityEvent) {
+	if r.newTokenDetector == nil {
+		return
+	}
+
+	candidate, err := r.newTokenDetector.ProcessPoolCreation(ctx, &discovery.LiquidityEvent{
+		Pool:        event.Pool,
+		Mint:        event.Mint,
+		EventType:   event.EventType,
+		TxSignature: event.TxSignature,
+		EventIndex:  event.EventIndex,
+		Slot:        event.Slot,
+		Timestamp:   event.Timestamp,
+		PoolInit:    true,
+		DEX:         event.DEX,
+	})
+	if err != nil {
+		r.logger.Printf("Error in NEW_TOKEN detection: %v", err)
+		return
+	}
+
+	if candidate != nil {
+		r.logger.Printf("NEW_TOKEN discovered at pool creation: %s (mint=%s)", candidate.CandidateID, candidate.Mint)
+		r.updateStats(func(st *RunnerStats) { st.NewTokensDiscovered++ })
+		r.programCandidates.inc(discovery.RaydiumAMMV4) // only Raydium emits pool creations
+		if event.CandidateID == "" {
+			event.CandidateID = candidate.CandidateID
+		}
+		r.upgradeProvisionalMint(ctx, candidate)
+		r.trackCandidate(ctx, candidate)
+
+		// Fetch and store metadata for new tokens
+		r.ingestMetadata(ctx, candidate.CandidateID, candidate.Mint)
+	}
+}
+
+// handleMintCreation records a mint creation as a provisional PRE_POOL mint.
+func (r *Runner) handleMintCreation(ctx context.Context, event *discovery.MintCreationEvent) {
+	if r.prePool == nil {
+		return
+	}
+
+	m, err := r.prePool.ProcessMintCreation(ctx, event)
+	if err != nil {
+		r.logger.Printf("Error recording provisional mint %s: %v", event.Mint, err)
+		return
+	}
+	if m != nil {
+		r.updateStats(func(st *RunnerStats) { st.ProvisionalMints++ })
+	}
+}
+
+// upgradeProvisionalMint links a NEW_TOKEN candidate to its provisional mint, if any.
+func (r *Runner) upgradeProvisionalMint(ctx context.Context, candidate *domain.TokenCandidate) {
+	if r.prePool == nil {
+		return
+	}
+
+	m, err := r.prePool.Upgrade(ctx, candidate)
+	if err != nil {
+		r.logger.Printf("Error upgrading provisional mint %s: %v", candidate.Mint, err)
+		return
+	}
+	if m != nil {
+		delay, _ := m.PoolDelayMs()
+		r.logger.Printf("PRE_POOL mint upgraded: %s -> %s (pool after %dms)", m.Mint, candidate.CandidateID, delay)
+		r.updateStats(func(st *RunnerStats) { st.ProvisionalUpgraded++ })
+	}
+}
+
 // ingestMetadata fetches and stores token metadata.
 func (r *Runner) ingestMetadata(ctx context.Context, candidateID, mint string) {
 	if r.metadataSource == nil || r.metadataStore == nil {
@@ -355,25 +612,118 @@ func (r *Runner) ingestMetadata(ctx context.Context, candidateID, mint string) {
 
 	meta.CandidateID = candidateID
 
-	if err := r.metadataStore.Insert(ctx, meta); err != nil {
-		if !errors.Is(err, storage.ErrDuplicateKey) {
-			r.logger.Printf("Error storing metadata for %s: %v", mint, err)
-		}
+	if err := r.metadataStore.Upsert(ctx, meta); err != nil {
+		r.logger.Printf("Error storing metadata for %s: %v", mint, err)
 	} else {
 		r.logger.Printf("Metadata stored for %s: name=%v symbol=%v decimals=%d",
 			mint, meta.Name, meta.Symbol, meta.Decimals)
 	}
 }
 
+// storeSwapEvent stores a swap event unless a per-candidate cap drops it, and
+// reports whether it was stored.
+func (r *Runner) storeSwapEvent(ctx context.Context, event *domain.SwapEvent) bool {
+	if r.swapEventStore == nil {
+		return false
+	}
+
+	caps, keep := r.capper.admitSwap(event)
+	if !keep {
+		r.updateStats(func(st *RunnerStats) { st.CappedSwapEvents++ })
+		return false
+	}
+
+	if err := r.swapEventStore.Insert(ctx, event); err != nil {
+		// Duplicate is expected when live and backfill windows overlap, not an error
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			r.capper.unseen(caps, true)
+			r.updateStats(func(st *RunnerStats) { st.DuplicateSwapEvents++ })
+		} else {
+			r.logger.Printf("Error storing swap event: %v", err)
+		}
+		return false
+	}
+	r.capper.stored(caps, true)
+	r.updateStats(func(st *RunnerStats) { st.SwapEventsProcessed++ })
+	return true
+}
+
 // handleLiquidityEvent processes a single liquidity event.
 func (r *Runner) handleLiquidityEvent(ctx context.Context, event *domain.LiquidityEvent) {
+	if r.liquidityStore == nil {
+		return
+	}
+
+	r.attributeLiquidityEvent(ctx, event)
+
+	caps, keep := r.capper.admitLiquidity(event)
+	if !keep {
+		r.updateStats(func(st *RunnerStats) { st.CappedLiquidityEvents++ })
+		return
+	}
+
 	// Store the liquidity event
-	if r.liquidityStore != nil {
-		if err := r.liquidityStore.Insert(ctx, event); err != nil {
-			if !errors.Is(err, storage.ErrDuplicateKey) {
-				r.logger.Printf("Error storing liquidity event: %v", err)
-			}
+	if err := r.liquidityStore.Insert(ctx, event); err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			r.capper.unseen(caps, false)
+			r.updateStats(func(st *RunnerStats) { st.DuplicateLiquidityEvents++ })
+		} else {
+			r.logger.Printf("Error storing liquidity event: %v", err)
 		}
+		return
+	}
+	r.capper.stored(caps, false)
+	r.updateStats(func(st *RunnerStats) { st.LiquidityEventsProcessed++ })
+}
+
+// trackCandidate applies the per-candidate event caps to a new candidate and
+// links its discovery pool.
+func (r *Runner) trackCandidate(ctx context.Context, candidate *domain.TokenCandidate) {
+	if err := r.capper.track(ctx, candidate); err != nil {
+		r.logger.Printf("Error tracking event caps: %v", err)
+	}
+	if err := r.links.linkCandidate(ctx, candidate); err != nil {
+		r.logger.Printf("Error linking candidate pool: %v", err)
+	}
+}
+
+// attributeLiquidityEvent attributes a liquidity event to the candidate its
+// pool is linked to, which wins over the mint: a migrated token's new pool
+// belongs to the canonical candidate. It also updates the links from the event.
+// A migration is attributed through the bonding curve link it closes, a pool
+// creation through the link it opens.
+func (r *Runner) attributeLiquidityEvent(ctx context.Context, event *domain.LiquidityEvent) {
+	if r.links == nil {
+		return
+	}
+	candidateID, err := r.links.resolve(ctx, event.DEX, event.Pool, event.Mint, event.Timestamp)
+	if err != nil {
+		r.logger.Printf("Error resolving pool link: %v", err)
+	}
+	if err := r.links.observe(ctx, event); err != nil {
+		r.logger.Printf("Error updating pool links: %v", err)
+	}
+	if candidateID == "" {
+		if candidateID, err = r.links.resolve(ctx, event.DEX, event.Pool, event.Mint, event.Timestamp); err != nil {
+			r.logger.Printf("Error resolving pool link: %v", err)
+		}
+	}
+	if candidateID != "" {
+		event.CandidateID = candidateID
+	}
+}
+
+// persistEventCounts stores the per-candidate event totals under event caps.
+func (r *Runner) persistEventCounts(ctx context.Context) {
+	if err := r.capper.persist(ctx, r.lastEventTime, time.Now().UnixMilli()); err != nil {
+		r.logger.Printf("Error storing event counts: %v", err)
+	}
+}
+
+// flushFailedTx stores the hourly failed transaction tallies of the live sources.
+func (r *Runner) flushFailedTx(ctx context.Context) {
+	if err := r.failedTx.Flush(ctx); err != nil {
+		r.logger.Printf("Error storing failed transaction tallies: %v", err)
 	}
 }
 
@@ -397,9 +747,14 @@ func (r *Runner) runActiveTokenDetection(ctx context.Context) {
 		r.logger.Printf("Error in ACTIVE_TOKEN detection: %v", err)
 		return
 	}
+	r.updateStats(func(st *RunnerStats) {
+		st.ActiveTokensDiscovered += int64(len(candidates))
+		st.LastActiveCheck = time.Now()
+	})
 
 	for _, candidate := range candidates {
 		r.logger.Printf("ACTIVE_TOKEN discovered: %s (mint=%s)", candidate.CandidateID, candidate.Mint)
+		r.trackCandidate(ctx, candidate)
 	}
 
 	if len(candidates) > 0 {
@@ -407,11 +762,266 @@ func (r *Runner) runActiveTokenDetection(ctx context.Context) {
 	}
 }
 
-// Stats returns current runner statistics.
+// runLiquidityAssociation attaches stored orphan liquidity events to candidates discovered since.
+func (r *Runner) runLiquidityAssociation(ctx context.Context) {
+	if r.liquidityStore == nil || r.candidateStore == nil {
+		return
+	}
+
+	assoc := NewLiquidityAssociator(r.liquidityStore, r.candidateStore, r.logger)
+	assoc.links = r.links
+	result, err := assoc.Run(ctx)
+	if err != nil {
+		r.logger.Printf("Error in liquidity association: %v", err)
+		return
+	}
+	if result.Scanned > 0 {
+		r.logger.Printf("Liquidity association: %d repaired, %d still orphaned", result.Repaired, result.Orphaned)
+	}
+}
+
+// runProvisionalExpiry expires provisional mints that never got a pool.
+// Like ACTIVE_TOKEN detection it is evaluated at the last event time.
+func (r *Runner) runProvisionalExpiry(ctx context.Context) {
+	if r.prePool == nil || r.lastEventTime == 0 {
+		return
+	}
+
+	n, err := r.prePool.ExpireAt(ctx, r.lastEventTime)
+	if err != nil {
+		r.logger.Printf("Error expiring provisional mints: %v", err)
+		return
+	}
+	if n > 0 {
+		r.logger.Printf("Provisional mints expired: %d", n)
+		r.updateStats(func(st *RunnerStats) { st.ProvisionalExpired += int64(n) })
+	}
+}
+
+// runWatchlistRefresh re-fetches metadata for watchlisted mints.
+func (r *Runner) runWatchlistRefresh(ctx context.Context) {
+	if r.watchlist == nil || r.metadataSource == nil || r.metadataStore == nil || r.candidateStore == nil {
+		return
+	}
+
+	n, err := RefreshWatchlistMetadata(ctx, r.metadataSource, r.watchlist, r.candidateStore, r.metadataStore, r.logger)
+	if err != nil {
+		r.logger.Printf("Error in watchlist refresh: %v", err)
+		return
+	}
+	if n > 0 {
+		r.logger.Printf("Watchlist refresh: %d metadata rows refreshed", n)
+	}
+}
+
+// checkWSHealth switches the endpoint of every live source with a starved
+// subscription, then backfills from the earliest starved window of the
+// switched sources to now, in the background.
+func (r *Runner) checkWSHealth(ctx, backfillCtx context.Context, now time.Time) {
+	var gapStart time.Time
+	for _, p := range r.probes {
+		starved := p.check(now)
+		if len(starved) == 0 {
+			continue
+		}
+		r.logger.Printf("[%s] %d subscription(s) below %.2f notifications/min over %v: %v",
+			p.feed.name, len(starved), r.wsHealth.MinNotificationsPerMinute, r.wsHealth.StarvationThreshold, starved)
+
+		// A fresh window either way, so a failed switch is retried a window later
+		p.reset()
+		switcher, ok := p.feed.ws.(EndpointSwitcher)
+		if !ok {
+			r.logger.Printf("[%s] WebSocket client cannot switch endpoints", p.feed.name)
+			continue
+		}
+		endpoint, err := switcher.SwitchEndpoint(ctx)
+		r.updateStats(func(st *RunnerStats) {
+			st.WSEndpointSwitches++
+			if err != nil {
+				st.WSEndpointSwitchErrors++
+			}
+		})
+		if r.onEndpointSwitch != nil {
+			r.onEndpointSwitch(p.feed.name, err)
+		}
+		if err != nil {
+			// The gap keeps growing from its start until a switch succeeds
+			r.logger.Printf("[%s] Endpoint switch to %s failed: %v", p.feed.name, endpoint, err)
+			continue
+		}
+		r.logger.Printf("[%s] Switched to endpoint %s and resubscribed %d program(s)", p.feed.name, endpoint, len(p.feed.list()))
+
+		if gapStart.IsZero() || p.gapStart.Before(gapStart) {
+			gapStart = p.gapStart
+		}
+		p.gapStart = time.Time{}
+	}
+
+	if gapStart.IsZero() || r.gapBackfill == nil {
+		return
+	}
+	from := gapStart.Add(-r.wsHealth.GapOverlap)
+	r.gapBackfills.Add(1)
+	go func() {
+		defer r.gapBackfills.Done()
+		r.gapBackfillMu.Lock()
+		defer r.gapBackfillMu.Unlock()
+		r.logger.Printf("Backfilling WS gap %s to %s", from.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339))
+		if err := r.gapBackfill(backfillCtx, from, now); err != nil {
+			r.logger.Printf("Error in WS gap backfill: %v", err)
+			r.updateStats(func(st *RunnerStats) { st.GapBackfillErrors++ })
+			return
+		}
+		r.updateStats(func(st *RunnerStats) { st.GapBackfills++ })
+	}()
+}
+
+// RunnerStats holds runner counters.
 type RunnerStats struct {
 	SwapEventsProcessed      int64
 	LiquidityEventsProcessed int64
+	DuplicateSwapEvents      int64 // already stored (e.g. by an overlapping backfill), skipped
+	DuplicateLiquidityEvents int64 // already stored (e.g. by an overlapping backfill), skipped
 	NewTokensDiscovered      int64
 	ActiveTokensDiscovered   int64
+	ProvisionalMints         int64 // PRE_POOL mints recorded at creation
+	ProvisionalUpgraded      int64 // PRE_POOL mints upgraded to NEW_TOKEN
+	ProvisionalExpired       int64 // PRE_POOL mints expired without a pool
+	CappedSwapEvents         int64 // dropped past a per-candidate cap (see EventCaps)
+	CappedLiquidityEvents    int64 // dropped past a per-candidate cap (see EventCaps)
 	LastActiveCheck          time.Time
+
+	// WS health probe (see WSHealthConfig)
+	WSEndpointSwitches     int64 // switches of a starved live source, failed ones included
+	WSEndpointSwitchErrors int64
+	GapBackfills           int64 // starved windows backfilled
+	GapBackfillErrors      int64
+}
+
+// Stats returns a snapshot of runner statistics. Safe to call while Run is active.
+func (r *Runner) Stats() RunnerStats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	return r.stats
+}
+
+// updateStats applies fn to the runner statistics under lock.
+func (r *Runner) updateStats(fn func(*RunnerStats)) {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	fn(&r.stats)
+}
+
+// AddProgram starts ingesting a DEX program on every live source without a restart.
+// Safe to call while Run is active.
+func (r *Runner) AddProgram(ctx context.Context, program string) error {
+	if r.wsSwapSource == nil && r.wsLiquiditySource == nil {
+		return errors.New("no live sources configured")
+	}
+	if r.wsSwapSource != nil {
+		if err := r.wsSwapSource.AddProgram(program); err != nil {
+			return fmt.Errorf("swap source: %w", err)
+		}
+	}
+	if r.wsLiquiditySource != nil {
+		if err := r.wsLiquiditySource.AddProgram(program); err != nil {
+			// Keep both sources on the same program set
+			if r.wsSwapSource != nil {
+				_ = r.wsSwapSource.RemoveProgram(ctx, program)
+			}
+			return fmt.Errorf("liquidity source: %w", err)
+		}
+	}
+	r.logger.Printf("Program added: %s", program)
+	return nil
+}
+
+// RemoveProgram stops ingesting a DEX program. Data already stored for it is kept
+// and its counters stay in ProgramStats. Safe to call while Run is active.
+func (r *Runner) RemoveProgram(ctx context.Context, program string) error {
+	if r.wsSwapSource == nil && r.wsLiquiditySource == nil {
+		return errors.New("no live sources configured")
+	}
+	var errs []error
+	if r.wsSwapSource != nil {
+		if err := r.wsSwapSource.RemoveProgram(ctx, program); err != nil {
+			errs = append(errs, fmt.Errorf("swap source: %w", err))
+		}
+	}
+	if r.wsLiquiditySource != nil {
+		if err := r.wsLiquiditySource.RemoveProgram(ctx, program); err != nil {
+			errs = append(errs, fmt.Errorf("liquidity source: %w", err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	r.logger.Printf("Program removed: %s", program)
+	return nil
+}
+
+// ProgramStats holds ingestion counters for one DEX program.
+type ProgramStats struct {
+	Program       string
+	Active        bool  // currently subscribed
+	Events        int64 // swap and liquidity events received
+	Candidates    int64 // NEW_TOKEN candidates discovered from the program's swaps
+	ParseFailures int64 // parsed events without a resolvable mint
+	Duplicates    int64 // redelivered notifications dropped before parsing
+	Transactions  int64 // transactions observed by the sources sharing RunnerOptions.FailedTx
+	FailedTxs     int64 // of Transactions, failed on chain and skipped
+	LastEventAt   int64 // Unix ms when the latest event was received, 0 if none
+}
+
+// ProgramStats returns per-program counters ordered by program, including
+// programs that have been removed. Safe to call while Run is active.
+func (r *Runner) ProgramStats() []ProgramStats {
+	events := r.programEvents.snapshot()
+	lastEvent := r.programLastEvent.snapshot()
+	candidates := r.programCandidates.snapshot()
+	failures := make(map[string]int64)
+	duplicates := make(map[string]int64)
+	active := make(map[string]int64)
+
+	if r.wsSwapSource != nil {
+		for p, n := range r.wsSwapSource.parseFailures.snapshot() {
+			failures[p] += n
+		}
+		for p, n := range r.wsSwapSource.duplicates.snapshot() {
+			duplicates[p] += n
+		}
+		for _, p := range r.wsSwapSource.Programs() {
+			active[p] = 1
+		}
+	}
+	if r.wsLiquiditySource != nil {
+		for p, n := range r.wsLiquiditySource.parseFailures.snapshot() {
+			failures[p] += n
+		}
+		for p, n := range r.wsLiquiditySource.duplicates.snapshot() {
+			duplicates[p] += n
+		}
+		for _, p := range r.wsLiquiditySource.Programs() {
+			active[p] = 1
+		}
+	}
+
+	transactions, failedTxs := r.failedTx.Counts()
+
+	programs := sortedPrograms(events, candidates, failures, duplicates, transactions, active)
+	stats := make([]ProgramStats, len(programs))
+	for i, p := range programs {
+		stats[i] = ProgramStats{
+			Program:       p,
+			Active:        active[p] > 0,
+			Events:        events[p],
+			Candidates:    candidates[p],
+			ParseFailures: failures[p],
+			Duplicates:    duplicates[p],
+			Transactions:  transactions[p],
+			FailedTxs:     failedTxs[p],
+			LastEventAt:   lastEvent[p],
+		}
+	}
+	return stats
 }
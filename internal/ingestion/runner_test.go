@@ -202,10 +202,11 @@ func TestRunner_DeterministicOrdering(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, events, 3)
 
-		// Should be sorted by (tx_signature, event_index) within the slot
-		assert.Equal(t, "txA", events[0].TxSignature, "Run %d: first should be txA", run)
-		assert.Equal(t, "txB", events[1].TxSignature, "Run %d: second should be txB", run)
-		assert.Equal(t, "txC", events[2].TxSignature, "Run %d: third should be txC", run)
+		// The store returns events by (timestamp, mint, tx_signature, event_index),
+		// so events of one timestamp come out in mint order
+		assert.Equal(t, "txC", events[0].TxSignature, "Run %d: first should be txC (mint1)", run)
+		assert.Equal(t, "txA", events[1].TxSignature, "Run %d: second should be txA (mint2)", run)
+		assert.Equal(t, "txB", events[2].TxSignature, "Run %d: third should be txB (mint3)", run)
 	}
 }
 
@@ -222,15 +223,15 @@ func TestRunner_LiquidityEventBuffering(t *testing.T) {
 
 	// Buffer liquidity events
 	runner.bufferLiquidityEvent(ctx, &domain.LiquidityEvent{
-		CandidateID: "c1", Slot: 3, TxSignature: "tx3", EventIndex: 0, Timestamp: 3000,
+		CandidateID: "c1", Slot: 3, TxSignature: "tx3", EventIndex: 0, Timestamp: 3000, EventType: domain.LiquidityEventAdd,
 	})
 	runner.bufferLiquidityEvent(ctx, &domain.LiquidityEvent{
-		CandidateID: "c1", Slot: 1, TxSignature: "tx1", EventIndex: 0, Timestamp: 1000,
+		CandidateID: "c1", Slot: 1, TxSignature: "tx1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd,
 	})
 
 	// Trigger finalization
 	runner.bufferLiquidityEvent(ctx, &domain.LiquidityEvent{
-		CandidateID: "c1", Slot: 5, TxSignature: "tx5", EventIndex: 0, Timestamp: 5000,
+		CandidateID: "c1", Slot: 5, TxSignature: "tx5", EventIndex: 0, Timestamp: 5000, EventType: domain.LiquidityEventAdd,
 	})
 
 	// Slots 1, 3 should be finalized (5 - 2 = 3, so slots <= 3 are finalized)
@@ -257,7 +258,7 @@ func TestRunner_MixedEventTypes(t *testing.T) {
 		Mint: "mint1", Slot: 3, TxSignature: "swap_tx", EventIndex: 0, Timestamp: 3000,
 	})
 	runner.bufferLiquidityEvent(ctx, &domain.LiquidityEvent{
-		CandidateID: "c1", Slot: 3, TxSignature: "liq_tx", EventIndex: 0, Timestamp: 3000,
+		CandidateID: "c1", Slot: 3, TxSignature: "liq_tx", EventIndex: 0, Timestamp: 3000, EventType: domain.LiquidityEventAdd,
 	})
 
 	// Trigger finalization
@@ -370,6 +371,56 @@ func TestRunner_NoDuplicateCandidates(t *testing.T) {
 	assert.Len(t, candidates, 1, "Should only have one candidate per mint")
 }
 
+func TestRunner_PrePoolMintUpgradedByFirstSwap(t *testing.T) {
+	candidateStore := memory.NewCandidateStore()
+	provisionalStore := memory.NewProvisionalMintStore()
+
+	runner := NewRunner(RunnerOptions{
+		SwapEventStore:   memory.NewSwapEventStore(),
+		CandidateStore:   candidateStore,
+		NewTokenDetector: discovery.NewDetector(candidateStore),
+		PrePoolTracker:   discovery.NewPrePoolTracker(provisionalStore, candidateStore, time.Minute),
+		SlotLagWindow:    1,
+		Logger:           log.New(os.Stderr, "[test] ", log.LstdFlags),
+	})
+
+	ctx := context.Background()
+
+	// Mint created and first swapped in the same slot: the creation is handled first
+	runner.bufferMintCreation(ctx, &discovery.MintCreationEvent{
+		Mint: "pooled_mint", TxSignature: "create1", Slot: 1, Timestamp: 1000,
+	})
+	runner.bufferSwapEvent(ctx, &domain.SwapEvent{
+		Mint: "pooled_mint", Slot: 1, TxSignature: "tx1", EventIndex: 0, Timestamp: 1000,
+	})
+	runner.bufferMintCreation(ctx, &discovery.MintCreationEvent{
+		Mint: "unpooled_mint", TxSignature: "create2", Slot: 2, Timestamp: 2000,
+	})
+
+	// Trigger finalization
+	runner.bufferSwapEvent(ctx, &domain.SwapEvent{
+		Mint: "trigger", Slot: 10, TxSignature: "tx10", EventIndex: 0, Timestamp: 120000,
+	})
+
+	pooled, err := provisionalStore.GetByMint(ctx, "pooled_mint")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProvisionalMintUpgraded, pooled.Status)
+	require.NotNil(t, pooled.CandidateID)
+
+	// Expiry runs on the check ticker, at the last processed event time
+	runner.flushAllSlots(ctx)
+	runner.runProvisionalExpiry(ctx)
+
+	unpooled, err := provisionalStore.GetByMint(ctx, "unpooled_mint")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProvisionalMintExpired, unpooled.Status)
+
+	stats := runner.Stats()
+	assert.Equal(t, int64(2), stats.ProvisionalMints)
+	assert.Equal(t, int64(1), stats.ProvisionalUpgraded)
+	assert.Equal(t, int64(1), stats.ProvisionalExpired)
+}
+
 func TestRunner_DefaultValues(t *testing.T) {
 	runner := NewRunner(RunnerOptions{})
 
@@ -377,3 +428,39 @@ func TestRunner_DefaultValues(t *testing.T) {
 	assert.Equal(t, int64(5), runner.slotLagWindow, "Default slot lag window should be 5")
 	assert.NotNil(t, runner.logger, "Logger should not be nil")
 }
+
+func TestRunner_DuplicatesFromBackfillSkipped(t *testing.T) {
+	swapEventStore := memory.NewSwapEventStore()
+	liquidityStore := memory.NewLiquidityEventStore()
+	ctx := context.Background()
+
+	// Backfill already stored the overlapping window
+	backfilled := &domain.SwapEvent{Mint: "mint1", Slot: 1, TxSignature: "tx1", EventIndex: 0, Timestamp: 1000}
+	require.NoError(t, swapEventStore.Insert(ctx, backfilled))
+	require.NoError(t, liquidityStore.Insert(ctx, &domain.LiquidityEvent{
+		Mint: "mint1", Pool: "pool1", Slot: 1, TxSignature: "tx1", EventIndex: 1, Timestamp: 1000, EventType: domain.LiquidityEventAdd,
+	}))
+
+	runner := NewRunner(RunnerOptions{
+		SwapEventStore: swapEventStore,
+		LiquidityStore: liquidityStore,
+		Logger:         log.New(os.Stderr, "[test] ", log.LstdFlags),
+	})
+
+	// Same events arrive over WebSocket, with the live path already knowing the candidate
+	runner.handleSwapEvent(ctx, backfilled)
+	runner.handleLiquidityEvent(ctx, &domain.LiquidityEvent{
+		CandidateID: "cand1", Mint: "mint1", Pool: "pool1", Slot: 1, TxSignature: "tx1", EventIndex: 1, Timestamp: 1000, EventType: domain.LiquidityEventAdd,
+	})
+	runner.handleSwapEvent(ctx, &domain.SwapEvent{Mint: "mint1", Slot: 2, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000})
+
+	stats := runner.Stats()
+	assert.Equal(t, int64(1), stats.DuplicateSwapEvents)
+	assert.Equal(t, int64(1), stats.DuplicateLiquidityEvents)
+	assert.Equal(t, int64(1), stats.SwapEventsProcessed)
+	assert.Equal(t, int64(0), stats.LiquidityEventsProcessed)
+
+	events, err := swapEventStore.GetByMintTimeRange(ctx, "mint1", 0, 3000)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
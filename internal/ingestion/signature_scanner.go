@@ -0,0 +1,147 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"solana-token-lab/internal/solana"
+)
+
+// DefaultSignatureOvershoot is how far past the start of the window a page must lie
+// before pagination stops. Signature pages are only roughly time-ordered (forks,
+// late block times), so one old signature does not mean the window is exhausted.
+const DefaultSignatureOvershoot = 2 * time.Minute
+
+// signaturePageLimit is the getSignaturesForAddress page size.
+const signaturePageLimit = 1000
+
+// signatureScan walks an address's signature history backwards over [fromSec, toSec).
+type signatureScan struct {
+	rpc       *solana.HTTPClient
+	program   string // progress is recorded under program
+	address   string // account whose signatures are walked; program when empty
+	fromSec   int64
+	toSec     int64
+	overshoot time.Duration
+	progress  *BackfillProgress
+	failedTx  *FailedTxTracker // counts in-window transactions under source
+	source    string
+}
+
+// run calls visit for every successful in-window transaction with its block time.
+// Transactions are visited once even if their signature appears on several pages.
+// Signatures without a block time are resolved by fetching the transaction.
+// Pagination stops on an empty page or once every signature on a page is older
+// than fromSec minus the overshoot margin.
+func (sc *signatureScan) run(ctx context.Context, visit func(tx *solana.Transaction, blockTime int64) error) error {
+	stopBefore := sc.fromSec - int64(sc.overshoot/time.Second)
+	address := sc.address
+	if address == "" {
+		address = sc.program
+	}
+	seen := make(map[string]bool)
+	var before string
+
+	for {
+		opts := &solana.SignaturesOpts{
+			Limit:  signaturePageLimit,
+			Before: before,
+		}
+
+		sigs, err := retryRPC(ctx, "GetSignaturesForAddress "+address, func() ([]solana.SignatureInfo, error) {
+			return sc.rpc.GetSignaturesForAddress(ctx, address, opts)
+		})
+		if err != nil {
+			return fmt.Errorf("get signatures: %w", err)
+		}
+
+		if len(sigs) == 0 {
+			return nil
+		}
+		sc.progress.AddSignatures(sc.program, len(sigs))
+
+		pageOlder := true
+		for _, sig := range sigs {
+			if seen[sig.Signature] {
+				continue
+			}
+			seen[sig.Signature] = true
+
+			// Failed transactions carry no events; only their block time matters for
+			// termination. Those without a block time cannot be placed in the window
+			// and are not counted.
+			if sig.Err != nil {
+				if sig.BlockTime == nil || *sig.BlockTime >= stopBefore {
+					pageOlder = false
+				}
+				if sig.BlockTime != nil && *sig.BlockTime >= sc.fromSec && *sig.BlockTime < sc.toSec {
+					sc.progress.AddFailedTransaction(sc.program)
+					sc.failedTx.observe(sc.source, sc.program, *sig.BlockTime, true)
+				}
+				continue
+			}
+
+			var tx *solana.Transaction
+			var blockTime int64
+			if sig.BlockTime != nil {
+				blockTime = *sig.BlockTime
+			} else {
+				// Block time not yet known to the signature index: ask for the transaction
+				tx, err = sc.fetch(ctx, sig.Signature)
+				if err != nil {
+					return err
+				}
+				if tx == nil || tx.BlockTime == 0 {
+					pageOlder = false
+					continue
+				}
+				blockTime = tx.BlockTime
+			}
+
+			if blockTime >= stopBefore {
+				pageOlder = false
+			}
+			if blockTime < sc.fromSec || blockTime >= sc.toSec {
+				continue
+			}
+			sc.failedTx.observe(sc.source, sc.program, blockTime, false)
+
+			if tx == nil {
+				tx, err = sc.fetch(ctx, sig.Signature)
+				if err != nil {
+					return err
+				}
+			}
+			if tx == nil || tx.Meta == nil {
+				continue
+			}
+
+			if err := visit(tx, blockTime); err != nil {
+				return err
+			}
+		}
+
+		if pageOlder {
+			return nil
+		}
+
+		before = sigs[len(sigs)-1].Signature
+	}
+}
+
+// fetch retrieves a transaction and records it in progress. Transient RPC
+// failures are retried; a transaction pruned from the node's history is
+// returned as nil and skipped by the scan.
+func (sc *signatureScan) fetch(ctx context.Context, signature string) (*solana.Transaction, error) {
+	tx, err := retryGetTransaction(ctx, sc.rpc, signature)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction %s: %w", signature, err)
+	}
+	blockTime := int64(0)
+	if tx != nil {
+		blockTime = tx.BlockTime
+	}
+	sc.progress.AddTransaction(sc.program, blockTime)
+	return tx, nil
+}
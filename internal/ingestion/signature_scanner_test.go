@@ -0,0 +1,298 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/solana"
+)
+
+// pagedRPC serves signature pages keyed by the "before" cursor and transactions
+// with pump.fun Buy logs keyed by signature.
+type pagedRPC struct {
+	pages      map[string][]map[string]interface{} // before cursor ("" for first page) -> page
+	blockTimes map[string]int64                    // signature -> transaction block time
+	txErrors   map[string][]int                    // signature -> JSON-RPC error codes served before the transaction
+
+	mu      sync.Mutex
+	fetched []string
+}
+
+func (f *pagedRPC) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getSignaturesForAddress":
+			var opts struct {
+				Before string `json:"before"`
+			}
+			if len(req.Params) > 1 {
+				_ = json.Unmarshal(req.Params[1], &opts)
+			}
+			page, ok := f.pages[opts.Before]
+			if !ok {
+				page = []map[string]interface{}{}
+			}
+			result = page
+		case "getTransaction":
+			var sig string
+			_ = json.Unmarshal(req.Params[0], &sig)
+			f.mu.Lock()
+			f.fetched = append(f.fetched, sig)
+			var code int
+			if queue := f.txErrors[sig]; len(queue) > 0 {
+				code, f.txErrors[sig] = queue[0], queue[1:]
+			}
+			f.mu.Unlock()
+			if code != 0 {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      req.ID,
+					"error":   map[string]interface{}{"code": code, "message": "fake error"},
+				})
+				return
+			}
+			result = map[string]interface{}{
+				"slot":      int64(1),
+				"blockTime": f.blockTimes[sig],
+				"meta": map[string]interface{}{
+					"err": nil,
+					"logMessages": []string{
+						"Program " + discovery.PumpFun + " invoke [1]",
+						"Program log: mint=Mint" + sig,
+						"Program log: Instruction: Buy",
+						"Program " + discovery.PumpFun + " success",
+					},
+				},
+				"transaction": map[string]interface{}{
+					"message": map[string]interface{}{"accountKeys": []string{}},
+				},
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func sigInfo(signature string, blockTime int64) map[string]interface{} {
+	return map[string]interface{}{"signature": signature, "slot": 1, "blockTime": blockTime}
+}
+
+func sigInfoNoTime(signature string) map[string]interface{} {
+	return map[string]interface{}{"signature": signature, "slot": 1, "blockTime": nil}
+}
+
+// outOfOrderPages builds pages for window [1000, 2000) seconds with a 60s overshoot:
+//   - page 1 contains an old signature before in-window ones (fork reordering)
+//     and a signature whose block time is not yet indexed
+//   - page 2 repeats a signature from page 1 and still has an in-window signature
+//   - page 3 is entirely older than 1000-60 and ends the scan
+//   - page 4 must never be requested
+func outOfOrderPages() *pagedRPC {
+	return &pagedRPC{
+		pages: map[string][]map[string]interface{}{
+			"": {
+				sigInfo("future", 2500),
+				sigInfo("in1", 1900),
+				sigInfo("old1", 500),
+				sigInfoNoTime("pending"),
+				sigInfo("in2", 1500),
+			},
+			"in2": {
+				sigInfo("in2", 1500), // duplicate across pages
+				sigInfo("old2", 900),
+				sigInfo("in3", 1010),
+			},
+			"in3": {
+				sigInfo("old3", 939),
+				sigInfo("old4", 100),
+			},
+			"old4": {
+				sigInfo("unreachable", 1200),
+			},
+		},
+		blockTimes: map[string]int64{
+			"in1":         1900,
+			"pending":     1700,
+			"in2":         1500,
+			"in3":         1010,
+			"unreachable": 1200,
+		},
+	}
+}
+
+func TestSignatureScan_OutOfOrderPages(t *testing.T) {
+	fake := outOfOrderPages()
+	server := fake.serve(t)
+	defer server.Close()
+
+	scan := &signatureScan{
+		rpc:       solana.NewHTTPClient(server.URL),
+		program:   discovery.PumpFun,
+		fromSec:   1000,
+		toSec:     2000,
+		overshoot: 60 * time.Second,
+	}
+
+	var visited []string
+	err := scan.run(context.Background(), func(tx *solana.Transaction, blockTime int64) error {
+		if blockTime != fake.blockTimes[tx.Signature] {
+			t.Errorf("%s: expected block time %d, got %d", tx.Signature, fake.blockTimes[tx.Signature], blockTime)
+		}
+		visited = append(visited, tx.Signature)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	want := []string{"in1", "pending", "in2", "in3"}
+	if len(visited) != len(want) {
+		t.Fatalf("expected %v, got %v", want, visited)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Errorf("visit %d: expected %s, got %s", i, want[i], visited[i])
+		}
+	}
+
+	// pending is fetched once to resolve its block time and reused for the visit
+	for _, sig := range fake.fetched {
+		if sig == "unreachable" {
+			t.Error("scan continued past a fully old page")
+		}
+	}
+	if len(fake.fetched) != 4 {
+		t.Errorf("expected 4 transaction fetches, got %v", fake.fetched)
+	}
+}
+
+func TestSignatureScan_OvershootStopsEarlier(t *testing.T) {
+	fake := outOfOrderPages()
+	server := fake.serve(t)
+	defer server.Close()
+
+	// With a large overshoot page 3 (939, 100) is not old enough, so page 4 is scanned
+	scan := &signatureScan{
+		rpc:       solana.NewHTTPClient(server.URL),
+		program:   discovery.PumpFun,
+		fromSec:   1000,
+		toSec:     2000,
+		overshoot: 10 * time.Minute,
+	}
+
+	var visited []string
+	if err := scan.run(context.Background(), func(tx *solana.Transaction, _ int64) error {
+		visited = append(visited, tx.Signature)
+		return nil
+	}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	if len(visited) != 5 || visited[4] != "unreachable" {
+		t.Errorf("expected page 4 to be scanned with large overshoot, got %v", visited)
+	}
+}
+
+func TestRPCSwapEventSource_NoInWindowSignatureMissed(t *testing.T) {
+	fake := outOfOrderPages()
+	server := fake.serve(t)
+	defer server.Close()
+
+	source := NewRPCSwapEventSource(solana.NewHTTPClient(server.URL), []string{discovery.PumpFun}).
+		WithOvershoot(60 * time.Second)
+
+	events, err := source.Fetch(context.Background(), 1000*1000, 2000*1000)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	var sigs []string
+	for _, e := range events {
+		sigs = append(sigs, e.TxSignature)
+		if e.Timestamp != fake.blockTimes[e.TxSignature]*1000 {
+			t.Errorf("%s: expected timestamp %d, got %d", e.TxSignature, fake.blockTimes[e.TxSignature]*1000, e.Timestamp)
+		}
+	}
+	sort.Strings(sigs)
+
+	want := []string{"in1", "in2", "in3", "pending"}
+	if len(sigs) != len(want) {
+		t.Fatalf("expected events for %v, got %v", want, sigs)
+	}
+	for i := range want {
+		if sigs[i] != want[i] {
+			t.Errorf("expected %s, got %s", want[i], sigs[i])
+		}
+	}
+}
+
+func TestSignatureScan_BranchesOnRPCErrors(t *testing.T) {
+	fake := outOfOrderPages()
+	fake.txErrors = map[string][]int{
+		"in1": {-32004},         // node behind: retried, then served
+		"in2": {-32011, -32011}, // history missing: skipped without retry
+	}
+	server := fake.serve(t)
+	defer server.Close()
+
+	scan := &signatureScan{
+		rpc:       solana.NewHTTPClient(server.URL),
+		program:   discovery.PumpFun,
+		fromSec:   1000,
+		toSec:     2000,
+		overshoot: 60 * time.Second,
+	}
+
+	var visited []string
+	if err := scan.run(context.Background(), func(tx *solana.Transaction, _ int64) error {
+		visited = append(visited, tx.Signature)
+		return nil
+	}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	want := []string{"in1", "pending", "in3"}
+	if len(visited) != len(want) {
+		t.Fatalf("expected %v, got %v", want, visited)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Errorf("visit %d: expected %s, got %s", i, want[i], visited[i])
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, sig := range fake.fetched {
+		counts[sig]++
+	}
+	if counts["in1"] != 2 {
+		t.Errorf("in1: expected a retry after node behind, got %d fetches", counts["in1"])
+	}
+	if counts["in2"] != 1 {
+		t.Errorf("in2: expected no retry after not found, got %d fetches", counts["in2"])
+	}
+}
@@ -59,12 +59,16 @@ func parseTokenAccountMint(data string) (string, error) {
 }
 
 // inferRaydiumPoolAndMint extracts pool and token mint from Raydium swap accounts.
+// Without an RPC client (offline reparse) only the pool is inferred.
 func inferRaydiumPoolAndMint(ctx context.Context, rpc *solana.HTTPClient, accountKeys []string) (string, string, error) {
 	if len(accountKeys) < 7 {
 		return "", "", nil
 	}
 
 	pool := accountKeys[1]
+	if rpc == nil {
+		return pool, "", nil
+	}
 	tokenAccounts := []string{accountKeys[5], accountKeys[6]}
 
 	for _, acct := range tokenAccounts {
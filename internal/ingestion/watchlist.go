@@ -0,0 +1,73 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// MetadataFetcher fetches token metadata for a mint.
+// Implemented by *RPCMetadataSource.
+type MetadataFetcher interface {
+	Fetch(ctx context.Context, mint string) (*domain.TokenMetadata, error)
+}
+
+// RefreshWatchlistMetadata re-fetches metadata for every watchlisted mint with a
+// candidate and upserts it, returning the number of mints refreshed. Metadata is
+// kept once per mint: the existing row is refreshed, or the mint's first candidate
+// gets a new one.
+// Mints are visited in watchlist order (mint ASC); per-mint fetch failures are
+// logged and skipped, store failures abort the pass.
+func RefreshWatchlistMetadata(
+	ctx context.Context,
+	fetcher MetadataFetcher,
+	watchlist storage.WatchlistStore,
+	candidateStore storage.CandidateStore,
+	metadataStore storage.TokenMetadataStore,
+	logger *log.Logger,
+) (int, error) {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	entries, err := watchlist.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list watchlist: %w", err)
+	}
+
+	refreshed := 0
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return refreshed, err
+		}
+
+		candidates, err := candidateStore.GetByMint(ctx, e.Mint)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return refreshed, fmt.Errorf("get candidates for mint %s: %w", e.Mint, err)
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		meta, err := fetcher.Fetch(ctx, e.Mint)
+		if err != nil {
+			logger.Printf("Watchlist refresh: metadata for %s: %v", e.Mint, err)
+			continue
+		}
+		if meta == nil {
+			continue
+		}
+
+		meta.CandidateID = candidates[0].CandidateID
+		if err := upsertMintMetadata(ctx, metadataStore, meta); err != nil {
+			return refreshed, err
+		}
+		refreshed++
+	}
+
+	return refreshed, nil
+}
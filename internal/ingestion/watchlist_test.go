@@ -0,0 +1,133 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// fakeMetadataFetcher returns metadata with a fixed symbol, failing for listed mints.
+type fakeMetadataFetcher struct {
+	fail  map[string]bool
+	calls []string
+}
+
+func (f *fakeMetadataFetcher) Fetch(_ context.Context, mint string) (*domain.TokenMetadata, error) {
+	f.calls = append(f.calls, mint)
+	if f.fail[mint] {
+		return nil, errors.New("rpc failed")
+	}
+	symbol := "SYM"
+	return &domain.TokenMetadata{Mint: mint, Symbol: &symbol, Decimals: 6, FetchedAt: 5000}, nil
+}
+
+func TestRefreshWatchlistMetadata(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	metadataStore := memory.NewTokenMetadataStore()
+	watchlist := memory.NewWatchlistStore()
+
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "w1", Source: domain.SourceNewToken, Mint: "mintW", TxSignature: "tx1"})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "w2", Source: domain.SourceActiveToken, Mint: "mintW", TxSignature: "tx2"})
+	// mintW already has metadata under its ACTIVE_TOKEN candidate
+	oldName := "Old"
+	_ = metadataStore.Insert(ctx, &domain.TokenMetadata{CandidateID: "w2", Mint: "mintW", Name: &oldName, FetchedAt: 1000})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "f1", Source: domain.SourceNewToken, Mint: "mintFail", TxSignature: "tx3"})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "u1", Source: domain.SourceNewToken, Mint: "mintUnwatched", TxSignature: "tx4"})
+	for _, mint := range []string{"mintW", "mintFail", "mintNoCandidate"} {
+		if err := watchlist.Add(ctx, &domain.WatchlistEntry{Mint: mint, Reason: "manual"}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	fetcher := &fakeMetadataFetcher{fail: map[string]bool{"mintFail": true}}
+	n, err := RefreshWatchlistMetadata(ctx, fetcher, watchlist, candidateStore, metadataStore, nil)
+	if err != nil {
+		t.Fatalf("RefreshWatchlistMetadata failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 refreshed mint, got %d", n)
+	}
+	// Mints without candidates are not fetched
+	if len(fetcher.calls) != 2 || fetcher.calls[0] != "mintFail" || fetcher.calls[1] != "mintW" {
+		t.Errorf("expected fetches for mintFail and mintW, got %v", fetcher.calls)
+	}
+	meta, err := metadataStore.GetByMint(ctx, "mintW")
+	if err != nil {
+		t.Fatalf("GetByMint failed: %v", err)
+	}
+	if meta.CandidateID != "w2" || meta.Symbol == nil || *meta.Symbol != "SYM" || meta.FetchedAt != 5000 {
+		t.Errorf("expected existing row refreshed in place, got %+v", meta)
+	}
+	if _, err := metadataStore.GetByID(ctx, "u1"); err == nil {
+		t.Error("expected no metadata for un-watchlisted mint")
+	}
+
+	// Un-watchlisting stops the refresh
+	_ = watchlist.Remove(ctx, "mintW")
+	_ = watchlist.Remove(ctx, "mintFail")
+	fetcher.calls = nil
+	n, err = RefreshWatchlistMetadata(ctx, fetcher, watchlist, candidateStore, metadataStore, nil)
+	if err != nil || n != 0 || len(fetcher.calls) != 0 {
+		t.Errorf("expected no refresh after removal, got n=%d err=%v calls=%v", n, err, fetcher.calls)
+	}
+}
+
+func TestHolderEnricher_WatchlistExemptFromActiveWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(100 * 3600000)
+	nowMs := now.UnixMilli()
+
+	candidateStore := memory.NewCandidateStore()
+	snapshotStore := memory.NewTokenHolderSnapshotStore()
+	watchlist := memory.NewWatchlistStore()
+
+	// Both discovered long before the active window
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "watched", Source: domain.SourceNewToken, Mint: "mintWatched", TxSignature: "tx1", DiscoveredAt: nowMs - 72*3600000})
+	_ = candidateStore.Insert(ctx, &domain.TokenCandidate{CandidateID: "old", Source: domain.SourceNewToken, Mint: "mintOld", TxSignature: "tx2", DiscoveredAt: nowMs - 72*3600000})
+	_ = watchlist.Add(ctx, &domain.WatchlistEntry{Mint: "mintWatched", Reason: "rug_detector"})
+
+	source := &fakeHolderSource{
+		accounts: map[string][]solana.TokenAccountBalance{
+			"mintWatched": {balance("w1", "10")},
+			"mintOld":     {balance("o1", "10")},
+		},
+		supply: map[string]*solana.TokenAmount{
+			"mintWatched": {Amount: "10", Decimals: 0},
+			"mintOld":     {Amount: "10", Decimals: 0},
+		},
+	}
+
+	clock := now
+	enricher := NewHolderEnricher(HolderEnricherOptions{
+		Source:          source,
+		CandidateStore:  candidateStore,
+		SnapshotStore:   snapshotStore,
+		Watchlist:       watchlist,
+		ActiveWindow:    24 * time.Hour,
+		MinMintInterval: time.Nanosecond,
+		Clock:           func() time.Time { return clock },
+	})
+
+	n, err := enricher.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if n != 1 || len(source.calls) != 1 || source.calls[0] != "mintWatched" {
+		t.Errorf("expected only the watchlisted mint snapshotted, got n=%d calls=%v", n, source.calls)
+	}
+
+	// Un-watchlisted: the mint falls back under the active window
+	_ = watchlist.Remove(ctx, "mintWatched")
+	clock = now.Add(time.Hour)
+	source.calls = nil
+	n, err = enricher.RunOnce(ctx)
+	if err != nil || n != 0 || len(source.calls) != 0 {
+		t.Errorf("expected no snapshots after removal, got n=%d err=%v calls=%v", n, err, source.calls)
+	}
+}
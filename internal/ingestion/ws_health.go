@@ -0,0 +1,118 @@
+package ingestion
+
+import (
+	"context"
+	"time"
+)
+
+// Defaults for the WS health probe.
+const (
+	DefaultStarvationThreshold = 5 * time.Minute
+	DefaultWSHealthInterval    = 30 * time.Second
+	DefaultGapOverlap          = 1 * time.Minute
+)
+
+// EndpointSwitcher is a LogSubscriber that can move its subscriptions to
+// another endpoint, keeping their channels. *solana.WSClientImpl implements it.
+type EndpointSwitcher interface {
+	SwitchEndpoint(ctx context.Context) (string, error)
+}
+
+// GapBackfillFunc backfills the events between from and to, e.g. through
+// Backfiller.BackfillRange. Events the live sources stored as well are
+// skipped as duplicates.
+type GapBackfillFunc func(ctx context.Context, from, to time.Time) error
+
+// WSHealthConfig configures the probe that catches a WS endpoint which stays
+// connected but stops delivering. When a subscription of a live source receives
+// fewer than MinNotificationsPerMinute over StarvationThreshold, the runner
+// switches the source's client to its next endpoint (reconnecting when it has
+// only one) and backfills the starved window. Set the floor below the rate of
+// the quietest monitored program.
+type WSHealthConfig struct {
+	MinNotificationsPerMinute float64       // floor per subscription; 0 disables the probe
+	StarvationThreshold       time.Duration // window the floor is measured over (default: DefaultStarvationThreshold)
+	CheckInterval             time.Duration // default: DefaultWSHealthInterval
+	GapOverlap                time.Duration // backfill starts this long before the starved window (default: DefaultGapOverlap)
+}
+
+// Enabled reports whether the probe runs.
+func (c WSHealthConfig) Enabled() bool {
+	return c.MinNotificationsPerMinute > 0
+}
+
+// withDefaults fills in the unset durations.
+func (c WSHealthConfig) withDefaults() WSHealthConfig {
+	if c.StarvationThreshold <= 0 {
+		c.StarvationThreshold = DefaultStarvationThreshold
+	}
+	if c.CheckInterval <= 0 {
+		c.CheckInterval = DefaultWSHealthInterval
+	}
+	if c.GapOverlap <= 0 {
+		c.GapOverlap = DefaultGapOverlap
+	}
+	return c
+}
+
+// healthSample is the notifications received per program of a feed up to a check.
+type healthSample struct {
+	at       time.Time
+	programs []string // monitored at the time
+	counts   map[string]int64
+}
+
+// wsHealthProbe tracks the notification rate of each subscription of one feed.
+// Not safe for concurrent use: the runner calls it from its event loop.
+type wsHealthProbe struct {
+	cfg      WSHealthConfig
+	feed     *programFeed
+	samples  []healthSample // oldest first
+	gapStart time.Time      // start of the starvation not yet backfilled, zero if none
+}
+
+func newWSHealthProbe(cfg WSHealthConfig, feed *programFeed) *wsHealthProbe {
+	return &wsHealthProbe{cfg: cfg, feed: feed}
+}
+
+// check samples the feed at now and returns the programs whose subscription
+// received less than the floor over the last StarvationThreshold. Nothing is
+// reported before a full window has been observed since the probe started or
+// was reset, and programs added during the window are not judged yet.
+func (p *wsHealthProbe) check(now time.Time) []string {
+	p.samples = append(p.samples, healthSample{at: now, programs: p.feed.list(), counts: p.feed.received.snapshot()})
+
+	// Keep the newest sample at or before the window start as the baseline
+	windowStart := now.Add(-p.cfg.StarvationThreshold)
+	for len(p.samples) > 1 && !p.samples[1].at.After(windowStart) {
+		p.samples = p.samples[1:]
+	}
+	base, last := p.samples[0], p.samples[len(p.samples)-1]
+	if base.at.After(windowStart) {
+		return nil
+	}
+
+	minutes := last.at.Sub(base.at).Minutes()
+	current := make(map[string]bool, len(last.programs))
+	for _, program := range last.programs {
+		current[program] = true
+	}
+	var starved []string
+	for _, program := range base.programs {
+		if !current[program] {
+			continue
+		}
+		if rate := float64(last.counts[program]-base.counts[program]) / minutes; rate < p.cfg.MinNotificationsPerMinute {
+			starved = append(starved, program)
+		}
+	}
+	if len(starved) > 0 && p.gapStart.IsZero() {
+		p.gapStart = base.at
+	}
+	return starved
+}
+
+// reset starts a new observation window, e.g. after an endpoint switch.
+func (p *wsHealthProbe) reset() {
+	p.samples = nil
+}
@@ -0,0 +1,255 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"solana-token-lab/internal/discovery"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/solana"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestWSHealthProbe_Check(t *testing.T) {
+	feed := newProgramFeed(nil, "ws-test", []string{"busy", "quiet"})
+	probe := newWSHealthProbe(WSHealthConfig{MinNotificationsPerMinute: 2, StarvationThreshold: time.Minute}.withDefaults(), feed)
+	start := time.Unix(1700000000, 0)
+
+	// No verdict before a full window was observed
+	if starved := probe.check(start); starved != nil {
+		t.Fatalf("expected no verdict at start, got %v", starved)
+	}
+	for i := 0; i < 3; i++ {
+		feed.received.inc("busy")
+	}
+	feed.received.inc("quiet")
+	if starved := probe.check(start.Add(30 * time.Second)); starved != nil {
+		t.Fatalf("expected no verdict within the first window, got %v", starved)
+	}
+
+	// A program added during the window is not judged until it spans a window
+	if err := feed.add("late"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	starved := probe.check(start.Add(time.Minute))
+	if !reflect.DeepEqual(starved, []string{"quiet"}) {
+		t.Errorf("expected [quiet] starved at 1 notification/min, got %v", starved)
+	}
+	if !probe.gapStart.Equal(start) {
+		t.Errorf("expected the gap to start with the window at %v, got %v", start, probe.gapStart)
+	}
+
+	probe.reset()
+	if starved := probe.check(start.Add(2 * time.Minute)); starved != nil {
+		t.Errorf("expected no verdict right after reset, got %v", starved)
+	}
+}
+
+// fakeWSEndpoint is a WebSocket endpoint answering logsSubscribe. notify pushes
+// a notification to the subscription of a program.
+type fakeWSEndpoint struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	conn       *websocket.Conn
+	subs       map[string]int64 // program -> subscription ID
+	subscribed []string
+}
+
+func newFakeWSEndpoint(t *testing.T, firstSubID int64) *fakeWSEndpoint {
+	t.Helper()
+	e := &fakeWSEndpoint{subs: make(map[string]int64)}
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	nextID := firstSubID
+	e.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		e.mu.Lock()
+		e.conn = conn
+		e.mu.Unlock()
+
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ID     uint64 `json:"id"`
+				Method string `json:"method"`
+				Params []struct {
+					Mentions []string `json:"mentions"`
+				} `json:"params"`
+			}
+			if json.Unmarshal(msg, &req) != nil || req.Method != "logsSubscribe" {
+				continue
+			}
+			program := req.Params[0].Mentions[0]
+			e.mu.Lock()
+			e.subs[program] = nextID
+			e.subscribed = append(e.subscribed, program)
+			conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": nextID})
+			nextID++
+			e.mu.Unlock()
+		}
+	}))
+	t.Cleanup(e.server.Close)
+	return e
+}
+
+func (e *fakeWSEndpoint) url() string {
+	return "ws" + strings.TrimPrefix(e.server.URL, "http")
+}
+
+func (e *fakeWSEndpoint) subscriptions() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.subscribed...)
+}
+
+func (e *fakeWSEndpoint) notify(t *testing.T, program, signature string, slot int64, logs []string) {
+	t.Helper()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	subID, ok := e.subs[program]
+	if !ok {
+		t.Fatalf("no subscription for %s", program)
+	}
+	err := e.conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "logsNotification",
+		"params": map[string]interface{}{
+			"subscription": subID,
+			"result": map[string]interface{}{
+				"context": map[string]interface{}{"slot": slot},
+				"value":   map[string]interface{}{"signature": signature, "logs": logs, "err": nil},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("write notification: %v", err)
+	}
+}
+
+func TestRunner_WSHealth_FailsOverStarvedEndpoint(t *testing.T) {
+	rpcServer := fakeTransactionRPC(t)
+	defer rpcServer.Close()
+
+	primary := newFakeWSEndpoint(t, 1)
+	secondary := newFakeWSEndpoint(t, 100)
+	ws, err := solana.NewWSClientWithEndpoints(context.Background(), []string{primary.url(), secondary.url()}, nil)
+	if err != nil {
+		t.Fatalf("NewWSClientWithEndpoints: %v", err)
+	}
+	defer ws.Close()
+
+	swapStore := memory.NewSwapEventStore()
+	candidateStore := memory.NewCandidateStore()
+
+	// The gap backfill stands in for an RPC backfill of the starved window: it
+	// finds the event only the primary's outage hid, and the last live one
+	// before it (the overlap), which must not be stored twice.
+	var mu sync.Mutex
+	var gapFrom, gapTo time.Time
+	gapBackfill := func(ctx context.Context, from, to time.Time) error {
+		mu.Lock()
+		gapFrom, gapTo = from, to
+		mu.Unlock()
+		for sig, mint := range map[string]string{"sig-live-1": "MintLive1", "sig-gap": "MintGap"} {
+			err := swapStore.Insert(ctx, &domain.SwapEvent{
+				Mint: mint, TxSignature: sig, EventIndex: 2, Slot: 102, Timestamp: 1700000500000, // log index of the Buy in pumpFunBuyLogs
+				Side: domain.SwapSideBuy, DEX: domain.DEXPumpFun, Origin: domain.OriginBackfill,
+			})
+			if err != nil && !errors.Is(err, storage.ErrDuplicateKey) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var switches []string
+	r := NewRunner(RunnerOptions{
+		WSSwapSource:     NewWSSwapEventSource(ws, solana.NewHTTPClient(rpcServer.URL), []string{"progA"}),
+		SwapEventStore:   swapStore,
+		CandidateStore:   candidateStore,
+		NewTokenDetector: discovery.NewDetector(candidateStore).WithProgressStore(memory.NewDiscoveryProgressStore()),
+		SlotLagWindow:    1,
+		Logger:           log.New(io.Discard, "", 0),
+		WSHealth: WSHealthConfig{
+			MinNotificationsPerMinute: 1,
+			StarvationThreshold:       300 * time.Millisecond,
+			CheckInterval:             20 * time.Millisecond,
+		},
+		GapBackfill: gapBackfill,
+		OnEndpointSwitch: func(source string, err error) {
+			if err != nil {
+				t.Errorf("switch of %s failed: %v", source, err)
+			}
+			switches = append(switches, source)
+		},
+	})
+
+	_, stop := startRunner(t, r)
+
+	waitFor(t, "subscription on the primary", func() bool { return len(primary.subscriptions()) == 1 })
+	primary.notify(t, "progA", "sig-live-1", 100, pumpFunBuyLogs("MintLive1"))
+	waitFor(t, "first live event", func() bool { return r.ProgramStats()[0].Events == 1 })
+	quietSince := time.Now()
+
+	// The primary stays connected but goes quiet: the probe moves to the secondary
+	waitFor(t, "resubscription on the secondary", func() bool { return len(secondary.subscriptions()) == 1 })
+	if got := secondary.subscriptions(); !reflect.DeepEqual(got, []string{"progA"}) {
+		t.Errorf("expected progA resubscribed on the secondary, got %v", got)
+	}
+	secondary.notify(t, "progA", "sig-live-2", 103, pumpFunBuyLogs("MintLive2"))
+	waitFor(t, "gap backfill", func() bool { return r.Stats().GapBackfills == 1 })
+	waitFor(t, "live event after the switch", func() bool { return r.ProgramStats()[0].Events == 2 })
+	stop() // flushes buffered slots
+
+	if ws.Endpoint() != secondary.url() {
+		t.Errorf("expected the client on the secondary, got %s", ws.Endpoint())
+	}
+	stats := r.Stats()
+	if stats.WSEndpointSwitches != 1 || stats.WSEndpointSwitchErrors != 0 || stats.GapBackfillErrors != 0 {
+		t.Errorf("expected one clean switch and backfill, got %+v", stats)
+	}
+	if !reflect.DeepEqual(switches, []string{"ws-swap"}) {
+		t.Errorf("expected one ws-swap switch, got %v", switches)
+	}
+
+	mu.Lock()
+	if gapFrom.After(quietSince) || gapTo.Before(quietSince) {
+		t.Errorf("gap backfill %v..%v does not cover the outage since %v", gapFrom, gapTo, quietSince)
+	}
+	mu.Unlock()
+
+	// Every event is stored exactly once: live before, backfilled gap, live after
+	events, err := swapStore.GetByTimeRange(context.Background(), 0, 1<<62)
+	if err != nil {
+		t.Fatalf("GetByTimeRange: %v", err)
+	}
+	var sigs []string
+	for _, e := range events {
+		sigs = append(sigs, e.TxSignature)
+	}
+	sort.Strings(sigs)
+	if want := []string{"sig-gap", "sig-live-1", "sig-live-2"}; !reflect.DeepEqual(sigs, want) {
+		t.Errorf("stored %v, want %v", sigs, want)
+	}
+}
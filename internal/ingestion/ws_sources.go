@@ -12,104 +12,124 @@ import (
 	"solana-token-lab/internal/storage"
 )
 
-const (
-	maxRetries     = 3
-	baseRetryDelay = 500 * time.Millisecond
-)
+// WSSwapEventSource provides real-time swap events via WebSocket subscription.
+type WSSwapEventSource struct {
+	feed          *programFeed
+	rpc           *solana.HTTPClient // For fetching full transaction data
+	parser        *discovery.DEXParser
+	parseFailures programCounter
+	duplicates    programCounter       // redelivered notifications dropped before parsing
+	recent        *recentNotifications // nil disables redelivery suppression
+	archive       storage.RawTxStore   // optional raw transaction archive
+	failedTx      *FailedTxTracker     // optional failed transaction counts
+}
 
-// retryGetTransaction fetches a transaction with exponential backoff retry.
-func retryGetTransaction(ctx context.Context, rpc *solana.HTTPClient, signature string) (*solana.Transaction, error) {
-	var lastErr error
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		tx, err := rpc.GetTransaction(ctx, signature)
-		if err == nil {
-			return tx, nil
-		}
-		lastErr = err
+// programSwapEvent is a swap event tagged with the program it was received for.
+type programSwapEvent struct {
+	program string
+	event   *domain.SwapEvent
+}
 
-		// Don't retry on context cancellation
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
+// NewWSSwapEventSource creates a new WebSocket-based swap event source.
+func NewWSSwapEventSource(ws LogSubscriber, rpc *solana.HTTPClient, programs []string) *WSSwapEventSource {
+	return &WSSwapEventSource{
+		feed:   newProgramFeed(ws, "ws-swap", programs),
+		rpc:    rpc,
+		parser: discovery.NewDEXParser(),
+		recent: newRecentNotifications(DefaultDedupTTL, DefaultDedupSize),
+	}
+}
 
-		// Exponential backoff: 500ms, 1s, 2s
-		delay := baseRetryDelay * time.Duration(1<<attempt)
-		log.Printf("[ws] Retry %d/%d for GetTransaction %s after %v: %v", attempt+1, maxRetries, signature, delay, err)
+// WithArchive stores every fetched transaction in archive for audit and reparse.
+func (s *WSSwapEventSource) WithArchive(archive storage.RawTxStore) *WSSwapEventSource {
+	s.archive = archive
+	return s
+}
 
-		select {
-		case <-time.After(delay):
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
-	return nil, lastErr
+// WithFailedTxTracker counts the transactions of every notification in
+// tracker, failed ones included, under the source name "ws-swap".
+func (s *WSSwapEventSource) WithFailedTxTracker(tracker *FailedTxTracker) *WSSwapEventSource {
+	s.failedTx = tracker
+	return s
 }
 
-// WSSwapEventSource provides real-time swap events via WebSocket subscription.
-type WSSwapEventSource struct {
-	ws       *solana.WSClientImpl
-	rpc      *solana.HTTPClient // For fetching full transaction data
-	parser   *discovery.DEXParser
-	programs []string
+// WithDedupWindow drops a notification whose signature was already received on
+// the same program subscription within ttl, remembering at most size of them.
+// Defaults to DefaultDedupTTL and DefaultDedupSize; a zero ttl disables it.
+// Call before Subscribe.
+func (s *WSSwapEventSource) WithDedupWindow(ttl time.Duration, size int) *WSSwapEventSource {
+	s.recent = newRecentNotifications(ttl, size)
+	return s
 }
 
-// NewWSSwapEventSource creates a new WebSocket-based swap event source.
-func NewWSSwapEventSource(ws *solana.WSClientImpl, rpc *solana.HTTPClient, programs []string) *WSSwapEventSource {
-	return &WSSwapEventSource{
-		ws:       ws,
-		rpc:      rpc,
-		parser:   discovery.NewDEXParser(),
-		programs: programs,
-	}
+// AddProgram subscribes to an additional program. It can be called before or during Subscribe.
+func (s *WSSwapEventSource) AddProgram(program string) error {
+	return s.feed.add(program)
+}
+
+// RemoveProgram unsubscribes from a program. Events already emitted are not affected.
+func (s *WSSwapEventSource) RemoveProgram(ctx context.Context, program string) error {
+	return s.feed.remove(ctx, program)
+}
+
+// Programs returns the monitored programs.
+func (s *WSSwapEventSource) Programs() []string {
+	return s.feed.list()
 }
 
 // Subscribe returns a channel of swap events from live WebSocket subscription.
 // The channel is closed when the context is cancelled or an error occurs.
 func (s *WSSwapEventSource) Subscribe(ctx context.Context) (<-chan *domain.SwapEvent, error) {
-	// Subscribe to logs for each program separately (some providers only support 1 address per subscription)
-	var logsChannels []<-chan solana.LogNotification
-	for _, program := range s.programs {
-		logsCh, err := s.ws.SubscribeLogs(ctx, solana.LogsFilter{
-			Mentions: []string{program},
-		})
-		if err != nil {
-			return nil, err
+	tagged, err := s.subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan *domain.SwapEvent, 100)
+	go func() {
+		defer close(out)
+		for e := range tagged {
+			select {
+			case out <- e.event:
+			case <-ctx.Done():
+				return
+			}
 		}
-		logsChannels = append(logsChannels, logsCh)
-		log.Printf("[ws-swap] Subscribed to program: %s", program)
+	}()
+	return out, nil
+}
+
+// subscribe is Subscribe with each event tagged by program.
+func (s *WSSwapEventSource) subscribe(ctx context.Context) (<-chan programSwapEvent, error) {
+	merged, err := s.feed.start(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	eventsCh := make(chan *domain.SwapEvent, 100)
+	eventsCh := make(chan programSwapEvent, 100)
 
-	// Merge all log channels and process
 	go func() {
 		defer close(eventsCh)
 
-		// Merge channels
-		merged := make(chan solana.LogNotification, 1000)
-		for _, ch := range logsChannels {
-			go func(logsCh <-chan solana.LogNotification) {
-				for notif := range logsCh {
-					select {
-					case merged <- notif:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}(ch)
-		}
-
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case notif, ok := <-merged:
+			case n, ok := <-merged:
 				if !ok {
 					log.Println("[ws-swap] merged channel closed")
 					return
 				}
-				log.Printf("[ws-swap] Received notif: sig=%s err=%v", notif.Signature, notif.Err)
-				s.processSwapNotification(ctx, eventsCh, notif)
+				// Drop notifications queued before their program was removed
+				if !s.feed.active(n.program) {
+					continue
+				}
+				if s.recent.duplicate(n.program, n.notif.Signature) {
+					s.duplicates.inc(n.program)
+					continue
+				}
+				log.Printf("[ws-swap] Received notif: program=%s sig=%s err=%v", n.program, n.notif.Signature, n.notif.Err)
+				s.failedTx.observe(s.feed.name, n.program, 0, n.notif.Err != nil)
+				s.processSwapNotification(ctx, eventsCh, n.program, n.notif)
 			}
 		}
 	}()
@@ -118,7 +138,7 @@ func (s *WSSwapEventSource) Subscribe(ctx context.Context) (<-chan *domain.SwapE
 }
 
 // processSwapNotification processes a single log notification for swaps.
-func (s *WSSwapEventSource) processSwapNotification(ctx context.Context, eventsCh chan<- *domain.SwapEvent, notif solana.LogNotification) {
+func (s *WSSwapEventSource) processSwapNotification(ctx context.Context, eventsCh chan<- programSwapEvent, program string, notif solana.LogNotification) {
 	// Skip failed transactions
 	if notif.Err != nil {
 		return
@@ -142,11 +162,12 @@ func (s *WSSwapEventSource) processSwapNotification(ctx context.Context, eventsC
 			notif.Slot,
 			timestamp,
 		)
-		s.sendSwapEvents(ctx, eventsCh, swapEvents)
+		s.sendSwapEvents(ctx, eventsCh, program, nil, swapEvents)
 		return
 	}
 
 	timestamp, _ := resolveBlockTimestamp(ctx, s.rpc, notif.Slot, tx.BlockTime)
+	archiveTx(ctx, s.archive, tx, timestamp/1000)
 
 	// Get account keys from transaction message
 	var accountKeys []string
@@ -185,14 +206,17 @@ func (s *WSSwapEventSource) processSwapNotification(ctx context.Context, eventsC
 	if len(swapEvents) > 0 {
 		log.Printf("[ws-swap] Parsed %d swaps from tx %s", len(swapEvents), notif.Signature)
 	}
-	s.sendSwapEvents(ctx, eventsCh, swapEvents)
+	s.sendSwapEvents(ctx, eventsCh, program, tx, swapEvents)
 }
 
 // sendSwapEvents sends parsed swap events to the channel.
-func (s *WSSwapEventSource) sendSwapEvents(ctx context.Context, eventsCh chan<- *domain.SwapEvent, swapEvents []*discovery.SwapEvent) {
+// tx supplies fee telemetry and may be nil when the fetch failed.
+func (s *WSSwapEventSource) sendSwapEvents(ctx context.Context, eventsCh chan<- programSwapEvent, program string, tx *solana.Transaction, swapEvents []*discovery.SwapEvent) {
+	feeLamports, priorityFeeLamports := txFees(tx)
 	for _, se := range swapEvents {
 		if se.Mint == "" {
 			log.Printf("[ws-swap] SKIP: empty mint for tx %s (event_index=%d)", se.TxSignature, se.EventIndex)
+			s.parseFailures.inc(program)
 			continue
 		}
 		log.Printf("[ws-swap] SEND: mint=%s tx=%s", se.Mint, se.TxSignature)
@@ -205,10 +229,17 @@ func (s *WSSwapEventSource) sendSwapEvents(ctx context.Context, eventsCh chan<-
 			Slot:        se.Slot,
 			Timestamp:   se.Timestamp,
 			AmountOut:   se.AmountOut,
+			Side:        se.Side,
+			DEX:         se.DEX,
+			Trader:      se.Trader,
+			Origin:      domain.OriginLiveWS,
+
+			FeeLamports:         feeLamports,
+			PriorityFeeLamports: priorityFeeLamports,
 		}
 
 		select {
-		case eventsCh <- event:
+		case eventsCh <- programSwapEvent{program: program, event: event}:
 		case <-ctx.Done():
 			return
 		}
@@ -227,78 +258,128 @@ func containsRaydiumProgram(logs []string) bool {
 
 // WSLiquidityEventSource provides real-time liquidity events via WebSocket.
 type WSLiquidityEventSource struct {
-	ws             *solana.WSClientImpl
+	feed           *programFeed
 	rpc            *solana.HTTPClient
 	parser         *discovery.DEXParser
-	programs       []string
 	candidateStore storage.CandidateStore // For looking up CandidateID by mint
+	reserves       *reserveEstimator      // Estimates LiquidityAfter from cumulative deltas
+	parseFailures  programCounter
+	duplicates     programCounter       // redelivered notifications dropped before parsing
+	recent         *recentNotifications // nil disables redelivery suppression
+	archive        storage.RawTxStore   // optional raw transaction archive
+	failedTx       *FailedTxTracker     // optional failed transaction counts
+}
+
+// programLiquidityEvent is a liquidity event tagged with the program it was received for.
+type programLiquidityEvent struct {
+	program string
+	event   *domain.LiquidityEvent
 }
 
 // NewWSLiquidityEventSource creates a new WebSocket-based liquidity event source.
-func NewWSLiquidityEventSource(ws *solana.WSClientImpl, rpc *solana.HTTPClient, programs []string) *WSLiquidityEventSource {
-	return &WSLiquidityEventSource{
-		ws:       ws,
-		rpc:      rpc,
-		parser:   discovery.NewDEXParser(),
-		programs: programs,
-	}
+func NewWSLiquidityEventSource(ws LogSubscriber, rpc *solana.HTTPClient, programs []string) *WSLiquidityEventSource {
+	return NewWSLiquidityEventSourceWithStore(ws, rpc, programs, nil)
 }
 
 // NewWSLiquidityEventSourceWithStore creates a liquidity event source with candidate store for ID lookup.
-func NewWSLiquidityEventSourceWithStore(ws *solana.WSClientImpl, rpc *solana.HTTPClient, programs []string, candidateStore storage.CandidateStore) *WSLiquidityEventSource {
+func NewWSLiquidityEventSourceWithStore(ws LogSubscriber, rpc *solana.HTTPClient, programs []string, candidateStore storage.CandidateStore) *WSLiquidityEventSource {
+	var seed reserveSeeder
+	if rpc != nil {
+		seed = rpcVaultSeeder(rpc)
+	}
 	return &WSLiquidityEventSource{
-		ws:             ws,
+		feed:           newProgramFeed(ws, "ws-liquidity", programs),
 		rpc:            rpc,
 		parser:         discovery.NewDEXParser(),
-		programs:       programs,
 		candidateStore: candidateStore,
+		reserves:       newReserveEstimator(seed),
+		recent:         newRecentNotifications(DefaultDedupTTL, DefaultDedupSize),
 	}
 }
 
+// WithArchive stores every fetched transaction in archive for audit and reparse.
+func (s *WSLiquidityEventSource) WithArchive(archive storage.RawTxStore) *WSLiquidityEventSource {
+	s.archive = archive
+	return s
+}
+
+// WithFailedTxTracker is WSSwapEventSource.WithFailedTxTracker for liquidity
+// notifications, under the source name "ws-liquidity".
+func (s *WSLiquidityEventSource) WithFailedTxTracker(tracker *FailedTxTracker) *WSLiquidityEventSource {
+	s.failedTx = tracker
+	return s
+}
+
+// WithDedupWindow is WSSwapEventSource.WithDedupWindow for liquidity notifications.
+func (s *WSLiquidityEventSource) WithDedupWindow(ttl time.Duration, size int) *WSLiquidityEventSource {
+	s.recent = newRecentNotifications(ttl, size)
+	return s
+}
+
+// AddProgram subscribes to an additional program. It can be called before or during Subscribe.
+func (s *WSLiquidityEventSource) AddProgram(program string) error {
+	return s.feed.add(program)
+}
+
+// RemoveProgram unsubscribes from a program. Events already emitted are not affected.
+func (s *WSLiquidityEventSource) RemoveProgram(ctx context.Context, program string) error {
+	return s.feed.remove(ctx, program)
+}
+
+// Programs returns the monitored programs.
+func (s *WSLiquidityEventSource) Programs() []string {
+	return s.feed.list()
+}
+
 // Subscribe returns a channel of liquidity events from live WebSocket subscription.
 func (s *WSLiquidityEventSource) Subscribe(ctx context.Context) (<-chan *domain.LiquidityEvent, error) {
-	// Subscribe to logs for each program separately (some providers only support 1 address per subscription)
-	var logsChannels []<-chan solana.LogNotification
-	for _, program := range s.programs {
-		logsCh, err := s.ws.SubscribeLogs(ctx, solana.LogsFilter{
-			Mentions: []string{program},
-		})
-		if err != nil {
-			return nil, err
+	tagged, err := s.subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan *domain.LiquidityEvent, 100)
+	go func() {
+		defer close(out)
+		for e := range tagged {
+			select {
+			case out <- e.event:
+			case <-ctx.Done():
+				return
+			}
 		}
-		logsChannels = append(logsChannels, logsCh)
-		log.Printf("[ws-liquidity] Subscribed to program: %s", program)
+	}()
+	return out, nil
+}
+
+// subscribe is Subscribe with each event tagged by program.
+func (s *WSLiquidityEventSource) subscribe(ctx context.Context) (<-chan programLiquidityEvent, error) {
+	merged, err := s.feed.start(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	eventsCh := make(chan *domain.LiquidityEvent, 100)
+	eventsCh := make(chan programLiquidityEvent, 100)
 
-	// Merge all log channels and process
 	go func() {
 		defer close(eventsCh)
 
-		// Merge channels
-		merged := make(chan solana.LogNotification, 1000)
-		for _, ch := range logsChannels {
-			go func(logsCh <-chan solana.LogNotification) {
-				for notif := range logsCh {
-					select {
-					case merged <- notif:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}(ch)
-		}
-
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case notif, ok := <-merged:
+			case n, ok := <-merged:
 				if !ok {
 					return
 				}
-				s.processLiquidityNotification(ctx, eventsCh, notif)
+				if !s.feed.active(n.program) {
+					continue
+				}
+				if s.recent.duplicate(n.program, n.notif.Signature) {
+					s.duplicates.inc(n.program)
+					continue
+				}
+				s.failedTx.observe(s.feed.name, n.program, 0, n.notif.Err != nil)
+				s.processLiquidityNotification(ctx, eventsCh, n.program, n.notif)
 			}
 		}
 	}()
@@ -307,7 +388,7 @@ func (s *WSLiquidityEventSource) Subscribe(ctx context.Context) (<-chan *domain.
 }
 
 // processLiquidityNotification processes a single log notification for liquidity events.
-func (s *WSLiquidityEventSource) processLiquidityNotification(ctx context.Context, eventsCh chan<- *domain.LiquidityEvent, notif solana.LogNotification) {
+func (s *WSLiquidityEventSource) processLiquidityNotification(ctx context.Context, eventsCh chan<- programLiquidityEvent, program string, notif solana.LogNotification) {
 	if notif.Err != nil {
 		return
 	}
@@ -324,11 +405,12 @@ func (s *WSLiquidityEventSource) processLiquidityNotification(ctx context.Contex
 			notif.Slot,
 			timestamp,
 		)
-		s.sendLiquidityEvents(ctx, eventsCh, liqEvents)
+		s.sendLiquidityEvents(ctx, eventsCh, program, liqEvents)
 		return
 	}
 
 	timestamp, _ := resolveBlockTimestamp(ctx, s.rpc, notif.Slot, tx.BlockTime)
+	archiveTx(ctx, s.archive, tx, timestamp/1000)
 
 	var accountKeys []string
 	if tx.Message != nil {
@@ -361,12 +443,23 @@ func (s *WSLiquidityEventSource) processLiquidityNotification(ctx context.Contex
 		}
 	}
 
-	s.sendLiquidityEvents(ctx, eventsCh, liqEvents)
+	s.sendLiquidityEvents(ctx, eventsCh, program, liqEvents)
 }
 
 // sendLiquidityEvents sends parsed liquidity events to the channel.
-func (s *WSLiquidityEventSource) sendLiquidityEvents(ctx context.Context, eventsCh chan<- *domain.LiquidityEvent, liqEvents []*discovery.LiquidityEvent) {
+func (s *WSLiquidityEventSource) sendLiquidityEvents(ctx context.Context, eventsCh chan<- programLiquidityEvent, program string, liqEvents []*discovery.LiquidityEvent) {
 	for _, le := range liqEvents {
+		eventType, err := domain.NormalizeLiquidityEventType(le.EventType)
+		if err != nil {
+			log.Printf("[ws-liquidity] SKIP: %v for tx %s (event_index=%d)", err, le.TxSignature, le.EventIndex)
+			s.parseFailures.inc(program)
+			continue
+		}
+		if le.Mint == "" {
+			// Stored for deferred association, but the parser could not tell the token
+			s.parseFailures.inc(program)
+		}
+
 		// Look up CandidateID by mint if store is available
 		var candidateID string
 		if s.candidateStore != nil && le.Mint != "" {
@@ -380,17 +473,22 @@ func (s *WSLiquidityEventSource) sendLiquidityEvents(ctx context.Context, events
 			CandidateID: candidateID,
 			Pool:        le.Pool,
 			Mint:        le.Mint,
-			EventType:   le.EventType,
+			EventType:   eventType,
 			AmountToken: float64(le.AmountToken),
 			AmountQuote: float64(le.AmountQuote),
 			TxSignature: le.TxSignature,
 			EventIndex:  le.EventIndex,
 			Slot:        le.Slot,
 			Timestamp:   le.Timestamp,
+			PoolInit:    le.PoolInit,
+			Migration:   le.Migration,
+			DEX:         le.DEX,
+			Origin:      domain.OriginLiveWS,
 		}
+		s.reserves.apply(ctx, event)
 
 		select {
-		case eventsCh <- event:
+		case eventsCh <- programLiquidityEvent{program: program, event: event}:
 		case <-ctx.Done():
 			return
 		}
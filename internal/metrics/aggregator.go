@@ -5,24 +5,41 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/strategy"
 )
 
-// ErrNoTrades is returned when no trades are available for aggregation.
-var ErrNoTrades = errors.New("no trades available for aggregation")
+var (
+	// ErrNoTrades is returned when no trades are available for aggregation.
+	ErrNoTrades = errors.New("no trades available for aggregation")
+	// ErrUnknownEntryFilter is returned for a labeled entry_event_type whose filter is not configured.
+	ErrUnknownEntryFilter = errors.New("unknown entry filter")
+)
 
 // Aggregator computes strategy aggregates from trade records.
+// It is safe for concurrent use: ComputeAggregate calls for different keys may
+// run in parallel and share the missing-candidate tracking. Missing candidates
+// accumulate across calls until ResetErrors, so a reused Aggregator should be
+// reset at the start of each run.
 type Aggregator struct {
 	tradeRecordStore storage.TradeRecordStore
 	strategyAggStore storage.StrategyAggregateStore
 	candidateStore   storage.CandidateStore
+	entryFilters     map[string]EntryFilter // keyed by label
+	closedOnly       bool                   // skip trades of OPEN candidates
+	dedup            bool                   // enables DedupLabel aggregates
 
-	// MissingCandidates tracks trade_ids with missing candidates (for data quality reporting).
-	// Key: candidate_id, Value: count of trades referencing it.
-	MissingCandidates map[string]int
+	// missingCandidates tracks trades whose candidate is missing (for data quality reporting).
+	// Key: candidate_id, Value: set of trade_ids referencing it, so a trade seen by
+	// several ComputeAggregate calls is counted once.
+	mu                sync.Mutex
+	missingCandidates map[string]map[string]struct{}
+	// deletedCandidates tracks the excluded trades of soft-deleted candidates, keyed the same way.
+	deletedCandidates map[string]map[string]struct{}
 }
 
 // NewAggregator creates a new metrics aggregator.
@@ -31,15 +48,59 @@ func NewAggregator(tradeStore storage.TradeRecordStore, aggStore storage.Strateg
 		tradeRecordStore:  tradeStore,
 		strategyAggStore:  aggStore,
 		candidateStore:    candidateStore,
-		MissingCandidates: make(map[string]int),
+		missingCandidates: make(map[string]map[string]struct{}),
+		deletedCandidates: make(map[string]map[string]struct{}),
+	}
+}
+
+// WithEntryFilters enables labeled aggregates restricted by entry context.
+// Request them via ComputeAggregate with LabeledEntryEventType(entryEventType, filter.Label).
+func (a *Aggregator) WithEntryFilters(filters []EntryFilter) *Aggregator {
+	a.entryFilters = make(map[string]EntryFilter, len(filters))
+	for _, f := range filters {
+		a.entryFilters[f.Label] = f
 	}
+	return a
+}
+
+// WithClosedOnly restricts aggregates to trades of CLOSED candidates, whose
+// observation window has elapsed and whose trades are final.
+func (a *Aggregator) WithClosedOnly(closedOnly bool) *Aggregator {
+	a.closedOnly = closedOnly
+	return a
+}
+
+// WithDedup enables aggregates over deduplicated trades (see DedupTrades).
+// Request them via ComputeAggregate with LabeledEntryEventType(entryEventType, DedupLabel).
+func (a *Aggregator) WithDedup(dedup bool) *Aggregator {
+	a.dedup = dedup
+	return a
 }
 
 // ComputeAggregate computes aggregate for a specific (strategy_id, scenario_id, entry_event_type).
 // Loads trades matching the key (using canonical base type for strategy matching),
 // filters by candidate source, computes all metrics, returns aggregate.
+// A labeled entry_event_type (see LabeledEntryEventType) further keeps only trades
+// matching that entry filter, or, for a DEX cohort (see DEXCohortLabel), only
+// trades of candidates discovered on that DEX, or, for DedupLabel, only trades
+// left after deduplicating overlapping positions across all entry types.
 // Returns ErrNoTrades if no trades match the criteria.
 func (a *Aggregator) ComputeAggregate(ctx context.Context, strategyID, scenarioID, entryEventType string) (*domain.StrategyAggregate, error) {
+	baseType, label := SplitEntryEventType(entryEventType)
+	var entryFilter *EntryFilter
+	dex, isDEXCohort := ParseDEXCohortLabel(label)
+	isDedup := label == DedupLabel
+	if isDedup && !a.dedup {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEntryFilter, label)
+	}
+	if label != "" && !isDEXCohort && !isDedup {
+		f, ok := a.entryFilters[label]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEntryFilter, label)
+		}
+		entryFilter = &f
+	}
+
 	// Load all trades for scenario and filter by canonical strategy type
 	// This handles parameterized IDs like "TIME_EXIT_NEW_TOKEN_300000ms" matching base type "TIME_EXIT"
 	trades, err := a.loadTradesByCanonicalStrategy(ctx, strategyID, scenarioID)
@@ -47,11 +108,21 @@ func (a *Aggregator) ComputeAggregate(ctx context.Context, strategyID, scenarioI
 		return nil, err
 	}
 
+	// Deduplicate before filtering by entry type: overlaps are across entry types
+	if isDedup {
+		if trades, err = a.dedupTrades(ctx, trades); err != nil {
+			return nil, err
+		}
+	}
+
 	// Filter trades by entry_event_type using candidate source
-	filteredTrades, err := a.filterByEntryEventType(ctx, trades, entryEventType)
+	filteredTrades, err := a.filterByEntryEventType(ctx, trades, baseType, dex)
 	if err != nil {
 		return nil, err
 	}
+	if entryFilter != nil {
+		filteredTrades = filterByEntryContext(filteredTrades, *entryFilter)
+	}
 
 	if len(filteredTrades) == 0 {
 		return nil, ErrNoTrades
@@ -102,9 +173,11 @@ func (a *Aggregator) loadTradesByCanonicalStrategy(ctx context.Context, baseStra
 	return filtered, nil
 }
 
-// filterByEntryEventType filters trades by matching candidate source to entry event type.
-// Tracks missing candidates in a.MissingCandidates instead of silently skipping.
-func (a *Aggregator) filterByEntryEventType(ctx context.Context, trades []*domain.TradeRecord, entryEventType string) ([]*domain.TradeRecord, error) {
+// filterByEntryEventType filters trades by matching candidate source to entry event type
+// and, when dex is set, the candidate's discovery DEX.
+// Tracks missing candidates instead of silently skipping. Trades of soft-deleted
+// candidates are excluded and tracked separately.
+func (a *Aggregator) filterByEntryEventType(ctx context.Context, trades []*domain.TradeRecord, entryEventType, dex string) ([]*domain.TradeRecord, error) {
 	var filtered []*domain.TradeRecord
 
 	for _, trade := range trades {
@@ -112,11 +185,22 @@ func (a *Aggregator) filterByEntryEventType(ctx context.Context, trades []*domai
 		if err != nil {
 			if errors.Is(err, storage.ErrNotFound) {
 				// Record missing candidate (don't silently skip)
-				a.MissingCandidates[trade.CandidateID]++
+				a.recordMissingCandidate(trade.CandidateID, trade.TradeID)
 				continue
 			}
 			return nil, err
 		}
+		if candidate.IsDeleted() {
+			a.recordDeletedCandidate(trade.CandidateID, trade.TradeID)
+			continue
+		}
+
+		if a.closedOnly && !candidate.IsClosed() {
+			continue
+		}
+		if dex != "" && candidate.DEX != dex {
+			continue
+		}
 
 		// Match candidate source to entry event type
 		if sourceMatchesEntryEventType(candidate.Source, entryEventType) {
@@ -127,23 +211,120 @@ func (a *Aggregator) filterByEntryEventType(ctx context.Context, trades []*domai
 	return filtered, nil
 }
 
+// dedupTrades applies DedupTrades with the mints of the trades' candidates.
+// Trades of missing and soft-deleted candidates are dropped; the unlabeled
+// aggregates record them.
+func (a *Aggregator) dedupTrades(ctx context.Context, trades []*domain.TradeRecord) ([]*domain.TradeRecord, error) {
+	mintOf := make(map[string]string)
+	for _, trade := range trades {
+		if _, ok := mintOf[trade.CandidateID]; ok {
+			continue
+		}
+		candidate, err := a.candidateStore.GetByID(ctx, trade.CandidateID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if candidate.IsDeleted() {
+			continue
+		}
+		mintOf[trade.CandidateID] = candidate.Mint
+	}
+	return DedupTrades(trades, mintOf), nil
+}
+
+// filterByEntryContext keeps trades whose entry context matches f.
+func filterByEntryContext(trades []*domain.TradeRecord, f EntryFilter) []*domain.TradeRecord {
+	var filtered []*domain.TradeRecord
+	for _, trade := range trades {
+		if f.Matches(trade.EntryContext) {
+			filtered = append(filtered, trade)
+		}
+	}
+	return filtered
+}
+
+// recordMissingCandidate notes that tradeID references a missing candidate.
+func (a *Aggregator) recordMissingCandidate(candidateID, tradeID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	recordTrade(a.missingCandidates, candidateID, tradeID)
+}
+
+// recordDeletedCandidate notes that tradeID of a soft-deleted candidate was excluded.
+func (a *Aggregator) recordDeletedCandidate(candidateID, tradeID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	recordTrade(a.deletedCandidates, candidateID, tradeID)
+}
+
+// recordTrade adds tradeID to the trades of candidateID in byCandidate.
+func recordTrade(byCandidate map[string]map[string]struct{}, candidateID, tradeID string) {
+	trades, ok := byCandidate[candidateID]
+	if !ok {
+		trades = make(map[string]struct{})
+		byCandidate[candidateID] = trades
+	}
+	trades[tradeID] = struct{}{}
+}
+
+// MissingCandidates returns a copy of the missing candidate counts.
+// Key: candidate_id, Value: number of distinct trades referencing it.
+func (a *Aggregator) MissingCandidates() map[string]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return tradeCounts(a.missingCandidates)
+}
+
+// DeletedCandidates returns the soft-deleted candidates whose trades were
+// excluded. Key: candidate_id, Value: number of distinct trades excluded.
+func (a *Aggregator) DeletedCandidates() map[string]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return tradeCounts(a.deletedCandidates)
+}
+
+// tradeCounts returns the number of trades per candidate of byCandidate.
+func tradeCounts(byCandidate map[string]map[string]struct{}) map[string]int {
+	counts := make(map[string]int, len(byCandidate))
+	for candidateID, trades := range byCandidate {
+		counts[candidateID] = len(trades)
+	}
+	return counts
+}
+
 // GetMissingCandidateErrors returns data quality errors for missing candidates.
 // Returns slice of error messages sorted by candidate_id for deterministic output.
 func (a *Aggregator) GetMissingCandidateErrors() []string {
-	if len(a.MissingCandidates) == 0 {
+	return formatMissingCandidates(a.MissingCandidates())
+}
+
+// ResetErrors clears the missing and deleted candidates collected by previous calls.
+func (a *Aggregator) ResetErrors() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.missingCandidates = make(map[string]map[string]struct{})
+	a.deletedCandidates = make(map[string]map[string]struct{})
+}
+
+// formatMissingCandidates formats missing candidate counts sorted by candidate_id.
+func formatMissingCandidates(missing map[string]int) []string {
+	if len(missing) == 0 {
 		return nil
 	}
 
 	// Sort keys for deterministic output
-	keys := make([]string, 0, len(a.MissingCandidates))
-	for k := range a.MissingCandidates {
+	keys := make([]string, 0, len(missing))
+	for k := range missing {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
 	errors := make([]string, len(keys))
 	for i, candidateID := range keys {
-		count := a.MissingCandidates[candidateID]
+		count := missing[candidateID]
 		errors[i] = fmt.Sprintf("missing candidate %s referenced by %d trade(s)", candidateID, count)
 	}
 	return errors
@@ -168,6 +349,7 @@ func (a *Aggregator) ComputeAndStore(ctx context.Context, strategyID, scenarioID
 	if err != nil {
 		return nil, err
 	}
+	agg.ComputedAt = time.Now().UnixMilli()
 
 	// Persist aggregate (append-only, returns ErrDuplicateKey on duplicate)
 	if err := a.strategyAggStore.Insert(ctx, agg); err != nil {
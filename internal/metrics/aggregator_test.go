@@ -2,10 +2,17 @@ package metrics
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"math"
+	"slices"
+	"sync"
 	"testing"
 
+	"github.com/mr-tron/base58"
+
+	"solana-token-lab/internal/discovery"
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/storage/memory"
@@ -614,3 +621,222 @@ func TestComputeAggregate_DeterministicSorting(t *testing.T) {
 		t.Errorf("expected MaxConsecutiveLosses 2, got %d", agg.MaxConsecutiveLosses)
 	}
 }
+
+func TestAggregator_ConcurrentMissingCandidates(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+
+	if err := candidateStore.Insert(ctx, makeCandidate("present", domain.SourceNewToken)); err != nil {
+		t.Fatalf("Insert candidate failed: %v", err)
+	}
+
+	strategies := []string{domain.StrategyTypeTimeExit, domain.StrategyTypeTrailingStop, domain.StrategyTypeLiquidityGuard}
+	scenarios := []string{domain.ScenarioOptimistic, domain.ScenarioRealistic, domain.ScenarioPessimistic, domain.ScenarioDegraded}
+	var trades []*domain.TradeRecord
+	for _, st := range strategies {
+		for _, sc := range scenarios {
+			trades = append(trades,
+				makeTrade(st+"-"+sc+"-ok", "present", st, sc, 0.1, domain.OutcomeClassWin, 1000),
+				makeTrade(st+"-"+sc+"-a", "ghost-a", st, sc, 0.1, domain.OutcomeClassWin, 1000),
+				makeTrade(st+"-"+sc+"-b", "ghost-b", st, sc, 0.1, domain.OutcomeClassWin, 1000),
+			)
+		}
+	}
+	if err := tradeStore.InsertBulk(ctx, trades); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	aggregator := NewAggregator(tradeStore, aggStore, candidateStore)
+
+	run := func() {
+		var wg sync.WaitGroup
+		for _, st := range strategies {
+			for _, sc := range scenarios {
+				for _, entry := range []string{"NEW_TOKEN", "ACTIVE_TOKEN"} {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, _ = aggregator.ComputeAggregate(ctx, st, sc, entry)
+					}()
+				}
+			}
+		}
+		wg.Wait()
+	}
+
+	// Each missing candidate is referenced by one trade per strategy/scenario,
+	// counted once even though both entry event types visit it.
+	want := []string{
+		"missing candidate ghost-a referenced by 12 trade(s)",
+		"missing candidate ghost-b referenced by 12 trade(s)",
+	}
+
+	run()
+	got := aggregator.GetMissingCandidateErrors()
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected errors:\n got %v\nwant %v", got, want)
+	}
+
+	// Repeating the computation must not inflate counts.
+	run()
+	if got := aggregator.GetMissingCandidateErrors(); !slices.Equal(got, want) {
+		t.Errorf("errors changed after rerun: %v", got)
+	}
+
+	// The returned counts are a copy.
+	counts := aggregator.MissingCandidates()
+	counts["ghost-a"] = 0
+	if aggregator.MissingCandidates()["ghost-a"] != 12 {
+		t.Error("MissingCandidates exposed internal state")
+	}
+
+	aggregator.ResetErrors()
+	if got := aggregator.GetMissingCandidateErrors(); got != nil {
+		t.Errorf("expected no errors after reset, got %v", got)
+	}
+}
+
+func TestAggregator_ExcludesDeletedCandidates(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+
+	for _, id := range []string{"live", "junk"} {
+		if err := candidateStore.Insert(ctx, makeCandidate(id, domain.SourceNewToken)); err != nil {
+			t.Fatalf("Insert candidate failed: %v", err)
+		}
+	}
+	if err := candidateStore.SoftDelete(ctx, &storage.CandidateDeletion{CandidateID: "junk", DeletedAt: 5000, Reason: "test token"}); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+	trades := []*domain.TradeRecord{
+		makeTrade("t-live", "live", domain.StrategyTypeTimeExit, domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, 1000),
+		makeTrade("t-junk-1", "junk", domain.StrategyTypeTimeExit, domain.ScenarioRealistic, 5.0, domain.OutcomeClassWin, 1000),
+		makeTrade("t-junk-2", "junk", domain.StrategyTypeTimeExit, domain.ScenarioRealistic, 3.0, domain.OutcomeClassWin, 2000),
+	}
+	if err := tradeStore.InsertBulk(ctx, trades); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	aggregator := NewAggregator(tradeStore, aggStore, candidateStore).WithDedup(true)
+	agg, err := aggregator.ComputeAggregate(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("ComputeAggregate failed: %v", err)
+	}
+	if agg.TotalTrades != 1 || agg.OutcomeMean != 0.1 {
+		t.Errorf("expected only the live trade, got %d trades with mean %v", agg.TotalTrades, agg.OutcomeMean)
+	}
+	dedup, err := aggregator.ComputeAggregate(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic, LabeledEntryEventType("NEW_TOKEN", DedupLabel))
+	if err != nil {
+		t.Fatalf("ComputeAggregate dedup failed: %v", err)
+	}
+	if dedup.TotalTrades != 1 {
+		t.Errorf("expected only the live trade after dedup, got %d", dedup.TotalTrades)
+	}
+
+	if got := aggregator.DeletedCandidates(); len(got) != 1 || got["junk"] != 2 {
+		t.Errorf("expected 2 excluded trades of junk, got %v", got)
+	}
+	if got := aggregator.GetMissingCandidateErrors(); got != nil {
+		t.Errorf("deleted candidates are not missing, got %v", got)
+	}
+
+	aggregator.ResetErrors()
+	if got := aggregator.DeletedCandidates(); len(got) != 0 {
+		t.Errorf("expected no deleted candidates after reset, got %v", got)
+	}
+}
+
+func TestComputeAggregate_DEXCohort(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	// Parser fixtures: a pump.fun buy and a Raydium swap (WSOL in) in separate transactions
+	parser := discovery.NewDEXParser()
+	pumpLogs := []string{
+		"Program " + discovery.PumpFun + " invoke [1]",
+		"Program log: mint=PumpMint111",
+		"Program log: Instruction: Buy",
+		"Program " + discovery.PumpFun + " success",
+	}
+	rayLog := make([]byte, 113)
+	rayLog[0] = 0x09 // SwapBaseIn
+	wsol, err := base58.Decode(discovery.WSOL)
+	if err != nil {
+		t.Fatalf("decode WSOL: %v", err)
+	}
+	copy(rayLog[33:65], wsol)
+	copy(rayLog[65:97], []byte("RaydiumMint11111111111111111111111"))
+	rayKeys := make([]string, 18)
+	for i := range rayKeys {
+		rayKeys[i] = fmt.Sprintf("Account%02d", i)
+	}
+
+	events := parser.ParseSwapEventsV2(pumpLogs, nil, "pumpTx", 100, 1000)
+	events = append(events, parser.ParseSwapEventsV2(
+		[]string{"Program log: ray_log: " + base64.StdEncoding.EncodeToString(rayLog)}, rayKeys, "rayTx", 101, 2000)...)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 parsed swaps, got %d", len(events))
+	}
+
+	candidates, err := discovery.NewDetector(candidateStore).ProcessEvents(ctx, events)
+	if err != nil {
+		t.Fatalf("ProcessEvents: %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(candidates))
+	}
+
+	// The label survives the round trip through the candidate store
+	stored, err := candidateStore.GetBySource(ctx, domain.SourceNewToken)
+	if err != nil {
+		t.Fatalf("GetBySource: %v", err)
+	}
+	byDEX := make(map[string]string) // dex -> candidate_id
+	for _, c := range stored {
+		byDEX[c.DEX] = c.CandidateID
+	}
+	if byDEX[domain.DEXPumpFun] == "" || byDEX[domain.DEXRaydium] == "" {
+		t.Fatalf("expected one stored candidate per DEX, got %v", byDEX)
+	}
+
+	strategyID, scenarioID := "strategy-dex", domain.ScenarioRealistic
+	if err := tradeStore.InsertBulk(ctx, []*domain.TradeRecord{
+		makeTrade("t-pump-1", byDEX[domain.DEXPumpFun], strategyID, scenarioID, 0.30, domain.OutcomeClassWin, 1000),
+		makeTrade("t-pump-2", byDEX[domain.DEXPumpFun], strategyID, scenarioID, 0.10, domain.OutcomeClassWin, 1500),
+		makeTrade("t-ray-1", byDEX[domain.DEXRaydium], strategyID, scenarioID, -0.20, domain.OutcomeClassLoss, 2000),
+	}); err != nil {
+		t.Fatalf("InsertBulk: %v", err)
+	}
+
+	aggregator := NewAggregator(tradeStore, memory.NewStrategyAggregateStore(), candidateStore)
+
+	pumpKey := LabeledEntryEventType("NEW_TOKEN", DEXCohortLabel(domain.DEXPumpFun))
+	if pumpKey != "NEW_TOKEN:dex_pumpfun" {
+		t.Errorf("unexpected aggregate key %q", pumpKey)
+	}
+	pump, err := aggregator.ComputeAggregate(ctx, strategyID, scenarioID, pumpKey)
+	if err != nil {
+		t.Fatalf("ComputeAggregate %s: %v", pumpKey, err)
+	}
+	if pump.EntryEventType != pumpKey || pump.TotalTrades != 2 || pump.Wins != 2 {
+		t.Errorf("pump.fun cohort: got entry=%s trades=%d wins=%d", pump.EntryEventType, pump.TotalTrades, pump.Wins)
+	}
+
+	ray, err := aggregator.ComputeAggregate(ctx, strategyID, scenarioID, LabeledEntryEventType("NEW_TOKEN", DEXCohortLabel(domain.DEXRaydium)))
+	if err != nil {
+		t.Fatalf("ComputeAggregate raydium: %v", err)
+	}
+	if ray.TotalTrades != 1 || ray.Losses != 1 {
+		t.Errorf("raydium cohort: got trades=%d losses=%d", ray.TotalTrades, ray.Losses)
+	}
+
+	// DEX cohorts need no entry filter configuration, and respect the source
+	if _, err := aggregator.ComputeAggregate(ctx, strategyID, scenarioID, LabeledEntryEventType("ACTIVE_TOKEN", DEXCohortLabel(domain.DEXPumpFun))); !errors.Is(err, ErrNoTrades) {
+		t.Errorf("ACTIVE_TOKEN pump.fun cohort: expected ErrNoTrades, got %v", err)
+	}
+}
@@ -29,15 +29,19 @@ func computeFromTrades(trades []*domain.TradeRecord, entryEventType string) *dom
 		return sortedTrades[i].TradeID < sortedTrades[j].TradeID
 	})
 
-	// Count wins/losses
+	// Count wins/losses and forced DATA_END exits
 	wins := 0
 	losses := 0
+	dataEnd := 0
 	for _, t := range sortedTrades {
 		if t.OutcomeClass == domain.OutcomeClassWin {
 			wins++
 		} else {
 			losses++
 		}
+		if t.IsDataEnd() {
+			dataEnd++
+		}
 	}
 
 	// Extract outcomes in sorted order for order-dependent calculations
@@ -83,6 +87,9 @@ func computeFromTrades(trades []*domain.TradeRecord, entryEventType string) *dom
 		// Drawdown (order-dependent, uses sortedTrades order)
 		MaxDrawdown:          computeMaxDrawdown(outcomes),
 		MaxConsecutiveLosses: computeMaxConsecutiveLosses(sortedTrades),
+
+		DataEndExits: dataEnd,
+		DataEndShare: float64(dataEnd) / float64(n),
 	}
 
 	return agg
@@ -225,9 +232,11 @@ func computeMaxConsecutiveLosses(trades []*domain.TradeRecord) int {
 	return maxStreak
 }
 
-// setSensitivityFields sets OutcomeRealistic/Pessimistic/Degraded based on scenarioID.
+// setSensitivityFields sets OutcomeRealistic/Pessimistic/Degraded based on scenarioID,
+// ignoring the scenario version of recomputed trades.
 func setSensitivityFields(agg *domain.StrategyAggregate) {
-	switch agg.ScenarioID {
+	scenarioID, _ := domain.SplitScenarioID(agg.ScenarioID)
+	switch scenarioID {
 	case domain.ScenarioRealistic:
 		agg.OutcomeRealistic = &agg.OutcomeMean
 	case domain.ScenarioPessimistic:
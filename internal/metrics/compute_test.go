@@ -174,3 +174,25 @@ func TestComputeTokenWinRate_SingleTradePerToken(t *testing.T) {
 		t.Errorf("expected winRate %.4f, got %.4f", expectedWinRate, winRate)
 	}
 }
+
+func TestComputeFromTrades_DataEndExits(t *testing.T) {
+	trades := []*domain.TradeRecord{
+		{TradeID: "t1", CandidateID: "token-A", Outcome: 0.10, ExitReason: domain.ExitReasonTimeExit},
+		{TradeID: "t2", CandidateID: "token-B", Outcome: -0.90, ExitReason: domain.ExitReasonDataEnd},
+		{TradeID: "t3", CandidateID: "token-C", Outcome: 0.05, ExitReason: domain.ExitReasonTimeExit},
+		{TradeID: "t4", CandidateID: "token-D", Outcome: 0.40, ExitReason: domain.ExitReasonDataEnd},
+	}
+
+	agg := computeFromTrades(trades, "NEW_TOKEN")
+
+	if agg.DataEndExits != 2 {
+		t.Errorf("expected 2 DATA_END exits, got %d", agg.DataEndExits)
+	}
+	if agg.DataEndShare != 0.5 {
+		t.Errorf("expected DATA_END share 0.5, got %f", agg.DataEndShare)
+	}
+	// DATA_END trades still count in the outcome distribution
+	if agg.TotalTrades != 4 || agg.OutcomeMin != -0.90 {
+		t.Errorf("expected DATA_END trades aggregated, got %d trades, min %f", agg.TotalTrades, agg.OutcomeMin)
+	}
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+)
+
+// CostComposition is the mean per-trade cost breakdown of one scenario.
+type CostComposition struct {
+	ScenarioID           string
+	Trades               int // trades with a cost breakdown
+	MeanEntrySlippageSOL float64
+	MeanExitSlippageSOL  float64
+	MeanNetworkFeeSOL    float64
+	MeanPriorityFeeSOL   float64
+	MeanMEVSOL           float64
+}
+
+// scenarioRank orders the predefined scenarios from cheapest to most expensive.
+var scenarioRank = map[string]int{
+	domain.ScenarioOptimistic:  0,
+	domain.ScenarioRealistic:   1,
+	domain.ScenarioPessimistic: 2,
+	domain.ScenarioDegraded:    3,
+}
+
+// ComputeCostComposition averages trade cost breakdowns per scenario.
+// Trades without a breakdown are skipped. Rows are ordered optimistic, realistic,
+// pessimistic, degraded, then any other scenario by ID.
+func ComputeCostComposition(trades []*domain.TradeRecord) []CostComposition {
+	byScenario := make(map[string]*CostComposition)
+	for _, t := range trades {
+		b := t.CostBreakdown
+		if b == nil {
+			continue
+		}
+		c, ok := byScenario[t.ScenarioID]
+		if !ok {
+			c = &CostComposition{ScenarioID: t.ScenarioID}
+			byScenario[t.ScenarioID] = c
+		}
+		c.Trades++
+		c.MeanEntrySlippageSOL += b.EntrySlippageSOL
+		c.MeanExitSlippageSOL += b.ExitSlippageSOL
+		c.MeanNetworkFeeSOL += b.NetworkFeeSOL
+		c.MeanPriorityFeeSOL += b.PriorityFeeSOL
+		c.MeanMEVSOL += b.MEVSOL
+	}
+
+	rows := make([]CostComposition, 0, len(byScenario))
+	for _, c := range byScenario {
+		n := float64(c.Trades)
+		c.MeanEntrySlippageSOL /= n
+		c.MeanExitSlippageSOL /= n
+		c.MeanNetworkFeeSOL /= n
+		c.MeanPriorityFeeSOL /= n
+		c.MeanMEVSOL /= n
+		rows = append(rows, *c)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		ri, iKnown := scenarioRank[rows[i].ScenarioID]
+		rj, jKnown := scenarioRank[rows[j].ScenarioID]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		if iKnown && ri != rj {
+			return ri < rj
+		}
+		return rows[i].ScenarioID < rows[j].ScenarioID
+	})
+	return rows
+}
+
+// CostComposition returns the mean cost composition per scenario over all stored trades.
+func (a *Aggregator) CostComposition(ctx context.Context) ([]CostComposition, error) {
+	trades, err := a.tradeRecordStore.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeCostComposition(trades), nil
+}
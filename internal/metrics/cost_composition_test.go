@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestComputeCostComposition(t *testing.T) {
+	withCosts := func(tr *domain.TradeRecord, slip, fee, mev float64) *domain.TradeRecord {
+		tr.CostBreakdown = &domain.CostBreakdown{
+			EntrySlippageSOL: slip,
+			ExitSlippageSOL:  slip,
+			NetworkFeeSOL:    fee,
+			PriorityFeeSOL:   fee * 10,
+			MEVSOL:           mev,
+		}
+		return tr
+	}
+	trades := []*domain.TradeRecord{
+		withCosts(makeTrade("t1", "c1", "TIME_EXIT", domain.ScenarioPessimistic, 0, domain.OutcomeClassLoss, 1000), 0.05, 0.0002, 0.03),
+		withCosts(makeTrade("t2", "c1", "TIME_EXIT", domain.ScenarioRealistic, 0, domain.OutcomeClassLoss, 1000), 0.02, 0.00002, 0.01),
+		withCosts(makeTrade("t3", "c2", "TIME_EXIT", domain.ScenarioRealistic, 0, domain.OutcomeClassLoss, 2000), 0.04, 0.00002, 0.03),
+		makeTrade("t4", "c3", "TIME_EXIT", domain.ScenarioOptimistic, 0, domain.OutcomeClassLoss, 3000), // no breakdown
+	}
+
+	rows := ComputeCostComposition(trades)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 scenarios, got %+v", rows)
+	}
+	if rows[0].ScenarioID != domain.ScenarioRealistic || rows[1].ScenarioID != domain.ScenarioPessimistic {
+		t.Errorf("unexpected order: %s, %s", rows[0].ScenarioID, rows[1].ScenarioID)
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }
+	r := rows[0]
+	if r.Trades != 2 || !near(r.MeanEntrySlippageSOL, 0.03) || !near(r.MeanMEVSOL, 0.02) ||
+		!near(r.MeanNetworkFeeSOL, 0.00002) || !near(r.MeanPriorityFeeSOL, 0.0002) {
+		t.Errorf("realistic composition = %+v", r)
+	}
+}
+
+func TestAggregator_CostComposition(t *testing.T) {
+	ctx := context.Background()
+	tradeStore := memory.NewTradeRecordStore()
+	tr := makeTrade("t1", "c1", "TIME_EXIT", domain.ScenarioRealistic, 0, domain.OutcomeClassLoss, 1000)
+	tr.CostBreakdown = &domain.CostBreakdown{MEVSOL: 0.01}
+	if err := tradeStore.Insert(ctx, tr); err != nil {
+		t.Fatalf("Insert trade failed: %v", err)
+	}
+
+	rows, err := NewAggregator(tradeStore, memory.NewStrategyAggregateStore(), memory.NewCandidateStore()).CostComposition(ctx)
+	if err != nil {
+		t.Fatalf("CostComposition failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].MeanMEVSOL != 0.01 {
+		t.Errorf("rows = %+v", rows)
+	}
+}
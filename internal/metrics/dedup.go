@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"sort"
+
+	"solana-token-lab/internal/domain"
+)
+
+// DedupLabel is the cohort label of aggregates over deduplicated trades (see
+// DedupTrades), e.g. "ACTIVE_TOKEN:dedup". It does not start with a predicate
+// name or the DEX cohort prefix, so it never collides with other labels.
+const DedupLabel = "dedup"
+
+// DedupTrades drops trades that double-count a mint's price action: a trade is
+// dropped when another candidate of the same mint holds an overlapping position
+// that was entered first. Typically a token discovered as NEW_TOKEN and later
+// flagged ACTIVE_TOKEN, both traded over the same period.
+//
+// mintOf maps candidate_id to mint; trades of unknown candidates are dropped.
+// Holding windows are [EntryActualTime, ExitActualTime], closed at both ends, so
+// an entry at the exact exit time of another trade overlaps it.
+//
+// Tie-breaking, for deterministic output: trades of a mint are visited by
+// EntryActualTime, then candidate_id, then trade_id. A visited trade is kept
+// unless it overlaps a kept trade of another candidate, so of two equally early
+// entries the lower candidate_id wins. Trades of the same candidate never drop
+// each other: they are different parameterizations of one position, which the
+// raw aggregate already combines. The result keeps the input order.
+func DedupTrades(trades []*domain.TradeRecord, mintOf map[string]string) []*domain.TradeRecord {
+	byMint := make(map[string][]*domain.TradeRecord)
+	for _, t := range trades {
+		mint, ok := mintOf[t.CandidateID]
+		if !ok {
+			continue
+		}
+		byMint[mint] = append(byMint[mint], t)
+	}
+
+	kept := make(map[*domain.TradeRecord]bool, len(trades))
+	for _, group := range byMint {
+		sort.SliceStable(group, func(i, j int) bool {
+			a, b := group[i], group[j]
+			if a.EntryActualTime != b.EntryActualTime {
+				return a.EntryActualTime < b.EntryActualTime
+			}
+			if a.CandidateID != b.CandidateID {
+				return a.CandidateID < b.CandidateID
+			}
+			return a.TradeID < b.TradeID
+		})
+
+		var held []*domain.TradeRecord
+		for _, t := range group {
+			if !overlapsOtherCandidate(t, held) {
+				held = append(held, t)
+				kept[t] = true
+			}
+		}
+	}
+
+	result := make([]*domain.TradeRecord, 0, len(kept))
+	for _, t := range trades {
+		if kept[t] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// overlapsOtherCandidate reports whether t's holding window overlaps one of a
+// held trade of another candidate.
+func overlapsOtherCandidate(t *domain.TradeRecord, held []*domain.TradeRecord) bool {
+	for _, h := range held {
+		if h.CandidateID != t.CandidateID &&
+			t.EntryActualTime <= h.ExitActualTime && h.EntryActualTime <= t.ExitActualTime {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// heldTrade creates a trade holding a position over [entry, exit].
+func heldTrade(id, candidateID string, entry, exit int64, outcome float64) *domain.TradeRecord {
+	class := domain.OutcomeClassWin
+	if outcome <= 0 {
+		class = domain.OutcomeClassLoss
+	}
+	t := makeTrade(id, candidateID, "TIME_EXIT", domain.ScenarioRealistic, outcome, class, entry)
+	t.EntryActualTime = entry
+	t.ExitActualTime = exit
+	return t
+}
+
+func tradeIDs(trades []*domain.TradeRecord) string {
+	ids := make([]string, len(trades))
+	for i, t := range trades {
+		ids[i] = t.TradeID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestDedupTrades(t *testing.T) {
+	mintOf := map[string]string{
+		"new-a": "mintA", "active-a": "mintA", "active-a2": "mintA",
+		"new-b": "mintB", "active-b": "mintB",
+		"new-c": "mintC",
+	}
+
+	tests := []struct {
+		name   string
+		trades []*domain.TradeRecord
+		want   string
+	}{
+		{
+			name: "later overlapping entry dropped",
+			trades: []*domain.TradeRecord{
+				heldTrade("t-active", "active-a", 2000, 5000, 0.2),
+				heldTrade("t-new", "new-a", 1000, 3000, 0.1),
+			},
+			want: "t-new",
+		},
+		{
+			name: "disjoint windows both kept",
+			trades: []*domain.TradeRecord{
+				heldTrade("t-new", "new-a", 1000, 2000, 0.1),
+				heldTrade("t-active", "active-a", 2001, 3000, 0.2),
+			},
+			want: "t-new,t-active",
+		},
+		{
+			name: "touching windows overlap",
+			trades: []*domain.TradeRecord{
+				heldTrade("t-new", "new-a", 1000, 2000, 0.1),
+				heldTrade("t-active", "active-a", 2000, 3000, 0.2),
+			},
+			want: "t-new",
+		},
+		{
+			name: "equal entries keep lower candidate_id",
+			trades: []*domain.TradeRecord{
+				heldTrade("t-new", "new-a", 1000, 2000, 0.1),
+				heldTrade("t-active", "active-a", 1000, 2000, 0.2),
+			},
+			want: "t-active",
+		},
+		{
+			name: "dropped trade does not suppress others",
+			trades: []*domain.TradeRecord{
+				heldTrade("t1", "new-a", 1000, 2000, 0.1),
+				heldTrade("t2", "active-a", 1500, 4000, 0.2),
+				heldTrade("t3", "active-a2", 3000, 5000, 0.3),
+			},
+			want: "t1,t3",
+		},
+		{
+			name: "same candidate never deduplicated",
+			trades: []*domain.TradeRecord{
+				heldTrade("t-short", "new-a", 1000, 2000, 0.1),
+				heldTrade("t-long", "new-a", 1000, 9000, 0.2),
+			},
+			want: "t-short,t-long",
+		},
+		{
+			name: "other mints and unknown candidates",
+			trades: []*domain.TradeRecord{
+				heldTrade("t-a", "new-a", 1000, 3000, 0.1),
+				heldTrade("t-b", "active-b", 1000, 3000, 0.2),
+				heldTrade("t-c", "new-c", 1000, 3000, 0.3),
+				heldTrade("t-missing", "missing", 1000, 3000, 0.4),
+			},
+			want: "t-a,t-b,t-c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tradeIDs(DedupTrades(tt.trades, mintOf)); got != tt.want {
+				t.Errorf("DedupTrades = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeAggregate_Dedup(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	// mintA: NEW_TOKEN then ACTIVE_TOKEN over the same period; mintB: ACTIVE_TOKEN only
+	candidates := []*domain.TokenCandidate{
+		makeCandidate("new-a", domain.SourceNewToken),
+		makeCandidate("active-a", domain.SourceActiveToken),
+		makeCandidate("active-b", domain.SourceActiveToken),
+	}
+	candidates[1].Mint = candidates[0].Mint
+	for _, c := range candidates {
+		if err := candidateStore.Insert(ctx, c); err != nil {
+			t.Fatalf("Insert candidate: %v", err)
+		}
+	}
+	for _, trade := range []*domain.TradeRecord{
+		heldTrade("t-new-a", "new-a", 1000, 5000, 0.5),
+		heldTrade("t-active-a", "active-a", 3000, 8000, 0.4),
+		heldTrade("t-active-b", "active-b", 3000, 8000, -0.1),
+	} {
+		if err := tradeStore.Insert(ctx, trade); err != nil {
+			t.Fatalf("Insert trade: %v", err)
+		}
+	}
+
+	aggregator := NewAggregator(tradeStore, memory.NewStrategyAggregateStore(), candidateStore)
+	activeDedup := LabeledEntryEventType("ACTIVE_TOKEN", DedupLabel)
+
+	if _, err := aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, activeDedup); !errors.Is(err, ErrUnknownEntryFilter) {
+		t.Fatalf("expected ErrUnknownEntryFilter without WithDedup, got %v", err)
+	}
+	aggregator.WithDedup(true)
+
+	raw, err := aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, "ACTIVE_TOKEN")
+	if err != nil {
+		t.Fatalf("raw aggregate: %v", err)
+	}
+	if raw.TotalTrades != 2 {
+		t.Errorf("expected 2 raw ACTIVE_TOKEN trades, got %d", raw.TotalTrades)
+	}
+
+	dedup, err := aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, activeDedup)
+	if err != nil {
+		t.Fatalf("dedup aggregate: %v", err)
+	}
+	if dedup.TotalTrades != 1 || dedup.OutcomeMedian != -0.1 || dedup.EntryEventType != activeDedup {
+		t.Errorf("expected only t-active-b in dedup aggregate, got %d trades, median %f, entry %s",
+			dedup.TotalTrades, dedup.OutcomeMedian, dedup.EntryEventType)
+	}
+
+	// The earlier NEW_TOKEN trade is unaffected
+	newDedup, err := aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, LabeledEntryEventType("NEW_TOKEN", DedupLabel))
+	if err != nil {
+		t.Fatalf("NEW_TOKEN dedup aggregate: %v", err)
+	}
+	if newDedup.TotalTrades != 1 || newDedup.OutcomeMedian != 0.5 {
+		t.Errorf("expected t-new-a kept, got %d trades, median %f", newDedup.TotalTrades, newDedup.OutcomeMedian)
+	}
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"solana-token-lab/internal/domain"
+)
+
+// labelSeparator joins an entry event type and an entry filter label.
+const labelSeparator = ":"
+
+// EntryFilter restricts an aggregate to trades whose entry context satisfies
+// every set bound. Trades without an entry context never match.
+type EntryFilter struct {
+	Label string // identifies the filter in the labeled entry_event_type
+
+	MaxTokenAgeMs     *int64   // token_age_ms < MaxTokenAgeMs
+	MinLiquidity      *float64 // liquidity >= MinLiquidity (unknown liquidity fails)
+	MinVolume1h       *float64 // volume_1h >= MinVolume1h
+	MinSwapsPerMinute *float64 // swaps_per_minute >= MinSwapsPerMinute
+}
+
+// Matches reports whether c satisfies the filter.
+func (f EntryFilter) Matches(c *domain.EntryContext) bool {
+	if c == nil {
+		return false
+	}
+	if f.MaxTokenAgeMs != nil && c.TokenAgeMs >= *f.MaxTokenAgeMs {
+		return false
+	}
+	if f.MinLiquidity != nil && (c.Liquidity == nil || *c.Liquidity < *f.MinLiquidity) {
+		return false
+	}
+	if f.MinVolume1h != nil && c.Volume1h < *f.MinVolume1h {
+		return false
+	}
+	if f.MinSwapsPerMinute != nil && c.SwapsPerMinute < *f.MinSwapsPerMinute {
+		return false
+	}
+	return true
+}
+
+// LabeledEntryEventType returns the entry_event_type under which aggregates of
+// entryEventType restricted by the filter labeled label are stored,
+// e.g. "NEW_TOKEN:token_age_lt_10m".
+func LabeledEntryEventType(entryEventType, label string) string {
+	return entryEventType + labelSeparator + label
+}
+
+// dexCohortPrefix marks a cohort label that segments by the candidate's discovery DEX.
+// Entry filter labels start with a predicate name, so they never collide.
+const dexCohortPrefix = "dex_"
+
+// DEXCohortLabel returns the cohort label restricting an aggregate to candidates
+// discovered on dex, e.g. "dex_raydium". Combine it with LabeledEntryEventType:
+// "NEW_TOKEN:dex_raydium".
+func DEXCohortLabel(dex string) string {
+	return dexCohortPrefix + dex
+}
+
+// ParseDEXCohortLabel returns the DEX of a DEX cohort label; ok is false for
+// any other label.
+func ParseDEXCohortLabel(label string) (dex string, ok bool) {
+	dex, ok = strings.CutPrefix(label, dexCohortPrefix)
+	if !ok || dex == "" {
+		return "", false
+	}
+	return dex, true
+}
+
+// SplitEntryEventType splits a labeled entry_event_type into its base type and
+// filter label. label is empty for unlabeled types.
+func SplitEntryEventType(entryEventType string) (base, label string) {
+	base, label, _ = strings.Cut(entryEventType, labelSeparator)
+	return base, label
+}
+
+// ParseEntryFilter parses a comma-separated list of predicates:
+//
+//	token_age_lt=<duration>   e.g. token_age_lt=10m
+//	liquidity_gte=<float>
+//	volume_1h_gte=<float>
+//	swaps_per_min_gte=<float>
+//
+// The label is derived from the spec, e.g. "token_age_lt=10m" → "token_age_lt_10m".
+func ParseEntryFilter(spec string) (EntryFilter, error) {
+	var f EntryFilter
+	var labels []string
+
+	for _, pred := range strings.Split(spec, ",") {
+		pred = strings.TrimSpace(pred)
+		key, value, ok := strings.Cut(pred, "=")
+		if !ok || value == "" {
+			return EntryFilter{}, fmt.Errorf("entry filter %q: expected key=value", pred)
+		}
+
+		switch key {
+		case "token_age_lt":
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return EntryFilter{}, fmt.Errorf("entry filter %q: %w", pred, err)
+			}
+			ms := d.Milliseconds()
+			f.MaxTokenAgeMs = &ms
+		case "liquidity_gte", "volume_1h_gte", "swaps_per_min_gte":
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return EntryFilter{}, fmt.Errorf("entry filter %q: %w", pred, err)
+			}
+			switch key {
+			case "liquidity_gte":
+				f.MinLiquidity = &v
+			case "volume_1h_gte":
+				f.MinVolume1h = &v
+			default:
+				f.MinSwapsPerMinute = &v
+			}
+		default:
+			return EntryFilter{}, fmt.Errorf("entry filter %q: unknown predicate %s", pred, key)
+		}
+		labels = append(labels, key+"_"+value)
+	}
+
+	f.Label = strings.Join(labels, "_and_")
+	return f, nil
+}
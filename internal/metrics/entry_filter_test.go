@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestParseEntryFilter(t *testing.T) {
+	f, err := ParseEntryFilter("token_age_lt=10m,liquidity_gte=1000")
+	if err != nil {
+		t.Fatalf("ParseEntryFilter failed: %v", err)
+	}
+	if f.Label != "token_age_lt_10m_and_liquidity_gte_1000" {
+		t.Errorf("Label = %q", f.Label)
+	}
+	if f.MaxTokenAgeMs == nil || *f.MaxTokenAgeMs != 600000 {
+		t.Errorf("MaxTokenAgeMs = %v, want 600000", f.MaxTokenAgeMs)
+	}
+	if f.MinLiquidity == nil || *f.MinLiquidity != 1000 {
+		t.Errorf("MinLiquidity = %v, want 1000", f.MinLiquidity)
+	}
+
+	for _, spec := range []string{"", "token_age_lt", "token_age_lt=soon", "liquidity_gte=x", "holders_gte=5"} {
+		if _, err := ParseEntryFilter(spec); err == nil {
+			t.Errorf("ParseEntryFilter(%q): expected error", spec)
+		}
+	}
+}
+
+func TestEntryFilter_Matches(t *testing.T) {
+	liq := 500.0
+	young := &domain.EntryContext{Liquidity: &liq, TokenAgeMs: 300000, Volume1h: 20}
+	old := &domain.EntryContext{TokenAgeMs: 600000, Volume1h: 20}
+
+	f, _ := ParseEntryFilter("token_age_lt=10m")
+	if !f.Matches(young) || f.Matches(old) || f.Matches(nil) {
+		t.Error("token_age_lt=10m: expected only the younger context to match")
+	}
+
+	f, _ = ParseEntryFilter("liquidity_gte=100")
+	if !f.Matches(young) || f.Matches(old) {
+		t.Error("liquidity_gte=100: unknown liquidity must not match")
+	}
+}
+
+func TestComputeAggregate_LabeledEntryFilter(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	for _, id := range []string{"c1", "c2", "c3"} {
+		if err := candidateStore.Insert(ctx, makeCandidate(id, domain.SourceNewToken)); err != nil {
+			t.Fatalf("Insert candidate failed: %v", err)
+		}
+	}
+
+	withAge := func(tr *domain.TradeRecord, ageMs int64) *domain.TradeRecord {
+		tr.EntryContext = &domain.EntryContext{TokenAgeMs: ageMs}
+		return tr
+	}
+	trades := []*domain.TradeRecord{
+		withAge(makeTrade("t1", "c1", "TIME_EXIT", domain.ScenarioRealistic, 0.20, domain.OutcomeClassWin, 1000), 60000),
+		withAge(makeTrade("t2", "c2", "TIME_EXIT", domain.ScenarioRealistic, -0.10, domain.OutcomeClassLoss, 2000), 3600000),
+		makeTrade("t3", "c3", "TIME_EXIT", domain.ScenarioRealistic, 0.05, domain.OutcomeClassWin, 3000), // no context
+	}
+	for _, tr := range trades {
+		if err := tradeStore.Insert(ctx, tr); err != nil {
+			t.Fatalf("Insert trade failed: %v", err)
+		}
+	}
+
+	filter, err := ParseEntryFilter("token_age_lt=10m")
+	if err != nil {
+		t.Fatalf("ParseEntryFilter failed: %v", err)
+	}
+	aggregator := NewAggregator(tradeStore, memory.NewStrategyAggregateStore(), candidateStore).
+		WithEntryFilters([]EntryFilter{filter})
+
+	labeled := LabeledEntryEventType("NEW_TOKEN", filter.Label)
+	if labeled != "NEW_TOKEN:token_age_lt_10m" {
+		t.Errorf("labeled entry event type = %q", labeled)
+	}
+
+	agg, err := aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, labeled)
+	if err != nil {
+		t.Fatalf("ComputeAggregate failed: %v", err)
+	}
+	if agg.EntryEventType != labeled || agg.TotalTrades != 1 || agg.OutcomeMean != 0.20 {
+		t.Errorf("labeled aggregate = %s/%d trades/mean %v, want %s/1/0.20",
+			agg.EntryEventType, agg.TotalTrades, agg.OutcomeMean, labeled)
+	}
+
+	// The unlabeled aggregate still covers all trades
+	base, err := aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("ComputeAggregate (base) failed: %v", err)
+	}
+	if base.TotalTrades != 3 {
+		t.Errorf("base TotalTrades = %d, want 3", base.TotalTrades)
+	}
+
+	_, err = aggregator.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, "NEW_TOKEN:unknown")
+	if !errors.Is(err, ErrUnknownEntryFilter) {
+		t.Errorf("expected ErrUnknownEntryFilter, got %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+package metrics
+
+import (
+	"sort"
+
+	"solana-token-lab/internal/domain"
+)
+
+// DefaultFeatureBins is the number of quantile bins per feature (quartiles).
+const DefaultFeatureBins = 4
+
+// Entry features, named as in feature_analysis.csv. The first five come from
+// the trade's entry context; the others are supplied as FeatureValues.
+const (
+	FeatureEntryLiquidity   = "entry_liquidity"
+	FeatureTokenAge         = "token_age_ms"
+	FeatureVolume1h         = "volume_1h"
+	FeatureSwapsPerMinute   = "swaps_per_minute"
+	FeatureSwapsPrior       = "swaps_prior"
+	FeatureBuySellImbalance = "buy_sell_imbalance" // see BuySellImbalance
+	FeatureHolderTop10Pct   = "holder_top10_pct"   // see HolderConcentration
+)
+
+// featureWindowMs is the look-back window of BuySellImbalance, the hour the
+// entry context's volume_1h covers.
+const featureWindowMs int64 = 3600000
+
+// contextFeatures read a feature from the entry context; ok is false when
+// the trade has no value for it.
+var contextFeatures = []struct {
+	name  string
+	value func(c *domain.EntryContext) (float64, bool)
+}{
+	{FeatureEntryLiquidity, func(c *domain.EntryContext) (float64, bool) {
+		if c.Liquidity == nil {
+			return 0, false
+		}
+		return *c.Liquidity, true
+	}},
+	{FeatureTokenAge, func(c *domain.EntryContext) (float64, bool) { return float64(c.TokenAgeMs), true }},
+	{FeatureVolume1h, func(c *domain.EntryContext) (float64, bool) { return c.Volume1h, true }},
+	{FeatureSwapsPerMinute, func(c *domain.EntryContext) (float64, bool) { return c.SwapsPerMinute, true }},
+	{FeatureSwapsPrior, func(c *domain.EntryContext) (float64, bool) { return float64(c.SwapsPrior), true }},
+}
+
+// FeatureValues are entry features outside the entry context, by feature name
+// then trade ID. Trades without a value are not binned for the feature.
+type FeatureValues map[string]map[string]float64
+
+// Set records the value of feature for a trade.
+func (v FeatureValues) Set(feature, tradeID string, value float64) {
+	if v[feature] == nil {
+		v[feature] = make(map[string]float64)
+	}
+	v[feature][tradeID] = value
+}
+
+// BuySellImbalance returns (buys - sells) / (buys + sells) over the swaps of
+// the hour ending at signalTime, from -1 (only sells) to 1 (only buys). ok is
+// false without a buy or sell in the window.
+func BuySellImbalance(swaps []*domain.Swap, signalTime int64) (imbalance float64, ok bool) {
+	var buys, sells int
+	for _, s := range swaps {
+		if s.Timestamp <= signalTime-featureWindowMs || s.Timestamp > signalTime {
+			continue
+		}
+		switch s.Side {
+		case domain.SwapSideBuy:
+			buys++
+		case domain.SwapSideSell:
+			sells++
+		}
+	}
+	if buys+sells == 0 {
+		return 0, false
+	}
+	return float64(buys-sells) / float64(buys+sells), true
+}
+
+// HolderConcentration returns the top-10 holder share (0-100) of the latest
+// snapshot taken at or before signalTime, so that the feature has no
+// look-ahead. ok is false without such a snapshot.
+func HolderConcentration(snapshots []*domain.TokenHolderSnapshot, signalTime int64) (top10Pct float64, ok bool) {
+	var latest *domain.TokenHolderSnapshot
+	for _, s := range snapshots {
+		if s.SnapshotAt <= signalTime && (latest == nil || s.SnapshotAt > latest.SnapshotAt) {
+			latest = s
+		}
+	}
+	if latest == nil {
+		return 0, false
+	}
+	return latest.Top10Pct, true
+}
+
+// FeatureAnalysis relates the entry features of a strategy's trades under one
+// scenario to their outcomes (see AnalyzeEntryFeatures).
+type FeatureAnalysis struct {
+	StrategyID string
+	ScenarioID string
+	Bins       int             // quantile bins requested per feature
+	Trades     int             // trades of the strategy and scenario
+	Features   []FeatureResult // ranked: the most predictive first
+}
+
+// FeatureResult is the binned outcome of one feature.
+type FeatureResult struct {
+	Feature string
+	Rank    int // 1 for the most predictive feature
+	Trades  int // trades with a value
+	Missing int // trades without a value
+	Bins    []FeatureBin
+
+	// Spreads between the best and the worst bin: the ranking criteria
+	WinRateSpread float64
+	MedianSpread  float64
+}
+
+// FeatureBin is the outcome of the trades whose feature value lies in
+// [Lower, Upper); the last bin includes Upper. Lower of the first and Upper
+// of the last bin are the smallest and largest value, the others are quantiles
+// of the values.
+type FeatureBin struct {
+	Lower         float64
+	Upper         float64
+	Trades        int
+	Wins          int
+	WinRate       float64
+	MedianOutcome float64
+}
+
+// AnalyzeEntryFeatures bins the trades of strategyID under scenarioID by each
+// entry feature and reports the win rate and median outcome of every bin. Bin
+// edges are the bins-quantiles of the feature's values (bins <= 0 uses
+// DefaultFeatureBins); tied edges merge bins, so a feature may have fewer.
+// Features are ranked by the win rate spread between their best and worst bin,
+// then by the median outcome spread, then by name; a feature without a value
+// on any trade is omitted.
+func AnalyzeEntryFeatures(trades []*domain.TradeRecord, strategyID, scenarioID string, extra FeatureValues, bins int) *FeatureAnalysis {
+	if bins <= 0 {
+		bins = DefaultFeatureBins
+	}
+	var selected []*domain.TradeRecord
+	for _, t := range trades {
+		if t.StrategyID == strategyID && t.ScenarioID == scenarioID {
+			selected = append(selected, t)
+		}
+	}
+	// Trade ID order makes the medians of ties independent of the store order
+	sort.Slice(selected, func(i, j int) bool { return selected[i].TradeID < selected[j].TradeID })
+
+	a := &FeatureAnalysis{StrategyID: strategyID, ScenarioID: scenarioID, Bins: bins, Trades: len(selected)}
+
+	analyze := func(name string, value func(t *domain.TradeRecord) (float64, bool)) {
+		var obs []featureObservation
+		for _, t := range selected {
+			if v, ok := value(t); ok {
+				obs = append(obs, featureObservation{v, t})
+			}
+		}
+		if len(obs) == 0 {
+			return
+		}
+		sort.SliceStable(obs, func(i, j int) bool { return obs[i].value < obs[j].value })
+
+		values := make([]float64, len(obs))
+		for i, o := range obs {
+			values[i] = o.value
+		}
+		edges := quantileEdges(values, bins)
+
+		r := FeatureResult{Feature: name, Trades: len(obs), Missing: len(selected) - len(obs)}
+		lower := values[0]
+		start := 0
+		for i := 0; i <= len(edges); i++ {
+			upper := values[len(values)-1]
+			end := len(obs)
+			if i < len(edges) {
+				upper = edges[i]
+				end = sort.SearchFloat64s(values, edges[i])
+			}
+			if end > start {
+				r.Bins = append(r.Bins, newFeatureBin(lower, upper, obs[start:end]))
+			}
+			lower, start = upper, end
+		}
+
+		first := r.Bins[0]
+		maxWinRate, minWinRate := first.WinRate, first.WinRate
+		maxMedian, minMedian := first.MedianOutcome, first.MedianOutcome
+		for _, b := range r.Bins[1:] {
+			maxWinRate, minWinRate = max(maxWinRate, b.WinRate), min(minWinRate, b.WinRate)
+			maxMedian, minMedian = max(maxMedian, b.MedianOutcome), min(minMedian, b.MedianOutcome)
+		}
+		r.WinRateSpread = maxWinRate - minWinRate
+		r.MedianSpread = maxMedian - minMedian
+		a.Features = append(a.Features, r)
+	}
+
+	for _, f := range contextFeatures {
+		analyze(f.name, func(t *domain.TradeRecord) (float64, bool) {
+			if t.EntryContext == nil {
+				return 0, false
+			}
+			return f.value(t.EntryContext)
+		})
+	}
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		analyze(name, func(t *domain.TradeRecord) (float64, bool) {
+			v, ok := extra[name][t.TradeID]
+			return v, ok
+		})
+	}
+
+	sort.SliceStable(a.Features, func(i, j int) bool {
+		fi, fj := a.Features[i], a.Features[j]
+		if fi.WinRateSpread != fj.WinRateSpread {
+			return fi.WinRateSpread > fj.WinRateSpread
+		}
+		if fi.MedianSpread != fj.MedianSpread {
+			return fi.MedianSpread > fj.MedianSpread
+		}
+		return fi.Feature < fj.Feature
+	})
+	for i := range a.Features {
+		a.Features[i].Rank = i + 1
+	}
+	return a
+}
+
+// quantileEdges returns the distinct inner quantiles 1/bins .. (bins-1)/bins of
+// sorted, above its minimum: the lower bounds of every bin but the first.
+func quantileEdges(sorted []float64, bins int) []float64 {
+	var edges []float64
+	for k := 1; k < bins; k++ {
+		q := computePercentile(sorted, float64(k)/float64(bins))
+		if q <= sorted[0] || (len(edges) > 0 && q <= edges[len(edges)-1]) {
+			continue
+		}
+		edges = append(edges, q)
+	}
+	return edges
+}
+
+// featureObservation is the value of a feature on one trade.
+type featureObservation struct {
+	value float64
+	trade *domain.TradeRecord
+}
+
+// newFeatureBin summarizes the trades of one bin.
+func newFeatureBin(lower, upper float64, obs []featureObservation) FeatureBin {
+	b := FeatureBin{Lower: lower, Upper: upper, Trades: len(obs)}
+	outcomes := make([]float64, len(obs))
+	for i, o := range obs {
+		t := o.trade
+		if t.OutcomeClass == domain.OutcomeClassWin {
+			b.Wins++
+		}
+		outcomes[i] = t.Outcome
+	}
+	sort.Float64s(outcomes)
+	b.WinRate = computeWinRate(b.Wins, b.Trades)
+	b.MedianOutcome = computePercentile(outcomes, 0.5)
+	return b
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+// featureTrades returns n TIME_EXIT realistic trades. volume_1h is predictive:
+// trade i has volume i and wins iff i >= n/2. Token age, liquidity and swaps
+// prior are permutations of the trades unrelated to the outcome, swaps per
+// minute is constant.
+func featureTrades(n int) []*domain.TradeRecord {
+	trades := make([]*domain.TradeRecord, 0, n)
+	for i := 0; i < n; i++ {
+		outcome, class := -0.1-float64(i%3)/100, domain.OutcomeClassLoss
+		if i >= n/2 {
+			outcome, class = 0.2+float64(i%3)/100, domain.OutcomeClassWin
+		}
+		tr := makeTrade(fmt.Sprintf("t%03d", i), fmt.Sprintf("c%03d", i), "TIME_EXIT", domain.ScenarioRealistic, outcome, class, int64(i)*1000)
+		liquidity := float64((i * 13) % n)
+		tr.EntryContext = &domain.EntryContext{
+			Liquidity:      &liquidity,
+			Volume1h:       float64(i),
+			SwapsPerMinute: 1,
+			SwapsPrior:     (i * 17) % n,
+			TokenAgeMs:     int64((i*7)%n) * 60000,
+		}
+		trades = append(trades, tr)
+	}
+	return trades
+}
+
+func TestAnalyzeEntryFeatures_RanksPredictiveFeature(t *testing.T) {
+	trades := featureTrades(40)
+	// Other strategies and scenarios are not analyzed
+	trades = append(trades,
+		makeTrade("x1", "c001", "TRAILING_STOP", domain.ScenarioRealistic, 1, domain.OutcomeClassWin, 0),
+		makeTrade("x2", "c001", "TIME_EXIT", domain.ScenarioPessimistic, 1, domain.OutcomeClassWin, 0),
+	)
+	// Imbalance is known for every other trade
+	extra := FeatureValues{}
+	for i, tr := range trades[:40] {
+		if i%2 == 0 {
+			extra.Set(FeatureBuySellImbalance, tr.TradeID, float64(i%5)/5)
+		}
+	}
+
+	a := AnalyzeEntryFeatures(trades, "TIME_EXIT", domain.ScenarioRealistic, extra, 0)
+	if a.Trades != 40 || a.Bins != DefaultFeatureBins {
+		t.Fatalf("Trades = %d, Bins = %d", a.Trades, a.Bins)
+	}
+	if len(a.Features) != 6 {
+		t.Fatalf("expected 5 entry context features and the imbalance, got %d", len(a.Features))
+	}
+
+	top := a.Features[0]
+	if top.Feature != FeatureVolume1h || top.Rank != 1 || top.WinRateSpread != 1 {
+		t.Fatalf("expected %s ranked first with a full win rate spread, got %+v", FeatureVolume1h, top)
+	}
+	for _, f := range a.Features[1:] {
+		if f.WinRateSpread >= top.WinRateSpread {
+			t.Errorf("%s spread %.2f not below the predictive feature", f.Feature, f.WinRateSpread)
+		}
+	}
+
+	// Quartiles of 0..39: edges at the interpolated 25th, 50th and 75th percentiles
+	wantEdges := [][2]float64{{0, 9.75}, {9.75, 19.5}, {19.5, 29.25}, {29.25, 39}}
+	if len(top.Bins) != 4 {
+		t.Fatalf("bins = %+v", top.Bins)
+	}
+	for i, b := range top.Bins {
+		if b.Lower != wantEdges[i][0] || b.Upper != wantEdges[i][1] || b.Trades != 10 {
+			t.Errorf("bin %d = %+v, want [%v, %v) with 10 trades", i, b, wantEdges[i][0], wantEdges[i][1])
+		}
+	}
+	if top.Bins[0].WinRate != 0 || top.Bins[3].WinRate != 1 || math.Abs(top.Bins[3].MedianOutcome-0.21) > 1e-9 {
+		t.Errorf("unexpected outer bins %+v, %+v", top.Bins[0], top.Bins[3])
+	}
+
+	// A constant feature has a single bin; a partial feature counts its missing trades
+	for _, f := range a.Features {
+		switch f.Feature {
+		case FeatureSwapsPerMinute:
+			if len(f.Bins) != 1 || f.WinRateSpread != 0 || f.MedianSpread != 0 {
+				t.Errorf("constant feature: %+v", f)
+			}
+		case FeatureBuySellImbalance:
+			if f.Trades != 20 || f.Missing != 20 {
+				t.Errorf("imbalance: %d trades, %d missing", f.Trades, f.Missing)
+			}
+		}
+	}
+}
+
+func TestAnalyzeEntryFeatures_Deterministic(t *testing.T) {
+	trades := featureTrades(37)
+	reversed := make([]*domain.TradeRecord, len(trades))
+	for i, tr := range trades {
+		reversed[len(trades)-1-i] = tr
+	}
+	// Trades without an entry context are missing from every context feature
+	reversed = append(reversed, makeTrade("old", "c999", "TIME_EXIT", domain.ScenarioRealistic, 0.5, domain.OutcomeClassWin, 0))
+
+	a := AnalyzeEntryFeatures(append(trades, reversed[len(reversed)-1]), "TIME_EXIT", domain.ScenarioRealistic, nil, 5)
+	b := AnalyzeEntryFeatures(reversed, "TIME_EXIT", domain.ScenarioRealistic, nil, 5)
+	if fmt.Sprintf("%+v", a) != fmt.Sprintf("%+v", b) {
+		t.Errorf("analysis depends on the trade order:\n%+v\n%+v", a, b)
+	}
+	if a.Features[0].Missing != 1 || len(a.Features[0].Bins) != 5 {
+		t.Errorf("unexpected top feature %+v", a.Features[0])
+	}
+
+	if empty := AnalyzeEntryFeatures(nil, "TIME_EXIT", domain.ScenarioRealistic, nil, 4); empty.Trades != 0 || len(empty.Features) != 0 {
+		t.Errorf("expected an empty analysis, got %+v", empty)
+	}
+}
+
+func TestBuySellImbalanceAndHolderConcentration(t *testing.T) {
+	const signal = int64(10_000_000)
+	swaps := []*domain.Swap{
+		{Timestamp: signal - 3_600_000, Side: domain.SwapSideSell}, // outside the hour
+		{Timestamp: signal - 60_000, Side: domain.SwapSideBuy},
+		{Timestamp: signal - 30_000, Side: domain.SwapSideBuy},
+		{Timestamp: signal, Side: domain.SwapSideSell},
+		{Timestamp: signal + 1, Side: domain.SwapSideSell}, // after the signal
+	}
+	if v, ok := BuySellImbalance(swaps, signal); !ok || math.Abs(v-1.0/3) > 1e-12 {
+		t.Errorf("imbalance = %v, %v; want 1/3", v, ok)
+	}
+	if _, ok := BuySellImbalance(swaps[:1], signal); ok {
+		t.Error("expected no imbalance without swaps in the window")
+	}
+
+	snapshots := []*domain.TokenHolderSnapshot{
+		{SnapshotAt: signal - 2000, Top10Pct: 80},
+		{SnapshotAt: signal - 1000, Top10Pct: 60},
+		{SnapshotAt: signal + 1000, Top10Pct: 40}, // after the signal
+	}
+	if v, ok := HolderConcentration(snapshots, signal); !ok || v != 60 {
+		t.Errorf("concentration = %v, %v; want 60", v, ok)
+	}
+	if _, ok := HolderConcentration(snapshots[2:], signal); ok {
+		t.Error("expected no concentration without an earlier snapshot")
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// LamportsPerSOL converts lamport amounts to SOL.
+const LamportsPerSOL = 1_000_000_000
+
+// FeePercentiles summarizes the fees paid by swap transactions in a time window.
+// Base fee is the total fee minus the priority fee. All amounts are in lamports.
+type FeePercentiles struct {
+	WindowStart  int64 // inclusive, Unix ms
+	WindowEnd    int64 // exclusive, Unix ms
+	Transactions int   // distinct transactions with fee data
+
+	BaseFeeP50     float64
+	BaseFeeP90     float64
+	PriorityFeeP50 float64
+	PriorityFeeP90 float64
+	PriorityFeeP99 float64
+	TotalFeeP50    float64
+	TotalFeeP90    float64
+	TotalFeeP99    float64
+}
+
+// ComputeFeePercentiles computes fee percentiles over swap events in [start, end).
+// Events without fee data are skipped, and a transaction with several swap events
+// counts once.
+func ComputeFeePercentiles(events []*domain.SwapEvent, start, end int64) FeePercentiles {
+	result := FeePercentiles{WindowStart: start, WindowEnd: end}
+
+	seen := make(map[string]struct{})
+	var base, priority, total []float64
+	for _, e := range events {
+		if e.Timestamp < start || e.Timestamp >= end || e.FeeLamports == nil {
+			continue
+		}
+		if _, ok := seen[e.TxSignature]; ok {
+			continue
+		}
+		seen[e.TxSignature] = struct{}{}
+
+		var prio int64
+		if e.PriorityFeeLamports != nil {
+			prio = *e.PriorityFeeLamports
+		}
+		fee := *e.FeeLamports
+		total = append(total, float64(fee))
+		priority = append(priority, float64(prio))
+		base = append(base, float64(max(fee-prio, 0)))
+	}
+
+	result.Transactions = len(total)
+	if result.Transactions == 0 {
+		return result
+	}
+
+	sort.Float64s(base)
+	sort.Float64s(priority)
+	sort.Float64s(total)
+
+	result.BaseFeeP50 = computePercentile(base, 0.50)
+	result.BaseFeeP90 = computePercentile(base, 0.90)
+	result.PriorityFeeP50 = computePercentile(priority, 0.50)
+	result.PriorityFeeP90 = computePercentile(priority, 0.90)
+	result.PriorityFeeP99 = computePercentile(priority, 0.99)
+	result.TotalFeeP50 = computePercentile(total, 0.50)
+	result.TotalFeeP90 = computePercentile(total, 0.90)
+	result.TotalFeeP99 = computePercentile(total, 0.99)
+	return result
+}
+
+// FeePercentilesForWindow loads swap events in [start, end) and computes their fee percentiles.
+func FeePercentilesForWindow(ctx context.Context, store storage.SwapEventStore, start, end int64) (FeePercentiles, error) {
+	events, err := store.GetByTimeRange(ctx, start, end)
+	if err != nil {
+		return FeePercentiles{}, err
+	}
+	return ComputeFeePercentiles(events, start, end), nil
+}
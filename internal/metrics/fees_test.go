@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func feeEvent(sig string, idx int, ts int64, fee, priority *int64) *domain.SwapEvent {
+	return &domain.SwapEvent{
+		Mint: "mint", TxSignature: sig, EventIndex: idx, Slot: ts / 400, Timestamp: ts, AmountOut: 1,
+		FeeLamports: fee, PriorityFeeLamports: priority,
+	}
+}
+
+func lamports(v int64) *int64 { return &v }
+
+func TestComputeFeePercentiles(t *testing.T) {
+	events := []*domain.SwapEvent{
+		feeEvent("tx1", 0, 1000, lamports(5000), lamports(0)),
+		feeEvent("tx1", 1, 1000, lamports(5000), lamports(0)), // same tx counted once
+		feeEvent("tx2", 0, 2000, lamports(15000), lamports(10000)),
+		feeEvent("tx3", 0, 3000, lamports(25000), lamports(20000)),
+		feeEvent("tx4", 0, 4000, nil, nil),                           // no telemetry
+		feeEvent("tx5", 0, 9000, lamports(999999), lamports(999999)), // outside window
+	}
+
+	got := ComputeFeePercentiles(events, 1000, 9000)
+
+	if got.Transactions != 3 {
+		t.Fatalf("expected 3 transactions, got %d", got.Transactions)
+	}
+	if got.TotalFeeP50 != 15000 {
+		t.Errorf("TotalFeeP50 = %v, want 15000", got.TotalFeeP50)
+	}
+	if got.PriorityFeeP50 != 10000 {
+		t.Errorf("PriorityFeeP50 = %v, want 10000", got.PriorityFeeP50)
+	}
+	if got.PriorityFeeP90 != 18000 {
+		t.Errorf("PriorityFeeP90 = %v, want 18000 (interpolated)", got.PriorityFeeP90)
+	}
+	if got.BaseFeeP50 != 5000 || got.BaseFeeP90 != 5000 {
+		t.Errorf("expected base fee 5000 at p50/p90, got %v/%v", got.BaseFeeP50, got.BaseFeeP90)
+	}
+	if got.WindowStart != 1000 || got.WindowEnd != 9000 {
+		t.Errorf("unexpected window [%d, %d)", got.WindowStart, got.WindowEnd)
+	}
+}
+
+func TestComputeFeePercentiles_NoTelemetry(t *testing.T) {
+	got := ComputeFeePercentiles([]*domain.SwapEvent{feeEvent("tx1", 0, 1000, nil, nil)}, 0, 2000)
+	if got.Transactions != 0 || got.TotalFeeP50 != 0 {
+		t.Errorf("expected empty percentiles, got %+v", got)
+	}
+}
+
+func TestFeePercentilesForWindow(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSwapEventStore()
+	if err := store.InsertBulk(ctx, []*domain.SwapEvent{
+		feeEvent("tx1", 0, 1000, lamports(5000), lamports(1000)),
+		feeEvent("tx2", 0, 5000, lamports(7000), lamports(2000)),
+	}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	got, err := FeePercentilesForWindow(ctx, store, 0, 2000)
+	if err != nil {
+		t.Fatalf("FeePercentilesForWindow: %v", err)
+	}
+	if got.Transactions != 1 || got.TotalFeeP50 != 5000 || got.PriorityFeeP50 != 1000 {
+		t.Errorf("unexpected percentiles: %+v", got)
+	}
+}
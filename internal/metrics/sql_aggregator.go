@@ -0,0 +1,375 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/strategy"
+)
+
+// AggregateComputer computes and persists strategy aggregates.
+// Implemented by Aggregator (in-Go) and SQLAggregator (database-side).
+type AggregateComputer interface {
+	ComputeAggregate(ctx context.Context, strategyID, scenarioID, entryEventType string) (*domain.StrategyAggregate, error)
+	ComputeAndStore(ctx context.Context, strategyID, scenarioID, entryEventType string) (*domain.StrategyAggregate, error)
+	GetMissingCandidateErrors() []string
+	ResetErrors()
+}
+
+// Compile-time interface checks.
+var (
+	_ AggregateComputer = (*Aggregator)(nil)
+	_ AggregateComputer = (*SQLAggregator)(nil)
+)
+
+// mirrorBatchSize bounds the number of trades written per mirror batch.
+const mirrorBatchSize = 10000
+
+// SQLAggregator computes strategy aggregates with SQL over a mirrored
+// trade_records table instead of loading every trade into memory.
+//
+// Trades must be mirrored with Replicate or Sync before aggregation. Candidate
+// sources are resolved at replication time, so missing and soft-deleted
+// candidates are tracked there rather than during ComputeAggregate. Trades
+// mirrored before their candidate was soft-deleted stay in the mirror.
+type SQLAggregator struct {
+	tradeRecordStore storage.TradeRecordStore
+	tradeAggStore    storage.TradeAggregateStore
+	strategyAggStore storage.StrategyAggregateStore
+	candidateStore   storage.CandidateStore
+	closedOnly       bool  // mirror only trades of CLOSED candidates
+	syncOverlapMs    int64 // see WithSyncOverlap
+
+	// missingCandidates tracks trades whose candidate was not found during replication.
+	// Key: candidate_id, Value: count of trades referencing it.
+	mu                sync.Mutex
+	missingCandidates map[string]int
+	deletedCandidates map[string]int // trades of soft-deleted candidates not mirrored
+}
+
+// NewSQLAggregator creates a new database-side metrics aggregator.
+func NewSQLAggregator(
+	tradeStore storage.TradeRecordStore,
+	tradeAggStore storage.TradeAggregateStore,
+	aggStore storage.StrategyAggregateStore,
+	candidateStore storage.CandidateStore,
+) *SQLAggregator {
+	return &SQLAggregator{
+		tradeRecordStore:  tradeStore,
+		tradeAggStore:     tradeAggStore,
+		strategyAggStore:  aggStore,
+		candidateStore:    candidateStore,
+		syncOverlapMs:     DefaultSyncOverlapMs,
+		missingCandidates: make(map[string]int),
+		deletedCandidates: make(map[string]int),
+	}
+}
+
+// WithClosedOnly restricts replication, and so every aggregate, to trades of
+// CLOSED candidates. Trades of open candidates are mirrored by the first
+// Replicate after their candidate closes.
+func (a *SQLAggregator) WithClosedOnly(closedOnly bool) *SQLAggregator {
+	a.closedOnly = closedOnly
+	return a
+}
+
+// Replicate mirrors all trade records into the aggregate store, tagged with
+// canonical strategy type and entry event type. Trades are read in pages of
+// mirrorBatchSize in write order, so memory stays bounded by one page. Safe to
+// re-run: the mirror collapses repeated trade_ids, including a trade rewritten
+// during the run and read again on a later page. Returns the number of trades
+// mirrored. Missing and deleted candidates are rebuilt on each call. The sync
+// watermark is left alone; see Sync for incremental mirroring.
+func (a *SQLAggregator) Replicate(ctx context.Context) (int, error) {
+	tagger := a.newMirrorTagger()
+	defer a.setCandidateErrors(tagger)
+
+	var cursor storage.TradeCursor
+	mirrored := 0
+	for {
+		page, err := a.tradeRecordStore.GetChangedSince(ctx, cursor, mirrorBatchSize)
+		if err != nil {
+			return mirrored, err
+		}
+		if len(page) == 0 {
+			return mirrored, nil
+		}
+
+		batch := make([]*storage.MirroredTrade, 0, len(page))
+		for _, c := range page {
+			m, _, err := tagger.tag(ctx, c.Trade)
+			if err != nil {
+				return mirrored, err
+			}
+			if m != nil {
+				batch = append(batch, m)
+			}
+		}
+		if err := a.tradeAggStore.InsertMirrored(ctx, batch); err != nil {
+			return mirrored, err
+		}
+		mirrored += len(batch)
+		cursor = page[len(page)-1].Cursor()
+	}
+}
+
+// DefaultSyncOverlapMs is how far before its watermark Sync starts reading. A
+// trade whose write committed after a later write was synced still has an
+// updated_at inside the overlap, so the next sync picks it up; re-mirroring
+// the other trades of the overlap is idempotent.
+const DefaultSyncOverlapMs = int64(60 * 1000)
+
+// TradeSyncResult reports one Sync and reconciles the row counts of the
+// trade record store and the mirror.
+type TradeSyncResult struct {
+	From      int64 `json:"from"`      // updated_at (Unix ms) the sync read from: the previous watermark minus the overlap
+	Watermark int64 `json:"watermark"` // updated_at the mirror is now complete up to
+	Read      int   `json:"read"`      // trades written since From
+	Mirrored  int   `json:"mirrored"`  // trades written to the mirror
+	Pending   int   `json:"pending"`   // trades of open (closed-only) or missing candidates, mirrored by a later sync
+	Deleted   int   `json:"deleted"`   // trades of soft-deleted candidates, not mirrored
+
+	SourceTrades int `json:"source_trades"` // trades in the trade record store
+	MirrorTrades int `json:"mirror_trades"` // distinct trades in the mirror
+}
+
+// Unreconciled returns the stored trades neither mirrored nor pending. Trades
+// of candidates soft-deleted before they were mirrored account for a positive
+// value; a negative one means the mirror holds trades the store no longer has.
+func (r *TradeSyncResult) Unreconciled() int {
+	return r.SourceTrades - r.MirrorTrades - r.Pending
+}
+
+// WithSyncOverlap sets how far before the watermark Sync starts reading
+// (default DefaultSyncOverlapMs).
+func (a *SQLAggregator) WithSyncOverlap(overlapMs int64) *SQLAggregator {
+	a.syncOverlapMs = overlapMs
+	return a
+}
+
+// Sync mirrors the trades written (inserted or upserted) since the watermark
+// of the last sync, so re-simulated trades replace their mirrored copy. The
+// watermark stays at the first pending trade, so trades of candidates still
+// open or missing are read again until they can be mirrored. Missing and
+// deleted candidates are those of the trades read by this sync.
+func (a *SQLAggregator) Sync(ctx context.Context) (*TradeSyncResult, error) {
+	watermark, err := a.tradeAggStore.GetSyncWatermark(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &TradeSyncResult{From: max(watermark-a.syncOverlapMs, 0), Watermark: watermark}
+	tagger := a.newMirrorTagger()
+	defer a.setCandidateErrors(tagger)
+
+	cursor := storage.TradeCursor{UpdatedAt: result.From}
+	pendingFrom := int64(-1)
+	for {
+		page, err := a.tradeRecordStore.GetChangedSince(ctx, cursor, mirrorBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		batch := make([]*storage.MirroredTrade, 0, len(page))
+		for _, c := range page {
+			m, skip, err := tagger.tag(ctx, c.Trade)
+			if err != nil {
+				return result, err
+			}
+			result.Read++
+			switch {
+			case m != nil:
+				batch = append(batch, m)
+			case skip == mirrorPending:
+				result.Pending++
+				if pendingFrom < 0 {
+					pendingFrom = c.UpdatedAt
+				}
+			default:
+				result.Deleted++
+			}
+			if pendingFrom < 0 {
+				result.Watermark = max(result.Watermark, c.UpdatedAt)
+			}
+		}
+		if err := a.tradeAggStore.InsertMirrored(ctx, batch); err != nil {
+			return result, err
+		}
+		result.Mirrored += len(batch)
+		cursor = page[len(page)-1].Cursor()
+	}
+	if pendingFrom >= 0 {
+		result.Watermark = pendingFrom
+	}
+
+	if err := a.tradeAggStore.SetSyncWatermark(ctx, result.Watermark); err != nil {
+		return result, err
+	}
+	if result.SourceTrades, err = a.tradeRecordStore.Count(ctx); err != nil {
+		return result, err
+	}
+	if result.MirrorTrades, err = a.tradeAggStore.CountMirrored(ctx); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// mirrorSkip tells why a trade was not mirrored.
+type mirrorSkip int
+
+const (
+	mirrorPending mirrorSkip = iota + 1 // candidate open (closed-only) or missing
+	mirrorDeleted                       // candidate soft-deleted
+)
+
+// mirrorTagger tags the trades of one Replicate or Sync, resolving each
+// candidate once.
+type mirrorTagger struct {
+	a         *SQLAggregator
+	sources   map[string]domain.Source
+	open      map[string]bool
+	isDeleted map[string]bool
+	missing   map[string]int // trades per missing candidate
+	deleted   map[string]int // trades per soft-deleted candidate
+}
+
+func (a *SQLAggregator) newMirrorTagger() *mirrorTagger {
+	return &mirrorTagger{
+		a:         a,
+		sources:   make(map[string]domain.Source),
+		open:      make(map[string]bool),
+		isDeleted: make(map[string]bool),
+		missing:   make(map[string]int),
+		deleted:   make(map[string]int),
+	}
+}
+
+// tag returns t tagged for the mirror, or nil and the reason it is left out.
+func (m *mirrorTagger) tag(ctx context.Context, t *domain.TradeRecord) (*storage.MirroredTrade, mirrorSkip, error) {
+	if m.open[t.CandidateID] {
+		return nil, mirrorPending, nil
+	}
+	if m.isDeleted[t.CandidateID] {
+		m.deleted[t.CandidateID]++
+		return nil, mirrorDeleted, nil
+	}
+	source, ok := m.sources[t.CandidateID]
+	if !ok {
+		candidate, err := m.a.candidateStore.GetByID(ctx, t.CandidateID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				m.missing[t.CandidateID]++
+				return nil, mirrorPending, nil
+			}
+			return nil, 0, err
+		}
+		if candidate.IsDeleted() {
+			m.isDeleted[t.CandidateID] = true
+			m.deleted[t.CandidateID]++
+			return nil, mirrorDeleted, nil
+		}
+		if m.a.closedOnly && !candidate.IsClosed() {
+			m.open[t.CandidateID] = true
+			return nil, mirrorPending, nil
+		}
+		source = candidate.Source
+		m.sources[t.CandidateID] = source
+	}
+
+	return &storage.MirroredTrade{
+		Trade:          t,
+		StrategyType:   strategy.CanonicalType(t.StrategyID),
+		EntryEventType: string(source),
+	}, 0, nil
+}
+
+// setCandidateErrors replaces the missing and deleted candidates with those of tagger.
+func (a *SQLAggregator) setCandidateErrors(tagger *mirrorTagger) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.missingCandidates = tagger.missing
+	a.deletedCandidates = tagger.deleted
+}
+
+// ComputeAggregate computes aggregate for a specific (strategy_id, scenario_id, entry_event_type).
+// Strategy matching follows Aggregator: exact strategy_id first, then canonical base type.
+// Returns ErrNoTrades if no trades match the criteria.
+func (a *SQLAggregator) ComputeAggregate(ctx context.Context, strategyID, scenarioID, entryEventType string) (*domain.StrategyAggregate, error) {
+	exact, err := a.tradeAggStore.CountByStrategyScenario(ctx, strategyID, scenarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	agg, err := a.tradeAggStore.ComputeAggregate(ctx, strategyID, scenarioID, entryEventType, exact == 0)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNoTrades
+		}
+		return nil, err
+	}
+
+	agg.StrategyID = strategyID
+	agg.ScenarioID = scenarioID
+	agg.EntryEventType = entryEventType
+	setSensitivityFields(agg)
+
+	return agg, nil
+}
+
+// GetMissingCandidateErrors returns data quality errors for candidates missing during replication.
+func (a *SQLAggregator) GetMissingCandidateErrors() []string {
+	return formatMissingCandidates(a.MissingCandidates())
+}
+
+// MissingCandidates returns a copy of the missing candidate counts from the last Replicate.
+func (a *SQLAggregator) MissingCandidates() map[string]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	counts := make(map[string]int, len(a.missingCandidates))
+	for candidateID, n := range a.missingCandidates {
+		counts[candidateID] = n
+	}
+	return counts
+}
+
+// DeletedCandidates returns a copy of the soft-deleted candidates whose trades
+// the last Replicate did not mirror, with their trade counts.
+func (a *SQLAggregator) DeletedCandidates() map[string]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	counts := make(map[string]int, len(a.deletedCandidates))
+	for candidateID, n := range a.deletedCandidates {
+		counts[candidateID] = n
+	}
+	return counts
+}
+
+// ResetErrors clears the missing and deleted candidates collected by the last Replicate.
+func (a *SQLAggregator) ResetErrors() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.missingCandidates = make(map[string]int)
+	a.deletedCandidates = make(map[string]int)
+}
+
+// ComputeAndStore computes and persists aggregate.
+// Returns storage.ErrDuplicateKey if aggregate already exists (append-only).
+func (a *SQLAggregator) ComputeAndStore(ctx context.Context, strategyID, scenarioID, entryEventType string) (*domain.StrategyAggregate, error) {
+	agg, err := a.ComputeAggregate(ctx, strategyID, scenarioID, entryEventType)
+	if err != nil {
+		return nil, err
+	}
+	agg.ComputedAt = time.Now().UnixMilli()
+
+	if err := a.strategyAggStore.Insert(ctx, agg); err != nil {
+		return nil, err
+	}
+
+	return agg, nil
+}
@@ -0,0 +1,349 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// fakeTradeAggStore records mirrored trades and answers aggregate queries
+// with the in-Go computation, so SQLAggregator wiring can be tested without ClickHouse.
+type fakeTradeAggStore struct {
+	mirrored  map[string]*storage.MirroredTrade
+	lastMatch bool
+	watermark int64
+}
+
+func newFakeTradeAggStore() *fakeTradeAggStore {
+	return &fakeTradeAggStore{mirrored: make(map[string]*storage.MirroredTrade)}
+}
+
+func (f *fakeTradeAggStore) InsertMirrored(_ context.Context, trades []*storage.MirroredTrade) error {
+	for _, m := range trades {
+		f.mirrored[m.Trade.TradeID] = m
+	}
+	return nil
+}
+
+func (f *fakeTradeAggStore) CountByStrategyScenario(_ context.Context, strategyID, scenarioID string) (int, error) {
+	count := 0
+	for _, m := range f.mirrored {
+		if m.Trade.StrategyID == strategyID && m.Trade.ScenarioID == scenarioID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeTradeAggStore) CountMirrored(context.Context) (int, error) {
+	return len(f.mirrored), nil
+}
+
+func (f *fakeTradeAggStore) GetSyncWatermark(context.Context) (int64, error) {
+	return f.watermark, nil
+}
+
+func (f *fakeTradeAggStore) SetSyncWatermark(_ context.Context, watermark int64) error {
+	f.watermark = watermark
+	return nil
+}
+
+func (f *fakeTradeAggStore) ComputeAggregate(_ context.Context, strategyID, scenarioID, entryEventType string, matchStrategyType bool) (*domain.StrategyAggregate, error) {
+	f.lastMatch = matchStrategyType
+	var trades []*domain.TradeRecord
+	for _, m := range f.mirrored {
+		key := m.Trade.StrategyID
+		if matchStrategyType {
+			key = m.StrategyType
+		}
+		if key == strategyID && m.Trade.ScenarioID == scenarioID && m.EntryEventType == entryEventType {
+			trades = append(trades, m.Trade)
+		}
+	}
+	if len(trades) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return computeFromTrades(trades, entryEventType), nil
+}
+
+func TestSQLAggregator_ReplicateTagsTrades(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	fake := newFakeTradeAggStore()
+
+	_ = candidateStore.Insert(ctx, makeCandidate("c1", domain.SourceNewToken))
+	_ = candidateStore.Insert(ctx, makeCandidate("c2", domain.SourceActiveToken))
+
+	trades := []*domain.TradeRecord{
+		makeTrade("t1", "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, 1000),
+		makeTrade("t2", "c2", "TRAILING_STOP_ACTIVE_TOKEN", domain.ScenarioRealistic, -0.1, domain.OutcomeClassLoss, 2000),
+		makeTrade("t3", "missing", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.2, domain.OutcomeClassWin, 3000),
+	}
+	if err := tradeStore.InsertBulk(ctx, trades); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	agg := NewSQLAggregator(tradeStore, fake, memory.NewStrategyAggregateStore(), candidateStore)
+	n, err := agg.Replicate(ctx)
+	if err != nil {
+		t.Fatalf("Replicate failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 mirrored trades, got %d", n)
+	}
+
+	if m := fake.mirrored["t1"]; m == nil || m.StrategyType != domain.StrategyTypeTimeExit || m.EntryEventType != "NEW_TOKEN" {
+		t.Errorf("t1 not tagged correctly: %+v", m)
+	}
+	if m := fake.mirrored["t2"]; m == nil || m.StrategyType != domain.StrategyTypeTrailingStop || m.EntryEventType != "ACTIVE_TOKEN" {
+		t.Errorf("t2 not tagged correctly: %+v", m)
+	}
+
+	// Re-running rebuilds missing candidate tracking instead of accumulating.
+	if _, err := agg.Replicate(ctx); err != nil {
+		t.Fatalf("Replicate failed: %v", err)
+	}
+	errs := agg.GetMissingCandidateErrors()
+	if len(errs) != 1 || errs[0] != "missing candidate missing referenced by 1 trade(s)" {
+		t.Errorf("unexpected missing candidate errors: %v", errs)
+	}
+}
+
+func TestSQLAggregator_ReplicateSkipsDeletedCandidates(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	fake := newFakeTradeAggStore()
+
+	_ = candidateStore.Insert(ctx, makeCandidate("c1", domain.SourceNewToken))
+	_ = candidateStore.Insert(ctx, makeCandidate("junk", domain.SourceNewToken))
+	if err := candidateStore.SoftDelete(ctx, &storage.CandidateDeletion{CandidateID: "junk", DeletedAt: 1, Reason: "test token"}); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	trades := []*domain.TradeRecord{
+		makeTrade("t1", "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, 1000),
+		makeTrade("t2", "junk", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 5, domain.OutcomeClassWin, 2000),
+		makeTrade("t3", "junk", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioPessimistic, 4, domain.OutcomeClassWin, 2000),
+	}
+	if err := tradeStore.InsertBulk(ctx, trades); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	agg := NewSQLAggregator(tradeStore, fake, memory.NewStrategyAggregateStore(), candidateStore)
+	n, err := agg.Replicate(ctx)
+	if err != nil {
+		t.Fatalf("Replicate failed: %v", err)
+	}
+	if n != 1 || fake.mirrored["t2"] != nil || fake.mirrored["t3"] != nil {
+		t.Errorf("expected only t1 mirrored, got %d: %v", n, fake.mirrored)
+	}
+	if deleted := agg.DeletedCandidates(); len(deleted) != 1 || deleted["junk"] != 2 {
+		t.Errorf("expected junk with 2 excluded trades, got %v", deleted)
+	}
+	if errs := agg.GetMissingCandidateErrors(); len(errs) != 0 {
+		t.Errorf("deleted candidates are not missing: %v", errs)
+	}
+}
+
+// pagedTradeStore fails GetAll and records the pages read with GetChangedSince.
+type pagedTradeStore struct {
+	*memory.TradeRecordStore
+	pages []int
+}
+
+func (s *pagedTradeStore) GetAll(context.Context) ([]*domain.TradeRecord, error) {
+	return nil, errors.New("GetAll must not be used to mirror trades")
+}
+
+func (s *pagedTradeStore) GetChangedSince(ctx context.Context, after storage.TradeCursor, limit int) ([]*storage.TradeChange, error) {
+	page, err := s.TradeRecordStore.GetChangedSince(ctx, after, limit)
+	s.pages = append(s.pages, len(page))
+	return page, err
+}
+
+func TestSQLAggregator_ReplicatePages(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := &pagedTradeStore{TradeRecordStore: memory.NewTradeRecordStore()}
+	fake := newFakeTradeAggStore()
+
+	_ = candidateStore.Insert(ctx, makeCandidate("c1", domain.SourceNewToken))
+	trades := make([]*domain.TradeRecord, mirrorBatchSize+1)
+	for i := range trades {
+		trades[i] = makeTrade(fmt.Sprintf("t%05d", i), "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, int64(i))
+	}
+	if err := tradeStore.InsertBulk(ctx, trades); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	agg := NewSQLAggregator(tradeStore, fake, memory.NewStrategyAggregateStore(), candidateStore)
+	n, err := agg.Replicate(ctx)
+	if err != nil {
+		t.Fatalf("Replicate failed: %v", err)
+	}
+	if n != len(trades) || len(fake.mirrored) != len(trades) {
+		t.Errorf("expected %d mirrored trades, got %d (%d in mirror)", len(trades), n, len(fake.mirrored))
+	}
+	if want := []int{mirrorBatchSize, 1, 0}; fmt.Sprint(tradeStore.pages) != fmt.Sprint(want) {
+		t.Errorf("expected pages %v, got %v", want, tradeStore.pages)
+	}
+}
+
+func TestSQLAggregator_StrategyMatching(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	fake := newFakeTradeAggStore()
+
+	_ = candidateStore.Insert(ctx, makeCandidate("c1", domain.SourceNewToken))
+	_ = tradeStore.Insert(ctx, makeTrade("t1", "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, 1000))
+
+	agg := NewSQLAggregator(tradeStore, fake, memory.NewStrategyAggregateStore(), candidateStore)
+	if _, err := agg.Replicate(ctx); err != nil {
+		t.Fatalf("Replicate failed: %v", err)
+	}
+
+	// Base type: no exact strategy_id match, falls back to canonical type
+	got, err := agg.ComputeAggregate(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("ComputeAggregate failed: %v", err)
+	}
+	if !fake.lastMatch {
+		t.Error("expected canonical strategy type matching")
+	}
+	if got.StrategyID != domain.StrategyTypeTimeExit || got.TotalTrades != 1 {
+		t.Errorf("unexpected aggregate: %+v", got)
+	}
+	if got.OutcomeRealistic == nil || *got.OutcomeRealistic != got.OutcomeMean {
+		t.Error("expected OutcomeRealistic to be set for REALISTIC scenario")
+	}
+
+	// Exact strategy_id
+	if _, err := agg.ComputeAggregate(ctx, "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, "NEW_TOKEN"); err != nil {
+		t.Fatalf("ComputeAggregate failed: %v", err)
+	}
+	if fake.lastMatch {
+		t.Error("expected exact strategy_id matching")
+	}
+
+	// No trades for entry type
+	_, err = agg.ComputeAggregate(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic, "ACTIVE_TOKEN")
+	if !errors.Is(err, ErrNoTrades) {
+		t.Errorf("expected ErrNoTrades, got %v", err)
+	}
+}
+
+func TestSQLAggregator_SyncMirrorsChangedTrades(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	fake := newFakeTradeAggStore()
+
+	_ = candidateStore.Insert(ctx, makeCandidate("c1", domain.SourceNewToken))
+	_ = candidateStore.Insert(ctx, makeCandidate("junk", domain.SourceNewToken))
+	if err := candidateStore.SoftDelete(ctx, &storage.CandidateDeletion{CandidateID: "junk", DeletedAt: 1, Reason: "test token"}); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+	if err := tradeStore.InsertBulk(ctx, []*domain.TradeRecord{
+		makeTrade("t1", "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, 1000),
+		makeTrade("t2", "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, -0.1, domain.OutcomeClassLoss, 2000),
+		makeTrade("t3", "junk", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 5, domain.OutcomeClassWin, 3000),
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	agg := NewSQLAggregator(tradeStore, fake, memory.NewStrategyAggregateStore(), candidateStore).WithSyncOverlap(0)
+	first, err := agg.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if first.Mirrored != 2 || first.Deleted != 1 || first.Pending != 0 || first.Watermark == 0 || fake.watermark != first.Watermark {
+		t.Errorf("unexpected first sync: %+v (stored watermark %d)", first, fake.watermark)
+	}
+	// t3 was never mirrored: its candidate was deleted first
+	if first.SourceTrades != 3 || first.MirrorTrades != 2 || first.Unreconciled() != 1 {
+		t.Errorf("unexpected reconciliation: %+v", first)
+	}
+
+	// A later write replaces the mirrored copy
+	time.Sleep(2 * time.Millisecond)
+	edited := makeTrade("t2", "c1", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.3, domain.OutcomeClassWin, 2000)
+	if err := tradeStore.Upsert(ctx, edited); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	second, err := agg.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if second.From != first.Watermark || second.Watermark <= first.Watermark {
+		t.Errorf("expected a sync from %d past it, got %+v", first.Watermark, second)
+	}
+	if m := fake.mirrored["t2"]; m == nil || m.Trade.Outcome != 0.3 {
+		t.Errorf("expected the edited t2 mirrored, got %+v", m)
+	}
+	if second.MirrorTrades != 2 || second.Unreconciled() != 1 {
+		t.Errorf("unexpected reconciliation: %+v", second)
+	}
+
+	// Nothing written since: nothing read past the watermark
+	time.Sleep(2 * time.Millisecond)
+	third, err := agg.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if third.Watermark != second.Watermark {
+		t.Errorf("expected the watermark to stay at %d, got %+v", second.Watermark, third)
+	}
+}
+
+func TestSQLAggregator_SyncKeepsPendingTrades(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	fake := newFakeTradeAggStore()
+
+	_ = candidateStore.Insert(ctx, makeCandidate("open", domain.SourceNewToken))
+	if err := tradeStore.Insert(ctx, makeTrade("t1", "open", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.1, domain.OutcomeClassWin, 1000)); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if err := tradeStore.Insert(ctx, makeTrade("t2", "missing", "TIME_EXIT_NEW_TOKEN_300000ms", domain.ScenarioRealistic, 0.2, domain.OutcomeClassWin, 2000)); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	agg := NewSQLAggregator(tradeStore, fake, memory.NewStrategyAggregateStore(), candidateStore).WithClosedOnly(true)
+	first, err := agg.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	changes, _ := tradeStore.GetChangedSince(ctx, storage.TradeCursor{}, 10)
+	if first.Mirrored != 0 || first.Pending != 2 || first.Watermark != changes[0].UpdatedAt {
+		t.Errorf("expected both trades pending and the watermark at t1, got %+v", first)
+	}
+	if first.Unreconciled() != 0 {
+		t.Errorf("pending trades are reconciled, got %+v", first)
+	}
+	if errs := agg.GetMissingCandidateErrors(); len(errs) != 1 {
+		t.Errorf("expected the missing candidate reported, got %v", errs)
+	}
+
+	// Closing the candidate does not rewrite its trades; the pinned watermark still reaches them
+	if err := candidateStore.Close(ctx, "open", 5000, "fingerprint"); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	second, err := agg.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if second.Mirrored != 1 || second.Pending != 1 || fake.mirrored["t1"] == nil || second.Watermark != changes[1].UpdatedAt {
+		t.Errorf("expected t1 mirrored and the watermark at t2, got %+v", second)
+	}
+}
@@ -0,0 +1,104 @@
+package normalization
+
+import (
+	"math"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+)
+
+// Decimals sources recorded on NormalizationState.
+const (
+	DecimalsSourceMetadata = "metadata" // token_metadata.decimals
+	DecimalsSourceInferred = "inferred" // InferDecimals over raw swap amounts
+)
+
+// DecimalsConfidence grades an inferred decimals value.
+type DecimalsConfidence string
+
+const (
+	DecimalsConfidenceHigh DecimalsConfidence = "high"
+	DecimalsConfidenceLow  DecimalsConfidence = "low"
+)
+
+// DefaultInferredDecimals is assumed when there are no raw amounts to infer from.
+const DefaultInferredDecimals = 6
+
+// Bounds on log10 of the median raw token amount per swap.
+// Typical trades move 10^3..10^7 whole tokens, i.e. raw 10^9..10^13 for
+// 6-decimal tokens and 10^12..10^16 for 9-decimal tokens. Medians between the
+// bounds fall into the overlap and are graded low confidence.
+const (
+	decimals6MaxLog10    = 11.5
+	decimals9MinLog10    = 13.5
+	decimalsSplitLog10   = 12.5 // overlap midpoint: below leans 6, at or above leans 9
+	pumpFunTokenDecimals = 6
+)
+
+// DecimalsInference is the result of InferDecimals.
+type DecimalsInference struct {
+	Decimals   int
+	Confidence DecimalsConfidence
+}
+
+// NormalizationState records how a candidate was normalized.
+type NormalizationState struct {
+	CandidateID    string
+	Decimals       int
+	DecimalsSource string             // DecimalsSourceMetadata | DecimalsSourceInferred
+	Confidence     DecimalsConfidence // high for metadata; graded for inferred
+}
+
+// Inferred reports whether decimals came from the heuristic rather than metadata.
+func (s NormalizationState) Inferred() bool {
+	return s.DecimalsSource == DecimalsSourceInferred
+}
+
+// InferDecimals estimates token decimals from raw swap amounts when metadata is unavailable.
+// pump.fun tokens are always minted with 6 decimals. Otherwise the median raw token
+// amount (AmountOut for buys, AmountIn for sells) is compared against the magnitudes
+// typical of 6- and 9-decimal tokens. The result depends only on the multiset of
+// amounts, so it is deterministic regardless of swap order.
+func InferDecimals(swaps []*domain.Swap) DecimalsInference {
+	amounts := make([]float64, 0, len(swaps))
+	pumpFun := len(swaps) > 0
+	for _, s := range swaps {
+		if s.DEX != domain.DEXPumpFun {
+			pumpFun = false
+		}
+		amount := s.AmountOut
+		if s.Side == domain.SwapSideSell {
+			amount = s.AmountIn
+		}
+		if amount > 0 {
+			amounts = append(amounts, amount)
+		}
+	}
+
+	if pumpFun {
+		return DecimalsInference{Decimals: pumpFunTokenDecimals, Confidence: DecimalsConfidenceHigh}
+	}
+	if len(amounts) == 0 {
+		return DecimalsInference{Decimals: DefaultInferredDecimals, Confidence: DecimalsConfidenceLow}
+	}
+
+	sort.Float64s(amounts)
+	var median float64
+	if n := len(amounts); n%2 == 1 {
+		median = amounts[n/2]
+	} else {
+		median = (amounts[n/2-1] + amounts[n/2]) / 2
+	}
+	magnitude := math.Log10(median)
+
+	switch {
+	case magnitude < decimals6MaxLog10:
+		return DecimalsInference{Decimals: 6, Confidence: DecimalsConfidenceHigh}
+	case magnitude >= decimals9MinLog10:
+		return DecimalsInference{Decimals: 9, Confidence: DecimalsConfidenceHigh}
+	case magnitude < decimalsSplitLog10:
+		return DecimalsInference{Decimals: 6, Confidence: DecimalsConfidenceLow}
+	default:
+		return DecimalsInference{Decimals: 9, Confidence: DecimalsConfidenceLow}
+	}
+}
@@ -204,3 +204,109 @@ func findPrevLiquidityEventTimestamp(timestamps []int64, target int64) *int64 {
 
 	return nil
 }
+
+// DefaultImbalanceWindowMs is the trailing window used for RollingImbalance.
+const DefaultImbalanceWindowMs int64 = 5 * 60 * 1000
+
+// sidedVolume holds cumulative buy/sell volume of a candidate's swaps in timestamp order.
+type sidedVolume struct {
+	timestamps []int64
+	buyPrefix  []float64 // buyPrefix[i] = buy volume of the first i swaps
+	sellPrefix []float64
+}
+
+// ApplyRollingImbalance sets RollingImbalance on each feature point to the
+// buy/sell imbalance of the candidate's swaps with timestamp in (t - windowMs, t].
+// Only swaps at or before t are used, so the feature has no lookahead.
+// Left NULL when the window holds no buy or sell volume.
+func ApplyRollingImbalance(features []*domain.DerivedFeaturePoint, swaps []*domain.Swap, windowMs int64) {
+	byCandidate := make(map[string][]*domain.Swap)
+	for _, s := range swaps {
+		if s.Side == domain.SwapSideBuy || s.Side == domain.SwapSideSell {
+			byCandidate[s.CandidateID] = append(byCandidate[s.CandidateID], s)
+		}
+	}
+
+	volumes := make(map[string]*sidedVolume, len(byCandidate))
+	for candidateID, candidateSwaps := range byCandidate {
+		sort.SliceStable(candidateSwaps, func(i, j int) bool {
+			return candidateSwaps[i].Timestamp < candidateSwaps[j].Timestamp
+		})
+		v := &sidedVolume{
+			timestamps: make([]int64, len(candidateSwaps)),
+			buyPrefix:  make([]float64, len(candidateSwaps)+1),
+			sellPrefix: make([]float64, len(candidateSwaps)+1),
+		}
+		for i, s := range candidateSwaps {
+			v.timestamps[i] = s.Timestamp
+			v.buyPrefix[i+1] = v.buyPrefix[i]
+			v.sellPrefix[i+1] = v.sellPrefix[i]
+			if s.Side == domain.SwapSideBuy {
+				v.buyPrefix[i+1] += s.AmountOut
+			} else {
+				v.sellPrefix[i+1] += s.AmountOut
+			}
+		}
+		volumes[candidateID] = v
+	}
+
+	for _, f := range features {
+		v, ok := volumes[f.CandidateID]
+		if !ok {
+			continue
+		}
+		hi := sort.Search(len(v.timestamps), func(i int) bool { return v.timestamps[i] > f.TimestampMs })
+		lo := sort.Search(len(v.timestamps), func(i int) bool { return v.timestamps[i] > f.TimestampMs-windowMs })
+		buy := v.buyPrefix[hi] - v.buyPrefix[lo]
+		sell := v.sellPrefix[hi] - v.sellPrefix[lo]
+		if buy+sell == 0 {
+			continue
+		}
+		imbalance := BuySellImbalance(buy, sell)
+		f.RollingImbalance = &imbalance
+	}
+}
+
+// liquidityPool identifies the pool of a liquidity event. pump.fun events carry
+// no pool address, so a bonding curve is identified by its DEX alone.
+type liquidityPool struct {
+	dex  string
+	pool string
+}
+
+// ApplyPoolChanges sets PoolChange on the first feature point at or after the
+// first liquidity event of each pool a candidate moved to, i.e. every pool but
+// the one of its first event. Events must be pre-sorted by canonical order.
+func ApplyPoolChanges(features []*domain.DerivedFeaturePoint, events []*domain.LiquidityEvent) {
+	seen := make(map[string]map[liquidityPool]bool)
+	changes := make(map[string][]int64)
+	for _, e := range events {
+		pools, ok := seen[e.CandidateID]
+		if !ok {
+			pools = make(map[liquidityPool]bool)
+			seen[e.CandidateID] = pools
+		}
+		p := liquidityPool{dex: e.DEX, pool: e.Pool}
+		if pools[p] {
+			continue
+		}
+		if len(pools) > 0 {
+			changes[e.CandidateID] = append(changes[e.CandidateID], e.Timestamp)
+		}
+		pools[p] = true
+	}
+
+	byCandidate := make(map[string][]*domain.DerivedFeaturePoint)
+	for _, f := range features {
+		byCandidate[f.CandidateID] = append(byCandidate[f.CandidateID], f)
+	}
+	for candidateID, times := range changes {
+		points := byCandidate[candidateID]
+		for _, t := range times {
+			i := sort.Search(len(points), func(i int) bool { return points[i].TimestampMs >= t })
+			if i < len(points) {
+				points[i].PoolChange = true
+			}
+		}
+	}
+}
@@ -20,6 +20,9 @@ type Runner struct {
 	liquidityTimeseriesStore storage.LiquidityTimeseriesStore
 	volumeTimeseriesStore    storage.VolumeTimeseriesStore
 	derivedFeatureStore      storage.DerivedFeatureStore
+
+	metadataStore storage.TokenMetadataStore    // optional, enables decimals resolution
+	states        map[string]NormalizationState // by candidate ID, set when metadataStore is
 }
 
 // NewRunner creates a new normalization runner.
@@ -40,3 +43,20 @@ func NewRunner(
 		derivedFeatureStore:      derivedFS,
 	}
 }
+
+// WithMetadataStore makes the runner resolve each candidate's token decimals.
+// Decimals come from token_metadata when present; candidates whose metadata is
+// missing (e.g. the fetch failed) fall back to InferDecimals over their swaps.
+// The outcome is available from State after normalization.
+func (r *Runner) WithMetadataStore(store storage.TokenMetadataStore) *Runner {
+	r.metadataStore = store
+	r.states = make(map[string]NormalizationState)
+	return r
+}
+
+// State returns the normalization state recorded for a candidate.
+// ok is false if the candidate was not normalized or no metadata store is set.
+func (r *Runner) State(candidateID string) (NormalizationState, bool) {
+	s, ok := r.states[candidateID]
+	return s, ok
+}
@@ -11,6 +11,9 @@ import (
 //   - liquidity = LAST(liquidity_after) by event order
 //   - liquidity_token = LAST(amount_token)
 //   - liquidity_quote = LAST(amount_quote)
+//
+// Measured liquidity is preferred: an estimated event never overrides a
+// measured one at the same timestamp.
 func GenerateLiquidityTimeseries(events []*domain.LiquidityEvent) []*domain.LiquidityTimeseriesPoint {
 	if len(events) == 0 {
 		return nil
@@ -18,6 +21,7 @@ func GenerateLiquidityTimeseries(events []*domain.LiquidityEvent) []*domain.Liqu
 
 	var result []*domain.LiquidityTimeseriesPoint
 	var current *domain.LiquidityTimeseriesPoint
+	var currentMeasured bool
 
 	for _, e := range events {
 		if current == nil || current.CandidateID != e.CandidateID || current.TimestampMs != e.Timestamp {
@@ -33,12 +37,16 @@ func GenerateLiquidityTimeseries(events []*domain.LiquidityEvent) []*domain.Liqu
 				LiquidityToken: e.AmountToken,
 				LiquidityQuote: e.AmountQuote,
 			}
+			currentMeasured = !e.Estimated
+		} else if e.Estimated && currentMeasured {
+			continue
 		} else {
 			// Aggregate into current point (LAST values)
 			current.Slot = e.Slot
 			current.Liquidity = e.LiquidityAfter
 			current.LiquidityToken = e.AmountToken
 			current.LiquidityQuote = e.AmountQuote
+			currentMeasured = !e.Estimated
 		}
 	}
 
@@ -2,6 +2,11 @@ package normalization
 
 import (
 	"context"
+	"errors"
+	"math"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
 )
 
 // NormalizeCandidate processes a single candidate and generates all timeseries and features.
@@ -24,6 +29,129 @@ func (r *Runner) NormalizeCandidate(ctx context.Context, candidateID string) err
 		return err
 	}
 
+	if err := r.resolveDecimals(ctx, candidateID, swaps); err != nil {
+		return err
+	}
+
+	return r.normalize(ctx, swaps, liquidityEvents)
+}
+
+// NormalizeCandidateUntil is NormalizeCandidate over the events with timestamp <= end,
+// e.g. the end of a candidate's observation window. Later events stay stored but
+// do not contribute to any timeseries.
+func (r *Runner) NormalizeCandidateUntil(ctx context.Context, candidateID string, end int64) error {
+	swaps, err := r.swapStore.GetByTimeRange(ctx, candidateID, math.MinInt64, end)
+	if err != nil {
+		return err
+	}
+
+	liquidityEvents, err := r.liquidityStore.GetByTimeRange(ctx, candidateID, math.MinInt64, end)
+	if err != nil {
+		return err
+	}
+
+	if err := r.resolveDecimals(ctx, candidateID, swaps); err != nil {
+		return err
+	}
+
+	return r.normalize(ctx, swaps, liquidityEvents)
+}
+
+// NormalizeCandidateMissing is NormalizeCandidateUntil for a candidate normalized
+// before: it stores only points at timestamps not stored yet, e.g. those of events
+// that arrived late. Points already stored are not recomputed, so volume buckets
+// and derived features covering both old and late events keep their old values.
+// Pass math.MaxInt64 as end without an observation window.
+func (r *Runner) NormalizeCandidateMissing(ctx context.Context, candidateID string, end int64) error {
+	swaps, err := r.swapStore.GetByTimeRange(ctx, candidateID, math.MinInt64, end)
+	if err != nil {
+		return err
+	}
+
+	liquidityEvents, err := r.liquidityStore.GetByTimeRange(ctx, candidateID, math.MinInt64, end)
+	if err != nil {
+		return err
+	}
+
+	if err := r.resolveDecimals(ctx, candidateID, swaps); err != nil {
+		return err
+	}
+
+	SortSwaps(swaps)
+	SortLiquidityEvents(liquidityEvents)
+
+	priceTS := GeneratePriceTimeseries(swaps)
+	liquidityTS := GenerateLiquidityTimeseries(liquidityEvents)
+	volumeTS := GenerateAllVolumeTimeseries(swaps)
+	derivedFeatures := ComputeDerivedFeatures(priceTS, liquidityTS)
+	ApplyRollingImbalance(derivedFeatures, swaps, DefaultImbalanceWindowMs)
+	ApplyPoolChanges(derivedFeatures, liquidityEvents)
+
+	storedPrices, err := r.priceTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return err
+	}
+	if points := missingPoints(priceTS, storedPrices, func(p *domain.PriceTimeseriesPoint) int64 { return p.TimestampMs }); len(points) > 0 {
+		if err := r.priceTimeseriesStore.InsertBulk(ctx, points); err != nil {
+			return err
+		}
+	}
+
+	storedLiquidity, err := r.liquidityTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return err
+	}
+	if points := missingPoints(liquidityTS, storedLiquidity, func(p *domain.LiquidityTimeseriesPoint) int64 { return p.TimestampMs }); len(points) > 0 {
+		if err := r.liquidityTimeseriesStore.InsertBulk(ctx, points); err != nil {
+			return err
+		}
+	}
+
+	storedVolume, err := r.volumeTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return err
+	}
+	type volumeKey struct {
+		timestampMs     int64
+		intervalSeconds int
+	}
+	volumeKeyOf := func(p *domain.VolumeTimeseriesPoint) volumeKey { return volumeKey{p.TimestampMs, p.IntervalSeconds} }
+	if points := missingPoints(volumeTS, storedVolume, volumeKeyOf); len(points) > 0 {
+		if err := r.volumeTimeseriesStore.InsertBulk(ctx, points); err != nil {
+			return err
+		}
+	}
+
+	storedFeatures, err := r.derivedFeatureStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return err
+	}
+	if points := missingPoints(derivedFeatures, storedFeatures, func(p *domain.DerivedFeaturePoint) int64 { return p.TimestampMs }); len(points) > 0 {
+		if err := r.derivedFeatureStore.InsertBulk(ctx, points); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// missingPoints returns the generated points whose key is not among the stored ones.
+func missingPoints[P any, K comparable](generated, stored []P, key func(P) K) []P {
+	have := make(map[K]bool, len(stored))
+	for _, p := range stored {
+		have[key(p)] = true
+	}
+	var missing []P
+	for _, p := range generated {
+		if !have[key(p)] {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+// normalize runs steps 2-6 of NormalizeCandidate over loaded events.
+func (r *Runner) normalize(ctx context.Context, swaps []*domain.Swap, liquidityEvents []*domain.LiquidityEvent) error {
 	// 2. Sort by canonical order
 	SortSwaps(swaps)
 	SortLiquidityEvents(liquidityEvents)
@@ -54,6 +182,8 @@ func (r *Runner) NormalizeCandidate(ctx context.Context, candidateID string) err
 
 	// 6. Compute derived features
 	derivedFeatures := ComputeDerivedFeatures(priceTS, liquidityTS)
+	ApplyRollingImbalance(derivedFeatures, swaps, DefaultImbalanceWindowMs)
+	ApplyPoolChanges(derivedFeatures, liquidityEvents)
 	if len(derivedFeatures) > 0 {
 		if err := r.derivedFeatureStore.InsertBulk(ctx, derivedFeatures); err != nil {
 			return err
@@ -63,6 +193,35 @@ func (r *Runner) NormalizeCandidate(ctx context.Context, candidateID string) err
 	return nil
 }
 
+// resolveDecimals records the candidate's decimals state when a metadata store is set.
+func (r *Runner) resolveDecimals(ctx context.Context, candidateID string, swaps []*domain.Swap) error {
+	if r.metadataStore == nil {
+		return nil
+	}
+
+	meta, err := r.metadataStore.GetByID(ctx, candidateID)
+	switch {
+	case err == nil:
+		r.states[candidateID] = NormalizationState{
+			CandidateID:    candidateID,
+			Decimals:       meta.Decimals,
+			DecimalsSource: DecimalsSourceMetadata,
+			Confidence:     DecimalsConfidenceHigh,
+		}
+	case errors.Is(err, storage.ErrNotFound):
+		inferred := InferDecimals(swaps)
+		r.states[candidateID] = NormalizationState{
+			CandidateID:    candidateID,
+			Decimals:       inferred.Decimals,
+			DecimalsSource: DecimalsSourceInferred,
+			Confidence:     inferred.Confidence,
+		}
+	default:
+		return err
+	}
+	return nil
+}
+
 // NormalizeBatch processes multiple candidates.
 func (r *Runner) NormalizeBatch(ctx context.Context, candidateIDs []string) error {
 	for _, candidateID := range candidateIDs {
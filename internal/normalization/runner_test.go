@@ -100,6 +100,28 @@ func TestGenerateLiquidityTimeseries_SameTimestamp(t *testing.T) {
 	}
 }
 
+func TestGenerateLiquidityTimeseries_PrefersMeasured(t *testing.T) {
+	events := []*domain.LiquidityEvent{
+		{CandidateID: "c1", Timestamp: 1000, Slot: 100, LiquidityAfter: 1000.0, AmountToken: 100.0, EventIndex: 0},
+		{CandidateID: "c1", Timestamp: 1000, Slot: 101, LiquidityAfter: 1200.0, AmountToken: 120.0, EventIndex: 1, Estimated: true},
+		{CandidateID: "c1", Timestamp: 2000, Slot: 200, LiquidityAfter: 900.0, AmountToken: 90.0, Estimated: true},
+		{CandidateID: "c1", Timestamp: 2000, Slot: 201, LiquidityAfter: 950.0, AmountToken: 95.0, Estimated: true},
+	}
+
+	result := GenerateLiquidityTimeseries(events)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 points, got %d", len(result))
+	}
+	if result[0].Liquidity != 1000.0 || result[0].Slot != 100 || result[0].LiquidityToken != 100.0 {
+		t.Errorf("Point 0: expected measured values, got %+v", result[0])
+	}
+	// Estimated-only timestamps keep LAST semantics
+	if result[1].Liquidity != 950.0 || result[1].Slot != 201 {
+		t.Errorf("Point 1: expected LAST estimated values, got %+v", result[1])
+	}
+}
+
 func TestGenerateVolumeTimeseries_IntervalAlignment(t *testing.T) {
 	// Test interval alignment: floor(timestamp_ms / interval_ms) * interval_ms
 	swaps := []*domain.Swap{
@@ -158,6 +180,108 @@ func TestGenerateVolumeTimeseries_BuySellSeparation(t *testing.T) {
 	}
 }
 
+func TestGenerateVolumeTimeseries_BuySellImbalance(t *testing.T) {
+	swaps := []*domain.Swap{
+		// Bucket 0: buy 30, sell 10 -> (30-10)/40 = 0.5
+		{CandidateID: "c1", Timestamp: 1000, AmountOut: 30.0, Side: domain.SwapSideBuy},
+		{CandidateID: "c1", Timestamp: 2000, AmountOut: 10.0, Side: domain.SwapSideSell},
+		// Bucket 60000: sells only -> -1; unsided volume does not dilute
+		{CandidateID: "c1", Timestamp: 61000, AmountOut: 5.0, Side: domain.SwapSideSell},
+		{CandidateID: "c1", Timestamp: 62000, AmountOut: 100.0},
+		// Bucket 120000: no sided volume -> 0
+		{CandidateID: "c1", Timestamp: 121000, AmountOut: 7.0},
+	}
+
+	result := GenerateVolumeTimeseries(swaps, 60)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 buckets, got %d", len(result))
+	}
+
+	want := []float64{0.5, -1, 0}
+	for i, w := range want {
+		if result[i].BuySellImbalance != w {
+			t.Errorf("Bucket %d: expected imbalance %v, got %v", result[i].TimestampMs, w, result[i].BuySellImbalance)
+		}
+	}
+}
+
+func TestApplyRollingImbalance(t *testing.T) {
+	swaps := []*domain.Swap{
+		{CandidateID: "c1", Timestamp: 1000, AmountOut: 10.0, Side: domain.SwapSideBuy},
+		{CandidateID: "c1", Timestamp: 2000, AmountOut: 30.0, Side: domain.SwapSideSell},
+		{CandidateID: "c1", Timestamp: 5000, AmountOut: 20.0, Side: domain.SwapSideBuy},
+		{CandidateID: "c2", Timestamp: 1000, AmountOut: 50.0}, // unsided
+	}
+	features := []*domain.DerivedFeaturePoint{
+		{CandidateID: "c1", TimestampMs: 1000}, // window (-2000, 1000]: buy 10
+		{CandidateID: "c1", TimestampMs: 2000}, // (-1000, 2000]: buy 10, sell 30
+		{CandidateID: "c1", TimestampMs: 5000}, // (2000, 5000]: buy 20; the sell at 2000 left the window
+		{CandidateID: "c1", TimestampMs: 9000}, // (6000, 9000]: nothing
+		{CandidateID: "c2", TimestampMs: 1000}, // no sided volume
+	}
+
+	ApplyRollingImbalance(features, swaps, 3000)
+
+	want := []*float64{ptrFloat(1), ptrFloat(-0.5), ptrFloat(1), nil, nil}
+	for i, w := range want {
+		got := features[i].RollingImbalance
+		switch {
+		case w == nil && got != nil:
+			t.Errorf("Feature %d: expected NULL imbalance, got %v", i, *got)
+		case w != nil && got == nil:
+			t.Errorf("Feature %d: expected imbalance %v, got NULL", i, *w)
+		case w != nil && *got != *w:
+			t.Errorf("Feature %d: expected imbalance %v, got %v", i, *w, *got)
+		}
+	}
+}
+
+// TestApplyPoolChanges follows a candidate from its pump.fun bonding curve to a
+// Raydium pool: one continuous liquidity series, marked where the pool changed.
+func TestApplyPoolChanges(t *testing.T) {
+	events := []*domain.LiquidityEvent{
+		{CandidateID: "c1", DEX: domain.DEXPumpFun, Slot: 1, Timestamp: 1000, LiquidityAfter: 40},
+		{CandidateID: "c1", DEX: domain.DEXPumpFun, Slot: 2, Timestamp: 5000, LiquidityAfter: 0},
+		{CandidateID: "c1", DEX: domain.DEXRaydium, Pool: "ray-pool", Slot: 2, Timestamp: 5000, LiquidityAfter: 80},
+		{CandidateID: "c1", DEX: domain.DEXRaydium, Pool: "ray-pool", Slot: 3, Timestamp: 7000, LiquidityAfter: 90},
+		{CandidateID: "c2", DEX: domain.DEXRaydium, Pool: "other", Slot: 1, Timestamp: 1000, LiquidityAfter: 10},
+	}
+	liquidityTS := GenerateLiquidityTimeseries(events)
+	if len(liquidityTS) != 4 {
+		t.Fatalf("Expected 4 liquidity points, got %d", len(liquidityTS))
+	}
+	if liquidityTS[1].Liquidity != 80 {
+		t.Errorf("Expected the new pool's liquidity 80 at the migration, got %v", liquidityTS[1].Liquidity)
+	}
+
+	priceTS := []*domain.PriceTimeseriesPoint{
+		{CandidateID: "c1", TimestampMs: 1000, Price: 1.0},
+		{CandidateID: "c1", TimestampMs: 4000, Price: 2.0},
+		{CandidateID: "c1", TimestampMs: 6000, Price: 2.5},
+		{CandidateID: "c1", TimestampMs: 7000, Price: 3.0},
+		{CandidateID: "c2", TimestampMs: 1000, Price: 1.0},
+	}
+	features := ComputeDerivedFeatures(priceTS, liquidityTS)
+	ApplyPoolChanges(features, events)
+
+	// The first point at or after the Raydium pool's first event (5000) is 6000
+	want := map[string][]bool{"c1": {false, false, true, false}, "c2": {false}}
+	got := make(map[string][]bool)
+	for _, f := range features {
+		got[f.CandidateID] = append(got[f.CandidateID], f.PoolChange)
+	}
+	for candidateID, w := range want {
+		if len(got[candidateID]) != len(w) {
+			t.Fatalf("%s: expected %d features, got %d", candidateID, len(w), len(got[candidateID]))
+		}
+		for i := range w {
+			if got[candidateID][i] != w[i] {
+				t.Errorf("%s feature %d: expected pool_change %v, got %v", candidateID, i, w[i], got[candidateID][i])
+			}
+		}
+	}
+}
+
 func TestComputeDerivedFeatures_FirstRow(t *testing.T) {
 	priceTS := []*domain.PriceTimeseriesPoint{
 		{CandidateID: "c1", TimestampMs: 1000, Price: 1.0},
@@ -619,3 +743,110 @@ func TestFindPrevLiquidityEventTimestamp(t *testing.T) {
 func ptrInt64(v int64) *int64 {
 	return &v
 }
+
+func ptrFloat(v float64) *float64 {
+	return &v
+}
+
+// decimalsSwaps builds alternating buy/sell swaps whose raw token amounts are amounts.
+func decimalsSwaps(dex string, amounts ...float64) []*domain.Swap {
+	swaps := make([]*domain.Swap, 0, len(amounts))
+	for i, a := range amounts {
+		s := &domain.Swap{CandidateID: "c1", Slot: int64(100 + i), EventIndex: i, Side: domain.SwapSideBuy, AmountIn: 0.5, AmountOut: a, DEX: dex}
+		if i%2 == 1 {
+			s.Side = domain.SwapSideSell
+			s.AmountIn, s.AmountOut = a, 0.5
+		}
+		swaps = append(swaps, s)
+	}
+	return swaps
+}
+
+func TestInferDecimals(t *testing.T) {
+	tests := []struct {
+		name  string
+		swaps []*domain.Swap
+		want  DecimalsInference
+	}{
+		{
+			// 2k..350k whole tokens at 6 decimals
+			name:  "6 decimals",
+			swaps: decimalsSwaps(domain.DEXRaydium, 2_000e6, 15_000e6, 48_500e6, 350_000e6),
+			want:  DecimalsInference{Decimals: 6, Confidence: DecimalsConfidenceHigh},
+		},
+		{
+			// 1.2k..900k whole tokens at 9 decimals
+			name:  "9 decimals",
+			swaps: decimalsSwaps(domain.DEXRaydium, 1_200e9, 30_000e9, 75_000e9, 900_000e9),
+			want:  DecimalsInference{Decimals: 9, Confidence: DecimalsConfidenceHigh},
+		},
+		{
+			// Median 5e12 is 5M tokens at 6 decimals or 5k tokens at 9 decimals
+			name:  "ambiguous",
+			swaps: decimalsSwaps(domain.DEXRaydium, 2e12, 4e12, 6e12, 8e12),
+			want:  DecimalsInference{Decimals: 9, Confidence: DecimalsConfidenceLow},
+		},
+		{
+			name:  "pump.fun",
+			swaps: decimalsSwaps(domain.DEXPumpFun, 2e12, 4e12, 6e12, 8e12),
+			want:  DecimalsInference{Decimals: 6, Confidence: DecimalsConfidenceHigh},
+		},
+		{
+			name: "no amounts",
+			want: DecimalsInference{Decimals: DefaultInferredDecimals, Confidence: DecimalsConfidenceLow},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferDecimals(tt.swaps); got != tt.want {
+				t.Errorf("InferDecimals = %+v, want %+v", got, tt.want)
+			}
+
+			// Order-independent
+			reversed := make([]*domain.Swap, len(tt.swaps))
+			for i, s := range tt.swaps {
+				reversed[len(tt.swaps)-1-i] = s
+			}
+			if got := InferDecimals(reversed); got != tt.want {
+				t.Errorf("InferDecimals(reversed) = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunner_DecimalsState(t *testing.T) {
+	ctx := context.Background()
+
+	swapStore := memory.NewSwapStore()
+	metadataStore := memory.NewTokenMetadataStore()
+	for _, cid := range []string{"with_meta", "no_meta"} {
+		for _, s := range decimalsSwaps(domain.DEXRaydium, 2e12, 4e12, 6e12, 8e12) {
+			s.CandidateID = cid
+			s.TxSignature = cid
+			if err := swapStore.Insert(ctx, s); err != nil {
+				t.Fatalf("insert swap: %v", err)
+			}
+		}
+	}
+	if err := metadataStore.Insert(ctx, &domain.TokenMetadata{CandidateID: "with_meta", Mint: "m1", Decimals: 6}); err != nil {
+		t.Fatalf("insert metadata: %v", err)
+	}
+
+	runner := NewRunner(swapStore, memory.NewLiquidityEventStore(), memory.NewPriceTimeseriesStore(),
+		memory.NewLiquidityTimeseriesStore(), memory.NewVolumeTimeseriesStore(), memory.NewDerivedFeatureStore()).
+		WithMetadataStore(metadataStore)
+	if err := runner.NormalizeBatch(ctx, []string{"with_meta", "no_meta"}); err != nil {
+		t.Fatalf("NormalizeBatch: %v", err)
+	}
+
+	state, ok := runner.State("with_meta")
+	if !ok || state.Inferred() || state.Decimals != 6 || state.DecimalsSource != DecimalsSourceMetadata {
+		t.Errorf("with_meta state = %+v (ok=%v), want decimals 6 from metadata", state, ok)
+	}
+
+	state, ok = runner.State("no_meta")
+	if !ok || !state.Inferred() || state.Decimals != 9 || state.Confidence != DecimalsConfidenceLow {
+		t.Errorf("no_meta state = %+v (ok=%v), want inferred 9 with low confidence", state, ok)
+	}
+}
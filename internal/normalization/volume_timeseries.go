@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/timeutil"
 )
 
 // GenerateVolumeTimeseries aggregates swaps into volume buckets by interval.
@@ -15,6 +16,7 @@ import (
 //   - swap_count = COUNT(*)
 //   - buy_volume = SUM(amount_out) WHERE side = 'buy'
 //   - sell_volume = SUM(amount_out) WHERE side = 'sell'
+//   - buy_sell_imbalance = (buy_volume - sell_volume) / (buy_volume + sell_volume), 0 if both are 0
 func GenerateVolumeTimeseries(swaps []*domain.Swap, intervalSeconds int) []*domain.VolumeTimeseriesPoint {
 	if len(swaps) == 0 || intervalSeconds <= 0 {
 		return nil
@@ -26,7 +28,7 @@ func GenerateVolumeTimeseries(swaps []*domain.Swap, intervalSeconds int) []*doma
 	buckets := make(map[string]map[int64]*domain.VolumeTimeseriesPoint)
 
 	for _, s := range swaps {
-		intervalStart := (s.Timestamp / intervalMs) * intervalMs
+		intervalStart := timeutil.IntervalAlign(s.Timestamp, intervalMs)
 
 		candidateBuckets, ok := buckets[s.CandidateID]
 		if !ok {
@@ -58,6 +60,7 @@ func GenerateVolumeTimeseries(swaps []*domain.Swap, intervalSeconds int) []*doma
 	var result []*domain.VolumeTimeseriesPoint
 	for _, candidateBuckets := range buckets {
 		for _, point := range candidateBuckets {
+			point.BuySellImbalance = BuySellImbalance(point.BuyVolume, point.SellVolume)
 			result = append(result, point)
 		}
 	}
@@ -72,6 +75,16 @@ func GenerateVolumeTimeseries(swaps []*domain.Swap, intervalSeconds int) []*doma
 	return result
 }
 
+// BuySellImbalance returns (buy - sell) / (buy + sell), in [-1, 1].
+// Returns 0 when there is no sided volume.
+func BuySellImbalance(buyVolume, sellVolume float64) float64 {
+	total := buyVolume + sellVolume
+	if total == 0 {
+		return 0
+	}
+	return (buyVolume - sellVolume) / total
+}
+
 // GenerateAllVolumeTimeseries generates volume timeseries for all supported intervals.
 func GenerateAllVolumeTimeseries(swaps []*domain.Swap) []*domain.VolumeTimeseriesPoint {
 	var result []*domain.VolumeTimeseriesPoint
@@ -2,11 +2,20 @@
 package observability
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"solana-token-lab/internal/decision"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
 )
 
 // Metrics holds all Prometheus metrics for the application.
@@ -17,11 +26,16 @@ type Metrics struct {
 	SwapEventsStored         prometheus.Counter
 	LiquidityEventsStored    prometheus.Counter
 	EventProcessingErrors    *prometheus.CounterVec
+	WSEndpointSwitches       *prometheus.CounterVec
+	TransactionsObserved     *prometheus.CounterVec
+	FailedTransactions       *prometheus.CounterVec
 
 	// Discovery metrics
 	NewTokensDiscovered    prometheus.Counter
 	ActiveTokensDiscovered prometheus.Counter
 	CandidatesCreated      *prometheus.CounterVec
+	DiscoveryLatency       *prometheus.HistogramVec
+	DiscoveriesFiltered    *prometheus.CounterVec
 
 	// Buffer metrics
 	SwapBufferSize      prometheus.Gauge
@@ -34,17 +48,57 @@ type Metrics struct {
 	WSMessageLatency       prometheus.Histogram
 
 	// Pipeline metrics
-	PipelineRunsTotal    *prometheus.CounterVec
-	PipelineDuration     *prometheus.HistogramVec
-	TradesSimulated      prometheus.Counter
-	AggregatesComputed   prometheus.Counter
-	ReportsGenerated     prometheus.Counter
+	PipelineRunsTotal  *prometheus.CounterVec
+	PipelineDuration   *prometheus.HistogramVec
+	TradesSimulated    prometheus.Counter
+	AggregatesComputed prometheus.Counter
+	ReportsGenerated   prometheus.Counter
 
 	// Database metrics
 	DBQueryDuration *prometheus.HistogramVec
 	DBQueryErrors   *prometheus.CounterVec
 	DBConnections   *prometheus.GaugeVec
 
+	// Store metrics (per storage interface wrapper)
+	StoreOperationDuration *prometheus.HistogramVec
+	StoreOperationErrors   *prometheus.CounterVec
+
+	// HTTP metrics (cmd/server, see api.Middleware)
+	HTTPRequestDuration  *prometheus.HistogramVec
+	HTTPRequestsInFlight prometheus.Gauge
+
+	// Backfill metrics (current run)
+	BackfillSignaturesScanned   prometheus.Gauge
+	BackfillTransactionsFetched prometheus.Gauge
+	BackfillEventsStored        prometheus.Gauge
+	BackfillBlockTime           prometheus.Gauge
+	BackfillProgressRatio       prometheus.Gauge
+	BackfillETASeconds          prometheus.Gauge
+
+	// Simulation metrics (current pipeline run)
+	SimulationCandidatesDone  prometheus.Gauge
+	SimulationCandidatesTotal prometheus.Gauge
+	SimulationTradesWritten   prometheus.Gauge
+
+	// Simulation time series loads
+	SimulationTimeseriesLoads  *prometheus.CounterVec
+	SimulationTimeseriesPoints *prometheus.CounterVec
+
+	// Report outcome metrics (last completed report run)
+	ReportDecision             *prometheus.GaugeVec
+	ReportSufficiencyCheckPass *prometheus.GaugeVec
+	ReportBestMedianRealistic  prometheus.Gauge
+	ReportBestWinRateRealistic prometheus.Gauge
+	ReportTotalTrades          prometheus.Gauge
+	ReportDataCoverageDays     prometheus.Gauge
+	ReportMetadataCoverage     prometheus.Gauge
+
+	// Data sufficiency metrics (latest stored sufficiency run)
+	SufficiencyCheckPass   *prometheus.GaugeVec
+	SufficiencyAllPass     prometheus.Gauge
+	SufficiencyErrorCount  prometheus.Gauge
+	SufficiencyLastRunTime prometheus.Gauge
+
 	// Health metrics
 	LastSuccessfulIngestion prometheus.Gauge
 	LastSuccessfulPipeline  prometheus.Gauge
@@ -89,6 +143,24 @@ func NewMetrics(namespace string) *Metrics {
 			Name:      "event_processing_errors_total",
 			Help:      "Total number of event processing errors by type",
 		}, []string{"event_type", "error_type"}),
+		WSEndpointSwitches: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ingestion",
+			Name:      "ws_endpoint_switches_total",
+			Help:      "Total number of WebSocket endpoint switches after starved subscriptions, by live source and result (ok, error)",
+		}, []string{"source", "result"}),
+		TransactionsObserved: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ingestion",
+			Name:      "transactions_observed_total",
+			Help:      "Total number of transactions observed by ingestion sources, failed ones included, by source and program",
+		}, []string{"source", "program"}),
+		FailedTransactions: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ingestion",
+			Name:      "failed_transactions_total",
+			Help:      "Total number of transactions that failed on chain and were skipped by ingestion sources, by source and program",
+		}, []string{"source", "program"}),
 
 		// Discovery metrics
 		NewTokensDiscovered: promauto.NewCounter(prometheus.CounterOpts{
@@ -109,6 +181,19 @@ func NewMetrics(namespace string) *Metrics {
 			Name:      "candidates_created_total",
 			Help:      "Total number of candidates created by source",
 		}, []string{"source"}),
+		DiscoveryLatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "discovery",
+			Name:      "latency_seconds",
+			Help:      "Time from the discovery event's block time to live candidate detection, by source",
+			Buckets:   []float64{0.5, 1, 2, 5, 10, 30, 60, 300, 900, 3600},
+		}, []string{"source"}),
+		DiscoveriesFiltered: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "discovery",
+			Name:      "filtered_total",
+			Help:      "Total number of mints kept from becoming candidates by the mint blacklist/allowlist, by reason",
+		}, []string{"reason"}),
 
 		// Buffer metrics
 		SwapBufferSize: promauto.NewGauge(prometheus.GaugeOpts{
@@ -207,6 +292,176 @@ func NewMetrics(namespace string) *Metrics {
 			Help:      "Number of database connections by state",
 		}, []string{"database", "state"}),
 
+		// Store metrics
+		StoreOperationDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "operation_duration_seconds",
+			Help:      "Store operation duration in seconds",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "store", "backend"}),
+		StoreOperationErrors: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "operation_errors_total",
+			Help:      "Total number of unexpected store operation errors",
+		}, []string{"operation", "store", "backend"}),
+
+		// HTTP metrics
+		HTTPRequestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request duration in seconds, by route pattern and status code",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "status"}),
+		HTTPRequestsInFlight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "HTTP requests currently being served",
+		}),
+
+		// Backfill metrics
+		BackfillSignaturesScanned: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "backfill",
+			Name:      "signatures_scanned",
+			Help:      "Signatures scanned by the current backfill",
+		}),
+		BackfillTransactionsFetched: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "backfill",
+			Name:      "transactions_fetched",
+			Help:      "Transactions fetched by the current backfill",
+		}),
+		BackfillEventsStored: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "backfill",
+			Name:      "events_stored",
+			Help:      "Swap and liquidity events stored by the current backfill",
+		}),
+		BackfillBlockTime: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "backfill",
+			Name:      "block_time_seconds",
+			Help:      "Block time reached by the active backfill scan (scans walk backwards)",
+		}),
+		BackfillProgressRatio: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "backfill",
+			Name:      "progress_ratio",
+			Help:      "Fraction of the backfill range covered across all program scans (0-1)",
+		}),
+		BackfillETASeconds: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "backfill",
+			Name:      "eta_seconds",
+			Help:      "Estimated seconds until the backfill completes (0 if unknown)",
+		}),
+
+		// Simulation metrics
+		SimulationCandidatesDone: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "simulation",
+			Name:      "candidates_done",
+			Help:      "Candidates simulated by the current pipeline run",
+		}),
+		SimulationCandidatesTotal: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "simulation",
+			Name:      "candidates_total",
+			Help:      "Candidates to simulate in the current pipeline run",
+		}),
+		SimulationTradesWritten: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "simulation",
+			Name:      "trades_written",
+			Help:      "Trades created or updated by the current pipeline run",
+		}),
+		SimulationTimeseriesLoads: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "simulation",
+			Name:      "timeseries_loads_total",
+			Help:      "Time series loads by the simulation runner, by mode (full, window, fallback)",
+		}, []string{"mode"}),
+		SimulationTimeseriesPoints: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "simulation",
+			Name:      "timeseries_points_loaded_total",
+			Help:      "Price and liquidity time series points read by the simulation runner, by mode",
+		}, []string{"mode"}),
+
+		// Report outcome metrics
+		ReportDecision: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "decision",
+			Help:      "Decision of the last report run (1 for the current decision, 0 otherwise)",
+		}, []string{"decision"}),
+		ReportSufficiencyCheckPass: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "sufficiency_check_pass",
+			Help:      "Whether each data sufficiency check passed in the last report run (1/0)",
+		}, []string{"check"}),
+		ReportBestMedianRealistic: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "best_strategy_median_realistic",
+			Help:      "Median outcome of the best strategy under the realistic scenario",
+		}),
+		ReportBestWinRateRealistic: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "best_strategy_win_rate_realistic",
+			Help:      "Win rate of the best strategy under the realistic scenario",
+		}),
+		ReportTotalTrades: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "total_trades",
+			Help:      "Trades covered by the last report run",
+		}),
+		ReportDataCoverageDays: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "data_coverage_days",
+			Help:      "Days between the first and last trade covered by the last report run",
+		}),
+		ReportMetadataCoverage: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "report",
+			Name:      "metadata_coverage_ratio",
+			Help:      "Share of candidates with token metadata in the last report run (0-1, NaN without a metadata store)",
+		}),
+
+		// Data sufficiency metrics
+		SufficiencyCheckPass: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "sufficiency",
+			Name:      "check_pass",
+			Help:      "Whether each data sufficiency check passed in the latest stored run (1/0)",
+		}, []string{"check"}),
+		SufficiencyAllPass: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "sufficiency",
+			Name:      "all_pass",
+			Help:      "Whether all checks passed without integrity errors in the latest stored run (1/0)",
+		}),
+		SufficiencyErrorCount: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "sufficiency",
+			Name:      "integrity_errors",
+			Help:      "Integrity errors found by the latest stored run",
+		}),
+		SufficiencyLastRunTime: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "sufficiency",
+			Name:      "last_run_timestamp_seconds",
+			Help:      "Unix time of the latest stored sufficiency run",
+		}),
+
 		// Health metrics
 		LastSuccessfulIngestion: promauto.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -259,11 +514,43 @@ func RecordActiveTokenDiscovered() {
 	DefaultMetrics.CandidatesCreated.WithLabelValues("ACTIVE_TOKEN").Inc()
 }
 
+// RecordDiscoveryLatency records the detection latency of a live-ingested candidate.
+// Matches discovery.LatencyRecorder so it can be passed to detectors directly.
+func RecordDiscoveryLatency(source domain.Source, latency time.Duration) {
+	DefaultMetrics.DiscoveryLatency.WithLabelValues(string(source)).Observe(latency.Seconds())
+}
+
+// RecordFilteredDiscovery counts a mint filtered out by the mint blacklist/allowlist.
+// Matches discovery.FilterRecorder.
+func RecordFilteredDiscovery(reason string) {
+	DefaultMetrics.DiscoveriesFiltered.WithLabelValues(reason).Inc()
+}
+
 // RecordEventError records an event processing error.
 func RecordEventError(eventType, errorType string) {
 	DefaultMetrics.EventProcessingErrors.WithLabelValues(eventType, errorType).Inc()
 }
 
+// RecordWSEndpointSwitch counts an endpoint switch of a starved live source.
+// Matches ingestion.RunnerOptions.OnEndpointSwitch.
+func RecordWSEndpointSwitch(source string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	DefaultMetrics.WSEndpointSwitches.WithLabelValues(source, result).Inc()
+}
+
+// RecordTransactionObserved counts a transaction observed by an ingestion
+// source and, if it failed on chain, the failure.
+// Matches the recorder of ingestion.FailedTxTracker.
+func RecordTransactionObserved(source, program string, failed bool) {
+	DefaultMetrics.TransactionsObserved.WithLabelValues(source, program).Inc()
+	if failed {
+		DefaultMetrics.FailedTransactions.WithLabelValues(source, program).Inc()
+	}
+}
+
 // UpdateBufferSizes updates the buffer size gauges.
 func UpdateBufferSizes(swapSlots, liquiditySlots int) {
 	DefaultMetrics.SwapBufferSize.Set(float64(swapSlots))
@@ -288,8 +575,137 @@ func RecordDBQuery(database, operation string, seconds float64, err error) {
 	}
 }
 
+// RecordStoreOperation records store operation metrics.
+// Matches instrumented.RecordFunc so it can be passed to store wrappers directly.
+func RecordStoreOperation(store, backend, operation string, seconds float64, err error) {
+	DefaultMetrics.StoreOperationDuration.WithLabelValues(operation, store, backend).Observe(seconds)
+	if err != nil {
+		DefaultMetrics.StoreOperationErrors.WithLabelValues(operation, store, backend).Inc()
+	}
+}
+
+// RecordHTTPRequest records a served HTTP request.
+// Matches api.RecordFunc so it can be passed to the middleware directly.
+func RecordHTTPRequest(route string, status int, seconds float64) {
+	DefaultMetrics.HTTPRequestDuration.WithLabelValues(route, strconv.Itoa(status)).Observe(seconds)
+}
+
+// AddHTTPInFlight adjusts the in-flight HTTP requests gauge by delta.
+func AddHTTPInFlight(delta int) {
+	DefaultMetrics.HTTPRequestsInFlight.Add(float64(delta))
+}
+
+// UpdateBackfillProgress sets the backfill progress gauges.
+func UpdateBackfillProgress(signatures, transactions, eventsStored int, blockTime int64, ratio, etaSeconds float64) {
+	DefaultMetrics.BackfillSignaturesScanned.Set(float64(signatures))
+	DefaultMetrics.BackfillTransactionsFetched.Set(float64(transactions))
+	DefaultMetrics.BackfillEventsStored.Set(float64(eventsStored))
+	DefaultMetrics.BackfillBlockTime.Set(float64(blockTime))
+	DefaultMetrics.BackfillProgressRatio.Set(ratio)
+	DefaultMetrics.BackfillETASeconds.Set(etaSeconds)
+}
+
+// UpdateSimulationProgress sets the simulation progress gauges.
+func UpdateSimulationProgress(candidatesDone, candidatesTotal, tradesWritten int) {
+	DefaultMetrics.SimulationCandidatesDone.Set(float64(candidatesDone))
+	DefaultMetrics.SimulationCandidatesTotal.Set(float64(candidatesTotal))
+	DefaultMetrics.SimulationTradesWritten.Set(float64(tradesWritten))
+}
+
+// RecordTimeseriesLoad records a simulation time series load and the points it read.
+func RecordTimeseriesLoad(mode string, points int) {
+	DefaultMetrics.SimulationTimeseriesLoads.WithLabelValues(mode).Inc()
+	DefaultMetrics.SimulationTimeseriesPoints.WithLabelValues(mode).Add(float64(points))
+}
+
 // RecordPipelineRun records a pipeline run.
 func RecordPipelineRun(phase, status string, durationSeconds float64) {
 	DefaultMetrics.PipelineRunsTotal.WithLabelValues(phase, status).Inc()
 	DefaultMetrics.PipelineDuration.WithLabelValues(phase).Observe(durationSeconds)
 }
+
+// reportDecisions are always published by ReportDecision, so a dashboard sees
+// the previous decision drop to 0 rather than disappear.
+var reportDecisions = []decision.Decision{
+	decision.DecisionGO,
+	decision.DecisionNOGO,
+	decision.DecisionInsufficientData,
+}
+
+// reportOutcomeMu serializes RecordReportOutcome so concurrent report runs
+// never interleave their gauge updates.
+var reportOutcomeMu sync.Mutex
+
+// RecordReportOutcome publishes the outcome of a report run, replacing the
+// previous run's values. Sufficiency checks absent from this report are dropped.
+func RecordReportOutcome(report *reporting.Report) {
+	reportOutcomeMu.Lock()
+	defer reportOutcomeMu.Unlock()
+
+	m := DefaultMetrics
+	current := report.ExecutiveSummary.Decision
+	known := false
+	m.ReportDecision.Reset()
+	for _, d := range reportDecisions {
+		v := 0.0
+		if string(d) == current {
+			v = 1
+			known = true
+		}
+		m.ReportDecision.WithLabelValues(string(d)).Set(v)
+	}
+	if !known && current != "" {
+		m.ReportDecision.WithLabelValues(current).Set(1)
+	}
+
+	m.ReportSufficiencyCheckPass.Reset()
+	for _, c := range report.DataQuality.SufficiencyChecks {
+		v := 0.0
+		if c.Pass {
+			v = 1
+		}
+		m.ReportSufficiencyCheckPass.WithLabelValues(c.Name).Set(v)
+	}
+
+	m.ReportBestMedianRealistic.Set(report.ExecutiveSummary.MedianRealistic)
+	m.ReportBestWinRateRealistic.Set(report.ExecutiveSummary.WinRateRealistic)
+	m.ReportTotalTrades.Set(float64(report.DataSummary.TotalTrades))
+
+	coverageDays := 0.0
+	if span := report.DataSummary.DateRangeEnd - report.DataSummary.DateRangeStart; span > 0 {
+		coverageDays = float64(span) / float64(24*time.Hour/time.Millisecond)
+	}
+	m.ReportDataCoverageDays.Set(coverageDays)
+
+	metadataCoverage := math.NaN()
+	if report.DataSummary.MetadataChecked {
+		metadataCoverage = report.DataSummary.MetadataCoveragePct / 100
+	}
+	m.ReportMetadataCoverage.Set(metadataCoverage)
+}
+
+// sufficiencyRunMu serializes RecordSufficiencyRun.
+var sufficiencyRunMu sync.Mutex
+
+// RecordSufficiencyRun publishes a stored sufficiency run, replacing the previous
+// run's values. Checks absent from this run are dropped.
+func RecordSufficiencyRun(run *storage.SufficiencyRun) {
+	sufficiencyRunMu.Lock()
+	defer sufficiencyRunMu.Unlock()
+
+	m := DefaultMetrics
+	m.SufficiencyCheckPass.Reset()
+	for _, c := range run.Checks {
+		m.SufficiencyCheckPass.WithLabelValues(c.Name).Set(boolGauge(c.Pass))
+	}
+	m.SufficiencyAllPass.Set(boolGauge(run.AllPass))
+	m.SufficiencyErrorCount.Set(float64(run.ErrorCount))
+	m.SufficiencyLastRunTime.Set(float64(run.RunAt) / 1000)
+}
+
+func boolGauge(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,237 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"solana-token-lab/internal/decision"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/pipeline"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestRecordStoreOperation(t *testing.T) {
+	errCounter := DefaultMetrics.StoreOperationErrors.WithLabelValues("get_all", "trade_record", "postgres")
+	before := testutil.ToFloat64(errCounter)
+
+	RecordStoreOperation("trade_record", "postgres", "get_all", 0.01, nil)
+	if got := testutil.ToFloat64(errCounter); got != before {
+		t.Errorf("expected error counter unchanged on success, got %f (was %f)", got, before)
+	}
+
+	RecordStoreOperation("trade_record", "postgres", "get_all", 0.02, errors.New("boom"))
+	if got := testutil.ToFloat64(errCounter); got != before+1 {
+		t.Errorf("expected error counter %f, got %f", before+1, got)
+	}
+
+	if n := testutil.CollectAndCount(DefaultMetrics.StoreOperationDuration, "solana_token_lab_store_operation_duration_seconds"); n == 0 {
+		t.Error("expected store operation duration to be collected")
+	}
+}
+
+func TestRecordWSEndpointSwitch(t *testing.T) {
+	ok := DefaultMetrics.WSEndpointSwitches.WithLabelValues("ws-swap", "ok")
+	failed := DefaultMetrics.WSEndpointSwitches.WithLabelValues("ws-swap", "error")
+	okBefore, failedBefore := testutil.ToFloat64(ok), testutil.ToFloat64(failed)
+
+	RecordWSEndpointSwitch("ws-swap", nil)
+	RecordWSEndpointSwitch("ws-swap", errors.New("dial failed"))
+
+	if got := testutil.ToFloat64(ok); got != okBefore+1 {
+		t.Errorf("ok switches: expected %f, got %f", okBefore+1, got)
+	}
+	if got := testutil.ToFloat64(failed); got != failedBefore+1 {
+		t.Errorf("failed switches: expected %f, got %f", failedBefore+1, got)
+	}
+}
+
+func TestRecordTransactionObserved(t *testing.T) {
+	observed := DefaultMetrics.TransactionsObserved.WithLabelValues("rpc-swap", "prog-a")
+	failed := DefaultMetrics.FailedTransactions.WithLabelValues("rpc-swap", "prog-a")
+	observedBefore, failedBefore := testutil.ToFloat64(observed), testutil.ToFloat64(failed)
+
+	RecordTransactionObserved("rpc-swap", "prog-a", false)
+	RecordTransactionObserved("rpc-swap", "prog-a", true)
+
+	if got := testutil.ToFloat64(observed); got != observedBefore+2 {
+		t.Errorf("observed: expected %f, got %f", observedBefore+2, got)
+	}
+	if got := testutil.ToFloat64(failed); got != failedBefore+1 {
+		t.Errorf("failed: expected %f, got %f", failedBefore+1, got)
+	}
+}
+
+func TestUpdateSimulationProgress(t *testing.T) {
+	UpdateSimulationProgress(3, 10, 42)
+
+	if got := testutil.ToFloat64(DefaultMetrics.SimulationCandidatesDone); got != 3 {
+		t.Errorf("candidates done: expected 3, got %f", got)
+	}
+	if got := testutil.ToFloat64(DefaultMetrics.SimulationCandidatesTotal); got != 10 {
+		t.Errorf("candidates total: expected 10, got %f", got)
+	}
+	if got := testutil.ToFloat64(DefaultMetrics.SimulationTradesWritten); got != 42 {
+		t.Errorf("trades written: expected 42, got %f", got)
+	}
+}
+
+func TestRecordDiscoveryLatency(t *testing.T) {
+	RecordDiscoveryLatency(domain.SourceNewToken, 1500*time.Millisecond)
+
+	if n := testutil.CollectAndCount(DefaultMetrics.DiscoveryLatency, "solana_token_lab_discovery_latency_seconds"); n == 0 {
+		t.Error("expected discovery latency to be collected")
+	}
+}
+
+func TestRecordReportOutcome_FixtureRun(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := pipeline.LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:    true,
+		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	p := pipeline.NewPhase1Pipeline(candidateStore, tradeStore, aggStore, implementable, t.TempDir()).
+		WithClock(func() time.Time { return fixedTime })
+
+	report, err := p.RunReport(ctx)
+	if err != nil {
+		t.Fatalf("run report: %v", err)
+	}
+	RecordReportOutcome(report)
+
+	got := report.ExecutiveSummary.Decision
+	if v := testutil.ToFloat64(DefaultMetrics.ReportDecision.WithLabelValues(got)); v != 1 {
+		t.Errorf("decision %s: expected 1, got %f", got, v)
+	}
+	if n := testutil.CollectAndCount(DefaultMetrics.ReportDecision); n != 3 {
+		t.Errorf("expected 3 decision series, got %d", n)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportTotalTrades); v != float64(report.DataSummary.TotalTrades) {
+		t.Errorf("total trades: expected %d, got %f", report.DataSummary.TotalTrades, v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportBestMedianRealistic); v != report.ExecutiveSummary.MedianRealistic {
+		t.Errorf("best median: expected %f, got %f", report.ExecutiveSummary.MedianRealistic, v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportBestWinRateRealistic); v != report.ExecutiveSummary.WinRateRealistic {
+		t.Errorf("best win rate: expected %f, got %f", report.ExecutiveSummary.WinRateRealistic, v)
+	}
+}
+
+func TestRecordReportOutcome_OverwritesPreviousRun(t *testing.T) {
+	RecordReportOutcome(&reporting.Report{
+		ExecutiveSummary: reporting.ExecutiveSummary{Decision: "GO", MedianRealistic: 0.2, WinRateRealistic: 0.6},
+		DataSummary: reporting.DataSummary{TotalTrades: 100, DateRangeStart: 0, DateRangeEnd: 3 * 86400000,
+			MetadataChecked: true, MetadataCandidates: 10, MetadataCoveredCandidates: 8, MetadataCoveragePct: 80},
+		DataQuality: reporting.DataQualitySection{SufficiencyChecks: []reporting.SufficiencyCheckRow{
+			{Name: "min_candidates", Pass: true},
+			{Name: "min_coverage_days", Pass: true},
+		}},
+	})
+	RecordReportOutcome(&reporting.Report{
+		ExecutiveSummary: reporting.ExecutiveSummary{Decision: "NO-GO", MedianRealistic: -0.1, WinRateRealistic: 0.3},
+		DataSummary:      reporting.DataSummary{TotalTrades: 40, DateRangeStart: 0, DateRangeEnd: 43200000},
+		DataQuality: reporting.DataQualitySection{SufficiencyChecks: []reporting.SufficiencyCheckRow{
+			{Name: "min_candidates", Pass: false},
+		}},
+	})
+
+	if v := testutil.ToFloat64(DefaultMetrics.ReportDecision.WithLabelValues("GO")); v != 0 {
+		t.Errorf("GO: expected 0 after NO-GO run, got %f", v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportDecision.WithLabelValues("NO-GO")); v != 1 {
+		t.Errorf("NO-GO: expected 1, got %f", v)
+	}
+	if n := testutil.CollectAndCount(DefaultMetrics.ReportSufficiencyCheckPass); n != 1 {
+		t.Errorf("expected stale checks to be cleared, got %d series", n)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportSufficiencyCheckPass.WithLabelValues("min_candidates")); v != 0 {
+		t.Errorf("min_candidates: expected 0, got %f", v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportTotalTrades); v != 40 {
+		t.Errorf("total trades: expected 40 (overwritten, not summed), got %f", v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportDataCoverageDays); v != 0.5 {
+		t.Errorf("coverage days: expected 0.5, got %f", v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportMetadataCoverage); !math.IsNaN(v) {
+		t.Errorf("metadata coverage: expected NaN without a metadata store, got %f", v)
+	}
+	if v := testutil.ToFloat64(DefaultMetrics.ReportBestMedianRealistic); v != -0.1 {
+		t.Errorf("best median: expected -0.1, got %f", v)
+	}
+}
+
+func TestRecordSufficiencyRun_LatestStoredRun(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewSufficiencyRunStore()
+	for _, run := range []*storage.SufficiencyRun{
+		{RunAt: 1000, Checks: []storage.SufficiencyCheckRecord{
+			{Name: "unique_new_token_candidates", Pass: false},
+			{Name: "discovery_uptime_days", Pass: true},
+		}, ErrorCount: 4},
+		{RunAt: 2000, AllPass: true, Checks: []storage.SufficiencyCheckRecord{
+			{Name: "unique_new_token_candidates", Pass: true},
+		}},
+	} {
+		if err := store.Insert(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err := store.GetLatest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	RecordSufficiencyRun(latest)
+
+	m := DefaultMetrics
+	if v := testutil.ToFloat64(m.SufficiencyCheckPass.WithLabelValues("unique_new_token_candidates")); v != 1 {
+		t.Errorf("unique_new_token_candidates: expected 1, got %f", v)
+	}
+	if n := testutil.CollectAndCount(m.SufficiencyCheckPass); n != 1 {
+		t.Errorf("expected checks of the older run to be dropped, got %d series", n)
+	}
+	if v := testutil.ToFloat64(m.SufficiencyAllPass); v != 1 {
+		t.Errorf("all pass: expected 1, got %f", v)
+	}
+	if v := testutil.ToFloat64(m.SufficiencyErrorCount); v != 0 {
+		t.Errorf("errors: expected 0, got %f", v)
+	}
+	if v := testutil.ToFloat64(m.SufficiencyLastRunTime); v != 2 {
+		t.Errorf("last run: expected 2s, got %f", v)
+	}
+}
+
+func TestRecordHTTPRequest(t *testing.T) {
+	before := testutil.CollectAndCount(DefaultMetrics.HTTPRequestDuration)
+
+	RecordHTTPRequest("GET /api/v1/trades", 200, 0.01)
+	RecordHTTPRequest("GET /api/v1/trades", 200, 0.03)
+	RecordHTTPRequest("GET /api/v1/trades", 503, 0.02)
+
+	// One series per route and status code
+	if n := testutil.CollectAndCount(DefaultMetrics.HTTPRequestDuration); n != before+2 {
+		t.Errorf("expected 2 new route/status series, got %d (was %d)", n, before)
+	}
+
+	AddHTTPInFlight(1)
+	AddHTTPInFlight(1)
+	AddHTTPInFlight(-1)
+	if got := testutil.ToFloat64(DefaultMetrics.HTTPRequestsInFlight); got != 1 {
+		t.Errorf("in flight: expected 1, got %f", got)
+	}
+	AddHTTPInFlight(-1)
+}
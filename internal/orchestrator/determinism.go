@@ -0,0 +1,153 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// ErrNondeterministic is returned by the determinism check when two simulations
+// of the same inputs produce different trades.
+var ErrNondeterministic = errors.New("simulation is not deterministic")
+
+// TradeDivergence is the first trade, in trade_id order, that differs between
+// the two runs of the determinism check.
+type TradeDivergence struct {
+	TradeID     string
+	CandidateID string
+	StrategyID  string
+	ScenarioID  string
+	Fields      []string // differing TradeRecord fields, empty when the trade is missing from a run
+	MissingFrom int      // run (1 or 2) without the trade, 0 when both have it
+}
+
+// Error implements error. It wraps ErrNondeterministic.
+func (d *TradeDivergence) Error() string {
+	where := fmt.Sprintf("trade %s (candidate %s, strategy %s, scenario %s)", d.TradeID, d.CandidateID, d.StrategyID, d.ScenarioID)
+	if d.MissingFrom != 0 {
+		return fmt.Sprintf("%v: %s missing from run %d", ErrNondeterministic, where, d.MissingFrom)
+	}
+	return fmt.Sprintf("%v: %s differs between runs in %s", ErrNondeterministic, where, strings.Join(d.Fields, ", "))
+}
+
+// Unwrap returns ErrNondeterministic.
+func (d *TradeDivergence) Unwrap() error { return ErrNondeterministic }
+
+// checkDeterminism simulates candidates twice into throwaway memory stores and
+// compares the trade sets byte for byte, and the simulation errors of both runs.
+// The configured stores are only read: dirty marks stay in place, and neither
+// progress nor load callbacks fire. It returns the number of trades compared,
+// or an error wrapping ErrNondeterministic (a *TradeDivergence for trades).
+func (o *Orchestrator) checkDeterminism(ctx context.Context, candidates []*domain.TokenCandidate) (int, error) {
+	var trades [2][]*domain.TradeRecord
+	var errs [2][]string
+	for i := range trades {
+		var err error
+		trades[i], errs[i], err = o.simulateThrowaway(ctx, candidates)
+		if err != nil {
+			return 0, fmt.Errorf("run %d: %w", i+1, err)
+		}
+	}
+	if d := compareTrades(trades[0], trades[1]); d != nil {
+		return 0, d
+	}
+	for i := 0; i < max(len(errs[0]), len(errs[1])); i++ {
+		if i >= len(errs[0]) || i >= len(errs[1]) || errs[0][i] != errs[1][i] {
+			return 0, fmt.Errorf("%w: simulation errors differ between runs: %d vs %d errors, first difference at #%d",
+				ErrNondeterministic, len(errs[0]), len(errs[1]), i+1)
+		}
+	}
+	return len(trades[0]), nil
+}
+
+// simulateThrowaway simulates candidates into a fresh memory trade store and
+// returns the trades ordered by trade_id, and the per-simulation errors.
+func (o *Orchestrator) simulateThrowaway(ctx context.Context, candidates []*domain.TokenCandidate) ([]*domain.TradeRecord, []string, error) {
+	store := memory.NewTradeRecordStore()
+	shadow := *o
+	shadow.tradeRecordStore = store
+	shadow.dirtyCandidateStore = nil
+	shadow.simulationCache = false
+	shadow.onSimulationProgress = nil
+	shadow.onTimeseriesLoad = nil
+	shadow.verbose = false
+
+	_, errs, err := shadow.runSimulations(ctx, candidates, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	trades, err := store.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(trades, func(i, j int) bool { return trades[i].TradeID < trades[j].TradeID })
+	return trades, errs, nil
+}
+
+// compareTrades returns the first divergence between two trade sets ordered by
+// trade_id, or nil when they are identical.
+func compareTrades(first, second []*domain.TradeRecord) *TradeDivergence {
+	i, j := 0, 0
+	for i < len(first) || j < len(second) {
+		switch {
+		case j == len(second) || (i < len(first) && first[i].TradeID < second[j].TradeID):
+			return newDivergence(first[i], nil, 2)
+		case i == len(first) || second[j].TradeID < first[i].TradeID:
+			return newDivergence(second[j], nil, 1)
+		}
+		if fields := diffFields(first[i], second[j]); len(fields) > 0 {
+			return newDivergence(first[i], fields, 0)
+		}
+		i++
+		j++
+	}
+	return nil
+}
+
+func newDivergence(t *domain.TradeRecord, fields []string, missingFrom int) *TradeDivergence {
+	return &TradeDivergence{
+		TradeID:     t.TradeID,
+		CandidateID: t.CandidateID,
+		StrategyID:  t.StrategyID,
+		ScenarioID:  t.ScenarioID,
+		Fields:      fields,
+		MissingFrom: missingFrom,
+	}
+}
+
+// diffFields returns the sorted names of the fields whose JSON encoding differs.
+func diffFields(a, b *domain.TradeRecord) []string {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return []string{"(unencodable)"}
+	}
+	if bytes.Equal(ja, jb) {
+		return nil
+	}
+
+	var fa, fb map[string]json.RawMessage
+	if json.Unmarshal(ja, &fa) != nil || json.Unmarshal(jb, &fb) != nil {
+		return []string{"(unencodable)"}
+	}
+	var fields []string
+	for name, va := range fa {
+		if !bytes.Equal(va, fb[name]) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range fb {
+		if _, ok := fa[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,149 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/strategy"
+)
+
+// unsortedExitStrategy wraps a strategy and picks the exit reason by ranging
+// over a map, so repeated runs disagree: the kind of bug the check must catch.
+type unsortedExitStrategy struct {
+	strategy.Strategy
+	reasons map[string]bool
+}
+
+func (s *unsortedExitStrategy) Execute(ctx context.Context, input *strategy.StrategyInput) (*domain.TradeRecord, error) {
+	trade, err := s.Strategy.Execute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	for reason := range s.reasons {
+		trade.ExitReason = reason
+		break
+	}
+	return trade, nil
+}
+
+// unsortedExitFactory builds strategies with unsortedExitStrategy exit reasons.
+func unsortedExitFactory(cfg domain.StrategyConfig) (strategy.Strategy, error) {
+	inner, err := strategy.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	reasons := make(map[string]bool)
+	for i := 0; i < 16; i++ {
+		reasons[fmt.Sprintf("%s_%02d", domain.ExitReasonTimeExit, i)] = true
+	}
+	return &unsortedExitStrategy{Strategy: inner, reasons: reasons}, nil
+}
+
+// determinismOrchestrator simulates TIME_EXIT on three candidates under every
+// scenario with the determinism check enabled.
+func determinismOrchestrator(t *testing.T, stores *testStores, factory func(domain.StrategyConfig) (strategy.Strategy, error)) *Orchestrator {
+	t.Helper()
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("det-%d", i)
+		if err := stores.candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "mint-" + id, TxSignature: "tx-" + id,
+			Slot: 100, DiscoveredAt: 1000000,
+		}); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+		if err := stores.priceTimeseriesStore.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+			{CandidateID: id, TimestampMs: 1000000, Slot: 100, Price: 1.0},
+			{CandidateID: id, TimestampMs: 1300000, Slot: 200, Price: 1.0 + float64(i)/10},
+		}); err != nil {
+			t.Fatalf("insert prices: %v", err)
+		}
+		if err := stores.liquidityTimeseriesStore.InsertBulk(ctx, []*domain.LiquidityTimeseriesPoint{
+			{CandidateID: id, TimestampMs: 1000000, Slot: 100, Liquidity: 10000},
+		}); err != nil {
+			t.Fatalf("insert liquidity: %v", err)
+		}
+	}
+
+	holdDuration := int64(300000)
+	return New(Options{
+		CandidateStore:           stores.candidateStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs: []domain.ScenarioConfig{
+			domain.ScenarioConfigOptimistic,
+			domain.ScenarioConfigRealistic,
+			domain.ScenarioConfigPessimistic,
+		},
+		SkipNormalization: true,
+		DeterminismCheck:  true,
+		StrategyFactory:   factory,
+	})
+}
+
+func TestOrchestrator_DeterminismCheck_Passes(t *testing.T) {
+	stores := createTestStores()
+	result, err := determinismOrchestrator(t, stores, nil).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.DeterminismCheckTrades != 9 || result.TradesCreated != 9 {
+		t.Errorf("expected 9 checked and 9 created trades, got %d and %d", result.DeterminismCheckTrades, result.TradesCreated)
+	}
+}
+
+func TestOrchestrator_DeterminismCheck_CatchesUnsortedMap(t *testing.T) {
+	stores := createTestStores()
+	result, err := determinismOrchestrator(t, stores, unsortedExitFactory).Run(context.Background())
+	if !errors.Is(err, ErrNondeterministic) {
+		t.Fatalf("expected ErrNondeterministic, got %v", err)
+	}
+
+	var d *TradeDivergence
+	if !errors.As(err, &d) {
+		t.Fatalf("expected a *TradeDivergence, got %T", err)
+	}
+	if !strings.HasPrefix(d.CandidateID, "det-") || !strings.HasPrefix(d.StrategyID, domain.StrategyTypeTimeExit) {
+		t.Errorf("divergence should name the candidate and strategy, got %+v", d)
+	}
+	if strings.Join(d.Fields, ",") != "ExitReason" {
+		t.Errorf("expected only ExitReason to differ, got %v", d.Fields)
+	}
+	if phaseStatuses(result)[PhaseSimulate] != PhaseStatusFailed {
+		t.Errorf("expected the simulate phase to fail, got %v", phaseStatuses(result))
+	}
+
+	// The check runs into throwaway stores: nothing reached the configured one
+	trades, err := stores.tradeRecordStore.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(trades) != 0 {
+		t.Errorf("expected no stored trades after a failed check, got %d", len(trades))
+	}
+}
+
+func TestCompareTrades_MissingTrade(t *testing.T) {
+	a := &domain.TradeRecord{TradeID: "a", CandidateID: "c1", StrategyID: "s", ScenarioID: "realistic", Outcome: 0.1}
+	b := &domain.TradeRecord{TradeID: "b", CandidateID: "c2", StrategyID: "s", ScenarioID: "realistic", Outcome: 0.2}
+
+	if d := compareTrades([]*domain.TradeRecord{a, b}, []*domain.TradeRecord{a, b}); d != nil {
+		t.Errorf("expected identical sets, got %v", d)
+	}
+	d := compareTrades([]*domain.TradeRecord{a, b}, []*domain.TradeRecord{b})
+	if d == nil || d.TradeID != "a" || d.MissingFrom != 2 {
+		t.Fatalf("expected trade a missing from run 2, got %+v", d)
+	}
+	if !strings.Contains(d.Error(), "trade a (candidate c1, strategy s, scenario realistic) missing from run 2") {
+		t.Errorf("unexpected message: %s", d.Error())
+	}
+}
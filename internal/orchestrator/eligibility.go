@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"solana-token-lab/internal/domain"
+)
+
+// SkipReasonInsufficientData marks a candidate/strategy pair that was not simulated
+// because the candidate lacks the time series the strategy's exit rule depends on.
+const SkipReasonInsufficientData = "SKIPPED_INSUFFICIENT_DATA"
+
+// DefaultMinDataPoints is the default number of points required by DefaultDataRequirements.
+const DefaultMinDataPoints = 3
+
+// DataRequirement is the minimum time series coverage a candidate needs inside the
+// hold window [entry, entry + max hold] before a strategy is simulated on it.
+// Zero fields impose no requirement.
+type DataRequirement struct {
+	MinPricePoints     int
+	MinLiquidityPoints int
+}
+
+// DefaultDataRequirements requires minPoints liquidity points for LIQUIDITY_GUARD,
+// whose guard cannot fire without them and otherwise exits via MAX_DURATION, and
+// minPoints price points for TRAILING_STOP. TIME_EXIT only needs the exit price.
+func DefaultDataRequirements(minPoints int) map[string]DataRequirement {
+	if minPoints <= 0 {
+		return nil
+	}
+	return map[string]DataRequirement{
+		domain.StrategyTypeTrailingStop:   {MinPricePoints: minPoints},
+		domain.StrategyTypeLiquidityGuard: {MinLiquidityPoints: minPoints},
+	}
+}
+
+// SkippedSimulation is a candidate/strategy pair left out of simulation.
+// It produces no trades for any scenario and so is excluded from aggregates.
+type SkippedSimulation struct {
+	CandidateID  string `json:"candidate_id"`
+	StrategyType string `json:"strategy_type"`
+	Reason       string `json:"reason"` // SkipReasonInsufficientData
+	Detail       string `json:"detail,omitempty"`
+}
+
+// SkippedByStrategy counts skipped pairs per strategy type.
+func (r *RunResult) SkippedByStrategy() map[string]int {
+	counts := make(map[string]int)
+	for _, s := range r.Skipped {
+		counts[s.StrategyType]++
+	}
+	return counts
+}
+
+// FormatSkippedByStrategy renders SkippedByStrategy as "TYPE n, TYPE n" in type order.
+func (r *RunResult) FormatSkippedByStrategy() string {
+	counts := r.SkippedByStrategy()
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%s %d", t, counts[t])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// holdWindowMs returns the longest time a strategy can hold a position.
+func holdWindowMs(cfg domain.StrategyConfig) int64 {
+	switch {
+	case cfg.MaxHoldDurationMs != nil:
+		return *cfg.MaxHoldDurationMs
+	case cfg.HoldDurationMs != nil:
+		return *cfg.HoldDurationMs
+	default:
+		return 0
+	}
+}
+
+// checkDataRequirement reports whether the time series cover the hold window of cfg
+// from entry well enough for req. When they do not, detail explains the shortfall.
+func checkDataRequirement(req DataRequirement, cfg domain.StrategyConfig, entry int64,
+	prices []*domain.PriceTimeseriesPoint, liquidity []*domain.LiquidityTimeseriesPoint) (detail string, ok bool) {
+	end := entry + holdWindowMs(cfg)
+
+	if req.MinPricePoints > 0 {
+		n := 0
+		for _, p := range prices {
+			if p.TimestampMs >= entry && p.TimestampMs <= end {
+				n++
+			}
+		}
+		if n < req.MinPricePoints {
+			return fmt.Sprintf("%d price points in hold window, need %d", n, req.MinPricePoints), false
+		}
+	}
+
+	if req.MinLiquidityPoints > 0 {
+		n := 0
+		for _, l := range liquidity {
+			if l.TimestampMs >= entry && l.TimestampMs <= end {
+				n++
+			}
+		}
+		if n < req.MinLiquidityPoints {
+			return fmt.Sprintf("%d liquidity points in hold window, need %d", n, req.MinLiquidityPoints), false
+		}
+	}
+
+	return "", true
+}
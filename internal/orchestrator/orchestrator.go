@@ -7,10 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"reflect"
+	"time"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
 	"solana-token-lab/internal/metrics"
 	"solana-token-lab/internal/normalization"
+	"solana-token-lab/internal/replay"
 	"solana-token-lab/internal/simulation"
 	"solana-token-lab/internal/storage"
 )
@@ -28,14 +33,45 @@ type Orchestrator struct {
 	derivedFeatureStore      storage.DerivedFeatureStore
 	tradeRecordStore         storage.TradeRecordStore
 	strategyAggregateStore   storage.StrategyAggregateStore
+	tradeAggregateStore      storage.TradeAggregateStore // optional, enables SQL aggregation
+	tokenMetadataStore       storage.TokenMetadataStore  // optional, enables decimals resolution
+	dirtyCandidateStore      storage.DirtyCandidateStore // optional, enables re-simulation after late data
+	eventCountStore          storage.EventCountStore     // optional, flags trades of downsampled candidates
 
 	// Configs
 	strategyConfigs []domain.StrategyConfig
 	scenarioConfigs []domain.ScenarioConfig
+	scenarioPreset  string
+	entryFilters    []metrics.EntryFilter
+	segmentByDEX    bool
+	dedupTrades     bool
+	dataReqs        map[string]DataRequirement
 
 	// Options
-	skipNormalization bool
-	verbose           bool
+	skipNormalization     bool
+	phases                []string
+	replaceExistingTrades bool
+	tradeBatchSize        int
+	onSimulationProgress  func(SimulationProgress)
+	simWindowMarginMs     int64
+	onTimeseriesLoad      simulation.LoadRecorder
+	observationWindowMs   int64
+	incremental           bool
+	determinismCheck      bool
+	simulationCache       bool
+	newStrategy           simulation.StrategyFactory
+	now                   func() time.Time
+	verbose               bool
+}
+
+// DefaultTradeBatchSize is the number of simulated trades buffered before they are persisted.
+const DefaultTradeBatchSize = 500
+
+// SimulationProgress reports phase 3 progress after each candidate.
+type SimulationProgress struct {
+	CandidatesDone  int
+	CandidatesTotal int
+	TradesWritten   int // trades created or updated so far
 }
 
 // Options for creating Orchestrator.
@@ -51,17 +87,126 @@ type Options struct {
 	TradeRecordStore         storage.TradeRecordStore
 	StrategyAggregateStore   storage.StrategyAggregateStore
 
+	// Optional: when set, the sync phase mirrors trade records written since
+	// its last run into this store, and aggregates are computed with
+	// database-side SQL instead of in Go.
+	TradeAggregateStore storage.TradeAggregateStore
+
+	// Optional: when set, normalization resolves token decimals per candidate and
+	// candidates without metadata get inferred decimals, reported in RunResult.Warnings.
+	TokenMetadataStore storage.TokenMetadataStore
+
+	// Optional: candidates marked dirty here (see storage/tracked) had late events
+	// stored inside one of their trade windows. They get the missing time series
+	// points normalized, are re-simulated with their trades replaced, and are
+	// unmarked once their trades are stored.
+	DirtyCandidateStore storage.DirtyCandidateStore
+
+	// Optional: trades of candidates whose events ingestion downsampled past a
+	// per-candidate cap are flagged in their entry context (see domain.EntryContext.SampleEvery).
+	EventCountStore storage.EventCountStore
+
 	// Strategy and scenario configs
 	StrategyConfigs []domain.StrategyConfig
 	ScenarioConfigs []domain.ScenarioConfig
 
+	// ScenarioPreset restricts ScenarioConfigs to the scenarios of a preset:
+	// domain.ScenarioPresetMonitoring for routine runs, domain.ScenarioPresetDecision
+	// for the full matrix decision reports require. Empty simulates every config.
+	// Candidates are only closed by full-matrix runs, and incremental runs pick up
+	// candidates missing trades of a selected scenario, so a decision run after
+	// monitoring runs completes the matrix.
+	ScenarioPreset string
+
+	// EntryFilters add aggregates restricted by entry context, stored under
+	// labeled entry_event_types (see metrics.LabeledEntryEventType).
+	// Only supported by the Go aggregator; ignored with TradeAggregateStore.
+	EntryFilters []metrics.EntryFilter
+
+	// SegmentByDEX adds aggregates per discovery DEX of the candidate, stored
+	// under labeled entry_event_types such as "NEW_TOKEN:dex_raydium"
+	// (see metrics.DEXCohortLabel). Only supported by the Go aggregator.
+	SegmentByDEX bool
+
+	// DedupTrades adds aggregates over trades deduplicated across candidates of the
+	// same mint (see metrics.DedupTrades), stored under labeled entry_event_types
+	// such as "ACTIVE_TOKEN:dedup". Only supported by the Go aggregator.
+	DedupTrades bool
+
+	// DataRequirements sets, per strategy type, the time series coverage a candidate
+	// needs before the strategy is simulated on it (see DefaultDataRequirements).
+	// Ineligible pairs are recorded in RunResult.Skipped instead of simulated. Nil disables.
+	DataRequirements map[string]DataRequirement
+
 	// Options
 	SkipNormalization bool // Skip if timeseries already exist
-	Verbose           bool
+
+	// Phases selects the phases to run (see AllPhases and ParsePhases); nil runs all.
+	// Each selected phase checks that the output of unselected dependencies is stored.
+	Phases []string
+
+	// ReplaceExistingTrades overwrites stored trades whose re-simulated record differs
+	// (e.g. after a data fix) and refreshes aggregates. When false, existing trade_ids are skipped.
+	ReplaceExistingTrades bool
+
+	// TradeBatchSize is the number of trades buffered before a write (default: DefaultTradeBatchSize).
+	// Batches are flushed on candidate boundaries, so a cancelled run leaves whole candidates stored.
+	TradeBatchSize int
+
+	// OnSimulationProgress is called after each simulated candidate (optional).
+	OnSimulationProgress func(SimulationProgress)
+
+	// SimulationWindowMarginMs > 0 makes simulation load time series only up to each
+	// strategy's holding horizon plus this margin (see simulation.RunnerOptions.WindowMarginMs).
+	SimulationWindowMarginMs int64
+
+	// OnTimeseriesLoad is notified of every simulation time series load (optional).
+	OnTimeseriesLoad simulation.LoadRecorder
+
+	// ObservationWindowMs is how long after DiscoveredAt a candidate is observed (e.g. 48h).
+	// Only events inside the window are normalized. Once the window has elapsed the
+	// candidate is simulated a final time, marked CLOSED with the replay fingerprint
+	// of its window, and left alone by later runs; aggregates cover closed candidates
+	// only. Zero disables closure.
+	ObservationWindowMs int64
+
+	// Incremental processes only dirty candidates, first, candidates without
+	// stored trades and candidates due for closure, instead of every open candidate. Aggregates are recomputed
+	// whenever trades were written.
+	Incremental bool
+
+	// DeterminismCheck simulates the candidates twice more before the simulate
+	// phase, into throwaway memory stores, and fails the phase with
+	// ErrNondeterministic at the first trade that differs between the two runs.
+	DeterminismCheck bool
+
+	// SimulationCache skips a (candidate, strategy, scenario) simulation when a
+	// stored trade of the candidate was simulated from the same inputs: its
+	// InputKey (see simulation.InputKey) matches the replay fingerprint of the
+	// candidate's events and the strategy and scenario configs. Simulated trades
+	// record their key. Hits and misses are counted in RunResult. Ignored with
+	// ReplaceExistingTrades, which re-simulates everything.
+	SimulationCache bool
+
+	// StrategyFactory builds the strategy of each config (default: strategy.FromConfig).
+	StrategyFactory simulation.StrategyFactory
+
+	// Now returns the current time for window closure (default: time.Now).
+	Now func() time.Time
+
+	Verbose bool
 }
 
 // New creates a new Orchestrator.
 func New(opts Options) *Orchestrator {
+	batchSize := opts.TradeBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultTradeBatchSize
+	}
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
 	return &Orchestrator{
 		candidateStore:           opts.CandidateStore,
 		swapStore:                opts.SwapStore,
@@ -72,71 +217,102 @@ func New(opts Options) *Orchestrator {
 		derivedFeatureStore:      opts.DerivedFeatureStore,
 		tradeRecordStore:         opts.TradeRecordStore,
 		strategyAggregateStore:   opts.StrategyAggregateStore,
+		tradeAggregateStore:      opts.TradeAggregateStore,
+		tokenMetadataStore:       opts.TokenMetadataStore,
+		dirtyCandidateStore:      opts.DirtyCandidateStore,
+		eventCountStore:          opts.EventCountStore,
 		strategyConfigs:          opts.StrategyConfigs,
-		scenarioConfigs:          opts.ScenarioConfigs,
+		scenarioConfigs:          selectScenarios(opts.ScenarioConfigs, opts.ScenarioPreset),
+		scenarioPreset:           opts.ScenarioPreset,
+		entryFilters:             opts.EntryFilters,
+		segmentByDEX:             opts.SegmentByDEX,
+		dedupTrades:              opts.DedupTrades,
+		dataReqs:                 opts.DataRequirements,
 		skipNormalization:        opts.SkipNormalization,
+		phases:                   opts.Phases,
+		replaceExistingTrades:    opts.ReplaceExistingTrades,
+		tradeBatchSize:           batchSize,
+		onSimulationProgress:     opts.OnSimulationProgress,
+		simWindowMarginMs:        opts.SimulationWindowMarginMs,
+		onTimeseriesLoad:         opts.OnTimeseriesLoad,
+		observationWindowMs:      opts.ObservationWindowMs,
+		incremental:              opts.Incremental,
+		determinismCheck:         opts.DeterminismCheck,
+		simulationCache:          opts.SimulationCache,
+		newStrategy:              opts.StrategyFactory,
+		now:                      now,
 		verbose:                  opts.Verbose,
 	}
 }
 
 // RunResult contains results from orchestrator execution.
 type RunResult struct {
-	CandidatesProcessed int
-	TradesCreated       int
-	AggregatesCreated   int
-	Errors              []string
+	CandidatesProcessed     int      `json:"candidates_processed"`
+	TradesCreated           int      `json:"trades_created"`
+	TradesSkipped           int      `json:"trades_skipped"` // existing trade_ids left untouched
+	TradesUpdated           int      `json:"trades_updated"` // existing trades replaced because the re-simulated record changed
+	AggregatesCreated       int      `json:"aggregates_created"`
+	AggregatesUpdated       int      `json:"aggregates_updated"`        // aggregates refreshed after trades changed
+	LiquidityEventsRepaired int      `json:"liquidity_events_repaired"` // orphan liquidity events associated in the pre-step
+	LiquidityEventsOrphaned int      `json:"liquidity_events_orphaned"` // liquidity events still without a candidate
+	CandidatesClosed        int      `json:"candidates_closed"`         // candidates whose observation window closed in this run
+	CandidatesAlreadyClosed int      `json:"candidates_already_closed"` // closed in earlier runs, not re-simulated
+	CandidatesUpToDate      int      `json:"candidates_up_to_date"`     // left out by incremental mode: simulated and not dirty
+	CandidatesResimulated   int      `json:"candidates_resimulated"`    // dirty candidates re-simulated and unmarked
+	DecimalsInferred        int      `json:"decimals_inferred"`         // candidates normalized with inferred decimals (no metadata)
+	Errors                  []string `json:"errors,omitempty"`
+	Warnings                []string `json:"warnings,omitempty"` // data integrity warnings, e.g. inferred decimals
+
+	// TradeSync reports the sync of the ClickHouse trade_records mirror and
+	// reconciles its row count with trade_records (see metrics.TradeSyncResult).
+	TradeSync *metrics.TradeSyncResult `json:"trade_sync,omitempty"`
+
+	// DeterminismCheckTrades is the number of trades found identical by the
+	// determinism check (see Options.DeterminismCheck).
+	DeterminismCheckTrades int `json:"determinism_check_trades,omitempty"`
+
+	// SimulationCacheHits and SimulationCacheMisses count the simulations
+	// skipped and run by the simulation cache (see Options.SimulationCache).
+	SimulationCacheHits   int `json:"simulation_cache_hits,omitempty"`
+	SimulationCacheMisses int `json:"simulation_cache_misses,omitempty"`
+
+	// Skipped lists candidate/strategy pairs not simulated because they fail their
+	// DataRequirement. They have no trades, so aggregates exclude them.
+	Skipped []SkippedSimulation `json:"skipped,omitempty"`
+
+	// Phases records every phase of the graph in order, including unselected ones.
+	Phases []PhaseResult `json:"phases"`
 }
 
-// Run executes the full E2E pipeline.
-// Phases:
-//  1. Load candidates
-//  2. Normalize each candidate (create timeseries)
-//  3. Simulate each (candidate, strategy, scenario) combination
-//  4. Aggregate metrics
+// Run executes the E2E pipeline as a graph of named phases (see phases.go):
+//
+//		associate → normalize → simulate → sync → aggregate
+//
+//	  - associate: associate orphan liquidity events with candidates
+//	  - normalize: load candidates (skipping already closed ones with an observation
+//	    window) and create their time series
+//	  - simulate: simulate each (candidate, strategy, scenario) combination, then close
+//	    candidates whose observation window has elapsed
+//	  - sync: with a TradeAggregateStore, mirror the trades written since the last
+//	    sync into it and reconcile row counts (RunResult.TradeSync)
+//	  - aggregate: compute metrics (with SQL over the mirror when a TradeAggregateStore
+//	    is configured, syncing it first if the sync phase did not run)
+//
+// Options.Phases restricts the run to a subset; a selected phase whose dependency did
+// not run checks that the dependency's output is already stored. The result is always
+// returned, with per-phase timing and status in RunResult.Phases, even on error.
+//
+// Cancelling ctx during simulate stops between candidates: trades of every completed
+// candidate are persisted, aggregation is skipped, and the partial result is returned
+// with an error wrapping ctx.Err(). Re-running resumes by skipping the stored trades.
 func (o *Orchestrator) Run(ctx context.Context) (*RunResult, error) {
 	result := &RunResult{}
-
-	// Phase 1: Load all candidates
-	o.log("Phase 1: Loading candidates...")
-	candidates, err := o.loadCandidates(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("phase 1 (load candidates) failed: %w", err)
-	}
-	result.CandidatesProcessed = len(candidates)
-	o.log("  Found %d candidates", len(candidates))
-
-	if len(candidates) == 0 {
-		return result, nil
+	if err := o.runGraph(ctx, result); err != nil {
+		return result, err
 	}
 
-	// Phase 2: Normalization
-	if !o.skipNormalization {
-		o.log("Phase 2: Normalizing candidates...")
-		if err := o.runNormalization(ctx, candidates); err != nil {
-			return nil, fmt.Errorf("phase 2 (normalization) failed: %w", err)
-		}
-		o.log("  Normalized %d candidates", len(candidates))
-	} else {
-		o.log("Phase 2: Skipping normalization (skipNormalization=true)")
-	}
-
-	// Phase 3: Simulation
-	o.log("Phase 3: Running simulations...")
-	tradesCreated, simErrors := o.runSimulations(ctx, candidates)
-	result.TradesCreated = tradesCreated
-	result.Errors = append(result.Errors, simErrors...)
-	o.log("  Created %d trades (%d errors)", tradesCreated, len(simErrors))
-
-	// Phase 4: Metrics Aggregation
-	o.log("Phase 4: Computing aggregates...")
-	aggsCreated, aggErrors := o.runAggregation(ctx)
-	result.AggregatesCreated = aggsCreated
-	result.Errors = append(result.Errors, aggErrors...)
-	o.log("  Created %d aggregates (%d errors)", aggsCreated, len(aggErrors))
-
 	o.log("Pipeline completed: %d candidates, %d trades, %d aggregates",
 		result.CandidatesProcessed, result.TradesCreated, result.AggregatesCreated)
-
 	return result, nil
 }
 
@@ -160,7 +336,9 @@ func (o *Orchestrator) loadCandidates(ctx context.Context) ([]*domain.TokenCandi
 }
 
 // runNormalization normalizes all candidates.
-func (o *Orchestrator) runNormalization(ctx context.Context, candidates []*domain.TokenCandidate) error {
+// Returns one warning per candidate whose decimals had to be inferred.
+// Dirty candidates only get the points of their late events added.
+func (o *Orchestrator) runNormalization(ctx context.Context, candidates []*domain.TokenCandidate, dirty map[string]*domain.DirtyCandidate) ([]string, error) {
 	runner := normalization.NewRunner(
 		o.swapStore,
 		o.liquidityEventStore,
@@ -169,45 +347,143 @@ func (o *Orchestrator) runNormalization(ctx context.Context, candidates []*domai
 		o.volumeTimeseriesStore,
 		o.derivedFeatureStore,
 	)
+	if o.tokenMetadataStore != nil {
+		runner = runner.WithMetadataStore(o.tokenMetadataStore)
+	}
 
+	var warnings []string
 	for _, c := range candidates {
-		if err := runner.NormalizeCandidate(ctx, c.CandidateID); err != nil {
+		var err error
+		switch {
+		case dirty[c.CandidateID] != nil:
+			end := int64(math.MaxInt64)
+			if o.observationWindowMs > 0 {
+				end = c.WindowEnd(o.observationWindowMs)
+			}
+			err = runner.NormalizeCandidateMissing(ctx, c.CandidateID, end)
+		case o.observationWindowMs > 0:
+			err = runner.NormalizeCandidateUntil(ctx, c.CandidateID, c.WindowEnd(o.observationWindowMs))
+		default:
+			err = runner.NormalizeCandidate(ctx, c.CandidateID)
+		}
+		if err != nil {
 			// Skip duplicate key errors (already normalized)
-			if errors.Is(err, storage.ErrDuplicateKey) {
-				continue
+			if !errors.Is(err, storage.ErrDuplicateKey) {
+				return nil, fmt.Errorf("normalize candidate %s: %w", c.CandidateID, err)
 			}
-			return fmt.Errorf("normalize candidate %s: %w", c.CandidateID, err)
+		}
+		if state, ok := runner.State(c.CandidateID); ok && state.Inferred() {
+			warnings = append(warnings, fmt.Sprintf("candidate %s: decimals inferred as %d (%s confidence, no token metadata)",
+				c.CandidateID, state.Decimals, state.Confidence))
 		}
 	}
-	return nil
+	return warnings, nil
+}
+
+// simulationCounts tallies how simulated trades were persisted.
+type simulationCounts struct {
+	created        int
+	skipped        int
+	updated        int
+	candidatesDone int // candidates whose trades are all persisted
+	resimulated    int // dirty candidates whose trades are persisted and mark removed
+	cacheHits      int // simulations skipped: a stored trade has the same input key
+	cacheMisses    int // simulations run with the cache enabled
+
+	insufficient []SkippedSimulation // pairs failing their data requirement
 }
 
 // runSimulations runs all strategy/scenario combinations for all candidates.
-func (o *Orchestrator) runSimulations(ctx context.Context, candidates []*domain.TokenCandidate) (int, []string) {
+// Trades are buffered and written in batches of tradeBatchSize, flushed only between
+// candidates. Trades of dirty candidates replace the stored ones, and the candidates
+// are unmarked once their batch is written. A non-nil error means ctx was cancelled
+// or a batch write failed.
+func (o *Orchestrator) runSimulations(ctx context.Context, candidates []*domain.TokenCandidate, dirty map[string]*domain.DirtyCandidate) (simulationCounts, []string, error) {
 	runner := simulation.NewRunner(simulation.RunnerOptions{
 		CandidateStore:       o.candidateStore,
 		PriceTimeseriesStore: o.priceTimeseriesStore,
 		LiqTimeseriesStore:   o.liquidityTimeseriesStore,
-		TradeRecordStore:     o.tradeRecordStore,
+		WindowMarginMs:       o.simWindowMarginMs,
+		OnLoad:               o.onTimeseriesLoad,
+		EventCountStore:      o.eventCountStore,
+		StrategyFactory:      o.newStrategy,
 	})
 
-	var tradesCreated int
+	var counts simulationCounts
 	var errs []string
+	var pending []*domain.TradeRecord
+	pendingCandidates := 0
+	var pendingDirty []*domain.DirtyCandidate
+	finalTrades := make(map[string]bool)
+
+	flush := func(ctx context.Context) error {
+		if err := o.persistTrades(ctx, pending, finalTrades, &counts); err != nil {
+			return err
+		}
+		for _, d := range pendingDirty {
+			if err := o.dirtyCandidateStore.Unmark(ctx, d.CandidateID, d.MarkedAt); err != nil {
+				return fmt.Errorf("unmark %s: %w", d.CandidateID, err)
+			}
+			counts.resimulated++
+		}
+		counts.candidatesDone += pendingCandidates
+		pending = pending[:0]
+		pendingCandidates = 0
+		pendingDirty = pendingDirty[:0]
+		return nil
+	}
 
-	for _, candidate := range candidates {
+	for i, candidate := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+
+		var trades []*domain.TradeRecord
+		var series *candidateSeries
+		var cache *inputCache
+		if o.simulationCache {
+			var err error
+			if cache, err = o.loadInputCache(ctx, candidate); err != nil {
+				errs = append(errs, fmt.Sprintf("simulation cache %s: %v", candidate.CandidateID, err))
+			}
+		}
 		for _, strategyCfg := range o.strategyConfigs {
 			// Skip if entry event type doesn't match candidate source
 			if !sourceMatches(candidate.Source, strategyCfg.EntryEventType) {
 				continue
 			}
 
+			if req, ok := o.dataReqs[strategyCfg.StrategyType]; ok {
+				if series == nil {
+					var err error
+					if series, err = o.loadSeries(ctx, candidate.CandidateID); err != nil {
+						errs = append(errs, fmt.Sprintf("simulate %s/%s: %v", candidate.CandidateID, strategyCfg.StrategyType, err))
+						continue
+					}
+				}
+				if detail, ok := checkDataRequirement(req, strategyCfg, candidate.DiscoveredAt, series.prices, series.liquidity); !ok {
+					counts.insufficient = append(counts.insufficient, SkippedSimulation{
+						CandidateID:  candidate.CandidateID,
+						StrategyType: strategyCfg.StrategyType,
+						Reason:       SkipReasonInsufficientData,
+						Detail:       detail,
+					})
+					continue
+				}
+			}
+
 			for _, scenarioCfg := range o.scenarioConfigs {
-				_, err := runner.Run(ctx, candidate.CandidateID, strategyCfg, scenarioCfg)
-				if err != nil {
-					// Skip duplicate key errors (already simulated)
-					if errors.Is(err, storage.ErrDuplicateKey) {
+				var key string
+				if cache != nil {
+					key = simulation.InputKey(cache.fingerprint, strategyCfg, scenarioCfg)
+					if cache.stored[key] {
+						counts.cacheHits++
 						continue
 					}
+					counts.cacheMisses++
+				}
+				trade, err := runner.Run(ctx, candidate.CandidateID, strategyCfg, scenarioCfg)
+				if err != nil {
 					// Skip source mismatch (expected for some combinations)
 					if errors.Is(err, simulation.ErrSourceMismatch) {
 						continue
@@ -216,31 +492,288 @@ func (o *Orchestrator) runSimulations(ctx context.Context, candidates []*domain.
 						candidate.CandidateID, strategyCfg.StrategyType, scenarioCfg.ScenarioID, err))
 					continue
 				}
-				tradesCreated++
+				trade.InputKey = key
+				trades = append(trades, trade)
+			}
+		}
+
+		// A candidate interrupted mid-simulation is dropped so the stored prefix stays whole
+		if ctx.Err() != nil {
+			break
+		}
+		d := dirty[candidate.CandidateID]
+		if o.expired(candidate) || d != nil {
+			// Final simulation of the window, or re-simulation with late data:
+			// it replaces whatever earlier runs stored
+			for _, t := range trades {
+				finalTrades[t.TradeID] = true
+			}
+		}
+		if d != nil {
+			pendingDirty = append(pendingDirty, d)
+		}
+		pending = append(pending, trades...)
+		pendingCandidates++
+
+		if len(pending) >= o.tradeBatchSize || i == len(candidates)-1 {
+			if err := flush(ctx); err != nil {
+				return counts, errs, fmt.Errorf("persist trades: %w", err)
 			}
 		}
+		o.reportSimulationProgress(counts.candidatesDone+pendingCandidates, len(candidates), counts)
 	}
 
-	return tradesCreated, errs
+	if err := ctx.Err(); err != nil {
+		// Keep completed candidates even though ctx is done
+		if flushErr := flush(context.WithoutCancel(ctx)); flushErr != nil {
+			return counts, errs, fmt.Errorf("persist trades after cancellation: %w", flushErr)
+		}
+		o.reportSimulationProgress(counts.candidatesDone, len(candidates), counts)
+		return counts, errs, err
+	}
+
+	return counts, errs, nil
 }
 
-// runAggregation computes aggregates for all strategy/scenario/entry combinations.
-func (o *Orchestrator) runAggregation(ctx context.Context) (int, []string) {
-	aggregator := metrics.NewAggregator(
+// inputCache holds what the simulation cache needs for one candidate.
+type inputCache struct {
+	fingerprint string          // replay fingerprint of the candidate's events
+	stored      map[string]bool // input keys of the candidate's stored trades
+}
+
+// loadInputCache fingerprints the candidate's events, up to its window end with
+// an observation window, and collects the input keys of its stored trades.
+// With ReplaceExistingTrades no stored key is collected, so every simulation
+// misses and replaces its trade.
+func (o *Orchestrator) loadInputCache(ctx context.Context, c *domain.TokenCandidate) (*inputCache, error) {
+	end := int64(math.MaxInt64)
+	if o.observationWindowMs > 0 {
+		end = c.WindowEnd(o.observationWindowMs)
+	}
+	fingerprint, err := o.eventFingerprint(ctx, c.CandidateID, end)
+	if err != nil {
+		return nil, err
+	}
+	cache := &inputCache{fingerprint: fingerprint, stored: make(map[string]bool)}
+	if o.replaceExistingTrades {
+		return cache, nil
+	}
+	trades, err := o.tradeRecordStore.GetByCandidateID(ctx, c.CandidateID)
+	if err != nil {
+		return nil, fmt.Errorf("load trades: %w", err)
+	}
+	for _, t := range trades {
+		if t.InputKey != "" {
+			cache.stored[t.InputKey] = true
+		}
+	}
+	return cache, nil
+}
+
+// eventFingerprint returns the replay fingerprint of the candidate's swaps and
+// liquidity events before end.
+func (o *Orchestrator) eventFingerprint(ctx context.Context, candidateID string, end int64) (string, error) {
+	swaps, err := o.swapStore.GetByTimeRange(ctx, candidateID, math.MinInt64, end)
+	if err != nil {
+		return "", fmt.Errorf("load swaps: %w", err)
+	}
+	var liquidity []*domain.LiquidityEvent
+	if o.liquidityEventStore != nil {
+		liquidity, err = o.liquidityEventStore.GetByTimeRange(ctx, candidateID, math.MinInt64, end)
+		if err != nil {
+			return "", fmt.Errorf("load liquidity events: %w", err)
+		}
+	}
+	return dossier.ReplayFingerprint(replay.MergeEvents(swaps, liquidity)), nil
+}
+
+// candidateSeries holds a candidate's time series for data requirement checks.
+type candidateSeries struct {
+	prices    []*domain.PriceTimeseriesPoint
+	liquidity []*domain.LiquidityTimeseriesPoint
+}
+
+// loadSeries loads a candidate's price and liquidity time series.
+func (o *Orchestrator) loadSeries(ctx context.Context, candidateID string) (*candidateSeries, error) {
+	prices, err := o.priceTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("load price timeseries: %w", err)
+	}
+	liquidity, err := o.liquidityTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("load liquidity timeseries: %w", err)
+	}
+	return &candidateSeries{prices: prices, liquidity: liquidity}, nil
+}
+
+// reportSimulationProgress invokes the progress callback, if any.
+func (o *Orchestrator) reportSimulationProgress(done, total int, counts simulationCounts) {
+	if o.onSimulationProgress == nil {
+		return
+	}
+	o.onSimulationProgress(SimulationProgress{
+		CandidatesDone:  done,
+		CandidatesTotal: total,
+		TradesWritten:   counts.created + counts.updated,
+	})
+}
+
+// persistTrades writes a batch with one bulk insert. If any trade_id already exists the
+// bulk insert is rejected as a whole, and each trade is persisted individually instead.
+// Trades in final are replaced when they differ, regardless of ReplaceExistingTrades.
+func (o *Orchestrator) persistTrades(ctx context.Context, trades []*domain.TradeRecord, final map[string]bool, counts *simulationCounts) error {
+	if len(trades) == 0 {
+		return nil
+	}
+	err := o.tradeRecordStore.InsertBulk(ctx, trades)
+	if err == nil {
+		counts.created += len(trades)
+		return nil
+	}
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		return err
+	}
+
+	for _, trade := range trades {
+		if err := o.persistTrade(ctx, trade, final[trade.TradeID], counts); err != nil {
+			return fmt.Errorf("trade %s: %w", trade.TradeID, err)
+		}
+	}
+	return nil
+}
+
+// persistTrade inserts a simulated trade. An existing trade_id is skipped, or replaced
+// when the stored record differs and either ReplaceExistingTrades or replace is set.
+// A stored record equal but for its input key gets the trade's key, so the
+// simulation cache hits on it next time.
+func (o *Orchestrator) persistTrade(ctx context.Context, trade *domain.TradeRecord, replace bool, counts *simulationCounts) error {
+	err := o.tradeRecordStore.Insert(ctx, trade)
+	if err == nil {
+		counts.created++
+		return nil
+	}
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		return err
+	}
+
+	if !o.replaceExistingTrades && !replace && trade.InputKey == "" {
+		counts.skipped++
+		return nil
+	}
+
+	existing, err := o.tradeRecordStore.GetByID(ctx, trade.TradeID)
+	if err != nil {
+		return err
+	}
+	if sameTrade(existing, trade) {
+		if trade.InputKey != "" && existing.InputKey != trade.InputKey {
+			if err := o.tradeRecordStore.Upsert(ctx, trade); err != nil {
+				return err
+			}
+		}
+		counts.skipped++
+		return nil
+	}
+	if !o.replaceExistingTrades && !replace {
+		counts.skipped++
+		return nil
+	}
+
+	if err := o.tradeRecordStore.Upsert(ctx, trade); err != nil {
+		return err
+	}
+	counts.updated++
+	return nil
+}
+
+// sameTrade reports whether two records of a trade are equal, ignoring their input keys.
+func sameTrade(a, b *domain.TradeRecord) bool {
+	aa := *a
+	aa.InputKey = b.InputKey
+	return reflect.DeepEqual(&aa, b)
+}
+
+// newAggregator returns the aggregate computer of the aggregate phase. With a
+// TradeAggregateStore configured it is the SQL aggregator synced by the sync
+// phase, or synced here when the sync phase did not run.
+func (o *Orchestrator) newAggregator(ctx context.Context, st *runState) (metrics.AggregateComputer, error) {
+	closedOnly := o.observationWindowMs > 0
+	if o.tradeAggregateStore == nil {
+		return metrics.NewAggregator(
+			o.tradeRecordStore,
+			o.strategyAggregateStore,
+			o.candidateStore,
+		).WithEntryFilters(o.entryFilters).WithClosedOnly(closedOnly).WithDedup(o.dedupTrades), nil
+	}
+	if len(o.entryFilters) > 0 {
+		o.log("  Entry filters are not supported by SQL aggregation, skipping %d labeled aggregates", len(o.entryFilters))
+	}
+	if o.segmentByDEX {
+		o.log("  DEX segmentation is not supported by SQL aggregation, skipping per-DEX aggregates")
+	}
+	if o.dedupTrades {
+		o.log("  Trade deduplication is not supported by SQL aggregation, skipping dedup aggregates")
+	}
+
+	if st.mirror != nil {
+		return st.mirror, nil
+	}
+	aggregator := o.newSQLAggregator()
+	synced, err := aggregator.Sync(ctx)
+	st.result.TradeSync = synced
+	if err != nil {
+		return nil, err
+	}
+	o.log("  Mirrored %d trades for SQL aggregation", synced.Mirrored)
+	return aggregator, nil
+}
+
+// newSQLAggregator returns a SQL aggregator over the trade_records mirror.
+func (o *Orchestrator) newSQLAggregator() *metrics.SQLAggregator {
+	return metrics.NewSQLAggregator(
 		o.tradeRecordStore,
+		o.tradeAggregateStore,
 		o.strategyAggregateStore,
 		o.candidateStore,
-	)
+	).WithClosedOnly(o.observationWindowMs > 0)
+}
 
+// runAggregation computes aggregates for all strategy/scenario/entry combinations.
+// With refresh set, existing aggregates are overwritten instead of skipped.
+func (o *Orchestrator) runAggregation(ctx context.Context, aggregator metrics.AggregateComputer, refresh bool) (int, []string) {
 	var aggsCreated int
 	var errs []string
 
 	entryTypes := []string{"NEW_TOKEN", "ACTIVE_TOKEN"}
+	if o.tradeAggregateStore == nil {
+		for _, f := range o.entryFilters {
+			entryTypes = append(entryTypes,
+				metrics.LabeledEntryEventType("NEW_TOKEN", f.Label),
+				metrics.LabeledEntryEventType("ACTIVE_TOKEN", f.Label))
+		}
+		if o.segmentByDEX {
+			for _, dex := range domain.KnownDEXes {
+				entryTypes = append(entryTypes,
+					metrics.LabeledEntryEventType("NEW_TOKEN", metrics.DEXCohortLabel(dex)),
+					metrics.LabeledEntryEventType("ACTIVE_TOKEN", metrics.DEXCohortLabel(dex)))
+			}
+		}
+		if o.dedupTrades {
+			entryTypes = append(entryTypes,
+				metrics.LabeledEntryEventType("NEW_TOKEN", metrics.DedupLabel),
+				metrics.LabeledEntryEventType("ACTIVE_TOKEN", metrics.DedupLabel))
+		}
+	}
 
 	for _, strategyCfg := range o.strategyConfigs {
 		for _, scenarioCfg := range o.scenarioConfigs {
 			for _, entryType := range entryTypes {
-				_, err := aggregator.ComputeAndStore(ctx, strategyCfg.StrategyType, scenarioCfg.ScenarioID, entryType)
+				var err error
+				if refresh {
+					err = o.refreshAggregate(ctx, aggregator, strategyCfg.StrategyType, scenarioCfg.ScenarioID, entryType)
+				} else {
+					_, err = aggregator.ComputeAndStore(ctx, strategyCfg.StrategyType, scenarioCfg.ScenarioID, entryType)
+				}
 				if err != nil {
 					// Skip duplicate key errors (already aggregated)
 					if errors.Is(err, storage.ErrDuplicateKey) {
@@ -262,6 +795,52 @@ func (o *Orchestrator) runAggregation(ctx context.Context) (int, []string) {
 	return aggsCreated, errs
 }
 
+// refreshAggregate recomputes an aggregate and overwrites the stored one.
+func (o *Orchestrator) refreshAggregate(ctx context.Context, aggregator metrics.AggregateComputer, strategyID, scenarioID, entryEventType string) error {
+	agg, err := aggregator.ComputeAggregate(ctx, strategyID, scenarioID, entryEventType)
+	if err != nil {
+		return err
+	}
+	return o.strategyAggregateStore.Upsert(ctx, agg)
+}
+
+// expired reports whether the candidate's observation window has elapsed.
+func (o *Orchestrator) expired(c *domain.TokenCandidate) bool {
+	return o.observationWindowMs > 0 && c.WindowEnd(o.observationWindowMs) <= o.now().UnixMilli()
+}
+
+// closeExpired marks every candidate past its observation window CLOSED, freezing
+// the replay fingerprint of the events inside the window. Trades were already
+// written by the final simulation in phase 3.
+func (o *Orchestrator) closeExpired(ctx context.Context, candidates []*domain.TokenCandidate) (int, error) {
+	closed := 0
+	for _, c := range candidates {
+		if !o.expired(c) {
+			continue
+		}
+		fingerprint, err := o.eventFingerprint(ctx, c.CandidateID, c.WindowEnd(o.observationWindowMs))
+		if err != nil {
+			return closed, fmt.Errorf("candidate %s: %w", c.CandidateID, err)
+		}
+		if err := o.candidateStore.Close(ctx, c.CandidateID, o.now().UnixMilli(), fingerprint); err != nil {
+			return closed, fmt.Errorf("candidate %s: %w", c.CandidateID, err)
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+// splitClosed drops closed candidates, returning the open ones and the number dropped.
+func splitClosed(candidates []*domain.TokenCandidate) ([]*domain.TokenCandidate, int) {
+	open := make([]*domain.TokenCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if !c.IsClosed() {
+			open = append(open, c)
+		}
+	}
+	return open, len(candidates) - len(open)
+}
+
 // sourceMatches checks if candidate source matches entry event type.
 func sourceMatches(source domain.Source, entryEventType string) bool {
 	switch entryEventType {
@@ -3,11 +3,16 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
+	"solana-token-lab/internal/replay"
 	"solana-token-lab/internal/storage/memory"
+	"solana-token-lab/internal/storage/tracked"
 )
 
 func TestOrchestrator_Run_EmptyCandidates(t *testing.T) {
@@ -404,3 +409,749 @@ func createTestStores() *testStores {
 		strategyAggregateStore:   memory.NewStrategyAggregateStore(),
 	}
 }
+
+// rerunOrchestrator runs TIME_EXIT over the given exit price for a fixed candidate,
+// reusing trade and aggregate stores across runs.
+func rerunOrchestrator(t *testing.T, stores *testStores, exitPrice float64, replace bool) *RunResult {
+	t.Helper()
+	ctx := context.Background()
+
+	// Resetting timeseries between runs simulates a data fix
+	priceStore := stores.priceTimeseriesStore
+	liqStore := stores.liquidityTimeseriesStore
+	if err := priceStore.Clear(ctx); err != nil {
+		t.Fatalf("clear prices: %v", err)
+	}
+	if err := liqStore.Clear(ctx); err != nil {
+		t.Fatalf("clear liquidity: %v", err)
+	}
+	if err := priceStore.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+		{CandidateID: "rerun-candidate", TimestampMs: 1000000, Slot: 100, Price: 1.0},
+		{CandidateID: "rerun-candidate", TimestampMs: 1300000, Slot: 200, Price: exitPrice},
+		{CandidateID: "rerun-candidate", TimestampMs: 1600000, Slot: 300, Price: exitPrice},
+	}); err != nil {
+		t.Fatalf("insert prices: %v", err)
+	}
+	if err := liqStore.InsertBulk(ctx, []*domain.LiquidityTimeseriesPoint{
+		{CandidateID: "rerun-candidate", TimestampMs: 1000000, Slot: 100, Liquidity: 10000},
+	}); err != nil {
+		t.Fatalf("insert liquidity: %v", err)
+	}
+
+	holdDuration := int64(300000)
+	orch := New(Options{
+		CandidateStore:           stores.candidateStore,
+		PriceTimeseriesStore:     priceStore,
+		LiquidityTimeseriesStore: liqStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs:       []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+		SkipNormalization:     true,
+		ReplaceExistingTrades: replace,
+	})
+
+	result, err := orch.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	return result
+}
+
+func TestOrchestrator_Run_ReplaceExistingTrades(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+
+	if err := stores.candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID:  "rerun-candidate",
+		Source:       domain.SourceNewToken,
+		Mint:         "rerun-mint",
+		TxSignature:  "rerun-tx",
+		Slot:         100,
+		DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+
+	first := rerunOrchestrator(t, stores, 2.0, false)
+	if first.TradesCreated != 1 || first.AggregatesCreated != 1 {
+		t.Fatalf("expected 1 trade and 1 aggregate created, got %+v", first)
+	}
+	trades, _ := stores.tradeRecordStore.GetAll(ctx)
+	originalOutcome := trades[0].Outcome
+	originalAgg, err := stores.strategyAggregateStore.GetByKey(ctx, domain.StrategyTypeTimeExit, domain.ScenarioConfigRealistic.ScenarioID, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("GetByKey failed: %v", err)
+	}
+
+	// Price data fixed, but replacement disabled: collision skipped, outcome untouched
+	skipped := rerunOrchestrator(t, stores, 0.5, false)
+	if skipped.TradesCreated != 0 || skipped.TradesSkipped != 1 || skipped.TradesUpdated != 0 {
+		t.Errorf("expected 1 skipped trade, got %+v", skipped)
+	}
+	trades, _ = stores.tradeRecordStore.GetAll(ctx)
+	if trades[0].Outcome != originalOutcome {
+		t.Errorf("outcome changed without ReplaceExistingTrades: %f -> %f", originalOutcome, trades[0].Outcome)
+	}
+
+	// Replacement enabled: outcome refreshed and aggregate recomputed
+	replaced := rerunOrchestrator(t, stores, 0.5, true)
+	if replaced.TradesUpdated != 1 || replaced.AggregatesUpdated != 1 {
+		t.Errorf("expected 1 updated trade and 1 refreshed aggregate, got %+v", replaced)
+	}
+	trades, _ = stores.tradeRecordStore.GetAll(ctx)
+	if len(trades) != 1 {
+		t.Fatalf("expected trade replaced in place, got %d trades", len(trades))
+	}
+	if trades[0].Outcome >= originalOutcome || trades[0].OutcomeClass != domain.OutcomeClassLoss {
+		t.Errorf("expected losing outcome after price fix, got %f (%s)", trades[0].Outcome, trades[0].OutcomeClass)
+	}
+	agg, err := stores.strategyAggregateStore.GetByKey(ctx, domain.StrategyTypeTimeExit, domain.ScenarioConfigRealistic.ScenarioID, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("GetByKey failed: %v", err)
+	}
+	if agg.OutcomeMean == originalAgg.OutcomeMean || agg.OutcomeMean != trades[0].Outcome {
+		t.Errorf("expected aggregate mean %f, got %f (was %f)", trades[0].Outcome, agg.OutcomeMean, originalAgg.OutcomeMean)
+	}
+
+	// Same data again: nothing changed, aggregates left alone
+	again := rerunOrchestrator(t, stores, 0.5, true)
+	if again.TradesUpdated != 0 || again.TradesSkipped != 1 || again.AggregatesUpdated != 0 {
+		t.Errorf("expected unchanged re-run, got %+v", again)
+	}
+}
+
+// seedSimulationCandidates inserts n NEW_TOKEN candidates with enough price and
+// liquidity history for one TIME_EXIT trade each.
+func seedSimulationCandidates(t *testing.T, stores *testStores, n int) {
+	t.Helper()
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("cand-%02d", i)
+		base := int64(1000000 + i*1000)
+		if err := stores.candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID:  id,
+			Source:       domain.SourceNewToken,
+			Mint:         "mint-" + id,
+			TxSignature:  "tx-" + id,
+			Slot:         100,
+			DiscoveredAt: base,
+		}); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+		if err := stores.priceTimeseriesStore.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+			{CandidateID: id, TimestampMs: base, Slot: 100, Price: 1.0},
+			{CandidateID: id, TimestampMs: base + 300000, Slot: 200, Price: 1.5},
+			{CandidateID: id, TimestampMs: base + 600000, Slot: 300, Price: 1.5},
+		}); err != nil {
+			t.Fatalf("insert prices: %v", err)
+		}
+		if err := stores.liquidityTimeseriesStore.InsertBulk(ctx, []*domain.LiquidityTimeseriesPoint{
+			{CandidateID: id, TimestampMs: base, Slot: 100, Liquidity: 10000},
+		}); err != nil {
+			t.Fatalf("insert liquidity: %v", err)
+		}
+	}
+}
+
+func newSimulationOrchestrator(stores *testStores, batchSize int, onProgress func(SimulationProgress)) *Orchestrator {
+	holdDuration := int64(300000)
+	return New(Options{
+		CandidateStore:           stores.candidateStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs:      []domain.ScenarioConfig{domain.ScenarioConfigOptimistic, domain.ScenarioConfigRealistic},
+		SkipNormalization:    true,
+		TradeBatchSize:       batchSize,
+		OnSimulationProgress: onProgress,
+	})
+}
+
+func TestOrchestrator_Run_CancelAndResume(t *testing.T) {
+	const total, cancelAfter = 6, 3
+	stores := createTestStores()
+	seedSimulationCandidates(t, stores, total)
+
+	// Batch larger than the run so the only mid-run write is the cancellation flush
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var progress []SimulationProgress
+	orch := newSimulationOrchestrator(stores, 100, func(p SimulationProgress) {
+		progress = append(progress, p)
+		if p.CandidatesDone == cancelAfter {
+			cancel()
+		}
+	})
+
+	result, err := orch.Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if result == nil || result.TradesCreated != cancelAfter*2 || result.AggregatesCreated != 0 {
+		t.Fatalf("expected %d trades and no aggregates, got %+v", cancelAfter*2, result)
+	}
+
+	trades, _ := stores.tradeRecordStore.GetAll(context.Background())
+	stored := make(map[string]int)
+	for _, tr := range trades {
+		stored[tr.CandidateID]++
+	}
+	if len(stored) != cancelAfter {
+		t.Fatalf("expected trades for exactly %d candidates, got %v", cancelAfter, stored)
+	}
+	for i := 0; i < cancelAfter; i++ {
+		if n := stored[fmt.Sprintf("cand-%02d", i)]; n != 2 {
+			t.Errorf("candidate %d: expected 2 trades, got %d", i, n)
+		}
+	}
+	last := progress[len(progress)-1]
+	if last.CandidatesDone != cancelAfter || last.CandidatesTotal != total || last.TradesWritten != cancelAfter*2 {
+		t.Errorf("unexpected final progress: %+v", last)
+	}
+
+	// Resume: completed candidates are skipped, the rest are written once
+	resumed, err := newSimulationOrchestrator(stores, 100, nil).Run(context.Background())
+	if err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if resumed.TradesSkipped != cancelAfter*2 || resumed.TradesCreated != (total-cancelAfter)*2 {
+		t.Errorf("expected %d skipped and %d created on resume, got %+v", cancelAfter*2, (total-cancelAfter)*2, resumed)
+	}
+	trades, _ = stores.tradeRecordStore.GetAll(context.Background())
+	if len(trades) != total*2 {
+		t.Errorf("expected %d trades after resume, got %d", total*2, len(trades))
+	}
+}
+
+func TestOrchestrator_Run_SimulationProgressBatches(t *testing.T) {
+	const total = 5
+	stores := createTestStores()
+	seedSimulationCandidates(t, stores, total)
+
+	var progress []SimulationProgress
+	result, err := newSimulationOrchestrator(stores, 3, func(p SimulationProgress) {
+		progress = append(progress, p)
+	}).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.TradesCreated != total*2 {
+		t.Fatalf("expected %d trades, got %d", total*2, result.TradesCreated)
+	}
+
+	if len(progress) != total {
+		t.Fatalf("expected one progress report per candidate, got %d", len(progress))
+	}
+	// Two trades per candidate with a batch of 3: writes land after candidates 2, 4 and 5
+	wantWritten := []int{0, 4, 4, 8, 10}
+	for i, p := range progress {
+		if p.CandidatesDone != i+1 || p.CandidatesTotal != total || p.TradesWritten != wantWritten[i] {
+			t.Errorf("progress[%d] = %+v, want done=%d written=%d", i, p, i+1, wantWritten[i])
+		}
+	}
+}
+
+const (
+	windowBase = int64(1000000000)
+	windowMs   = int64(3600000) // 1h
+)
+
+// seedWindowCandidate inserts a NEW_TOKEN candidate discovered at windowBase with
+// swaps and liquidity inside a 1h observation window.
+func seedWindowCandidate(t *testing.T, stores *testStores) {
+	t.Helper()
+	ctx := context.Background()
+	if err := stores.candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID:  "window-candidate",
+		Source:       domain.SourceNewToken,
+		Mint:         "window-mint",
+		TxSignature:  "window-tx",
+		Slot:         100,
+		DiscoveredAt: windowBase,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+	addWindowSwap(t, stores, "swap-1", 100, windowBase, 1.0)
+	addWindowSwap(t, stores, "swap-2", 200, windowBase+600000, 1.5) // +10 min
+	if err := stores.liquidityEventStore.Insert(ctx, &domain.LiquidityEvent{
+		CandidateID:    "window-candidate",
+		TxSignature:    "liq-1",
+		Slot:           100,
+		Timestamp:      windowBase,
+		EventType:      domain.LiquidityEventAdd,
+		AmountToken:    10000,
+		AmountQuote:    100,
+		LiquidityAfter: 10100,
+	}); err != nil {
+		t.Fatalf("insert liquidity event: %v", err)
+	}
+}
+
+func addWindowSwap(t *testing.T, stores *testStores, sig string, slot, ts int64, price float64) {
+	t.Helper()
+	if err := stores.swapStore.Insert(context.Background(), &domain.Swap{
+		CandidateID: "window-candidate",
+		TxSignature: sig,
+		Slot:        slot,
+		Timestamp:   ts,
+		Side:        domain.SwapSideBuy,
+		AmountIn:    100,
+		AmountOut:   100 / price,
+		Price:       price,
+	}); err != nil {
+		t.Fatalf("insert swap: %v", err)
+	}
+}
+
+// newWindowOrchestrator runs a 90 min TIME_EXIT, longer than the window, so
+// that any late price would become the exit price. windowMs 0 disables closure.
+func newWindowOrchestrator(stores *testStores, window int64, now time.Time, replace bool) *Orchestrator {
+	holdDuration := int64(5400000) // 90 min
+	return New(Options{
+		CandidateStore:           stores.candidateStore,
+		SwapStore:                stores.swapStore,
+		LiquidityEventStore:      stores.liquidityEventStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		VolumeTimeseriesStore:    stores.volumeTimeseriesStore,
+		DerivedFeatureStore:      stores.derivedFeatureStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs:       []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+		ReplaceExistingTrades: replace,
+		ObservationWindowMs:   window,
+		Now:                   func() time.Time { return now },
+	})
+}
+
+func onlyTrade(t *testing.T, stores *testStores) *domain.TradeRecord {
+	t.Helper()
+	trades, err := stores.tradeRecordStore.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("get trades: %v", err)
+	}
+	if len(trades) != 1 {
+		t.Fatalf("expected 1 trade, got %d", len(trades))
+	}
+	return trades[0]
+}
+
+func TestOrchestrator_Run_ObservationWindowClosure(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+	seedWindowCandidate(t, stores)
+
+	// 30 min in: still observing, trade written but not decision-grade yet
+	open, err := newWindowOrchestrator(stores, windowMs, time.UnixMilli(windowBase+1800000), false).Run(ctx)
+	if err != nil {
+		t.Fatalf("open run: %v", err)
+	}
+	if open.TradesCreated != 1 || open.CandidatesClosed != 0 || open.AggregatesCreated != 0 {
+		t.Errorf("expected 1 trade, no closure and no aggregates, got %+v", open)
+	}
+	cand, _ := stores.candidateStore.GetByID(ctx, "window-candidate")
+	if cand.IsClosed() {
+		t.Fatalf("candidate closed inside its window")
+	}
+	before := onlyTrade(t, stores)
+
+	// A late swap arrives after the window end
+	addWindowSwap(t, stores, "swap-late-1", 300, windowBase+windowMs+600000, 10.0)
+
+	// 2h in: the window has elapsed, the candidate closes and joins the aggregates
+	closing, err := newWindowOrchestrator(stores, windowMs, time.UnixMilli(windowBase+2*windowMs), false).Run(ctx)
+	if err != nil {
+		t.Fatalf("closing run: %v", err)
+	}
+	if closing.CandidatesClosed != 1 || closing.AggregatesUpdated != 1 {
+		t.Errorf("expected 1 closed candidate and 1 aggregate, got %+v", closing)
+	}
+	cand, _ = stores.candidateStore.GetByID(ctx, "window-candidate")
+	if !cand.IsClosed() || cand.ClosedAt == nil || *cand.ClosedAt != windowBase+2*windowMs {
+		t.Fatalf("expected candidate closed at %d, got %+v", windowBase+2*windowMs, cand)
+	}
+
+	swaps, _ := stores.swapStore.GetByTimeRange(ctx, "window-candidate", 0, windowBase+windowMs)
+	liquidity, _ := stores.liquidityEventStore.GetByCandidateID(ctx, "window-candidate")
+	if want := dossier.ReplayFingerprint(replay.MergeEvents(swaps, liquidity)); cand.ReplayFingerprint != want {
+		t.Errorf("fingerprint %q does not cover the window events only (want %q)", cand.ReplayFingerprint, want)
+	}
+	closed := onlyTrade(t, stores)
+	if closed.ExitSignalPrice != 1.5 || closed.Outcome != before.Outcome {
+		t.Errorf("late swap changed the trade: exit price %f, outcome %f -> %f",
+			closed.ExitSignalPrice, before.Outcome, closed.Outcome)
+	}
+	agg, err := stores.strategyAggregateStore.GetByKey(ctx, domain.StrategyTypeTimeExit, domain.ScenarioConfigRealistic.ScenarioID, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("aggregate missing after closure: %v", err)
+	}
+	if agg.TotalTrades != 1 {
+		t.Errorf("expected aggregate over 1 trade, got %d", agg.TotalTrades)
+	}
+
+	// Closed candidates are frozen: even rebuilt timeseries with replacement enabled leave them alone
+	addWindowSwap(t, stores, "swap-late-2", 400, windowBase+windowMs+1200000, 20.0)
+	if err := stores.priceTimeseriesStore.Clear(ctx); err != nil {
+		t.Fatalf("clear prices: %v", err)
+	}
+	later, err := newWindowOrchestrator(stores, windowMs, time.UnixMilli(windowBase+3*windowMs), true).Run(ctx)
+	if err != nil {
+		t.Fatalf("later run: %v", err)
+	}
+	if later.CandidatesAlreadyClosed != 1 || later.CandidatesProcessed != 0 || later.TradesUpdated != 0 {
+		t.Errorf("expected closed candidate skipped, got %+v", later)
+	}
+	if after := onlyTrade(t, stores); after.Outcome != closed.Outcome {
+		t.Errorf("closed trade changed: %f -> %f", closed.Outcome, after.Outcome)
+	}
+	frozen, _ := stores.candidateStore.GetByID(ctx, "window-candidate")
+	if frozen.ReplayFingerprint != cand.ReplayFingerprint || *frozen.ClosedAt != *cand.ClosedAt {
+		t.Errorf("closure changed on a later run: %+v", frozen)
+	}
+}
+
+func TestOrchestrator_Run_ObservationWindowExcludesLateEvents(t *testing.T) {
+	ctx := context.Background()
+	late := windowBase + windowMs + 600000
+
+	// Without a window the late swap is the 90 min exit price
+	unbounded := createTestStores()
+	seedWindowCandidate(t, unbounded)
+	addWindowSwap(t, unbounded, "swap-late-1", 300, late, 10.0)
+	if _, err := newWindowOrchestrator(unbounded, 0, time.UnixMilli(windowBase+2*windowMs), false).Run(ctx); err != nil {
+		t.Fatalf("unbounded run: %v", err)
+	}
+	if got := onlyTrade(t, unbounded).ExitSignalPrice; got != 10.0 {
+		t.Fatalf("expected unbounded exit at the late price 10.0, got %f", got)
+	}
+
+	// First seen after its window: normalized and simulated on in-window events only
+	bounded := createTestStores()
+	seedWindowCandidate(t, bounded)
+	addWindowSwap(t, bounded, "swap-late-1", 300, late, 10.0)
+	result, err := newWindowOrchestrator(bounded, windowMs, time.UnixMilli(windowBase+2*windowMs), false).Run(ctx)
+	if err != nil {
+		t.Fatalf("bounded run: %v", err)
+	}
+	if result.CandidatesClosed != 1 {
+		t.Errorf("expected candidate closed, got %+v", result)
+	}
+	if got := onlyTrade(t, bounded).ExitSignalPrice; got != 1.5 {
+		t.Errorf("expected exit at the last in-window price 1.5, got %f", got)
+	}
+	prices, _ := bounded.priceTimeseriesStore.GetByCandidateID(ctx, "window-candidate")
+	for _, p := range prices {
+		if p.TimestampMs > windowBase+windowMs {
+			t.Errorf("price point after the window end: %+v", p)
+		}
+	}
+}
+
+func TestOrchestrator_Run_InferredDecimalsWarning(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+	metadataStore := memory.NewTokenMetadataStore()
+
+	for _, id := range []string{"with-meta", "no-meta"} {
+		if err := stores.candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID:  id,
+			Source:       domain.SourceNewToken,
+			Mint:         "mint-" + id,
+			TxSignature:  "tx-" + id,
+			Slot:         100,
+			DiscoveredAt: 1000000,
+		}); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+		if err := stores.swapStore.Insert(ctx, &domain.Swap{
+			CandidateID: id,
+			TxSignature: "swap-" + id,
+			Slot:        100,
+			Timestamp:   1000000,
+			Side:        domain.SwapSideBuy,
+			AmountIn:    1,
+			AmountOut:   250_000e6,
+			Price:       1.0,
+		}); err != nil {
+			t.Fatalf("insert swap: %v", err)
+		}
+	}
+	if err := metadataStore.Insert(ctx, &domain.TokenMetadata{CandidateID: "with-meta", Mint: "mint-with-meta", Decimals: 6}); err != nil {
+		t.Fatalf("insert metadata: %v", err)
+	}
+
+	result, err := New(Options{
+		CandidateStore:           stores.candidateStore,
+		SwapStore:                stores.swapStore,
+		LiquidityEventStore:      stores.liquidityEventStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		VolumeTimeseriesStore:    stores.volumeTimeseriesStore,
+		DerivedFeatureStore:      stores.derivedFeatureStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		TokenMetadataStore:       metadataStore,
+	}).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.DecimalsInferred != 1 {
+		t.Errorf("DecimalsInferred = %d, want 1", result.DecimalsInferred)
+	}
+	want := "candidate no-meta: decimals inferred as 6 (high confidence, no token metadata)"
+	if len(result.Warnings) != 1 || result.Warnings[0] != want {
+		t.Errorf("Warnings = %q, want [%q]", result.Warnings, want)
+	}
+}
+
+func TestOrchestrator_Run_SkipsInsufficientLiquidityData(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+
+	// cand-00 has a single liquidity point; "dense" has one per minute
+	seedSimulationCandidates(t, stores, 1)
+	if err := stores.candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID:  "dense",
+		Source:       domain.SourceNewToken,
+		Mint:         "mint-dense",
+		TxSignature:  "tx-dense",
+		Slot:         100,
+		DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+	var prices []*domain.PriceTimeseriesPoint
+	var liquidity []*domain.LiquidityTimeseriesPoint
+	for i := int64(0); i < 10; i++ {
+		ts := 1000000 + i*60000
+		prices = append(prices, &domain.PriceTimeseriesPoint{CandidateID: "dense", TimestampMs: ts, Slot: 100 + i, Price: 1.0})
+		liquidity = append(liquidity, &domain.LiquidityTimeseriesPoint{CandidateID: "dense", TimestampMs: ts, Slot: 100 + i, Liquidity: 10000})
+	}
+	if err := stores.priceTimeseriesStore.InsertBulk(ctx, prices); err != nil {
+		t.Fatalf("insert prices: %v", err)
+	}
+	if err := stores.liquidityTimeseriesStore.InsertBulk(ctx, liquidity); err != nil {
+		t.Fatalf("insert liquidity: %v", err)
+	}
+
+	holdDuration := int64(300000)
+	maxHold := int64(3600000)
+	dropPct := 0.3
+	orch := New(Options{
+		CandidateStore:           stores.candidateStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+			{StrategyType: domain.StrategyTypeLiquidityGuard, EntryEventType: "NEW_TOKEN", LiquidityDropPct: &dropPct, MaxHoldDurationMs: &maxHold},
+		},
+		ScenarioConfigs:   []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+		SkipNormalization: true,
+		DataRequirements:  DefaultDataRequirements(3),
+	})
+
+	result, err := orch.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	// Sparse candidate: TIME_EXIT simulated, LIQUIDITY_GUARD skipped
+	if len(result.Skipped) != 1 {
+		t.Fatalf("expected 1 skipped pair, got %+v", result.Skipped)
+	}
+	skipped := result.Skipped[0]
+	if skipped.CandidateID != "cand-00" || skipped.StrategyType != domain.StrategyTypeLiquidityGuard ||
+		skipped.Reason != SkipReasonInsufficientData {
+		t.Errorf("unexpected skipped pair: %+v", skipped)
+	}
+	if got := result.SkippedByStrategy(); got[domain.StrategyTypeLiquidityGuard] != 1 || len(got) != 1 {
+		t.Errorf("unexpected skipped counts: %v", got)
+	}
+	if result.TradesCreated != 3 {
+		t.Errorf("expected 3 trades (2 TIME_EXIT, 1 LIQUIDITY_GUARD), got %d", result.TradesCreated)
+	}
+
+	sparseTrades, err := stores.tradeRecordStore.GetByCandidateID(ctx, "cand-00")
+	if err != nil {
+		t.Fatalf("GetByCandidateID: %v", err)
+	}
+	if len(sparseTrades) != 1 || sparseTrades[0].ExitReason != domain.ExitReasonTimeExit {
+		t.Errorf("expected only a TIME_EXIT trade for cand-00, got %d trades", len(sparseTrades))
+	}
+
+	// The skipped pair is left out of the LIQUIDITY_GUARD aggregate
+	agg, err := stores.strategyAggregateStore.GetByKey(ctx, domain.StrategyTypeLiquidityGuard, domain.ScenarioRealistic, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("get aggregate: %v", err)
+	}
+	if agg.TotalTrades != 1 || agg.TotalTokens != 1 {
+		t.Errorf("expected LIQUIDITY_GUARD aggregate over 1 trade, got %d trades / %d tokens", agg.TotalTrades, agg.TotalTokens)
+	}
+}
+
+func TestOrchestrator_Run_IncrementalResimulatesDirtyCandidate(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+	seedWindowCandidate(t, stores)
+
+	dirtyStore := memory.NewDirtyCandidateStore()
+	tracker := tracked.NewTracker(stores.tradeRecordStore, dirtyStore)
+	liquidityStore := tracked.NewLiquidityEventStore(stores.liquidityEventStore, tracker)
+
+	maxHold := int64(1800000) // 30 min
+	dropPct := 0.3
+	newOrch := func(incremental bool) *Orchestrator {
+		return New(Options{
+			CandidateStore:           stores.candidateStore,
+			SwapStore:                stores.swapStore,
+			LiquidityEventStore:      liquidityStore,
+			PriceTimeseriesStore:     stores.priceTimeseriesStore,
+			LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+			VolumeTimeseriesStore:    stores.volumeTimeseriesStore,
+			DerivedFeatureStore:      stores.derivedFeatureStore,
+			TradeRecordStore:         stores.tradeRecordStore,
+			StrategyAggregateStore:   stores.strategyAggregateStore,
+			DirtyCandidateStore:      dirtyStore,
+			StrategyConfigs: []domain.StrategyConfig{
+				{StrategyType: domain.StrategyTypeLiquidityGuard, EntryEventType: "NEW_TOKEN", LiquidityDropPct: &dropPct, MaxHoldDurationMs: &maxHold},
+			},
+			ScenarioConfigs: []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+			Incremental:     incremental,
+		})
+	}
+
+	// Liquidity never drops: the guard holds until the price data ends, well
+	// before max duration
+	if _, err := newOrch(false).Run(ctx); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if trade := onlyTrade(t, stores); trade.ExitReason != domain.ExitReasonDataEnd {
+		t.Fatalf("expected DATA_END before the late event, got %s", trade.ExitReason)
+	}
+
+	// Nothing changed: incremental run has nothing to do
+	idle, err := newOrch(true).Run(ctx)
+	if err != nil {
+		t.Fatalf("idle run: %v", err)
+	}
+	if idle.CandidatesProcessed != 0 || idle.CandidatesUpToDate != 1 {
+		t.Errorf("expected the simulated candidate left out, got %+v", idle)
+	}
+
+	// A drop to 30% of entry liquidity, 5 min in, arrives late
+	if err := liquidityStore.Insert(ctx, &domain.LiquidityEvent{
+		CandidateID:    "window-candidate",
+		TxSignature:    "liq-late",
+		Slot:           150,
+		Timestamp:      windowBase + 300000,
+		EventType:      domain.LiquidityEventRemove,
+		AmountToken:    7000,
+		AmountQuote:    70,
+		LiquidityAfter: 3030,
+	}); err != nil {
+		t.Fatalf("insert late liquidity event: %v", err)
+	}
+	if n, _ := dirtyStore.Count(ctx); n != 1 {
+		t.Fatalf("expected the candidate marked dirty, got %d dirty candidates", n)
+	}
+
+	result, err := newOrch(true).Run(ctx)
+	if err != nil {
+		t.Fatalf("incremental run: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if result.CandidatesResimulated != 1 || result.TradesUpdated != 1 || result.AggregatesUpdated != 1 {
+		t.Errorf("expected 1 re-simulated candidate, 1 updated trade and 1 refreshed aggregate, got %+v", result)
+	}
+
+	trade := onlyTrade(t, stores)
+	if trade.ExitReason != domain.ExitReasonLiquidityDrop || trade.ExitSignalTime != windowBase+300000 {
+		t.Errorf("expected LIQUIDITY_DROP at the late event, got %s at %d", trade.ExitReason, trade.ExitSignalTime)
+	}
+	agg, err := stores.strategyAggregateStore.GetByKey(ctx, domain.StrategyTypeLiquidityGuard, domain.ScenarioRealistic, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("get aggregate: %v", err)
+	}
+	if agg.OutcomeMean != trade.Outcome {
+		t.Errorf("expected aggregate recomputed to %f, got %f", trade.Outcome, agg.OutcomeMean)
+	}
+	if n, _ := dirtyStore.Count(ctx); n != 0 {
+		t.Errorf("expected the candidate unmarked, got %d dirty candidates", n)
+	}
+}
+
+func TestOrchestrator_Run_SimulationCache(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+	seedWindowCandidate(t, stores)
+
+	run := func(name string, configure func(*Orchestrator)) *RunResult {
+		t.Helper()
+		orch := newWindowOrchestrator(stores, 0, time.UnixMilli(windowBase+1800000), false)
+		orch.simulationCache = true
+		if configure != nil {
+			configure(orch)
+		}
+		result, err := orch.Run(ctx)
+		if err != nil {
+			t.Fatalf("%s run: %v", name, err)
+		}
+		if len(result.Errors) > 0 {
+			t.Fatalf("%s run: unexpected errors %v", name, result.Errors)
+		}
+		return result
+	}
+
+	first := run("first", nil)
+	if first.SimulationCacheHits != 0 || first.SimulationCacheMisses != 1 || first.TradesCreated != 1 {
+		t.Fatalf("first run: expected 1 miss and 1 trade, got %d hits, %d misses, %d trades",
+			first.SimulationCacheHits, first.SimulationCacheMisses, first.TradesCreated)
+	}
+	if onlyTrade(t, stores).InputKey == "" {
+		t.Fatal("expected the trade to record its input key")
+	}
+
+	// Unchanged inputs: the simulation is skipped
+	unchanged := run("unchanged", nil)
+	if unchanged.SimulationCacheHits != 1 || unchanged.SimulationCacheMisses != 0 || unchanged.TradesSkipped != 0 {
+		t.Errorf("unchanged run: expected 1 hit and nothing simulated, got %d hits, %d misses, %d skipped",
+			unchanged.SimulationCacheHits, unchanged.SimulationCacheMisses, unchanged.TradesSkipped)
+	}
+
+	// A parameter change misses
+	longer := int64(7200000)
+	params := run("parameter change", func(o *Orchestrator) {
+		o.strategyConfigs[0].HoldDurationMs = &longer
+	})
+	if params.SimulationCacheHits != 0 || params.SimulationCacheMisses != 1 {
+		t.Errorf("parameter change: expected 1 miss, got %d hits, %d misses", params.SimulationCacheHits, params.SimulationCacheMisses)
+	}
+
+	// A data change misses
+	addWindowSwap(t, stores, "swap-3", 300, windowBase+1200000, 2.0)
+	data := run("data change", nil)
+	if data.SimulationCacheHits != 0 || data.SimulationCacheMisses != 1 {
+		t.Errorf("data change: expected 1 miss, got %d hits, %d misses", data.SimulationCacheHits, data.SimulationCacheMisses)
+	}
+}
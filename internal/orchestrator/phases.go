@@ -0,0 +1,525 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/ingestion"
+	"solana-token-lab/internal/metrics"
+)
+
+// Phase names, in dependency order.
+const (
+	PhaseAssociate = "associate" // associate orphan liquidity events with candidates
+	PhaseNormalize = "normalize" // build time series from swaps and liquidity events
+	PhaseSimulate  = "simulate"  // simulate strategies and close expired candidates
+	PhaseSync      = "sync"      // mirror trades written since the last sync into ClickHouse
+	PhaseAggregate = "aggregate" // compute strategy aggregates from stored trades
+)
+
+// ErrUnknownPhase is returned for a phase name not in the graph.
+var ErrUnknownPhase = errors.New("unknown phase")
+
+// PhaseStatus is the outcome of one phase in a run.
+type PhaseStatus string
+
+const (
+	PhaseStatusOK      PhaseStatus = "ok"
+	PhaseStatusFailed  PhaseStatus = "failed"
+	PhaseStatusSkipped PhaseStatus = "skipped" // not selected, nothing to do, or run stopped earlier
+)
+
+// PhaseResult records timing and outcome of one phase.
+type PhaseResult struct {
+	Name       string      `json:"name"`
+	DependsOn  []string    `json:"depends_on,omitempty"`
+	Status     PhaseStatus `json:"status"`
+	StartedAt  time.Time   `json:"started_at,omitzero"`
+	DurationMs int64       `json:"duration_ms"`
+	Summary    string      `json:"summary,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// phase is a node of the orchestrator's dependency graph.
+type phase struct {
+	name      string
+	dependsOn []string
+
+	// precondition validates the phase's inputs before it runs. It is called with
+	// the run state, so it can tell whether its dependencies ran in this invocation.
+	precondition func(ctx context.Context, st *runState) error
+
+	run func(ctx context.Context, st *runState) (summary string, err error)
+}
+
+// runState carries data between the phases of one run.
+type runState struct {
+	result     *RunResult
+	ran        map[string]bool // phases completed in this run
+	candidates []*domain.TokenCandidate
+	dirty      map[string]*domain.DirtyCandidate // marks of dirty candidates among candidates
+	loaded     bool
+	refresh    bool                   // stored aggregates are stale: trades changed or candidates closed
+	mirror     *metrics.SQLAggregator // synced by the sync phase, reused by aggregate
+}
+
+// skipPhase is returned by a phase or precondition when there is nothing to do.
+type skipPhase string
+
+func (s skipPhase) Error() string { return string(s) }
+
+// AllPhases returns the phase names in execution order.
+func AllPhases() []string {
+	return []string{PhaseAssociate, PhaseNormalize, PhaseSimulate, PhaseSync, PhaseAggregate}
+}
+
+// ParsePhases parses a comma-separated phase list such as "simulate,aggregate".
+// An empty spec or "all" selects every phase (nil).
+func ParsePhases(spec string) ([]string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "all" {
+		return nil, nil
+	}
+	known := make(map[string]bool)
+	for _, name := range AllPhases() {
+		known[name] = true
+	}
+	var phases []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if !known[name] {
+			return nil, fmt.Errorf("%w: %q (expected %s)", ErrUnknownPhase, name, strings.Join(AllPhases(), ", "))
+		}
+		phases = append(phases, name)
+	}
+	return phases, nil
+}
+
+// graph returns the phases in dependency order.
+func (o *Orchestrator) graph() []phase {
+	return []phase{
+		{
+			name:         PhaseAssociate,
+			precondition: o.checkAssociate,
+			run:          o.phaseAssociate,
+		},
+		{
+			name:         PhaseNormalize,
+			dependsOn:    []string{PhaseAssociate},
+			precondition: o.checkNormalize,
+			run:          o.phaseNormalize,
+		},
+		{
+			name:         PhaseSimulate,
+			dependsOn:    []string{PhaseNormalize},
+			precondition: o.checkSimulate,
+			run:          o.phaseSimulate,
+		},
+		{
+			name:         PhaseSync,
+			dependsOn:    []string{PhaseSimulate},
+			precondition: o.checkSync,
+			run:          o.phaseSync,
+		},
+		{
+			name:         PhaseAggregate,
+			dependsOn:    []string{PhaseSimulate},
+			precondition: o.checkAggregate,
+			run:          o.phaseAggregate,
+		},
+	}
+}
+
+// selected returns the set of phases to run. SkipNormalization removes normalize
+// from the default set.
+func (o *Orchestrator) selected() (map[string]bool, error) {
+	names := o.phases
+	if len(names) == 0 {
+		names = AllPhases()
+	}
+	set := make(map[string]bool)
+	for _, name := range names {
+		if _, err := ParsePhases(name); err != nil {
+			return nil, err
+		}
+		set[name] = true
+	}
+	if o.skipNormalization {
+		delete(set, PhaseNormalize)
+	}
+	return set, nil
+}
+
+// runGraph executes the selected phases in dependency order. A phase whose
+// dependencies did not run in this invocation relies on its precondition to
+// find their output in the stores. The first failing phase stops the run;
+// later phases are recorded as skipped.
+func (o *Orchestrator) runGraph(ctx context.Context, result *RunResult) error {
+	selected, err := o.selected()
+	if err != nil {
+		return err
+	}
+	if err := checkScenarioPreset(o.scenarioPreset); err != nil {
+		return err
+	}
+
+	st := &runState{result: result, ran: make(map[string]bool)}
+	var stopped string
+	var runErr error
+	for _, ph := range o.graph() {
+		pr := PhaseResult{Name: ph.name, DependsOn: ph.dependsOn, Status: PhaseStatusSkipped}
+		switch {
+		case !selected[ph.name]:
+			pr.Summary = "not selected"
+			o.log("Phase %s: not selected", ph.name)
+		case stopped != "":
+			pr.Summary = stopped
+		default:
+			o.log("Phase %s: running...", ph.name)
+			pr.StartedAt = o.now().UTC()
+			start := time.Now()
+			summary, err := o.runPhase(ctx, ph, st)
+			pr.DurationMs = time.Since(start).Milliseconds()
+			pr.Summary = summary
+
+			var skip skipPhase
+			switch {
+			case errors.As(err, &skip):
+				pr.Summary = skip.Error()
+				o.log("  Skipped: %s", skip)
+			case err != nil:
+				pr.Status = PhaseStatusFailed
+				pr.Error = err.Error()
+				runErr = fmt.Errorf("phase %s failed: %w", ph.name, err)
+				stopped = fmt.Sprintf("run stopped: phase %s failed", ph.name)
+			default:
+				pr.Status = PhaseStatusOK
+				st.ran[ph.name] = true
+				o.log("  %s", summary)
+			}
+			// Without candidates nothing downstream of loading has work
+			if runErr == nil && st.loaded && len(st.candidates) == 0 {
+				stopped = "no candidates to process"
+			}
+		}
+		result.Phases = append(result.Phases, pr)
+	}
+	return runErr
+}
+
+// runPhase validates a phase's preconditions and runs it.
+func (o *Orchestrator) runPhase(ctx context.Context, ph phase, st *runState) (string, error) {
+	if ph.precondition != nil {
+		if err := ph.precondition(ctx, st); err != nil {
+			var skip skipPhase
+			if errors.As(err, &skip) {
+				return "", err
+			}
+			return "", fmt.Errorf("precondition: %w", err)
+		}
+	}
+	return ph.run(ctx, st)
+}
+
+// dependenciesRan reports whether every dependency of name completed in this run.
+func (st *runState) dependenciesRan(deps []string) bool {
+	for _, d := range deps {
+		if !st.ran[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// loadCandidates loads the candidates to process once per run, leaving out
+// closed ones when an observation window is configured. In incremental mode only
+// dirty candidates, first, candidates missing trades and candidates due for
+// closure are kept.
+func (o *Orchestrator) loadRunCandidates(ctx context.Context, st *runState) error {
+	if st.loaded {
+		return nil
+	}
+	candidates, err := o.loadCandidates(ctx)
+	if err != nil {
+		return fmt.Errorf("load candidates: %w", err)
+	}
+	if o.observationWindowMs > 0 {
+		var closed int
+		candidates, closed = splitClosed(candidates)
+		st.result.CandidatesAlreadyClosed = closed
+		o.log("  Skipping %d closed candidates", closed)
+	}
+	if err := o.loadDirty(ctx, st, candidates); err != nil {
+		return err
+	}
+	if o.incremental {
+		var upToDate int
+		candidates, upToDate, err = o.incrementalCandidates(ctx, candidates, st.dirty)
+		if err != nil {
+			return err
+		}
+		st.result.CandidatesUpToDate = upToDate
+		o.log("  Incremental: %d dirty, %d up to date", len(st.dirty), upToDate)
+	}
+	st.result.CandidatesProcessed = len(candidates)
+	st.candidates = candidates
+	st.loaded = true
+	return nil
+}
+
+// loadDirty loads the marks of the dirty candidates among candidates. Marks of
+// candidates not loaded (closed, so frozen) are removed, since they would
+// never be re-simulated.
+func (o *Orchestrator) loadDirty(ctx context.Context, st *runState, candidates []*domain.TokenCandidate) error {
+	st.dirty = make(map[string]*domain.DirtyCandidate)
+	if o.dirtyCandidateStore == nil {
+		return nil
+	}
+	marks, err := o.dirtyCandidateStore.List(ctx)
+	if err != nil {
+		return fmt.Errorf("load dirty candidates: %w", err)
+	}
+	loaded := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		loaded[c.CandidateID] = true
+	}
+	for _, d := range marks {
+		if loaded[d.CandidateID] {
+			st.dirty[d.CandidateID] = d
+			continue
+		}
+		if err := o.dirtyCandidateStore.Unmark(ctx, d.CandidateID, d.MarkedAt); err != nil {
+			return fmt.Errorf("unmark %s: %w", d.CandidateID, err)
+		}
+		o.log("  Dropped dirty mark of closed or unknown candidate %s", d.CandidateID)
+	}
+	return nil
+}
+
+// incrementalCandidates keeps the dirty candidates, in mark order, followed by
+// the candidates without stored trades of every simulated scenario and those due for their final
+// simulation before closure. It returns how many were left out.
+func (o *Orchestrator) incrementalCandidates(ctx context.Context, candidates []*domain.TokenCandidate, dirty map[string]*domain.DirtyCandidate) ([]*domain.TokenCandidate, int, error) {
+	var dirtyCandidates, pending []*domain.TokenCandidate
+	for _, c := range candidates {
+		if dirty[c.CandidateID] != nil {
+			dirtyCandidates = append(dirtyCandidates, c)
+			continue
+		}
+		if o.expired(c) {
+			pending = append(pending, c)
+			continue
+		}
+		trades, err := o.tradeRecordStore.GetByCandidateID(ctx, c.CandidateID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("load trades of %s: %w", c.CandidateID, err)
+		}
+		if len(trades) == 0 || o.missingScenario(trades) {
+			pending = append(pending, c)
+		}
+	}
+	sort.SliceStable(dirtyCandidates, func(i, j int) bool {
+		a, b := dirty[dirtyCandidates[i].CandidateID], dirty[dirtyCandidates[j].CandidateID]
+		if a.MarkedAt != b.MarkedAt {
+			return a.MarkedAt < b.MarkedAt
+		}
+		return a.CandidateID < b.CandidateID
+	})
+	selected := append(dirtyCandidates, pending...)
+	return selected, len(candidates) - len(selected), nil
+}
+
+func (o *Orchestrator) checkAssociate(context.Context, *runState) error {
+	if o.liquidityEventStore == nil || o.candidateStore == nil {
+		return skipPhase("no liquidity event store configured")
+	}
+	return nil
+}
+
+func (o *Orchestrator) phaseAssociate(ctx context.Context, st *runState) (string, error) {
+	assoc, err := ingestion.NewLiquidityAssociator(o.liquidityEventStore, o.candidateStore, nil).Run(ctx)
+	if err != nil {
+		return "", err
+	}
+	st.result.LiquidityEventsRepaired = assoc.Repaired
+	st.result.LiquidityEventsOrphaned = assoc.Orphaned
+	return fmt.Sprintf("repaired %d, %d still orphaned", assoc.Repaired, assoc.Orphaned), nil
+}
+
+func (o *Orchestrator) checkNormalize(context.Context, *runState) error {
+	if o.swapStore == nil || o.priceTimeseriesStore == nil || o.liquidityTimeseriesStore == nil {
+		return errors.New("normalize needs swap, price timeseries and liquidity timeseries stores")
+	}
+	return nil
+}
+
+func (o *Orchestrator) phaseNormalize(ctx context.Context, st *runState) (string, error) {
+	if err := o.loadRunCandidates(ctx, st); err != nil {
+		return "", err
+	}
+	if len(st.candidates) == 0 {
+		return "", skipPhase("no candidates to process")
+	}
+	warnings, err := o.runNormalization(ctx, st.candidates, st.dirty)
+	if err != nil {
+		return "", err
+	}
+	st.result.DecimalsInferred = len(warnings)
+	st.result.Warnings = append(st.result.Warnings, warnings...)
+	return fmt.Sprintf("normalized %d candidates (%d with inferred decimals)", len(st.candidates), len(warnings)), nil
+}
+
+// checkSimulate requires stored time series when normalize did not run in this invocation.
+func (o *Orchestrator) checkSimulate(ctx context.Context, st *runState) error {
+	if o.priceTimeseriesStore == nil || o.tradeRecordStore == nil {
+		return errors.New("simulate needs price timeseries and trade record stores")
+	}
+	if err := o.loadRunCandidates(ctx, st); err != nil {
+		return err
+	}
+	if len(st.candidates) == 0 {
+		return skipPhase("no candidates to process")
+	}
+	if st.ran[PhaseNormalize] {
+		return nil
+	}
+	_, maxTs, err := o.priceTimeseriesStore.GetGlobalTimeRange(ctx)
+	if err != nil {
+		return fmt.Errorf("check price timeseries: %w", err)
+	}
+	if maxTs == 0 {
+		return fmt.Errorf("no price timeseries stored for %d candidates: run the %s phase first (e.g. --phases %s,%s)",
+			len(st.candidates), PhaseNormalize, PhaseNormalize, PhaseSimulate)
+	}
+	return nil
+}
+
+func (o *Orchestrator) phaseSimulate(ctx context.Context, st *runState) (string, error) {
+	r := st.result
+	if o.determinismCheck {
+		checked, err := o.checkDeterminism(ctx, st.candidates)
+		if err != nil {
+			return "", fmt.Errorf("determinism check: %w", err)
+		}
+		r.DeterminismCheckTrades = checked
+		o.log("  Determinism check: %d trades identical across two runs", checked)
+	}
+
+	sim, simErrors, err := o.runSimulations(ctx, st.candidates, st.dirty)
+	r.TradesCreated = sim.created
+	r.TradesSkipped = sim.skipped
+	r.TradesUpdated = sim.updated
+	r.CandidatesResimulated = sim.resimulated
+	r.SimulationCacheHits = sim.cacheHits
+	r.SimulationCacheMisses = sim.cacheMisses
+	r.Skipped = sim.insufficient
+	r.Errors = append(r.Errors, simErrors...)
+	if err != nil {
+		return "", fmt.Errorf("stopped after %d/%d candidates: %w", sim.candidatesDone, len(st.candidates), err)
+	}
+	if len(sim.insufficient) > 0 {
+		o.log("  Not simulated for insufficient data: %s", r.FormatSkippedByStrategy())
+	}
+	summary := fmt.Sprintf("created %d trades, %d skipped, %d updated (%d errors)", sim.created, sim.skipped, sim.updated, len(simErrors))
+	if sim.resimulated > 0 {
+		summary += fmt.Sprintf(", re-simulated %d dirty candidates", sim.resimulated)
+	}
+	if o.simulationCache {
+		summary += fmt.Sprintf(", simulation cache %d hits / %d misses", sim.cacheHits, sim.cacheMisses)
+	}
+
+	if o.observationWindowMs > 0 && o.fullMatrix() {
+		closed, err := o.closeExpired(ctx, st.candidates)
+		r.CandidatesClosed = closed
+		if err != nil {
+			return summary, fmt.Errorf("close candidates: %w", err)
+		}
+		summary += fmt.Sprintf(", closed %d candidates", closed)
+	} else if o.observationWindowMs > 0 {
+		summary += fmt.Sprintf(", closure left to full-matrix runs (%s preset)", o.scenarioPreset)
+	}
+	// Incremental runs add trades to existing aggregates, which must be recomputed
+	st.refresh = sim.updated > 0 || r.CandidatesClosed > 0 || (o.incremental && sim.created > 0)
+	return summary, nil
+}
+
+func (o *Orchestrator) checkSync(context.Context, *runState) error {
+	if o.tradeAggregateStore == nil {
+		return skipPhase("no trade aggregate store configured")
+	}
+	if o.tradeRecordStore == nil || o.candidateStore == nil {
+		return errors.New("sync needs trade record and candidate stores")
+	}
+	return nil
+}
+
+func (o *Orchestrator) phaseSync(ctx context.Context, st *runState) (string, error) {
+	mirror := o.newSQLAggregator()
+	synced, err := mirror.Sync(ctx)
+	st.result.TradeSync = synced
+	if err != nil {
+		return "", err
+	}
+	st.mirror = mirror
+	if n := synced.Unreconciled(); n < 0 {
+		st.result.Warnings = append(st.result.Warnings, fmt.Sprintf(
+			"trade mirror holds %d trades more than trade_records (%d mirrored, %d stored): rebuild the ClickHouse trade_records table",
+			-n, synced.MirrorTrades, synced.SourceTrades))
+	}
+	return fmt.Sprintf("mirrored %d of %d changed trades (%d pending, %d of deleted candidates); %d stored, %d mirrored",
+		synced.Mirrored, synced.Read, synced.Pending, synced.Deleted, synced.SourceTrades, synced.MirrorTrades), nil
+}
+
+// checkAggregate requires stored trades when simulate did not run in this invocation.
+func (o *Orchestrator) checkAggregate(ctx context.Context, st *runState) error {
+	if o.tradeRecordStore == nil || o.strategyAggregateStore == nil {
+		return errors.New("aggregate needs trade record and strategy aggregate stores")
+	}
+	if st.ran[PhaseSimulate] {
+		return nil
+	}
+	trades, err := o.tradeRecordStore.GetPage(ctx, "", 1)
+	if err != nil {
+		return fmt.Errorf("check trade records: %w", err)
+	}
+	if len(trades) == 0 {
+		return fmt.Errorf("no trade records stored: run the %s phase first (e.g. --phases %s,%s)",
+			PhaseSimulate, PhaseSimulate, PhaseAggregate)
+	}
+	return nil
+}
+
+// phaseAggregate computes aggregates. Run on its own (e.g. after manual trade
+// edits) it refreshes every stored aggregate, since the trades may have changed.
+func (o *Orchestrator) phaseAggregate(ctx context.Context, st *runState) (string, error) {
+	aggregator, err := o.newAggregator(ctx, st)
+	if err != nil {
+		return "", fmt.Errorf("trade sync: %w", err)
+	}
+	refresh := st.refresh || !st.ran[PhaseSimulate]
+	written, aggErrors := o.runAggregation(ctx, aggregator, refresh)
+	if refresh {
+		st.result.AggregatesUpdated = written
+	} else {
+		st.result.AggregatesCreated = written
+	}
+	st.result.Errors = append(st.result.Errors, aggErrors...)
+	return fmt.Sprintf("wrote %d aggregates (refresh=%v, %d errors)", written, refresh, len(aggErrors)), nil
+}
+
+// WriteRunSummary writes result, including its per-phase summaries, as JSON to path
+// (conventionally orchestrator_run.json next to the report artifacts).
+func WriteRunSummary(path string, result *RunResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal run summary: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,305 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+func TestParsePhases(t *testing.T) {
+	for _, spec := range []string{"", "all", " all "} {
+		phases, err := ParsePhases(spec)
+		if err != nil || phases != nil {
+			t.Errorf("ParsePhases(%q) = %v, %v; want all phases (nil)", spec, phases, err)
+		}
+	}
+
+	phases, err := ParsePhases("simulate, aggregate")
+	if err != nil {
+		t.Fatalf("ParsePhases: %v", err)
+	}
+	if strings.Join(phases, ",") != "simulate,aggregate" {
+		t.Errorf("phases = %v, want [simulate aggregate]", phases)
+	}
+
+	if _, err := ParsePhases("simulate,report"); !errors.Is(err, ErrUnknownPhase) {
+		t.Errorf("expected ErrUnknownPhase, got %v", err)
+	}
+}
+
+// insertRerunCandidate stores the candidate simulated by rerunOrchestrator.
+func insertRerunCandidate(t *testing.T, stores *testStores) {
+	t.Helper()
+	if err := stores.candidateStore.Insert(context.Background(), &domain.TokenCandidate{
+		CandidateID:  "rerun-candidate",
+		Source:       domain.SourceNewToken,
+		Mint:         "rerun-mint",
+		TxSignature:  "rerun-tx",
+		Slot:         100,
+		DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+}
+
+// phaseStatuses maps phase name to its recorded status.
+func phaseStatuses(result *RunResult) map[string]PhaseStatus {
+	out := make(map[string]PhaseStatus)
+	for _, p := range result.Phases {
+		out[p.Name] = p.Status
+	}
+	return out
+}
+
+func TestOrchestrator_Run_PhaseGraph(t *testing.T) {
+	stores := createTestStores()
+	insertRerunCandidate(t, stores)
+	result := rerunOrchestrator(t, stores, 1.2, false)
+
+	if len(result.Phases) != len(AllPhases()) {
+		t.Fatalf("expected %d phase results, got %d", len(AllPhases()), len(result.Phases))
+	}
+	for i, name := range AllPhases() {
+		if result.Phases[i].Name != name {
+			t.Errorf("phase %d = %s, want %s", i, result.Phases[i].Name, name)
+		}
+	}
+
+	want := map[string]PhaseStatus{
+		PhaseAssociate: PhaseStatusSkipped, // no liquidity event store
+		PhaseNormalize: PhaseStatusSkipped, // SkipNormalization
+		PhaseSimulate:  PhaseStatusOK,
+		PhaseSync:      PhaseStatusSkipped, // no trade aggregate store
+		PhaseAggregate: PhaseStatusOK,
+	}
+	got := phaseStatuses(result)
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("phase %s status = %s, want %s", name, got[name], status)
+		}
+	}
+	if result.TradesCreated != 1 || result.AggregatesCreated == 0 {
+		t.Errorf("expected 1 trade and aggregates, got %d trades, %d aggregates", result.TradesCreated, result.AggregatesCreated)
+	}
+	if deps := result.Phases[4].DependsOn; len(deps) != 1 || deps[0] != PhaseSimulate {
+		t.Errorf("aggregate depends on %v, want [simulate]", deps)
+	}
+}
+
+func TestOrchestrator_Run_AggregateOnlyAfterTradeEdit(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+	insertRerunCandidate(t, stores)
+	rerunOrchestrator(t, stores, 1.2, false)
+
+	// Manually edit the stored trade; only re-aggregation should pick it up
+	trades, err := stores.tradeRecordStore.GetAll(ctx)
+	if err != nil || len(trades) != 1 {
+		t.Fatalf("expected 1 stored trade, got %d (%v)", len(trades), err)
+	}
+	edited := *trades[0]
+	edited.Outcome = -0.5
+	edited.OutcomeClass = domain.OutcomeClassLoss
+	if err := stores.tradeRecordStore.Upsert(ctx, &edited); err != nil {
+		t.Fatalf("upsert trade: %v", err)
+	}
+
+	holdDuration := int64(300000)
+	orch := New(Options{
+		CandidateStore:         stores.candidateStore,
+		TradeRecordStore:       stores.tradeRecordStore,
+		StrategyAggregateStore: stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs: []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+		Phases:          []string{PhaseAggregate},
+	})
+	result, err := orch.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.AggregatesUpdated == 0 {
+		t.Errorf("expected aggregates refreshed, got %d updated", result.AggregatesUpdated)
+	}
+	got := phaseStatuses(result)
+	if got[PhaseSimulate] != PhaseStatusSkipped || got[PhaseAggregate] != PhaseStatusOK {
+		t.Errorf("unexpected phase statuses: %v", got)
+	}
+
+	agg, err := stores.strategyAggregateStore.GetByKey(ctx, domain.StrategyTypeTimeExit, domain.ScenarioConfigRealistic.ScenarioID, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("get aggregate: %v", err)
+	}
+	if agg.OutcomeMean != -0.5 {
+		t.Errorf("aggregate OutcomeMean = %v, want -0.5 from edited trade", agg.OutcomeMean)
+	}
+}
+
+func TestOrchestrator_Run_SimulatePreconditionWithoutTimeseries(t *testing.T) {
+	stores := createTestStores()
+	insertRerunCandidate(t, stores)
+
+	holdDuration := int64(300000)
+	orch := New(Options{
+		CandidateStore:           stores.candidateStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs: []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+		Phases:          []string{PhaseSimulate, PhaseAggregate},
+	})
+	result, err := orch.Run(context.Background())
+	if err == nil {
+		t.Fatal("expected precondition error without stored timeseries")
+	}
+	if !strings.Contains(err.Error(), "run the normalize phase first") {
+		t.Errorf("error should tell which phase to run, got: %v", err)
+	}
+	if result == nil {
+		t.Fatal("expected result with phase summaries on error")
+	}
+	got := phaseStatuses(result)
+	if got[PhaseSimulate] != PhaseStatusFailed || got[PhaseAggregate] != PhaseStatusSkipped {
+		t.Errorf("unexpected phase statuses: %v", got)
+	}
+	if result.Phases[4].Summary != "run stopped: phase simulate failed" {
+		t.Errorf("aggregate summary = %q", result.Phases[4].Summary)
+	}
+}
+
+func TestOrchestrator_Run_AggregatePreconditionWithoutTrades(t *testing.T) {
+	stores := createTestStores()
+	orch := New(Options{
+		CandidateStore:         stores.candidateStore,
+		TradeRecordStore:       stores.tradeRecordStore,
+		StrategyAggregateStore: stores.strategyAggregateStore,
+		Phases:                 []string{PhaseAggregate},
+	})
+	_, err := orch.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "run the simulate phase first") {
+		t.Errorf("expected missing trades precondition error, got: %v", err)
+	}
+}
+
+// mirrorStore is a storage.TradeAggregateStore keeping the latest mirrored copy of each trade.
+type mirrorStore struct {
+	trades    map[string]*storage.MirroredTrade
+	watermark int64
+}
+
+func (m *mirrorStore) InsertMirrored(_ context.Context, trades []*storage.MirroredTrade) error {
+	for _, t := range trades {
+		m.trades[t.Trade.TradeID] = t
+	}
+	return nil
+}
+
+func (m *mirrorStore) CountByStrategyScenario(context.Context, string, string) (int, error) {
+	return 0, nil
+}
+
+func (m *mirrorStore) ComputeAggregate(context.Context, string, string, string, bool) (*domain.StrategyAggregate, error) {
+	return nil, storage.ErrNotFound
+}
+
+func (m *mirrorStore) CountMirrored(context.Context) (int, error) { return len(m.trades), nil }
+
+func (m *mirrorStore) GetSyncWatermark(context.Context) (int64, error) { return m.watermark, nil }
+
+func (m *mirrorStore) SetSyncWatermark(_ context.Context, watermark int64) error {
+	m.watermark = watermark
+	return nil
+}
+
+func TestOrchestrator_Run_SyncMirrorsChangedTrades(t *testing.T) {
+	ctx := context.Background()
+	stores := createTestStores()
+	insertRerunCandidate(t, stores)
+	rerunOrchestrator(t, stores, 1.2, false)
+
+	mirror := &mirrorStore{trades: make(map[string]*storage.MirroredTrade)}
+	syncOnly := func() *RunResult {
+		t.Helper()
+		result, err := New(Options{
+			CandidateStore:         stores.candidateStore,
+			TradeRecordStore:       stores.tradeRecordStore,
+			StrategyAggregateStore: stores.strategyAggregateStore,
+			TradeAggregateStore:    mirror,
+			Phases:                 []string{PhaseSync},
+		}).Run(ctx)
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if got := phaseStatuses(result)[PhaseSync]; got != PhaseStatusOK {
+			t.Fatalf("sync phase status = %s", got)
+		}
+		return result
+	}
+
+	first := syncOnly().TradeSync
+	if first == nil || first.Mirrored != 1 || first.SourceTrades != 1 || first.MirrorTrades != 1 || first.Unreconciled() != 0 {
+		t.Fatalf("unexpected first sync: %+v", first)
+	}
+
+	// Edit the stored trade after the sync; the next sync replaces the mirrored copy
+	trades, err := stores.tradeRecordStore.GetAll(ctx)
+	if err != nil || len(trades) != 1 {
+		t.Fatalf("expected 1 stored trade, got %d (%v)", len(trades), err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	edited := *trades[0]
+	edited.Outcome = -0.5
+	edited.OutcomeClass = domain.OutcomeClassLoss
+	if err := stores.tradeRecordStore.Upsert(ctx, &edited); err != nil {
+		t.Fatalf("upsert trade: %v", err)
+	}
+
+	second := syncOnly().TradeSync
+	if second.Watermark <= first.Watermark || second.MirrorTrades != 1 || second.Unreconciled() != 0 {
+		t.Errorf("unexpected second sync: %+v", second)
+	}
+	if m := mirror.trades[edited.TradeID]; m == nil || m.Trade.Outcome != -0.5 || m.EntryEventType != "NEW_TOKEN" {
+		t.Errorf("expected the edited trade mirrored, got %+v", m)
+	}
+}
+
+func TestWriteRunSummary(t *testing.T) {
+	stores := createTestStores()
+	insertRerunCandidate(t, stores)
+	result := rerunOrchestrator(t, stores, 1.2, false)
+
+	path := filepath.Join(t.TempDir(), "orchestrator_run.json")
+	if err := WriteRunSummary(path, result); err != nil {
+		t.Fatalf("WriteRunSummary: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read summary: %v", err)
+	}
+	var decoded struct {
+		TradesCreated int           `json:"trades_created"`
+		Phases        []PhaseResult `json:"phases"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode summary: %v", err)
+	}
+	if decoded.TradesCreated != result.TradesCreated || len(decoded.Phases) != len(AllPhases()) {
+		t.Errorf("summary mismatch: %+v", decoded)
+	}
+	if decoded.Phases[2].Name != PhaseSimulate || decoded.Phases[2].Status != PhaseStatusOK {
+		t.Errorf("simulate phase = %+v", decoded.Phases[2])
+	}
+}
@@ -0,0 +1,65 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+)
+
+// ErrUnknownScenarioPreset is returned for a scenario preset other than
+// domain.ScenarioPresetMonitoring and domain.ScenarioPresetDecision.
+var ErrUnknownScenarioPreset = errors.New("unknown scenario preset")
+
+// checkScenarioPreset validates the preset of the run; empty is valid.
+func checkScenarioPreset(preset string) error {
+	if preset == "" {
+		return nil
+	}
+	if _, ok := domain.ScenarioPresetIDs(preset); !ok {
+		return fmt.Errorf("%w: %q (expected %s or %s)",
+			ErrUnknownScenarioPreset, preset, domain.ScenarioPresetMonitoring, domain.ScenarioPresetDecision)
+	}
+	return nil
+}
+
+// selectScenarios keeps the configs whose scenario belongs to the preset,
+// versioned ones included. An empty preset keeps every config, an unknown one
+// none (the run fails in checkScenarioPreset).
+func selectScenarios(configs []domain.ScenarioConfig, preset string) []domain.ScenarioConfig {
+	if preset == "" {
+		return configs
+	}
+	ids, _ := domain.ScenarioPresetIDs(preset)
+	in := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		in[id] = true
+	}
+	var selected []domain.ScenarioConfig
+	for _, cfg := range configs {
+		if base, _ := domain.SplitScenarioID(cfg.ScenarioID); in[base] {
+			selected = append(selected, cfg)
+		}
+	}
+	return selected
+}
+
+// fullMatrix reports whether the run simulates every configured scenario, which
+// closing a candidate requires: closed candidates are never simulated again.
+func (o *Orchestrator) fullMatrix() bool {
+	return o.scenarioPreset == "" || o.scenarioPreset == domain.ScenarioPresetDecision
+}
+
+// missingScenario reports whether trades lack one of the scenarios simulated in this run.
+func (o *Orchestrator) missingScenario(trades []*domain.TradeRecord) bool {
+	have := make(map[string]bool)
+	for _, t := range trades {
+		have[t.ScenarioID] = true
+	}
+	for _, cfg := range o.scenarioConfigs {
+		if !have[cfg.ScenarioID] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func newPresetOrchestrator(stores *testStores, preset string) *Orchestrator {
+	holdDuration := int64(300000)
+	return New(Options{
+		CandidateStore:           stores.candidateStore,
+		PriceTimeseriesStore:     stores.priceTimeseriesStore,
+		LiquidityTimeseriesStore: stores.liquidityTimeseriesStore,
+		TradeRecordStore:         stores.tradeRecordStore,
+		StrategyAggregateStore:   stores.strategyAggregateStore,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs: []domain.ScenarioConfig{
+			domain.ScenarioConfigOptimistic,
+			domain.ScenarioConfigRealistic,
+			domain.ScenarioConfigPessimistic,
+			domain.ScenarioConfigDegraded,
+		},
+		ScenarioPreset:    preset,
+		Incremental:       true,
+		SkipNormalization: true,
+	})
+}
+
+func scenarioTradeCounts(t *testing.T, stores *testStores) map[string]int {
+	t.Helper()
+	trades, err := stores.tradeRecordStore.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	counts := make(map[string]int)
+	for _, tr := range trades {
+		counts[tr.ScenarioID]++
+	}
+	return counts
+}
+
+func TestOrchestrator_Run_ScenarioPresetTradeCounts(t *testing.T) {
+	const candidates = 3
+	tests := []struct {
+		preset string
+		want   map[string]int
+	}{
+		{domain.ScenarioPresetMonitoring, map[string]int{
+			domain.ScenarioRealistic: candidates, domain.ScenarioPessimistic: candidates,
+		}},
+		{domain.ScenarioPresetDecision, map[string]int{
+			domain.ScenarioOptimistic: candidates, domain.ScenarioRealistic: candidates,
+			domain.ScenarioPessimistic: candidates, domain.ScenarioDegraded: candidates,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			stores := createTestStores()
+			seedSimulationCandidates(t, stores, candidates)
+
+			result, err := newPresetOrchestrator(stores, tt.preset).Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if result.TradesCreated != candidates*len(tt.want) {
+				t.Errorf("expected %d trades, got %d", candidates*len(tt.want), result.TradesCreated)
+			}
+			counts := scenarioTradeCounts(t, stores)
+			if len(counts) != len(tt.want) {
+				t.Errorf("expected scenarios %v, got %v", tt.want, counts)
+			}
+			for scenario, n := range tt.want {
+				if counts[scenario] != n {
+					t.Errorf("%s: expected %d trades, got %d", scenario, n, counts[scenario])
+				}
+			}
+		})
+	}
+}
+
+func TestOrchestrator_Run_DecisionPresetCompletesMonitoringRuns(t *testing.T) {
+	const candidates = 3
+	stores := createTestStores()
+	seedSimulationCandidates(t, stores, candidates)
+	ctx := context.Background()
+
+	// Hourly monitoring runs simulate realistic and pessimistic only
+	if _, err := newPresetOrchestrator(stores, domain.ScenarioPresetMonitoring).Run(ctx); err != nil {
+		t.Fatalf("monitoring run failed: %v", err)
+	}
+	again, err := newPresetOrchestrator(stores, domain.ScenarioPresetMonitoring).Run(ctx)
+	if err != nil {
+		t.Fatalf("second monitoring run failed: %v", err)
+	}
+	if again.CandidatesUpToDate != candidates || again.TradesCreated != 0 {
+		t.Errorf("monitoring rerun should find every candidate up to date, got %+v", again)
+	}
+
+	// The full run requested before a report picks the same candidates up again
+	// and adds the missing scenarios, leaving the monitoring trades alone
+	full, err := newPresetOrchestrator(stores, domain.ScenarioPresetDecision).Run(ctx)
+	if err != nil {
+		t.Fatalf("decision run failed: %v", err)
+	}
+	if full.CandidatesProcessed != candidates || full.TradesCreated != candidates*2 || full.TradesSkipped != candidates*2 {
+		t.Errorf("expected %d candidates, %d created and %d skipped, got %+v", candidates, candidates*2, candidates*2, full)
+	}
+	counts := scenarioTradeCounts(t, stores)
+	for _, scenario := range []string{domain.ScenarioOptimistic, domain.ScenarioRealistic, domain.ScenarioPessimistic, domain.ScenarioDegraded} {
+		if counts[scenario] != candidates {
+			t.Errorf("%s: expected %d trades, got %d", scenario, candidates, counts[scenario])
+		}
+	}
+	aggs, err := stores.strategyAggregateStore.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll aggregates: %v", err)
+	}
+	aggScenarios := make(map[string]bool)
+	for _, a := range aggs {
+		aggScenarios[a.ScenarioID] = true
+	}
+	if len(aggScenarios) != 4 {
+		t.Errorf("expected aggregates for the full matrix, got %v", aggScenarios)
+	}
+}
+
+func TestOrchestrator_Run_UnknownScenarioPreset(t *testing.T) {
+	stores := createTestStores()
+	seedSimulationCandidates(t, stores, 1)
+
+	_, err := newPresetOrchestrator(stores, "weekly").Run(context.Background())
+	if !errors.Is(err, ErrUnknownScenarioPreset) {
+		t.Fatalf("expected ErrUnknownScenarioPreset, got %v", err)
+	}
+	if counts := scenarioTradeCounts(t, stores); len(counts) != 0 {
+		t.Errorf("no trades should be written, got %v", counts)
+	}
+}
@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Artifacts a report run can select (see ArtifactSet). metadata.json and
+// checksums.sha256 are not selectable: every run writes them.
+const (
+	ArtifactReport             = "report"              // REPORT_PHASE1.md
+	ArtifactDecision           = "decision"            // DECISION_GATE_REPORT.md
+	ArtifactReportJSON         = "report_json"         // report.json
+	ArtifactStrategyAggregates = "strategy_aggregates" // strategy_aggregates.csv
+	ArtifactTradeRecords       = "trade_records"       // trade_records.csv
+	ArtifactScenarioOutcomes   = "scenario_outcomes"   // scenario_outcomes.csv
+	ArtifactScenarioMatrix     = "scenario_matrix"     // scenario_matrix.csv
+	ArtifactMetricsQueries     = "metrics_queries"     // metrics_queries.sql
+	ArtifactCatalog            = "catalog"             // catalog.json
+	ArtifactCharts             = "charts"              // charts/*.svg, with WithCharts
+	ArtifactDossiers           = "dossiers"            // dossiers/*.json, with WithDossierExport
+	ArtifactFeatureAnalysis    = "feature_analysis"    // feature_analysis.csv, with WithFeatureAnalysis
+)
+
+// artifactPaths maps every selectable artifact to its file, or to its
+// directory for artifacts whose file names depend on the data.
+var artifactPaths = map[string]string{
+	ArtifactReport:             "REPORT_PHASE1.md",
+	ArtifactDecision:           "DECISION_GATE_REPORT.md",
+	ArtifactReportJSON:         "report.json",
+	ArtifactStrategyAggregates: "strategy_aggregates.csv",
+	ArtifactTradeRecords:       "trade_records.csv",
+	ArtifactScenarioOutcomes:   "scenario_outcomes.csv",
+	ArtifactScenarioMatrix:     "scenario_matrix.csv",
+	ArtifactMetricsQueries:     "metrics_queries.sql",
+	ArtifactCatalog:            "catalog.json",
+	ArtifactCharts:             "charts",
+	ArtifactDossiers:           DossierDir,
+	ArtifactFeatureAnalysis:    "feature_analysis.csv",
+}
+
+// Artifact profiles, from the cheapest to the complete set.
+const (
+	// ArtifactProfileMinimal is the report, the decision and the aggregates:
+	// enough for frequent monitoring runs.
+	ArtifactProfileMinimal = "minimal"
+	// ArtifactProfileStandard adds the scenario tables, metrics queries and
+	// catalog, but not the per-trade and per-candidate artifacts.
+	ArtifactProfileStandard = "standard"
+	// ArtifactProfileFull is every artifact, the default. Decision runs use it.
+	ArtifactProfileFull = "full"
+)
+
+var artifactProfiles = map[string][]string{
+	ArtifactProfileMinimal: {ArtifactReport, ArtifactDecision, ArtifactReportJSON, ArtifactStrategyAggregates},
+	ArtifactProfileStandard: {ArtifactReport, ArtifactDecision, ArtifactReportJSON, ArtifactStrategyAggregates,
+		ArtifactScenarioOutcomes, ArtifactScenarioMatrix, ArtifactMetricsQueries, ArtifactCatalog},
+	ArtifactProfileFull: {ArtifactReport, ArtifactDecision, ArtifactReportJSON, ArtifactStrategyAggregates,
+		ArtifactScenarioOutcomes, ArtifactScenarioMatrix, ArtifactMetricsQueries, ArtifactCatalog,
+		ArtifactTradeRecords, ArtifactCharts, ArtifactDossiers, ArtifactFeatureAnalysis},
+}
+
+// ArtifactSet selects the artifacts a report run writes: artifact name ->
+// enabled. Artifacts missing from the set are not written.
+type ArtifactSet map[string]bool
+
+// ArtifactProfile returns the artifacts of a profile, nil for an unknown profile.
+func ArtifactProfile(profile string) ArtifactSet {
+	names, ok := artifactProfiles[profile]
+	if !ok {
+		return nil
+	}
+	set := make(ArtifactSet, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// ParseArtifacts parses an --artifacts value: a profile name or a
+// comma-separated list of artifact names.
+func ParseArtifacts(spec string) (ArtifactSet, error) {
+	spec = strings.TrimSpace(spec)
+	if set := ArtifactProfile(spec); set != nil {
+		return set, nil
+	}
+	set := make(ArtifactSet)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := artifactPaths[name]; !ok {
+			return nil, fmt.Errorf("unknown artifact %q: want %s, %s, %s or a comma-separated list of %s",
+				name, ArtifactProfileMinimal, ArtifactProfileStandard, ArtifactProfileFull, strings.Join(ArtifactNames(), ", "))
+		}
+		set[name] = true
+	}
+	if len(set) == 0 {
+		return nil, fmt.Errorf("no artifacts selected")
+	}
+	return set, nil
+}
+
+// ArtifactNames returns the names of all selectable artifacts, sorted.
+func ArtifactNames() []string {
+	names := make([]string, 0, len(artifactPaths))
+	for name := range artifactPaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ArtifactPath returns the file of an artifact, or its directory for charts
+// and dossiers; "" for an unknown artifact.
+func ArtifactPath(name string) string {
+	return artifactPaths[name]
+}
+
+// Enabled reports whether the set selects the artifact name.
+func (s ArtifactSet) Enabled(name string) bool {
+	return s[name]
+}
+
+// Profile returns the name of the profile the set equals, "custom" otherwise.
+func (s ArtifactSet) Profile() string {
+	for _, profile := range []string{ArtifactProfileMinimal, ArtifactProfileStandard, ArtifactProfileFull} {
+		if s.equal(ArtifactProfile(profile)) {
+			return profile
+		}
+	}
+	return "custom"
+}
+
+func (s ArtifactSet) equal(other ArtifactSet) bool {
+	n := 0
+	for name, enabled := range s {
+		if !enabled {
+			continue
+		}
+		if !other[name] {
+			return false
+		}
+		n++
+	}
+	return n == len(other)
+}
@@ -0,0 +1,231 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/decision"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// artifactsTestPipeline returns a fixture pipeline reaching a GO/NO-GO decision,
+// with charts and the dossier export configured.
+func artifactsTestPipeline(t *testing.T, dir string) *Phase1Pipeline {
+	t.Helper()
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	if err := priceStore.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+		{CandidateID: "cand_001", TimestampMs: 1000, Price: 1.0},
+		{CandidateID: "cand_001", TimestampMs: 2000, Price: 1.1},
+	}); err != nil {
+		t.Fatalf("insert price: %v", err)
+	}
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:    true,
+		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN"}: true,
+	}
+	return NewPhase1Pipeline(candidateStore, tradeStore, aggStore, implementable, dir).
+		WithClock(func() time.Time { return time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC) }).
+		WithCharts(candidateStore, priceStore, memory.NewLiquidityTimeseriesStore(), 1).
+		WithDossierExport(candidateStore, dossier.NewBuilder(dossier.Stores{Candidates: candidateStore, Trades: tradeStore}))
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestPhase1Pipeline_ArtifactProfiles(t *testing.T) {
+	fixed := []string{"DECISION_GATE_REPORT.md", "REPORT_PHASE1.md", "checksums.sha256", "metadata.json", "report.json", "strategy_aggregates.csv"}
+	standard := append([]string{"catalog.json", "metrics_queries.sql", "scenario_matrix.csv", "scenario_outcomes.csv"}, fixed...)
+
+	tests := []struct {
+		spec    string
+		profile string
+		want    []string // besides charts/ and dossiers/
+		extra   bool     // charts and dossiers
+	}{
+		{spec: "minimal", profile: "minimal", want: fixed},
+		{spec: "standard", profile: "standard", want: standard},
+		{spec: "full", profile: "full", want: append([]string{"trade_records.csv"}, standard...), extra: true},
+		{spec: "report,trade_records,charts", profile: "custom",
+			want: []string{"REPORT_PHASE1.md", "checksums.sha256", "metadata.json", "trade_records.csv"}, extra: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			set, err := ParseArtifacts(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseArtifacts: %v", err)
+			}
+			artifacts, err := artifactsTestPipeline(t, "").WithArtifacts(set).RunArtifacts(context.Background())
+			if err != nil {
+				t.Fatalf("RunArtifacts failed: %v", err)
+			}
+
+			var got []string
+			var charts, dossiers int
+			for _, name := range sortedNames(artifacts.Files) {
+				switch {
+				case strings.HasPrefix(name, "charts/"):
+					charts++
+				case strings.HasPrefix(name, DossierDir+"/"):
+					dossiers++
+				default:
+					got = append(got, name)
+				}
+			}
+			want := append([]string(nil), tt.want...)
+			sort.Strings(want)
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("files = %v, want %v", got, want)
+			}
+			if wantCharts := tt.extra; (charts > 0) != wantCharts {
+				t.Errorf("charts: %d files, want some: %v", charts, wantCharts)
+			}
+			if wantDossiers := tt.extra && set.Enabled(ArtifactDossiers); (dossiers > 0) != wantDossiers {
+				t.Errorf("dossiers: %d files, want some: %v", dossiers, wantDossiers)
+			}
+
+			// Every artifact but the manifest itself is checksummed
+			manifest := string(artifacts.Files[ChecksumsFile])
+			listed := strings.Count(manifest, "\n")
+			for name := range artifacts.Files {
+				if name == ChecksumsFile {
+					continue
+				}
+				if !strings.Contains(manifest, "  "+name+"\n") {
+					t.Errorf("%s missing from the checksum manifest", name)
+				}
+			}
+			if listed != len(artifacts.Files)-1 {
+				t.Errorf("manifest lists %d files, want %d:\n%s", listed, len(artifacts.Files)-1, manifest)
+			}
+
+			// metadata.json records the profile and the artifacts of the run
+			var meta struct {
+				Profile   string   `json:"artifact_profile"`
+				Artifacts []string `json:"artifacts"`
+			}
+			if err := json.Unmarshal(artifacts.Files["metadata.json"], &meta); err != nil {
+				t.Fatalf("parse metadata.json: %v", err)
+			}
+			if meta.Profile != tt.profile {
+				t.Errorf("artifact_profile = %q, want %q", meta.Profile, tt.profile)
+			}
+			var generated []string
+			for _, name := range sortedNames(artifacts.Files) {
+				if name != ChecksumsFile {
+					generated = append(generated, name)
+				}
+			}
+			if strings.Join(meta.Artifacts, ",") != strings.Join(generated, ",") {
+				t.Errorf("metadata artifacts = %v, want %v", meta.Artifacts, generated)
+			}
+		})
+	}
+}
+
+func TestPhase1Pipeline_ArtifactsRemoveStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	if err := artifactsTestPipeline(t, dir).Run(ctx); err != nil {
+		t.Fatalf("full run failed: %v", err)
+	}
+	if err := artifactsTestPipeline(t, dir).WithArtifacts(ArtifactProfile(ArtifactProfileMinimal)).Run(ctx); err != nil {
+		t.Fatalf("minimal run failed: %v", err)
+	}
+
+	for _, name := range []string{"trade_records.csv", "catalog.json", "charts", DossierDir} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s of the full run left behind: %v", name, err)
+		}
+	}
+	results, err := VerifyChecksums(dir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums failed: %v", err)
+	}
+	if !ChecksumsOK(results) {
+		t.Errorf("expected a consistent directory, got %+v", results)
+	}
+}
+
+func TestParseArtifacts(t *testing.T) {
+	set, err := ParseArtifacts(" report , catalog ")
+	if err != nil {
+		t.Fatalf("ParseArtifacts: %v", err)
+	}
+	if len(set) != 2 || !set.Enabled(ArtifactReport) || !set.Enabled(ArtifactCatalog) || set.Profile() != "custom" {
+		t.Errorf("unexpected set %v", set)
+	}
+
+	// A list naming exactly the artifacts of a profile is that profile
+	set, err = ParseArtifacts("strategy_aggregates,report_json,decision,report")
+	if err != nil {
+		t.Fatalf("ParseArtifacts: %v", err)
+	}
+	if set.Profile() != ArtifactProfileMinimal {
+		t.Errorf("profile = %q, want minimal", set.Profile())
+	}
+
+	for _, spec := range []string{"", " , ", "tiny", "report,metadata"} {
+		if _, err := ParseArtifacts(spec); err == nil {
+			t.Errorf("ParseArtifacts(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestPhase1Pipeline_BaselineComparison(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	report, err := artifactsTestPipeline(t, dir).RunReport(ctx)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	baseline := reporting.NewBaseline(report)
+	baseline.Aggregates[0].Metrics["win_rate"] += 0.5
+
+	p := artifactsTestPipeline(t, dir).WithBaselineComparison(baseline, reporting.DefaultBaselineTolerances())
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("comparison run failed: %v", err)
+	}
+	diff := p.BaselineDiff()
+	if diff == nil || !diff.Failed() || len(diff.Regressions()) != 1 || diff.Compared != len(baseline.Aggregates) {
+		t.Fatalf("expected one regression over the same data, got %+v", diff)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, BaselineDiffFile))
+	if err != nil || !strings.Contains(string(data), "**Result: REGRESSED.**") {
+		t.Errorf("unexpected %s (%v):\n%s", BaselineDiffFile, err, data)
+	}
+	results, err := VerifyChecksums(dir)
+	if err != nil || !ChecksumsOK(results) {
+		t.Errorf("expected a consistent directory, got %+v (%v)", results, err)
+	}
+
+	// A run without a baseline removes the comparison of the previous run
+	if err := artifactsTestPipeline(t, dir).Run(ctx); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, BaselineDiffFile)); !os.IsNotExist(err) {
+		t.Errorf("%s of the previous run left behind: %v", BaselineDiffFile, err)
+	}
+}
@@ -0,0 +1,218 @@
+package pipeline
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumsFile is the manifest written to the output directory.
+const ChecksumsFile = "checksums.sha256"
+
+// ArtifactFiles lists every fixed-name artifact covered by the checksum manifest.
+// Features that write new report artifacts register them here.
+var ArtifactFiles = []string{
+	"REPORT_PHASE1.md",
+	"DECISION_GATE_REPORT.md",
+	"report.json",
+	"strategy_aggregates.csv",
+	"trade_records.csv",
+	"scenario_outcomes.csv",
+	"scenario_matrix.csv",
+	"metadata.json",
+	"metrics_queries.sql",
+	"catalog.json",
+	"feature_analysis.csv",
+	BaselineDiffFile,
+}
+
+// BaselineDiffFile is the comparison with a stored baseline (see WithBaselineComparison).
+const BaselineDiffFile = "BASELINE_DIFF.md"
+
+// PreviewDir is the output subdirectory of report previews. Previews are not
+// authoritative artifacts: they are neither listed in nor verified against the manifest.
+const PreviewDir = "preview"
+
+// ArtifactGlobs lists patterns for artifacts whose names depend on the data.
+var ArtifactGlobs = []string{
+	"charts/*.svg",
+	DossierDir + "/*.json",
+}
+
+// ChecksumStatus is the verification outcome for one file.
+type ChecksumStatus string
+
+const (
+	ChecksumOK       ChecksumStatus = "OK"
+	ChecksumMismatch ChecksumStatus = "MISMATCH"
+	ChecksumMissing  ChecksumStatus = "MISSING"  // listed in the manifest, absent on disk
+	ChecksumUnlisted ChecksumStatus = "UNLISTED" // present on disk, absent from the manifest
+)
+
+// ChecksumResult is the verification result for one file.
+type ChecksumResult struct {
+	File     string // slash-separated path relative to the directory
+	Status   ChecksumStatus
+	Expected string // empty for unlisted files
+	Actual   string // empty for missing files
+}
+
+// ChecksumsOK reports whether every result is OK.
+func ChecksumsOK(results []ChecksumResult) bool {
+	for _, r := range results {
+		if r.Status != ChecksumOK {
+			return false
+		}
+	}
+	return true
+}
+
+// collectArtifacts returns registered artifacts present in out, sorted by path.
+func collectArtifacts(out OutputWriter) ([]string, error) {
+	var files []string
+	for _, pattern := range append(append([]string(nil), ArtifactFiles...), ArtifactGlobs...) {
+		matches, err := out.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("glob %s: %w", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// hashFile returns the hex SHA256 of a file.
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return hashBytes(data), nil
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteChecksums (re)generates the manifest in dir for all registered artifacts
+// present there. Lines are sorted by path so the manifest is deterministic.
+func WriteChecksums(dir string) error {
+	return writeChecksums(DirWriter(dir))
+}
+
+// writeChecksums is WriteChecksums over any OutputWriter.
+func writeChecksums(out OutputWriter) error {
+	files, err := collectArtifacts(out)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, f := range files {
+		data, err := out.ReadFile(f)
+		if err != nil {
+			return fmt.Errorf("hash %s: %w", f, err)
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s", hashBytes(data), f))
+	}
+
+	content := strings.Join(lines, "\n") + "\n"
+	return out.WriteFile(ChecksumsFile, []byte(content))
+}
+
+// readManifest parses "<sha256>  <path>" lines into path -> hash.
+func readManifest(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	manifest := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		hash, file, ok := strings.Cut(line, "  ")
+		if !ok || len(hash) != sha256.Size*2 || file == "" {
+			return nil, fmt.Errorf("%s:%d: malformed checksum line", ChecksumsFile, lineNo)
+		}
+		manifest[file] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// VerifyChecksums recomputes SHA256 for every file listed in dir/checksums.sha256
+// and reports files present in dir but absent from the manifest. Results are sorted
+// by path. An error is returned only if the manifest cannot be read or parsed.
+func VerifyChecksums(dir string) ([]ChecksumResult, error) {
+	manifest, err := readManifest(filepath.Join(dir, ChecksumsFile))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	var results []ChecksumResult
+	for file, expected := range manifest {
+		r := ChecksumResult{File: file, Expected: expected}
+		actual, err := hashFile(filepath.Join(dir, filepath.FromSlash(file)))
+		switch {
+		case os.IsNotExist(err):
+			r.Status = ChecksumMissing
+		case err != nil:
+			return nil, fmt.Errorf("hash %s: %w", file, err)
+		case actual == expected:
+			r.Actual, r.Status = actual, ChecksumOK
+		default:
+			r.Actual, r.Status = actual, ChecksumMismatch
+		}
+		results = append(results, r)
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == filepath.Join(dir, PreviewDir) || path == filepath.Join(dir, RunsDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ChecksumsFile {
+			return nil
+		}
+		if _, listed := manifest[rel]; !listed {
+			actual, err := hashFile(path)
+			if err != nil {
+				return err
+			}
+			results = append(results, ChecksumResult{File: rel, Status: ChecksumUnlisted, Actual: actual})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", dir, err)
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].File < results[j].File })
+	return results, nil
+}
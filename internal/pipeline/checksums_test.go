@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeArtifacts(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func statusByFile(results []ChecksumResult) map[string]ChecksumStatus {
+	m := make(map[string]ChecksumStatus)
+	for _, r := range results {
+		m[r.File] = r.Status
+	}
+	return m
+}
+
+func TestWriteChecksums_SortedRegisteredArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifacts(t, dir, map[string]string{
+		"trade_records.csv":   "trades",
+		"REPORT_PHASE1.md":    "report",
+		"charts/cand_b.svg":   "<svg/>",
+		"charts/cand_a.svg":   "<svg/>",
+		"notes.txt":           "not an artifact",
+		"metrics_queries.sql": "SELECT 1;",
+	})
+
+	if err := WriteChecksums(dir); err != nil {
+		t.Fatalf("WriteChecksums failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ChecksumsFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		files = append(files, strings.SplitN(line, "  ", 2)[1])
+	}
+	want := []string{"REPORT_PHASE1.md", "charts/cand_a.svg", "charts/cand_b.svg", "metrics_queries.sql", "trade_records.csv"}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("expected manifest %v, got %v", want, files)
+	}
+}
+
+func TestVerifyChecksums_TamperedMissingAndUnlisted(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifacts(t, dir, map[string]string{
+		"REPORT_PHASE1.md":        "report",
+		"trade_records.csv":       "trades",
+		"strategy_aggregates.csv": "aggregates",
+		"charts/cand_a.svg":       "<svg/>",
+	})
+	if err := WriteChecksums(dir); err != nil {
+		t.Fatalf("WriteChecksums failed: %v", err)
+	}
+
+	results, err := VerifyChecksums(dir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums failed: %v", err)
+	}
+	if !ChecksumsOK(results) || len(results) != 4 {
+		t.Fatalf("expected 4 OK results for untouched artifacts, got %+v", results)
+	}
+
+	// Tamper, delete and add files
+	writeArtifacts(t, dir, map[string]string{
+		"trade_records.csv": "trades, edited",
+		"charts/extra.svg":  "<svg/>",
+	})
+	if err := os.Remove(filepath.Join(dir, "strategy_aggregates.csv")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	results, err = VerifyChecksums(dir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums failed: %v", err)
+	}
+	if ChecksumsOK(results) {
+		t.Fatal("expected verification to fail")
+	}
+
+	got := statusByFile(results)
+	want := map[string]ChecksumStatus{
+		"REPORT_PHASE1.md":        ChecksumOK,
+		"charts/cand_a.svg":       ChecksumOK,
+		"charts/extra.svg":        ChecksumUnlisted,
+		"strategy_aggregates.csv": ChecksumMissing,
+		"trade_records.csv":       ChecksumMismatch,
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d results, got %+v", len(want), results)
+	}
+	for file, status := range want {
+		if got[file] != status {
+			t.Errorf("%s: expected %s, got %s", file, status, got[file])
+		}
+	}
+
+	// Results are sorted by path
+	for i := 1; i < len(results); i++ {
+		if results[i-1].File > results[i].File {
+			t.Errorf("results not sorted: %s before %s", results[i-1].File, results[i].File)
+		}
+	}
+}
+
+func TestVerifyChecksums_MalformedManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifacts(t, dir, map[string]string{ChecksumsFile: "not-a-hash report.md\n"})
+
+	if _, err := VerifyChecksums(dir); err == nil {
+		t.Error("expected error for malformed manifest")
+	}
+	if _, err := VerifyChecksums(t.TempDir()); err == nil {
+		t.Error("expected error for missing manifest")
+	}
+}
+
+func TestWriteChecksums_MemoryWriterMatchesDirectory(t *testing.T) {
+	files := map[string]string{
+		"REPORT_PHASE1.md":  "report",
+		"charts/cand_a.svg": "<svg/>",
+		"notes.txt":         "not an artifact",
+	}
+	dir := t.TempDir()
+	writeArtifacts(t, dir, files)
+	if err := WriteChecksums(dir); err != nil {
+		t.Fatalf("WriteChecksums failed: %v", err)
+	}
+
+	mem := NewMemoryWriter()
+	for name, content := range files {
+		if err := mem.WriteFile(name, []byte(content)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := writeChecksums(mem); err != nil {
+		t.Fatalf("writeChecksums failed: %v", err)
+	}
+
+	want, _ := os.ReadFile(filepath.Join(dir, ChecksumsFile))
+	got, err := mem.ReadFile(ChecksumsFile)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("manifest mismatch:\n--- memory ---\n%s--- directory ---\n%s", got, want)
+	}
+
+	// RemoveAll drops the subtree only
+	if err := mem.RemoveAll("charts"); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+	if names, _ := mem.Glob("charts/*.svg"); len(names) != 0 {
+		t.Errorf("expected charts removed, got %v", names)
+	}
+	if _, err := mem.ReadFile("REPORT_PHASE1.md"); err != nil {
+		t.Errorf("expected report kept, got %v", err)
+	}
+}
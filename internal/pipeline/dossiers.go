@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/sampling"
+	"solana-token-lab/internal/storage"
+)
+
+// DossierDir is the output subdirectory of the dossier batch export.
+const DossierDir = "dossiers"
+
+// WithDossierExport writes the dossier of every candidate to dossiers/<candidate_id>.json
+// alongside the report, or of a sample of the candidates with WithSampleSize.
+func (p *Phase1Pipeline) WithDossierExport(candidates storage.CandidateStore, builder *dossier.Builder) *Phase1Pipeline {
+	p.dossierCandidates = candidates
+	p.dossierBuilder = builder
+	return p
+}
+
+// writeDossiers renders the dossier batch export. Dossiers are built before the
+// output is touched so a failing store leaves no partial export.
+func (p *Phase1Pipeline) writeDossiers(ctx context.Context, report *reporting.Report) error {
+	if p.dossierBuilder == nil || p.dossierCandidates == nil || !p.artifacts.Enabled(ArtifactDossiers) {
+		return nil
+	}
+
+	var candidates []*domain.TokenCandidate
+	for _, source := range []domain.Source{domain.SourceNewToken, domain.SourceActiveToken} {
+		cands, err := p.dossierCandidates.GetBySource(ctx, source)
+		if err != nil {
+			return fmt.Errorf("get %s candidates for dossiers: %w", source, err)
+		}
+		candidates = append(candidates, cands...)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].CandidateID < candidates[j].CandidateID
+	})
+	selected := sampling.Select(candidates, func(c *domain.TokenCandidate) string {
+		return c.CandidateID
+	}, p.sampleSize, report.Reproducibility.DataVersion)
+
+	files := make([][]byte, len(selected))
+	for i, cand := range selected {
+		d, err := p.dossierBuilder.Build(ctx, cand.CandidateID)
+		if err != nil {
+			return fmt.Errorf("build dossier for %s: %w", cand.CandidateID, err)
+		}
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal dossier for %s: %w", cand.CandidateID, err)
+		}
+		files[i] = append(data, '\n')
+	}
+
+	// Drop dossiers of earlier runs so the checksum manifest only covers this export
+	if err := p.out.RemoveAll(DossierDir); err != nil {
+		return err
+	}
+	for i, cand := range selected {
+		rel := path.Join(DossierDir, filepath.Base(cand.CandidateID)+".json")
+		if err := p.out.WriteFile(rel, files[i]); err != nil {
+			return err
+		}
+	}
+
+	report.DossierCount = len(selected)
+	if sampling.Sampled(p.sampleSize, len(candidates)) {
+		report.DossierSample = &reporting.SampleInfo{Size: len(selected), Population: len(candidates)}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/decision"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestPipeline_DossierExportSample(t *testing.T) {
+	ctx := context.Background()
+	candidates := memory.NewCandidateStore()
+	for i := 0; i < 30; i++ {
+		id := fmt.Sprintf("dos_%02d", i)
+		if err := candidates.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "mint_" + id, TxSignature: "tx_" + id,
+			Slot: int64(100 + i), DiscoveredAt: int64(1000000 + i),
+		}); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+	}
+	trades := memory.NewTradeRecordStore()
+
+	exported := func(sampleSize int) ([]string, string) {
+		p := NewPhase1Pipeline(candidates, trades, memory.NewStrategyAggregateStore(), map[decision.StrategyKey]bool{}, "").
+			WithClock(func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }).
+			WithDossierExport(candidates, dossier.NewBuilder(dossier.Stores{Candidates: candidates, Trades: trades})).
+			WithSampleSize(sampleSize)
+		artifacts, err := p.RunArtifacts(ctx)
+		if err != nil {
+			t.Fatalf("RunArtifacts: %v", err)
+		}
+		var files []string
+		for name := range artifacts.Files {
+			if strings.HasPrefix(name, DossierDir+"/") {
+				files = append(files, name)
+			}
+		}
+		sort.Strings(files)
+		return files, string(artifacts.Files["REPORT_PHASE1.md"])
+	}
+
+	all, md := exported(0)
+	if len(all) != 30 || strings.Contains(md, "**Sampled:**") {
+		t.Errorf("expected 30 unsampled dossiers, got %d", len(all))
+	}
+
+	sample, md := exported(5)
+	if len(sample) != 5 {
+		t.Fatalf("expected 5 dossiers, got %v", sample)
+	}
+	if !strings.Contains(md, "**Sampled:** 5 of 30 candidates") {
+		t.Errorf("report must state the dossier export was sampled:\n%s", md)
+	}
+	again, _ := exported(5)
+	if strings.Join(sample, ",") != strings.Join(again, ",") {
+		t.Errorf("same data sampled different dossiers:\n%v\n%v", sample, again)
+	}
+}
@@ -0,0 +1,114 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
+)
+
+// WithFeatureAnalysis relates the entry features of strategyID's trades under
+// scenario (a base scenario ID, versioned with WithScenarioVersion) to their
+// outcomes: feature_analysis.csv and a ranked summary in REPORT_PHASE1.md (see
+// metrics.AnalyzeEntryFeatures). bins <= 0 uses metrics.DefaultFeatureBins.
+// Besides the entry context, swaps add the buy/sell imbalance and holders the
+// holder concentration before each entry; both are optional.
+func (p *Phase1Pipeline) WithFeatureAnalysis(strategyID, scenario string, bins int, swaps storage.SwapStore, holders storage.TokenHolderSnapshotStore) *Phase1Pipeline {
+	p.featureStrategy = strategyID
+	p.featureScenario = scenario
+	p.featureBins = bins
+	p.featureSwaps = swaps
+	p.featureHolders = holders
+	return p
+}
+
+// analyzeFeatures sets report.FeatureAnalysis. A failing swap or holder store
+// omits its feature and is recorded in the provenance; only a cancelled
+// context fails the run.
+func (p *Phase1Pipeline) analyzeFeatures(ctx context.Context, report *reporting.Report, trades []*domain.TradeRecord) error {
+	if p.featureStrategy == "" {
+		return nil
+	}
+	scenarioID := domain.VersionedScenarioID(p.featureScenario, p.scenarioVersion)
+
+	var selected []*domain.TradeRecord
+	for _, t := range trades {
+		if t.StrategyID == p.featureStrategy && t.ScenarioID == scenarioID {
+			selected = append(selected, t)
+		}
+	}
+
+	extra := metrics.FeatureValues{}
+	var source string
+	var storeErr error
+	if p.featureSwaps != nil {
+		err := setCandidateFeature(ctx, selected, func(candidateID string) ([]*domain.Swap, error) {
+			return p.featureSwaps.GetByCandidateID(ctx, candidateID)
+		}, func(t *domain.TradeRecord, swaps []*domain.Swap) {
+			if v, ok := metrics.BuySellImbalance(swaps, t.EntrySignalTime); ok {
+				extra.Set(metrics.FeatureBuySellImbalance, t.TradeID, v)
+			}
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			delete(extra, metrics.FeatureBuySellImbalance)
+			source, storeErr = "swaps", err
+		}
+	}
+	if p.featureHolders != nil {
+		err := setCandidateFeature(ctx, selected, func(candidateID string) ([]*domain.TokenHolderSnapshot, error) {
+			return p.featureHolders.GetByCandidateID(ctx, candidateID)
+		}, func(t *domain.TradeRecord, snapshots []*domain.TokenHolderSnapshot) {
+			if v, ok := metrics.HolderConcentration(snapshots, t.EntrySignalTime); ok {
+				extra.Set(metrics.FeatureHolderTop10Pct, t.TradeID, v)
+			}
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			delete(extra, metrics.FeatureHolderTop10Pct)
+			source, storeErr = "token_holder_snapshots", err
+		}
+	}
+	if storeErr != nil {
+		report.Provenance.Record(reporting.SectionFeatureAnalysis, source, reporting.SourceFallback, false, storeErr)
+	} else {
+		report.Provenance.Record(reporting.SectionFeatureAnalysis, "trade_records", reporting.SourceMissing, false, nil)
+	}
+
+	report.FeatureAnalysis = metrics.AnalyzeEntryFeatures(selected, p.featureStrategy, scenarioID, extra, p.featureBins)
+	return nil
+}
+
+// setCandidateFeature loads the rows of every candidate of trades once and
+// passes them to set with each of the candidate's trades.
+func setCandidateFeature[T any](ctx context.Context, trades []*domain.TradeRecord, load func(candidateID string) ([]T, error), set func(*domain.TradeRecord, []T)) error {
+	rows := make(map[string][]T)
+	for _, t := range trades {
+		r, ok := rows[t.CandidateID]
+		if !ok {
+			var err error
+			if r, err = load(t.CandidateID); err != nil {
+				return fmt.Errorf("load entry features of %s: %w", t.CandidateID, err)
+			}
+			rows[t.CandidateID] = r
+		}
+		set(t, r)
+	}
+	return ctx.Err()
+}
+
+// writeFeatureAnalysis writes feature_analysis.csv; without WithFeatureAnalysis
+// it removes the file of an earlier run.
+func (p *Phase1Pipeline) writeFeatureAnalysis(report *reporting.Report) error {
+	if report.FeatureAnalysis == nil {
+		return p.out.RemoveAll(artifactPaths[ArtifactFeatureAnalysis])
+	}
+	return p.writeArtifact(ArtifactFeatureAnalysis, []byte(reporting.RenderFeatureAnalysisCSV(report.FeatureAnalysis)))
+}
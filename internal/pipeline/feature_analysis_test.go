@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// failingHolderStore fails every read.
+type failingHolderStore struct {
+	*memory.TokenHolderSnapshotStore
+}
+
+func (failingHolderStore) GetByCandidateID(context.Context, string) ([]*domain.TokenHolderSnapshot, error) {
+	return nil, errors.New("postgres unavailable")
+}
+
+func TestPhase1Pipeline_FeatureAnalysis(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	trades, err := tradeStore.GetByStrategyScenario(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic)
+	if err != nil || len(trades) < 2 {
+		t.Fatalf("expected fixture trades, got %d (%v)", len(trades), err)
+	}
+	swapStore := memory.NewSwapStore()
+	for i, tr := range trades {
+		tr.EntryContext = &domain.EntryContext{Volume1h: float64(i), TokenAgeMs: 60000}
+		if err := tradeStore.Upsert(ctx, tr); err != nil {
+			t.Fatalf("upsert trade: %v", err)
+		}
+	}
+	if err := swapStore.InsertBulk(ctx, []*domain.Swap{
+		{CandidateID: trades[0].CandidateID, TxSignature: "sig1", Timestamp: trades[0].EntrySignalTime - 1000, Side: domain.SwapSideBuy},
+	}); err != nil {
+		t.Fatalf("insert swaps: %v", err)
+	}
+
+	holders := failingHolderStore{memory.NewTokenHolderSnapshotStore()}
+	artifacts, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, nil, "").
+		WithFeatureAnalysis(domain.StrategyTypeTimeExit, domain.ScenarioRealistic, 2, swapStore, holders).
+		RunArtifacts(ctx)
+	if err != nil {
+		t.Fatalf("RunArtifacts failed: %v", err)
+	}
+
+	csv := string(artifacts.Files["feature_analysis.csv"])
+	if !strings.HasPrefix(csv, "strategy_id,scenario_id,feature,rank,bin,bin_lower,bin_upper,trades,wins,win_rate,median_outcome\n") {
+		t.Fatalf("unexpected feature_analysis.csv:\n%s", csv)
+	}
+	for _, want := range []string{`"TIME_EXIT","realistic",volume_1h,`, `"TIME_EXIT","realistic",buy_sell_imbalance,`} {
+		if !strings.Contains(csv, want) {
+			t.Errorf("feature_analysis.csv missing %q:\n%s", want, csv)
+		}
+	}
+	if strings.Contains(csv, "holder_top10_pct") {
+		t.Errorf("feature of the failing store in feature_analysis.csv:\n%s", csv)
+	}
+
+	report := string(artifacts.Files["REPORT_PHASE1.md"])
+	if !strings.Contains(report, "## Entry Feature Analysis (TIME_EXIT, realistic)") || !strings.Contains(report, "| volume_1h |") {
+		t.Errorf("REPORT_PHASE1.md lacks the feature summary:\n%s", report)
+	}
+	// The failing holder store degrades the section without failing the run
+	var meta struct {
+		Provenance []sectionProvenanceJSON `json:"provenance"`
+	}
+	if err := json.Unmarshal(artifacts.Files["metadata.json"], &meta); err != nil {
+		t.Fatalf("parse metadata.json: %v", err)
+	}
+	var found bool
+	for _, s := range meta.Provenance {
+		if s.Section == "feature_analysis" {
+			found = s.Source == "token_holder_snapshots" && s.Status == "fallback"
+		}
+	}
+	if !found {
+		t.Errorf("metadata.json lacks the degraded feature analysis: %+v", meta.Provenance)
+	}
+	if !strings.Contains(string(artifacts.Files[ChecksumsFile]), "  feature_analysis.csv\n") {
+		t.Error("feature_analysis.csv missing from the checksum manifest")
+	}
+}
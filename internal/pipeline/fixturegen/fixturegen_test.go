@@ -0,0 +1,144 @@
+package fixturegen
+
+import (
+	"bytes"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func smallParams() Params {
+	p := DefaultParams()
+	p.Candidates = 20
+	p.Days = 2
+	p.HistoryMinutes = 30
+	return p
+}
+
+func writeJSONL(t *testing.T, p Params) []byte {
+	t.Helper()
+	ds, err := Generate(p)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := ds.WriteJSONL(&buf); err != nil {
+		t.Fatalf("WriteJSONL failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerate_SeedDeterminism(t *testing.T) {
+	p := smallParams()
+
+	first := writeJSONL(t, p)
+	second := writeJSONL(t, p)
+	if !bytes.Equal(first, second) {
+		t.Error("same params produced different output")
+	}
+
+	p.Seed = 2
+	if bytes.Equal(first, writeJSONL(t, p)) {
+		t.Error("different seeds produced identical output")
+	}
+}
+
+func TestGenerate_GrowingCountKeepsEarlierCandidates(t *testing.T) {
+	p := smallParams()
+	p.Days = 1
+	small, err := Generate(p)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Same spacing with twice the candidates over twice the days
+	p.Candidates *= 2
+	p.Days *= 2
+	large, err := Generate(p)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for i, c := range small.Candidates {
+		if large.Candidates[i].CandidateID != c.CandidateID {
+			t.Fatalf("candidate %d changed: %s vs %s", i, c.CandidateID, large.Candidates[i].CandidateID)
+		}
+	}
+}
+
+func TestGenerate_Shape(t *testing.T) {
+	p := smallParams()
+	ds, err := Generate(p)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if len(ds.Candidates) != p.Candidates {
+		t.Fatalf("expected %d candidates, got %d", p.Candidates, len(ds.Candidates))
+	}
+	wantPoints := p.Candidates * p.HistoryMinutes
+	if len(ds.Swaps) != wantPoints || len(ds.Prices) != wantPoints || len(ds.Liquidity) != wantPoints {
+		t.Errorf("expected %d swaps/prices/liquidity points, got %d/%d/%d",
+			wantPoints, len(ds.Swaps), len(ds.Prices), len(ds.Liquidity))
+	}
+
+	endMs := p.StartMs + int64(p.Days)*dayMs
+	for _, c := range ds.Candidates {
+		if c.DiscoveredAt < p.StartMs || c.DiscoveredAt >= endMs {
+			t.Errorf("candidate %s discovered outside window: %d", c.CandidateID, c.DiscoveredAt)
+		}
+	}
+	for _, pt := range ds.Prices {
+		if pt.Price <= 0 {
+			t.Fatalf("non-positive price for %s at %d", pt.CandidateID, pt.TimestampMs)
+		}
+	}
+}
+
+func TestGenerate_RugRate(t *testing.T) {
+	countRemovals := func(rugRate float64) int {
+		p := smallParams()
+		p.RugRate = rugRate
+		ds, err := Generate(p)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		n := 0
+		for _, e := range ds.LiquidityEvents {
+			if e.EventType == domain.LiquidityEventRemove {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := countRemovals(0); n != 0 {
+		t.Errorf("rug rate 0: expected no removals, got %d", n)
+	}
+	if n := countRemovals(1); n != smallParams().Candidates {
+		t.Errorf("rug rate 1: expected one removal per candidate, got %d", n)
+	}
+}
+
+func TestParams_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Params)
+	}{
+		{"zero candidates", func(p *Params) { p.Candidates = 0 }},
+		{"zero days", func(p *Params) { p.Days = 0 }},
+		{"rug rate above 1", func(p *Params) { p.RugRate = 1.5 }},
+		{"negative active share", func(p *Params) { p.ActiveShare = -0.1 }},
+		{"short history", func(p *Params) { p.HistoryMinutes = 1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DefaultParams()
+			tt.mutate(&p)
+			if _, err := Generate(p); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
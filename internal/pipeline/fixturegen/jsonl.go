@@ -0,0 +1,65 @@
+package fixturegen
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Record types written by WriteJSONL.
+const (
+	RecordCandidate      = "candidate"
+	RecordSwap           = "swap"
+	RecordLiquidityEvent = "liquidity_event"
+	RecordPrice          = "price"
+	RecordLiquidity      = "liquidity"
+)
+
+// jsonlRecord is one line of the JSONL export.
+type jsonlRecord struct {
+	Type   string      `json:"type"`
+	Record interface{} `json:"record"`
+}
+
+// WriteJSONL writes the dataset as one JSON object per line, tagged with its
+// record type. Records are written in dataset order, so output is byte-stable.
+func (ds *Dataset) WriteJSONL(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	write := func(typ string, rec interface{}) error {
+		if err := enc.Encode(jsonlRecord{Type: typ, Record: rec}); err != nil {
+			return fmt.Errorf("encode %s: %w", typ, err)
+		}
+		return nil
+	}
+
+	for _, c := range ds.Candidates {
+		if err := write(RecordCandidate, c); err != nil {
+			return err
+		}
+	}
+	for _, s := range ds.Swaps {
+		if err := write(RecordSwap, s); err != nil {
+			return err
+		}
+	}
+	for _, e := range ds.LiquidityEvents {
+		if err := write(RecordLiquidityEvent, e); err != nil {
+			return err
+		}
+	}
+	for _, pt := range ds.Prices {
+		if err := write(RecordPrice, pt); err != nil {
+			return err
+		}
+	}
+	for _, pt := range ds.Liquidity {
+		if err := write(RecordLiquidity, pt); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
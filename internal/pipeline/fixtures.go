@@ -2,8 +2,10 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/pipeline/fixturegen"
 	"solana-token-lab/internal/storage"
 )
 
@@ -54,6 +56,56 @@ func LoadCandidatesAndTrades(
 	return nil
 }
 
+// GeneratedStores are the stores LoadGenerated writes to. Nil stores are skipped.
+type GeneratedStores struct {
+	Candidates      storage.CandidateStore
+	Swaps           storage.SwapStore
+	LiquidityEvents storage.LiquidityEventStore
+	Prices          storage.PriceTimeseriesStore
+	Liquidity       storage.LiquidityTimeseriesStore
+}
+
+// LoadGenerated populates stores with a synthetic dataset from fixturegen.
+// Unlike the bundled fixtures, a dataset generated with fixturegen.DefaultParams
+// is large enough to pass the sufficiency checks. No trades are loaded;
+// simulate them via the orchestrator.
+func LoadGenerated(ctx context.Context, stores GeneratedStores, params fixturegen.Params) error {
+	ds, err := fixturegen.Generate(params)
+	if err != nil {
+		return fmt.Errorf("generate fixtures: %w", err)
+	}
+
+	if stores.Candidates != nil {
+		for _, c := range ds.Candidates {
+			if err := stores.Candidates.Insert(ctx, c); err != nil {
+				return fmt.Errorf("insert candidate %s: %w", c.CandidateID, err)
+			}
+		}
+	}
+	if stores.Swaps != nil {
+		if err := stores.Swaps.InsertBulk(ctx, ds.Swaps); err != nil {
+			return fmt.Errorf("insert swaps: %w", err)
+		}
+	}
+	if stores.LiquidityEvents != nil {
+		if err := stores.LiquidityEvents.InsertBulk(ctx, ds.LiquidityEvents); err != nil {
+			return fmt.Errorf("insert liquidity events: %w", err)
+		}
+	}
+	if stores.Prices != nil {
+		if err := stores.Prices.InsertBulk(ctx, ds.Prices); err != nil {
+			return fmt.Errorf("insert price timeseries: %w", err)
+		}
+	}
+	if stores.Liquidity != nil {
+		if err := stores.Liquidity.InsertBulk(ctx, ds.Liquidity); err != nil {
+			return fmt.Errorf("insert liquidity timeseries: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // LoadCandidatesOnly populates candidate store with test data (no trades).
 // Use this when simulation will generate trades fresh via orchestrator.
 func LoadCandidatesOnly(
@@ -111,6 +163,7 @@ func loadTrades(ctx context.Context, store storage.TradeRecordStore) error {
 			ExitActualPrice:  1.08,
 			Outcome:          0.08,
 			OutcomeClass:     domain.OutcomeClassWin,
+			HoldDurationMs:   3600000,
 		},
 		{
 			TradeID:          "trade_002",
@@ -127,6 +180,7 @@ func loadTrades(ctx context.Context, store storage.TradeRecordStore) error {
 			ExitActualPrice:  1.05,
 			Outcome:          0.05,
 			OutcomeClass:     domain.OutcomeClassWin,
+			HoldDurationMs:   3600000,
 		},
 		// TIME_EXIT strategy, degraded scenario, NEW_TOKEN
 		{
@@ -144,6 +198,7 @@ func loadTrades(ctx context.Context, store storage.TradeRecordStore) error {
 			ExitActualPrice:  1.04,
 			Outcome:          0.04,
 			OutcomeClass:     domain.OutcomeClassWin,
+			HoldDurationMs:   3600000,
 		},
 		{
 			TradeID:          "trade_004",
@@ -160,6 +215,7 @@ func loadTrades(ctx context.Context, store storage.TradeRecordStore) error {
 			ExitActualPrice:  1.02,
 			Outcome:          0.02,
 			OutcomeClass:     domain.OutcomeClassWin,
+			HoldDurationMs:   3600000,
 		},
 		// ACTIVE_TOKEN trades
 		{
@@ -177,6 +233,7 @@ func loadTrades(ctx context.Context, store storage.TradeRecordStore) error {
 			ExitActualPrice:  1.03,
 			Outcome:          0.03,
 			OutcomeClass:     domain.OutcomeClassWin,
+			HoldDurationMs:   3600000,
 		},
 	}
 
@@ -0,0 +1,143 @@
+package pipeline
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OutputWriter is the destination of report artifacts. Names are slash-separated
+// paths relative to the output root (e.g. "charts/cand_001.svg").
+type OutputWriter interface {
+	// WriteFile creates or replaces name, creating parent directories as needed.
+	WriteFile(name string, data []byte) error
+	// ReadFile returns the content of name, or an error wrapping fs.ErrNotExist.
+	ReadFile(name string) ([]byte, error)
+	// RemoveAll removes name and everything below it. A missing name is not an error.
+	RemoveAll(name string) error
+	// Glob returns the names matching pattern (path.Match syntax), sorted.
+	Glob(pattern string) ([]string, error)
+}
+
+// DirWriter returns an OutputWriter over an OS directory. This is the default
+// output of Phase1Pipeline.
+func DirWriter(dir string) OutputWriter {
+	return dirWriter{dir: dir}
+}
+
+type dirWriter struct {
+	dir string
+}
+
+func (w dirWriter) path(name string) string {
+	return filepath.Join(w.dir, filepath.FromSlash(name))
+}
+
+func (w dirWriter) WriteFile(name string, data []byte) error {
+	full := w.path(name)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(full, data, 0644)
+}
+
+func (w dirWriter) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(w.path(name))
+}
+
+func (w dirWriter) RemoveAll(name string) error {
+	return os.RemoveAll(w.path(name))
+}
+
+func (w dirWriter) Glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(w.path(pattern))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		rel, err := filepath.Rel(w.dir, m)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, filepath.ToSlash(rel))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// MemoryWriter is an OutputWriter that keeps artifacts in memory.
+// It is safe for concurrent use.
+type MemoryWriter struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+// NewMemoryWriter creates an empty MemoryWriter.
+func NewMemoryWriter() *MemoryWriter {
+	return &MemoryWriter{files: make(map[string][]byte)}
+}
+
+// WriteFile stores a copy of data under name.
+func (w *MemoryWriter) WriteFile(name string, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files[path.Clean(name)] = append([]byte(nil), data...)
+	return nil
+}
+
+// ReadFile returns a copy of the content stored under name.
+func (w *MemoryWriter) ReadFile(name string) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	data, ok := w.files[path.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// RemoveAll removes name and every file below it.
+func (w *MemoryWriter) RemoveAll(name string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	name = path.Clean(name)
+	for f := range w.files {
+		if f == name || strings.HasPrefix(f, name+"/") {
+			delete(w.files, f)
+		}
+	}
+	return nil
+}
+
+// Glob returns the stored names matching pattern, sorted.
+func (w *MemoryWriter) Glob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	var names []string
+	for f := range w.files {
+		if ok, _ := path.Match(pattern, f); ok {
+			names = append(names, f)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Files returns a copy of all stored artifacts keyed by name.
+func (w *MemoryWriter) Files() map[string][]byte {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	out := make(map[string][]byte, len(w.files))
+	for name, data := range w.files {
+		out[name] = append([]byte(nil), data...)
+	}
+	return out
+}
@@ -5,10 +5,10 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -16,9 +16,12 @@ import (
 
 	"solana-token-lab/internal/decision"
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/dossier"
 	"solana-token-lab/internal/metrics"
 	"solana-token-lab/internal/replay"
 	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/reporting/schema"
+	"solana-token-lab/internal/sampling"
 	"solana-token-lab/internal/storage"
 )
 
@@ -28,27 +31,74 @@ const (
 	StrategyVersion  = "v1.0.0"
 )
 
+// DegradedDataVersionPrefix tags a DataVersion computed from substitute inputs after a
+// store failure, so it never matches the version of the complete data set.
+const DegradedDataVersionPrefix = "degraded:"
+
 // Phase1Pipeline orchestrates report + decision generation.
 type Phase1Pipeline struct {
 	reportGen          *reporting.Generator
 	decisionBuild      *decision.Builder
 	decisionEval       *decision.Evaluator
 	sufficiencyChecker *SufficiencyChecker
-	aggregator         *metrics.Aggregator      // optional, for collecting missing candidate errors
-	tradeStore         storage.TradeRecordStore // for CSV export
+	sufficiencyRuns    storage.SufficiencyRunStore // optional, persists sufficiency results
+	swapEventStore     storage.SwapEventStore      // optional, for sufficiency coverage check
+	finalityStore      storage.FinalityStore       // optional, reports unfinalized events
+	eventCountStore    storage.EventCountStore     // optional, reports downsampled candidates
+	metadataStore      storage.TokenMetadataStore  // optional, reports metadata coverage
+	closedOnly         bool                        // sufficiency over closed candidates only
+	minLiveSharePct    float64                     // live discovery sufficiency check, 0 disables
+	minMetadataPct     float64                     // metadata coverage sufficiency check, 0 disables
+	aggregator         *metrics.Aggregator         // optional, for collecting missing candidate errors
+	tradeStore         storage.TradeRecordStore    // for CSV export
 	outputDir          string
+	out                OutputWriter // defaults to DirWriter(outputDir)
 	clock              func() time.Time
 	integrityErrors    []string // additional integrity errors (e.g., from aggregation)
 	dataSource         string   // "fixtures" or "db" for replay command
 	postgresDSN        string   // for DB mode replay command
 	clickhouseDSN      string   // for DB mode replay command
+	mintFilterHash     string   // discovery blacklist/allowlist hash, "" if none
+	scenarioVersion    int      // scenario definitions version the report covers
 	// Raw data stores for DataVersion hash (per REPORTING_SPEC)
 	candidateStoreForHash    storage.CandidateStore
 	priceTimeseriesStoreHash storage.PriceTimeseriesStore
 	liqTimeseriesStoreHash   storage.LiquidityTimeseriesStore
+	// Optional chart rendering for top candidates of the best strategy
+	chartCandidateStore storage.CandidateStore
+	chartPriceStore     storage.PriceTimeseriesStore
+	chartLiqStore       storage.LiquidityTimeseriesStore
+	chartTopN           int
+	// Optional dossier batch export (see WithDossierExport)
+	dossierCandidates storage.CandidateStore
+	dossierBuilder    *dossier.Builder
+	// Candidate sample for the replayability check, charts and dossiers, 0 for all
+	sampleSize int
+	// Resolved implementability matrix recorded in metadata.json (see WithImplementability)
+	implementability decision.Implementability
+	// ClickHouse tables metrics_queries.sql reads (see WithMetricsQueryTables)
+	metricsTables reporting.MetricsQueryTables
+	// Artifacts the run writes (see WithArtifacts)
+	artifacts ArtifactSet
+	// Optional entry feature analysis (see WithFeatureAnalysis)
+	featureStrategy string
+	featureScenario string
+	featureBins     int
+	featureSwaps    storage.SwapStore
+	featureHolders  storage.TokenHolderSnapshotStore
+	// Optional comparison with a stored baseline (see WithBaselineComparison)
+	baseline           *reporting.Baseline
+	baselineTolerances reporting.BaselineTolerances
+	baselineDiff       *reporting.BaselineDiff
+	// Run manifest written after every run (see WithRunManifest)
+	manifestEnabled  bool
+	manifestStore    storage.RunManifestStore
+	manifestConfig   map[string]string
+	manifestBackends []storage.RunBackend
 }
 
-// NewPhase1Pipeline creates a new pipeline.
+// NewPhase1Pipeline creates a new pipeline. Binaries pass a nil implementable map
+// and set the resolved registry with WithImplementability.
 func NewPhase1Pipeline(
 	candidateStore storage.CandidateStore,
 	tradeStore storage.TradeRecordStore,
@@ -57,16 +107,21 @@ func NewPhase1Pipeline(
 	outputDir string,
 ) *Phase1Pipeline {
 	return &Phase1Pipeline{
-		reportGen:     reporting.NewGenerator(candidateStore, tradeStore, aggStore),
-		decisionBuild: decision.NewBuilder(implementable),
-		decisionEval:  decision.NewEvaluator(),
-		tradeStore:    tradeStore,
-		outputDir:     outputDir,
-		clock:         func() time.Time { return time.Now().UTC() },
+		reportGen:       reporting.NewGenerator(candidateStore, tradeStore, aggStore),
+		decisionBuild:   decision.NewBuilder(implementable),
+		decisionEval:    decision.NewEvaluator(),
+		tradeStore:      tradeStore,
+		outputDir:       outputDir,
+		out:             DirWriter(outputDir),
+		clock:           func() time.Time { return time.Now().UTC() },
+		scenarioVersion: domain.BaseScenarioVersion,
+		metricsTables:   reporting.DefaultMetricsQueryTables(),
+		artifacts:       ArtifactProfile(ArtifactProfileFull),
 	}
 }
 
-// WithSufficiencyChecker adds a sufficiency checker to the pipeline.
+// WithSufficiencyChecker adds a sufficiency checker to the pipeline. The
+// liquidity store also feeds the report's ingestion origin split.
 func (p *Phase1Pipeline) WithSufficiencyChecker(
 	candidateStore storage.CandidateStore,
 	tradeStore storage.TradeRecordStore,
@@ -75,6 +130,138 @@ func (p *Phase1Pipeline) WithSufficiencyChecker(
 	replayRunner *replay.Runner,
 ) *Phase1Pipeline {
 	p.sufficiencyChecker = NewSufficiencyChecker(candidateStore, tradeStore, swapStore, liquidityStore, replayRunner)
+	p.sufficiencyChecker.WithClosedOnly(p.closedOnly)
+	p.sufficiencyChecker.WithMinLiveShare(p.minLiveSharePct)
+	p.sufficiencyChecker.WithMinMetadataCoverage(p.minMetadataPct)
+	p.reportGen = p.reportGen.WithLiquidityEventStore(liquidityStore)
+	if p.swapEventStore != nil {
+		p.sufficiencyChecker.WithSwapEventStore(p.swapEventStore)
+	}
+	if p.finalityStore != nil {
+		p.sufficiencyChecker.WithFinalityStore(p.finalityStore)
+	}
+	if p.eventCountStore != nil {
+		p.sufficiencyChecker.WithEventCountStore(p.eventCountStore)
+	}
+	if p.metadataStore != nil {
+		p.sufficiencyChecker.WithMetadataStore(p.metadataStore)
+	}
+	return p
+}
+
+// WithImplementability decides strategy implementability from matrix instead of
+// the constructor's map, and records the matrix in metadata.json.
+func (p *Phase1Pipeline) WithImplementability(matrix decision.Implementability) *Phase1Pipeline {
+	p.implementability = matrix
+	p.decisionBuild = decision.NewBuilder(matrix.Map())
+	return p
+}
+
+// WithSufficiencyRunStore records each run's sufficiency checks in store and
+// renders the report's checks from the stored run, so the report and the API
+// serving the latest run agree.
+func (p *Phase1Pipeline) WithSufficiencyRunStore(store storage.SufficiencyRunStore) *Phase1Pipeline {
+	p.sufficiencyRuns = store
+	return p
+}
+
+// WithRunManifest writes a run manifest (see NewRunManifest) to
+// RunsDir/report/ after every run, failed ones included, and to store when set.
+// config is the effective configuration of the binary; credentials are redacted.
+func (p *Phase1Pipeline) WithRunManifest(store storage.RunManifestStore, config map[string]string, backends []storage.RunBackend) *Phase1Pipeline {
+	p.manifestEnabled = true
+	p.manifestStore = store
+	p.manifestConfig = config
+	p.manifestBackends = backends
+	return p
+}
+
+// WithFinalityStore makes the sufficiency checks report events still awaiting
+// finality verification. May be called before or after WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithFinalityStore(store storage.FinalityStore) *Phase1Pipeline {
+	p.finalityStore = store
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithFinalityStore(store)
+	}
+	return p
+}
+
+// WithEventCountStore makes the sufficiency checks report candidates whose
+// events were downsampled at ingestion. May be called before or after WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithEventCountStore(store storage.EventCountStore) *Phase1Pipeline {
+	p.eventCountStore = store
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithEventCountStore(store)
+	}
+	return p
+}
+
+// WithMetadataStore makes the sufficiency checks report the share of candidates
+// with token metadata, shown in the data summary. May be called before or after
+// WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithMetadataStore(store storage.TokenMetadataStore) *Phase1Pipeline {
+	p.metadataStore = store
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithMetadataStore(store)
+	}
+	return p
+}
+
+// WithMinMetadataCoverage requires at least pct percent of the candidates to
+// have token metadata (see SufficiencyChecker.WithMinMetadataCoverage).
+// May be called before or after WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithMinMetadataCoverage(pct float64) *Phase1Pipeline {
+	p.minMetadataPct = pct
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithMinMetadataCoverage(pct)
+	}
+	return p
+}
+
+// WithClosedOnly makes only candidates with a closed observation window count
+// towards the sufficiency checks; open candidates are reported separately.
+// May be called before or after WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithClosedOnly(closedOnly bool) *Phase1Pipeline {
+	p.closedOnly = closedOnly
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithClosedOnly(closedOnly)
+	}
+	return p
+}
+
+// WithMinLiveShare requires at least pct percent of the candidates to be
+// discovered via live ingestion (see SufficiencyChecker.WithMinLiveShare).
+// May be called before or after WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithMinLiveShare(pct float64) *Phase1Pipeline {
+	p.minLiveSharePct = pct
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithMinLiveShare(pct)
+	}
+	return p
+}
+
+// WithSwapEventStore sets the discovery swap event store used by the sufficiency
+// coverage check and the report's observed fee comparison. May be called before
+// or after WithSufficiencyChecker.
+func (p *Phase1Pipeline) WithSwapEventStore(store storage.SwapEventStore) *Phase1Pipeline {
+	p.swapEventStore = store
+	p.reportGen = p.reportGen.WithSwapEventStore(store)
+	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithSwapEventStore(store)
+	}
+	return p
+}
+
+// WithFailedTxStore adds the on-chain failure rate observed by ingestion to
+// the report's data summary.
+func (p *Phase1Pipeline) WithFailedTxStore(store storage.FailedTxStore) *Phase1Pipeline {
+	p.reportGen = p.reportGen.WithFailedTxStore(store)
+	return p
+}
+
+// WithOutput replaces the output directory with w. Artifact names are unchanged.
+func (p *Phase1Pipeline) WithOutput(w OutputWriter) *Phase1Pipeline {
+	p.out = w
 	return p
 }
 
@@ -85,6 +272,43 @@ func (p *Phase1Pipeline) WithClock(clock func() time.Time) *Phase1Pipeline {
 	return p
 }
 
+// WithDegradationThreshold sets the realistic→pessimistic degradation percentage
+// above which strategies are flagged in the scenario matrix.
+func (p *Phase1Pipeline) WithDegradationThreshold(pct float64) *Phase1Pipeline {
+	p.reportGen = p.reportGen.WithDegradationThreshold(pct)
+	return p
+}
+
+// WithMaxDataEndPct makes the decision NO-GO for strategies whose realistic
+// trades exit on DATA_END more than pct percent of the time. 0 disables.
+func (p *Phase1Pipeline) WithMaxDataEndPct(pct float64) *Phase1Pipeline {
+	p.decisionEval = p.decisionEval.WithMaxDataEndPct(pct)
+	return p
+}
+
+// WithSampleSizeTarget sets the realistic win rate precision, ±margin at
+// confidence (both 0-1), below which decisions other than GO tell how many
+// more trades reach it. A margin of 0 disables the guidance.
+func (p *Phase1Pipeline) WithSampleSizeTarget(margin, confidence float64) *Phase1Pipeline {
+	p.decisionEval = p.decisionEval.WithSampleSizeTarget(margin, confidence)
+	return p
+}
+
+// WithMinScenarioTrades sets the trade count below which a strategy's scenario
+// outcomes are low confidence and its decision is INSUFFICIENT_DATA.
+func (p *Phase1Pipeline) WithMinScenarioTrades(n int) *Phase1Pipeline {
+	p.reportGen = p.reportGen.WithMinScenarioTrades(n)
+	return p
+}
+
+// WithScenarioVersion reports on trades recomputed with the given version of the
+// scenario definitions (see simulation.RecomputeCosts) instead of the originals.
+func (p *Phase1Pipeline) WithScenarioVersion(version int) *Phase1Pipeline {
+	p.scenarioVersion = version
+	p.reportGen = p.reportGen.WithScenarioVersion(version)
+	return p
+}
+
 // WithIntegrityErrors adds additional integrity errors to include in the report.
 // These are merged with errors from sufficiency checks.
 // Use this to pass missing candidate errors from aggregation.
@@ -94,7 +318,7 @@ func (p *Phase1Pipeline) WithIntegrityErrors(errors []string) *Phase1Pipeline {
 }
 
 // WithAggregator sets the aggregator to automatically collect missing candidate errors.
-// The aggregator's MissingCandidates are collected during Run() and merged with integrity errors.
+// The aggregator's missing candidates are collected during Run() and merged with integrity errors.
 // This is the preferred way to wire aggregator errors - call this after computing aggregates.
 func (p *Phase1Pipeline) WithAggregator(agg *metrics.Aggregator) *Phase1Pipeline {
 	p.aggregator = agg
@@ -116,6 +340,13 @@ func (p *Phase1Pipeline) WithDBSource(postgresDSN, clickhouseDSN string) *Phase1
 	return p
 }
 
+// WithMintFilterHash records the hash of the discovery mint blacklist/allowlist
+// (discovery.MintFilter.Hash) in metadata.json. Empty means no lists were active.
+func (p *Phase1Pipeline) WithMintFilterHash(hash string) *Phase1Pipeline {
+	p.mintFilterHash = hash
+	return p
+}
+
 // WithRawDataStores sets raw data stores for DataVersion computation per REPORTING_SPEC.
 // DataVersion = SHA256(SHA256(price_timeseries) || SHA256(liquidity_timeseries) || SHA256(candidates))
 func (p *Phase1Pipeline) WithRawDataStores(
@@ -129,26 +360,168 @@ func (p *Phase1Pipeline) WithRawDataStores(
 	return p
 }
 
+// WithCharts enables SVG price/liquidity charts for the topN candidates by outcome
+// under the best strategy (realistic scenario). Charts are written to charts/ in the
+// output directory and linked from REPORT_PHASE1.md.
+func (p *Phase1Pipeline) WithCharts(
+	candidateStore storage.CandidateStore,
+	priceStore storage.PriceTimeseriesStore,
+	liqStore storage.LiquidityTimeseriesStore,
+	topN int,
+) *Phase1Pipeline {
+	p.chartCandidateStore = candidateStore
+	p.chartPriceStore = priceStore
+	p.chartLiqStore = liqStore
+	p.chartTopN = topN
+	return p
+}
+
+// WithSampleSize limits the replayability sufficiency check, the chart selection and
+// the dossier batch export to a deterministic sample of n candidates seeded by the report's data_version,
+// so regenerating a report from the same data samples the same candidates.
+// n <= 0 (the default) covers the full population.
+func (p *Phase1Pipeline) WithSampleSize(n int) *Phase1Pipeline {
+	p.sampleSize = n
+	return p
+}
+
+// WithMetricsQueryTables sets the ClickHouse tables metrics_queries.sql reads,
+// e.g. the database-qualified TableName of the deployment's ClickHouse stores.
+// Defaults to reporting.DefaultMetricsQueryTables, also kept for zero tables.
+func (p *Phase1Pipeline) WithMetricsQueryTables(tables reporting.MetricsQueryTables) *Phase1Pipeline {
+	if tables != (reporting.MetricsQueryTables{}) {
+		p.metricsTables = tables
+	}
+	return p
+}
+
+// WithArtifacts selects the artifacts the run writes, e.g. a profile from
+// ArtifactProfile or an --artifacts value parsed by ParseArtifacts.
+// metadata.json and checksums.sha256 are always written. Defaults to the full
+// profile, also kept for a nil set.
+func (p *Phase1Pipeline) WithArtifacts(set ArtifactSet) *Phase1Pipeline {
+	if set != nil {
+		p.artifacts = set
+	}
+	return p
+}
+
+// WithBaselineComparison compares the strategy aggregates of the run with a
+// stored baseline and writes the outcome to BASELINE_DIFF.md (see
+// reporting.CompareBaseline). The comparison is available from BaselineDiff
+// after the run.
+func (p *Phase1Pipeline) WithBaselineComparison(baseline *reporting.Baseline, tol reporting.BaselineTolerances) *Phase1Pipeline {
+	p.baseline = baseline
+	p.baselineTolerances = tol
+	return p
+}
+
+// BaselineDiff returns the baseline comparison of the last run, nil without
+// WithBaselineComparison or before a run.
+func (p *Phase1Pipeline) BaselineDiff() *reporting.BaselineDiff {
+	return p.baselineDiff
+}
+
+// Artifacts is the in-memory result of RunArtifacts.
+type Artifacts struct {
+	Files    map[string][]byte // artifact name (slash-separated) -> content
+	Decision decision.Decision // overall decision, as in REPORT_PHASE1.md
+}
+
+// RunArtifacts executes the pipeline like Run but renders into memory instead of
+// the configured output, returning every artifact and the overall decision.
+func (p *Phase1Pipeline) RunArtifacts(ctx context.Context) (*Artifacts, error) {
+	mem := NewMemoryWriter()
+	saved := p.out
+	p.out = mem
+	defer func() { p.out = saved }()
+
+	report, err := p.run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Artifacts{
+		Files:    mem.Files(),
+		Decision: decision.Decision(report.ExecutiveSummary.Decision),
+	}, nil
+}
+
 // Run executes full pipeline and writes output files:
 // - REPORT_PHASE1.md
 // - strategy_aggregates.csv
 // - trade_records.csv
 // - scenario_outcomes.csv
+// - scenario_matrix.csv
 // - DECISION_GATE_REPORT.md
+// - report.json, metrics_queries.sql, catalog.json
+// - charts/*.svg (if WithCharts is set)
+// - dossiers/*.json (if WithDossierExport is set)
+// - feature_analysis.csv (if WithFeatureAnalysis is set)
+// - BASELINE_DIFF.md (if WithBaselineComparison is set)
+// - metadata.json, checksums.sha256
+//
+// as selected by WithArtifacts. Files go to the output directory unless
+// WithOutput is set.
 func (p *Phase1Pipeline) Run(ctx context.Context) error {
-	// Ensure output directory exists
-	if err := os.MkdirAll(p.outputDir, 0755); err != nil {
-		return err
+	_, err := p.run(ctx)
+	return err
+}
+
+// RunReport executes the pipeline like Run and also returns the final report,
+// e.g. for publishing its outcome as metrics.
+func (p *Phase1Pipeline) RunReport(ctx context.Context) (*reporting.Report, error) {
+	return p.run(ctx)
+}
+
+// run generates the report and, with WithRunManifest, records the run's manifest.
+func (p *Phase1Pipeline) run(ctx context.Context) (*reporting.Report, error) {
+	if !p.manifestEnabled {
+		return p.generate(ctx)
+	}
+	manifest := NewRunManifest(storage.RunKindReport, time.Now(), p.manifestConfig, p.manifestBackends)
+	report, err := p.generate(ctx)
+	recordReportRun(manifest, report, err)
+	if mErr := WriteRunManifest(ctx, p.out, p.manifestStore, manifest); mErr != nil {
+		return report, errors.Join(err, mErr)
+	}
+	return report, err
+}
+
+// generate renders all artifacts through p.out and returns the final report.
+func (p *Phase1Pipeline) generate(ctx context.Context) (*reporting.Report, error) {
+	// 1. Generate report (data quality section is filled in below)
+	report, err := p.reportGen.Generate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Load trades early (needed for DataVersion hash and CSV export)
+	trades, err := p.tradeStore.GetAll(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		trades = nil
 	}
+	trades = reporting.FilterScenarioVersion(trades, p.scenarioVersion)
+	report.Provenance.Record(reporting.SectionTradeRecords, "trade_records", reporting.SourceMissing, false, err)
 
-	// 1. Run sufficiency check FIRST (if configured)
+	// 3. Populate Executive Summary
+	p.populateExecutiveSummary(report)
+
+	// 4. Populate Reproducibility metadata (needs trades for DataVersion)
+	p.populateReproducibility(ctx, report, trades)
+
+	// 5. Run sufficiency check (if configured); samples are drawn with the DataVersion
 	var dataQuality reporting.DataQualitySection
 	if p.sufficiencyChecker != nil {
+		p.sufficiencyChecker.WithReplaySample(p.sampleSize, report.Reproducibility.DataVersion)
 		suffResult, err := p.sufficiencyChecker.Check(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		dataQuality = convertToDataQuality(suffResult)
+		setMetadataCoverage(&report.DataSummary, suffResult)
 	}
 
 	// Collect missing candidate errors from aggregator (if configured)
@@ -157,6 +530,13 @@ func (p *Phase1Pipeline) Run(ctx context.Context) error {
 		if len(aggErrors) > 0 {
 			p.integrityErrors = append(p.integrityErrors, aggErrors...)
 		}
+		// Without sufficiency checks, the aggregator's exclusions are the deletion note
+		if p.sufficiencyChecker == nil {
+			for _, trades := range p.aggregator.DeletedCandidates() {
+				dataQuality.DeletedCandidates++
+				dataQuality.DeletedTrades += trades
+			}
+		}
 	}
 
 	// Merge additional integrity errors (e.g., from aggregation)
@@ -165,113 +545,129 @@ func (p *Phase1Pipeline) Run(ctx context.Context) error {
 		// If we have integrity errors, all checks did not pass
 		dataQuality.AllChecksPassed = false
 	}
+	report.DataQuality = dataQuality
 
-	// 2. Generate report (includes data quality section)
-	report, err := p.reportGen.Generate(ctx)
-	if err != nil {
-		return err
+	// 5a. Persist the sufficiency checks with the data version they gated
+	if p.sufficiencyChecker != nil && p.sufficiencyRuns != nil {
+		if err := p.storeSufficiencyRun(ctx, report); err != nil {
+			return nil, err
+		}
+		dataQuality = report.DataQuality
 	}
-	report.DataQuality = dataQuality
 
-	// 3. Load trades early (needed for DataVersion hash and CSV export)
-	trades, err := p.tradeStore.GetAll(ctx)
-	if err != nil {
-		return err
+	// 5b. Render charts for top candidates of the best strategy (if configured)
+	if err := p.writeCharts(ctx, report, trades); err != nil {
+		return nil, err
 	}
 
-	// 4. Populate Executive Summary
-	p.populateExecutiveSummary(report)
+	// Write the dossier batch export (if configured)
+	if err := p.writeDossiers(ctx, report); err != nil {
+		return nil, err
+	}
 
-	// 5. Populate Reproducibility metadata (needs trades for DataVersion)
-	p.populateReproducibility(ctx, report, trades)
+	// Relate entry features to outcomes (if configured)
+	if err := p.analyzeFeatures(ctx, report, trades); err != nil {
+		return nil, err
+	}
+
+	// 5c. A degraded decision-critical section makes the data insufficient for a decision
+	degraded := report.Provenance.CriticalDegraded()
+	if degraded {
+		dataQuality.IntegrityErrors = append(dataQuality.IntegrityErrors, report.Provenance.DegradedErrors()...)
+		dataQuality.AllChecksPassed = false
+		report.DataQuality = dataQuality
+	}
 
 	// 6. Set decision checklist reference
 	report.DecisionChecklistRef = "docs/DECISION_CHECKLIST.md"
 
 	// 7. Write REPORT_PHASE1.md
 	reportMD := reporting.RenderMarkdown(report)
-	reportPath := filepath.Join(p.outputDir, "REPORT_PHASE1.md")
-	if err := os.WriteFile(reportPath, []byte(reportMD), 0644); err != nil {
-		return err
+	if err := p.writeArtifact(ArtifactReport, []byte(reportMD)); err != nil {
+		return nil, err
 	}
 
-	// 8. Write strategy_aggregates.csv (18 columns per REPORTING_SPEC)
+	// 8. Write strategy_aggregates.csv (20 columns per REPORTING_SPEC)
 	aggCSV := reporting.RenderStrategyAggregatesCSV(report.StrategyMetrics)
-	aggPath := filepath.Join(p.outputDir, "strategy_aggregates.csv")
-	if err := os.WriteFile(aggPath, []byte(aggCSV), 0644); err != nil {
-		return err
+	if err := p.writeArtifact(ArtifactStrategyAggregates, []byte(aggCSV)); err != nil {
+		return nil, err
 	}
 
-	// 9. Write trade_records.csv (27 columns per REPORTING_SPEC)
+	// 9. Write trade_records.csv (31 columns per REPORTING_SPEC)
 	tradeCSV := reporting.RenderTradeRecordsCSV(trades)
-	tradePath := filepath.Join(p.outputDir, "trade_records.csv")
-	if err := os.WriteFile(tradePath, []byte(tradeCSV), 0644); err != nil {
-		return err
+	if err := p.writeArtifact(ArtifactTradeRecords, []byte(tradeCSV)); err != nil {
+		return nil, err
 	}
 
 	// 9. Write scenario_outcomes.csv (6 columns per REPORTING_SPEC)
 	scenarioCSV := reporting.RenderScenarioOutcomesCSV(report.ScenarioSensitivity)
-	scenarioPath := filepath.Join(p.outputDir, "scenario_outcomes.csv")
-	if err := os.WriteFile(scenarioPath, []byte(scenarioCSV), 0644); err != nil {
-		return err
+	if err := p.writeArtifact(ArtifactScenarioOutcomes, []byte(scenarioCSV)); err != nil {
+		return nil, err
+	}
+
+	// Write scenario_matrix.csv (strategies × scenarios with degradation flags)
+	matrixCSV := reporting.RenderScenarioMatrixCSV(report.ScenarioMatrix)
+	if err := p.writeArtifact(ArtifactScenarioMatrix, []byte(matrixCSV)); err != nil {
+		return nil, err
+	}
+
+	// Write feature_analysis.csv (if configured)
+	if err := p.writeFeatureAnalysis(report); err != nil {
+		return nil, err
 	}
 
 	// 10. If sufficiency fails -> INSUFFICIENT_DATA decision
-	if p.sufficiencyChecker != nil && !dataQuality.AllChecksPassed {
+	if (p.sufficiencyChecker != nil || degraded) && !dataQuality.AllChecksPassed {
 		report.ExecutiveSummary.Decision = string(decision.DecisionInsufficientData)
 
 		// Re-render REPORT_PHASE1.md with updated decision
 		reportMD = reporting.RenderMarkdown(report)
-		if err := os.WriteFile(reportPath, []byte(reportMD), 0644); err != nil {
-			return err
+		if err := p.writeArtifact(ArtifactReport, []byte(reportMD)); err != nil {
+			return nil, err
 		}
 
 		if err := p.writeInsufficientDataReport(dataQuality); err != nil {
-			return err
+			return nil, err
 		}
 
 		// Write additional artifacts even for INSUFFICIENT_DATA
-		if err := p.writeReportJSON(report); err != nil {
-			return err
-		}
-		if err := p.writeMetadata(report); err != nil {
-			return err
-		}
-		if err := p.writeMetricsQueries(); err != nil {
-			return err
-		}
-		if err := p.writeChecksums(); err != nil {
-			return err
+		if err := p.finishArtifacts(report); err != nil {
+			return nil, err
 		}
-
-		return nil
+		return report, nil
 	}
 
 	// 11. Otherwise proceed with GO/NO-GO evaluation
 	inputs, err := p.decisionBuild.BuildAll(report)
 	if err != nil {
-		// If no realistic scenarios or missing pessimistic scenario, treat as insufficient data
-		if err == decision.ErrNoRealisticScenario || err == decision.ErrMissingPessimisticScenario {
+		// If no realistic scenarios or a scenario of the matrix is missing, treat as insufficient data
+		if errors.Is(err, decision.ErrNoRealisticScenario) || errors.Is(err, decision.ErrMissingScenario) {
 			report.ExecutiveSummary.Decision = string(decision.DecisionInsufficientData)
 
 			// Re-render REPORT_PHASE1.md with updated decision
 			reportMD = reporting.RenderMarkdown(report)
-			if err := os.WriteFile(reportPath, []byte(reportMD), 0644); err != nil {
-				return err
+			if err := p.writeArtifact(ArtifactReport, []byte(reportMD)); err != nil {
+				return nil, err
 			}
 
 			// Write insufficient data decision report
 			dataQuality.IntegrityErrors = append(dataQuality.IntegrityErrors, err.Error())
 			dataQuality.AllChecksPassed = false
-			return p.writeInsufficientDataReport(dataQuality)
+			if err := p.writeInsufficientDataReport(dataQuality); err != nil {
+				return nil, err
+			}
+			if err := p.finishArtifacts(report); err != nil {
+				return nil, err
+			}
+			return report, nil
 		}
-		return err
+		return nil, err
 	}
 
 	// Evaluate each strategy and render combined decision report
 	decisionMD, overallDecision, err := p.renderDecisionReportWithDecision(inputs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Update executive summary with overall decision
@@ -279,81 +675,87 @@ func (p *Phase1Pipeline) Run(ctx context.Context) error {
 
 	// Re-render report with updated decision
 	reportMD = reporting.RenderMarkdown(report)
-	if err := os.WriteFile(reportPath, []byte(reportMD), 0644); err != nil {
-		return err
+	if err := p.writeArtifact(ArtifactReport, []byte(reportMD)); err != nil {
+		return nil, err
 	}
 
-	decisionPath := filepath.Join(p.outputDir, "DECISION_GATE_REPORT.md")
-	if err := os.WriteFile(decisionPath, []byte(decisionMD), 0644); err != nil {
-		return err
+	if err := p.writeArtifact(ArtifactDecision, []byte(decisionMD)); err != nil {
+		return nil, err
 	}
 
 	// Write additional artifacts per REPORTING_SPEC
+	if err := p.finishArtifacts(report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// finishArtifacts writes the artifacts every decision path ends with:
+// report.json, metrics_queries.sql and catalog.json as selected, then
+// metadata.json listing the run's artifacts and checksums.sha256. Artifacts
+// the selection excludes are removed, so that a directory reused across
+// profiles holds only the artifacts of its last run.
+func (p *Phase1Pipeline) finishArtifacts(report *reporting.Report) error {
 	if err := p.writeReportJSON(report); err != nil {
 		return err
 	}
-	if err := p.writeMetadata(report); err != nil {
+	if err := p.writeMetricsQueries(report); err != nil {
 		return err
 	}
-	if err := p.writeMetricsQueries(); err != nil {
+	if err := p.writeCatalog(); err != nil {
 		return err
 	}
-	// writeChecksums must be last as it computes hashes of all other files
-	if err := p.writeChecksums(); err != nil {
+	if err := p.writeBaselineDiff(report); err != nil {
 		return err
 	}
+	for _, name := range ArtifactNames() {
+		if !p.artifacts.Enabled(name) {
+			if err := p.out.RemoveAll(artifactPaths[name]); err != nil {
+				return fmt.Errorf("remove %s: %w", artifactPaths[name], err)
+			}
+		}
+	}
 
-	return nil
-}
-
-// populateExecutiveSummary fills in executive summary from report data.
-func (p *Phase1Pipeline) populateExecutiveSummary(report *reporting.Report) {
-	summary := &report.ExecutiveSummary
-
-	// Token counts
-	summary.NewTokenCount = report.DataSummary.NewTokenCandidates
-	summary.ActiveTokenCount = report.DataSummary.ActiveTokenCandidates
-
-	// Data period
-	if report.DataSummary.DateRangeStart > 0 {
-		summary.DataPeriodStart = time.UnixMilli(report.DataSummary.DateRangeStart).UTC()
+	files, err := collectArtifacts(p.out)
+	if err != nil {
+		return err
 	}
-	if report.DataSummary.DateRangeEnd > 0 {
-		summary.DataPeriodEnd = time.UnixMilli(report.DataSummary.DateRangeEnd).UTC()
+	if err := p.writeMetadata(report, files); err != nil {
+		return err
 	}
+	// writeChecksums must be last as it computes hashes of all other files
+	return p.writeChecksums()
+}
 
-	// Find best strategy under realistic scenario (highest median)
-	var bestMetric *reporting.StrategyMetricRow
-	var pessimisticMedian float64
-	for i := range report.StrategyMetrics {
-		m := &report.StrategyMetrics[i]
-		if m.ScenarioID == domain.ScenarioRealistic {
-			if bestMetric == nil || m.OutcomeMedian > bestMetric.OutcomeMedian {
-				bestMetric = m
-			}
+// writeBaselineDiff compares the report with the baseline of
+// WithBaselineComparison and writes BASELINE_DIFF.md; without a baseline it
+// removes the file of an earlier run.
+func (p *Phase1Pipeline) writeBaselineDiff(report *reporting.Report) error {
+	if p.baseline == nil {
+		if err := p.out.RemoveAll(BaselineDiffFile); err != nil {
+			return fmt.Errorf("remove %s: %w", BaselineDiffFile, err)
 		}
+		return nil
 	}
+	p.baselineDiff = reporting.CompareBaseline(p.baseline, report, p.baselineTolerances)
+	return p.out.WriteFile(BaselineDiffFile, []byte(reporting.RenderBaselineDiff(p.baselineDiff)))
+}
 
-	if bestMetric != nil {
-		summary.BestStrategy = bestMetric.StrategyID
-		summary.BestEntryType = bestMetric.EntryEventType
-		summary.WinRateRealistic = bestMetric.WinRate
-		summary.MedianRealistic = bestMetric.OutcomeMedian
-
-		// Find corresponding pessimistic median
-		for _, m := range report.StrategyMetrics {
-			if m.StrategyID == bestMetric.StrategyID &&
-				m.EntryEventType == bestMetric.EntryEventType &&
-				m.ScenarioID == domain.ScenarioPessimistic {
-				pessimisticMedian = m.OutcomeMedian
-				break
-			}
-		}
-		summary.MedianPessimistic = pessimisticMedian
+// writeArtifact writes the file of a fixed-name artifact if the artifact is selected.
+func (p *Phase1Pipeline) writeArtifact(name string, data []byte) error {
+	if !p.artifacts.Enabled(name) {
+		return nil
 	}
+	return p.out.WriteFile(artifactPaths[name], data)
+}
+
+// populateExecutiveSummary fills in executive summary from report data.
+func (p *Phase1Pipeline) populateExecutiveSummary(report *reporting.Report) {
+	report.ExecutiveSummary = reporting.SummarizeExecutive(report.DataSummary, report.StrategyMetrics)
 
 	// Decision will be set after evaluation
-	summary.Decision = "PENDING"
+	report.ExecutiveSummary.Decision = "PENDING"
 }
 
 // populateReproducibility fills in reproducibility metadata.
@@ -363,42 +765,62 @@ func (p *Phase1Pipeline) populateReproducibility(ctx context.Context, report *re
 		GeneratorVersion: GeneratorVersion,
 		DataVersion:      p.computeDataVersion(ctx, report, trades),
 		StrategyVersion:  StrategyVersion,
-		ReplayCommitHash: getGitCommitHash(),
+		ReplayCommitHash: GitCommitHash(),
 		ReplayCommand:    p.buildReplayCommand(),
+		ScenarioVersion:  p.scenarioVersion,
 	}
 }
 
 // buildReplayCommand returns the command to reproduce this report.
 func (p *Phase1Pipeline) buildReplayCommand() string {
+	var cmd string
 	switch p.dataSource {
-	case "fixtures":
-		return "go run cmd/report/main.go --use-fixtures"
 	case "db":
 		// Use actual DSN flags for reproducibility
-		return fmt.Sprintf("go run cmd/report/main.go --postgres-dsn %q --clickhouse-dsn %q",
+		cmd = fmt.Sprintf("go run cmd/report/main.go --postgres-dsn %q --clickhouse-dsn %q",
 			p.postgresDSN, p.clickhouseDSN)
 	default:
-		// Default to fixtures if not specified
-		return "go run cmd/report/main.go --use-fixtures"
+		// Fixtures, also when not specified
+		cmd = "go run cmd/report/main.go --use-fixtures"
 	}
+	if p.scenarioVersion > domain.BaseScenarioVersion {
+		cmd += fmt.Sprintf(" --scenario-version %d", p.scenarioVersion)
+	}
+	return cmd
 }
 
 // computeDataVersion computes SHA256 hash per REPORTING_SPEC section 3.2:
 // data_version = SHA256(SHA256(price_timeseries) || SHA256(liquidity_timeseries) || SHA256(candidates))
-// Falls back to trades-based hash if raw data stores not configured or on error.
+// Uses the trades-based hash if raw data stores are not configured. If a raw store fails,
+// or an input of the trades-based hash is degraded, the result carries
+// DegradedDataVersionPrefix and the data_version section is marked as fallback.
 func (p *Phase1Pipeline) computeDataVersion(ctx context.Context, report *reporting.Report, trades []*domain.TradeRecord) string {
 	// Use raw data stores if configured (per spec)
 	if p.candidateStoreForHash != nil && p.priceTimeseriesStoreHash != nil && p.liqTimeseriesStoreHash != nil {
 		hash, err := p.computeDataVersionFromRaw(ctx)
+		report.Provenance.Record(reporting.SectionDataVersion, "raw_timeseries", reporting.SourceFallback, true, err)
 		if err != nil {
-			// Fall back to trades-based hash on error
-			fmt.Fprintf(os.Stderr, "WARNING: raw data hash failed, using fallback: %v\n", err)
-			return p.computeDataVersionFromTrades(report, trades)
+			return DegradedDataVersionPrefix + p.computeDataVersionFromTrades(report, trades)
 		}
 		return hash
 	}
-	// Fallback: use trades-based hash
-	return p.computeDataVersionFromTrades(report, trades)
+
+	var err error
+	var inputs []string
+	for _, section := range []string{reporting.SectionStrategyMetrics, reporting.SectionTradeRecords} {
+		if s, ok := report.Provenance.Get(section); ok && s.Status != reporting.SourceOK {
+			inputs = append(inputs, section)
+		}
+	}
+	if len(inputs) > 0 {
+		err = fmt.Errorf("hash inputs degraded: %s", strings.Join(inputs, ", "))
+	}
+	report.Provenance.Record(reporting.SectionDataVersion, "trade_records", reporting.SourceFallback, true, err)
+	hash := p.computeDataVersionFromTrades(report, trades)
+	if err != nil {
+		return DegradedDataVersionPrefix + hash
+	}
+	return hash
 }
 
 // computeDataVersionFromRaw computes hash from raw data per REPORTING_SPEC.
@@ -595,8 +1017,8 @@ func (p *Phase1Pipeline) computeDataVersionFromTrades(report *reporting.Report,
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// getGitCommitHash returns current git commit hash or "unknown" if not in git repo.
-func getGitCommitHash() string {
+// GitCommitHash returns the current git commit hash or "unknown" if not in a git repo.
+func GitCommitHash() string {
 	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
 	var out bytes.Buffer
 	cmd.Stdout = &out
@@ -606,6 +1028,122 @@ func getGitCommitHash() string {
 	return strings.TrimSpace(out.String())
 }
 
+// writeCharts renders charts for the chartTopN candidates with the highest outcome under
+// the best strategy in the realistic scenario, one chart per candidate (its best trade).
+// Ties are broken by candidate_id so the selection is stable across runs. With
+// WithSampleSize the top candidates are picked from a sample of the candidates.
+func (p *Phase1Pipeline) writeCharts(ctx context.Context, report *reporting.Report, trades []*domain.TradeRecord) error {
+	if p.chartTopN <= 0 || p.chartPriceStore == nil || p.chartLiqStore == nil || p.chartCandidateStore == nil ||
+		!p.artifacts.Enabled(ArtifactCharts) {
+		return nil
+	}
+	best := report.ExecutiveSummary
+	if best.BestStrategy == "" {
+		return nil
+	}
+
+	bestTrade := make(map[string]*domain.TradeRecord)
+	for _, t := range trades {
+		if t.StrategyID != best.BestStrategy || t.ScenarioID != domain.ScenarioRealistic {
+			continue
+		}
+		if prev, ok := bestTrade[t.CandidateID]; !ok || t.Outcome > prev.Outcome ||
+			(t.Outcome == prev.Outcome && t.TradeID < prev.TradeID) {
+			bestTrade[t.CandidateID] = t
+		}
+	}
+
+	selected := make([]*domain.TradeRecord, 0, len(bestTrade))
+	for candidateID, t := range bestTrade {
+		candidate, err := p.chartCandidateStore.GetByID(ctx, candidateID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return p.chartsMissing(ctx, report, fmt.Errorf("get candidate %s: %w", candidateID, err))
+		}
+		if string(candidate.Source) == best.BestEntryType {
+			selected = append(selected, t)
+		}
+	}
+	population := len(selected)
+	selected = sampling.Select(selected, func(t *domain.TradeRecord) string {
+		return t.CandidateID
+	}, p.sampleSize, report.Reproducibility.DataVersion)
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Outcome != selected[j].Outcome {
+			return selected[i].Outcome > selected[j].Outcome
+		}
+		return selected[i].CandidateID < selected[j].CandidateID
+	})
+	if len(selected) > p.chartTopN {
+		selected = selected[:p.chartTopN]
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	// Load all series before touching the output so a failing store leaves no partial charts
+	inputs := make([]reporting.ChartInput, 0, len(selected))
+	for _, t := range selected {
+		price, err := p.chartPriceStore.GetByCandidateID(ctx, t.CandidateID)
+		if err != nil {
+			return p.chartsMissing(ctx, report, fmt.Errorf("get price timeseries for %s: %w", t.CandidateID, err))
+		}
+		liq, err := p.chartLiqStore.GetByCandidateID(ctx, t.CandidateID)
+		if err != nil {
+			return p.chartsMissing(ctx, report, fmt.Errorf("get liquidity timeseries for %s: %w", t.CandidateID, err))
+		}
+		inputs = append(inputs, reporting.ChartInput{
+			CandidateID: t.CandidateID,
+			Title:       fmt.Sprintf("%s | %s %s | outcome %.4f", t.CandidateID, t.StrategyID, best.BestEntryType, t.Outcome),
+			Price:       price,
+			Liquidity:   liq,
+			EntryTimeMs: t.EntryActualTime,
+			ExitTimeMs:  t.ExitActualTime,
+		})
+	}
+	report.Provenance.Record(reporting.SectionCharts, "timeseries", reporting.SourceMissing, false, nil)
+	if sampling.Sampled(p.sampleSize, population) {
+		report.ChartSample = &reporting.SampleInfo{Size: p.sampleSize, Population: population}
+	}
+
+	// Drop charts of earlier runs so the checksum manifest only covers this selection
+	if err := p.out.RemoveAll("charts"); err != nil {
+		return err
+	}
+
+	for i, t := range selected {
+		svg := reporting.RenderCandidateChartSVG(inputs[i])
+
+		rel := path.Join("charts", filepath.Base(t.CandidateID)+".svg")
+		if err := p.out.WriteFile(rel, []byte(svg)); err != nil {
+			return err
+		}
+		report.Charts = append(report.Charts, reporting.ChartReference{
+			CandidateID: t.CandidateID,
+			StrategyID:  t.StrategyID,
+			ScenarioID:  t.ScenarioID,
+			Outcome:     t.Outcome,
+			Path:        rel,
+		})
+	}
+
+	return nil
+}
+
+// chartsMissing marks the charts section as missing after a store failure.
+// Charts are not decision-critical, so only context cancellation aborts the run.
+func (p *Phase1Pipeline) chartsMissing(ctx context.Context, report *reporting.Report, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	report.Charts = nil
+	report.ChartSample = nil
+	report.Provenance.Record(reporting.SectionCharts, "timeseries", reporting.SourceMissing, false, err)
+	return nil
+}
+
 // convertToDataQuality converts SufficiencyResult to reporting.DataQualitySection.
 func convertToDataQuality(result *SufficiencyResult) reporting.DataQualitySection {
 	checks := make([]reporting.SufficiencyCheckRow, len(result.Checks))
@@ -618,9 +1156,35 @@ func convertToDataQuality(result *SufficiencyResult) reporting.DataQualitySectio
 		}
 	}
 	return reporting.DataQualitySection{
-		SufficiencyChecks: checks,
-		IntegrityErrors:   result.Errors,
-		AllChecksPassed:   result.AllPass,
+		SufficiencyChecks:     checks,
+		IntegrityErrors:       result.Errors,
+		AllChecksPassed:       result.AllPass,
+		ClosedOnly:            result.ClosedOnly,
+		ClosedCandidates:      result.ClosedCandidates,
+		OpenCandidates:        result.OpenCandidates,
+		FinalityChecked:       result.FinalityChecked,
+		UnfinalizedEvents:     result.UnfinalizedEvents,
+		ReplaySampled:         result.ReplaySampled,
+		ReplaySampleSize:      result.ReplaySampleSize,
+		ReplayPopulation:      result.ReplayPopulation,
+		DownsampleChecked:     result.DownsampleChecked,
+		DownsampledCandidates: result.DownsampledCandidates,
+		DroppedEvents:         result.DroppedEvents,
+		DeletedCandidates:     result.DeletedCandidates,
+		DeletedTrades:         result.DeletedTrades,
+	}
+}
+
+// setMetadataCoverage copies the metadata coverage of result into ds.
+func setMetadataCoverage(ds *reporting.DataSummary, result *SufficiencyResult) {
+	if !result.MetadataChecked {
+		return
+	}
+	ds.MetadataChecked = true
+	ds.MetadataCandidates = result.MetadataCandidates
+	ds.MetadataCoveredCandidates = result.MetadataCoveredCandidates
+	if result.MetadataCandidates > 0 {
+		ds.MetadataCoveragePct = float64(result.MetadataCoveredCandidates) / float64(result.MetadataCandidates) * 100
 	}
 }
 
@@ -642,6 +1206,21 @@ func (p *Phase1Pipeline) writeInsufficientDataReport(dataQuality reporting.DataQ
 		content += "| " + check.Name + " | " + check.Threshold + " | " + check.Actual + " | " + status + " |\n"
 	}
 	content += "\n"
+	if dataQuality.ClosedOnly {
+		content += reporting.ClosedOnlyNote(dataQuality)
+	}
+	if dataQuality.FinalityChecked {
+		content += reporting.FinalityNote(dataQuality)
+	}
+	if dataQuality.ReplaySampled {
+		content += reporting.ReplaySampleNote(dataQuality)
+	}
+	if dataQuality.DownsampleChecked {
+		content += reporting.DownsampleNote(dataQuality)
+	}
+	if dataQuality.DeletedCandidates > 0 {
+		content += reporting.DeletedCandidatesNote(dataQuality)
+	}
 
 	if len(dataQuality.IntegrityErrors) > 0 {
 		content += "### Integrity Errors\n\n"
@@ -656,8 +1235,7 @@ func (p *Phase1Pipeline) writeInsufficientDataReport(dataQuality reporting.DataQ
 	content += "2. Fix any data integrity issues\n"
 	content += "3. Re-run the pipeline\n"
 
-	decisionPath := filepath.Join(p.outputDir, "DECISION_GATE_REPORT.md")
-	return os.WriteFile(decisionPath, []byte(content), 0644)
+	return p.writeArtifact(ArtifactDecision, []byte(content))
 }
 
 // renderDecisionReport renders combined decision report for all strategies.
@@ -681,11 +1259,14 @@ func (p *Phase1Pipeline) renderDecisionReportWithDecision(inputs []*decision.Dec
 		return content, decision.DecisionNOGO, nil
 	}
 
-	// Find best strategy by RealisticMedian
+	// Find best strategy by RealisticMedian; low-confidence strategies are
+	// only picked when no other is left
 	bestIdx := 0
 	bestMedian := inputs[0].RealisticMedian
 	for i, input := range inputs {
-		if input.RealisticMedian > bestMedian {
+		best := inputs[bestIdx]
+		if (best.LowConfidence && !input.LowConfidence) ||
+			(best.LowConfidence == input.LowConfidence && input.RealisticMedian > bestMedian) {
 			bestMedian = input.RealisticMedian
 			bestIdx = i
 		}
@@ -733,111 +1314,105 @@ func (p *Phase1Pipeline) renderDecisionReportWithDecision(inputs []*decision.Dec
 	return content, overallDecision, nil
 }
 
-// writeReportJSON writes report.json with full machine-readable report per REPORTING_SPEC.
+// writeReportJSON writes report.json in the published schema (see reporting/schema),
+// canonically encoded like every JSON artifact (see reporting.MarshalCanonicalJSON).
 func (p *Phase1Pipeline) writeReportJSON(report *reporting.Report) error {
-	data, err := json.MarshalIndent(report, "", "  ")
+	data, err := reporting.MarshalCanonicalJSON(schema.FromReport(report))
 	if err != nil {
 		return fmt.Errorf("marshal report: %w", err)
 	}
-	path := filepath.Join(p.outputDir, "report.json")
-	return os.WriteFile(path, data, 0644)
+	return p.writeArtifact(ArtifactReportJSON, data)
 }
 
 // writeMetadata writes metadata.json with report metadata per REPORTING_SPEC.
-func (p *Phase1Pipeline) writeMetadata(report *reporting.Report) error {
+// files are the artifacts of the run; metadata.json itself is added to them.
+func (p *Phase1Pipeline) writeMetadata(report *reporting.Report, files []string) error {
+	artifacts := []string{"metadata.json"}
+	for _, f := range files {
+		if f != "metadata.json" {
+			artifacts = append(artifacts, f)
+		}
+	}
+	sort.Strings(artifacts)
+
 	metadata := map[string]interface{}{
 		"report_timestamp":   report.Reproducibility.ReportTimestamp.Format(time.RFC3339),
 		"generator_version":  report.Reproducibility.GeneratorVersion,
 		"data_version":       report.Reproducibility.DataVersion,
 		"strategy_version":   report.Reproducibility.StrategyVersion,
+		"scenario_version":   report.Reproducibility.ScenarioVersion,
 		"replay_commit_hash": report.Reproducibility.ReplayCommitHash,
 		"replay_command":     report.Reproducibility.ReplayCommand,
 		"strategy_count":     report.StrategyCount,
 		"scenario_count":     report.ScenarioCount,
 		"decision":           report.ExecutiveSummary.Decision,
+		"artifact_profile":   p.artifacts.Profile(),
+		"artifacts":          artifacts,
+	}
+	if p.mintFilterHash != "" {
+		metadata["mint_filter_hash"] = p.mintFilterHash
+	}
+	if p.implementability != nil {
+		metadata["implementability"] = p.implementability
+	}
+	if len(report.Provenance.Sections) > 0 {
+		metadata["degraded"] = len(report.Provenance.Degraded()) > 0
+		metadata["provenance"] = provenanceMetadata(report.Provenance)
 	}
 
-	data, err := json.MarshalIndent(metadata, "", "  ")
+	data, err := reporting.MarshalCanonicalJSON(metadata)
 	if err != nil {
 		return fmt.Errorf("marshal metadata: %w", err)
 	}
 
-	path := filepath.Join(p.outputDir, "metadata.json")
-	return os.WriteFile(path, data, 0644)
+	return p.out.WriteFile("metadata.json", data)
 }
 
-// writeChecksums writes checksums.sha256 for all output files per REPORTING_SPEC.
-func (p *Phase1Pipeline) writeChecksums() error {
-	files := []string{
-		"REPORT_PHASE1.md",
-		"DECISION_GATE_REPORT.md",
-		"report.json",
-		"strategy_aggregates.csv",
-		"trade_records.csv",
-		"scenario_outcomes.csv",
-		"metadata.json",
-		"metrics_queries.sql",
-	}
-
-	var checksums []string
-	for _, file := range files {
-		path := filepath.Join(p.outputDir, file)
-		data, err := os.ReadFile(path)
-		if err != nil {
-			// Skip files that don't exist (e.g., if INSUFFICIENT_DATA decision)
-			continue
+// writeCatalog writes catalog.json: the strategies and scenarios of this build,
+// tagged with StrategyVersion.
+func (p *Phase1Pipeline) writeCatalog() error {
+	data, err := reporting.MarshalCanonicalJSON(domain.NewCatalog(StrategyVersion))
+	if err != nil {
+		return fmt.Errorf("marshal catalog: %w", err)
+	}
+	return p.writeArtifact(ArtifactCatalog, data)
+}
+
+// sectionProvenanceJSON is one metadata.json provenance entry.
+type sectionProvenanceJSON struct {
+	Section  string `json:"section"`
+	Source   string `json:"source"`
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// provenanceMetadata converts the report provenance for metadata.json.
+func provenanceMetadata(prov reporting.Provenance) []sectionProvenanceJSON {
+	out := make([]sectionProvenanceJSON, len(prov.Sections))
+	for i, s := range prov.Sections {
+		out[i] = sectionProvenanceJSON{
+			Section:  s.Section,
+			Source:   s.Source,
+			Status:   string(s.Status),
+			Critical: s.Critical,
+			Detail:   s.Detail,
 		}
-		hash := sha256.Sum256(data)
-		checksums = append(checksums, fmt.Sprintf("%s  %s", hex.EncodeToString(hash[:]), file))
-	}
-
-	content := strings.Join(checksums, "\n") + "\n"
-	path := filepath.Join(p.outputDir, "checksums.sha256")
-	return os.WriteFile(path, []byte(content), 0644)
-}
-
-// writeMetricsQueries writes metrics_queries.sql with SQL templates per REPORTING_SPEC.
-func (p *Phase1Pipeline) writeMetricsQueries() error {
-	queries := `-- Metrics Queries for Phase 1 Report
--- Generated by Phase1Pipeline
-
--- Strategy aggregates by scenario
-SELECT
-    strategy_id,
-    scenario_id,
-    entry_event_type,
-    total_trades,
-    win_rate,
-    outcome_median,
-    outcome_p25,
-    outcome_p75
-FROM strategy_aggregates
-ORDER BY strategy_id, scenario_id, entry_event_type;
-
--- Top performing strategies (Realistic scenario)
-SELECT
-    strategy_id,
-    entry_event_type,
-    win_rate,
-    outcome_median,
-    max_drawdown
-FROM strategy_aggregates
-WHERE scenario_id = 'Realistic'
-ORDER BY outcome_median DESC
-LIMIT 10;
-
--- Scenario sensitivity comparison
-SELECT
-    a.strategy_id,
-    a.entry_event_type,
-    a.outcome_median AS realistic_median,
-    b.outcome_median AS pessimistic_median,
-    (a.outcome_median - b.outcome_median) / a.outcome_median * 100 AS degradation_pct
-FROM strategy_aggregates a
-JOIN strategy_aggregates b ON a.strategy_id = b.strategy_id
-    AND a.entry_event_type = b.entry_event_type
-WHERE a.scenario_id = 'Realistic' AND b.scenario_id = 'Pessimistic';
-`
-	path := filepath.Join(p.outputDir, "metrics_queries.sql")
-	return os.WriteFile(path, []byte(queries), 0644)
+	}
+	return out
+}
+
+// writeChecksums writes checksums.sha256 for all registered artifacts per REPORTING_SPEC.
+func (p *Phase1Pipeline) writeChecksums() error {
+	return writeChecksums(p.out)
+}
+
+// writeMetricsQueries writes metrics_queries.sql per REPORTING_SPEC: ClickHouse
+// queries against the configured tables, bounded to the report's data period.
+func (p *Phase1Pipeline) writeMetricsQueries(report *reporting.Report) error {
+	queries, err := reporting.RenderMetricsQueries(report, p.metricsTables)
+	if err != nil {
+		return err
+	}
+	return p.writeArtifact(ArtifactMetricsQueries, []byte(queries))
 }
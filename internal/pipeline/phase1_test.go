@@ -2,6 +2,9 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,9 +14,37 @@ import (
 	"solana-token-lab/internal/decision"
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/simulation"
+	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/storage/memory"
+	"solana-token-lab/internal/strategy"
 )
 
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares got with testdata/name, rewriting the file with -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatalf("create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("write golden %s: %v", name, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v", name, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch:\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
 func TestPhase1Pipeline_Run(t *testing.T) {
 	// Create temp directory for output
 	tempDir, err := os.MkdirTemp("", "phase1_test")
@@ -311,3 +342,607 @@ func TestPhase1Pipeline_WithAggregator(t *testing.T) {
 		t.Error("Report should have Integrity Errors section")
 	}
 }
+
+func TestPhase1Pipeline_ScenarioMatrixGolden(t *testing.T) {
+	tempDir := t.TempDir()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+
+	ctx := context.Background()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:    true,
+		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN"}: true,
+	}
+
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	p := NewPhase1Pipeline(
+		candidateStore,
+		tradeStore,
+		aggStore,
+		implementable,
+		tempDir,
+	).WithClock(func() time.Time { return fixedTime })
+
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("Pipeline run failed: %v", err)
+	}
+
+	matrixCSV, err := os.ReadFile(filepath.Join(tempDir, "scenario_matrix.csv"))
+	if err != nil {
+		t.Fatalf("read scenario_matrix.csv: %v", err)
+	}
+	assertGolden(t, "scenario_matrix.csv", string(matrixCSV))
+
+	// Markdown section runs from its heading to the next one
+	reportData, err := os.ReadFile(filepath.Join(tempDir, "REPORT_PHASE1.md"))
+	if err != nil {
+		t.Fatalf("read REPORT_PHASE1.md: %v", err)
+	}
+	report := string(reportData)
+	start := strings.Index(report, "## Scenario Matrix")
+	if start < 0 {
+		t.Fatal("Report should contain Scenario Matrix section")
+	}
+	section := report[start:]
+	if end := strings.Index(section[1:], "\n## "); end >= 0 {
+		section = section[:end+2]
+	}
+	assertGolden(t, "scenario_matrix.md", section)
+
+	checksums, err := os.ReadFile(filepath.Join(tempDir, "checksums.sha256"))
+	if err != nil {
+		t.Fatalf("read checksums.sha256: %v", err)
+	}
+	if !strings.Contains(string(checksums), "  scenario_matrix.csv\n") {
+		t.Error("checksums.sha256 should include scenario_matrix.csv")
+	}
+}
+
+func TestPhase1Pipeline_ScenarioMatrixThreshold(t *testing.T) {
+	tempDir := t.TempDir()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+
+	ctx := context.Background()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	p := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, nil, tempDir).
+		WithClock(func() time.Time { return fixedTime }).
+		WithDegradationThreshold(0)
+
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("Pipeline run failed: %v", err)
+	}
+
+	matrixCSV, err := os.ReadFile(filepath.Join(tempDir, "scenario_matrix.csv"))
+	if err != nil {
+		t.Fatalf("read scenario_matrix.csv: %v", err)
+	}
+	if !strings.Contains(string(matrixCSV), ",true\n") {
+		t.Errorf("expected degrading strategies flagged at 0%% threshold, got:\n%s", matrixCSV)
+	}
+}
+
+func TestPhase1Pipeline_Charts(t *testing.T) {
+	tempDir := t.TempDir()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+
+	ctx := context.Background()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	for _, id := range []string{"cand_001", "cand_002"} {
+		if err := priceStore.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+			{CandidateID: id, TimestampMs: 1000, Price: 1.0},
+			{CandidateID: id, TimestampMs: 2000, Price: 1.1},
+		}); err != nil {
+			t.Fatalf("insert price: %v", err)
+		}
+	}
+
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	run := func(dir string) {
+		p := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, nil, dir).
+			WithClock(func() time.Time { return fixedTime }).
+			WithCharts(candidateStore, priceStore, liqStore, 1)
+		if err := p.Run(ctx); err != nil {
+			t.Fatalf("Pipeline run failed: %v", err)
+		}
+	}
+	run(tempDir)
+
+	// Best strategy is TIME_EXIT | NEW_TOKEN; cand_001 has the highest realistic outcome
+	entries, err := os.ReadDir(filepath.Join(tempDir, "charts"))
+	if err != nil {
+		t.Fatalf("read charts dir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "cand_001.svg" {
+		t.Fatalf("expected only cand_001.svg, got %v", entries)
+	}
+
+	reportData, _ := os.ReadFile(filepath.Join(tempDir, "REPORT_PHASE1.md"))
+	if !strings.Contains(string(reportData), "![cand_001](charts/cand_001.svg)") {
+		t.Error("report should reference the chart")
+	}
+	checksums, _ := os.ReadFile(filepath.Join(tempDir, "checksums.sha256"))
+	if !strings.Contains(string(checksums), "  charts/cand_001.svg\n") {
+		t.Error("checksums should include the chart")
+	}
+
+	// Rendering again yields identical bytes
+	otherDir := t.TempDir()
+	run(otherDir)
+	first, _ := os.ReadFile(filepath.Join(tempDir, "charts", "cand_001.svg"))
+	second, _ := os.ReadFile(filepath.Join(otherDir, "charts", "cand_001.svg"))
+	if string(first) != string(second) {
+		t.Error("chart is not deterministic between runs")
+	}
+}
+
+func TestPhase1Pipeline_RunArtifactsMatchesDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+
+	ctx := context.Background()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	if err := priceStore.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+		{CandidateID: "cand_001", TimestampMs: 1000, Price: 1.0},
+		{CandidateID: "cand_001", TimestampMs: 2000, Price: 1.1},
+	}); err != nil {
+		t.Fatalf("insert price: %v", err)
+	}
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}:    true,
+		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	p := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, implementable, tempDir).
+		WithClock(func() time.Time { return fixedTime }).
+		WithCharts(candidateStore, priceStore, liqStore, 1)
+
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	artifacts, err := p.RunArtifacts(ctx)
+	if err != nil {
+		t.Fatalf("RunArtifacts failed: %v", err)
+	}
+
+	// Every file on disk is rendered in memory with identical bytes, and vice versa
+	onDisk := make(map[string][]byte)
+	err = filepath.WalkDir(tempDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(tempDir, path)
+		data, err := os.ReadFile(path)
+		onDisk[filepath.ToSlash(rel)] = data
+		return err
+	})
+	if err != nil {
+		t.Fatalf("walk output: %v", err)
+	}
+	if len(onDisk) != len(artifacts.Files) {
+		t.Errorf("expected %d artifacts, got %d", len(onDisk), len(artifacts.Files))
+	}
+	for name, want := range onDisk {
+		got, ok := artifacts.Files[name]
+		if !ok {
+			t.Errorf("%s missing from in-memory artifacts", name)
+			continue
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s differs between directory and in-memory output", name)
+		}
+	}
+	if _, ok := artifacts.Files["charts/cand_001.svg"]; !ok {
+		t.Error("expected chart in in-memory artifacts")
+	}
+
+	assertGolden(t, "scenario_matrix.csv", string(artifacts.Files["scenario_matrix.csv"]))
+	if !strings.Contains(string(artifacts.Files["REPORT_PHASE1.md"]), string(artifacts.Decision)) || artifacts.Decision == "" {
+		t.Errorf("decision %q not reflected in report", artifacts.Decision)
+	}
+
+	// RunArtifacts leaves the configured output untouched
+	if err := os.Remove(filepath.Join(tempDir, "report.json")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := p.RunArtifacts(ctx); err != nil {
+		t.Fatalf("RunArtifacts failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "report.json")); !os.IsNotExist(err) {
+		t.Errorf("RunArtifacts wrote to the output directory: %v", err)
+	}
+}
+
+var errStoreDown = errors.New("clickhouse: table unavailable")
+
+// failingAggregateStore fails every aggregate read.
+type failingAggregateStore struct {
+	storage.StrategyAggregateStore
+}
+
+func (failingAggregateStore) Find(context.Context, storage.AggregateFilter) ([]*domain.StrategyAggregate, error) {
+	return nil, errStoreDown
+}
+
+// failingPriceStore fails every price timeseries read.
+type failingPriceStore struct {
+	storage.PriceTimeseriesStore
+}
+
+func (failingPriceStore) GetByCandidateID(context.Context, string) ([]*domain.PriceTimeseriesPoint, error) {
+	return nil, errStoreDown
+}
+
+func (failingPriceStore) GetByTimeRange(context.Context, string, int64, int64) ([]*domain.PriceTimeseriesPoint, error) {
+	return nil, errStoreDown
+}
+
+func (failingPriceStore) GetGlobalTimeRange(context.Context) (int64, int64, error) {
+	return 0, 0, errStoreDown
+}
+
+// readMetadataProvenance returns the metadata.json provenance entries by section.
+func readMetadataProvenance(t *testing.T, dir string) (bool, map[string]sectionProvenanceJSON) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		t.Fatalf("read metadata.json: %v", err)
+	}
+	var meta struct {
+		Degraded   bool                    `json:"degraded"`
+		Provenance []sectionProvenanceJSON `json:"provenance"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("parse metadata.json: %v", err)
+	}
+	bySection := make(map[string]sectionProvenanceJSON)
+	for _, s := range meta.Provenance {
+		bySection[s.Section] = s
+	}
+	return meta.Degraded, bySection
+}
+
+func TestPhase1Pipeline_FailingAggregateStore(t *testing.T) {
+	tempDir := t.TempDir()
+	ctx := context.Background()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	report, err := NewPhase1Pipeline(candidateStore, tradeStore, failingAggregateStore{aggStore}, implementable, tempDir).
+		WithClock(func() time.Time { return fixedTime }).
+		RunReport(ctx)
+	if err != nil {
+		t.Fatalf("RunReport should tolerate a failing aggregate store: %v", err)
+	}
+
+	if report.ExecutiveSummary.Decision != string(decision.DecisionInsufficientData) {
+		t.Errorf("expected INSUFFICIENT_DATA, got %q", report.ExecutiveSummary.Decision)
+	}
+	metricsProv, _ := report.Provenance.Get(reporting.SectionStrategyMetrics)
+	if metricsProv.Status != reporting.SourceMissing || !metricsProv.Critical {
+		t.Errorf("strategy metrics should be missing and critical: %+v", metricsProv)
+	}
+	// Trades are intact, but the trades-based data version also hashes the aggregates
+	if !strings.HasPrefix(report.Reproducibility.DataVersion, DegradedDataVersionPrefix) {
+		t.Errorf("data version should be tagged degraded, got %q", report.Reproducibility.DataVersion)
+	}
+	if s, _ := report.Provenance.Get(reporting.SectionCostComposition); s.Status != reporting.SourceOK {
+		t.Errorf("cost composition reads trades and should be ok: %+v", s)
+	}
+
+	degraded, bySection := readMetadataProvenance(t, tempDir)
+	if !degraded || bySection[reporting.SectionStrategyMetrics].Status != "missing" ||
+		!strings.Contains(bySection[reporting.SectionStrategyMetrics].Detail, errStoreDown.Error()) {
+		t.Errorf("metadata.json should record the missing aggregates: degraded=%v %+v", degraded, bySection)
+	}
+	md, _ := os.ReadFile(filepath.Join(tempDir, "REPORT_PHASE1.md"))
+	if !strings.Contains(string(md), "## Data Provenance") ||
+		!strings.Contains(string(md), "| strategy_metrics | strategy_aggregates | missing | yes |") {
+		t.Error("REPORT_PHASE1.md should render the provenance block")
+	}
+	gate, _ := os.ReadFile(filepath.Join(tempDir, "DECISION_GATE_REPORT.md"))
+	if !strings.Contains(string(gate), "section strategy_metrics missing") {
+		t.Error("decision gate report should list the degraded section")
+	}
+}
+
+func TestPhase1Pipeline_FailingTimeseriesStore(t *testing.T) {
+	ctx := context.Background()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	liqStore := memory.NewLiquidityTimeseriesStore()
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	run := func(priceStore storage.PriceTimeseriesStore) (*reporting.Report, string) {
+		dir := t.TempDir()
+		report, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, implementable, dir).
+			WithClock(func() time.Time { return fixedTime }).
+			WithRawDataStores(candidateStore, priceStore, liqStore).
+			WithCharts(candidateStore, priceStore, liqStore, 1).
+			RunReport(ctx)
+		if err != nil {
+			t.Fatalf("RunReport: %v", err)
+		}
+		return report, dir
+	}
+
+	healthy, _ := run(memory.NewPriceTimeseriesStore())
+	if len(healthy.Provenance.Degraded()) != 0 {
+		t.Fatalf("healthy run should not be degraded: %v", healthy.Provenance.DegradedErrors())
+	}
+
+	report, dir := run(failingPriceStore{memory.NewPriceTimeseriesStore()})
+	if report.Reproducibility.DataVersion == healthy.Reproducibility.DataVersion ||
+		!strings.HasPrefix(report.Reproducibility.DataVersion, DegradedDataVersionPrefix) {
+		t.Errorf("partial failure must not yield an untagged data version, got %q", report.Reproducibility.DataVersion)
+	}
+	if s, _ := report.Provenance.Get(reporting.SectionDataVersion); s.Status != reporting.SourceFallback {
+		t.Errorf("data version should be a fallback: %+v", s)
+	}
+	if s, _ := report.Provenance.Get(reporting.SectionCharts); s.Status != reporting.SourceMissing || s.Critical {
+		t.Errorf("charts should be missing and non-critical: %+v", s)
+	}
+	if len(report.Charts) != 0 {
+		t.Errorf("expected no charts, got %v", report.Charts)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "charts")); !os.IsNotExist(err) {
+		t.Error("no chart files should be written after a timeseries failure")
+	}
+	if report.ExecutiveSummary.Decision != string(decision.DecisionInsufficientData) {
+		t.Errorf("expected INSUFFICIENT_DATA, got %q", report.ExecutiveSummary.Decision)
+	}
+
+	degraded, bySection := readMetadataProvenance(t, dir)
+	if !degraded || bySection[reporting.SectionDataVersion].Status != "fallback" {
+		t.Errorf("metadata.json should record the fallback data version: %+v", bySection)
+	}
+}
+
+func TestPhase1Pipeline_ScenarioVersion(t *testing.T) {
+	ctx := context.Background()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	// Recompute every fixture trade with tripled slippage as scenario version 2
+	originals, err := tradeStore.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	recomputed, err := simulation.RecomputeCosts(originals, domain.BaseScenarioVersion, 2, func(id string) (domain.ScenarioConfig, bool) {
+		for _, sc := range []domain.ScenarioConfig{domain.ScenarioConfigOptimistic, domain.ScenarioConfigRealistic,
+			domain.ScenarioConfigPessimistic, domain.ScenarioConfigDegraded} {
+			if sc.ScenarioID == id {
+				sc.SlippagePct *= 3
+				return sc, true
+			}
+		}
+		return domain.ScenarioConfig{}, false
+	})
+	if err != nil {
+		t.Fatalf("RecomputeCosts: %v", err)
+	}
+	// Fixtures have no pessimistic trades; cost the realistic ones pessimistically so
+	// version 2 has every scenario the decision needs
+	pessimistic := domain.ScenarioConfigPessimistic
+	pessimistic.SlippagePct *= 3
+	for _, o := range originals {
+		if o.ScenarioID == domain.ScenarioRealistic {
+			recomputed = append(recomputed, strategy.RecostTrade(o, pessimistic, domain.VersionedScenarioID(domain.ScenarioPessimistic, 2)))
+		}
+	}
+	if err := tradeStore.InsertBulk(ctx, recomputed); err != nil {
+		t.Fatalf("InsertBulk: %v", err)
+	}
+	agg := metrics.NewAggregator(tradeStore, aggStore, candidateStore)
+	for _, scenario := range []string{domain.ScenarioOptimistic, domain.ScenarioRealistic, domain.ScenarioPessimistic, domain.ScenarioDegraded} {
+		for _, entry := range []string{"NEW_TOKEN", "ACTIVE_TOKEN"} {
+			if _, err := agg.ComputeAndStore(ctx, domain.StrategyTypeTimeExit, domain.VersionedScenarioID(scenario, 2), entry); err != nil && !errors.Is(err, metrics.ErrNoTrades) {
+				t.Fatalf("aggregate %s/%s: %v", scenario, entry, err)
+			}
+		}
+	}
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	run := func(version int) (*reporting.Report, string) {
+		dir := t.TempDir()
+		report, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, implementable, dir).
+			WithClock(func() time.Time { return fixedTime }).
+			WithScenarioVersion(version).
+			RunReport(ctx)
+		if err != nil {
+			t.Fatalf("RunReport v%d: %v", version, err)
+		}
+		return report, dir
+	}
+
+	base, _ := run(domain.BaseScenarioVersion)
+	v2, dir := run(2)
+
+	if base.Reproducibility.ScenarioVersion != 1 || v2.Reproducibility.ScenarioVersion != 2 {
+		t.Errorf("reports should state their scenario version: %d, %d",
+			base.Reproducibility.ScenarioVersion, v2.Reproducibility.ScenarioVersion)
+	}
+	for _, m := range append(base.StrategyMetrics, v2.StrategyMetrics...) {
+		if m.ScenarioID != domain.ScenarioOptimistic && m.ScenarioID != domain.ScenarioRealistic &&
+			m.ScenarioID != domain.ScenarioPessimistic && m.ScenarioID != domain.ScenarioDegraded {
+			t.Errorf("report rows should use bare scenario ids, got %q", m.ScenarioID)
+		}
+	}
+	if len(base.StrategyMetrics) == 0 || len(v2.StrategyMetrics) == 0 {
+		t.Fatal("both versions should have strategy metrics")
+	}
+	median := func(r *reporting.Report) float64 {
+		for _, m := range r.StrategyMetrics {
+			if m.StrategyID == domain.StrategyTypeTimeExit && m.ScenarioID == domain.ScenarioRealistic && m.EntryEventType == "NEW_TOKEN" {
+				return m.OutcomeMedian
+			}
+		}
+		t.Fatal("no realistic TIME_EXIT NEW_TOKEN row")
+		return 0
+	}
+	if median(v2) >= median(base) {
+		t.Errorf("higher slippage should lower the realistic median: v1=%v v2=%v", median(base), median(v2))
+	}
+	if base.Reproducibility.DataVersion == v2.Reproducibility.DataVersion {
+		t.Error("versions should have different data versions")
+	}
+
+	md, _ := os.ReadFile(filepath.Join(dir, "REPORT_PHASE1.md"))
+	if !strings.Contains(string(md), "| Scenario Version | v2 |") ||
+		!strings.Contains(string(md), "--scenario-version 2") {
+		t.Error("REPORT_PHASE1.md should state scenario version 2 and replay it")
+	}
+	meta, _ := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	if !strings.Contains(string(meta), `"scenario_version": 2`) {
+		t.Errorf("metadata.json should record scenario_version 2: %s", meta)
+	}
+}
+
+func TestPhase1Pipeline_ImplementabilityInMetadata(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	matrix := decision.ResolveImplementability(decision.Deployment{RealtimePriceFeed: true})
+	artifacts, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, nil, "").
+		WithImplementability(matrix).
+		WithClock(func() time.Time { return time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC) }).
+		RunArtifacts(ctx)
+	if err != nil {
+		t.Fatalf("RunArtifacts failed: %v", err)
+	}
+
+	var meta struct {
+		Implementability decision.Implementability `json:"implementability"`
+	}
+	if err := json.Unmarshal(artifacts.Files["metadata.json"], &meta); err != nil {
+		t.Fatalf("parse metadata.json: %v", err)
+	}
+	if len(meta.Implementability) != len(matrix) {
+		t.Fatalf("expected the resolved matrix in metadata.json, got %+v", meta.Implementability)
+	}
+	for _, e := range meta.Implementability {
+		guard := e.StrategyID == domain.StrategyTypeLiquidityGuard
+		if e.Implementable == guard {
+			t.Errorf("%s/%s: implementable=%v missing=%v", e.StrategyID, e.EntryEventType, e.Implementable, e.Missing)
+		}
+	}
+}
+
+func TestPhase1Pipeline_CatalogArtifact(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	artifacts, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, nil, "").
+		WithClock(func() time.Time { return time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC) }).
+		RunArtifacts(ctx)
+	if err != nil {
+		t.Fatalf("RunArtifacts failed: %v", err)
+	}
+
+	var cat domain.Catalog
+	if err := json.Unmarshal(artifacts.Files["catalog.json"], &cat); err != nil {
+		t.Fatalf("parse catalog.json: %v", err)
+	}
+	if cat.StrategyVersion != StrategyVersion || len(cat.Strategies) != len(domain.StrategySpecs) || len(cat.Scenarios) != 4 {
+		t.Errorf("unexpected catalog.json: %+v", cat)
+	}
+	if !strings.Contains(string(artifacts.Files[ChecksumsFile]), "  catalog.json\n") {
+		t.Errorf("catalog.json missing from the checksum manifest:\n%s", artifacts.Files[ChecksumsFile])
+	}
+}
+
+func TestPhase1Pipeline_MetricsQueriesTables(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	artifacts, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, nil, "").
+		WithClock(func() time.Time { return time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC) }).
+		WithMetricsQueryTables(reporting.MetricsQueryTables{
+			StrategyAggregates: "analytics.strategy_aggregates",
+			TradeRecords:       "analytics.trade_records",
+		}).
+		RunArtifacts(ctx)
+	if err != nil {
+		t.Fatalf("RunArtifacts failed: %v", err)
+	}
+
+	sql := string(artifacts.Files["metrics_queries.sql"])
+	for _, want := range []string{
+		"FROM analytics.strategy_aggregates FINAL",
+		"FROM analytics.trade_records FINAL",
+		"-- Data version: ",
+		"-- Data period: ",
+		"entry_signal_time BETWEEN ",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("metrics_queries.sql missing %q:\n%s", want, sql)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"solana-token-lab/internal/reporting"
+)
+
+// Preview artifacts written under PreviewDir.
+const (
+	PreviewMarkdownFile = "REPORT_PREVIEW.md"
+	PreviewJSONFile     = "preview.json"
+)
+
+// WritePreview writes the preview as PreviewDir/REPORT_PREVIEW.md and
+// PreviewDir/preview.json. The checksum manifest is left untouched.
+func WritePreview(out OutputWriter, preview *reporting.Preview) error {
+	if err := out.WriteFile(path.Join(PreviewDir, PreviewMarkdownFile), []byte(reporting.RenderPreviewMarkdown(preview))); err != nil {
+		return fmt.Errorf("write %s: %w", PreviewMarkdownFile, err)
+	}
+	data, err := json.MarshalIndent(preview, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal preview: %w", err)
+	}
+	if err := out.WriteFile(path.Join(PreviewDir, PreviewJSONFile), append(data, '\n')); err != nil {
+		return fmt.Errorf("write %s: %w", PreviewJSONFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/orchestrator"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestWritePreview_ReflectsAggregatesOfSameRun(t *testing.T) {
+	ctx := context.Background()
+	candidates := memory.NewCandidateStore()
+	prices := memory.NewPriceTimeseriesStore()
+	liquidity := memory.NewLiquidityTimeseriesStore()
+	trades := memory.NewTradeRecordStore()
+	aggregates := memory.NewStrategyAggregateStore()
+
+	if err := candidates.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "prev-1", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx1", Slot: 100, DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+	if err := prices.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{
+		{CandidateID: "prev-1", TimestampMs: 1000000, Slot: 100, Price: 1.0},
+		{CandidateID: "prev-1", TimestampMs: 1300000, Slot: 200, Price: 1.5},
+	}); err != nil {
+		t.Fatalf("insert prices: %v", err)
+	}
+	if err := liquidity.InsertBulk(ctx, []*domain.LiquidityTimeseriesPoint{
+		{CandidateID: "prev-1", TimestampMs: 1000000, Slot: 100, Liquidity: 10000},
+	}); err != nil {
+		t.Fatalf("insert liquidity: %v", err)
+	}
+
+	holdDuration := int64(300000)
+	_, err := orchestrator.New(orchestrator.Options{
+		CandidateStore:           candidates,
+		PriceTimeseriesStore:     prices,
+		LiquidityTimeseriesStore: liquidity,
+		TradeRecordStore:         trades,
+		StrategyAggregateStore:   aggregates,
+		StrategyConfigs: []domain.StrategyConfig{
+			{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &holdDuration},
+		},
+		ScenarioConfigs:   []domain.ScenarioConfig{domain.ScenarioConfigRealistic},
+		SkipNormalization: true,
+	}).Run(ctx)
+	if err != nil {
+		t.Fatalf("orchestrator run: %v", err)
+	}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	preview, err := reporting.NewGenerator(candidates, trades, aggregates).
+		WithClock(func() time.Time { return now }).
+		GeneratePreview(ctx)
+	if err != nil {
+		t.Fatalf("GeneratePreview: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WritePreview(DirWriter(dir), preview); err != nil {
+		t.Fatalf("WritePreview: %v", err)
+	}
+	out := DirWriter(dir)
+
+	data, err := out.ReadFile(PreviewDir + "/" + PreviewJSONFile)
+	if err != nil {
+		t.Fatalf("read preview json: %v", err)
+	}
+	var decoded reporting.Preview
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if decoded.Authoritative || decoded.Notice != reporting.PreviewNotice {
+		t.Errorf("preview must be marked non-authoritative, got authoritative=%v notice=%q", decoded.Authoritative, decoded.Notice)
+	}
+	if len(decoded.StrategyMetrics) != 1 {
+		t.Fatalf("expected 1 strategy metrics row, got %+v", decoded.StrategyMetrics)
+	}
+	row := decoded.StrategyMetrics[0]
+	if row.StrategyID != domain.StrategyTypeTimeExit || row.ScenarioID != domain.ScenarioRealistic || row.TotalTrades != 1 {
+		t.Errorf("unexpected metrics row: %+v", row)
+	}
+	if es := decoded.ExecutiveSummary; es.BestStrategy != domain.StrategyTypeTimeExit || es.NewTokenCount != 1 || es.MedianRealistic <= 0 {
+		t.Errorf("unexpected executive summary: %+v", es)
+	}
+
+	md, err := out.ReadFile(PreviewDir + "/" + PreviewMarkdownFile)
+	if err != nil {
+		t.Fatalf("read preview markdown: %v", err)
+	}
+	if !strings.Contains(string(md), reporting.PreviewNotice) || strings.Contains(string(md), "Decision") {
+		t.Errorf("markdown must carry the notice and no decision:\n%s", md)
+	}
+}
+
+func TestVerifyChecksums_IgnoresPreview(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifacts(t, dir, map[string]string{"REPORT_PHASE1.md": "report"})
+	if err := WriteChecksums(dir); err != nil {
+		t.Fatalf("WriteChecksums failed: %v", err)
+	}
+	if err := WritePreview(DirWriter(dir), &reporting.Preview{Notice: reporting.PreviewNotice}); err != nil {
+		t.Fatalf("WritePreview: %v", err)
+	}
+	// Regenerating the manifest does not pick the preview up either
+	if err := WriteChecksums(dir); err != nil {
+		t.Fatalf("WriteChecksums failed: %v", err)
+	}
+
+	results, err := VerifyChecksums(dir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums failed: %v", err)
+	}
+	if !ChecksumsOK(results) || len(results) != 1 || results[0].File != "REPORT_PHASE1.md" {
+		t.Errorf("expected only REPORT_PHASE1.md verified, got %+v", results)
+	}
+	if _, err := DirWriter(dir).ReadFile(PreviewDir + "/" + PreviewJSONFile); err != nil {
+		t.Errorf("preview json missing: %v", err)
+	}
+}
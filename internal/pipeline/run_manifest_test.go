@@ -0,0 +1,188 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/decision"
+	"solana-token-lab/internal/orchestrator"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestPhase1Pipeline_RunManifest(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	aggStore := memory.NewStrategyAggregateStore()
+	if err := LoadFixtures(ctx, candidateStore, tradeStore, aggStore); err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	runs := memory.NewRunManifestStore()
+	config := map[string]string{
+		"postgres-dsn": "postgres://lab:hunter2@db:5432/lab?sslmode=disable",
+		"output":       tempDir,
+	}
+	backends := []storage.RunBackend{{Name: "memory"}}
+
+	implementable := map[decision.StrategyKey]bool{
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN"}: true,
+	}
+	fixedTime := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	report, err := NewPhase1Pipeline(candidateStore, tradeStore, aggStore, implementable, tempDir).
+		WithClock(func() time.Time { return fixedTime }).
+		WithRunManifest(runs, config, backends).
+		RunReport(ctx)
+	if err != nil {
+		t.Fatalf("RunReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, RunsDir, storage.RunKindReport, RunManifestFile))
+	if err != nil {
+		t.Fatalf("read run manifest: %v", err)
+	}
+	var m storage.RunManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("unmarshal run manifest: %v", err)
+	}
+
+	if !strings.HasPrefix(m.RunID, storage.RunKindReport+"-") || m.Kind != storage.RunKindReport {
+		t.Errorf("unexpected run ID or kind: %q %q", m.RunID, m.Kind)
+	}
+	if m.StartedAt == 0 || m.FinishedAt < m.StartedAt {
+		t.Errorf("unexpected run times: %d..%d", m.StartedAt, m.FinishedAt)
+	}
+	if m.GitCommit == "" {
+		t.Error("git commit should be set")
+	}
+	if m.Config["postgres-dsn"] != "postgres://lab:"+Redacted+"@db:5432/lab?sslmode=disable" || m.Config["output"] != tempDir {
+		t.Errorf("config should be the sanitized snapshot, got %v", m.Config)
+	}
+	if len(m.Backends) != 1 || m.Backends[0].Name != "memory" {
+		t.Errorf("unexpected backends: %+v", m.Backends)
+	}
+	if m.Candidates != report.DataSummary.TotalCandidates || m.Trades != report.DataSummary.TotalTrades ||
+		m.Aggregates != len(report.StrategyMetrics) || m.Trades == 0 {
+		t.Errorf("counts should match the report: %+v", m)
+	}
+	if m.DataVersion == "" || m.DataVersion != report.Reproducibility.DataVersion {
+		t.Errorf("expected data version %q, got %q", report.Reproducibility.DataVersion, m.DataVersion)
+	}
+	if m.Decision == "" || m.Decision != report.ExecutiveSummary.Decision {
+		t.Errorf("expected decision %q, got %q", report.ExecutiveSummary.Decision, m.Decision)
+	}
+	if m.Errors == nil || m.ErrorCount != len(report.DataQuality.IntegrityErrors) {
+		t.Errorf("error summary should match the integrity errors: %+v", m)
+	}
+
+	stored, err := runs.GetLatest(ctx, storage.RunKindReport)
+	if err != nil {
+		t.Fatalf("GetLatest: %v", err)
+	}
+	if stored.RunID != m.RunID || stored.Decision != m.Decision || stored.FinishedAt != m.FinishedAt {
+		t.Errorf("stored manifest differs from the written one: %+v", stored)
+	}
+
+	// The manifest is not an artifact: published checksums still verify
+	results, err := VerifyChecksums(tempDir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums: %v", err)
+	}
+	if !ChecksumsOK(results) {
+		t.Errorf("checksums should verify with the run manifest present: %+v", results)
+	}
+}
+
+func TestRecordOrchestratorRun(t *testing.T) {
+	m := NewRunManifest(storage.RunKindPipeline, time.UnixMilli(1000), nil, nil)
+	result := &orchestrator.RunResult{
+		CandidatesProcessed: 3,
+		TradesCreated:       4,
+		TradesUpdated:       2,
+		AggregatesCreated:   1,
+		Errors:              make([]string, storage.MaxRunManifestErrors+5),
+	}
+	RecordOrchestratorRun(m, result, errors.New("cancelled"))
+
+	if m.Candidates != 3 || m.Trades != 6 || m.Aggregates != 1 {
+		t.Errorf("unexpected counts: %+v", m)
+	}
+	if m.ErrorCount != storage.MaxRunManifestErrors+6 || len(m.Errors) != storage.MaxRunManifestErrors || m.Errors[0] != "cancelled" {
+		t.Errorf("expected %d errors capped at %d, run error first; got %d, %d",
+			storage.MaxRunManifestErrors+6, storage.MaxRunManifestErrors, m.ErrorCount, len(m.Errors))
+	}
+	if m.StartedAt != 1000 || m.Config == nil {
+		t.Errorf("unexpected manifest: %+v", m)
+	}
+}
+
+func TestSanitizeDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{"postgres url", "postgres://lab:hunter2@db:5432/lab?sslmode=disable", "postgres://lab:REDACTED@db:5432/lab?sslmode=disable"},
+		{"postgres url without password", "postgres://lab@db:5432/lab", "postgres://lab@db:5432/lab"},
+		{"clickhouse url", "clickhouse://default:s3cret@ch:9000/lab", "clickhouse://default:REDACTED@ch:9000/lab"},
+		{"clickhouse password param", "clickhouse://ch:9000/lab?username=default&password=s3cret", "clickhouse://ch:9000/lab?password=REDACTED&username=default"},
+		{"rpc api key", "https://mainnet.helius-rpc.com/?api-key=abcd-1234", "https://mainnet.helius-rpc.com/?api-key=REDACTED"},
+		{"key value", "host=db user=lab password=hunter2 dbname=lab", "host=db user=lab password=REDACTED dbname=lab"},
+		{"key value quoted", "host=db password='hunter 2' dbname=lab", "host=db password=REDACTED dbname=lab"},
+		{"plain value", "./output", "./output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeDSN(tt.dsn); got != tt.want {
+				t.Errorf("SanitizeDSN(%q) = %q, want %q", tt.dsn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeConfig(t *testing.T) {
+	config := map[string]string{
+		"rpc-endpoints":  "https://a.example/?api-key=k1,https://b.example/",
+		"helius-api-key": "k2",
+		"admin-token":    "t1",
+		"postgres-dsn":   "postgres://lab:hunter2@db/lab",
+		"interval":       "1h0m0s",
+		"empty-secret":   "",
+	}
+	got := SanitizeConfig(config)
+
+	want := map[string]string{
+		"rpc-endpoints":  "https://a.example/?api-key=REDACTED,https://b.example/",
+		"helius-api-key": Redacted,
+		"admin-token":    Redacted,
+		"postgres-dsn":   "postgres://lab:REDACTED@db/lab",
+		"interval":       "1h0m0s",
+		"empty-secret":   "",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: got %q, want %q", k, got[k], v)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d keys, got %v", len(want), got)
+	}
+	for _, v := range got {
+		for _, secret := range []string{"hunter2", "k1", "k2", "t1"} {
+			if strings.Contains(v, secret) {
+				t.Errorf("credential %q leaked in %q", secret, v)
+			}
+		}
+	}
+	if config["postgres-dsn"] != "postgres://lab:hunter2@db/lab" {
+		t.Error("SanitizeConfig must not modify its input")
+	}
+}
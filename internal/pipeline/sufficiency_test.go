@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +10,10 @@ import (
 	"time"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/pipeline/fixturegen"
+	"solana-token-lab/internal/replay"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/storage/memory"
 )
 
@@ -188,6 +193,353 @@ func TestSufficiencyChecker_InsufficientCandidates(t *testing.T) {
 	}
 }
 
+func TestSufficiencyChecker_ClosedOnly(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+
+	// 320 NEW_TOKEN candidates, of which only 250 have closed observation windows
+	now := time.Now().UTC()
+	for i := 0; i < 320; i++ {
+		id := fmt.Sprintf("cand_%03d", i)
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID:  id,
+			Source:       domain.SourceNewToken,
+			Mint:         "mint_" + id,
+			TxSignature:  "tx_" + id,
+			Slot:         int64(1000 + i),
+			DiscoveredAt: now.AddDate(0, 0, -i%10).UnixMilli(),
+		}); err != nil {
+			t.Fatalf("Failed to insert candidate: %v", err)
+		}
+		if i < 250 {
+			if err := candidateStore.Close(ctx, id, now.UnixMilli(), "fp"); err != nil {
+				t.Fatalf("Failed to close candidate: %v", err)
+			}
+		}
+	}
+
+	countCheck := func(result *SufficiencyResult) SufficiencyCheck {
+		for _, check := range result.Checks {
+			if check.Name == "Unique NEW_TOKEN candidates" {
+				return check
+			}
+		}
+		t.Fatal("missing 'Unique NEW_TOKEN candidates' check")
+		return SufficiencyCheck{}
+	}
+
+	all, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), nil, nil, nil).Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if c := countCheck(all); !c.Pass || c.Actual != "320" {
+		t.Errorf("expected all 320 candidates to count, got %+v", c)
+	}
+	if all.ClosedOnly {
+		t.Error("expected ClosedOnly unset by default")
+	}
+
+	closed, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), nil, nil, nil).
+		WithClosedOnly(true).Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if c := countCheck(closed); c.Pass || c.Actual != "250" {
+		t.Errorf("expected only 250 closed candidates to count, got %+v", c)
+	}
+	if !closed.ClosedOnly || closed.ClosedCandidates != 250 || closed.OpenCandidates != 70 {
+		t.Errorf("expected 250 closed and 70 open candidates, got closed=%d open=%d",
+			closed.ClosedCandidates, closed.OpenCandidates)
+	}
+}
+
+func TestSufficiencyChecker_UnfinalizedEvents(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	swapStore := memory.NewSwapStore()
+	finalityStore := memory.NewFinalityStore(swapStore, nil, nil)
+
+	if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "c1", Source: domain.SourceNewToken, Mint: "m1", TxSignature: "tx_c1", DiscoveredAt: 1000,
+	}); err != nil {
+		t.Fatalf("Failed to insert candidate: %v", err)
+	}
+	if err := swapStore.InsertBulk(ctx, []*domain.Swap{
+		{CandidateID: "c1", TxSignature: "s1", Timestamp: 1000, Side: domain.SwapSideBuy, Price: 1},
+		{CandidateID: "c1", TxSignature: "s2", Timestamp: 2000, Side: domain.SwapSideBuy, Price: 1},
+	}); err != nil {
+		t.Fatalf("Failed to insert swaps: %v", err)
+	}
+	if err := finalityStore.MarkFinalized(ctx, []string{"s1"}, 5000); err != nil {
+		t.Fatalf("MarkFinalized failed: %v", err)
+	}
+
+	without, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), swapStore, nil, nil).Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if without.FinalityChecked {
+		t.Error("expected no finality count without a finality store")
+	}
+
+	result, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), swapStore, nil, nil).
+		WithFinalityStore(finalityStore).
+		Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !result.FinalityChecked || result.UnfinalizedEvents != 1 {
+		t.Errorf("expected 1 unfinalized event, got checked=%v count=%d", result.FinalityChecked, result.UnfinalizedEvents)
+	}
+	if len(result.Checks) != 6 {
+		t.Errorf("expected the unfinalized count to be informational, got %d checks", len(result.Checks))
+	}
+}
+
+func TestSufficiencyChecker_CoverageFromSwapEvents(t *testing.T) {
+	ctx := context.Background()
+	swapEventStore := memory.NewSwapEventStore()
+
+	const dayMs = int64(24 * 60 * 60 * 1000)
+	_ = swapEventStore.Insert(ctx, &domain.SwapEvent{Mint: "m1", TxSignature: "tx1", Slot: 1, Timestamp: 0})
+	_ = swapEventStore.Insert(ctx, &domain.SwapEvent{Mint: "m2", TxSignature: "tx2", Slot: 2, Timestamp: 15 * dayMs})
+
+	// No timeseries, no candidates: coverage must come from swap events alone
+	checker := NewSufficiencyChecker(memory.NewCandidateStore(), memory.NewTradeRecordStore(),
+		memory.NewSwapStore(), memory.NewLiquidityEventStore(), nil).
+		WithSwapEventStore(swapEventStore)
+
+	check, err := checker.checkBacktestCoverage(ctx)
+	if err != nil {
+		t.Fatalf("checkBacktestCoverage failed: %v", err)
+	}
+	if !check.Pass {
+		t.Errorf("Expected coverage check to pass, got actual=%s", check.Actual)
+	}
+	if check.Actual != "15.0 days" {
+		t.Errorf("Expected 15.0 days, got %s", check.Actual)
+	}
+}
+
+func TestSufficiencyChecker_MinLiveShare(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	swapEventStore := memory.NewSwapEventStore()
+
+	origins := []string{domain.OriginLiveWS, domain.OriginBackfill, domain.OriginLiveWS}
+	for i, origin := range origins {
+		id := fmt.Sprintf("c%d", i+1)
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "m_" + id, TxSignature: "tx_" + id, DiscoveredAt: 1000,
+		}); err != nil {
+			t.Fatalf("Failed to insert candidate: %v", err)
+		}
+		if err := swapEventStore.Insert(ctx, &domain.SwapEvent{
+			Mint: "m_" + id, TxSignature: "tx_" + id, Slot: int64(i + 1), Timestamp: 1000, Origin: origin,
+		}); err != nil {
+			t.Fatalf("Failed to insert swap event: %v", err)
+		}
+	}
+
+	check := func(minPct float64) *SufficiencyResult {
+		t.Helper()
+		result, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), memory.NewSwapStore(), nil, nil).
+			WithSwapEventStore(swapEventStore).
+			WithMinLiveShare(minPct).
+			Check(ctx)
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		return result
+	}
+
+	pass := check(60)
+	if len(pass.Checks) != 7 {
+		t.Fatalf("expected 7 checks, got %d", len(pass.Checks))
+	}
+	live := pass.Checks[6]
+	if !live.Pass || live.Actual != "66.7% (2/3)" || live.Threshold != ">= 60%" {
+		t.Errorf("expected live check to pass at 60%%, got %+v", live)
+	}
+
+	fail := check(80)
+	if fail.Checks[6].Pass {
+		t.Errorf("expected live check to fail at 80%%, got %+v", fail.Checks[6])
+	}
+	if fail.AllPass {
+		t.Error("expected AllPass=false when the live share is below the minimum")
+	}
+
+	if disabled := check(0); len(disabled.Checks) != 6 {
+		t.Errorf("expected no live check when disabled, got %d checks", len(disabled.Checks))
+	}
+}
+
+func TestSufficiencyChecker_DownsampledCandidates(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	counts := memory.NewEventCountStore()
+
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("c%d", i)
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "m_" + id, TxSignature: "tx_" + id, DiscoveredAt: 1000,
+		}); err != nil {
+			t.Fatalf("Failed to insert candidate: %v", err)
+		}
+	}
+	// c1 and c2 were downsampled; c3 is complete; "gone" is not a checked candidate
+	for _, c := range []*domain.CandidateEventCounts{
+		{CandidateID: "c1", SwapsSeen: 100, SwapsStored: 40, SampleEvery: 10},
+		{CandidateID: "c2", LiquiditySeen: 12, LiquidityStored: 10, SampleEvery: 10},
+		{CandidateID: "c3", SwapsSeen: 30, SwapsStored: 30, SampleEvery: 10},
+		{CandidateID: "gone", SwapsSeen: 50, SwapsStored: 20, SampleEvery: 10},
+	} {
+		if err := counts.Upsert(ctx, c); err != nil {
+			t.Fatalf("Failed to upsert counts: %v", err)
+		}
+	}
+
+	result, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), memory.NewSwapStore(), nil, nil).
+		WithEventCountStore(counts).
+		Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !result.DownsampleChecked {
+		t.Fatal("expected DownsampleChecked")
+	}
+	if result.DownsampledCandidates != 2 || result.DroppedEvents != 62 {
+		t.Errorf("expected 2 downsampled candidates and 62 dropped events, got %d and %d",
+			result.DownsampledCandidates, result.DroppedEvents)
+	}
+	if len(result.Checks) != 6 {
+		t.Errorf("downsampling is informational, expected 6 checks, got %d", len(result.Checks))
+	}
+
+	dq := convertToDataQuality(result)
+	if note := reporting.DownsampleNote(dq); !strings.Contains(note, "2 candidates") || !strings.Contains(note, "62 events") {
+		t.Errorf("unexpected downsample note: %q", note)
+	}
+}
+
+func TestSufficiencyChecker_MetadataCoverage(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	metadataStore := memory.NewTokenMetadataStore()
+
+	// c1 and c2 share mint m1; only m3 and m4 have metadata: 2 of 5 candidates covered
+	mints := map[string]string{"c1": "m1", "c2": "m1", "c3": "m2", "c4": "m3", "c5": "m4"}
+	for id, mint := range mints {
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: mint, TxSignature: "tx_" + id, DiscoveredAt: 1000,
+		}); err != nil {
+			t.Fatalf("Failed to insert candidate: %v", err)
+		}
+	}
+	for _, id := range []string{"c4", "c5"} {
+		if err := metadataStore.Insert(ctx, &domain.TokenMetadata{CandidateID: id, Mint: mints[id], Decimals: 6, FetchedAt: 1000}); err != nil {
+			t.Fatalf("Failed to insert metadata: %v", err)
+		}
+	}
+
+	check := func(store storage.TokenMetadataStore, minPct float64) *SufficiencyResult {
+		t.Helper()
+		checker := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), memory.NewSwapStore(), nil, nil).
+			WithMinMetadataCoverage(minPct)
+		if store != nil {
+			checker.WithMetadataStore(store)
+		}
+		result, err := checker.Check(ctx)
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		return result
+	}
+
+	// Without a minimum the coverage is informational
+	info := check(metadataStore, 0)
+	if !info.MetadataChecked || info.MetadataCandidates != 5 || info.MetadataCoveredCandidates != 2 {
+		t.Errorf("expected 2/5 candidates covered, got %+v", info)
+	}
+	if len(info.Checks) != 6 {
+		t.Errorf("expected no metadata check when disabled, got %d checks", len(info.Checks))
+	}
+
+	pass := check(metadataStore, 40)
+	if len(pass.Checks) != 7 {
+		t.Fatalf("expected 7 checks, got %d", len(pass.Checks))
+	}
+	if c := pass.Checks[6]; !c.Pass || c.Actual != "40.0% (2/5)" || c.Threshold != ">= 40%" {
+		t.Errorf("expected metadata check to pass at 40%%, got %+v", c)
+	}
+
+	// A failing check lists the mints without metadata, most candidates first
+	fail := check(metadataStore, 50)
+	if fail.Checks[6].Pass || fail.AllPass {
+		t.Errorf("expected metadata check to fail at 50%%, got %+v", fail.Checks[6])
+	}
+	var metadataErrors []string
+	for _, e := range fail.Errors {
+		if strings.HasPrefix(e, "no token metadata") {
+			metadataErrors = append(metadataErrors, e)
+		}
+	}
+	want := []string{
+		"no token metadata for mint m1 (2 candidates)",
+		"no token metadata for mint m2 (1 candidates)",
+	}
+	if strings.Join(metadataErrors, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected errors %q, got %q", want, metadataErrors)
+	}
+
+	// The data summary carries the coverage percentage
+	var ds reporting.DataSummary
+	setMetadataCoverage(&ds, fail)
+	if !ds.MetadataChecked || ds.MetadataCoveragePct != 40 {
+		t.Errorf("expected 40%% coverage in the data summary, got %+v", ds)
+	}
+
+	if missing := check(nil, 50); missing.Checks[6].Pass || missing.MetadataChecked {
+		t.Errorf("expected the metadata check to fail without a store, got %+v", missing.Checks[6])
+	}
+}
+
+func TestSufficiencyChecker_MetadataCoverageErrorCap(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	for i := 0; i < maxMetadataCoverageErrors+3; i++ {
+		id := fmt.Sprintf("c%02d", i)
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "m_" + id, TxSignature: "tx_" + id, DiscoveredAt: 1000,
+		}); err != nil {
+			t.Fatalf("Failed to insert candidate: %v", err)
+		}
+	}
+
+	result, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), memory.NewSwapStore(), nil, nil).
+		WithMetadataStore(memory.NewTokenMetadataStore()).
+		WithMinMetadataCoverage(90).
+		Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	var listed int
+	var overflow string
+	for _, e := range result.Errors {
+		switch {
+		case strings.HasPrefix(e, "no token metadata"):
+			listed++
+		case strings.Contains(e, "more mints without token metadata"):
+			overflow = e
+		}
+	}
+	if listed != maxMetadataCoverageErrors || overflow != "... and 3 more mints without token metadata" {
+		t.Errorf("expected %d listed mints and 3 more, got %d and %q", maxMetadataCoverageErrors, listed, overflow)
+	}
+}
+
 func TestSufficiencyChecker_InsufficientUptime(t *testing.T) {
 	ctx := context.Background()
 	candidateStore := memory.NewCandidateStore()
@@ -236,6 +588,31 @@ func TestSufficiencyChecker_InsufficientUptime(t *testing.T) {
 	}
 }
 
+func TestSufficiencyChecker_UptimeAcrossMonthChange(t *testing.T) {
+	// One candidate per day from Jan 28 to Feb 3 (UTC), late in the day so
+	// that a local-time bucketing would shift them
+	daily := func(days ...int) []*domain.TokenCandidate {
+		var candidates []*domain.TokenCandidate
+		for _, d := range days {
+			ts := time.Date(2024, time.January, 28+d, 23, 30, 0, 0, time.UTC)
+			candidates = append(candidates, &domain.TokenCandidate{DiscoveredAt: ts.UnixMilli()})
+		}
+		return candidates
+	}
+
+	checker := &SufficiencyChecker{}
+	check := checker.checkDiscoveryUptime(daily(0, 1, 2, 3, 4, 5, 6))
+	if !check.Pass || check.Actual != ">= 7 days (7 total days)" {
+		t.Errorf("expected 7 continuous days across the month change, got %+v", check)
+	}
+
+	// Feb 1 missing
+	check = checker.checkDiscoveryUptime(daily(0, 1, 2, 3, 5, 6, 7))
+	if check.Pass || check.Actual != "3 continuous days (max)" {
+		t.Errorf("expected the gap on Feb 1 to break the range, got %+v", check)
+	}
+}
+
 func TestSufficiencyChecker_DuplicateCandidates(t *testing.T) {
 	ctx := context.Background()
 	candidateStore := memory.NewCandidateStore()
@@ -343,6 +720,90 @@ func TestPipeline_InsufficientDataDecision(t *testing.T) {
 	}
 }
 
+func TestPipeline_SufficiencyRunStored(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	runs := memory.NewSufficiencyRunStore()
+
+	now := time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		c := &domain.TokenCandidate{
+			CandidateID:  fmt.Sprintf("cand_%d", i),
+			Source:       domain.SourceNewToken,
+			Mint:         fmt.Sprintf("mint_%d", i),
+			TxSignature:  fmt.Sprintf("tx_%d", i),
+			Slot:         int64(1000 + i),
+			DiscoveredAt: now.AddDate(0, 0, -i).UnixMilli(),
+		}
+		if err := candidateStore.Insert(ctx, c); err != nil {
+			t.Fatalf("Insert candidate: %v", err)
+		}
+	}
+
+	integrityErrors := make([]string, storage.MaxSufficiencyRunErrors+20)
+	for i := range integrityErrors {
+		integrityErrors[i] = fmt.Sprintf("integrity error %d", i)
+	}
+
+	run := func(at time.Time, errs []string) *reporting.Report {
+		p := NewPhase1Pipeline(candidateStore, tradeStore, memory.NewStrategyAggregateStore(), nil, t.TempDir()).
+			WithSufficiencyChecker(candidateStore, tradeStore, memory.NewSwapStore(), memory.NewLiquidityEventStore(), nil).
+			WithSufficiencyRunStore(runs).
+			WithIntegrityErrors(errs).
+			WithClock(func() time.Time { return at })
+		report, err := p.RunReport(ctx)
+		if err != nil {
+			t.Fatalf("RunReport: %v", err)
+		}
+		return report
+	}
+
+	first := run(now, integrityErrors)
+	latest, err := runs.GetLatest(ctx)
+	if err != nil {
+		t.Fatalf("GetLatest: %v", err)
+	}
+	if latest.RunAt != now.UnixMilli() || latest.DataVersion != first.Reproducibility.DataVersion || latest.AllPass {
+		t.Errorf("unexpected stored run: %+v", latest)
+	}
+	// The report keeps every error: the checker's own and the pipeline's
+	if n := len(first.DataQuality.IntegrityErrors); n <= len(integrityErrors) || latest.ErrorCount != n ||
+		len(latest.Errors) != storage.MaxSufficiencyRunErrors {
+		t.Errorf("expected %d errors truncated to %d, got %d stored of %d",
+			n, storage.MaxSufficiencyRunErrors, len(latest.Errors), latest.ErrorCount)
+	}
+	assertChecksMatch(t, first, latest)
+
+	second := run(now.Add(time.Hour), nil)
+	latest, err = runs.GetLatest(ctx)
+	if err != nil {
+		t.Fatalf("GetLatest: %v", err)
+	}
+	if latest.RunAt != now.Add(time.Hour).UnixMilli() || latest.ErrorCount != len(second.DataQuality.IntegrityErrors) ||
+		latest.ErrorCount >= len(integrityErrors) {
+		t.Errorf("expected the second run to be latest, got %+v", latest)
+	}
+	assertChecksMatch(t, second, latest)
+}
+
+// assertChecksMatch checks that the report shows the stored run's checks.
+func assertChecksMatch(t *testing.T, report *reporting.Report, run *storage.SufficiencyRun) {
+	t.Helper()
+	if len(report.DataQuality.SufficiencyChecks) != len(run.Checks) || len(run.Checks) == 0 {
+		t.Fatalf("report has %d checks, stored run %d", len(report.DataQuality.SufficiencyChecks), len(run.Checks))
+	}
+	for i, c := range report.DataQuality.SufficiencyChecks {
+		stored := run.Checks[i]
+		if c.Name != stored.Name || c.Threshold != stored.Threshold || c.Actual != stored.Actual || c.Pass != stored.Pass {
+			t.Errorf("check %d: report %+v, stored %+v", i, c, stored)
+		}
+	}
+	if report.DataQuality.AllChecksPassed != run.AllPass {
+		t.Errorf("all pass: report %v, stored %v", report.DataQuality.AllChecksPassed, run.AllPass)
+	}
+}
+
 // readFile reads file contents from directory
 func readFile(dir, filename string) (string, error) {
 	data, err := os.ReadFile(filepath.Join(dir, filename))
@@ -356,3 +817,119 @@ func readFile(dir, filename string) (string, error) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestSufficiencyChecker_GeneratedFixturesPass(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	swapStore := memory.NewSwapStore()
+	liquidityStore := memory.NewLiquidityEventStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+
+	err := LoadGenerated(ctx, GeneratedStores{
+		Candidates:      candidateStore,
+		Swaps:           swapStore,
+		LiquidityEvents: liquidityStore,
+		Prices:          priceStore,
+		Liquidity:       liqStore,
+	}, fixturegen.DefaultParams())
+	if err != nil {
+		t.Fatalf("LoadGenerated failed: %v", err)
+	}
+
+	checker := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), swapStore, liquidityStore,
+		replay.NewRunner(swapStore, liquidityStore)).
+		WithTimeseriesStores(priceStore, liqStore)
+	result, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	for _, c := range result.Checks {
+		if !c.Pass {
+			t.Errorf("check %q failed: threshold %s, actual %s", c.Name, c.Threshold, c.Actual)
+		}
+	}
+	if !result.AllPass {
+		t.Errorf("expected generated dataset to pass, errors: %v", result.Errors)
+	}
+}
+
+// recordingSwapStore records the candidates whose swaps were loaded for replay.
+type recordingSwapStore struct {
+	*memory.SwapStore
+	replayed []string
+}
+
+func (s *recordingSwapStore) GetByCandidateID(ctx context.Context, candidateID string) ([]*domain.Swap, error) {
+	s.replayed = append(s.replayed, candidateID)
+	return s.SwapStore.GetByCandidateID(ctx, candidateID)
+}
+
+func TestSufficiencyChecker_ReplaySample(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("cand_%03d", i)
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "mint_" + id, TxSignature: "tx_" + id,
+			Slot: int64(1000 + i), DiscoveredAt: int64(1000000 + i),
+		}); err != nil {
+			t.Fatalf("Failed to insert candidate: %v", err)
+		}
+	}
+
+	replayed := func(size int, dataVersion string) ([]string, *SufficiencyResult) {
+		// Only the replay runner records; the other checks load swaps too
+		swaps := &recordingSwapStore{SwapStore: memory.NewSwapStore()}
+		liquidity := memory.NewLiquidityEventStore()
+		result, err := NewSufficiencyChecker(candidateStore, memory.NewTradeRecordStore(), swaps.SwapStore, liquidity,
+			replay.NewRunner(swaps, liquidity)).
+			WithReplaySample(size, dataVersion).Check(ctx)
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		return swaps.replayed, result
+	}
+
+	full, result := replayed(0, "v1")
+	if len(full) != 100 || result.ReplaySampled {
+		t.Fatalf("expected full population replayed, got %d (sampled=%v)", len(full), result.ReplaySampled)
+	}
+
+	first, result := replayed(10, "v1")
+	if len(first) != 10 {
+		t.Fatalf("expected 10 sampled replays, got %d", len(first))
+	}
+	if !result.ReplaySampled || result.ReplaySampleSize != 10 || result.ReplayPopulation != 100 {
+		t.Errorf("expected sample 10 of 100 recorded, got %+v", result)
+	}
+	for _, check := range result.Checks {
+		if check.Name == "Replayable tokens" && check.Actual != "100.0% (10/10 sampled, est. 100/100)" {
+			t.Errorf("replayability must be labeled as sampled, got %q", check.Actual)
+		}
+	}
+
+	again, _ := replayed(10, "v1")
+	if strings.Join(first, ",") != strings.Join(again, ",") {
+		t.Errorf("same data version sampled different candidates:\n%v\n%v", first, again)
+	}
+	other, _ := replayed(10, "v2")
+	if strings.Join(first, ",") == strings.Join(other, ",") {
+		t.Errorf("changing the data version did not change the sample: %v", first)
+	}
+}
+
+func TestRenderMarkdown_ReplaySampleNote(t *testing.T) {
+	report := &reporting.Report{DataQuality: reporting.DataQualitySection{
+		SufficiencyChecks: []reporting.SufficiencyCheckRow{{Name: "Replayable tokens", Threshold: "= 100%", Actual: "100.0% (10/10 sampled, est. 100/100)", Pass: true}},
+		AllChecksPassed:   true,
+		ReplaySampled:     true,
+		ReplaySampleSize:  10,
+		ReplayPopulation:  100,
+	}}
+	md := reporting.RenderMarkdown(report)
+	if !strings.Contains(md, "replayed 10 of 100 candidates") {
+		t.Errorf("report must state the check was sampled:\n%s", md)
+	}
+}
@@ -0,0 +1,185 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DefaultTradeIntegrityPageSize is the number of trades loaded per page.
+const DefaultTradeIntegrityPageSize = 1000
+
+// knownStrategyTypes are the base strategy types a trade's strategy_id may carry,
+// either bare (fixtures, aggregates) or as a prefix of a parameterized ID.
+var knownStrategyTypes = []string{
+	domain.StrategyTypeTimeExit,
+	domain.StrategyTypeTrailingStop,
+	domain.StrategyTypeLiquidityGuard,
+}
+
+// knownScenarioIDs are the execution scenarios defined in EXECUTION_SCENARIOS.md.
+var knownScenarioIDs = map[string]bool{
+	domain.ScenarioOptimistic:  true,
+	domain.ScenarioRealistic:   true,
+	domain.ScenarioPessimistic: true,
+	domain.ScenarioDegraded:    true,
+}
+
+// TradeIntegrityResult contains the outcome of a trade integrity pass.
+type TradeIntegrityResult struct {
+	TradesChecked int
+	Violations    []string // one entry per violated rule, prefixed with the trade ID
+
+	// Soft-deleted candidates referenced by trades, and those trades. They are
+	// excluded from the rules: an integrity note, not a violation.
+	DeletedCandidates int
+	DeletedTrades     int
+}
+
+// TradeIntegrityValidator cross-checks stored trade records against candidates
+// and the invariants of the simulation output.
+type TradeIntegrityValidator struct {
+	candidateStore storage.CandidateStore
+	tradeStore     storage.TradeRecordStore
+	pageSize       int
+}
+
+// NewTradeIntegrityValidator creates a validator reading trades in pages.
+func NewTradeIntegrityValidator(candidateStore storage.CandidateStore, tradeStore storage.TradeRecordStore) *TradeIntegrityValidator {
+	return &TradeIntegrityValidator{
+		candidateStore: candidateStore,
+		tradeStore:     tradeStore,
+		pageSize:       DefaultTradeIntegrityPageSize,
+	}
+}
+
+// WithPageSize overrides the number of trades loaded per page.
+func (v *TradeIntegrityValidator) WithPageSize(n int) *TradeIntegrityValidator {
+	if n > 0 {
+		v.pageSize = n
+	}
+	return v
+}
+
+// Validate walks all trades page by page. Trades of soft-deleted candidates are
+// counted and skipped. Each other trade must:
+//   - reference an existing candidate
+//   - carry a known strategy and scenario ID
+//   - be classified WIN iff Outcome > 0, LOSS otherwise
+//   - have entry signal <= entry actual <= exit actual and entry signal <= exit signal <= exit actual
+//   - have HoldDurationMs == ExitActualTime - EntryActualTime
+func (v *TradeIntegrityValidator) Validate(ctx context.Context) (*TradeIntegrityResult, error) {
+	result := &TradeIntegrityResult{}
+	candidateExists := make(map[string]bool)
+	candidateDeleted := make(map[string]bool)
+
+	after := ""
+	for {
+		page, err := v.tradeStore.GetPage(ctx, after, v.pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("get trades after %q: %w", after, err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, t := range page {
+			exists, cached := candidateExists[t.CandidateID]
+			if !cached {
+				c, err := v.candidateStore.GetByID(ctx, t.CandidateID)
+				switch {
+				case err == nil:
+					exists = true
+					if c.IsDeleted() {
+						candidateDeleted[t.CandidateID] = true
+						result.DeletedCandidates++
+					}
+				case errors.Is(err, storage.ErrNotFound):
+					exists = false
+				default:
+					return nil, fmt.Errorf("get candidate %s: %w", t.CandidateID, err)
+				}
+				candidateExists[t.CandidateID] = exists
+			}
+			if candidateDeleted[t.CandidateID] {
+				result.DeletedTrades++
+				continue
+			}
+			if !exists {
+				result.Violations = append(result.Violations,
+					fmt.Sprintf("trade %s: candidate %s does not exist", t.TradeID, t.CandidateID))
+			}
+
+			result.Violations = append(result.Violations, tradeViolations(t)...)
+		}
+
+		result.TradesChecked += len(page)
+		after = page[len(page)-1].TradeID
+	}
+
+	return result, nil
+}
+
+// tradeViolations checks the record-local rules of a single trade.
+func tradeViolations(t *domain.TradeRecord) []string {
+	var violations []string
+	add := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf("trade %s: ", t.TradeID)+fmt.Sprintf(format, args...))
+	}
+
+	if !isKnownStrategyID(t.StrategyID) {
+		add("unknown strategy_id %q", t.StrategyID)
+	}
+	if !knownScenarioIDs[t.ScenarioID] {
+		add("unknown scenario_id %q", t.ScenarioID)
+	}
+
+	switch t.OutcomeClass {
+	case domain.OutcomeClassWin:
+		if t.Outcome <= 0 {
+			add("outcome_class WIN with outcome %.6f", t.Outcome)
+		}
+	case domain.OutcomeClassLoss:
+		if t.Outcome > 0 {
+			add("outcome_class LOSS with outcome %.6f", t.Outcome)
+		}
+	default:
+		add("unknown outcome_class %q", t.OutcomeClass)
+	}
+
+	if t.EntryActualTime < t.EntrySignalTime {
+		add("entry_actual_time %d before entry_signal_time %d", t.EntryActualTime, t.EntrySignalTime)
+	}
+	if t.ExitSignalTime < t.EntrySignalTime {
+		add("exit_signal_time %d before entry_signal_time %d", t.ExitSignalTime, t.EntrySignalTime)
+	}
+	if t.ExitActualTime < t.ExitSignalTime {
+		add("exit_actual_time %d before exit_signal_time %d", t.ExitActualTime, t.ExitSignalTime)
+	}
+	if t.ExitActualTime < t.EntryActualTime {
+		add("exit_actual_time %d before entry_actual_time %d", t.ExitActualTime, t.EntryActualTime)
+	}
+	if want := t.ExitActualTime - t.EntryActualTime; t.HoldDurationMs != want {
+		add("hold_duration_ms %d, expected %d", t.HoldDurationMs, want)
+	}
+
+	return violations
+}
+
+// isKnownStrategyID reports whether id is a base strategy type or a parameterized ID of one,
+// or the namespaced ID of an imported external strategy.
+func isKnownStrategyID(id string) bool {
+	if domain.IsExternalStrategyID(id) {
+		return true
+	}
+	for _, base := range knownStrategyTypes {
+		if id == base || strings.HasPrefix(id, base+"_") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// validTrade returns a trade satisfying every integrity rule.
+func validTrade(tradeID, candidateID string) *domain.TradeRecord {
+	return &domain.TradeRecord{
+		TradeID:         tradeID,
+		CandidateID:     candidateID,
+		StrategyID:      "TIME_EXIT_NEW_TOKEN_300000ms",
+		ScenarioID:      domain.ScenarioRealistic,
+		EntrySignalTime: 1000,
+		EntryActualTime: 1500,
+		ExitSignalTime:  301000,
+		ExitActualTime:  301500,
+		Outcome:         0.05,
+		OutcomeClass:    domain.OutcomeClassWin,
+		HoldDurationMs:  300000,
+	}
+}
+
+func TestTradeIntegrityValidator_Rules(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "cand_ok", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx1",
+	}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+
+	crafted := map[string]func(*domain.TradeRecord){
+		"t_ok":            func(*domain.TradeRecord) {},
+		"t_base_strategy": func(tr *domain.TradeRecord) { tr.StrategyID = domain.StrategyTypeTrailingStop },
+		"t_external":      func(tr *domain.TradeRecord) { tr.StrategyID = domain.ExternalStrategyID("alice", "MOON_SHOT") },
+		"t_zero_loss":     func(tr *domain.TradeRecord) { tr.Outcome, tr.OutcomeClass = 0, domain.OutcomeClassLoss },
+		"t_missing_cand":  func(tr *domain.TradeRecord) { tr.CandidateID = "cand_deleted" },
+		"t_bad_strategy":  func(tr *domain.TradeRecord) { tr.StrategyID = "MOON_SHOT" },
+		"t_bad_scenario":  func(tr *domain.TradeRecord) { tr.ScenarioID = "fantasy" },
+		"t_win_negative":  func(tr *domain.TradeRecord) { tr.Outcome = -0.01 },
+		"t_loss_positive": func(tr *domain.TradeRecord) { tr.OutcomeClass = domain.OutcomeClassLoss },
+		"t_bad_class":     func(tr *domain.TradeRecord) { tr.OutcomeClass = "DRAW" },
+		"t_entry_order":   func(tr *domain.TradeRecord) { tr.EntryActualTime = 500; tr.HoldDurationMs = 301000 },
+		"t_exit_signal":   func(tr *domain.TradeRecord) { tr.ExitSignalTime = 900 },
+		"t_exit_actual":   func(tr *domain.TradeRecord) { tr.ExitActualTime = 300800; tr.HoldDurationMs = 299300 },
+		"t_exit_before": func(tr *domain.TradeRecord) {
+			tr.ExitSignalTime, tr.ExitActualTime, tr.HoldDurationMs = 1000, 1200, -300
+		},
+		"t_hold_mismatch": func(tr *domain.TradeRecord) { tr.HoldDurationMs = 0 },
+	}
+	for id, mutate := range crafted {
+		tr := validTrade(id, "cand_ok")
+		mutate(tr)
+		if err := tradeStore.Insert(ctx, tr); err != nil {
+			t.Fatalf("insert trade %s: %v", id, err)
+		}
+	}
+
+	// Small pages exercise pagination across the whole set
+	result, err := NewTradeIntegrityValidator(candidateStore, tradeStore).WithPageSize(3).Validate(ctx)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.TradesChecked != len(crafted) {
+		t.Errorf("expected %d trades checked, got %d", len(crafted), result.TradesChecked)
+	}
+
+	want := map[string]string{
+		"t_missing_cand":  "candidate cand_deleted does not exist",
+		"t_bad_strategy":  `unknown strategy_id "MOON_SHOT"`,
+		"t_bad_scenario":  `unknown scenario_id "fantasy"`,
+		"t_win_negative":  "outcome_class WIN with outcome -0.010000",
+		"t_loss_positive": "outcome_class LOSS with outcome 0.050000",
+		"t_bad_class":     `unknown outcome_class "DRAW"`,
+		"t_entry_order":   "entry_actual_time 500 before entry_signal_time 1000",
+		"t_exit_signal":   "exit_signal_time 900 before entry_signal_time 1000",
+		"t_exit_actual":   "exit_actual_time 300800 before exit_signal_time 301000",
+		"t_exit_before":   "exit_actual_time 1200 before entry_actual_time 1500",
+		"t_hold_mismatch": "hold_duration_ms 0, expected 300000",
+	}
+	joined := strings.Join(result.Violations, "\n")
+	for id, msg := range want {
+		if !strings.Contains(joined, "trade "+id+": "+msg) {
+			t.Errorf("missing violation for %s: %s\ngot:\n%s", id, msg, joined)
+		}
+	}
+	for _, id := range []string{"t_ok", "t_base_strategy", "t_external", "t_zero_loss"} {
+		if strings.Contains(joined, "trade "+id+":") {
+			t.Errorf("valid trade %s reported:\n%s", id, joined)
+		}
+	}
+}
+
+func TestSufficiencyChecker_TradeIntegrityErrors(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	if err := tradeStore.Insert(ctx, validTrade("t_orphan", "cand_gone")); err != nil {
+		t.Fatalf("insert trade: %v", err)
+	}
+
+	checker := NewSufficiencyChecker(candidateStore, tradeStore, memory.NewSwapStore(), memory.NewLiquidityEventStore(), nil)
+	result, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.AllPass {
+		t.Error("expected AllPass=false with integrity violations")
+	}
+	found := false
+	for _, e := range result.Errors {
+		if e == "trade t_orphan: candidate cand_gone does not exist" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected orphan trade in integrity errors, got %v", result.Errors)
+	}
+}
+
+func TestSufficiencyChecker_ExcludesDeletedCandidates(t *testing.T) {
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	for _, c := range []*domain.TokenCandidate{
+		{CandidateID: "cand_ok", Source: domain.SourceNewToken, Mint: "mint1", TxSignature: "tx1"},
+		{CandidateID: "cand_junk", Source: domain.SourceNewToken, Mint: "mint2", TxSignature: "tx2"},
+	} {
+		if err := candidateStore.Insert(ctx, c); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+	}
+	if err := candidateStore.SoftDelete(ctx, &storage.CandidateDeletion{
+		CandidateID: "cand_junk", DeletedAt: 5000, Reason: "test token", Requester: "ops",
+	}); err != nil {
+		t.Fatalf("SoftDelete: %v", err)
+	}
+
+	// The junk trade would break a rule: it must be counted, not reported
+	junk := validTrade("t_junk", "cand_junk")
+	junk.ScenarioID = "fantasy"
+	for _, tr := range []*domain.TradeRecord{validTrade("t_ok", "cand_ok"), junk, validTrade("t_junk2", "cand_junk")} {
+		if err := tradeStore.Insert(ctx, tr); err != nil {
+			t.Fatalf("insert trade: %v", err)
+		}
+	}
+
+	checker := NewSufficiencyChecker(candidateStore, tradeStore, memory.NewSwapStore(), memory.NewLiquidityEventStore(), nil)
+	result, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if joined := strings.Join(result.Errors, "\n"); strings.Contains(joined, "cand_junk") || strings.Contains(joined, "t_junk") {
+		t.Errorf("deleted candidate should not be reported, got:\n%s", joined)
+	}
+	if result.DeletedCandidates != 1 || result.DeletedTrades != 2 {
+		t.Errorf("expected 1 deleted candidate with 2 trades, got %d/%d", result.DeletedCandidates, result.DeletedTrades)
+	}
+	if actual := result.Checks[0].Actual; actual != "1" {
+		t.Errorf("expected the deleted candidate excluded from %s, got %s", result.Checks[0].Name, actual)
+	}
+}
@@ -116,5 +116,6 @@ func domainToDiscoveryEvent(e *domain.SwapEvent) *discovery.SwapEvent {
 		EventIndex:  e.EventIndex,
 		Slot:        e.Slot,
 		Timestamp:   e.Timestamp,
+		DEX:         e.DEX,
 	}
 }
@@ -106,8 +106,8 @@ func TestRunner_MergesSwapsAndLiquidity(t *testing.T) {
 
 	// Insert liquidity events
 	liquidity := []*domain.LiquidityEvent{
-		{CandidateID: "c1", Slot: 200, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000},
-		{CandidateID: "c1", Slot: 400, TxSignature: "tx4", EventIndex: 0, Timestamp: 4000},
+		{CandidateID: "c1", Slot: 200, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", Slot: 400, TxSignature: "tx4", EventIndex: 0, Timestamp: 4000, EventType: domain.LiquidityEventAdd},
 	}
 	if err := liquidityStore.InsertBulk(ctx, liquidity); err != nil {
 		t.Fatalf("InsertBulk liquidity failed: %v", err)
@@ -162,7 +162,7 @@ func TestRunner_Deterministic(t *testing.T) {
 		_ = swapStore.InsertBulk(ctx, swaps)
 
 		liquidity := []*domain.LiquidityEvent{
-			{CandidateID: "c1", Slot: 200, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000},
+			{CandidateID: "c1", Slot: 200, TxSignature: "tx2", EventIndex: 0, Timestamp: 2000, EventType: domain.LiquidityEventAdd},
 		}
 		_ = liquidityStore.InsertBulk(ctx, liquidity)
 
@@ -207,7 +207,7 @@ func TestMergeEvents(t *testing.T) {
 	}
 
 	liquidity := []*domain.LiquidityEvent{
-		{Slot: 200, TxSignature: "tx2", EventIndex: 0},
+		{Slot: 200, TxSignature: "tx2", EventIndex: 0, EventType: domain.LiquidityEventAdd},
 	}
 
 	events := MergeEvents(swaps, liquidity)
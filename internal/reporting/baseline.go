@@ -0,0 +1,386 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BaselineVersion is the format version of a baseline file.
+const BaselineVersion = 1
+
+// Baseline is a stored set of strategy aggregates that later reports are
+// compared against (see CompareBaseline), with the data version they were
+// computed from.
+type Baseline struct {
+	Version     int                 `json:"version"`
+	DataVersion string              `json:"data_version"`
+	Aggregates  []BaselineAggregate `json:"aggregates"`
+}
+
+// BaselineAggregate is one strategy aggregate of a baseline: its key and the
+// compared metrics by name (see BaselineMetricNames).
+type BaselineAggregate struct {
+	StrategyID     string             `json:"strategy_id"`
+	ScenarioID     string             `json:"scenario_id"`
+	EntryEventType string             `json:"entry_event_type"`
+	Metrics        map[string]float64 `json:"metrics"`
+}
+
+func (a BaselineAggregate) key() string {
+	return a.StrategyID + "/" + a.ScenarioID + "/" + a.EntryEventType
+}
+
+// baselineMetric is a compared metric. direction is +1 if higher is better,
+// -1 if lower is better, 0 if a change is neither.
+type baselineMetric struct {
+	name      string
+	direction int
+	value     func(StrategyMetricRow) float64
+}
+
+// baselineMetrics are the compared metrics, named after the strategy_aggregates.csv columns.
+var baselineMetrics = []baselineMetric{
+	{"total_trades", 0, func(m StrategyMetricRow) float64 { return float64(m.TotalTrades) }},
+	{"total_tokens", 0, func(m StrategyMetricRow) float64 { return float64(m.TotalTokens) }},
+	{"win_rate", 1, func(m StrategyMetricRow) float64 { return m.WinRate }},
+	{"token_win_rate", 1, func(m StrategyMetricRow) float64 { return m.TokenWinRate }},
+	{"outcome_mean", 1, func(m StrategyMetricRow) float64 { return m.OutcomeMean }},
+	{"outcome_median", 1, func(m StrategyMetricRow) float64 { return m.OutcomeMedian }},
+	{"outcome_p10", 1, func(m StrategyMetricRow) float64 { return m.OutcomeP10 }},
+	{"outcome_p25", 1, func(m StrategyMetricRow) float64 { return m.OutcomeP25 }},
+	{"outcome_p75", 1, func(m StrategyMetricRow) float64 { return m.OutcomeP75 }},
+	{"outcome_p90", 1, func(m StrategyMetricRow) float64 { return m.OutcomeP90 }},
+	{"outcome_min", 1, func(m StrategyMetricRow) float64 { return m.OutcomeMin }},
+	{"outcome_max", 1, func(m StrategyMetricRow) float64 { return m.OutcomeMax }},
+	{"outcome_stddev", 0, func(m StrategyMetricRow) float64 { return m.OutcomeStddev }},
+	{"max_drawdown", -1, func(m StrategyMetricRow) float64 { return m.MaxDrawdown }},
+	{"max_consecutive_losses", -1, func(m StrategyMetricRow) float64 { return float64(m.MaxConsecutiveLosses) }},
+	{"data_end_share", -1, func(m StrategyMetricRow) float64 { return m.DataEndShare }},
+}
+
+// BaselineMetricNames returns the names of the compared metrics, in report order.
+func BaselineMetricNames() []string {
+	names := make([]string, len(baselineMetrics))
+	for i, m := range baselineMetrics {
+		names[i] = m.name
+	}
+	return names
+}
+
+// NewBaseline returns the baseline of a report: its strategy aggregates and data version.
+func NewBaseline(report *Report) *Baseline {
+	b := &Baseline{
+		Version:     BaselineVersion,
+		DataVersion: report.Reproducibility.DataVersion,
+		Aggregates:  make([]BaselineAggregate, 0, len(report.StrategyMetrics)),
+	}
+	for _, row := range report.StrategyMetrics {
+		agg := BaselineAggregate{
+			StrategyID:     row.StrategyID,
+			ScenarioID:     row.ScenarioID,
+			EntryEventType: row.EntryEventType,
+			Metrics:        make(map[string]float64, len(baselineMetrics)),
+		}
+		for _, m := range baselineMetrics {
+			agg.Metrics[m.name] = m.value(row)
+		}
+		b.Aggregates = append(b.Aggregates, agg)
+	}
+	sort.Slice(b.Aggregates, func(i, j int) bool { return b.Aggregates[i].key() < b.Aggregates[j].key() })
+	return b
+}
+
+// MarshalBaseline encodes a baseline canonically (see MarshalCanonicalJSON).
+func MarshalBaseline(b *Baseline) ([]byte, error) {
+	return MarshalCanonicalJSON(b)
+}
+
+// ParseBaseline decodes a baseline file.
+func ParseBaseline(data []byte) (*Baseline, error) {
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse baseline: %w", err)
+	}
+	if b.Version != BaselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d (want %d)", b.Version, BaselineVersion)
+	}
+	return &b, nil
+}
+
+// Tolerance bounds the change of a metric that is not reported: a change is
+// within tolerance if it is at most Abs, or at most Rel (a fraction) of the
+// baseline value, whichever is larger.
+type Tolerance struct {
+	Abs float64
+	Rel float64
+}
+
+// Within reports whether the change from base to cur is within the tolerance.
+func (t Tolerance) Within(base, cur float64) bool {
+	return math.Abs(cur-base) <= max(t.Abs, t.Rel*math.Abs(base))
+}
+
+// String formats the tolerance as in a --baseline-tolerance value.
+func (t Tolerance) String() string {
+	return fmt.Sprintf("%g:%g%%", t.Abs, 100*t.Rel)
+}
+
+// Default tolerances of a baseline comparison.
+const (
+	DefaultBaselineAbsTolerance = 0.001
+	DefaultBaselineRelTolerance = 0.01
+)
+
+// BaselineTolerances are the tolerances of a baseline comparison: Default,
+// overridden per metric by Metrics.
+type BaselineTolerances struct {
+	Default Tolerance
+	Metrics map[string]Tolerance
+}
+
+// DefaultBaselineTolerances returns the default tolerances, without overrides.
+func DefaultBaselineTolerances() BaselineTolerances {
+	return BaselineTolerances{Default: Tolerance{Abs: DefaultBaselineAbsTolerance, Rel: DefaultBaselineRelTolerance}}
+}
+
+// For returns the tolerance of a metric.
+func (t BaselineTolerances) For(metric string) Tolerance {
+	if tol, ok := t.Metrics[metric]; ok {
+		return tol
+	}
+	return t.Default
+}
+
+// SetMetric parses a per-metric override "<metric>=<abs>:<rel>%", e.g.
+// "max_consecutive_losses=1:0%", and adds it to the tolerances.
+func (t *BaselineTolerances) SetMetric(spec string) error {
+	name, value, ok := strings.Cut(spec, "=")
+	abs, rel, ok2 := strings.Cut(value, ":")
+	if !ok || !ok2 {
+		return fmt.Errorf("invalid tolerance %q: want <metric>=<abs>:<rel>%%", spec)
+	}
+	name = strings.TrimSpace(name)
+	known := false
+	for _, m := range baselineMetrics {
+		known = known || m.name == name
+	}
+	if !known {
+		return fmt.Errorf("unknown metric %q: want one of %s", name, strings.Join(BaselineMetricNames(), ", "))
+	}
+	a, err := strconv.ParseFloat(strings.TrimSpace(abs), 64)
+	if err != nil || a < 0 {
+		return fmt.Errorf("invalid absolute tolerance in %q", spec)
+	}
+	r, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(rel), "%"), 64)
+	if err != nil || r < 0 {
+		return fmt.Errorf("invalid relative tolerance in %q", spec)
+	}
+	if t.Metrics == nil {
+		t.Metrics = make(map[string]Tolerance)
+	}
+	t.Metrics[name] = Tolerance{Abs: a, Rel: r / 100}
+	return nil
+}
+
+// BaselineChangeKind classifies a metric change beyond its tolerance.
+type BaselineChangeKind string
+
+const (
+	BaselineRegression  BaselineChangeKind = "REGRESSION"
+	BaselineImprovement BaselineChangeKind = "IMPROVEMENT"
+	BaselineChanged     BaselineChangeKind = "CHANGED" // metric without a better direction
+)
+
+// BaselineChange is a metric of an aggregate that changed beyond its tolerance.
+type BaselineChange struct {
+	StrategyID     string
+	ScenarioID     string
+	EntryEventType string
+	Metric         string
+	Baseline       float64
+	Current        float64
+	Tolerance      Tolerance
+	Kind           BaselineChangeKind
+}
+
+// BaselineDiff is the comparison of a report with a baseline.
+type BaselineDiff struct {
+	BaselineDataVersion string
+	CurrentDataVersion  string
+	Tolerances          BaselineTolerances
+	Compared            int              // aggregates in both
+	Changes             []BaselineChange // sorted by aggregate, then metric
+	Missing             []string         // aggregates of the baseline absent from the report, "strategy/scenario/entry"
+	Added               []string         // aggregates of the report absent from the baseline
+}
+
+// Informational reports whether the data versions differ: the aggregates are
+// computed from different data, so changes are expected and not regressions.
+func (d *BaselineDiff) Informational() bool {
+	return d.BaselineDataVersion != d.CurrentDataVersion
+}
+
+// Regressions returns the changes for the worse.
+func (d *BaselineDiff) Regressions() []BaselineChange {
+	return d.changes(BaselineRegression)
+}
+
+// Improvements returns the changes for the better.
+func (d *BaselineDiff) Improvements() []BaselineChange {
+	return d.changes(BaselineImprovement)
+}
+
+func (d *BaselineDiff) changes(kind BaselineChangeKind) []BaselineChange {
+	var out []BaselineChange
+	for _, c := range d.Changes {
+		if c.Kind == kind {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Failed reports whether the report regressed from the baseline: over the same
+// data, a metric changed for the worse beyond its tolerance or an aggregate
+// of the baseline is missing.
+func (d *BaselineDiff) Failed() bool {
+	return !d.Informational() && (len(d.Regressions()) > 0 || len(d.Missing) > 0)
+}
+
+// CompareBaseline compares the strategy aggregates of a report with a baseline.
+func CompareBaseline(baseline *Baseline, report *Report, tol BaselineTolerances) *BaselineDiff {
+	current := NewBaseline(report)
+	diff := &BaselineDiff{
+		BaselineDataVersion: baseline.DataVersion,
+		CurrentDataVersion:  current.DataVersion,
+		Tolerances:          tol,
+	}
+
+	byKey := make(map[string]BaselineAggregate, len(current.Aggregates))
+	for _, agg := range current.Aggregates {
+		byKey[agg.key()] = agg
+	}
+	base := append([]BaselineAggregate(nil), baseline.Aggregates...)
+	sort.Slice(base, func(i, j int) bool { return base[i].key() < base[j].key() })
+
+	seen := make(map[string]bool, len(base))
+	for _, b := range base {
+		seen[b.key()] = true
+		cur, ok := byKey[b.key()]
+		if !ok {
+			diff.Missing = append(diff.Missing, b.key())
+			continue
+		}
+		diff.Compared++
+		for _, m := range baselineMetrics {
+			bv, ok := b.Metrics[m.name]
+			if !ok {
+				continue // metric added after the baseline was saved
+			}
+			cv := cur.Metrics[m.name]
+			t := tol.For(m.name)
+			if t.Within(bv, cv) {
+				continue
+			}
+			kind := BaselineChanged
+			switch {
+			case m.direction != 0 && (cv-bv)*float64(m.direction) > 0:
+				kind = BaselineImprovement
+			case m.direction != 0:
+				kind = BaselineRegression
+			}
+			diff.Changes = append(diff.Changes, BaselineChange{
+				StrategyID:     b.StrategyID,
+				ScenarioID:     b.ScenarioID,
+				EntryEventType: b.EntryEventType,
+				Metric:         m.name,
+				Baseline:       bv,
+				Current:        cv,
+				Tolerance:      t,
+				Kind:           kind,
+			})
+		}
+	}
+	for _, agg := range current.Aggregates {
+		if !seen[agg.key()] {
+			diff.Added = append(diff.Added, agg.key())
+		}
+	}
+	return diff
+}
+
+// RenderBaselineDiff renders BASELINE_DIFF.md: the regressions, improvements
+// and other changes beyond tolerance, and the aggregates missing or added.
+func RenderBaselineDiff(d *BaselineDiff) string {
+	var sb strings.Builder
+	sb.WriteString("# Baseline Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("- **Baseline data version:** %s\n", d.BaselineDataVersion))
+	sb.WriteString(fmt.Sprintf("- **Current data version:** %s\n", d.CurrentDataVersion))
+	sb.WriteString(fmt.Sprintf("- **Tolerance:** %s (absolute:relative)", d.Tolerances.Default))
+	if len(d.Tolerances.Metrics) > 0 {
+		names := make([]string, 0, len(d.Tolerances.Metrics))
+		for name := range d.Tolerances.Metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		overrides := make([]string, len(names))
+		for i, name := range names {
+			overrides[i] = fmt.Sprintf("%s %s", name, d.Tolerances.Metrics[name])
+		}
+		sb.WriteString(fmt.Sprintf("; %s", strings.Join(overrides, ", ")))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("- **Aggregates compared:** %d\n\n", d.Compared))
+
+	regressions, improvements := d.Regressions(), d.Improvements()
+	switch {
+	case d.Informational():
+		sb.WriteString("**Result: INFORMATIONAL.** The data version changed since the baseline was saved, ")
+		sb.WriteString("so the differences below are not regressions.\n\n")
+	case d.Failed():
+		sb.WriteString(fmt.Sprintf("**Result: REGRESSED.** %d regressions and %d missing aggregates beyond tolerance.\n\n",
+			len(regressions), len(d.Missing)))
+	default:
+		sb.WriteString("**Result: OK.** No regressions beyond tolerance.\n\n")
+	}
+
+	sb.WriteString("## Regressions\n\n")
+	renderBaselineChanges(&sb, regressions)
+	if len(d.Missing) > 0 {
+		sb.WriteString("Aggregates of the baseline missing from this report:\n\n")
+		for _, key := range d.Missing {
+			sb.WriteString(fmt.Sprintf("- %s\n", key))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("## Improvements\n\n")
+	renderBaselineChanges(&sb, improvements)
+	sb.WriteString("## Other Changes\n\n")
+	renderBaselineChanges(&sb, d.changes(BaselineChanged))
+	if len(d.Added) > 0 {
+		sb.WriteString("Aggregates of this report not in the baseline:\n\n")
+		for _, key := range d.Added {
+			sb.WriteString(fmt.Sprintf("- %s\n", key))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func renderBaselineChanges(sb *strings.Builder, changes []BaselineChange) {
+	if len(changes) == 0 {
+		sb.WriteString("None.\n\n")
+		return
+	}
+	sb.WriteString("| Strategy | Scenario | Entry Type | Metric | Baseline | Current | Change | Tolerance |\n")
+	sb.WriteString("|----------|----------|------------|--------|----------|---------|--------|-----------|\n")
+	for _, c := range changes {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %.6f | %.6f | %+.6f | %s |\n",
+			c.StrategyID, c.ScenarioID, c.EntryEventType, c.Metric, c.Baseline, c.Current, c.Current-c.Baseline, c.Tolerance))
+	}
+	sb.WriteString("\n")
+}
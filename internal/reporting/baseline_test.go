@@ -0,0 +1,143 @@
+package reporting
+
+import (
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func baselineTestReport(dataVersion string) *Report {
+	return &Report{
+		Reproducibility: ReproducibilityMetadata{DataVersion: dataVersion},
+		StrategyMetrics: []StrategyMetricRow{
+			{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN",
+				TotalTrades: 100, TotalTokens: 40, WinRate: 0.55, OutcomeMean: 0.04, OutcomeMedian: 0.02,
+				MaxDrawdown: 0.8, MaxConsecutiveLosses: 6, OutcomeStddev: 0.3},
+			{StrategyID: "TRAILING_STOP", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN",
+				TotalTrades: 80, TotalTokens: 30, WinRate: 0.40, OutcomeMean: -0.01, OutcomeMedian: -0.02,
+				MaxDrawdown: 1.2, MaxConsecutiveLosses: 9, OutcomeStddev: 0.4},
+		},
+	}
+}
+
+func TestCompareBaseline_Matching(t *testing.T) {
+	report := baselineTestReport("v1")
+	data, err := MarshalBaseline(NewBaseline(report))
+	if err != nil {
+		t.Fatalf("MarshalBaseline: %v", err)
+	}
+	baseline, err := ParseBaseline(data)
+	if err != nil {
+		t.Fatalf("ParseBaseline: %v", err)
+	}
+
+	// Changes within the default tolerance are not reported
+	report.StrategyMetrics[0].WinRate = 0.5505
+	report.StrategyMetrics[1].MaxDrawdown = 1.21
+
+	diff := CompareBaseline(baseline, report, DefaultBaselineTolerances())
+	if diff.Compared != 2 || len(diff.Changes) != 0 || len(diff.Missing) != 0 || len(diff.Added) != 0 {
+		t.Errorf("expected a clean comparison, got %+v", diff)
+	}
+	if diff.Failed() || diff.Informational() {
+		t.Errorf("Failed = %v, Informational = %v, want false", diff.Failed(), diff.Informational())
+	}
+	if md := RenderBaselineDiff(diff); !strings.Contains(md, "**Result: OK.**") {
+		t.Errorf("unexpected BASELINE_DIFF.md:\n%s", md)
+	}
+}
+
+func TestCompareBaseline_Diverging(t *testing.T) {
+	baseline := NewBaseline(baselineTestReport("v1"))
+
+	report := baselineTestReport("v1")
+	report.StrategyMetrics[0].WinRate = 0.50                 // regression
+	report.StrategyMetrics[0].MaxDrawdown = 0.6              // improvement: lower is better
+	report.StrategyMetrics[0].OutcomeStddev = 0.5            // no direction
+	report.StrategyMetrics[0].MaxConsecutiveLosses = 7       // within its override
+	report.StrategyMetrics[1].StrategyID = "LIQUIDITY_GUARD" // TRAILING_STOP missing, LIQUIDITY_GUARD added
+
+	tol := DefaultBaselineTolerances()
+	if err := tol.SetMetric("max_consecutive_losses=1:0%"); err != nil {
+		t.Fatalf("SetMetric: %v", err)
+	}
+	diff := CompareBaseline(baseline, report, tol)
+
+	regressions, improvements := diff.Regressions(), diff.Improvements()
+	if len(regressions) != 1 || regressions[0].Metric != "win_rate" || regressions[0].Baseline != 0.55 || regressions[0].Current != 0.50 {
+		t.Errorf("regressions = %+v", regressions)
+	}
+	if len(improvements) != 1 || improvements[0].Metric != "max_drawdown" {
+		t.Errorf("improvements = %+v", improvements)
+	}
+	if len(diff.Changes) != 3 || diff.Changes[1].Metric != "outcome_stddev" || diff.Changes[1].Kind != BaselineChanged {
+		t.Errorf("changes = %+v", diff.Changes)
+	}
+	if strings.Join(diff.Missing, ",") != "TRAILING_STOP/realistic/NEW_TOKEN" || strings.Join(diff.Added, ",") != "LIQUIDITY_GUARD/realistic/NEW_TOKEN" {
+		t.Errorf("missing = %v, added = %v", diff.Missing, diff.Added)
+	}
+	if !diff.Failed() {
+		t.Error("expected the comparison to fail")
+	}
+
+	md := RenderBaselineDiff(diff)
+	for _, want := range []string{
+		"**Result: REGRESSED.** 1 regressions and 1 missing aggregates",
+		"| TIME_EXIT | realistic | NEW_TOKEN | win_rate | 0.550000 | 0.500000 | -0.050000 | 0.001:1% |",
+		"| TIME_EXIT | realistic | NEW_TOKEN | max_drawdown | 0.800000 | 0.600000 | -0.200000 | 0.001:1% |",
+		"- TRAILING_STOP/realistic/NEW_TOKEN",
+		"max_consecutive_losses 1:0%",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("BASELINE_DIFF.md missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestCompareBaseline_DataVersionGuard(t *testing.T) {
+	baseline := NewBaseline(baselineTestReport("v1"))
+
+	report := baselineTestReport("v2")
+	report.StrategyMetrics[0].WinRate = 0.30
+	report.StrategyMetrics = report.StrategyMetrics[:1]
+
+	diff := CompareBaseline(baseline, report, DefaultBaselineTolerances())
+	if len(diff.Regressions()) != 1 || len(diff.Missing) != 1 {
+		t.Fatalf("expected the differences to be reported, got %+v", diff)
+	}
+	if !diff.Informational() || diff.Failed() {
+		t.Errorf("a changed data version must make the comparison informational: Informational = %v, Failed = %v",
+			diff.Informational(), diff.Failed())
+	}
+	if md := RenderBaselineDiff(diff); !strings.Contains(md, "**Result: INFORMATIONAL.**") {
+		t.Errorf("unexpected BASELINE_DIFF.md:\n%s", md)
+	}
+}
+
+func TestBaselineTolerances_SetMetric(t *testing.T) {
+	var tol BaselineTolerances
+	if err := tol.SetMetric("win_rate=0.02:5%"); err != nil {
+		t.Fatalf("SetMetric: %v", err)
+	}
+	if got := tol.For("win_rate"); got != (Tolerance{Abs: 0.02, Rel: 0.05}) {
+		t.Errorf("win_rate tolerance = %+v", got)
+	}
+	for _, spec := range []string{"win_rate", "win_rate=0.02", "unknown=0:0", "win_rate=x:1", "win_rate=0:-1%"} {
+		if err := tol.SetMetric(spec); err == nil {
+			t.Errorf("SetMetric(%q): expected an error", spec)
+		}
+	}
+
+	// The larger of the absolute and relative bounds applies
+	tolerance := Tolerance{Abs: 0.01, Rel: 0.1}
+	if !tolerance.Within(1, 1.09) || tolerance.Within(1, 1.11) || !tolerance.Within(0, 0.01) || tolerance.Within(0, 0.02) {
+		t.Error("unexpected Within results")
+	}
+}
+
+func TestParseBaseline_Version(t *testing.T) {
+	if _, err := ParseBaseline([]byte(`{"version":2,"data_version":"v1","aggregates":[]}`)); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+}
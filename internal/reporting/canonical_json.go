@@ -0,0 +1,157 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultFloatPrecision is the number of decimals canonical JSON keeps for
+// floats without an entry in FloatPrecision.
+const DefaultFloatPrecision = 8
+
+// FloatPrecision is the number of decimals canonical JSON keeps per object key.
+// A key's precision also applies to the numbers nested under it, e.g. every
+// median of "medians".
+var FloatPrecision = map[string]int{
+	// Rates and shares in [0, 1]
+	"win_rate":              6,
+	"token_win_rate":        6,
+	"win_rate_realistic":    6,
+	"new_token_win_rate":    6,
+	"active_token_win_rate": 6,
+	"delta_win_rate":        6,
+	"data_end_share":        6,
+
+	// Percentages
+	"degradation_pct": 4,
+	"threshold_pct":   4,
+}
+
+// MarshalCanonicalJSON encodes v as canonical JSON, byte-stable for equal
+// values: object keys sorted (struct fields included), two-space indentation,
+// no HTML escaping, floats rounded to their FloatPrecision with trailing zeros
+// dropped, and a trailing newline. Integers are written unchanged.
+//
+// Used for every JSON artifact covered by checksums.sha256, so that runs over
+// the same data can be diffed byte for byte.
+func MarshalCanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("decode for canonical form: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, tree, DefaultFloatPrecision, ""); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// writeCanonical writes value at the given indentation; precision applies to
+// the numbers of value unless a nested key overrides it.
+func writeCanonical(buf *bytes.Buffer, value any, precision int, indent string) error {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteString("{\n")
+		for i, k := range keys {
+			buf.WriteString(indent + "  ")
+			writeCanonicalString(buf, k)
+			buf.WriteString(": ")
+			p := precision
+			if fp, ok := FloatPrecision[k]; ok {
+				p = fp
+			}
+			if err := writeCanonical(buf, v[k], p, indent+"  "); err != nil {
+				return err
+			}
+			if i < len(keys)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "}")
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, elem := range v {
+			buf.WriteString(indent + "  ")
+			if err := writeCanonical(buf, elem, precision, indent+"  "); err != nil {
+				return err
+			}
+			if i < len(v)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "]")
+	case string:
+		writeCanonicalString(buf, v)
+	case json.Number:
+		n, err := canonicalNumber(v, precision)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return fmt.Errorf("canonical json: unexpected %T", value)
+	}
+	return nil
+}
+
+// writeCanonicalString writes s as a JSON string without HTML escaping.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	var sb bytes.Buffer
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s) // encoding a string cannot fail
+	buf.Write(bytes.TrimSuffix(sb.Bytes(), []byte("\n")))
+}
+
+// canonicalNumber formats n: integers as written, other numbers rounded to
+// precision decimals without trailing zeros, negative zero as 0.
+func canonicalNumber(n json.Number, precision int) (string, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		return s, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return "", fmt.Errorf("canonical json: %w", err)
+	}
+	out := strconv.FormatFloat(f, 'f', precision, 64)
+	if strings.Contains(out, ".") {
+		out = strings.TrimRight(strings.TrimRight(out, "0"), ".")
+	}
+	if out == "-0" || math.Abs(f) == 0 {
+		out = "0"
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package reporting
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// canonicalSample exercises every canonical JSON rule: struct fields out of
+// key order, an unordered map, per-key and inherited float precision, float
+// noise, negative zero, integers, HTML characters and empty containers.
+type canonicalSample struct {
+	Name      string             `json:"name"`
+	WinRate   float64            `json:"win_rate"`
+	Outcome   float64            `json:"outcome"`
+	Trades    int64              `json:"trades"`
+	Medians   map[string]float64 `json:"medians"`
+	Rates     []float64          `json:"degradation_pct"`
+	Flat      float64            `json:"flat"`
+	Note      string             `json:"note"`
+	Tags      []string           `json:"tags"`
+	Extra     map[string]string  `json:"extra"`
+	Enabled   bool               `json:"enabled"`
+	Missing   *string            `json:"missing"`
+	Timestamp int64              `json:"timestamp_ms"`
+}
+
+func newCanonicalSample() canonicalSample {
+	return canonicalSample{
+		Name:      "TIME_EXIT",
+		WinRate:   0.1 + 0.2, // 0.30000000000000004
+		Outcome:   -0.0000000001,
+		Trades:    9007199254740993,
+		Medians:   map[string]float64{"realistic": 0.0123456789, "optimistic": 0.05, "degraded": -0.25},
+		Rates:     []float64{12.345678, 100},
+		Flat:      3.0,
+		Note:      "<a href=\"x\">p&l</a>",
+		Tags:      []string{},
+		Extra:     map[string]string{},
+		Enabled:   true,
+		Timestamp: 1704067200000,
+	}
+}
+
+func TestMarshalCanonicalJSON_Golden(t *testing.T) {
+	got, err := MarshalCanonicalJSON(newCanonicalSample())
+	if err != nil {
+		t.Fatalf("MarshalCanonicalJSON: %v", err)
+	}
+
+	path := filepath.Join("testdata", "canonical.json")
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatalf("create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("canonical.json mismatch:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestMarshalCanonicalJSON_ByteStable(t *testing.T) {
+	first, err := MarshalCanonicalJSON(newCanonicalSample())
+	if err != nil {
+		t.Fatalf("MarshalCanonicalJSON: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		again, err := MarshalCanonicalJSON(newCanonicalSample())
+		if err != nil {
+			t.Fatalf("MarshalCanonicalJSON: %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("run %d differs:\n%s\n---\n%s", i, again, first)
+		}
+	}
+
+	// Encoding decoded output again is the identity
+	var decoded canonicalSample
+	if err := json.Unmarshal(first, &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	again, err := MarshalCanonicalJSON(decoded)
+	if err != nil {
+		t.Fatalf("MarshalCanonicalJSON: %v", err)
+	}
+	if string(again) != string(first) {
+		t.Errorf("re-encoding changed the output:\n%s\n---\n%s", again, first)
+	}
+}
+
+func TestMarshalCanonicalJSON_RoundTrip(t *testing.T) {
+	// Values within their precision decode back unchanged
+	in := canonicalSample{
+		Name:      "LIQUIDITY_GUARD",
+		WinRate:   0.625,
+		Outcome:   -0.12345678,
+		Trades:    42,
+		Medians:   map[string]float64{"realistic": 0.01, "pessimistic": -0.5},
+		Rates:     []float64{33.3333},
+		Flat:      7,
+		Note:      "a < b && c > d",
+		Tags:      []string{"dex_raydium", "dedup"},
+		Extra:     map[string]string{"z": "last", "a": "first"},
+		Timestamp: 1704240000000,
+	}
+	data, err := MarshalCanonicalJSON(in)
+	if err != nil {
+		t.Fatalf("MarshalCanonicalJSON: %v", err)
+	}
+	var out canonicalSample
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip differs:\n in: %+v\nout: %+v", in, out)
+	}
+}
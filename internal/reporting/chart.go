@@ -0,0 +1,176 @@
+package reporting
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"solana-token-lab/internal/domain"
+)
+
+// Chart layout in SVG user units.
+const (
+	chartWidth       = 640
+	chartPanelHeight = 160
+	chartMarginLeft  = 72
+	chartMarginRight = 16
+	chartMarginTop   = 28
+	chartPanelGap    = 36
+)
+
+// ChartInput holds the series and trade markers for one candidate chart.
+type ChartInput struct {
+	CandidateID string
+	Title       string // header line, e.g. strategy and outcome
+	Price       []*domain.PriceTimeseriesPoint
+	Liquidity   []*domain.LiquidityTimeseriesPoint
+	EntryTimeMs int64 // 0 to omit the marker
+	ExitTimeMs  int64 // 0 to omit the marker
+}
+
+// ChartReference links a rendered chart from the report.
+type ChartReference struct {
+	CandidateID string
+	StrategyID  string
+	ScenarioID  string
+	Outcome     float64
+	Path        string // relative to the output directory
+}
+
+// SampleInfo describes a deterministic candidate sample a report section was built from.
+type SampleInfo struct {
+	Size       int // candidates in the sample
+	Population int // candidates the sample was drawn from
+}
+
+// chartPoint is one (time, value) sample of a panel series.
+type chartPoint struct {
+	t int64
+	v float64
+}
+
+// RenderCandidateChartSVG renders price (top) and liquidity (bottom) panels as SVG.
+// Output depends only on the input: axes are scaled to the data range across both
+// panels' shared time axis and coordinates are printed with fixed precision.
+func RenderCandidateChartSVG(in ChartInput) string {
+	price := make([]chartPoint, 0, len(in.Price))
+	for _, p := range in.Price {
+		price = append(price, chartPoint{t: p.TimestampMs, v: p.Price})
+	}
+	liquidity := make([]chartPoint, 0, len(in.Liquidity))
+	for _, l := range in.Liquidity {
+		liquidity = append(liquidity, chartPoint{t: l.TimestampMs, v: l.Liquidity})
+	}
+
+	// Shared time axis over both series and the trade markers
+	tMin, tMax := int64(math.MaxInt64), int64(math.MinInt64)
+	extend := func(t int64) {
+		if t < tMin {
+			tMin = t
+		}
+		if t > tMax {
+			tMax = t
+		}
+	}
+	for _, p := range price {
+		extend(p.t)
+	}
+	for _, p := range liquidity {
+		extend(p.t)
+	}
+	if in.EntryTimeMs > 0 {
+		extend(in.EntryTimeMs)
+	}
+	if in.ExitTimeMs > 0 {
+		extend(in.ExitTimeMs)
+	}
+	if tMin > tMax {
+		tMin, tMax = 0, 1
+	}
+	if tMin == tMax {
+		tMax = tMin + 1
+	}
+
+	height := chartMarginTop + 2*chartPanelHeight + chartPanelGap + 24
+	plotWidth := float64(chartWidth - chartMarginLeft - chartMarginRight)
+	xOf := func(t int64) float64 {
+		return chartMarginLeft + float64(t-tMin)/float64(tMax-tMin)*plotWidth
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="11">`+"\n",
+		chartWidth, height, chartWidth, height))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", chartWidth, height))
+	title := in.Title
+	if title == "" {
+		title = in.CandidateID
+	}
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="16">%s</text>`+"\n", chartMarginLeft, html.EscapeString(title)))
+
+	writeChartPanel(&sb, "price", price, chartMarginTop, "#1f77b4", xOf)
+	writeChartPanel(&sb, "liquidity", liquidity, chartMarginTop+chartPanelHeight+chartPanelGap, "#2ca02c", xOf)
+
+	// Entry/exit markers span both panels
+	bottom := chartMarginTop + 2*chartPanelHeight + chartPanelGap
+	if in.EntryTimeMs > 0 {
+		sb.WriteString(fmt.Sprintf(`<line class="entry" x1="%.2f" y1="%d" x2="%.2f" y2="%d" stroke="#888888" stroke-dasharray="4 3"/>`+"\n",
+			xOf(in.EntryTimeMs), chartMarginTop, xOf(in.EntryTimeMs), bottom))
+	}
+	if in.ExitTimeMs > 0 {
+		sb.WriteString(fmt.Sprintf(`<line class="exit" x1="%.2f" y1="%d" x2="%.2f" y2="%d" stroke="#d62728" stroke-dasharray="4 3"/>`+"\n",
+			xOf(in.ExitTimeMs), chartMarginTop, xOf(in.ExitTimeMs), bottom))
+	}
+
+	// Time axis as offset from the first sample keeps labels independent of wall clock
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d">+0s</text>`+"\n", chartMarginLeft, height-6))
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="end">+%ds</text>`+"\n",
+		chartWidth-chartMarginRight, height-6, (tMax-tMin)/1000))
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// writeChartPanel draws one framed panel with min/max labels and a polyline path.
+func writeChartPanel(sb *strings.Builder, label string, points []chartPoint, top int, color string, xOf func(int64) float64) {
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="#cccccc"/>`+"\n",
+		chartMarginLeft, top, chartWidth-chartMarginLeft-chartMarginRight, chartPanelHeight))
+	sb.WriteString(fmt.Sprintf(`<text x="4" y="%d">%s</text>`+"\n", top+chartPanelHeight/2, label))
+
+	if len(points) == 0 {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d">no data</text>`+"\n", chartMarginLeft+8, top+chartPanelHeight/2))
+		return
+	}
+
+	vMin, vMax := points[0].v, points[0].v
+	for _, p := range points[1:] {
+		vMin = math.Min(vMin, p.v)
+		vMax = math.Max(vMax, p.v)
+	}
+	// Flat series sit mid-panel
+	lo, hi := vMin, vMax
+	if lo == hi {
+		pad := math.Abs(lo) * 0.5
+		if pad == 0 {
+			pad = 1
+		}
+		lo, hi = lo-pad, hi+pad
+	}
+	yOf := func(v float64) float64 {
+		return float64(top+chartPanelHeight) - (v-lo)/(hi-lo)*chartPanelHeight
+	}
+
+	sb.WriteString(fmt.Sprintf(`<text x="4" y="%d">%.6g</text>`+"\n", top+10, vMax))
+	sb.WriteString(fmt.Sprintf(`<text x="4" y="%d">%.6g</text>`+"\n", top+chartPanelHeight-2, vMin))
+
+	var path strings.Builder
+	for i, p := range points {
+		cmd := "L"
+		if i == 0 {
+			cmd = "M"
+		}
+		path.WriteString(fmt.Sprintf("%s%.2f %.2f ", cmd, xOf(p.t), yOf(p.v)))
+	}
+	sb.WriteString(fmt.Sprintf(`<path class="%s" d="%s" fill="none" stroke="%s" stroke-width="1.5"/>`+"\n",
+		label, strings.TrimSpace(path.String()), color))
+}
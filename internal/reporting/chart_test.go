@@ -0,0 +1,89 @@
+package reporting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func chartFixture() ChartInput {
+	return ChartInput{
+		CandidateID: "cand_001",
+		Title:       "cand_001 | TIME_EXIT NEW_TOKEN | outcome 0.0800",
+		Price: []*domain.PriceTimeseriesPoint{
+			{CandidateID: "cand_001", TimestampMs: 1704067200000, Price: 1.00},
+			{CandidateID: "cand_001", TimestampMs: 1704067260000, Price: 1.02},
+			{CandidateID: "cand_001", TimestampMs: 1704067320000, Price: 0.97},
+			{CandidateID: "cand_001", TimestampMs: 1704067380000, Price: 1.10},
+		},
+		Liquidity: []*domain.LiquidityTimeseriesPoint{
+			{CandidateID: "cand_001", TimestampMs: 1704067200000, Liquidity: 500},
+			{CandidateID: "cand_001", TimestampMs: 1704067380000, Liquidity: 650},
+		},
+		EntryTimeMs: 1704067260000,
+		ExitTimeMs:  1704067380000,
+	}
+}
+
+var svgPathData = regexp.MustCompile(` d="([^"]*)"`)
+
+// pathDataHash hashes the d attributes of all paths in the SVG.
+func pathDataHash(svg string) string {
+	h := sha256.New()
+	for _, m := range svgPathData.FindAllStringSubmatch(svg, -1) {
+		h.Write([]byte(m[1]))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestRenderCandidateChartSVG_Deterministic(t *testing.T) {
+	first := RenderCandidateChartSVG(chartFixture())
+	second := RenderCandidateChartSVG(chartFixture())
+	if first != second {
+		t.Fatal("chart output differs between identical renders")
+	}
+
+	paths := svgPathData.FindAllStringSubmatch(first, -1)
+	if len(paths) != 2 {
+		t.Fatalf("expected price and liquidity paths, got %d", len(paths))
+	}
+	// Price spans the full width: first sample on the left edge, last on the right
+	if !strings.HasPrefix(paths[0][1], "M72.00 ") || !strings.Contains(paths[0][1], "L624.00 28.00") {
+		t.Errorf("unexpected price path scaling: %s", paths[0][1])
+	}
+
+	const wantHash = "a2091744ccf0ad5c60552091eebabef3c5ede597de793581dc302fe44b50f553"
+	if got := pathDataHash(first); got != wantHash {
+		t.Errorf("path data hash changed: got %s, want %s", got, wantHash)
+	}
+
+	if !strings.Contains(first, `class="entry"`) || !strings.Contains(first, `class="exit"`) {
+		t.Error("expected entry and exit markers")
+	}
+}
+
+func TestRenderCandidateChartSVG_EmptyAndFlat(t *testing.T) {
+	svg := RenderCandidateChartSVG(ChartInput{
+		CandidateID: "cand_flat",
+		Price: []*domain.PriceTimeseriesPoint{
+			{TimestampMs: 1000, Price: 2},
+			{TimestampMs: 2000, Price: 2},
+		},
+	})
+
+	// Flat price renders mid-panel; missing liquidity renders a placeholder
+	if !strings.Contains(svg, "M72.00 108.00 L624.00 108.00") {
+		t.Errorf("expected flat price line mid-panel, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, "no data") {
+		t.Error("expected no data placeholder for empty liquidity")
+	}
+	if strings.Contains(svg, "NaN") || strings.Contains(svg, "Inf") {
+		t.Error("chart contains non-finite coordinates")
+	}
+}
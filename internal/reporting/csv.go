@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/simulation"
 )
 
 // csvQuote wraps string in double quotes and escapes internal quotes.
@@ -15,18 +17,19 @@ func csvQuote(s string) string {
 }
 
 // RenderStrategyAggregatesCSV renders strategy aggregates as CSV string.
-// Per REPORTING_SPEC.md: 18 columns.
+// Per REPORTING_SPEC.md: 20 columns.
 func RenderStrategyAggregatesCSV(metrics []StrategyMetricRow) string {
 	var sb strings.Builder
 
-	// Header (18 columns per spec)
+	// Header (20 columns per spec)
 	sb.WriteString("strategy_id,scenario_id,entry_event_type,total_trades,wins,losses,win_rate,")
 	sb.WriteString("outcome_mean,outcome_median,outcome_p10,outcome_p25,outcome_p75,outcome_p90,")
-	sb.WriteString("outcome_min,outcome_max,outcome_stddev,max_drawdown,max_consecutive_losses\n")
+	sb.WriteString("outcome_min,outcome_max,outcome_stddev,max_drawdown,max_consecutive_losses,")
+	sb.WriteString("data_end_exits,data_end_share\n")
 
 	// Rows
 	for _, m := range metrics {
-		sb.WriteString(fmt.Sprintf("%s,%s,%s,%d,%d,%d,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%d\n",
+		sb.WriteString(fmt.Sprintf("%s,%s,%s,%d,%d,%d,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%d,%d,%.6f\n",
 			csvQuote(m.StrategyID),
 			csvQuote(m.ScenarioID),
 			csvQuote(m.EntryEventType),
@@ -45,6 +48,8 @@ func RenderStrategyAggregatesCSV(metrics []StrategyMetricRow) string {
 			m.OutcomeStddev,
 			m.MaxDrawdown,
 			m.MaxConsecutiveLosses,
+			m.DataEndExits,
+			m.DataEndShare,
 		))
 	}
 
@@ -52,18 +57,20 @@ func RenderStrategyAggregatesCSV(metrics []StrategyMetricRow) string {
 }
 
 // RenderTradeRecordsCSV renders trade records as CSV string.
-// Per REPORTING_SPEC.md: 27 columns.
+// Per REPORTING_SPEC.md: 31 columns; the cost breakdown columns are empty for
+// trades simulated without one (MEV is already mev_cost_sol).
 func RenderTradeRecordsCSV(trades []*domain.TradeRecord) string {
 	var sb strings.Builder
 
-	// Header (27 columns per spec)
+	// Header (31 columns per spec)
 	sb.WriteString("trade_id,candidate_id,strategy_id,scenario_id,")
 	sb.WriteString("entry_signal_time,entry_signal_price,entry_actual_time,entry_actual_price,")
 	sb.WriteString("entry_liquidity,position_size,position_value,")
 	sb.WriteString("exit_signal_time,exit_signal_price,exit_actual_time,exit_actual_price,")
 	sb.WriteString("exit_reason,entry_cost_sol,exit_cost_sol,mev_cost_sol,total_cost_sol,")
 	sb.WriteString("total_cost_pct,gross_return,outcome,outcome_class,")
-	sb.WriteString("hold_duration_ms,peak_price,min_liquidity\n")
+	sb.WriteString("hold_duration_ms,peak_price,min_liquidity,")
+	sb.WriteString("entry_slippage_sol,exit_slippage_sol,network_fee_sol,priority_fee_sol\n")
 
 	// Rows
 	for _, t := range trades {
@@ -80,8 +87,15 @@ func RenderTradeRecordsCSV(trades []*domain.TradeRecord) string {
 		if t.MinLiquidity != nil {
 			minLiquidity = fmt.Sprintf("%.6f", *t.MinLiquidity)
 		}
+		var entrySlippage, exitSlippage, networkFee, priorityFee string
+		if b := t.CostBreakdown; b != nil {
+			entrySlippage = fmt.Sprintf("%.6f", b.EntrySlippageSOL)
+			exitSlippage = fmt.Sprintf("%.6f", b.ExitSlippageSOL)
+			networkFee = fmt.Sprintf("%.6f", b.NetworkFeeSOL)
+			priorityFee = fmt.Sprintf("%.6f", b.PriorityFeeSOL)
+		}
 
-		sb.WriteString(fmt.Sprintf("%s,%s,%s,%s,%d,%.6f,%d,%.6f,%s,%.6f,%.6f,%d,%.6f,%d,%.6f,%s,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%s,%d,%s,%s\n",
+		sb.WriteString(fmt.Sprintf("%s,%s,%s,%s,%d,%.6f,%d,%.6f,%s,%.6f,%.6f,%d,%.6f,%d,%.6f,%s,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%.6f,%s,%d,%s,%s,%s,%s,%s,%s\n",
 			csvQuote(t.TradeID),
 			csvQuote(t.CandidateID),
 			csvQuote(t.StrategyID),
@@ -109,6 +123,10 @@ func RenderTradeRecordsCSV(trades []*domain.TradeRecord) string {
 			t.HoldDurationMs,
 			peakPrice,
 			minLiquidity,
+			entrySlippage,
+			exitSlippage,
+			networkFee,
+			priorityFee,
 		))
 	}
 
@@ -116,22 +134,83 @@ func RenderTradeRecordsCSV(trades []*domain.TradeRecord) string {
 }
 
 // RenderScenarioOutcomesCSV renders scenario outcomes as CSV string.
-// Per REPORTING_SPEC.md: 6 columns.
+// Per REPORTING_SPEC.md: 8 columns.
 func RenderScenarioOutcomesCSV(sensitivity []ScenarioSensitivityRow) string {
 	var sb strings.Builder
 
-	// Header (6 columns per spec)
-	sb.WriteString("strategy_id,entry_event_type,outcome_optimistic,outcome_realistic,outcome_pessimistic,outcome_degraded\n")
+	// Header (8 columns per spec)
+	sb.WriteString("strategy_id,entry_event_type,outcome_optimistic,outcome_realistic,outcome_pessimistic,outcome_degraded,total_trades,low_confidence\n")
 
 	// Rows
 	for _, s := range sensitivity {
-		sb.WriteString(fmt.Sprintf("%s,%s,%.6f,%.6f,%.6f,%.6f\n",
+		sb.WriteString(fmt.Sprintf("%s,%s,%.6f,%.6f,%.6f,%.6f,%d,%t\n",
 			csvQuote(s.StrategyID),
 			csvQuote(s.EntryEventType),
 			s.OptimisticMedian,
 			s.RealisticMedian,
 			s.PessimisticMedian,
 			s.DegradedMedian,
+			s.TotalTrades,
+			s.LowConfidence,
+		))
+	}
+
+	return sb.String()
+}
+
+// RenderFeatureAnalysisCSV renders feature_analysis.csv: one row per bin of
+// every feature, features in rank order and bins in ascending feature values.
+// bin_lower and bin_upper are the quantile edges of the bin.
+func RenderFeatureAnalysisCSV(a *metrics.FeatureAnalysis) string {
+	var sb strings.Builder
+
+	sb.WriteString("strategy_id,scenario_id,feature,rank,bin,bin_lower,bin_upper,trades,wins,win_rate,median_outcome\n")
+
+	for _, f := range a.Features {
+		for i, b := range f.Bins {
+			sb.WriteString(fmt.Sprintf("%s,%s,%s,%d,%d,%.6f,%.6f,%d,%d,%.6f,%.6f\n",
+				csvQuote(a.StrategyID),
+				csvQuote(a.ScenarioID),
+				f.Feature,
+				f.Rank,
+				i+1,
+				b.Lower,
+				b.Upper,
+				b.Trades,
+				b.Wins,
+				b.WinRate,
+				b.MedianOutcome,
+			))
+		}
+	}
+
+	return sb.String()
+}
+
+// RenderWhatIfCSV renders what-if results (whatif_analysis.csv), one row per original trade.
+// outcome_delta and hold_delta_ms are alternate minus original.
+func RenderWhatIfCSV(results []*simulation.WhatIfResult) string {
+	var sb strings.Builder
+
+	sb.WriteString("trade_id,candidate_id,scenario_id,original_strategy_id,alternate_strategy_id,")
+	sb.WriteString("original_exit_reason,alternate_exit_reason,original_hold_duration_ms,alternate_hold_duration_ms,")
+	sb.WriteString("original_outcome,alternate_outcome,outcome_delta,hold_delta_ms\n")
+
+	for _, r := range results {
+		sb.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%d,%d,%.6f,%.6f,%.6f,%d\n",
+			csvQuote(r.Original.TradeID),
+			csvQuote(r.Original.CandidateID),
+			csvQuote(r.Original.ScenarioID),
+			csvQuote(r.Original.StrategyID),
+			csvQuote(r.Alternate.StrategyID),
+			csvQuote(r.Original.ExitReason),
+			csvQuote(r.Alternate.ExitReason),
+			r.Original.HoldDurationMs,
+			r.Alternate.HoldDurationMs,
+			r.Original.Outcome,
+			r.Alternate.Outcome,
+			r.OutcomeDelta,
+			r.HoldDeltaMs,
 		))
 	}
 
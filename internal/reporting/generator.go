@@ -2,19 +2,29 @@ package reporting
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sort"
 	"time"
 
+	"solana-token-lab/internal/discovery"
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
 	"solana-token-lab/internal/storage"
 )
 
 // Generator produces reports from stored data.
 type Generator struct {
-	candidateStore   storage.CandidateStore
-	tradeRecordStore storage.TradeRecordStore
-	aggregateStore   storage.StrategyAggregateStore
-	now              func() time.Time // Injectable clock for deterministic output
+	candidateStore    storage.CandidateStore
+	tradeRecordStore  storage.TradeRecordStore
+	aggregateStore    storage.StrategyAggregateStore
+	swapEventStore    storage.SwapEventStore      // optional, for observed fee telemetry and trader counts
+	liquidityStore    storage.LiquidityEventStore // optional, for the ingestion origin split
+	failedTxStore     storage.FailedTxStore       // optional, for the observed failure rate
+	now               func() time.Time            // Injectable clock for deterministic output
+	degradationPct    float64                     // scenario matrix flag threshold
+	minScenarioTrades int                         // scenario sensitivity rows below this are low confidence
+	scenarioVersion   int                         // scenario definitions version reported on
 }
 
 // NewGenerator creates a new report generator.
@@ -24,10 +34,13 @@ func NewGenerator(
 	aggStore storage.StrategyAggregateStore,
 ) *Generator {
 	return &Generator{
-		candidateStore:   candidateStore,
-		tradeRecordStore: tradeStore,
-		aggregateStore:   aggStore,
-		now:              func() time.Time { return time.Now().UTC() },
+		candidateStore:    candidateStore,
+		tradeRecordStore:  tradeStore,
+		aggregateStore:    aggStore,
+		now:               func() time.Time { return time.Now().UTC() },
+		degradationPct:    DefaultDegradationThresholdPct,
+		minScenarioTrades: DefaultMinScenarioTrades,
+		scenarioVersion:   domain.BaseScenarioVersion,
 	}
 }
 
@@ -37,19 +50,96 @@ func (g *Generator) WithClock(now func() time.Time) *Generator {
 	return g
 }
 
+// WithDegradationThreshold sets the realistic→pessimistic degradation percentage
+// above which strategies are flagged in the scenario matrix.
+func (g *Generator) WithDegradationThreshold(pct float64) *Generator {
+	g.degradationPct = pct
+	return g
+}
+
+// WithMinScenarioTrades sets the trade count below which a scenario sensitivity
+// row is flagged low confidence. 0 flags nothing.
+func (g *Generator) WithMinScenarioTrades(n int) *Generator {
+	g.minScenarioTrades = n
+	return g
+}
+
+// WithScenarioVersion reports on the trades and aggregates costed with the given
+// version of the scenario definitions (see domain.VersionedScenarioID) instead of
+// the original ones. Other versions are left out of the report.
+func (g *Generator) WithScenarioVersion(version int) *Generator {
+	g.scenarioVersion = version
+	return g
+}
+
+// WithSwapEventStore enables the observed fee section, comparing swap transaction
+// fees against the scenario fee assumptions, and the unique trader counts of the
+// data summary.
+func (g *Generator) WithSwapEventStore(store storage.SwapEventStore) *Generator {
+	g.swapEventStore = store
+	return g
+}
+
+// WithLiquidityEventStore adds liquidity events to the ingestion origin split of
+// the data summary, which otherwise uses swap events only.
+func (g *Generator) WithLiquidityEventStore(store storage.LiquidityEventStore) *Generator {
+	g.liquidityStore = store
+	return g
+}
+
+// WithFailedTxStore adds the on-chain failure rate observed by ingestion over
+// the date range to the data summary.
+func (g *Generator) WithFailedTxStore(store storage.FailedTxStore) *Generator {
+	g.failedTxStore = store
+	return g
+}
+
 // Generate produces a complete Phase 1 report.
+// A failing store does not abort the report: the affected sections are left empty
+// and marked in Report.Provenance. Only context cancellation is returned as an error.
 func (g *Generator) Generate(ctx context.Context) (*Report, error) {
-	// Load all aggregates
-	aggs, err := g.aggregateStore.GetAll(ctx)
-	if err != nil {
-		return nil, err
-	}
+	var prov Provenance
 
-	// Generate data summary
-	dataSummary, err := g.generateDataSummary(ctx, aggs)
-	if err != nil {
-		return nil, err
+	// Load all aggregates; strategy metrics and scenario sensitivity gate the decision
+	aggs, aggErr := g.aggregateStore.Find(ctx, storage.AggregateFilter{})
+	if aggErr != nil {
+		aggs = nil
+	}
+	aggs = filterAggregateScenarioVersion(aggs, g.scenarioVersion)
+	prov.Record(SectionStrategyMetrics, "strategy_aggregates", SourceMissing, true, aggErr)
+	prov.Record(SectionScenarioSensitivity, "strategy_aggregates", SourceMissing, true, aggErr)
+	prov.Record(SectionSourceComparison, "strategy_aggregates", SourceMissing, false, aggErr)
+
+	trades, tradeErr := g.tradeRecordStore.GetAll(ctx)
+	if tradeErr != nil {
+		trades = nil
 	}
+	trades = FilterScenarioVersion(trades, g.scenarioVersion)
+
+	// Generate data summary; without trades it still counts candidates
+	dataSummary, candidates, err := g.generateDataSummary(ctx, trades)
+	var traderErr, originErr, failedTxErr error
+	if err == nil {
+		traderErr = g.countUniqueTraders(ctx, dataSummary, candidates)
+		originErr = g.countOrigins(ctx, dataSummary, candidates)
+		failedTxErr = g.countFailedTransactions(ctx, dataSummary)
+	}
+	switch {
+	case err != nil:
+		dataSummary = &DataSummary{}
+		prov.Record(SectionDataSummary, "candidates", SourceMissing, false, err)
+	case tradeErr != nil:
+		prov.Record(SectionDataSummary, "trade_records", SourceFallback, false, tradeErr)
+	case traderErr != nil:
+		prov.Record(SectionDataSummary, "swap_events", SourceFallback, false, traderErr)
+	case originErr != nil:
+		prov.Record(SectionDataSummary, "liquidity_events", SourceFallback, false, originErr)
+	case failedTxErr != nil:
+		prov.Record(SectionDataSummary, "failed_tx_tallies", SourceFallback, false, failedTxErr)
+	default:
+		prov.Record(SectionDataSummary, "candidates", SourceOK, false, nil)
+	}
+	prov.Record(SectionCostComposition, "trade_records", SourceMissing, false, tradeErr)
 
 	// Generate strategy metrics
 	metrics := g.generateStrategyMetrics(aggs)
@@ -60,9 +150,36 @@ func (g *Generator) Generate(ctx context.Context) (*Report, error) {
 	// Generate scenario sensitivity
 	sensitivity := g.generateScenarioSensitivity(aggs)
 
+	dexComparison, err := g.generateDEXComparison(ctx, aggs)
+	if err == nil {
+		err = aggErr
+	}
+	if err != nil {
+		dexComparison = nil
+	}
+	prov.Record(SectionDEXComparison, "candidates", SourceMissing, false, err)
+
+	dedupComparison := generateDedupComparison(aggs)
+
 	// Generate replay references
 	replayRefs, err := g.generateReplayReferences(ctx, aggs)
+	if err == nil {
+		err = aggErr
+	}
 	if err != nil {
+		replayRefs = nil
+	}
+	prov.Record(SectionReplayReferences, "trade_records", SourceMissing, false, err)
+
+	observedFees, err := g.generateObservedFees(ctx)
+	if err != nil {
+		observedFees = nil
+	}
+	if g.swapEventStore != nil {
+		prov.Record(SectionObservedFees, "swap_events", SourceMissing, false, err)
+	}
+
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
@@ -75,6 +192,7 @@ func (g *Generator) Generate(ctx context.Context) (*Report, error) {
 	}
 
 	return &Report{
+		SchemaVersion:       ReportSchemaVersion,
 		GeneratedAt:         g.now(),
 		StrategyCount:       len(strategySet),
 		ScenarioCount:       len(scenarioSet),
@@ -82,29 +200,33 @@ func (g *Generator) Generate(ctx context.Context) (*Report, error) {
 		StrategyMetrics:     metrics,
 		SourceComparison:    sourceComparison,
 		ScenarioSensitivity: sensitivity,
+		ScenarioMatrix:      BuildScenarioMatrix(metrics, g.degradationPct),
+		DEXComparison:       dexComparison,
+		DedupComparison:     dedupComparison,
+		CostComposition:     generateCostComposition(trades),
+		ObservedFees:        observedFees,
 		ReplayReferences:    replayRefs,
+		Provenance:          prov,
+		Reproducibility:     ReproducibilityMetadata{ScenarioVersion: g.scenarioVersion},
 	}, nil
 }
 
-// generateDataSummary computes data summary from candidates and aggregates.
-func (g *Generator) generateDataSummary(ctx context.Context, aggs []*domain.StrategyAggregate) (*DataSummary, error) {
+// generateDataSummary computes data summary from candidates and trades.
+// It also returns the loaded candidates.
+func (g *Generator) generateDataSummary(ctx context.Context, trades []*domain.TradeRecord) (*DataSummary, []*domain.TokenCandidate, error) {
 	// Load candidates by source
 	newTokenCandidates, err := g.candidateStore.GetBySource(ctx, domain.SourceNewToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	activeTokenCandidates, err := g.candidateStore.GetBySource(ctx, domain.SourceActiveToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Count distinct trades from trade store (not from aggregates to avoid double-counting)
 	// Aggregates sum trades across strategy/scenario/entry combinations which counts same trade multiple times
-	trades, err := g.tradeRecordStore.GetAll(ctx)
-	if err != nil {
-		return nil, err
-	}
 	// Count unique trade IDs
 	uniqueTradeIDs := make(map[string]struct{})
 	for _, t := range trades {
@@ -128,14 +250,174 @@ func (g *Generator) generateDataSummary(ctx context.Context, aggs []*domain.Stra
 		}
 	}
 
+	multiCandidateMints := 0
+	for _, group := range domain.GroupByMint(allCandidates) {
+		if len(group) > 1 {
+			multiCandidateMints++
+		}
+	}
+
+	// Discovery latency over live-detected candidates
+	var latenciesMs []int64
+	for _, c := range allCandidates {
+		if latency, ok := discovery.DiscoveryLatency(c); ok {
+			latenciesMs = append(latenciesMs, latency.Milliseconds())
+		}
+	}
+	sort.Slice(latenciesMs, func(i, j int) bool { return latenciesMs[i] < latenciesMs[j] })
+
 	return &DataSummary{
-		TotalCandidates:       len(newTokenCandidates) + len(activeTokenCandidates),
-		NewTokenCandidates:    len(newTokenCandidates),
-		ActiveTokenCandidates: len(activeTokenCandidates),
-		TotalTrades:           totalTrades,
-		DateRangeStart:        dateRangeStart,
-		DateRangeEnd:          dateRangeEnd,
-	}, nil
+		TotalCandidates:        len(newTokenCandidates) + len(activeTokenCandidates),
+		NewTokenCandidates:     len(newTokenCandidates),
+		ActiveTokenCandidates:  len(activeTokenCandidates),
+		TotalTrades:            totalTrades,
+		DateRangeStart:         dateRangeStart,
+		DateRangeEnd:           dateRangeEnd,
+		MultiCandidateMints:    multiCandidateMints,
+		LiveDetectedCandidates: len(latenciesMs),
+		DiscoveryLatencyP50Ms:  nearestRank(latenciesMs, 0.50),
+		DiscoveryLatencyP90Ms:  nearestRank(latenciesMs, 0.90),
+		DiscoveryLatencyP99Ms:  nearestRank(latenciesMs, 0.99),
+	}, allCandidates, nil
+}
+
+// countUniqueTraders fills the unique trader fields of ds. Each candidate counts
+// the distinct traders of its mint within an hour either side of discovery, which
+// covers the ACTIVE_TOKEN detection window and the first hour of a NEW_TOKEN.
+func (g *Generator) countUniqueTraders(ctx context.Context, ds *DataSummary, candidates []*domain.TokenCandidate) error {
+	if g.swapEventStore == nil {
+		return nil
+	}
+	var counts []int64
+	for _, c := range candidates {
+		start, end := c.DiscoveredAt-discovery.Window1hMs, c.DiscoveredAt+discovery.Window1hMs
+		swaps, err := g.swapEventStore.GetByMintTimeRange(ctx, c.Mint, start, end)
+		if err != nil {
+			return fmt.Errorf("swap events of %s: %w", c.Mint, err)
+		}
+		if n := discovery.UniqueTraders(swaps, start, end); n > 0 {
+			counts = append(counts, int64(n))
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i] < counts[j] })
+
+	ds.TraderCountedCandidates = len(counts)
+	ds.UniqueTradersP50 = int(nearestRank(counts, 0.50))
+	ds.UniqueTradersP90 = int(nearestRank(counts, 0.90))
+	return nil
+}
+
+// countOrigins fills the ingestion origin fields of ds from the discovery event
+// of each candidate (see discovery.SummarizeOrigins).
+func (g *Generator) countOrigins(ctx context.Context, ds *DataSummary, candidates []*domain.TokenCandidate) error {
+	if g.swapEventStore == nil && g.liquidityStore == nil {
+		return nil
+	}
+	summary, err := discovery.SummarizeOrigins(ctx, candidates, g.swapEventStore, g.liquidityStore)
+	if err != nil {
+		return err
+	}
+	ds.LiveCandidates = summary.ByOrigin[domain.OriginLiveWS]
+	ds.BackfillCandidates = summary.ByOrigin[domain.OriginBackfill]
+	ds.ReplayCandidates = summary.ByOrigin[domain.OriginReplay]
+	ds.UnknownOriginCandidates = summary.ByOrigin[domain.OriginUnknown]
+	ds.LiveEventShareP50 = summary.LiveShareMedian
+	return nil
+}
+
+// countFailedTransactions fills the failure rate fields of ds from the tallies
+// of the hours overlapping the date range, or of every hour without one.
+func (g *Generator) countFailedTransactions(ctx context.Context, ds *DataSummary) error {
+	if g.failedTxStore == nil {
+		return nil
+	}
+	from, to := int64(0), int64(math.MaxInt64)
+	if ds.DateRangeEnd > 0 {
+		from, to = storage.FailedTxHour(ds.DateRangeStart), ds.DateRangeEnd+1
+	}
+	tallies, err := g.failedTxStore.GetRange(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("failed tx tallies: %w", err)
+	}
+
+	ds.FailedTxChecked = true
+	for _, t := range tallies {
+		ds.ObservedTransactions += t.Transactions
+		ds.FailedTransactions += t.Failed
+	}
+	if ds.ObservedTransactions > 0 {
+		ds.FailedTxRate = float64(ds.FailedTransactions) / float64(ds.ObservedTransactions)
+	}
+	return nil
+}
+
+// feeScenarios are the scenarios whose fee assumptions are cited against observed fees.
+var feeScenarios = []domain.ScenarioConfig{
+	domain.ScenarioConfigOptimistic,
+	domain.ScenarioConfigRealistic,
+	domain.ScenarioConfigPessimistic,
+	domain.ScenarioConfigDegraded,
+}
+
+// generateObservedFees computes fee percentiles over all stored swap events.
+// Returns nil without a swap event store or when no event carries fee data.
+func (g *Generator) generateObservedFees(ctx context.Context) (*ObservedFeesSection, error) {
+	if g.swapEventStore == nil {
+		return nil, nil
+	}
+	minTs, maxTs, err := g.swapEventStore.GetGlobalTimeRange(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if maxTs == 0 {
+		return nil, nil
+	}
+	fees, err := metrics.FeePercentilesForWindow(ctx, g.swapEventStore, minTs, maxTs+1)
+	if err != nil {
+		return nil, err
+	}
+	if fees.Transactions == 0 {
+		return nil, nil
+	}
+
+	section := &ObservedFeesSection{
+		WindowStart:          fees.WindowStart,
+		WindowEnd:            fees.WindowEnd,
+		Transactions:         fees.Transactions,
+		MedianBaseFeeSOL:     fees.BaseFeeP50 / metrics.LamportsPerSOL,
+		MedianPriorityFeeSOL: fees.PriorityFeeP50 / metrics.LamportsPerSOL,
+		P90PriorityFeeSOL:    fees.PriorityFeeP90 / metrics.LamportsPerSOL,
+	}
+	for _, sc := range feeScenarios {
+		section.Scenarios = append(section.Scenarios, ScenarioFeeRow{
+			ScenarioID:            sc.ScenarioID,
+			AssumedFeeSOL:         sc.FeeSOL,
+			AssumedPriorityFeeSOL: sc.PriorityFeeSOL,
+		})
+	}
+	return section, nil
+}
+
+// nearestRank returns the p-th percentile (0 < p <= 1) of an ascending slice
+// by the nearest-rank method, or 0 for an empty slice.
+func nearestRank(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// generateCostComposition averages trade cost breakdowns per scenario.
+func generateCostComposition(trades []*domain.TradeRecord) []CostCompositionRow {
+	var rows []CostCompositionRow
+	for _, c := range metrics.ComputeCostComposition(trades) {
+		rows = append(rows, CostCompositionRow(c))
+	}
+	return rows
 }
 
 // generateStrategyMetrics loads aggregates and builds sorted rows.
@@ -163,6 +445,8 @@ func (g *Generator) generateStrategyMetrics(aggs []*domain.StrategyAggregate) []
 			OutcomeStddev:        agg.OutcomeStddev,
 			MaxDrawdown:          agg.MaxDrawdown,
 			MaxConsecutiveLosses: agg.MaxConsecutiveLosses,
+			DataEndExits:         agg.DataEndExits,
+			DataEndShare:         agg.DataEndShare,
 		}
 	}
 
@@ -228,6 +512,127 @@ func (g *Generator) generateSourceComparison(aggs []*domain.StrategyAggregate) [
 	return rows
 }
 
+// generateDEXComparison counts candidates per discovery DEX and picks the best
+// per-DEX aggregate under the Realistic scenario by median outcome.
+// Returns nil when no candidate carries a DEX label.
+func (g *Generator) generateDEXComparison(ctx context.Context, aggs []*domain.StrategyAggregate) ([]DEXComparisonRow, error) {
+	counts := make(map[string]int)
+	labeled := false
+	for _, source := range []domain.Source{domain.SourceNewToken, domain.SourceActiveToken} {
+		candidates, err := g.candidateStore.GetBySource(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range candidates {
+			counts[c.DEX]++
+			labeled = labeled || c.DEX != ""
+		}
+	}
+	if !labeled {
+		return nil, nil
+	}
+
+	best := make(map[string]*domain.StrategyAggregate)
+	for _, agg := range aggs {
+		if agg.ScenarioID != domain.ScenarioRealistic {
+			continue
+		}
+		_, label := metrics.SplitEntryEventType(agg.EntryEventType)
+		dex, ok := metrics.ParseDEXCohortLabel(label)
+		if !ok {
+			continue
+		}
+		if cur := best[dex]; cur == nil || betterDEXAggregate(agg, cur) {
+			best[dex] = agg
+		}
+		if _, seen := counts[dex]; !seen {
+			counts[dex] = 0
+		}
+	}
+
+	rows := make([]DEXComparisonRow, 0, len(counts))
+	for dex, n := range counts {
+		row := DEXComparisonRow{DEX: dex, Candidates: n}
+		if agg := best[dex]; agg != nil {
+			row.BestStrategy = agg.StrategyID
+			row.BestEntryType, _ = metrics.SplitEntryEventType(agg.EntryEventType)
+			row.TotalTrades = agg.TotalTrades
+			row.WinRate = agg.WinRate
+			row.Median = agg.OutcomeMedian
+		}
+		rows = append(rows, row)
+	}
+
+	// Labeled DEXes by name, unlabeled candidates last
+	sort.Slice(rows, func(i, j int) bool {
+		if (rows[i].DEX == "") != (rows[j].DEX == "") {
+			return rows[j].DEX == ""
+		}
+		return rows[i].DEX < rows[j].DEX
+	})
+	return rows, nil
+}
+
+// generateDedupComparison pairs each Realistic aggregate of a base entry type with
+// its dedup aggregate, sorted by strategy_id then entry_event_type.
+// Returns nil when there are no dedup aggregates.
+func generateDedupComparison(aggs []*domain.StrategyAggregate) []DedupComparisonRow {
+	type key struct{ strategyID, entryEventType string }
+	raw := make(map[key]*domain.StrategyAggregate)
+	dedup := make(map[key]*domain.StrategyAggregate)
+	for _, agg := range aggs {
+		if agg.ScenarioID != domain.ScenarioRealistic {
+			continue
+		}
+		base, label := metrics.SplitEntryEventType(agg.EntryEventType)
+		switch label {
+		case "":
+			raw[key{agg.StrategyID, base}] = agg
+		case metrics.DedupLabel:
+			dedup[key{agg.StrategyID, base}] = agg
+		}
+	}
+	if len(dedup) == 0 {
+		return nil
+	}
+
+	rows := make([]DedupComparisonRow, 0, len(raw))
+	for k, agg := range raw {
+		row := DedupComparisonRow{
+			StrategyID:     k.strategyID,
+			EntryEventType: k.entryEventType,
+			Trades:         agg.TotalTrades,
+			WinRate:        agg.WinRate,
+			Median:         agg.OutcomeMedian,
+		}
+		if d := dedup[k]; d != nil {
+			row.DedupTrades = d.TotalTrades
+			row.DedupWinRate = d.WinRate
+			row.DedupMedian = d.OutcomeMedian
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].StrategyID != rows[j].StrategyID {
+			return rows[i].StrategyID < rows[j].StrategyID
+		}
+		return rows[i].EntryEventType < rows[j].EntryEventType
+	})
+	return rows
+}
+
+// betterDEXAggregate orders per-DEX aggregates by median outcome, breaking ties
+// by entry_event_type then strategy_id for deterministic output.
+func betterDEXAggregate(a, b *domain.StrategyAggregate) bool {
+	if a.OutcomeMedian != b.OutcomeMedian {
+		return a.OutcomeMedian > b.OutcomeMedian
+	}
+	if a.EntryEventType != b.EntryEventType {
+		return a.EntryEventType < b.EntryEventType
+	}
+	return a.StrategyID < b.StrategyID
+}
+
 // generateScenarioSensitivity builds scenario sensitivity comparison.
 // Per REPORTING_SPEC.md: uses median (not mean) and includes all 4 scenarios.
 func (g *Generator) generateScenarioSensitivity(aggs []*domain.StrategyAggregate) []ScenarioSensitivityRow {
@@ -255,6 +660,13 @@ func (g *Generator) generateScenarioSensitivity(aggs []*domain.StrategyAggregate
 		}
 
 		// Use median instead of mean per REPORTING_SPEC.md
+		row.TotalTrades = -1
+		for _, agg := range scenarios {
+			if row.TotalTrades < 0 || agg.TotalTrades < row.TotalTrades {
+				row.TotalTrades = agg.TotalTrades
+			}
+		}
+		row.LowConfidence = row.TotalTrades < g.minScenarioTrades
 		if optimistic := scenarios[domain.ScenarioOptimistic]; optimistic != nil {
 			row.OptimisticMedian = optimistic.OutcomeMedian
 		}
@@ -305,7 +717,7 @@ func (g *Generator) generateReplayReferences(ctx context.Context, aggs []*domain
 	candidateSeen := make(map[string]struct{})
 
 	for k := range seen {
-		trades, err := g.tradeRecordStore.GetByStrategyScenario(ctx, k.StrategyID, k.ScenarioID)
+		trades, err := g.tradeRecordStore.GetByStrategyScenario(ctx, k.StrategyID, domain.VersionedScenarioID(k.ScenarioID, g.scenarioVersion))
 		if err != nil {
 			return nil, err
 		}
@@ -2,11 +2,13 @@ package reporting
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/storage/memory"
 )
 
@@ -370,6 +372,52 @@ func TestScenarioSensitivity_Correct(t *testing.T) {
 	}
 }
 
+func TestScenarioSensitivity_LowConfidence(t *testing.T) {
+	ctx := context.Background()
+	aggStore := memory.NewStrategyAggregateStore()
+	for _, agg := range []*domain.StrategyAggregate{
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", TotalTrades: 900, OutcomeMedian: 0.02},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioPessimistic, EntryEventType: "NEW_TOKEN", TotalTrades: 900, OutcomeMedian: 0.01},
+		{StrategyID: "TRAILING_STOP", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", TotalTrades: 40, OutcomeMedian: 0.30},
+		{StrategyID: "TRAILING_STOP", ScenarioID: domain.ScenarioPessimistic, EntryEventType: "NEW_TOKEN", TotalTrades: 3, OutcomeMedian: 0.20},
+	} {
+		if err := aggStore.Insert(ctx, agg); err != nil {
+			t.Fatalf("Insert aggregate failed: %v", err)
+		}
+	}
+
+	report, err := NewGenerator(memory.NewCandidateStore(), memory.NewTradeRecordStore(), aggStore).
+		WithMinScenarioTrades(30).
+		Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(report.ScenarioSensitivity) != 2 {
+		t.Fatalf("expected 2 sensitivity rows, got %+v", report.ScenarioSensitivity)
+	}
+	// The thinnest scenario decides: TRAILING_STOP's pessimistic median has 3 trades
+	if s := report.ScenarioSensitivity[0]; s.TotalTrades != 900 || s.LowConfidence {
+		t.Errorf("TIME_EXIT: expected 900 trades, confident, got %+v", s)
+	}
+	if s := report.ScenarioSensitivity[1]; s.TotalTrades != 3 || !s.LowConfidence {
+		t.Errorf("TRAILING_STOP: expected 3 trades, low confidence, got %+v", s)
+	}
+
+	lines := strings.Split(strings.TrimSpace(RenderScenarioOutcomesCSV(report.ScenarioSensitivity)), "\n")
+	want := []string{
+		"strategy_id,entry_event_type,outcome_optimistic,outcome_realistic,outcome_pessimistic,outcome_degraded,total_trades,low_confidence",
+		`"TIME_EXIT","NEW_TOKEN",0.000000,0.020000,0.010000,0.000000,900,false`,
+		`"TRAILING_STOP","NEW_TOKEN",0.000000,0.300000,0.200000,0.000000,3,true`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("scenario_outcomes.csv:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	if md := RenderMarkdown(report); !strings.Contains(md, "| 3 (low) |") {
+		t.Errorf("markdown should mark the low-confidence row:\n%s", md)
+	}
+}
+
 // TestRenderMarkdown_IntegrityErrorsWithoutSufficiencyChecks verifies that
 // integrity errors are shown even when no sufficiency checks are configured.
 // This addresses High #3 from review: data quality section was hiding errors.
@@ -436,3 +484,518 @@ func TestRenderMarkdown_NoDataQuality(t *testing.T) {
 		t.Error("Markdown should contain 'No data quality checks performed' when no checks exist")
 	}
 }
+
+func TestRenderMarkdown_LabelsExternalStrategies(t *testing.T) {
+	external := domain.ExternalStrategyID("alice", "momentum")
+	report := &Report{
+		GeneratedAt: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		StrategyMetrics: []StrategyMetricRow{
+			{StrategyID: domain.StrategyTypeTimeExit, ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN"},
+			{StrategyID: external, ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN"},
+		},
+	}
+
+	md := RenderMarkdown(report)
+
+	if !strings.Contains(md, "| "+external+" (external) |") {
+		t.Errorf("external strategy row not labeled:\n%s", md)
+	}
+	if strings.Contains(md, domain.StrategyTypeTimeExit+" (external)") {
+		t.Error("simulated strategy labeled external")
+	}
+	if !strings.Contains(md, "imported from an external simulator") {
+		t.Error("missing note on external strategies")
+	}
+}
+
+func TestRenderMarkdown_DataEndExits(t *testing.T) {
+	report := &Report{
+		GeneratedAt: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		StrategyMetrics: []StrategyMetricRow{
+			{StrategyID: domain.StrategyTypeTimeExit, ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", TotalTrades: 8, DataEndExits: 2, DataEndShare: 0.25},
+			{StrategyID: domain.StrategyTypeTrailingStop, ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", TotalTrades: 8},
+		},
+	}
+
+	md := RenderMarkdown(report)
+	if !strings.Contains(md, "| TIME_EXIT | realistic | NEW_TOKEN | 2 | 25.00% |") {
+		t.Errorf("missing DATA_END row:\n%s", md)
+	}
+	if strings.Contains(md, "| TRAILING_STOP | realistic | NEW_TOKEN | 0 |") {
+		t.Error("row without DATA_END exits listed")
+	}
+
+	csv := RenderStrategyAggregatesCSV(report.StrategyMetrics)
+	if !strings.HasSuffix(strings.SplitN(csv, "\n", 3)[1], ",2,0.250000") {
+		t.Errorf("expected DATA_END columns in CSV, got:\n%s", csv)
+	}
+
+	// No DATA_END exits: no section
+	report.StrategyMetrics = report.StrategyMetrics[1:]
+	if strings.Contains(RenderMarkdown(report), "DATA_END exits") {
+		t.Error("DATA_END section rendered without DATA_END exits")
+	}
+}
+
+func TestGenerate_DiscoveryLatencyPercentiles(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	// Ten live candidates detected 1..10s after their block time.
+	for i := int64(1); i <= 10; i++ {
+		detectedAt := 3000000 + i*1000
+		c := &domain.TokenCandidate{
+			CandidateID:  "live" + string(rune('a'+i)),
+			Source:       domain.SourceNewToken,
+			Mint:         "liveMint" + string(rune('a'+i)),
+			TxSignature:  "liveTx" + string(rune('a'+i)),
+			Slot:         200 + i,
+			DiscoveredAt: 3000000,
+			DetectedAt:   &detectedAt,
+		}
+		if err := candidateStore.Insert(ctx, c); err != nil {
+			t.Fatalf("Insert candidate failed: %v", err)
+		}
+	}
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	ds := report.DataSummary
+	// Backfilled candidates from setupTestData carry no DetectedAt and are excluded.
+	if ds.LiveDetectedCandidates != 10 {
+		t.Errorf("LiveDetectedCandidates = %d, want 10", ds.LiveDetectedCandidates)
+	}
+	if ds.DiscoveryLatencyP50Ms != 5000 || ds.DiscoveryLatencyP90Ms != 9000 || ds.DiscoveryLatencyP99Ms != 10000 {
+		t.Errorf("latency p50/p90/p99 = %d/%d/%d, want 5000/9000/10000",
+			ds.DiscoveryLatencyP50Ms, ds.DiscoveryLatencyP90Ms, ds.DiscoveryLatencyP99Ms)
+	}
+
+	md := RenderMarkdown(report)
+	for _, row := range []string{
+		"| Live-Detected Candidates | 10 |",
+		"| Discovery Latency (median) | 5.0s |",
+		"| Discovery Latency (p99) | 10.0s |",
+	} {
+		if !strings.Contains(md, row) {
+			t.Errorf("markdown missing row %q", row)
+		}
+	}
+}
+
+func TestRenderMarkdown_NoLiveCandidatesOmitsLatency(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Contains(RenderMarkdown(report), "Discovery Latency") {
+		t.Error("latency rows should be omitted without live-detected candidates")
+	}
+}
+
+func TestGenerate_CostComposition(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	trade := &domain.TradeRecord{
+		TradeID: "t4", CandidateID: "c1", StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic,
+		Outcome: 0.05, OutcomeClass: domain.OutcomeClassWin,
+		CostBreakdown: &domain.CostBreakdown{
+			EntrySlippageSOL: 0.01, ExitSlippageSOL: 0.012, NetworkFeeSOL: 0.00002, PriorityFeeSOL: 0.0002, MEVSOL: 0.0101,
+		},
+	}
+	if err := tradeStore.Insert(ctx, trade); err != nil {
+		t.Fatalf("Insert trade failed: %v", err)
+	}
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Trades from setupTestData carry no breakdown and are skipped
+	if len(report.CostComposition) != 1 {
+		t.Fatalf("expected 1 cost composition row, got %+v", report.CostComposition)
+	}
+	row := report.CostComposition[0]
+	if row.ScenarioID != domain.ScenarioRealistic || row.Trades != 1 || row.MeanMEVSOL != 0.0101 {
+		t.Errorf("unexpected row: %+v", row)
+	}
+
+	md := RenderMarkdown(report)
+	if !strings.Contains(md, "| realistic | 1 | 0.010000 | 0.012000 | 0.000020 | 0.000200 | 0.010100 |") {
+		t.Errorf("markdown missing cost composition row:\n%s", md)
+	}
+}
+
+func TestGenerate_ObservedFees(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	fee := func(v int64) *int64 { return &v }
+	swapEvents := memory.NewSwapEventStore()
+	if err := swapEvents.InsertBulk(ctx, []*domain.SwapEvent{
+		{Mint: "m1", TxSignature: "tx1", Timestamp: 1000, FeeLamports: fee(5000), PriorityFeeLamports: fee(0)},
+		{Mint: "m1", TxSignature: "tx2", Timestamp: 2000, FeeLamports: fee(55000), PriorityFeeLamports: fee(50000)},
+		{Mint: "m2", TxSignature: "tx3", Timestamp: 3000, FeeLamports: fee(105000), PriorityFeeLamports: fee(100000)},
+		{Mint: "m2", TxSignature: "tx4", Timestamp: 4000}, // ingested without telemetry
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	// Without a swap event store the section is omitted
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if report.ObservedFees != nil {
+		t.Errorf("expected no observed fees without swap event store, got %+v", report.ObservedFees)
+	}
+
+	report, err = NewGenerator(candidateStore, tradeStore, aggStore).WithSwapEventStore(swapEvents).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	f := report.ObservedFees
+	if f == nil {
+		t.Fatal("expected observed fees section")
+	}
+	if f.Transactions != 3 || f.WindowStart != 1000 || f.WindowEnd != 4001 {
+		t.Errorf("unexpected window/count: %+v", f)
+	}
+	if f.MedianBaseFeeSOL != 0.000005 || f.MedianPriorityFeeSOL != 0.00005 {
+		t.Errorf("unexpected medians: base %v priority %v", f.MedianBaseFeeSOL, f.MedianPriorityFeeSOL)
+	}
+	if len(f.Scenarios) != 4 || f.Scenarios[1].ScenarioID != domain.ScenarioRealistic {
+		t.Fatalf("expected the four predefined scenarios, got %+v", f.Scenarios)
+	}
+
+	md := RenderMarkdown(report)
+	if !strings.Contains(md, "Observed over 3 swap transactions: median base fee 0.000005, median priority fee 0.000050") {
+		t.Errorf("markdown missing observed fee summary:\n%s", md)
+	}
+	if !strings.Contains(md, "| realistic | 0.000010 | 0.000005 | 0.000100 | 0.000050 |") {
+		t.Errorf("markdown missing realistic assumption vs observed row:\n%s", md)
+	}
+}
+
+func TestGenerate_UniqueTraders(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	swapEvents := memory.NewSwapEventStore()
+	if err := swapEvents.InsertBulk(ctx, []*domain.SwapEvent{
+		// mint1 (discovered at 1000000): two traders, one of them twice
+		{Mint: "mint1", TxSignature: "a1", Timestamp: 1000100, Trader: "w1"},
+		{Mint: "mint1", TxSignature: "a2", Timestamp: 1000200, Trader: "w1"},
+		{Mint: "mint1", TxSignature: "a3", Timestamp: 1000300, Trader: "w2"},
+		{Mint: "mint1", TxSignature: "a4", Timestamp: 1000400}, // unattributed
+		// mint3 (discovered at 1500000): five traders
+		{Mint: "mint3", TxSignature: "b1", Timestamp: 1400000, Trader: "w1"},
+		{Mint: "mint3", TxSignature: "b2", Timestamp: 1400001, Trader: "w2"},
+		{Mint: "mint3", TxSignature: "b3", Timestamp: 1400002, Trader: "w3"},
+		{Mint: "mint3", TxSignature: "b4", Timestamp: 1400003, Trader: "w4"},
+		{Mint: "mint3", TxSignature: "b5", Timestamp: 1400004, Trader: "w5"},
+		// mint2: only a trade far outside the discovery window
+		{Mint: "mint2", TxSignature: "c1", Timestamp: 2000000 + 2*3600000, Trader: "w9"},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).WithSwapEventStore(swapEvents).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	ds := report.DataSummary
+	if ds.TraderCountedCandidates != 2 || ds.UniqueTradersP50 != 2 || ds.UniqueTradersP90 != 5 {
+		t.Errorf("unexpected trader counts: counted %d, p50 %d, p90 %d",
+			ds.TraderCountedCandidates, ds.UniqueTradersP50, ds.UniqueTradersP90)
+	}
+
+	md := RenderMarkdown(report)
+	if !strings.Contains(md, "| Unique Traders per Candidate (median) | 2 |") {
+		t.Errorf("markdown missing unique trader row:\n%s", md)
+	}
+}
+
+func TestGenerate_FailedTransactionRate(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	failedTx := memory.NewFailedTxStore()
+	if err := failedTx.Add(ctx, []*storage.FailedTxTally{
+		// candidates span 1000000-2000000, all within the first hour
+		{Program: "progA", Source: "ws-swap", HourStart: 0, Transactions: 150, Failed: 12},
+		{Program: "progB", Source: "rpc-swap", HourStart: 0, Transactions: 50, Failed: 8},
+		{Program: "progA", Source: "ws-swap", HourStart: storage.FailedTxHourMs, Transactions: 100, Failed: 100},
+	}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).WithFailedTxStore(failedTx).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	ds := report.DataSummary
+	if !ds.FailedTxChecked || ds.ObservedTransactions != 200 || ds.FailedTransactions != 20 || ds.FailedTxRate != 0.1 {
+		t.Errorf("unexpected failure rate: checked %v, %d/%d, rate %v",
+			ds.FailedTxChecked, ds.FailedTransactions, ds.ObservedTransactions, ds.FailedTxRate)
+	}
+
+	md := RenderMarkdown(report)
+	if !strings.Contains(md, "| On-Chain Failure Rate | 10.0% (20/200 transactions) |") {
+		t.Errorf("markdown missing failure rate row:\n%s", md)
+	}
+
+	// Without a store the row is omitted
+	report, err = NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if md := RenderMarkdown(report); strings.Contains(md, "On-Chain Failure Rate") {
+		t.Errorf("expected no failure rate row without a store:\n%s", md)
+	}
+}
+
+func TestGenerate_OriginSplit(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	swapEvents := memory.NewSwapEventStore()
+	if err := swapEvents.InsertBulk(ctx, []*domain.SwapEvent{
+		{Mint: "mint1", TxSignature: "tx1", Timestamp: 1000000, Origin: domain.OriginLiveWS},
+		{Mint: "mint1", TxSignature: "a1", Timestamp: 1000100, Origin: domain.OriginBackfill},
+		{Mint: "mint2", TxSignature: "tx2", Timestamp: 2000000, Origin: domain.OriginLiveWS},
+		{Mint: "mint3", TxSignature: "tx3", Timestamp: 1500000, Origin: domain.OriginBackfill},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).WithSwapEventStore(swapEvents).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	ds := report.DataSummary
+	if ds.LiveCandidates != 2 || ds.BackfillCandidates != 1 || ds.ReplayCandidates != 0 || ds.UnknownOriginCandidates != 0 {
+		t.Errorf("unexpected origin split: live %d, backfill %d, replay %d, unknown %d",
+			ds.LiveCandidates, ds.BackfillCandidates, ds.ReplayCandidates, ds.UnknownOriginCandidates)
+	}
+	// Per-candidate live shares: 0.5, 1, 0
+	if ds.LiveEventShareP50 != 0.5 {
+		t.Errorf("expected median live event share 0.5, got %v", ds.LiveEventShareP50)
+	}
+
+	md := RenderMarkdown(report)
+	for _, row := range []string{
+		"| Live-Ingested Candidates | 2 (66.7%) |",
+		"| Backfilled Candidates | 1 (33.3%) |",
+		"| Live Event Share per Candidate (median) | 50.0% |",
+	} {
+		if !strings.Contains(md, row) {
+			t.Errorf("markdown missing %q:\n%s", row, md)
+		}
+	}
+	if strings.Contains(md, "Replayed Candidates") || strings.Contains(md, "Unknown-Origin Candidates") {
+		t.Error("expected empty origin rows to be omitted")
+	}
+}
+
+func TestRenderTradeRecordsCSV_CostBreakdownColumns(t *testing.T) {
+	trades := []*domain.TradeRecord{
+		{TradeID: "t1", CostBreakdown: &domain.CostBreakdown{EntrySlippageSOL: 0.01, ExitSlippageSOL: 0.02, NetworkFeeSOL: 0.00002, PriorityFeeSOL: 0.0002}},
+		{TradeID: "t2"},
+	}
+
+	lines := strings.Split(strings.TrimSpace(RenderTradeRecordsCSV(trades)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d lines", len(lines))
+	}
+	for i, line := range lines {
+		if n := len(strings.Split(line, ",")); n != 31 {
+			t.Errorf("line %d: expected 31 columns, got %d", i, n)
+		}
+	}
+	if !strings.HasSuffix(lines[0], ",entry_slippage_sol,exit_slippage_sol,network_fee_sol,priority_fee_sol") {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",0.010000,0.020000,0.000020,0.000200") {
+		t.Errorf("unexpected breakdown columns: %s", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], ",,,,") {
+		t.Errorf("expected empty breakdown columns without a breakdown: %s", lines[2])
+	}
+}
+
+func TestGenerate_DEXComparison(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	// setupTestData candidates carry no DEX label: section omitted
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if report.DEXComparison != nil || strings.Contains(RenderMarkdown(report), "Discovery DEX") {
+		t.Errorf("expected no DEX comparison without labels, got %+v", report.DEXComparison)
+	}
+
+	for _, c := range []*domain.TokenCandidate{
+		{CandidateID: "p1", Source: domain.SourceNewToken, Mint: "pm1", TxSignature: "ptx1", DiscoveredAt: 1000000, DEX: domain.DEXPumpFun},
+		{CandidateID: "p2", Source: domain.SourceActiveToken, Mint: "pm2", TxSignature: "ptx2", DiscoveredAt: 1000000, DEX: domain.DEXPumpFun},
+		{CandidateID: "r1", Source: domain.SourceNewToken, Mint: "rm1", TxSignature: "rtx1", DiscoveredAt: 1000000, DEX: domain.DEXRaydium},
+	} {
+		if err := candidateStore.Insert(ctx, c); err != nil {
+			t.Fatalf("Insert candidate failed: %v", err)
+		}
+	}
+	for _, agg := range []*domain.StrategyAggregate{
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN:dex_pumpfun", TotalTrades: 4, WinRate: 0.5, OutcomeMedian: 0.10},
+		{StrategyID: "TRAILING_STOP", ScenarioID: domain.ScenarioRealistic, EntryEventType: "ACTIVE_TOKEN:dex_pumpfun", TotalTrades: 3, WinRate: 0.6667, OutcomeMedian: 0.20},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioPessimistic, EntryEventType: "NEW_TOKEN:dex_pumpfun", TotalTrades: 4, OutcomeMedian: 0.50},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN:dex_raydium", TotalTrades: 2, WinRate: 0, OutcomeMedian: -0.05},
+	} {
+		if err := aggStore.Insert(ctx, agg); err != nil {
+			t.Fatalf("Insert aggregate failed: %v", err)
+		}
+	}
+
+	report, err = NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	want := []DEXComparisonRow{
+		{DEX: domain.DEXPumpFun, Candidates: 2, BestStrategy: "TRAILING_STOP", BestEntryType: "ACTIVE_TOKEN", TotalTrades: 3, WinRate: 0.6667, Median: 0.20},
+		{DEX: domain.DEXRaydium, Candidates: 1, BestStrategy: "TIME_EXIT", BestEntryType: "NEW_TOKEN", TotalTrades: 2, Median: -0.05},
+		{DEX: "", Candidates: 3},
+	}
+	if len(report.DEXComparison) != len(want) {
+		t.Fatalf("expected %d DEX rows, got %+v", len(want), report.DEXComparison)
+	}
+	for i, row := range report.DEXComparison {
+		if row != want[i] {
+			t.Errorf("row %d: got %+v, want %+v", i, row, want[i])
+		}
+	}
+
+	md := RenderMarkdown(report)
+	for _, line := range []string{
+		"## Discovery DEX Comparison (Realistic Scenario)",
+		"| pumpfun | 2 | TRAILING_STOP | ACTIVE_TOKEN | 3 | 0.6667 | 0.2000 |",
+		"| raydium | 1 | TIME_EXIT | NEW_TOKEN | 2 | 0.0000 | -0.0500 |",
+		"| unknown | 3 | - | - | - | - | - |",
+	} {
+		if !strings.Contains(md, line) {
+			t.Errorf("markdown missing %q:\n%s", line, md)
+		}
+	}
+}
+
+func TestGenerate_DedupComparison(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if report.DedupComparison != nil || report.DataSummary.MultiCandidateMints != 0 {
+		t.Errorf("expected no dedup comparison or shared mints, got %+v, %d",
+			report.DedupComparison, report.DataSummary.MultiCandidateMints)
+	}
+
+	// mint1 is flagged ACTIVE_TOKEN after its NEW_TOKEN discovery
+	if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "c4", Source: domain.SourceActiveToken, Mint: "mint1", TxSignature: "tx4", Slot: 103, DiscoveredAt: 1200000,
+	}); err != nil {
+		t.Fatalf("Insert candidate failed: %v", err)
+	}
+	if err := aggStore.Insert(ctx, &domain.StrategyAggregate{
+		StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN:dedup",
+		TotalTrades: 2, WinRate: 0.5, OutcomeMedian: 0.025,
+	}); err != nil {
+		t.Fatalf("Insert aggregate failed: %v", err)
+	}
+
+	report, err = NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if report.DataSummary.MultiCandidateMints != 1 {
+		t.Errorf("expected 1 multi-candidate mint, got %d", report.DataSummary.MultiCandidateMints)
+	}
+
+	// ACTIVE_TOKEN has no dedup aggregate: every trade was dropped
+	want := []DedupComparisonRow{
+		{StrategyID: "TIME_EXIT", EntryEventType: "ACTIVE_TOKEN", Trades: 1, WinRate: 1.0, Median: 0.15},
+		{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN", Trades: 2, DedupTrades: 2, WinRate: 0.5, DedupWinRate: 0.5, Median: 0.025, DedupMedian: 0.025},
+	}
+	if len(report.DedupComparison) != len(want) {
+		t.Fatalf("expected %d dedup rows, got %+v", len(want), report.DedupComparison)
+	}
+	for i, row := range report.DedupComparison {
+		if row != want[i] {
+			t.Errorf("row %d: got %+v, want %+v", i, row, want[i])
+		}
+	}
+
+	md := RenderMarkdown(report)
+	for _, line := range []string{
+		"| Multi-Candidate Mints | 1 |",
+		"## Raw vs Deduplicated Trades (Realistic Scenario)",
+		"| TIME_EXIT | ACTIVE_TOKEN | 1 | 0 | 1.0000 | 0.0000 | 0.1500 | 0.0000 |",
+	} {
+		if !strings.Contains(md, line) {
+			t.Errorf("markdown missing %q:\n%s", line, md)
+		}
+	}
+}
+
+// failingTradeStore fails every trade read.
+type failingTradeStore struct {
+	storage.TradeRecordStore
+}
+
+func (failingTradeStore) GetAll(context.Context) ([]*domain.TradeRecord, error) {
+	return nil, errors.New("trades unavailable")
+}
+
+func TestGenerate_ProvenanceOnStoreFailure(t *testing.T) {
+	ctx := context.Background()
+	candidateStore, tradeStore, aggStore := setupTestData(t)
+
+	report, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(report.Provenance.Degraded()) != 0 || report.Provenance.CriticalDegraded() {
+		t.Errorf("healthy stores should not degrade: %v", report.Provenance.DegradedErrors())
+	}
+
+	report, err = NewGenerator(candidateStore, failingTradeStore{tradeStore}, aggStore).Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate should tolerate a failing trade store: %v", err)
+	}
+	summary, _ := report.Provenance.Get(SectionDataSummary)
+	if summary.Status != SourceFallback || report.DataSummary.TotalCandidates != 3 || report.DataSummary.TotalTrades != 0 {
+		t.Errorf("data summary should fall back to candidates only: %+v %+v", summary, report.DataSummary)
+	}
+	if cost, _ := report.Provenance.Get(SectionCostComposition); cost.Status != SourceMissing {
+		t.Errorf("cost composition should be missing: %+v", cost)
+	}
+	// Aggregate-backed sections are unaffected and the decision inputs stay intact
+	if report.Provenance.CriticalDegraded() || len(report.StrategyMetrics) == 0 {
+		t.Error("trade failure should not degrade decision-critical sections")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := NewGenerator(candidateStore, tradeStore, aggStore).Generate(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
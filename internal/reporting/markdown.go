@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
 )
 
 // RenderMarkdown renders report as Markdown string per REPORTING_SPEC.md.
@@ -55,6 +58,41 @@ func RenderMarkdown(r *Report) string {
 		sb.WriteString(fmt.Sprintf("| Date Range End | %s |\n", endTime.Format(time.RFC3339)))
 		sb.WriteString(fmt.Sprintf("| Duration | %.1f days |\n", durationDays))
 	}
+	sb.WriteString(fmt.Sprintf("| Multi-Candidate Mints | %d |\n", r.DataSummary.MultiCandidateMints))
+	if ds := r.DataSummary; ds.LiveDetectedCandidates > 0 {
+		sb.WriteString(fmt.Sprintf("| Live-Detected Candidates | %d |\n", ds.LiveDetectedCandidates))
+		sb.WriteString(fmt.Sprintf("| Discovery Latency (median) | %.1fs |\n", float64(ds.DiscoveryLatencyP50Ms)/1000))
+		sb.WriteString(fmt.Sprintf("| Discovery Latency (p90) | %.1fs |\n", float64(ds.DiscoveryLatencyP90Ms)/1000))
+		sb.WriteString(fmt.Sprintf("| Discovery Latency (p99) | %.1fs |\n", float64(ds.DiscoveryLatencyP99Ms)/1000))
+	}
+	if ds := r.DataSummary; ds.TraderCountedCandidates > 0 {
+		sb.WriteString(fmt.Sprintf("| Candidates With Trader Data | %d |\n", ds.TraderCountedCandidates))
+		sb.WriteString(fmt.Sprintf("| Unique Traders per Candidate (median) | %d |\n", ds.UniqueTradersP50))
+		sb.WriteString(fmt.Sprintf("| Unique Traders per Candidate (p90) | %d |\n", ds.UniqueTradersP90))
+	}
+	if ds := r.DataSummary; ds.LiveCandidates+ds.BackfillCandidates+ds.ReplayCandidates > 0 {
+		sb.WriteString(fmt.Sprintf("| Live-Ingested Candidates | %s |\n", countShare(ds.LiveCandidates, ds.TotalCandidates)))
+		sb.WriteString(fmt.Sprintf("| Backfilled Candidates | %s |\n", countShare(ds.BackfillCandidates, ds.TotalCandidates)))
+		if ds.ReplayCandidates > 0 {
+			sb.WriteString(fmt.Sprintf("| Replayed Candidates | %s |\n", countShare(ds.ReplayCandidates, ds.TotalCandidates)))
+		}
+		if ds.UnknownOriginCandidates > 0 {
+			sb.WriteString(fmt.Sprintf("| Unknown-Origin Candidates | %s |\n", countShare(ds.UnknownOriginCandidates, ds.TotalCandidates)))
+		}
+		sb.WriteString(fmt.Sprintf("| Live Event Share per Candidate (median) | %.1f%% |\n", ds.LiveEventShareP50*100))
+	}
+	if ds := r.DataSummary; ds.MetadataChecked {
+		sb.WriteString(fmt.Sprintf("| Candidates With Token Metadata | %d/%d (%.1f%%) |\n",
+			ds.MetadataCoveredCandidates, ds.MetadataCandidates, ds.MetadataCoveragePct))
+	}
+	if ds := r.DataSummary; ds.FailedTxChecked {
+		if ds.ObservedTransactions > 0 {
+			sb.WriteString(fmt.Sprintf("| On-Chain Failure Rate | %.1f%% (%d/%d transactions) |\n",
+				ds.FailedTxRate*100, ds.FailedTransactions, ds.ObservedTransactions))
+		} else {
+			sb.WriteString("| On-Chain Failure Rate | n/a (no transactions tallied) |\n")
+		}
+	}
 	sb.WriteString("\n")
 
 	// Data Quality
@@ -72,6 +110,18 @@ func RenderMarkdown(r *Report) string {
 				check.Name, check.Threshold, check.Actual, status))
 		}
 		sb.WriteString("\n")
+		if r.DataQuality.ClosedOnly {
+			sb.WriteString(ClosedOnlyNote(r.DataQuality))
+		}
+		if r.DataQuality.FinalityChecked {
+			sb.WriteString(FinalityNote(r.DataQuality))
+		}
+		if r.DataQuality.ReplaySampled {
+			sb.WriteString(ReplaySampleNote(r.DataQuality))
+		}
+		if r.DataQuality.DownsampleChecked {
+			sb.WriteString(DownsampleNote(r.DataQuality))
+		}
 
 		// Overall status
 		if r.DataQuality.AllChecksPassed {
@@ -83,6 +133,10 @@ func RenderMarkdown(r *Report) string {
 		sb.WriteString("No data quality checks performed.\n\n")
 	}
 
+	if r.DataQuality.DeletedCandidates > 0 {
+		sb.WriteString(DeletedCandidatesNote(r.DataQuality))
+	}
+
 	// Integrity errors (always shown if present, even without sufficiency checks)
 	if len(r.DataQuality.IntegrityErrors) > 0 {
 		sb.WriteString("### Integrity Errors\n\n")
@@ -99,13 +153,17 @@ func RenderMarkdown(r *Report) string {
 		sb.WriteString("|----------|----------|-------|--------|------|--------|---------|------|--------|-----|-----|-----|-----|-----|-----|--------|-------|--------|\n")
 		for _, m := range r.StrategyMetrics {
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d | %d | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f | %d |\n",
-				m.StrategyID, m.ScenarioID, m.EntryEventType,
+				strategyLabel(m.StrategyID), m.ScenarioID, m.EntryEventType,
 				m.TotalTrades, m.Wins, m.Losses, m.WinRate,
 				m.OutcomeMean, m.OutcomeMedian,
 				m.OutcomeP10, m.OutcomeP25, m.OutcomeP75, m.OutcomeP90,
 				m.OutcomeMin, m.OutcomeMax, m.OutcomeStddev,
 				m.MaxDrawdown, m.MaxConsecutiveLosses))
 		}
+		if hasExternalStrategy(r.StrategyMetrics) {
+			sb.WriteString("\nStrategies marked (external) are trades imported from an external simulator, aggregated like simulated ones.\n")
+		}
+		writeDataEndExits(&sb, r.StrategyMetrics)
 	} else {
 		sb.WriteString("No strategy metrics available.\n")
 	}
@@ -118,7 +176,7 @@ func RenderMarkdown(r *Report) string {
 		sb.WriteString("|----------|-------------|----------------|-----------|------------|---------------|----------|\n")
 		for _, c := range r.SourceComparison {
 			sb.WriteString(fmt.Sprintf("| %s | %.4f | %.4f | %.4f | %.4f | %.4f | %.4f |\n",
-				c.StrategyID,
+				strategyLabel(c.StrategyID),
 				c.NewTokenWinRate, c.ActiveTokenWinRate, c.DeltaWinRate,
 				c.NewTokenMedian, c.ActiveTokenMedian, c.DeltaMedian))
 		}
@@ -127,22 +185,126 @@ func RenderMarkdown(r *Report) string {
 	}
 	sb.WriteString("\n")
 
+	// Discovery DEX comparison is omitted when no candidate carries a DEX label
+	if len(r.DEXComparison) > 0 {
+		sb.WriteString("## Discovery DEX Comparison (Realistic Scenario)\n\n")
+		sb.WriteString("| DEX | Candidates | Best Strategy | Entry | Trades | WinRate | Median |\n")
+		sb.WriteString("|-----|------------|---------------|-------|--------|---------|--------|\n")
+		for _, d := range r.DEXComparison {
+			dex := d.DEX
+			if dex == "" {
+				dex = "unknown"
+			}
+			if d.BestStrategy == "" {
+				sb.WriteString(fmt.Sprintf("| %s | %d | - | - | - | - | - |\n", dex, d.Candidates))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %d | %.4f | %.4f |\n",
+				dex, d.Candidates, d.BestStrategy, d.BestEntryType, d.TotalTrades, d.WinRate, d.Median))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Dedup comparison is omitted unless dedup aggregates were computed
+	if len(r.DedupComparison) > 0 {
+		sb.WriteString("## Raw vs Deduplicated Trades (Realistic Scenario)\n\n")
+		sb.WriteString("Dedup drops trades overlapping an earlier-entered position of another candidate of the same mint.\n\n")
+		sb.WriteString("| Strategy | Entry | Trades | Dedup Trades | WinRate | Dedup WinRate | Median | Dedup Median |\n")
+		sb.WriteString("|----------|-------|--------|--------------|---------|---------------|--------|--------------|\n")
+		for _, d := range r.DedupComparison {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %.4f | %.4f | %.4f | %.4f |\n",
+				strategyLabel(d.StrategyID), d.EntryEventType, d.Trades, d.DedupTrades,
+				d.WinRate, d.DedupWinRate, d.Median, d.DedupMedian))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Scenario Sensitivity with median and optimistic (per REPORTING_SPEC.md)
 	sb.WriteString("## Scenario Sensitivity (Median Outcomes)\n\n")
 	if len(r.ScenarioSensitivity) > 0 {
-		sb.WriteString("| Strategy | Entry | Optimistic | Realistic | Pessimistic | Degraded | Δ% (R→P) |\n")
-		sb.WriteString("|----------|-------|------------|-----------|-------------|----------|----------|\n")
+		sb.WriteString("| Strategy | Entry | Optimistic | Realistic | Pessimistic | Degraded | Δ% (R→P) | Trades |\n")
+		sb.WriteString("|----------|-------|------------|-----------|-------------|----------|----------|--------|\n")
+		lowConfidence := 0
 		for _, s := range r.ScenarioSensitivity {
-			sb.WriteString(fmt.Sprintf("| %s | %s | %.4f | %.4f | %.4f | %.4f | %.2f%% |\n",
-				s.StrategyID, s.EntryEventType,
+			trades := fmt.Sprintf("%d", s.TotalTrades)
+			if s.LowConfidence {
+				trades += " (low)"
+				lowConfidence++
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %.4f | %.4f | %.4f | %.4f | %.2f%% | %s |\n",
+				strategyLabel(s.StrategyID), s.EntryEventType,
 				s.OptimisticMedian, s.RealisticMedian, s.PessimisticMedian, s.DegradedMedian,
-				s.DegradationPct))
+				s.DegradationPct, trades))
+		}
+		if lowConfidence > 0 {
+			sb.WriteString(fmt.Sprintf("\n%d row(s) backed by too few trades are low confidence and do not count toward the decision gate.\n", lowConfidence))
 		}
 	} else {
 		sb.WriteString("No scenario sensitivity data available.\n")
 	}
 	sb.WriteString("\n")
 
+	// Scenario matrix: every scenario as a column, flags strategies degrading past the threshold
+	sb.WriteString("## Scenario Matrix\n\n")
+	sb.WriteString(RenderScenarioMatrixMarkdown(r.ScenarioMatrix))
+	sb.WriteString("\n")
+
+	// Cost composition is omitted when no trade carries a cost breakdown
+	if len(r.CostComposition) > 0 {
+		sb.WriteString("## Cost Composition (Mean per Trade, SOL)\n\n")
+		sb.WriteString("| Scenario | Trades | Entry Slippage | Exit Slippage | Network Fee | Priority Fee | MEV |\n")
+		sb.WriteString("|----------|--------|----------------|---------------|-------------|--------------|-----|\n")
+		for _, c := range r.CostComposition {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %.6f | %.6f | %.6f | %.6f | %.6f |\n",
+				c.ScenarioID, c.Trades,
+				c.MeanEntrySlippageSOL, c.MeanExitSlippageSOL,
+				c.MeanNetworkFeeSOL, c.MeanPriorityFeeSOL, c.MeanMEVSOL))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Observed fees are omitted when no swap event carries fee telemetry
+	if f := r.ObservedFees; f != nil {
+		sb.WriteString("## Observed Fees vs Scenario Assumptions (SOL per Transaction)\n\n")
+		sb.WriteString(fmt.Sprintf("Observed over %d swap transactions: median base fee %.6f, median priority fee %.6f (p90 %.6f).\n\n",
+			f.Transactions, f.MedianBaseFeeSOL, f.MedianPriorityFeeSOL, f.P90PriorityFeeSOL))
+		sb.WriteString("| Scenario | Assumed Fee | Observed Median Fee | Assumed Priority Fee | Observed Median Priority Fee |\n")
+		sb.WriteString("|----------|-------------|---------------------|----------------------|------------------------------|\n")
+		for _, s := range f.Scenarios {
+			sb.WriteString(fmt.Sprintf("| %s | %.6f | %.6f | %.6f | %.6f |\n",
+				s.ScenarioID, s.AssumedFeeSOL, f.MedianBaseFeeSOL, s.AssumedPriorityFeeSOL, f.MedianPriorityFeeSOL))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Feature analysis is optional as well
+	if a := r.FeatureAnalysis; a != nil {
+		writeFeatureAnalysis(&sb, a)
+	}
+
+	// Charts are optional; the section is omitted when none were rendered
+	if len(r.Charts) > 0 {
+		sb.WriteString("## Top Candidate Charts\n\n")
+		if r.ChartSample != nil {
+			sb.WriteString(fmt.Sprintf("**Sampled:** top candidates picked from %d of %d candidates, drawn deterministically from the data version.\n\n",
+				r.ChartSample.Size, r.ChartSample.Population))
+		}
+		for _, c := range r.Charts {
+			sb.WriteString(fmt.Sprintf("### %s (%s, %s, outcome %.4f)\n\n", c.CandidateID, c.StrategyID, c.ScenarioID, c.Outcome))
+			sb.WriteString(fmt.Sprintf("![%s](%s)\n\n", c.CandidateID, c.Path))
+		}
+	}
+
+	// Dossier batch export is optional as well
+	if r.DossierCount > 0 {
+		sb.WriteString("## Candidate Dossiers\n\n")
+		sb.WriteString(fmt.Sprintf("%d candidate dossiers are in dossiers/.\n\n", r.DossierCount))
+		if r.DossierSample != nil {
+			sb.WriteString(fmt.Sprintf("**Sampled:** %d of %d candidates, drawn deterministically from the data version.\n\n",
+				r.DossierSample.Size, r.DossierSample.Population))
+		}
+	}
+
 	// Reproducibility (per REPORTING_SPEC.md)
 	sb.WriteString("## Reproducibility\n\n")
 	sb.WriteString("| Metadata | Value |\n")
@@ -151,12 +313,19 @@ func RenderMarkdown(r *Report) string {
 	sb.WriteString(fmt.Sprintf("| Generator Version | %s |\n", r.Reproducibility.GeneratorVersion))
 	sb.WriteString(fmt.Sprintf("| Data Version | %s |\n", r.Reproducibility.DataVersion))
 	sb.WriteString(fmt.Sprintf("| Strategy Version | %s |\n", r.Reproducibility.StrategyVersion))
+	if r.Reproducibility.ScenarioVersion > 0 {
+		sb.WriteString(fmt.Sprintf("| Scenario Version | v%d |\n", r.Reproducibility.ScenarioVersion))
+	}
 	sb.WriteString(fmt.Sprintf("| Replay Commit | %s |\n", r.Reproducibility.ReplayCommitHash))
 	if r.Reproducibility.ReplayCommand != "" {
 		sb.WriteString(fmt.Sprintf("| Replay Command | `%s` |\n", r.Reproducibility.ReplayCommand))
 	}
 	sb.WriteString("\n")
 
+	if len(r.Provenance.Sections) > 0 {
+		renderProvenance(&sb, r.Provenance)
+	}
+
 	// Decision Checklist reference
 	if r.DecisionChecklistRef != "" {
 		sb.WriteString("## Decision Checklist\n\n")
@@ -179,3 +348,110 @@ func RenderMarkdown(r *Report) string {
 
 	return sb.String()
 }
+
+// countShare renders n with its share of total, e.g. "12 (40.0%)".
+// writeFeatureAnalysis renders the ranked summary of the entry feature
+// analysis; the bins of every feature are in feature_analysis.csv.
+func writeFeatureAnalysis(sb *strings.Builder, a *metrics.FeatureAnalysis) {
+	sb.WriteString(fmt.Sprintf("## Entry Feature Analysis (%s, %s)\n\n", strategyLabel(a.StrategyID), a.ScenarioID))
+	if len(a.Features) == 0 {
+		sb.WriteString(fmt.Sprintf("None of the %d trades has entry features.\n\n", a.Trades))
+		return
+	}
+	sb.WriteString(fmt.Sprintf("%d trades, each feature split into up to %d bins at quantiles of its values (edges in feature_analysis.csv). ",
+		a.Trades, a.Bins))
+	sb.WriteString("Features are ranked by the spread of the win rate between their best and worst bin.\n\n")
+	sb.WriteString("| Rank | Feature | Trades | Missing | Bins | Win Rate Range | Win Rate Spread | Median Outcome Spread |\n")
+	sb.WriteString("|------|---------|--------|---------|------|----------------|-----------------|-----------------------|\n")
+	for _, f := range a.Features {
+		lo, hi := f.Bins[0].WinRate, f.Bins[0].WinRate
+		for _, b := range f.Bins[1:] {
+			lo, hi = min(lo, b.WinRate), max(hi, b.WinRate)
+		}
+		sb.WriteString(fmt.Sprintf("| %d | %s | %d | %d | %d | %.4f – %.4f | %.4f | %.4f |\n",
+			f.Rank, f.Feature, f.Trades, f.Missing, len(f.Bins), lo, hi, f.WinRateSpread, f.MedianSpread))
+	}
+	sb.WriteString("\nA spread over few trades per bin is noise; compare it with the trade counts in feature_analysis.csv.\n\n")
+}
+
+func countShare(n, total int) string {
+	if total == 0 {
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%d (%.1f%%)", n, float64(n)/float64(total)*100)
+}
+
+// strategyLabel returns the strategy ID as shown in report tables, marking
+// strategies imported from an external simulator.
+func strategyLabel(strategyID string) string {
+	if domain.IsExternalStrategyID(strategyID) {
+		return strategyID + " (external)"
+	}
+	return strategyID
+}
+
+// hasExternalStrategy reports whether any metric row is of an imported strategy.
+// writeDataEndExits lists the rows with DATA_END exits: trades forced out at
+// the last price point because the token's price data ended before the exit.
+func writeDataEndExits(sb *strings.Builder, rows []StrategyMetricRow) {
+	header := false
+	for _, m := range rows {
+		if m.DataEndExits == 0 {
+			continue
+		}
+		if !header {
+			sb.WriteString("\nDATA_END exits (price data ended before the exit; outcome priced at the last point):\n\n")
+			sb.WriteString("| Strategy | Scenario | Entry | DATA_END | Share |\n")
+			sb.WriteString("|----------|----------|-------|----------|-------|\n")
+			header = true
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %.2f%% |\n",
+			strategyLabel(m.StrategyID), m.ScenarioID, m.EntryEventType, m.DataEndExits, m.DataEndShare*100))
+	}
+}
+
+func hasExternalStrategy(rows []StrategyMetricRow) bool {
+	for _, m := range rows {
+		if domain.IsExternalStrategyID(m.StrategyID) {
+			return true
+		}
+	}
+	return false
+}
+
+// FinalityNote reports events still awaiting finality verification.
+func FinalityNote(dq DataQualitySection) string {
+	if dq.UnfinalizedEvents == 0 {
+		return "All events are verified at finalized commitment.\n\n"
+	}
+	return fmt.Sprintf("%d events are not yet verified at finalized commitment and may still be removed if their transactions are dropped.\n\n",
+		dq.UnfinalizedEvents)
+}
+
+// DownsampleNote reports candidates whose events were downsampled at ingestion.
+func DownsampleNote(dq DataQualitySection) string {
+	if dq.DownsampledCandidates == 0 {
+		return "No candidate had events downsampled by per-candidate ingestion caps.\n\n"
+	}
+	return fmt.Sprintf("%d candidates had events downsampled by per-candidate ingestion caps (%d events not stored); their trades are flagged with sample_every in the entry context.\n\n",
+		dq.DownsampledCandidates, dq.DroppedEvents)
+}
+
+// DeletedCandidatesNote reports soft-deleted candidates whose trades were excluded.
+func DeletedCandidatesNote(dq DataQualitySection) string {
+	return fmt.Sprintf("%d soft-deleted candidates (%d trades) are excluded from the sufficiency checks and metrics; see the candidate_deletions audit log.\n\n",
+		dq.DeletedCandidates, dq.DeletedTrades)
+}
+
+// ReplaySampleNote states that the replayability check ran on a sample.
+func ReplaySampleNote(dq DataQualitySection) string {
+	return fmt.Sprintf("**Sampled:** the replayability check replayed %d of %d candidates, drawn deterministically from the data version; the population result is extrapolated from the sample.\n\n",
+		dq.ReplaySampleSize, dq.ReplayPopulation)
+}
+
+// ClosedOnlyNote explains which candidates the sufficiency checks and metrics
+// cover when only closed candidates are decision-grade.
+func ClosedOnlyNote(dq DataQualitySection) string {
+	return fmt.Sprintf("Checks and metrics cover %d closed candidates; %d candidates still inside their observation window are excluded.\n\n",
+		dq.ClosedCandidates, dq.OpenCandidates)
+}
@@ -0,0 +1,188 @@
+package reporting
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"solana-token-lab/internal/domain"
+)
+
+// MetricsQueryTables names the ClickHouse tables metrics_queries.sql reads,
+// optionally qualified with their database ("analytics.trade_records").
+type MetricsQueryTables struct {
+	StrategyAggregates string
+	TradeRecords       string // trade_records mirror (see TradeAggregateStore)
+}
+
+// DefaultMetricsQueryTables returns the unqualified table names, which resolve
+// against the database the analyst's client selects.
+func DefaultMetricsQueryTables() MetricsQueryTables {
+	return MetricsQueryTables{
+		StrategyAggregates: "strategy_aggregates",
+		TradeRecords:       "trade_records",
+	}
+}
+
+// tableIdentifier matches a table name, optionally qualified with its database.
+var tableIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// validate rejects names that are not plain identifiers, since they are
+// rendered into the SQL as-is.
+func (t MetricsQueryTables) validate() error {
+	for _, name := range []string{t.StrategyAggregates, t.TradeRecords} {
+		if !tableIdentifier.MatchString(name) {
+			return fmt.Errorf("invalid metrics query table name %q", name)
+		}
+	}
+	return nil
+}
+
+// metricsQueriesParams are the values metricsQueriesTemplate is rendered with.
+type metricsQueriesParams struct {
+	MetricsQueryTables
+	DataVersion    string
+	PeriodStart    int64 // Unix ms, inclusive
+	PeriodEnd      int64 // Unix ms, inclusive
+	PeriodStartISO string
+	PeriodEndISO   string
+	Realistic      string
+	Pessimistic    string
+}
+
+// metricsQueriesTemplate is the ClickHouse SQL of metrics_queries.sql. Trade
+// queries are bounded to the report's data period by entry_signal_time; stored
+// aggregates have no time column and cover the whole data version.
+var metricsQueriesTemplate = template.Must(template.New("metrics_queries.sql").Parse(`-- Metrics Queries for Phase 1 Report
+-- Generated by Phase1Pipeline (ClickHouse SQL)
+-- Data version: {{.DataVersion}}
+-- Data period: {{.PeriodStartISO}} to {{.PeriodEndISO}} (entry_signal_time {{.PeriodStart}}..{{.PeriodEnd}} ms)
+
+-- 1. Strategy aggregates by scenario
+SELECT
+    strategy_id,
+    scenario_id,
+    entry_event_type,
+    total_trades,
+    win_rate,
+    outcome_median,
+    outcome_p25,
+    outcome_p75
+FROM {{.StrategyAggregates}} FINAL
+ORDER BY strategy_id, scenario_id, entry_event_type;
+
+-- 2. Top performing strategies (realistic scenario)
+SELECT
+    strategy_id,
+    entry_event_type,
+    win_rate,
+    outcome_median,
+    max_drawdown
+FROM {{.StrategyAggregates}} FINAL
+WHERE scenario_id = '{{.Realistic}}'
+ORDER BY outcome_median DESC, strategy_id, entry_event_type
+LIMIT 10;
+
+-- 3. Scenario sensitivity comparison
+SELECT
+    a.strategy_id,
+    a.entry_event_type,
+    a.outcome_median AS realistic_median,
+    b.outcome_median AS pessimistic_median,
+    (a.outcome_median - b.outcome_median) / nullIf(a.outcome_median, 0) * 100 AS degradation_pct
+FROM {{.StrategyAggregates}} AS a FINAL
+INNER JOIN {{.StrategyAggregates}} AS b FINAL ON a.strategy_id = b.strategy_id
+    AND a.entry_event_type = b.entry_event_type
+WHERE a.scenario_id = '{{.Realistic}}' AND b.scenario_id = '{{.Pessimistic}}'
+ORDER BY a.strategy_id, a.entry_event_type;
+
+-- 4. Win rate and outcome distribution from trades in the data period
+SELECT
+    strategy_id,
+    scenario_id,
+    entry_event_type,
+    count() AS total_trades,
+    countIf(outcome > 0) AS wins,
+    countIf(outcome > 0) / count() AS win_rate,
+    avg(outcome) AS outcome_mean,
+    quantileExact(0.5)(outcome) AS outcome_median,
+    quantileExact(0.10)(outcome) AS outcome_p10,
+    quantileExact(0.25)(outcome) AS outcome_p25,
+    quantileExact(0.75)(outcome) AS outcome_p75,
+    quantileExact(0.90)(outcome) AS outcome_p90,
+    min(outcome) AS outcome_min,
+    max(outcome) AS outcome_max,
+    stddevSamp(outcome) AS outcome_stddev
+FROM {{.TradeRecords}} FINAL
+WHERE entry_signal_time BETWEEN {{.PeriodStart}} AND {{.PeriodEnd}}
+GROUP BY strategy_id, scenario_id, entry_event_type
+ORDER BY strategy_id, scenario_id, entry_event_type;
+
+-- 5. DEX cohorts (per-DEX aggregates, entry_event_type "<type>:dex_<dex>")
+SELECT
+    strategy_id,
+    scenario_id,
+    entry_event_type,
+    total_trades,
+    win_rate,
+    outcome_median
+FROM {{.StrategyAggregates}} FINAL
+WHERE position(entry_event_type, ':dex_') > 0
+ORDER BY strategy_id, scenario_id, entry_event_type;
+
+-- 6. Daily outcome trend (realistic scenario)
+SELECT
+    toDate(fromUnixTimestamp64Milli(entry_signal_time)) AS day,
+    strategy_type,
+    entry_event_type,
+    count() AS trades,
+    countIf(outcome > 0) / count() AS win_rate,
+    quantileExact(0.5)(outcome) AS outcome_median
+FROM {{.TradeRecords}} FINAL
+WHERE scenario_id = '{{.Realistic}}'
+    AND entry_signal_time BETWEEN {{.PeriodStart}} AND {{.PeriodEnd}}
+GROUP BY day, strategy_type, entry_event_type
+ORDER BY day, strategy_type, entry_event_type;
+
+-- 7. Mean per-trade costs by scenario (fees and MEV, SOL)
+SELECT
+    scenario_id,
+    count() AS trades,
+    avg(entry_cost_sol) AS mean_entry_cost_sol,
+    avg(exit_cost_sol) AS mean_exit_cost_sol,
+    avg(mev_cost_sol) AS mean_mev_cost_sol,
+    avg(total_cost_sol) AS mean_total_cost_sol,
+    avg(total_cost_pct) AS mean_total_cost_pct
+FROM {{.TradeRecords}} FINAL
+WHERE entry_signal_time BETWEEN {{.PeriodStart}} AND {{.PeriodEnd}}
+GROUP BY scenario_id
+ORDER BY scenario_id;
+`))
+
+// RenderMetricsQueries renders metrics_queries.sql for report against tables:
+// ClickHouse queries reproducing the report's metrics, bounded to its data
+// period and headed by its data version.
+func RenderMetricsQueries(report *Report, tables MetricsQueryTables) (string, error) {
+	if err := tables.validate(); err != nil {
+		return "", err
+	}
+	start, end := report.DataSummary.DateRangeStart, report.DataSummary.DateRangeEnd
+	params := metricsQueriesParams{
+		MetricsQueryTables: tables,
+		DataVersion:        report.Reproducibility.DataVersion,
+		PeriodStart:        start,
+		PeriodEnd:          end,
+		PeriodStartISO:     time.UnixMilli(start).UTC().Format(time.RFC3339),
+		PeriodEndISO:       time.UnixMilli(end).UTC().Format(time.RFC3339),
+		Realistic:          domain.ScenarioRealistic,
+		Pessimistic:        domain.ScenarioPessimistic,
+	}
+
+	var sb strings.Builder
+	if err := metricsQueriesTemplate.Execute(&sb, params); err != nil {
+		return "", fmt.Errorf("render metrics queries: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,60 @@
+package reporting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMetricsQueries(t *testing.T) {
+	report := &Report{
+		DataSummary:     DataSummary{DateRangeStart: 1704067200000, DateRangeEnd: 1704153600000},
+		Reproducibility: ReproducibilityMetadata{DataVersion: "abc123"},
+	}
+	sql, err := RenderMetricsQueries(report, MetricsQueryTables{
+		StrategyAggregates: "analytics.strategy_aggregates",
+		TradeRecords:       "analytics.trade_records",
+	})
+	if err != nil {
+		t.Fatalf("RenderMetricsQueries: %v", err)
+	}
+
+	for _, want := range []string{
+		"-- Data version: abc123\n",
+		"-- Data period: 2024-01-01T00:00:00Z to 2024-01-02T00:00:00Z",
+		"FROM analytics.strategy_aggregates FINAL",
+		"FROM analytics.trade_records FINAL",
+		"entry_signal_time BETWEEN 1704067200000 AND 1704153600000",
+		"WHERE scenario_id = 'realistic'",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("metrics queries missing %q", want)
+		}
+	}
+	for _, table := range []string{"FROM strategy_aggregates", "FROM trade_records", "JOIN strategy_aggregates"} {
+		if strings.Contains(sql, table) {
+			t.Errorf("metrics queries use the unqualified %q", table)
+		}
+	}
+
+	// Deterministic for the same report
+	again, _ := RenderMetricsQueries(report, MetricsQueryTables{
+		StrategyAggregates: "analytics.strategy_aggregates",
+		TradeRecords:       "analytics.trade_records",
+	})
+	if again != sql {
+		t.Error("metrics queries differ between renders of the same report")
+	}
+}
+
+func TestRenderMetricsQueries_InvalidTable(t *testing.T) {
+	for _, name := range []string{"", "trade_records; DROP TABLE x", "a.b.c", "1table"} {
+		tables := DefaultMetricsQueryTables()
+		tables.TradeRecords = name
+		if _, err := RenderMetricsQueries(&Report{}, tables); err == nil {
+			t.Errorf("expected error for table name %q", name)
+		}
+	}
+	if _, err := RenderMetricsQueries(&Report{}, DefaultMetricsQueryTables()); err != nil {
+		t.Errorf("default tables rejected: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// PreviewNotice marks every preview as non-authoritative.
+const PreviewNotice = "PREVIEW - NOT AUTHORITATIVE: no sufficiency checks and no decision. " +
+	"REPORT_PHASE1.md from the scheduled report is the gated result."
+
+// Preview is a quick look at the latest aggregates: the executive summary and
+// strategy metrics only, without sufficiency checks or a decision.
+type Preview struct {
+	Authoritative    bool               `json:"authoritative"` // always false
+	Notice           string             `json:"notice"`
+	GeneratedAt      time.Time          `json:"generated_at"`
+	ExecutiveSummary PreviewSummary     `json:"executive_summary"`
+	StrategyMetrics  []PreviewMetricRow `json:"strategy_metrics"`
+}
+
+// PreviewSummary is the executive summary of a preview. It has no decision.
+type PreviewSummary struct {
+	BestStrategy      string  `json:"best_strategy,omitempty"`
+	BestEntryType     string  `json:"best_entry_type,omitempty"`
+	WinRateRealistic  float64 `json:"win_rate_realistic"`
+	MedianRealistic   float64 `json:"median_realistic"`
+	MedianPessimistic float64 `json:"median_pessimistic"`
+	DataPeriodStart   int64   `json:"data_period_start,omitempty"` // Unix ms
+	DataPeriodEnd     int64   `json:"data_period_end,omitempty"`   // Unix ms
+	NewTokenCount     int     `json:"new_token_count"`
+	ActiveTokenCount  int     `json:"active_token_count"`
+}
+
+// PreviewMetricRow is one strategy metrics row of a preview.
+type PreviewMetricRow struct {
+	StrategyID     string  `json:"strategy_id"`
+	ScenarioID     string  `json:"scenario_id"`
+	EntryEventType string  `json:"entry_event_type"`
+	TotalTrades    int     `json:"total_trades"`
+	WinRate        float64 `json:"win_rate"`
+	OutcomeMean    float64 `json:"outcome_mean"`
+	OutcomeMedian  float64 `json:"outcome_median"`
+}
+
+// SummarizeExecutive builds the executive summary of ds and metrics: token
+// counts, data period and the best strategy by realistic median outcome.
+// Decision is left empty for the caller.
+func SummarizeExecutive(ds DataSummary, metrics []StrategyMetricRow) ExecutiveSummary {
+	summary := ExecutiveSummary{
+		NewTokenCount:    ds.NewTokenCandidates,
+		ActiveTokenCount: ds.ActiveTokenCandidates,
+	}
+	if ds.DateRangeStart > 0 {
+		summary.DataPeriodStart = time.UnixMilli(ds.DateRangeStart).UTC()
+	}
+	if ds.DateRangeEnd > 0 {
+		summary.DataPeriodEnd = time.UnixMilli(ds.DateRangeEnd).UTC()
+	}
+
+	var best *StrategyMetricRow
+	for i := range metrics {
+		m := &metrics[i]
+		if m.ScenarioID == domain.ScenarioRealistic && (best == nil || m.OutcomeMedian > best.OutcomeMedian) {
+			best = m
+		}
+	}
+	if best == nil {
+		return summary
+	}
+	summary.BestStrategy = best.StrategyID
+	summary.BestEntryType = best.EntryEventType
+	summary.WinRateRealistic = best.WinRate
+	summary.MedianRealistic = best.OutcomeMedian
+	for _, m := range metrics {
+		if m.StrategyID == best.StrategyID && m.EntryEventType == best.EntryEventType &&
+			m.ScenarioID == domain.ScenarioPessimistic {
+			summary.MedianPessimistic = m.OutcomeMedian
+			break
+		}
+	}
+	return summary
+}
+
+// GeneratePreview builds a preview from the stored aggregates and candidates.
+// Unlike Generate it reads no trades and any store failure is returned.
+func (g *Generator) GeneratePreview(ctx context.Context) (*Preview, error) {
+	aggs, err := g.aggregateStore.Find(ctx, storage.AggregateFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("load aggregates: %w", err)
+	}
+	aggs = filterAggregateScenarioVersion(aggs, g.scenarioVersion)
+	metrics := g.generateStrategyMetrics(aggs)
+
+	ds, _, err := g.generateDataSummary(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load candidates: %w", err)
+	}
+	exec := SummarizeExecutive(*ds, metrics)
+
+	p := &Preview{
+		Notice:      PreviewNotice,
+		GeneratedAt: g.now(),
+		ExecutiveSummary: PreviewSummary{
+			BestStrategy:      exec.BestStrategy,
+			BestEntryType:     exec.BestEntryType,
+			WinRateRealistic:  exec.WinRateRealistic,
+			MedianRealistic:   exec.MedianRealistic,
+			MedianPessimistic: exec.MedianPessimistic,
+			DataPeriodStart:   ds.DateRangeStart,
+			DataPeriodEnd:     ds.DateRangeEnd,
+			NewTokenCount:     exec.NewTokenCount,
+			ActiveTokenCount:  exec.ActiveTokenCount,
+		},
+		StrategyMetrics: make([]PreviewMetricRow, len(metrics)),
+	}
+	for i, m := range metrics {
+		p.StrategyMetrics[i] = PreviewMetricRow{
+			StrategyID:     m.StrategyID,
+			ScenarioID:     m.ScenarioID,
+			EntryEventType: m.EntryEventType,
+			TotalTrades:    m.TotalTrades,
+			WinRate:        m.WinRate,
+			OutcomeMean:    m.OutcomeMean,
+			OutcomeMedian:  m.OutcomeMedian,
+		}
+	}
+	return p, nil
+}
+
+// RenderPreviewMarkdown renders a preview as REPORT_PREVIEW.md.
+func RenderPreviewMarkdown(p *Preview) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Phase 1 Report Preview\n\n")
+	sb.WriteString(fmt.Sprintf("> **%s**\n\n", p.Notice))
+	sb.WriteString(fmt.Sprintf("Generated: %s\n\n", p.GeneratedAt.Format(time.RFC3339)))
+
+	es := p.ExecutiveSummary
+	sb.WriteString("## Executive Summary\n\n")
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	if es.BestStrategy != "" {
+		sb.WriteString(fmt.Sprintf("| Best Strategy | %s (%s) |\n", es.BestStrategy, es.BestEntryType))
+	}
+	sb.WriteString(fmt.Sprintf("| Win Rate (Realistic) | %.2f%% |\n", es.WinRateRealistic*100))
+	sb.WriteString(fmt.Sprintf("| Median Outcome (Realistic) | %.4f |\n", es.MedianRealistic))
+	sb.WriteString(fmt.Sprintf("| Median Outcome (Pessimistic) | %.4f |\n", es.MedianPessimistic))
+	if es.DataPeriodStart > 0 {
+		sb.WriteString(fmt.Sprintf("| Data Period | %s to %s |\n",
+			time.UnixMilli(es.DataPeriodStart).UTC().Format(time.RFC3339),
+			time.UnixMilli(es.DataPeriodEnd).UTC().Format(time.RFC3339)))
+	}
+	sb.WriteString(fmt.Sprintf("| NEW_TOKEN Candidates | %d |\n", es.NewTokenCount))
+	sb.WriteString(fmt.Sprintf("| ACTIVE_TOKEN Candidates | %d |\n", es.ActiveTokenCount))
+	sb.WriteString("\n")
+
+	sb.WriteString("## Strategy Metrics\n\n")
+	if len(p.StrategyMetrics) == 0 {
+		sb.WriteString("No strategy aggregates yet.\n")
+		return sb.String()
+	}
+	sb.WriteString("| Strategy | Scenario | Entry Type | Trades | Win Rate | Mean | Median |\n")
+	sb.WriteString("|----------|----------|------------|--------|----------|------|--------|\n")
+	for _, m := range p.StrategyMetrics {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %.2f%% | %.4f | %.4f |\n",
+			m.StrategyID, m.ScenarioID, m.EntryEventType, m.TotalTrades, m.WinRate*100, m.OutcomeMean, m.OutcomeMedian))
+	}
+	return sb.String()
+}
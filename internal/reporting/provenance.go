@@ -0,0 +1,126 @@
+package reporting
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SourceStatus describes how a report section obtained its data.
+type SourceStatus string
+
+const (
+	// SourceOK means the section was built from its primary store.
+	SourceOK SourceStatus = "ok"
+	// SourceFallback means the primary store failed and a substitute input was used.
+	SourceFallback SourceStatus = "fallback"
+	// SourceMissing means the store failed and the section is empty.
+	SourceMissing SourceStatus = "missing"
+)
+
+// Report section names recorded in Provenance.
+const (
+	SectionDataSummary         = "data_summary"
+	SectionStrategyMetrics     = "strategy_metrics"
+	SectionScenarioSensitivity = "scenario_sensitivity"
+	SectionSourceComparison    = "source_comparison"
+	SectionDEXComparison       = "dex_comparison"
+	SectionCostComposition     = "cost_composition"
+	SectionObservedFees        = "observed_fees"
+	SectionReplayReferences    = "replay_references"
+	SectionTradeRecords        = "trade_records"
+	SectionDataVersion         = "data_version"
+	SectionCharts              = "charts"
+	SectionFeatureAnalysis     = "feature_analysis"
+)
+
+// SectionProvenance is the data source status of one report section.
+type SectionProvenance struct {
+	Section  string
+	Source   string // store the section reads, e.g. "strategy_aggregates"
+	Status   SourceStatus
+	Critical bool   // the GO/NO-GO decision depends on this section
+	Detail   string // error that caused a fallback or missing status
+}
+
+// Provenance records, per report section, whether its data was fully available.
+// Sections are kept in recording order; re-recording a section replaces it.
+type Provenance struct {
+	Sections []SectionProvenance
+}
+
+// Record sets the status of a section. A nil err records SourceOK regardless of status.
+func (p *Provenance) Record(section, source string, status SourceStatus, critical bool, err error) {
+	entry := SectionProvenance{
+		Section:  section,
+		Source:   source,
+		Status:   SourceOK,
+		Critical: critical,
+	}
+	if err != nil {
+		entry.Status = status
+		entry.Detail = err.Error()
+	}
+	for i := range p.Sections {
+		if p.Sections[i].Section == section {
+			p.Sections[i] = entry
+			return
+		}
+	}
+	p.Sections = append(p.Sections, entry)
+}
+
+// Get returns the recorded status of a section.
+func (p *Provenance) Get(section string) (SectionProvenance, bool) {
+	for _, s := range p.Sections {
+		if s.Section == section {
+			return s, true
+		}
+	}
+	return SectionProvenance{}, false
+}
+
+// Degraded returns the sections whose status is not SourceOK.
+func (p *Provenance) Degraded() []SectionProvenance {
+	var out []SectionProvenance
+	for _, s := range p.Sections {
+		if s.Status != SourceOK {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// CriticalDegraded reports whether any decision-critical section is degraded.
+func (p *Provenance) CriticalDegraded() bool {
+	for _, s := range p.Sections {
+		if s.Critical && s.Status != SourceOK {
+			return true
+		}
+	}
+	return false
+}
+
+// DegradedErrors formats degraded sections as integrity error lines.
+func (p *Provenance) DegradedErrors() []string {
+	var out []string
+	for _, s := range p.Degraded() {
+		out = append(out, fmt.Sprintf("section %s %s (%s): %s", s.Section, s.Status, s.Source, s.Detail))
+	}
+	return out
+}
+
+// renderProvenance renders the Data Provenance table.
+func renderProvenance(sb *strings.Builder, p Provenance) {
+	sb.WriteString("## Data Provenance\n\n")
+	sb.WriteString("| Section | Source | Status | Decision-Critical | Detail |\n")
+	sb.WriteString("|---------|--------|--------|-------------------|--------|\n")
+	for _, s := range p.Sections {
+		critical := "no"
+		if s.Critical {
+			critical = "yes"
+		}
+		detail := strings.ReplaceAll(s.Detail, "|", "\\|")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", s.Section, s.Source, s.Status, critical, detail))
+	}
+	sb.WriteString("\n")
+}
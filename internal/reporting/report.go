@@ -1,10 +1,19 @@
 package reporting
 
-import "time"
+import (
+	"time"
+
+	"solana-token-lab/internal/metrics"
+)
+
+// ReportSchemaVersion is the version of the published report.json schema
+// (see internal/reporting/schema). Bump it when the published shape changes.
+const ReportSchemaVersion = 2
 
 // Report represents the Phase 1 report structure.
 type Report struct {
 	// Metadata
+	SchemaVersion int // published report.json schema version
 	GeneratedAt   time.Time
 	StrategyCount int
 	ScenarioCount int
@@ -24,6 +33,31 @@ type Report struct {
 	// Comparisons
 	SourceComparison    []SourceComparisonRow    // NEW_TOKEN vs ACTIVE_TOKEN
 	ScenarioSensitivity []ScenarioSensitivityRow // optimistic vs realistic vs pessimistic vs degraded
+	ScenarioMatrix      ScenarioMatrix           // strategies × scenarios medians with degradation flags
+
+	// Candidates and best strategy per discovery DEX (empty if no candidate has a DEX label)
+	DEXComparison []DEXComparisonRow
+
+	// Raw vs deduplicated aggregates (empty without dedup aggregates)
+	DedupComparison []DedupComparisonRow
+
+	// Mean per-trade cost breakdown per scenario (empty if no trade has one)
+	CostComposition []CostCompositionRow
+
+	// Observed swap transaction fees vs scenario assumptions (nil without fee telemetry)
+	ObservedFees *ObservedFeesSection
+
+	// Entry features binned against outcomes for one strategy and scenario (nil unless enabled)
+	FeatureAnalysis *metrics.FeatureAnalysis
+
+	// Charts for top candidates of the best strategy (empty unless enabled)
+	Charts []ChartReference
+	// Set when Charts were picked from a sample of the candidates
+	ChartSample *SampleInfo
+
+	// Dossiers written to dossiers/ by the batch export, and their sample if any
+	DossierCount  int
+	DossierSample *SampleInfo
 
 	// Replay References (strategy_id, scenario_id, candidate_id)
 	ReplayReferences []ReplayReferenceRow
@@ -31,6 +65,9 @@ type Report struct {
 	// Reproducibility metadata (per REPORTING_SPEC.md)
 	Reproducibility ReproducibilityMetadata
 
+	// Data source status per section (degraded when a store failed mid-report)
+	Provenance Provenance
+
 	// Decision Checklist reference
 	DecisionChecklistRef string
 }
@@ -57,6 +94,7 @@ type ReproducibilityMetadata struct {
 	StrategyVersion   string    // git commit or semver of strategy code
 	ReplayCommitHash  string    // git commit for replay
 	ReplayCommand     string    // command to reproduce the report
+	ScenarioVersion   int       // version of the scenario definitions the trades were costed with
 }
 
 // DataQualitySection contains data sufficiency checks and integrity errors.
@@ -64,6 +102,29 @@ type DataQualitySection struct {
 	SufficiencyChecks []SufficiencyCheckRow
 	IntegrityErrors   []string
 	AllChecksPassed   bool
+
+	// Set when only candidates with a closed observation window are decision-grade.
+	ClosedOnly       bool
+	ClosedCandidates int
+	OpenCandidates   int // still observing, excluded from checks and metrics
+
+	// Set when finality verification runs: events not yet confirmed at finalized commitment.
+	FinalityChecked   bool
+	UnfinalizedEvents int
+
+	// Set when the replayability check replayed a sample of the candidates.
+	ReplaySampled    bool
+	ReplaySampleSize int
+	ReplayPopulation int
+
+	// Set when ingestion caps were checked: candidates stored downsampled.
+	DownsampleChecked     bool
+	DownsampledCandidates int
+	DroppedEvents         int64 // seen at ingestion but not stored
+
+	// Soft-deleted candidates and their trades, excluded from checks and metrics.
+	DeletedCandidates int
+	DeletedTrades     int
 }
 
 // SufficiencyCheckRow represents one sufficiency criterion.
@@ -82,6 +143,49 @@ type DataSummary struct {
 	TotalTrades           int
 	DateRangeStart        int64 // Unix ms
 	DateRangeEnd          int64 // Unix ms
+
+	// Mints with more than one candidate (e.g. NEW_TOKEN and later ACTIVE_TOKEN),
+	// whose price action the raw aggregates may count more than once.
+	MultiCandidateMints int
+
+	// Discovery latency (detection wall clock minus event block time) over
+	// live-detected candidates only; backfilled candidates have no detection time.
+	LiveDetectedCandidates int
+	DiscoveryLatencyP50Ms  int64
+	DiscoveryLatencyP90Ms  int64
+	DiscoveryLatencyP99Ms  int64
+
+	// Distinct traders (swap tx fee payers) of each candidate's mint within an
+	// hour either side of discovery, over candidates with attributed swaps.
+	// Zero without a swap event store.
+	TraderCountedCandidates int
+	UniqueTradersP50        int
+	UniqueTradersP90        int
+
+	// Candidates by the ingestion origin of their discovery event, and the
+	// median per-candidate share of live events (0-1). All zero without event
+	// stores; rows stored before origins were recorded count as unknown.
+	LiveCandidates          int
+	BackfillCandidates      int
+	ReplayCandidates        int
+	UnknownOriginCandidates int
+	LiveEventShareP50       float64
+
+	// Candidates whose mint has token metadata, over the candidates the
+	// sufficiency checks covered. Set only when a metadata store is configured.
+	MetadataChecked           bool
+	MetadataCandidates        int
+	MetadataCoveredCandidates int
+	MetadataCoveragePct       float64 // 0-100
+
+	// Transactions ingestion observed over the date range and the share that
+	// failed on chain, from the hourly failed transaction tallies; the
+	// observed counterpart of the degraded scenario. Set only when a failed
+	// transaction store is configured.
+	FailedTxChecked      bool
+	ObservedTransactions int64
+	FailedTransactions   int64
+	FailedTxRate         float64 // 0-1
 }
 
 // StrategyMetricRow represents one row in strategy metrics table.
@@ -106,6 +210,8 @@ type StrategyMetricRow struct {
 	OutcomeStddev        float64
 	MaxDrawdown          float64
 	MaxConsecutiveLosses int
+	DataEndExits         int     // trades forced out when the price data ended (DATA_END)
+	DataEndShare         float64 // DataEndExits / TotalTrades
 }
 
 // SourceComparisonRow compares NEW_TOKEN vs ACTIVE_TOKEN (Realistic scenario only per REPORTING_SPEC.md).
@@ -129,6 +235,67 @@ type ScenarioSensitivityRow struct {
 	PessimisticMedian  float64 // median outcome under pessimistic scenario
 	DegradedMedian     float64 // median outcome under degraded scenario
 	DegradationPct     float64 // (realistic - pessimistic) / realistic * 100, 0 if realistic == 0
+	TotalTrades        int     // fewest trades behind any of the scenario medians
+	LowConfidence      bool    // TotalTrades below the generator's minimum (see WithMinScenarioTrades)
+}
+
+// DEXComparisonRow summarizes candidates discovered on one DEX. The best strategy
+// is the per-DEX aggregate (see metrics.DEXCohortLabel) with the highest median
+// under the Realistic scenario; it is empty when no per-DEX aggregates exist.
+type DEXComparisonRow struct {
+	DEX           string // "" for candidates without a DEX label
+	Candidates    int
+	BestStrategy  string
+	BestEntryType string // base entry_event_type of the best aggregate
+	TotalTrades   int
+	WinRate       float64
+	Median        float64
+}
+
+// DedupComparisonRow puts a raw aggregate beside its deduplicated counterpart
+// (see metrics.DedupLabel) under the Realistic scenario. The Dedup fields are zero
+// when deduplication dropped every trade.
+type DedupComparisonRow struct {
+	StrategyID     string
+	EntryEventType string
+	Trades         int
+	DedupTrades    int
+	WinRate        float64
+	DedupWinRate   float64
+	Median         float64
+	DedupMedian    float64
+}
+
+// CostCompositionRow is the mean per-trade cost breakdown of one scenario.
+// Fees and MEV are charged in total_cost_sol; slippage is priced into the execution prices.
+type CostCompositionRow struct {
+	ScenarioID           string
+	Trades               int
+	MeanEntrySlippageSOL float64
+	MeanExitSlippageSOL  float64
+	MeanNetworkFeeSOL    float64
+	MeanPriorityFeeSOL   float64
+	MeanMEVSOL           float64
+}
+
+// ObservedFeesSection compares fees paid by ingested swap transactions with the
+// per-transaction fee assumptions of each scenario. Observed base fee is the
+// total fee minus the ComputeBudget priority fee.
+type ObservedFeesSection struct {
+	WindowStart          int64 // Unix ms, inclusive
+	WindowEnd            int64 // Unix ms, exclusive
+	Transactions         int
+	MedianBaseFeeSOL     float64
+	MedianPriorityFeeSOL float64
+	P90PriorityFeeSOL    float64
+	Scenarios            []ScenarioFeeRow
+}
+
+// ScenarioFeeRow is one scenario's per-transaction fee assumption.
+type ScenarioFeeRow struct {
+	ScenarioID            string
+	AssumedFeeSOL         float64
+	AssumedPriorityFeeSOL float64
 }
 
 // ReplayReferenceRow lists replay identifiers.
@@ -0,0 +1,202 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"solana-token-lab/internal/domain"
+)
+
+// DefaultDegradationThresholdPct flags strategies losing more than half of the
+// realistic median under the pessimistic scenario, matching the decision gate
+// stability criterion (pessimistic/realistic ratio >= 0.5).
+const DefaultDegradationThresholdPct = 50.0
+
+// DefaultMinScenarioTrades is the trade count below which a scenario sensitivity
+// row is low confidence: its medians are reported but do not satisfy the
+// decision gate.
+const DefaultMinScenarioTrades = 30
+
+// canonicalScenarioOrder is the column order of the scenario matrix.
+// Scenarios outside this list follow in alphabetical order.
+var canonicalScenarioOrder = []string{
+	domain.ScenarioOptimistic,
+	domain.ScenarioRealistic,
+	domain.ScenarioPessimistic,
+	domain.ScenarioDegraded,
+}
+
+// ScenarioMatrix pivots strategy metrics into strategies × scenarios median outcomes.
+type ScenarioMatrix struct {
+	Scenarios    []string // column order
+	ThresholdPct float64  // degradation above this flags a row
+	Rows         []ScenarioMatrixRow
+}
+
+// ScenarioMatrixRow is one (strategy_id, entry_event_type) row of the matrix.
+type ScenarioMatrixRow struct {
+	StrategyID     string
+	EntryEventType string
+	Medians        []*float64 // aligned with ScenarioMatrix.Scenarios, nil if the scenario has no aggregate
+	DegradationPct *float64   // (realistic - pessimistic) / realistic * 100, nil if either is missing or realistic == 0
+	Flagged        bool       // DegradationPct > ThresholdPct
+}
+
+// BuildScenarioMatrix pivots strategy metrics into a scenario matrix sorted by
+// (strategy_id, entry_event_type) and flags rows whose realistic→pessimistic
+// degradation exceeds thresholdPct.
+func BuildScenarioMatrix(metrics []StrategyMetricRow, thresholdPct float64) ScenarioMatrix {
+	type key struct {
+		StrategyID     string
+		EntryEventType string
+	}
+	medians := make(map[key]map[string]float64)
+	scenarioSet := make(map[string]struct{})
+
+	for _, m := range metrics {
+		k := key{StrategyID: m.StrategyID, EntryEventType: m.EntryEventType}
+		if medians[k] == nil {
+			medians[k] = make(map[string]float64)
+		}
+		medians[k][m.ScenarioID] = m.OutcomeMedian
+		scenarioSet[m.ScenarioID] = struct{}{}
+	}
+
+	matrix := ScenarioMatrix{ThresholdPct: thresholdPct}
+	for _, s := range canonicalScenarioOrder {
+		if _, ok := scenarioSet[s]; ok {
+			matrix.Scenarios = append(matrix.Scenarios, s)
+			delete(scenarioSet, s)
+		}
+	}
+	var extra []string
+	for s := range scenarioSet {
+		extra = append(extra, s)
+	}
+	sort.Strings(extra)
+	matrix.Scenarios = append(matrix.Scenarios, extra...)
+
+	for k, byScenario := range medians {
+		row := ScenarioMatrixRow{
+			StrategyID:     k.StrategyID,
+			EntryEventType: k.EntryEventType,
+			Medians:        make([]*float64, len(matrix.Scenarios)),
+		}
+		for i, s := range matrix.Scenarios {
+			if v, ok := byScenario[s]; ok {
+				row.Medians[i] = &v
+			}
+		}
+
+		realistic, hasRealistic := byScenario[domain.ScenarioRealistic]
+		pessimistic, hasPessimistic := byScenario[domain.ScenarioPessimistic]
+		if hasRealistic && hasPessimistic && realistic != 0 {
+			pct := (realistic - pessimistic) / realistic * 100
+			row.DegradationPct = &pct
+			row.Flagged = pct > thresholdPct
+		}
+
+		matrix.Rows = append(matrix.Rows, row)
+	}
+
+	sort.Slice(matrix.Rows, func(i, j int) bool {
+		if matrix.Rows[i].StrategyID != matrix.Rows[j].StrategyID {
+			return matrix.Rows[i].StrategyID < matrix.Rows[j].StrategyID
+		}
+		return matrix.Rows[i].EntryEventType < matrix.Rows[j].EntryEventType
+	})
+
+	return matrix
+}
+
+// FlaggedRows returns rows whose degradation exceeds the threshold.
+func (m ScenarioMatrix) FlaggedRows() []ScenarioMatrixRow {
+	var flagged []ScenarioMatrixRow
+	for _, r := range m.Rows {
+		if r.Flagged {
+			flagged = append(flagged, r)
+		}
+	}
+	return flagged
+}
+
+// RenderScenarioMatrixMarkdown renders the matrix as a Markdown table with one
+// median column per scenario, the degradation column and a flag column.
+func RenderScenarioMatrixMarkdown(m ScenarioMatrix) string {
+	var sb strings.Builder
+
+	if len(m.Rows) == 0 {
+		sb.WriteString("No scenario matrix data available.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Strategy | Entry |")
+	for _, s := range m.Scenarios {
+		sb.WriteString(fmt.Sprintf(" %s |", s))
+	}
+	sb.WriteString(" Δ% (R→P) | Flag |\n")
+
+	sb.WriteString("|----------|-------|")
+	for range m.Scenarios {
+		sb.WriteString("--------|")
+	}
+	sb.WriteString("----------|------|\n")
+
+	for _, r := range m.Rows {
+		sb.WriteString(fmt.Sprintf("| %s | %s |", r.StrategyID, r.EntryEventType))
+		for _, v := range r.Medians {
+			if v == nil {
+				sb.WriteString(" - |")
+			} else {
+				sb.WriteString(fmt.Sprintf(" %.4f |", *v))
+			}
+		}
+		if r.DegradationPct == nil {
+			sb.WriteString(" - |")
+		} else {
+			sb.WriteString(fmt.Sprintf(" %.2f%% |", *r.DegradationPct))
+		}
+		if r.Flagged {
+			sb.WriteString(" ⚠ |\n")
+		} else {
+			sb.WriteString(" |\n")
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\nDegradation threshold: %.2f%%. Flagged: %d of %d.\n",
+		m.ThresholdPct, len(m.FlaggedRows()), len(m.Rows)))
+
+	return sb.String()
+}
+
+// RenderScenarioMatrixCSV renders the matrix as CSV. Missing medians and
+// undefined degradation are written as empty fields.
+func RenderScenarioMatrixCSV(m ScenarioMatrix) string {
+	var sb strings.Builder
+
+	sb.WriteString("strategy_id,entry_event_type")
+	for _, s := range m.Scenarios {
+		sb.WriteString(",median_" + s)
+	}
+	sb.WriteString(",degradation_pct,flagged\n")
+
+	for _, r := range m.Rows {
+		sb.WriteString(csvQuote(r.StrategyID))
+		sb.WriteString(",")
+		sb.WriteString(csvQuote(r.EntryEventType))
+		for _, v := range r.Medians {
+			sb.WriteString(",")
+			if v != nil {
+				sb.WriteString(fmt.Sprintf("%.6f", *v))
+			}
+		}
+		sb.WriteString(",")
+		if r.DegradationPct != nil {
+			sb.WriteString(fmt.Sprintf("%.6f", *r.DegradationPct))
+		}
+		sb.WriteString(fmt.Sprintf(",%t\n", r.Flagged))
+	}
+
+	return sb.String()
+}
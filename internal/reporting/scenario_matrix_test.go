@@ -0,0 +1,66 @@
+package reporting
+
+import (
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func TestBuildScenarioMatrix_PivotAndFlag(t *testing.T) {
+	metrics := []StrategyMetricRow{
+		{StrategyID: "TRAILING_STOP", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.10},
+		{StrategyID: "TRAILING_STOP", ScenarioID: domain.ScenarioPessimistic, EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.02},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioPessimistic, EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.08},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.10},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioOptimistic, EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.12},
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "ACTIVE_TOKEN", OutcomeMedian: 0.05},
+	}
+
+	m := BuildScenarioMatrix(metrics, DefaultDegradationThresholdPct)
+
+	wantScenarios := []string{domain.ScenarioOptimistic, domain.ScenarioRealistic, domain.ScenarioPessimistic}
+	if strings.Join(m.Scenarios, ",") != strings.Join(wantScenarios, ",") {
+		t.Fatalf("expected scenarios %v, got %v", wantScenarios, m.Scenarios)
+	}
+	if len(m.Rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(m.Rows))
+	}
+
+	// Sorted by (strategy_id, entry_event_type)
+	activeRow, timeExit, trailing := m.Rows[0], m.Rows[1], m.Rows[2]
+	if activeRow.StrategyID != "TIME_EXIT" || activeRow.EntryEventType != "ACTIVE_TOKEN" || trailing.StrategyID != "TRAILING_STOP" {
+		t.Fatalf("unexpected row order: %+v", m.Rows)
+	}
+
+	// Missing scenarios stay empty, degradation undefined without pessimistic
+	if activeRow.Medians[0] != nil || activeRow.Medians[2] != nil || activeRow.DegradationPct != nil || activeRow.Flagged {
+		t.Errorf("expected only realistic median for ACTIVE_TOKEN row, got %+v", activeRow)
+	}
+
+	if timeExit.DegradationPct == nil || *timeExit.DegradationPct < 19.99 || *timeExit.DegradationPct > 20.01 || timeExit.Flagged {
+		t.Errorf("expected unflagged 20%% degradation for TIME_EXIT, got %+v", timeExit)
+	}
+	if trailing.DegradationPct == nil || *trailing.DegradationPct < 79.99 || *trailing.DegradationPct > 80.01 || !trailing.Flagged {
+		t.Errorf("expected flagged 80%% degradation for TRAILING_STOP, got %+v", trailing)
+	}
+
+	// Threshold is configurable
+	strict := BuildScenarioMatrix(metrics, 10)
+	if len(strict.FlaggedRows()) != 2 {
+		t.Errorf("expected 2 flagged rows at 10%% threshold, got %d", len(strict.FlaggedRows()))
+	}
+}
+
+func TestRenderScenarioMatrixCSV_MissingCells(t *testing.T) {
+	metrics := []StrategyMetricRow{
+		{StrategyID: "TIME_EXIT", ScenarioID: domain.ScenarioRealistic, EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.05},
+	}
+
+	got := RenderScenarioMatrixCSV(BuildScenarioMatrix(metrics, DefaultDegradationThresholdPct))
+	want := "strategy_id,entry_event_type,median_realistic,degradation_pct,flagged\n" +
+		"\"TIME_EXIT\",\"NEW_TOKEN\",0.050000,,false\n"
+	if got != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,35 @@
+package reporting
+
+import "solana-token-lab/internal/domain"
+
+// FilterScenarioVersion returns copies of the trades costed with the given version
+// of the scenario definitions, their scenario_id reduced to the bare scenario, so
+// a report over a recomputed version reads like one over the original trades.
+func FilterScenarioVersion(trades []*domain.TradeRecord, version int) []*domain.TradeRecord {
+	var out []*domain.TradeRecord
+	for _, t := range trades {
+		scenarioID, v := domain.SplitScenarioID(t.ScenarioID)
+		if v != version {
+			continue
+		}
+		c := *t
+		c.ScenarioID = scenarioID
+		out = append(out, &c)
+	}
+	return out
+}
+
+// filterAggregateScenarioVersion is FilterScenarioVersion for strategy aggregates.
+func filterAggregateScenarioVersion(aggs []*domain.StrategyAggregate, version int) []*domain.StrategyAggregate {
+	var out []*domain.StrategyAggregate
+	for _, a := range aggs {
+		scenarioID, v := domain.SplitScenarioID(a.ScenarioID)
+		if v != version {
+			continue
+		}
+		c := *a
+		c.ScenarioID = scenarioID
+		out = append(out, &c)
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package schema
+
+import "solana-token-lab/internal/reporting"
+
+// FromReport converts an internal report into the current published schema.
+func FromReport(r *reporting.Report) *Report {
+	out := &Report{
+		SchemaVersion: Version,
+		GeneratedAt:   r.GeneratedAt,
+		StrategyCount: r.StrategyCount,
+		ScenarioCount: r.ScenarioCount,
+		ExecutiveSummary: ExecutiveSummary{
+			Decision:          r.ExecutiveSummary.Decision,
+			BestStrategy:      r.ExecutiveSummary.BestStrategy,
+			BestEntryType:     r.ExecutiveSummary.BestEntryType,
+			WinRateRealistic:  r.ExecutiveSummary.WinRateRealistic,
+			MedianRealistic:   r.ExecutiveSummary.MedianRealistic,
+			MedianPessimistic: r.ExecutiveSummary.MedianPessimistic,
+			DataPeriodStart:   r.ExecutiveSummary.DataPeriodStart,
+			DataPeriodEnd:     r.ExecutiveSummary.DataPeriodEnd,
+			NewTokenCount:     r.ExecutiveSummary.NewTokenCount,
+			ActiveTokenCount:  r.ExecutiveSummary.ActiveTokenCount,
+		},
+		DataSummary: DataSummary{
+			TotalCandidates:       r.DataSummary.TotalCandidates,
+			NewTokenCandidates:    r.DataSummary.NewTokenCandidates,
+			ActiveTokenCandidates: r.DataSummary.ActiveTokenCandidates,
+			TotalTrades:           r.DataSummary.TotalTrades,
+			DateRangeStart:        r.DataSummary.DateRangeStart,
+			DateRangeEnd:          r.DataSummary.DateRangeEnd,
+		},
+		DataQuality: DataQuality{
+			IntegrityErrors: r.DataQuality.IntegrityErrors,
+			AllChecksPassed: r.DataQuality.AllChecksPassed,
+		},
+		ScenarioMatrix: ScenarioMatrix{
+			Scenarios:    r.ScenarioMatrix.Scenarios,
+			ThresholdPct: r.ScenarioMatrix.ThresholdPct,
+		},
+		Reproducibility: Reproducibility{
+			ReportTimestamp:  r.Reproducibility.ReportTimestamp,
+			GeneratorVersion: r.Reproducibility.GeneratorVersion,
+			DataVersion:      r.Reproducibility.DataVersion,
+			StrategyVersion:  r.Reproducibility.StrategyVersion,
+			ReplayCommitHash: r.Reproducibility.ReplayCommitHash,
+			ReplayCommand:    r.Reproducibility.ReplayCommand,
+		},
+		DecisionChecklistRef: r.DecisionChecklistRef,
+	}
+
+	for _, c := range r.DataQuality.SufficiencyChecks {
+		out.DataQuality.SufficiencyChecks = append(out.DataQuality.SufficiencyChecks, SufficiencyCheckRow(c))
+	}
+	for _, m := range r.StrategyMetrics {
+		out.StrategyMetrics = append(out.StrategyMetrics, StrategyMetricRow(m))
+	}
+	for _, c := range r.SourceComparison {
+		out.SourceComparison = append(out.SourceComparison, SourceComparisonRow(c))
+	}
+	for _, s := range r.ScenarioSensitivity {
+		out.ScenarioSensitivity = append(out.ScenarioSensitivity, ScenarioSensitivityRow(s))
+	}
+	for _, row := range r.ScenarioMatrix.Rows {
+		out.ScenarioMatrix.Rows = append(out.ScenarioMatrix.Rows, ScenarioMatrixRow(row))
+	}
+	for _, c := range r.Charts {
+		out.Charts = append(out.Charts, ChartReference(c))
+	}
+	for _, ref := range r.ReplayReferences {
+		out.ReplayReferences = append(out.ReplayReferences, ReplayReferenceRow(ref))
+	}
+
+	return out
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//go:generate go test -run TestJSONSchemaFileUpToDate -update
+
+// JSONSchemaFile is the committed JSON Schema generated from Report.
+const JSONSchemaFile = "report.schema.json"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema generates a JSON Schema (draft 2020-12) document for Report.
+// Object properties are emitted in sorted key order, so output is stable.
+func JSONSchema() ([]byte, error) {
+	root, err := typeSchema(reflect.TypeOf(Report{}))
+	if err != nil {
+		return nil, err
+	}
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = fmt.Sprintf("https://solana-token-lab/report.v%d.schema.json", Version)
+	root["title"] = "Phase 1 report"
+
+	// Pin the version so readers can dispatch on it
+	props := root["properties"].(map[string]interface{})
+	props["schema_version"] = map[string]interface{}{"const": Version}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// typeSchema returns the schema for a Go type used in the published structs.
+func typeSchema(t reflect.Type) (map[string]interface{}, error) {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Ptr:
+		elem, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		elem["type"] = []interface{}{elem["type"], "null"}
+		return elem, nil
+	case reflect.Slice:
+		items, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		// encoding/json writes nil slices as null
+		return map[string]interface{}{"type": []interface{}{"array", "null"}, "items": items}, nil
+	case reflect.Struct:
+		props := make(map[string]interface{})
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				return nil, fmt.Errorf("%s.%s: published fields need a json tag", t.Name(), f.Name)
+			}
+			fs, err := typeSchema(f.Type)
+			if err != nil {
+				return nil, err
+			}
+			props[name] = fs
+			required = append(required, name)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
@@ -0,0 +1,206 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedVersion is returned for report.json written by a newer schema.
+var ErrUnsupportedVersion = errors.New("unsupported report schema version")
+
+// v1FieldNames maps version 1 keys (Go field names of reporting.Report and its
+// nested structs) to their version 2 names. Names are shared across nested
+// structs, so a single table covers the whole document.
+var v1FieldNames = map[string]string{
+	// Report
+	"GeneratedAt":          "generated_at",
+	"StrategyCount":        "strategy_count",
+	"ScenarioCount":        "scenario_count",
+	"ExecutiveSummary":     "executive_summary",
+	"DataSummary":          "data_summary",
+	"DataQuality":          "data_quality",
+	"StrategyMetrics":      "strategy_metrics",
+	"SourceComparison":     "source_comparison",
+	"ScenarioSensitivity":  "scenario_sensitivity",
+	"ScenarioMatrix":       "scenario_matrix",
+	"Charts":               "charts",
+	"ReplayReferences":     "replay_references",
+	"Reproducibility":      "reproducibility",
+	"DecisionChecklistRef": "decision_checklist_ref",
+
+	// ExecutiveSummary
+	"Decision":          "decision",
+	"BestStrategy":      "best_strategy",
+	"BestEntryType":     "best_entry_type",
+	"WinRateRealistic":  "win_rate_realistic",
+	"MedianRealistic":   "median_realistic",
+	"MedianPessimistic": "median_pessimistic",
+	"DataPeriodStart":   "data_period_start",
+	"DataPeriodEnd":     "data_period_end",
+	"NewTokenCount":     "new_token_count",
+	"ActiveTokenCount":  "active_token_count",
+
+	// DataSummary (date range renamed to carry its unit)
+	"TotalCandidates":       "total_candidates",
+	"NewTokenCandidates":    "new_token_candidates",
+	"ActiveTokenCandidates": "active_token_candidates",
+	"TotalTrades":           "total_trades",
+	"DateRangeStart":        "date_range_start_ms",
+	"DateRangeEnd":          "date_range_end_ms",
+
+	// DataQualitySection, SufficiencyCheckRow
+	"SufficiencyChecks": "sufficiency_checks",
+	"IntegrityErrors":   "integrity_errors",
+	"AllChecksPassed":   "all_checks_passed",
+	"Name":              "name",
+	"Threshold":         "threshold",
+	"Actual":            "actual",
+	"Pass":              "pass",
+
+	// StrategyMetricRow
+	"StrategyID":           "strategy_id",
+	"ScenarioID":           "scenario_id",
+	"EntryEventType":       "entry_event_type",
+	"TotalTokens":          "total_tokens",
+	"Wins":                 "wins",
+	"Losses":               "losses",
+	"WinRate":              "win_rate",
+	"TokenWinRate":         "token_win_rate",
+	"OutcomeMean":          "outcome_mean",
+	"OutcomeMedian":        "outcome_median",
+	"OutcomeP10":           "outcome_p10",
+	"OutcomeP25":           "outcome_p25",
+	"OutcomeP75":           "outcome_p75",
+	"OutcomeP90":           "outcome_p90",
+	"OutcomeMin":           "outcome_min",
+	"OutcomeMax":           "outcome_max",
+	"OutcomeStddev":        "outcome_stddev",
+	"MaxDrawdown":          "max_drawdown",
+	"MaxConsecutiveLosses": "max_consecutive_losses",
+
+	// SourceComparisonRow
+	"NewTokenWinRate":    "new_token_win_rate",
+	"ActiveTokenWinRate": "active_token_win_rate",
+	"DeltaWinRate":       "delta_win_rate",
+	"NewTokenMedian":     "new_token_median",
+	"ActiveTokenMedian":  "active_token_median",
+	"DeltaMedian":        "delta_median",
+
+	// ScenarioSensitivityRow
+	"OptimisticMedian":  "optimistic_median",
+	"RealisticMedian":   "realistic_median",
+	"PessimisticMedian": "pessimistic_median",
+	"DegradedMedian":    "degraded_median",
+	"DegradationPct":    "degradation_pct",
+
+	// ScenarioMatrix, ScenarioMatrixRow
+	"Scenarios":    "scenarios",
+	"ThresholdPct": "threshold_pct",
+	"Rows":         "rows",
+	"Medians":      "medians",
+	"Flagged":      "flagged",
+
+	// ChartReference, ReplayReferenceRow
+	"CandidateID": "candidate_id",
+	"Outcome":     "outcome",
+	"Path":        "path",
+
+	// ReproducibilityMetadata
+	"ReportTimestamp":  "report_timestamp",
+	"GeneratorVersion": "generator_version",
+	"DataVersion":      "data_version",
+	"StrategyVersion":  "strategy_version",
+	"ReplayCommitHash": "replay_commit_hash",
+	"ReplayCommand":    "replay_command",
+}
+
+// ParseReport decodes report.json of the current or any previous schema version
+// into the current schema. Version 1 documents carry no version marker.
+func ParseReport(data []byte) (*Report, error) {
+	var header struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("decode report: %w", err)
+	}
+
+	version := 1
+	if header.SchemaVersion != nil {
+		version = *header.SchemaVersion
+	}
+
+	switch {
+	case version == Version:
+		var r Report
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, fmt.Errorf("decode report v%d: %w", version, err)
+		}
+		return &r, nil
+	case version == 1:
+		return parseV1(data)
+	default:
+		return nil, fmt.Errorf("%w: %d (reader supports 1..%d)", ErrUnsupportedVersion, version, Version)
+	}
+}
+
+// parseV1 renames version 1 keys to the current names and decodes the result.
+func parseV1(data []byte) (*Report, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode report v1: %w", err)
+	}
+
+	migrated, err := renameV1Keys(doc, "")
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := json.Marshal(migrated)
+	if err != nil {
+		return nil, fmt.Errorf("encode migrated report: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(buf, &r); err != nil {
+		return nil, fmt.Errorf("decode migrated report: %w", err)
+	}
+	r.SchemaVersion = Version
+	return &r, nil
+}
+
+// renameV1Keys rewrites object keys recursively. Unknown keys are rejected so a
+// field missing from the table surfaces instead of silently decoding to zero.
+func renameV1Keys(v interface{}, path string) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			// Internal structs that grew SchemaVersion before the format was versioned
+			if k == "SchemaVersion" {
+				continue
+			}
+			name, ok := v1FieldNames[k]
+			if !ok {
+				return nil, fmt.Errorf("report v1: unknown field %s%s", path, k)
+			}
+			renamed, err := renameV1Keys(child, path+k+".")
+			if err != nil {
+				return nil, err
+			}
+			out[name] = renamed
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, child := range val {
+			renamed, err := renameV1Keys(child, path)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = renamed
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
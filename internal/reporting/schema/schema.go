@@ -0,0 +1,170 @@
+// Package schema defines the published report.json format.
+//
+// The types here are the compatibility contract for tooling that reads report.json.
+// They are decoupled from reporting.Report: new internal fields do not change the
+// published shape until they are added here and Version is bumped.
+//
+// Version history:
+//   - 1: reporting.Report marshaled directly (Go field names, no version marker).
+//   - 2: snake_case field names and an explicit schema_version.
+package schema
+
+import (
+	"time"
+
+	"solana-token-lab/internal/reporting"
+)
+
+// Version is the current published schema version.
+const Version = reporting.ReportSchemaVersion
+
+// Report is the published report.json document.
+type Report struct {
+	SchemaVersion        int                      `json:"schema_version"`
+	GeneratedAt          time.Time                `json:"generated_at"`
+	StrategyCount        int                      `json:"strategy_count"`
+	ScenarioCount        int                      `json:"scenario_count"`
+	ExecutiveSummary     ExecutiveSummary         `json:"executive_summary"`
+	DataSummary          DataSummary              `json:"data_summary"`
+	DataQuality          DataQuality              `json:"data_quality"`
+	StrategyMetrics      []StrategyMetricRow      `json:"strategy_metrics"`
+	SourceComparison     []SourceComparisonRow    `json:"source_comparison"`
+	ScenarioSensitivity  []ScenarioSensitivityRow `json:"scenario_sensitivity"`
+	ScenarioMatrix       ScenarioMatrix           `json:"scenario_matrix"`
+	Charts               []ChartReference         `json:"charts"`
+	ReplayReferences     []ReplayReferenceRow     `json:"replay_references"`
+	Reproducibility      Reproducibility          `json:"reproducibility"`
+	DecisionChecklistRef string                   `json:"decision_checklist_ref"`
+}
+
+// ExecutiveSummary contains key decision metrics.
+type ExecutiveSummary struct {
+	Decision          string    `json:"decision"`
+	BestStrategy      string    `json:"best_strategy"`
+	BestEntryType     string    `json:"best_entry_type"`
+	WinRateRealistic  float64   `json:"win_rate_realistic"`
+	MedianRealistic   float64   `json:"median_realistic"`
+	MedianPessimistic float64   `json:"median_pessimistic"`
+	DataPeriodStart   time.Time `json:"data_period_start"`
+	DataPeriodEnd     time.Time `json:"data_period_end"`
+	NewTokenCount     int       `json:"new_token_count"`
+	ActiveTokenCount  int       `json:"active_token_count"`
+}
+
+// DataSummary describes the input data.
+type DataSummary struct {
+	TotalCandidates       int   `json:"total_candidates"`
+	NewTokenCandidates    int   `json:"new_token_candidates"`
+	ActiveTokenCandidates int   `json:"active_token_candidates"`
+	TotalTrades           int   `json:"total_trades"`
+	DateRangeStart        int64 `json:"date_range_start_ms"`
+	DateRangeEnd          int64 `json:"date_range_end_ms"`
+}
+
+// DataQuality contains sufficiency checks and integrity errors.
+type DataQuality struct {
+	SufficiencyChecks []SufficiencyCheckRow `json:"sufficiency_checks"`
+	IntegrityErrors   []string              `json:"integrity_errors"`
+	AllChecksPassed   bool                  `json:"all_checks_passed"`
+}
+
+// SufficiencyCheckRow is one sufficiency criterion.
+type SufficiencyCheckRow struct {
+	Name      string `json:"name"`
+	Threshold string `json:"threshold"`
+	Actual    string `json:"actual"`
+	Pass      bool   `json:"pass"`
+}
+
+// StrategyMetricRow is one (strategy, scenario, entry type) aggregate.
+type StrategyMetricRow struct {
+	StrategyID           string  `json:"strategy_id"`
+	ScenarioID           string  `json:"scenario_id"`
+	EntryEventType       string  `json:"entry_event_type"`
+	TotalTrades          int     `json:"total_trades"`
+	TotalTokens          int     `json:"total_tokens"`
+	Wins                 int     `json:"wins"`
+	Losses               int     `json:"losses"`
+	WinRate              float64 `json:"win_rate"`
+	TokenWinRate         float64 `json:"token_win_rate"`
+	OutcomeMean          float64 `json:"outcome_mean"`
+	OutcomeMedian        float64 `json:"outcome_median"`
+	OutcomeP10           float64 `json:"outcome_p10"`
+	OutcomeP25           float64 `json:"outcome_p25"`
+	OutcomeP75           float64 `json:"outcome_p75"`
+	OutcomeP90           float64 `json:"outcome_p90"`
+	OutcomeMin           float64 `json:"outcome_min"`
+	OutcomeMax           float64 `json:"outcome_max"`
+	OutcomeStddev        float64 `json:"outcome_stddev"`
+	MaxDrawdown          float64 `json:"max_drawdown"`
+	MaxConsecutiveLosses int     `json:"max_consecutive_losses"`
+	DataEndExits         int     `json:"data_end_exits"`
+	DataEndShare         float64 `json:"data_end_share"`
+}
+
+// SourceComparisonRow compares NEW_TOKEN vs ACTIVE_TOKEN under one scenario.
+type SourceComparisonRow struct {
+	StrategyID         string  `json:"strategy_id"`
+	ScenarioID         string  `json:"scenario_id"`
+	NewTokenWinRate    float64 `json:"new_token_win_rate"`
+	ActiveTokenWinRate float64 `json:"active_token_win_rate"`
+	DeltaWinRate       float64 `json:"delta_win_rate"`
+	NewTokenMedian     float64 `json:"new_token_median"`
+	ActiveTokenMedian  float64 `json:"active_token_median"`
+	DeltaMedian        float64 `json:"delta_median"`
+}
+
+// ScenarioSensitivityRow compares median outcomes across the four scenarios.
+type ScenarioSensitivityRow struct {
+	StrategyID        string  `json:"strategy_id"`
+	EntryEventType    string  `json:"entry_event_type"`
+	OptimisticMedian  float64 `json:"optimistic_median"`
+	RealisticMedian   float64 `json:"realistic_median"`
+	PessimisticMedian float64 `json:"pessimistic_median"`
+	DegradedMedian    float64 `json:"degraded_median"`
+	DegradationPct    float64 `json:"degradation_pct"`
+	TotalTrades       int     `json:"total_trades"`
+	LowConfidence     bool    `json:"low_confidence"`
+}
+
+// ScenarioMatrix is the strategies × scenarios median matrix.
+type ScenarioMatrix struct {
+	Scenarios    []string            `json:"scenarios"`
+	ThresholdPct float64             `json:"threshold_pct"`
+	Rows         []ScenarioMatrixRow `json:"rows"`
+}
+
+// ScenarioMatrixRow is one row of the scenario matrix; medians align with Scenarios.
+type ScenarioMatrixRow struct {
+	StrategyID     string     `json:"strategy_id"`
+	EntryEventType string     `json:"entry_event_type"`
+	Medians        []*float64 `json:"medians"`
+	DegradationPct *float64   `json:"degradation_pct"`
+	Flagged        bool       `json:"flagged"`
+}
+
+// ChartReference links a rendered candidate chart.
+type ChartReference struct {
+	CandidateID string  `json:"candidate_id"`
+	StrategyID  string  `json:"strategy_id"`
+	ScenarioID  string  `json:"scenario_id"`
+	Outcome     float64 `json:"outcome"`
+	Path        string  `json:"path"`
+}
+
+// ReplayReferenceRow lists replay identifiers.
+type ReplayReferenceRow struct {
+	StrategyID  string `json:"strategy_id"`
+	ScenarioID  string `json:"scenario_id"`
+	CandidateID string `json:"candidate_id"`
+}
+
+// Reproducibility contains version info for reproducing the report.
+type Reproducibility struct {
+	ReportTimestamp  time.Time `json:"report_timestamp"`
+	GeneratorVersion string    `json:"generator_version"`
+	DataVersion      string    `json:"data_version"`
+	StrategyVersion  string    `json:"strategy_version"`
+	ReplayCommitHash string    `json:"replay_commit_hash"`
+	ReplayCommand    string    `json:"replay_command"`
+}
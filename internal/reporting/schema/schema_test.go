@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/reporting"
+)
+
+var update = flag.Bool("update", false, "regenerate report.schema.json")
+
+func TestParseReport_V1Fixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/report_v1.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	r, err := ParseReport(data)
+	if err != nil {
+		t.Fatalf("ParseReport failed: %v", err)
+	}
+
+	if r.SchemaVersion != Version {
+		t.Errorf("expected migrated schema version %d, got %d", Version, r.SchemaVersion)
+	}
+	if !r.GeneratedAt.Equal(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected generated_at %v", r.GeneratedAt)
+	}
+	if r.ExecutiveSummary.Decision != "GO" || r.ExecutiveSummary.BestStrategy != "TIME_EXIT" {
+		t.Errorf("unexpected executive summary %+v", r.ExecutiveSummary)
+	}
+	// Renamed with unit suffix in v2
+	if r.DataSummary.DateRangeStart != 1704067200000 || r.DataSummary.DateRangeEnd != 1704240000000 {
+		t.Errorf("date range not migrated: %+v", r.DataSummary)
+	}
+	if len(r.DataQuality.SufficiencyChecks) != 1 || !r.DataQuality.SufficiencyChecks[0].Pass {
+		t.Errorf("sufficiency checks not migrated: %+v", r.DataQuality)
+	}
+	if len(r.StrategyMetrics) == 0 || r.StrategyMetrics[0].StrategyID != "TIME_EXIT" || r.StrategyMetrics[0].OutcomeMedian == 0 {
+		t.Errorf("strategy metrics not migrated: %+v", r.StrategyMetrics)
+	}
+	if len(r.ScenarioSensitivity) == 0 || r.ScenarioSensitivity[0].DegradationPct == 0 {
+		t.Errorf("scenario sensitivity not migrated: %+v", r.ScenarioSensitivity)
+	}
+	if len(r.ReplayReferences) != 3 || r.ReplayReferences[0].CandidateID == "" {
+		t.Errorf("replay references not migrated: %+v", r.ReplayReferences)
+	}
+	if r.Reproducibility.DataVersion != "3f1c0a9e5b7d2468ace013579bdf2468ace013579bdf2468ace013579bdf2468" {
+		t.Errorf("data version not migrated: %q", r.Reproducibility.DataVersion)
+	}
+	// Fields added after v1 stay empty
+	if len(r.Charts) != 0 || len(r.ScenarioMatrix.Rows) != 0 {
+		t.Errorf("expected no charts or matrix in v1 report")
+	}
+}
+
+func TestParseReport_CurrentRoundTrip(t *testing.T) {
+	median := 0.05
+	internal := &reporting.Report{
+		SchemaVersion: reporting.ReportSchemaVersion,
+		GeneratedAt:   time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+		StrategyCount: 1,
+		DataSummary:   reporting.DataSummary{DateRangeStart: 1000, DateRangeEnd: 2000},
+		StrategyMetrics: []reporting.StrategyMetricRow{
+			{StrategyID: "TIME_EXIT", ScenarioID: "realistic", EntryEventType: "NEW_TOKEN", OutcomeMedian: 0.05},
+		},
+		ScenarioMatrix: reporting.ScenarioMatrix{
+			Scenarios: []string{"realistic", "pessimistic"},
+			Rows: []reporting.ScenarioMatrixRow{
+				{StrategyID: "TIME_EXIT", EntryEventType: "NEW_TOKEN", Medians: []*float64{&median, nil}},
+			},
+		},
+		Charts:          []reporting.ChartReference{{CandidateID: "cand_001", Path: "charts/cand_001.svg"}},
+		Reproducibility: reporting.ReproducibilityMetadata{DataVersion: "abc"},
+	}
+
+	published := FromReport(internal)
+	data, err := json.Marshal(published)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"schema_version":2`) || !strings.Contains(string(data), `"date_range_start_ms":1000`) {
+		t.Errorf("unexpected published JSON: %s", data)
+	}
+
+	parsed, err := ParseReport(data)
+	if err != nil {
+		t.Fatalf("ParseReport failed: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, published) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", parsed, published)
+	}
+}
+
+func TestParseReport_Rejects(t *testing.T) {
+	_, err := ParseReport([]byte(`{"schema_version": 99}`))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("future version: expected ErrUnsupportedVersion, got %v", err)
+	}
+
+	_, err = ParseReport([]byte(`{"GeneratedAt": "2025-01-04T12:00:00Z", "DataSummary": {"Unexpected": 1}}`))
+	if err == nil || !strings.Contains(err.Error(), "DataSummary.Unexpected") {
+		t.Errorf("unknown v1 field: expected error naming the field, got %v", err)
+	}
+
+	if _, err := ParseReport([]byte(`not json`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestJSONSchemaFileUpToDate(t *testing.T) {
+	generated, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(JSONSchemaFile, generated, 0644); err != nil {
+			t.Fatalf("write schema: %v", err)
+		}
+		return
+	}
+
+	committed, err := os.ReadFile(JSONSchemaFile)
+	if err != nil {
+		t.Fatalf("read %s: %v", JSONSchemaFile, err)
+	}
+	if string(committed) != string(generated) {
+		t.Errorf("%s is stale; run go generate ./internal/reporting/schema", JSONSchemaFile)
+	}
+}
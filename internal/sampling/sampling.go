@@ -0,0 +1,53 @@
+// Package sampling selects deterministic subsets of candidates for checks and
+// reports that are too expensive to run over the full population.
+package sampling
+
+import (
+	"crypto/sha256"
+	"sort"
+)
+
+// Select returns n items of items chosen by SHA256(seed || 0x00 || key(item)):
+// the same seed and population always yield the same sample, a different seed
+// a different one. The sample keeps the input order. n <= 0 or n >= len(items)
+// selects the full population.
+//
+// Seed with the report's data_version so a report samples the same candidates
+// every time it is regenerated from the same data.
+func Select[T any](items []T, key func(T) string, n int, seed string) []T {
+	if !Sampled(n, len(items)) {
+		return items
+	}
+
+	type ranked struct {
+		index int
+		rank  [sha256.Size]byte
+	}
+	ranks := make([]ranked, len(items))
+	for i, item := range items {
+		ranks[i] = ranked{index: i, rank: sha256.Sum256([]byte(seed + "\x00" + key(item)))}
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		if ranks[i].rank != ranks[j].rank {
+			return string(ranks[i].rank[:]) < string(ranks[j].rank[:])
+		}
+		return ranks[i].index < ranks[j].index
+	})
+
+	chosen := make([]int, n)
+	for i := range chosen {
+		chosen[i] = ranks[i].index
+	}
+	sort.Ints(chosen)
+
+	sample := make([]T, n)
+	for i, idx := range chosen {
+		sample[i] = items[idx]
+	}
+	return sample
+}
+
+// Sampled reports whether a sample size of n selects fewer than total items.
+func Sampled(n, total int) bool {
+	return n > 0 && n < total
+}
@@ -0,0 +1,66 @@
+package sampling
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func population(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("cand-%03d", i)
+	}
+	return ids
+}
+
+func identity(s string) string { return s }
+
+func TestSelect_DeterministicForSeed(t *testing.T) {
+	ids := population(200)
+
+	first := Select(ids, identity, 20, "data-version-a")
+	second := Select(ids, identity, 20, "data-version-a")
+	if len(first) != 20 {
+		t.Fatalf("expected 20 items, got %d", len(first))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed gave different samples:\n%v\n%v", first, second)
+	}
+
+	// Input order does not matter, the sample keeps it
+	reversed := make([]string, len(ids))
+	for i, id := range ids {
+		reversed[len(ids)-1-i] = id
+	}
+	fromReversed := Select(reversed, identity, 20, "data-version-a")
+	for i, j := 0, len(fromReversed)-1; i < j; i, j = i+1, j-1 {
+		fromReversed[i], fromReversed[j] = fromReversed[j], fromReversed[i]
+	}
+	if !reflect.DeepEqual(first, fromReversed) {
+		t.Errorf("sample depends on input order:\n%v\n%v", first, fromReversed)
+	}
+}
+
+func TestSelect_SeedChangesSample(t *testing.T) {
+	ids := population(200)
+
+	a := Select(ids, identity, 20, "data-version-a")
+	b := Select(ids, identity, 20, "data-version-b")
+	if reflect.DeepEqual(a, b) {
+		t.Errorf("different seeds gave the same sample: %v", a)
+	}
+}
+
+func TestSelect_FullPopulation(t *testing.T) {
+	ids := population(10)
+
+	for _, n := range []int{0, -1, 10, 11} {
+		if got := Select(ids, identity, n, "v"); !reflect.DeepEqual(got, ids) {
+			t.Errorf("n=%d: expected full population, got %v", n, got)
+		}
+	}
+	if Sampled(0, 10) || Sampled(10, 10) || !Sampled(9, 10) {
+		t.Error("Sampled does not match Select")
+	}
+}
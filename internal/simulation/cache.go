@@ -0,0 +1,44 @@
+package simulation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"solana-token-lab/internal/domain"
+)
+
+// StrategyVersion is the version of the simulation and strategy code. Bump it
+// when a change alters the trades simulated from unchanged inputs, so trades
+// cached under older input keys are simulated again.
+const StrategyVersion = 1
+
+// InputKey returns the simulation cache key of a (candidate, strategy,
+// scenario) simulation: SHA256 of StrategyVersion, the candidate's data
+// fingerprint (see dossier.ReplayFingerprint) and the hashes of the strategy
+// and scenario configs, hex-encoded. Two simulations with the same key
+// produce the same trade.
+func InputKey(dataFingerprint string, strategyCfg domain.StrategyConfig, scenarioCfg domain.ScenarioConfig) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.Itoa(StrategyVersion)))
+	h.Write([]byte{'|'})
+	h.Write([]byte(dataFingerprint))
+	h.Write([]byte{'|'})
+	h.Write([]byte(configHash(strategyCfg)))
+	h.Write([]byte{'|'})
+	h.Write([]byte(configHash(scenarioCfg)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// configHash returns the SHA256 of a config's JSON encoding, which lists the
+// fields in declaration order and so is stable for a given config.
+func configHash(cfg interface{}) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		// Strategy and scenario configs hold only plain values and pointers to them
+		panic("simulation: encode config: " + err.Error())
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
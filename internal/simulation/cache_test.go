@@ -0,0 +1,42 @@
+package simulation
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/domain"
+)
+
+func TestInputKey(t *testing.T) {
+	hold := int64(60000)
+	strategyCfg := domain.StrategyConfig{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: &hold}
+	scenarioCfg := domain.ScenarioConfig{ScenarioID: domain.ScenarioRealistic, DelayMs: 500, SlippagePct: 1}
+	key := InputKey("fp", strategyCfg, scenarioCfg)
+
+	if len(key) != 64 {
+		t.Fatalf("expected a hex SHA256, got %q", key)
+	}
+	sameHold := int64(60000)
+	same := strategyCfg
+	same.HoldDurationMs = &sameHold
+	if got := InputKey("fp", same, scenarioCfg); got != key {
+		t.Errorf("equal configs through different pointers: expected %s, got %s", key, got)
+	}
+
+	longerHold := int64(120000)
+	changedStrategy := strategyCfg
+	changedStrategy.HoldDurationMs = &longerHold
+	changedScenario := scenarioCfg
+	changedScenario.SlippagePct = 2
+	for name, other := range map[string]string{
+		"data":     InputKey("fp2", strategyCfg, scenarioCfg),
+		"strategy": InputKey("fp", changedStrategy, scenarioCfg),
+		"scenario": InputKey("fp", strategyCfg, changedScenario),
+		"sandwich": InputKey("fp", strategyCfg, domain.ScenarioConfig{
+			ScenarioID: scenarioCfg.ScenarioID, DelayMs: 500, SlippagePct: 1, Sandwich: &domain.SandwichModel{MaxProbability: 0.1},
+		}),
+	} {
+		if other == key {
+			t.Errorf("a %s change should change the key", name)
+		}
+	}
+}
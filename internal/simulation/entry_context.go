@@ -0,0 +1,46 @@
+package simulation
+
+import (
+	"solana-token-lab/internal/domain"
+)
+
+// entryContextWindowMs is the look-back window for volume and swap rate.
+const entryContextWindowMs int64 = 3600000
+
+// ComputeEntryContext summarizes the market before an entry signal.
+// prices must be ordered by timestamp; points after signalTime are ignored.
+// liquidity is the pool liquidity at the signal, as used for the trade's EntryLiquidity.
+func ComputeEntryContext(signalTime int64, prices []*domain.PriceTimeseriesPoint, liquidity *float64) *domain.EntryContext {
+	ec := &domain.EntryContext{Liquidity: liquidity}
+
+	windowStart := signalTime - entryContextWindowMs
+	swapsInWindow := 0
+	firstSeen := int64(-1)
+
+	for _, p := range prices {
+		if p.TimestampMs > signalTime {
+			break
+		}
+		if firstSeen < 0 {
+			firstSeen = p.TimestampMs
+		}
+		ec.SwapsPrior += p.SwapCount
+		if p.TimestampMs > windowStart {
+			ec.Volume1h += p.Volume
+			swapsInWindow += p.SwapCount
+		}
+	}
+	if firstSeen < 0 {
+		return ec
+	}
+	ec.TokenAgeMs = signalTime - firstSeen
+
+	// A token younger than the window is rated over its lifetime (at least one minute)
+	windowMs := entryContextWindowMs
+	if ec.TokenAgeMs < windowMs {
+		windowMs = max(ec.TokenAgeMs, 60000)
+	}
+	ec.SwapsPerMinute = float64(swapsInWindow) / (float64(windowMs) / 60000)
+
+	return ec
+}
@@ -0,0 +1,126 @@
+package simulation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestComputeEntryContext_HandBuiltSeries(t *testing.T) {
+	const signal = int64(10_000_000)
+	liq := 5000.0
+
+	prices := []*domain.PriceTimeseriesPoint{
+		{TimestampMs: signal - 7_200_000, Price: 1.0, Volume: 100, SwapCount: 4}, // first swap, outside 1h window
+		{TimestampMs: signal - 3_600_000, Price: 1.1, Volume: 50, SwapCount: 2},  // exactly 1h before: excluded
+		{TimestampMs: signal - 1_800_000, Price: 1.2, Volume: 30, SwapCount: 3},
+		{TimestampMs: signal, Price: 1.3, Volume: 20, SwapCount: 3},
+		{TimestampMs: signal + 1000, Price: 9.9, Volume: 1e6, SwapCount: 100}, // future: ignored
+	}
+
+	got := ComputeEntryContext(signal, prices, &liq)
+	want := &domain.EntryContext{
+		Liquidity:      &liq,
+		Volume1h:       50,
+		SwapsPerMinute: 0.1, // 6 swaps / 60 minutes
+		SwapsPrior:     12,
+		TokenAgeMs:     7_200_000,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeEntryContext() = %+v, want %+v", got, want)
+	}
+}
+
+func TestComputeEntryContext_YoungToken(t *testing.T) {
+	const signal = int64(1_000_000)
+	prices := []*domain.PriceTimeseriesPoint{
+		{TimestampMs: signal - 300_000, Volume: 10, SwapCount: 5},
+		{TimestampMs: signal, Volume: 5, SwapCount: 5},
+	}
+
+	got := ComputeEntryContext(signal, prices, nil)
+	if got.TokenAgeMs != 300_000 {
+		t.Errorf("TokenAgeMs = %d, want 300000", got.TokenAgeMs)
+	}
+	// 10 swaps over a 5-minute life
+	if got.SwapsPerMinute != 2 {
+		t.Errorf("SwapsPerMinute = %v, want 2", got.SwapsPerMinute)
+	}
+	if got.Liquidity != nil {
+		t.Errorf("Liquidity = %v, want nil", *got.Liquidity)
+	}
+
+	// A signal on the first swap is rated over the one-minute floor
+	first := ComputeEntryContext(signal-300_000, prices, nil)
+	if first.TokenAgeMs != 0 || first.SwapsPrior != 5 || first.SwapsPerMinute != 5 {
+		t.Errorf("first-swap context = %+v, want age 0, 5 prior swaps, 5/min", first)
+	}
+}
+
+func TestComputeEntryContext_NoPriorPoints(t *testing.T) {
+	prices := []*domain.PriceTimeseriesPoint{{TimestampMs: 2000, Volume: 1, SwapCount: 1}}
+
+	got := ComputeEntryContext(1000, prices, nil)
+	if !reflect.DeepEqual(got, &domain.EntryContext{}) {
+		t.Errorf("expected zero context, got %+v", got)
+	}
+}
+
+func TestRunner_Run_AttachesEntryContext(t *testing.T) {
+	ctx := context.Background()
+	candidateID := "ctx-candidate"
+
+	candidateStore := memory.NewCandidateStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+
+	if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID:  candidateID,
+		Source:       domain.SourceNewToken,
+		Mint:         "mint1",
+		TxSignature:  "tx1",
+		Slot:         100,
+		DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("Insert candidate failed: %v", err)
+	}
+	prices := makePriceTimeseries(candidateID, []float64{1.0, 1.1, 1.2, 1.15}, 1000000, 30000)
+	for _, p := range prices {
+		p.Volume = 10
+		p.SwapCount = 1
+	}
+	if err := priceStore.InsertBulk(ctx, prices); err != nil {
+		t.Fatalf("Insert prices failed: %v", err)
+	}
+	if err := liqStore.InsertBulk(ctx, makeLiquidityTimeseries(candidateID, []float64{1000, 950}, 1000000, 60000)); err != nil {
+		t.Fatalf("Insert liquidity failed: %v", err)
+	}
+
+	runner := NewRunner(RunnerOptions{
+		CandidateStore:       candidateStore,
+		PriceTimeseriesStore: priceStore,
+		LiqTimeseriesStore:   liqStore,
+	})
+	trade, err := runner.Run(ctx, candidateID, domain.StrategyConfig{
+		StrategyType:   domain.StrategyTypeTimeExit,
+		EntryEventType: "NEW_TOKEN",
+		HoldDurationMs: ptrInt64(60000),
+	}, domain.ScenarioConfigRealistic)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Signal is the first swap: only it is visible to the context
+	want := &domain.EntryContext{
+		Liquidity:      trade.EntryLiquidity,
+		Volume1h:       10,
+		SwapsPerMinute: 1,
+		SwapsPrior:     1,
+	}
+	if !reflect.DeepEqual(trade.EntryContext, want) {
+		t.Errorf("EntryContext = %+v, want %+v", trade.EntryContext, want)
+	}
+}
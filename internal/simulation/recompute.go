@@ -0,0 +1,47 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/strategy"
+)
+
+// ErrScenarioVersion is returned when a cost recomputation would not produce a newer scenario version.
+var ErrScenarioVersion = errors.New("target scenario version must be newer than the source version")
+
+// RecomputeCosts re-costs the trades simulated with version from of the scenario
+// definitions under the current definitions, returning new trades tagged with
+// version to (see domain.VersionedScenarioID). Only execution prices, costs and
+// outcomes change: entry and exit signals are taken from the stored trades, so
+// no time series are read. Trades of other versions are skipped and the inputs
+// are not modified. Results are ordered by the original trade_id.
+// scenarios resolves a bare scenario_id; trades with an unknown scenario are an error.
+func RecomputeCosts(trades []*domain.TradeRecord, from, to int, scenarios func(scenarioID string) (domain.ScenarioConfig, bool)) ([]*domain.TradeRecord, error) {
+	if to <= from || to <= domain.BaseScenarioVersion {
+		return nil, fmt.Errorf("%w: %d -> %d", ErrScenarioVersion, from, to)
+	}
+
+	sorted := make([]*domain.TradeRecord, 0, len(trades))
+	for _, t := range trades {
+		if _, version := domain.SplitScenarioID(t.ScenarioID); version == from {
+			sorted = append(sorted, t)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TradeID < sorted[j].TradeID
+	})
+
+	out := make([]*domain.TradeRecord, 0, len(sorted))
+	for _, t := range sorted {
+		scenarioID, _ := domain.SplitScenarioID(t.ScenarioID)
+		scenario, ok := scenarios(scenarioID)
+		if !ok {
+			return nil, fmt.Errorf("trade %s: unknown scenario %q", t.TradeID, scenarioID)
+		}
+		out = append(out, strategy.RecostTrade(t, scenario, domain.VersionedScenarioID(scenarioID, to)))
+	}
+	return out, nil
+}
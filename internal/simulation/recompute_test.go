@@ -0,0 +1,146 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func TestRecomputeCosts_PreservesOriginals(t *testing.T) {
+	ctx := context.Background()
+	runner, tradeStore := newWhatIfRunner(t, "c1", "c2")
+
+	cfg := domain.StrategyConfig{
+		StrategyType:   domain.StrategyTypeTimeExit,
+		EntryEventType: "NEW_TOKEN",
+		HoldDurationMs: ptrInt64(600000),
+	}
+	for _, id := range []string{"c1", "c2"} {
+		if _, err := runner.Run(ctx, id, cfg, domain.ScenarioConfigRealistic); err != nil {
+			t.Fatalf("Run %s: %v", id, err)
+		}
+	}
+	originals, err := tradeStore.GetAll(ctx)
+	if err != nil || len(originals) != 2 {
+		t.Fatalf("expected 2 stored trades, got %d (%v)", len(originals), err)
+	}
+	before := make(map[string]domain.TradeRecord)
+	for _, o := range originals {
+		before[o.TradeID] = *o
+	}
+
+	// The realistic scenario's slippage doubles
+	changed := domain.ScenarioConfigRealistic
+	changed.SlippagePct = 4.0
+	scenarios := func(id string) (domain.ScenarioConfig, bool) {
+		return changed, id == domain.ScenarioRealistic
+	}
+
+	recomputed, err := RecomputeCosts(originals, domain.BaseScenarioVersion, 2, scenarios)
+	if err != nil {
+		t.Fatalf("RecomputeCosts: %v", err)
+	}
+	if err := tradeStore.InsertBulk(ctx, recomputed); err != nil {
+		t.Fatalf("InsertBulk recomputed: %v", err)
+	}
+
+	for _, r := range recomputed {
+		if r.ScenarioID != "realistic@v2" {
+			t.Errorf("expected versioned scenario, got %q", r.ScenarioID)
+		}
+		if _, clash := before[r.TradeID]; clash {
+			t.Errorf("recomputed trade %s reuses an original trade_id", r.TradeID)
+		}
+		want := r.EntrySignalPrice * (1 + changed.SlippagePct/200)
+		if math.Abs(r.EntryActualPrice-want) > 1e-12 {
+			t.Errorf("entry price %v, want %v", r.EntryActualPrice, want)
+		}
+	}
+
+	// Originals are untouched in the store and the recomputed outcomes are worse
+	for id, orig := range before {
+		stored, err := tradeStore.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID %s: %v", id, err)
+		}
+		if stored.ScenarioID != domain.ScenarioRealistic || stored.EntryActualPrice != orig.EntryActualPrice ||
+			stored.Outcome != orig.Outcome {
+			t.Errorf("original trade %s changed: %+v", id, stored)
+		}
+	}
+	for i, r := range recomputed {
+		var orig domain.TradeRecord
+		for _, o := range before {
+			if o.CandidateID == r.CandidateID {
+				orig = o
+			}
+		}
+		if r.Outcome >= orig.Outcome || r.ExitSignalPrice != orig.ExitSignalPrice || r.ExitReason != orig.ExitReason {
+			t.Errorf("recomputed[%d] should keep signals and lose more to slippage: %+v vs %+v", i, r, orig)
+		}
+	}
+
+	// Aggregates for the new version sit next to the original ones
+	aggStore := memory.NewStrategyAggregateStore()
+	agg := metrics.NewAggregator(tradeStore, aggStore, runner.candidateStore)
+	v1, err := agg.ComputeAndStore(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic, "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("aggregate v1: %v", err)
+	}
+	v2, err := agg.ComputeAndStore(ctx, domain.StrategyTypeTimeExit, "realistic@v2", "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("aggregate v2: %v", err)
+	}
+	if v1.TotalTrades != 2 || v2.TotalTrades != 2 || v2.OutcomeMean >= v1.OutcomeMean {
+		t.Errorf("unexpected aggregates: v1=%+v v2=%+v", v1, v2)
+	}
+	if v2.OutcomeRealistic == nil {
+		t.Error("versioned realistic aggregate should set OutcomeRealistic")
+	}
+
+	// Trades of other versions in the input are skipped
+	again, err := RecomputeCosts(append(originals, recomputed...), domain.BaseScenarioVersion, 2, scenarios)
+	if err != nil {
+		t.Fatalf("RecomputeCosts again: %v", err)
+	}
+	if len(again) != 2 {
+		t.Errorf("only base-version trades should be recomputed, got %d", len(again))
+	}
+}
+
+func TestRecomputeCosts_RejectsSameVersion(t *testing.T) {
+	scenarios := func(string) (domain.ScenarioConfig, bool) { return domain.ScenarioConfigRealistic, true }
+	if _, err := RecomputeCosts(nil, 2, 2, scenarios); !errors.Is(err, ErrScenarioVersion) {
+		t.Errorf("expected ErrScenarioVersion, got %v", err)
+	}
+	if _, err := RecomputeCosts(nil, domain.BaseScenarioVersion, domain.BaseScenarioVersion, scenarios); !errors.Is(err, ErrScenarioVersion) {
+		t.Errorf("expected ErrScenarioVersion, got %v", err)
+	}
+}
+
+func TestSplitScenarioID(t *testing.T) {
+	tests := []struct {
+		id      string
+		base    string
+		version int
+	}{
+		{"realistic", "realistic", 1},
+		{"realistic@v2", "realistic", 2},
+		{"pessimistic@v10", "pessimistic", 10},
+		{"realistic@vx", "realistic@vx", 1},
+	}
+	for _, tt := range tests {
+		base, version := domain.SplitScenarioID(tt.id)
+		if base != tt.base || version != tt.version {
+			t.Errorf("SplitScenarioID(%q) = %q, %d; want %q, %d", tt.id, base, version, tt.base, tt.version)
+		}
+		if got := domain.VersionedScenarioID(base, version); got != tt.id {
+			t.Errorf("VersionedScenarioID(%q, %d) = %q, want %q", base, version, got, tt.id)
+		}
+	}
+}
@@ -3,9 +3,11 @@ package simulation
 import (
 	"context"
 	"errors"
+	"sort"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/lookup"
+	"solana-token-lab/internal/normalization"
 	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/strategy"
 )
@@ -21,35 +23,79 @@ type Runner struct {
 	priceTimeseriesStore storage.PriceTimeseriesStore
 	liqTimeseriesStore   storage.LiquidityTimeseriesStore
 	tradeRecordStore     storage.TradeRecordStore
+	swapStore            storage.SwapStore
+	liquidityEventStore  storage.LiquidityEventStore
+	eventCountStore      storage.EventCountStore // optional
+	fromRaw              bool
+	windowMarginMs       int64        // > 0 enables windowed loading
+	onLoad               LoadRecorder // optional
+	newStrategy          StrategyFactory
 }
 
+// StrategyFactory builds the strategy simulated for a config.
+type StrategyFactory func(cfg domain.StrategyConfig) (strategy.Strategy, error)
+
 // RunnerOptions contains configuration for creating a Runner.
 type RunnerOptions struct {
 	CandidateStore       storage.CandidateStore
 	PriceTimeseriesStore storage.PriceTimeseriesStore
 	LiqTimeseriesStore   storage.LiquidityTimeseriesStore
 	TradeRecordStore     storage.TradeRecordStore
+
+	// FromRaw builds time series in memory from SwapStore and LiquidityEventStore
+	// instead of reading PriceTimeseriesStore and LiqTimeseriesStore.
+	FromRaw             bool
+	SwapStore           storage.SwapStore
+	LiquidityEventStore storage.LiquidityEventStore
+
+	// WindowMarginMs > 0 loads time series only up to the strategy's holding horizon
+	// plus this margin (see DefaultWindowMarginMs) instead of the candidate's whole
+	// history. Trades are identical to a full load: windows that could change the
+	// result are reloaded whole. Strategies without a horizon always load everything.
+	// Ignored with FromRaw.
+	WindowMarginMs int64
+
+	// OnLoad is notified of every time series load (optional).
+	OnLoad LoadRecorder
+
+	// EventCountStore flags trades of candidates whose events were downsampled at
+	// ingestion (see domain.EntryContext.SampleEvery). Optional.
+	EventCountStore storage.EventCountStore
+
+	// StrategyFactory builds the strategy of each config (default: strategy.FromConfig).
+	StrategyFactory StrategyFactory
 }
 
 // NewRunner creates a simulation runner.
 func NewRunner(opts RunnerOptions) *Runner {
+	newStrategy := opts.StrategyFactory
+	if newStrategy == nil {
+		newStrategy = strategy.FromConfig
+	}
 	return &Runner{
 		candidateStore:       opts.CandidateStore,
 		priceTimeseriesStore: opts.PriceTimeseriesStore,
 		liqTimeseriesStore:   opts.LiqTimeseriesStore,
 		tradeRecordStore:     opts.TradeRecordStore,
+		swapStore:            opts.SwapStore,
+		liquidityEventStore:  opts.LiquidityEventStore,
+		eventCountStore:      opts.EventCountStore,
+		fromRaw:              opts.FromRaw,
+		windowMarginMs:       opts.WindowMarginMs,
+		onLoad:               opts.OnLoad,
+		newStrategy:          newStrategy,
 	}
 }
 
 // Run executes a simulation for a candidate with strategy and scenario.
 // Steps:
 //  1. Load candidate by ID
-//  2. Build strategy via strategy.FromConfig(cfg)
+//  2. Build strategy via strategy.FromConfig(cfg), or RunnerOptions.StrategyFactory
 //  3. Validate candidate.Source matches cfg.EntryEventType
-//  4. Load price/liquidity time series
+//  4. Load price/liquidity time series (or build them from raw events), windowed if enabled
 //  5. Compute entry signal values per REPLAY_PROTOCOL.md
 //  6. Build StrategyInput
-//  7. Execute strategy
+//  7. Execute strategy and attach the entry context snapshot, flagged if the events were downsampled
 //  8. Persist TradeRecord
 func (r *Runner) Run(ctx context.Context, candidateID string, cfg domain.StrategyConfig, scenario domain.ScenarioConfig) (*domain.TradeRecord, error) {
 	// 1. Load candidate by ID
@@ -59,7 +105,7 @@ func (r *Runner) Run(ctx context.Context, candidateID string, cfg domain.Strateg
 	}
 
 	// 2. Build strategy via factory
-	strat, err := strategy.FromConfig(cfg)
+	strat, err := r.newStrategy(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -70,18 +116,13 @@ func (r *Runner) Run(ctx context.Context, candidateID string, cfg domain.Strateg
 	}
 
 	// 4. Load price/liquidity time series
-	prices, err := r.priceTimeseriesStore.GetByCandidateID(ctx, candidateID)
-	if err != nil {
-		return nil, err
-	}
-
-	liquidity, err := r.liqTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	entrySignalTime := candidate.DiscoveredAt
+	prices, liquidity, err := r.loadTimeseries(ctx, candidateID, entrySignalTime, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// 5. Compute entry signal values per REPLAY_PROTOCOL.md
-	entrySignalTime := candidate.DiscoveredAt
 	entrySignalPrice, err := lookup.PriceAt(entrySignalTime, prices)
 	if err != nil {
 		return nil, err
@@ -112,6 +153,10 @@ func (r *Runner) Run(ctx context.Context, candidateID string, cfg domain.Strateg
 	if err != nil {
 		return nil, err
 	}
+	trade.EntryContext = ComputeEntryContext(entrySignalTime, prices, entryLiquidity)
+	if err := r.flagDownsampled(ctx, candidateID, trade.EntryContext); err != nil {
+		return nil, err
+	}
 
 	// 8. Persist TradeRecord
 	if r.tradeRecordStore != nil {
@@ -123,6 +168,81 @@ func (r *Runner) Run(ctx context.Context, candidateID string, cfg domain.Strateg
 	return trade, nil
 }
 
+// flagDownsampled sets ec.SampleEvery when ingestion dropped events of the candidate.
+func (r *Runner) flagDownsampled(ctx context.Context, candidateID string, ec *domain.EntryContext) error {
+	if r.eventCountStore == nil {
+		return nil
+	}
+	counts, err := r.eventCountStore.GetByCandidateID(ctx, candidateID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if counts.Downsampled() {
+		ec.SampleEvery = counts.SampleEvery
+	}
+	return nil
+}
+
+// loadTimeseries returns the candidate's price and liquidity time series ordered by timestamp,
+// as far as needed to simulate cfg from entrySignalTime.
+func (r *Runner) loadTimeseries(ctx context.Context, candidateID string, entrySignalTime int64, cfg domain.StrategyConfig) ([]*domain.PriceTimeseriesPoint, []*domain.LiquidityTimeseriesPoint, error) {
+	if r.fromRaw {
+		return r.buildTimeseries(ctx, candidateID)
+	}
+	if horizon := holdHorizonMs(cfg); r.windowMarginMs > 0 && horizon > 0 {
+		return r.loadWindow(ctx, candidateID, entrySignalTime, cfg, horizon)
+	}
+
+	prices, err := r.priceTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	liquidity, err := r.liqTimeseriesStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.recordLoad(LoadFull, len(prices)+len(liquidity))
+	return prices, liquidity, nil
+}
+
+// buildTimeseries generates time series from raw swaps and liquidity events with the
+// same functions normalization uses, so results match the store-backed path.
+func (r *Runner) buildTimeseries(ctx context.Context, candidateID string) ([]*domain.PriceTimeseriesPoint, []*domain.LiquidityTimeseriesPoint, error) {
+	swaps, err := r.swapStore.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var events []*domain.LiquidityEvent
+	if r.liquidityEventStore != nil {
+		events, err = r.liquidityEventStore.GetByCandidateID(ctx, candidateID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	normalization.SortSwaps(swaps)
+	normalization.SortLiquidityEvents(events)
+
+	prices := normalization.GeneratePriceTimeseries(swaps)
+	liquidity := normalization.GenerateLiquidityTimeseries(events)
+
+	// Timeseries stores return points ordered by timestamp, not by slot
+	sort.SliceStable(prices, func(i, j int) bool {
+		return prices[i].TimestampMs < prices[j].TimestampMs
+	})
+	sort.SliceStable(liquidity, func(i, j int) bool {
+		return liquidity[i].TimestampMs < liquidity[j].TimestampMs
+	})
+
+	return prices, liquidity, nil
+}
+
 // sourceMatches checks if candidate source matches entry event type.
 func sourceMatches(candidateSource domain.Source, entryEventType string) bool {
 	switch entryEventType {
@@ -3,10 +3,12 @@ package simulation
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/lookup"
+	"solana-token-lab/internal/normalization"
 	"solana-token-lab/internal/storage"
 	"solana-token-lab/internal/storage/memory"
 )
@@ -222,6 +224,58 @@ func TestRunner_Run_StoresTradeRecord(t *testing.T) {
 	}
 }
 
+func TestRunner_Run_FlagsDownsampledCandidates(t *testing.T) {
+	ctx := context.Background()
+
+	candidateStore := memory.NewCandidateStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+	counts := memory.NewEventCountStore()
+
+	for _, id := range []string{"capped", "complete", "uncounted"} {
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Source: domain.SourceNewToken, Mint: "mint-" + id, TxSignature: "tx-" + id, DiscoveredAt: 1000000,
+		}); err != nil {
+			t.Fatalf("Insert candidate failed: %v", err)
+		}
+		if err := priceStore.InsertBulk(ctx, makePriceTimeseries(id, []float64{1.0, 1.1, 1.2}, 1000000, 30000)); err != nil {
+			t.Fatalf("Insert prices failed: %v", err)
+		}
+		if err := liqStore.InsertBulk(ctx, makeLiquidityTimeseries(id, []float64{1000, 1100, 1200}, 1000000, 30000)); err != nil {
+			t.Fatalf("Insert liquidity failed: %v", err)
+		}
+	}
+	if err := counts.Upsert(ctx, &domain.CandidateEventCounts{CandidateID: "capped", SwapsSeen: 50, SwapsStored: 20, SampleEvery: 5}); err != nil {
+		t.Fatalf("Upsert counts failed: %v", err)
+	}
+	if err := counts.Upsert(ctx, &domain.CandidateEventCounts{CandidateID: "complete", SwapsSeen: 20, SwapsStored: 20, SampleEvery: 5}); err != nil {
+		t.Fatalf("Upsert counts failed: %v", err)
+	}
+
+	runner := NewRunner(RunnerOptions{
+		CandidateStore:       candidateStore,
+		PriceTimeseriesStore: priceStore,
+		LiqTimeseriesStore:   liqStore,
+		EventCountStore:      counts,
+	})
+	cfg := domain.StrategyConfig{
+		StrategyType:   domain.StrategyTypeTimeExit,
+		EntryEventType: "NEW_TOKEN",
+		HoldDurationMs: ptrInt64(60000),
+	}
+
+	want := map[string]int64{"capped": 5, "complete": 0, "uncounted": 0}
+	for id, sampleEvery := range want {
+		trade, err := runner.Run(ctx, id, cfg, domain.ScenarioConfigRealistic)
+		if err != nil {
+			t.Fatalf("Run %s failed: %v", id, err)
+		}
+		if trade.EntryContext.SampleEvery != sampleEvery {
+			t.Errorf("%s: expected SampleEvery %d, got %d", id, sampleEvery, trade.EntryContext.SampleEvery)
+		}
+	}
+}
+
 func TestRunner_Run_CandidateNotFound(t *testing.T) {
 	ctx := context.Background()
 
@@ -287,3 +341,94 @@ func TestRunner_Run_NoPriceData(t *testing.T) {
 		t.Errorf("expected ErrNoPriceData, got %v", err)
 	}
 }
+
+func ptrFloat64(f float64) *float64 {
+	return &f
+}
+
+func TestRunner_Run_FromRawMatchesStoreBacked(t *testing.T) {
+	ctx := context.Background()
+	candidateID := "test-candidate-raw"
+
+	candidateStore := memory.NewCandidateStore()
+	swapStore := memory.NewSwapStore()
+	liqEventStore := memory.NewLiquidityEventStore()
+
+	if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID:  candidateID,
+		Source:       domain.SourceNewToken,
+		Mint:         "mint1",
+		TxSignature:  "tx0",
+		Slot:         100,
+		DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("Insert candidate failed: %v", err)
+	}
+
+	// Swaps inserted out of order, with two swaps sharing a timestamp
+	swaps := []*domain.Swap{
+		{CandidateID: candidateID, TxSignature: "tx3", Slot: 103, Timestamp: 1060000, Side: domain.SwapSideSell, AmountOut: 5, Price: 1.3},
+		{CandidateID: candidateID, TxSignature: "tx1", Slot: 101, Timestamp: 1000000, Side: domain.SwapSideBuy, AmountOut: 10, Price: 1.0},
+		{CandidateID: candidateID, TxSignature: "tx2", EventIndex: 1, Slot: 102, Timestamp: 1030000, Side: domain.SwapSideBuy, AmountOut: 3, Price: 1.25},
+		{CandidateID: candidateID, TxSignature: "tx2", Slot: 102, Timestamp: 1030000, Side: domain.SwapSideBuy, AmountOut: 2, Price: 1.2},
+		{CandidateID: candidateID, TxSignature: "tx4", Slot: 104, Timestamp: 1090000, Side: domain.SwapSideSell, AmountOut: 7, Price: 1.1},
+		{CandidateID: candidateID, TxSignature: "tx5", Slot: 105, Timestamp: 1120000, Side: domain.SwapSideSell, AmountOut: 9, Price: 0.9},
+	}
+	if err := swapStore.InsertBulk(ctx, swaps); err != nil {
+		t.Fatalf("Insert swaps failed: %v", err)
+	}
+
+	events := []*domain.LiquidityEvent{
+		{CandidateID: candidateID, Mint: "mint1", TxSignature: "tx2", Slot: 102, Timestamp: 1030000, EventType: domain.LiquidityEventRemove, LiquidityAfter: 900},
+		{CandidateID: candidateID, Mint: "mint1", TxSignature: "tx1", Slot: 101, Timestamp: 1000000, EventType: domain.LiquidityEventAdd, LiquidityAfter: 1000},
+		{CandidateID: candidateID, Mint: "mint1", TxSignature: "tx4", Slot: 104, Timestamp: 1090000, EventType: domain.LiquidityEventRemove, LiquidityAfter: 500},
+	}
+	if err := liqEventStore.InsertBulk(ctx, events); err != nil {
+		t.Fatalf("Insert liquidity events failed: %v", err)
+	}
+
+	// Store-backed path: normalize into timeseries stores first
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+	normalizer := normalization.NewRunner(swapStore, liqEventStore, priceStore, liqStore,
+		memory.NewVolumeTimeseriesStore(), memory.NewDerivedFeatureStore())
+	if err := normalizer.NormalizeCandidate(ctx, candidateID); err != nil {
+		t.Fatalf("NormalizeCandidate failed: %v", err)
+	}
+
+	storeRunner := NewRunner(RunnerOptions{
+		CandidateStore:       candidateStore,
+		PriceTimeseriesStore: priceStore,
+		LiqTimeseriesStore:   liqStore,
+	})
+	rawRunner := NewRunner(RunnerOptions{
+		CandidateStore:      candidateStore,
+		FromRaw:             true,
+		SwapStore:           swapStore,
+		LiquidityEventStore: liqEventStore,
+	})
+
+	configs := []domain.StrategyConfig{
+		{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: ptrInt64(60000)},
+		{StrategyType: domain.StrategyTypeTrailingStop, EntryEventType: "NEW_TOKEN",
+			TrailPct: ptrFloat64(0.1), InitialStopPct: ptrFloat64(0.2), MaxHoldDurationMs: ptrInt64(200000)},
+		{StrategyType: domain.StrategyTypeLiquidityGuard, EntryEventType: "NEW_TOKEN",
+			LiquidityDropPct: ptrFloat64(0.3), MaxHoldDurationMs: ptrInt64(200000)},
+	}
+
+	for _, cfg := range configs {
+		t.Run(cfg.StrategyType, func(t *testing.T) {
+			want, err := storeRunner.Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic)
+			if err != nil {
+				t.Fatalf("store-backed Run failed: %v", err)
+			}
+			got, err := rawRunner.Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic)
+			if err != nil {
+				t.Fatalf("raw Run failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("raw trade differs from store-backed trade:\n got  %+v\n want %+v", got, want)
+			}
+		})
+	}
+}
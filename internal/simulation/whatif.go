@@ -0,0 +1,159 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/strategy"
+)
+
+// ErrNilTrade is returned when a what-if analysis is requested without an original trade.
+var ErrNilTrade = errors.New("original trade is nil")
+
+// WhatIfResult compares a stored trade with the same entry exited by an alternate strategy.
+type WhatIfResult struct {
+	Original  *domain.TradeRecord
+	Alternate *domain.TradeRecord
+
+	OutcomeDelta      float64 // alternate.Outcome - original.Outcome
+	HoldDeltaMs       int64   // alternate.HoldDurationMs - original.HoldDurationMs
+	ExitReasonChanged bool    // alternate exited for a different reason
+}
+
+// WhatIfSummary is the distribution of outcome deltas over a batch of what-if results.
+type WhatIfSummary struct {
+	Trades    int
+	Improved  int // OutcomeDelta > 0
+	Worsened  int // OutcomeDelta < 0
+	Unchanged int
+
+	DeltaMean   float64
+	DeltaMedian float64
+	DeltaP10    float64
+	DeltaP90    float64
+	DeltaMin    float64
+	DeltaMax    float64
+}
+
+// WhatIf re-simulates the exit of an existing trade with an alternate strategy.
+// The entry is taken from the original trade (signal time, signal price and liquidity),
+// so only the exit path differs; the candidate's discovery is not re-evaluated.
+// scenario must be the scenario the original trade was simulated with.
+// The alternate trade is never persisted.
+func (r *Runner) WhatIf(ctx context.Context, original *domain.TradeRecord, alt domain.StrategyConfig, scenario domain.ScenarioConfig) (*WhatIfResult, error) {
+	if original == nil {
+		return nil, ErrNilTrade
+	}
+
+	strat, err := strategy.FromConfig(alt)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, liquidity, err := r.loadTimeseries(ctx, original.CandidateID, original.EntrySignalTime, alt)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &strategy.StrategyInput{
+		CandidateID:         original.CandidateID,
+		EntrySignalTime:     original.EntrySignalTime,
+		EntrySignalPrice:    original.EntrySignalPrice,
+		EntryLiquidity:      original.EntryLiquidity,
+		PriceTimeseries:     prices,
+		LiquidityTimeseries: liquidity,
+		Scenario:            scenario,
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	trade, err := strat.Execute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	trade.EntryContext = original.EntryContext
+
+	return &WhatIfResult{
+		Original:          original,
+		Alternate:         trade,
+		OutcomeDelta:      trade.Outcome - original.Outcome,
+		HoldDeltaMs:       trade.HoldDurationMs - original.HoldDurationMs,
+		ExitReasonChanged: trade.ExitReason != original.ExitReason,
+	}, nil
+}
+
+// WhatIfAll runs WhatIf for every trade, ordered by trade_id.
+// scenarios resolves a trade's scenario_id; trades with an unknown scenario are an error.
+func (r *Runner) WhatIfAll(ctx context.Context, trades []*domain.TradeRecord, alt domain.StrategyConfig, scenarios func(scenarioID string) (domain.ScenarioConfig, bool)) ([]*WhatIfResult, error) {
+	sorted := make([]*domain.TradeRecord, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TradeID < sorted[j].TradeID
+	})
+
+	results := make([]*WhatIfResult, 0, len(sorted))
+	for _, t := range sorted {
+		scenario, ok := scenarios(t.ScenarioID)
+		if !ok {
+			return nil, fmt.Errorf("trade %s: unknown scenario %q", t.TradeID, t.ScenarioID)
+		}
+		res, err := r.WhatIf(ctx, t, alt, scenario)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// SummarizeWhatIf computes the distribution of outcome deltas.
+// Percentiles use linear interpolation, as in strategy aggregates.
+func SummarizeWhatIf(results []*WhatIfResult) WhatIfSummary {
+	s := WhatIfSummary{Trades: len(results)}
+	if len(results) == 0 {
+		return s
+	}
+
+	deltas := make([]float64, len(results))
+	sum := 0.0
+	for i, r := range results {
+		deltas[i] = r.OutcomeDelta
+		sum += r.OutcomeDelta
+		switch {
+		case r.OutcomeDelta > 0:
+			s.Improved++
+		case r.OutcomeDelta < 0:
+			s.Worsened++
+		default:
+			s.Unchanged++
+		}
+	}
+	sort.Float64s(deltas)
+
+	s.DeltaMean = sum / float64(len(deltas))
+	s.DeltaMedian = percentile(deltas, 0.50)
+	s.DeltaP10 = percentile(deltas, 0.10)
+	s.DeltaP90 = percentile(deltas, 0.90)
+	s.DeltaMin = deltas[0]
+	s.DeltaMax = deltas[len(deltas)-1]
+	return s
+}
+
+// percentile returns the p-th percentile of sorted values with linear interpolation.
+func percentile(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	idx := p * float64(n-1)
+	lower := int(idx)
+	if lower+1 >= n {
+		return sorted[n-1]
+	}
+	frac := idx - float64(lower)
+	return sorted[lower] + frac*(sorted[lower+1]-sorted[lower])
+}
@@ -0,0 +1,164 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+func trailingStopConfig(maxHoldMs int64) domain.StrategyConfig {
+	return domain.StrategyConfig{
+		StrategyType:      domain.StrategyTypeTrailingStop,
+		EntryEventType:    "NEW_TOKEN",
+		TrailPct:          ptrFloat64(0.5),
+		InitialStopPct:    ptrFloat64(0.5),
+		MaxHoldDurationMs: ptrInt64(maxHoldMs),
+	}
+}
+
+// newWhatIfRunner stores a candidate whose price is flat for 5 minutes and then climbs
+// to a peak at minute 15, so a 5-minute max hold exits via MAX_DURATION before the peak.
+func newWhatIfRunner(t *testing.T, candidateIDs ...string) (*Runner, *memory.TradeRecordStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	candidateStore := memory.NewCandidateStore()
+	priceStore := memory.NewPriceTimeseriesStore()
+	liqStore := memory.NewLiquidityTimeseriesStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	for _, id := range candidateIDs {
+		if err := candidateStore.Insert(ctx, &domain.TokenCandidate{
+			CandidateID:  id,
+			Source:       domain.SourceNewToken,
+			Mint:         "mint-" + id,
+			TxSignature:  "tx-" + id,
+			Slot:         100,
+			DiscoveredAt: 1000000,
+		}); err != nil {
+			t.Fatalf("Insert candidate failed: %v", err)
+		}
+
+		prices := makePriceTimeseries(id, []float64{
+			1.0, 1.0, 1.0, 1.0, 1.0, 1.0, // minutes 0-5
+			1.1, 1.2, 1.3, 1.4, 1.5, 1.6, 1.7, 1.8, 1.9, 2.0, // minutes 6-15 peak
+			1.9, 1.9, 1.9, 1.9, 1.9, // minutes 16-20
+		}, 1000000, 60000)
+		if err := priceStore.InsertBulk(ctx, prices); err != nil {
+			t.Fatalf("Insert prices failed: %v", err)
+		}
+		liq := makeLiquidityTimeseries(id, []float64{1000}, 1000000, 60000)
+		if err := liqStore.InsertBulk(ctx, liq); err != nil {
+			t.Fatalf("Insert liquidity failed: %v", err)
+		}
+	}
+
+	runner := NewRunner(RunnerOptions{
+		CandidateStore:       candidateStore,
+		PriceTimeseriesStore: priceStore,
+		LiqTimeseriesStore:   liqStore,
+		TradeRecordStore:     tradeStore,
+	})
+	return runner, tradeStore
+}
+
+func TestRunner_WhatIf_LongerHoldCapturesPeak(t *testing.T) {
+	ctx := context.Background()
+	runner, tradeStore := newWhatIfRunner(t, "c1")
+
+	original, err := runner.Run(ctx, "c1", trailingStopConfig(300000), domain.ScenarioConfigRealistic)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if original.ExitReason != domain.ExitReasonMaxDuration {
+		t.Fatalf("expected original to exit via MAX_DURATION, got %s", original.ExitReason)
+	}
+
+	res, err := runner.WhatIf(ctx, original, trailingStopConfig(1200000), domain.ScenarioConfigRealistic)
+	if err != nil {
+		t.Fatalf("WhatIf failed: %v", err)
+	}
+
+	alt := res.Alternate
+	if alt.EntrySignalTime != original.EntrySignalTime || alt.EntrySignalPrice != original.EntrySignalPrice {
+		t.Errorf("alternate entry (%d, %f) differs from original (%d, %f)",
+			alt.EntrySignalTime, alt.EntrySignalPrice, original.EntrySignalTime, original.EntrySignalPrice)
+	}
+	if alt.PeakPrice == nil || *alt.PeakPrice != 2.0 {
+		t.Errorf("expected alternate peak 2.0, got %v", alt.PeakPrice)
+	}
+	if res.OutcomeDelta <= 0 {
+		t.Errorf("expected longer hold to improve outcome, delta %f", res.OutcomeDelta)
+	}
+	if math.Abs(res.OutcomeDelta-(alt.Outcome-original.Outcome)) > 1e-12 {
+		t.Errorf("delta %f != %f - %f", res.OutcomeDelta, alt.Outcome, original.Outcome)
+	}
+	if res.HoldDeltaMs != 900000 {
+		t.Errorf("expected hold delta 900000ms, got %d", res.HoldDeltaMs)
+	}
+
+	// What-if never persists the alternate trade
+	all, err := tradeStore.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("expected only the original trade stored, got %d", len(all))
+	}
+}
+
+func TestRunner_WhatIfAll_Summary(t *testing.T) {
+	ctx := context.Background()
+	runner, tradeStore := newWhatIfRunner(t, "c1", "c2")
+
+	for _, id := range []string{"c1", "c2"} {
+		if _, err := runner.Run(ctx, id, trailingStopConfig(300000), domain.ScenarioConfigRealistic); err != nil {
+			t.Fatalf("Run %s failed: %v", id, err)
+		}
+	}
+	trades, err := tradeStore.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+
+	scenarios := func(id string) (domain.ScenarioConfig, bool) {
+		if id == domain.ScenarioRealistic {
+			return domain.ScenarioConfigRealistic, true
+		}
+		return domain.ScenarioConfig{}, false
+	}
+	results, err := runner.WhatIfAll(ctx, trades, trailingStopConfig(1200000), scenarios)
+	if err != nil {
+		t.Fatalf("WhatIfAll failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Original.TradeID > results[1].Original.TradeID {
+		t.Fatalf("expected 2 results ordered by trade_id, got %d", len(results))
+	}
+
+	sum := SummarizeWhatIf(results)
+	if sum.Trades != 2 || sum.Improved != 2 || sum.Worsened != 0 {
+		t.Errorf("unexpected summary counts: %+v", sum)
+	}
+	if sum.DeltaMin != results[0].OutcomeDelta || sum.DeltaMedian != sum.DeltaMean {
+		t.Errorf("unexpected summary distribution: %+v", sum)
+	}
+
+	// Trades whose scenario cannot be resolved are rejected
+	if _, err := runner.WhatIfAll(ctx, trades, trailingStopConfig(1200000), func(string) (domain.ScenarioConfig, bool) {
+		return domain.ScenarioConfig{}, false
+	}); err == nil {
+		t.Error("expected error for unknown scenario")
+	}
+}
+
+func TestRunner_WhatIf_NilTrade(t *testing.T) {
+	runner, _ := newWhatIfRunner(t)
+	_, err := runner.WhatIf(context.Background(), nil, trailingStopConfig(1200000), domain.ScenarioConfigRealistic)
+	if !errors.Is(err, ErrNilTrade) {
+		t.Errorf("expected ErrNilTrade, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package simulation
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+)
+
+// DefaultWindowMarginMs is the default margin loaded past a strategy's holding horizon.
+const DefaultWindowMarginMs int64 = 600000 // 10 minutes
+
+// Time series load modes reported to a LoadRecorder.
+const (
+	LoadFull     = "full"     // whole series: windowing disabled or the strategy has no horizon
+	LoadWindow   = "window"   // series up to the end of the holding window only
+	LoadFallback = "fallback" // the window did not determine the trade, whole series reloaded
+)
+
+// LoadRecorder receives the mode of each time series load and the number of points it read.
+type LoadRecorder func(mode string, points int)
+
+// holdHorizonMs returns how long after the entry signal cfg can hold a position,
+// or 0 if the strategy has no bound.
+func holdHorizonMs(cfg domain.StrategyConfig) int64 {
+	switch cfg.StrategyType {
+	case domain.StrategyTypeTimeExit:
+		if cfg.HoldDurationMs != nil {
+			return *cfg.HoldDurationMs
+		}
+	case domain.StrategyTypeTrailingStop, domain.StrategyTypeLiquidityGuard:
+		if cfg.MaxHoldDurationMs != nil {
+			return *cfg.MaxHoldDurationMs
+		}
+	}
+	return 0
+}
+
+// loadWindow loads the time series up to horizon+margin after the entry signal.
+// The window starts at the beginning of the series, since the entry context
+// summarizes all trading before the signal.
+//
+// A strategy only reads points up to its exit, and lookups are "at or before", so
+// the window gives the same trade as the full series when:
+//   - neither series is empty in the window (an empty window cannot tell "no data"
+//     from "data only after the window"), and
+//   - for strategies that scan forward until the horizon (TRAILING_STOP on prices,
+//     LIQUIDITY_GUARD on either series), the window holds a point at or after the horizon.
+//
+// A series failing this is reloaded whole.
+func (r *Runner) loadWindow(ctx context.Context, candidateID string, entrySignalTime int64, cfg domain.StrategyConfig, horizonMs int64) ([]*domain.PriceTimeseriesPoint, []*domain.LiquidityTimeseriesPoint, error) {
+	end := entrySignalTime + horizonMs + r.windowMarginMs
+	prices, err := r.priceTimeseriesStore.GetByTimeRange(ctx, candidateID, 0, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	liquidity, err := r.liqTimeseriesStore.GetByTimeRange(ctx, candidateID, 0, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	points := len(prices) + len(liquidity)
+
+	horizon := entrySignalTime + horizonMs
+	pricesReach := len(prices) > 0 && prices[len(prices)-1].TimestampMs >= horizon
+	liquidityReach := len(liquidity) > 0 && liquidity[len(liquidity)-1].TimestampMs >= horizon
+
+	pricesComplete, liquidityComplete := len(prices) > 0, len(liquidity) > 0
+	switch cfg.StrategyType {
+	case domain.StrategyTypeTrailingStop:
+		pricesComplete = pricesComplete && pricesReach
+	case domain.StrategyTypeLiquidityGuard:
+		pricesComplete = pricesComplete && (pricesReach || liquidityReach)
+		liquidityComplete = liquidityComplete && (pricesReach || liquidityReach)
+	}
+
+	mode := LoadWindow
+	if !pricesComplete {
+		mode = LoadFallback
+		if prices, err = r.priceTimeseriesStore.GetByCandidateID(ctx, candidateID); err != nil {
+			return nil, nil, err
+		}
+		points += len(prices)
+	}
+	if !liquidityComplete {
+		mode = LoadFallback
+		if liquidity, err = r.liqTimeseriesStore.GetByCandidateID(ctx, candidateID); err != nil {
+			return nil, nil, err
+		}
+		points += len(liquidity)
+	}
+
+	r.recordLoad(mode, points)
+	return prices, liquidity, nil
+}
+
+// recordLoad reports a load to the recorder, if any.
+func (r *Runner) recordLoad(mode string, points int) {
+	if r.onLoad != nil {
+		r.onLoad(mode, points)
+	}
+}
@@ -0,0 +1,212 @@
+package simulation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// loadCall is one LoadRecorder notification.
+type loadCall struct {
+	mode   string
+	points int
+}
+
+// windowFixture stores a candidate discovered at signalMs with the given series.
+func windowFixture(t *testing.T, prices []*domain.PriceTimeseriesPoint, liquidity []*domain.LiquidityTimeseriesPoint) (RunnerOptions, string) {
+	t.Helper()
+	ctx := context.Background()
+	candidateID := "window-candidate"
+
+	opts := RunnerOptions{
+		CandidateStore:       memory.NewCandidateStore(),
+		PriceTimeseriesStore: memory.NewPriceTimeseriesStore(),
+		LiqTimeseriesStore:   memory.NewLiquidityTimeseriesStore(),
+	}
+	if err := opts.CandidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID:  candidateID,
+		Source:       domain.SourceNewToken,
+		Mint:         "mint-window",
+		TxSignature:  "tx-window",
+		Slot:         100,
+		DiscoveredAt: 1000000,
+	}); err != nil {
+		t.Fatalf("Insert candidate: %v", err)
+	}
+	for _, p := range prices {
+		p.CandidateID = candidateID
+	}
+	for _, l := range liquidity {
+		l.CandidateID = candidateID
+	}
+	if len(prices) > 0 {
+		if err := opts.PriceTimeseriesStore.InsertBulk(ctx, prices); err != nil {
+			t.Fatalf("Insert prices: %v", err)
+		}
+	}
+	if len(liquidity) > 0 {
+		if err := opts.LiqTimeseriesStore.InsertBulk(ctx, liquidity); err != nil {
+			t.Fatalf("Insert liquidity: %v", err)
+		}
+	}
+	return opts, candidateID
+}
+
+func windowStrategies() []domain.StrategyConfig {
+	trail, stop, drop := 0.1, 0.2, 0.3
+	return []domain.StrategyConfig{
+		{StrategyType: domain.StrategyTypeTimeExit, EntryEventType: "NEW_TOKEN", HoldDurationMs: ptrInt64(120000)},
+		{StrategyType: domain.StrategyTypeTrailingStop, EntryEventType: "NEW_TOKEN", TrailPct: &trail, InitialStopPct: &stop, MaxHoldDurationMs: ptrInt64(120000)},
+		{StrategyType: domain.StrategyTypeLiquidityGuard, EntryEventType: "NEW_TOKEN", LiquidityDropPct: &drop, MaxHoldDurationMs: ptrInt64(120000)},
+	}
+}
+
+func TestRunner_WindowedLoadMatchesFullLoad(t *testing.T) {
+	ctx := context.Background()
+	const signal, margin = int64(1000000), int64(30000)
+
+	// Flat series: no stop or guard triggers, so strategies scan to the horizon
+	flat := make([]float64, 60)
+	level := make([]float64, 60)
+	for i := range flat {
+		flat[i] = 1.0
+		level[i] = 1000
+	}
+
+	tests := []struct {
+		name      string
+		prices    []*domain.PriceTimeseriesPoint
+		liquidity []*domain.LiquidityTimeseriesPoint
+		wantModes map[string]string // strategy type -> expected load mode
+	}{
+		{
+			name:      "long tail after horizon",
+			prices:    makePriceTimeseries("", flat, signal-300000, 10000),
+			liquidity: makeLiquidityTimeseries("", level, signal-300000, 10000),
+			wantModes: map[string]string{
+				domain.StrategyTypeTimeExit:       LoadWindow,
+				domain.StrategyTypeTrailingStop:   LoadWindow,
+				domain.StrategyTypeLiquidityGuard: LoadWindow,
+			},
+		},
+		{
+			name: "gap past the margin",
+			prices: append(makePriceTimeseries("", []float64{1.0, 1.0, 1.0}, signal-20000, 10000),
+				makePriceTimeseries("", []float64{0.5, 0.4}, signal+600000, 10000)...),
+			liquidity: append(makeLiquidityTimeseries("", []float64{1000, 1000}, signal, 10000),
+				makeLiquidityTimeseries("", []float64{100}, signal+600000, 10000)...),
+			wantModes: map[string]string{
+				domain.StrategyTypeTimeExit:       LoadWindow,
+				domain.StrategyTypeTrailingStop:   LoadFallback,
+				domain.StrategyTypeLiquidityGuard: LoadFallback,
+			},
+		},
+		{
+			name:      "no liquidity",
+			prices:    makePriceTimeseries("", flat, signal, 10000),
+			wantModes: map[string]string{domain.StrategyTypeTimeExit: LoadFallback},
+		},
+		{
+			name:      "liquidity only after window end",
+			prices:    makePriceTimeseries("", flat, signal, 10000),
+			liquidity: makeLiquidityTimeseries("", []float64{1000, 500}, signal+400000, 10000),
+			wantModes: map[string]string{domain.StrategyTypeLiquidityGuard: LoadFallback},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cfg := range windowStrategies() {
+				opts, candidateID := windowFixture(t, tt.prices, tt.liquidity)
+				full := NewRunner(opts)
+				fullTrade, fullErr := full.Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic)
+
+				var calls []loadCall
+				opts.WindowMarginMs = margin
+				opts.OnLoad = func(mode string, points int) { calls = append(calls, loadCall{mode, points}) }
+				windowed := NewRunner(opts)
+				windowedTrade, windowedErr := windowed.Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic)
+
+				if (fullErr == nil) != (windowedErr == nil) {
+					t.Fatalf("%s: full err %v, windowed err %v", cfg.StrategyType, fullErr, windowedErr)
+				}
+				if !reflect.DeepEqual(fullTrade, windowedTrade) {
+					t.Errorf("%s: windowed trade differs from full load:\nfull:     %+v\nwindowed: %+v", cfg.StrategyType, fullTrade, windowedTrade)
+				}
+				if len(calls) != 1 {
+					t.Fatalf("%s: expected 1 recorded load, got %v", cfg.StrategyType, calls)
+				}
+				if want, ok := tt.wantModes[cfg.StrategyType]; ok && calls[0].mode != want {
+					t.Errorf("%s: expected mode %s, got %s", cfg.StrategyType, want, calls[0].mode)
+				}
+			}
+		})
+	}
+}
+
+func TestRunner_WindowedLoadReadsFewerPoints(t *testing.T) {
+	ctx := context.Background()
+
+	// 1 hour of 10s points starting at discovery
+	prices := make([]float64, 360)
+	liquidity := make([]float64, 360)
+	for i := range prices {
+		prices[i] = 1.0 + float64(i%7)*0.01
+		liquidity[i] = 1000
+	}
+	opts, candidateID := windowFixture(t,
+		makePriceTimeseries("", prices, 1000000, 10000),
+		makeLiquidityTimeseries("", liquidity, 1000000, 10000))
+
+	var calls []loadCall
+	opts.OnLoad = func(mode string, points int) { calls = append(calls, loadCall{mode, points}) }
+	cfg := windowStrategies()[0] // 2 minute hold
+
+	if _, err := NewRunner(opts).Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic); err != nil {
+		t.Fatalf("full Run: %v", err)
+	}
+	opts.WindowMarginMs = 60000
+	if _, err := NewRunner(opts).Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic); err != nil {
+		t.Fatalf("windowed Run: %v", err)
+	}
+
+	if len(calls) != 2 || calls[0].mode != LoadFull || calls[1].mode != LoadWindow {
+		t.Fatalf("unexpected loads: %v", calls)
+	}
+	if calls[0].points != 720 {
+		t.Errorf("expected full load of 720 points, got %d", calls[0].points)
+	}
+	// Points in [0, signal+3m]: 19 of each series
+	if calls[1].points != 38 {
+		t.Errorf("expected windowed load of 38 points, got %d", calls[1].points)
+	}
+}
+
+func TestRunner_WindowedLoadWithoutHorizon(t *testing.T) {
+	ctx := context.Background()
+	opts, candidateID := windowFixture(t,
+		makePriceTimeseries("", []float64{1.0, 1.1, 1.2}, 1000000, 10000),
+		makeLiquidityTimeseries("", []float64{1000, 1000, 1000}, 1000000, 10000))
+
+	var modes []string
+	opts.WindowMarginMs = DefaultWindowMarginMs
+	opts.OnLoad = func(mode string, _ int) { modes = append(modes, mode) }
+	trail, stop := 0.1, 0.2
+	cfg := domain.StrategyConfig{
+		StrategyType:      domain.StrategyTypeTrailingStop,
+		EntryEventType:    "NEW_TOKEN",
+		TrailPct:          &trail,
+		InitialStopPct:    &stop,
+		MaxHoldDurationMs: ptrInt64(0),
+	}
+
+	if _, err := NewRunner(opts).Run(ctx, candidateID, cfg, domain.ScenarioConfigRealistic); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(modes) != 1 || modes[0] != LoadFull {
+		t.Errorf("expected a full load for a strategy without max hold, got %v", modes)
+	}
+}
@@ -0,0 +1,92 @@
+package solana
+
+import (
+	"encoding/binary"
+
+	"github.com/mr-tron/base58"
+)
+
+// ComputeBudgetProgramID is the native program that sets per-transaction
+// compute unit limits and prices.
+const ComputeBudgetProgramID = "ComputeBudget111111111111111111111111111111"
+
+// Compute budget defaults applied by the runtime when a transaction does not
+// request an explicit limit.
+const (
+	DefaultInstructionComputeUnits = 200_000
+	MaxTransactionComputeUnits     = 1_400_000
+)
+
+// ComputeBudget instruction discriminators.
+const (
+	computeBudgetSetUnitLimit = 2
+	computeBudgetSetUnitPrice = 3
+)
+
+// ComputeBudget is the compute budget requested by a transaction's
+// ComputeBudget instructions.
+type ComputeBudget struct {
+	UnitLimit  uint32 // requested compute unit limit (0 if not set)
+	UnitPrice  uint64 // price per compute unit in micro-lamports (0 if not set)
+	HasLimit   bool   // a SetComputeUnitLimit instruction was present
+	OtherInstr int    // top-level instructions outside the ComputeBudget program
+}
+
+// ParseComputeBudget extracts SetComputeUnitLimit and SetComputeUnitPrice from
+// the top-level instructions of msg. Malformed instruction data is ignored.
+func ParseComputeBudget(msg *TransactionMessage) ComputeBudget {
+	var b ComputeBudget
+	if msg == nil {
+		return b
+	}
+	for _, ix := range msg.Instructions {
+		if ix.ProgramIDIndex < 0 || ix.ProgramIDIndex >= len(msg.AccountKeys) ||
+			msg.AccountKeys[ix.ProgramIDIndex] != ComputeBudgetProgramID {
+			b.OtherInstr++
+			continue
+		}
+		data, err := base58.Decode(ix.Data)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+		switch data[0] {
+		case computeBudgetSetUnitLimit:
+			if len(data) >= 5 {
+				b.UnitLimit = binary.LittleEndian.Uint32(data[1:5])
+				b.HasLimit = true
+			}
+		case computeBudgetSetUnitPrice:
+			if len(data) >= 9 {
+				b.UnitPrice = binary.LittleEndian.Uint64(data[1:9])
+			}
+		}
+	}
+	return b
+}
+
+// EffectiveUnitLimit returns the compute unit limit the runtime charges for:
+// the requested limit, or the per-instruction default, capped at the maximum.
+func (b ComputeBudget) EffectiveUnitLimit() uint64 {
+	limit := uint64(b.UnitLimit)
+	if !b.HasLimit {
+		limit = uint64(b.OtherInstr) * DefaultInstructionComputeUnits
+	}
+	if limit > MaxTransactionComputeUnits {
+		limit = MaxTransactionComputeUnits
+	}
+	return limit
+}
+
+// PriorityFeeLamports returns the prioritization fee in lamports:
+// ceil(unit price × effective unit limit / 1e6).
+func (b ComputeBudget) PriorityFeeLamports() uint64 {
+	if b.UnitPrice == 0 {
+		return 0
+	}
+	limit := b.EffectiveUnitLimit()
+	if b.UnitPrice > (^uint64(0)-999_999)/max(limit, 1) {
+		// Saturate instead of overflowing on absurd prices.
+		return ^uint64(0) / 1_000_000
+	}
+	return (b.UnitPrice*limit + 999_999) / 1_000_000
+}
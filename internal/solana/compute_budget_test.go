@@ -0,0 +1,161 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mr-tron/base58"
+)
+
+// setUnitLimitData encodes a SetComputeUnitLimit instruction.
+func setUnitLimitData(units uint32) string {
+	data := make([]byte, 5)
+	data[0] = computeBudgetSetUnitLimit
+	binary.LittleEndian.PutUint32(data[1:], units)
+	return base58.Encode(data)
+}
+
+// setUnitPriceData encodes a SetComputeUnitPrice instruction.
+func setUnitPriceData(microLamports uint64) string {
+	data := make([]byte, 9)
+	data[0] = computeBudgetSetUnitPrice
+	binary.LittleEndian.PutUint64(data[1:], microLamports)
+	return base58.Encode(data)
+}
+
+func TestParseComputeBudget(t *testing.T) {
+	keys := []string{"payer", ComputeBudgetProgramID, "swapProgram"}
+
+	tests := []struct {
+		name         string
+		instructions []Instruction
+		wantLimit    uint64
+		wantPriority uint64
+	}{
+		{
+			name: "explicit limit and price",
+			instructions: []Instruction{
+				{ProgramIDIndex: 1, Data: setUnitLimitData(300_000)},
+				{ProgramIDIndex: 1, Data: setUnitPriceData(50_000)},
+				{ProgramIDIndex: 2, Data: "3Bxs4h24hBtQy9rw"},
+			},
+			wantLimit:    300_000,
+			wantPriority: 15_000, // 300k units × 0.05 lamports
+		},
+		{
+			name: "default limit per non-budget instruction",
+			instructions: []Instruction{
+				{ProgramIDIndex: 1, Data: setUnitPriceData(1_000_000)},
+				{ProgramIDIndex: 2},
+				{ProgramIDIndex: 2},
+			},
+			wantLimit:    400_000,
+			wantPriority: 400_000,
+		},
+		{
+			name: "limit capped at transaction maximum",
+			instructions: []Instruction{
+				{ProgramIDIndex: 1, Data: setUnitLimitData(2_000_000)},
+				{ProgramIDIndex: 1, Data: setUnitPriceData(1_000)},
+			},
+			wantLimit:    MaxTransactionComputeUnits,
+			wantPriority: 1_400,
+		},
+		{
+			name: "fractional lamports round up",
+			instructions: []Instruction{
+				{ProgramIDIndex: 1, Data: setUnitLimitData(1_000)},
+				{ProgramIDIndex: 1, Data: setUnitPriceData(1)},
+			},
+			wantLimit:    1_000,
+			wantPriority: 1,
+		},
+		{
+			name: "no price means no priority fee",
+			instructions: []Instruction{
+				{ProgramIDIndex: 1, Data: setUnitLimitData(100_000)},
+				{ProgramIDIndex: 2},
+			},
+			wantLimit:    100_000,
+			wantPriority: 0,
+		},
+		{
+			name: "malformed data and bad index ignored",
+			instructions: []Instruction{
+				{ProgramIDIndex: 1, Data: "0OIl"}, // not base58
+				{ProgramIDIndex: 1, Data: base58.Encode([]byte{computeBudgetSetUnitPrice, 1})},
+				{ProgramIDIndex: 9},
+			},
+			wantLimit:    DefaultInstructionComputeUnits,
+			wantPriority: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := ParseComputeBudget(&TransactionMessage{AccountKeys: keys, Instructions: tt.instructions})
+			if got := b.EffectiveUnitLimit(); got != tt.wantLimit {
+				t.Errorf("EffectiveUnitLimit = %d, want %d", got, tt.wantLimit)
+			}
+			if got := b.PriorityFeeLamports(); got != tt.wantPriority {
+				t.Errorf("PriorityFeeLamports = %d, want %d", got, tt.wantPriority)
+			}
+		})
+	}
+
+	if b := ParseComputeBudget(nil); b.PriorityFeeLamports() != 0 {
+		t.Errorf("expected zero priority fee for nil message, got %d", b.PriorityFeeLamports())
+	}
+}
+
+func TestHTTPClient_GetTransaction_FeeAndInstructions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"slot":      int64(1),
+				"blockTime": int64(1700000000),
+				"meta": map[string]interface{}{
+					"err":         nil,
+					"fee":         20000,
+					"logMessages": []string{},
+				},
+				"transaction": map[string]interface{}{
+					"message": map[string]interface{}{
+						"accountKeys": []string{"payer", ComputeBudgetProgramID},
+						"instructions": []map[string]interface{}{
+							{"programIdIndex": 1, "accounts": []int{}, "data": setUnitLimitData(200_000)},
+							{"programIdIndex": 1, "accounts": []int{}, "data": setUnitPriceData(75_000)},
+						},
+					},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	tx, err := NewHTTPClient(server.URL).GetTransaction(context.Background(), "sig")
+	if err != nil {
+		t.Fatalf("GetTransaction: %v", err)
+	}
+	if tx.Meta.Fee != 20000 {
+		t.Errorf("expected fee 20000, got %d", tx.Meta.Fee)
+	}
+	if len(tx.Message.Instructions) != 2 {
+		t.Fatalf("expected 2 instructions, got %d", len(tx.Message.Instructions))
+	}
+	if got := ParseComputeBudget(tx.Message).PriorityFeeLamports(); got != 15000 {
+		t.Errorf("expected priority fee 15000, got %d", got)
+	}
+}
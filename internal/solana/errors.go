@@ -0,0 +1,67 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// Typed RPC failures. Errors returned by HTTPClient wrap one of these when the
+// failure class is known, so callers can branch with errors.Is.
+var (
+	// ErrRateLimited means the endpoint rejected the request for exceeding its rate limit.
+	ErrRateLimited = errors.New("rpc rate limited")
+
+	// ErrNotFound means the node has no data for the requested slot or transaction
+	// (skipped slot, pruned ledger history).
+	ErrNotFound = errors.New("rpc data not found")
+
+	// ErrNodeBehind means the node has not caught up with the requested data yet;
+	// the same request may succeed shortly or on another node.
+	ErrNodeBehind = errors.New("rpc node behind")
+
+	// ErrTimeout means a single request exceeded its per-call deadline while the
+	// caller's context was still live.
+	ErrTimeout = errors.New("rpc request timeout")
+)
+
+// Solana JSON-RPC server error codes.
+const (
+	codeBlockNotAvailable         = -32004
+	codeNodeUnhealthy             = -32005
+	codeSlotSkipped               = -32007
+	codeLongTermStorageSlotSkip   = -32009
+	codeTransactionHistoryMissing = -32011
+	codeBlockStatusNotAvailable   = -32014
+	codeMinContextSlotNotReached  = -32016
+	codeRateLimited               = -32429
+)
+
+// Unwrap maps the JSON-RPC error code to a typed error, or nil for codes
+// without a class (invalid params, method not found, ...).
+func (e *rpcError) Unwrap() error {
+	switch e.Code {
+	case codeRateLimited:
+		return ErrRateLimited
+	case codeSlotSkipped, codeLongTermStorageSlotSkip, codeTransactionHistoryMissing:
+		return ErrNotFound
+	case codeBlockNotAvailable, codeNodeUnhealthy, codeBlockStatusNotAvailable, codeMinContextSlotNotReached:
+		return ErrNodeBehind
+	}
+	return nil
+}
+
+// IsRetryable reports whether err is a transient RPC failure worth retrying
+// after a backoff: rate limiting, a lagging node or a request timeout.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNodeBehind) || errors.Is(err, ErrTimeout)
+}
+
+// isTimeout reports whether a transport error is a deadline or network timeout.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -25,11 +25,27 @@ type Transaction struct {
 
 // TransactionMeta contains transaction metadata.
 type TransactionMeta struct {
-	Err         interface{}
-	LogMessages []string
+	Err               interface{}
+	LogMessages       []string
+	Fee               uint64              // total fee paid in lamports (base + priority)
+	InnerInstructions []InnerInstructions // instructions invoked by top-level instructions (CPI)
+}
+
+// InnerInstructions are the instructions a top-level instruction invoked.
+type InnerInstructions struct {
+	Index        int // index of the top-level instruction in the message
+	Instructions []Instruction
 }
 
 // TransactionMessage contains parsed transaction message.
 type TransactionMessage struct {
-	AccountKeys []string
+	AccountKeys  []string
+	Instructions []Instruction
+}
+
+// Instruction is a compiled instruction of a transaction message, top-level or inner.
+type Instruction struct {
+	ProgramIDIndex int    // index into AccountKeys
+	Accounts       []int  // indexes into AccountKeys
+	Data           string // base58-encoded instruction data
 }
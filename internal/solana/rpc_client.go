@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"sync/atomic"
 	"time"
@@ -22,13 +23,16 @@ const (
 
 // HTTPClient implements RPCClient using HTTP JSON-RPC 2.0.
 type HTTPClient struct {
-	endpoint    string
-	client      *http.Client
-	maxRetries  int
-	retryDelay  time.Duration
-	maxDelay    time.Duration
-	backoffMult float64
-	requestID   atomic.Uint64
+	endpoint       string
+	client         *http.Client
+	requestTimeout time.Duration // per-attempt deadline (0 = caller's context only)
+	maxRetries     int
+	retryDelay     time.Duration
+	maxDelay       time.Duration
+	backoffMult    float64
+	slowThreshold  time.Duration // log attempts slower than this (0 = disabled)
+	slowLogger     *log.Logger
+	requestID      atomic.Uint64
 }
 
 // ClientOption configures HTTPClient.
@@ -41,6 +45,27 @@ func WithTimeout(d time.Duration) ClientOption {
 	}
 }
 
+// WithRequestTimeout sets the deadline of each request attempt. An attempt that
+// exceeds it fails with ErrTimeout and is retried; the caller's context still
+// bounds the call as a whole.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return func(c *HTTPClient) {
+		c.requestTimeout = d
+	}
+}
+
+// WithSlowRequestLog logs request attempts slower than threshold with their
+// method and duration. A nil logger uses log.Default().
+func WithSlowRequestLog(threshold time.Duration, logger *log.Logger) ClientOption {
+	return func(c *HTTPClient) {
+		if logger == nil {
+			logger = log.Default()
+		}
+		c.slowThreshold = threshold
+		c.slowLogger = logger
+	}
+}
+
 // WithMaxRetries sets maximum retry attempts.
 func WithMaxRetries(n int) ClientOption {
 	return func(c *HTTPClient) {
@@ -72,12 +97,13 @@ func WithHTTPClient(client *http.Client) ClientOption {
 // NewHTTPClient creates a new Solana RPC HTTP client.
 func NewHTTPClient(endpoint string, opts ...ClientOption) *HTTPClient {
 	c := &HTTPClient{
-		endpoint:    endpoint,
-		client:      &http.Client{Timeout: DefaultTimeout},
-		maxRetries:  DefaultMaxRetries,
-		retryDelay:  DefaultRetryDelay,
-		maxDelay:    DefaultMaxDelay,
-		backoffMult: DefaultBackoffMult,
+		endpoint:       endpoint,
+		client:         &http.Client{Timeout: DefaultTimeout},
+		requestTimeout: DefaultTimeout,
+		maxRetries:     DefaultMaxRetries,
+		retryDelay:     DefaultRetryDelay,
+		maxDelay:       DefaultMaxDelay,
+		backoffMult:    DefaultBackoffMult,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -112,6 +138,9 @@ func (e *rpcError) Error() string {
 }
 
 // call performs a JSON-RPC call with retries and exponential backoff.
+// Transport failures, timeouts, non-200 responses and rate limits are retried;
+// other JSON-RPC errors are returned as is. Cancellation of ctx aborts the call
+// immediately with ctx.Err().
 func (c *HTTPClient) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
 	reqID := c.requestID.Add(1)
 	reqBody := rpcRequest{
@@ -142,34 +171,31 @@ func (c *HTTPClient) call(ctx context.Context, method string, params []interface
 				delay = c.maxDelay
 			}
 		}
-
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
-		if err != nil {
-			return fmt.Errorf("create request: %w", err)
-		}
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := c.client.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("http request: %w", err)
-			continue
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		respBody, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		status, respBody, err := c.do(ctx, method, body)
 		if err != nil {
-			lastErr = fmt.Errorf("read response: %w", err)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if isTimeout(err) {
+				lastErr = fmt.Errorf("%s: %w", method, ErrTimeout)
+			} else {
+				lastErr = err
+			}
 			continue
 		}
 
 		// Handle rate limiting
-		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("rate limited (429)")
+		if status == http.StatusTooManyRequests {
+			lastErr = fmt.Errorf("%w (429)", ErrRateLimited)
 			continue
 		}
 
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+		if status != http.StatusOK {
+			lastErr = fmt.Errorf("unexpected status %d: %s", status, string(respBody))
 			continue
 		}
 
@@ -180,7 +206,11 @@ func (c *HTTPClient) call(ctx context.Context, method string, params []interface
 		}
 
 		if rpcResp.Error != nil {
-			// RPC errors are not retried
+			if rpcResp.Error.Code == codeRateLimited {
+				lastErr = rpcResp.Error
+				continue
+			}
+			// Other RPC errors are not retried
 			return rpcResp.Error
 		}
 
@@ -196,6 +226,41 @@ func (c *HTTPClient) call(ctx context.Context, method string, params []interface
 	return fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// do sends one request attempt under the per-request deadline and returns the
+// HTTP status and body.
+func (c *HTTPClient) do(ctx context.Context, method string, body []byte) (int, []byte, error) {
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	defer func() {
+		if d := time.Since(start); c.slowThreshold > 0 && d >= c.slowThreshold {
+			c.slowLogger.Printf("[rpc] slow request %s took %v", method, d)
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read response: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
 // GetTransaction retrieves a transaction by signature.
 func (c *HTTPClient) GetTransaction(ctx context.Context, signature string) (*Transaction, error) {
 	params := []interface{}{
@@ -225,17 +290,9 @@ func (c *HTTPClient) GetTransaction(ctx context.Context, signature string) (*Tra
 		tx.BlockTime = *result.BlockTime
 	}
 
-	if result.Meta != nil {
-		tx.Meta = &TransactionMeta{
-			Err:         result.Meta.Err,
-			LogMessages: result.Meta.LogMessages,
-		}
-	}
-
-	if result.Transaction != nil && result.Transaction.Message != nil {
-		tx.Message = &TransactionMessage{
-			AccountKeys: result.Transaction.Message.AccountKeys,
-		}
+	tx.Meta = result.Meta.toMeta()
+	if result.Transaction != nil {
+		tx.Message = result.Transaction.Message.toMessage()
 	}
 
 	return tx, nil
@@ -250,16 +307,87 @@ type getTransactionResult struct {
 }
 
 type getTransactionMeta struct {
-	Err         interface{} `json:"err"`
-	LogMessages []string    `json:"logMessages"`
+	Err               interface{}                       `json:"err"`
+	LogMessages       []string                          `json:"logMessages"`
+	Fee               uint64                            `json:"fee"`
+	InnerInstructions []getTransactionInnerInstructions `json:"innerInstructions,omitempty"`
+}
+
+type getTransactionInnerInstructions struct {
+	Index        int                         `json:"index"`
+	Instructions []getTransactionInstruction `json:"instructions"`
 }
 
 type getTransactionTx struct {
-	Message *getTransactionMessage `json:"message"`
+	Signatures []string               `json:"signatures,omitempty"`
+	Message    *getTransactionMessage `json:"message"`
 }
 
 type getTransactionMessage struct {
-	AccountKeys []string `json:"accountKeys"`
+	AccountKeys  []string                    `json:"accountKeys"`
+	Instructions []getTransactionInstruction `json:"instructions"`
+}
+
+type getTransactionInstruction struct {
+	ProgramIDIndex int    `json:"programIdIndex"`
+	Accounts       []int  `json:"accounts"`
+	Data           string `json:"data"`
+}
+
+// toMeta converts the raw meta, returning nil when the RPC omitted it.
+func (m *getTransactionMeta) toMeta() *TransactionMeta {
+	if m == nil {
+		return nil
+	}
+	meta := &TransactionMeta{
+		Err:         m.Err,
+		LogMessages: m.LogMessages,
+		Fee:         m.Fee,
+	}
+	for _, inner := range m.InnerInstructions {
+		meta.InnerInstructions = append(meta.InnerInstructions, InnerInstructions{
+			Index:        inner.Index,
+			Instructions: toInstructions(inner.Instructions),
+		})
+	}
+	return meta
+}
+
+// toMessage converts the raw message, returning nil when the RPC omitted it.
+func (m *getTransactionMessage) toMessage() *TransactionMessage {
+	if m == nil {
+		return nil
+	}
+	return &TransactionMessage{
+		AccountKeys:  m.AccountKeys,
+		Instructions: toInstructions(m.Instructions),
+	}
+}
+
+// toInstructions converts raw compiled instructions.
+func toInstructions(raw []getTransactionInstruction) []Instruction {
+	var out []Instruction
+	for _, ix := range raw {
+		out = append(out, Instruction{
+			ProgramIDIndex: ix.ProgramIDIndex,
+			Accounts:       ix.Accounts,
+			Data:           ix.Data,
+		})
+	}
+	return out
+}
+
+// fromInstructions is the inverse of toInstructions.
+func fromInstructions(ixs []Instruction) []getTransactionInstruction {
+	var out []getTransactionInstruction
+	for _, ix := range ixs {
+		out = append(out, getTransactionInstruction{
+			ProgramIDIndex: ix.ProgramIDIndex,
+			Accounts:       ix.Accounts,
+			Data:           ix.Data,
+		})
+	}
+	return out
 }
 
 // GetBlock retrieves a block by slot number.
@@ -296,18 +424,8 @@ func (c *HTTPClient) GetBlock(ctx context.Context, slot int64) (*Block, error) {
 			tx.Signature = txWrapper.Transaction.Signatures[0]
 		}
 
-		if txWrapper.Meta != nil {
-			tx.Meta = &TransactionMeta{
-				Err:         txWrapper.Meta.Err,
-				LogMessages: txWrapper.Meta.LogMessages,
-			}
-		}
-
-		if txWrapper.Transaction.Message != nil {
-			tx.Message = &TransactionMessage{
-				AccountKeys: txWrapper.Transaction.Message.AccountKeys,
-			}
-		}
+		tx.Meta = txWrapper.Meta.toMeta()
+		tx.Message = txWrapper.Transaction.Message.toMessage()
 
 		block.Transactions = append(block.Transactions, tx)
 	}
@@ -315,6 +433,28 @@ func (c *HTTPClient) GetBlock(ctx context.Context, slot int64) (*Block, error) {
 	return block, nil
 }
 
+// GetBlockSignatures retrieves the signatures of a block's transactions, without
+// their details: a cheap getBlock for locating a point in time.
+func (c *HTTPClient) GetBlockSignatures(ctx context.Context, slot int64) (*BlockSignatures, error) {
+	params := []interface{}{
+		slot,
+		map[string]interface{}{
+			"transactionDetails":             "signatures",
+			"rewards":                        false,
+			"maxSupportedTransactionVersion": 0,
+		},
+	}
+
+	var result struct {
+		BlockTime  *int64   `json:"blockTime"`
+		Signatures []string `json:"signatures"`
+	}
+	if err := c.call(ctx, "getBlock", params, &result); err != nil {
+		return nil, err
+	}
+	return &BlockSignatures{Slot: slot, BlockTime: result.BlockTime, Signatures: result.Signatures}, nil
+}
+
 // getBlockResult is the raw RPC response for getBlock.
 type getBlockResult struct {
 	BlockTime    *int64               `json:"blockTime"`
@@ -331,6 +471,19 @@ type getBlockTx struct {
 	Message    *getTransactionMessage `json:"message"`
 }
 
+// MaxGetBlocksRange is the largest slot range a single getBlocks call accepts.
+const MaxGetBlocksRange = 500_000
+
+// GetBlocks returns the slots within [startSlot, endSlot] that have a confirmed block,
+// in ascending order. Skipped slots are absent.
+func (c *HTTPClient) GetBlocks(ctx context.Context, startSlot, endSlot int64) ([]int64, error) {
+	var result []int64
+	if err := c.call(ctx, "getBlocks", []interface{}{startSlot, endSlot}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetSignaturesForAddress retrieves signatures for an address with pagination.
 func (c *HTTPClient) GetSignaturesForAddress(ctx context.Context, address string, opts *SignaturesOpts) ([]SignatureInfo, error) {
 	config := make(map[string]interface{})
@@ -369,6 +522,52 @@ func (c *HTTPClient) GetSignaturesForAddress(ctx context.Context, address string
 	return sigs, nil
 }
 
+// MaxSignatureStatuses is the most signatures getSignatureStatuses accepts per call.
+const MaxSignatureStatuses = 256
+
+// GetSignatureStatuses retrieves the processing status of up to MaxSignatureStatuses signatures,
+// searching the full ledger rather than only the recent status cache.
+// The result is index-aligned with signatures; an entry is nil when the node does not know
+// the transaction (never landed, or landed on a fork that was abandoned).
+func (c *HTTPClient) GetSignatureStatuses(ctx context.Context, signatures []string) ([]*SignatureStatus, error) {
+	params := []interface{}{
+		signatures,
+		map[string]interface{}{"searchTransactionHistory": true},
+	}
+
+	var result getSignatureStatusesResult
+	if err := c.call(ctx, "getSignatureStatuses", params, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Value) != len(signatures) {
+		return nil, fmt.Errorf("getSignatureStatuses: %d statuses for %d signatures", len(result.Value), len(signatures))
+	}
+
+	statuses := make([]*SignatureStatus, len(result.Value))
+	for i, v := range result.Value {
+		if v == nil {
+			continue
+		}
+		statuses[i] = &SignatureStatus{
+			Slot:               v.Slot,
+			Confirmations:      v.Confirmations,
+			Err:                v.Err,
+			ConfirmationStatus: v.ConfirmationStatus,
+		}
+	}
+
+	return statuses, nil
+}
+
+type getSignatureStatusesResult struct {
+	Value []*struct {
+		Slot               int64       `json:"slot"`
+		Confirmations      *int        `json:"confirmations"`
+		Err                interface{} `json:"err"`
+		ConfirmationStatus string      `json:"confirmationStatus"`
+	} `json:"value"`
+}
+
 // getSignaturesResult is the raw RPC response item for getSignaturesForAddress.
 type getSignaturesResult struct {
 	Signature string      `json:"signature"`
@@ -449,3 +648,48 @@ func (c *HTTPClient) GetBlockTime(ctx context.Context, slot int64) (*int64, erro
 	}
 	return result, nil
 }
+
+// GetTokenLargestAccounts retrieves the largest token accounts for a mint (up to 20, largest first).
+func (c *HTTPClient) GetTokenLargestAccounts(ctx context.Context, mint string) ([]TokenAccountBalance, error) {
+	var result getTokenLargestAccountsResult
+	if err := c.call(ctx, "getTokenLargestAccounts", []interface{}{mint}, &result); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]TokenAccountBalance, len(result.Value))
+	for i, v := range result.Value {
+		accounts[i] = TokenAccountBalance{
+			Address:     v.Address,
+			TokenAmount: TokenAmount{Amount: v.Amount, Decimals: v.Decimals},
+		}
+	}
+
+	return accounts, nil
+}
+
+// GetTokenSupply retrieves the total supply of a mint.
+func (c *HTTPClient) GetTokenSupply(ctx context.Context, mint string) (*TokenAmount, error) {
+	var result getTokenSupplyResult
+	if err := c.call(ctx, "getTokenSupply", []interface{}{mint}, &result); err != nil {
+		return nil, err
+	}
+
+	return &TokenAmount{Amount: result.Value.Amount, Decimals: result.Value.Decimals}, nil
+}
+
+// rpcTokenAmount is the raw RPC token amount object.
+type rpcTokenAmount struct {
+	Amount   string `json:"amount"`
+	Decimals int    `json:"decimals"`
+}
+
+type getTokenLargestAccountsResult struct {
+	Value []struct {
+		Address string `json:"address"`
+		rpcTokenAmount
+	} `json:"value"`
+}
+
+type getTokenSupplyResult struct {
+	Value rpcTokenAmount `json:"value"`
+}
@@ -1,10 +1,14 @@
 package solana
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -155,6 +159,60 @@ func TestHTTPClient_GetSignaturesForAddress(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_GetSignatureStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if req.Method != "getSignatureStatuses" {
+			t.Errorf("expected method getSignatureStatuses, got %s", req.Method)
+		}
+		if len(req.Params) != 2 {
+			t.Fatalf("expected 2 params, got %d", len(req.Params))
+		}
+		config, _ := req.Params[1].(map[string]interface{})
+		if config["searchTransactionHistory"] != true {
+			t.Errorf("expected searchTransactionHistory=true, got %v", req.Params[1])
+		}
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"context": map[string]interface{}{"slot": 500},
+				"value": []interface{}{
+					map[string]interface{}{"slot": 100, "confirmations": nil, "err": nil, "confirmationStatus": "finalized"},
+					nil,
+					map[string]interface{}{"slot": 490, "confirmations": 10, "err": nil, "confirmationStatus": "confirmed"},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL)
+
+	statuses, err := client.GetSignatureStatuses(context.Background(), []string{"final", "dropped", "recent"})
+	if err != nil {
+		t.Fatalf("GetSignatureStatuses: %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("expected 3 statuses, got %d", len(statuses))
+	}
+	if statuses[0] == nil || !statuses[0].Finalized() || statuses[0].Slot != 100 {
+		t.Errorf("expected finalized status at slot 100, got %+v", statuses[0])
+	}
+	if statuses[1] != nil {
+		t.Errorf("expected nil status for unknown signature, got %+v", statuses[1])
+	}
+	if statuses[2] == nil || statuses[2].Finalized() || statuses[2].Confirmations == nil || *statuses[2].Confirmations != 10 {
+		t.Errorf("expected confirmed status with 10 confirmations, got %+v", statuses[2])
+	}
+}
+
 func TestHTTPClient_GetBlock(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var req rpcRequest
@@ -398,3 +456,279 @@ func TestHTTPClient_ContextCancellation(t *testing.T) {
 		t.Fatal("expected error from cancelled context")
 	}
 }
+
+func TestHTTPClient_TokenHolderMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getTokenLargestAccounts":
+			result = map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": []map[string]interface{}{
+					{"address": "acct1", "amount": "7000", "decimals": 2, "uiAmount": 70.0, "uiAmountString": "70"},
+					{"address": "acct2", "amount": "3000", "decimals": 2, "uiAmount": 30.0, "uiAmountString": "30"},
+				},
+			}
+		case "getTokenSupply":
+			result = map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value":   map[string]interface{}{"amount": "10000", "decimals": 2, "uiAmount": 100.0, "uiAmountString": "100"},
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL)
+	ctx := context.Background()
+
+	accounts, err := client.GetTokenLargestAccounts(ctx, "mint1")
+	if err != nil {
+		t.Fatalf("GetTokenLargestAccounts: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("expected 2 accounts, got %d", len(accounts))
+	}
+	if accounts[0].Address != "acct1" || accounts[0].Amount != "7000" || accounts[0].Decimals != 2 {
+		t.Errorf("unexpected first account: %+v", accounts[0])
+	}
+
+	supply, err := client.GetTokenSupply(ctx, "mint1")
+	if err != nil {
+		t.Fatalf("GetTokenSupply: %v", err)
+	}
+	if supply.Amount != "10000" || supply.Decimals != 2 {
+		t.Errorf("unexpected supply: %+v", supply)
+	}
+}
+
+// rpcErrorServer answers every request with the given JSON-RPC error code.
+func rpcErrorServer(code int, message string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error":   map[string]interface{}{"code": code, "message": message},
+		})
+	}))
+}
+
+func TestHTTPClient_ErrorTaxonomy(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		message string
+		want    error
+	}{
+		{"slot skipped", -32007, "Slot 100 was skipped, or missing due to ledger jump to recent snapshot", ErrNotFound},
+		{"long-term storage", -32009, "Slot 100 was skipped, or missing in long-term storage", ErrNotFound},
+		{"history missing", -32011, "Transaction history is not available from this node", ErrNotFound},
+		{"block not available", -32004, "Block not available for slot 100", ErrNodeBehind},
+		{"node unhealthy", -32005, "Node is behind by 42 slots", ErrNodeBehind},
+		{"min context slot", -32016, "Minimum context slot has not been reached", ErrNodeBehind},
+		{"rate limited", -32429, "rate limit exceeded", ErrRateLimited},
+		{"invalid request", -32600, "Invalid Request", nil},
+	}
+
+	typed := []error{ErrNotFound, ErrNodeBehind, ErrRateLimited, ErrTimeout}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := rpcErrorServer(tt.code, tt.message)
+			defer server.Close()
+
+			client := NewHTTPClient(server.URL, WithMaxRetries(1), WithRetryDelay(time.Millisecond))
+			_, err := client.GetBlock(context.Background(), 100)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			for _, target := range typed {
+				if got := errors.Is(err, target); got != (target == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, target, got)
+				}
+			}
+			if got, want := IsRetryable(err), tt.want == ErrNodeBehind || tt.want == ErrRateLimited; got != want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", err, got, want)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_HTTP429IsRateLimited(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, WithMaxRetries(2), WithRetryDelay(time.Millisecond))
+	_, err := client.GetSlot(context.Background())
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestHTTPClient_RequestTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL,
+		WithRequestTimeout(20*time.Millisecond),
+		WithMaxRetries(1),
+		WithRetryDelay(time.Millisecond),
+	)
+	_, err := client.GetSlot(context.Background())
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Error("expected timeout to be retryable")
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected each attempt to time out and retry (2 attempts), got %d", attempts.Load())
+	}
+}
+
+func TestHTTPClient_CallerDeadlineIsNotTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, WithRequestTimeout(time.Second), WithRetryDelay(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetSlot(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected caller's context error, got %v", err)
+	}
+	if errors.Is(err, ErrTimeout) {
+		t.Error("caller deadline must not be reported as ErrTimeout")
+	}
+}
+
+func TestHTTPClient_SlowRequestLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		time.Sleep(30 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": int64(1)})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewHTTPClient(server.URL, WithSlowRequestLog(10*time.Millisecond, log.New(&buf, "", 0)))
+	if _, err := client.GetSlot(context.Background()); err != nil {
+		t.Fatalf("GetSlot: %v", err)
+	}
+	if !strings.Contains(buf.String(), "slow request getSlot took") {
+		t.Errorf("expected slow request log, got %q", buf.String())
+	}
+
+	buf.Reset()
+	fast := NewHTTPClient(server.URL, WithSlowRequestLog(time.Second, log.New(&buf, "", 0)))
+	if _, err := fast.GetSlot(context.Background()); err != nil {
+		t.Fatalf("GetSlot: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no log under threshold, got %q", buf.String())
+	}
+}
+
+func TestHTTPClient_GetBlocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64  `json:"id"`
+			Method string  `json:"method"`
+			Params []int64 `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if req.Method != "getBlocks" {
+			t.Errorf("expected method getBlocks, got %s", req.Method)
+		}
+		if len(req.Params) != 2 || req.Params[0] != 100 || req.Params[1] != 104 {
+			t.Errorf("expected params [100 104], got %v", req.Params)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  []int64{100, 101, 103},
+		})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL)
+	slots, err := client.GetBlocks(context.Background(), 100, 104)
+	if err != nil {
+		t.Fatalf("GetBlocks: %v", err)
+	}
+	if len(slots) != 3 || slots[0] != 100 || slots[1] != 101 || slots[2] != 103 {
+		t.Errorf("expected [100 101 103], got %v", slots)
+	}
+}
+
+func TestHTTPClient_GetBlockSignatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64        `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if req.Method != "getBlock" {
+			t.Errorf("expected method getBlock, got %s", req.Method)
+		}
+		if cfg, ok := req.Params[1].(map[string]interface{}); !ok || cfg["transactionDetails"] != "signatures" {
+			t.Errorf("expected transactionDetails=signatures, got %v", req.Params)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"blockTime":  1700000000,
+				"signatures": []string{"sig1", "sig2"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL)
+	block, err := client.GetBlockSignatures(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("GetBlockSignatures: %v", err)
+	}
+	if block.Slot != 42 || block.BlockTime == nil || *block.BlockTime != 1700000000 || len(block.Signatures) != 2 {
+		t.Errorf("unexpected block %+v", block)
+	}
+}
@@ -0,0 +1,98 @@
+package solana
+
+import (
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+// SPL Token program IDs. Both create mints with the same instruction layout.
+const (
+	TokenProgramID     = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	Token2022ProgramID = "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb"
+)
+
+// SPL Token instruction discriminators creating a mint. The mint is the first
+// account of both.
+const (
+	tokenInitializeMint  = 0
+	tokenInitializeMint2 = 20
+)
+
+// initializeMintLog is the prefix the token programs log for both instructions.
+const initializeMintLog = "Program log: Instruction: InitializeMint"
+
+// MintInitialization is an InitializeMint or InitializeMint2 instruction.
+type MintInitialization struct {
+	Mint    string // the initialized mint account
+	Program string // TokenProgramID or Token2022ProgramID
+}
+
+// LogsInitializeMint reports whether logs show a mint being initialized.
+// It is a cheap filter before fetching the transaction.
+func LogsInitializeMint(logs []string) bool {
+	for _, l := range logs {
+		if strings.HasPrefix(l, initializeMintLog) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMintInitializations returns the mints initialized by tx, in instruction
+// order: each top-level instruction followed by its inner instructions, so mints
+// created through a CPI (e.g. a launchpad's create) are found too. A mint
+// initialized twice is returned once. Accounts outside the static account keys
+// (address lookup tables) and malformed data are skipped.
+func ParseMintInitializations(tx *Transaction) []MintInitialization {
+	if tx == nil || tx.Message == nil {
+		return nil
+	}
+	keys := tx.Message.AccountKeys
+
+	inner := make(map[int][]Instruction)
+	if tx.Meta != nil {
+		for _, ii := range tx.Meta.InnerInstructions {
+			inner[ii.Index] = append(inner[ii.Index], ii.Instructions...)
+		}
+	}
+
+	var out []MintInitialization
+	seen := make(map[string]bool)
+	visit := func(ix Instruction) {
+		m, ok := parseMintInitialization(ix, keys)
+		if ok && !seen[m.Mint] {
+			seen[m.Mint] = true
+			out = append(out, m)
+		}
+	}
+	for i, ix := range tx.Message.Instructions {
+		visit(ix)
+		for _, cpi := range inner[i] {
+			visit(cpi)
+		}
+	}
+	return out
+}
+
+// parseMintInitialization decodes ix if it is a token program mint initialization.
+func parseMintInitialization(ix Instruction, keys []string) (MintInitialization, bool) {
+	if ix.ProgramIDIndex < 0 || ix.ProgramIDIndex >= len(keys) {
+		return MintInitialization{}, false
+	}
+	program := keys[ix.ProgramIDIndex]
+	if program != TokenProgramID && program != Token2022ProgramID {
+		return MintInitialization{}, false
+	}
+	data, err := base58.Decode(ix.Data)
+	if err != nil || len(data) == 0 {
+		return MintInitialization{}, false
+	}
+	if data[0] != tokenInitializeMint && data[0] != tokenInitializeMint2 {
+		return MintInitialization{}, false
+	}
+	if len(ix.Accounts) == 0 || ix.Accounts[0] < 0 || ix.Accounts[0] >= len(keys) {
+		return MintInitialization{}, false
+	}
+	return MintInitialization{Mint: keys[ix.Accounts[0]], Program: program}, true
+}
@@ -0,0 +1,96 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/mr-tron/base58"
+)
+
+// initializeMintData encodes an InitializeMint/InitializeMint2 instruction
+// (discriminator, decimals, mint authority; freeze authority omitted).
+func initializeMintData(discriminator byte) string {
+	data := make([]byte, 35)
+	data[0] = discriminator
+	data[1] = 6
+	return base58.Encode(data)
+}
+
+func TestParseMintInitializations(t *testing.T) {
+	keys := []string{"payer", "mintTop", TokenProgramID, "rent", "launchpad", "mintCPI", Token2022ProgramID}
+	tx := &Transaction{
+		Meta: &TransactionMeta{
+			InnerInstructions: []InnerInstructions{{
+				Index: 1,
+				Instructions: []Instruction{
+					{ProgramIDIndex: 6, Accounts: []int{5}, Data: initializeMintData(tokenInitializeMint2)},
+					// Transfer (3) is not a mint creation
+					{ProgramIDIndex: 2, Accounts: []int{1, 0}, Data: base58.Encode([]byte{3, 1, 0, 0, 0, 0, 0, 0, 0})},
+				},
+			}},
+		},
+		Message: &TransactionMessage{
+			AccountKeys: keys,
+			Instructions: []Instruction{
+				{ProgramIDIndex: 2, Accounts: []int{1, 3}, Data: initializeMintData(tokenInitializeMint)},
+				{ProgramIDIndex: 4, Accounts: []int{0, 5}, Data: "3Bxs4h24hBtQy9rw"},
+				// Same mint again, and an account index only resolvable via lookup tables
+				{ProgramIDIndex: 2, Accounts: []int{1}, Data: initializeMintData(tokenInitializeMint2)},
+				{ProgramIDIndex: 2, Accounts: []int{42}, Data: initializeMintData(tokenInitializeMint2)},
+			},
+		},
+	}
+
+	got := ParseMintInitializations(tx)
+	want := []MintInitialization{
+		{Mint: "mintTop", Program: TokenProgramID},
+		{Mint: "mintCPI", Program: Token2022ProgramID},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d mints, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mint %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	if got := ParseMintInitializations(&Transaction{}); got != nil {
+		t.Errorf("expected no mints without a message, got %+v", got)
+	}
+}
+
+func TestLogsInitializeMint(t *testing.T) {
+	if !LogsInitializeMint([]string{"Program " + TokenProgramID + " invoke [2]", "Program log: Instruction: InitializeMint2"}) {
+		t.Error("expected InitializeMint2 log to match")
+	}
+	if LogsInitializeMint([]string{"Program log: Instruction: Transfer", "Program log: Instruction: InitializeAccount3"}) {
+		t.Error("expected no match without a mint initialization")
+	}
+}
+
+func TestTransactionJSON_InnerInstructionsRoundTrip(t *testing.T) {
+	tx := &Transaction{
+		Slot:      7,
+		Signature: "sig",
+		Meta: &TransactionMeta{InnerInstructions: []InnerInstructions{{
+			Index:        0,
+			Instructions: []Instruction{{ProgramIDIndex: 1, Accounts: []int{0}, Data: initializeMintData(tokenInitializeMint2)}},
+		}}},
+		Message: &TransactionMessage{
+			AccountKeys:  []string{"mintX", TokenProgramID, "launchpad"},
+			Instructions: []Instruction{{ProgramIDIndex: 2, Accounts: []int{0}}},
+		},
+	}
+	data, err := EncodeTransactionJSON(tx)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoded, err := DecodeTransactionJSON(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	got := ParseMintInitializations(decoded)
+	if len(got) != 1 || got[0].Mint != "mintX" {
+		t.Errorf("expected mintX from decoded inner instructions, got %+v", got)
+	}
+}
@@ -0,0 +1,67 @@
+package solana
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeTransactionJSON renders tx in the getTransaction response shape ("json" encoding),
+// carrying the fields this package reads. DecodeTransactionJSON reverses it.
+func EncodeTransactionJSON(tx *Transaction) ([]byte, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("encode transaction: nil transaction")
+	}
+
+	blockTime := tx.BlockTime
+	result := getTransactionResult{
+		Slot:        tx.Slot,
+		BlockTime:   &blockTime,
+		Transaction: &getTransactionTx{},
+	}
+	if tx.Signature != "" {
+		result.Transaction.Signatures = []string{tx.Signature}
+	}
+	if tx.Meta != nil {
+		result.Meta = &getTransactionMeta{
+			Err:         tx.Meta.Err,
+			LogMessages: tx.Meta.LogMessages,
+			Fee:         tx.Meta.Fee,
+		}
+		for _, inner := range tx.Meta.InnerInstructions {
+			result.Meta.InnerInstructions = append(result.Meta.InnerInstructions, getTransactionInnerInstructions{
+				Index:        inner.Index,
+				Instructions: fromInstructions(inner.Instructions),
+			})
+		}
+	}
+	if tx.Message != nil {
+		result.Transaction.Message = &getTransactionMessage{
+			AccountKeys:  tx.Message.AccountKeys,
+			Instructions: fromInstructions(tx.Message.Instructions),
+		}
+	}
+
+	return json.Marshal(result)
+}
+
+// DecodeTransactionJSON parses a getTransaction response (as written by EncodeTransactionJSON
+// or returned by the RPC). The signature is the first transaction signature.
+func DecodeTransactionJSON(data []byte) (*Transaction, error) {
+	var result getTransactionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+
+	tx := &Transaction{Slot: result.Slot}
+	if result.BlockTime != nil {
+		tx.BlockTime = *result.BlockTime
+	}
+	tx.Meta = result.Meta.toMeta()
+	if result.Transaction != nil {
+		if len(result.Transaction.Signatures) > 0 {
+			tx.Signature = result.Transaction.Signatures[0]
+		}
+		tx.Message = result.Transaction.Message.toMessage()
+	}
+	return tx, nil
+}
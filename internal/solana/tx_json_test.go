@@ -0,0 +1,60 @@
+package solana
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransactionJSON_RoundTrip(t *testing.T) {
+	tx := &Transaction{
+		Slot:      12345,
+		Signature: "sig1",
+		BlockTime: 1700000000,
+		Meta: &TransactionMeta{
+			LogMessages: []string{"Program log: Instruction: Swap"},
+			Fee:         5000,
+		},
+		Message: &TransactionMessage{
+			AccountKeys: []string{"payer", "program"},
+			Instructions: []Instruction{
+				{ProgramIDIndex: 1, Accounts: []int{0}, Data: "3Bxs"},
+			},
+		},
+	}
+
+	data, err := EncodeTransactionJSON(tx)
+	if err != nil {
+		t.Fatalf("EncodeTransactionJSON: %v", err)
+	}
+	got, err := DecodeTransactionJSON(data)
+	if err != nil {
+		t.Fatalf("DecodeTransactionJSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, tx) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", got, tx)
+	}
+}
+
+func TestDecodeTransactionJSON_RPCResponse(t *testing.T) {
+	data := []byte(`{
+		"slot": 99,
+		"blockTime": 1700000001,
+		"meta": {"err": {"InstructionError": [0, "Custom"]}, "logMessages": ["a"], "fee": 10},
+		"transaction": {"signatures": ["sigA", "sigB"], "message": {"accountKeys": ["k"], "instructions": []}}
+	}`)
+
+	tx, err := DecodeTransactionJSON(data)
+	if err != nil {
+		t.Fatalf("DecodeTransactionJSON: %v", err)
+	}
+	if tx.Slot != 99 || tx.BlockTime != 1700000001 || tx.Signature != "sigA" {
+		t.Errorf("unexpected header: %+v", tx)
+	}
+	if tx.Meta == nil || tx.Meta.Err == nil || tx.Meta.Fee != 10 {
+		t.Errorf("unexpected meta: %+v", tx.Meta)
+	}
+
+	if _, err := DecodeTransactionJSON([]byte(`not json`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
@@ -8,6 +8,26 @@ type SignatureInfo struct {
 	Err       interface{}
 }
 
+// Commitment levels reported in SignatureStatus.ConfirmationStatus.
+const (
+	CommitmentProcessed = "processed"
+	CommitmentConfirmed = "confirmed"
+	CommitmentFinalized = "finalized"
+)
+
+// SignatureStatus from getSignatureStatuses.
+type SignatureStatus struct {
+	Slot               int64
+	Confirmations      *int // nil once finalized
+	Err                interface{}
+	ConfirmationStatus string // CommitmentProcessed | CommitmentConfirmed | CommitmentFinalized
+}
+
+// Finalized reports whether the transaction is rooted at finalized commitment.
+func (s *SignatureStatus) Finalized() bool {
+	return s.ConfirmationStatus == CommitmentFinalized
+}
+
 // SignaturesOpts defines optional pagination parameters for getSignaturesForAddress.
 type SignaturesOpts struct {
 	Before string // Start searching backwards from this signature
@@ -21,3 +41,24 @@ type Block struct {
 	BlockTime    *int64
 	Transactions []Transaction
 }
+
+// BlockSignatures is a block without transaction details: the first signature
+// of each of its transactions.
+type BlockSignatures struct {
+	Slot       int64
+	BlockTime  *int64
+	Signatures []string
+}
+
+// TokenAmount is an SPL token amount as returned by token RPC methods.
+// Amount is the raw integer amount (base units) as a decimal string.
+type TokenAmount struct {
+	Amount   string
+	Decimals int
+}
+
+// TokenAccountBalance is one entry of getTokenLargestAccounts.
+type TokenAccountBalance struct {
+	Address string
+	TokenAmount
+}
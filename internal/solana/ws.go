@@ -7,6 +7,9 @@ type WSClient interface {
 	// SubscribeLogs subscribes to program logs matching the filter.
 	SubscribeLogs(ctx context.Context, filter LogsFilter) (<-chan LogNotification, error)
 
+	// UnsubscribeLogs cancels the subscription returned as ch by SubscribeLogs.
+	UnsubscribeLogs(ctx context.Context, ch <-chan LogNotification) error
+
 	// Close closes the WebSocket connection.
 	Close() error
 }
@@ -3,6 +3,7 @@ package solana
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -38,8 +39,9 @@ func DefaultWSConfig() WSClientConfig {
 
 // WSClientImpl implements WSClient using gorilla/websocket.
 type WSClientImpl struct {
-	endpoint string
-	config   WSClientConfig
+	endpoints   []string // primary first, then failover endpoints
+	endpointIdx int      // index into endpoints of the current connection, guarded by connMu
+	config      WSClientConfig
 
 	conn      *websocket.Conn
 	connMu    sync.Mutex
@@ -54,8 +56,8 @@ type WSClientImpl struct {
 	activeFilters   map[int64]LogsFilter
 	activeFiltersMu sync.RWMutex
 
-	// pendingSubs maps request ID to channel waiting for subscription ID
-	pendingSubs   map[uint64]chan int64
+	// pendingSubs maps request ID to the subscription waiting for its ID
+	pendingSubs   map[uint64]*pendingSubscription
 	pendingSubsMu sync.Mutex
 
 	// done signals shutdown
@@ -68,22 +70,40 @@ type WSClientImpl struct {
 
 // NewWSClient creates a new WebSocket client and connects to the endpoint.
 func NewWSClient(ctx context.Context, endpoint string, config *WSClientConfig) (*WSClientImpl, error) {
+	return NewWSClientWithEndpoints(ctx, []string{endpoint}, config)
+}
+
+// NewWSClientWithEndpoints creates a WebSocket client that connects to the
+// first reachable endpoint, in order. SwitchEndpoint moves to the next one.
+func NewWSClientWithEndpoints(ctx context.Context, endpoints []string, config *WSClientConfig) (*WSClientImpl, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no websocket endpoints")
+	}
 	cfg := DefaultWSConfig()
 	if config != nil {
 		cfg = *config
 	}
 
 	c := &WSClientImpl{
-		endpoint:      endpoint,
+		endpoints:     append([]string(nil), endpoints...),
 		config:        cfg,
 		subs:          make(map[int64]chan LogNotification),
 		activeFilters: make(map[int64]LogsFilter),
-		pendingSubs:   make(map[uint64]chan int64),
+		pendingSubs:   make(map[uint64]*pendingSubscription),
 		done:          make(chan struct{}),
 	}
 
-	if err := c.connect(ctx); err != nil {
-		return nil, err
+	var errs []error
+	for i := range c.endpoints {
+		c.endpointIdx = i
+		err := c.connect(ctx)
+		if err == nil {
+			break
+		}
+		errs = append(errs, err)
+		if len(errs) == len(c.endpoints) {
+			return nil, errors.Join(errs...)
+		}
 	}
 
 	// Start reader goroutine
@@ -106,99 +126,115 @@ func (c *WSClientImpl) connect(ctx context.Context) error {
 		HandshakeTimeout: 10 * time.Second,
 	}
 
-	conn, _, err := dialer.DialContext(ctx, c.endpoint, nil)
+	conn, _, err := dialer.DialContext(ctx, c.endpoints[c.endpointIdx], nil)
 	if err != nil {
-		return fmt.Errorf("websocket dial: %w", err)
+		return fmt.Errorf("websocket dial %s: %w", c.endpoints[c.endpointIdx], err)
 	}
 
 	c.conn = conn
 	return nil
 }
 
-// SubscribeLogs subscribes to program logs matching the filter.
-func (c *WSClientImpl) SubscribeLogs(ctx context.Context, filter LogsFilter) (<-chan LogNotification, error) {
+// Endpoint returns the endpoint of the current connection.
+func (c *WSClientImpl) Endpoint() string {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.endpoints[c.endpointIdx]
+}
+
+// SwitchEndpoint drops the current connection, connects to the next endpoint
+// (the same one when only one is configured) and resubscribes every active
+// subscription there. Subscription channels are kept, so readers are not
+// affected. It returns the new endpoint; on a failed dial the client stays on
+// it and the read loop keeps retrying as after any disconnect.
+func (c *WSClientImpl) SwitchEndpoint(ctx context.Context) (string, error) {
 	if c.closed.Load() {
-		return nil, fmt.Errorf("client closed")
+		return "", fmt.Errorf("client closed")
+	}
+	if c.reconnecting.Swap(true) {
+		return "", fmt.Errorf("reconnect in progress")
 	}
+	defer c.reconnecting.Store(false)
 
-	reqID := c.requestID.Add(1)
+	c.connMu.Lock()
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+	c.endpointIdx = (c.endpointIdx + 1) % len(c.endpoints)
+	endpoint := c.endpoints[c.endpointIdx]
+	c.connMu.Unlock()
 
-	// Build subscription request
-	mentionsFilter := make(map[string]interface{})
-	if len(filter.Mentions) > 0 {
-		mentionsFilter["mentions"] = filter.Mentions
-	} else {
-		mentionsFilter["all"] = nil
+	if err := c.connect(ctx); err != nil {
+		return endpoint, err
+	}
+	if err := c.resubscribeAll(); err != nil {
+		return endpoint, fmt.Errorf("resubscribe on %s: %w", endpoint, err)
+	}
+	return endpoint, nil
+}
+
+// SubscribeLogs subscribes to program logs matching the filter.
+func (c *WSClientImpl) SubscribeLogs(ctx context.Context, filter LogsFilter) (<-chan LogNotification, error) {
+	// Create notification channel with large buffer for backpressure
+	// Blocking send ensures no event loss; buffer absorbs burst
+	ch := make(chan LogNotification, 10000)
+	if _, err := c.subscribe(ctx, filter, ch, 0); err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// UnsubscribeLogs cancels the subscription that delivers to ch.
+// The channel is not closed (the read loop may still hold it); callers stop
+// reading from it instead. Unknown channels are ignored.
+func (c *WSClientImpl) UnsubscribeLogs(ctx context.Context, ch <-chan LogNotification) error {
+	subID, ok := c.dropSubscription(ch)
+	if !ok || c.closed.Load() {
+		return nil
 	}
 
 	req := wsRequest{
 		JSONRPC: "2.0",
-		ID:      reqID,
-		Method:  "logsSubscribe",
-		Params: []interface{}{
-			mentionsFilter,
-			map[string]string{"commitment": "confirmed"},
-		},
+		ID:      c.requestID.Add(1),
+		Method:  "logsUnsubscribe",
+		Params:  []interface{}{subID},
 	}
 
-	// Create channel to receive subscription ID
-	confirmCh := make(chan int64, 1)
-	c.pendingSubsMu.Lock()
-	c.pendingSubs[reqID] = confirmCh
-	c.pendingSubsMu.Unlock()
-
-	// Send subscribe request
+	// Fire and forget: once the mapping is dropped, late notifications are discarded
 	c.connMu.Lock()
+	defer c.connMu.Unlock()
 	if c.conn == nil {
-		c.connMu.Unlock()
-		c.pendingSubsMu.Lock()
-		delete(c.pendingSubs, reqID)
-		c.pendingSubsMu.Unlock()
-		return nil, fmt.Errorf("not connected")
+		return fmt.Errorf("not connected")
 	}
-
 	c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout))
-	err := c.conn.WriteJSON(req)
-	c.connMu.Unlock()
-
-	if err != nil {
-		c.pendingSubsMu.Lock()
-		delete(c.pendingSubs, reqID)
-		c.pendingSubsMu.Unlock()
-		return nil, fmt.Errorf("write subscribe: %w", err)
+	if err := c.conn.WriteJSON(req); err != nil {
+		return fmt.Errorf("write unsubscribe: %w", err)
 	}
+	return nil
+}
 
-	// Wait for subscription confirmation (30s timeout for slow providers)
+// dropSubscription removes the subscription delivering to ch from the
+// dispatch and resubscription maps, returning its current ID.
+func (c *WSClientImpl) dropSubscription(ch <-chan LogNotification) (int64, bool) {
+	c.subsMu.Lock()
 	var subID int64
-	select {
-	case subID = <-confirmCh:
-	case <-time.After(30 * time.Second):
-		c.pendingSubsMu.Lock()
-		delete(c.pendingSubs, reqID)
-		c.pendingSubsMu.Unlock()
-		return nil, fmt.Errorf("subscription timeout after 30s")
-	case <-c.done:
-		return nil, fmt.Errorf("client closed")
-	case <-ctx.Done():
-		c.pendingSubsMu.Lock()
-		delete(c.pendingSubs, reqID)
-		c.pendingSubsMu.Unlock()
-		return nil, ctx.Err()
+	found := false
+	for id, sub := range c.subs {
+		if (<-chan LogNotification)(sub) == ch {
+			subID, found = id, true
+			delete(c.subs, id)
+			break
+		}
 	}
-
-	// Create notification channel with large buffer for backpressure
-	// Blocking send ensures no event loss; buffer absorbs burst
-	ch := make(chan LogNotification, 10000)
-	c.subsMu.Lock()
-	c.subs[subID] = ch
 	c.subsMu.Unlock()
 
-	// Store filter for resubscription after reconnect
-	c.activeFiltersMu.Lock()
-	c.activeFilters[subID] = filter
-	c.activeFiltersMu.Unlock()
-
-	return ch, nil
+	if found {
+		c.activeFiltersMu.Lock()
+		delete(c.activeFilters, subID)
+		c.activeFiltersMu.Unlock()
+	}
+	return subID, found
 }
 
 // Close closes the WebSocket connection.
@@ -227,8 +263,8 @@ func (c *WSClientImpl) Close() error {
 
 	// Close pending subscription channels
 	c.pendingSubsMu.Lock()
-	for id, ch := range c.pendingSubs {
-		close(ch)
+	for id, pending := range c.pendingSubs {
+		close(pending.confirm)
 		delete(c.pendingSubs, id)
 	}
 	c.pendingSubsMu.Unlock()
@@ -249,6 +285,11 @@ func (c *WSClientImpl) readLoop() {
 		c.connMu.Unlock()
 
 		if conn == nil {
+			// A failed reconnect or endpoint switch leaves no connection: retry
+			if !c.reconnecting.Swap(true) {
+				go c.reconnect(reconnectDelay)
+				reconnectDelay = min(reconnectDelay*2, c.config.MaxReconnectDelay)
+			}
 			select {
 			case <-c.done:
 				return
@@ -323,12 +364,13 @@ func (c *WSClientImpl) reconnect(delay time.Duration) {
 		return
 	}
 
-	// Resubscribe to all active subscriptions
-	c.resubscribeAll()
+	// Resubscribe to all active subscriptions; failed ones keep their old
+	// mapping and deliver nothing until the next reconnect
+	_ = c.resubscribeAll()
 }
 
 // resubscribeAll resubscribes to all active filters after reconnect.
-func (c *WSClientImpl) resubscribeAll() {
+func (c *WSClientImpl) resubscribeAll() error {
 	c.activeFiltersMu.RLock()
 	filters := make(map[int64]LogsFilter)
 	for id, f := range c.activeFilters {
@@ -343,43 +385,45 @@ func (c *WSClientImpl) resubscribeAll() {
 	}
 	c.subsMu.RUnlock()
 
+	var errs []error
 	for oldSubID, filter := range filters {
 		ch := channels[oldSubID]
 		if ch == nil {
 			continue
 		}
 
-		// Resubscribe
+		// Resubscribe; the confirmation moves ch to the new subscription ID
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		newSubID, err := c.subscribeLogsInternal(ctx, filter)
+		_, err := c.subscribe(ctx, filter, ch, oldSubID)
 		cancel()
 
 		if err != nil {
 			// Failed to resubscribe, keep old mapping
-			continue
+			errs = append(errs, fmt.Errorf("resubscribe %v: %w", filter.Mentions, err))
 		}
-
-		// Update mappings with new subscription ID
-		c.subsMu.Lock()
-		delete(c.subs, oldSubID)
-		c.subs[newSubID] = ch
-		c.subsMu.Unlock()
-
-		c.activeFiltersMu.Lock()
-		delete(c.activeFilters, oldSubID)
-		c.activeFilters[newSubID] = filter
-		c.activeFiltersMu.Unlock()
 	}
+	return errors.Join(errs...)
+}
+
+// pendingSubscription is a logsSubscribe request waiting for its subscription ID.
+type pendingSubscription struct {
+	confirm chan int64
+	ch      chan LogNotification // notification channel to map the ID to
+	filter  LogsFilter
+	oldID   int64 // subscription of ch replaced by a resubscription, 0 for a new one
 }
 
-// subscribeLogsInternal subscribes without storing channel/filter.
-func (c *WSClientImpl) subscribeLogsInternal(ctx context.Context, filter LogsFilter) (int64, error) {
+// subscribe sends logsSubscribe for filter and waits for the subscription ID.
+// The read loop maps the ID to ch (replacing oldID) before it reads the next
+// message, so notifications sent right after the confirmation are not dropped.
+func (c *WSClientImpl) subscribe(ctx context.Context, filter LogsFilter, ch chan LogNotification, oldID int64) (int64, error) {
 	if c.closed.Load() {
 		return 0, fmt.Errorf("client closed")
 	}
 
 	reqID := c.requestID.Add(1)
 
+	// Build subscription request
 	mentionsFilter := make(map[string]interface{})
 	if len(filter.Mentions) > 0 {
 		mentionsFilter["mentions"] = filter.Mentions
@@ -397,11 +441,12 @@ func (c *WSClientImpl) subscribeLogsInternal(ctx context.Context, filter LogsFil
 		},
 	}
 
-	confirmCh := make(chan int64, 1)
+	pending := &pendingSubscription{confirm: make(chan int64, 1), ch: ch, filter: filter, oldID: oldID}
 	c.pendingSubsMu.Lock()
-	c.pendingSubs[reqID] = confirmCh
+	c.pendingSubs[reqID] = pending
 	c.pendingSubsMu.Unlock()
 
+	// Send subscribe request
 	c.connMu.Lock()
 	if c.conn == nil {
 		c.connMu.Unlock()
@@ -422,8 +467,12 @@ func (c *WSClientImpl) subscribeLogsInternal(ctx context.Context, filter LogsFil
 		return 0, fmt.Errorf("write subscribe: %w", err)
 	}
 
+	// Wait for subscription confirmation (30s timeout for slow providers)
 	select {
-	case subID := <-confirmCh:
+	case subID, ok := <-pending.confirm:
+		if !ok {
+			return 0, fmt.Errorf("client closed")
+		}
 		return subID, nil
 	case <-time.After(30 * time.Second):
 		c.pendingSubsMu.Lock()
@@ -474,17 +523,36 @@ func (c *WSClientImpl) handleMessage(message []byte) {
 // handleSubscribeResponse handles subscription confirmation.
 func (c *WSClientImpl) handleSubscribeResponse(resp *wsSubscribeResponse) {
 	c.pendingSubsMu.Lock()
-	ch, ok := c.pendingSubs[resp.ID]
+	pending, ok := c.pendingSubs[resp.ID]
 	if ok {
 		delete(c.pendingSubs, resp.ID)
 	}
 	c.pendingSubsMu.Unlock()
 
-	if ok {
-		select {
-		case ch <- resp.Result:
-		default:
-		}
+	if !ok {
+		return
+	}
+
+	c.subsMu.Lock()
+	// A resubscription only takes over if the channel was not unsubscribed meanwhile
+	register := pending.oldID == 0 || c.subs[pending.oldID] == pending.ch
+	if register {
+		delete(c.subs, pending.oldID)
+		c.subs[resp.Result] = pending.ch
+	}
+	c.subsMu.Unlock()
+
+	if register {
+		// Store filter for resubscription after reconnect
+		c.activeFiltersMu.Lock()
+		delete(c.activeFilters, pending.oldID)
+		c.activeFilters[resp.Result] = pending.filter
+		c.activeFiltersMu.Unlock()
+	}
+
+	select {
+	case pending.confirm <- resp.Result:
+	default:
 	}
 }
 
@@ -268,3 +268,188 @@ func TestWSClient_CustomConfig(t *testing.T) {
 		t.Errorf("expected PingInterval 5s, got %v", client.config.PingInterval)
 	}
 }
+
+func TestWSClient_UnsubscribeLogs(t *testing.T) {
+	unsubscribed := make(chan wsRequest, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsRequest
+			if err := json.Unmarshal(msg, &req); err != nil {
+				t.Errorf("unmarshal request: %v", err)
+				return
+			}
+			switch req.Method {
+			case "logsSubscribe":
+				c.WriteJSON(wsSubscribeResponse{JSONRPC: "2.0", ID: req.ID, Result: 777})
+			case "logsUnsubscribe":
+				unsubscribed <- req
+				c.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": true})
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx := context.Background()
+	client, err := NewWSClient(ctx, wsURL, nil)
+	if err != nil {
+		t.Fatalf("NewWSClient: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.SubscribeLogs(ctx, LogsFilter{Mentions: []string{"testprogram"}})
+	if err != nil {
+		t.Fatalf("SubscribeLogs: %v", err)
+	}
+
+	if err := client.UnsubscribeLogs(ctx, ch); err != nil {
+		t.Fatalf("UnsubscribeLogs: %v", err)
+	}
+
+	select {
+	case req := <-unsubscribed:
+		if len(req.Params) != 1 || req.Params[0] != float64(777) {
+			t.Errorf("expected unsubscribe of subscription 777, got params %v", req.Params)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for logsUnsubscribe")
+	}
+
+	client.subsMu.RLock()
+	remaining := len(client.subs)
+	client.subsMu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("expected subscription to be dropped, %d remain", remaining)
+	}
+
+	// A second unsubscribe of the same channel is a no-op
+	if err := client.UnsubscribeLogs(ctx, ch); err != nil {
+		t.Errorf("repeated UnsubscribeLogs: %v", err)
+	}
+}
+
+func TestWSClient_SwitchEndpoint(t *testing.T) {
+	// newServer answers logsSubscribe with subID and pushes one notification
+	// for it, reporting the subscribed filters on subscribed.
+	newServer := func(subID int64, signature string, subscribed chan<- []interface{}) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer c.Close()
+			for {
+				_, msg, err := c.ReadMessage()
+				if err != nil {
+					return
+				}
+				var req wsRequest
+				if err := json.Unmarshal(msg, &req); err != nil || req.Method != "logsSubscribe" {
+					continue
+				}
+				subscribed <- req.Params
+				c.WriteJSON(wsSubscribeResponse{JSONRPC: "2.0", ID: req.ID, Result: subID})
+				time.Sleep(50 * time.Millisecond) // let the client map subID to its channel
+				c.WriteJSON(wsNotification{
+					JSONRPC: "2.0",
+					Method:  "logsNotification",
+					Params: &wsNotificationParams{
+						Subscription: subID,
+						Result:       wsNotificationResult{Value: wsLogsValue{Signature: signature}},
+					},
+				})
+			}
+		}))
+	}
+
+	primarySubs := make(chan []interface{}, 1)
+	secondarySubs := make(chan []interface{}, 1)
+	primary := newServer(1, "sig-primary", primarySubs)
+	defer primary.Close()
+	secondary := newServer(2, "sig-secondary", secondarySubs)
+	defer secondary.Close()
+
+	primaryURL := "ws" + strings.TrimPrefix(primary.URL, "http")
+	secondaryURL := "ws" + strings.TrimPrefix(secondary.URL, "http")
+
+	ctx := context.Background()
+	client, err := NewWSClientWithEndpoints(ctx, []string{primaryURL, secondaryURL}, nil)
+	if err != nil {
+		t.Fatalf("NewWSClientWithEndpoints: %v", err)
+	}
+	defer client.Close()
+
+	ch, err := client.SubscribeLogs(ctx, LogsFilter{Mentions: []string{"testprogram"}})
+	if err != nil {
+		t.Fatalf("SubscribeLogs: %v", err)
+	}
+	<-primarySubs
+	if n := <-ch; n.Signature != "sig-primary" {
+		t.Fatalf("expected sig-primary, got %s", n.Signature)
+	}
+
+	endpoint, err := client.SwitchEndpoint(ctx)
+	if err != nil {
+		t.Fatalf("SwitchEndpoint: %v", err)
+	}
+	if endpoint != secondaryURL || client.Endpoint() != secondaryURL {
+		t.Errorf("expected switch to %s, got %s", secondaryURL, endpoint)
+	}
+
+	// The subscription moved to the secondary and keeps delivering on ch
+	select {
+	case params := <-secondarySubs:
+		if mentions := params[0].(map[string]interface{})["mentions"]; mentions.([]interface{})[0] != "testprogram" {
+			t.Errorf("expected resubscription to testprogram, got %v", params)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for resubscription")
+	}
+	select {
+	case n := <-ch:
+		if n.Signature != "sig-secondary" {
+			t.Errorf("expected sig-secondary, got %s", n.Signature)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for notification from the secondary")
+	}
+}
+
+func TestWSClient_SkipsUnreachableEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, err := NewWSClientWithEndpoints(context.Background(), []string{"ws://127.0.0.1:1", wsURL}, nil)
+	if err != nil {
+		t.Fatalf("NewWSClientWithEndpoints: %v", err)
+	}
+	defer client.Close()
+
+	if client.Endpoint() != wsURL {
+		t.Errorf("expected %s, got %s", wsURL, client.Endpoint())
+	}
+}
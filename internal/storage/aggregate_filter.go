@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"strings"
+
+	"solana-token-lab/internal/domain"
+)
+
+// AggregateFilter selects strategy aggregates. Zero-valued fields match everything,
+// so AggregateFilter{} selects all aggregates.
+type AggregateFilter struct {
+	StrategyID string
+	ScenarioID string
+
+	// EntryEventType is the base entry event type (NEW_TOKEN | ACTIVE_TOKEN).
+	// It matches plain aggregates and their entry-filter cohorts alike.
+	EntryEventType string
+
+	// Cohort is an entry filter label: it matches aggregates stored under
+	// "<entry_event_type>:<label>" (see metrics.LabeledEntryEventType).
+	Cohort string
+
+	// ComputedAfter keeps aggregates with ComputedAt strictly after it (Unix ms).
+	ComputedAfter int64
+}
+
+// cohortSeparator splits a labeled entry_event_type into base type and cohort label.
+const cohortSeparator = ":"
+
+// Matches reports whether a satisfies the filter.
+func (f AggregateFilter) Matches(a *domain.StrategyAggregate) bool {
+	if f.StrategyID != "" && a.StrategyID != f.StrategyID {
+		return false
+	}
+	if f.ScenarioID != "" && a.ScenarioID != f.ScenarioID {
+		return false
+	}
+	base, cohort, _ := strings.Cut(a.EntryEventType, cohortSeparator)
+	if f.EntryEventType != "" && base != f.EntryEventType {
+		return false
+	}
+	if f.Cohort != "" && cohort != f.Cohort {
+		return false
+	}
+	return f.ComputedAfter == 0 || a.ComputedAt > f.ComputedAfter
+}
@@ -0,0 +1,31 @@
+package storage
+
+// CandidateDeletion audits one soft-delete of a candidate (see CandidateStore.SoftDelete).
+type CandidateDeletion struct {
+	CandidateID string
+	DeletedAt   int64  // Unix ms
+	Reason      string // e.g. "test token", "parser bug: duplicate pool"
+	Requester   string // who asked for it, e.g. the admin API client address
+}
+
+// CandidateReadOptions adjust which candidates a CandidateStore list read returns.
+type CandidateReadOptions struct {
+	IncludeDeleted bool // also return soft-deleted candidates
+}
+
+// CandidateReadOption sets a CandidateReadOptions field.
+type CandidateReadOption func(*CandidateReadOptions)
+
+// IncludeDeleted makes a candidate read also return soft-deleted candidates.
+func IncludeDeleted() CandidateReadOption {
+	return func(o *CandidateReadOptions) { o.IncludeDeleted = true }
+}
+
+// NewCandidateReadOptions applies opts to the defaults, which exclude soft-deleted candidates.
+func NewCandidateReadOptions(opts ...CandidateReadOption) CandidateReadOptions {
+	var o CandidateReadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
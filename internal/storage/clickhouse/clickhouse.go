@@ -13,6 +13,7 @@ import (
 // Conn wraps clickhouse driver.Conn for dependency injection.
 type Conn struct {
 	driver.Conn
+	database string // database selected by the DSN, "" for the server default
 }
 
 // NewConn creates a new ClickHouse connection.
@@ -33,7 +34,7 @@ func NewConn(ctx context.Context, dsn string) (*Conn, error) {
 		return nil, fmt.Errorf("ping clickhouse: %w", err)
 	}
 
-	return &Conn{Conn: conn}, nil
+	return &Conn{Conn: conn, database: opts.Auth.Database}, nil
 }
 
 // NewConnWithDatabase creates a ClickHouse connection overriding the database name.
@@ -55,7 +56,30 @@ func NewConnWithDatabase(ctx context.Context, dsn, database string) (*Conn, erro
 		return nil, fmt.Errorf("ping clickhouse: %w", err)
 	}
 
-	return &Conn{Conn: conn}, nil
+	return &Conn{Conn: conn, database: opts.Auth.Database}, nil
+}
+
+// Database returns the database the connection selects, "" for the server default.
+func (c *Conn) Database() string {
+	return c.database
+}
+
+// QualifiedTable returns table qualified with the connection's database,
+// e.g. "analytics.trade_records", or table itself without a database.
+func (c *Conn) QualifiedTable(table string) string {
+	if c.database == "" {
+		return table
+	}
+	return c.database + "." + table
+}
+
+// Version returns the ClickHouse server version, e.g. "24.3.1".
+func (c *Conn) Version() (string, error) {
+	v, err := c.Conn.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("query clickhouse server version: %w", err)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Version.Major, v.Version.Minor, v.Version.Patch), nil
 }
 
 // Close closes the connection.
@@ -57,7 +57,8 @@ func (s *DerivedFeatureStore) InsertBulk(ctx context.Context, points []*domain.D
 			candidate_id, timestamp_ms,
 			price_delta, price_velocity, price_acceleration,
 			liquidity_delta, liquidity_velocity,
-			token_lifetime_ms, last_swap_interval_ms, last_liq_event_interval_ms
+			token_lifetime_ms, last_swap_interval_ms, last_liq_event_interval_ms,
+			rolling_imbalance, pool_change
 		)
 	`)
 	if err != nil {
@@ -71,6 +72,7 @@ func (s *DerivedFeatureStore) InsertBulk(ctx context.Context, points []*domain.D
 			p.PriceDelta, p.PriceVelocity, p.PriceAcceleration,
 			p.LiquidityDelta, p.LiquidityVelocity,
 			uint64(p.TokenLifetimeMs), toNullableUint64(p.LastSwapIntervalMs), toNullableUint64(p.LastLiqEventIntervalMs),
+			p.RollingImbalance, p.PoolChange,
 		)
 		if err != nil {
 			return fmt.Errorf("append to batch: %w", err)
@@ -91,7 +93,8 @@ func (s *DerivedFeatureStore) GetByCandidateID(ctx context.Context, candidateID
 			candidate_id, timestamp_ms,
 			price_delta, price_velocity, price_acceleration,
 			liquidity_delta, liquidity_velocity,
-			token_lifetime_ms, last_swap_interval_ms, last_liq_event_interval_ms
+			token_lifetime_ms, last_swap_interval_ms, last_liq_event_interval_ms,
+			rolling_imbalance, pool_change
 		FROM derived_features
 		WHERE candidate_id = ?
 		ORDER BY timestamp_ms ASC
@@ -113,7 +116,8 @@ func (s *DerivedFeatureStore) GetByTimeRange(ctx context.Context, candidateID st
 			candidate_id, timestamp_ms,
 			price_delta, price_velocity, price_acceleration,
 			liquidity_delta, liquidity_velocity,
-			token_lifetime_ms, last_swap_interval_ms, last_liq_event_interval_ms
+			token_lifetime_ms, last_swap_interval_ms, last_liq_event_interval_ms,
+			rolling_imbalance, pool_change
 		FROM derived_features
 		WHERE candidate_id = ? AND timestamp_ms >= ? AND timestamp_ms <= ?
 		ORDER BY timestamp_ms ASC
@@ -166,6 +170,7 @@ func scanDerivedFeatures(rows chRows) ([]*domain.DerivedFeaturePoint, error) {
 			&p.PriceDelta, &p.PriceVelocity, &p.PriceAcceleration,
 			&p.LiquidityDelta, &p.LiquidityVelocity,
 			&tokenLifetimeMs, &lastSwapIntervalMs, &lastLiqEventIntervalMs,
+			&p.RollingImbalance, &p.PoolChange,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan derived features row: %w", err)
@@ -31,6 +31,7 @@ func TestDerivedFeatureStore_InsertBulk(t *testing.T) {
 	liqVelocity := 1.0
 	lastSwap := int64(500)
 	lastLiq := int64(1000)
+	imbalance := -0.25
 
 	points := []*domain.DerivedFeaturePoint{
 		{
@@ -44,6 +45,8 @@ func TestDerivedFeatureStore_InsertBulk(t *testing.T) {
 			TokenLifetimeMs:        5000,
 			LastSwapIntervalMs:     &lastSwap,
 			LastLiqEventIntervalMs: &lastLiq,
+			RollingImbalance:       &imbalance,
+			PoolChange:             true,
 		},
 	}
 
@@ -71,6 +74,9 @@ func TestDerivedFeatureStore_InsertBulk(t *testing.T) {
 	assert.Equal(t, int64(500), *got[0].LastSwapIntervalMs)
 	assert.NotNil(t, got[0].LastLiqEventIntervalMs)
 	assert.Equal(t, int64(1000), *got[0].LastLiqEventIntervalMs)
+	require.NotNil(t, got[0].RollingImbalance)
+	assert.Equal(t, -0.25, *got[0].RollingImbalance)
+	assert.True(t, got[0].PoolChange)
 }
 
 func TestDerivedFeatureStore_InsertBulk_NullableFields(t *testing.T) {
@@ -0,0 +1,71 @@
+package clickhouse
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
+)
+
+func TestMetricsQueries_RunAgainstSchema(t *testing.T) {
+	conn, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	aggStore := NewStrategyAggregateStore(conn)
+	tradeStore := NewTradeAggregateStore(conn)
+	assert.Equal(t, "test.strategy_aggregates", aggStore.TableName())
+	assert.Equal(t, "test.trade_records", tradeStore.TableName())
+
+	strategyID := "TIME_EXIT_NEW_TOKEN_300000ms"
+	var mirrored []*storage.MirroredTrade
+	for i, scenario := range []string{domain.ScenarioRealistic, domain.ScenarioPessimistic} {
+		mirrored = append(mirrored, &storage.MirroredTrade{
+			Trade:          goldenTrade("t"+scenario, "c1", strategyID, scenario, 0.5-float64(i), 1700000000000),
+			StrategyType:   domain.StrategyTypeTimeExit,
+			EntryEventType: "NEW_TOKEN",
+		})
+		require.NoError(t, aggStore.Upsert(ctx, &domain.StrategyAggregate{
+			StrategyID: strategyID, ScenarioID: scenario, EntryEventType: "NEW_TOKEN",
+			TotalTrades: 1, OutcomeMedian: 0.5 - float64(i),
+		}))
+	}
+	require.NoError(t, tradeStore.InsertMirrored(ctx, mirrored))
+
+	report := &reporting.Report{
+		DataSummary:     reporting.DataSummary{DateRangeStart: 1699990000000, DateRangeEnd: 1700010000000},
+		Reproducibility: reporting.ReproducibilityMetadata{DataVersion: "abc123"},
+	}
+	sql, err := reporting.RenderMetricsQueries(report, reporting.MetricsQueryTables{
+		StrategyAggregates: aggStore.TableName(),
+		TradeRecords:       tradeStore.TableName(),
+	})
+	require.NoError(t, err)
+
+	// Every statement parses and runs against the migrated schema
+	statements := 0
+	for _, stmt := range strings.Split(sql, ";\n") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		statements++
+		rows, err := conn.Query(ctx, stmt)
+		require.NoError(t, err, "statement %d:\n%s", statements, stmt)
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		require.NoError(t, rows.Err())
+		rows.Close()
+		if statements == 4 {
+			assert.Equal(t, 2, n, "outcome distribution should cover both scenarios in the period")
+		}
+	}
+	assert.Equal(t, 7, statements)
+}
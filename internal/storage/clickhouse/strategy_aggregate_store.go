@@ -3,6 +3,7 @@ package clickhouse
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
@@ -21,6 +22,11 @@ func NewStrategyAggregateStore(conn *Conn) *StrategyAggregateStore {
 // Compile-time interface check.
 var _ storage.StrategyAggregateStore = (*StrategyAggregateStore)(nil)
 
+// TableName returns the database-qualified name of the strategy_aggregates table.
+func (s *StrategyAggregateStore) TableName() string {
+	return s.conn.QualifiedTable("strategy_aggregates")
+}
+
 // Insert adds a new aggregate. Returns ErrDuplicateKey if key exists.
 func (s *StrategyAggregateStore) Insert(ctx context.Context, a *domain.StrategyAggregate) error {
 	// Check if exists (ReplacingMergeTree will replace, but we want append-only semantics)
@@ -32,6 +38,17 @@ func (s *StrategyAggregateStore) Insert(ctx context.Context, a *domain.StrategyA
 		return storage.ErrDuplicateKey
 	}
 
+	return s.insert(ctx, a)
+}
+
+// Upsert inserts an aggregate or replaces the stored one with the same composite key.
+// ReplacingMergeTree keeps the row with the latest created_at, and all reads use FINAL.
+func (s *StrategyAggregateStore) Upsert(ctx context.Context, a *domain.StrategyAggregate) error {
+	return s.insert(ctx, a)
+}
+
+// insert writes an aggregate row without checking for an existing key.
+func (s *StrategyAggregateStore) insert(ctx context.Context, a *domain.StrategyAggregate) error {
 	query := `
 		INSERT INTO strategy_aggregates (
 			strategy_id, scenario_id, entry_event_type,
@@ -39,24 +56,27 @@ func (s *StrategyAggregateStore) Insert(ctx context.Context, a *domain.StrategyA
 			outcome_mean, outcome_median, outcome_p10, outcome_p25, outcome_p75, outcome_p90,
 			outcome_min, outcome_max, outcome_stddev,
 			max_drawdown, max_consecutive_losses,
-			outcome_realistic, outcome_pessimistic, outcome_degraded
+			outcome_realistic, outcome_pessimistic, outcome_degraded,
+			computed_at, data_end_exits, data_end_share
 		) VALUES (
 			?, ?, ?,
 			?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?,
 			?, ?, ?,
 			?, ?,
+			?, ?, ?,
 			?, ?, ?
 		)
 	`
 
-	err = s.conn.Exec(ctx, query,
+	err := s.conn.Exec(ctx, query,
 		a.StrategyID, a.ScenarioID, a.EntryEventType,
 		a.TotalTrades, a.TotalTokens, a.Wins, a.Losses, a.WinRate, a.TokenWinRate,
 		a.OutcomeMean, a.OutcomeMedian, a.OutcomeP10, a.OutcomeP25, a.OutcomeP75, a.OutcomeP90,
 		a.OutcomeMin, a.OutcomeMax, a.OutcomeStddev,
 		a.MaxDrawdown, a.MaxConsecutiveLosses,
 		a.OutcomeRealistic, a.OutcomePessimistic, a.OutcomeDegraded,
+		a.ComputedAt, a.DataEndExits, a.DataEndShare,
 	)
 	if err != nil {
 		return fmt.Errorf("insert strategy aggregate: %w", err)
@@ -99,7 +119,8 @@ func (s *StrategyAggregateStore) InsertBulk(ctx context.Context, aggregates []*d
 			outcome_mean, outcome_median, outcome_p10, outcome_p25, outcome_p75, outcome_p90,
 			outcome_min, outcome_max, outcome_stddev,
 			max_drawdown, max_consecutive_losses,
-			outcome_realistic, outcome_pessimistic, outcome_degraded
+			outcome_realistic, outcome_pessimistic, outcome_degraded,
+			computed_at, data_end_exits, data_end_share
 		)
 	`)
 	if err != nil {
@@ -114,6 +135,7 @@ func (s *StrategyAggregateStore) InsertBulk(ctx context.Context, aggregates []*d
 			a.OutcomeMin, a.OutcomeMax, a.OutcomeStddev,
 			a.MaxDrawdown, a.MaxConsecutiveLosses,
 			a.OutcomeRealistic, a.OutcomePessimistic, a.OutcomeDegraded,
+			a.ComputedAt, a.DataEndExits, a.DataEndShare,
 		)
 		if err != nil {
 			return fmt.Errorf("append to batch: %w", err)
@@ -136,7 +158,8 @@ func (s *StrategyAggregateStore) GetByKey(ctx context.Context, strategyID, scena
 			outcome_mean, outcome_median, outcome_p10, outcome_p25, outcome_p75, outcome_p90,
 			outcome_min, outcome_max, outcome_stddev,
 			max_drawdown, max_consecutive_losses,
-			outcome_realistic, outcome_pessimistic, outcome_degraded
+			outcome_realistic, outcome_pessimistic, outcome_degraded,
+			computed_at, data_end_exits, data_end_share
 		FROM strategy_aggregates FINAL
 		WHERE strategy_id = ? AND scenario_id = ? AND entry_event_type = ?
 		LIMIT 1
@@ -152,6 +175,7 @@ func (s *StrategyAggregateStore) GetByKey(ctx context.Context, strategyID, scena
 		&a.OutcomeMin, &a.OutcomeMax, &a.OutcomeStddev,
 		&a.MaxDrawdown, &a.MaxConsecutiveLosses,
 		&a.OutcomeRealistic, &a.OutcomePessimistic, &a.OutcomeDegraded,
+		&a.ComputedAt, &a.DataEndExits, &a.DataEndShare,
 	)
 	if err != nil {
 		return nil, storage.ErrNotFound
@@ -169,7 +193,8 @@ func (s *StrategyAggregateStore) GetByStrategy(ctx context.Context, strategyID s
 			outcome_mean, outcome_median, outcome_p10, outcome_p25, outcome_p75, outcome_p90,
 			outcome_min, outcome_max, outcome_stddev,
 			max_drawdown, max_consecutive_losses,
-			outcome_realistic, outcome_pessimistic, outcome_degraded
+			outcome_realistic, outcome_pessimistic, outcome_degraded,
+			computed_at, data_end_exits, data_end_share
 		FROM strategy_aggregates FINAL
 		WHERE strategy_id = ?
 		ORDER BY scenario_id ASC, entry_event_type ASC
@@ -193,7 +218,8 @@ func (s *StrategyAggregateStore) GetAll(ctx context.Context) ([]*domain.Strategy
 			outcome_mean, outcome_median, outcome_p10, outcome_p25, outcome_p75, outcome_p90,
 			outcome_min, outcome_max, outcome_stddev,
 			max_drawdown, max_consecutive_losses,
-			outcome_realistic, outcome_pessimistic, outcome_degraded
+			outcome_realistic, outcome_pessimistic, outcome_degraded,
+			computed_at, data_end_exits, data_end_share
 		FROM strategy_aggregates FINAL
 		ORDER BY strategy_id ASC, scenario_id ASC, entry_event_type ASC
 	`
@@ -207,6 +233,61 @@ func (s *StrategyAggregateStore) GetAll(ctx context.Context) ([]*domain.Strategy
 	return scanStrategyAggregates(rows)
 }
 
+// Find retrieves aggregates matching filter, ordered by strategy, scenario, entry event type.
+func (s *StrategyAggregateStore) Find(ctx context.Context, filter storage.AggregateFilter) ([]*domain.StrategyAggregate, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	if filter.StrategyID != "" {
+		conds = append(conds, "strategy_id = ?")
+		args = append(args, filter.StrategyID)
+	}
+	if filter.ScenarioID != "" {
+		conds = append(conds, "scenario_id = ?")
+		args = append(args, filter.ScenarioID)
+	}
+	if filter.EntryEventType != "" {
+		conds = append(conds, "splitByChar(':', entry_event_type)[1] = ?")
+		args = append(args, filter.EntryEventType)
+	}
+	if filter.Cohort != "" {
+		conds = append(conds, "splitByChar(':', entry_event_type)[2] = ?")
+		args = append(args, filter.Cohort)
+	}
+	if filter.ComputedAfter != 0 {
+		conds = append(conds, "computed_at > ?")
+		args = append(args, filter.ComputedAfter)
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	query := `
+		SELECT
+			strategy_id, scenario_id, entry_event_type,
+			total_trades, total_tokens, wins, losses, win_rate, token_win_rate,
+			outcome_mean, outcome_median, outcome_p10, outcome_p25, outcome_p75, outcome_p90,
+			outcome_min, outcome_max, outcome_stddev,
+			max_drawdown, max_consecutive_losses,
+			outcome_realistic, outcome_pessimistic, outcome_degraded,
+			computed_at, data_end_exits, data_end_share
+		FROM strategy_aggregates FINAL
+		` + where + `
+		ORDER BY strategy_id ASC, scenario_id ASC, entry_event_type ASC
+	`
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query by filter: %w", err)
+	}
+	defer rows.Close()
+
+	return scanStrategyAggregates(rows)
+}
+
 // exists checks if an aggregate with the given key exists.
 func (s *StrategyAggregateStore) exists(ctx context.Context, strategyID, scenarioID, entryEventType string) (bool, error) {
 	query := `
@@ -242,6 +323,7 @@ func scanStrategyAggregates(rows chRows) ([]*domain.StrategyAggregate, error) {
 			&a.OutcomeMin, &a.OutcomeMax, &a.OutcomeStddev,
 			&a.MaxDrawdown, &a.MaxConsecutiveLosses,
 			&a.OutcomeRealistic, &a.OutcomePessimistic, &a.OutcomeDegraded,
+			&a.ComputedAt, &a.DataEndExits, &a.DataEndShare,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan aggregate row: %w", err)
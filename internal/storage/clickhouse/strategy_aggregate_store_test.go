@@ -9,6 +9,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestStrategyAggregateStore_Insert(t *testing.T) {
@@ -364,3 +365,42 @@ func TestStrategyAggregateStore_Ordering(t *testing.T) {
 	assert.Equal(t, "Z_SCENARIO", got[1].ScenarioID)
 	assert.Equal(t, "Z_STRATEGY", got[2].StrategyID)
 }
+
+func TestStrategyAggregateStore_Upsert(t *testing.T) {
+	conn, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store := NewStrategyAggregateStore(conn)
+	ctx := context.Background()
+
+	agg := &domain.StrategyAggregate{
+		StrategyID:     "TIME_EXIT_5M",
+		ScenarioID:     "REALISTIC",
+		EntryEventType: "NEW_TOKEN",
+		TotalTrades:    10,
+		OutcomeMean:    0.12,
+	}
+	require.NoError(t, store.Insert(ctx, agg))
+
+	refreshed := *agg
+	refreshed.OutcomeMean = -0.04
+	require.NoError(t, store.Upsert(ctx, &refreshed))
+
+	got, err := store.GetByKey(ctx, "TIME_EXIT_5M", "REALISTIC", "NEW_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, -0.04, got.OutcomeMean)
+
+	all, err := store.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestStrategyAggregateStore_FindContract(t *testing.T) {
+	conn, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunStrategyAggregateFindSuite(t, func(t *testing.T) storage.StrategyAggregateStore {
+		require.NoError(t, conn.Exec(context.Background(), "TRUNCATE TABLE strategy_aggregates"))
+		return NewStrategyAggregateStore(conn)
+	})
+}
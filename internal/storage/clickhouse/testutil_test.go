@@ -80,6 +80,12 @@ func runMigrations(t *testing.T, conn *Conn) {
 		"002_derived_features.sql",
 		"003_feature_views.sql",
 		"004_strategy_aggregates.sql",
+		"005_trade_records.sql",
+		"006_buy_sell_imbalance.sql",
+		"007_strategy_aggregates_computed_at.sql",
+		"008_derived_features_pool_change.sql",
+		"009_strategy_aggregates_data_end.sql",
+		"010_trade_sync_state.sql",
 	}
 
 	// Try to find the sql directory
@@ -161,7 +167,8 @@ func runInlineMigrations(t *testing.T, conn *Conn) {
 			volume              Float64,
 			swap_count          UInt32,
 			buy_volume          Float64,
-			sell_volume         Float64
+			sell_volume         Float64,
+			buy_sell_imbalance  Float64 DEFAULT 0
 		) ENGINE = MergeTree()
 		ORDER BY (candidate_id, interval_seconds, timestamp_ms)
 		SETTINGS index_granularity = 8192
@@ -180,7 +187,9 @@ func runInlineMigrations(t *testing.T, conn *Conn) {
 			liquidity_velocity          Nullable(Float64),
 			token_lifetime_ms           UInt64,
 			last_swap_interval_ms       Nullable(UInt64),
-			last_liq_event_interval_ms  Nullable(UInt64)
+			last_liq_event_interval_ms  Nullable(UInt64),
+			rolling_imbalance           Nullable(Float64),
+			pool_change                 Bool DEFAULT false
 		) ENGINE = MergeTree()
 		ORDER BY (candidate_id, timestamp_ms)
 		SETTINGS index_granularity = 8192
@@ -213,6 +222,9 @@ func runInlineMigrations(t *testing.T, conn *Conn) {
 			outcome_realistic Nullable(Float64),
 			outcome_pessimistic Nullable(Float64),
 			outcome_degraded Nullable(Float64),
+			computed_at Int64 DEFAULT 0,
+			data_end_exits UInt32 DEFAULT 0,
+			data_end_share Float64 DEFAULT 0,
 			created_at DateTime DEFAULT now()
 		)
 		ENGINE = ReplacingMergeTree(created_at)
@@ -220,6 +232,58 @@ func runInlineMigrations(t *testing.T, conn *Conn) {
 		SETTINGS index_granularity = 8192
 	`)
 	require.NoError(t, err)
+
+	// 005_trade_records.sql
+	err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS trade_records (
+			trade_id String,
+			candidate_id String,
+			strategy_id String,
+			strategy_type String,
+			scenario_id String,
+			entry_event_type String,
+			entry_signal_time Int64,
+			entry_signal_price Float64,
+			entry_actual_time Int64,
+			entry_actual_price Float64,
+			entry_liquidity Nullable(Float64),
+			position_size Float64,
+			position_value Float64,
+			exit_signal_time Int64,
+			exit_signal_price Float64,
+			exit_actual_time Int64,
+			exit_actual_price Float64,
+			exit_reason String,
+			entry_cost_sol Float64,
+			exit_cost_sol Float64,
+			mev_cost_sol Float64,
+			total_cost_sol Float64,
+			total_cost_pct Float64,
+			gross_return Float64,
+			outcome Float64,
+			outcome_class String,
+			hold_duration_ms Int64,
+			peak_price Nullable(Float64),
+			min_liquidity Nullable(Float64),
+			replicated_at DateTime DEFAULT now()
+		)
+		ENGINE = ReplacingMergeTree(replicated_at)
+		ORDER BY (scenario_id, strategy_type, entry_event_type, strategy_id, trade_id)
+		SETTINGS index_granularity = 8192
+	`)
+	require.NoError(t, err)
+
+	// 010_trade_sync_state.sql
+	err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS trade_sync_state (
+			mirror String,
+			watermark Int64,
+			synced_at DateTime64(3) DEFAULT now64(3)
+		)
+		ENGINE = ReplacingMergeTree(synced_at)
+		ORDER BY mirror
+	`)
+	require.NoError(t, err)
 }
 
 // ptr is a helper to create pointers for test values
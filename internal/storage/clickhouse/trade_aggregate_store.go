@@ -0,0 +1,288 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TradeAggregateStore implements storage.TradeAggregateStore using ClickHouse.
+// Trades are mirrored into the trade_records table and aggregated with SQL.
+type TradeAggregateStore struct {
+	conn *Conn
+}
+
+// NewTradeAggregateStore creates a new TradeAggregateStore.
+func NewTradeAggregateStore(conn *Conn) *TradeAggregateStore {
+	return &TradeAggregateStore{conn: conn}
+}
+
+// Compile-time interface check.
+var _ storage.TradeAggregateStore = (*TradeAggregateStore)(nil)
+
+// TableName returns the database-qualified name of the mirrored trade_records table.
+func (s *TradeAggregateStore) TableName() string {
+	return s.conn.QualifiedTable("trade_records")
+}
+
+// tradeOrder is the deterministic trade order used by order-dependent metrics.
+// Must match metrics.computeFromTrades: EntrySignalTime ASC, TradeID ASC.
+const tradeOrder = `ORDER BY entry_signal_time ASC, trade_id ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW`
+
+// InsertMirrored writes tagged trades into trade_records.
+// ReplacingMergeTree collapses repeated trade_ids, and all reads use FINAL.
+func (s *TradeAggregateStore) InsertMirrored(ctx context.Context, trades []*storage.MirroredTrade) error {
+	if len(trades) == 0 {
+		return nil
+	}
+
+	batch, err := s.conn.PrepareBatch(ctx, `
+		INSERT INTO trade_records (
+			trade_id, candidate_id, strategy_id, strategy_type, scenario_id, entry_event_type,
+			entry_signal_time, entry_signal_price, entry_actual_time, entry_actual_price,
+			entry_liquidity, position_size, position_value,
+			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
+			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
+			gross_return, outcome, outcome_class,
+			hold_duration_ms, peak_price, min_liquidity
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare batch: %w", err)
+	}
+
+	for _, m := range trades {
+		t := m.Trade
+		err = batch.Append(
+			t.TradeID, t.CandidateID, t.StrategyID, m.StrategyType, t.ScenarioID, m.EntryEventType,
+			t.EntrySignalTime, t.EntrySignalPrice, t.EntryActualTime, t.EntryActualPrice,
+			t.EntryLiquidity, t.PositionSize, t.PositionValue,
+			t.ExitSignalTime, t.ExitSignalPrice, t.ExitActualTime, t.ExitActualPrice, t.ExitReason,
+			t.EntryCostSOL, t.ExitCostSOL, t.MEVCostSOL, t.TotalCostSOL, t.TotalCostPct,
+			t.GrossReturn, t.Outcome, t.OutcomeClass,
+			t.HoldDurationMs, t.PeakPrice, t.MinLiquidity,
+		)
+		if err != nil {
+			return fmt.Errorf("append to batch: %w", err)
+		}
+	}
+
+	if err := batch.Send(); err != nil {
+		return fmt.Errorf("send batch: %w", err)
+	}
+
+	return nil
+}
+
+// CountByStrategyScenario returns the number of mirrored trades with an exact strategy_id match.
+func (s *TradeAggregateStore) CountByStrategyScenario(ctx context.Context, strategyID, scenarioID string) (int, error) {
+	query := `
+		SELECT count(*) FROM trade_records FINAL
+		WHERE strategy_id = ? AND scenario_id = ?
+	`
+
+	var count uint64
+	if err := s.conn.QueryRow(ctx, query, strategyID, scenarioID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count trades: %w", err)
+	}
+	return int(count), nil
+}
+
+// CountMirrored returns the number of distinct mirrored trades.
+func (s *TradeAggregateStore) CountMirrored(ctx context.Context) (int, error) {
+	var count uint64
+	if err := s.conn.QueryRow(ctx, `SELECT count(*) FROM trade_records FINAL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count mirrored trades: %w", err)
+	}
+	return int(count), nil
+}
+
+// syncMirror is the trade_sync_state key of the trade_records mirror.
+const syncMirror = "trade_records"
+
+// GetSyncWatermark returns the most recently recorded watermark, 0 if none.
+func (s *TradeAggregateStore) GetSyncWatermark(ctx context.Context) (int64, error) {
+	query := `
+		SELECT argMax(watermark, synced_at) FROM trade_sync_state
+		WHERE mirror = ?
+	`
+
+	var watermark int64
+	if err := s.conn.QueryRow(ctx, query, syncMirror).Scan(&watermark); err != nil {
+		return 0, fmt.Errorf("get sync watermark: %w", err)
+	}
+	return watermark, nil
+}
+
+// SetSyncWatermark records the watermark; ReplacingMergeTree keeps the latest.
+func (s *TradeAggregateStore) SetSyncWatermark(ctx context.Context, watermark int64) error {
+	if err := s.conn.Exec(ctx, `INSERT INTO trade_sync_state (mirror, watermark) VALUES (?, ?)`, syncMirror, watermark); err != nil {
+		return fmt.Errorf("set sync watermark: %w", err)
+	}
+	return nil
+}
+
+// ComputeAggregate computes the aggregate over trades matching the key.
+// Only the trade-derived fields are populated; sensitivity fields are left to the caller.
+func (s *TradeAggregateStore) ComputeAggregate(ctx context.Context, strategyID, scenarioID, entryEventType string, matchStrategyType bool) (*domain.StrategyAggregate, error) {
+	strategyColumn := "strategy_id"
+	if matchStrategyType {
+		strategyColumn = "strategy_type"
+	}
+	where := fmt.Sprintf("%s = ? AND scenario_id = ? AND entry_event_type = ?", strategyColumn)
+	args := []interface{}{strategyID, scenarioID, entryEventType}
+
+	agg := &domain.StrategyAggregate{
+		StrategyID:     strategyID,
+		ScenarioID:     scenarioID,
+		EntryEventType: entryEventType,
+	}
+
+	found, err := s.scanDistribution(ctx, where, args, agg)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := s.scanTokenWinRate(ctx, where, args, agg); err != nil {
+		return nil, err
+	}
+	if err := s.scanMaxDrawdown(ctx, where, args, agg); err != nil {
+		return nil, err
+	}
+	if err := s.scanMaxConsecutiveLosses(ctx, where, args, agg); err != nil {
+		return nil, err
+	}
+
+	return agg, nil
+}
+
+// scanDistribution fills counts, mean, quantiles, extremes, stddev and DATA_END exits.
+// quantilesExactInclusive uses the same linear interpolation as metrics.computePercentile.
+// Returns false if no trades match.
+func (s *TradeAggregateStore) scanDistribution(ctx context.Context, where string, args []interface{}, agg *domain.StrategyAggregate) (bool, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			count(*),
+			countIf(outcome_class = 'WIN'),
+			avg(outcome),
+			quantilesExactInclusive(0.5, 0.1, 0.25, 0.75, 0.9)(outcome),
+			min(outcome),
+			max(outcome),
+			if(count(*) < 2, toFloat64(0), stddevSamp(outcome)),
+			countIf(exit_reason = 'DATA_END')
+		FROM trade_records FINAL
+		WHERE %s
+	`, where)
+
+	var total, wins, dataEnd uint64
+	var quantiles []float64
+	err := s.conn.QueryRow(ctx, query, args...).Scan(
+		&total, &wins, &agg.OutcomeMean, &quantiles,
+		&agg.OutcomeMin, &agg.OutcomeMax, &agg.OutcomeStddev, &dataEnd,
+	)
+	if err != nil {
+		return false, fmt.Errorf("query outcome distribution: %w", err)
+	}
+	if total == 0 {
+		return false, nil
+	}
+	if len(quantiles) != 5 {
+		return false, fmt.Errorf("query outcome distribution: expected 5 quantiles, got %d", len(quantiles))
+	}
+
+	agg.TotalTrades = int(total)
+	agg.Wins = int(wins)
+	agg.Losses = int(total - wins)
+	agg.WinRate = float64(wins) / float64(total)
+	agg.DataEndExits = int(dataEnd)
+	agg.DataEndShare = float64(dataEnd) / float64(total)
+	agg.OutcomeMedian = quantiles[0]
+	agg.OutcomeP10 = quantiles[1]
+	agg.OutcomeP25 = quantiles[2]
+	agg.OutcomeP75 = quantiles[3]
+	agg.OutcomeP90 = quantiles[4]
+
+	return true, nil
+}
+
+// scanTokenWinRate fills TotalTokens and TokenWinRate (tokens with positive mean outcome).
+func (s *TradeAggregateStore) scanTokenWinRate(ctx context.Context, where string, args []interface{}, agg *domain.StrategyAggregate) error {
+	query := fmt.Sprintf(`
+		SELECT count(*), countIf(mean_outcome > 0)
+		FROM (
+			SELECT candidate_id, avg(outcome) AS mean_outcome
+			FROM trade_records FINAL
+			WHERE %s
+			GROUP BY candidate_id
+		)
+	`, where)
+
+	var tokens, winning uint64
+	if err := s.conn.QueryRow(ctx, query, args...).Scan(&tokens, &winning); err != nil {
+		return fmt.Errorf("query token win rate: %w", err)
+	}
+
+	agg.TotalTokens = int(tokens)
+	if tokens > 0 {
+		agg.TokenWinRate = float64(winning) / float64(tokens)
+	}
+	return nil
+}
+
+// scanMaxDrawdown fills MaxDrawdown from cumulative outcomes in trade order.
+// The running peak starts at 0, matching metrics.computeMaxDrawdown.
+func (s *TradeAggregateStore) scanMaxDrawdown(ctx context.Context, where string, args []interface{}, agg *domain.StrategyAggregate) error {
+	query := fmt.Sprintf(`
+		SELECT greatest(toFloat64(0), max(greatest(toFloat64(0), running_peak) - cumulative))
+		FROM (
+			SELECT
+				cumulative,
+				max(cumulative) OVER (%[2]s) AS running_peak
+			FROM (
+				SELECT
+					entry_signal_time,
+					trade_id,
+					sum(outcome) OVER (%[2]s) AS cumulative
+				FROM trade_records FINAL
+				WHERE %[1]s
+			)
+		)
+	`, where, tradeOrder)
+
+	if err := s.conn.QueryRow(ctx, query, args...).Scan(&agg.MaxDrawdown); err != nil {
+		return fmt.Errorf("query max drawdown: %w", err)
+	}
+	return nil
+}
+
+// scanMaxConsecutiveLosses fills MaxConsecutiveLosses (longest run of outcome <= 0 in trade order).
+// Each loss is grouped by the number of wins preceding it, so a streak is one group.
+func (s *TradeAggregateStore) scanMaxConsecutiveLosses(ctx context.Context, where string, args []interface{}, agg *domain.StrategyAggregate) error {
+	query := fmt.Sprintf(`
+		SELECT max(streak)
+		FROM (
+			SELECT win_group, count(*) AS streak
+			FROM (
+				SELECT
+					outcome,
+					sum(outcome > 0) OVER (%[2]s) AS win_group
+				FROM trade_records FINAL
+				WHERE %[1]s
+			)
+			WHERE outcome <= 0
+			GROUP BY win_group
+		)
+	`, where, tradeOrder)
+
+	var streak uint64
+	if err := s.conn.QueryRow(ctx, query, args...).Scan(&streak); err != nil {
+		return fmt.Errorf("query max consecutive losses: %w", err)
+	}
+	agg.MaxConsecutiveLosses = int(streak)
+	return nil
+}
@@ -0,0 +1,192 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// goldenTrade builds a trade for the golden dataset.
+// Outcomes are dyadic fractions so float sums are exact regardless of summation order.
+func goldenTrade(id, candidateID, strategyID, scenarioID string, outcome float64, entrySignalTime int64) *domain.TradeRecord {
+	class := domain.OutcomeClassLoss
+	if outcome > 0 {
+		class = domain.OutcomeClassWin
+	}
+	return &domain.TradeRecord{
+		TradeID:         id,
+		CandidateID:     candidateID,
+		StrategyID:      strategyID,
+		ScenarioID:      scenarioID,
+		EntrySignalTime: entrySignalTime,
+		ExitReason:      domain.ExitReasonTimeExit,
+		PositionSize:    1.0,
+		Outcome:         outcome,
+		OutcomeClass:    class,
+	}
+}
+
+func TestTradeAggregateStore_MatchesGoAggregator(t *testing.T) {
+	conn, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+
+	for _, c := range []*domain.TokenCandidate{
+		{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "m1", TxSignature: "tx1", Slot: 1, DiscoveredAt: 1000},
+		{CandidateID: "c2", Source: domain.SourceNewToken, Mint: "m2", TxSignature: "tx2", Slot: 2, DiscoveredAt: 2000},
+		{CandidateID: "c3", Source: domain.SourceNewToken, Mint: "m3", TxSignature: "tx3", Slot: 3, DiscoveredAt: 3000},
+		{CandidateID: "c4", Source: domain.SourceActiveToken, Mint: "m4", TxSignature: "tx4", Slot: 4, DiscoveredAt: 4000},
+	} {
+		require.NoError(t, candidateStore.Insert(ctx, c))
+	}
+
+	strategyID := "TIME_EXIT_NEW_TOKEN_300000ms"
+	scenario := domain.ScenarioRealistic
+
+	// Insertion order differs from (EntrySignalTime, TradeID) order, and several
+	// trades share an EntrySignalTime so TradeID decides drawdown and streaks.
+	trades := []*domain.TradeRecord{
+		goldenTrade("t09", "c3", strategyID, scenario, 0.75, 5000),
+		goldenTrade("t02", "c1", strategyID, scenario, -0.25, 1000),
+		goldenTrade("t01", "c1", strategyID, scenario, 0.5, 1000),
+		goldenTrade("t05", "c2", strategyID, scenario, -0.125, 3000),
+		goldenTrade("t04", "c2", strategyID, scenario, -0.5, 3000),
+		goldenTrade("t03", "c1", strategyID, scenario, 0, 2000),
+		goldenTrade("t06", "c2", strategyID, scenario, -0.0625, 3000),
+		goldenTrade("t08", "c3", strategyID, scenario, 1.0, 4000),
+		goldenTrade("t07", "c3", strategyID, scenario, -0.375, 4000),
+		goldenTrade("t10", "c4", strategyID, scenario, 0.25, 1000),
+		goldenTrade("t11", "c4", strategyID, scenario, -0.75, 2000),
+		goldenTrade("t12", "c1", strategyID, domain.ScenarioPessimistic, -1.0, 1000),
+		goldenTrade("t13", "c9", strategyID, scenario, 2.0, 1000), // missing candidate
+	}
+	require.NoError(t, tradeStore.InsertBulk(ctx, trades))
+
+	goAgg := metrics.NewAggregator(tradeStore, memory.NewStrategyAggregateStore(), candidateStore)
+	sqlAgg := metrics.NewSQLAggregator(tradeStore, NewTradeAggregateStore(conn), NewStrategyAggregateStore(conn), candidateStore)
+
+	mirrored, err := sqlAgg.Replicate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 12, mirrored)
+
+	// Replication is idempotent
+	_, err = sqlAgg.Replicate(ctx)
+	require.NoError(t, err)
+
+	for _, key := range []struct{ strategy, scenario, entry string }{
+		{domain.StrategyTypeTimeExit, scenario, "NEW_TOKEN"},
+		{domain.StrategyTypeTimeExit, scenario, "ACTIVE_TOKEN"},
+		{domain.StrategyTypeTimeExit, domain.ScenarioPessimistic, "NEW_TOKEN"},
+		{strategyID, scenario, "NEW_TOKEN"},
+	} {
+		want, err := goAgg.ComputeAggregate(ctx, key.strategy, key.scenario, key.entry)
+		require.NoError(t, err)
+		got, err := sqlAgg.ComputeAggregate(ctx, key.strategy, key.scenario, key.entry)
+		require.NoError(t, err)
+
+		// stddevSamp is single-pass and quantilesExactInclusive computes the interpolation
+		// fraction from a 1-based index, so these may differ from Go in the last ulp.
+		// Everything else (counts, mean, extremes, drawdown, streaks) must match exactly.
+		for name, pair := range map[string][2]*float64{
+			"stddev": {&want.OutcomeStddev, &got.OutcomeStddev},
+			"median": {&want.OutcomeMedian, &got.OutcomeMedian},
+			"p10":    {&want.OutcomeP10, &got.OutcomeP10},
+			"p25":    {&want.OutcomeP25, &got.OutcomeP25},
+			"p75":    {&want.OutcomeP75, &got.OutcomeP75},
+			"p90":    {&want.OutcomeP90, &got.OutcomeP90},
+		} {
+			assert.InDelta(t, *pair[0], *pair[1], 1e-12, "%s %v", name, key)
+			*pair[1] = *pair[0]
+		}
+
+		assert.Equal(t, want, got, "aggregate %v", key)
+	}
+
+	assert.Equal(t, []string{"missing candidate c9 referenced by 1 trade(s)"}, sqlAgg.GetMissingCandidateErrors())
+}
+
+func TestTradeAggregateStore_NoTrades(t *testing.T) {
+	conn, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store := NewTradeAggregateStore(conn)
+	ctx := context.Background()
+
+	_, err := store.ComputeAggregate(ctx, "TIME_EXIT", domain.ScenarioRealistic, "NEW_TOKEN", true)
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+	count, err := store.CountByStrategyScenario(ctx, "TIME_EXIT", domain.ScenarioRealistic)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestTradeAggregateStore_SyncConverges(t *testing.T) {
+	conn, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateStore := memory.NewCandidateStore()
+	tradeStore := memory.NewTradeRecordStore()
+	store := NewTradeAggregateStore(conn)
+
+	require.NoError(t, candidateStore.Insert(ctx, &domain.TokenCandidate{
+		CandidateID: "c1", Source: domain.SourceNewToken, Mint: "m1", TxSignature: "tx1", Slot: 1, DiscoveredAt: 1000,
+	}))
+	strategyID := "TIME_EXIT_NEW_TOKEN_300000ms"
+	require.NoError(t, tradeStore.InsertBulk(ctx, []*domain.TradeRecord{
+		goldenTrade("t1", "c1", strategyID, domain.ScenarioRealistic, 0.5, 1000),
+		goldenTrade("t2", "c1", strategyID, domain.ScenarioRealistic, -0.25, 2000),
+	}))
+
+	watermark, err := store.GetSyncWatermark(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), watermark, "never synced")
+
+	sqlAgg := metrics.NewSQLAggregator(tradeStore, store, NewStrategyAggregateStore(conn), candidateStore)
+	first, err := sqlAgg.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.Mirrored)
+	assert.Equal(t, 2, first.MirrorTrades)
+	assert.Equal(t, 0, first.Unreconciled())
+
+	// Re-simulation replaces a trade after the upsert lands
+	time.Sleep(2 * time.Millisecond)
+	mutated := goldenTrade("t2", "c1", strategyID, domain.ScenarioRealistic, 0.75, 2000)
+	require.NoError(t, tradeStore.Upsert(ctx, mutated))
+	require.NoError(t, tradeStore.Insert(ctx, goldenTrade("t3", "c1", strategyID, domain.ScenarioRealistic, 0.125, 3000)))
+
+	second, err := sqlAgg.Sync(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, second.Watermark, first.Watermark)
+	assert.Equal(t, 3, second.SourceTrades)
+	assert.Equal(t, 3, second.MirrorTrades)
+	assert.Equal(t, 0, second.Unreconciled())
+
+	watermark, err = store.GetSyncWatermark(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, second.Watermark, watermark)
+
+	// The mirror holds the replaced outcome, once
+	var outcome float64
+	var count uint64
+	require.NoError(t, conn.QueryRow(ctx, `SELECT any(outcome), count(*) FROM trade_records FINAL WHERE trade_id = 't2'`).Scan(&outcome, &count))
+	assert.Equal(t, 0.75, outcome)
+	assert.Equal(t, uint64(1), count)
+
+	agg, err := sqlAgg.ComputeAggregate(ctx, domain.StrategyTypeTimeExit, domain.ScenarioRealistic, "NEW_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, 3, agg.TotalTrades)
+	assert.Equal(t, 3, agg.Wins)
+}
@@ -55,7 +55,8 @@ func (s *VolumeTimeseriesStore) InsertBulk(ctx context.Context, points []*domain
 
 	batch, err := s.conn.PrepareBatch(ctx, `
 		INSERT INTO volume_timeseries (
-			candidate_id, timestamp_ms, interval_seconds, volume, swap_count, buy_volume, sell_volume
+			candidate_id, timestamp_ms, interval_seconds, volume, swap_count, buy_volume, sell_volume,
+			buy_sell_imbalance
 		)
 	`)
 	if err != nil {
@@ -66,6 +67,7 @@ func (s *VolumeTimeseriesStore) InsertBulk(ctx context.Context, points []*domain
 		err = batch.Append(
 			p.CandidateID, uint64(p.TimestampMs), uint32(p.IntervalSeconds),
 			p.Volume, uint32(p.SwapCount), p.BuyVolume, p.SellVolume,
+			p.BuySellImbalance,
 		)
 		if err != nil {
 			return fmt.Errorf("append to batch: %w", err)
@@ -82,7 +84,8 @@ func (s *VolumeTimeseriesStore) InsertBulk(ctx context.Context, points []*domain
 // GetByCandidateID retrieves all points for a candidate, ordered by timestamp ASC.
 func (s *VolumeTimeseriesStore) GetByCandidateID(ctx context.Context, candidateID string) ([]*domain.VolumeTimeseriesPoint, error) {
 	query := `
-		SELECT candidate_id, timestamp_ms, interval_seconds, volume, swap_count, buy_volume, sell_volume
+		SELECT candidate_id, timestamp_ms, interval_seconds, volume, swap_count, buy_volume, sell_volume,
+			buy_sell_imbalance
 		FROM volume_timeseries
 		WHERE candidate_id = ?
 		ORDER BY interval_seconds ASC, timestamp_ms ASC
@@ -100,7 +103,8 @@ func (s *VolumeTimeseriesStore) GetByCandidateID(ctx context.Context, candidateI
 // GetByTimeRange retrieves points for a candidate within [start, end] (inclusive).
 func (s *VolumeTimeseriesStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) ([]*domain.VolumeTimeseriesPoint, error) {
 	query := `
-		SELECT candidate_id, timestamp_ms, interval_seconds, volume, swap_count, buy_volume, sell_volume
+		SELECT candidate_id, timestamp_ms, interval_seconds, volume, swap_count, buy_volume, sell_volume,
+			buy_sell_imbalance
 		FROM volume_timeseries
 		WHERE candidate_id = ? AND timestamp_ms >= ? AND timestamp_ms <= ?
 		ORDER BY interval_seconds ASC, timestamp_ms ASC
@@ -142,6 +146,7 @@ func scanVolumeTimeseries(rows chRows) ([]*domain.VolumeTimeseriesPoint, error)
 		err := rows.Scan(
 			&p.CandidateID, &timestampMs, &intervalSeconds,
 			&p.Volume, &swapCount, &p.BuyVolume, &p.SellVolume,
+			&p.BuySellImbalance,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan volume timeseries row: %w", err)
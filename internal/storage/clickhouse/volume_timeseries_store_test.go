@@ -26,13 +26,14 @@ func TestVolumeTimeseriesStore_InsertBulk(t *testing.T) {
 	// Test single insert
 	points := []*domain.VolumeTimeseriesPoint{
 		{
-			CandidateID:     "cand-1",
-			TimestampMs:     1000,
-			IntervalSeconds: 60,
-			Volume:          1000.0,
-			SwapCount:       10,
-			BuyVolume:       600.0,
-			SellVolume:      400.0,
+			CandidateID:      "cand-1",
+			TimestampMs:      1000,
+			IntervalSeconds:  60,
+			Volume:           1000.0,
+			SwapCount:        10,
+			BuyVolume:        600.0,
+			SellVolume:       400.0,
+			BuySellImbalance: 0.2,
 		},
 	}
 
@@ -50,6 +51,7 @@ func TestVolumeTimeseriesStore_InsertBulk(t *testing.T) {
 	assert.Equal(t, 10, got[0].SwapCount)
 	assert.Equal(t, 600.0, got[0].BuyVolume)
 	assert.Equal(t, 400.0, got[0].SellVolume)
+	assert.Equal(t, 0.2, got[0].BuySellImbalance)
 }
 
 func TestVolumeTimeseriesStore_InsertBulk_DuplicateKey(t *testing.T) {
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+)
+
+// DirtyCandidateStore tracks candidates awaiting re-simulation because late
+// events landed inside one of their trade windows.
+// Like the watchlist it is operational state: marks are added and cleared.
+type DirtyCandidateStore interface {
+	// Mark flags a candidate as dirty. Marking an already dirty candidate keeps the
+	// earliest event time and the latest markedAt. Returns ErrInvalidInput for an
+	// empty candidateID.
+	Mark(ctx context.Context, candidateID string, eventTime, markedAt int64) error
+
+	// List retrieves all dirty candidates, ordered by marked_at ASC, candidate_id ASC.
+	List(ctx context.Context) ([]*domain.DirtyCandidate, error)
+
+	// Count returns the number of dirty candidates.
+	Count(ctx context.Context) (int, error)
+
+	// Unmark removes the mark of a candidate re-simulated from data as of markedAt.
+	// A mark made after markedAt is kept, so events stored during the
+	// re-simulation are not lost. Unmarking a clean candidate is a no-op.
+	Unmark(ctx context.Context, candidateID string, markedAt int64) error
+}
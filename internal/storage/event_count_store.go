@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+)
+
+// EventCountStore holds the per-candidate event counts of capped ingestion
+// (see domain.CandidateEventCounts). Rows are upserted as ingestion proceeds.
+type EventCountStore interface {
+	// Upsert inserts the counts of a candidate or replaces the stored ones.
+	// Returns ErrInvalidInput for an empty CandidateID.
+	Upsert(ctx context.Context, c *domain.CandidateEventCounts) error
+
+	// GetByCandidateID retrieves the counts of a candidate. Returns ErrNotFound if not exists.
+	GetByCandidateID(ctx context.Context, candidateID string) (*domain.CandidateEventCounts, error)
+
+	// GetDownsampled retrieves the counts of every candidate with dropped events,
+	// ordered by candidate_id ASC.
+	GetDownsampled(ctx context.Context) ([]*domain.CandidateEventCounts, error)
+}
@@ -0,0 +1,463 @@
+// Package factory builds the stores of a binary for the selected storage
+// backend, so that every cmd/ connects, migrates, instruments and cleans up
+// the same way.
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"solana-token-lab/internal/observability"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
+	chstore "solana-token-lab/internal/storage/clickhouse"
+	"solana-token-lab/internal/storage/instrumented"
+	"solana-token-lab/internal/storage/memory"
+	"solana-token-lab/internal/storage/migrations"
+	pgstore "solana-token-lab/internal/storage/postgres"
+)
+
+// Storage backends accepted in Config.Backend.
+const (
+	BackendMemory   = "memory"   // in-memory stores, lost on exit
+	BackendPostgres = "postgres" // PostgreSQL, with ClickHouse for analytics stores
+	BackendSQLite   = "sqlite"   // reserved, not implemented yet
+)
+
+// Errors returned by BuildStores for an invalid Config.
+var (
+	ErrUnknownBackend     = errors.New("unknown storage backend")
+	ErrUnsupportedBackend = errors.New("storage backend not supported yet")
+	ErrMissingDSN         = errors.New("missing DSN")
+	ErrIncompatibleSchema = errors.New("incompatible database schema")
+)
+
+// Need selects the stores BuildStores constructs. Stores outside the mask are
+// left nil, and a database only serving unselected stores is not connected.
+type Need uint32
+
+// Stores that can be selected in Config.Needs.
+const (
+	NeedCandidates Need = 1 << iota
+	NeedSwaps
+	NeedSwapEvents
+	NeedLiquidityEvents
+	NeedTrades
+	NeedTokenMetadata
+	NeedHolderSnapshots
+	NeedWatchlist
+	NeedFinality
+	NeedSufficiencyRuns
+	NeedProvisionalMints
+	NeedDirtyCandidates
+	NeedEventCounts
+	NeedPoolLinks
+	NeedRunManifests
+	NeedSlotCheckpoints
+	NeedReparse
+	NeedFailedTx
+	NeedPriceTimeseries
+	NeedLiquidityTimeseries
+	NeedVolumeTimeseries
+	NeedDerivedFeatures
+	NeedStrategyAggregates
+	NeedTradeAggregates // ClickHouse only, nil with BackendMemory
+)
+
+// NeedAll selects every store.
+const NeedAll = NeedTradeAggregates<<1 - 1
+
+// clickhouseNeeds are the stores that live in ClickHouse with BackendPostgres.
+const clickhouseNeeds = NeedPriceTimeseries | NeedLiquidityTimeseries | NeedVolumeTimeseries |
+	NeedDerivedFeatures | NeedStrategyAggregates | NeedTradeAggregates
+
+// Has reports whether n selects every store of other.
+func (n Need) Has(other Need) bool {
+	return n&other == other
+}
+
+// Config selects the backend and the stores to build.
+type Config struct {
+	Backend       string // BackendMemory or BackendPostgres
+	PostgresDSN   string // required with BackendPostgres when a PostgreSQL store is needed
+	ClickHouseDSN string // required with BackendPostgres when a ClickHouse store is needed
+	Needs         Need
+
+	// Instrument wraps the stores with latency and error metrics, labelled by
+	// the backend they live in.
+	Instrument bool
+
+	// SkipMigrations connects without applying migrations, for tools that only
+	// read an existing schema.
+	SkipMigrations bool
+
+	// ReadOnly accepts a database lacking only columns added by migrations to
+	// existing tables, for binaries that read without migrating. The gap is
+	// reported in Stores.Schema. Missing tables fail regardless.
+	ReadOnly bool
+}
+
+// Stores holds the stores selected by Config.Needs; the others are nil.
+type Stores struct {
+	Candidates          storage.CandidateStore
+	Swaps               storage.SwapStore
+	SwapEvents          storage.SwapEventStore
+	LiquidityEvents     storage.LiquidityEventStore
+	Trades              storage.TradeRecordStore
+	TokenMetadata       storage.TokenMetadataStore
+	HolderSnapshots     storage.TokenHolderSnapshotStore
+	Watchlist           storage.WatchlistStore
+	Finality            storage.FinalityStore
+	SufficiencyRuns     storage.SufficiencyRunStore
+	ProvisionalMints    storage.ProvisionalMintStore
+	DirtyCandidates     storage.DirtyCandidateStore
+	EventCounts         storage.EventCountStore
+	PoolLinks           storage.PoolLinkStore
+	RunManifests        storage.RunManifestStore
+	SlotCheckpoints     storage.SlotCheckpointStore
+	Reparse             storage.ReparseStore
+	FailedTx            storage.FailedTxStore
+	PriceTimeseries     storage.PriceTimeseriesStore
+	LiquidityTimeseries storage.LiquidityTimeseriesStore
+	VolumeTimeseries    storage.VolumeTimeseriesStore
+	DerivedFeatures     storage.DerivedFeatureStore
+	StrategyAggregates  storage.StrategyAggregateStore
+	TradeAggregates     storage.TradeAggregateStore
+
+	// Postgres is the connection pool of BackendPostgres, nil otherwise or when
+	// no PostgreSQL store is needed. Used for stores built outside the factory,
+	// such as the raw transaction archive.
+	Postgres *pgstore.Pool
+
+	// MetricsTables are the ClickHouse tables metrics_queries.sql reads.
+	MetricsTables reporting.MetricsQueryTables
+
+	// Backends are the backends and server versions recorded in run manifests.
+	Backends []storage.RunBackend
+
+	// Schema is the schema check of every connected database, empty for BackendMemory.
+	Schema []migrations.SchemaStatus
+
+	// Clearables are the memory stores that can be reset, empty for databases.
+	Clearables []storage.Clearable
+}
+
+// BuildStores builds the stores of cfg.Needs on cfg.Backend. The cleanup
+// closes the database connections, ClickHouse before PostgreSQL. On error
+// every connection opened so far is closed and stores and cleanup are nil.
+func BuildStores(ctx context.Context, cfg Config) (*Stores, func(), error) {
+	return buildStores(ctx, cfg, defaultDrivers)
+}
+
+func buildStores(ctx context.Context, cfg Config, d drivers) (*Stores, func(), error) {
+	var (
+		stores  *Stores
+		cleanup = func() {}
+		err     error
+	)
+	switch cfg.Backend {
+	case BackendMemory:
+		stores = buildMemoryStores(cfg.Needs)
+	case BackendPostgres:
+		stores, cleanup, err = buildDatabaseStores(ctx, cfg, d)
+		if err != nil {
+			return nil, nil, err
+		}
+	case BackendSQLite:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, cfg.Backend)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownBackend, cfg.Backend)
+	}
+
+	if cfg.Instrument {
+		pgBackend, chBackend := "postgres", "clickhouse"
+		if cfg.Backend == BackendMemory {
+			pgBackend, chBackend = "memory", "memory"
+		}
+		stores.instrument(pgBackend, chBackend)
+	}
+	return stores, cleanup, nil
+}
+
+// buildMemoryStores builds the needed in-memory stores. Finality and reparse
+// share the event stores, so they see the events stored through them.
+func buildMemoryStores(needs Need) *Stores {
+	swaps := memory.NewSwapStore()
+	swapEvents := memory.NewSwapEventStore()
+	liquidityEvents := memory.NewLiquidityEventStore()
+
+	s := &Stores{
+		MetricsTables: reporting.DefaultMetricsQueryTables(),
+		Backends:      []storage.RunBackend{{Name: "memory"}},
+	}
+	add := func(need Need, set func()) {
+		if needs.Has(need) {
+			set()
+		}
+	}
+	add(NeedCandidates, func() { s.Candidates = memory.NewCandidateStore() })
+	add(NeedSwaps, func() { s.Swaps = swaps })
+	add(NeedSwapEvents, func() { s.SwapEvents = swapEvents })
+	add(NeedLiquidityEvents, func() { s.LiquidityEvents = liquidityEvents })
+	add(NeedTrades, func() { s.Trades = memory.NewTradeRecordStore() })
+	add(NeedTokenMetadata, func() { s.TokenMetadata = memory.NewTokenMetadataStore() })
+	add(NeedHolderSnapshots, func() { s.HolderSnapshots = memory.NewTokenHolderSnapshotStore() })
+	add(NeedWatchlist, func() { s.Watchlist = memory.NewWatchlistStore() })
+	add(NeedFinality, func() { s.Finality = memory.NewFinalityStore(swaps, swapEvents, liquidityEvents) })
+	add(NeedSufficiencyRuns, func() { s.SufficiencyRuns = memory.NewSufficiencyRunStore() })
+	add(NeedProvisionalMints, func() { s.ProvisionalMints = memory.NewProvisionalMintStore() })
+	add(NeedDirtyCandidates, func() { s.DirtyCandidates = memory.NewDirtyCandidateStore() })
+	add(NeedEventCounts, func() { s.EventCounts = memory.NewEventCountStore() })
+	add(NeedPoolLinks, func() { s.PoolLinks = memory.NewPoolLinkStore() })
+	add(NeedRunManifests, func() { s.RunManifests = memory.NewRunManifestStore() })
+	add(NeedSlotCheckpoints, func() { s.SlotCheckpoints = memory.NewSlotCheckpointStore() })
+	add(NeedReparse, func() { s.Reparse = memory.NewReparseStore(swapEvents, liquidityEvents) })
+	add(NeedFailedTx, func() { s.FailedTx = memory.NewFailedTxStore() })
+	add(NeedPriceTimeseries, func() { s.PriceTimeseries = memory.NewPriceTimeseriesStore() })
+	add(NeedLiquidityTimeseries, func() { s.LiquidityTimeseries = memory.NewLiquidityTimeseriesStore() })
+	add(NeedVolumeTimeseries, func() { s.VolumeTimeseries = memory.NewVolumeTimeseriesStore() })
+	add(NeedDerivedFeatures, func() { s.DerivedFeatures = memory.NewDerivedFeatureStore() })
+	add(NeedStrategyAggregates, func() { s.StrategyAggregates = memory.NewStrategyAggregateStore() })
+
+	for _, st := range []any{
+		s.Candidates, s.Swaps, s.SwapEvents, s.LiquidityEvents, s.Trades,
+		s.PriceTimeseries, s.LiquidityTimeseries, s.VolumeTimeseries, s.DerivedFeatures, s.StrategyAggregates,
+	} {
+		if c, ok := st.(storage.Clearable); ok {
+			s.Clearables = append(s.Clearables, c)
+		}
+	}
+	return s
+}
+
+// buildDatabaseStores connects to PostgreSQL and, when a ClickHouse store is
+// needed, to ClickHouse, and builds the needed stores over them.
+func buildDatabaseStores(ctx context.Context, cfg Config, d drivers) (*Stores, func(), error) {
+	needs := cfg.Needs
+	needPostgres := needs&^clickhouseNeeds != 0
+	needClickHouse := needs&clickhouseNeeds != 0
+	if needPostgres && cfg.PostgresDSN == "" {
+		return nil, nil, fmt.Errorf("%w: postgres DSN is required", ErrMissingDSN)
+	}
+	if needClickHouse && cfg.ClickHouseDSN == "" {
+		return nil, nil, fmt.Errorf("%w: clickhouse DSN is required", ErrMissingDSN)
+	}
+
+	// Connections are closed in reverse order of opening, on failure as well
+	var closers []func()
+	cleanup := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	s := &Stores{}
+	if needPostgres {
+		pool, err := d.openPostgres(ctx, cfg.PostgresDSN, !cfg.SkipMigrations)
+		if err != nil {
+			return nil, nil, err
+		}
+		closers = append(closers, func() { d.closePostgres(pool) })
+		status, err := d.checkPostgres(ctx, pool)
+		if err == nil {
+			s.Schema = append(s.Schema, status)
+			err = checkSchema(cfg, status)
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		s.Postgres = pool
+		s.Backends = append(s.Backends, storage.RunBackend{Name: "postgres", Version: d.postgresVersion(ctx, pool)})
+		s.addPostgresStores(needs, pool)
+	}
+	if needClickHouse {
+		conn, err := d.openClickHouse(ctx, cfg.ClickHouseDSN, !cfg.SkipMigrations)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		closers = append(closers, func() { d.closeClickHouse(conn) })
+		status, err := d.checkClickHouse(ctx, conn)
+		if err == nil {
+			s.Schema = append(s.Schema, status)
+			err = checkSchema(cfg, status)
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		s.Backends = append(s.Backends, storage.RunBackend{Name: "clickhouse", Version: d.clickhouseVersion(conn)})
+		s.addClickHouseStores(needs, conn)
+	}
+	return s, cleanup, nil
+}
+
+// checkSchema fails with ErrIncompatibleSchema unless the database has every
+// column the migrations create, or cfg.ReadOnly and only added columns are missing.
+func checkSchema(cfg Config, status migrations.SchemaStatus) error {
+	if status.Compatible() || (cfg.ReadOnly && status.Additive()) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrIncompatibleSchema, status.Problem())
+}
+
+// addPostgresStores builds the needed PostgreSQL stores over pool.
+func (s *Stores) addPostgresStores(needs Need, pool *pgstore.Pool) {
+	add := func(need Need, set func()) {
+		if needs.Has(need) {
+			set()
+		}
+	}
+	add(NeedCandidates, func() { s.Candidates = pgstore.NewCandidateStore(pool) })
+	add(NeedSwaps, func() { s.Swaps = pgstore.NewSwapStore(pool) })
+	add(NeedSwapEvents, func() { s.SwapEvents = pgstore.NewSwapEventStore(pool) })
+	add(NeedLiquidityEvents, func() { s.LiquidityEvents = pgstore.NewLiquidityEventStore(pool) })
+	add(NeedTrades, func() { s.Trades = pgstore.NewTradeRecordStore(pool) })
+	add(NeedTokenMetadata, func() { s.TokenMetadata = pgstore.NewTokenMetadataStore(pool) })
+	add(NeedHolderSnapshots, func() { s.HolderSnapshots = pgstore.NewTokenHolderSnapshotStore(pool) })
+	add(NeedWatchlist, func() { s.Watchlist = pgstore.NewWatchlistStore(pool) })
+	add(NeedFinality, func() { s.Finality = pgstore.NewFinalityStore(pool) })
+	add(NeedSufficiencyRuns, func() { s.SufficiencyRuns = pgstore.NewSufficiencyRunStore(pool) })
+	add(NeedProvisionalMints, func() { s.ProvisionalMints = pgstore.NewProvisionalMintStore(pool) })
+	add(NeedDirtyCandidates, func() { s.DirtyCandidates = pgstore.NewDirtyCandidateStore(pool) })
+	add(NeedEventCounts, func() { s.EventCounts = pgstore.NewEventCountStore(pool) })
+	add(NeedPoolLinks, func() { s.PoolLinks = pgstore.NewPoolLinkStore(pool) })
+	add(NeedRunManifests, func() { s.RunManifests = pgstore.NewRunManifestStore(pool) })
+	add(NeedSlotCheckpoints, func() { s.SlotCheckpoints = pgstore.NewSlotCheckpointStore(pool) })
+	add(NeedReparse, func() { s.Reparse = pgstore.NewReparseStore(pool) })
+	add(NeedFailedTx, func() { s.FailedTx = pgstore.NewFailedTxStore(pool) })
+}
+
+// addClickHouseStores builds the needed ClickHouse stores over conn.
+func (s *Stores) addClickHouseStores(needs Need, conn *chstore.Conn) {
+	add := func(need Need, set func()) {
+		if needs.Has(need) {
+			set()
+		}
+	}
+	add(NeedPriceTimeseries, func() { s.PriceTimeseries = chstore.NewPriceTimeseriesStore(conn) })
+	add(NeedLiquidityTimeseries, func() { s.LiquidityTimeseries = chstore.NewLiquidityTimeseriesStore(conn) })
+	add(NeedVolumeTimeseries, func() { s.VolumeTimeseries = chstore.NewVolumeTimeseriesStore(conn) })
+	add(NeedDerivedFeatures, func() { s.DerivedFeatures = chstore.NewDerivedFeatureStore(conn) })
+	add(NeedStrategyAggregates, func() { s.StrategyAggregates = chstore.NewStrategyAggregateStore(conn) })
+	add(NeedTradeAggregates, func() { s.TradeAggregates = chstore.NewTradeAggregateStore(conn) })
+
+	s.MetricsTables = reporting.MetricsQueryTables{
+		StrategyAggregates: chstore.NewStrategyAggregateStore(conn).TableName(),
+		TradeRecords:       chstore.NewTradeAggregateStore(conn).TableName(),
+	}
+}
+
+// instrument wraps every store that has an instrumented counterpart with
+// latency and error metrics. pgBackend and chBackend label the stores that
+// live in PostgreSQL and ClickHouse respectively.
+func (s *Stores) instrument(pgBackend, chBackend string) {
+	record := observability.RecordStoreOperation
+	if s.Candidates != nil {
+		s.Candidates = instrumented.NewCandidateStore(s.Candidates, pgBackend, record)
+	}
+	if s.Swaps != nil {
+		s.Swaps = instrumented.NewSwapStore(s.Swaps, pgBackend, record)
+	}
+	if s.SwapEvents != nil {
+		s.SwapEvents = instrumented.NewSwapEventStore(s.SwapEvents, pgBackend, record)
+	}
+	if s.LiquidityEvents != nil {
+		s.LiquidityEvents = instrumented.NewLiquidityEventStore(s.LiquidityEvents, pgBackend, record)
+	}
+	if s.Trades != nil {
+		s.Trades = instrumented.NewTradeRecordStore(s.Trades, pgBackend, record)
+	}
+	if s.TokenMetadata != nil {
+		s.TokenMetadata = instrumented.NewTokenMetadataStore(s.TokenMetadata, pgBackend, record)
+	}
+	if s.HolderSnapshots != nil {
+		s.HolderSnapshots = instrumented.NewTokenHolderSnapshotStore(s.HolderSnapshots, pgBackend, record)
+	}
+	if s.Watchlist != nil {
+		s.Watchlist = instrumented.NewWatchlistStore(s.Watchlist, pgBackend, record)
+	}
+	if s.PriceTimeseries != nil {
+		s.PriceTimeseries = instrumented.NewPriceTimeseriesStore(s.PriceTimeseries, chBackend, record)
+	}
+	if s.LiquidityTimeseries != nil {
+		s.LiquidityTimeseries = instrumented.NewLiquidityTimeseriesStore(s.LiquidityTimeseries, chBackend, record)
+	}
+	if s.VolumeTimeseries != nil {
+		s.VolumeTimeseries = instrumented.NewVolumeTimeseriesStore(s.VolumeTimeseries, chBackend, record)
+	}
+	if s.DerivedFeatures != nil {
+		s.DerivedFeatures = instrumented.NewDerivedFeatureStore(s.DerivedFeatures, chBackend, record)
+	}
+	if s.StrategyAggregates != nil {
+		s.StrategyAggregates = instrumented.NewStrategyAggregateStore(s.StrategyAggregates, chBackend, record)
+	}
+	if s.TradeAggregates != nil {
+		s.TradeAggregates = instrumented.NewTradeAggregateStore(s.TradeAggregates, chBackend, record)
+	}
+}
+
+// drivers opens and closes the database connections of BackendPostgres.
+// Tests replace them to exercise failures without databases.
+type drivers struct {
+	openPostgres      func(ctx context.Context, dsn string, migrate bool) (*pgstore.Pool, error)
+	openClickHouse    func(ctx context.Context, dsn string, migrate bool) (*chstore.Conn, error)
+	closePostgres     func(pool *pgstore.Pool)
+	closeClickHouse   func(conn *chstore.Conn)
+	postgresVersion   func(ctx context.Context, pool *pgstore.Pool) string
+	clickhouseVersion func(conn *chstore.Conn) string
+	checkPostgres     func(ctx context.Context, pool *pgstore.Pool) (migrations.SchemaStatus, error)
+	checkClickHouse   func(ctx context.Context, conn *chstore.Conn) (migrations.SchemaStatus, error)
+}
+
+var defaultDrivers = drivers{
+	openPostgres:    openPostgres,
+	openClickHouse:  openClickHouse,
+	closePostgres:   func(pool *pgstore.Pool) { pool.Close() },
+	closeClickHouse: func(conn *chstore.Conn) { conn.Close() },
+	// A version that cannot be queried is left empty
+	postgresVersion: func(ctx context.Context, pool *pgstore.Pool) string {
+		v, _ := pool.ServerVersion(ctx)
+		return v
+	},
+	clickhouseVersion: func(conn *chstore.Conn) string {
+		v, _ := conn.Version()
+		return v
+	},
+	checkPostgres:   migrations.CheckPostgresSchema,
+	checkClickHouse: migrations.CheckClickhouseSchema,
+}
+
+// openPostgres connects to PostgreSQL and, if migrate is set, applies the migrations.
+func openPostgres(ctx context.Context, dsn string, migrate bool) (*pgstore.Pool, error) {
+	pool, err := pgstore.NewPool(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+	if migrate {
+		if err := migrations.RunPostgresMigrations(ctx, pool); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("postgres migrations: %w", err)
+		}
+	}
+	return pool, nil
+}
+
+// openClickHouse connects to ClickHouse and, if migrate is set, creates the
+// database and applies the migrations first.
+func openClickHouse(ctx context.Context, dsn string, migrate bool) (*chstore.Conn, error) {
+	if migrate {
+		conn, err := migrations.RunClickhouseMigrations(ctx, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("clickhouse migrations: %w", err)
+		}
+		return conn, nil
+	}
+	conn, err := chstore.NewConn(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect to clickhouse: %w", err)
+	}
+	return conn, nil
+}
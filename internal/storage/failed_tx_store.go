@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+
+	"solana-token-lab/internal/timeutil"
+)
+
+// FailedTxHourMs is the bucket width of failed transaction tallies.
+const FailedTxHourMs = int64(60 * 60 * 1000)
+
+// FailedTxTally counts the transactions an ingestion source observed for one
+// program in one hour, and how many of them failed on chain. Failed
+// transactions carry no events and are skipped by ingestion; their share is
+// the observed on-chain failure rate.
+type FailedTxTally struct {
+	Program      string
+	Source       string // ingestion source, e.g. "ws-swap" or "rpc-swap"
+	HourStart    int64  // Unix ms, a multiple of FailedTxHourMs
+	Transactions int64  // observed, failed ones included
+	Failed       int64
+}
+
+// FailedTxStore keeps hourly failed transaction tallies.
+type FailedTxStore interface {
+	// Add adds the counts of tallies to the stored ones of the same (program,
+	// source, hour). Returns ErrInvalidInput for a nil tally, an empty Program
+	// or Source, a HourStart not on an hour boundary, negative counts or more
+	// failed than observed transactions.
+	Add(ctx context.Context, tallies []*FailedTxTally) error
+
+	// GetRange returns the tallies with HourStart within [from, to), ordered by
+	// HourStart, Program and Source.
+	GetRange(ctx context.Context, from, to int64) ([]*FailedTxTally, error)
+}
+
+// FailedTxHour returns the start of the tally hour containing ts (Unix ms).
+func FailedTxHour(ts int64) int64 {
+	return timeutil.IntervalAlign(ts, FailedTxHourMs)
+}
+
+// ValidFailedTxTally reports whether t can be stored.
+func ValidFailedTxTally(t *FailedTxTally) bool {
+	return t != nil && t.Program != "" && t.Source != "" &&
+		t.HourStart%FailedTxHourMs == 0 &&
+		t.Failed >= 0 && t.Transactions >= t.Failed
+}
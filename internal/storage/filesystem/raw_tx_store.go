@@ -0,0 +1,237 @@
+// Package filesystem provides storage implementations backed by files in a local directory.
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"solana-token-lab/internal/storage"
+)
+
+const rawTxExt = ".json"
+
+// rawTxFile is the on-disk form of an archived transaction.
+type rawTxFile struct {
+	TxSignature string          `json:"tx_signature"`
+	Slot        int64           `json:"slot"`
+	BlockTime   int64           `json:"block_time"`
+	ArchivedAt  int64           `json:"archived_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// rawTxEntry indexes one archive file.
+type rawTxEntry struct {
+	slot      int64
+	signature string
+	size      int64 // len(Data)
+}
+
+// RawTxStore is a storage.RawTxStore that writes one JSON file per transaction,
+// named <slot>_<signature>.json with the slot zero-padded so names sort by (slot, signature).
+// Data must be valid JSON and is stored compacted. The index of archived files is loaded when the store is opened.
+type RawTxStore struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	index map[string]rawTxEntry // tx_signature -> entry
+	bytes int64
+}
+
+// NewRawTxStore opens (creating if needed) an archive in dir holding at most maxBytes
+// of transaction data (storage.DefaultRawTxMaxBytes if maxBytes <= 0).
+func NewRawTxStore(dir string, maxBytes int64) (*RawTxStore, error) {
+	if maxBytes <= 0 {
+		maxBytes = storage.DefaultRawTxMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create raw tx archive dir: %w", err)
+	}
+
+	s := &RawTxStore{
+		dir:      dir,
+		maxBytes: maxBytes,
+		index:    make(map[string]rawTxEntry),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read raw tx archive dir: %w", err)
+	}
+	for _, de := range entries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), rawTxExt) {
+			continue
+		}
+		f, err := s.readFile(de.Name())
+		if err != nil {
+			return nil, err
+		}
+		s.index[f.TxSignature] = rawTxEntry{slot: f.Slot, signature: f.TxSignature, size: int64(len(f.Data))}
+		s.bytes += int64(len(f.Data))
+	}
+	return s, nil
+}
+
+// fileName returns the archive file name for (slot, signature).
+func fileName(slot int64, signature string) string {
+	return fmt.Sprintf("%020d_%s%s", slot, signature, rawTxExt)
+}
+
+func (s *RawTxStore) readFile(name string) (*rawTxFile, error) {
+	raw, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("read raw tx %s: %w", name, err)
+	}
+	var f rawTxFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("decode raw tx %s: %w", name, err)
+	}
+	return &f, nil
+}
+
+func (s *RawTxStore) remove(e rawTxEntry) error {
+	if err := os.Remove(filepath.Join(s.dir, fileName(e.slot, e.signature))); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove raw tx %s: %w", e.signature, err)
+	}
+	delete(s.index, e.signature)
+	s.bytes -= e.size
+	return nil
+}
+
+// sortedLocked returns index entries ordered by (slot, signature). Caller holds mu.
+func (s *RawTxStore) sortedLocked() []rawTxEntry {
+	entries := make([]rawTxEntry, 0, len(s.index))
+	for _, e := range s.index {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].slot != entries[j].slot {
+			return entries[i].slot < entries[j].slot
+		}
+		return entries[i].signature < entries[j].signature
+	})
+	return entries
+}
+
+// Put archives a transaction and evicts the oldest ones beyond the size cap.
+func (s *RawTxStore) Put(_ context.Context, tx *storage.RawTransaction) error {
+	if tx == nil || tx.TxSignature == "" || strings.ContainsAny(tx.TxSignature, `/\`) {
+		return storage.ErrInvalidInput
+	}
+	// Data is stored compacted, which is also what is read back and counted.
+	var data bytes.Buffer
+	if err := json.Compact(&data, tx.Data); err != nil {
+		return storage.ErrInvalidInput
+	}
+
+	raw, err := json.Marshal(rawTxFile{
+		TxSignature: tx.TxSignature,
+		Slot:        tx.Slot,
+		BlockTime:   tx.BlockTime,
+		ArchivedAt:  tx.ArchivedAt,
+		Data:        data.Bytes(),
+	})
+	if err != nil {
+		return fmt.Errorf("encode raw tx %s: %w", tx.TxSignature, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.index[tx.TxSignature]; ok {
+		if err := s.remove(old); err != nil {
+			return err
+		}
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated archive entry.
+	name := fileName(tx.Slot, tx.TxSignature)
+	tmp := filepath.Join(s.dir, name+".tmp")
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return fmt.Errorf("write raw tx %s: %w", tx.TxSignature, err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("write raw tx %s: %w", tx.TxSignature, err)
+	}
+
+	size := int64(data.Len())
+	s.index[tx.TxSignature] = rawTxEntry{slot: tx.Slot, signature: tx.TxSignature, size: size}
+	s.bytes += size
+
+	if s.bytes > s.maxBytes {
+		for _, e := range s.sortedLocked() {
+			if s.bytes <= s.maxBytes {
+				break
+			}
+			if err := s.remove(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns an archived transaction.
+func (s *RawTxStore) Get(_ context.Context, signature string) (*storage.RawTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.index[signature]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return s.load(e)
+}
+
+func (s *RawTxStore) load(e rawTxEntry) (*storage.RawTransaction, error) {
+	f, err := s.readFile(fileName(e.slot, e.signature))
+	if err != nil {
+		return nil, err
+	}
+	return &storage.RawTransaction{
+		TxSignature: f.TxSignature,
+		Slot:        f.Slot,
+		BlockTime:   f.BlockTime,
+		Data:        []byte(f.Data),
+		ArchivedAt:  f.ArchivedAt,
+	}, nil
+}
+
+// GetPage returns up to limit transactions after (afterSlot, afterSignature), ordered by (slot, tx_signature).
+func (s *RawTxStore) GetPage(_ context.Context, afterSlot int64, afterSignature string, limit int) ([]*storage.RawTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*storage.RawTransaction
+	for _, e := range s.sortedLocked() {
+		if e.slot < afterSlot || (e.slot == afterSlot && e.signature <= afterSignature) {
+			continue
+		}
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		tx, err := s.load(e)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, nil
+}
+
+// Stats returns the number of archived transactions and their total data size.
+func (s *RawTxStore) Stats(_ context.Context) (int, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.index), s.bytes, nil
+}
+
+var _ storage.RawTxStore = (*RawTxStore)(nil)
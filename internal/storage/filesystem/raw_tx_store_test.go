@@ -0,0 +1,57 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestRawTxStore_Contract(t *testing.T) {
+	storagetest.RunRawTxStoreSuite(t, func(t *testing.T, maxBytes int64) storage.RawTxStore {
+		s, err := NewRawTxStore(t.TempDir(), maxBytes)
+		if err != nil {
+			t.Fatalf("NewRawTxStore: %v", err)
+		}
+		return s
+	})
+}
+
+func TestRawTxStore_ReopenLoadsIndex(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := NewRawTxStore(dir, 100)
+	if err != nil {
+		t.Fatalf("NewRawTxStore: %v", err)
+	}
+	tx := &storage.RawTransaction{TxSignature: "sig1", Slot: 42, BlockTime: 7, Data: []byte(`{"slot": 42}`), ArchivedAt: 9}
+	if err := s.Put(ctx, tx); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "00000000000000000042_sig1.json")); err != nil {
+		t.Errorf("expected archive file named by slot and signature: %v", err)
+	}
+
+	reopened, err := NewRawTxStore(dir, 100)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	count, bytes, err := reopened.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if count != 1 || bytes != int64(len(`{"slot":42}`)) {
+		t.Errorf("expected 1 tx / compacted size after reopen, got %d / %d", count, bytes)
+	}
+	got, err := reopened.Get(ctx, "sig1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Slot != 42 || got.BlockTime != 7 || got.ArchivedAt != 9 || string(got.Data) != `{"slot":42}` {
+		t.Errorf("unexpected tx after reopen: %+v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package storage
+
+import "context"
+
+// Event tables covered by finality verification.
+const (
+	EventTableSwaps           = "swaps"
+	EventTableSwapEvents      = "swap_events"
+	EventTableLiquidityEvents = "liquidity_events"
+)
+
+// UnverifiedSignature is a transaction with stored events that is not yet known to be finalized.
+type UnverifiedSignature struct {
+	TxSignature string
+	Slot        int64 // lowest slot among its events
+	Timestamp   int64 // earliest event timestamp (ms)
+}
+
+// FinalityCorrection audits one event removed because its transaction never
+// reached finalized commitment (e.g. its confirmed slot was skipped by a fork).
+type FinalityCorrection struct {
+	EventTable  string // EventTableSwaps | EventTableSwapEvents | EventTableLiquidityEvents
+	TxSignature string
+	EventIndex  int
+	CandidateID string // "" for swap events and unassociated liquidity events
+	Mint        string // "" for swaps
+	Slot        int64
+	Timestamp   int64 // event timestamp (ms)
+	DetectedAt  int64 // when the transaction was found missing (ms)
+}
+
+// FinalityStore tracks which ingested transactions are confirmed at finalized
+// commitment across the swaps, swap_events and liquidity_events tables, and
+// removes the events of transactions that were dropped.
+type FinalityStore interface {
+	// GetUnverified returns distinct signatures of events with timestamp in [start, end)
+	// that are not marked finalized, ordered by (slot, tx_signature). limit <= 0 means no limit.
+	GetUnverified(ctx context.Context, start, end int64, limit int) ([]UnverifiedSignature, error)
+
+	// MarkFinalized records signatures as finalized. Re-marking a signature is a no-op.
+	MarkFinalized(ctx context.Context, signatures []string, verifiedAt int64) error
+
+	// RemoveDropped deletes every event of a signature from all event tables and records
+	// one correction per deleted event, atomically. Returns the corrections (none if the
+	// signature has no events).
+	RemoveDropped(ctx context.Context, signature string, detectedAt int64) ([]*FinalityCorrection, error)
+
+	// CountUnverified returns the number of stored events whose signature is not marked finalized.
+	CountUnverified(ctx context.Context) (int, error)
+
+	// GetCorrections returns corrections with detected_at in [start, end),
+	// ordered by (detected_at, event_table, tx_signature, event_index).
+	GetCorrections(ctx context.Context, start, end int64) ([]*FinalityCorrection, error)
+}
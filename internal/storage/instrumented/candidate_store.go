@@ -0,0 +1,71 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// CandidateStore wraps a storage.CandidateStore with token_candidates latency and error metrics.
+type CandidateStore struct {
+	observer
+	inner storage.CandidateStore
+}
+
+// NewCandidateStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewCandidateStore(inner storage.CandidateStore, backend string, record RecordFunc) *CandidateStore {
+	return &CandidateStore{observer: observer{store: "candidate", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.CandidateStore = (*CandidateStore)(nil)
+
+// Insert implements storage.CandidateStore.
+func (s *CandidateStore) Insert(ctx context.Context, c *domain.TokenCandidate) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, c)
+}
+
+// GetByID implements storage.CandidateStore.
+func (s *CandidateStore) GetByID(ctx context.Context, candidateID string) (_ *domain.TokenCandidate, err error) {
+	defer s.observe("get_by_id", time.Now(), &err)
+	return s.inner.GetByID(ctx, candidateID)
+}
+
+// GetByMint implements storage.CandidateStore.
+func (s *CandidateStore) GetByMint(ctx context.Context, mint string) (_ []*domain.TokenCandidate, err error) {
+	defer s.observe("get_by_mint", time.Now(), &err)
+	return s.inner.GetByMint(ctx, mint)
+}
+
+// GetByTimeRange implements storage.CandidateStore.
+func (s *CandidateStore) GetByTimeRange(ctx context.Context, start, end int64, opts ...storage.CandidateReadOption) (_ []*domain.TokenCandidate, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, start, end, opts...)
+}
+
+// GetBySource implements storage.CandidateStore.
+func (s *CandidateStore) GetBySource(ctx context.Context, source domain.Source, opts ...storage.CandidateReadOption) (_ []*domain.TokenCandidate, err error) {
+	defer s.observe("get_by_source", time.Now(), &err)
+	return s.inner.GetBySource(ctx, source, opts...)
+}
+
+// Close implements storage.CandidateStore.
+func (s *CandidateStore) Close(ctx context.Context, candidateID string, closedAt int64, fingerprint string) (err error) {
+	defer s.observe("close", time.Now(), &err)
+	return s.inner.Close(ctx, candidateID, closedAt, fingerprint)
+}
+
+// SoftDelete implements storage.CandidateStore.
+func (s *CandidateStore) SoftDelete(ctx context.Context, d *storage.CandidateDeletion) (err error) {
+	defer s.observe("soft_delete", time.Now(), &err)
+	return s.inner.SoftDelete(ctx, d)
+}
+
+// GetDeletions implements storage.CandidateStore.
+func (s *CandidateStore) GetDeletions(ctx context.Context) (_ []*storage.CandidateDeletion, err error) {
+	defer s.observe("get_deletions", time.Now(), &err)
+	return s.inner.GetDeletions(ctx)
+}
@@ -0,0 +1,41 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DerivedFeatureStore wraps a storage.DerivedFeatureStore with derived_features latency and error metrics.
+type DerivedFeatureStore struct {
+	observer
+	inner storage.DerivedFeatureStore
+}
+
+// NewDerivedFeatureStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewDerivedFeatureStore(inner storage.DerivedFeatureStore, backend string, record RecordFunc) *DerivedFeatureStore {
+	return &DerivedFeatureStore{observer: observer{store: "derived_feature", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.DerivedFeatureStore = (*DerivedFeatureStore)(nil)
+
+// InsertBulk implements storage.DerivedFeatureStore.
+func (s *DerivedFeatureStore) InsertBulk(ctx context.Context, points []*domain.DerivedFeaturePoint) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, points)
+}
+
+// GetByCandidateID implements storage.DerivedFeatureStore.
+func (s *DerivedFeatureStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.DerivedFeaturePoint, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByTimeRange implements storage.DerivedFeatureStore.
+func (s *DerivedFeatureStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) (_ []*domain.DerivedFeaturePoint, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, candidateID, start, end)
+}
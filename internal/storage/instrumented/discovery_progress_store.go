@@ -0,0 +1,52 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/storage"
+)
+
+// DiscoveryProgressStore wraps a storage.DiscoveryProgressStore with discovery progress latency and error metrics.
+type DiscoveryProgressStore struct {
+	observer
+	inner storage.DiscoveryProgressStore
+}
+
+// NewDiscoveryProgressStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewDiscoveryProgressStore(inner storage.DiscoveryProgressStore, backend string, record RecordFunc) *DiscoveryProgressStore {
+	return &DiscoveryProgressStore{observer: observer{store: "discovery_progress", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.DiscoveryProgressStore = (*DiscoveryProgressStore)(nil)
+
+// GetLastProcessed implements storage.DiscoveryProgressStore.
+func (s *DiscoveryProgressStore) GetLastProcessed(ctx context.Context) (_ *storage.DiscoveryProgress, err error) {
+	defer s.observe("get_last_processed", time.Now(), &err)
+	return s.inner.GetLastProcessed(ctx)
+}
+
+// SetLastProcessed implements storage.DiscoveryProgressStore.
+func (s *DiscoveryProgressStore) SetLastProcessed(ctx context.Context, progress *storage.DiscoveryProgress) (err error) {
+	defer s.observe("set_last_processed", time.Now(), &err)
+	return s.inner.SetLastProcessed(ctx, progress)
+}
+
+// IsMintSeen implements storage.DiscoveryProgressStore.
+func (s *DiscoveryProgressStore) IsMintSeen(ctx context.Context, mint string) (_ bool, err error) {
+	defer s.observe("is_mint_seen", time.Now(), &err)
+	return s.inner.IsMintSeen(ctx, mint)
+}
+
+// MarkMintSeen implements storage.DiscoveryProgressStore.
+func (s *DiscoveryProgressStore) MarkMintSeen(ctx context.Context, mint string) (err error) {
+	defer s.observe("mark_mint_seen", time.Now(), &err)
+	return s.inner.MarkMintSeen(ctx, mint)
+}
+
+// LoadSeenMints implements storage.DiscoveryProgressStore.
+func (s *DiscoveryProgressStore) LoadSeenMints(ctx context.Context) (_ []string, err error) {
+	defer s.observe("load_seen_mints", time.Now(), &err)
+	return s.inner.LoadSeenMints(ctx)
+}
@@ -0,0 +1,38 @@
+// Package instrumented decorates storage interfaces with latency and error metrics.
+//
+// Each wrapper implements the same storage interface as the store it wraps and
+// returns its results and errors unchanged, so sentinel checks such as
+// errors.Is(err, storage.ErrDuplicateKey) keep working on wrapped stores.
+package instrumented
+
+import (
+	"errors"
+	"time"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RecordFunc receives one observation per store call.
+// err is nil for successful calls and for expected sentinel errors
+// (ErrNotFound, ErrDuplicateKey), which are part of normal control flow.
+type RecordFunc func(store, backend, operation string, seconds float64, err error)
+
+// observer holds the labels shared by every call on one wrapped store.
+type observer struct {
+	store   string
+	backend string
+	record  RecordFunc
+}
+
+// observe records a call that started at start and returned *errp.
+// Intended for use as: defer s.observe("insert", time.Now(), &err).
+func (o observer) observe(operation string, start time.Time, errp *error) {
+	if o.record == nil {
+		return
+	}
+	err := *errp
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrDuplicateKey) {
+		err = nil
+	}
+	o.record(o.store, o.backend, operation, time.Since(start).Seconds(), err)
+}
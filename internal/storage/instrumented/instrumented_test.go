@@ -0,0 +1,131 @@
+package instrumented
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// observation is one RecordFunc call captured by a test recorder.
+type observation struct {
+	store, backend, operation string
+	seconds                   float64
+	err                       error
+}
+
+// recorder captures observations for assertions.
+type recorder struct {
+	calls []observation
+}
+
+func (r *recorder) record(store, backend, operation string, seconds float64, err error) {
+	r.calls = append(r.calls, observation{store, backend, operation, seconds, err})
+}
+
+// failingTradeStore returns errBoom from GetAll.
+type failingTradeStore struct {
+	storage.TradeRecordStore
+}
+
+var errBoom = errors.New("boom")
+
+func (failingTradeStore) GetAll(context.Context) ([]*domain.TradeRecord, error) {
+	return nil, errBoom
+}
+
+func TestCandidateStore_RecordsCall(t *testing.T) {
+	ctx := context.Background()
+	rec := &recorder{}
+	store := NewCandidateStore(memory.NewCandidateStore(), "memory", rec.record)
+
+	c := &domain.TokenCandidate{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "m1", TxSignature: "tx1"}
+	if err := store.Insert(ctx, c); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	if len(rec.calls) != 1 {
+		t.Fatalf("expected 1 observation, got %d", len(rec.calls))
+	}
+	got := rec.calls[0]
+	if got.store != "candidate" || got.backend != "memory" || got.operation != "insert" {
+		t.Errorf("unexpected labels: %+v", got)
+	}
+	if got.seconds < 0 {
+		t.Errorf("expected non-negative duration, got %f", got.seconds)
+	}
+	if got.err != nil {
+		t.Errorf("expected nil error, got %v", got.err)
+	}
+
+	// Results pass through unchanged
+	loaded, err := store.GetByID(ctx, "c1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if loaded.Mint != "m1" {
+		t.Errorf("expected mint m1, got %s", loaded.Mint)
+	}
+	if rec.calls[1].operation != "get_by_id" {
+		t.Errorf("expected get_by_id, got %s", rec.calls[1].operation)
+	}
+}
+
+func TestCandidateStore_SentinelErrorsPassThrough(t *testing.T) {
+	ctx := context.Background()
+	rec := &recorder{}
+	store := NewCandidateStore(memory.NewCandidateStore(), "memory", rec.record)
+
+	c := &domain.TokenCandidate{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "m1", TxSignature: "tx1"}
+	if err := store.Insert(ctx, c); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	err := store.Insert(ctx, c)
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got %v", err)
+	}
+
+	_, err = store.GetByID(ctx, "missing")
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	// Expected sentinels are timed but not counted as errors
+	for _, call := range rec.calls {
+		if call.err != nil {
+			t.Errorf("expected no error observation for %s, got %v", call.operation, call.err)
+		}
+	}
+}
+
+func TestTradeRecordStore_RecordsError(t *testing.T) {
+	rec := &recorder{}
+	store := NewTradeRecordStore(failingTradeStore{}, "postgres", rec.record)
+
+	_, err := store.GetAll(context.Background())
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected errBoom, got %v", err)
+	}
+
+	if len(rec.calls) != 1 {
+		t.Fatalf("expected 1 observation, got %d", len(rec.calls))
+	}
+	got := rec.calls[0]
+	if got.store != "trade_record" || got.backend != "postgres" || got.operation != "get_all" {
+		t.Errorf("unexpected labels: %+v", got)
+	}
+	if !errors.Is(got.err, errBoom) {
+		t.Errorf("expected recorded error errBoom, got %v", got.err)
+	}
+}
+
+func TestNilRecordFunc(t *testing.T) {
+	store := NewSwapEventStore(memory.NewSwapEventStore(), "memory", nil)
+	if _, err := store.GetDistinctMintsByTimeRange(context.Background(), 0, 1000); err != nil {
+		t.Fatalf("GetDistinctMintsByTimeRange failed: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// LiquidityEventStore wraps a storage.LiquidityEventStore with liquidity_events latency and error metrics.
+type LiquidityEventStore struct {
+	observer
+	inner storage.LiquidityEventStore
+}
+
+// NewLiquidityEventStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewLiquidityEventStore(inner storage.LiquidityEventStore, backend string, record RecordFunc) *LiquidityEventStore {
+	return &LiquidityEventStore{observer: observer{store: "liquidity_event", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.LiquidityEventStore = (*LiquidityEventStore)(nil)
+
+// Insert implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) Insert(ctx context.Context, e *domain.LiquidityEvent) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, e)
+}
+
+// InsertBulk implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) InsertBulk(ctx context.Context, events []*domain.LiquidityEvent) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, events)
+}
+
+// GetByCandidateID implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.LiquidityEvent, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByTimeRange implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) (_ []*domain.LiquidityEvent, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, candidateID, start, end)
+}
+
+// GetByMintTimeRange implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) GetByMintTimeRange(ctx context.Context, mint string, start, end int64) (_ []*domain.LiquidityEvent, err error) {
+	defer s.observe("get_by_mint_time_range", time.Now(), &err)
+	return s.inner.GetByMintTimeRange(ctx, mint, start, end)
+}
+
+// GetUnassociated implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) GetUnassociated(ctx context.Context) (_ []*domain.LiquidityEvent, err error) {
+	defer s.observe("get_unassociated", time.Now(), &err)
+	return s.inner.GetUnassociated(ctx)
+}
+
+// UpdateCandidateID implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) UpdateCandidateID(ctx context.Context, e *domain.LiquidityEvent, candidateID string) (err error) {
+	defer s.observe("update_candidate_id", time.Now(), &err)
+	return s.inner.UpdateCandidateID(ctx, e, candidateID)
+}
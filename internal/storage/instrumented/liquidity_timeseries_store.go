@@ -0,0 +1,47 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// LiquidityTimeseriesStore wraps a storage.LiquidityTimeseriesStore with liquidity_timeseries latency and error metrics.
+type LiquidityTimeseriesStore struct {
+	observer
+	inner storage.LiquidityTimeseriesStore
+}
+
+// NewLiquidityTimeseriesStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewLiquidityTimeseriesStore(inner storage.LiquidityTimeseriesStore, backend string, record RecordFunc) *LiquidityTimeseriesStore {
+	return &LiquidityTimeseriesStore{observer: observer{store: "liquidity_timeseries", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.LiquidityTimeseriesStore = (*LiquidityTimeseriesStore)(nil)
+
+// InsertBulk implements storage.LiquidityTimeseriesStore.
+func (s *LiquidityTimeseriesStore) InsertBulk(ctx context.Context, points []*domain.LiquidityTimeseriesPoint) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, points)
+}
+
+// GetByCandidateID implements storage.LiquidityTimeseriesStore.
+func (s *LiquidityTimeseriesStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.LiquidityTimeseriesPoint, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByTimeRange implements storage.LiquidityTimeseriesStore.
+func (s *LiquidityTimeseriesStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) (_ []*domain.LiquidityTimeseriesPoint, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, candidateID, start, end)
+}
+
+// GetGlobalTimeRange implements storage.LiquidityTimeseriesStore.
+func (s *LiquidityTimeseriesStore) GetGlobalTimeRange(ctx context.Context) (minTs, maxTs int64, err error) {
+	defer s.observe("get_global_time_range", time.Now(), &err)
+	return s.inner.GetGlobalTimeRange(ctx)
+}
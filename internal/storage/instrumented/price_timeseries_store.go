@@ -0,0 +1,47 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// PriceTimeseriesStore wraps a storage.PriceTimeseriesStore with price_timeseries latency and error metrics.
+type PriceTimeseriesStore struct {
+	observer
+	inner storage.PriceTimeseriesStore
+}
+
+// NewPriceTimeseriesStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewPriceTimeseriesStore(inner storage.PriceTimeseriesStore, backend string, record RecordFunc) *PriceTimeseriesStore {
+	return &PriceTimeseriesStore{observer: observer{store: "price_timeseries", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.PriceTimeseriesStore = (*PriceTimeseriesStore)(nil)
+
+// InsertBulk implements storage.PriceTimeseriesStore.
+func (s *PriceTimeseriesStore) InsertBulk(ctx context.Context, points []*domain.PriceTimeseriesPoint) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, points)
+}
+
+// GetByCandidateID implements storage.PriceTimeseriesStore.
+func (s *PriceTimeseriesStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.PriceTimeseriesPoint, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByTimeRange implements storage.PriceTimeseriesStore.
+func (s *PriceTimeseriesStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) (_ []*domain.PriceTimeseriesPoint, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, candidateID, start, end)
+}
+
+// GetGlobalTimeRange implements storage.PriceTimeseriesStore.
+func (s *PriceTimeseriesStore) GetGlobalTimeRange(ctx context.Context) (minTs, maxTs int64, err error) {
+	defer s.observe("get_global_time_range", time.Now(), &err)
+	return s.inner.GetGlobalTimeRange(ctx)
+}
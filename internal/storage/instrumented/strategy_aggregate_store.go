@@ -0,0 +1,65 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// StrategyAggregateStore wraps a storage.StrategyAggregateStore with strategy_aggregates latency and error metrics.
+type StrategyAggregateStore struct {
+	observer
+	inner storage.StrategyAggregateStore
+}
+
+// NewStrategyAggregateStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewStrategyAggregateStore(inner storage.StrategyAggregateStore, backend string, record RecordFunc) *StrategyAggregateStore {
+	return &StrategyAggregateStore{observer: observer{store: "strategy_aggregate", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.StrategyAggregateStore = (*StrategyAggregateStore)(nil)
+
+// Insert implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) Insert(ctx context.Context, a *domain.StrategyAggregate) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, a)
+}
+
+// InsertBulk implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) InsertBulk(ctx context.Context, aggregates []*domain.StrategyAggregate) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, aggregates)
+}
+
+// Upsert implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) Upsert(ctx context.Context, a *domain.StrategyAggregate) (err error) {
+	defer s.observe("upsert", time.Now(), &err)
+	return s.inner.Upsert(ctx, a)
+}
+
+// GetByKey implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) GetByKey(ctx context.Context, strategyID, scenarioID, entryEventType string) (_ *domain.StrategyAggregate, err error) {
+	defer s.observe("get_by_key", time.Now(), &err)
+	return s.inner.GetByKey(ctx, strategyID, scenarioID, entryEventType)
+}
+
+// GetByStrategy implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) GetByStrategy(ctx context.Context, strategyID string) (_ []*domain.StrategyAggregate, err error) {
+	defer s.observe("get_by_strategy", time.Now(), &err)
+	return s.inner.GetByStrategy(ctx, strategyID)
+}
+
+// Find implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) Find(ctx context.Context, filter storage.AggregateFilter) (_ []*domain.StrategyAggregate, err error) {
+	defer s.observe("find", time.Now(), &err)
+	return s.inner.Find(ctx, filter)
+}
+
+// GetAll implements storage.StrategyAggregateStore.
+func (s *StrategyAggregateStore) GetAll(ctx context.Context) (_ []*domain.StrategyAggregate, err error) {
+	defer s.observe("get_all", time.Now(), &err)
+	return s.inner.GetAll(ctx)
+}
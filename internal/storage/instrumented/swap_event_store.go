@@ -0,0 +1,59 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// SwapEventStore wraps a storage.SwapEventStore with swap_events latency and error metrics.
+type SwapEventStore struct {
+	observer
+	inner storage.SwapEventStore
+}
+
+// NewSwapEventStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewSwapEventStore(inner storage.SwapEventStore, backend string, record RecordFunc) *SwapEventStore {
+	return &SwapEventStore{observer: observer{store: "swap_event", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.SwapEventStore = (*SwapEventStore)(nil)
+
+// Insert implements storage.SwapEventStore.
+func (s *SwapEventStore) Insert(ctx context.Context, e *domain.SwapEvent) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, e)
+}
+
+// InsertBulk implements storage.SwapEventStore.
+func (s *SwapEventStore) InsertBulk(ctx context.Context, events []*domain.SwapEvent) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, events)
+}
+
+// GetByTimeRange implements storage.SwapEventStore.
+func (s *SwapEventStore) GetByTimeRange(ctx context.Context, start, end int64) (_ []*domain.SwapEvent, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, start, end)
+}
+
+// GetByMintTimeRange implements storage.SwapEventStore.
+func (s *SwapEventStore) GetByMintTimeRange(ctx context.Context, mint string, start, end int64) (_ []*domain.SwapEvent, err error) {
+	defer s.observe("get_by_mint_time_range", time.Now(), &err)
+	return s.inner.GetByMintTimeRange(ctx, mint, start, end)
+}
+
+// GetDistinctMintsByTimeRange implements storage.SwapEventStore.
+func (s *SwapEventStore) GetDistinctMintsByTimeRange(ctx context.Context, start, end int64) (_ []string, err error) {
+	defer s.observe("get_distinct_mints_by_time_range", time.Now(), &err)
+	return s.inner.GetDistinctMintsByTimeRange(ctx, start, end)
+}
+
+// GetGlobalTimeRange implements storage.SwapEventStore.
+func (s *SwapEventStore) GetGlobalTimeRange(ctx context.Context) (minTs, maxTs int64, err error) {
+	defer s.observe("get_global_time_range", time.Now(), &err)
+	return s.inner.GetGlobalTimeRange(ctx)
+}
@@ -0,0 +1,47 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// SwapStore wraps a storage.SwapStore with swaps latency and error metrics.
+type SwapStore struct {
+	observer
+	inner storage.SwapStore
+}
+
+// NewSwapStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewSwapStore(inner storage.SwapStore, backend string, record RecordFunc) *SwapStore {
+	return &SwapStore{observer: observer{store: "swap", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.SwapStore = (*SwapStore)(nil)
+
+// Insert implements storage.SwapStore.
+func (s *SwapStore) Insert(ctx context.Context, sw *domain.Swap) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, sw)
+}
+
+// InsertBulk implements storage.SwapStore.
+func (s *SwapStore) InsertBulk(ctx context.Context, swaps []*domain.Swap) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, swaps)
+}
+
+// GetByCandidateID implements storage.SwapStore.
+func (s *SwapStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.Swap, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByTimeRange implements storage.SwapStore.
+func (s *SwapStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) (_ []*domain.Swap, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, candidateID, start, end)
+}
@@ -0,0 +1,41 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TokenHolderSnapshotStore wraps a storage.TokenHolderSnapshotStore with token_holder_snapshots latency and error metrics.
+type TokenHolderSnapshotStore struct {
+	observer
+	inner storage.TokenHolderSnapshotStore
+}
+
+// NewTokenHolderSnapshotStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewTokenHolderSnapshotStore(inner storage.TokenHolderSnapshotStore, backend string, record RecordFunc) *TokenHolderSnapshotStore {
+	return &TokenHolderSnapshotStore{observer: observer{store: "token_holder_snapshot", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.TokenHolderSnapshotStore = (*TokenHolderSnapshotStore)(nil)
+
+// Insert implements storage.TokenHolderSnapshotStore.
+func (s *TokenHolderSnapshotStore) Insert(ctx context.Context, snap *domain.TokenHolderSnapshot) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, snap)
+}
+
+// GetLatest implements storage.TokenHolderSnapshotStore.
+func (s *TokenHolderSnapshotStore) GetLatest(ctx context.Context, candidateID string) (_ *domain.TokenHolderSnapshot, err error) {
+	defer s.observe("get_latest", time.Now(), &err)
+	return s.inner.GetLatest(ctx, candidateID)
+}
+
+// GetByCandidateID implements storage.TokenHolderSnapshotStore.
+func (s *TokenHolderSnapshotStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.TokenHolderSnapshot, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
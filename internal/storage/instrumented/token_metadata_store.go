@@ -0,0 +1,59 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TokenMetadataStore wraps a storage.TokenMetadataStore with token_metadata latency and error metrics.
+type TokenMetadataStore struct {
+	observer
+	inner storage.TokenMetadataStore
+}
+
+// NewTokenMetadataStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewTokenMetadataStore(inner storage.TokenMetadataStore, backend string, record RecordFunc) *TokenMetadataStore {
+	return &TokenMetadataStore{observer: observer{store: "token_metadata", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.TokenMetadataStore = (*TokenMetadataStore)(nil)
+
+// Insert implements storage.TokenMetadataStore.
+func (s *TokenMetadataStore) Insert(ctx context.Context, m *domain.TokenMetadata) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, m)
+}
+
+// GetByID implements storage.TokenMetadataStore.
+func (s *TokenMetadataStore) GetByID(ctx context.Context, candidateID string) (_ *domain.TokenMetadata, err error) {
+	defer s.observe("get_by_id", time.Now(), &err)
+	return s.inner.GetByID(ctx, candidateID)
+}
+
+// GetByMint implements storage.TokenMetadataStore.
+func (s *TokenMetadataStore) GetByMint(ctx context.Context, mint string) (_ *domain.TokenMetadata, err error) {
+	defer s.observe("get_by_mint", time.Now(), &err)
+	return s.inner.GetByMint(ctx, mint)
+}
+
+// GetByMints implements storage.TokenMetadataStore.
+func (s *TokenMetadataStore) GetByMints(ctx context.Context, mints []string) (_ []*domain.TokenMetadata, err error) {
+	defer s.observe("get_by_mints", time.Now(), &err)
+	return s.inner.GetByMints(ctx, mints)
+}
+
+// Upsert implements storage.TokenMetadataStore.
+func (s *TokenMetadataStore) Upsert(ctx context.Context, m *domain.TokenMetadata) (err error) {
+	defer s.observe("upsert", time.Now(), &err)
+	return s.inner.Upsert(ctx, m)
+}
+
+// GetStale implements storage.TokenMetadataStore.
+func (s *TokenMetadataStore) GetStale(ctx context.Context, olderThan int64) (_ []*domain.TokenMetadata, err error) {
+	defer s.observe("get_stale", time.Now(), &err)
+	return s.inner.GetStale(ctx, olderThan)
+}
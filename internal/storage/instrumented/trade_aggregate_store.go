@@ -0,0 +1,59 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TradeAggregateStore wraps a storage.TradeAggregateStore with trade_records mirror latency and error metrics.
+type TradeAggregateStore struct {
+	observer
+	inner storage.TradeAggregateStore
+}
+
+// NewTradeAggregateStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewTradeAggregateStore(inner storage.TradeAggregateStore, backend string, record RecordFunc) *TradeAggregateStore {
+	return &TradeAggregateStore{observer: observer{store: "trade_aggregate", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.TradeAggregateStore = (*TradeAggregateStore)(nil)
+
+// InsertMirrored implements storage.TradeAggregateStore.
+func (s *TradeAggregateStore) InsertMirrored(ctx context.Context, trades []*storage.MirroredTrade) (err error) {
+	defer s.observe("insert_mirrored", time.Now(), &err)
+	return s.inner.InsertMirrored(ctx, trades)
+}
+
+// CountByStrategyScenario implements storage.TradeAggregateStore.
+func (s *TradeAggregateStore) CountByStrategyScenario(ctx context.Context, strategyID, scenarioID string) (_ int, err error) {
+	defer s.observe("count_by_strategy_scenario", time.Now(), &err)
+	return s.inner.CountByStrategyScenario(ctx, strategyID, scenarioID)
+}
+
+// ComputeAggregate implements storage.TradeAggregateStore.
+func (s *TradeAggregateStore) ComputeAggregate(ctx context.Context, strategyID, scenarioID, entryEventType string, matchStrategyType bool) (_ *domain.StrategyAggregate, err error) {
+	defer s.observe("compute_aggregate", time.Now(), &err)
+	return s.inner.ComputeAggregate(ctx, strategyID, scenarioID, entryEventType, matchStrategyType)
+}
+
+// CountMirrored implements storage.TradeAggregateStore.
+func (s *TradeAggregateStore) CountMirrored(ctx context.Context) (_ int, err error) {
+	defer s.observe("count_mirrored", time.Now(), &err)
+	return s.inner.CountMirrored(ctx)
+}
+
+// GetSyncWatermark implements storage.TradeAggregateStore.
+func (s *TradeAggregateStore) GetSyncWatermark(ctx context.Context) (_ int64, err error) {
+	defer s.observe("get_sync_watermark", time.Now(), &err)
+	return s.inner.GetSyncWatermark(ctx)
+}
+
+// SetSyncWatermark implements storage.TradeAggregateStore.
+func (s *TradeAggregateStore) SetSyncWatermark(ctx context.Context, watermark int64) (err error) {
+	defer s.observe("set_sync_watermark", time.Now(), &err)
+	return s.inner.SetSyncWatermark(ctx, watermark)
+}
@@ -0,0 +1,83 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TradeRecordStore wraps a storage.TradeRecordStore with trade_records latency and error metrics.
+type TradeRecordStore struct {
+	observer
+	inner storage.TradeRecordStore
+}
+
+// NewTradeRecordStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewTradeRecordStore(inner storage.TradeRecordStore, backend string, record RecordFunc) *TradeRecordStore {
+	return &TradeRecordStore{observer: observer{store: "trade_record", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.TradeRecordStore = (*TradeRecordStore)(nil)
+
+// Insert implements storage.TradeRecordStore.
+func (s *TradeRecordStore) Insert(ctx context.Context, t *domain.TradeRecord) (err error) {
+	defer s.observe("insert", time.Now(), &err)
+	return s.inner.Insert(ctx, t)
+}
+
+// InsertBulk implements storage.TradeRecordStore.
+func (s *TradeRecordStore) InsertBulk(ctx context.Context, trades []*domain.TradeRecord) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, trades)
+}
+
+// Upsert implements storage.TradeRecordStore.
+func (s *TradeRecordStore) Upsert(ctx context.Context, t *domain.TradeRecord) (err error) {
+	defer s.observe("upsert", time.Now(), &err)
+	return s.inner.Upsert(ctx, t)
+}
+
+// GetByID implements storage.TradeRecordStore.
+func (s *TradeRecordStore) GetByID(ctx context.Context, tradeID string) (_ *domain.TradeRecord, err error) {
+	defer s.observe("get_by_id", time.Now(), &err)
+	return s.inner.GetByID(ctx, tradeID)
+}
+
+// GetByCandidateID implements storage.TradeRecordStore.
+func (s *TradeRecordStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.TradeRecord, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByStrategyScenario implements storage.TradeRecordStore.
+func (s *TradeRecordStore) GetByStrategyScenario(ctx context.Context, strategyID, scenarioID string) (_ []*domain.TradeRecord, err error) {
+	defer s.observe("get_by_strategy_scenario", time.Now(), &err)
+	return s.inner.GetByStrategyScenario(ctx, strategyID, scenarioID)
+}
+
+// GetAll implements storage.TradeRecordStore.
+func (s *TradeRecordStore) GetAll(ctx context.Context) (_ []*domain.TradeRecord, err error) {
+	defer s.observe("get_all", time.Now(), &err)
+	return s.inner.GetAll(ctx)
+}
+
+// GetPage implements storage.TradeRecordStore.
+func (s *TradeRecordStore) GetPage(ctx context.Context, afterTradeID string, limit int) (_ []*domain.TradeRecord, err error) {
+	defer s.observe("get_page", time.Now(), &err)
+	return s.inner.GetPage(ctx, afterTradeID, limit)
+}
+
+// GetChangedSince implements storage.TradeRecordStore.
+func (s *TradeRecordStore) GetChangedSince(ctx context.Context, after storage.TradeCursor, limit int) (_ []*storage.TradeChange, err error) {
+	defer s.observe("get_changed_since", time.Now(), &err)
+	return s.inner.GetChangedSince(ctx, after, limit)
+}
+
+// Count implements storage.TradeRecordStore.
+func (s *TradeRecordStore) Count(ctx context.Context) (_ int, err error) {
+	defer s.observe("count", time.Now(), &err)
+	return s.inner.Count(ctx)
+}
@@ -0,0 +1,41 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// VolumeTimeseriesStore wraps a storage.VolumeTimeseriesStore with volume_timeseries latency and error metrics.
+type VolumeTimeseriesStore struct {
+	observer
+	inner storage.VolumeTimeseriesStore
+}
+
+// NewVolumeTimeseriesStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewVolumeTimeseriesStore(inner storage.VolumeTimeseriesStore, backend string, record RecordFunc) *VolumeTimeseriesStore {
+	return &VolumeTimeseriesStore{observer: observer{store: "volume_timeseries", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.VolumeTimeseriesStore = (*VolumeTimeseriesStore)(nil)
+
+// InsertBulk implements storage.VolumeTimeseriesStore.
+func (s *VolumeTimeseriesStore) InsertBulk(ctx context.Context, points []*domain.VolumeTimeseriesPoint) (err error) {
+	defer s.observe("insert_bulk", time.Now(), &err)
+	return s.inner.InsertBulk(ctx, points)
+}
+
+// GetByCandidateID implements storage.VolumeTimeseriesStore.
+func (s *VolumeTimeseriesStore) GetByCandidateID(ctx context.Context, candidateID string) (_ []*domain.VolumeTimeseriesPoint, err error) {
+	defer s.observe("get_by_candidate_id", time.Now(), &err)
+	return s.inner.GetByCandidateID(ctx, candidateID)
+}
+
+// GetByTimeRange implements storage.VolumeTimeseriesStore.
+func (s *VolumeTimeseriesStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) (_ []*domain.VolumeTimeseriesPoint, err error) {
+	defer s.observe("get_by_time_range", time.Now(), &err)
+	return s.inner.GetByTimeRange(ctx, candidateID, start, end)
+}
@@ -0,0 +1,47 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// WatchlistStore wraps a storage.WatchlistStore with watchlist latency and error metrics.
+type WatchlistStore struct {
+	observer
+	inner storage.WatchlistStore
+}
+
+// NewWatchlistStore wraps inner, labelling observations with backend (e.g. "postgres", "memory").
+func NewWatchlistStore(inner storage.WatchlistStore, backend string, record RecordFunc) *WatchlistStore {
+	return &WatchlistStore{observer: observer{store: "watchlist", backend: backend, record: record}, inner: inner}
+}
+
+// Compile-time interface check.
+var _ storage.WatchlistStore = (*WatchlistStore)(nil)
+
+// Add implements storage.WatchlistStore.
+func (s *WatchlistStore) Add(ctx context.Context, e *domain.WatchlistEntry) (err error) {
+	defer s.observe("add", time.Now(), &err)
+	return s.inner.Add(ctx, e)
+}
+
+// Remove implements storage.WatchlistStore.
+func (s *WatchlistStore) Remove(ctx context.Context, mint string) (err error) {
+	defer s.observe("remove", time.Now(), &err)
+	return s.inner.Remove(ctx, mint)
+}
+
+// Get implements storage.WatchlistStore.
+func (s *WatchlistStore) Get(ctx context.Context, mint string) (_ *domain.WatchlistEntry, err error) {
+	defer s.observe("get", time.Now(), &err)
+	return s.inner.Get(ctx, mint)
+}
+
+// List implements storage.WatchlistStore.
+func (s *WatchlistStore) List(ctx context.Context) (_ []*domain.WatchlistEntry, err error) {
+	defer s.observe("list", time.Now(), &err)
+	return s.inner.List(ctx)
+}
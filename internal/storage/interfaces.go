@@ -1,3 +1,15 @@
+// Package storage defines the store interfaces shared by the memory, Postgres
+// and ClickHouse implementations.
+//
+// Every implementation must be safe for concurrent use and must not share
+// memory with its callers:
+//   - records and slices passed to Insert/Upsert-style methods may be reused or
+//     mutated by the caller after the call returns;
+//   - records and slices returned by Get-style methods are owned by the caller,
+//     who may mutate or sort them without affecting stored state or other callers.
+//
+// Database-backed stores get this for free by scanning into new values; the
+// memory stores copy on every write and read, including nullable pointer fields.
 package storage
 
 import (
@@ -6,22 +18,47 @@ import (
 	"solana-token-lab/internal/domain"
 )
 
+// Clearable is implemented by stores that can drop all their data in place.
+// Only in-memory stores support it; callers resetting a set of stores should
+// type-assert and skip the ones that do not.
+type Clearable interface {
+	// Clear removes all records.
+	Clear(ctx context.Context) error
+}
+
 // CandidateStore provides access to token_candidates storage.
 type CandidateStore interface {
 	// Insert adds a new candidate. Returns ErrDuplicateKey if candidate_id exists.
 	Insert(ctx context.Context, c *domain.TokenCandidate) error
 
-	// GetByID retrieves a candidate by its ID. Returns ErrNotFound if not exists.
+	// GetByID retrieves a candidate by its ID, soft-deleted or not. Returns ErrNotFound if not exists.
 	GetByID(ctx context.Context, candidateID string) (*domain.TokenCandidate, error)
 
-	// GetByMint retrieves all candidates for a given mint address.
+	// GetByMint retrieves all candidates for a given mint address, soft-deleted
+	// ones included so discovery does not re-create a removed candidate.
 	GetByMint(ctx context.Context, mint string) ([]*domain.TokenCandidate, error)
 
 	// GetByTimeRange retrieves candidates discovered within [start, end] (inclusive).
-	GetByTimeRange(ctx context.Context, start, end int64) ([]*domain.TokenCandidate, error)
+	// Soft-deleted candidates are excluded unless IncludeDeleted is passed.
+	GetByTimeRange(ctx context.Context, start, end int64, opts ...CandidateReadOption) ([]*domain.TokenCandidate, error)
 
 	// GetBySource retrieves all candidates of a given source type.
-	GetBySource(ctx context.Context, source domain.Source) ([]*domain.TokenCandidate, error)
+	// Soft-deleted candidates are excluded unless IncludeDeleted is passed.
+	GetBySource(ctx context.Context, source domain.Source, opts ...CandidateReadOption) ([]*domain.TokenCandidate, error)
+
+	// Close marks an OPEN candidate CLOSED with its closure time and the replay
+	// fingerprint of its observation window. Closing an already closed candidate
+	// leaves it unchanged. Returns ErrNotFound if not exists.
+	Close(ctx context.Context, candidateID string, closedAt int64, fingerprint string) error
+
+	// SoftDelete marks a candidate deleted and records d in the deletion audit
+	// log, atomically. The row and its trades are kept. Returns ErrInvalidInput
+	// without a candidate ID or reason, ErrNotFound if the candidate does not
+	// exist and ErrDuplicateKey if it is already deleted.
+	SoftDelete(ctx context.Context, d *CandidateDeletion) error
+
+	// GetDeletions returns the deletion audit log ordered by DeletedAt ASC, then candidate_id.
+	GetDeletions(ctx context.Context) ([]*CandidateDeletion, error)
 }
 
 // SwapStore provides access to swaps storage.
@@ -41,7 +78,8 @@ type SwapStore interface {
 
 // LiquidityEventStore provides access to liquidity_events storage.
 type LiquidityEventStore interface {
-	// Insert adds a new liquidity event. Returns ErrDuplicateKey if exists.
+	// Insert adds a new liquidity event.
+	// Returns ErrDuplicateKey if (tx_signature, event_index, mint or pool) exists, whatever the candidate ID.
 	Insert(ctx context.Context, e *domain.LiquidityEvent) error
 
 	// InsertBulk adds multiple events atomically. Fails entire batch on any duplicate.
@@ -56,6 +94,15 @@ type LiquidityEventStore interface {
 	// GetByMintTimeRange retrieves events by mint within [start, end) (end exclusive).
 	// Used for pre-candidate spike detection (ACTIVE_TOKEN discovery).
 	GetByMintTimeRange(ctx context.Context, mint string, start, end int64) ([]*domain.LiquidityEvent, error)
+
+	// GetUnassociated retrieves events stored without a candidate ID (deferred association),
+	// ordered by timestamp ASC.
+	GetUnassociated(ctx context.Context) ([]*domain.LiquidityEvent, error)
+
+	// UpdateCandidateID associates an unassociated event with a candidate.
+	// The event is identified by (tx_signature, event_index, mint, pool).
+	// Returns ErrNotFound if no unassociated event matches.
+	UpdateCandidateID(ctx context.Context, e *domain.LiquidityEvent, candidateID string) error
 }
 
 // TokenMetadataStore provides access to token_metadata storage.
@@ -68,6 +115,47 @@ type TokenMetadataStore interface {
 
 	// GetByMint retrieves metadata by mint address. Returns ErrNotFound if not exists.
 	GetByMint(ctx context.Context, mint string) (*domain.TokenMetadata, error)
+
+	// GetByMints retrieves the metadata of each of mints that has any, one row per
+	// mint (the latest fetched), ordered by mint ASC. Mints without metadata are omitted.
+	GetByMints(ctx context.Context, mints []string) ([]*domain.TokenMetadata, error)
+
+	// Upsert inserts metadata or refreshes the existing row for candidate_id.
+	// Name, symbol, URI and supply are only overwritten when the new value is non-nil.
+	// Authorities are overwritten whenever the mint account was read (Supply != nil),
+	// so a revoked authority becomes nil. FirstFetchedAt is preserved; FetchedAt is replaced.
+	Upsert(ctx context.Context, m *domain.TokenMetadata) error
+
+	// GetStale returns metadata with fetched_at < olderThan (ms), ordered by fetched_at ASC, candidate_id ASC.
+	GetStale(ctx context.Context, olderThan int64) ([]*domain.TokenMetadata, error)
+}
+
+// WatchlistStore provides access to watchlist storage.
+// Unlike the event tables, the watchlist is mutable: mints are added and removed.
+type WatchlistStore interface {
+	// Add watchlists a mint. Returns ErrDuplicateKey if mint is already watchlisted.
+	Add(ctx context.Context, e *domain.WatchlistEntry) error
+
+	// Remove un-watchlists a mint. Returns ErrNotFound if mint is not watchlisted.
+	Remove(ctx context.Context, mint string) error
+
+	// Get retrieves the entry for a mint. Returns ErrNotFound if mint is not watchlisted.
+	Get(ctx context.Context, mint string) (*domain.WatchlistEntry, error)
+
+	// List retrieves all entries, ordered by mint ASC.
+	List(ctx context.Context) ([]*domain.WatchlistEntry, error)
+}
+
+// TokenHolderSnapshotStore provides access to token_holder_snapshots storage.
+type TokenHolderSnapshotStore interface {
+	// Insert adds a snapshot. Returns ErrDuplicateKey if (candidate_id, snapshot_at) exists.
+	Insert(ctx context.Context, s *domain.TokenHolderSnapshot) error
+
+	// GetLatest retrieves the most recent snapshot for a candidate. Returns ErrNotFound if none.
+	GetLatest(ctx context.Context, candidateID string) (*domain.TokenHolderSnapshot, error)
+
+	// GetByCandidateID retrieves all snapshots for a candidate, ordered by snapshot_at ASC.
+	GetByCandidateID(ctx context.Context, candidateID string) ([]*domain.TokenHolderSnapshot, error)
 }
 
 // PriceTimeseriesStore provides access to price_timeseries storage.
@@ -134,6 +222,10 @@ type TradeRecordStore interface {
 	// InsertBulk adds multiple trades atomically. Fails entire batch on any duplicate.
 	InsertBulk(ctx context.Context, trades []*domain.TradeRecord) error
 
+	// Upsert inserts a trade or replaces the stored record with the same trade_id.
+	// Used when re-simulation after a data fix must refresh outcomes.
+	Upsert(ctx context.Context, t *domain.TradeRecord) error
+
 	// GetByID retrieves a trade by its ID. Returns ErrNotFound if not exists.
 	GetByID(ctx context.Context, tradeID string) (*domain.TradeRecord, error)
 
@@ -145,6 +237,17 @@ type TradeRecordStore interface {
 
 	// GetAll retrieves all trades.
 	GetAll(ctx context.Context) ([]*domain.TradeRecord, error)
+
+	// GetPage retrieves up to limit trades with trade_id > afterTradeID, ordered by trade_id.
+	// Pass "" to start; an empty page means no more trades.
+	GetPage(ctx context.Context, afterTradeID string, limit int) ([]*domain.TradeRecord, error)
+
+	// GetChangedSince retrieves up to limit trades last written (inserted or upserted)
+	// after the cursor, ordered by write time then trade_id. Pass the zero cursor to start.
+	GetChangedSince(ctx context.Context, after TradeCursor, limit int) ([]*TradeChange, error)
+
+	// Count returns the number of stored trades.
+	Count(ctx context.Context) (int, error)
 }
 
 // StrategyAggregateStore provides access to strategy_aggregates storage.
@@ -155,6 +258,9 @@ type StrategyAggregateStore interface {
 	// InsertBulk adds multiple aggregates atomically. Fails entire batch on any duplicate.
 	InsertBulk(ctx context.Context, aggregates []*domain.StrategyAggregate) error
 
+	// Upsert inserts an aggregate or replaces the stored one with the same composite key.
+	Upsert(ctx context.Context, a *domain.StrategyAggregate) error
+
 	// GetByKey retrieves an aggregate by its composite key.
 	GetByKey(ctx context.Context, strategyID, scenarioID, entryEventType string) (*domain.StrategyAggregate, error)
 
@@ -163,6 +269,43 @@ type StrategyAggregateStore interface {
 
 	// GetAll retrieves all aggregates.
 	GetAll(ctx context.Context) ([]*domain.StrategyAggregate, error)
+
+	// Find retrieves aggregates matching filter, ordered by
+	// (strategy_id, scenario_id, entry_event_type).
+	Find(ctx context.Context, filter AggregateFilter) ([]*domain.StrategyAggregate, error)
+}
+
+// MirroredTrade is a trade record tagged with the dimensions needed for
+// database-side aggregation without joining back to candidates.
+type MirroredTrade struct {
+	Trade          *domain.TradeRecord
+	StrategyType   string // canonical base strategy type
+	EntryEventType string // NEW_TOKEN | ACTIVE_TOKEN (from candidate source)
+}
+
+// TradeAggregateStore mirrors trade_records into an analytical backend and
+// computes strategy aggregates with SQL instead of loading trades into memory.
+type TradeAggregateStore interface {
+	// InsertMirrored writes tagged trades. Re-writing an existing trade_id is idempotent.
+	InsertMirrored(ctx context.Context, trades []*MirroredTrade) error
+
+	// CountByStrategyScenario returns the number of mirrored trades with an exact strategy_id match.
+	CountByStrategyScenario(ctx context.Context, strategyID, scenarioID string) (int, error)
+
+	// ComputeAggregate computes the aggregate over trades matching the key.
+	// If matchStrategyType is true, strategyID is compared to the canonical strategy type
+	// instead of the exact strategy_id. Returns ErrNotFound if no trades match.
+	ComputeAggregate(ctx context.Context, strategyID, scenarioID, entryEventType string, matchStrategyType bool) (*domain.StrategyAggregate, error)
+
+	// CountMirrored returns the number of distinct mirrored trades.
+	CountMirrored(ctx context.Context) (int, error)
+
+	// GetSyncWatermark returns the trade write time (Unix ms, see TradeRecordStore.GetChangedSince)
+	// up to which the mirror is complete, 0 if it was never synced.
+	GetSyncWatermark(ctx context.Context) (int64, error)
+
+	// SetSyncWatermark records the watermark reached by a sync.
+	SetSyncWatermark(ctx context.Context, watermark int64) error
 }
 
 // SwapEventStore provides access to discovery swap events storage.
@@ -182,4 +325,8 @@ type SwapEventStore interface {
 
 	// GetDistinctMintsByTimeRange returns all distinct mints with swap events in [start, end).
 	GetDistinctMintsByTimeRange(ctx context.Context, start, end int64) ([]string, error)
+
+	// GetGlobalTimeRange returns min and max event timestamps across all swap events.
+	// Returns (0, 0, nil) if no data exists.
+	GetGlobalTimeRange(ctx context.Context) (minTs, maxTs int64, err error)
 }
@@ -11,8 +11,9 @@ import (
 
 // CandidateStore is an in-memory implementation of storage.CandidateStore.
 type CandidateStore struct {
-	mu   sync.RWMutex
-	data map[string]*domain.TokenCandidate // keyed by candidate_id
+	mu        sync.RWMutex
+	data      map[string]*domain.TokenCandidate // keyed by candidate_id
+	deletions []*storage.CandidateDeletion      // audit log, in deletion order
 }
 
 // NewCandidateStore creates a new in-memory candidate store.
@@ -22,6 +23,16 @@ func NewCandidateStore() *CandidateStore {
 	}
 }
 
+// Clear removes all stored candidates.
+func (s *CandidateStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.TokenCandidate)
+	s.deletions = nil
+	return nil
+}
+
 // Insert adds a new candidate. Returns ErrDuplicateKey if candidate_id exists.
 func (s *CandidateStore) Insert(_ context.Context, c *domain.TokenCandidate) error {
 	if c == nil || c.CandidateID == "" {
@@ -36,7 +47,14 @@ func (s *CandidateStore) Insert(_ context.Context, c *domain.TokenCandidate) err
 	}
 
 	// Store a copy to prevent external mutation
-	candidateCopy := *c
+	candidateCopy := cloneCandidate(c)
+	if candidateCopy.Status == "" {
+		candidateCopy.Status = domain.CandidateStatusOpen
+	}
+	candidateCopy.ClosedAt = nil
+	candidateCopy.ReplayFingerprint = ""
+	candidateCopy.DeletedAt = nil
+	candidateCopy.DeletedReason = ""
 	s.data[c.CandidateID] = &candidateCopy
 	return nil
 }
@@ -52,10 +70,33 @@ func (s *CandidateStore) GetByID(_ context.Context, candidateID string) (*domain
 	}
 
 	// Return a copy
-	candidateCopy := *c
+	candidateCopy := cloneCandidate(c)
 	return &candidateCopy, nil
 }
 
+// Close marks an OPEN candidate CLOSED. Closing an already closed candidate
+// leaves it unchanged. Returns ErrNotFound if not exists.
+func (s *CandidateStore) Close(_ context.Context, candidateID string, closedAt int64, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, exists := s.data[candidateID]
+	if !exists {
+		return storage.ErrNotFound
+	}
+	if c.IsClosed() {
+		return nil
+	}
+
+	// Replace rather than mutate so copies handed out earlier are unaffected
+	closed := cloneCandidate(c)
+	closed.Status = domain.CandidateStatusClosed
+	closed.ClosedAt = &closedAt
+	closed.ReplayFingerprint = fingerprint
+	s.data[candidateID] = &closed
+	return nil
+}
+
 // GetByMint retrieves all candidates for a given mint address.
 func (s *CandidateStore) GetByMint(_ context.Context, mint string) ([]*domain.TokenCandidate, error) {
 	s.mu.RLock()
@@ -64,60 +105,125 @@ func (s *CandidateStore) GetByMint(_ context.Context, mint string) ([]*domain.To
 	var result []*domain.TokenCandidate
 	for _, c := range s.data {
 		if c.Mint == mint {
-			candidateCopy := *c
+			candidateCopy := cloneCandidate(c)
 			result = append(result, &candidateCopy)
 		}
 	}
 
 	// Sort by discovered_at ASC
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].DiscoveredAt < result[j].DiscoveredAt
+		if result[i].DiscoveredAt != result[j].DiscoveredAt {
+			return result[i].DiscoveredAt < result[j].DiscoveredAt
+		}
+		return result[i].CandidateID < result[j].CandidateID
 	})
 
 	return result, nil
 }
 
 // GetByTimeRange retrieves candidates discovered within [start, end] (inclusive).
-func (s *CandidateStore) GetByTimeRange(_ context.Context, start, end int64) ([]*domain.TokenCandidate, error) {
+// Soft-deleted candidates are excluded unless IncludeDeleted is passed.
+func (s *CandidateStore) GetByTimeRange(_ context.Context, start, end int64, opts ...storage.CandidateReadOption) ([]*domain.TokenCandidate, error) {
+	includeDeleted := storage.NewCandidateReadOptions(opts...).IncludeDeleted
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var result []*domain.TokenCandidate
 	for _, c := range s.data {
-		if c.DiscoveredAt >= start && c.DiscoveredAt <= end {
-			candidateCopy := *c
+		if c.DiscoveredAt >= start && c.DiscoveredAt <= end && (includeDeleted || !c.IsDeleted()) {
+			candidateCopy := cloneCandidate(c)
 			result = append(result, &candidateCopy)
 		}
 	}
 
 	// Sort by discovered_at ASC
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].DiscoveredAt < result[j].DiscoveredAt
+		if result[i].DiscoveredAt != result[j].DiscoveredAt {
+			return result[i].DiscoveredAt < result[j].DiscoveredAt
+		}
+		return result[i].CandidateID < result[j].CandidateID
 	})
 
 	return result, nil
 }
 
 // GetBySource retrieves all candidates of a given source type.
-func (s *CandidateStore) GetBySource(_ context.Context, source domain.Source) ([]*domain.TokenCandidate, error) {
+// Soft-deleted candidates are excluded unless IncludeDeleted is passed.
+func (s *CandidateStore) GetBySource(_ context.Context, source domain.Source, opts ...storage.CandidateReadOption) ([]*domain.TokenCandidate, error) {
+	includeDeleted := storage.NewCandidateReadOptions(opts...).IncludeDeleted
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var result []*domain.TokenCandidate
 	for _, c := range s.data {
-		if c.Source == source {
-			candidateCopy := *c
+		if c.Source == source && (includeDeleted || !c.IsDeleted()) {
+			candidateCopy := cloneCandidate(c)
 			result = append(result, &candidateCopy)
 		}
 	}
 
 	// Sort by discovered_at ASC
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].DiscoveredAt < result[j].DiscoveredAt
+		if result[i].DiscoveredAt != result[j].DiscoveredAt {
+			return result[i].DiscoveredAt < result[j].DiscoveredAt
+		}
+		return result[i].CandidateID < result[j].CandidateID
 	})
 
 	return result, nil
 }
 
+// SoftDelete marks a candidate deleted and appends d to the audit log.
+// Returns ErrInvalidInput without a candidate ID or reason, ErrNotFound if not
+// exists and ErrDuplicateKey if already deleted.
+func (s *CandidateStore) SoftDelete(_ context.Context, d *storage.CandidateDeletion) error {
+	if d == nil || d.CandidateID == "" || d.Reason == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, exists := s.data[d.CandidateID]
+	if !exists {
+		return storage.ErrNotFound
+	}
+	if c.IsDeleted() {
+		return storage.ErrDuplicateKey
+	}
+
+	// Replace rather than mutate so copies handed out earlier are unaffected
+	deleted := cloneCandidate(c)
+	deletedAt := d.DeletedAt
+	deleted.DeletedAt = &deletedAt
+	deleted.DeletedReason = d.Reason
+	s.data[d.CandidateID] = &deleted
+
+	entry := *d
+	s.deletions = append(s.deletions, &entry)
+	return nil
+}
+
+// GetDeletions returns the deletion audit log ordered by DeletedAt ASC, then candidate_id.
+func (s *CandidateStore) GetDeletions(_ context.Context) ([]*storage.CandidateDeletion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*storage.CandidateDeletion, len(s.deletions))
+	for i, d := range s.deletions {
+		entry := *d
+		result[i] = &entry
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].DeletedAt != result[j].DeletedAt {
+			return result[i].DeletedAt < result[j].DeletedAt
+		}
+		return result[i].CandidateID < result[j].CandidateID
+	})
+	return result, nil
+}
+
 // Verify interface compliance at compile time.
 var _ storage.CandidateStore = (*CandidateStore)(nil)
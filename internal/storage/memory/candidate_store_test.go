@@ -8,6 +8,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestCandidateStore_InsertAndGet(t *testing.T) {
@@ -186,3 +187,9 @@ func TestCandidateStore_InvalidInput(t *testing.T) {
 		t.Errorf("Expected ErrInvalidInput for empty ID, got %v", err)
 	}
 }
+
+func TestCandidateStore_Contract(t *testing.T) {
+	storagetest.RunCandidateStoreSuite(t, func(*testing.T) storage.CandidateStore {
+		return NewCandidateStore()
+	})
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// Compile-time interface checks.
+var (
+	_ storage.Clearable = (*CandidateStore)(nil)
+	_ storage.Clearable = (*SwapStore)(nil)
+	_ storage.Clearable = (*SwapEventStore)(nil)
+	_ storage.Clearable = (*LiquidityEventStore)(nil)
+	_ storage.Clearable = (*PriceTimeseriesStore)(nil)
+	_ storage.Clearable = (*LiquidityTimeseriesStore)(nil)
+	_ storage.Clearable = (*VolumeTimeseriesStore)(nil)
+	_ storage.Clearable = (*DerivedFeatureStore)(nil)
+	_ storage.Clearable = (*TradeRecordStore)(nil)
+	_ storage.Clearable = (*StrategyAggregateStore)(nil)
+	_ storage.Clearable = (*TokenMetadataStore)(nil)
+	_ storage.Clearable = (*TokenHolderSnapshotStore)(nil)
+	_ storage.Clearable = (*DiscoveryProgressStore)(nil)
+	_ storage.Clearable = (*WatchlistStore)(nil)
+	_ storage.Clearable = (*SufficiencyRunStore)(nil)
+	_ storage.Clearable = (*ProvisionalMintStore)(nil)
+	_ storage.Clearable = (*DirtyCandidateStore)(nil)
+	_ storage.Clearable = (*EventCountStore)(nil)
+	_ storage.Clearable = (*PoolLinkStore)(nil)
+	_ storage.Clearable = (*RunManifestStore)(nil)
+)
+
+func TestClear_RemovesAllRecords(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("candidates", func(t *testing.T) {
+		store := NewCandidateStore()
+		c := &domain.TokenCandidate{CandidateID: "c1", Mint: "m1", Source: domain.SourceNewToken}
+		mustNoErr(t, store.Insert(ctx, c))
+		mustNoErr(t, store.Clear(ctx))
+		all, err := store.GetBySource(ctx, domain.SourceNewToken)
+		mustNoErr(t, err)
+		if len(all) != 0 {
+			t.Errorf("expected no candidates after Clear, got %d", len(all))
+		}
+		// Keys are released, so the same record can be inserted again
+		mustNoErr(t, store.Insert(ctx, c))
+	})
+
+	t.Run("swap events", func(t *testing.T) {
+		store := NewSwapEventStore()
+		e := &domain.SwapEvent{Mint: "m1", TxSignature: "tx1", Timestamp: 1000}
+		mustNoErr(t, store.Insert(ctx, e))
+		mustNoErr(t, store.Clear(ctx))
+		events, err := store.GetByTimeRange(ctx, 0, 2000)
+		mustNoErr(t, err)
+		if len(events) != 0 {
+			t.Errorf("expected no swap events after Clear, got %d", len(events))
+		}
+		mustNoErr(t, store.Insert(ctx, e))
+	})
+
+	t.Run("trades", func(t *testing.T) {
+		store := NewTradeRecordStore()
+		mustNoErr(t, store.Insert(ctx, &domain.TradeRecord{TradeID: "t1", CandidateID: "c1"}))
+		mustNoErr(t, store.Clear(ctx))
+		all, err := store.GetAll(ctx)
+		mustNoErr(t, err)
+		if len(all) != 0 {
+			t.Errorf("expected no trades after Clear, got %d", len(all))
+		}
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		store := NewTokenMetadataStore()
+		m := &domain.TokenMetadata{CandidateID: "c1", Mint: "m1"}
+		mustNoErr(t, store.Insert(ctx, m))
+		mustNoErr(t, store.Clear(ctx))
+		if _, err := store.GetByMint(ctx, "m1"); err != storage.ErrNotFound {
+			t.Errorf("expected ErrNotFound by mint after Clear, got %v", err)
+		}
+		mustNoErr(t, store.Insert(ctx, m))
+	})
+
+	t.Run("discovery progress", func(t *testing.T) {
+		store := NewDiscoveryProgressStore()
+		mustNoErr(t, store.SetLastProcessed(ctx, &storage.DiscoveryProgress{Slot: 10, Signature: "sig"}))
+		mustNoErr(t, store.Clear(ctx))
+		if _, err := store.GetLastProcessed(ctx); err != storage.ErrNotFound {
+			t.Errorf("expected ErrNotFound after Clear, got %v", err)
+		}
+	})
+}
+
+// TestClear_ConcurrentWithInsert races Clear against Insert; run with -race.
+func TestClear_ConcurrentWithInsert(t *testing.T) {
+	ctx := context.Background()
+	store := NewTradeRecordStore()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				_ = store.Insert(ctx, &domain.TradeRecord{TradeID: fmt.Sprintf("t%d-%d", w, i), CandidateID: "c1"})
+			}
+		}(w)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = store.Clear(ctx)
+		}
+	}()
+	wg.Wait()
+
+	mustNoErr(t, store.Clear(ctx))
+	all, err := store.GetAll(ctx)
+	mustNoErr(t, err)
+	if len(all) != 0 {
+		t.Errorf("expected empty store after final Clear, got %d", len(all))
+	}
+}
+
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
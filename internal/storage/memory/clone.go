@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// Stores keep private copies of every record and hand out fresh copies, so a
+// caller mutating a returned record, or a record it inserted, never changes
+// stored state. A plain struct copy is enough for flat records; the helpers
+// below also copy nullable pointer fields and byte slices, which a struct copy
+// would share.
+
+// clonePtr returns a copy of *p, or nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneCandidate(c *domain.TokenCandidate) domain.TokenCandidate {
+	out := *c
+	out.Pool = clonePtr(c.Pool)
+	out.DetectedAt = clonePtr(c.DetectedAt)
+	out.ClosedAt = clonePtr(c.ClosedAt)
+	out.DeletedAt = clonePtr(c.DeletedAt)
+	return out
+}
+
+func cloneSwapEvent(e *domain.SwapEvent) domain.SwapEvent {
+	out := *e
+	out.Pool = clonePtr(e.Pool)
+	out.FeeLamports = clonePtr(e.FeeLamports)
+	out.PriorityFeeLamports = clonePtr(e.PriorityFeeLamports)
+	return out
+}
+
+func cloneTrade(t *domain.TradeRecord) domain.TradeRecord {
+	out := *t
+	out.EntryLiquidity = clonePtr(t.EntryLiquidity)
+	out.PeakPrice = clonePtr(t.PeakPrice)
+	out.MinLiquidity = clonePtr(t.MinLiquidity)
+	out.PeakLiquidity = clonePtr(t.PeakLiquidity)
+	out.ArmedAt = clonePtr(t.ArmedAt)
+	out.CostBreakdown = clonePtr(t.CostBreakdown)
+	if t.EntryContext != nil {
+		ec := *t.EntryContext
+		ec.Liquidity = clonePtr(t.EntryContext.Liquidity)
+		out.EntryContext = &ec
+	}
+	return out
+}
+
+func cloneAggregate(a *domain.StrategyAggregate) domain.StrategyAggregate {
+	out := *a
+	out.OutcomeRealistic = clonePtr(a.OutcomeRealistic)
+	out.OutcomePessimistic = clonePtr(a.OutcomePessimistic)
+	out.OutcomeDegraded = clonePtr(a.OutcomeDegraded)
+	return out
+}
+
+func cloneMetadata(m *domain.TokenMetadata) domain.TokenMetadata {
+	out := *m
+	out.Name = clonePtr(m.Name)
+	out.Symbol = clonePtr(m.Symbol)
+	out.URI = clonePtr(m.URI)
+	out.Supply = clonePtr(m.Supply)
+	out.MintAuthority = clonePtr(m.MintAuthority)
+	out.FreezeAuthority = clonePtr(m.FreezeAuthority)
+	return out
+}
+
+func cloneFeature(p *domain.DerivedFeaturePoint) domain.DerivedFeaturePoint {
+	out := *p
+	out.PriceDelta = clonePtr(p.PriceDelta)
+	out.PriceVelocity = clonePtr(p.PriceVelocity)
+	out.PriceAcceleration = clonePtr(p.PriceAcceleration)
+	out.LiquidityDelta = clonePtr(p.LiquidityDelta)
+	out.LiquidityVelocity = clonePtr(p.LiquidityVelocity)
+	out.LastSwapIntervalMs = clonePtr(p.LastSwapIntervalMs)
+	out.LastLiqEventIntervalMs = clonePtr(p.LastLiqEventIntervalMs)
+	out.RollingImbalance = clonePtr(p.RollingImbalance)
+	return out
+}
+
+func cloneRawTx(tx *storage.RawTransaction) storage.RawTransaction {
+	out := *tx
+	out.Data = append([]byte(nil), tx.Data...)
+	return out
+}
+
+func cloneProvisionalMint(m *domain.ProvisionalMint) domain.ProvisionalMint {
+	out := *m
+	out.DetectedAt = clonePtr(m.DetectedAt)
+	out.CandidateID = clonePtr(m.CandidateID)
+	out.ResolvedAt = clonePtr(m.ResolvedAt)
+	return out
+}
+
+func clonePoolLink(l *domain.PoolLink) domain.PoolLink {
+	out := *l
+	out.ValidTo = clonePtr(l.ValidTo)
+	return out
+}
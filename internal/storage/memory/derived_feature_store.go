@@ -23,6 +23,15 @@ func NewDerivedFeatureStore() *DerivedFeatureStore {
 	}
 }
 
+// Clear removes all stored feature points.
+func (s *DerivedFeatureStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.DerivedFeaturePoint)
+	return nil
+}
+
 // derivedFeatureKey generates a unique key for a feature point.
 func derivedFeatureKey(candidateID string, timestampMs int64) string {
 	return fmt.Sprintf("%s|%d", candidateID, timestampMs)
@@ -61,7 +70,7 @@ func (s *DerivedFeatureStore) InsertBulk(_ context.Context, points []*domain.Der
 	// Second pass: insert all
 	for _, p := range points {
 		key := derivedFeatureKey(p.CandidateID, p.TimestampMs)
-		featureCopy := *p
+		featureCopy := cloneFeature(p)
 		s.data[key] = &featureCopy
 	}
 
@@ -76,7 +85,7 @@ func (s *DerivedFeatureStore) GetByCandidateID(_ context.Context, candidateID st
 	var result []*domain.DerivedFeaturePoint
 	for _, p := range s.data {
 		if p.CandidateID == candidateID {
-			featureCopy := *p
+			featureCopy := cloneFeature(p)
 			result = append(result, &featureCopy)
 		}
 	}
@@ -96,7 +105,7 @@ func (s *DerivedFeatureStore) GetByTimeRange(_ context.Context, candidateID stri
 	var result []*domain.DerivedFeaturePoint
 	for _, p := range s.data {
 		if p.CandidateID == candidateID && p.TimestampMs >= start && p.TimestampMs <= end {
-			featureCopy := *p
+			featureCopy := cloneFeature(p)
 			result = append(result, &featureCopy)
 		}
 	}
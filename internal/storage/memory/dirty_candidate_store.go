@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DirtyCandidateStore is an in-memory implementation of storage.DirtyCandidateStore.
+type DirtyCandidateStore struct {
+	mu    sync.RWMutex
+	marks map[string]domain.DirtyCandidate // keyed by candidate_id
+}
+
+// NewDirtyCandidateStore creates a new in-memory dirty candidate store.
+func NewDirtyCandidateStore() *DirtyCandidateStore {
+	return &DirtyCandidateStore{
+		marks: make(map[string]domain.DirtyCandidate),
+	}
+}
+
+// Clear removes all marks.
+func (s *DirtyCandidateStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.marks = make(map[string]domain.DirtyCandidate)
+	return nil
+}
+
+// Mark flags a candidate as dirty, keeping the earliest event time and latest markedAt.
+func (s *DirtyCandidateStore) Mark(_ context.Context, candidateID string, eventTime, markedAt int64) error {
+	if candidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, exists := s.marks[candidateID]
+	if !exists {
+		s.marks[candidateID] = domain.DirtyCandidate{CandidateID: candidateID, EarliestEventTime: eventTime, MarkedAt: markedAt}
+		return nil
+	}
+	d.EarliestEventTime = min(d.EarliestEventTime, eventTime)
+	d.MarkedAt = max(d.MarkedAt, markedAt)
+	s.marks[candidateID] = d
+	return nil
+}
+
+// List retrieves all dirty candidates, ordered by marked_at ASC, candidate_id ASC.
+func (s *DirtyCandidateStore) List(_ context.Context) ([]*domain.DirtyCandidate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*domain.DirtyCandidate, 0, len(s.marks))
+	for _, d := range s.marks {
+		markCopy := d
+		result = append(result, &markCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MarkedAt != result[j].MarkedAt {
+			return result[i].MarkedAt < result[j].MarkedAt
+		}
+		return result[i].CandidateID < result[j].CandidateID
+	})
+
+	return result, nil
+}
+
+// Count returns the number of dirty candidates.
+func (s *DirtyCandidateStore) Count(_ context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.marks), nil
+}
+
+// Unmark removes the mark of a candidate unless it was marked after markedAt.
+func (s *DirtyCandidateStore) Unmark(_ context.Context, candidateID string, markedAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, exists := s.marks[candidateID]; exists && d.MarkedAt <= markedAt {
+		delete(s.marks, candidateID)
+	}
+	return nil
+}
+
+var _ storage.DirtyCandidateStore = (*DirtyCandidateStore)(nil)
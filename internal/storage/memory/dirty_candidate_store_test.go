@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestDirtyCandidateStore_Contract(t *testing.T) {
+	storagetest.RunDirtyCandidateStoreSuite(t, func(*testing.T) storage.DirtyCandidateStore {
+		return NewDirtyCandidateStore()
+	})
+}
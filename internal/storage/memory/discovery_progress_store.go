@@ -21,6 +21,16 @@ func NewDiscoveryProgressStore() *DiscoveryProgressStore {
 	}
 }
 
+// Clear removes all stored progress and seen mints.
+func (s *DiscoveryProgressStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.progress = nil
+	s.seenMints = make(map[string]bool)
+	return nil
+}
+
 // GetLastProcessed returns the last processed slot and signature.
 func (s *DiscoveryProgressStore) GetLastProcessed(_ context.Context) (*storage.DiscoveryProgress, error) {
 	s.mu.RLock()
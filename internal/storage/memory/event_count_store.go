@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// EventCountStore is an in-memory implementation of storage.EventCountStore.
+type EventCountStore struct {
+	mu     sync.RWMutex
+	counts map[string]domain.CandidateEventCounts // keyed by candidate_id
+}
+
+// NewEventCountStore creates a new in-memory event count store.
+func NewEventCountStore() *EventCountStore {
+	return &EventCountStore{
+		counts: make(map[string]domain.CandidateEventCounts),
+	}
+}
+
+// Clear removes all counts.
+func (s *EventCountStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts = make(map[string]domain.CandidateEventCounts)
+	return nil
+}
+
+// Upsert inserts the counts of a candidate or replaces the stored ones.
+func (s *EventCountStore) Upsert(_ context.Context, c *domain.CandidateEventCounts) error {
+	if c == nil || c.CandidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[c.CandidateID] = *c
+	return nil
+}
+
+// GetByCandidateID retrieves the counts of a candidate.
+func (s *EventCountStore) GetByCandidateID(_ context.Context, candidateID string) (*domain.CandidateEventCounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, exists := s.counts[candidateID]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	return &c, nil
+}
+
+// GetDownsampled retrieves the counts of every candidate with dropped events,
+// ordered by candidate_id ASC.
+func (s *EventCountStore) GetDownsampled(_ context.Context) ([]*domain.CandidateEventCounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*domain.CandidateEventCounts
+	for _, c := range s.counts {
+		if c.Downsampled() {
+			countsCopy := c
+			result = append(result, &countsCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CandidateID < result[j].CandidateID
+	})
+
+	return result, nil
+}
+
+var _ storage.EventCountStore = (*EventCountStore)(nil)
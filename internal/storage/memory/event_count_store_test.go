@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestEventCountStore_Contract(t *testing.T) {
+	storagetest.RunEventCountStoreSuite(t, func(*testing.T) storage.EventCountStore {
+		return NewEventCountStore()
+	})
+}
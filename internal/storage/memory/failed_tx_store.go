@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/storage"
+)
+
+// failedTxKey identifies a tally row.
+type failedTxKey struct {
+	program   string
+	source    string
+	hourStart int64
+}
+
+// FailedTxStore is an in-memory implementation of storage.FailedTxStore.
+type FailedTxStore struct {
+	mu      sync.RWMutex
+	tallies map[failedTxKey]storage.FailedTxTally
+}
+
+// NewFailedTxStore creates a new in-memory failed transaction store.
+func NewFailedTxStore() *FailedTxStore {
+	return &FailedTxStore{
+		tallies: make(map[failedTxKey]storage.FailedTxTally),
+	}
+}
+
+// Clear removes all tallies.
+func (s *FailedTxStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tallies = make(map[failedTxKey]storage.FailedTxTally)
+	return nil
+}
+
+// Add adds the counts of tallies to the stored ones. Nothing is stored if any
+// tally is invalid.
+func (s *FailedTxStore) Add(_ context.Context, tallies []*storage.FailedTxTally) error {
+	for _, t := range tallies {
+		if !storage.ValidFailedTxTally(t) {
+			return storage.ErrInvalidInput
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range tallies {
+		key := failedTxKey{program: t.Program, source: t.Source, hourStart: t.HourStart}
+		stored, ok := s.tallies[key]
+		if !ok {
+			stored = storage.FailedTxTally{Program: t.Program, Source: t.Source, HourStart: t.HourStart}
+		}
+		stored.Transactions += t.Transactions
+		stored.Failed += t.Failed
+		s.tallies[key] = stored
+	}
+	return nil
+}
+
+// GetRange returns the tallies with HourStart within [from, to), ordered by
+// HourStart, Program and Source.
+func (s *FailedTxStore) GetRange(_ context.Context, from, to int64) ([]*storage.FailedTxTally, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*storage.FailedTxTally
+	for _, t := range s.tallies {
+		if t.HourStart >= from && t.HourStart < to {
+			tallyCopy := t
+			result = append(result, &tallyCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.HourStart != b.HourStart {
+			return a.HourStart < b.HourStart
+		}
+		if a.Program != b.Program {
+			return a.Program < b.Program
+		}
+		return a.Source < b.Source
+	})
+	return result, nil
+}
+
+var _ storage.FailedTxStore = (*FailedTxStore)(nil)
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestFailedTxStore_Contract(t *testing.T) {
+	storagetest.RunFailedTxStoreSuite(t, func(*testing.T) storage.FailedTxStore {
+		return NewFailedTxStore()
+	})
+}
@@ -0,0 +1,257 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// FinalityStore is an in-memory implementation of storage.FinalityStore
+// over the in-memory event stores. Any of the event stores may be nil.
+type FinalityStore struct {
+	swaps     *SwapStore
+	events    *SwapEventStore
+	liquidity *LiquidityEventStore
+
+	mu          sync.Mutex
+	finalized   map[string]int64 // tx_signature -> verified_at
+	corrections []*storage.FinalityCorrection
+}
+
+// NewFinalityStore creates a finality store over the given event stores.
+func NewFinalityStore(swaps *SwapStore, events *SwapEventStore, liquidity *LiquidityEventStore) *FinalityStore {
+	return &FinalityStore{
+		swaps:     swaps,
+		events:    events,
+		liquidity: liquidity,
+		finalized: make(map[string]int64),
+	}
+}
+
+// Clear removes finality marks and corrections; the event stores are left alone.
+func (s *FinalityStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.finalized = make(map[string]int64)
+	s.corrections = nil
+	return nil
+}
+
+// eventRef identifies one stored event for finality tracking.
+type eventRef struct {
+	txSignature string
+	slot        int64
+	timestamp   int64
+}
+
+// forEachEvent calls fn for every event in the wrapped stores.
+func (s *FinalityStore) forEachEvent(fn func(eventRef)) {
+	if s.swaps != nil {
+		s.swaps.mu.RLock()
+		for _, e := range s.swaps.data {
+			fn(eventRef{e.TxSignature, e.Slot, e.Timestamp})
+		}
+		s.swaps.mu.RUnlock()
+	}
+	if s.events != nil {
+		s.events.mu.RLock()
+		for _, e := range s.events.data {
+			fn(eventRef{e.TxSignature, e.Slot, e.Timestamp})
+		}
+		s.events.mu.RUnlock()
+	}
+	if s.liquidity != nil {
+		s.liquidity.mu.RLock()
+		for _, e := range s.liquidity.data {
+			fn(eventRef{e.TxSignature, e.Slot, e.Timestamp})
+		}
+		s.liquidity.mu.RUnlock()
+	}
+}
+
+// GetUnverified returns distinct unverified signatures with events in [start, end), ordered by (slot, tx_signature).
+func (s *FinalityStore) GetUnverified(_ context.Context, start, end int64, limit int) ([]storage.UnverifiedSignature, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bySig := make(map[string]*storage.UnverifiedSignature)
+	s.forEachEvent(func(e eventRef) {
+		if e.timestamp < start || e.timestamp >= end {
+			return
+		}
+		if _, ok := s.finalized[e.txSignature]; ok {
+			return
+		}
+		u, ok := bySig[e.txSignature]
+		if !ok {
+			bySig[e.txSignature] = &storage.UnverifiedSignature{TxSignature: e.txSignature, Slot: e.slot, Timestamp: e.timestamp}
+			return
+		}
+		if e.slot < u.Slot {
+			u.Slot = e.slot
+		}
+		if e.timestamp < u.Timestamp {
+			u.Timestamp = e.timestamp
+		}
+	})
+
+	result := make([]storage.UnverifiedSignature, 0, len(bySig))
+	for _, u := range bySig {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Slot != result[j].Slot {
+			return result[i].Slot < result[j].Slot
+		}
+		return result[i].TxSignature < result[j].TxSignature
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// MarkFinalized records signatures as finalized, keeping the first verification time.
+func (s *FinalityStore) MarkFinalized(_ context.Context, signatures []string, verifiedAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sig := range signatures {
+		if _, ok := s.finalized[sig]; !ok {
+			s.finalized[sig] = verifiedAt
+		}
+	}
+	return nil
+}
+
+// RemoveDropped deletes every event of signature and records one correction per event.
+func (s *FinalityStore) RemoveDropped(_ context.Context, signature string, detectedAt int64) ([]*storage.FinalityCorrection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed []*storage.FinalityCorrection
+
+	if s.swaps != nil {
+		s.swaps.mu.Lock()
+		for key, e := range s.swaps.data {
+			if e.TxSignature != signature {
+				continue
+			}
+			removed = append(removed, &storage.FinalityCorrection{
+				EventTable:  storage.EventTableSwaps,
+				TxSignature: e.TxSignature,
+				EventIndex:  e.EventIndex,
+				CandidateID: e.CandidateID,
+				Slot:        e.Slot,
+				Timestamp:   e.Timestamp,
+				DetectedAt:  detectedAt,
+			})
+			delete(s.swaps.data, key)
+		}
+		s.swaps.mu.Unlock()
+	}
+
+	if s.events != nil {
+		s.events.mu.Lock()
+		kept := make([]*domain.SwapEvent, 0, len(s.events.data))
+		for _, e := range s.events.data {
+			if e.TxSignature != signature {
+				kept = append(kept, e)
+				continue
+			}
+			removed = append(removed, &storage.FinalityCorrection{
+				EventTable:  storage.EventTableSwapEvents,
+				TxSignature: e.TxSignature,
+				EventIndex:  e.EventIndex,
+				Mint:        e.Mint,
+				Slot:        e.Slot,
+				Timestamp:   e.Timestamp,
+				DetectedAt:  detectedAt,
+			})
+			delete(s.events.keys, swapEventKey{Mint: e.Mint, TxSignature: e.TxSignature, EventIndex: e.EventIndex})
+		}
+		s.events.data = kept
+		s.events.mu.Unlock()
+	}
+
+	if s.liquidity != nil {
+		s.liquidity.mu.Lock()
+		for key, e := range s.liquidity.data {
+			if e.TxSignature != signature {
+				continue
+			}
+			removed = append(removed, &storage.FinalityCorrection{
+				EventTable:  storage.EventTableLiquidityEvents,
+				TxSignature: e.TxSignature,
+				EventIndex:  e.EventIndex,
+				CandidateID: e.CandidateID,
+				Mint:        e.Mint,
+				Slot:        e.Slot,
+				Timestamp:   e.Timestamp,
+				DetectedAt:  detectedAt,
+			})
+			delete(s.liquidity.data, key)
+		}
+		s.liquidity.mu.Unlock()
+	}
+
+	sortCorrections(removed)
+	for _, c := range removed {
+		stored := *c
+		s.corrections = append(s.corrections, &stored)
+	}
+	return removed, nil
+}
+
+// CountUnverified returns the number of stored events whose signature is not marked finalized.
+func (s *FinalityStore) CountUnverified(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	s.forEachEvent(func(e eventRef) {
+		if _, ok := s.finalized[e.txSignature]; !ok {
+			count++
+		}
+	})
+	return count, nil
+}
+
+// GetCorrections returns corrections with detected_at in [start, end).
+func (s *FinalityStore) GetCorrections(_ context.Context, start, end int64) ([]*storage.FinalityCorrection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*storage.FinalityCorrection
+	for _, c := range s.corrections {
+		if c.DetectedAt >= start && c.DetectedAt < end {
+			cCopy := *c
+			result = append(result, &cCopy)
+		}
+	}
+	sortCorrections(result)
+	return result, nil
+}
+
+// sortCorrections orders corrections by (detected_at, event_table, tx_signature, event_index).
+func sortCorrections(cs []*storage.FinalityCorrection) {
+	sort.Slice(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if a.DetectedAt != b.DetectedAt {
+			return a.DetectedAt < b.DetectedAt
+		}
+		if a.EventTable != b.EventTable {
+			return a.EventTable < b.EventTable
+		}
+		if a.TxSignature != b.TxSignature {
+			return a.TxSignature < b.TxSignature
+		}
+		return a.EventIndex < b.EventIndex
+	})
+}
+
+var _ storage.FinalityStore = (*FinalityStore)(nil)
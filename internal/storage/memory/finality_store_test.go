@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestFinalityStore_Contract(t *testing.T) {
+	storagetest.RunFinalityStoreSuite(t, func(*testing.T, ...string) storagetest.FinalityStores {
+		swaps, events, liquidity := NewSwapStore(), NewSwapEventStore(), NewLiquidityEventStore()
+		return storagetest.FinalityStores{
+			Finality:   NewFinalityStore(swaps, events, liquidity),
+			Swaps:      swaps,
+			SwapEvents: events,
+			Liquidity:  liquidity,
+		}
+	})
+}
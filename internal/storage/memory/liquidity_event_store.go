@@ -23,18 +23,57 @@ func NewLiquidityEventStore() *LiquidityEventStore {
 	}
 }
 
+// Clear removes all stored liquidity events.
+func (s *LiquidityEventStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.LiquidityEvent)
+	return nil
+}
+
 // liquidityEventKey generates a unique key for a liquidity event.
-func liquidityEventKey(candidateID, txSignature string, eventIndex int) string {
-	return fmt.Sprintf("%s|%s|%d", candidateID, txSignature, eventIndex)
+// Mirrors the Postgres unique index on (tx_signature, event_index, COALESCE(mint, pool)),
+// so the same on-chain event is a duplicate regardless of candidate association.
+func liquidityEventKey(e *domain.LiquidityEvent) string {
+	token := e.Mint
+	if token == "" {
+		token = e.Pool
+	}
+	return fmt.Sprintf("%s|%d|%s", e.TxSignature, e.EventIndex, token)
+}
+
+// validLiquidityEvent reports whether e can be stored: it needs a candidate ID,
+// or a mint or pool for deferred association.
+func validLiquidityEvent(e *domain.LiquidityEvent) bool {
+	return e != nil && (e.CandidateID != "" || e.Mint != "" || e.Pool != "")
+}
+
+// storedLiquidityEvent returns the copy of e to store, with its event type
+// canonicalized and an empty origin recorded as domain.OriginUnknown. Returns ErrInvalidInput if e is not valid or its type unknown.
+func storedLiquidityEvent(e *domain.LiquidityEvent) (*domain.LiquidityEvent, error) {
+	if !validLiquidityEvent(e) {
+		return nil, storage.ErrInvalidInput
+	}
+	eventType, err := domain.NormalizeLiquidityEventType(e.EventType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", storage.ErrInvalidInput, err)
+	}
+	eventCopy := *e
+	eventCopy.EventType = eventType
+	eventCopy.Origin = domain.NormalizeOrigin(e.Origin)
+	return &eventCopy, nil
 }
 
 // Insert adds a new liquidity event. Returns ErrDuplicateKey if exists.
+// Events without a candidate ID are accepted when mint or pool is set.
 func (s *LiquidityEventStore) Insert(_ context.Context, e *domain.LiquidityEvent) error {
-	if e == nil || e.CandidateID == "" {
-		return storage.ErrInvalidInput
+	e, err := storedLiquidityEvent(e)
+	if err != nil {
+		return err
 	}
 
-	key := liquidityEventKey(e.CandidateID, e.TxSignature, e.EventIndex)
+	key := liquidityEventKey(e)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -43,8 +82,7 @@ func (s *LiquidityEventStore) Insert(_ context.Context, e *domain.LiquidityEvent
 		return storage.ErrDuplicateKey
 	}
 
-	eventCopy := *e
-	s.data[key] = &eventCopy
+	s.data[key] = e
 	return nil
 }
 
@@ -59,13 +97,16 @@ func (s *LiquidityEventStore) InsertBulk(_ context.Context, events []*domain.Liq
 
 	// Track keys in this batch to detect intra-batch duplicates
 	batchKeys := make(map[string]struct{}, len(events))
+	stored := make([]*domain.LiquidityEvent, len(events))
 
-	// First pass: check for duplicates (existing + intra-batch)
-	for _, e := range events {
-		if e == nil || e.CandidateID == "" {
-			return storage.ErrInvalidInput
+	// First pass: validate and check for duplicates (existing + intra-batch)
+	for i, e := range events {
+		e, err := storedLiquidityEvent(e)
+		if err != nil {
+			return err
 		}
-		key := liquidityEventKey(e.CandidateID, e.TxSignature, e.EventIndex)
+		stored[i] = e
+		key := liquidityEventKey(e)
 
 		// Check existing data
 		if _, exists := s.data[key]; exists {
@@ -79,10 +120,8 @@ func (s *LiquidityEventStore) InsertBulk(_ context.Context, events []*domain.Liq
 	}
 
 	// Second pass: insert all
-	for _, e := range events {
-		key := liquidityEventKey(e.CandidateID, e.TxSignature, e.EventIndex)
-		eventCopy := *e
-		s.data[key] = &eventCopy
+	for _, e := range stored {
+		s.data[liquidityEventKey(e)] = e
 	}
 
 	return nil
@@ -158,4 +197,54 @@ func (s *LiquidityEventStore) GetByMintTimeRange(_ context.Context, mint string,
 	return result, nil
 }
 
+// GetUnassociated retrieves events stored without a candidate ID, ordered by timestamp ASC.
+func (s *LiquidityEventStore) GetUnassociated(_ context.Context) ([]*domain.LiquidityEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*domain.LiquidityEvent
+	for _, e := range s.data {
+		if e.CandidateID == "" {
+			eventCopy := *e
+			result = append(result, &eventCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Timestamp != result[j].Timestamp {
+			return result[i].Timestamp < result[j].Timestamp
+		}
+		if result[i].TxSignature != result[j].TxSignature {
+			return result[i].TxSignature < result[j].TxSignature
+		}
+		return result[i].EventIndex < result[j].EventIndex
+	})
+
+	return result, nil
+}
+
+// UpdateCandidateID associates an unassociated event with a candidate.
+// Returns ErrNotFound if no unassociated event matches (tx_signature, event_index, mint, pool).
+func (s *LiquidityEventStore) UpdateCandidateID(_ context.Context, e *domain.LiquidityEvent, candidateID string) error {
+	if e == nil || candidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	key := liquidityEventKey(e)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.data[key]
+	if !ok || stored.CandidateID != "" || stored.Mint != e.Mint || stored.Pool != e.Pool {
+		return storage.ErrNotFound
+	}
+
+	updated := *stored
+	updated.CandidateID = candidateID
+	s.data[key] = &updated
+
+	return nil
+}
+
 var _ storage.LiquidityEventStore = (*LiquidityEventStore)(nil)
@@ -7,6 +7,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestLiquidityEventStore_InsertAndGet(t *testing.T) {
@@ -54,6 +55,7 @@ func TestLiquidityEventStore_DuplicateKey(t *testing.T) {
 		TxSignature: "sig1",
 		EventIndex:  0,
 		Timestamp:   1000,
+		EventType:   domain.LiquidityEventAdd,
 	}
 
 	if err := store.Insert(ctx, event); err != nil {
@@ -71,9 +73,9 @@ func TestLiquidityEventStore_InsertBulk(t *testing.T) {
 	ctx := context.Background()
 
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000},
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 1, Timestamp: 1001},
-		{CandidateID: "c1", TxSignature: "s2", EventIndex: 0, Timestamp: 1002},
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 1, Timestamp: 1001, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s2", EventIndex: 0, Timestamp: 1002, EventType: domain.LiquidityEventAdd},
 	}
 
 	err := store.InsertBulk(ctx, events)
@@ -93,8 +95,8 @@ func TestLiquidityEventStore_InsertBulkIntraBatchDuplicate(t *testing.T) {
 
 	// Batch with duplicate within itself
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000},
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000}, // duplicate
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd}, // duplicate
 	}
 
 	err := store.InsertBulk(ctx, events)
@@ -113,14 +115,14 @@ func TestLiquidityEventStore_InsertBulkExistingDuplicate(t *testing.T) {
 	store := NewLiquidityEventStore()
 	ctx := context.Background()
 
-	first := &domain.LiquidityEvent{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000}
+	first := &domain.LiquidityEvent{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd}
 	if err := store.Insert(ctx, first); err != nil {
 		t.Fatalf("First insert failed: %v", err)
 	}
 
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 1, Timestamp: 1001},
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000}, // duplicate
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 1, Timestamp: 1001, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd}, // duplicate
 	}
 
 	err := store.InsertBulk(ctx, events)
@@ -139,10 +141,10 @@ func TestLiquidityEventStore_GetByTimeRange(t *testing.T) {
 	ctx := context.Background()
 
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000},
-		{CandidateID: "c1", TxSignature: "s2", EventIndex: 0, Timestamp: 2000},
-		{CandidateID: "c1", TxSignature: "s3", EventIndex: 0, Timestamp: 3000},
-		{CandidateID: "c2", TxSignature: "s4", EventIndex: 0, Timestamp: 2500}, // different candidate
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s3", EventIndex: 0, Timestamp: 3000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c2", TxSignature: "s4", EventIndex: 0, Timestamp: 2500, EventType: domain.LiquidityEventAdd}, // different candidate
 	}
 
 	if err := store.InsertBulk(ctx, events); err != nil {
@@ -168,9 +170,9 @@ func TestLiquidityEventStore_OrderByTimestamp(t *testing.T) {
 	ctx := context.Background()
 
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s3", EventIndex: 0, Timestamp: 3000},
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000},
-		{CandidateID: "c1", TxSignature: "s2", EventIndex: 0, Timestamp: 2000},
+		{CandidateID: "c1", TxSignature: "s3", EventIndex: 0, Timestamp: 3000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, EventType: domain.LiquidityEventAdd},
 	}
 
 	if err := store.InsertBulk(ctx, events); err != nil {
@@ -195,7 +197,7 @@ func TestLiquidityEventStore_InvalidInput(t *testing.T) {
 		t.Errorf("Expected ErrInvalidInput for nil, got %v", err)
 	}
 
-	err = store.Insert(ctx, &domain.LiquidityEvent{CandidateID: ""})
+	err = store.Insert(ctx, &domain.LiquidityEvent{CandidateID: "", EventType: domain.LiquidityEventAdd})
 	if !errors.Is(err, storage.ErrInvalidInput) {
 		t.Errorf("Expected ErrInvalidInput for empty CandidateID, got %v", err)
 	}
@@ -206,10 +208,10 @@ func TestLiquidityEventStore_GetByMintTimeRange(t *testing.T) {
 	ctx := context.Background()
 
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, Mint: "mintA"},
-		{CandidateID: "c2", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, Mint: "mintA"},
-		{CandidateID: "c3", TxSignature: "s3", EventIndex: 0, Timestamp: 3000, Mint: "mintA"},
-		{CandidateID: "c4", TxSignature: "s4", EventIndex: 0, Timestamp: 2500, Mint: "mintB"}, // different mint
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, Mint: "mintA", EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c2", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, Mint: "mintA", EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c3", TxSignature: "s3", EventIndex: 0, Timestamp: 3000, Mint: "mintA", EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c4", TxSignature: "s4", EventIndex: 0, Timestamp: 2500, Mint: "mintB", EventType: domain.LiquidityEventAdd}, // different mint
 	}
 
 	if err := store.InsertBulk(ctx, events); err != nil {
@@ -267,9 +269,9 @@ func TestLiquidityEventStore_GetByMintTimeRange_Ordering(t *testing.T) {
 
 	// Insert out of order
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c3", TxSignature: "s3", EventIndex: 0, Timestamp: 3000, Slot: 300, Mint: "mintX"},
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, Slot: 100, Mint: "mintX"},
-		{CandidateID: "c2", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, Slot: 200, Mint: "mintX"},
+		{CandidateID: "c3", TxSignature: "s3", EventIndex: 0, Timestamp: 3000, Slot: 300, Mint: "mintX", EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, Slot: 100, Mint: "mintX", EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c2", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, Slot: 200, Mint: "mintX", EventType: domain.LiquidityEventAdd},
 	}
 
 	if err := store.InsertBulk(ctx, events); err != nil {
@@ -292,8 +294,8 @@ func TestLiquidityEventStore_GetByMintTimeRange_EndExclusive(t *testing.T) {
 
 	// Test [start, end) semantics per DISCOVERY_SPEC.md
 	events := []*domain.LiquidityEvent{
-		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, Mint: "mintY"},
-		{CandidateID: "c2", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, Mint: "mintY"}, // exactly at end
+		{CandidateID: "c1", TxSignature: "s1", EventIndex: 0, Timestamp: 1000, Mint: "mintY", EventType: domain.LiquidityEventAdd},
+		{CandidateID: "c2", TxSignature: "s2", EventIndex: 0, Timestamp: 2000, Mint: "mintY", EventType: domain.LiquidityEventAdd}, // exactly at end
 	}
 
 	if err := store.InsertBulk(ctx, events); err != nil {
@@ -313,3 +315,69 @@ func TestLiquidityEventStore_GetByMintTimeRange_EndExclusive(t *testing.T) {
 		t.Errorf("Expected timestamp 1000 (start inclusive), got %d", result[0].Timestamp)
 	}
 }
+
+func TestLiquidityEventStore_GetUnassociatedAndUpdateCandidateID(t *testing.T) {
+	store := NewLiquidityEventStore()
+	ctx := context.Background()
+
+	events := []*domain.LiquidityEvent{
+		{CandidateID: "cand1", Mint: "mint1", TxSignature: "sig1", EventIndex: 0, Timestamp: 1000, EventType: domain.LiquidityEventAdd},
+		{Mint: "mint2", Pool: "pool2", TxSignature: "sig3", EventIndex: 0, Timestamp: 3000, EventType: domain.LiquidityEventAdd},
+		{Mint: "mint2", Pool: "pool2", TxSignature: "sig2", EventIndex: 1, Timestamp: 2000, EventType: domain.LiquidityEventAdd},
+	}
+	for _, e := range events {
+		if err := store.Insert(ctx, e); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	orphans, err := store.GetUnassociated(ctx)
+	if err != nil {
+		t.Fatalf("GetUnassociated failed: %v", err)
+	}
+	if len(orphans) != 2 || orphans[0].TxSignature != "sig2" || orphans[1].TxSignature != "sig3" {
+		t.Fatalf("Expected orphans [sig2 sig3] by timestamp, got %d", len(orphans))
+	}
+
+	if err := store.UpdateCandidateID(ctx, orphans[0], "cand2"); err != nil {
+		t.Fatalf("UpdateCandidateID failed: %v", err)
+	}
+
+	// Already associated: nothing left to update
+	if err := store.UpdateCandidateID(ctx, orphans[0], "cand2"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on second update, got %v", err)
+	}
+
+	// Mismatched pool does not identify the stored event
+	mismatched := *orphans[1]
+	mismatched.Pool = "other"
+	if err := store.UpdateCandidateID(ctx, &mismatched, "cand2"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for mismatched pool, got %v", err)
+	}
+
+	associated, err := store.GetByCandidateID(ctx, "cand2")
+	if err != nil {
+		t.Fatalf("GetByCandidateID failed: %v", err)
+	}
+	if len(associated) != 1 || associated[0].TxSignature != "sig2" {
+		t.Errorf("Expected sig2 associated with cand2, got %d events", len(associated))
+	}
+
+	orphans, err = store.GetUnassociated(ctx)
+	if err != nil {
+		t.Fatalf("GetUnassociated failed: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].TxSignature != "sig3" {
+		t.Errorf("Expected only sig3 to remain orphaned, got %d", len(orphans))
+	}
+}
+
+func TestLiquidityEventStore_DuplicateContract(t *testing.T) {
+	storagetest.LiquidityEventStoreDuplicates(t, NewLiquidityEventStore())
+}
+
+func TestLiquidityEventStore_Contract(t *testing.T) {
+	storagetest.RunLiquidityEventStoreSuite(t, func(*testing.T, ...string) storage.LiquidityEventStore {
+		return NewLiquidityEventStore()
+	})
+}
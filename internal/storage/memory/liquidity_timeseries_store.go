@@ -23,6 +23,15 @@ func NewLiquidityTimeseriesStore() *LiquidityTimeseriesStore {
 	}
 }
 
+// Clear removes all stored liquidity points.
+func (s *LiquidityTimeseriesStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.LiquidityTimeseriesPoint)
+	return nil
+}
+
 // liquidityTsKey generates a unique key for a liquidity point.
 func liquidityTsKey(candidateID string, timestampMs int64) string {
 	return fmt.Sprintf("%s|%d", candidateID, timestampMs)
@@ -7,6 +7,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestLiquidityTimeseriesStore_InsertBulkAndGet(t *testing.T) {
@@ -147,3 +148,9 @@ func TestLiquidityTimeseriesStore_EmptyBulk(t *testing.T) {
 		t.Errorf("Empty bulk should succeed, got %v", err)
 	}
 }
+
+func TestLiquidityTimeseriesStore_Contract(t *testing.T) {
+	storagetest.RunLiquidityTimeseriesStoreSuite(t, func(*testing.T) storage.LiquidityTimeseriesStore {
+		return NewLiquidityTimeseriesStore()
+	})
+}
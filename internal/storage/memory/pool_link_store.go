@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// PoolLinkStore is an in-memory implementation of storage.PoolLinkStore.
+type PoolLinkStore struct {
+	mu    sync.RWMutex
+	links map[string]domain.PoolLink // keyed by pool
+}
+
+// NewPoolLinkStore creates a new in-memory pool link store.
+func NewPoolLinkStore() *PoolLinkStore {
+	return &PoolLinkStore{
+		links: make(map[string]domain.PoolLink),
+	}
+}
+
+// Clear removes all links.
+func (s *PoolLinkStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.links = make(map[string]domain.PoolLink)
+	return nil
+}
+
+// Insert adds a link.
+func (s *PoolLinkStore) Insert(_ context.Context, link *domain.PoolLink) error {
+	if link == nil || link.Pool == "" || link.Mint == "" || link.CandidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.links[link.Pool]; exists {
+		return storage.ErrDuplicateKey
+	}
+	s.links[link.Pool] = clonePoolLink(link)
+	return nil
+}
+
+// Close ends the open link of a pool at validTo.
+func (s *PoolLinkStore) Close(_ context.Context, pool string, validTo int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link, exists := s.links[pool]
+	if !exists || link.ValidTo != nil {
+		return storage.ErrNotFound
+	}
+	link.ValidTo = &validTo
+	s.links[pool] = link
+	return nil
+}
+
+// GetByPool retrieves the link of a pool.
+func (s *PoolLinkStore) GetByPool(_ context.Context, pool string) (*domain.PoolLink, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link, exists := s.links[pool]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	result := clonePoolLink(&link)
+	return &result, nil
+}
+
+// GetByMint retrieves the links of a mint, ordered by valid_from ASC, pool ASC.
+func (s *PoolLinkStore) GetByMint(_ context.Context, mint string) ([]*domain.PoolLink, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*domain.PoolLink
+	for _, link := range s.links {
+		if link.Mint == mint {
+			linkCopy := clonePoolLink(&link)
+			result = append(result, &linkCopy)
+		}
+	}
+	sortPoolLinks(result)
+	return result, nil
+}
+
+// GetAll retrieves every link, ordered by mint ASC, valid_from ASC, pool ASC.
+func (s *PoolLinkStore) GetAll(_ context.Context) ([]*domain.PoolLink, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*domain.PoolLink, 0, len(s.links))
+	for _, link := range s.links {
+		linkCopy := clonePoolLink(&link)
+		result = append(result, &linkCopy)
+	}
+	sortPoolLinks(result)
+	return result, nil
+}
+
+func sortPoolLinks(links []*domain.PoolLink) {
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Mint != links[j].Mint {
+			return links[i].Mint < links[j].Mint
+		}
+		if links[i].ValidFrom != links[j].ValidFrom {
+			return links[i].ValidFrom < links[j].ValidFrom
+		}
+		return links[i].Pool < links[j].Pool
+	})
+}
+
+var _ storage.PoolLinkStore = (*PoolLinkStore)(nil)
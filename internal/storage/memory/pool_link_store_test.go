@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestPoolLinkStore_Contract(t *testing.T) {
+	storagetest.RunPoolLinkStoreSuite(t, func(*testing.T) storage.PoolLinkStore {
+		return NewPoolLinkStore()
+	})
+}
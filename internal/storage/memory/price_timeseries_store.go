@@ -23,6 +23,15 @@ func NewPriceTimeseriesStore() *PriceTimeseriesStore {
 	}
 }
 
+// Clear removes all stored price points.
+func (s *PriceTimeseriesStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.PriceTimeseriesPoint)
+	return nil
+}
+
 // priceKey generates a unique key for a price point.
 func priceKey(candidateID string, timestampMs int64) string {
 	return fmt.Sprintf("%s|%d", candidateID, timestampMs)
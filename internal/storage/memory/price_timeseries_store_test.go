@@ -7,6 +7,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestPriceTimeseriesStore_InsertBulkAndGet(t *testing.T) {
@@ -149,3 +150,9 @@ func TestPriceTimeseriesStore_EmptyBulk(t *testing.T) {
 		t.Errorf("Empty bulk should succeed, got %v", err)
 	}
 }
+
+func TestPriceTimeseriesStore_Contract(t *testing.T) {
+	storagetest.RunPriceTimeseriesStoreSuite(t, func(*testing.T) storage.PriceTimeseriesStore {
+		return NewPriceTimeseriesStore()
+	})
+}
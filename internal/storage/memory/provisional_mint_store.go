@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// ProvisionalMintStore is an in-memory implementation of storage.ProvisionalMintStore.
+type ProvisionalMintStore struct {
+	mu    sync.RWMutex
+	mints map[string]*domain.ProvisionalMint // keyed by mint
+}
+
+// NewProvisionalMintStore creates a new in-memory provisional mint store.
+func NewProvisionalMintStore() *ProvisionalMintStore {
+	return &ProvisionalMintStore{
+		mints: make(map[string]*domain.ProvisionalMint),
+	}
+}
+
+// Clear removes all mints.
+func (s *ProvisionalMintStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mints = make(map[string]*domain.ProvisionalMint)
+	return nil
+}
+
+// Insert adds a PENDING mint. Returns ErrDuplicateKey if the mint exists.
+func (s *ProvisionalMintStore) Insert(_ context.Context, m *domain.ProvisionalMint) error {
+	if m == nil || m.Mint == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.mints[m.Mint]; exists {
+		return storage.ErrDuplicateKey
+	}
+
+	mintCopy := cloneProvisionalMint(m)
+	mintCopy.Status = domain.ProvisionalMintPending
+	mintCopy.CandidateID = nil
+	mintCopy.ResolvedAt = nil
+	s.mints[m.Mint] = &mintCopy
+	return nil
+}
+
+// GetByMint retrieves a mint. Returns ErrNotFound if not exists.
+func (s *ProvisionalMintStore) GetByMint(_ context.Context, mint string) (*domain.ProvisionalMint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m, exists := s.mints[mint]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+
+	mintCopy := cloneProvisionalMint(m)
+	return &mintCopy, nil
+}
+
+// Upgrade links a PENDING mint to its candidate and marks it UPGRADED.
+// Returns ErrNotFound if the mint has no PENDING record.
+func (s *ProvisionalMintStore) Upgrade(_ context.Context, mint, candidateID string, discoveredAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, exists := s.mints[mint]
+	if !exists || m.Status != domain.ProvisionalMintPending {
+		return storage.ErrNotFound
+	}
+
+	// Replace rather than mutate so copies handed out earlier are unaffected
+	upgraded := cloneProvisionalMint(m)
+	upgraded.Status = domain.ProvisionalMintUpgraded
+	upgraded.CandidateID = &candidateID
+	upgraded.ResolvedAt = &discoveredAt
+	s.mints[mint] = &upgraded
+	return nil
+}
+
+// ExpirePending marks PENDING mints created before createdBefore EXPIRED.
+func (s *ProvisionalMintStore) ExpirePending(_ context.Context, createdBefore, expiredAt int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for mint, m := range s.mints {
+		if m.Status != domain.ProvisionalMintPending || m.CreatedAt >= createdBefore {
+			continue
+		}
+		expired := cloneProvisionalMint(m)
+		expired.Status = domain.ProvisionalMintExpired
+		expired.ResolvedAt = &expiredAt
+		s.mints[mint] = &expired
+		n++
+	}
+	return n, nil
+}
+
+// GetByStatus retrieves mints of a status, ordered by created_at ASC, mint ASC.
+func (s *ProvisionalMintStore) GetByStatus(_ context.Context, status domain.ProvisionalMintStatus) ([]*domain.ProvisionalMint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*domain.ProvisionalMint
+	for _, m := range s.mints {
+		if m.Status == status {
+			mintCopy := cloneProvisionalMint(m)
+			result = append(result, &mintCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt != result[j].CreatedAt {
+			return result[i].CreatedAt < result[j].CreatedAt
+		}
+		return result[i].Mint < result[j].Mint
+	})
+
+	return result, nil
+}
+
+var _ storage.ProvisionalMintStore = (*ProvisionalMintStore)(nil)
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestProvisionalMintStore_Contract(t *testing.T) {
+	storagetest.RunProvisionalMintStoreSuite(t, func(*testing.T) storage.ProvisionalMintStore {
+		return NewProvisionalMintStore()
+	})
+}
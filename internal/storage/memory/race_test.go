@@ -0,0 +1,507 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// The tests in this file exercise each store with mixed concurrent writes,
+// reads and Clears. They assert little on their own; run them with -race:
+//
+//	go test -race ./internal/storage/memory -run Concurrent
+
+const (
+	raceWorkers    = 8
+	raceIterations = 50
+)
+
+// hammer runs fn from raceWorkers goroutines, raceIterations times each.
+// Every fourth worker clears the store halfway through, so reads and writes
+// interleave with a Clear.
+func hammer(t *testing.T, clear func() error, fn func(worker, i int) error) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, raceWorkers*raceIterations)
+	for w := 0; w < raceWorkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < raceIterations; i++ {
+				if w%4 == 0 && i == raceIterations/2 {
+					if err := clear(); err != nil {
+						errs <- err
+					}
+				}
+				if err := fn(w, i); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// ignoreDup treats ErrDuplicateKey as success; workers may write the same key
+// before and after a concurrent Clear.
+func ignoreDup(err error) error {
+	if errors.Is(err, storage.ErrDuplicateKey) {
+		return nil
+	}
+	return err
+}
+
+func f64(v float64) *float64 { return &v }
+
+func TestConcurrent_CandidateStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewCandidateStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		id := fmt.Sprintf("c-%d-%d", w, i)
+		pool := "pool"
+		detected := int64(i)
+		if err := ignoreDup(store.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: id, Mint: fmt.Sprintf("m%d", i%5), Source: domain.SourceNewToken,
+			DiscoveredAt: int64(i), Pool: &pool, DetectedAt: &detected,
+		})); err != nil {
+			return err
+		}
+		if err := store.Close(ctx, id, int64(i), "fp"); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		got, err := store.GetByTimeRange(ctx, 0, raceIterations)
+		if err != nil {
+			return err
+		}
+		// Returned records belong to the caller
+		for _, c := range got {
+			if c.Pool != nil {
+				*c.Pool = "mutated"
+			}
+			if c.ClosedAt != nil {
+				*c.ClosedAt = -1
+			}
+		}
+		_, err = store.GetByMint(ctx, fmt.Sprintf("m%d", i%5))
+		return err
+	})
+}
+
+func TestConcurrent_SwapEventStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewSwapEventStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		fee := int64(5000)
+		if err := ignoreDup(store.Insert(ctx, &domain.SwapEvent{
+			Mint: fmt.Sprintf("m%d", w), TxSignature: fmt.Sprintf("tx-%d", i), Timestamp: int64(i), FeeLamports: &fee,
+		})); err != nil {
+			return err
+		}
+		got, err := store.GetByMintTimeRange(ctx, fmt.Sprintf("m%d", w), 0, raceIterations)
+		if err != nil {
+			return err
+		}
+		for _, e := range got {
+			if e.FeeLamports != nil {
+				*e.FeeLamports = 0
+			}
+		}
+		if _, err := store.GetDistinctMintsByTimeRange(ctx, 0, raceIterations); err != nil {
+			return err
+		}
+		_, _, err = store.GetGlobalTimeRange(ctx)
+		return err
+	})
+}
+
+func TestConcurrent_LiquidityEventStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLiquidityEventStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		e := &domain.LiquidityEvent{
+			Mint: fmt.Sprintf("m%d", w), Pool: "pool", TxSignature: fmt.Sprintf("tx-%d-%d", w, i), Timestamp: int64(i), EventType: domain.LiquidityEventAdd,
+		}
+		if err := ignoreDup(store.Insert(ctx, e)); err != nil {
+			return err
+		}
+		if err := store.UpdateCandidateID(ctx, e, fmt.Sprintf("c%d", w)); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		if _, err := store.GetUnassociated(ctx); err != nil {
+			return err
+		}
+		_, err := store.GetByTimeRange(ctx, fmt.Sprintf("c%d", w), 0, raceIterations)
+		return err
+	})
+}
+
+func TestConcurrent_SwapStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewSwapStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		candidateID := fmt.Sprintf("c%d", w)
+		if err := ignoreDup(store.Insert(ctx, &domain.Swap{
+			CandidateID: candidateID, TxSignature: fmt.Sprintf("tx-%d", i), Timestamp: int64(i), Side: domain.SwapSideBuy,
+		})); err != nil {
+			return err
+		}
+		if err := ignoreDup(store.InsertBulk(ctx, []*domain.Swap{
+			{CandidateID: candidateID, TxSignature: fmt.Sprintf("tx-%d", i), EventIndex: 1, Timestamp: int64(i), Side: domain.SwapSideSell},
+		})); err != nil {
+			return err
+		}
+		got, err := store.GetByCandidateID(ctx, candidateID)
+		if err != nil {
+			return err
+		}
+		for _, sw := range got {
+			sw.Side = domain.SwapSideSell
+		}
+		_, err = store.GetByTimeRange(ctx, candidateID, 0, raceIterations)
+		return err
+	})
+}
+
+func TestConcurrent_SlotCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewSlotCheckpointStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		slot := int64(w*raceIterations + i)
+		if err := store.MarkComplete(ctx, []*storage.SlotCheckpoint{{Slot: slot, Transactions: i}}); err != nil {
+			return err
+		}
+		completed, err := store.GetCompleted(ctx, 0, raceWorkers*raceIterations)
+		if err != nil {
+			return err
+		}
+		for _, c := range completed {
+			c.Transactions = -1
+		}
+		_, err = store.GetGaps(ctx, 0, raceWorkers*raceIterations)
+		return err
+	})
+}
+
+// TestConcurrent_FinalityStore races finality checks and removals with writes
+// to the event stores it reads and deletes from.
+func TestConcurrent_FinalityStore(t *testing.T) {
+	ctx := context.Background()
+	swaps, events, liquidity := NewSwapStore(), NewSwapEventStore(), NewLiquidityEventStore()
+	store := NewFinalityStore(swaps, events, liquidity)
+	clear := func() error {
+		return errors.Join(store.Clear(ctx), swaps.Clear(ctx), events.Clear(ctx), liquidity.Clear(ctx))
+	}
+	hammer(t, clear, func(w, i int) error {
+		sig := fmt.Sprintf("tx-%d-%d", w, i)
+		err := errors.Join(
+			ignoreDup(swaps.Insert(ctx, &domain.Swap{CandidateID: fmt.Sprintf("c%d", w), TxSignature: sig, Timestamp: int64(i)})),
+			ignoreDup(events.Insert(ctx, &domain.SwapEvent{Mint: fmt.Sprintf("m%d", w), TxSignature: sig, Timestamp: int64(i)})),
+			ignoreDup(liquidity.Insert(ctx, &domain.LiquidityEvent{
+				Mint: fmt.Sprintf("m%d", w), Pool: "pool", TxSignature: sig, Timestamp: int64(i), EventType: domain.LiquidityEventAdd,
+			})),
+		)
+		if err != nil {
+			return err
+		}
+		if _, err := store.GetUnverified(ctx, 0, raceIterations, 10); err != nil {
+			return err
+		}
+		if i%2 == 0 {
+			if err := store.MarkFinalized(ctx, []string{sig}, int64(i)); err != nil {
+				return err
+			}
+		} else if _, err := store.RemoveDropped(ctx, sig, int64(i)); err != nil {
+			return err
+		}
+		if _, err := store.CountUnverified(ctx); err != nil {
+			return err
+		}
+		corrections, err := store.GetCorrections(ctx, 0, raceIterations)
+		if err != nil {
+			return err
+		}
+		for _, c := range corrections {
+			c.EventTable = "mutated"
+		}
+		return nil
+	})
+}
+
+// TestConcurrent_ReparseStore races reparse fixes with writes to the event
+// stores they replace events in.
+func TestConcurrent_ReparseStore(t *testing.T) {
+	ctx := context.Background()
+	events, liquidity := NewSwapEventStore(), NewLiquidityEventStore()
+	store := NewReparseStore(events, liquidity)
+	clear := func() error {
+		return errors.Join(store.Clear(ctx), events.Clear(ctx), liquidity.Clear(ctx))
+	}
+	hammer(t, clear, func(w, i int) error {
+		sig := fmt.Sprintf("tx-%d-%d", w, i)
+		stored := &domain.SwapEvent{Mint: fmt.Sprintf("m%d", w), TxSignature: sig, Timestamp: int64(i)}
+		if err := ignoreDup(events.Insert(ctx, stored)); err != nil {
+			return err
+		}
+		corrected := *stored
+		corrected.AmountOut = 1
+		err := store.Apply(ctx, &storage.ReparseFix{
+			TxSignature:       sig,
+			ReplaceSwapEvents: []*domain.SwapEvent{stored},
+			InsertSwapEvents:  []*domain.SwapEvent{&corrected},
+			Corrections: []*storage.ReparseCorrection{{
+				EventTable: storage.EventTableSwapEvents, TxSignature: sig, Change: storage.ReparseChanged,
+				Before: "{}", After: "{}", CorrectedAt: int64(i),
+			}},
+		})
+		// A concurrent Clear may have removed the stored event
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		swapEvents, _, err := store.GetTxEvents(ctx, sig)
+		if err != nil {
+			return err
+		}
+		for _, e := range swapEvents {
+			e.AmountOut = -1
+		}
+		corrections, err := store.GetCorrections(ctx, 0, raceIterations)
+		if err != nil {
+			return err
+		}
+		for _, c := range corrections {
+			c.After = "mutated"
+		}
+		return nil
+	})
+}
+
+func TestConcurrent_TimeseriesStores(t *testing.T) {
+	ctx := context.Background()
+	prices := NewPriceTimeseriesStore()
+	liquidity := NewLiquidityTimeseriesStore()
+	volume := NewVolumeTimeseriesStore()
+	features := NewDerivedFeatureStore()
+	clear := func() error {
+		return errors.Join(prices.Clear(ctx), liquidity.Clear(ctx), volume.Clear(ctx), features.Clear(ctx))
+	}
+	hammer(t, clear, func(w, i int) error {
+		id := fmt.Sprintf("c%d", w)
+		ts := int64(i)
+		err := errors.Join(
+			ignoreDup(prices.InsertBulk(ctx, []*domain.PriceTimeseriesPoint{{CandidateID: id, TimestampMs: ts, Price: 1}})),
+			ignoreDup(liquidity.InsertBulk(ctx, []*domain.LiquidityTimeseriesPoint{{CandidateID: id, TimestampMs: ts, Liquidity: 1}})),
+			ignoreDup(volume.InsertBulk(ctx, []*domain.VolumeTimeseriesPoint{{CandidateID: id, TimestampMs: ts, Volume: 1}})),
+			ignoreDup(features.InsertBulk(ctx, []*domain.DerivedFeaturePoint{{CandidateID: id, TimestampMs: ts, PriceDelta: f64(1)}})),
+		)
+		if err != nil {
+			return err
+		}
+		if _, err := prices.GetByTimeRange(ctx, id, 0, ts); err != nil {
+			return err
+		}
+		if _, err := liquidity.GetByTimeRange(ctx, id, 0, ts); err != nil {
+			return err
+		}
+		if _, err := volume.GetByTimeRange(ctx, id, 0, ts); err != nil {
+			return err
+		}
+		got, err := features.GetByTimeRange(ctx, id, 0, ts)
+		if err != nil {
+			return err
+		}
+		for _, p := range got {
+			if p.PriceDelta != nil {
+				*p.PriceDelta = 0
+			}
+		}
+		_, _, err = prices.GetGlobalTimeRange(ctx)
+		return err
+	})
+}
+
+func TestConcurrent_TradeRecordStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewTradeRecordStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		trade := &domain.TradeRecord{
+			TradeID: fmt.Sprintf("t-%d-%d", w, i), CandidateID: fmt.Sprintf("c%d", w),
+			StrategyID: "TIME_EXIT", ScenarioID: "realistic", EntrySignalTime: int64(i),
+			EntryContext:  &domain.EntryContext{Liquidity: f64(100)},
+			CostBreakdown: &domain.CostBreakdown{EntrySlippageSOL: 0.01},
+			PeakPrice:     f64(2),
+		}
+		if err := ignoreDup(store.Insert(ctx, trade)); err != nil {
+			return err
+		}
+		trade.Outcome = float64(i)
+		if err := store.Upsert(ctx, trade); err != nil {
+			return err
+		}
+		got, err := store.GetByStrategyScenario(ctx, "TIME_EXIT", "realistic")
+		if err != nil {
+			return err
+		}
+		for _, tr := range got {
+			*tr.EntryContext.Liquidity = 0
+			tr.CostBreakdown.EntrySlippageSOL = 0
+			*tr.PeakPrice = 0
+		}
+		if _, err := store.GetPage(ctx, "", 10); err != nil {
+			return err
+		}
+		_, err = store.GetByCandidateID(ctx, fmt.Sprintf("c%d", w))
+		return err
+	})
+}
+
+func TestConcurrent_StrategyAggregateStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewStrategyAggregateStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		agg := &domain.StrategyAggregate{
+			StrategyID: fmt.Sprintf("S%d", w), ScenarioID: "realistic", EntryEventType: fmt.Sprintf("E%d", i%3),
+			TotalTrades: i, OutcomeRealistic: f64(0.1),
+		}
+		if err := store.Upsert(ctx, agg); err != nil {
+			return err
+		}
+		got, err := store.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, a := range got {
+			if a.OutcomeRealistic != nil {
+				*a.OutcomeRealistic = 0
+			}
+		}
+		_, err = store.Find(ctx, storage.AggregateFilter{StrategyID: fmt.Sprintf("S%d", w)})
+		return err
+	})
+}
+
+func TestConcurrent_TokenMetadataStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewTokenMetadataStore()
+	hammer(t, func() error { return store.Clear(ctx) }, func(w, i int) error {
+		name := "token"
+		id := fmt.Sprintf("c%d", w)
+		if err := store.Upsert(ctx, &domain.TokenMetadata{
+			CandidateID: id, Mint: fmt.Sprintf("m%d", w), Name: &name, FetchedAt: int64(i),
+		}); err != nil {
+			return err
+		}
+		m, err := store.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil // cleared in between
+		}
+		if err != nil {
+			return err
+		}
+		if m.Name != nil {
+			*m.Name = "mutated"
+		}
+		_, err = store.GetStale(ctx, int64(i))
+		return err
+	})
+}
+
+func TestConcurrent_MiscStores(t *testing.T) {
+	ctx := context.Background()
+	raw := NewRawTxStore(0)
+	watchlist := NewWatchlistStore()
+	snapshots := NewTokenHolderSnapshotStore()
+	progress := NewDiscoveryProgressStore()
+	runs := NewSufficiencyRunStore()
+	clear := func() error {
+		return errors.Join(raw.Clear(ctx), watchlist.Clear(ctx), snapshots.Clear(ctx), progress.Clear(ctx), runs.Clear(ctx))
+	}
+	hammer(t, clear, func(w, i int) error {
+		sig := fmt.Sprintf("tx-%d-%d", w, i)
+		mint := fmt.Sprintf("m%d", w)
+		err := errors.Join(
+			raw.Put(ctx, &storage.RawTransaction{TxSignature: sig, Slot: int64(i), Data: []byte("{}")}),
+			ignoreDup(watchlist.Add(ctx, &domain.WatchlistEntry{Mint: mint})),
+			ignoreDup(snapshots.Insert(ctx, &domain.TokenHolderSnapshot{CandidateID: mint, SnapshotAt: int64(i)})),
+			progress.MarkMintSeen(ctx, mint),
+			progress.SetLastProcessed(ctx, &storage.DiscoveryProgress{Slot: uint64(i), Signature: sig}),
+			runs.Insert(ctx, &storage.SufficiencyRun{RunAt: int64(i), Errors: []string{"e"}}),
+		)
+		if err != nil {
+			return err
+		}
+		page, err := raw.GetPage(ctx, 0, "", 5)
+		if err != nil {
+			return err
+		}
+		for _, tx := range page {
+			if len(tx.Data) > 0 {
+				tx.Data[0] = 'x'
+			}
+		}
+		if _, err := watchlist.List(ctx); err != nil {
+			return err
+		}
+		if _, err := snapshots.GetByCandidateID(ctx, mint); err != nil {
+			return err
+		}
+		if _, err := progress.LoadSeenMints(ctx); err != nil {
+			return err
+		}
+		if run, err := runs.GetLatest(ctx); err == nil && len(run.Errors) > 0 {
+			run.Errors[0] = "mutated"
+		}
+		return nil
+	})
+}
+
+func TestCopyOnReturn_NullableFields(t *testing.T) {
+	ctx := context.Background()
+
+	trades := NewTradeRecordStore()
+	liq := f64(100)
+	trade := &domain.TradeRecord{TradeID: "t1", EntryContext: &domain.EntryContext{Liquidity: liq}, PeakPrice: f64(2)}
+	mustNoErr(t, trades.Insert(ctx, trade))
+	*liq = -1 // caller reuses its input
+	got, err := trades.GetByID(ctx, "t1")
+	mustNoErr(t, err)
+	*got.PeakPrice = -1
+	again, err := trades.GetByID(ctx, "t1")
+	mustNoErr(t, err)
+	if *again.EntryContext.Liquidity != 100 || *again.PeakPrice != 2 {
+		t.Errorf("stored trade changed through caller pointers: liquidity=%v peak=%v",
+			*again.EntryContext.Liquidity, *again.PeakPrice)
+	}
+
+	raw := NewRawTxStore(0)
+	mustNoErr(t, raw.Put(ctx, &storage.RawTransaction{TxSignature: "tx1", Data: []byte("abc")}))
+	tx, err := raw.Get(ctx, "tx1")
+	mustNoErr(t, err)
+	tx.Data[0] = 'x'
+	tx, err = raw.Get(ctx, "tx1")
+	mustNoErr(t, err)
+	if string(tx.Data) != "abc" {
+		t.Errorf("stored raw tx changed through returned slice: %q", tx.Data)
+	}
+
+	candidates := NewCandidateStore()
+	pool := "pool-1"
+	mustNoErr(t, candidates.Insert(ctx, &domain.TokenCandidate{CandidateID: "c1", Pool: &pool}))
+	c, err := candidates.GetByID(ctx, "c1")
+	mustNoErr(t, err)
+	*c.Pool = "mutated"
+	c, err = candidates.GetByID(ctx, "c1")
+	mustNoErr(t, err)
+	if *c.Pool != "pool-1" {
+		t.Errorf("stored candidate pool changed through returned pointer: %q", *c.Pool)
+	}
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RawTxStore is an in-memory implementation of storage.RawTxStore.
+type RawTxStore struct {
+	mu       sync.RWMutex
+	data     map[string]*storage.RawTransaction
+	bytes    int64
+	maxBytes int64
+}
+
+// NewRawTxStore creates an archive holding at most maxBytes of transaction data
+// (storage.DefaultRawTxMaxBytes if maxBytes <= 0).
+func NewRawTxStore(maxBytes int64) *RawTxStore {
+	if maxBytes <= 0 {
+		maxBytes = storage.DefaultRawTxMaxBytes
+	}
+	return &RawTxStore{
+		data:     make(map[string]*storage.RawTransaction),
+		maxBytes: maxBytes,
+	}
+}
+
+// Clear removes all archived transactions.
+func (s *RawTxStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*storage.RawTransaction)
+	s.bytes = 0
+	return nil
+}
+
+// Put archives a transaction and evicts the oldest ones beyond the size cap.
+func (s *RawTxStore) Put(_ context.Context, tx *storage.RawTransaction) error {
+	if tx == nil || tx.TxSignature == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.data[tx.TxSignature]; ok {
+		s.bytes -= int64(len(old.Data))
+	}
+	txCopy := cloneRawTx(tx)
+	s.data[tx.TxSignature] = &txCopy
+	s.bytes += int64(len(txCopy.Data))
+
+	if s.bytes > s.maxBytes {
+		for _, old := range s.sortedLocked() {
+			if s.bytes <= s.maxBytes {
+				break
+			}
+			delete(s.data, old.TxSignature)
+			s.bytes -= int64(len(old.Data))
+		}
+	}
+	return nil
+}
+
+// Get returns an archived transaction.
+func (s *RawTxStore) Get(_ context.Context, signature string) (*storage.RawTransaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx, ok := s.data[signature]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	txCopy := cloneRawTx(tx)
+	return &txCopy, nil
+}
+
+// GetPage returns up to limit transactions after (afterSlot, afterSignature), ordered by (slot, tx_signature).
+func (s *RawTxStore) GetPage(_ context.Context, afterSlot int64, afterSignature string, limit int) ([]*storage.RawTransaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*storage.RawTransaction
+	for _, tx := range s.sortedLocked() {
+		if tx.Slot < afterSlot || (tx.Slot == afterSlot && tx.TxSignature <= afterSignature) {
+			continue
+		}
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		txCopy := cloneRawTx(tx)
+		result = append(result, &txCopy)
+	}
+	return result, nil
+}
+
+// Stats returns the number of archived transactions and their total data size.
+func (s *RawTxStore) Stats(_ context.Context) (int, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.data), s.bytes, nil
+}
+
+// sortedLocked returns archived transactions ordered by (slot, tx_signature). Caller holds mu.
+func (s *RawTxStore) sortedLocked() []*storage.RawTransaction {
+	txs := make([]*storage.RawTransaction, 0, len(s.data))
+	for _, tx := range s.data {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].Slot != txs[j].Slot {
+			return txs[i].Slot < txs[j].Slot
+		}
+		return txs[i].TxSignature < txs[j].TxSignature
+	})
+	return txs
+}
+
+var _ storage.RawTxStore = (*RawTxStore)(nil)
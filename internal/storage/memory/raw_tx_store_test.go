@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestRawTxStore_Contract(t *testing.T) {
+	storagetest.RunRawTxStoreSuite(t, func(_ *testing.T, maxBytes int64) storage.RawTxStore {
+		return NewRawTxStore(maxBytes)
+	})
+}
+
+func TestReparseStore_Contract(t *testing.T) {
+	storagetest.RunReparseStoreSuite(t, func(*testing.T, ...string) storagetest.ReparseStores {
+		events, liquidity := NewSwapEventStore(), NewLiquidityEventStore()
+		return storagetest.ReparseStores{
+			Reparse:    NewReparseStore(events, liquidity),
+			SwapEvents: events,
+			Liquidity:  liquidity,
+		}
+	})
+}
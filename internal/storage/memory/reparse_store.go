@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// ReparseStore is an in-memory implementation of storage.ReparseStore
+// over the in-memory swap event and liquidity event stores.
+type ReparseStore struct {
+	events    *SwapEventStore
+	liquidity *LiquidityEventStore
+
+	mu          sync.Mutex
+	corrections []*storage.ReparseCorrection
+}
+
+// NewReparseStore creates a reparse store over the given event stores.
+func NewReparseStore(events *SwapEventStore, liquidity *LiquidityEventStore) *ReparseStore {
+	return &ReparseStore{
+		events:    events,
+		liquidity: liquidity,
+	}
+}
+
+// Clear removes recorded corrections; the event stores are left alone.
+func (s *ReparseStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.corrections = nil
+	return nil
+}
+
+// GetTxEvents returns the stored events of a transaction, ordered by event_index.
+func (s *ReparseStore) GetTxEvents(_ context.Context, signature string) ([]*domain.SwapEvent, []*domain.LiquidityEvent, error) {
+	var swaps []*domain.SwapEvent
+	s.events.mu.RLock()
+	for _, e := range s.events.data {
+		if e.TxSignature == signature {
+			eventCopy := cloneSwapEvent(e)
+			swaps = append(swaps, &eventCopy)
+		}
+	}
+	s.events.mu.RUnlock()
+
+	var liquidity []*domain.LiquidityEvent
+	s.liquidity.mu.RLock()
+	for _, e := range s.liquidity.data {
+		if e.TxSignature == signature {
+			eventCopy := *e
+			liquidity = append(liquidity, &eventCopy)
+		}
+	}
+	s.liquidity.mu.RUnlock()
+
+	sort.Slice(swaps, func(i, j int) bool {
+		if swaps[i].EventIndex != swaps[j].EventIndex {
+			return swaps[i].EventIndex < swaps[j].EventIndex
+		}
+		return swaps[i].Mint < swaps[j].Mint
+	})
+	sort.Slice(liquidity, func(i, j int) bool {
+		if liquidity[i].EventIndex != liquidity[j].EventIndex {
+			return liquidity[i].EventIndex < liquidity[j].EventIndex
+		}
+		return liquidityEventKey(liquidity[i]) < liquidityEventKey(liquidity[j])
+	})
+	return swaps, liquidity, nil
+}
+
+// Apply records the corrections and replaces the events of fix.
+// Returns ErrNotFound if an event to replace is not stored and ErrDuplicateKey if an
+// inserted event collides with one that is kept; nothing is changed in either case.
+func (s *ReparseStore) Apply(_ context.Context, fix *storage.ReparseFix) error {
+	if fix == nil || fix.TxSignature == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events.mu.Lock()
+	defer s.events.mu.Unlock()
+	s.liquidity.mu.Lock()
+	defer s.liquidity.mu.Unlock()
+
+	// Validate the whole fix before changing anything.
+	removedSwaps := make(map[swapEventKey]bool, len(fix.ReplaceSwapEvents))
+	for _, e := range fix.ReplaceSwapEvents {
+		key := swapEventKey{Mint: e.Mint, TxSignature: e.TxSignature, EventIndex: e.EventIndex}
+		if e.TxSignature != fix.TxSignature || !s.events.keys[key] {
+			return storage.ErrNotFound
+		}
+		removedSwaps[key] = true
+	}
+	insertedSwaps := make(map[swapEventKey]bool, len(fix.InsertSwapEvents))
+	for _, e := range fix.InsertSwapEvents {
+		if e == nil || e.TxSignature != fix.TxSignature {
+			return storage.ErrInvalidInput
+		}
+		key := swapEventKey{Mint: e.Mint, TxSignature: e.TxSignature, EventIndex: e.EventIndex}
+		if (s.events.keys[key] && !removedSwaps[key]) || insertedSwaps[key] {
+			return storage.ErrDuplicateKey
+		}
+		insertedSwaps[key] = true
+	}
+
+	removedLiq := make(map[string]bool, len(fix.ReplaceLiquidity))
+	for _, e := range fix.ReplaceLiquidity {
+		key := liquidityEventKey(e)
+		if _, ok := s.liquidity.data[key]; !ok || e.TxSignature != fix.TxSignature {
+			return storage.ErrNotFound
+		}
+		removedLiq[key] = true
+	}
+	insertedLiq := make(map[string]bool, len(fix.InsertLiquidity))
+	for _, e := range fix.InsertLiquidity {
+		if !validLiquidityEvent(e) || e.TxSignature != fix.TxSignature {
+			return storage.ErrInvalidInput
+		}
+		key := liquidityEventKey(e)
+		if _, ok := s.liquidity.data[key]; (ok && !removedLiq[key]) || insertedLiq[key] {
+			return storage.ErrDuplicateKey
+		}
+		insertedLiq[key] = true
+	}
+
+	if len(removedSwaps) > 0 {
+		kept := make([]*domain.SwapEvent, 0, len(s.events.data))
+		for _, e := range s.events.data {
+			key := swapEventKey{Mint: e.Mint, TxSignature: e.TxSignature, EventIndex: e.EventIndex}
+			if removedSwaps[key] {
+				delete(s.events.keys, key)
+				continue
+			}
+			kept = append(kept, e)
+		}
+		s.events.data = kept
+	}
+	for _, e := range fix.InsertSwapEvents {
+		eventCopy := storedSwapEvent(e)
+		s.events.data = append(s.events.data, &eventCopy)
+		s.events.keys[swapEventKey{Mint: e.Mint, TxSignature: e.TxSignature, EventIndex: e.EventIndex}] = true
+	}
+
+	for key := range removedLiq {
+		delete(s.liquidity.data, key)
+	}
+	for _, e := range fix.InsertLiquidity {
+		eventCopy := *e
+		eventCopy.Origin = domain.NormalizeOrigin(e.Origin)
+		s.liquidity.data[liquidityEventKey(e)] = &eventCopy
+	}
+
+	for _, c := range fix.Corrections {
+		cCopy := *c
+		s.corrections = append(s.corrections, &cCopy)
+	}
+	return nil
+}
+
+// GetCorrections returns corrections with corrected_at in [start, end).
+func (s *ReparseStore) GetCorrections(_ context.Context, start, end int64) ([]*storage.ReparseCorrection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*storage.ReparseCorrection
+	for _, c := range s.corrections {
+		if c.CorrectedAt >= start && c.CorrectedAt < end {
+			cCopy := *c
+			result = append(result, &cCopy)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.CorrectedAt != b.CorrectedAt {
+			return a.CorrectedAt < b.CorrectedAt
+		}
+		if a.EventTable != b.EventTable {
+			return a.EventTable < b.EventTable
+		}
+		if a.TxSignature != b.TxSignature {
+			return a.TxSignature < b.TxSignature
+		}
+		return a.EventIndex < b.EventIndex
+	})
+	return result, nil
+}
+
+var _ storage.ReparseStore = (*ReparseStore)(nil)
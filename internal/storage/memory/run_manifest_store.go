@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RunManifestStore is an in-memory implementation of storage.RunManifestStore.
+type RunManifestStore struct {
+	mu        sync.RWMutex
+	manifests []*storage.RunManifest // insertion order
+}
+
+// NewRunManifestStore creates a new in-memory run manifest store.
+func NewRunManifestStore() *RunManifestStore {
+	return &RunManifestStore{}
+}
+
+// Clear removes all manifests.
+func (s *RunManifestStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.manifests = nil
+	return nil
+}
+
+// Insert records a copy of m.
+func (s *RunManifestStore) Insert(_ context.Context, m *storage.RunManifest) error {
+	if m == nil || m.RunID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.manifests {
+		if existing.RunID == m.RunID {
+			return storage.ErrDuplicateKey
+		}
+	}
+	s.manifests = append(s.manifests, copyRunManifest(m))
+	return nil
+}
+
+// GetByID returns the manifest of a run.
+func (s *RunManifestStore) GetByID(_ context.Context, runID string) (*storage.RunManifest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, m := range s.manifests {
+		if m.RunID == runID {
+			return copyRunManifest(m), nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// GetLatest returns the manifest of kind with the highest StartedAt, the last
+// inserted among equal StartedAt.
+func (s *RunManifestStore) GetLatest(ctx context.Context, kind string) (*storage.RunManifest, error) {
+	list, err := s.List(ctx, kind, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return list[0], nil
+}
+
+// List returns up to limit manifests of kind, newest first.
+func (s *RunManifestStore) List(_ context.Context, kind string, limit int) ([]*storage.RunManifest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*storage.RunManifest
+	for i := len(s.manifests) - 1; i >= 0; i-- { // last inserted first among ties
+		if m := s.manifests[i]; kind == "" || m.Kind == kind {
+			result = append(result, copyRunManifest(m))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt > result[j].StartedAt
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func copyRunManifest(m *storage.RunManifest) *storage.RunManifest {
+	mCopy := *m
+	mCopy.Config = maps.Clone(m.Config)
+	mCopy.Backends = append([]storage.RunBackend(nil), m.Backends...)
+	mCopy.Errors = append([]string(nil), m.Errors...)
+	return &mCopy
+}
+
+var _ storage.RunManifestStore = (*RunManifestStore)(nil)
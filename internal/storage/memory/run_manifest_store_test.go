@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestRunManifestStore_Contract(t *testing.T) {
+	storagetest.RunRunManifestStoreSuite(t, func(*testing.T) storage.RunManifestStore {
+		return NewRunManifestStore()
+	})
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/storage"
+)
+
+// SlotCheckpointStore is an in-memory implementation of storage.SlotCheckpointStore.
+type SlotCheckpointStore struct {
+	mu    sync.RWMutex
+	slots map[int64]storage.SlotCheckpoint
+}
+
+// NewSlotCheckpointStore creates a new in-memory slot checkpoint store.
+func NewSlotCheckpointStore() *SlotCheckpointStore {
+	return &SlotCheckpointStore{
+		slots: make(map[int64]storage.SlotCheckpoint),
+	}
+}
+
+// Clear removes all checkpoints.
+func (s *SlotCheckpointStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.slots = make(map[int64]storage.SlotCheckpoint)
+	return nil
+}
+
+// MarkComplete records processed slots, overwriting existing checkpoints.
+func (s *SlotCheckpointStore) MarkComplete(_ context.Context, checkpoints []*storage.SlotCheckpoint) error {
+	for _, c := range checkpoints {
+		if c == nil {
+			return storage.ErrInvalidInput
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range checkpoints {
+		s.slots[c.Slot] = *c
+	}
+	return nil
+}
+
+// GetCompleted returns checkpoints within [from, to], ordered by slot.
+func (s *SlotCheckpointStore) GetCompleted(_ context.Context, from, to int64) ([]*storage.SlotCheckpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*storage.SlotCheckpoint
+	for slot, c := range s.slots {
+		if slot >= from && slot <= to {
+			cp := c
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Slot < result[j].Slot
+	})
+	return result, nil
+}
+
+// GetGaps returns the maximal ranges within [from, to] without a checkpoint.
+func (s *SlotCheckpointStore) GetGaps(ctx context.Context, from, to int64) ([]storage.SlotRange, error) {
+	completed, err := s.GetCompleted(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]int64, len(completed))
+	for i, c := range completed {
+		slots[i] = c.Slot
+	}
+	return storage.SlotGaps(slots, from, to), nil
+}
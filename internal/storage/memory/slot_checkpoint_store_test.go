@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"solana-token-lab/internal/storage"
+)
+
+func TestSlotCheckpointStore_CompletedAndGaps(t *testing.T) {
+	store := NewSlotCheckpointStore()
+	ctx := context.Background()
+
+	err := store.MarkComplete(ctx, []*storage.SlotCheckpoint{
+		{Slot: 103, Transactions: 2},
+		{Slot: 100, Transactions: 1},
+		{Slot: 101, Skipped: true},
+		{Slot: 107},
+	})
+	if err != nil {
+		t.Fatalf("MarkComplete failed: %v", err)
+	}
+
+	// Re-marking overwrites
+	if err := store.MarkComplete(ctx, []*storage.SlotCheckpoint{{Slot: 103, Transactions: 5}}); err != nil {
+		t.Fatalf("MarkComplete failed: %v", err)
+	}
+
+	completed, err := store.GetCompleted(ctx, 101, 106)
+	if err != nil {
+		t.Fatalf("GetCompleted failed: %v", err)
+	}
+	want := []*storage.SlotCheckpoint{{Slot: 101, Skipped: true}, {Slot: 103, Transactions: 5}}
+	if !reflect.DeepEqual(completed, want) {
+		t.Errorf("expected %+v, got %+v", want, completed)
+	}
+
+	gaps, err := store.GetGaps(ctx, 99, 108)
+	if err != nil {
+		t.Fatalf("GetGaps failed: %v", err)
+	}
+	wantGaps := []storage.SlotRange{{From: 99, To: 99}, {From: 102, To: 102}, {From: 104, To: 106}, {From: 108, To: 108}}
+	if !reflect.DeepEqual(gaps, wantGaps) {
+		t.Errorf("expected gaps %+v, got %+v", wantGaps, gaps)
+	}
+
+	gaps, err = store.GetGaps(ctx, 100, 101)
+	if err != nil {
+		t.Fatalf("GetGaps failed: %v", err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("expected no gaps over a covered range, got %+v", gaps)
+	}
+
+	if err := store.MarkComplete(ctx, []*storage.SlotCheckpoint{nil}); err != storage.ErrInvalidInput {
+		t.Errorf("expected ErrInvalidInput for nil checkpoint, got %v", err)
+	}
+}
@@ -23,6 +23,15 @@ func NewStrategyAggregateStore() *StrategyAggregateStore {
 	}
 }
 
+// Clear removes all stored aggregates.
+func (s *StrategyAggregateStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.StrategyAggregate)
+	return nil
+}
+
 // aggregateKey generates a unique key for an aggregate.
 func aggregateKey(strategyID, scenarioID, entryEventType string) string {
 	return fmt.Sprintf("%s|%s|%s", strategyID, scenarioID, entryEventType)
@@ -43,7 +52,23 @@ func (s *StrategyAggregateStore) Insert(_ context.Context, a *domain.StrategyAgg
 		return storage.ErrDuplicateKey
 	}
 
-	aggCopy := *a
+	aggCopy := cloneAggregate(a)
+	s.data[key] = &aggCopy
+	return nil
+}
+
+// Upsert inserts an aggregate or replaces the stored one with the same composite key.
+func (s *StrategyAggregateStore) Upsert(_ context.Context, a *domain.StrategyAggregate) error {
+	if a == nil || a.StrategyID == "" || a.ScenarioID == "" || a.EntryEventType == "" {
+		return storage.ErrInvalidInput
+	}
+
+	key := aggregateKey(a.StrategyID, a.ScenarioID, a.EntryEventType)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aggCopy := cloneAggregate(a)
 	s.data[key] = &aggCopy
 	return nil
 }
@@ -81,7 +106,7 @@ func (s *StrategyAggregateStore) InsertBulk(_ context.Context, aggregates []*dom
 	// Second pass: insert all
 	for _, a := range aggregates {
 		key := aggregateKey(a.StrategyID, a.ScenarioID, a.EntryEventType)
-		aggCopy := *a
+		aggCopy := cloneAggregate(a)
 		s.data[key] = &aggCopy
 	}
 
@@ -99,7 +124,7 @@ func (s *StrategyAggregateStore) GetByKey(_ context.Context, strategyID, scenari
 		return nil, storage.ErrNotFound
 	}
 
-	aggCopy := *a
+	aggCopy := cloneAggregate(a)
 	return &aggCopy, nil
 }
 
@@ -111,7 +136,7 @@ func (s *StrategyAggregateStore) GetByStrategy(_ context.Context, strategyID str
 	var result []*domain.StrategyAggregate
 	for _, a := range s.data {
 		if a.StrategyID == strategyID {
-			aggCopy := *a
+			aggCopy := cloneAggregate(a)
 			result = append(result, &aggCopy)
 		}
 	}
@@ -134,7 +159,7 @@ func (s *StrategyAggregateStore) GetAll(_ context.Context) ([]*domain.StrategyAg
 
 	var result []*domain.StrategyAggregate
 	for _, a := range s.data {
-		aggCopy := *a
+		aggCopy := cloneAggregate(a)
 		result = append(result, &aggCopy)
 	}
 
@@ -152,4 +177,30 @@ func (s *StrategyAggregateStore) GetAll(_ context.Context) ([]*domain.StrategyAg
 	return result, nil
 }
 
+// Find retrieves aggregates matching filter, ordered by strategy, scenario, entry event type.
+func (s *StrategyAggregateStore) Find(_ context.Context, filter storage.AggregateFilter) ([]*domain.StrategyAggregate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*domain.StrategyAggregate
+	for _, a := range s.data {
+		if filter.Matches(a) {
+			aggCopy := cloneAggregate(a)
+			result = append(result, &aggCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StrategyID != result[j].StrategyID {
+			return result[i].StrategyID < result[j].StrategyID
+		}
+		if result[i].ScenarioID != result[j].ScenarioID {
+			return result[i].ScenarioID < result[j].ScenarioID
+		}
+		return result[i].EntryEventType < result[j].EntryEventType
+	})
+
+	return result, nil
+}
+
 var _ storage.StrategyAggregateStore = (*StrategyAggregateStore)(nil)
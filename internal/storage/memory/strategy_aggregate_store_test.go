@@ -7,6 +7,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestStrategyAggregateStore_InsertAndGet(t *testing.T) {
@@ -196,3 +197,39 @@ func TestStrategyAggregateStore_InvalidInput(t *testing.T) {
 		t.Errorf("Expected ErrInvalidInput for empty entry event type, got %v", err)
 	}
 }
+
+func TestStrategyAggregateStore_Upsert(t *testing.T) {
+	store := NewStrategyAggregateStore()
+	ctx := context.Background()
+
+	agg := &domain.StrategyAggregate{
+		StrategyID:     "TIME_EXIT",
+		ScenarioID:     "realistic",
+		EntryEventType: "NEW_TOKEN",
+		TotalTrades:    10,
+		OutcomeMean:    0.1,
+	}
+	if err := store.Insert(ctx, agg); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	refreshed := *agg
+	refreshed.OutcomeMean = -0.05
+	if err := store.Upsert(ctx, &refreshed); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	stored, err := store.GetByKey(ctx, "TIME_EXIT", "realistic", "NEW_TOKEN")
+	if err != nil {
+		t.Fatalf("GetByKey failed: %v", err)
+	}
+	if stored.OutcomeMean != -0.05 {
+		t.Errorf("Expected refreshed mean -0.05, got %f", stored.OutcomeMean)
+	}
+}
+
+func TestStrategyAggregateStore_FindContract(t *testing.T) {
+	storagetest.RunStrategyAggregateFindSuite(t, func(*testing.T) storage.StrategyAggregateStore {
+		return NewStrategyAggregateStore()
+	})
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"solana-token-lab/internal/storage"
+)
+
+// SufficiencyRunStore is an in-memory implementation of storage.SufficiencyRunStore.
+type SufficiencyRunStore struct {
+	mu   sync.RWMutex
+	runs []*storage.SufficiencyRun // insertion order
+}
+
+// NewSufficiencyRunStore creates a new in-memory sufficiency run store.
+func NewSufficiencyRunStore() *SufficiencyRunStore {
+	return &SufficiencyRunStore{}
+}
+
+// Clear removes all runs.
+func (s *SufficiencyRunStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = nil
+	return nil
+}
+
+// Insert records a copy of run.
+func (s *SufficiencyRunStore) Insert(_ context.Context, run *storage.SufficiencyRun) error {
+	if run == nil {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = append(s.runs, copySufficiencyRun(run))
+	return nil
+}
+
+// GetLatest returns the run with the highest RunAt, the last inserted among equal RunAt.
+func (s *SufficiencyRunStore) GetLatest(_ context.Context) (*storage.SufficiencyRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *storage.SufficiencyRun
+	for _, r := range s.runs {
+		if latest == nil || r.RunAt >= latest.RunAt {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, storage.ErrNotFound
+	}
+	return copySufficiencyRun(latest), nil
+}
+
+func copySufficiencyRun(run *storage.SufficiencyRun) *storage.SufficiencyRun {
+	runCopy := *run
+	runCopy.Checks = append([]storage.SufficiencyCheckRecord(nil), run.Checks...)
+	runCopy.Errors = append([]string(nil), run.Errors...)
+	return &runCopy
+}
+
+var _ storage.SufficiencyRunStore = (*SufficiencyRunStore)(nil)
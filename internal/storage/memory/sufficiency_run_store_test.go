@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestSufficiencyRunStore_Contract(t *testing.T) {
+	storagetest.RunSufficiencyRunStoreSuite(t, func(*testing.T) storage.SufficiencyRunStore {
+		return NewSufficiencyRunStore()
+	})
+}
@@ -31,6 +31,24 @@ func NewSwapEventStore() *SwapEventStore {
 	}
 }
 
+// Clear removes all stored swap events.
+func (s *SwapEventStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make([]*domain.SwapEvent, 0)
+	s.keys = make(map[swapEventKey]bool)
+	return nil
+}
+
+// storedSwapEvent returns the copy of e to store, with an empty origin
+// recorded as domain.OriginUnknown.
+func storedSwapEvent(e *domain.SwapEvent) domain.SwapEvent {
+	out := cloneSwapEvent(e)
+	out.Origin = domain.NormalizeOrigin(e.Origin)
+	return out
+}
+
 // Insert adds a new swap event. Returns ErrDuplicateKey if (mint, tx_signature, event_index) exists.
 func (s *SwapEventStore) Insert(_ context.Context, e *domain.SwapEvent) error {
 	if e == nil {
@@ -51,7 +69,7 @@ func (s *SwapEventStore) Insert(_ context.Context, e *domain.SwapEvent) error {
 	}
 
 	// Store a copy
-	eventCopy := *e
+	eventCopy := storedSwapEvent(e)
 	s.data = append(s.data, &eventCopy)
 	s.keys[key] = true
 
@@ -88,7 +106,7 @@ func (s *SwapEventStore) InsertBulk(_ context.Context, events []*domain.SwapEven
 
 	// Insert all
 	for _, e := range events {
-		eventCopy := *e
+		eventCopy := storedSwapEvent(e)
 		s.data = append(s.data, &eventCopy)
 
 		key := swapEventKey{
@@ -110,12 +128,12 @@ func (s *SwapEventStore) GetByTimeRange(_ context.Context, start, end int64) ([]
 	var result []*domain.SwapEvent
 	for _, e := range s.data {
 		if e.Timestamp >= start && e.Timestamp < end {
-			eventCopy := *e
+			eventCopy := cloneSwapEvent(e)
 			result = append(result, &eventCopy)
 		}
 	}
 
-	// Sort by (slot, tx_signature, event_index)
+	// Sort by (timestamp, mint, tx_signature, event_index)
 	sortSwapEvents(result)
 
 	return result, nil
@@ -129,12 +147,12 @@ func (s *SwapEventStore) GetByMintTimeRange(_ context.Context, mint string, star
 	var result []*domain.SwapEvent
 	for _, e := range s.data {
 		if e.Mint == mint && e.Timestamp >= start && e.Timestamp < end {
-			eventCopy := *e
+			eventCopy := cloneSwapEvent(e)
 			result = append(result, &eventCopy)
 		}
 	}
 
-	// Sort by (slot, tx_signature, event_index)
+	// Sort by (timestamp, mint, tx_signature, event_index)
 	sortSwapEvents(result)
 
 	return result, nil
@@ -163,11 +181,37 @@ func (s *SwapEventStore) GetDistinctMintsByTimeRange(_ context.Context, start, e
 	return result, nil
 }
 
-// sortSwapEvents sorts events by (slot, tx_signature, event_index).
+// GetGlobalTimeRange returns min and max event timestamps across all swap events.
+func (s *SwapEventStore) GetGlobalTimeRange(_ context.Context) (minTs, maxTs int64, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.data) == 0 {
+		return 0, 0, nil
+	}
+
+	minTs = s.data[0].Timestamp
+	maxTs = s.data[0].Timestamp
+	for _, e := range s.data[1:] {
+		if e.Timestamp < minTs {
+			minTs = e.Timestamp
+		}
+		if e.Timestamp > maxTs {
+			maxTs = e.Timestamp
+		}
+	}
+
+	return minTs, maxTs, nil
+}
+
+// sortSwapEvents sorts events by (timestamp, mint, tx_signature, event_index), matching Postgres.
 func sortSwapEvents(events []*domain.SwapEvent) {
 	sort.Slice(events, func(i, j int) bool {
-		if events[i].Slot != events[j].Slot {
-			return events[i].Slot < events[j].Slot
+		if events[i].Timestamp != events[j].Timestamp {
+			return events[i].Timestamp < events[j].Timestamp
+		}
+		if events[i].Mint != events[j].Mint {
+			return events[i].Mint < events[j].Mint
 		}
 		if events[i].TxSignature != events[j].TxSignature {
 			return events[i].TxSignature < events[j].TxSignature
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func seedBoundarySwapEvents(t *testing.T, store *SwapEventStore) {
+	t.Helper()
+	events := []*domain.SwapEvent{
+		{Mint: "mintA", TxSignature: "tx_before", Slot: 99, Timestamp: 999},
+		{Mint: "mintA", TxSignature: "tx_start", Slot: 100, Timestamp: 1000},
+		{Mint: "mintB", TxSignature: "tx_mid", Slot: 101, Timestamp: 1500},
+		{Mint: "mintA", TxSignature: "tx_last", Slot: 102, Timestamp: 1999},
+		{Mint: "mintC", TxSignature: "tx_end", Slot: 103, Timestamp: 2000},
+	}
+	if err := store.InsertBulk(context.Background(), events); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+}
+
+func TestSwapEventStore_GetByMintTimeRange_Boundaries(t *testing.T) {
+	store := NewSwapEventStore()
+	seedBoundarySwapEvents(t, store)
+
+	// [1000, 2000): start inclusive, end exclusive
+	events, err := store.GetByMintTimeRange(context.Background(), "mintA", 1000, 2000)
+	if err != nil {
+		t.Fatalf("GetByMintTimeRange failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].TxSignature != "tx_start" || events[1].TxSignature != "tx_last" {
+		t.Errorf("unexpected events: %s, %s", events[0].TxSignature, events[1].TxSignature)
+	}
+}
+
+func TestSwapEventStore_GetDistinctMintsByTimeRange_Boundaries(t *testing.T) {
+	store := NewSwapEventStore()
+	seedBoundarySwapEvents(t, store)
+
+	// mintC only has an event at the exclusive end
+	mints, err := store.GetDistinctMintsByTimeRange(context.Background(), 1000, 2000)
+	if err != nil {
+		t.Fatalf("GetDistinctMintsByTimeRange failed: %v", err)
+	}
+	if len(mints) != 2 || mints[0] != "mintA" || mints[1] != "mintB" {
+		t.Errorf("expected [mintA mintB], got %v", mints)
+	}
+
+	mints, err = store.GetDistinctMintsByTimeRange(context.Background(), 2000, 2001)
+	if err != nil {
+		t.Fatalf("GetDistinctMintsByTimeRange failed: %v", err)
+	}
+	if len(mints) != 1 || mints[0] != "mintC" {
+		t.Errorf("expected [mintC], got %v", mints)
+	}
+}
+
+func TestSwapEventStore_GetGlobalTimeRange(t *testing.T) {
+	store := NewSwapEventStore()
+	ctx := context.Background()
+
+	minTs, maxTs, err := store.GetGlobalTimeRange(ctx)
+	if err != nil {
+		t.Fatalf("GetGlobalTimeRange failed: %v", err)
+	}
+	if minTs != 0 || maxTs != 0 {
+		t.Errorf("expected (0, 0) for empty store, got (%d, %d)", minTs, maxTs)
+	}
+
+	seedBoundarySwapEvents(t, store)
+
+	minTs, maxTs, err = store.GetGlobalTimeRange(ctx)
+	if err != nil {
+		t.Fatalf("GetGlobalTimeRange failed: %v", err)
+	}
+	if minTs != 999 || maxTs != 2000 {
+		t.Errorf("expected (999, 2000), got (%d, %d)", minTs, maxTs)
+	}
+}
+
+func TestSwapEventStore_DuplicateContract(t *testing.T) {
+	storagetest.SwapEventStoreDuplicates(t, NewSwapEventStore())
+}
+
+func TestSwapEventStore_Contract(t *testing.T) {
+	storagetest.RunSwapEventStoreSuite(t, func(*testing.T) storage.SwapEventStore {
+		return NewSwapEventStore()
+	})
+}
@@ -23,6 +23,15 @@ func NewSwapStore() *SwapStore {
 	}
 }
 
+// Clear removes all stored swaps.
+func (s *SwapStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.Swap)
+	return nil
+}
+
 // swapKey generates a unique key for a swap.
 func swapKey(candidateID, txSignature string, eventIndex int) string {
 	return fmt.Sprintf("%s|%s|%d", candidateID, txSignature, eventIndex)
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// holderSnapshotKey is the composite key for snapshot deduplication.
+type holderSnapshotKey struct {
+	CandidateID string
+	SnapshotAt  int64
+}
+
+// TokenHolderSnapshotStore is an in-memory implementation of storage.TokenHolderSnapshotStore.
+type TokenHolderSnapshotStore struct {
+	mu          sync.RWMutex
+	byCandidate map[string][]*domain.TokenHolderSnapshot
+	keys        map[holderSnapshotKey]bool
+}
+
+// NewTokenHolderSnapshotStore creates a new in-memory holder snapshot store.
+func NewTokenHolderSnapshotStore() *TokenHolderSnapshotStore {
+	return &TokenHolderSnapshotStore{
+		byCandidate: make(map[string][]*domain.TokenHolderSnapshot),
+		keys:        make(map[holderSnapshotKey]bool),
+	}
+}
+
+// Clear removes all stored snapshots.
+func (s *TokenHolderSnapshotStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byCandidate = make(map[string][]*domain.TokenHolderSnapshot)
+	s.keys = make(map[holderSnapshotKey]bool)
+	return nil
+}
+
+// Insert adds a snapshot. Returns ErrDuplicateKey if (candidate_id, snapshot_at) exists.
+func (s *TokenHolderSnapshotStore) Insert(_ context.Context, snap *domain.TokenHolderSnapshot) error {
+	if snap == nil || snap.CandidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	key := holderSnapshotKey{CandidateID: snap.CandidateID, SnapshotAt: snap.SnapshotAt}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys[key] {
+		return storage.ErrDuplicateKey
+	}
+
+	snapCopy := *snap
+	snapshots := append(s.byCandidate[snap.CandidateID], &snapCopy)
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].SnapshotAt < snapshots[j].SnapshotAt
+	})
+	s.byCandidate[snap.CandidateID] = snapshots
+	s.keys[key] = true
+
+	return nil
+}
+
+// GetLatest retrieves the most recent snapshot for a candidate. Returns ErrNotFound if none.
+func (s *TokenHolderSnapshotStore) GetLatest(_ context.Context, candidateID string) (*domain.TokenHolderSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.byCandidate[candidateID]
+	if len(snapshots) == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	snapCopy := *snapshots[len(snapshots)-1]
+	return &snapCopy, nil
+}
+
+// GetByCandidateID retrieves all snapshots for a candidate, ordered by snapshot_at ASC.
+func (s *TokenHolderSnapshotStore) GetByCandidateID(_ context.Context, candidateID string) ([]*domain.TokenHolderSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.byCandidate[candidateID]
+	result := make([]*domain.TokenHolderSnapshot, len(snapshots))
+	for i, snap := range snapshots {
+		snapCopy := *snap
+		result[i] = &snapCopy
+	}
+
+	return result, nil
+}
+
+var _ storage.TokenHolderSnapshotStore = (*TokenHolderSnapshotStore)(nil)
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+func TestTokenHolderSnapshotStore_InsertAndGetLatest(t *testing.T) {
+	store := NewTokenHolderSnapshotStore()
+	ctx := context.Background()
+
+	// Insert out of order; latest is by snapshot_at, not insertion order
+	for _, ts := range []int64{2000, 3000, 1000} {
+		snap := &domain.TokenHolderSnapshot{
+			CandidateID: "cand1",
+			Mint:        "mint1",
+			SnapshotAt:  ts,
+			HolderCount: int(ts / 1000),
+			Top10Pct:    float64(ts) / 100,
+		}
+		if err := store.Insert(ctx, snap); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	latest, err := store.GetLatest(ctx, "cand1")
+	if err != nil {
+		t.Fatalf("GetLatest failed: %v", err)
+	}
+	if latest.SnapshotAt != 3000 || latest.HolderCount != 3 {
+		t.Errorf("expected latest snapshot at 3000, got %+v", latest)
+	}
+
+	all, err := store.GetByCandidateID(ctx, "cand1")
+	if err != nil {
+		t.Fatalf("GetByCandidateID failed: %v", err)
+	}
+	if len(all) != 3 || all[0].SnapshotAt != 1000 || all[2].SnapshotAt != 3000 {
+		t.Errorf("expected 3 snapshots ordered by snapshot_at, got %d", len(all))
+	}
+}
+
+func TestTokenHolderSnapshotStore_Errors(t *testing.T) {
+	store := NewTokenHolderSnapshotStore()
+	ctx := context.Background()
+
+	if _, err := store.GetLatest(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	snap := &domain.TokenHolderSnapshot{CandidateID: "cand1", Mint: "mint1", SnapshotAt: 1000}
+	if err := store.Insert(ctx, snap); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if err := store.Insert(ctx, snap); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got %v", err)
+	}
+
+	all, err := store.GetByCandidateID(ctx, "missing")
+	if err != nil || len(all) != 0 {
+		t.Errorf("expected empty result, got %d snapshots, err=%v", len(all), err)
+	}
+}
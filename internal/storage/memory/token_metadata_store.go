@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"solana-token-lab/internal/domain"
@@ -23,6 +24,16 @@ func NewTokenMetadataStore() *TokenMetadataStore {
 	}
 }
 
+// Clear removes all stored metadata.
+func (s *TokenMetadataStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byCandidate = make(map[string]*domain.TokenMetadata)
+	s.byMint = make(map[string]*domain.TokenMetadata)
+	return nil
+}
+
 // Insert adds new metadata. Returns ErrDuplicateKey if candidate_id or mint already exists.
 func (s *TokenMetadataStore) Insert(_ context.Context, m *domain.TokenMetadata) error {
 	if m == nil || m.CandidateID == "" {
@@ -40,12 +51,81 @@ func (s *TokenMetadataStore) Insert(_ context.Context, m *domain.TokenMetadata)
 		return storage.ErrDuplicateKey
 	}
 
-	metaCopy := *m
+	metaCopy := cloneMetadata(m)
+	if metaCopy.FirstFetchedAt == 0 {
+		metaCopy.FirstFetchedAt = metaCopy.FetchedAt
+	}
 	s.byCandidate[m.CandidateID] = &metaCopy
 	s.byMint[m.Mint] = &metaCopy
 	return nil
 }
 
+// Upsert inserts metadata or refreshes the existing row for candidate_id.
+// Returns ErrDuplicateKey if the mint already belongs to another candidate.
+func (s *TokenMetadataStore) Upsert(_ context.Context, m *domain.TokenMetadata) error {
+	if m == nil || m.CandidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.byCandidate[m.CandidateID]
+	if !exists {
+		if _, taken := s.byMint[m.Mint]; taken {
+			return storage.ErrDuplicateKey
+		}
+		metaCopy := cloneMetadata(m)
+		if metaCopy.FirstFetchedAt == 0 {
+			metaCopy.FirstFetchedAt = metaCopy.FetchedAt
+		}
+		s.byCandidate[m.CandidateID] = &metaCopy
+		s.byMint[m.Mint] = &metaCopy
+		return nil
+	}
+
+	// existing is shared by both indexes, so updating in place keeps them in sync
+	if m.Name != nil {
+		existing.Name = m.Name
+	}
+	if m.Symbol != nil {
+		existing.Symbol = m.Symbol
+	}
+	if m.URI != nil {
+		existing.URI = m.URI
+	}
+	if m.Supply != nil {
+		existing.Supply = m.Supply
+		existing.MintAuthority = m.MintAuthority
+		existing.FreezeAuthority = m.FreezeAuthority
+	}
+	existing.FetchedAt = m.FetchedAt
+	return nil
+}
+
+// GetStale returns metadata with fetched_at < olderThan, ordered by fetched_at ASC, candidate_id ASC.
+func (s *TokenMetadataStore) GetStale(_ context.Context, olderThan int64) ([]*domain.TokenMetadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*domain.TokenMetadata
+	for _, m := range s.byCandidate {
+		if m.FetchedAt < olderThan {
+			metaCopy := cloneMetadata(m)
+			result = append(result, &metaCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FetchedAt != result[j].FetchedAt {
+			return result[i].FetchedAt < result[j].FetchedAt
+		}
+		return result[i].CandidateID < result[j].CandidateID
+	})
+
+	return result, nil
+}
+
 // GetByID retrieves metadata by candidate ID. Returns ErrNotFound if not exists.
 func (s *TokenMetadataStore) GetByID(_ context.Context, candidateID string) (*domain.TokenMetadata, error) {
 	s.mu.RLock()
@@ -56,7 +136,7 @@ func (s *TokenMetadataStore) GetByID(_ context.Context, candidateID string) (*do
 		return nil, storage.ErrNotFound
 	}
 
-	metaCopy := *m
+	metaCopy := cloneMetadata(m)
 	return &metaCopy, nil
 }
 
@@ -70,8 +150,29 @@ func (s *TokenMetadataStore) GetByMint(_ context.Context, mint string) (*domain.
 		return nil, storage.ErrNotFound
 	}
 
-	metaCopy := *m
+	metaCopy := cloneMetadata(m)
 	return &metaCopy, nil
 }
 
+// GetByMints retrieves the metadata of each of mints that has any, ordered by mint ASC.
+func (s *TokenMetadataStore) GetByMints(_ context.Context, mints []string) ([]*domain.TokenMetadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*domain.TokenMetadata, 0, len(mints))
+	seen := make(map[string]bool, len(mints))
+	for _, mint := range mints {
+		m, exists := s.byMint[mint]
+		if !exists || seen[mint] {
+			continue
+		}
+		seen[mint] = true
+		metaCopy := cloneMetadata(m)
+		result = append(result, &metaCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Mint < result[j].Mint })
+	return result, nil
+}
+
 var _ storage.TokenMetadataStore = (*TokenMetadataStore)(nil)
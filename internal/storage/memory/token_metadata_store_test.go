@@ -7,6 +7,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestTokenMetadataStore_InsertAndGetByID(t *testing.T) {
@@ -191,3 +192,126 @@ func TestTokenMetadataStore_ReturnsCopy(t *testing.T) {
 		t.Error("Store should return copy, not reference")
 	}
 }
+
+func TestTokenMetadataStore_InsertThenUpsert(t *testing.T) {
+	store := NewTokenMetadataStore()
+	ctx := context.Background()
+
+	name := "OldName"
+	uri := "https://example.com/old.json"
+	authority := "AuthorityA"
+	supply := 1000.0
+	if err := store.Insert(ctx, &domain.TokenMetadata{
+		CandidateID:   "cand1",
+		Mint:          "mint1",
+		Name:          &name,
+		URI:           &uri,
+		Decimals:      6,
+		Supply:        &supply,
+		MintAuthority: &authority,
+		FetchedAt:     1000,
+	}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	// Refresh: supply grew, mint authority revoked, name not resolved this time
+	newSupply := 2500.0
+	freeze := "FreezeB"
+	if err := store.Upsert(ctx, &domain.TokenMetadata{
+		CandidateID:     "cand1",
+		Mint:            "mint1",
+		Decimals:        6,
+		Supply:          &newSupply,
+		FreezeAuthority: &freeze,
+		FetchedAt:       5000,
+	}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	got, err := store.GetByMint(ctx, "mint1")
+	if err != nil {
+		t.Fatalf("GetByMint failed: %v", err)
+	}
+	if got.FirstFetchedAt != 1000 || got.FetchedAt != 5000 {
+		t.Errorf("expected FirstFetchedAt=1000 FetchedAt=5000, got %d, %d", got.FirstFetchedAt, got.FetchedAt)
+	}
+	if got.Supply == nil || *got.Supply != 2500 {
+		t.Errorf("expected supply 2500, got %v", got.Supply)
+	}
+	if got.MintAuthority != nil {
+		t.Errorf("expected mint authority revoked, got %s", *got.MintAuthority)
+	}
+	if got.FreezeAuthority == nil || *got.FreezeAuthority != "FreezeB" {
+		t.Errorf("expected freeze authority FreezeB, got %v", got.FreezeAuthority)
+	}
+	if got.Name == nil || *got.Name != "OldName" {
+		t.Errorf("expected name preserved, got %v", got.Name)
+	}
+	if got.URI == nil || *got.URI != uri {
+		t.Errorf("expected uri preserved, got %v", got.URI)
+	}
+
+	// Refresh without mint account data keeps supply and authorities
+	if err := store.Upsert(ctx, &domain.TokenMetadata{CandidateID: "cand1", Mint: "mint1", FetchedAt: 9000}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	got, _ = store.GetByID(ctx, "cand1")
+	if got.Supply == nil || *got.Supply != 2500 || got.FreezeAuthority == nil {
+		t.Errorf("expected supply and authorities preserved, got %+v", got)
+	}
+	if got.FetchedAt != 9000 {
+		t.Errorf("expected FetchedAt 9000, got %d", got.FetchedAt)
+	}
+}
+
+func TestTokenMetadataStore_UpsertInsertsAndRejectsForeignMint(t *testing.T) {
+	store := NewTokenMetadataStore()
+	ctx := context.Background()
+
+	if err := store.Upsert(ctx, &domain.TokenMetadata{CandidateID: "cand1", Mint: "mint1", FetchedAt: 1000}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	got, err := store.GetByID(ctx, "cand1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.FirstFetchedAt != 1000 {
+		t.Errorf("expected FirstFetchedAt 1000, got %d", got.FirstFetchedAt)
+	}
+
+	err = store.Upsert(ctx, &domain.TokenMetadata{CandidateID: "cand2", Mint: "mint1", FetchedAt: 2000})
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey for mint owned by another candidate, got %v", err)
+	}
+}
+
+func TestTokenMetadataStore_GetStale(t *testing.T) {
+	store := NewTokenMetadataStore()
+	ctx := context.Background()
+
+	_ = store.Insert(ctx, &domain.TokenMetadata{CandidateID: "c3", Mint: "m3", FetchedAt: 3000})
+	_ = store.Insert(ctx, &domain.TokenMetadata{CandidateID: "c2", Mint: "m2", FetchedAt: 1000})
+	_ = store.Insert(ctx, &domain.TokenMetadata{CandidateID: "c1", Mint: "m1", FetchedAt: 1000})
+
+	// Strictly older than the cutoff
+	stale, err := store.GetStale(ctx, 3000)
+	if err != nil {
+		t.Fatalf("GetStale failed: %v", err)
+	}
+	if len(stale) != 2 || stale[0].CandidateID != "c1" || stale[1].CandidateID != "c2" {
+		t.Fatalf("expected [c1 c2], got %v", stale)
+	}
+
+	// A refresh moves the row out of the stale set
+	_ = store.Upsert(ctx, &domain.TokenMetadata{CandidateID: "c1", Mint: "m1", FetchedAt: 4000})
+	stale, _ = store.GetStale(ctx, 3000)
+	if len(stale) != 1 || stale[0].CandidateID != "c2" {
+		t.Errorf("expected [c2] after refresh, got %v", stale)
+	}
+}
+
+func TestTokenMetadataStore_Contract(t *testing.T) {
+	storagetest.RunTokenMetadataStoreSuite(t, func(*testing.T, ...string) storage.TokenMetadataStore {
+		return NewTokenMetadataStore()
+	})
+}
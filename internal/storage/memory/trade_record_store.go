@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
@@ -11,17 +12,29 @@ import (
 
 // TradeRecordStore is an in-memory implementation of storage.TradeRecordStore.
 type TradeRecordStore struct {
-	mu   sync.RWMutex
-	data map[string]*domain.TradeRecord // keyed by trade_id
+	mu        sync.RWMutex
+	data      map[string]*domain.TradeRecord // keyed by trade_id
+	updatedAt map[string]int64               // Unix ms of the last write, keyed by trade_id
 }
 
 // NewTradeRecordStore creates a new in-memory trade record store.
 func NewTradeRecordStore() *TradeRecordStore {
 	return &TradeRecordStore{
-		data: make(map[string]*domain.TradeRecord),
+		data:      make(map[string]*domain.TradeRecord),
+		updatedAt: make(map[string]int64),
 	}
 }
 
+// Clear removes all stored trades.
+func (s *TradeRecordStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.TradeRecord)
+	s.updatedAt = make(map[string]int64)
+	return nil
+}
+
 // Insert adds a new trade. Returns ErrDuplicateKey if trade_id exists.
 func (s *TradeRecordStore) Insert(_ context.Context, t *domain.TradeRecord) error {
 	if t == nil || t.TradeID == "" {
@@ -35,8 +48,20 @@ func (s *TradeRecordStore) Insert(_ context.Context, t *domain.TradeRecord) erro
 		return storage.ErrDuplicateKey
 	}
 
-	tradeCopy := *t
-	s.data[t.TradeID] = &tradeCopy
+	s.put(t, time.Now().UnixMilli())
+	return nil
+}
+
+// Upsert inserts a trade or replaces the stored record with the same trade_id.
+func (s *TradeRecordStore) Upsert(_ context.Context, t *domain.TradeRecord) error {
+	if t == nil || t.TradeID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(t, time.Now().UnixMilli())
 	return nil
 }
 
@@ -70,9 +95,9 @@ func (s *TradeRecordStore) InsertBulk(_ context.Context, trades []*domain.TradeR
 	}
 
 	// Second pass: insert all
+	now := time.Now().UnixMilli()
 	for _, t := range trades {
-		tradeCopy := *t
-		s.data[t.TradeID] = &tradeCopy
+		s.put(t, now)
 	}
 
 	return nil
@@ -88,11 +113,11 @@ func (s *TradeRecordStore) GetByID(_ context.Context, tradeID string) (*domain.T
 		return nil, storage.ErrNotFound
 	}
 
-	tradeCopy := *t
+	tradeCopy := cloneTrade(t)
 	return &tradeCopy, nil
 }
 
-// GetByCandidateID retrieves all trades for a candidate, ordered by entry_signal_time ASC.
+// GetByCandidateID retrieves all trades for a candidate, ordered by entry_signal_time ASC, trade_id ASC.
 func (s *TradeRecordStore) GetByCandidateID(_ context.Context, candidateID string) ([]*domain.TradeRecord, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -100,13 +125,16 @@ func (s *TradeRecordStore) GetByCandidateID(_ context.Context, candidateID strin
 	var result []*domain.TradeRecord
 	for _, t := range s.data {
 		if t.CandidateID == candidateID {
-			tradeCopy := *t
+			tradeCopy := cloneTrade(t)
 			result = append(result, &tradeCopy)
 		}
 	}
 
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].EntrySignalTime < result[j].EntrySignalTime
+		if result[i].EntrySignalTime != result[j].EntrySignalTime {
+			return result[i].EntrySignalTime < result[j].EntrySignalTime
+		}
+		return result[i].TradeID < result[j].TradeID
 	})
 
 	return result, nil
@@ -120,34 +148,112 @@ func (s *TradeRecordStore) GetByStrategyScenario(_ context.Context, strategyID,
 	var result []*domain.TradeRecord
 	for _, t := range s.data {
 		if t.StrategyID == strategyID && t.ScenarioID == scenarioID {
-			tradeCopy := *t
+			tradeCopy := cloneTrade(t)
 			result = append(result, &tradeCopy)
 		}
 	}
 
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].EntrySignalTime < result[j].EntrySignalTime
+		if result[i].EntrySignalTime != result[j].EntrySignalTime {
+			return result[i].EntrySignalTime < result[j].EntrySignalTime
+		}
+		return result[i].TradeID < result[j].TradeID
 	})
 
 	return result, nil
 }
 
-// GetAll retrieves all trades, ordered by entry_signal_time ASC.
+// GetAll retrieves all trades, ordered by entry_signal_time ASC, trade_id ASC.
 func (s *TradeRecordStore) GetAll(_ context.Context) ([]*domain.TradeRecord, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	result := make([]*domain.TradeRecord, 0, len(s.data))
 	for _, t := range s.data {
-		tradeCopy := *t
+		tradeCopy := cloneTrade(t)
 		result = append(result, &tradeCopy)
 	}
 
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].EntrySignalTime < result[j].EntrySignalTime
+		if result[i].EntrySignalTime != result[j].EntrySignalTime {
+			return result[i].EntrySignalTime < result[j].EntrySignalTime
+		}
+		return result[i].TradeID < result[j].TradeID
 	})
 
 	return result, nil
 }
 
+// GetPage retrieves up to limit trades with trade_id > afterTradeID, ordered by trade_id.
+func (s *TradeRecordStore) GetPage(_ context.Context, afterTradeID string, limit int) ([]*domain.TradeRecord, error) {
+	if limit <= 0 {
+		return nil, storage.ErrInvalidInput
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.data))
+	for id := range s.data {
+		if id > afterTradeID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	result := make([]*domain.TradeRecord, 0, len(ids))
+	for _, id := range ids {
+		tradeCopy := cloneTrade(s.data[id])
+		result = append(result, &tradeCopy)
+	}
+	return result, nil
+}
+
+// GetChangedSince retrieves up to limit trades last written after the cursor,
+// ordered by write time then trade_id.
+func (s *TradeRecordStore) GetChangedSince(_ context.Context, after storage.TradeCursor, limit int) ([]*storage.TradeChange, error) {
+	if limit <= 0 {
+		return nil, storage.ErrInvalidInput
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*storage.TradeChange
+	for id, t := range s.data {
+		c := &storage.TradeChange{Trade: t, UpdatedAt: s.updatedAt[id]}
+		if c.Cursor().After(after) {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[j].Cursor().After(result[i].Cursor())
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	for _, c := range result {
+		tradeCopy := cloneTrade(c.Trade)
+		c.Trade = &tradeCopy
+	}
+	return result, nil
+}
+
+// Count returns the number of stored trades.
+func (s *TradeRecordStore) Count(_ context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.data), nil
+}
+
+// put stores a copy of t written at updatedAt (Unix ms). Callers hold mu.
+func (s *TradeRecordStore) put(t *domain.TradeRecord, updatedAt int64) {
+	tradeCopy := cloneTrade(t)
+	s.data[t.TradeID] = &tradeCopy
+	s.updatedAt[t.TradeID] = updatedAt
+}
+
 var _ storage.TradeRecordStore = (*TradeRecordStore)(nil)
@@ -3,10 +3,12 @@ package memory
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestTradeRecordStore_InsertAndGet(t *testing.T) {
@@ -162,3 +164,90 @@ func TestTradeRecordStore_InvalidInput(t *testing.T) {
 		t.Errorf("Expected ErrInvalidInput for empty ID, got %v", err)
 	}
 }
+
+func TestTradeRecordStore_Upsert(t *testing.T) {
+	store := NewTradeRecordStore()
+	ctx := context.Background()
+
+	trade := &domain.TradeRecord{
+		TradeID:      "trade1",
+		CandidateID:  "cand1",
+		StrategyID:   "strat1",
+		ScenarioID:   "realistic",
+		Outcome:      0.5,
+		OutcomeClass: domain.OutcomeClassWin,
+	}
+
+	// Upsert inserts when missing
+	if err := store.Upsert(ctx, trade); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	updated := *trade
+	updated.Outcome = -0.2
+	updated.OutcomeClass = domain.OutcomeClassLoss
+	if err := store.Upsert(ctx, &updated); err != nil {
+		t.Fatalf("Upsert existing failed: %v", err)
+	}
+
+	stored, err := store.GetByID(ctx, "trade1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.Outcome != -0.2 || stored.OutcomeClass != domain.OutcomeClassLoss {
+		t.Errorf("Expected replaced outcome, got %f (%s)", stored.Outcome, stored.OutcomeClass)
+	}
+
+	all, _ := store.GetAll(ctx)
+	if len(all) != 1 {
+		t.Errorf("Expected 1 trade, got %d", len(all))
+	}
+
+	if err := store.Upsert(ctx, &domain.TradeRecord{}); !errors.Is(err, storage.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestTradeRecordStore_GetPage(t *testing.T) {
+	store := NewTradeRecordStore()
+	ctx := context.Background()
+
+	for _, id := range []string{"t3", "t1", "t5", "t2", "t4"} {
+		if err := store.Insert(ctx, &domain.TradeRecord{TradeID: id, CandidateID: "c1"}); err != nil {
+			t.Fatalf("insert %s: %v", id, err)
+		}
+	}
+
+	var seen []string
+	after := ""
+	for {
+		page, err := store.GetPage(ctx, after, 2)
+		if err != nil {
+			t.Fatalf("GetPage failed: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		if len(page) > 2 {
+			t.Fatalf("page exceeds limit: %d", len(page))
+		}
+		for _, tr := range page {
+			seen = append(seen, tr.TradeID)
+		}
+		after = page[len(page)-1].TradeID
+	}
+
+	if got := strings.Join(seen, ","); got != "t1,t2,t3,t4,t5" {
+		t.Errorf("expected trades in trade_id order, got %s", got)
+	}
+
+	if _, err := store.GetPage(ctx, "", 0); !errors.Is(err, storage.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for zero limit, got %v", err)
+	}
+}
+
+func TestTradeRecordStore_Contract(t *testing.T) {
+	storagetest.RunTradeRecordStoreSuite(t, func(*testing.T) storage.TradeRecordStore {
+		return NewTradeRecordStore()
+	})
+}
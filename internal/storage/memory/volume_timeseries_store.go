@@ -23,6 +23,15 @@ func NewVolumeTimeseriesStore() *VolumeTimeseriesStore {
 	}
 }
 
+// Clear removes all stored volume points.
+func (s *VolumeTimeseriesStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[string]*domain.VolumeTimeseriesPoint)
+	return nil
+}
+
 // volumeKey generates a unique key for a volume point.
 func volumeKey(candidateID string, timestampMs int64, intervalSeconds int) string {
 	return fmt.Sprintf("%s|%d|%d", candidateID, timestampMs, intervalSeconds)
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// WatchlistStore is an in-memory implementation of storage.WatchlistStore.
+type WatchlistStore struct {
+	mu      sync.RWMutex
+	entries map[string]*domain.WatchlistEntry
+}
+
+// NewWatchlistStore creates a new in-memory watchlist store.
+func NewWatchlistStore() *WatchlistStore {
+	return &WatchlistStore{
+		entries: make(map[string]*domain.WatchlistEntry),
+	}
+}
+
+// Clear removes all entries.
+func (s *WatchlistStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = make(map[string]*domain.WatchlistEntry)
+	return nil
+}
+
+// Add watchlists a mint. Returns ErrDuplicateKey if mint is already watchlisted.
+func (s *WatchlistStore) Add(_ context.Context, e *domain.WatchlistEntry) error {
+	if e == nil || e.Mint == "" {
+		return storage.ErrInvalidInput
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[e.Mint]; exists {
+		return storage.ErrDuplicateKey
+	}
+
+	entryCopy := *e
+	s.entries[e.Mint] = &entryCopy
+	return nil
+}
+
+// Remove un-watchlists a mint. Returns ErrNotFound if mint is not watchlisted.
+func (s *WatchlistStore) Remove(_ context.Context, mint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[mint]; !exists {
+		return storage.ErrNotFound
+	}
+	delete(s.entries, mint)
+	return nil
+}
+
+// Get retrieves the entry for a mint. Returns ErrNotFound if mint is not watchlisted.
+func (s *WatchlistStore) Get(_ context.Context, mint string) (*domain.WatchlistEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, exists := s.entries[mint]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+
+	entryCopy := *e
+	return &entryCopy, nil
+}
+
+// List retrieves all entries, ordered by mint ASC.
+func (s *WatchlistStore) List(_ context.Context) ([]*domain.WatchlistEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*domain.WatchlistEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entryCopy := *e
+		result = append(result, &entryCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Mint < result[j].Mint
+	})
+
+	return result, nil
+}
+
+var _ storage.WatchlistStore = (*WatchlistStore)(nil)
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+func TestWatchlistStore_CRUD(t *testing.T) {
+	store := NewWatchlistStore()
+	ctx := context.Background()
+
+	for _, mint := range []string{"mintB", "mintA"} {
+		if err := store.Add(ctx, &domain.WatchlistEntry{Mint: mint, Reason: "manual", AddedAt: 1000}); err != nil {
+			t.Fatalf("Add %s failed: %v", mint, err)
+		}
+	}
+
+	err := store.Add(ctx, &domain.WatchlistEntry{Mint: "mintA", Reason: "rug_detector"})
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got %v", err)
+	}
+	if err := store.Add(ctx, &domain.WatchlistEntry{}); !errors.Is(err, storage.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for empty mint, got %v", err)
+	}
+
+	got, err := store.Get(ctx, "mintA")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Reason != "manual" || got.AddedAt != 1000 {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+
+	list, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Mint != "mintA" || list[1].Mint != "mintB" {
+		t.Errorf("expected [mintA mintB], got %v", list)
+	}
+
+	if err := store.Remove(ctx, "mintA"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := store.Remove(ctx, "mintA"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound on second remove, got %v", err)
+	}
+	if _, err := store.Get(ctx, "mintA"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after remove, got %v", err)
+	}
+
+	// Removed mints can be re-added
+	if err := store.Add(ctx, &domain.WatchlistEntry{Mint: "mintA", Reason: "rug_detector"}); err != nil {
+		t.Errorf("re-add failed: %v", err)
+	}
+}
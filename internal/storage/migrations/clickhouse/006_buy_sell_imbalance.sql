@@ -0,0 +1,11 @@
+-- Migration: 006_buy_sell_imbalance
+-- Description: Buy/sell imbalance on volume buckets and derived features
+--
+-- buy_sell_imbalance = (buy_volume - sell_volume) / (buy_volume + sell_volume),
+-- 0 when the bucket has no sided volume.
+-- rolling_imbalance is the same ratio over swaps in the trailing window ending
+-- at the feature timestamp (default 5 minutes), NULL without sided volume.
+
+ALTER TABLE volume_timeseries ADD COLUMN IF NOT EXISTS buy_sell_imbalance Float64 DEFAULT 0;
+
+ALTER TABLE derived_features ADD COLUMN IF NOT EXISTS rolling_imbalance Nullable(Float64);
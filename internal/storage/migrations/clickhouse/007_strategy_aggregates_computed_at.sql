@@ -0,0 +1,8 @@
+-- Migration: 007_strategy_aggregates_computed_at
+-- Description: Computation timestamp on strategy aggregates
+--
+-- computed_at is the Unix ms time the aggregate was computed (0 for rows
+-- written before this column existed). It lets readers select only
+-- aggregates refreshed after a given point, e.g. after a recompute.
+
+ALTER TABLE strategy_aggregates ADD COLUMN IF NOT EXISTS computed_at Int64 DEFAULT 0;
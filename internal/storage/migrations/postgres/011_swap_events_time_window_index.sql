@@ -0,0 +1,9 @@
+-- Migration: 011_swap_events_time_window_index
+-- Description: Covering index for time-windowed swap_events queries
+--
+-- GetDistinctMintsByTimeRange scans [start, end) and projects only mint.
+-- With (timestamp, mint) it becomes an index-only scan instead of a heap
+-- fetch per row. GetByMintTimeRange keeps using idx_swap_events_mint_timestamp
+-- and GetGlobalTimeRange uses idx_swap_events_timestamp for MIN/MAX.
+
+CREATE INDEX IF NOT EXISTS idx_swap_events_timestamp_mint ON swap_events(timestamp, mint);
@@ -0,0 +1,28 @@
+-- Migration: 012_token_metadata_refresh
+-- Description: Allow token metadata refresh (supply and authorities change over time)
+--
+-- token_metadata becomes upsertable: rows are updated in place on re-fetch,
+-- first_fetched_at keeps the original fetch time and fetched_at the latest.
+-- DELETE remains forbidden.
+
+-- Refresh updates rows in place
+DROP TRIGGER IF EXISTS token_metadata_no_update ON token_metadata;
+
+ALTER TABLE token_metadata ADD COLUMN IF NOT EXISTS uri TEXT;
+ALTER TABLE token_metadata ADD COLUMN IF NOT EXISTS mint_authority TEXT;
+ALTER TABLE token_metadata ADD COLUMN IF NOT EXISTS freeze_authority TEXT;
+ALTER TABLE token_metadata ADD COLUMN IF NOT EXISTS first_fetched_at BIGINT;
+
+-- Rows written before this migration were fetched exactly once
+UPDATE token_metadata SET first_fetched_at = fetched_at WHERE first_fetched_at IS NULL;
+ALTER TABLE token_metadata ALTER COLUMN first_fetched_at SET NOT NULL;
+
+-- Staleness scan for the refresh scheduler
+CREATE INDEX IF NOT EXISTS idx_token_metadata_fetched_at ON token_metadata(fetched_at);
+
+COMMENT ON TABLE token_metadata IS 'Token metadata from on-chain. Refreshed in place, no DELETE.';
+COMMENT ON COLUMN token_metadata.uri IS 'Metaplex metadata URI (may be NULL)';
+COMMENT ON COLUMN token_metadata.mint_authority IS 'Mint authority (NULL if revoked)';
+COMMENT ON COLUMN token_metadata.freeze_authority IS 'Freeze authority (NULL if revoked)';
+COMMENT ON COLUMN token_metadata.first_fetched_at IS 'Unix timestamp (ms) of the first fetch, preserved on refresh';
+COMMENT ON COLUMN token_metadata.fetched_at IS 'Unix timestamp (ms) of the latest fetch';
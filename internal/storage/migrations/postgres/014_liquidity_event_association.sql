@@ -0,0 +1,23 @@
+-- Migration: 014_liquidity_event_association
+-- Description: Allow deferred candidate association of liquidity events
+-- The only permitted UPDATE sets candidate_id on a row where it is NULL; all other columns must be unchanged.
+-- DELETE remains prohibited.
+
+CREATE OR REPLACE FUNCTION liquidity_events_allow_association()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.candidate_id IS NULL
+       AND NEW.candidate_id IS NOT NULL
+       AND (to_jsonb(NEW) - 'candidate_id') = (to_jsonb(OLD) - 'candidate_id') THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'Table % is append-only. Only deferred candidate_id association is allowed.', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS liquidity_events_no_update ON liquidity_events;
+CREATE TRIGGER liquidity_events_no_update
+    BEFORE UPDATE ON liquidity_events
+    FOR EACH ROW EXECUTE FUNCTION liquidity_events_allow_association();
+
+COMMENT ON FUNCTION liquidity_events_allow_association() IS 'Append-only guard that permits NULL -> candidate_id association only';
@@ -0,0 +1,16 @@
+-- Migration: 015_watchlist
+-- Description: Mints flagged for prioritized ingestion and extended observation
+--
+-- Mutable by design: entries are added and removed through the admin API.
+-- Watchlisted mints get frequent metadata refresh and are exempt from the
+-- holder enrichment activity window.
+
+CREATE TABLE IF NOT EXISTS watchlist (
+    mint        TEXT PRIMARY KEY,
+    reason      TEXT NOT NULL DEFAULT '',  -- e.g. manual, rug_detector
+    added_at    BIGINT NOT NULL            -- Unix timestamp (ms)
+);
+
+COMMENT ON TABLE watchlist IS 'Mints flagged for denser data collection. Rows are deleted on un-watchlisting.';
+COMMENT ON COLUMN watchlist.reason IS 'Why the mint is watched (free text, e.g. manual or rug_detector)';
+COMMENT ON COLUMN watchlist.added_at IS 'Unix timestamp (ms) when the mint was added';
@@ -0,0 +1,10 @@
+-- Migration: 016_token_candidates_detected_at
+-- Description: Wall-clock detection time for discovery latency measurement
+--
+-- NULL for candidates produced by backfill or replay; only live detectors set it.
+-- Adding a nullable column does not touch existing rows, so the append-only
+-- triggers from 001 are unaffected.
+
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS detected_at BIGINT;
+
+COMMENT ON COLUMN token_candidates.detected_at IS 'Unix timestamp (ms) when a live detector produced the candidate; NULL for backfilled/replayed candidates';
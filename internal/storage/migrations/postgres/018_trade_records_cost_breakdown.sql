@@ -0,0 +1,11 @@
+-- Migration: 018_trade_records_cost_breakdown
+-- Description: Itemized execution costs on simulated trades
+--
+-- cost_breakdown holds domain.CostBreakdown as JSONB: entry/exit slippage,
+-- network fee, priority fee and MEV in SOL. Fees and MEV sum to total_cost_sol;
+-- slippage is already priced into the actual entry/exit prices. NULL for
+-- trades simulated before this migration.
+
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS cost_breakdown JSONB;
+
+COMMENT ON COLUMN trade_records.cost_breakdown IS 'Itemized costs (SOL): entry_slippage_sol, exit_slippage_sol, network_fee_sol, priority_fee_sol, mev_sol';
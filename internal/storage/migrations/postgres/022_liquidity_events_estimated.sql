@@ -0,0 +1,10 @@
+-- Migration: 022_liquidity_events_estimated
+-- Description: Flag estimated liquidity_after values
+--
+-- Live WebSocket ingestion does not see pool reserves, so it estimates
+-- liquidity_after from a running per-pool sum of add/remove deltas.
+-- Measured values (estimated = FALSE) are preferred by normalization.
+
+ALTER TABLE liquidity_events ADD COLUMN IF NOT EXISTS estimated BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN liquidity_events.estimated IS 'TRUE if liquidity_after is estimated from cumulative deltas';
@@ -0,0 +1,43 @@
+-- Migration: 023_token_candidates_closure
+-- Description: Observation-window closure of candidates
+--
+-- A candidate is OPEN until its observation window (discovered_at + window)
+-- has elapsed; the orchestrator then simulates it one last time and marks it
+-- CLOSED, recording the closure time and the replay fingerprint of the events
+-- inside the window. Closed candidates are never re-simulated.
+-- The only permitted UPDATE is that single OPEN -> CLOSED transition; all other
+-- columns must be unchanged. DELETE remains prohibited.
+
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'OPEN';
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS closed_at BIGINT;
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS replay_fingerprint TEXT;
+
+ALTER TABLE token_candidates DROP CONSTRAINT IF EXISTS token_candidates_status_check;
+ALTER TABLE token_candidates ADD CONSTRAINT token_candidates_status_check
+    CHECK (status IN ('OPEN', 'CLOSED'));
+
+CREATE INDEX IF NOT EXISTS idx_token_candidates_status ON token_candidates(status);
+
+CREATE OR REPLACE FUNCTION token_candidates_allow_closure()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status = 'OPEN'
+       AND NEW.status = 'CLOSED'
+       AND NEW.closed_at IS NOT NULL
+       AND (to_jsonb(NEW) - 'status' - 'closed_at' - 'replay_fingerprint')
+           = (to_jsonb(OLD) - 'status' - 'closed_at' - 'replay_fingerprint') THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'Table % is append-only. Only OPEN -> CLOSED closure is allowed.', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS token_candidates_no_update ON token_candidates;
+CREATE TRIGGER token_candidates_no_update
+    BEFORE UPDATE ON token_candidates
+    FOR EACH ROW EXECUTE FUNCTION token_candidates_allow_closure();
+
+COMMENT ON COLUMN token_candidates.status IS 'OPEN while the observation window runs; CLOSED once final trades are frozen';
+COMMENT ON COLUMN token_candidates.closed_at IS 'Unix timestamp (ms) when the candidate was closed; NULL while OPEN';
+COMMENT ON COLUMN token_candidates.replay_fingerprint IS 'Replay fingerprint of the events inside the observation window, frozen at closure';
+COMMENT ON FUNCTION token_candidates_allow_closure() IS 'Append-only guard that permits OPEN -> CLOSED closure only';
@@ -0,0 +1,10 @@
+-- Migration: 028_swap_events_trader
+-- Description: Trader attribution on discovery swap events
+--
+-- trader is the fee payer of the swap transaction (first account key), used
+-- by ACTIVE_TOKEN detection to count distinct traders in the 1h window.
+-- NULL for events ingested before this migration.
+
+ALTER TABLE swap_events ADD COLUMN IF NOT EXISTS trader TEXT;
+
+COMMENT ON COLUMN swap_events.trader IS 'Fee payer of the swap transaction (trader proxy)';
@@ -0,0 +1,18 @@
+-- Migration: 030_dirty_candidates
+-- Description: Candidates awaiting re-simulation after late events
+--
+-- Operational state like the watchlist: a candidate is marked when events with
+-- timestamps inside one of its trade windows are stored after it was simulated
+-- (backfill repair, deferred liquidity association), and unmarked once the
+-- orchestrator has re-simulated it. Rows are upserted and deleted.
+
+CREATE TABLE IF NOT EXISTS dirty_candidates (
+    candidate_id        TEXT PRIMARY KEY,
+    earliest_event_time BIGINT NOT NULL,    -- Unix timestamp (ms) of the earliest late event
+    marked_at           BIGINT NOT NULL     -- Unix timestamp (ms) of the latest mark
+);
+
+CREATE INDEX IF NOT EXISTS idx_dirty_candidates_marked_at ON dirty_candidates(marked_at);
+
+COMMENT ON TABLE dirty_candidates IS 'Candidates whose trades are stale after late events. Upserted on mark, deleted after re-simulation.';
+COMMENT ON COLUMN dirty_candidates.marked_at IS 'Latest mark (ms); a re-simulation only unmarks candidates not marked after it started';
@@ -0,0 +1,11 @@
+-- Migration: 034_trade_armed_at
+-- Description: Trailing stop activation time on trade records
+--
+-- TRAILING_STOP with an activation gain arms its trailing stop only once the
+-- price exceeds entry by that gain. armed_at records when it armed (ms); NULL
+-- if it never armed, for other strategies, without an activation gain and for
+-- older trades.
+
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS armed_at BIGINT;
+
+COMMENT ON COLUMN trade_records.armed_at IS 'When the trailing stop armed (ms), TRAILING_STOP with activation gain';
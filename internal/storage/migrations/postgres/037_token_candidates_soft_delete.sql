@@ -0,0 +1,61 @@
+-- Migration: 037_token_candidates_soft_delete
+-- Description: Soft-delete of junk candidates with an audit log
+--
+-- Junk candidates (test tokens, parser bugs) must leave the research data
+-- without a hard delete, which would orphan their trade records and break the
+-- reproducibility of older reports. A soft-deleted candidate keeps its row and
+-- trades; deleted_at/deleted_reason mark it and default reads skip it.
+-- Each deletion is audited in candidate_deletions, written in the same
+-- transaction. Besides OPEN -> CLOSED closure (migration 023), the only
+-- permitted UPDATE is setting deleted_at and deleted_reason once.
+
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS deleted_at BIGINT;
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS deleted_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_token_candidates_deleted ON token_candidates(deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION token_candidates_allow_closure()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status = 'OPEN'
+       AND NEW.status = 'CLOSED'
+       AND NEW.closed_at IS NOT NULL
+       AND (to_jsonb(NEW) - 'status' - 'closed_at' - 'replay_fingerprint')
+           = (to_jsonb(OLD) - 'status' - 'closed_at' - 'replay_fingerprint') THEN
+        RETURN NEW;
+    END IF;
+    IF OLD.deleted_at IS NULL
+       AND NEW.deleted_at IS NOT NULL
+       AND COALESCE(NEW.deleted_reason, '') <> ''
+       AND (to_jsonb(NEW) - 'deleted_at' - 'deleted_reason')
+           = (to_jsonb(OLD) - 'deleted_at' - 'deleted_reason') THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'Table % is append-only. Only OPEN -> CLOSED closure and a single soft-delete are allowed.', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE IF NOT EXISTS candidate_deletions (
+    id              BIGSERIAL PRIMARY KEY,
+    candidate_id    TEXT NOT NULL UNIQUE REFERENCES token_candidates(candidate_id),
+    deleted_at      BIGINT NOT NULL,            -- Unix timestamp (ms)
+    reason          TEXT NOT NULL,
+    requester       TEXT NOT NULL DEFAULT ''    -- e.g. admin API client address
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_deletions_deleted_at ON candidate_deletions(deleted_at, candidate_id);
+
+DROP TRIGGER IF EXISTS candidate_deletions_no_update ON candidate_deletions;
+CREATE TRIGGER candidate_deletions_no_update
+    BEFORE UPDATE ON candidate_deletions
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+DROP TRIGGER IF EXISTS candidate_deletions_no_delete ON candidate_deletions;
+CREATE TRIGGER candidate_deletions_no_delete
+    BEFORE DELETE ON candidate_deletions
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+COMMENT ON COLUMN token_candidates.deleted_at IS 'Unix timestamp (ms) of the soft-delete; NULL for live candidates';
+COMMENT ON COLUMN token_candidates.deleted_reason IS 'Why the candidate was soft-deleted';
+COMMENT ON TABLE candidate_deletions IS 'Audit log of candidate soft-deletes. Append-only.';
+COMMENT ON FUNCTION token_candidates_allow_closure() IS 'Append-only guard that permits OPEN -> CLOSED closure and a single soft-delete only';
@@ -0,0 +1,19 @@
+-- Migration: 039_trade_records_updated_at
+-- Description: Last write time of each trade record, for incremental mirroring
+--
+-- Upsert replaces re-simulated trades in place, so created_at alone cannot
+-- tell which trades changed since the ClickHouse trade_records mirror was last
+-- synced. updated_at is set on insert and on every upsert; the sync pages
+-- through (updated_at, trade_id) from its watermark. Existing rows start at
+-- their created_at.
+
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS updated_at BIGINT;
+
+UPDATE trade_records SET updated_at = (EXTRACT(EPOCH FROM created_at) * 1000)::BIGINT WHERE updated_at IS NULL;
+
+ALTER TABLE trade_records ALTER COLUMN updated_at SET DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT;
+ALTER TABLE trade_records ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_trade_records_updated_at ON trade_records(updated_at, trade_id);
+
+COMMENT ON COLUMN trade_records.updated_at IS 'Unix timestamp (ms) of the last insert or upsert; watermark of the ClickHouse mirror sync';
@@ -0,0 +1,13 @@
+-- Migration: 040_trade_records_input_key
+-- Description: Simulation cache key of each trade record
+--
+-- input_key hashes the inputs a trade was simulated from: the replay
+-- fingerprint of its candidate's events, the strategy and scenario configs and
+-- the simulation code version. With the simulation cache enabled, the
+-- orchestrator skips a (candidate, strategy, scenario) simulation when a
+-- stored trade of the candidate has the same key. Empty for trades simulated
+-- without the cache.
+
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS input_key TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN trade_records.input_key IS 'Hash of the simulation inputs (data fingerprint, strategy and scenario configs, simulation version); empty without the simulation cache';
@@ -0,0 +1,317 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+
+	chstore "solana-token-lab/internal/storage/clickhouse"
+	"solana-token-lab/internal/storage/postgres"
+)
+
+// SchemaColumn is a table column created by a migration.
+type SchemaColumn struct {
+	Migration string // migration file that creates the column, e.g. "038_failed_tx_tallies.sql"
+	Table     string
+	Column    string
+	Added     bool // added to an existing table by ALTER TABLE ... ADD COLUMN
+}
+
+// Schema is the schema the embedded migrations of one backend create.
+type Schema struct {
+	Migrations []string // migration files in the order they are applied
+	Columns    []SchemaColumn
+}
+
+// Latest returns the last migration file, "" without migrations.
+func (s Schema) Latest() string {
+	if len(s.Migrations) == 0 {
+		return ""
+	}
+	return s.Migrations[len(s.Migrations)-1]
+}
+
+// SchemaStatus compares a database's tables and columns with the expected schema.
+type SchemaStatus struct {
+	Backend  string         // "postgres" or "clickhouse"
+	Version  string         // last migration whose columns, and those of every earlier one, exist; "" if none
+	Expected string         // last embedded migration
+	Missing  []SchemaColumn // expected columns the database lacks, in migration order
+}
+
+// Compatible reports whether the database has every expected column.
+func (s SchemaStatus) Compatible() bool {
+	return len(s.Missing) == 0
+}
+
+// Additive reports whether the only missing columns were added to tables that
+// exist, so code reading the other columns still works.
+func (s SchemaStatus) Additive() bool {
+	for _, c := range s.Missing {
+		if !c.Added {
+			return false
+		}
+	}
+	return true
+}
+
+// Problem describes the missing migrations and columns, "" when compatible.
+func (s SchemaStatus) Problem() string {
+	if s.Compatible() {
+		return ""
+	}
+
+	var pending, missing []string
+	tables := make(map[string]bool)
+	for _, c := range s.Missing {
+		if len(pending) == 0 || pending[len(pending)-1] != c.Migration {
+			pending = append(pending, c.Migration)
+		}
+		switch {
+		case c.Added:
+			missing = append(missing, "column "+c.Table+"."+c.Column)
+		case !tables[c.Table]:
+			tables[c.Table] = true
+			missing = append(missing, "table "+c.Table)
+		}
+	}
+	version := s.Version
+	if version == "" {
+		version = "no migrations"
+	}
+	return fmt.Sprintf("%s schema is at %s, expected %s: apply migration %s (missing %s)",
+		s.Backend, version, s.Expected, strings.Join(pending, ", "), strings.Join(missing, ", "))
+}
+
+// ExpectedPostgresSchema returns the schema of the embedded PostgreSQL migrations.
+func ExpectedPostgresSchema() (Schema, error) {
+	return parseSchema(PostgresFS, "postgres")
+}
+
+// ExpectedClickhouseSchema returns the schema of the embedded ClickHouse migrations.
+func ExpectedClickhouseSchema() (Schema, error) {
+	return parseSchema(ClickhouseFS, "clickhouse")
+}
+
+// CheckPostgresSchema compares the tables of the pool's current schema with
+// the embedded PostgreSQL migrations.
+func CheckPostgresSchema(ctx context.Context, pool *postgres.Pool) (SchemaStatus, error) {
+	expected, err := ExpectedPostgresSchema()
+	if err != nil {
+		return SchemaStatus{}, err
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+	`)
+	if err != nil {
+		return SchemaStatus{}, fmt.Errorf("query postgres columns: %w", err)
+	}
+	defer rows.Close()
+
+	present := make(map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return SchemaStatus{}, fmt.Errorf("scan postgres column: %w", err)
+		}
+		present[table+"."+column] = true
+	}
+	if err := rows.Err(); err != nil {
+		return SchemaStatus{}, fmt.Errorf("iterate postgres columns: %w", err)
+	}
+	return CompareSchema("postgres", expected, present), nil
+}
+
+// CheckClickhouseSchema compares the tables of the connection's database with
+// the embedded ClickHouse migrations.
+func CheckClickhouseSchema(ctx context.Context, conn *chstore.Conn) (SchemaStatus, error) {
+	expected, err := ExpectedClickhouseSchema()
+	if err != nil {
+		return SchemaStatus{}, err
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT table, name
+		FROM system.columns
+		WHERE database = currentDatabase()
+	`)
+	if err != nil {
+		return SchemaStatus{}, fmt.Errorf("query clickhouse columns: %w", err)
+	}
+	defer rows.Close()
+
+	present := make(map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return SchemaStatus{}, fmt.Errorf("scan clickhouse column: %w", err)
+		}
+		present[table+"."+column] = true
+	}
+	if err := rows.Err(); err != nil {
+		return SchemaStatus{}, fmt.Errorf("iterate clickhouse columns: %w", err)
+	}
+	return CompareSchema("clickhouse", expected, present), nil
+}
+
+// CompareSchema checks the expected columns against present, the database's
+// columns keyed "table.column". Columns the migrations do not know are ignored,
+// so a database migrated by a newer binary is compatible.
+func CompareSchema(backend string, expected Schema, present map[string]bool) SchemaStatus {
+	status := SchemaStatus{Backend: backend, Expected: expected.Latest()}
+	for _, c := range expected.Columns {
+		if !present[c.Table+"."+c.Column] {
+			status.Missing = append(status.Missing, c)
+		}
+	}
+
+	if status.Compatible() {
+		status.Version = status.Expected
+		return status
+	}
+	// Migrations before the first one with a missing column are applied
+	for i, m := range expected.Migrations {
+		if m == status.Missing[0].Migration {
+			if i > 0 {
+				status.Version = expected.Migrations[i-1]
+			}
+			break
+		}
+	}
+	return status
+}
+
+var (
+	createTableRe = regexp.MustCompile(`(?i)\bCREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w."]+)\s*\(`)
+	addColumnRe   = regexp.MustCompile(`(?i)\bALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?([\w."]+)\s+ADD\s+COLUMN\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w"]+)`)
+)
+
+// tableElementKeywords start CREATE TABLE elements that are not columns.
+var tableElementKeywords = map[string]bool{
+	"constraint": true, "primary": true, "unique": true, "check": true,
+	"foreign": true, "exclude": true, "index": true, "projection": true, "like": true,
+}
+
+// parseSchema collects the columns of the CREATE TABLE and ALTER TABLE ... ADD
+// COLUMN statements of the migrations in dir, the only ways the migrations
+// create columns. A column is attributed to the first migration creating it.
+func parseSchema(fsys fs.FS, dir string) (Schema, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return Schema{}, fmt.Errorf("read embedded %s migrations: %w", dir, err)
+	}
+
+	var schema Schema
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			schema.Migrations = append(schema.Migrations, entry.Name())
+		}
+	}
+	sort.Strings(schema.Migrations)
+
+	seen := make(map[string]bool)
+	add := func(c SchemaColumn) {
+		key := c.Table + "." + c.Column
+		if !seen[key] {
+			seen[key] = true
+			schema.Columns = append(schema.Columns, c)
+		}
+	}
+	for _, file := range schema.Migrations {
+		data, err := fs.ReadFile(fsys, dir+"/"+file)
+		if err != nil {
+			return Schema{}, fmt.Errorf("read migration %s: %w", file, err)
+		}
+		sql := stripLineComments(string(data))
+
+		for _, m := range createTableRe.FindAllStringSubmatchIndex(sql, -1) {
+			table := identifier(sql[m[2]:m[3]])
+			body, ok := parenthesized(sql[m[1]:])
+			if !ok {
+				return Schema{}, fmt.Errorf("migration %s: unterminated CREATE TABLE %s", file, table)
+			}
+			for _, element := range splitTopLevel(body) {
+				fields := strings.Fields(element)
+				if len(fields) == 0 || tableElementKeywords[strings.ToLower(fields[0])] {
+					continue
+				}
+				add(SchemaColumn{Migration: file, Table: table, Column: identifier(fields[0])})
+			}
+		}
+		for _, m := range addColumnRe.FindAllStringSubmatch(sql, -1) {
+			add(SchemaColumn{Migration: file, Table: identifier(m[1]), Column: identifier(m[2]), Added: true})
+		}
+	}
+	return schema, nil
+}
+
+// stripLineComments removes -- comments. Migrations keep "--" out of string
+// literals, as the ClickHouse statement splitter does.
+func stripLineComments(sql string) string {
+	lines := strings.Split(sql, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "--"); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parenthesized returns the text up to the parenthesis closing the one just
+// before s, skipping string literals.
+func parenthesized(s string) (string, bool) {
+	depth := 1
+	inString := false
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\'':
+			inString = !inString
+		case inString:
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 {
+				return s[:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitTopLevel splits s at commas outside parentheses and string literals.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	inString := false
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\'':
+			inString = !inString
+		case inString:
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// identifier normalizes a possibly quoted or schema-qualified name to the
+// unqualified lower-case name the catalogs report.
+func identifier(name string) string {
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.ToLower(strings.Trim(name, `"`))
+}
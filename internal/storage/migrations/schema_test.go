@@ -0,0 +1,182 @@
+package migrations
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func findColumn(s Schema, table, column string) (SchemaColumn, bool) {
+	for _, c := range s.Columns {
+		if c.Table == table && c.Column == column {
+			return c, true
+		}
+	}
+	return SchemaColumn{}, false
+}
+
+func TestExpectedPostgresSchema(t *testing.T) {
+	s, err := ExpectedPostgresSchema()
+	if err != nil {
+		t.Fatalf("ExpectedPostgresSchema: %v", err)
+	}
+	if s.Migrations[0] != "001_token_candidates.sql" {
+		t.Errorf("expected 001_token_candidates.sql first, got %s", s.Migrations[0])
+	}
+
+	tests := []struct {
+		table, column, migration string
+		added                    bool
+	}{
+		{"token_candidates", "candidate_id", "001_token_candidates.sql", false},
+		{"token_candidates", "deleted_at", "037_token_candidates_soft_delete.sql", true},
+		{"swaps", "dex", "024_dex_labels.sql", true},
+		{"finalized_signatures", "verified_at", "025_finality.sql", false}, // created in a DO block
+		{"failed_tx_tallies", "failed", "038_failed_tx_tallies.sql", false},
+	}
+	for _, tt := range tests {
+		c, ok := findColumn(s, tt.table, tt.column)
+		if !ok {
+			t.Errorf("%s.%s not found", tt.table, tt.column)
+			continue
+		}
+		if c.Migration != tt.migration || c.Added != tt.added {
+			t.Errorf("%s.%s: expected %s added=%v, got %+v", tt.table, tt.column, tt.migration, tt.added, c)
+		}
+	}
+	for _, bogus := range []string{"constraint", "primary", "check"} {
+		if _, ok := findColumn(s, "swaps", bogus); ok {
+			t.Errorf("table constraint parsed as column %q", bogus)
+		}
+	}
+}
+
+func TestExpectedClickhouseSchema(t *testing.T) {
+	s, err := ExpectedClickhouseSchema()
+	if err != nil {
+		t.Fatalf("ExpectedClickhouseSchema: %v", err)
+	}
+	if c, ok := findColumn(s, "strategy_aggregates", "data_end_share"); !ok || !c.Added || c.Migration != "009_strategy_aggregates_data_end.sql" {
+		t.Errorf("unexpected data_end_share column %+v (found %v)", c, ok)
+	}
+	if _, ok := findColumn(s, "price_timeseries", "swap_count"); !ok {
+		t.Error("price_timeseries.swap_count not found")
+	}
+}
+
+func testSchema(t *testing.T) Schema {
+	t.Helper()
+	s, err := parseSchema(fstest.MapFS{
+		"m/001_a.sql": {Data: []byte(`
+-- Migration: 001_a
+CREATE TABLE IF NOT EXISTS a (
+    id      TEXT PRIMARY KEY,
+    kind    TEXT NOT NULL DEFAULT 'x,y', -- commas in literals and comments, (parens)
+    amount  NUMERIC(20, 8),
+    CONSTRAINT chk_kind CHECK (kind IN ('x', 'y'))
+);`)},
+		"m/002_index.sql":  {Data: []byte(`CREATE INDEX IF NOT EXISTS idx_a_kind ON a(kind);`)},
+		"m/003_a_note.sql": {Data: []byte(`ALTER TABLE a ADD COLUMN IF NOT EXISTS note TEXT;`)},
+		"m/004_b.sql":      {Data: []byte(`CREATE TABLE b (id BIGINT);`)},
+	}, "m")
+	if err != nil {
+		t.Fatalf("parseSchema: %v", err)
+	}
+	return s
+}
+
+func TestCompareSchema(t *testing.T) {
+	s := testSchema(t)
+	if len(s.Columns) != 5 {
+		t.Fatalf("expected 5 columns, got %+v", s.Columns)
+	}
+
+	tests := []struct {
+		name       string
+		present    []string
+		version    string
+		compatible bool
+		additive   bool
+		problem    string
+	}{
+		{
+			name:       "up to date",
+			present:    []string{"a.id", "a.kind", "a.amount", "a.note", "b.id", "b.extra"},
+			version:    "004_b.sql",
+			compatible: true,
+			additive:   true,
+		},
+		{
+			name:     "missing column",
+			present:  []string{"a.id", "a.kind", "a.amount", "b.id"},
+			version:  "002_index.sql",
+			additive: true,
+			problem:  "test schema is at 002_index.sql, expected 004_b.sql: apply migration 003_a_note.sql (missing column a.note)",
+		},
+		{
+			name:    "missing table",
+			present: []string{"a.id", "a.kind", "a.amount"},
+			version: "002_index.sql",
+			problem: "apply migration 003_a_note.sql, 004_b.sql (missing column a.note, table b)",
+		},
+		{
+			name:    "empty database",
+			version: "",
+			problem: "test schema is at no migrations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			present := make(map[string]bool)
+			for _, c := range tt.present {
+				present[c] = true
+			}
+			status := CompareSchema("test", s, present)
+			if status.Version != tt.version || status.Expected != "004_b.sql" {
+				t.Errorf("expected version %q of 004_b.sql, got %q of %q", tt.version, status.Version, status.Expected)
+			}
+			if status.Compatible() != tt.compatible || status.Additive() != tt.additive {
+				t.Errorf("expected compatible=%v additive=%v, got %v %v", tt.compatible, tt.additive, status.Compatible(), status.Additive())
+			}
+			if !strings.Contains(status.Problem(), tt.problem) || (tt.problem == "") != (status.Problem() == "") {
+				t.Errorf("expected problem containing %q, got %q", tt.problem, status.Problem())
+			}
+		})
+	}
+}
+
+// TestMigrationsMatchSQLDir checks that the embedded migrations are copies of
+// the ones in sql/, which docker-compose applies to a fresh database.
+func TestMigrationsMatchSQLDir(t *testing.T) {
+	for _, m := range []struct {
+		dir    string
+		fsys   fs.FS
+		subdir string
+	}{
+		{"../../../sql/postgres", PostgresFS, "postgres"},
+		{"../../../sql/clickhouse", ClickhouseFS, "clickhouse"},
+	} {
+		files, err := filepath.Glob(filepath.Join(m.dir, "*.sql"))
+		if err != nil || len(files) == 0 {
+			t.Fatalf("no migrations in %s (err %v)", m.dir, err)
+		}
+		for _, file := range files {
+			want, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("read %s: %v", file, err)
+			}
+			got, err := fs.ReadFile(m.fsys, m.subdir+"/"+filepath.Base(file))
+			if err != nil {
+				t.Errorf("%s is not embedded: %v", file, err)
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("embedded %s/%s differs from %s", m.subdir, filepath.Base(file), file)
+			}
+		}
+	}
+}
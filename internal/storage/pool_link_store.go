@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+)
+
+// PoolLinkStore records which pools belong to a mint's canonical candidate and
+// when (see domain.PoolLink). Ingestion attribution consults it for events
+// whose mint alone does not identify the candidate.
+type PoolLinkStore interface {
+	// Insert adds a link. Returns ErrDuplicateKey if the pool is already linked
+	// and ErrInvalidInput for a nil link or an empty Pool, Mint or CandidateID.
+	Insert(ctx context.Context, link *domain.PoolLink) error
+
+	// Close ends the open link of a pool at validTo (ms, exclusive).
+	// Returns ErrNotFound if the pool has no open link.
+	Close(ctx context.Context, pool string, validTo int64) error
+
+	// GetByPool retrieves the link of a pool. Returns ErrNotFound if not exists.
+	GetByPool(ctx context.Context, pool string) (*domain.PoolLink, error)
+
+	// GetByMint retrieves the links of a mint, ordered by valid_from ASC, pool ASC.
+	GetByMint(ctx context.Context, mint string) ([]*domain.PoolLink, error)
+
+	// GetAll retrieves every link, ordered by mint ASC, valid_from ASC, pool ASC.
+	GetAll(ctx context.Context) ([]*domain.PoolLink, error)
+}
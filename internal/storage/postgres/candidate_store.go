@@ -27,8 +27,8 @@ var _ storage.CandidateStore = (*CandidateStore)(nil)
 func (s *CandidateStore) Insert(ctx context.Context, c *domain.TokenCandidate) error {
 	query := `
 		INSERT INTO token_candidates (
-			candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, detected_at, dex
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))
 	`
 
 	_, err := s.pool.Exec(ctx, query,
@@ -40,6 +40,8 @@ func (s *CandidateStore) Insert(ctx context.Context, c *domain.TokenCandidate) e
 		c.EventIndex,
 		c.Slot,
 		c.DiscoveredAt,
+		c.DetectedAt,
+		c.DEX,
 	)
 	if err != nil {
 		if isDuplicateKeyError(err) {
@@ -53,7 +55,8 @@ func (s *CandidateStore) Insert(ctx context.Context, c *domain.TokenCandidate) e
 // GetByID retrieves a candidate by its ID. Returns ErrNotFound if not exists.
 func (s *CandidateStore) GetByID(ctx context.Context, candidateID string) (*domain.TokenCandidate, error) {
 	query := `
-		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, created_at
+		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, detected_at, created_at,
+			status, closed_at, COALESCE(replay_fingerprint, ''), COALESCE(dex, ''), deleted_at, COALESCE(deleted_reason, '')
 		FROM token_candidates
 		WHERE candidate_id = $1
 	`
@@ -72,7 +75,8 @@ func (s *CandidateStore) GetByID(ctx context.Context, candidateID string) (*doma
 // GetByMint retrieves all candidates for a given mint address.
 func (s *CandidateStore) GetByMint(ctx context.Context, mint string) ([]*domain.TokenCandidate, error) {
 	query := `
-		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, created_at
+		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, detected_at, created_at,
+			status, closed_at, COALESCE(replay_fingerprint, ''), COALESCE(dex, ''), deleted_at, COALESCE(deleted_reason, '')
 		FROM token_candidates
 		WHERE mint = $1
 		ORDER BY discovered_at ASC, candidate_id ASC
@@ -88,15 +92,17 @@ func (s *CandidateStore) GetByMint(ctx context.Context, mint string) ([]*domain.
 }
 
 // GetByTimeRange retrieves candidates discovered within [start, end] (inclusive).
-func (s *CandidateStore) GetByTimeRange(ctx context.Context, start, end int64) ([]*domain.TokenCandidate, error) {
+// Soft-deleted candidates are excluded unless IncludeDeleted is passed.
+func (s *CandidateStore) GetByTimeRange(ctx context.Context, start, end int64, opts ...storage.CandidateReadOption) ([]*domain.TokenCandidate, error) {
 	query := `
-		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, created_at
+		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, detected_at, created_at,
+			status, closed_at, COALESCE(replay_fingerprint, ''), COALESCE(dex, ''), deleted_at, COALESCE(deleted_reason, '')
 		FROM token_candidates
-		WHERE discovered_at >= $1 AND discovered_at <= $2
+		WHERE discovered_at >= $1 AND discovered_at <= $2 AND ($3 OR deleted_at IS NULL)
 		ORDER BY discovered_at ASC, candidate_id ASC
 	`
 
-	rows, err := s.pool.Query(ctx, query, start, end)
+	rows, err := s.pool.Query(ctx, query, start, end, storage.NewCandidateReadOptions(opts...).IncludeDeleted)
 	if err != nil {
 		return nil, fmt.Errorf("get candidates by time range: %w", err)
 	}
@@ -106,15 +112,17 @@ func (s *CandidateStore) GetByTimeRange(ctx context.Context, start, end int64) (
 }
 
 // GetBySource retrieves all candidates of a given source type.
-func (s *CandidateStore) GetBySource(ctx context.Context, source domain.Source) ([]*domain.TokenCandidate, error) {
+// Soft-deleted candidates are excluded unless IncludeDeleted is passed.
+func (s *CandidateStore) GetBySource(ctx context.Context, source domain.Source, opts ...storage.CandidateReadOption) ([]*domain.TokenCandidate, error) {
 	query := `
-		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, created_at
+		SELECT candidate_id, source, mint, pool, tx_signature, event_index, slot, discovered_at, detected_at, created_at,
+			status, closed_at, COALESCE(replay_fingerprint, ''), COALESCE(dex, ''), deleted_at, COALESCE(deleted_reason, '')
 		FROM token_candidates
-		WHERE source = $1
+		WHERE source = $1 AND ($2 OR deleted_at IS NULL)
 		ORDER BY discovered_at ASC, candidate_id ASC
 	`
 
-	rows, err := s.pool.Query(ctx, query, string(source))
+	rows, err := s.pool.Query(ctx, query, string(source), storage.NewCandidateReadOptions(opts...).IncludeDeleted)
 	if err != nil {
 		return nil, fmt.Errorf("get candidates by source: %w", err)
 	}
@@ -123,10 +131,116 @@ func (s *CandidateStore) GetBySource(ctx context.Context, source domain.Source)
 	return scanCandidates(rows)
 }
 
+// Close marks an OPEN candidate CLOSED. Closing an already closed candidate
+// leaves it unchanged. Returns ErrNotFound if not exists.
+func (s *CandidateStore) Close(ctx context.Context, candidateID string, closedAt int64, fingerprint string) error {
+	query := `
+		UPDATE token_candidates
+		SET status = 'CLOSED', closed_at = $2, replay_fingerprint = $3
+		WHERE candidate_id = $1 AND status = 'OPEN'
+	`
+
+	tag, err := s.pool.Exec(ctx, query, candidateID, closedAt, fingerprint)
+	if err != nil {
+		return fmt.Errorf("close candidate: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Nothing updated: either already closed or missing
+	var exists bool
+	if err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM token_candidates WHERE candidate_id = $1)`, candidateID,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("close candidate: %w", err)
+	}
+	if !exists {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// SoftDelete marks a candidate deleted and records d in candidate_deletions in
+// one transaction (see migration 037). Returns ErrInvalidInput without a
+// candidate ID or reason, ErrNotFound if not exists and ErrDuplicateKey if
+// already deleted.
+func (s *CandidateStore) SoftDelete(ctx context.Context, d *storage.CandidateDeletion) error {
+	if d == nil || d.CandidateID == "" || d.Reason == "" {
+		return storage.ErrInvalidInput
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE token_candidates
+		SET deleted_at = $2, deleted_reason = $3
+		WHERE candidate_id = $1 AND deleted_at IS NULL
+	`, d.CandidateID, d.DeletedAt, d.Reason)
+	if err != nil {
+		return fmt.Errorf("soft-delete candidate: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		// Nothing updated: either already deleted or missing
+		var exists bool
+		if err := tx.QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM token_candidates WHERE candidate_id = $1)`, d.CandidateID,
+		).Scan(&exists); err != nil {
+			return fmt.Errorf("soft-delete candidate: %w", err)
+		}
+		if !exists {
+			return storage.ErrNotFound
+		}
+		return storage.ErrDuplicateKey
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO candidate_deletions (candidate_id, deleted_at, reason, requester)
+		VALUES ($1, $2, $3, $4)
+	`, d.CandidateID, d.DeletedAt, d.Reason, d.Requester); err != nil {
+		return fmt.Errorf("audit candidate deletion: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// GetDeletions returns the deletion audit log ordered by deleted_at ASC, then candidate_id.
+func (s *CandidateStore) GetDeletions(ctx context.Context) ([]*storage.CandidateDeletion, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT candidate_id, deleted_at, reason, requester
+		FROM candidate_deletions
+		ORDER BY deleted_at ASC, candidate_id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get candidate deletions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*storage.CandidateDeletion
+	for rows.Next() {
+		var d storage.CandidateDeletion
+		if err := rows.Scan(&d.CandidateID, &d.DeletedAt, &d.Reason, &d.Requester); err != nil {
+			return nil, fmt.Errorf("scan candidate deletion: %w", err)
+		}
+		result = append(result, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate candidate deletions: %w", err)
+	}
+	return result, nil
+}
+
 // scanCandidate scans a single row into a TokenCandidate.
 func scanCandidate(row pgx.Row) (*domain.TokenCandidate, error) {
 	var c domain.TokenCandidate
-	var sourceStr string
+	var sourceStr, statusStr string
 
 	err := row.Scan(
 		&c.CandidateID,
@@ -137,13 +251,21 @@ func scanCandidate(row pgx.Row) (*domain.TokenCandidate, error) {
 		&c.EventIndex,
 		&c.Slot,
 		&c.DiscoveredAt,
+		&c.DetectedAt,
 		&c.CreatedAt,
+		&statusStr,
+		&c.ClosedAt,
+		&c.ReplayFingerprint,
+		&c.DEX,
+		&c.DeletedAt,
+		&c.DeletedReason,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	c.Source = domain.Source(sourceStr)
+	c.Status = domain.CandidateStatus(statusStr)
 	return &c, nil
 }
 
@@ -153,7 +275,7 @@ func scanCandidates(rows pgx.Rows) ([]*domain.TokenCandidate, error) {
 
 	for rows.Next() {
 		var c domain.TokenCandidate
-		var sourceStr string
+		var sourceStr, statusStr string
 
 		err := rows.Scan(
 			&c.CandidateID,
@@ -164,13 +286,21 @@ func scanCandidates(rows pgx.Rows) ([]*domain.TokenCandidate, error) {
 			&c.EventIndex,
 			&c.Slot,
 			&c.DiscoveredAt,
+			&c.DetectedAt,
 			&c.CreatedAt,
+			&statusStr,
+			&c.ClosedAt,
+			&c.ReplayFingerprint,
+			&c.DEX,
+			&c.DeletedAt,
+			&c.DeletedReason,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan candidate row: %w", err)
 		}
 
 		c.Source = domain.Source(sourceStr)
+		c.Status = domain.CandidateStatus(statusStr)
 		candidates = append(candidates, &c)
 	}
 
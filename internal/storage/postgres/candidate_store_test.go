@@ -9,6 +9,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestCandidateStore_InsertAndGetByID(t *testing.T) {
@@ -354,3 +355,13 @@ func TestCandidateStore_EmptyResult(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, result)
 }
+
+func TestCandidateStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunCandidateStoreSuite(t, func(t *testing.T) storage.CandidateStore {
+		truncateTables(t, pool, "token_candidates")
+		return NewCandidateStore(pool)
+	})
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DirtyCandidateStore is a PostgreSQL implementation of storage.DirtyCandidateStore
+// over the dirty_candidates table (see migration 030).
+type DirtyCandidateStore struct {
+	pool *Pool
+}
+
+// NewDirtyCandidateStore creates a new DirtyCandidateStore.
+func NewDirtyCandidateStore(pool *Pool) *DirtyCandidateStore {
+	return &DirtyCandidateStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.DirtyCandidateStore = (*DirtyCandidateStore)(nil)
+
+// Mark flags a candidate as dirty, keeping the earliest event time and latest markedAt.
+func (s *DirtyCandidateStore) Mark(ctx context.Context, candidateID string, eventTime, markedAt int64) error {
+	if candidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	query := `
+		INSERT INTO dirty_candidates (candidate_id, earliest_event_time, marked_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (candidate_id) DO UPDATE SET
+			earliest_event_time = LEAST(dirty_candidates.earliest_event_time, EXCLUDED.earliest_event_time),
+			marked_at = GREATEST(dirty_candidates.marked_at, EXCLUDED.marked_at)
+	`
+	if _, err := s.pool.Exec(ctx, query, candidateID, eventTime, markedAt); err != nil {
+		return fmt.Errorf("mark dirty candidate: %w", err)
+	}
+	return nil
+}
+
+// List retrieves all dirty candidates, ordered by marked_at ASC, candidate_id ASC.
+func (s *DirtyCandidateStore) List(ctx context.Context) ([]*domain.DirtyCandidate, error) {
+	query := `
+		SELECT candidate_id, earliest_event_time, marked_at
+		FROM dirty_candidates
+		ORDER BY marked_at ASC, candidate_id ASC
+	`
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query dirty candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var marks []*domain.DirtyCandidate
+	for rows.Next() {
+		var d domain.DirtyCandidate
+		if err := rows.Scan(&d.CandidateID, &d.EarliestEventTime, &d.MarkedAt); err != nil {
+			return nil, fmt.Errorf("scan dirty candidate row: %w", err)
+		}
+		marks = append(marks, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dirty candidate rows: %w", err)
+	}
+
+	return marks, nil
+}
+
+// Count returns the number of dirty candidates.
+func (s *DirtyCandidateStore) Count(ctx context.Context) (int, error) {
+	var n int
+	if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM dirty_candidates`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count dirty candidates: %w", err)
+	}
+	return n, nil
+}
+
+// Unmark removes the mark of a candidate unless it was marked after markedAt.
+func (s *DirtyCandidateStore) Unmark(ctx context.Context, candidateID string, markedAt int64) error {
+	query := `DELETE FROM dirty_candidates WHERE candidate_id = $1 AND marked_at <= $2`
+	if _, err := s.pool.Exec(ctx, query, candidateID, markedAt); err != nil {
+		return fmt.Errorf("unmark dirty candidate: %w", err)
+	}
+	return nil
+}
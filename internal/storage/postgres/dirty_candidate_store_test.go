@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestDirtyCandidateStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunDirtyCandidateStoreSuite(t, func(t *testing.T) storage.DirtyCandidateStore {
+		truncateTables(t, pool, "dirty_candidates")
+		return NewDirtyCandidateStore(pool)
+	})
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// EventCountStore is a PostgreSQL implementation of storage.EventCountStore
+// over the candidate_event_counts table (see migration 032).
+type EventCountStore struct {
+	pool *Pool
+}
+
+// NewEventCountStore creates a new EventCountStore.
+func NewEventCountStore(pool *Pool) *EventCountStore {
+	return &EventCountStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.EventCountStore = (*EventCountStore)(nil)
+
+const eventCountColumns = `candidate_id, swaps_seen, swaps_stored, liquidity_seen, liquidity_stored, sample_every, updated_at`
+
+// Upsert inserts the counts of a candidate or replaces the stored ones.
+func (s *EventCountStore) Upsert(ctx context.Context, c *domain.CandidateEventCounts) error {
+	if c == nil || c.CandidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	query := `
+		INSERT INTO candidate_event_counts (` + eventCountColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (candidate_id) DO UPDATE SET
+			swaps_seen = EXCLUDED.swaps_seen,
+			swaps_stored = EXCLUDED.swaps_stored,
+			liquidity_seen = EXCLUDED.liquidity_seen,
+			liquidity_stored = EXCLUDED.liquidity_stored,
+			sample_every = EXCLUDED.sample_every,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := s.pool.Exec(ctx, query, c.CandidateID, c.SwapsSeen, c.SwapsStored,
+		c.LiquiditySeen, c.LiquidityStored, c.SampleEvery, c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert candidate event counts: %w", err)
+	}
+	return nil
+}
+
+// GetByCandidateID retrieves the counts of a candidate.
+func (s *EventCountStore) GetByCandidateID(ctx context.Context, candidateID string) (*domain.CandidateEventCounts, error) {
+	query := `SELECT ` + eventCountColumns + ` FROM candidate_event_counts WHERE candidate_id = $1`
+
+	var c domain.CandidateEventCounts
+	err := s.pool.QueryRow(ctx, query, candidateID).Scan(&c.CandidateID, &c.SwapsSeen, &c.SwapsStored,
+		&c.LiquiditySeen, &c.LiquidityStored, &c.SampleEvery, &c.UpdatedAt)
+	if isNotFoundError(err) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get candidate event counts: %w", err)
+	}
+	return &c, nil
+}
+
+// GetDownsampled retrieves the counts of every candidate with dropped events,
+// ordered by candidate_id ASC.
+func (s *EventCountStore) GetDownsampled(ctx context.Context) ([]*domain.CandidateEventCounts, error) {
+	query := `
+		SELECT ` + eventCountColumns + `
+		FROM candidate_event_counts
+		WHERE swaps_stored < swaps_seen OR liquidity_stored < liquidity_seen
+		ORDER BY candidate_id ASC
+	`
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query downsampled candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.CandidateEventCounts
+	for rows.Next() {
+		var c domain.CandidateEventCounts
+		if err := rows.Scan(&c.CandidateID, &c.SwapsSeen, &c.SwapsStored,
+			&c.LiquiditySeen, &c.LiquidityStored, &c.SampleEvery, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan candidate event counts row: %w", err)
+		}
+		result = append(result, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate candidate event counts rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestEventCountStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunEventCountStoreSuite(t, func(t *testing.T) storage.EventCountStore {
+		truncateTables(t, pool, "candidate_event_counts")
+		return NewEventCountStore(pool)
+	})
+}
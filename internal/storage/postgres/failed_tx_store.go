@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/storage"
+)
+
+// FailedTxStore is a PostgreSQL implementation of storage.FailedTxStore
+// over the failed_tx_tallies table (see migration 038).
+type FailedTxStore struct {
+	pool *Pool
+}
+
+// NewFailedTxStore creates a new FailedTxStore.
+func NewFailedTxStore(pool *Pool) *FailedTxStore {
+	return &FailedTxStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.FailedTxStore = (*FailedTxStore)(nil)
+
+// Add adds the counts of tallies to the stored ones in one transaction.
+// Nothing is stored if any tally is invalid.
+func (s *FailedTxStore) Add(ctx context.Context, tallies []*storage.FailedTxTally) error {
+	if len(tallies) == 0 {
+		return nil
+	}
+	for _, t := range tallies {
+		if !storage.ValidFailedTxTally(t) {
+			return storage.ErrInvalidInput
+		}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO failed_tx_tallies (program, source, hour_start, transactions, failed)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (program, source, hour_start) DO UPDATE
+		SET transactions = failed_tx_tallies.transactions + EXCLUDED.transactions,
+		    failed = failed_tx_tallies.failed + EXCLUDED.failed
+	`
+	for _, t := range tallies {
+		if _, err := tx.Exec(ctx, query, t.Program, t.Source, t.HourStart, t.Transactions, t.Failed); err != nil {
+			return fmt.Errorf("add failed tx tally %s/%s@%d: %w", t.Program, t.Source, t.HourStart, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// GetRange returns the tallies with HourStart within [from, to), ordered by
+// HourStart, Program and Source.
+func (s *FailedTxStore) GetRange(ctx context.Context, from, to int64) ([]*storage.FailedTxTally, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT program, source, hour_start, transactions, failed
+		FROM failed_tx_tallies
+		WHERE hour_start >= $1 AND hour_start < $2
+		ORDER BY hour_start, program, source
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query failed tx tallies: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*storage.FailedTxTally
+	for rows.Next() {
+		var t storage.FailedTxTally
+		if err := rows.Scan(&t.Program, &t.Source, &t.HourStart, &t.Transactions, &t.Failed); err != nil {
+			return nil, fmt.Errorf("scan failed tx tally row: %w", err)
+		}
+		result = append(result, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate failed tx tally rows: %w", err)
+	}
+	return result, nil
+}
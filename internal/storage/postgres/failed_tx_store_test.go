@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestFailedTxStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunFailedTxStoreSuite(t, func(t *testing.T) storage.FailedTxStore {
+		truncateTables(t, pool, "failed_tx_tallies")
+		return NewFailedTxStore(pool)
+	})
+}
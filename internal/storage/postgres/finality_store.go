@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+
+	"solana-token-lab/internal/storage"
+)
+
+// FinalityStore is a PostgreSQL implementation of storage.FinalityStore.
+// Finalized transactions are kept in finalized_signatures; deleted events are
+// audited in finality_corrections (see migration 025).
+type FinalityStore struct {
+	pool *Pool
+}
+
+// NewFinalityStore creates a new PostgreSQL finality store.
+func NewFinalityStore(pool *Pool) *FinalityStore {
+	return &FinalityStore{pool: pool}
+}
+
+// eventsUnion selects (tx_signature, slot, timestamp) of every event across the event tables.
+const eventsUnion = `
+	SELECT tx_signature, slot, timestamp FROM swaps
+	UNION ALL SELECT tx_signature, slot, timestamp FROM swap_events
+	UNION ALL SELECT tx_signature, slot, timestamp FROM liquidity_events
+`
+
+// GetUnverified returns distinct unverified signatures with events in [start, end), ordered by (slot, tx_signature).
+func (s *FinalityStore) GetUnverified(ctx context.Context, start, end int64, limit int) ([]storage.UnverifiedSignature, error) {
+	query := `
+		SELECT e.tx_signature, MIN(e.slot), MIN(e.timestamp)
+		FROM (` + eventsUnion + `) e
+		WHERE e.timestamp >= $1 AND e.timestamp < $2
+		  AND NOT EXISTS (SELECT 1 FROM finalized_signatures f WHERE f.tx_signature = e.tx_signature)
+		GROUP BY e.tx_signature
+		ORDER BY MIN(e.slot), e.tx_signature
+	`
+	args := []interface{}{start, end}
+	if limit > 0 {
+		query += ` LIMIT $3`
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []storage.UnverifiedSignature
+	for rows.Next() {
+		var u storage.UnverifiedSignature
+		if err := rows.Scan(&u.TxSignature, &u.Slot, &u.Timestamp); err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+	return result, rows.Err()
+}
+
+// MarkFinalized records signatures as finalized, keeping the first verification time.
+func (s *FinalityStore) MarkFinalized(ctx context.Context, signatures []string, verifiedAt int64) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO finalized_signatures (tx_signature, verified_at)
+		SELECT unnest($1::text[]), $2
+		ON CONFLICT (tx_signature) DO NOTHING
+	`, signatures, verifiedAt)
+	return err
+}
+
+// RemoveDropped records a correction per event of signature and deletes the events in one transaction.
+// The delete triggers only accept events with a correction row, so the audit cannot be skipped.
+func (s *FinalityStore) RemoveDropped(ctx context.Context, signature string, detectedAt int64) ([]*storage.FinalityCorrection, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO finality_corrections
+			(event_table, tx_signature, event_index, candidate_id, mint, slot, timestamp, detected_at)
+		SELECT 'swaps', tx_signature, event_index, candidate_id, NULL, slot, timestamp, $2
+		FROM swaps WHERE tx_signature = $1
+		UNION ALL
+		SELECT 'swap_events', tx_signature, event_index, NULL, mint, slot, timestamp, $2
+		FROM swap_events WHERE tx_signature = $1
+		UNION ALL
+		SELECT 'liquidity_events', tx_signature, event_index, candidate_id, mint, slot, timestamp, $2
+		FROM liquidity_events WHERE tx_signature = $1
+		RETURNING event_table, tx_signature, event_index,
+		          COALESCE(candidate_id, ''), COALESCE(mint, ''), slot, timestamp, detected_at
+	`, signature, detectedAt)
+	if err != nil {
+		return nil, fmt.Errorf("audit dropped events of %s: %w", signature, err)
+	}
+	corrections, err := scanFinalityCorrections(rows)
+	if err != nil {
+		return nil, fmt.Errorf("audit dropped events of %s: %w", signature, err)
+	}
+
+	for _, table := range []string{"swaps", "swap_events", "liquidity_events"} {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE tx_signature = $1`, signature); err != nil {
+			return nil, fmt.Errorf("delete dropped events of %s from %s: %w", signature, table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	sortFinalityCorrections(corrections)
+	return corrections, nil
+}
+
+// CountUnverified returns the number of stored events whose signature is not marked finalized.
+func (s *FinalityStore) CountUnverified(ctx context.Context) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM (`+eventsUnion+`) e
+		WHERE NOT EXISTS (SELECT 1 FROM finalized_signatures f WHERE f.tx_signature = e.tx_signature)
+	`).Scan(&count)
+	return count, err
+}
+
+// GetCorrections returns corrections with detected_at in [start, end).
+func (s *FinalityStore) GetCorrections(ctx context.Context, start, end int64) ([]*storage.FinalityCorrection, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT event_table, tx_signature, event_index,
+		       COALESCE(candidate_id, ''), COALESCE(mint, ''), slot, timestamp, detected_at
+		FROM finality_corrections
+		WHERE detected_at >= $1 AND detected_at < $2
+		ORDER BY detected_at, event_table, tx_signature, event_index
+	`, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return scanFinalityCorrections(rows)
+}
+
+func scanFinalityCorrections(rows pgx.Rows) ([]*storage.FinalityCorrection, error) {
+	defer rows.Close()
+
+	var result []*storage.FinalityCorrection
+	for rows.Next() {
+		var c storage.FinalityCorrection
+		if err := rows.Scan(&c.EventTable, &c.TxSignature, &c.EventIndex,
+			&c.CandidateID, &c.Mint, &c.Slot, &c.Timestamp, &c.DetectedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &c)
+	}
+	return result, rows.Err()
+}
+
+// sortFinalityCorrections orders corrections of one detection time like GetCorrections.
+func sortFinalityCorrections(cs []*storage.FinalityCorrection) {
+	sort.Slice(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if a.EventTable != b.EventTable {
+			return a.EventTable < b.EventTable
+		}
+		if a.TxSignature != b.TxSignature {
+			return a.TxSignature < b.TxSignature
+		}
+		return a.EventIndex < b.EventIndex
+	})
+}
+
+var _ storage.FinalityStore = (*FinalityStore)(nil)
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestFinalityStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunFinalityStoreSuite(t, func(t *testing.T, candidateIDs ...string) storagetest.FinalityStores {
+		truncateTables(t, pool, "finality_corrections", "finalized_signatures",
+			"swaps", "swap_events", "liquidity_events", "token_candidates")
+		for _, id := range candidateIDs {
+			createTestCandidate(t, context.Background(), pool, id)
+		}
+		return storagetest.FinalityStores{
+			Finality:   NewFinalityStore(pool),
+			Swaps:      NewSwapStore(pool),
+			SwapEvents: NewSwapEventStore(pool),
+			Liquidity:  NewLiquidityEventStore(pool),
+		}
+	})
+}
+
+func TestFinalityStore_DeleteRequiresCorrection(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := pool.Exec(ctx, `
+		INSERT INTO swap_events (mint, tx_signature, event_index, slot, timestamp, amount_out)
+		VALUES ('mintA', 'sig1', 0, 1, 1000, 1)
+	`)
+	require.NoError(t, err)
+
+	// Unaudited deletes are still rejected by the append-only trigger
+	_, err = pool.Exec(ctx, `DELETE FROM swap_events WHERE tx_signature = 'sig1'`)
+	require.Error(t, err)
+}
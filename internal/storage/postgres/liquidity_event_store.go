@@ -23,14 +23,20 @@ func NewLiquidityEventStore(pool *Pool) *LiquidityEventStore {
 // Compile-time interface check.
 var _ storage.LiquidityEventStore = (*LiquidityEventStore)(nil)
 
-// Insert adds a new liquidity event. Returns ErrDuplicateKey if exists.
-func (s *LiquidityEventStore) Insert(ctx context.Context, e *domain.LiquidityEvent) error {
-	query := `
-		INSERT INTO liquidity_events (
-			candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, pool, mint
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`
+// insertLiquidityEventQuery inserts one liquidity event; its arguments come from liquidityEventArgs.
+const insertLiquidityEventQuery = `
+	INSERT INTO liquidity_events (
+		candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, estimated, pool, mint, dex, origin
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14)
+`
 
+// liquidityEventArgs returns the insert arguments of e with its event type
+// canonicalized; an unknown type is ErrInvalidInput.
+func liquidityEventArgs(e *domain.LiquidityEvent) ([]interface{}, error) {
+	eventType, err := domain.NormalizeLiquidityEventType(e.EventType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", storage.ErrInvalidInput, err)
+	}
 	// Convert empty strings to nil for nullable columns
 	var candidateID, pool, mint interface{}
 	if e.CandidateID != "" {
@@ -42,20 +48,31 @@ func (s *LiquidityEventStore) Insert(ctx context.Context, e *domain.LiquidityEve
 	if e.Mint != "" {
 		mint = e.Mint
 	}
-
-	_, err := s.pool.Exec(ctx, query,
+	return []interface{}{
 		candidateID,
 		e.TxSignature,
 		e.EventIndex,
 		e.Slot,
 		e.Timestamp,
-		e.EventType,
+		eventType,
 		e.AmountToken,
 		e.AmountQuote,
 		e.LiquidityAfter,
+		e.Estimated,
 		pool,
 		mint,
-	)
+		e.DEX,
+		domain.NormalizeOrigin(e.Origin),
+	}, nil
+}
+
+// Insert adds a new liquidity event. Returns ErrDuplicateKey if exists.
+func (s *LiquidityEventStore) Insert(ctx context.Context, e *domain.LiquidityEvent) error {
+	args, err := liquidityEventArgs(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.pool.Exec(ctx, insertLiquidityEventQuery, args...)
 	if err != nil {
 		if isDuplicateKeyError(err) {
 			return storage.ErrDuplicateKey
@@ -77,38 +94,12 @@ func (s *LiquidityEventStore) InsertBulk(ctx context.Context, events []*domain.L
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-		INSERT INTO liquidity_events (
-			candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, pool, mint
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`
-
 	for _, e := range events {
-		// Convert empty strings to nil for nullable columns
-		var candidateID, pool, mint interface{}
-		if e.CandidateID != "" {
-			candidateID = e.CandidateID
-		}
-		if e.Pool != "" {
-			pool = e.Pool
-		}
-		if e.Mint != "" {
-			mint = e.Mint
+		args, err := liquidityEventArgs(e)
+		if err != nil {
+			return err
 		}
-
-		_, err := tx.Exec(ctx, query,
-			candidateID,
-			e.TxSignature,
-			e.EventIndex,
-			e.Slot,
-			e.Timestamp,
-			e.EventType,
-			e.AmountToken,
-			e.AmountQuote,
-			e.LiquidityAfter,
-			pool,
-			mint,
-		)
+		_, err = tx.Exec(ctx, insertLiquidityEventQuery, args...)
 		if err != nil {
 			if isDuplicateKeyError(err) {
 				return storage.ErrDuplicateKey
@@ -127,7 +118,7 @@ func (s *LiquidityEventStore) InsertBulk(ctx context.Context, events []*domain.L
 // GetByCandidateID retrieves all events for a candidate, ordered by timestamp ASC.
 func (s *LiquidityEventStore) GetByCandidateID(ctx context.Context, candidateID string) ([]*domain.LiquidityEvent, error) {
 	query := `
-		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, created_at, pool, mint
+		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, estimated, created_at, pool, mint, COALESCE(dex, ''), origin
 		FROM liquidity_events
 		WHERE candidate_id = $1
 		ORDER BY timestamp ASC, id ASC
@@ -145,7 +136,7 @@ func (s *LiquidityEventStore) GetByCandidateID(ctx context.Context, candidateID
 // GetByTimeRange retrieves events for a candidate within [start, end] (inclusive).
 func (s *LiquidityEventStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) ([]*domain.LiquidityEvent, error) {
 	query := `
-		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, created_at, pool, mint
+		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, estimated, created_at, pool, mint, COALESCE(dex, ''), origin
 		FROM liquidity_events
 		WHERE candidate_id = $1 AND timestamp >= $2 AND timestamp <= $3
 		ORDER BY timestamp ASC, id ASC
@@ -164,7 +155,7 @@ func (s *LiquidityEventStore) GetByTimeRange(ctx context.Context, candidateID st
 // Used for pre-candidate spike detection (ACTIVE_TOKEN discovery).
 func (s *LiquidityEventStore) GetByMintTimeRange(ctx context.Context, mint string, start, end int64) ([]*domain.LiquidityEvent, error) {
 	query := `
-		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, created_at, pool, mint
+		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, estimated, created_at, pool, mint, COALESCE(dex, ''), origin
 		FROM liquidity_events
 		WHERE mint = $1 AND timestamp >= $2 AND timestamp < $3
 		ORDER BY timestamp ASC, id ASC
@@ -179,6 +170,93 @@ func (s *LiquidityEventStore) GetByMintTimeRange(ctx context.Context, mint strin
 	return scanLiquidityEvents(rows)
 }
 
+// GetUnassociated retrieves events stored without a candidate ID, ordered by timestamp ASC.
+func (s *LiquidityEventStore) GetUnassociated(ctx context.Context) ([]*domain.LiquidityEvent, error) {
+	query := `
+		SELECT id, COALESCE(candidate_id, ''), tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, estimated, created_at, pool, mint, COALESCE(dex, ''), origin
+		FROM liquidity_events
+		WHERE candidate_id IS NULL
+		ORDER BY timestamp ASC, id ASC
+	`
+
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("get unassociated liquidity events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLiquidityEvents(rows)
+}
+
+// UpdateCandidateID associates an unassociated event with a candidate.
+// Only NULL candidate_id rows are updated (see migration 014).
+// Returns ErrNotFound if no unassociated event matches (tx_signature, event_index, mint, pool).
+func (s *LiquidityEventStore) UpdateCandidateID(ctx context.Context, e *domain.LiquidityEvent, candidateID string) error {
+	query := `
+		UPDATE liquidity_events
+		SET candidate_id = $1
+		WHERE candidate_id IS NULL
+		  AND tx_signature = $2
+		  AND event_index = $3
+		  AND mint IS NOT DISTINCT FROM $4
+		  AND pool IS NOT DISTINCT FROM $5
+	`
+
+	var pool, mint interface{}
+	if e.Pool != "" {
+		pool = e.Pool
+	}
+	if e.Mint != "" {
+		mint = e.Mint
+	}
+
+	tag, err := s.pool.Exec(ctx, query, candidateID, e.TxSignature, e.EventIndex, mint, pool)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("update liquidity event candidate id: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// NonCanonicalEventTypes is a one-off audit of stored rows: it counts the rows
+// of each event_type other than domain.LiquidityEventAdd and
+// domain.LiquidityEventRemove. chk_event_type (migration 003) keeps the result
+// empty; rows found here were written while that constraint was missing and are
+// invisible to queries filtering on the canonical types.
+func (s *LiquidityEventStore) NonCanonicalEventTypes(ctx context.Context) (map[string]int64, error) {
+	query := `
+		SELECT event_type, COUNT(*)
+		FROM liquidity_events
+		WHERE event_type NOT IN ($1, $2)
+		GROUP BY event_type
+	`
+
+	rows, err := s.pool.Query(ctx, query, domain.LiquidityEventAdd, domain.LiquidityEventRemove)
+	if err != nil {
+		return nil, fmt.Errorf("audit liquidity event types: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var eventType string
+		var n int64
+		if err := rows.Scan(&eventType, &n); err != nil {
+			return nil, fmt.Errorf("scan liquidity event type count: %w", err)
+		}
+		counts[eventType] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate liquidity event type counts: %w", err)
+	}
+	return counts, nil
+}
+
 // scanLiquidityEvents scans multiple rows into a slice of LiquidityEvent.
 func scanLiquidityEvents(rows pgx.Rows) ([]*domain.LiquidityEvent, error) {
 	var events []*domain.LiquidityEvent
@@ -198,9 +276,12 @@ func scanLiquidityEvents(rows pgx.Rows) ([]*domain.LiquidityEvent, error) {
 			&e.AmountToken,
 			&e.AmountQuote,
 			&e.LiquidityAfter,
+			&e.Estimated,
 			&e.CreatedAt,
 			&pool,
 			&mint,
+			&e.DEX,
+			&e.Origin,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan liquidity event row: %w", err)
@@ -9,6 +9,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestLiquidityEventStore_InsertAndGetByCandidateID(t *testing.T) {
@@ -513,3 +514,98 @@ func TestLiquidityEventStore_EmptyPoolAndMint(t *testing.T) {
 	assert.Empty(t, result[0].Pool)
 	assert.Empty(t, result[0].Mint)
 }
+
+func TestLiquidityEventStore_UpdateCandidateID(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateID := createTestCandidate(t, ctx, pool, "liq-assoc-candidate")
+
+	store := NewLiquidityEventStore(pool)
+
+	orphan := &domain.LiquidityEvent{
+		Pool:           "AssocPool",
+		Mint:           "AssocMint",
+		TxSignature:    "AssocTx",
+		EventIndex:     0,
+		Slot:           101,
+		Timestamp:      1000,
+		EventType:      domain.LiquidityEventAdd,
+		AmountToken:    1000.0,
+		AmountQuote:    10.0,
+		LiquidityAfter: 1010.0,
+	}
+	require.NoError(t, store.Insert(ctx, orphan))
+
+	orphans, err := store.GetUnassociated(ctx)
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Empty(t, orphans[0].CandidateID)
+
+	require.NoError(t, store.UpdateCandidateID(ctx, orphans[0], candidateID))
+
+	// Second update finds no unassociated row
+	err = store.UpdateCandidateID(ctx, orphans[0], candidateID)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	orphans, err = store.GetUnassociated(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	result, err := store.GetByCandidateID(ctx, candidateID)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "AssocTx", result[0].TxSignature)
+	assert.Equal(t, 1010.0, result[0].LiquidityAfter)
+}
+
+func TestLiquidityEventStore_DuplicateContract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.LiquidityEventStoreDuplicates(t, NewLiquidityEventStore(pool))
+}
+
+func TestLiquidityEventStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunLiquidityEventStoreSuite(t, func(t *testing.T, candidateIDs ...string) storage.LiquidityEventStore {
+		truncateTables(t, pool, "liquidity_events", "token_candidates")
+		for _, id := range candidateIDs {
+			createTestCandidate(t, context.Background(), pool, id)
+		}
+		return NewLiquidityEventStore(pool)
+	})
+}
+
+func TestLiquidityEventStore_NonCanonicalEventTypes(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewLiquidityEventStore(pool)
+
+	require.NoError(t, store.Insert(ctx, &domain.LiquidityEvent{
+		Mint: "MintAudit", TxSignature: "AuditTx1", Slot: 1, Timestamp: 1000, EventType: "Deposit",
+	}))
+	counts, err := store.NonCanonicalEventTypes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+
+	// Rows written without the check constraint are reported per type
+	_, err = pool.Exec(ctx, `ALTER TABLE liquidity_events DROP CONSTRAINT chk_event_type`)
+	require.NoError(t, err)
+	for i, eventType := range []string{"Deposit", "Deposit", "withdraw"} {
+		_, err = pool.Exec(ctx, `
+			INSERT INTO liquidity_events (tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, mint)
+			VALUES ('AuditTx2', $1, 2, 2000, $2, 0, 0, 0, 'MintAudit')
+		`, i, eventType)
+		require.NoError(t, err)
+	}
+
+	counts, err = store.NonCanonicalEventTypes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"Deposit": 2, "withdraw": 1}, counts)
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// PoolLinkStore is a PostgreSQL implementation of storage.PoolLinkStore
+// over the pool_links table (see migration 035).
+type PoolLinkStore struct {
+	pool *Pool
+}
+
+// NewPoolLinkStore creates a new PoolLinkStore.
+func NewPoolLinkStore(pool *Pool) *PoolLinkStore {
+	return &PoolLinkStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.PoolLinkStore = (*PoolLinkStore)(nil)
+
+const poolLinkColumns = `pool, mint, candidate_id, dex, reason, tx_signature, valid_from, valid_to, created_at`
+
+// Insert adds a link. Returns ErrDuplicateKey if the pool is already linked.
+func (s *PoolLinkStore) Insert(ctx context.Context, link *domain.PoolLink) error {
+	if link == nil || link.Pool == "" || link.Mint == "" || link.CandidateID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	query := `INSERT INTO pool_links (` + poolLinkColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := s.pool.Exec(ctx, query, link.Pool, link.Mint, link.CandidateID, link.DEX, link.Reason,
+		link.TxSignature, link.ValidFrom, link.ValidTo, link.CreatedAt)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("insert pool link: %w", err)
+	}
+	return nil
+}
+
+// Close ends the open link of a pool at validTo. Returns ErrNotFound if the
+// pool has no open link.
+func (s *PoolLinkStore) Close(ctx context.Context, pool string, validTo int64) error {
+	query := `UPDATE pool_links SET valid_to = $2 WHERE pool = $1 AND valid_to IS NULL`
+	tag, err := s.pool.Exec(ctx, query, pool, validTo)
+	if err != nil {
+		return fmt.Errorf("close pool link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// GetByPool retrieves the link of a pool. Returns ErrNotFound if not exists.
+func (s *PoolLinkStore) GetByPool(ctx context.Context, pool string) (*domain.PoolLink, error) {
+	query := `SELECT ` + poolLinkColumns + ` FROM pool_links WHERE pool = $1`
+
+	link, err := scanPoolLink(s.pool.QueryRow(ctx, query, pool))
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get pool link: %w", err)
+	}
+	return link, nil
+}
+
+// GetByMint retrieves the links of a mint, ordered by valid_from ASC, pool ASC.
+func (s *PoolLinkStore) GetByMint(ctx context.Context, mint string) ([]*domain.PoolLink, error) {
+	query := `SELECT ` + poolLinkColumns + ` FROM pool_links WHERE mint = $1 ORDER BY valid_from ASC, pool ASC`
+	return s.query(ctx, query, mint)
+}
+
+// GetAll retrieves every link, ordered by mint ASC, valid_from ASC, pool ASC.
+func (s *PoolLinkStore) GetAll(ctx context.Context) ([]*domain.PoolLink, error) {
+	query := `SELECT ` + poolLinkColumns + ` FROM pool_links ORDER BY mint ASC, valid_from ASC, pool ASC`
+	return s.query(ctx, query)
+}
+
+func (s *PoolLinkStore) query(ctx context.Context, query string, args ...any) ([]*domain.PoolLink, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pool links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*domain.PoolLink
+	for rows.Next() {
+		link, err := scanPoolLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan pool link row: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pool link rows: %w", err)
+	}
+
+	return links, nil
+}
+
+// scanPoolLink scans a single row into a PoolLink.
+func scanPoolLink(row pgx.Row) (*domain.PoolLink, error) {
+	var link domain.PoolLink
+	err := row.Scan(
+		&link.Pool,
+		&link.Mint,
+		&link.CandidateID,
+		&link.DEX,
+		&link.Reason,
+		&link.TxSignature,
+		&link.ValidFrom,
+		&link.ValidTo,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestPoolLinkStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunPoolLinkStoreSuite(t, func(t *testing.T) storage.PoolLinkStore {
+		truncateTables(t, pool, "pool_links")
+		return NewPoolLinkStore(pool)
+	})
+}
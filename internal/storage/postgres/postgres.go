@@ -41,6 +41,15 @@ func (p *Pool) Close() {
 	p.Pool.Close()
 }
 
+// ServerVersion returns the PostgreSQL server version, e.g. "16.2".
+func (p *Pool) ServerVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := p.QueryRow(ctx, "SHOW server_version").Scan(&version); err != nil {
+		return "", fmt.Errorf("query postgres server version: %w", err)
+	}
+	return version, nil
+}
+
 // PostgreSQL error codes
 const (
 	pgErrUniqueViolation = "23505" // unique_violation
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// ProvisionalMintStore is a PostgreSQL implementation of storage.ProvisionalMintStore
+// over the provisional_mints table (see migration 029).
+type ProvisionalMintStore struct {
+	pool *Pool
+}
+
+// NewProvisionalMintStore creates a new ProvisionalMintStore.
+func NewProvisionalMintStore(pool *Pool) *ProvisionalMintStore {
+	return &ProvisionalMintStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.ProvisionalMintStore = (*ProvisionalMintStore)(nil)
+
+const provisionalMintColumns = `mint, tx_signature, slot, created_at, detected_at, status, candidate_id, resolved_at`
+
+// Insert adds a PENDING mint. Returns ErrDuplicateKey if the mint exists.
+func (s *ProvisionalMintStore) Insert(ctx context.Context, m *domain.ProvisionalMint) error {
+	if m == nil || m.Mint == "" {
+		return storage.ErrInvalidInput
+	}
+
+	query := `
+		INSERT INTO provisional_mints (mint, tx_signature, slot, created_at, detected_at, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := s.pool.Exec(ctx, query, m.Mint, m.TxSignature, m.Slot, m.CreatedAt, m.DetectedAt, string(domain.ProvisionalMintPending))
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("insert provisional mint: %w", err)
+	}
+	return nil
+}
+
+// GetByMint retrieves a mint. Returns ErrNotFound if not exists.
+func (s *ProvisionalMintStore) GetByMint(ctx context.Context, mint string) (*domain.ProvisionalMint, error) {
+	query := `SELECT ` + provisionalMintColumns + ` FROM provisional_mints WHERE mint = $1`
+
+	m, err := scanProvisionalMint(s.pool.QueryRow(ctx, query, mint))
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get provisional mint: %w", err)
+	}
+	return m, nil
+}
+
+// Upgrade links a PENDING mint to its candidate and marks it UPGRADED.
+// Returns ErrNotFound if the mint has no PENDING record.
+func (s *ProvisionalMintStore) Upgrade(ctx context.Context, mint, candidateID string, discoveredAt int64) error {
+	query := `
+		UPDATE provisional_mints
+		SET status = $2, candidate_id = $3, resolved_at = $4
+		WHERE mint = $1 AND status = $5
+	`
+	tag, err := s.pool.Exec(ctx, query, mint, string(domain.ProvisionalMintUpgraded), candidateID, discoveredAt,
+		string(domain.ProvisionalMintPending))
+	if err != nil {
+		return fmt.Errorf("upgrade provisional mint: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// ExpirePending marks PENDING mints created before createdBefore EXPIRED.
+func (s *ProvisionalMintStore) ExpirePending(ctx context.Context, createdBefore, expiredAt int64) (int, error) {
+	query := `
+		UPDATE provisional_mints
+		SET status = $1, resolved_at = $2
+		WHERE status = $3 AND created_at < $4
+	`
+	tag, err := s.pool.Exec(ctx, query, string(domain.ProvisionalMintExpired), expiredAt,
+		string(domain.ProvisionalMintPending), createdBefore)
+	if err != nil {
+		return 0, fmt.Errorf("expire provisional mints: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// GetByStatus retrieves mints of a status, ordered by created_at ASC, mint ASC.
+func (s *ProvisionalMintStore) GetByStatus(ctx context.Context, status domain.ProvisionalMintStatus) ([]*domain.ProvisionalMint, error) {
+	query := `SELECT ` + provisionalMintColumns + ` FROM provisional_mints WHERE status = $1 ORDER BY created_at ASC, mint ASC`
+
+	rows, err := s.pool.Query(ctx, query, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("query provisional mints: %w", err)
+	}
+	defer rows.Close()
+
+	var mints []*domain.ProvisionalMint
+	for rows.Next() {
+		m, err := scanProvisionalMint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan provisional mint row: %w", err)
+		}
+		mints = append(mints, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate provisional mint rows: %w", err)
+	}
+
+	return mints, nil
+}
+
+// scanProvisionalMint scans a single row into a ProvisionalMint.
+func scanProvisionalMint(row pgx.Row) (*domain.ProvisionalMint, error) {
+	var m domain.ProvisionalMint
+	var statusStr string
+
+	err := row.Scan(
+		&m.Mint,
+		&m.TxSignature,
+		&m.Slot,
+		&m.CreatedAt,
+		&m.DetectedAt,
+		&statusStr,
+		&m.CandidateID,
+		&m.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Status = domain.ProvisionalMintStatus(statusStr)
+	return &m, nil
+}
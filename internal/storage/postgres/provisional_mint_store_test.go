@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestProvisionalMintStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunProvisionalMintStoreSuite(t, func(t *testing.T) storage.ProvisionalMintStore {
+		truncateTables(t, pool, "provisional_mints")
+		return NewProvisionalMintStore(pool)
+	})
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RawTxStore is a PostgreSQL implementation of storage.RawTxStore over the
+// raw_transactions table (see migration 026).
+type RawTxStore struct {
+	pool     *Pool
+	maxBytes int64
+}
+
+// NewRawTxStore creates an archive holding at most maxBytes of transaction data
+// (storage.DefaultRawTxMaxBytes if maxBytes <= 0).
+func NewRawTxStore(pool *Pool, maxBytes int64) *RawTxStore {
+	if maxBytes <= 0 {
+		maxBytes = storage.DefaultRawTxMaxBytes
+	}
+	return &RawTxStore{pool: pool, maxBytes: maxBytes}
+}
+
+// Put upserts a transaction and evicts the oldest ones beyond the size cap in one transaction.
+func (s *RawTxStore) Put(ctx context.Context, raw *storage.RawTransaction) error {
+	if raw == nil || raw.TxSignature == "" {
+		return storage.ErrInvalidInput
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO raw_transactions (tx_signature, slot, block_time, data, size_bytes, archived_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tx_signature) DO UPDATE SET
+			slot = EXCLUDED.slot,
+			block_time = EXCLUDED.block_time,
+			data = EXCLUDED.data,
+			size_bytes = EXCLUDED.size_bytes,
+			archived_at = EXCLUDED.archived_at
+	`, raw.TxSignature, raw.Slot, raw.BlockTime, raw.Data, int64(len(raw.Data)), raw.ArchivedAt)
+	if err != nil {
+		return fmt.Errorf("archive raw tx %s: %w", raw.TxSignature, err)
+	}
+
+	// Keep the newest transactions whose running size fits the cap.
+	_, err = tx.Exec(ctx, `
+		DELETE FROM raw_transactions
+		WHERE tx_signature IN (
+			SELECT tx_signature FROM (
+				SELECT tx_signature,
+				       SUM(size_bytes) OVER (ORDER BY slot DESC, tx_signature DESC) AS running
+				FROM raw_transactions
+			) r
+			WHERE r.running > $1
+		)
+	`, s.maxBytes)
+	if err != nil {
+		return fmt.Errorf("evict raw txs: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// Get returns an archived transaction.
+func (s *RawTxStore) Get(ctx context.Context, signature string) (*storage.RawTransaction, error) {
+	var raw storage.RawTransaction
+	err := s.pool.QueryRow(ctx, `
+		SELECT tx_signature, slot, block_time, data, archived_at
+		FROM raw_transactions
+		WHERE tx_signature = $1
+	`, signature).Scan(&raw.TxSignature, &raw.Slot, &raw.BlockTime, &raw.Data, &raw.ArchivedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get raw tx: %w", err)
+	}
+	return &raw, nil
+}
+
+// GetPage returns up to limit transactions after (afterSlot, afterSignature), ordered by (slot, tx_signature).
+func (s *RawTxStore) GetPage(ctx context.Context, afterSlot int64, afterSignature string, limit int) ([]*storage.RawTransaction, error) {
+	query := `
+		SELECT tx_signature, slot, block_time, data, archived_at
+		FROM raw_transactions
+		WHERE (slot, tx_signature) > ($1, $2)
+		ORDER BY slot, tx_signature
+	`
+	args := []interface{}{afterSlot, afterSignature}
+	if limit > 0 {
+		query += ` LIMIT $3`
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get raw tx page: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*storage.RawTransaction
+	for rows.Next() {
+		var raw storage.RawTransaction
+		if err := rows.Scan(&raw.TxSignature, &raw.Slot, &raw.BlockTime, &raw.Data, &raw.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("scan raw tx row: %w", err)
+		}
+		result = append(result, &raw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate raw tx rows: %w", err)
+	}
+	return result, nil
+}
+
+// Stats returns the number of archived transactions and their total data size.
+func (s *RawTxStore) Stats(ctx context.Context) (int, int64, error) {
+	var count int
+	var bytes int64
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(size_bytes), 0) FROM raw_transactions
+	`).Scan(&count, &bytes)
+	if err != nil {
+		return 0, 0, fmt.Errorf("raw tx stats: %w", err)
+	}
+	return count, bytes, nil
+}
+
+var _ storage.RawTxStore = (*RawTxStore)(nil)
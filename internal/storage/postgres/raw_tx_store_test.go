@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestRawTxStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunRawTxStoreSuite(t, func(t *testing.T, maxBytes int64) storage.RawTxStore {
+		truncateTables(t, pool, "raw_transactions")
+		return NewRawTxStore(pool, maxBytes)
+	})
+}
+
+func TestReparseStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunReparseStoreSuite(t, func(t *testing.T, candidateIDs ...string) storagetest.ReparseStores {
+		truncateTables(t, pool, "reparse_corrections", "swap_events", "liquidity_events", "token_candidates")
+		for _, id := range candidateIDs {
+			createTestCandidate(t, context.Background(), pool, id)
+		}
+		return storagetest.ReparseStores{
+			Reparse:    NewReparseStore(pool),
+			SwapEvents: NewSwapEventStore(pool),
+			Liquidity:  NewLiquidityEventStore(pool),
+		}
+	})
+}
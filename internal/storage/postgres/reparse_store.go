@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// ReparseStore is a PostgreSQL implementation of storage.ReparseStore.
+// Corrections are audited in reparse_corrections (see migration 026).
+type ReparseStore struct {
+	pool *Pool
+}
+
+// NewReparseStore creates a new PostgreSQL reparse store.
+func NewReparseStore(pool *Pool) *ReparseStore {
+	return &ReparseStore{pool: pool}
+}
+
+// GetTxEvents returns the stored events of a transaction, ordered by event_index.
+func (s *ReparseStore) GetTxEvents(ctx context.Context, signature string) ([]*domain.SwapEvent, []*domain.LiquidityEvent, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT mint, pool, tx_signature, event_index, slot, timestamp, amount_out,
+			COALESCE(side, ''), fee_lamports, priority_fee_lamports, COALESCE(dex, ''), COALESCE(trader, ''), origin
+		FROM swap_events
+		WHERE tx_signature = $1
+		ORDER BY event_index, mint
+	`, signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get swap events of tx: %w", err)
+	}
+	swaps, err := scanSwapEvents(rows)
+	rows.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = s.pool.Query(ctx, `
+		SELECT id, COALESCE(candidate_id, ''), tx_signature, event_index, slot, timestamp, event_type, amount_token, amount_quote, liquidity_after, estimated, created_at, pool, mint, COALESCE(dex, ''), origin
+		FROM liquidity_events
+		WHERE tx_signature = $1
+		ORDER BY event_index, COALESCE(mint, pool)
+	`, signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get liquidity events of tx: %w", err)
+	}
+	defer rows.Close()
+	liquidity, err := scanLiquidityEvents(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+	return swaps, liquidity, nil
+}
+
+// Apply records the corrections, then deletes the replaced events and inserts the new
+// ones in one transaction. The delete triggers only accept events with a CHANGED
+// correction row, so replacements cannot skip the audit.
+func (s *ReparseStore) Apply(ctx context.Context, fix *storage.ReparseFix) error {
+	if fix == nil || fix.TxSignature == "" {
+		return storage.ErrInvalidInput
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, c := range fix.Corrections {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO reparse_corrections
+				(event_table, tx_signature, event_index, change, before_json, after_json, corrected_at)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7)
+		`, c.EventTable, c.TxSignature, c.EventIndex, c.Change, c.Before, c.After, c.CorrectedAt)
+		if err != nil {
+			return fmt.Errorf("record reparse correction: %w", err)
+		}
+	}
+
+	for _, e := range fix.ReplaceSwapEvents {
+		tag, err := tx.Exec(ctx, `
+			DELETE FROM swap_events WHERE mint = $1 AND tx_signature = $2 AND event_index = $3
+		`, e.Mint, e.TxSignature, e.EventIndex)
+		if err != nil {
+			return fmt.Errorf("delete replaced swap event: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+	}
+	for _, e := range fix.ReplaceLiquidity {
+		tag, err := tx.Exec(ctx, `
+			DELETE FROM liquidity_events
+			WHERE tx_signature = $1 AND event_index = $2 AND COALESCE(mint, pool) = $3
+		`, e.TxSignature, e.EventIndex, liquidityToken(e))
+		if err != nil {
+			return fmt.Errorf("delete replaced liquidity event: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+	}
+
+	for _, e := range fix.InsertSwapEvents {
+		if _, err := tx.Exec(ctx, insertSwapEventQuery, swapEventArgs(e)...); err != nil {
+			if isDuplicateKeyError(err) {
+				return storage.ErrDuplicateKey
+			}
+			return fmt.Errorf("insert reparsed swap event: %w", err)
+		}
+	}
+	for _, e := range fix.InsertLiquidity {
+		args, err := liquidityEventArgs(e)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, insertLiquidityEventQuery, args...); err != nil {
+			if isDuplicateKeyError(err) {
+				return storage.ErrDuplicateKey
+			}
+			return fmt.Errorf("insert reparsed liquidity event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// liquidityToken returns the token part of the liquidity_events unique key, COALESCE(mint, pool).
+func liquidityToken(e *domain.LiquidityEvent) string {
+	if e.Mint != "" {
+		return e.Mint
+	}
+	return e.Pool
+}
+
+// GetCorrections returns corrections with corrected_at in [start, end).
+func (s *ReparseStore) GetCorrections(ctx context.Context, start, end int64) ([]*storage.ReparseCorrection, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT event_table, tx_signature, event_index, change,
+		       COALESCE(before_json, ''), COALESCE(after_json, ''), corrected_at
+		FROM reparse_corrections
+		WHERE corrected_at >= $1 AND corrected_at < $2
+		ORDER BY corrected_at, event_table, tx_signature, event_index, id
+	`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("get reparse corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*storage.ReparseCorrection
+	for rows.Next() {
+		var c storage.ReparseCorrection
+		if err := rows.Scan(&c.EventTable, &c.TxSignature, &c.EventIndex, &c.Change,
+			&c.Before, &c.After, &c.CorrectedAt); err != nil {
+			return nil, fmt.Errorf("scan reparse correction row: %w", err)
+		}
+		result = append(result, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reparse correction rows: %w", err)
+	}
+	return result, nil
+}
+
+var _ storage.ReparseStore = (*ReparseStore)(nil)
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RunManifestStore is a PostgreSQL implementation of storage.RunManifestStore
+// over the run_manifests table (see migration 036).
+type RunManifestStore struct {
+	pool *Pool
+}
+
+// NewRunManifestStore creates a new RunManifestStore.
+func NewRunManifestStore(pool *Pool) *RunManifestStore {
+	return &RunManifestStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.RunManifestStore = (*RunManifestStore)(nil)
+
+const runManifestColumns = `run_id, kind, started_at, finished_at, git_commit, config, backends,
+	candidates, trades, aggregates, data_version, decision, errors, error_count`
+
+// Insert records a manifest.
+func (s *RunManifestStore) Insert(ctx context.Context, m *storage.RunManifest) error {
+	if m == nil || m.RunID == "" {
+		return storage.ErrInvalidInput
+	}
+
+	config := m.Config
+	if config == nil {
+		config = map[string]string{}
+	}
+	backends := m.Backends
+	if backends == nil {
+		backends = []storage.RunBackend{}
+	}
+	errs := m.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO run_manifests (`+runManifestColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, m.RunID, m.Kind, m.StartedAt, m.FinishedAt, m.GitCommit, config, backends,
+		m.Candidates, m.Trades, m.Aggregates, m.DataVersion, m.Decision, errs, m.ErrorCount)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("insert run manifest: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns the manifest of a run.
+func (s *RunManifestStore) GetByID(ctx context.Context, runID string) (*storage.RunManifest, error) {
+	m, err := scanRunManifest(s.pool.QueryRow(ctx, `
+		SELECT `+runManifestColumns+`
+		FROM run_manifests
+		WHERE run_id = $1
+	`, runID))
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get run manifest: %w", err)
+	}
+	return m, nil
+}
+
+// GetLatest returns the manifest of kind with the highest started_at, the last
+// inserted among equal started_at.
+func (s *RunManifestStore) GetLatest(ctx context.Context, kind string) (*storage.RunManifest, error) {
+	list, err := s.List(ctx, kind, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return list[0], nil
+}
+
+// List returns up to limit manifests of kind, newest first.
+func (s *RunManifestStore) List(ctx context.Context, kind string, limit int) ([]*storage.RunManifest, error) {
+	query := `
+		SELECT ` + runManifestColumns + `
+		FROM run_manifests
+		WHERE $1 = '' OR kind = $1
+		ORDER BY started_at DESC, id DESC`
+	args := []any{kind}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list run manifests: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*storage.RunManifest
+	for rows.Next() {
+		m, err := scanRunManifest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan run manifest: %w", err)
+		}
+		result = append(result, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate run manifests: %w", err)
+	}
+	return result, nil
+}
+
+func scanRunManifest(row pgx.Row) (*storage.RunManifest, error) {
+	var m storage.RunManifest
+	err := row.Scan(&m.RunID, &m.Kind, &m.StartedAt, &m.FinishedAt, &m.GitCommit, &m.Config, &m.Backends,
+		&m.Candidates, &m.Trades, &m.Aggregates, &m.DataVersion, &m.Decision, &m.Errors, &m.ErrorCount)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
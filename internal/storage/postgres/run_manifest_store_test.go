@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestRunManifestStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunRunManifestStoreSuite(t, func(t *testing.T) storage.RunManifestStore {
+		truncateTables(t, pool, "run_manifests")
+		return NewRunManifestStore(pool)
+	})
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/storage"
+)
+
+// SlotCheckpointStore is a PostgreSQL implementation of storage.SlotCheckpointStore.
+type SlotCheckpointStore struct {
+	pool *Pool
+}
+
+// NewSlotCheckpointStore creates a new PostgreSQL slot checkpoint store.
+func NewSlotCheckpointStore(pool *Pool) *SlotCheckpointStore {
+	return &SlotCheckpointStore{pool: pool}
+}
+
+// MarkComplete records processed slots in one transaction, overwriting existing checkpoints.
+func (s *SlotCheckpointStore) MarkComplete(ctx context.Context, checkpoints []*storage.SlotCheckpoint) error {
+	if len(checkpoints) == 0 {
+		return nil
+	}
+	for _, c := range checkpoints {
+		if c == nil {
+			return storage.ErrInvalidInput
+		}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO slot_checkpoints (slot, skipped, transactions, completed_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (slot) DO UPDATE
+		SET skipped = EXCLUDED.skipped,
+		    transactions = EXCLUDED.transactions,
+		    completed_at = NOW()
+	`
+	for _, c := range checkpoints {
+		if _, err := tx.Exec(ctx, query, c.Slot, c.Skipped, c.Transactions); err != nil {
+			return fmt.Errorf("mark slot %d complete: %w", c.Slot, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// GetCompleted returns checkpoints within [from, to], ordered by slot.
+func (s *SlotCheckpointStore) GetCompleted(ctx context.Context, from, to int64) ([]*storage.SlotCheckpoint, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT slot, skipped, transactions
+		FROM slot_checkpoints
+		WHERE slot >= $1 AND slot <= $2
+		ORDER BY slot
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*storage.SlotCheckpoint
+	for rows.Next() {
+		var c storage.SlotCheckpoint
+		if err := rows.Scan(&c.Slot, &c.Skipped, &c.Transactions); err != nil {
+			return nil, err
+		}
+		result = append(result, &c)
+	}
+	return result, rows.Err()
+}
+
+// GetGaps returns the maximal ranges within [from, to] without a checkpoint.
+func (s *SlotCheckpointStore) GetGaps(ctx context.Context, from, to int64) ([]storage.SlotRange, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT slot
+		FROM slot_checkpoints
+		WHERE slot >= $1 AND slot <= $2
+		ORDER BY slot
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []int64
+	for rows.Next() {
+		var slot int64
+		if err := rows.Scan(&slot); err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return storage.SlotGaps(slots, from, to), nil
+}
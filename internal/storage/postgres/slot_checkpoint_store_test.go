@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/storage"
+)
+
+func TestSlotCheckpointStore_CompletedAndGaps(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewSlotCheckpointStore(pool)
+
+	err := store.MarkComplete(ctx, []*storage.SlotCheckpoint{
+		{Slot: 100, Transactions: 1},
+		{Slot: 101, Skipped: true},
+		{Slot: 103, Transactions: 2},
+	})
+	require.NoError(t, err)
+
+	// Re-marking overwrites
+	require.NoError(t, store.MarkComplete(ctx, []*storage.SlotCheckpoint{{Slot: 103, Transactions: 5}}))
+
+	completed, err := store.GetCompleted(ctx, 101, 110)
+	require.NoError(t, err)
+	assert.Equal(t, []*storage.SlotCheckpoint{
+		{Slot: 101, Skipped: true},
+		{Slot: 103, Transactions: 5},
+	}, completed)
+
+	gaps, err := store.GetGaps(ctx, 99, 105)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.SlotRange{
+		{From: 99, To: 99},
+		{From: 102, To: 102},
+		{From: 104, To: 105},
+	}, gaps)
+}
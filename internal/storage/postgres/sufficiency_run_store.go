@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/storage"
+)
+
+// SufficiencyRunStore is a PostgreSQL implementation of storage.SufficiencyRunStore
+// over the sufficiency_runs table (see migration 027).
+type SufficiencyRunStore struct {
+	pool *Pool
+}
+
+// NewSufficiencyRunStore creates a new SufficiencyRunStore.
+func NewSufficiencyRunStore(pool *Pool) *SufficiencyRunStore {
+	return &SufficiencyRunStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.SufficiencyRunStore = (*SufficiencyRunStore)(nil)
+
+// Insert records a run.
+func (s *SufficiencyRunStore) Insert(ctx context.Context, run *storage.SufficiencyRun) error {
+	if run == nil {
+		return storage.ErrInvalidInput
+	}
+
+	checks := run.Checks
+	if checks == nil {
+		checks = []storage.SufficiencyCheckRecord{}
+	}
+	errs := run.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO sufficiency_runs (run_at, data_version, all_pass, checks, errors, error_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, run.RunAt, run.DataVersion, run.AllPass, checks, errs, run.ErrorCount)
+	if err != nil {
+		return fmt.Errorf("insert sufficiency run: %w", err)
+	}
+	return nil
+}
+
+// GetLatest returns the run with the highest run_at, the last inserted among equal run_at.
+func (s *SufficiencyRunStore) GetLatest(ctx context.Context) (*storage.SufficiencyRun, error) {
+	var run storage.SufficiencyRun
+	err := s.pool.QueryRow(ctx, `
+		SELECT run_at, data_version, all_pass, checks, errors, error_count
+		FROM sufficiency_runs
+		ORDER BY run_at DESC, id DESC
+		LIMIT 1
+	`).Scan(&run.RunAt, &run.DataVersion, &run.AllPass, &run.Checks, &run.Errors, &run.ErrorCount)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get latest sufficiency run: %w", err)
+	}
+	return &run, nil
+}
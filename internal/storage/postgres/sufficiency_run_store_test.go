@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
+)
+
+func TestSufficiencyRunStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunSufficiencyRunStoreSuite(t, func(t *testing.T) storage.SufficiencyRunStore {
+		truncateTables(t, pool, "sufficiency_runs")
+		return NewSufficiencyRunStore(pool)
+	})
+}
@@ -23,15 +23,16 @@ func NewSwapEventStore(pool *Pool) *SwapEventStore {
 // Compile-time interface check.
 var _ storage.SwapEventStore = (*SwapEventStore)(nil)
 
-// Insert adds a new swap event. Returns ErrDuplicateKey if (mint, tx_signature, event_index) exists.
-func (s *SwapEventStore) Insert(ctx context.Context, e *domain.SwapEvent) error {
-	query := `
-		INSERT INTO swap_events (
-			mint, pool, tx_signature, event_index, slot, timestamp, amount_out
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
-
-	_, err := s.pool.Exec(ctx, query,
+// insertSwapEventQuery inserts one swap event; its arguments come from swapEventArgs.
+const insertSwapEventQuery = `
+	INSERT INTO swap_events (
+		mint, pool, tx_signature, event_index, slot, timestamp, amount_out,
+		side, fee_lamports, priority_fee_lamports, dex, trader, origin
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, NULLIF($11, ''), NULLIF($12, ''), $13)
+`
+
+func swapEventArgs(e *domain.SwapEvent) []interface{} {
+	return []interface{}{
 		e.Mint,
 		e.Pool,
 		e.TxSignature,
@@ -39,7 +40,19 @@ func (s *SwapEventStore) Insert(ctx context.Context, e *domain.SwapEvent) error
 		e.Slot,
 		e.Timestamp,
 		e.AmountOut,
-	)
+		e.Side,
+		e.FeeLamports,
+		e.PriorityFeeLamports,
+		e.DEX,
+		e.Trader,
+		domain.NormalizeOrigin(e.Origin),
+	}
+}
+
+// Insert adds a new swap event. Returns ErrDuplicateKey if (mint, tx_signature, event_index) exists.
+func (s *SwapEventStore) Insert(ctx context.Context, e *domain.SwapEvent) error {
+
+	_, err := s.pool.Exec(ctx, insertSwapEventQuery, swapEventArgs(e)...)
 	if err != nil {
 		if isDuplicateKeyError(err) {
 			return storage.ErrDuplicateKey
@@ -61,22 +74,8 @@ func (s *SwapEventStore) InsertBulk(ctx context.Context, events []*domain.SwapEv
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-		INSERT INTO swap_events (
-			mint, pool, tx_signature, event_index, slot, timestamp, amount_out
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
-
 	for _, e := range events {
-		_, err := tx.Exec(ctx, query,
-			e.Mint,
-			e.Pool,
-			e.TxSignature,
-			e.EventIndex,
-			e.Slot,
-			e.Timestamp,
-			e.AmountOut,
-		)
+		_, err := tx.Exec(ctx, insertSwapEventQuery, swapEventArgs(e)...)
 		if err != nil {
 			if isDuplicateKeyError(err) {
 				return storage.ErrDuplicateKey
@@ -95,7 +94,8 @@ func (s *SwapEventStore) InsertBulk(ctx context.Context, events []*domain.SwapEv
 // GetByTimeRange retrieves swap events within [start, end) (inclusive start, exclusive end).
 func (s *SwapEventStore) GetByTimeRange(ctx context.Context, start, end int64) ([]*domain.SwapEvent, error) {
 	query := `
-		SELECT mint, pool, tx_signature, event_index, slot, timestamp, amount_out
+		SELECT mint, pool, tx_signature, event_index, slot, timestamp, amount_out,
+			COALESCE(side, ''), fee_lamports, priority_fee_lamports, COALESCE(dex, ''), COALESCE(trader, ''), origin
 		FROM swap_events
 		WHERE timestamp >= $1 AND timestamp < $2
 		ORDER BY timestamp ASC, mint ASC, tx_signature ASC, event_index ASC
@@ -113,7 +113,8 @@ func (s *SwapEventStore) GetByTimeRange(ctx context.Context, start, end int64) (
 // GetByMintTimeRange retrieves swap events for a mint within [start, end).
 func (s *SwapEventStore) GetByMintTimeRange(ctx context.Context, mint string, start, end int64) ([]*domain.SwapEvent, error) {
 	query := `
-		SELECT mint, pool, tx_signature, event_index, slot, timestamp, amount_out
+		SELECT mint, pool, tx_signature, event_index, slot, timestamp, amount_out,
+			COALESCE(side, ''), fee_lamports, priority_fee_lamports, COALESCE(dex, ''), COALESCE(trader, ''), origin
 		FROM swap_events
 		WHERE mint = $1 AND timestamp >= $2 AND timestamp < $3
 		ORDER BY timestamp ASC, tx_signature ASC, event_index ASC
@@ -159,6 +160,21 @@ func (s *SwapEventStore) GetDistinctMintsByTimeRange(ctx context.Context, start,
 	return mints, nil
 }
 
+// GetGlobalTimeRange returns min and max event timestamps across all swap events.
+// Both aggregates are answered from idx_swap_events_timestamp.
+func (s *SwapEventStore) GetGlobalTimeRange(ctx context.Context) (minTs, maxTs int64, err error) {
+	query := `
+		SELECT COALESCE(MIN(timestamp), 0), COALESCE(MAX(timestamp), 0)
+		FROM swap_events
+	`
+
+	if err := s.pool.QueryRow(ctx, query).Scan(&minTs, &maxTs); err != nil {
+		return 0, 0, fmt.Errorf("get swap events global time range: %w", err)
+	}
+
+	return minTs, maxTs, nil
+}
+
 // scanSwapEvents scans multiple rows into a slice of SwapEvent.
 func scanSwapEvents(rows pgx.Rows) ([]*domain.SwapEvent, error) {
 	var events []*domain.SwapEvent
@@ -174,6 +190,12 @@ func scanSwapEvents(rows pgx.Rows) ([]*domain.SwapEvent, error) {
 			&e.Slot,
 			&e.Timestamp,
 			&e.AmountOut,
+			&e.Side,
+			&e.FeeLamports,
+			&e.PriorityFeeLamports,
+			&e.DEX,
+			&e.Trader,
+			&e.Origin,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan swap event row: %w", err)
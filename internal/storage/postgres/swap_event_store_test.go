@@ -9,6 +9,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestSwapEventStore_InsertAndGetByTimeRange(t *testing.T) {
@@ -433,3 +434,73 @@ func TestSwapEventStore_EmptyResult(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, mints)
 }
+
+func TestSwapEventStore_MintAndDistinctBoundaries(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewSwapEventStore(pool)
+
+	events := []*domain.SwapEvent{
+		{Mint: "WinMintA", TxSignature: "WinTxStart", Slot: 100, Timestamp: 1000, AmountOut: 1.0},
+		{Mint: "WinMintA", TxSignature: "WinTxLast", Slot: 101, Timestamp: 1999, AmountOut: 1.0},
+		{Mint: "WinMintA", TxSignature: "WinTxEnd", Slot: 102, Timestamp: 2000, AmountOut: 1.0},
+		{Mint: "WinMintB", TxSignature: "WinTxOnlyEnd", Slot: 103, Timestamp: 2000, AmountOut: 1.0},
+	}
+	require.NoError(t, store.InsertBulk(ctx, events))
+
+	// [1000, 2000): timestamp=2000 excluded for both queries
+	result, err := store.GetByMintTimeRange(ctx, "WinMintA", 1000, 2000)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "WinTxStart", result[0].TxSignature)
+	assert.Equal(t, "WinTxLast", result[1].TxSignature)
+
+	mints, err := store.GetDistinctMintsByTimeRange(ctx, 1000, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"WinMintA"}, mints)
+}
+
+func TestSwapEventStore_GetGlobalTimeRange(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewSwapEventStore(pool)
+
+	// Empty table
+	minTs, maxTs, err := store.GetGlobalTimeRange(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), minTs)
+	assert.Equal(t, int64(0), maxTs)
+
+	events := []*domain.SwapEvent{
+		{Mint: "RangeMint1", TxSignature: "RangeTx1", Slot: 100, Timestamp: 5000, AmountOut: 1.0},
+		{Mint: "RangeMint2", TxSignature: "RangeTx2", Slot: 101, Timestamp: 1000, AmountOut: 1.0},
+		{Mint: "RangeMint1", TxSignature: "RangeTx3", Slot: 102, Timestamp: 3000, AmountOut: 1.0},
+	}
+	require.NoError(t, store.InsertBulk(ctx, events))
+
+	minTs, maxTs, err = store.GetGlobalTimeRange(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), minTs)
+	assert.Equal(t, int64(5000), maxTs)
+}
+
+func TestSwapEventStore_DuplicateContract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.SwapEventStoreDuplicates(t, NewSwapEventStore(pool))
+}
+
+func TestSwapEventStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunSwapEventStoreSuite(t, func(t *testing.T) storage.SwapEventStore {
+		truncateTables(t, pool, "swap_events")
+		return NewSwapEventStore(pool)
+	})
+}
@@ -27,8 +27,8 @@ var _ storage.SwapStore = (*SwapStore)(nil)
 func (s *SwapStore) Insert(ctx context.Context, swap *domain.Swap) error {
 	query := `
 		INSERT INTO swaps (
-			candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price, dex
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))
 	`
 
 	_, err := s.pool.Exec(ctx, query,
@@ -41,6 +41,7 @@ func (s *SwapStore) Insert(ctx context.Context, swap *domain.Swap) error {
 		swap.AmountIn,
 		swap.AmountOut,
 		swap.Price,
+		swap.DEX,
 	)
 	if err != nil {
 		if isDuplicateKeyError(err) {
@@ -65,8 +66,8 @@ func (s *SwapStore) InsertBulk(ctx context.Context, swaps []*domain.Swap) error
 
 	query := `
 		INSERT INTO swaps (
-			candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price, dex
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))
 	`
 
 	for _, swap := range swaps {
@@ -80,6 +81,7 @@ func (s *SwapStore) InsertBulk(ctx context.Context, swaps []*domain.Swap) error
 			swap.AmountIn,
 			swap.AmountOut,
 			swap.Price,
+			swap.DEX,
 		)
 		if err != nil {
 			if isDuplicateKeyError(err) {
@@ -99,7 +101,8 @@ func (s *SwapStore) InsertBulk(ctx context.Context, swaps []*domain.Swap) error
 // GetByCandidateID retrieves all swaps for a candidate, ordered by timestamp ASC.
 func (s *SwapStore) GetByCandidateID(ctx context.Context, candidateID string) ([]*domain.Swap, error) {
 	query := `
-		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price, created_at
+		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price, created_at,
+			COALESCE(dex, '')
 		FROM swaps
 		WHERE candidate_id = $1
 		ORDER BY timestamp ASC, id ASC
@@ -117,7 +120,8 @@ func (s *SwapStore) GetByCandidateID(ctx context.Context, candidateID string) ([
 // GetByTimeRange retrieves swaps for a candidate within [start, end] (inclusive).
 func (s *SwapStore) GetByTimeRange(ctx context.Context, candidateID string, start, end int64) ([]*domain.Swap, error) {
 	query := `
-		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price, created_at
+		SELECT id, candidate_id, tx_signature, event_index, slot, timestamp, side, amount_in, amount_out, price, created_at,
+			COALESCE(dex, '')
 		FROM swaps
 		WHERE candidate_id = $1 AND timestamp >= $2 AND timestamp <= $3
 		ORDER BY timestamp ASC, id ASC
@@ -151,6 +155,7 @@ func scanSwaps(rows pgx.Rows) ([]*domain.Swap, error) {
 			&swap.AmountOut,
 			&swap.Price,
 			&swap.CreatedAt,
+			&swap.DEX,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan swap row: %w", err)
@@ -50,6 +50,7 @@ func TestSwapStore_InsertAndGetByCandidateID(t *testing.T) {
 		AmountIn:    1.0,
 		AmountOut:   100.0,
 		Price:       0.01,
+		DEX:         domain.DEXRaydium,
 	}
 
 	// Insert
@@ -67,6 +68,7 @@ func TestSwapStore_InsertAndGetByCandidateID(t *testing.T) {
 	assert.Equal(t, swap.Slot, swaps[0].Slot)
 	assert.Equal(t, swap.Timestamp, swaps[0].Timestamp)
 	assert.Equal(t, swap.Side, swaps[0].Side)
+	assert.Equal(t, swap.DEX, swaps[0].DEX)
 	assert.InDelta(t, swap.AmountIn, swaps[0].AmountIn, 0.0001)
 	assert.InDelta(t, swap.AmountOut, swaps[0].AmountOut, 0.0001)
 	assert.InDelta(t, swap.Price, swaps[0].Price, 0.0001)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -114,6 +115,15 @@ func findProjectRoot(t *testing.T) string {
 	}
 }
 
+// truncateTables empties tables between contract subtests sharing one container.
+// TRUNCATE bypasses the append-only row triggers.
+func truncateTables(t *testing.T, pool *Pool, tables ...string) {
+	t.Helper()
+
+	_, err := pool.Exec(context.Background(), "TRUNCATE "+strings.Join(tables, ", ")+" CASCADE")
+	require.NoError(t, err, "failed to truncate tables")
+}
+
 // ptr is a helper to create pointers to values.
 func ptr[T any](v T) *T {
 	return &v
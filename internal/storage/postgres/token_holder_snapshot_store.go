@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TokenHolderSnapshotStore implements storage.TokenHolderSnapshotStore using PostgreSQL.
+type TokenHolderSnapshotStore struct {
+	pool *Pool
+}
+
+// NewTokenHolderSnapshotStore creates a new TokenHolderSnapshotStore.
+func NewTokenHolderSnapshotStore(pool *Pool) *TokenHolderSnapshotStore {
+	return &TokenHolderSnapshotStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.TokenHolderSnapshotStore = (*TokenHolderSnapshotStore)(nil)
+
+// Insert adds a snapshot. Returns ErrDuplicateKey if (candidate_id, snapshot_at) exists.
+func (s *TokenHolderSnapshotStore) Insert(ctx context.Context, snap *domain.TokenHolderSnapshot) error {
+	query := `
+		INSERT INTO token_holder_snapshots (
+			candidate_id, mint, snapshot_at, holder_count, top10_pct, total_supply, largest_holder
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	var largestHolder interface{}
+	if snap.LargestHolder != "" {
+		largestHolder = snap.LargestHolder
+	}
+
+	_, err := s.pool.Exec(ctx, query,
+		snap.CandidateID,
+		snap.Mint,
+		snap.SnapshotAt,
+		snap.HolderCount,
+		snap.Top10Pct,
+		snap.TotalSupply,
+		largestHolder,
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("insert token holder snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetLatest retrieves the most recent snapshot for a candidate. Returns ErrNotFound if none.
+func (s *TokenHolderSnapshotStore) GetLatest(ctx context.Context, candidateID string) (*domain.TokenHolderSnapshot, error) {
+	query := `
+		SELECT candidate_id, mint, snapshot_at, holder_count, top10_pct, total_supply, largest_holder
+		FROM token_holder_snapshots
+		WHERE candidate_id = $1
+		ORDER BY snapshot_at DESC
+		LIMIT 1
+	`
+
+	snap, err := scanTokenHolderSnapshot(s.pool.QueryRow(ctx, query, candidateID))
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get latest token holder snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// GetByCandidateID retrieves all snapshots for a candidate, ordered by snapshot_at ASC.
+func (s *TokenHolderSnapshotStore) GetByCandidateID(ctx context.Context, candidateID string) ([]*domain.TokenHolderSnapshot, error) {
+	query := `
+		SELECT candidate_id, mint, snapshot_at, holder_count, top10_pct, total_supply, largest_holder
+		FROM token_holder_snapshots
+		WHERE candidate_id = $1
+		ORDER BY snapshot_at ASC
+	`
+
+	rows, err := s.pool.Query(ctx, query, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("get token holder snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*domain.TokenHolderSnapshot
+	for rows.Next() {
+		snap, err := scanTokenHolderSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan token holder snapshot row: %w", err)
+		}
+		snapshots = append(snapshots, snap)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate token holder snapshot rows: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// scanTokenHolderSnapshot scans a single row into TokenHolderSnapshot.
+func scanTokenHolderSnapshot(row pgx.Row) (*domain.TokenHolderSnapshot, error) {
+	var snap domain.TokenHolderSnapshot
+	var largestHolder *string
+
+	err := row.Scan(
+		&snap.CandidateID,
+		&snap.Mint,
+		&snap.SnapshotAt,
+		&snap.HolderCount,
+		&snap.Top10Pct,
+		&snap.TotalSupply,
+		&largestHolder,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if largestHolder != nil {
+		snap.LargestHolder = *largestHolder
+	}
+
+	return &snap, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+func TestTokenHolderSnapshotStore_InsertAndGetLatest(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateID := createTestCandidate(t, ctx, pool, "holder-test-candidate-1")
+
+	store := NewTokenHolderSnapshotStore(pool)
+
+	older := &domain.TokenHolderSnapshot{
+		CandidateID:   candidateID,
+		Mint:          "HolderMint1",
+		SnapshotAt:    1700000000000,
+		HolderCount:   20,
+		Top10Pct:      82.5,
+		TotalSupply:   1000000,
+		LargestHolder: "Whale1",
+	}
+	newer := &domain.TokenHolderSnapshot{
+		CandidateID: candidateID,
+		Mint:        "HolderMint1",
+		SnapshotAt:  1700003600000,
+		HolderCount: 20,
+		Top10Pct:    61.25,
+		TotalSupply: 1000000,
+	}
+
+	require.NoError(t, store.Insert(ctx, older))
+	require.NoError(t, store.Insert(ctx, newer))
+
+	latest, err := store.GetLatest(ctx, candidateID)
+	require.NoError(t, err)
+	assert.Equal(t, newer.SnapshotAt, latest.SnapshotAt)
+	assert.InDelta(t, newer.Top10Pct, latest.Top10Pct, 0.0001)
+	assert.Empty(t, latest.LargestHolder)
+
+	all, err := store.GetByCandidateID(ctx, candidateID)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, older.SnapshotAt, all[0].SnapshotAt)
+	assert.Equal(t, "Whale1", all[0].LargestHolder)
+
+	// Duplicate (candidate_id, snapshot_at)
+	err = store.Insert(ctx, older)
+	assert.ErrorIs(t, err, storage.ErrDuplicateKey)
+}
+
+func TestTokenHolderSnapshotStore_GetLatestNotFound(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store := NewTokenHolderSnapshotStore(pool)
+
+	_, err := store.GetLatest(context.Background(), "nonexistent-candidate")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
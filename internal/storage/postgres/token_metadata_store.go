@@ -27,32 +27,109 @@ var _ storage.TokenMetadataStore = (*TokenMetadataStore)(nil)
 func (s *TokenMetadataStore) Insert(ctx context.Context, m *domain.TokenMetadata) error {
 	query := `
 		INSERT INTO token_metadata (
-			candidate_id, mint, name, symbol, decimals, supply, fetched_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			candidate_id, mint, name, symbol, uri, decimals, supply,
+			mint_authority, freeze_authority, first_fetched_at, fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := s.pool.Exec(ctx, query,
+	_, err := s.pool.Exec(ctx, query, tokenMetadataArgs(m)...)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("insert token metadata: %w", err)
+	}
+	return nil
+}
+
+// Upsert inserts metadata or refreshes the existing row for candidate_id.
+// first_fetched_at and decimals are never changed by a refresh.
+func (s *TokenMetadataStore) Upsert(ctx context.Context, m *domain.TokenMetadata) error {
+	query := `
+		INSERT INTO token_metadata (
+			candidate_id, mint, name, symbol, uri, decimals, supply,
+			mint_authority, freeze_authority, first_fetched_at, fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (candidate_id) DO UPDATE SET
+			name = COALESCE(EXCLUDED.name, token_metadata.name),
+			symbol = COALESCE(EXCLUDED.symbol, token_metadata.symbol),
+			uri = COALESCE(EXCLUDED.uri, token_metadata.uri),
+			supply = COALESCE(EXCLUDED.supply, token_metadata.supply),
+			mint_authority = CASE WHEN EXCLUDED.supply IS NULL
+				THEN token_metadata.mint_authority ELSE EXCLUDED.mint_authority END,
+			freeze_authority = CASE WHEN EXCLUDED.supply IS NULL
+				THEN token_metadata.freeze_authority ELSE EXCLUDED.freeze_authority END,
+			fetched_at = EXCLUDED.fetched_at
+	`
+
+	_, err := s.pool.Exec(ctx, query, tokenMetadataArgs(m)...)
+	if err != nil {
+		return fmt.Errorf("upsert token metadata: %w", err)
+	}
+	return nil
+}
+
+// GetStale returns metadata with fetched_at < olderThan, ordered by fetched_at ASC, candidate_id ASC.
+func (s *TokenMetadataStore) GetStale(ctx context.Context, olderThan int64) ([]*domain.TokenMetadata, error) {
+	query := `
+		SELECT ` + tokenMetadataColumns + `
+		FROM token_metadata
+		WHERE fetched_at < $1
+		ORDER BY fetched_at ASC, candidate_id ASC
+	`
+
+	rows, err := s.pool.Query(ctx, query, olderThan)
+	if err != nil {
+		return nil, fmt.Errorf("get stale token metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.TokenMetadata
+	for rows.Next() {
+		m, err := scanTokenMetadata(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan token metadata row: %w", err)
+		}
+		result = append(result, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate token metadata rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// tokenMetadataColumns is the column list read by scanTokenMetadata.
+const tokenMetadataColumns = `candidate_id, mint, name, symbol, uri, decimals, supply,
+			mint_authority, freeze_authority, first_fetched_at, fetched_at, created_at`
+
+// tokenMetadataArgs returns insert arguments in column order.
+// FirstFetchedAt defaults to FetchedAt for a first fetch.
+func tokenMetadataArgs(m *domain.TokenMetadata) []any {
+	firstFetchedAt := m.FirstFetchedAt
+	if firstFetchedAt == 0 {
+		firstFetchedAt = m.FetchedAt
+	}
+	return []any{
 		m.CandidateID,
 		m.Mint,
 		m.Name,
 		m.Symbol,
+		m.URI,
 		m.Decimals,
 		m.Supply,
+		m.MintAuthority,
+		m.FreezeAuthority,
+		firstFetchedAt,
 		m.FetchedAt,
-	)
-	if err != nil {
-		if isDuplicateKeyError(err) {
-			return storage.ErrDuplicateKey
-		}
-		return fmt.Errorf("insert token metadata: %w", err)
 	}
-	return nil
 }
 
 // GetByID retrieves metadata by candidate ID. Returns ErrNotFound if not exists.
 func (s *TokenMetadataStore) GetByID(ctx context.Context, candidateID string) (*domain.TokenMetadata, error) {
 	query := `
-		SELECT candidate_id, mint, name, symbol, decimals, supply, fetched_at, created_at
+		SELECT ` + tokenMetadataColumns + `
 		FROM token_metadata
 		WHERE candidate_id = $1
 	`
@@ -71,7 +148,7 @@ func (s *TokenMetadataStore) GetByID(ctx context.Context, candidateID string) (*
 // GetByMint retrieves metadata by mint address. Returns ErrNotFound if not exists.
 func (s *TokenMetadataStore) GetByMint(ctx context.Context, mint string) (*domain.TokenMetadata, error) {
 	query := `
-		SELECT candidate_id, mint, name, symbol, decimals, supply, fetched_at, created_at
+		SELECT ` + tokenMetadataColumns + `
 		FROM token_metadata
 		WHERE mint = $1
 		ORDER BY fetched_at DESC
@@ -89,6 +166,41 @@ func (s *TokenMetadataStore) GetByMint(ctx context.Context, mint string) (*domai
 	return m, nil
 }
 
+// GetByMints retrieves the latest metadata of each of mints that has any, ordered by mint ASC.
+func (s *TokenMetadataStore) GetByMints(ctx context.Context, mints []string) ([]*domain.TokenMetadata, error) {
+	if len(mints) == 0 {
+		return []*domain.TokenMetadata{}, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (mint) ` + tokenMetadataColumns + `
+		FROM token_metadata
+		WHERE mint = ANY($1)
+		ORDER BY mint ASC, fetched_at DESC
+	`
+
+	rows, err := s.pool.Query(ctx, query, mints)
+	if err != nil {
+		return nil, fmt.Errorf("get token metadata by mints: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]*domain.TokenMetadata, 0, len(mints))
+	for rows.Next() {
+		m, err := scanTokenMetadata(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan token metadata row: %w", err)
+		}
+		result = append(result, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate token metadata rows: %w", err)
+	}
+
+	return result, nil
+}
+
 // scanTokenMetadata scans a single row into TokenMetadata.
 func scanTokenMetadata(row pgx.Row) (*domain.TokenMetadata, error) {
 	var m domain.TokenMetadata
@@ -98,8 +210,12 @@ func scanTokenMetadata(row pgx.Row) (*domain.TokenMetadata, error) {
 		&m.Mint,
 		&m.Name,
 		&m.Symbol,
+		&m.URI,
 		&m.Decimals,
 		&m.Supply,
+		&m.MintAuthority,
+		&m.FreezeAuthority,
+		&m.FirstFetchedAt,
 		&m.FetchedAt,
 		&m.CreatedAt,
 	)
@@ -9,6 +9,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func TestTokenMetadataStore_InsertAndGetByID(t *testing.T) {
@@ -279,3 +280,156 @@ func TestTokenMetadataStore_LargeSupply(t *testing.T) {
 	assert.NotNil(t, retrieved.Supply)
 	assert.InDelta(t, largeSupply, *retrieved.Supply, 1.0)
 }
+
+func TestTokenMetadataStore_InsertThenUpsert(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateID := createTestCandidate(t, ctx, pool, "meta-upsert-candidate")
+	store := NewTokenMetadataStore(pool)
+
+	require.NoError(t, store.Insert(ctx, &domain.TokenMetadata{
+		CandidateID:   candidateID,
+		Mint:          "UpsertMint",
+		Name:          ptr("Old Name"),
+		URI:           ptr("https://example.com/old.json"),
+		Decimals:      6,
+		Supply:        ptr(1000.0),
+		MintAuthority: ptr("AuthorityA"),
+		FetchedAt:     1700000000000,
+	}))
+
+	// Refresh: supply grew, mint authority revoked, name not resolved
+	require.NoError(t, store.Upsert(ctx, &domain.TokenMetadata{
+		CandidateID:     candidateID,
+		Mint:            "UpsertMint",
+		Decimals:        6,
+		Supply:          ptr(2500.0),
+		FreezeAuthority: ptr("FreezeB"),
+		FetchedAt:       1700000600000,
+	}))
+
+	got, err := store.GetByID(ctx, candidateID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000000), got.FirstFetchedAt)
+	assert.Equal(t, int64(1700000600000), got.FetchedAt)
+	require.NotNil(t, got.Supply)
+	assert.InDelta(t, 2500.0, *got.Supply, 0.0001)
+	assert.Nil(t, got.MintAuthority)
+	require.NotNil(t, got.FreezeAuthority)
+	assert.Equal(t, "FreezeB", *got.FreezeAuthority)
+	require.NotNil(t, got.Name)
+	assert.Equal(t, "Old Name", *got.Name)
+	require.NotNil(t, got.URI)
+	assert.Equal(t, "https://example.com/old.json", *got.URI)
+
+	// Refresh without mint account data keeps supply and authorities
+	require.NoError(t, store.Upsert(ctx, &domain.TokenMetadata{
+		CandidateID: candidateID,
+		Mint:        "UpsertMint",
+		FetchedAt:   1700001200000,
+	}))
+
+	got, err = store.GetByID(ctx, candidateID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Supply)
+	assert.InDelta(t, 2500.0, *got.Supply, 0.0001)
+	require.NotNil(t, got.FreezeAuthority)
+	assert.Equal(t, 6, got.Decimals)
+	assert.Equal(t, int64(1700000000000), got.FirstFetchedAt)
+	assert.Equal(t, int64(1700001200000), got.FetchedAt)
+}
+
+func TestTokenMetadataStore_UpsertInserts(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateID := createTestCandidate(t, ctx, pool, "meta-upsert-new")
+	store := NewTokenMetadataStore(pool)
+
+	require.NoError(t, store.Upsert(ctx, &domain.TokenMetadata{
+		CandidateID: candidateID,
+		Mint:        "UpsertNewMint",
+		Decimals:    9,
+		FetchedAt:   1700000000000,
+	}))
+
+	got, err := store.GetByID(ctx, candidateID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000000), got.FirstFetchedAt)
+	assert.Equal(t, int64(1700000000000), got.FetchedAt)
+}
+
+func TestTokenMetadataStore_GetStale(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewTokenMetadataStore(pool)
+
+	fetched := map[string]int64{
+		"stale-c2": 1000,
+		"stale-c1": 1000,
+		"stale-c3": 3000,
+	}
+	for id, ts := range fetched {
+		candidateID := createTestCandidate(t, ctx, pool, id)
+		require.NoError(t, store.Insert(ctx, &domain.TokenMetadata{
+			CandidateID: candidateID,
+			Mint:        "Mint-" + id,
+			FetchedAt:   ts,
+		}))
+	}
+
+	// Strictly older than the cutoff, ordered by fetched_at then candidate_id
+	stale, err := store.GetStale(ctx, 3000)
+	require.NoError(t, err)
+	require.Len(t, stale, 2)
+	assert.Equal(t, "stale-c1", stale[0].CandidateID)
+	assert.Equal(t, "stale-c2", stale[1].CandidateID)
+
+	// A refresh moves the row out of the stale set
+	require.NoError(t, store.Upsert(ctx, &domain.TokenMetadata{CandidateID: "stale-c1", Mint: "Mint-stale-c1", FetchedAt: 4000}))
+	stale, err = store.GetStale(ctx, 3000)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "stale-c2", stale[0].CandidateID)
+}
+
+func TestTokenMetadataStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunTokenMetadataStoreSuite(t, func(t *testing.T, candidateIDs ...string) storage.TokenMetadataStore {
+		truncateTables(t, pool, "token_metadata", "token_candidates")
+		for _, id := range candidateIDs {
+			createTestCandidate(t, context.Background(), pool, id)
+		}
+		return NewTokenMetadataStore(pool)
+	})
+}
+
+func TestTokenMetadataStore_GetByMintsLatest(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewTokenMetadataStore(pool)
+
+	// Two candidates of one mint: the latest fetched row is returned
+	for id, ts := range map[string]int64{"mints-c1": 1000, "mints-c2": 2000} {
+		candidateID := createTestCandidate(t, ctx, pool, id)
+		require.NoError(t, store.Insert(ctx, &domain.TokenMetadata{
+			CandidateID: candidateID,
+			Mint:        "Mint-shared",
+			FetchedAt:   ts,
+		}))
+	}
+
+	got, err := store.GetByMints(ctx, []string{"Mint-shared", "Mint-missing"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "mints-c2", got[0].CandidateID)
+}
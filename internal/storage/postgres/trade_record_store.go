@@ -33,7 +33,9 @@ func (s *TradeRecordStore) Insert(ctx context.Context, t *domain.TradeRecord) er
 			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
 			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
 			gross_return, outcome, outcome_class,
-			hold_duration_ms, peak_price, min_liquidity
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, entry_volume_1h, token_age_ms, swaps_prior_count,
+			cost_breakdown, peak_liquidity, armed_at, input_key
 		) VALUES (
 			$1, $2, $3, $4,
 			$5, $6, $7, $8,
@@ -41,10 +43,13 @@ func (s *TradeRecordStore) Insert(ctx context.Context, t *domain.TradeRecord) er
 			$12, $13, $14, $15, $16,
 			$17, $18, $19, $20, $21,
 			$22, $23, $24,
-			$25, $26, $27
+			$25, $26, $27,
+			$28, $29, $30, $31,
+			$32, $33, $34, $35
 		)
 	`
 
+	volume1h, tokenAgeMs, swapsPrior := entryContextColumns(t.EntryContext)
 	_, err := s.pool.Exec(ctx, query,
 		t.TradeID, t.CandidateID, t.StrategyID, t.ScenarioID,
 		t.EntrySignalTime, t.EntrySignalPrice, t.EntryActualTime, t.EntryActualPrice,
@@ -53,6 +58,8 @@ func (s *TradeRecordStore) Insert(ctx context.Context, t *domain.TradeRecord) er
 		t.EntryCostSOL, t.ExitCostSOL, t.MEVCostSOL, t.TotalCostSOL, t.TotalCostPct,
 		t.GrossReturn, t.Outcome, t.OutcomeClass,
 		t.HoldDurationMs, t.PeakPrice, t.MinLiquidity,
+		t.EntryContext, volume1h, tokenAgeMs, swapsPrior,
+		t.CostBreakdown, t.PeakLiquidity, t.ArmedAt, t.InputKey,
 	)
 	if err != nil {
 		if isDuplicateKeyError(err) {
@@ -63,6 +70,87 @@ func (s *TradeRecordStore) Insert(ctx context.Context, t *domain.TradeRecord) er
 	return nil
 }
 
+// Upsert inserts a trade or replaces the stored record with the same trade_id.
+// The original created_at is kept; updated_at is set to the time of the upsert.
+func (s *TradeRecordStore) Upsert(ctx context.Context, t *domain.TradeRecord) error {
+	query := `
+		INSERT INTO trade_records (
+			trade_id, candidate_id, strategy_id, scenario_id,
+			entry_signal_time, entry_signal_price, entry_actual_time, entry_actual_price,
+			entry_liquidity, position_size, position_value,
+			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
+			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
+			gross_return, outcome, outcome_class,
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, entry_volume_1h, token_age_ms, swaps_prior_count,
+			cost_breakdown, peak_liquidity, armed_at, input_key
+		) VALUES (
+			$1, $2, $3, $4,
+			$5, $6, $7, $8,
+			$9, $10, $11,
+			$12, $13, $14, $15, $16,
+			$17, $18, $19, $20, $21,
+			$22, $23, $24,
+			$25, $26, $27,
+			$28, $29, $30, $31,
+			$32, $33, $34, $35
+		)
+		ON CONFLICT (trade_id) DO UPDATE SET
+			candidate_id = EXCLUDED.candidate_id,
+			strategy_id = EXCLUDED.strategy_id,
+			scenario_id = EXCLUDED.scenario_id,
+			entry_signal_time = EXCLUDED.entry_signal_time,
+			entry_signal_price = EXCLUDED.entry_signal_price,
+			entry_actual_time = EXCLUDED.entry_actual_time,
+			entry_actual_price = EXCLUDED.entry_actual_price,
+			entry_liquidity = EXCLUDED.entry_liquidity,
+			position_size = EXCLUDED.position_size,
+			position_value = EXCLUDED.position_value,
+			exit_signal_time = EXCLUDED.exit_signal_time,
+			exit_signal_price = EXCLUDED.exit_signal_price,
+			exit_actual_time = EXCLUDED.exit_actual_time,
+			exit_actual_price = EXCLUDED.exit_actual_price,
+			exit_reason = EXCLUDED.exit_reason,
+			entry_cost_sol = EXCLUDED.entry_cost_sol,
+			exit_cost_sol = EXCLUDED.exit_cost_sol,
+			mev_cost_sol = EXCLUDED.mev_cost_sol,
+			total_cost_sol = EXCLUDED.total_cost_sol,
+			total_cost_pct = EXCLUDED.total_cost_pct,
+			gross_return = EXCLUDED.gross_return,
+			outcome = EXCLUDED.outcome,
+			outcome_class = EXCLUDED.outcome_class,
+			hold_duration_ms = EXCLUDED.hold_duration_ms,
+			peak_price = EXCLUDED.peak_price,
+			min_liquidity = EXCLUDED.min_liquidity,
+			entry_context = EXCLUDED.entry_context,
+			entry_volume_1h = EXCLUDED.entry_volume_1h,
+			token_age_ms = EXCLUDED.token_age_ms,
+			swaps_prior_count = EXCLUDED.swaps_prior_count,
+			cost_breakdown = EXCLUDED.cost_breakdown,
+			peak_liquidity = EXCLUDED.peak_liquidity,
+			armed_at = EXCLUDED.armed_at,
+			input_key = EXCLUDED.input_key,
+			updated_at = (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+	`
+
+	volume1h, tokenAgeMs, swapsPrior := entryContextColumns(t.EntryContext)
+	_, err := s.pool.Exec(ctx, query,
+		t.TradeID, t.CandidateID, t.StrategyID, t.ScenarioID,
+		t.EntrySignalTime, t.EntrySignalPrice, t.EntryActualTime, t.EntryActualPrice,
+		t.EntryLiquidity, t.PositionSize, t.PositionValue,
+		t.ExitSignalTime, t.ExitSignalPrice, t.ExitActualTime, t.ExitActualPrice, t.ExitReason,
+		t.EntryCostSOL, t.ExitCostSOL, t.MEVCostSOL, t.TotalCostSOL, t.TotalCostPct,
+		t.GrossReturn, t.Outcome, t.OutcomeClass,
+		t.HoldDurationMs, t.PeakPrice, t.MinLiquidity,
+		t.EntryContext, volume1h, tokenAgeMs, swapsPrior,
+		t.CostBreakdown, t.PeakLiquidity, t.ArmedAt, t.InputKey,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert trade record: %w", err)
+	}
+	return nil
+}
+
 // InsertBulk adds multiple trades atomically. Fails entire batch on any duplicate.
 func (s *TradeRecordStore) InsertBulk(ctx context.Context, trades []*domain.TradeRecord) error {
 	if len(trades) == 0 {
@@ -83,7 +171,9 @@ func (s *TradeRecordStore) InsertBulk(ctx context.Context, trades []*domain.Trad
 			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
 			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
 			gross_return, outcome, outcome_class,
-			hold_duration_ms, peak_price, min_liquidity
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, entry_volume_1h, token_age_ms, swaps_prior_count,
+			cost_breakdown, peak_liquidity, armed_at, input_key
 		) VALUES (
 			$1, $2, $3, $4,
 			$5, $6, $7, $8,
@@ -91,11 +181,14 @@ func (s *TradeRecordStore) InsertBulk(ctx context.Context, trades []*domain.Trad
 			$12, $13, $14, $15, $16,
 			$17, $18, $19, $20, $21,
 			$22, $23, $24,
-			$25, $26, $27
+			$25, $26, $27,
+			$28, $29, $30, $31,
+			$32, $33, $34, $35
 		)
 	`
 
 	for _, t := range trades {
+		volume1h, tokenAgeMs, swapsPrior := entryContextColumns(t.EntryContext)
 		_, err := tx.Exec(ctx, query,
 			t.TradeID, t.CandidateID, t.StrategyID, t.ScenarioID,
 			t.EntrySignalTime, t.EntrySignalPrice, t.EntryActualTime, t.EntryActualPrice,
@@ -104,6 +197,8 @@ func (s *TradeRecordStore) InsertBulk(ctx context.Context, trades []*domain.Trad
 			t.EntryCostSOL, t.ExitCostSOL, t.MEVCostSOL, t.TotalCostSOL, t.TotalCostPct,
 			t.GrossReturn, t.Outcome, t.OutcomeClass,
 			t.HoldDurationMs, t.PeakPrice, t.MinLiquidity,
+			t.EntryContext, volume1h, tokenAgeMs, swapsPrior,
+			t.CostBreakdown, t.PeakLiquidity, t.ArmedAt, t.InputKey,
 		)
 		if err != nil {
 			if isDuplicateKeyError(err) {
@@ -130,7 +225,8 @@ func (s *TradeRecordStore) GetByID(ctx context.Context, tradeID string) (*domain
 			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
 			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
 			gross_return, outcome, outcome_class,
-			hold_duration_ms, peak_price, min_liquidity
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, cost_breakdown, peak_liquidity, armed_at, input_key
 		FROM trade_records
 		WHERE trade_id = $1
 	`
@@ -156,7 +252,8 @@ func (s *TradeRecordStore) GetByCandidateID(ctx context.Context, candidateID str
 			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
 			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
 			gross_return, outcome, outcome_class,
-			hold_duration_ms, peak_price, min_liquidity
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, cost_breakdown, peak_liquidity, armed_at, input_key
 		FROM trade_records
 		WHERE candidate_id = $1
 		ORDER BY entry_signal_time ASC, trade_id ASC
@@ -181,7 +278,8 @@ func (s *TradeRecordStore) GetByStrategyScenario(ctx context.Context, strategyID
 			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
 			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
 			gross_return, outcome, outcome_class,
-			hold_duration_ms, peak_price, min_liquidity
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, cost_breakdown, peak_liquidity, armed_at, input_key
 		FROM trade_records
 		WHERE strategy_id = $1 AND scenario_id = $2
 		ORDER BY entry_signal_time ASC, trade_id ASC
@@ -206,7 +304,8 @@ func (s *TradeRecordStore) GetAll(ctx context.Context) ([]*domain.TradeRecord, e
 			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
 			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
 			gross_return, outcome, outcome_class,
-			hold_duration_ms, peak_price, min_liquidity
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, cost_breakdown, peak_liquidity, armed_at, input_key
 		FROM trade_records
 		ORDER BY entry_signal_time ASC, trade_id ASC
 	`
@@ -220,6 +319,112 @@ func (s *TradeRecordStore) GetAll(ctx context.Context) ([]*domain.TradeRecord, e
 	return scanTradeRecords(rows)
 }
 
+// GetPage retrieves up to limit trades with trade_id > afterTradeID, ordered by trade_id.
+// Keyset pagination on the unique trade_id index keeps each page a range scan.
+func (s *TradeRecordStore) GetPage(ctx context.Context, afterTradeID string, limit int) ([]*domain.TradeRecord, error) {
+	if limit <= 0 {
+		return nil, storage.ErrInvalidInput
+	}
+
+	query := `
+		SELECT
+			trade_id, candidate_id, strategy_id, scenario_id,
+			entry_signal_time, entry_signal_price, entry_actual_time, entry_actual_price,
+			entry_liquidity, position_size, position_value,
+			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
+			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
+			gross_return, outcome, outcome_class,
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, cost_breakdown, peak_liquidity, armed_at, input_key
+		FROM trade_records
+		WHERE trade_id > $1
+		ORDER BY trade_id ASC
+		LIMIT $2
+	`
+
+	rows, err := s.pool.Query(ctx, query, afterTradeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get trade records page: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTradeRecords(rows)
+}
+
+// GetChangedSince retrieves up to limit trades with (updated_at, trade_id) after the
+// cursor, ordered by updated_at then trade_id, using idx_trade_records_updated_at.
+func (s *TradeRecordStore) GetChangedSince(ctx context.Context, after storage.TradeCursor, limit int) ([]*storage.TradeChange, error) {
+	if limit <= 0 {
+		return nil, storage.ErrInvalidInput
+	}
+
+	query := `
+		SELECT
+			trade_id, candidate_id, strategy_id, scenario_id,
+			entry_signal_time, entry_signal_price, entry_actual_time, entry_actual_price,
+			entry_liquidity, position_size, position_value,
+			exit_signal_time, exit_signal_price, exit_actual_time, exit_actual_price, exit_reason,
+			entry_cost_sol, exit_cost_sol, mev_cost_sol, total_cost_sol, total_cost_pct,
+			gross_return, outcome, outcome_class,
+			hold_duration_ms, peak_price, min_liquidity,
+			entry_context, cost_breakdown, peak_liquidity, armed_at, input_key,
+			updated_at
+		FROM trade_records
+		WHERE (updated_at, trade_id) > ($1, $2)
+		ORDER BY updated_at ASC, trade_id ASC
+		LIMIT $3
+	`
+
+	rows, err := s.pool.Query(ctx, query, after.UpdatedAt, after.TradeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get changed trade records: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*storage.TradeChange
+	for rows.Next() {
+		var t domain.TradeRecord
+		var updatedAt int64
+		err := rows.Scan(
+			&t.TradeID, &t.CandidateID, &t.StrategyID, &t.ScenarioID,
+			&t.EntrySignalTime, &t.EntrySignalPrice, &t.EntryActualTime, &t.EntryActualPrice,
+			&t.EntryLiquidity, &t.PositionSize, &t.PositionValue,
+			&t.ExitSignalTime, &t.ExitSignalPrice, &t.ExitActualTime, &t.ExitActualPrice, &t.ExitReason,
+			&t.EntryCostSOL, &t.ExitCostSOL, &t.MEVCostSOL, &t.TotalCostSOL, &t.TotalCostPct,
+			&t.GrossReturn, &t.Outcome, &t.OutcomeClass,
+			&t.HoldDurationMs, &t.PeakPrice, &t.MinLiquidity,
+			&t.EntryContext, &t.CostBreakdown, &t.PeakLiquidity, &t.ArmedAt, &t.InputKey,
+			&updatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan changed trade record row: %w", err)
+		}
+		changes = append(changes, &storage.TradeChange{Trade: &t, UpdatedAt: updatedAt})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate changed trade record rows: %w", err)
+	}
+	return changes, nil
+}
+
+// Count returns the number of stored trades.
+func (s *TradeRecordStore) Count(ctx context.Context) (int, error) {
+	var count int64
+	if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM trade_records`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count trade records: %w", err)
+	}
+	return int(count), nil
+}
+
+// entryContextColumns returns the entry_volume_1h, token_age_ms and swaps_prior_count
+// values denormalized from the entry_context JSONB; all NULL without a context.
+func entryContextColumns(c *domain.EntryContext) (volume1h *float64, tokenAgeMs *int64, swapsPrior *int) {
+	if c == nil {
+		return nil, nil, nil
+	}
+	return &c.Volume1h, &c.TokenAgeMs, &c.SwapsPrior
+}
+
 // scanTradeRecord scans a single row into a TradeRecord.
 func scanTradeRecord(row pgx.Row) (*domain.TradeRecord, error) {
 	var t domain.TradeRecord
@@ -232,6 +437,7 @@ func scanTradeRecord(row pgx.Row) (*domain.TradeRecord, error) {
 		&t.EntryCostSOL, &t.ExitCostSOL, &t.MEVCostSOL, &t.TotalCostSOL, &t.TotalCostPct,
 		&t.GrossReturn, &t.Outcome, &t.OutcomeClass,
 		&t.HoldDurationMs, &t.PeakPrice, &t.MinLiquidity,
+		&t.EntryContext, &t.CostBreakdown, &t.PeakLiquidity, &t.ArmedAt, &t.InputKey,
 	)
 	if err != nil {
 		return nil, err
@@ -255,6 +461,7 @@ func scanTradeRecords(rows pgx.Rows) ([]*domain.TradeRecord, error) {
 			&t.EntryCostSOL, &t.ExitCostSOL, &t.MEVCostSOL, &t.TotalCostSOL, &t.TotalCostPct,
 			&t.GrossReturn, &t.Outcome, &t.OutcomeClass,
 			&t.HoldDurationMs, &t.PeakPrice, &t.MinLiquidity,
+			&t.EntryContext, &t.CostBreakdown, &t.PeakLiquidity, &t.ArmedAt, &t.InputKey,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan trade record row: %w", err)
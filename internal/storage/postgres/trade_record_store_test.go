@@ -9,6 +9,7 @@ import (
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/storagetest"
 )
 
 func createTestTradeRecord(candidateID, tradeID, strategyID, scenarioID string) *domain.TradeRecord {
@@ -279,6 +280,41 @@ func TestTradeRecordStore_GetAll(t *testing.T) {
 	assert.Len(t, result, 3)
 }
 
+func TestTradeRecordStore_GetPage(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateID := createTestCandidate(t, ctx, pool, "trade-page-candidate")
+
+	store := NewTradeRecordStore(pool)
+
+	trades := []*domain.TradeRecord{
+		createTestTradeRecord(candidateID, "page-trade-003", "TIME_EXIT", "REALISTIC"),
+		createTestTradeRecord(candidateID, "page-trade-001", "TIME_EXIT", "REALISTIC"),
+		createTestTradeRecord(candidateID, "page-trade-002", "TIME_EXIT", "REALISTIC"),
+	}
+	require.NoError(t, store.InsertBulk(ctx, trades))
+
+	first, err := store.GetPage(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "page-trade-001", first[0].TradeID)
+	assert.Equal(t, "page-trade-002", first[1].TradeID)
+
+	second, err := store.GetPage(ctx, first[1].TradeID, 2)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, "page-trade-003", second[0].TradeID)
+
+	last, err := store.GetPage(ctx, second[0].TradeID, 2)
+	require.NoError(t, err)
+	assert.Empty(t, last)
+
+	_, err = store.GetPage(ctx, "", 0)
+	assert.ErrorIs(t, err, storage.ErrInvalidInput)
+}
+
 func TestTradeRecordStore_Ordering(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -406,3 +442,46 @@ func TestTradeRecordStore_OutcomeClasses(t *testing.T) {
 	assert.True(t, winFound, "WIN trade not found")
 	assert.True(t, lossFound, "LOSS trade not found")
 }
+
+func TestTradeRecordStore_Upsert(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	candidateID := createTestCandidate(t, ctx, pool, "trade-upsert-candidate")
+
+	store := NewTradeRecordStore(pool)
+
+	trade := createTestTradeRecord(candidateID, "trade-upsert", "TIME_EXIT_5min", "REALISTIC")
+	require.NoError(t, store.Upsert(ctx, trade))
+
+	// Re-simulation after a data fix changes the outcome
+	updated := createTestTradeRecord(candidateID, "trade-upsert", "TIME_EXIT_5min", "REALISTIC")
+	updated.ExitActualPrice = 0.005
+	updated.GrossReturn = -0.52
+	updated.Outcome = -0.54
+	updated.OutcomeClass = "LOSS"
+	updated.PeakPrice = nil
+	require.NoError(t, store.Upsert(ctx, updated))
+
+	stored, err := store.GetByID(ctx, "trade-upsert")
+	require.NoError(t, err)
+	assert.Equal(t, -0.54, stored.Outcome)
+	assert.Equal(t, "LOSS", stored.OutcomeClass)
+	assert.Equal(t, 0.005, stored.ExitActualPrice)
+	assert.Nil(t, stored.PeakPrice)
+
+	all, err := store.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestTradeRecordStore_Contract(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	storagetest.RunTradeRecordStoreSuite(t, func(t *testing.T) storage.TradeRecordStore {
+		truncateTables(t, pool, "trade_records")
+		return NewTradeRecordStore(pool)
+	})
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// WatchlistStore implements storage.WatchlistStore using PostgreSQL.
+type WatchlistStore struct {
+	pool *Pool
+}
+
+// NewWatchlistStore creates a new WatchlistStore.
+func NewWatchlistStore(pool *Pool) *WatchlistStore {
+	return &WatchlistStore{pool: pool}
+}
+
+// Compile-time interface check.
+var _ storage.WatchlistStore = (*WatchlistStore)(nil)
+
+// Add watchlists a mint. Returns ErrDuplicateKey if mint is already watchlisted.
+func (s *WatchlistStore) Add(ctx context.Context, e *domain.WatchlistEntry) error {
+	if e == nil || e.Mint == "" {
+		return storage.ErrInvalidInput
+	}
+
+	query := `INSERT INTO watchlist (mint, reason, added_at) VALUES ($1, $2, $3)`
+
+	_, err := s.pool.Exec(ctx, query, e.Mint, e.Reason, e.AddedAt)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return storage.ErrDuplicateKey
+		}
+		return fmt.Errorf("insert watchlist entry: %w", err)
+	}
+	return nil
+}
+
+// Remove un-watchlists a mint. Returns ErrNotFound if mint is not watchlisted.
+func (s *WatchlistStore) Remove(ctx context.Context, mint string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM watchlist WHERE mint = $1`, mint)
+	if err != nil {
+		return fmt.Errorf("delete watchlist entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// Get retrieves the entry for a mint. Returns ErrNotFound if mint is not watchlisted.
+func (s *WatchlistStore) Get(ctx context.Context, mint string) (*domain.WatchlistEntry, error) {
+	query := `SELECT mint, reason, added_at FROM watchlist WHERE mint = $1`
+
+	var e domain.WatchlistEntry
+	err := s.pool.QueryRow(ctx, query, mint).Scan(&e.Mint, &e.Reason, &e.AddedAt)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("get watchlist entry: %w", err)
+	}
+	return &e, nil
+}
+
+// List retrieves all entries, ordered by mint ASC.
+func (s *WatchlistStore) List(ctx context.Context) ([]*domain.WatchlistEntry, error) {
+	rows, err := s.pool.Query(ctx, `SELECT mint, reason, added_at FROM watchlist ORDER BY mint ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("list watchlist: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.WatchlistEntry
+	for rows.Next() {
+		var e domain.WatchlistEntry
+		if err := rows.Scan(&e.Mint, &e.Reason, &e.AddedAt); err != nil {
+			return nil, fmt.Errorf("scan watchlist row: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate watchlist rows: %w", err)
+	}
+
+	return entries, nil
+}
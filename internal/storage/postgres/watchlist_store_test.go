@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+func TestWatchlistStore_CRUD(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewWatchlistStore(pool)
+
+	require.NoError(t, store.Add(ctx, &domain.WatchlistEntry{Mint: "WatchMintB", Reason: "rug_detector", AddedAt: 2000}))
+	require.NoError(t, store.Add(ctx, &domain.WatchlistEntry{Mint: "WatchMintA", Reason: "manual", AddedAt: 1000}))
+
+	err := store.Add(ctx, &domain.WatchlistEntry{Mint: "WatchMintA", Reason: "manual", AddedAt: 3000})
+	assert.ErrorIs(t, err, storage.ErrDuplicateKey)
+
+	got, err := store.Get(ctx, "WatchMintA")
+	require.NoError(t, err)
+	assert.Equal(t, "manual", got.Reason)
+	assert.Equal(t, int64(1000), got.AddedAt)
+
+	list, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "WatchMintA", list[0].Mint)
+	assert.Equal(t, "WatchMintB", list[1].Mint)
+
+	require.NoError(t, store.Remove(ctx, "WatchMintA"))
+	assert.ErrorIs(t, store.Remove(ctx, "WatchMintA"), storage.ErrNotFound)
+	_, err = store.Get(ctx, "WatchMintA")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	// Un-watchlisted mints can be added again
+	require.NoError(t, store.Add(ctx, &domain.WatchlistEntry{Mint: "WatchMintA", Reason: "manual", AddedAt: 4000}))
+}
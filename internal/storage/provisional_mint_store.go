@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+)
+
+// ProvisionalMintStore keeps mints discovered at creation (PRE_POOL) until a
+// pool upgrades them to a NEW_TOKEN candidate or they expire.
+// Unlike the event tables it is operational state: rows change status.
+type ProvisionalMintStore interface {
+	// Insert adds a mint; its Status is stored as PENDING and CandidateID and
+	// ResolvedAt are ignored. Returns ErrDuplicateKey if the mint exists and
+	// ErrInvalidInput for a nil mint or empty Mint.
+	Insert(ctx context.Context, m *domain.ProvisionalMint) error
+
+	// GetByMint retrieves a mint. Returns ErrNotFound if not exists.
+	GetByMint(ctx context.Context, mint string) (*domain.ProvisionalMint, error)
+
+	// Upgrade links a PENDING mint to its NEW_TOKEN candidate and marks it
+	// UPGRADED at discoveredAt (ms). Returns ErrNotFound if the mint has no
+	// PENDING record.
+	Upgrade(ctx context.Context, mint, candidateID string, discoveredAt int64) error
+
+	// ExpirePending marks PENDING mints created before createdBefore (ms)
+	// EXPIRED at expiredAt (ms) and returns how many were expired.
+	ExpirePending(ctx context.Context, createdBefore, expiredAt int64) (int, error)
+
+	// GetByStatus retrieves mints of a status, ordered by created_at ASC, mint ASC.
+	GetByStatus(ctx context.Context, status domain.ProvisionalMintStatus) ([]*domain.ProvisionalMint, error)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+)
+
+// DefaultRawTxMaxBytes caps the raw transaction archive when no size is configured.
+const DefaultRawTxMaxBytes int64 = 1 << 30 // 1 GiB
+
+// RawTransaction is an archived transaction as fetched during ingestion.
+type RawTransaction struct {
+	TxSignature string
+	Slot        int64
+	BlockTime   int64  // Unix timestamp (seconds)
+	Data        []byte // transaction JSON in the getTransaction response shape
+	ArchivedAt  int64  // when it was (last) archived (ms)
+}
+
+// RawTxStore archives raw transactions so they can be parsed again without RPC.
+// The archive is size-capped: each Put evicts the oldest transactions, by
+// (slot, tx_signature), until the total size of Data fits the cap.
+type RawTxStore interface {
+	// Put archives a transaction, replacing an archived one with the same signature.
+	Put(ctx context.Context, tx *RawTransaction) error
+
+	// Get returns an archived transaction. Returns ErrNotFound if not archived.
+	Get(ctx context.Context, signature string) (*RawTransaction, error)
+
+	// GetPage returns up to limit transactions after (afterSlot, afterSignature),
+	// ordered by (slot, tx_signature). Pass (0, "") to start; an empty page means no more.
+	GetPage(ctx context.Context, afterSlot int64, afterSignature string, limit int) ([]*RawTransaction, error)
+
+	// Stats returns the number of archived transactions and the total size of their data.
+	Stats(ctx context.Context) (count int, bytes int64, err error)
+}
+
+// Reparse change kinds.
+const (
+	ReparseAdded   = "ADDED"   // event produced by the current parser but not stored
+	ReparseChanged = "CHANGED" // stored event whose parsed fields differ
+	ReparseRemoved = "REMOVED" // stored event the current parser no longer produces (reported only, not audited)
+)
+
+// ReparseCorrection audits one event difference found by re-parsing an archived transaction.
+type ReparseCorrection struct {
+	EventTable  string // EventTableSwapEvents | EventTableLiquidityEvents
+	TxSignature string
+	EventIndex  int
+	Change      string // ReparseAdded | ReparseChanged | ReparseRemoved
+	Before      string // stored event as JSON, "" for ADDED
+	After       string // re-parsed event as JSON
+	CorrectedAt int64  // ms
+}
+
+// ReparseFix is the set of corrections for one transaction.
+// Stored events in Replace* are deleted and events in Insert* are inserted;
+// a changed event appears in both.
+type ReparseFix struct {
+	TxSignature       string
+	ReplaceSwapEvents []*domain.SwapEvent
+	InsertSwapEvents  []*domain.SwapEvent
+	ReplaceLiquidity  []*domain.LiquidityEvent
+	InsertLiquidity   []*domain.LiquidityEvent
+	Corrections       []*ReparseCorrection
+}
+
+// ReparseStore reads and corrects the parsed events of one transaction across
+// the swap_events and liquidity_events tables.
+type ReparseStore interface {
+	// GetTxEvents returns the stored swap and liquidity events of a transaction, ordered by event_index.
+	GetTxEvents(ctx context.Context, signature string) ([]*domain.SwapEvent, []*domain.LiquidityEvent, error)
+
+	// Apply records the corrections and replaces the events of fix atomically.
+	// Only ADDED and CHANGED corrections are passed; REMOVED differences are reported, not applied.
+	Apply(ctx context.Context, fix *ReparseFix) error
+
+	// GetCorrections returns corrections with corrected_at in [start, end),
+	// ordered by (corrected_at, event_table, tx_signature, event_index).
+	GetCorrections(ctx context.Context, start, end int64) ([]*ReparseCorrection, error)
+}
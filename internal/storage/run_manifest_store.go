@@ -0,0 +1,57 @@
+package storage
+
+import "context"
+
+// Run kinds recorded in RunManifest.Kind.
+const (
+	RunKindPipeline = "pipeline" // orchestrator run: normalization → simulation → aggregation
+	RunKindReport   = "report"   // Phase 1 report run
+)
+
+// MaxRunManifestErrors caps the errors stored per run manifest;
+// RunManifest.ErrorCount keeps the full count.
+const MaxRunManifestErrors = 100
+
+// RunBackend is a storage backend a run read from and wrote to.
+type RunBackend struct {
+	Name    string `json:"name"`              // "postgres", "clickhouse" or "memory"
+	Version string `json:"version,omitempty"` // server version, empty if unknown
+}
+
+// RunManifest ties the numbers of one pipeline or report run to the code,
+// configuration and data that produced them.
+type RunManifest struct {
+	RunID       string            `json:"run_id"`
+	Kind        string            `json:"kind"`        // RunKindPipeline or RunKindReport
+	StartedAt   int64             `json:"started_at"`  // Unix ms
+	FinishedAt  int64             `json:"finished_at"` // Unix ms
+	GitCommit   string            `json:"git_commit"`
+	Config      map[string]string `json:"config"` // effective configuration, credentials redacted
+	Backends    []RunBackend      `json:"backends"`
+	Candidates  int               `json:"candidates"`             // processed by a pipeline run, reported by a report run
+	Trades      int               `json:"trades"`                 // created or updated by a pipeline run, reported by a report run
+	Aggregates  int               `json:"aggregates"`             // created or updated by a pipeline run, reported by a report run
+	DataVersion string            `json:"data_version,omitempty"` // report runs only
+	Decision    string            `json:"decision,omitempty"`     // report runs only
+	Errors      []string          `json:"errors"`                 // first MaxRunManifestErrors errors
+	ErrorCount  int               `json:"error_count"`            // total errors, including those not stored
+}
+
+// RunManifestStore keeps the history of run manifests for the API.
+type RunManifestStore interface {
+	// Insert records a manifest. Returns ErrInvalidInput for a nil manifest or
+	// an empty RunID, ErrDuplicateKey if the RunID is already stored.
+	Insert(ctx context.Context, m *RunManifest) error
+
+	// GetByID returns the manifest of a run. Returns ErrNotFound if it is not stored.
+	GetByID(ctx context.Context, runID string) (*RunManifest, error)
+
+	// GetLatest returns the manifest of kind with the highest StartedAt, the
+	// last inserted among equal StartedAt; an empty kind matches every kind.
+	// Returns ErrNotFound if no run matches.
+	GetLatest(ctx context.Context, kind string) (*RunManifest, error)
+
+	// List returns up to limit manifests of kind (empty: every kind), newest
+	// first by StartedAt. A limit <= 0 returns all.
+	List(ctx context.Context, kind string, limit int) ([]*RunManifest, error)
+}
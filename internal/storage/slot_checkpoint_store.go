@@ -0,0 +1,52 @@
+package storage
+
+import "context"
+
+// SlotRange is an inclusive range of slots.
+type SlotRange struct {
+	From int64
+	To   int64
+}
+
+// SlotCheckpoint records that a block-based backfill fully processed a slot.
+type SlotCheckpoint struct {
+	Slot         int64
+	Skipped      bool // no block was produced for the slot
+	Transactions int  // transactions mentioning a configured program
+}
+
+// SlotCheckpointStore tracks per-slot completion of block-based backfills,
+// so the slots a range is still missing can be enumerated.
+type SlotCheckpointStore interface {
+	// MarkComplete records processed slots. Re-marking a slot overwrites its checkpoint.
+	MarkComplete(ctx context.Context, checkpoints []*SlotCheckpoint) error
+
+	// GetCompleted returns checkpoints within [from, to], ordered by slot.
+	GetCompleted(ctx context.Context, from, to int64) ([]*SlotCheckpoint, error)
+
+	// GetGaps returns the maximal ranges within [from, to] without a checkpoint, ordered by slot.
+	GetGaps(ctx context.Context, from, to int64) ([]SlotRange, error)
+}
+
+// SlotGaps returns the maximal ranges within [from, to] not covered by the
+// ascending completed slots. Slots outside [from, to] are ignored.
+func SlotGaps(completed []int64, from, to int64) []SlotRange {
+	var gaps []SlotRange
+	next := from
+	for _, slot := range completed {
+		if slot < next {
+			continue
+		}
+		if slot > to {
+			break
+		}
+		if slot > next {
+			gaps = append(gaps, SlotRange{From: next, To: slot - 1})
+		}
+		next = slot + 1
+	}
+	if next <= to {
+		gaps = append(gaps, SlotRange{From: next, To: to})
+	}
+	return gaps
+}
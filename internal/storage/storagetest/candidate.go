@@ -0,0 +1,336 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// CandidateStoreFactory returns an empty store. It is called once per subtest.
+type CandidateStoreFactory func(t *testing.T) storage.CandidateStore
+
+// RunCandidateStoreSuite runs the CandidateStore contract against fresh stores from newStore.
+func RunCandidateStoreSuite(t *testing.T, newStore CandidateStoreFactory) {
+	candidate := func(id, mint string, source domain.Source, discoveredAt int64) *domain.TokenCandidate {
+		return &domain.TokenCandidate{
+			CandidateID:  id,
+			Source:       source,
+			Mint:         mint,
+			TxSignature:  "tx-" + id,
+			Slot:         100,
+			DiscoveredAt: discoveredAt,
+		}
+	}
+
+	t.Run("InsertAndGetByID", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		pool := "pool1"
+		in := candidate("c1", "mint1", domain.SourceNewToken, 1000)
+		in.Pool = &pool
+		in.EventIndex = 2
+		mustInsert(t, store.Insert(ctx, in))
+
+		got, err := store.GetByID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.Mint != in.Mint || got.Source != in.Source || got.TxSignature != in.TxSignature ||
+			got.EventIndex != in.EventIndex || got.Slot != in.Slot || got.DiscoveredAt != in.DiscoveredAt {
+			t.Errorf("round trip mismatch: got %+v, want %+v", got, in)
+		}
+		if got.Pool == nil || *got.Pool != pool {
+			t.Errorf("expected pool %q, got %v", pool, got.Pool)
+		}
+		if got.DetectedAt != nil {
+			t.Errorf("expected nil DetectedAt for backfilled candidate, got %d", *got.DetectedAt)
+		}
+	})
+
+	t.Run("DetectedAtRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		detectedAt := int64(1750)
+		in := candidate("c1", "mint1", domain.SourceNewToken, 1000)
+		in.DetectedAt = &detectedAt
+		mustInsert(t, store.Insert(ctx, in))
+		detectedAt = 0 // stored value must not alias the caller's
+
+		got, err := store.GetByID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.DetectedAt == nil || *got.DetectedAt != 1750 {
+			t.Errorf("expected DetectedAt 1750, got %v", got.DetectedAt)
+		}
+	})
+
+	t.Run("DEXRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		labeled := candidate("c1", "mint1", domain.SourceNewToken, 1000)
+		labeled.DEX = domain.DEXPumpFun
+		mustInsert(t, store.Insert(ctx, labeled))
+		mustInsert(t, store.Insert(ctx, candidate("c2", "mint2", domain.SourceNewToken, 2000)))
+
+		got, err := store.GetBySource(ctx, domain.SourceNewToken)
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		if len(got) != 2 || got[0].DEX != domain.DEXPumpFun || got[1].DEX != "" {
+			t.Errorf("expected DEX %q on c1 and none on c2, got %+v", domain.DEXPumpFun, got)
+		}
+	})
+
+	t.Run("GetByIDNotFound", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.GetByID(context.Background(), "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("DuplicateKey", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.Insert(ctx, candidate("c1", "mint1", domain.SourceNewToken, 1000)))
+		if err := store.Insert(ctx, candidate("c1", "mint2", domain.SourceActiveToken, 2000)); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("expected ErrDuplicateKey, got %v", err)
+		}
+	})
+
+	t.Run("TimeRangeInclusive", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		for _, c := range []*domain.TokenCandidate{
+			candidate("c1", "m1", domain.SourceNewToken, 1000),
+			candidate("c2", "m2", domain.SourceNewToken, 2000),
+			candidate("c3", "m3", domain.SourceNewToken, 3000),
+		} {
+			mustInsert(t, store.Insert(ctx, c))
+		}
+
+		got, err := store.GetByTimeRange(ctx, 1000, 2000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, candidateIDs(got), "c1", "c2")
+
+		got, err = store.GetByTimeRange(ctx, 2001, 2999)
+		if err != nil {
+			t.Fatalf("get by empty time range: %v", err)
+		}
+		assertIDs(t, candidateIDs(got))
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		// Inserted out of order; ties on discovered_at break by candidate_id
+		for _, c := range []*domain.TokenCandidate{
+			candidate("c3", "mint", domain.SourceNewToken, 2000),
+			candidate("c2", "mint", domain.SourceNewToken, 1000),
+			candidate("c1", "mint", domain.SourceNewToken, 2000),
+		} {
+			mustInsert(t, store.Insert(ctx, c))
+		}
+
+		byMint, err := store.GetByMint(ctx, "mint")
+		if err != nil {
+			t.Fatalf("get by mint: %v", err)
+		}
+		assertIDs(t, candidateIDs(byMint), "c2", "c1", "c3")
+
+		bySource, err := store.GetBySource(ctx, domain.SourceNewToken)
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		assertIDs(t, candidateIDs(bySource), "c2", "c1", "c3")
+
+		byRange, err := store.GetByTimeRange(ctx, 0, 5000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, candidateIDs(byRange), "c2", "c1", "c3")
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.Insert(ctx, candidate("c1", "mintA", domain.SourceNewToken, 1000)))
+		mustInsert(t, store.Insert(ctx, candidate("c2", "mintB", domain.SourceActiveToken, 1000)))
+
+		byMint, err := store.GetByMint(ctx, "mintB")
+		if err != nil {
+			t.Fatalf("get by mint: %v", err)
+		}
+		assertIDs(t, candidateIDs(byMint), "c2")
+
+		bySource, err := store.GetBySource(ctx, domain.SourceNewToken)
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		assertIDs(t, candidateIDs(bySource), "c1")
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.Insert(ctx, candidate("c1", "mint1", domain.SourceNewToken, 1000)))
+
+		got, err := store.GetByID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.Status != domain.CandidateStatusOpen || got.ClosedAt != nil || got.ReplayFingerprint != "" {
+			t.Errorf("expected new candidate OPEN without closure, got %+v", got)
+		}
+
+		if err := store.Close(ctx, "c1", 5000, "fp1"); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+		// Closing again must not move closed_at or the frozen fingerprint
+		if err := store.Close(ctx, "c1", 9000, "fp2"); err != nil {
+			t.Fatalf("close again: %v", err)
+		}
+
+		got, err = store.GetByID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.Status != domain.CandidateStatusClosed {
+			t.Errorf("expected CLOSED, got %q", got.Status)
+		}
+		if got.ClosedAt == nil || *got.ClosedAt != 5000 {
+			t.Errorf("expected ClosedAt 5000, got %v", got.ClosedAt)
+		}
+		if got.ReplayFingerprint != "fp1" {
+			t.Errorf("expected fingerprint fp1, got %q", got.ReplayFingerprint)
+		}
+
+		bySource, err := store.GetBySource(ctx, domain.SourceNewToken)
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		if len(bySource) != 1 || !bySource[0].IsClosed() {
+			t.Errorf("expected closed candidate from GetBySource, got %+v", bySource)
+		}
+
+		if err := store.Close(ctx, "missing", 5000, "fp"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("SoftDelete", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		mustInsert(t, store.Insert(ctx, candidate("c1", "mint1", domain.SourceNewToken, 1000)))
+		mustInsert(t, store.Insert(ctx, candidate("c2", "mint2", domain.SourceNewToken, 2000)))
+		if err := store.Close(ctx, "c2", 3000, "fp"); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+
+		del := &storage.CandidateDeletion{CandidateID: "c2", DeletedAt: 5000, Reason: "test token", Requester: "admin"}
+		if err := store.SoftDelete(ctx, del); err != nil {
+			t.Fatalf("soft-delete: %v", err)
+		}
+
+		// Default reads exclude the deleted candidate
+		bySource, err := store.GetBySource(ctx, domain.SourceNewToken)
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		assertIDs(t, candidateIDs(bySource), "c1")
+		byRange, err := store.GetByTimeRange(ctx, 0, 5000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, candidateIDs(byRange), "c1")
+
+		// IncludeDeleted returns it, marked
+		bySource, err = store.GetBySource(ctx, domain.SourceNewToken, storage.IncludeDeleted())
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		assertIDs(t, candidateIDs(bySource), "c1", "c2")
+		if bySource[0].IsDeleted() || !bySource[1].IsDeleted() {
+			t.Errorf("expected only c2 deleted, got %+v", bySource)
+		}
+		byRange, err = store.GetByTimeRange(ctx, 0, 5000, storage.IncludeDeleted())
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, candidateIDs(byRange), "c1", "c2")
+
+		// Lookups by ID and mint still find it
+		got, err := store.GetByID(ctx, "c2")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.DeletedAt == nil || *got.DeletedAt != 5000 || got.DeletedReason != "test token" {
+			t.Errorf("expected deletion at 5000 for test token, got %v %q", got.DeletedAt, got.DeletedReason)
+		}
+		if !got.IsClosed() || got.ReplayFingerprint != "fp" {
+			t.Errorf("soft-delete must keep the closure, got %+v", got)
+		}
+		byMint, err := store.GetByMint(ctx, "mint2")
+		if err != nil {
+			t.Fatalf("get by mint: %v", err)
+		}
+		if len(byMint) != 1 || !byMint[0].IsDeleted() {
+			t.Errorf("expected the deleted candidate by mint, got %+v", byMint)
+		}
+
+		audit, err := store.GetDeletions(ctx)
+		if err != nil {
+			t.Fatalf("get deletions: %v", err)
+		}
+		if len(audit) != 1 || *audit[0] != *del {
+			t.Errorf("expected audit entry %+v, got %+v", del, audit)
+		}
+
+		if err := store.SoftDelete(ctx, del); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("expected ErrDuplicateKey deleting twice, got %v", err)
+		}
+		if err := store.SoftDelete(ctx, &storage.CandidateDeletion{CandidateID: "missing", DeletedAt: 1, Reason: "x"}); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+		if err := store.SoftDelete(ctx, &storage.CandidateDeletion{CandidateID: "c1", DeletedAt: 1}); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput without a reason, got %v", err)
+		}
+		if audit, _ := store.GetDeletions(ctx); len(audit) != 1 {
+			t.Errorf("failed deletions must not be audited, got %+v", audit)
+		}
+	})
+
+	t.Run("EmptyResults", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		byMint, err := store.GetByMint(ctx, "none")
+		if err != nil {
+			t.Fatalf("get by mint: %v", err)
+		}
+		assertIDs(t, candidateIDs(byMint))
+
+		bySource, err := store.GetBySource(ctx, domain.SourceActiveToken)
+		if err != nil {
+			t.Fatalf("get by source: %v", err)
+		}
+		assertIDs(t, candidateIDs(bySource))
+	})
+}
+
+func candidateIDs(cs []*domain.TokenCandidate) []string {
+	ids := make([]string, len(cs))
+	for i, c := range cs {
+		ids[i] = c.CandidateID
+	}
+	return ids
+}
@@ -0,0 +1,142 @@
+// Package storagetest provides contract tests shared by storage implementations.
+// Each implementation's test package calls these with a fresh store so memory
+// and Postgres are held to identical semantics.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// SwapEventStoreDuplicates asserts (mint, tx_signature, event_index) uniqueness of swap events.
+func SwapEventStoreDuplicates(t *testing.T, store storage.SwapEventStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	swap := func(mint, sig string, idx int) *domain.SwapEvent {
+		return &domain.SwapEvent{Mint: mint, TxSignature: sig, EventIndex: idx, Slot: 100, Timestamp: 1000, AmountOut: 1}
+	}
+
+	if err := store.Insert(ctx, swap("mintA", "dupSwap1", 0)); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// Same event arriving via a second path (WS + backfill overlap)
+	if err := store.Insert(ctx, swap("mintA", "dupSwap1", 0)); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("same (mint, tx, index): expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Other mint or index in the same transaction is a distinct event
+	if err := store.Insert(ctx, swap("mintB", "dupSwap1", 0)); err != nil {
+		t.Errorf("other mint: expected insert, got %v", err)
+	}
+	if err := store.Insert(ctx, swap("mintA", "dupSwap1", 1)); err != nil {
+		t.Errorf("other event index: expected insert, got %v", err)
+	}
+
+	// Bulk with an existing event fails atomically
+	err := store.InsertBulk(ctx, []*domain.SwapEvent{swap("mintA", "dupSwap2", 0), swap("mintA", "dupSwap1", 0)})
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("bulk with existing event: expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Bulk with an intra-batch duplicate fails atomically
+	err = store.InsertBulk(ctx, []*domain.SwapEvent{swap("mintA", "dupSwap3", 0), swap("mintA", "dupSwap3", 0)})
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("bulk with intra-batch duplicate: expected ErrDuplicateKey, got %v", err)
+	}
+
+	events, err := store.GetByMintTimeRange(ctx, "mintA", 0, 2000)
+	if err != nil {
+		t.Fatalf("get by mint: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("expected 2 stored mintA events (failed batches not applied), got %d", len(events))
+	}
+}
+
+// LiquidityEventStoreDuplicates asserts (tx_signature, event_index, mint or pool) uniqueness of
+// liquidity events, independent of candidate association.
+func LiquidityEventStoreDuplicates(t *testing.T, store storage.LiquidityEventStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	liq := func(candidateID, mint, pool, sig string, idx int) *domain.LiquidityEvent {
+		return &domain.LiquidityEvent{
+			CandidateID: candidateID,
+			Mint:        mint,
+			Pool:        pool,
+			TxSignature: sig,
+			EventIndex:  idx,
+			Slot:        100,
+			Timestamp:   1000,
+			EventType:   domain.LiquidityEventAdd,
+			AmountToken: 1,
+			AmountQuote: 1,
+		}
+	}
+
+	if err := store.Insert(ctx, liq("", "mintA", "poolA", "dupLiq1", 0)); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	if err := store.Insert(ctx, liq("", "mintA", "poolA", "dupLiq1", 0)); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("same event: expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Live path may already know the candidate while the backfill stored it unassociated
+	if err := store.Insert(ctx, liq("cand1", "mintA", "poolA", "dupLiq1", 0)); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("same event with candidate ID: expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Mint takes precedence over pool in the key
+	if err := store.Insert(ctx, liq("", "mintA", "poolOther", "dupLiq1", 0)); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("same mint, other pool: expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Pool identifies events without mint
+	if err := store.Insert(ctx, liq("", "", "poolB", "dupLiq2", 0)); err != nil {
+		t.Fatalf("insert pool-only: %v", err)
+	}
+	if err := store.Insert(ctx, liq("", "", "poolB", "dupLiq2", 0)); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("same pool-only event: expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Other mint or index in the same transaction is a distinct event
+	if err := store.Insert(ctx, liq("", "mintB", "poolA", "dupLiq1", 0)); err != nil {
+		t.Errorf("other mint: expected insert, got %v", err)
+	}
+	if err := store.Insert(ctx, liq("", "mintA", "poolA", "dupLiq1", 1)); err != nil {
+		t.Errorf("other event index: expected insert, got %v", err)
+	}
+
+	// Bulk with an existing event fails atomically
+	err := store.InsertBulk(ctx, []*domain.LiquidityEvent{
+		liq("cand2", "mintC", "", "dupLiq3", 0),
+		liq("cand1", "mintA", "poolA", "dupLiq1", 0),
+	})
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("bulk with existing event: expected ErrDuplicateKey, got %v", err)
+	}
+
+	// Bulk with an intra-batch duplicate fails atomically
+	err = store.InsertBulk(ctx, []*domain.LiquidityEvent{
+		liq("cand2", "mintC", "", "dupLiq4", 0),
+		liq("", "mintC", "", "dupLiq4", 0),
+	})
+	if !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("bulk with intra-batch duplicate: expected ErrDuplicateKey, got %v", err)
+	}
+
+	events, err := store.GetByMintTimeRange(ctx, "mintC", 0, 2000)
+	if err != nil {
+		t.Fatalf("get by mint: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no mintC events (failed batches not applied), got %d", len(events))
+	}
+}
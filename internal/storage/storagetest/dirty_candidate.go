@@ -0,0 +1,81 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// DirtyCandidateStoreFactory returns an empty store. It is called once per subtest.
+type DirtyCandidateStoreFactory func(t *testing.T) storage.DirtyCandidateStore
+
+// RunDirtyCandidateStoreSuite runs the DirtyCandidateStore contract against fresh stores from newStore.
+func RunDirtyCandidateStoreSuite(t *testing.T, newStore DirtyCandidateStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("MarkMerges", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Mark(ctx, "cand-b", 5000, 100))
+		mustInsert(t, s.Mark(ctx, "cand-a", 7000, 200))
+		// Re-marking keeps the earliest event and the latest mark
+		mustInsert(t, s.Mark(ctx, "cand-b", 3000, 300))
+		mustInsert(t, s.Mark(ctx, "cand-b", 9000, 250))
+
+		got, err := s.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		want := []domain.DirtyCandidate{
+			{CandidateID: "cand-a", EarliestEventTime: 7000, MarkedAt: 200},
+			{CandidateID: "cand-b", EarliestEventTime: 3000, MarkedAt: 300},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %d marks, got %d", len(want), len(got))
+		}
+		for i := range want {
+			if *got[i] != want[i] {
+				t.Errorf("mark %d: expected %+v, got %+v", i, want[i], *got[i])
+			}
+		}
+
+		n, err := s.Count(ctx)
+		if err != nil {
+			t.Fatalf("Count: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("expected count 2, got %d", n)
+		}
+	})
+
+	t.Run("UnmarkKeepsLaterMarks", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Mark(ctx, "cand-a", 1000, 100))
+		mustInsert(t, s.Mark(ctx, "cand-b", 1000, 100))
+
+		// cand-b was marked again while being re-simulated from data as of 100
+		mustInsert(t, s.Mark(ctx, "cand-b", 2000, 150))
+		for _, id := range []string{"cand-a", "cand-b", "clean"} {
+			if err := s.Unmark(ctx, id, 100); err != nil {
+				t.Fatalf("Unmark %s: %v", id, err)
+			}
+		}
+
+		got, err := s.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(got) != 1 || got[0].CandidateID != "cand-b" || got[0].MarkedAt != 150 {
+			t.Errorf("expected only the re-marked cand-b, got %+v", got)
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t)
+		if err := s.Mark(ctx, "", 1000, 100); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// EventCountStoreFactory returns an empty store. It is called once per subtest.
+type EventCountStoreFactory func(t *testing.T) storage.EventCountStore
+
+// RunEventCountStoreSuite runs the EventCountStore contract against fresh stores from newStore.
+func RunEventCountStoreSuite(t *testing.T, newStore EventCountStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("UpsertReplaces", func(t *testing.T) {
+		s := newStore(t)
+		first := &domain.CandidateEventCounts{CandidateID: "cand-a", SwapsSeen: 10, SwapsStored: 10, SampleEvery: 5, UpdatedAt: 100}
+		mustInsert(t, s.Upsert(ctx, first))
+		second := &domain.CandidateEventCounts{
+			CandidateID: "cand-a", SwapsSeen: 30, SwapsStored: 14,
+			LiquiditySeen: 4, LiquidityStored: 4, SampleEvery: 5, UpdatedAt: 200,
+		}
+		mustInsert(t, s.Upsert(ctx, second))
+
+		got, err := s.GetByCandidateID(ctx, "cand-a")
+		if err != nil {
+			t.Fatalf("GetByCandidateID: %v", err)
+		}
+		if *got != *second {
+			t.Errorf("expected %+v, got %+v", *second, *got)
+		}
+
+		if _, err := s.GetByCandidateID(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("GetDownsampled", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Upsert(ctx, &domain.CandidateEventCounts{CandidateID: "cand-c", LiquiditySeen: 9, LiquidityStored: 6, SampleEvery: 2}))
+		mustInsert(t, s.Upsert(ctx, &domain.CandidateEventCounts{CandidateID: "cand-b", SwapsSeen: 5, SwapsStored: 5, SampleEvery: 2}))
+		mustInsert(t, s.Upsert(ctx, &domain.CandidateEventCounts{CandidateID: "cand-a", SwapsSeen: 8, SwapsStored: 5, SampleEvery: 2}))
+
+		got, err := s.GetDownsampled(ctx)
+		if err != nil {
+			t.Fatalf("GetDownsampled: %v", err)
+		}
+		if len(got) != 2 || got[0].CandidateID != "cand-a" || got[1].CandidateID != "cand-c" {
+			t.Fatalf("expected cand-a and cand-c, got %+v", got)
+		}
+		if got[0].DroppedEvents() != 3 || got[1].DroppedEvents() != 3 {
+			t.Errorf("expected 3 dropped events each, got %d and %d", got[0].DroppedEvents(), got[1].DroppedEvents())
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t)
+		if err := s.Upsert(ctx, &domain.CandidateEventCounts{}); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput, got %v", err)
+		}
+	})
+}
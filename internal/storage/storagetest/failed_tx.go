@@ -0,0 +1,96 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"solana-token-lab/internal/storage"
+)
+
+// FailedTxStoreFactory returns an empty store. It is called once per subtest.
+type FailedTxStoreFactory func(t *testing.T) storage.FailedTxStore
+
+// RunFailedTxStoreSuite runs the FailedTxStore contract against fresh stores from newStore.
+func RunFailedTxStoreSuite(t *testing.T, newStore FailedTxStoreFactory) {
+	ctx := context.Background()
+	const hour = storage.FailedTxHourMs
+
+	t.Run("AddAccumulates", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Add(ctx, []*storage.FailedTxTally{
+			{Program: "prog-b", Source: "ws-swap", HourStart: hour, Transactions: 10, Failed: 2},
+			{Program: "prog-a", Source: "ws-swap", HourStart: hour, Transactions: 4, Failed: 0},
+			{Program: "prog-a", Source: "rpc-swap", HourStart: 0, Transactions: 3, Failed: 3},
+		}))
+		mustInsert(t, s.Add(ctx, []*storage.FailedTxTally{
+			{Program: "prog-b", Source: "ws-swap", HourStart: hour, Transactions: 5, Failed: 1},
+		}))
+
+		got, err := s.GetRange(ctx, 0, 2*hour)
+		if err != nil {
+			t.Fatalf("GetRange: %v", err)
+		}
+		want := []*storage.FailedTxTally{
+			{Program: "prog-a", Source: "rpc-swap", HourStart: 0, Transactions: 3, Failed: 3},
+			{Program: "prog-a", Source: "ws-swap", HourStart: hour, Transactions: 4, Failed: 0},
+			{Program: "prog-b", Source: "ws-swap", HourStart: hour, Transactions: 15, Failed: 3},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("GetRangeHalfOpen", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Add(ctx, []*storage.FailedTxTally{
+			{Program: "prog-a", Source: "ws-swap", HourStart: 0, Transactions: 1},
+			{Program: "prog-a", Source: "ws-swap", HourStart: hour, Transactions: 2},
+			{Program: "prog-a", Source: "ws-swap", HourStart: 2 * hour, Transactions: 3},
+		}))
+
+		got, err := s.GetRange(ctx, hour, 2*hour)
+		if err != nil {
+			t.Fatalf("GetRange: %v", err)
+		}
+		if len(got) != 1 || got[0].HourStart != hour {
+			t.Errorf("expected the middle hour only, got %+v", got)
+		}
+
+		got, err = s.GetRange(ctx, 3*hour, 4*hour)
+		if err != nil {
+			t.Fatalf("GetRange: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no tallies, got %+v", got)
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t)
+		invalid := []*storage.FailedTxTally{
+			nil,
+			{Source: "ws-swap", HourStart: hour, Transactions: 1},
+			{Program: "prog-a", HourStart: hour, Transactions: 1},
+			{Program: "prog-a", Source: "ws-swap", HourStart: hour + 1, Transactions: 1},
+			{Program: "prog-a", Source: "ws-swap", HourStart: hour, Transactions: 1, Failed: 2},
+			{Program: "prog-a", Source: "ws-swap", HourStart: hour, Transactions: 1, Failed: -1},
+		}
+		for i, tally := range invalid {
+			valid := &storage.FailedTxTally{Program: "prog-a", Source: "ws-swap", HourStart: 0, Transactions: 1}
+			if err := s.Add(ctx, []*storage.FailedTxTally{valid, tally}); !errors.Is(err, storage.ErrInvalidInput) {
+				t.Errorf("tally %d: expected ErrInvalidInput, got %v", i, err)
+			}
+		}
+
+		// A rejected batch stores nothing
+		got, err := s.GetRange(ctx, 0, 2*hour)
+		if err != nil {
+			t.Fatalf("GetRange: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected nothing stored, got %+v", got)
+		}
+	})
+}
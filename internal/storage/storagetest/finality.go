@@ -0,0 +1,168 @@
+package storagetest
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// FinalityStores is a FinalityStore together with the event stores it covers.
+type FinalityStores struct {
+	Finality   storage.FinalityStore
+	Swaps      storage.SwapStore
+	SwapEvents storage.SwapEventStore
+	Liquidity  storage.LiquidityEventStore
+}
+
+// FinalityStoreFactory returns empty stores in which the given candidates exist. It is called once per subtest.
+type FinalityStoreFactory func(t *testing.T, candidateIDs ...string) FinalityStores
+
+// RunFinalityStoreSuite runs the FinalityStore contract against fresh stores from newStores.
+func RunFinalityStoreSuite(t *testing.T, newStores FinalityStoreFactory) {
+	ctx := context.Background()
+
+	// seed stores tx "dropped" in all three tables and "kept" as a swap, both unverified.
+	seed := func(t *testing.T, s FinalityStores) {
+		t.Helper()
+		mustInsert(t, s.Swaps.InsertBulk(ctx, []*domain.Swap{
+			{CandidateID: "c1", TxSignature: "dropped", EventIndex: 0, Slot: 200, Timestamp: 2000, Side: domain.SwapSideBuy, AmountIn: 1, AmountOut: 10, Price: 0.1},
+			{CandidateID: "c1", TxSignature: "kept", EventIndex: 0, Slot: 100, Timestamp: 1000, Side: domain.SwapSideBuy, AmountIn: 1, AmountOut: 10, Price: 0.1},
+		}))
+		mustInsert(t, s.SwapEvents.Insert(ctx, &domain.SwapEvent{Mint: "mintA", TxSignature: "dropped", EventIndex: 1, Slot: 200, Timestamp: 2000, AmountOut: 10}))
+		mustInsert(t, s.Liquidity.Insert(ctx, &domain.LiquidityEvent{
+			CandidateID: "c1", Mint: "mintA", Pool: "poolA", TxSignature: "dropped", EventIndex: 2,
+			Slot: 200, Timestamp: 2000, EventType: domain.LiquidityEventAdd, AmountToken: 1, AmountQuote: 1, LiquidityAfter: 1,
+		}))
+	}
+
+	t.Run("UnverifiedAndCount", func(t *testing.T) {
+		s := newStores(t, "c1")
+		seed(t, s)
+
+		pending, err := s.Finality.GetUnverified(ctx, 0, math.MaxInt64, 0)
+		if err != nil {
+			t.Fatalf("GetUnverified: %v", err)
+		}
+		if len(pending) != 2 || pending[0].TxSignature != "kept" || pending[1].TxSignature != "dropped" {
+			t.Fatalf("expected [kept dropped] ordered by slot, got %+v", pending)
+		}
+		if pending[1].Slot != 200 || pending[1].Timestamp != 2000 {
+			t.Errorf("unexpected slot/timestamp for dropped: %+v", pending[1])
+		}
+
+		// Time range is [start, end) and limit caps the result
+		inRange, err := s.Finality.GetUnverified(ctx, 1000, 2000, 0)
+		if err != nil {
+			t.Fatalf("GetUnverified: %v", err)
+		}
+		if len(inRange) != 1 || inRange[0].TxSignature != "kept" {
+			t.Errorf("expected [kept] in [1000, 2000), got %+v", inRange)
+		}
+		limited, err := s.Finality.GetUnverified(ctx, 0, math.MaxInt64, 1)
+		if err != nil {
+			t.Fatalf("GetUnverified: %v", err)
+		}
+		if len(limited) != 1 {
+			t.Errorf("expected 1 signature with limit 1, got %d", len(limited))
+		}
+
+		count, err := s.Finality.CountUnverified(ctx)
+		if err != nil {
+			t.Fatalf("CountUnverified: %v", err)
+		}
+		if count != 4 {
+			t.Errorf("expected 4 unverified events, got %d", count)
+		}
+
+		if err := s.Finality.MarkFinalized(ctx, []string{"kept", "kept"}, 5000); err != nil {
+			t.Fatalf("MarkFinalized: %v", err)
+		}
+		if err := s.Finality.MarkFinalized(ctx, []string{"kept"}, 6000); err != nil {
+			t.Fatalf("MarkFinalized again: %v", err)
+		}
+		count, err = s.Finality.CountUnverified(ctx)
+		if err != nil {
+			t.Fatalf("CountUnverified: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("expected 3 unverified events after finalizing kept, got %d", count)
+		}
+	})
+
+	t.Run("RemoveDropped", func(t *testing.T) {
+		s := newStores(t, "c1")
+		seed(t, s)
+
+		corrections, err := s.Finality.RemoveDropped(ctx, "dropped", 9000)
+		if err != nil {
+			t.Fatalf("RemoveDropped: %v", err)
+		}
+		want := []storage.FinalityCorrection{
+			{EventTable: storage.EventTableLiquidityEvents, TxSignature: "dropped", EventIndex: 2, CandidateID: "c1", Mint: "mintA", Slot: 200, Timestamp: 2000, DetectedAt: 9000},
+			{EventTable: storage.EventTableSwapEvents, TxSignature: "dropped", EventIndex: 1, Mint: "mintA", Slot: 200, Timestamp: 2000, DetectedAt: 9000},
+			{EventTable: storage.EventTableSwaps, TxSignature: "dropped", EventIndex: 0, CandidateID: "c1", Slot: 200, Timestamp: 2000, DetectedAt: 9000},
+		}
+		assertCorrections(t, corrections, want)
+
+		swaps, err := s.Swaps.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("GetByCandidateID: %v", err)
+		}
+		if len(swaps) != 1 || swaps[0].TxSignature != "kept" {
+			t.Errorf("expected only swap kept to remain, got %d swaps", len(swaps))
+		}
+		events, err := s.SwapEvents.GetByTimeRange(ctx, 0, math.MaxInt64)
+		if err != nil {
+			t.Fatalf("GetByTimeRange: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected no swap events, got %d", len(events))
+		}
+		liq, err := s.Liquidity.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("GetByCandidateID: %v", err)
+		}
+		if len(liq) != 0 {
+			t.Errorf("expected no liquidity events, got %d", len(liq))
+		}
+
+		audit, err := s.Finality.GetCorrections(ctx, 9000, 9001)
+		if err != nil {
+			t.Fatalf("GetCorrections: %v", err)
+		}
+		assertCorrections(t, audit, want)
+		outside, err := s.Finality.GetCorrections(ctx, 0, 9000)
+		if err != nil {
+			t.Fatalf("GetCorrections: %v", err)
+		}
+		if len(outside) != 0 {
+			t.Errorf("expected no corrections before 9000, got %d", len(outside))
+		}
+
+		// A dropped event can be re-ingested if the transaction lands later
+		mustInsert(t, s.SwapEvents.Insert(ctx, &domain.SwapEvent{Mint: "mintA", TxSignature: "dropped", EventIndex: 1, Slot: 210, Timestamp: 2100, AmountOut: 10}))
+
+		none, err := s.Finality.RemoveDropped(ctx, "unknown", 9000)
+		if err != nil {
+			t.Fatalf("RemoveDropped unknown: %v", err)
+		}
+		if len(none) != 0 {
+			t.Errorf("expected no corrections for unknown signature, got %d", len(none))
+		}
+	})
+}
+
+func assertCorrections(t *testing.T, got []*storage.FinalityCorrection, want []storage.FinalityCorrection) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d corrections, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Errorf("correction %d: got %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+}
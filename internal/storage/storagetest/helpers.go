@@ -0,0 +1,22 @@
+package storagetest
+
+import (
+	"strings"
+	"testing"
+)
+
+// mustInsert fails the test on a setup insert error.
+func mustInsert(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+}
+
+// assertIDs compares result identifiers in order. Nil and empty results are equivalent.
+func assertIDs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if strings.Join(got, ",") != strings.Join(want, ",") || len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
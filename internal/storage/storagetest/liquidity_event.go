@@ -0,0 +1,222 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// LiquidityEventStoreFactory returns an empty store. Backends with a foreign key
+// to token_candidates must create candidateIDs before returning.
+type LiquidityEventStoreFactory func(t *testing.T, candidateIDs ...string) storage.LiquidityEventStore
+
+// RunLiquidityEventStoreSuite runs the LiquidityEventStore contract against fresh stores from newStore.
+func RunLiquidityEventStoreSuite(t *testing.T, newStore LiquidityEventStoreFactory) {
+	event := func(candidateID, mint, sig string, ts int64) *domain.LiquidityEvent {
+		return &domain.LiquidityEvent{
+			CandidateID:    candidateID,
+			Mint:           mint,
+			Pool:           "pool-" + mint,
+			TxSignature:    sig,
+			Slot:           ts / 400,
+			Timestamp:      ts,
+			EventType:      domain.LiquidityEventAdd,
+			AmountToken:    1,
+			AmountQuote:    1,
+			LiquidityAfter: 2,
+		}
+	}
+	sigs := func(events []*domain.LiquidityEvent) []string {
+		out := make([]string, len(events))
+		for i, e := range events {
+			out[i] = e.TxSignature
+		}
+		return out
+	}
+
+	t.Run("Duplicates", func(t *testing.T) {
+		LiquidityEventStoreDuplicates(t, newStore(t, "cand1", "cand2"))
+	})
+
+	t.Run("CandidateTimeRangeInclusive", func(t *testing.T) {
+		store := newStore(t, "c1", "c2")
+		ctx := context.Background()
+		mustInsert(t, store.InsertBulk(ctx, []*domain.LiquidityEvent{
+			event("c1", "mintA", "tx1", 1000),
+			event("c1", "mintA", "tx2", 2000),
+			event("c1", "mintA", "tx3", 3000),
+			event("c2", "mintB", "tx4", 2000),
+		}))
+
+		got, err := store.GetByTimeRange(ctx, "c1", 1000, 2000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, sigs(got), "tx1", "tx2")
+	})
+
+	t.Run("MintTimeRangeExclusiveEnd", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		mustInsert(t, store.InsertBulk(ctx, []*domain.LiquidityEvent{
+			event("c1", "mintA", "tx1", 1000),
+			event("c1", "mintA", "tx2", 2000),
+		}))
+
+		got, err := store.GetByMintTimeRange(ctx, "mintA", 1000, 2000)
+		if err != nil {
+			t.Fatalf("get by mint time range: %v", err)
+		}
+		assertIDs(t, sigs(got), "tx1")
+	})
+
+	t.Run("OrderedByTimestamp", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		for _, ts := range []int64{3000, 1000, 2000} {
+			mustInsert(t, store.Insert(ctx, event("c1", "mintA", fmt.Sprintf("tx%d", ts), ts)))
+		}
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertIDs(t, sigs(got), "tx1000", "tx2000", "tx3000")
+	})
+
+	t.Run("DeferredAssociation", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		pending := event("", "mintA", "tx2", 2000)
+		mustInsert(t, store.Insert(ctx, pending))
+		mustInsert(t, store.Insert(ctx, event("", "mintA", "tx1", 1000)))
+		mustInsert(t, store.Insert(ctx, event("c1", "mintA", "tx3", 3000)))
+
+		unassociated, err := store.GetUnassociated(ctx)
+		if err != nil {
+			t.Fatalf("get unassociated: %v", err)
+		}
+		assertIDs(t, sigs(unassociated), "tx1", "tx2")
+
+		if err := store.UpdateCandidateID(ctx, pending, "c1"); err != nil {
+			t.Fatalf("associate: %v", err)
+		}
+		// Already associated rows no longer match
+		if err := store.UpdateCandidateID(ctx, pending, "c1"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("re-associate: expected ErrNotFound, got %v", err)
+		}
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertIDs(t, sigs(got), "tx2", "tx3")
+	})
+
+	t.Run("EstimatedRoundTrip", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		estimated := event("c1", "mintA", "tx2", 2000)
+		estimated.Estimated = true
+		mustInsert(t, store.InsertBulk(ctx, []*domain.LiquidityEvent{event("c1", "mintA", "tx1", 1000), estimated}))
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		if len(got) != 2 || got[0].Estimated || !got[1].Estimated {
+			t.Errorf("expected estimated flag only on tx2, got %+v", got)
+		}
+	})
+
+	t.Run("DEXRoundTrip", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		labeled := event("c1", "mintA", "tx2", 2000)
+		labeled.DEX = domain.DEXPumpFun
+		mustInsert(t, store.InsertBulk(ctx, []*domain.LiquidityEvent{event("c1", "mintA", "tx1", 1000), labeled}))
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		if len(got) != 2 || got[0].DEX != "" || got[1].DEX != domain.DEXPumpFun {
+			t.Errorf("expected DEX %q only on tx2, got %+v", domain.DEXPumpFun, got)
+		}
+	})
+
+	t.Run("OriginRoundTrip", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		backfilled := event("c1", "mintA", "tx2", 2000)
+		backfilled.Origin = domain.OriginBackfill
+		mustInsert(t, store.InsertBulk(ctx, []*domain.LiquidityEvent{event("c1", "mintA", "tx1", 1000), backfilled}))
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		if len(got) != 2 || got[0].Origin != domain.OriginUnknown || got[1].Origin != domain.OriginBackfill {
+			t.Errorf("expected origin %q on tx1 and %q on tx2, got %+v", domain.OriginUnknown, domain.OriginBackfill, got)
+		}
+	})
+
+	t.Run("EventTypeNormalized", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+		deposit := event("c1", "mintA", "tx1", 1000)
+		deposit.EventType = "Deposit"
+		withdraw := event("c1", "mintA", "tx2", 2000)
+		withdraw.EventType = "REMOVE"
+		mustInsert(t, store.InsertBulk(ctx, []*domain.LiquidityEvent{deposit}))
+		mustInsert(t, store.Insert(ctx, withdraw))
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		if len(got) != 2 || got[0].EventType != domain.LiquidityEventAdd || got[1].EventType != domain.LiquidityEventRemove {
+			t.Errorf("expected canonical add/remove, got %+v", got)
+		}
+
+		// An unknown type rejects the whole batch
+		unknown := event("c1", "mintA", "tx3", 3000)
+		unknown.EventType = "burn"
+		err = store.InsertBulk(ctx, []*domain.LiquidityEvent{event("c1", "mintA", "tx4", 4000), unknown})
+		var typeErr *domain.UnknownLiquidityEventTypeError
+		if !errors.Is(err, storage.ErrInvalidInput) || !errors.As(err, &typeErr) {
+			t.Errorf("expected ErrInvalidInput with UnknownLiquidityEventTypeError, got %v", err)
+		}
+		if err := store.Insert(ctx, unknown); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("insert: expected ErrInvalidInput, got %v", err)
+		}
+		if got, _ := store.GetByCandidateID(ctx, "c1"); len(got) != 2 {
+			t.Errorf("expected the rejected batch not stored, got %d events", len(got))
+		}
+	})
+
+	t.Run("EmptyResults", func(t *testing.T) {
+		store := newStore(t, "c1")
+		ctx := context.Background()
+
+		got, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertIDs(t, sigs(got))
+
+		got, err = store.GetUnassociated(ctx)
+		if err != nil {
+			t.Fatalf("get unassociated: %v", err)
+		}
+		assertIDs(t, sigs(got))
+
+		if err := store.InsertBulk(ctx, nil); err != nil {
+			t.Errorf("empty bulk insert: expected no error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// PoolLinkStoreFactory returns an empty store. It is called once per subtest.
+type PoolLinkStoreFactory func(t *testing.T) storage.PoolLinkStore
+
+// RunPoolLinkStoreSuite runs the PoolLinkStore contract against fresh stores from newStore.
+func RunPoolLinkStoreSuite(t *testing.T, newStore PoolLinkStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("InsertAndGetByPool", func(t *testing.T) {
+		s := newStore(t)
+		link := &domain.PoolLink{
+			Pool: "curve-a", Mint: "mint-a", CandidateID: "cand-a", DEX: "pumpfun",
+			Reason: domain.PoolLinkDiscovery, TxSignature: "tx-1", ValidFrom: 1000, CreatedAt: 1000,
+		}
+		mustInsert(t, s.Insert(ctx, link))
+
+		got, err := s.GetByPool(ctx, "curve-a")
+		if err != nil {
+			t.Fatalf("GetByPool: %v", err)
+		}
+		if got.CandidateID != "cand-a" || got.Reason != domain.PoolLinkDiscovery || got.ValidTo != nil {
+			t.Errorf("unexpected link %+v", *got)
+		}
+
+		if err := s.Insert(ctx, link); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("expected ErrDuplicateKey, got %v", err)
+		}
+		if _, err := s.GetByPool(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("CloseSetsValidTo", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Insert(ctx, &domain.PoolLink{Pool: "curve-a", Mint: "mint-a", CandidateID: "cand-a", ValidFrom: 1000}))
+
+		if err := s.Close(ctx, "curve-a", 5000); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		got, err := s.GetByPool(ctx, "curve-a")
+		if err != nil {
+			t.Fatalf("GetByPool: %v", err)
+		}
+		if got.ValidTo == nil || *got.ValidTo != 5000 {
+			t.Fatalf("expected valid_to 5000, got %v", got.ValidTo)
+		}
+		if !got.Covers(4999) || got.Covers(5000) || got.Covers(999) {
+			t.Errorf("unexpected coverage for [1000, 5000)")
+		}
+
+		// A closed link cannot be closed again
+		if err := s.Close(ctx, "curve-a", 6000); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound closing a closed link, got %v", err)
+		}
+		if err := s.Close(ctx, "missing", 6000); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("GetByMintOrdered", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Insert(ctx, &domain.PoolLink{Pool: "raydium-a", Mint: "mint-a", CandidateID: "cand-a", ValidFrom: 5000}))
+		mustInsert(t, s.Insert(ctx, &domain.PoolLink{Pool: "curve-a", Mint: "mint-a", CandidateID: "cand-a", ValidFrom: 1000}))
+		mustInsert(t, s.Insert(ctx, &domain.PoolLink{Pool: "curve-b", Mint: "mint-b", CandidateID: "cand-b", ValidFrom: 500}))
+
+		got, err := s.GetByMint(ctx, "mint-a")
+		if err != nil {
+			t.Fatalf("GetByMint: %v", err)
+		}
+		if len(got) != 2 || got[0].Pool != "curve-a" || got[1].Pool != "raydium-a" {
+			t.Fatalf("expected curve-a then raydium-a, got %+v", got)
+		}
+
+		all, err := s.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		if len(all) != 3 || all[0].Pool != "curve-a" || all[2].Pool != "curve-b" {
+			t.Errorf("expected links ordered by mint, got %+v", all)
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t)
+		for _, link := range []*domain.PoolLink{
+			nil,
+			{Mint: "mint-a", CandidateID: "cand-a"},
+			{Pool: "pool-a", CandidateID: "cand-a"},
+			{Pool: "pool-a", Mint: "mint-a"},
+		} {
+			if err := s.Insert(ctx, link); !errors.Is(err, storage.ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput for %+v, got %v", link, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// ProvisionalMintStoreFactory returns an empty store. It is called once per subtest.
+type ProvisionalMintStoreFactory func(t *testing.T) storage.ProvisionalMintStore
+
+// provisionalMint builds a mint created at createdAt.
+func provisionalMint(mint string, createdAt int64) *domain.ProvisionalMint {
+	return &domain.ProvisionalMint{
+		Mint:        mint,
+		TxSignature: "sig-" + mint,
+		Slot:        createdAt / 400,
+		CreatedAt:   createdAt,
+	}
+}
+
+// provisionalMints returns the mint addresses of ms in order.
+func provisionalMints(ms []*domain.ProvisionalMint) []string {
+	out := make([]string, len(ms))
+	for i, m := range ms {
+		out[i] = m.Mint
+	}
+	return out
+}
+
+// RunProvisionalMintStoreSuite runs the ProvisionalMintStore contract against fresh stores from newStore.
+func RunProvisionalMintStoreSuite(t *testing.T, newStore ProvisionalMintStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("InsertAndGet", func(t *testing.T) {
+		s := newStore(t)
+		detectedAt := int64(1500)
+		m := provisionalMint("mintA", 1000)
+		m.DetectedAt = &detectedAt
+		m.Status = domain.ProvisionalMintUpgraded // ignored: inserted mints are PENDING
+		mustInsert(t, s.Insert(ctx, m))
+
+		if err := s.Insert(ctx, provisionalMint("mintA", 2000)); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("expected ErrDuplicateKey, got %v", err)
+		}
+
+		got, err := s.GetByMint(ctx, "mintA")
+		if err != nil {
+			t.Fatalf("GetByMint: %v", err)
+		}
+		if got.Status != domain.ProvisionalMintPending || got.CreatedAt != 1000 || got.TxSignature != "sig-mintA" ||
+			got.DetectedAt == nil || *got.DetectedAt != 1500 || got.CandidateID != nil || got.ResolvedAt != nil {
+			t.Errorf("unexpected mint: %+v", got)
+		}
+
+		if _, err := s.GetByMint(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Upgrade", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Insert(ctx, provisionalMint("mintA", 1000)))
+
+		if err := s.Upgrade(ctx, "mintA", "cand-a", 4000); err != nil {
+			t.Fatalf("Upgrade: %v", err)
+		}
+		got, err := s.GetByMint(ctx, "mintA")
+		if err != nil {
+			t.Fatalf("GetByMint: %v", err)
+		}
+		if got.Status != domain.ProvisionalMintUpgraded || got.CandidateID == nil || *got.CandidateID != "cand-a" {
+			t.Errorf("unexpected upgraded mint: %+v", got)
+		}
+		if delay, ok := got.PoolDelayMs(); !ok || delay != 3000 {
+			t.Errorf("expected pool delay 3000, got %d (ok=%v)", delay, ok)
+		}
+
+		// Only PENDING mints upgrade
+		if err := s.Upgrade(ctx, "mintA", "cand-b", 5000); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound upgrading twice, got %v", err)
+		}
+		if err := s.Upgrade(ctx, "missing", "cand-c", 5000); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound for unknown mint, got %v", err)
+		}
+	})
+
+	t.Run("ExpirePending", func(t *testing.T) {
+		s := newStore(t)
+		mustInsert(t, s.Insert(ctx, provisionalMint("old", 1000)))
+		mustInsert(t, s.Insert(ctx, provisionalMint("older", 500)))
+		mustInsert(t, s.Insert(ctx, provisionalMint("upgraded", 600)))
+		mustInsert(t, s.Insert(ctx, provisionalMint("fresh", 3000)))
+		if err := s.Upgrade(ctx, "upgraded", "cand-u", 800); err != nil {
+			t.Fatalf("Upgrade: %v", err)
+		}
+
+		n, err := s.ExpirePending(ctx, 2000, 9000)
+		if err != nil {
+			t.Fatalf("ExpirePending: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("expected 2 expired, got %d", n)
+		}
+
+		expired, err := s.GetByStatus(ctx, domain.ProvisionalMintExpired)
+		if err != nil {
+			t.Fatalf("GetByStatus: %v", err)
+		}
+		assertIDs(t, provisionalMints(expired), "older", "old")
+		if expired[0].ResolvedAt == nil || *expired[0].ResolvedAt != 9000 {
+			t.Errorf("expected expiry time 9000, got %+v", expired[0])
+		}
+
+		pending, _ := s.GetByStatus(ctx, domain.ProvisionalMintPending)
+		assertIDs(t, provisionalMints(pending), "fresh")
+		upgraded, _ := s.GetByStatus(ctx, domain.ProvisionalMintUpgraded)
+		assertIDs(t, provisionalMints(upgraded), "upgraded")
+
+		// Expired mints no longer upgrade
+		if err := s.Upgrade(ctx, "old", "cand-late", 9500); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound upgrading an expired mint, got %v", err)
+		}
+
+		// Expiring again finds nothing
+		if n, _ := s.ExpirePending(ctx, 2000, 9100); n != 0 {
+			t.Errorf("expected 0 expired on second pass, got %d", n)
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t)
+		if err := s.Insert(ctx, nil); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for nil, got %v", err)
+		}
+		if err := s.Insert(ctx, provisionalMint("", 1000)); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for empty mint, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// RawTxStoreFactory returns an empty archive capped at maxBytes. It is called once per subtest.
+type RawTxStoreFactory func(t *testing.T, maxBytes int64) storage.RawTxStore
+
+// rawTx builds an archived transaction whose Data is compact JSON of exactly size bytes (size >= 10).
+func rawTx(sig string, slot int64, size int) *storage.RawTransaction {
+	data := fmt.Sprintf(`{"pad":"%s"}`, strings.Repeat("x", size-len(`{"pad":""}`)))
+	return &storage.RawTransaction{TxSignature: sig, Slot: slot, BlockTime: slot * 10, Data: []byte(data), ArchivedAt: slot * 1000}
+}
+
+// pageSigs returns the signatures of a page.
+func pageSigs(txs []*storage.RawTransaction) []string {
+	sigs := make([]string, len(txs))
+	for i, tx := range txs {
+		sigs[i] = tx.TxSignature
+	}
+	return sigs
+}
+
+// RunRawTxStoreSuite runs the RawTxStore contract against fresh archives from newStore.
+func RunRawTxStoreSuite(t *testing.T, newStore RawTxStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("PutGetAndPage", func(t *testing.T) {
+		s := newStore(t, 1<<20)
+		mustInsert(t, s.Put(ctx, rawTx("b", 20, 20)))
+		mustInsert(t, s.Put(ctx, rawTx("a", 20, 20)))
+		mustInsert(t, s.Put(ctx, rawTx("c", 10, 20)))
+
+		got, err := s.Get(ctx, "a")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		want := rawTx("a", 20, 20)
+		if got.Slot != want.Slot || got.BlockTime != want.BlockTime || got.ArchivedAt != want.ArchivedAt || string(got.Data) != string(want.Data) {
+			t.Errorf("Get(a) = %+v, want %+v", got, want)
+		}
+		if _, err := s.Get(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+
+		page, err := s.GetPage(ctx, 0, "", 2)
+		if err != nil {
+			t.Fatalf("GetPage: %v", err)
+		}
+		assertIDs(t, pageSigs(page), "c", "a")
+		page, err = s.GetPage(ctx, 20, "a", 2)
+		if err != nil {
+			t.Fatalf("GetPage: %v", err)
+		}
+		assertIDs(t, pageSigs(page), "b")
+		page, err = s.GetPage(ctx, 20, "b", 2)
+		if err != nil {
+			t.Fatalf("GetPage: %v", err)
+		}
+		assertIDs(t, pageSigs(page))
+
+		count, bytes, err := s.Stats(ctx)
+		if err != nil {
+			t.Fatalf("Stats: %v", err)
+		}
+		if count != 3 || bytes != 60 {
+			t.Errorf("expected 3 txs / 60 bytes, got %d / %d", count, bytes)
+		}
+	})
+
+	t.Run("PutReplaces", func(t *testing.T) {
+		s := newStore(t, 1<<20)
+		mustInsert(t, s.Put(ctx, rawTx("a", 10, 20)))
+		mustInsert(t, s.Put(ctx, rawTx("a", 10, 30)))
+
+		count, bytes, err := s.Stats(ctx)
+		if err != nil {
+			t.Fatalf("Stats: %v", err)
+		}
+		if count != 1 || bytes != 30 {
+			t.Errorf("expected 1 tx / 30 bytes after replace, got %d / %d", count, bytes)
+		}
+	})
+
+	t.Run("EvictsOldestFirst", func(t *testing.T) {
+		s := newStore(t, 100)
+		mustInsert(t, s.Put(ctx, rawTx("old", 10, 40)))
+		mustInsert(t, s.Put(ctx, rawTx("mid", 20, 40)))
+		mustInsert(t, s.Put(ctx, rawTx("new", 30, 40)))
+
+		page, err := s.GetPage(ctx, 0, "", 0)
+		if err != nil {
+			t.Fatalf("GetPage: %v", err)
+		}
+		assertIDs(t, pageSigs(page), "mid", "new")
+		count, bytes, err := s.Stats(ctx)
+		if err != nil {
+			t.Fatalf("Stats: %v", err)
+		}
+		if count != 2 || bytes != 80 {
+			t.Errorf("expected 2 txs / 80 bytes, got %d / %d", count, bytes)
+		}
+
+		// An older transaction archived late is the first to go
+		mustInsert(t, s.Put(ctx, rawTx("late", 5, 40)))
+		page, err = s.GetPage(ctx, 0, "", 0)
+		if err != nil {
+			t.Fatalf("GetPage: %v", err)
+		}
+		assertIDs(t, pageSigs(page), "mid", "new")
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t, 100)
+		if err := s.Put(ctx, nil); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for nil, got %v", err)
+		}
+		if err := s.Put(ctx, &storage.RawTransaction{Data: []byte(`{}`)}); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for empty signature, got %v", err)
+		}
+	})
+}
+
+// ReparseStores is a ReparseStore together with the event stores it corrects.
+type ReparseStores struct {
+	Reparse    storage.ReparseStore
+	SwapEvents storage.SwapEventStore
+	Liquidity  storage.LiquidityEventStore
+}
+
+// ReparseStoreFactory returns empty stores in which the given candidates exist. It is called once per subtest.
+type ReparseStoreFactory func(t *testing.T, candidateIDs ...string) ReparseStores
+
+// RunReparseStoreSuite runs the ReparseStore contract against fresh stores from newStores.
+func RunReparseStoreSuite(t *testing.T, newStores ReparseStoreFactory) {
+	ctx := context.Background()
+
+	swapEvent := func(idx int, amountOut float64) *domain.SwapEvent {
+		return &domain.SwapEvent{Mint: "mintA", TxSignature: "tx1", EventIndex: idx, Slot: 100, Timestamp: 1000, AmountOut: amountOut}
+	}
+	liqEvent := func(idx int, amountToken float64) *domain.LiquidityEvent {
+		return &domain.LiquidityEvent{
+			CandidateID: "c1", Mint: "mintA", Pool: "poolA", TxSignature: "tx1", EventIndex: idx,
+			Slot: 100, Timestamp: 1000, EventType: domain.LiquidityEventAdd, AmountToken: amountToken, AmountQuote: 1, LiquidityAfter: 5,
+		}
+	}
+
+	t.Run("GetTxEvents", func(t *testing.T) {
+		s := newStores(t, "c1")
+		mustInsert(t, s.SwapEvents.InsertBulk(ctx, []*domain.SwapEvent{swapEvent(2, 10), swapEvent(0, 10)}))
+		mustInsert(t, s.SwapEvents.Insert(ctx, &domain.SwapEvent{Mint: "mintA", TxSignature: "other", EventIndex: 0, Timestamp: 1000}))
+		mustInsert(t, s.Liquidity.Insert(ctx, liqEvent(1, 3)))
+
+		swaps, liquidity, err := s.Reparse.GetTxEvents(ctx, "tx1")
+		if err != nil {
+			t.Fatalf("GetTxEvents: %v", err)
+		}
+		if len(swaps) != 2 || swaps[0].EventIndex != 0 || swaps[1].EventIndex != 2 {
+			t.Errorf("expected swap events [0 2] of tx1, got %+v", swaps)
+		}
+		if len(liquidity) != 1 || liquidity[0].LiquidityAfter != 5 || liquidity[0].CandidateID != "c1" {
+			t.Errorf("expected liquidity event 1 of tx1, got %+v", liquidity)
+		}
+	})
+
+	t.Run("ApplyReplacesAndRecords", func(t *testing.T) {
+		s := newStores(t, "c1")
+		mustInsert(t, s.SwapEvents.Insert(ctx, swapEvent(0, 10)))
+		mustInsert(t, s.Liquidity.Insert(ctx, liqEvent(1, 3)))
+
+		fix := &storage.ReparseFix{
+			TxSignature:       "tx1",
+			ReplaceSwapEvents: []*domain.SwapEvent{swapEvent(0, 10)},
+			InsertSwapEvents:  []*domain.SwapEvent{swapEvent(0, 99), swapEvent(3, 7)},
+			ReplaceLiquidity:  []*domain.LiquidityEvent{liqEvent(1, 3)},
+			InsertLiquidity:   []*domain.LiquidityEvent{liqEvent(1, 4)},
+			Corrections: []*storage.ReparseCorrection{
+				{EventTable: storage.EventTableSwapEvents, TxSignature: "tx1", EventIndex: 3, Change: storage.ReparseAdded, After: `{"a":1}`, CorrectedAt: 5000},
+				{EventTable: storage.EventTableSwapEvents, TxSignature: "tx1", EventIndex: 0, Change: storage.ReparseChanged, Before: `{"a":0}`, After: `{"a":1}`, CorrectedAt: 5000},
+				{EventTable: storage.EventTableLiquidityEvents, TxSignature: "tx1", EventIndex: 1, Change: storage.ReparseChanged, Before: `{"b":0}`, After: `{"b":1}`, CorrectedAt: 5000},
+			},
+		}
+		if err := s.Reparse.Apply(ctx, fix); err != nil {
+			t.Fatalf("Apply: %v", err)
+		}
+
+		swaps, liquidity, err := s.Reparse.GetTxEvents(ctx, "tx1")
+		if err != nil {
+			t.Fatalf("GetTxEvents: %v", err)
+		}
+		if len(swaps) != 2 || swaps[0].AmountOut != 99 || swaps[1].EventIndex != 3 {
+			t.Errorf("expected corrected swap events, got %+v", swaps)
+		}
+		if len(liquidity) != 1 || liquidity[0].AmountToken != 4 {
+			t.Errorf("expected corrected liquidity event, got %+v", liquidity)
+		}
+
+		corrections, err := s.Reparse.GetCorrections(ctx, 0, 6000)
+		if err != nil {
+			t.Fatalf("GetCorrections: %v", err)
+		}
+		if len(corrections) != 3 {
+			t.Fatalf("expected 3 corrections, got %d", len(corrections))
+		}
+		if corrections[0].EventTable != storage.EventTableLiquidityEvents || corrections[1].EventIndex != 0 || corrections[2].EventIndex != 3 {
+			t.Errorf("unexpected correction order: %+v %+v %+v", corrections[0], corrections[1], corrections[2])
+		}
+		if corrections[1].Before != `{"a":0}` || corrections[1].Change != storage.ReparseChanged {
+			t.Errorf("unexpected correction: %+v", corrections[1])
+		}
+		if none, err := s.Reparse.GetCorrections(ctx, 5001, 6000); err != nil || len(none) != 0 {
+			t.Errorf("expected no corrections after 5000, got %d (%v)", len(none), err)
+		}
+	})
+
+	t.Run("ApplyConflictChangesNothing", func(t *testing.T) {
+		s := newStores(t, "c1")
+		mustInsert(t, s.SwapEvents.InsertBulk(ctx, []*domain.SwapEvent{swapEvent(0, 10), swapEvent(1, 10)}))
+
+		err := s.Reparse.Apply(ctx, &storage.ReparseFix{
+			TxSignature:       "tx1",
+			ReplaceSwapEvents: []*domain.SwapEvent{swapEvent(0, 10)},
+			InsertSwapEvents:  []*domain.SwapEvent{swapEvent(0, 20), swapEvent(1, 20)},
+		})
+		if err == nil {
+			t.Fatal("expected error inserting over a kept event")
+		}
+
+		swaps, _, err := s.Reparse.GetTxEvents(ctx, "tx1")
+		if err != nil {
+			t.Fatalf("GetTxEvents: %v", err)
+		}
+		if len(swaps) != 2 || swaps[0].AmountOut != 10 || swaps[1].AmountOut != 10 {
+			t.Errorf("expected events unchanged after failed apply, got %+v", swaps)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"solana-token-lab/internal/storage"
+)
+
+// RunManifestStoreFactory returns an empty store. It is called once per subtest.
+type RunManifestStoreFactory func(t *testing.T) storage.RunManifestStore
+
+// runManifest builds a finished manifest with every field set.
+func runManifest(runID, kind string, startedAt int64) *storage.RunManifest {
+	return &storage.RunManifest{
+		RunID:      runID,
+		Kind:       kind,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt + 1500,
+		GitCommit:  "abc1234",
+		Config:     map[string]string{"postgres-dsn": "postgres://lab:REDACTED@db:5432/lab", "incremental": "true"},
+		Backends: []storage.RunBackend{
+			{Name: "postgres", Version: "16.2"},
+			{Name: "clickhouse", Version: "24.3.1"},
+		},
+		Candidates:  12,
+		Trades:      48,
+		Aggregates:  8,
+		DataVersion: "v-" + runID,
+		Decision:    "INSUFFICIENT_DATA",
+		Errors:      []string{"simulate c1: no price"},
+		ErrorCount:  2,
+	}
+}
+
+// manifestIDs returns the run IDs of manifests in order.
+func manifestIDs(manifests []*storage.RunManifest) []string {
+	ids := make([]string, len(manifests))
+	for i, m := range manifests {
+		ids[i] = m.RunID
+	}
+	return ids
+}
+
+// RunRunManifestStoreSuite runs the RunManifestStore contract against fresh stores from newStore.
+func RunRunManifestStoreSuite(t *testing.T, newStore RunManifestStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		s := newStore(t)
+		want := runManifest("report-1", storage.RunKindReport, 1000)
+		mustInsert(t, s.Insert(ctx, want))
+
+		got, err := s.GetByID(ctx, "report-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("manifest differs:\ngot:  %+v\nwant: %+v", got, want)
+		}
+
+		// The returned manifest is a copy
+		got.Config["incremental"] = "false"
+		got.Backends[0].Version = "changed"
+		again, _ := s.GetByID(ctx, "report-1")
+		if again.Config["incremental"] != "true" || again.Backends[0].Version != "16.2" {
+			t.Error("modifying a returned manifest changed the store")
+		}
+
+		if _, err := s.GetByID(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("LatestAndListByKind", func(t *testing.T) {
+		s := newStore(t)
+		if _, err := s.GetLatest(ctx, ""); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound on empty store, got %v", err)
+		}
+
+		mustInsert(t, s.Insert(ctx, runManifest("pipeline-2", storage.RunKindPipeline, 2000)))
+		mustInsert(t, s.Insert(ctx, runManifest("pipeline-1", storage.RunKindPipeline, 1000))) // older run inserted last
+		mustInsert(t, s.Insert(ctx, runManifest("report-3", storage.RunKindReport, 3000)))
+		mustInsert(t, s.Insert(ctx, runManifest("pipeline-3", storage.RunKindPipeline, 3000))) // tie: last inserted wins
+
+		latest, err := s.GetLatest(ctx, "")
+		if err != nil || latest.RunID != "pipeline-3" {
+			t.Errorf("GetLatest(any): expected pipeline-3, got %v, %v", latest, err)
+		}
+		latest, err = s.GetLatest(ctx, storage.RunKindReport)
+		if err != nil || latest.RunID != "report-3" {
+			t.Errorf("GetLatest(report): expected report-3, got %v, %v", latest, err)
+		}
+
+		all, err := s.List(ctx, "", 0)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertIDs(t, manifestIDs(all), "pipeline-3", "report-3", "pipeline-2", "pipeline-1")
+
+		pipelines, err := s.List(ctx, storage.RunKindPipeline, 2)
+		if err != nil {
+			t.Fatalf("List(pipeline, 2): %v", err)
+		}
+		assertIDs(t, manifestIDs(pipelines), "pipeline-3", "pipeline-2")
+	})
+
+	t.Run("InvalidInputAndDuplicates", func(t *testing.T) {
+		s := newStore(t)
+		if err := s.Insert(ctx, nil); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("nil: expected ErrInvalidInput, got %v", err)
+		}
+		if err := s.Insert(ctx, runManifest("", storage.RunKindReport, 1000)); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("empty run ID: expected ErrInvalidInput, got %v", err)
+		}
+		mustInsert(t, s.Insert(ctx, runManifest("report-1", storage.RunKindReport, 1000)))
+		if err := s.Insert(ctx, runManifest("report-1", storage.RunKindReport, 2000)); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("expected ErrDuplicateKey, got %v", err)
+		}
+	})
+}
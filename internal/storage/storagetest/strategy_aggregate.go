@@ -0,0 +1,136 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// StrategyAggregateStoreFactory returns an empty store. It is called once per subtest.
+type StrategyAggregateStoreFactory func(t *testing.T) storage.StrategyAggregateStore
+
+// RunStrategyAggregateFindSuite runs the StrategyAggregateStore.Find contract against
+// fresh stores from newStore.
+func RunStrategyAggregateFindSuite(t *testing.T, newStore StrategyAggregateStoreFactory) {
+	agg := func(strategyID, scenarioID, entryEventType string, computedAt int64) *domain.StrategyAggregate {
+		return &domain.StrategyAggregate{
+			StrategyID:     strategyID,
+			ScenarioID:     scenarioID,
+			EntryEventType: entryEventType,
+			TotalTrades:    1,
+			ComputedAt:     computedAt,
+		}
+	}
+	key := func(aggs []*domain.StrategyAggregate) []string {
+		out := make([]string, len(aggs))
+		for i, a := range aggs {
+			out[i] = a.StrategyID + "/" + a.ScenarioID + "/" + a.EntryEventType
+		}
+		return out
+	}
+	seed := func(t *testing.T) storage.StrategyAggregateStore {
+		store := newStore(t)
+		mustInsert(t, store.InsertBulk(context.Background(), []*domain.StrategyAggregate{
+			agg("TRAILING_STOP", domain.ScenarioRealistic, "NEW_TOKEN", 3000),
+			agg("TIME_EXIT", domain.ScenarioRealistic, "NEW_TOKEN", 1000),
+			agg("TIME_EXIT", domain.ScenarioPessimistic, "NEW_TOKEN", 2000),
+			agg("TIME_EXIT", domain.ScenarioRealistic, "ACTIVE_TOKEN", 1000),
+			agg("TIME_EXIT", domain.ScenarioRealistic, "NEW_TOKEN:token_age_lt_10m", 4000),
+			agg("TIME_EXIT", domain.ScenarioRealistic, "ACTIVE_TOKEN:token_age_lt_10m", 0),
+		}))
+		return store
+	}
+
+	tests := []struct {
+		name   string
+		filter storage.AggregateFilter
+		want   []string
+	}{
+		{
+			name:   "Empty",
+			filter: storage.AggregateFilter{},
+			want: []string{
+				"TIME_EXIT/pessimistic/NEW_TOKEN",
+				"TIME_EXIT/realistic/ACTIVE_TOKEN",
+				"TIME_EXIT/realistic/ACTIVE_TOKEN:token_age_lt_10m",
+				"TIME_EXIT/realistic/NEW_TOKEN",
+				"TIME_EXIT/realistic/NEW_TOKEN:token_age_lt_10m",
+				"TRAILING_STOP/realistic/NEW_TOKEN",
+			},
+		},
+		{
+			name:   "Strategy",
+			filter: storage.AggregateFilter{StrategyID: "TRAILING_STOP"},
+			want:   []string{"TRAILING_STOP/realistic/NEW_TOKEN"},
+		},
+		{
+			name:   "Scenario",
+			filter: storage.AggregateFilter{ScenarioID: domain.ScenarioPessimistic},
+			want:   []string{"TIME_EXIT/pessimistic/NEW_TOKEN"},
+		},
+		{
+			name:   "EntryEventTypeIncludesCohorts",
+			filter: storage.AggregateFilter{EntryEventType: "ACTIVE_TOKEN"},
+			want: []string{
+				"TIME_EXIT/realistic/ACTIVE_TOKEN",
+				"TIME_EXIT/realistic/ACTIVE_TOKEN:token_age_lt_10m",
+			},
+		},
+		{
+			name:   "Cohort",
+			filter: storage.AggregateFilter{Cohort: "token_age_lt_10m"},
+			want: []string{
+				"TIME_EXIT/realistic/ACTIVE_TOKEN:token_age_lt_10m",
+				"TIME_EXIT/realistic/NEW_TOKEN:token_age_lt_10m",
+			},
+		},
+		{
+			name:   "ComputedAfter",
+			filter: storage.AggregateFilter{ComputedAfter: 2000},
+			want: []string{
+				"TIME_EXIT/realistic/NEW_TOKEN:token_age_lt_10m",
+				"TRAILING_STOP/realistic/NEW_TOKEN",
+			},
+		},
+		{
+			name: "Combined",
+			filter: storage.AggregateFilter{
+				StrategyID:     "TIME_EXIT",
+				ScenarioID:     domain.ScenarioRealistic,
+				EntryEventType: "NEW_TOKEN",
+				Cohort:         "token_age_lt_10m",
+				ComputedAfter:  1000,
+			},
+			want: []string{"TIME_EXIT/realistic/NEW_TOKEN:token_age_lt_10m"},
+		},
+		{
+			name:   "NoMatch",
+			filter: storage.AggregateFilter{StrategyID: "TIME_EXIT", Cohort: "liquidity_gte_100"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := seed(t)
+			got, err := store.Find(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("find: %v", err)
+			}
+			assertIDs(t, key(got), tt.want...)
+		})
+	}
+
+	t.Run("RoundTripsComputedAt", func(t *testing.T) {
+		store := seed(t)
+		got, err := store.GetByKey(context.Background(), "TRAILING_STOP", domain.ScenarioRealistic, "NEW_TOKEN")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.ComputedAt != 3000 {
+			t.Errorf("expected ComputedAt 3000, got %d", got.ComputedAt)
+		}
+	})
+}
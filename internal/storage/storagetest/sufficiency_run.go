@@ -0,0 +1,89 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"solana-token-lab/internal/storage"
+)
+
+// SufficiencyRunStoreFactory returns an empty store. It is called once per subtest.
+type SufficiencyRunStoreFactory func(t *testing.T) storage.SufficiencyRunStore
+
+// sufficiencyRun builds a run with one passing and one failing check.
+func sufficiencyRun(runAt int64, dataVersion string) *storage.SufficiencyRun {
+	return &storage.SufficiencyRun{
+		RunAt:       runAt,
+		DataVersion: dataVersion,
+		Checks: []storage.SufficiencyCheckRecord{
+			{Name: "unique_new_token_candidates", Threshold: ">= 300", Actual: "312", Pass: true},
+			{Name: "discovery_uptime_days", Threshold: ">= 7", Actual: "5.5", Pass: false},
+		},
+		Errors:     []string{"missing candidate c1"},
+		ErrorCount: 3,
+	}
+}
+
+// RunSufficiencyRunStoreSuite runs the SufficiencyRunStore contract against fresh stores from newStore.
+func RunSufficiencyRunStoreSuite(t *testing.T, newStore SufficiencyRunStoreFactory) {
+	ctx := context.Background()
+
+	t.Run("LatestOfSeveral", func(t *testing.T) {
+		s := newStore(t)
+		if _, err := s.GetLatest(ctx); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound on empty store, got %v", err)
+		}
+
+		first := sufficiencyRun(1000, "v-first")
+		second := sufficiencyRun(2000, "v-second")
+		second.AllPass = true
+		second.Checks[1].Pass = true
+		second.Errors = nil
+		second.ErrorCount = 0
+		mustInsert(t, s.Insert(ctx, second))
+		mustInsert(t, s.Insert(ctx, first)) // older run inserted last
+
+		got, err := s.GetLatest(ctx)
+		if err != nil {
+			t.Fatalf("GetLatest: %v", err)
+		}
+		if got.RunAt != 2000 || got.DataVersion != "v-second" || !got.AllPass || got.ErrorCount != 0 || len(got.Errors) != 0 {
+			t.Errorf("unexpected latest run: %+v", got)
+		}
+		if !reflect.DeepEqual(got.Checks, second.Checks) {
+			t.Errorf("checks differ:\ngot:  %+v\nwant: %+v", got.Checks, second.Checks)
+		}
+	})
+
+	t.Run("RoundTripAndTies", func(t *testing.T) {
+		s := newStore(t)
+		a := sufficiencyRun(5000, "v-a")
+		b := sufficiencyRun(5000, "v-b")
+		mustInsert(t, s.Insert(ctx, a))
+		mustInsert(t, s.Insert(ctx, b))
+
+		got, err := s.GetLatest(ctx)
+		if err != nil {
+			t.Fatalf("GetLatest: %v", err)
+		}
+		if !reflect.DeepEqual(got, b) {
+			t.Errorf("expected the last inserted of equal run_at:\ngot:  %+v\nwant: %+v", got, b)
+		}
+
+		// The returned run is a copy
+		got.Checks[0].Pass = false
+		again, _ := s.GetLatest(ctx)
+		if !again.Checks[0].Pass {
+			t.Error("modifying a returned run changed the store")
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		s := newStore(t)
+		if err := s.Insert(ctx, nil); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// SwapEventStoreFactory returns an empty store. It is called once per subtest.
+type SwapEventStoreFactory func(t *testing.T) storage.SwapEventStore
+
+// RunSwapEventStoreSuite runs the SwapEventStore contract against fresh stores from newStore.
+func RunSwapEventStoreSuite(t *testing.T, newStore SwapEventStoreFactory) {
+	event := func(mint, sig string, idx int, ts int64) *domain.SwapEvent {
+		return &domain.SwapEvent{Mint: mint, TxSignature: sig, EventIndex: idx, Slot: ts / 400, Timestamp: ts, AmountOut: 1}
+	}
+	keys := func(events []*domain.SwapEvent) []string {
+		out := make([]string, len(events))
+		for i, e := range events {
+			out[i] = fmt.Sprintf("%s/%s/%d", e.Mint, e.TxSignature, e.EventIndex)
+		}
+		return out
+	}
+
+	t.Run("Duplicates", func(t *testing.T) {
+		SwapEventStoreDuplicates(t, newStore(t))
+	})
+
+	t.Run("TimeRangeExclusiveEnd", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.InsertBulk(ctx, []*domain.SwapEvent{
+			event("mintA", "tx1", 0, 1000),
+			event("mintA", "tx2", 0, 2000),
+			event("mintB", "tx3", 0, 3000),
+		}))
+
+		got, err := store.GetByTimeRange(ctx, 1000, 3000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, keys(got), "mintA/tx1/0", "mintA/tx2/0")
+
+		got, err = store.GetByMintTimeRange(ctx, "mintA", 1001, 2000)
+		if err != nil {
+			t.Fatalf("get by mint time range: %v", err)
+		}
+		assertIDs(t, keys(got))
+
+		mints, err := store.GetDistinctMintsByTimeRange(ctx, 1000, 3001)
+		if err != nil {
+			t.Fatalf("get distinct mints: %v", err)
+		}
+		assertIDs(t, mints, "mintA", "mintB")
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		// Ties on timestamp break by mint, then tx_signature, then event_index
+		mustInsert(t, store.InsertBulk(ctx, []*domain.SwapEvent{
+			event("mintB", "tx1", 0, 1000),
+			event("mintA", "tx2", 1, 1000),
+			event("mintA", "tx2", 0, 1000),
+			event("mintA", "tx1", 0, 2000),
+			event("mintA", "tx9", 0, 500),
+		}))
+
+		got, err := store.GetByTimeRange(ctx, 0, 5000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, keys(got), "mintA/tx9/0", "mintA/tx2/0", "mintA/tx2/1", "mintB/tx1/0", "mintA/tx1/0")
+	})
+
+	t.Run("DEXRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		labeled := event("mintA", "tx1", 0, 1000)
+		labeled.DEX = domain.DEXRaydium
+		mustInsert(t, store.InsertBulk(ctx, []*domain.SwapEvent{labeled, event("mintA", "tx2", 0, 2000)}))
+
+		got, err := store.GetByMintTimeRange(ctx, "mintA", 0, 5000)
+		if err != nil {
+			t.Fatalf("get by mint time range: %v", err)
+		}
+		if len(got) != 2 || got[0].DEX != domain.DEXRaydium || got[1].DEX != "" {
+			t.Errorf("expected DEX %q only on tx1, got %+v", domain.DEXRaydium, got)
+		}
+	})
+
+	t.Run("TraderRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		attributed := event("mintA", "tx1", 0, 1000)
+		attributed.Trader = "wallet1"
+		mustInsert(t, store.InsertBulk(ctx, []*domain.SwapEvent{attributed, event("mintA", "tx2", 0, 2000)}))
+
+		got, err := store.GetByMintTimeRange(ctx, "mintA", 0, 5000)
+		if err != nil {
+			t.Fatalf("get by mint time range: %v", err)
+		}
+		if len(got) != 2 || got[0].Trader != "wallet1" || got[1].Trader != "" {
+			t.Errorf("expected trader wallet1 only on tx1, got %+v", got)
+		}
+	})
+
+	t.Run("OriginRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		live := event("mintA", "tx1", 0, 1000)
+		live.Origin = domain.OriginLiveWS
+		mustInsert(t, store.InsertBulk(ctx, []*domain.SwapEvent{live, event("mintA", "tx2", 0, 2000)}))
+
+		got, err := store.GetByMintTimeRange(ctx, "mintA", 0, 5000)
+		if err != nil {
+			t.Fatalf("get by mint time range: %v", err)
+		}
+		if len(got) != 2 || got[0].Origin != domain.OriginLiveWS || got[1].Origin != domain.OriginUnknown {
+			t.Errorf("expected origin %q on tx1 and %q on tx2, got %+v", domain.OriginLiveWS, domain.OriginUnknown, got)
+		}
+	})
+
+	t.Run("GlobalTimeRange", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		minTs, maxTs, err := store.GetGlobalTimeRange(ctx)
+		if err != nil {
+			t.Fatalf("empty global time range: %v", err)
+		}
+		if minTs != 0 || maxTs != 0 {
+			t.Errorf("empty store: expected (0, 0), got (%d, %d)", minTs, maxTs)
+		}
+
+		mustInsert(t, store.InsertBulk(ctx, []*domain.SwapEvent{
+			event("mintA", "tx1", 0, 2000),
+			event("mintB", "tx2", 0, 1000),
+			event("mintC", "tx3", 0, 3000),
+		}))
+		minTs, maxTs, err = store.GetGlobalTimeRange(ctx)
+		if err != nil {
+			t.Fatalf("global time range: %v", err)
+		}
+		if minTs != 1000 || maxTs != 3000 {
+			t.Errorf("expected (1000, 3000), got (%d, %d)", minTs, maxTs)
+		}
+	})
+
+	t.Run("EmptyResults", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		got, err := store.GetByTimeRange(ctx, 0, 5000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertIDs(t, keys(got))
+
+		mints, err := store.GetDistinctMintsByTimeRange(ctx, 0, 5000)
+		if err != nil {
+			t.Fatalf("get distinct mints: %v", err)
+		}
+		assertIDs(t, mints)
+
+		if err := store.InsertBulk(ctx, nil); err != nil {
+			t.Errorf("empty bulk insert: expected no error, got %v", err)
+		}
+	})
+}
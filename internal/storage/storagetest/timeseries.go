@@ -0,0 +1,181 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// PriceTimeseriesStoreFactory returns an empty store. It is called once per subtest.
+type PriceTimeseriesStoreFactory func(t *testing.T) storage.PriceTimeseriesStore
+
+// LiquidityTimeseriesStoreFactory returns an empty store. It is called once per subtest.
+type LiquidityTimeseriesStoreFactory func(t *testing.T) storage.LiquidityTimeseriesStore
+
+// timeseriesOps adapts the price and liquidity timeseries stores, which share a
+// contract but not a point type, to one suite.
+type timeseriesOps struct {
+	insert      func(ctx context.Context, candidateID string, timestamps ...int64) error
+	byCandidate func(ctx context.Context, candidateID string) ([]int64, error)
+	byTimeRange func(ctx context.Context, candidateID string, start, end int64) ([]int64, error)
+	globalRange func(ctx context.Context) (int64, int64, error)
+	insertEmpty func(ctx context.Context) error
+}
+
+// RunPriceTimeseriesStoreSuite runs the PriceTimeseriesStore contract against fresh stores from newStore.
+func RunPriceTimeseriesStoreSuite(t *testing.T, newStore PriceTimeseriesStoreFactory) {
+	runTimeseriesSuite(t, func(t *testing.T) timeseriesOps {
+		store := newStore(t)
+		return timeseriesOps{
+			insert: func(ctx context.Context, candidateID string, timestamps ...int64) error {
+				points := make([]*domain.PriceTimeseriesPoint, len(timestamps))
+				for i, ts := range timestamps {
+					points[i] = &domain.PriceTimeseriesPoint{CandidateID: candidateID, TimestampMs: ts, Slot: ts / 400, Price: 1, SwapCount: 1}
+				}
+				return store.InsertBulk(ctx, points)
+			},
+			byCandidate: func(ctx context.Context, candidateID string) ([]int64, error) {
+				points, err := store.GetByCandidateID(ctx, candidateID)
+				return priceTimestamps(points), err
+			},
+			byTimeRange: func(ctx context.Context, candidateID string, start, end int64) ([]int64, error) {
+				points, err := store.GetByTimeRange(ctx, candidateID, start, end)
+				return priceTimestamps(points), err
+			},
+			globalRange: store.GetGlobalTimeRange,
+			insertEmpty: func(ctx context.Context) error { return store.InsertBulk(ctx, nil) },
+		}
+	})
+}
+
+// RunLiquidityTimeseriesStoreSuite runs the LiquidityTimeseriesStore contract against fresh stores from newStore.
+func RunLiquidityTimeseriesStoreSuite(t *testing.T, newStore LiquidityTimeseriesStoreFactory) {
+	runTimeseriesSuite(t, func(t *testing.T) timeseriesOps {
+		store := newStore(t)
+		return timeseriesOps{
+			insert: func(ctx context.Context, candidateID string, timestamps ...int64) error {
+				points := make([]*domain.LiquidityTimeseriesPoint, len(timestamps))
+				for i, ts := range timestamps {
+					points[i] = &domain.LiquidityTimeseriesPoint{CandidateID: candidateID, TimestampMs: ts, Slot: ts / 400, Liquidity: 100}
+				}
+				return store.InsertBulk(ctx, points)
+			},
+			byCandidate: func(ctx context.Context, candidateID string) ([]int64, error) {
+				points, err := store.GetByCandidateID(ctx, candidateID)
+				return liquidityTimestamps(points), err
+			},
+			byTimeRange: func(ctx context.Context, candidateID string, start, end int64) ([]int64, error) {
+				points, err := store.GetByTimeRange(ctx, candidateID, start, end)
+				return liquidityTimestamps(points), err
+			},
+			globalRange: store.GetGlobalTimeRange,
+			insertEmpty: func(ctx context.Context) error { return store.InsertBulk(ctx, nil) },
+		}
+	})
+}
+
+func runTimeseriesSuite(t *testing.T, newOps func(t *testing.T) timeseriesOps) {
+	t.Run("BulkDuplicatesAtomic", func(t *testing.T) {
+		ops := newOps(t)
+		ctx := context.Background()
+		mustInsert(t, ops.insert(ctx, "c1", 1000))
+
+		if err := ops.insert(ctx, "c1", 2000, 1000); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("bulk with existing point: expected ErrDuplicateKey, got %v", err)
+		}
+		if err := ops.insert(ctx, "c1", 3000, 3000); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("bulk with intra-batch duplicate: expected ErrDuplicateKey, got %v", err)
+		}
+		// Same timestamp for another candidate is a distinct point
+		mustInsert(t, ops.insert(ctx, "c2", 1000))
+
+		got, err := ops.byCandidate(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertTimestamps(t, got, 1000)
+	})
+
+	t.Run("OrderedAndInclusiveRange", func(t *testing.T) {
+		ops := newOps(t)
+		ctx := context.Background()
+		mustInsert(t, ops.insert(ctx, "c1", 3000, 1000, 2000, 4000))
+
+		got, err := ops.byCandidate(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertTimestamps(t, got, 1000, 2000, 3000, 4000)
+
+		got, err = ops.byTimeRange(ctx, "c1", 2000, 3000)
+		if err != nil {
+			t.Fatalf("get by time range: %v", err)
+		}
+		assertTimestamps(t, got, 2000, 3000)
+	})
+
+	t.Run("GlobalTimeRange", func(t *testing.T) {
+		ops := newOps(t)
+		ctx := context.Background()
+
+		minTs, maxTs, err := ops.globalRange(ctx)
+		if err != nil {
+			t.Fatalf("empty global time range: %v", err)
+		}
+		if minTs != 0 || maxTs != 0 {
+			t.Errorf("empty store: expected (0, 0), got (%d, %d)", minTs, maxTs)
+		}
+
+		mustInsert(t, ops.insert(ctx, "c1", 2000, 5000))
+		mustInsert(t, ops.insert(ctx, "c2", 1000))
+		minTs, maxTs, err = ops.globalRange(ctx)
+		if err != nil {
+			t.Fatalf("global time range: %v", err)
+		}
+		if minTs != 1000 || maxTs != 5000 {
+			t.Errorf("expected (1000, 5000), got (%d, %d)", minTs, maxTs)
+		}
+	})
+
+	t.Run("EmptyResults", func(t *testing.T) {
+		ops := newOps(t)
+		ctx := context.Background()
+
+		got, err := ops.byCandidate(ctx, "none")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertTimestamps(t, got)
+
+		if err := ops.insertEmpty(ctx); err != nil {
+			t.Errorf("empty bulk insert: expected no error, got %v", err)
+		}
+	})
+}
+
+func priceTimestamps(points []*domain.PriceTimeseriesPoint) []int64 {
+	out := make([]int64, len(points))
+	for i, p := range points {
+		out[i] = p.TimestampMs
+	}
+	return out
+}
+
+func liquidityTimestamps(points []*domain.LiquidityTimeseriesPoint) []int64 {
+	out := make([]int64, len(points))
+	for i, p := range points {
+		out[i] = p.TimestampMs
+	}
+	return out
+}
+
+func assertTimestamps(t *testing.T, got []int64, want ...int64) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) && !(len(got) == 0 && len(want) == 0) {
+		t.Errorf("got timestamps %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TokenMetadataStoreFactory returns an empty store. Backends with a foreign key
+// to token_candidates must create candidateIDs before returning.
+type TokenMetadataStoreFactory func(t *testing.T, candidateIDs ...string) storage.TokenMetadataStore
+
+// RunTokenMetadataStoreSuite runs the TokenMetadataStore contract against fresh stores from newStore.
+func RunTokenMetadataStoreSuite(t *testing.T, newStore TokenMetadataStoreFactory) {
+	ctx := context.Background()
+	mints := func(metadata []*domain.TokenMetadata) []string {
+		out := make([]string, len(metadata))
+		for i, m := range metadata {
+			out[i] = m.Mint
+		}
+		return out
+	}
+
+	t.Run("GetByMintsPartialCoverage", func(t *testing.T) {
+		s := newStore(t, "cand-c", "cand-a", "cand-b")
+		symbol := "BBB"
+		mustInsert(t, s.Insert(ctx, &domain.TokenMetadata{CandidateID: "cand-c", Mint: "mint-c", Decimals: 6, FetchedAt: 300}))
+		mustInsert(t, s.Insert(ctx, &domain.TokenMetadata{CandidateID: "cand-a", Mint: "mint-a", Decimals: 9, FetchedAt: 100}))
+		mustInsert(t, s.Insert(ctx, &domain.TokenMetadata{CandidateID: "cand-b", Mint: "mint-b", Symbol: &symbol, Decimals: 6, FetchedAt: 200}))
+
+		// Mints without metadata are omitted; repeated mints are returned once
+		got, err := s.GetByMints(ctx, []string{"mint-x", "mint-c", "mint-b", "mint-y", "mint-c"})
+		if err != nil {
+			t.Fatalf("GetByMints: %v", err)
+		}
+		assertIDs(t, mints(got), "mint-b", "mint-c")
+		if got[0].CandidateID != "cand-b" || got[0].Symbol == nil || *got[0].Symbol != "BBB" || got[1].Decimals != 6 {
+			t.Errorf("unexpected metadata: %+v, %+v", *got[0], *got[1])
+		}
+	})
+
+	t.Run("GetByMintsEmpty", func(t *testing.T) {
+		s := newStore(t, "cand-a")
+		mustInsert(t, s.Insert(ctx, &domain.TokenMetadata{CandidateID: "cand-a", Mint: "mint-a", FetchedAt: 100}))
+
+		for _, in := range [][]string{nil, {}, {"mint-x"}} {
+			got, err := s.GetByMints(ctx, in)
+			if err != nil {
+				t.Fatalf("GetByMints(%v): %v", in, err)
+			}
+			if len(got) != 0 {
+				t.Errorf("GetByMints(%v): expected no metadata, got %v", in, mints(got))
+			}
+		}
+	})
+}
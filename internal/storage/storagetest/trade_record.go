@@ -0,0 +1,321 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// TradeRecordStoreFactory returns an empty store. It is called once per subtest.
+type TradeRecordStoreFactory func(t *testing.T) storage.TradeRecordStore
+
+// RunTradeRecordStoreSuite runs the TradeRecordStore contract against fresh stores from newStore.
+func RunTradeRecordStoreSuite(t *testing.T, newStore TradeRecordStoreFactory) {
+	trade := func(id, candidateID, strategyID string, entry int64) *domain.TradeRecord {
+		return &domain.TradeRecord{
+			TradeID:          id,
+			CandidateID:      candidateID,
+			StrategyID:       strategyID,
+			ScenarioID:       domain.ScenarioRealistic,
+			EntrySignalTime:  entry,
+			EntrySignalPrice: 1,
+			EntryActualTime:  entry + 100,
+			EntryActualPrice: 1,
+			PositionSize:     1,
+			PositionValue:    1,
+			ExitSignalTime:   entry + 1000,
+			ExitSignalPrice:  1.1,
+			ExitActualTime:   entry + 1100,
+			ExitActualPrice:  1.1,
+			ExitReason:       "TIME_EXIT",
+			GrossReturn:      0.1,
+			Outcome:          0.1,
+			OutcomeClass:     domain.OutcomeClassWin,
+			HoldDurationMs:   1000,
+		}
+	}
+	ids := func(trades []*domain.TradeRecord) []string {
+		out := make([]string, len(trades))
+		for i, tr := range trades {
+			out[i] = tr.TradeID
+		}
+		return out
+	}
+
+	t.Run("InsertAndGetByID", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		in := trade("t1", "c1", "TIME_EXIT", 1000)
+		in.InputKey = "key1"
+		mustInsert(t, store.Insert(ctx, in))
+
+		got, err := store.GetByID(ctx, "t1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.CandidateID != in.CandidateID || got.StrategyID != in.StrategyID || got.Outcome != in.Outcome ||
+			got.OutcomeClass != in.OutcomeClass || got.HoldDurationMs != in.HoldDurationMs || got.InputKey != in.InputKey {
+			t.Errorf("round trip mismatch: got %+v, want %+v", got, in)
+		}
+
+		if _, err := store.GetByID(ctx, "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("missing trade: expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("EntryContextRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		liq := 2500.5
+		in := trade("t1", "c1", "TIME_EXIT", 1000)
+		in.EntryContext = &domain.EntryContext{
+			Liquidity:      &liq,
+			Volume1h:       123.25,
+			SwapsPerMinute: 0.75,
+			SwapsPrior:     42,
+			TokenAgeMs:     600000,
+		}
+		mustInsert(t, store.Insert(ctx, in))
+		mustInsert(t, store.Insert(ctx, trade("t2", "c1", "TIME_EXIT", 2000)))
+
+		got, err := store.GetByID(ctx, "t1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.EntryContext == nil || got.EntryContext.Liquidity == nil || *got.EntryContext.Liquidity != liq ||
+			got.EntryContext.Volume1h != 123.25 || got.EntryContext.SwapsPerMinute != 0.75 ||
+			got.EntryContext.SwapsPrior != 42 || got.EntryContext.TokenAgeMs != 600000 {
+			t.Errorf("entry context mismatch: got %+v, want %+v", got.EntryContext, in.EntryContext)
+		}
+
+		without, err := store.GetByID(ctx, "t2")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if without.EntryContext != nil {
+			t.Errorf("expected nil entry context, got %+v", without.EntryContext)
+		}
+	})
+
+	t.Run("CostBreakdownRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		in := trade("t1", "c1", "TIME_EXIT", 1000)
+		in.CostBreakdown = &domain.CostBreakdown{
+			EntrySlippageSOL: 0.01,
+			ExitSlippageSOL:  0.011,
+			NetworkFeeSOL:    0.00002,
+			PriorityFeeSOL:   0.0002,
+			MEVSOL:           0.0101,
+		}
+		mustInsert(t, store.Insert(ctx, in))
+
+		got, err := store.GetByID(ctx, "t1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.CostBreakdown == nil || *got.CostBreakdown != *in.CostBreakdown {
+			t.Errorf("cost breakdown mismatch: got %+v, want %+v", got.CostBreakdown, in.CostBreakdown)
+		}
+	})
+
+	t.Run("DuplicateKey", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.Insert(ctx, trade("t1", "c1", "TIME_EXIT", 1000)))
+		if err := store.Insert(ctx, trade("t1", "c2", "TRAILING_STOP", 2000)); !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("expected ErrDuplicateKey, got %v", err)
+		}
+	})
+
+	t.Run("BulkAtomicity", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.Insert(ctx, trade("t1", "c1", "TIME_EXIT", 1000)))
+
+		err := store.InsertBulk(ctx, []*domain.TradeRecord{trade("t2", "c1", "TIME_EXIT", 2000), trade("t1", "c1", "TIME_EXIT", 1000)})
+		if !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("bulk with existing trade: expected ErrDuplicateKey, got %v", err)
+		}
+		err = store.InsertBulk(ctx, []*domain.TradeRecord{trade("t3", "c1", "TIME_EXIT", 3000), trade("t3", "c1", "TIME_EXIT", 3000)})
+		if !errors.Is(err, storage.ErrDuplicateKey) {
+			t.Errorf("bulk with intra-batch duplicate: expected ErrDuplicateKey, got %v", err)
+		}
+
+		all, err := store.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("get all: %v", err)
+		}
+		assertIDs(t, ids(all), "t1")
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		// Ties on entry_signal_time break by trade_id
+		mustInsert(t, store.InsertBulk(ctx, []*domain.TradeRecord{
+			trade("t3", "c1", "TIME_EXIT", 2000),
+			trade("t2", "c1", "TIME_EXIT", 1000),
+			trade("t1", "c1", "TIME_EXIT", 2000),
+		}))
+
+		all, err := store.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("get all: %v", err)
+		}
+		assertIDs(t, ids(all), "t2", "t1", "t3")
+
+		byCandidate, err := store.GetByCandidateID(ctx, "c1")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertIDs(t, ids(byCandidate), "t2", "t1", "t3")
+
+		byStrategy, err := store.GetByStrategyScenario(ctx, "TIME_EXIT", domain.ScenarioRealistic)
+		if err != nil {
+			t.Fatalf("get by strategy/scenario: %v", err)
+		}
+		assertIDs(t, ids(byStrategy), "t2", "t1", "t3")
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.InsertBulk(ctx, []*domain.TradeRecord{
+			trade("t3", "c1", "TIME_EXIT", 1000),
+			trade("t1", "c1", "TIME_EXIT", 3000),
+			trade("t2", "c1", "TIME_EXIT", 2000),
+		}))
+
+		first, err := store.GetPage(ctx, "", 2)
+		if err != nil {
+			t.Fatalf("first page: %v", err)
+		}
+		assertIDs(t, ids(first), "t1", "t2")
+
+		second, err := store.GetPage(ctx, "t2", 2)
+		if err != nil {
+			t.Fatalf("second page: %v", err)
+		}
+		assertIDs(t, ids(second), "t3")
+
+		last, err := store.GetPage(ctx, "t3", 2)
+		if err != nil {
+			t.Fatalf("last page: %v", err)
+		}
+		assertIDs(t, ids(last))
+
+		if _, err := store.GetPage(ctx, "", 0); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("zero limit: expected ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("ChangedSince", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.InsertBulk(ctx, []*domain.TradeRecord{
+			trade("t2", "c1", "TIME_EXIT", 1000),
+			trade("t1", "c1", "TIME_EXIT", 2000),
+		}))
+		if n, err := store.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("Count = %d, %v; want 2", n, err)
+		}
+
+		changes, err := store.GetChangedSince(ctx, storage.TradeCursor{}, 10)
+		if err != nil {
+			t.Fatalf("GetChangedSince: %v", err)
+		}
+		if len(changes) != 2 || changes[0].UpdatedAt == 0 || changes[0].Cursor().After(changes[1].Cursor()) {
+			t.Fatalf("expected 2 changes in write order, got %+v", changes)
+		}
+		page, err := store.GetChangedSince(ctx, changes[0].Cursor(), 1)
+		if err != nil {
+			t.Fatalf("GetChangedSince page: %v", err)
+		}
+		if len(page) != 1 || page[0].Trade.TradeID != changes[1].Trade.TradeID {
+			t.Errorf("expected the second change after the first, got %+v", page)
+		}
+		last := changes[1].Cursor()
+		if rest, err := store.GetChangedSince(ctx, last, 10); err != nil || len(rest) != 0 {
+			t.Errorf("expected nothing after the last change, got %+v, %v", rest, err)
+		}
+
+		// An upsert moves the trade past every earlier write
+		time.Sleep(2 * time.Millisecond)
+		updated := trade("t2", "c1", "TIME_EXIT", 1000)
+		updated.Outcome = -0.2
+		updated.OutcomeClass = domain.OutcomeClassLoss
+		if err := store.Upsert(ctx, updated); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+		rest, err := store.GetChangedSince(ctx, last, 10)
+		if err != nil {
+			t.Fatalf("GetChangedSince after upsert: %v", err)
+		}
+		if len(rest) != 1 || rest[0].Trade.TradeID != "t2" || rest[0].Trade.Outcome != -0.2 || rest[0].UpdatedAt <= last.UpdatedAt {
+			t.Errorf("expected the upserted t2 after the last change, got %+v", rest)
+		}
+		if n, _ := store.Count(ctx); n != 2 {
+			t.Errorf("Count after upsert = %d, want 2", n)
+		}
+
+		if _, err := store.GetChangedSince(ctx, storage.TradeCursor{}, 0); !errors.Is(err, storage.ErrInvalidInput) {
+			t.Errorf("zero limit: expected ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("UpsertReplaces", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+		mustInsert(t, store.Insert(ctx, trade("t1", "c1", "TIME_EXIT", 1000)))
+
+		updated := trade("t1", "c1", "TIME_EXIT", 1000)
+		updated.Outcome = -0.2
+		updated.OutcomeClass = domain.OutcomeClassLoss
+		if err := store.Upsert(ctx, updated); err != nil {
+			t.Fatalf("upsert existing: %v", err)
+		}
+		if err := store.Upsert(ctx, trade("t2", "c1", "TIME_EXIT", 2000)); err != nil {
+			t.Fatalf("upsert new: %v", err)
+		}
+
+		got, err := store.GetByID(ctx, "t1")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if got.Outcome != -0.2 || got.OutcomeClass != domain.OutcomeClassLoss {
+			t.Errorf("expected replaced outcome, got %f %s", got.Outcome, got.OutcomeClass)
+		}
+
+		all, err := store.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("get all: %v", err)
+		}
+		assertIDs(t, ids(all), "t1", "t2")
+	})
+
+	t.Run("EmptyResults", func(t *testing.T) {
+		store := newStore(t)
+		ctx := context.Background()
+
+		all, err := store.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("get all: %v", err)
+		}
+		assertIDs(t, ids(all))
+
+		byCandidate, err := store.GetByCandidateID(ctx, "none")
+		if err != nil {
+			t.Fatalf("get by candidate: %v", err)
+		}
+		assertIDs(t, ids(byCandidate))
+
+		if err := store.InsertBulk(ctx, nil); err != nil {
+			t.Errorf("empty bulk insert: expected no error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package storage
+
+import "context"
+
+// MaxSufficiencyRunErrors caps the integrity errors stored per sufficiency run;
+// SufficiencyRun.ErrorCount keeps the full count.
+const MaxSufficiencyRunErrors = 100
+
+// SufficiencyCheckRecord is one data sufficiency check of a run.
+type SufficiencyCheckRecord struct {
+	Name      string `json:"name"`
+	Threshold string `json:"threshold"`
+	Actual    string `json:"actual"`
+	Pass      bool   `json:"pass"`
+}
+
+// SufficiencyRun is the result of the data sufficiency checks of one pipeline run.
+type SufficiencyRun struct {
+	RunAt       int64  // when the checks ran (ms)
+	DataVersion string // data version of the report the checks gated
+	AllPass     bool   // all checks passed and no integrity errors were found
+	Checks      []SufficiencyCheckRecord
+	Errors      []string // first MaxSufficiencyRunErrors integrity errors
+	ErrorCount  int      // total integrity errors, including those not stored
+}
+
+// SufficiencyRunStore keeps the history of sufficiency check runs, so the
+// current data quality status can be served without re-running the checks.
+type SufficiencyRunStore interface {
+	// Insert records a run. Returns ErrInvalidInput for a nil run.
+	Insert(ctx context.Context, run *SufficiencyRun) error
+
+	// GetLatest returns the run with the highest RunAt, the last inserted among
+	// equal RunAt. Returns ErrNotFound if no run was recorded.
+	GetLatest(ctx context.Context) (*SufficiencyRun, error)
+}
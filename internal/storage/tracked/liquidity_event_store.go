@@ -0,0 +1,53 @@
+package tracked
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// LiquidityEventStore wraps a storage.LiquidityEventStore, marking candidates
+// dirty when their events are inserted or associated late.
+type LiquidityEventStore struct {
+	storage.LiquidityEventStore
+	tracker *Tracker
+}
+
+// NewLiquidityEventStore wraps inner, reporting stored events to tracker.
+func NewLiquidityEventStore(inner storage.LiquidityEventStore, tracker *Tracker) *LiquidityEventStore {
+	return &LiquidityEventStore{LiquidityEventStore: inner, tracker: tracker}
+}
+
+// Compile-time interface check.
+var _ storage.LiquidityEventStore = (*LiquidityEventStore)(nil)
+
+// Insert implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) Insert(ctx context.Context, e *domain.LiquidityEvent) error {
+	if err := s.LiquidityEventStore.Insert(ctx, e); err != nil {
+		return err
+	}
+	s.tracker.observe(ctx, e.CandidateID, e.Timestamp)
+	return nil
+}
+
+// InsertBulk implements storage.LiquidityEventStore.
+func (s *LiquidityEventStore) InsertBulk(ctx context.Context, events []*domain.LiquidityEvent) error {
+	if err := s.LiquidityEventStore.InsertBulk(ctx, events); err != nil {
+		return err
+	}
+	s.tracker.observeBatch(ctx, len(events), func(i int) (string, int64) {
+		return events[i].CandidateID, events[i].Timestamp
+	})
+	return nil
+}
+
+// UpdateCandidateID implements storage.LiquidityEventStore. A deferred
+// association is a late event for the candidate it is associated with.
+func (s *LiquidityEventStore) UpdateCandidateID(ctx context.Context, e *domain.LiquidityEvent, candidateID string) error {
+	if err := s.LiquidityEventStore.UpdateCandidateID(ctx, e, candidateID); err != nil {
+		return err
+	}
+	s.tracker.observe(ctx, candidateID, e.Timestamp)
+	return nil
+}
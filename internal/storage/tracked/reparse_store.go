@@ -0,0 +1,44 @@
+package tracked
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// ReparseStore wraps a storage.ReparseStore, marking candidates dirty when a
+// reparse correction replaces or adds events inside one of their trade windows.
+type ReparseStore struct {
+	storage.ReparseStore
+	tracker *Tracker
+}
+
+// NewReparseStore wraps inner, reporting corrected events to tracker.
+func NewReparseStore(inner storage.ReparseStore, tracker *Tracker) *ReparseStore {
+	return &ReparseStore{ReparseStore: inner, tracker: tracker}
+}
+
+// Compile-time interface check.
+var _ storage.ReparseStore = (*ReparseStore)(nil)
+
+// Apply implements storage.ReparseStore. Both the replaced and the inserted
+// events are observed: a changed event can leave a window as well as enter one.
+func (s *ReparseStore) Apply(ctx context.Context, fix *storage.ReparseFix) error {
+	if err := s.ReparseStore.Apply(ctx, fix); err != nil {
+		return err
+	}
+
+	swaps := make([]*domain.SwapEvent, 0, len(fix.ReplaceSwapEvents)+len(fix.InsertSwapEvents))
+	swaps = append(append(swaps, fix.ReplaceSwapEvents...), fix.InsertSwapEvents...)
+	s.tracker.observeMints(ctx, len(swaps), func(i int) (string, int64) {
+		return swaps[i].Mint, swaps[i].Timestamp
+	})
+
+	liquidity := make([]*domain.LiquidityEvent, 0, len(fix.ReplaceLiquidity)+len(fix.InsertLiquidity))
+	liquidity = append(append(liquidity, fix.ReplaceLiquidity...), fix.InsertLiquidity...)
+	s.tracker.observeBatch(ctx, len(liquidity), func(i int) (string, int64) {
+		return liquidity[i].CandidateID, liquidity[i].Timestamp
+	})
+	return nil
+}
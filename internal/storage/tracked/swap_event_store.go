@@ -0,0 +1,44 @@
+package tracked
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// SwapEventStore wraps a storage.SwapEventStore, marking the candidates of a
+// mint dirty when its raw swap events are inserted late, e.g. by a backfill.
+// Events are resolved to candidates through the tracker's candidate store.
+type SwapEventStore struct {
+	storage.SwapEventStore
+	tracker *Tracker
+}
+
+// NewSwapEventStore wraps inner, reporting stored events to tracker.
+func NewSwapEventStore(inner storage.SwapEventStore, tracker *Tracker) *SwapEventStore {
+	return &SwapEventStore{SwapEventStore: inner, tracker: tracker}
+}
+
+// Compile-time interface check.
+var _ storage.SwapEventStore = (*SwapEventStore)(nil)
+
+// Insert implements storage.SwapEventStore.
+func (s *SwapEventStore) Insert(ctx context.Context, e *domain.SwapEvent) error {
+	if err := s.SwapEventStore.Insert(ctx, e); err != nil {
+		return err
+	}
+	s.tracker.observeMints(ctx, 1, func(int) (string, int64) { return e.Mint, e.Timestamp })
+	return nil
+}
+
+// InsertBulk implements storage.SwapEventStore.
+func (s *SwapEventStore) InsertBulk(ctx context.Context, events []*domain.SwapEvent) error {
+	if err := s.SwapEventStore.InsertBulk(ctx, events); err != nil {
+		return err
+	}
+	s.tracker.observeMints(ctx, len(events), func(i int) (string, int64) {
+		return events[i].Mint, events[i].Timestamp
+	})
+	return nil
+}
@@ -0,0 +1,43 @@
+package tracked
+
+import (
+	"context"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+)
+
+// SwapStore wraps a storage.SwapStore, marking candidates dirty when their
+// swaps are inserted late.
+type SwapStore struct {
+	storage.SwapStore
+	tracker *Tracker
+}
+
+// NewSwapStore wraps inner, reporting stored swaps to tracker.
+func NewSwapStore(inner storage.SwapStore, tracker *Tracker) *SwapStore {
+	return &SwapStore{SwapStore: inner, tracker: tracker}
+}
+
+// Compile-time interface check.
+var _ storage.SwapStore = (*SwapStore)(nil)
+
+// Insert implements storage.SwapStore.
+func (s *SwapStore) Insert(ctx context.Context, sw *domain.Swap) error {
+	if err := s.SwapStore.Insert(ctx, sw); err != nil {
+		return err
+	}
+	s.tracker.observe(ctx, sw.CandidateID, sw.Timestamp)
+	return nil
+}
+
+// InsertBulk implements storage.SwapStore.
+func (s *SwapStore) InsertBulk(ctx context.Context, swaps []*domain.Swap) error {
+	if err := s.SwapStore.InsertBulk(ctx, swaps); err != nil {
+		return err
+	}
+	s.tracker.observeBatch(ctx, len(swaps), func(i int) (string, int64) {
+		return swaps[i].CandidateID, swaps[i].Timestamp
+	})
+	return nil
+}
@@ -0,0 +1,141 @@
+// Package tracked wraps event stores so that storing an event inside the trade
+// window of an already simulated candidate marks the candidate dirty for
+// re-simulation (see storage.DirtyCandidateStore). Late events come from
+// backfill repairs, deferred liquidity association and slow live ingestion.
+package tracked
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"solana-token-lab/internal/storage"
+)
+
+// Tracker marks candidates dirty when events land inside one of their trade windows.
+type Tracker struct {
+	trades     storage.TradeRecordStore
+	dirty      storage.DirtyCandidateStore
+	candidates storage.CandidateStore // optional: resolves swap events to candidates by mint
+	now        func() time.Time
+	logger     *log.Logger
+}
+
+// NewTracker creates a tracker checking events against trades and recording marks in dirty.
+func NewTracker(trades storage.TradeRecordStore, dirty storage.DirtyCandidateStore) *Tracker {
+	return &Tracker{
+		trades: trades,
+		dirty:  dirty,
+		now:    time.Now,
+		logger: log.Default(),
+	}
+}
+
+// WithClock sets the clock used for marked_at (default: time.Now).
+func (t *Tracker) WithClock(now func() time.Time) *Tracker {
+	t.now = now
+	return t
+}
+
+// WithCandidates sets the store resolving swap events, which carry a mint but
+// no candidate, to the candidates of their mint. Without it swap events are not
+// tracked.
+func (t *Tracker) WithCandidates(candidates storage.CandidateStore) *Tracker {
+	t.candidates = candidates
+	return t
+}
+
+// WithLogger sets the logger for marking failures (default: log.Default()).
+func (t *Tracker) WithLogger(logger *log.Logger) *Tracker {
+	t.logger = logger
+	return t
+}
+
+// Observe marks candidateID dirty if any of the event timestamps falls inside
+// [EntrySignalTime, ExitActualTime] of one of its stored trades. Candidates
+// without trades have not been simulated yet and are left alone.
+func (t *Tracker) Observe(ctx context.Context, candidateID string, timestamps ...int64) error {
+	if candidateID == "" || len(timestamps) == 0 {
+		return nil
+	}
+
+	trades, err := t.trades.GetByCandidateID(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("load trades of %s: %w", candidateID, err)
+	}
+
+	earliest, late := int64(0), false
+	for _, ts := range timestamps {
+		for _, tr := range trades {
+			if ts >= tr.EntrySignalTime && ts <= tr.ExitActualTime {
+				if !late || ts < earliest {
+					earliest = ts
+				}
+				late = true
+				break
+			}
+		}
+	}
+	if !late {
+		return nil
+	}
+
+	if err := t.dirty.Mark(ctx, candidateID, earliest, t.now().UnixMilli()); err != nil {
+		return fmt.Errorf("mark %s dirty: %w", candidateID, err)
+	}
+	return nil
+}
+
+// observe runs Observe for an event that is already stored. A failure cannot
+// undo the write, so it is logged rather than returned.
+func (t *Tracker) observe(ctx context.Context, candidateID string, timestamps ...int64) {
+	if err := t.Observe(ctx, candidateID, timestamps...); err != nil {
+		t.logger.Printf("WARN: dirty tracking: %v", err)
+	}
+}
+
+// observeBatch observes n stored events, one Observe per candidate in first-seen
+// order. event returns the candidate ID and timestamp of the i-th event.
+func (t *Tracker) observeBatch(ctx context.Context, n int, event func(i int) (string, int64)) {
+	byCandidate := make(map[string][]int64)
+	var order []string
+	for i := 0; i < n; i++ {
+		id, ts := event(i)
+		if _, ok := byCandidate[id]; !ok {
+			order = append(order, id)
+		}
+		byCandidate[id] = append(byCandidate[id], ts)
+	}
+	for _, id := range order {
+		t.observe(ctx, id, byCandidate[id]...)
+	}
+}
+
+// observeMints observes n stored swap events for every candidate of their mint,
+// one lookup per mint in first-seen order. event returns the mint and timestamp
+// of the i-th event.
+func (t *Tracker) observeMints(ctx context.Context, n int, event func(i int) (string, int64)) {
+	if t.candidates == nil {
+		return
+	}
+	byMint := make(map[string][]int64)
+	var order []string
+	for i := 0; i < n; i++ {
+		mint, ts := event(i)
+		if _, ok := byMint[mint]; !ok {
+			order = append(order, mint)
+		}
+		byMint[mint] = append(byMint[mint], ts)
+	}
+	for _, mint := range order {
+		candidates, err := t.candidates.GetByMint(ctx, mint)
+		if err != nil {
+			t.logger.Printf("WARN: dirty tracking: load candidates of mint %s: %v", mint, err)
+			continue
+		}
+		for _, c := range candidates {
+			t.observe(ctx, c.CandidateID, byMint[mint]...)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package tracked
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// newTestTracker returns a tracker over one trade of "c1" spanning [1000, 5000].
+func newTestTracker(t *testing.T) (*Tracker, *memory.DirtyCandidateStore) {
+	t.Helper()
+	trades := memory.NewTradeRecordStore()
+	if err := trades.Insert(context.Background(), &domain.TradeRecord{
+		TradeID:         "t1",
+		CandidateID:     "c1",
+		EntrySignalTime: 1000,
+		ExitActualTime:  5000,
+	}); err != nil {
+		t.Fatalf("insert trade: %v", err)
+	}
+	dirty := memory.NewDirtyCandidateStore()
+	tracker := NewTracker(trades, dirty).WithClock(func() time.Time { return time.UnixMilli(9000) })
+	return tracker, dirty
+}
+
+func TestSwapStore_MarksEventsInsideTradeWindow(t *testing.T) {
+	ctx := context.Background()
+	tracker, dirty := newTestTracker(t)
+	store := NewSwapStore(memory.NewSwapStore(), tracker)
+
+	// Outside the window, and a candidate that was never simulated
+	if err := store.InsertBulk(ctx, []*domain.Swap{
+		{CandidateID: "c1", TxSignature: "tx-after", Timestamp: 6000},
+		{CandidateID: "c2", TxSignature: "tx-other", Timestamp: 2000},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+	if n, _ := dirty.Count(ctx); n != 0 {
+		t.Fatalf("expected no dirty candidates, got %d", n)
+	}
+
+	if err := store.InsertBulk(ctx, []*domain.Swap{
+		{CandidateID: "c1", TxSignature: "tx-late-1", Timestamp: 4000},
+		{CandidateID: "c1", TxSignature: "tx-late-2", Timestamp: 3000},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+
+	marks, err := dirty.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(marks) != 1 {
+		t.Fatalf("expected 1 dirty candidate, got %d", len(marks))
+	}
+	if marks[0].CandidateID != "c1" || marks[0].EarliestEventTime != 3000 || marks[0].MarkedAt != 9000 {
+		t.Errorf("unexpected mark: %+v", marks[0])
+	}
+}
+
+func TestLiquidityEventStore_MarksDeferredAssociation(t *testing.T) {
+	ctx := context.Background()
+	tracker, dirty := newTestTracker(t)
+	inner := memory.NewLiquidityEventStore()
+	store := NewLiquidityEventStore(inner, tracker)
+
+	// Stored before the pool was linked to a candidate
+	e := &domain.LiquidityEvent{Pool: "pool1", TxSignature: "tx-liq", Timestamp: 2000, EventType: domain.LiquidityEventRemove}
+	if err := store.Insert(ctx, e); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if n, _ := dirty.Count(ctx); n != 0 {
+		t.Fatalf("expected no dirty candidates before association, got %d", n)
+	}
+
+	if err := store.UpdateCandidateID(ctx, e, "c1"); err != nil {
+		t.Fatalf("UpdateCandidateID failed: %v", err)
+	}
+	if n, _ := dirty.Count(ctx); n != 1 {
+		t.Errorf("expected c1 marked dirty after association, got %d dirty candidates", n)
+	}
+}
+
+func TestSwapEventStore_MarksCandidatesOfMint(t *testing.T) {
+	ctx := context.Background()
+	tracker, dirty := newTestTracker(t)
+	candidates := memory.NewCandidateStore()
+	if err := candidates.Insert(ctx, &domain.TokenCandidate{CandidateID: "c1", Source: domain.SourceNewToken, Mint: "mint1"}); err != nil {
+		t.Fatalf("insert candidate: %v", err)
+	}
+	store := NewSwapEventStore(memory.NewSwapEventStore(), tracker)
+
+	// Without a candidate store swap events cannot be resolved
+	if err := store.Insert(ctx, &domain.SwapEvent{Mint: "mint1", TxSignature: "tx-untracked", Timestamp: 2000}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if n, _ := dirty.Count(ctx); n != 0 {
+		t.Fatalf("expected no dirty candidates without a candidate store, got %d", n)
+	}
+
+	tracker.WithCandidates(candidates)
+	if err := store.InsertBulk(ctx, []*domain.SwapEvent{
+		{Mint: "mint2", TxSignature: "tx-other", Timestamp: 2000},
+		{Mint: "mint1", TxSignature: "tx-after", Timestamp: 6000},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+	if n, _ := dirty.Count(ctx); n != 0 {
+		t.Fatalf("expected no dirty candidates, got %d", n)
+	}
+
+	if err := store.InsertBulk(ctx, []*domain.SwapEvent{
+		{Mint: "mint1", TxSignature: "tx-late", Timestamp: 3000},
+	}); err != nil {
+		t.Fatalf("InsertBulk failed: %v", err)
+	}
+	marks, err := dirty.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(marks) != 1 || marks[0].CandidateID != "c1" || marks[0].EarliestEventTime != 3000 {
+		t.Errorf("expected c1 marked at 3000, got %+v", marks)
+	}
+}
+
+func TestReparseStore_MarksCorrectedEvents(t *testing.T) {
+	ctx := context.Background()
+	tracker, dirty := newTestTracker(t)
+	swapEvents := memory.NewSwapEventStore()
+	liquidity := memory.NewLiquidityEventStore()
+	store := NewReparseStore(memory.NewReparseStore(swapEvents, liquidity), tracker)
+
+	// A liquidity event moved into the trade window by a parser fix
+	before := &domain.LiquidityEvent{CandidateID: "c1", Pool: "pool1", TxSignature: "tx-liq", Timestamp: 7000, EventType: domain.LiquidityEventRemove}
+	if err := liquidity.Insert(ctx, before); err != nil {
+		t.Fatalf("insert event: %v", err)
+	}
+	after := *before
+	after.Timestamp = 2500
+	if err := store.Apply(ctx, &storage.ReparseFix{
+		TxSignature:      "tx-liq",
+		ReplaceLiquidity: []*domain.LiquidityEvent{before},
+		InsertLiquidity:  []*domain.LiquidityEvent{&after},
+	}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	marks, err := dirty.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(marks) != 1 || marks[0].CandidateID != "c1" || marks[0].EarliestEventTime != 2500 {
+		t.Errorf("expected c1 marked at 2500, got %+v", marks)
+	}
+}
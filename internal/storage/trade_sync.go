@@ -0,0 +1,29 @@
+package storage
+
+import "solana-token-lab/internal/domain"
+
+// TradeCursor is a position in the write order of trade records:
+// updated_at, then trade_id.
+type TradeCursor struct {
+	UpdatedAt int64 // Unix ms of the last insert or upsert
+	TradeID   string
+}
+
+// TradeChange is a trade record with the time it was last written.
+type TradeChange struct {
+	Trade     *domain.TradeRecord
+	UpdatedAt int64 // Unix ms
+}
+
+// Cursor returns the position of c in the write order.
+func (c *TradeChange) Cursor() TradeCursor {
+	return TradeCursor{UpdatedAt: c.UpdatedAt, TradeID: c.Trade.TradeID}
+}
+
+// After reports whether c comes after other in the write order.
+func (c TradeCursor) After(other TradeCursor) bool {
+	if c.UpdatedAt != other.UpdatedAt {
+		return c.UpdatedAt > other.UpdatedAt
+	}
+	return c.TradeID > other.TradeID
+}
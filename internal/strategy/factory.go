@@ -2,6 +2,7 @@ package strategy
 
 import (
 	"errors"
+	"fmt"
 
 	"solana-token-lab/internal/domain"
 )
@@ -14,6 +15,9 @@ var (
 	ErrMissingInitialStopPct   = errors.New("TRAILING_STOP requires InitialStopPct")
 	ErrMissingMaxHoldDuration  = errors.New("TRAILING_STOP/LIQUIDITY_GUARD requires MaxHoldDurationMs")
 	ErrMissingLiquidityDropPct = errors.New("LIQUIDITY_GUARD requires LiquidityDropPct")
+
+	ErrNegativeActivationGain    = errors.New("TRAILING_STOP ActivationGainPct must not be negative")
+	ErrInvalidLiquidityReference = errors.New("LIQUIDITY_GUARD LiquidityReference must be entry or peak")
 )
 
 // FromConfig creates a Strategy from domain.StrategyConfig.
@@ -53,12 +57,19 @@ func fromTrailingStopConfig(cfg domain.StrategyConfig) (*TrailingStopStrategy, e
 		return nil, ErrMissingMaxHoldDuration
 	}
 
-	return NewTrailingStopStrategy(
+	s := NewTrailingStopStrategy(
 		cfg.EntryEventType,
 		*cfg.TrailPct,
 		*cfg.InitialStopPct,
 		*cfg.MaxHoldDurationMs,
-	), nil
+	)
+	if cfg.ActivationGainPct != nil {
+		if *cfg.ActivationGainPct < 0 {
+			return nil, ErrNegativeActivationGain
+		}
+		s.WithActivationGain(*cfg.ActivationGainPct)
+	}
+	return s, nil
 }
 
 // fromLiquidityGuardConfig creates LiquidityGuardStrategy from config.
@@ -70,9 +81,15 @@ func fromLiquidityGuardConfig(cfg domain.StrategyConfig) (*LiquidityGuardStrateg
 		return nil, ErrMissingMaxHoldDuration
 	}
 
+	switch cfg.LiquidityReference {
+	case "", domain.LiquidityReferenceEntry, domain.LiquidityReferencePeak:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLiquidityReference, cfg.LiquidityReference)
+	}
+
 	return NewLiquidityGuardStrategy(
 		cfg.EntryEventType,
 		*cfg.LiquidityDropPct,
 		*cfg.MaxHoldDurationMs,
-	), nil
+	).WithReferenceMode(cfg.LiquidityReference), nil
 }
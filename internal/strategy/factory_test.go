@@ -100,6 +100,90 @@ func TestFromConfig_LiquidityGuard(t *testing.T) {
 	}
 }
 
+func TestFromConfig_TrailingStopActivationGain(t *testing.T) {
+	trailPct, initialStopPct, maxHoldMs := 0.10, 0.10, int64(3600000)
+	cfg := domain.StrategyConfig{
+		StrategyType:      domain.StrategyTypeTrailingStop,
+		EntryEventType:    "NEW_TOKEN",
+		TrailPct:          &trailPct,
+		InitialStopPct:    &initialStopPct,
+		MaxHoldDurationMs: &maxHoldMs,
+	}
+	base, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatalf("FromConfig failed: %v", err)
+	}
+
+	zero := 0.0
+	cfg.ActivationGainPct = &zero
+	s, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatalf("FromConfig failed: %v", err)
+	}
+	if s.ID() != base.ID() {
+		t.Errorf("zero activation gain changed the ID: %s vs %s", s.ID(), base.ID())
+	}
+
+	gain := 0.25
+	cfg.ActivationGainPct = &gain
+	s, err = FromConfig(cfg)
+	if err != nil {
+		t.Fatalf("FromConfig failed: %v", err)
+	}
+	if s.(*TrailingStopStrategy).ActivationGainPct != 0.25 {
+		t.Errorf("expected activation gain 0.25, got %f", s.(*TrailingStopStrategy).ActivationGainPct)
+	}
+
+	// Sub-percent gains keep distinct IDs, and so distinct trade IDs
+	ids := make(map[string]float64)
+	for _, g := range []float64{0.004, 0.0045, 0.005} {
+		gain := g
+		cfg.ActivationGainPct = &gain
+		s, err := FromConfig(cfg)
+		if err != nil {
+			t.Fatalf("FromConfig failed: %v", err)
+		}
+		if prev, ok := ids[s.ID()]; ok {
+			t.Errorf("activation gains %v and %v share the ID %s", prev, g, s.ID())
+		}
+		ids[s.ID()] = g
+	}
+	if _, ok := ids[base.ID()+"_arm0.4"]; !ok {
+		t.Errorf("expected the ID %s_arm0.4, got %v", base.ID(), ids)
+	}
+
+	negative := -0.1
+	cfg.ActivationGainPct = &negative
+	if _, err := FromConfig(cfg); !errors.Is(err, ErrNegativeActivationGain) {
+		t.Errorf("expected ErrNegativeActivationGain, got %v", err)
+	}
+}
+
+func TestFromConfig_LiquidityGuardReference(t *testing.T) {
+	liquidityDropPct := 0.30
+	maxHoldMs := int64(1800000)
+	cfg := domain.StrategyConfig{
+		StrategyType:       domain.StrategyTypeLiquidityGuard,
+		EntryEventType:     "NEW_TOKEN",
+		LiquidityDropPct:   &liquidityDropPct,
+		LiquidityReference: domain.LiquidityReferencePeak,
+		MaxHoldDurationMs:  &maxHoldMs,
+	}
+
+	s, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatalf("FromConfig failed: %v", err)
+	}
+	if s.ID() != "LIQUIDITY_GUARD_NEW_TOKEN_drop30_1800000ms_peak" {
+		t.Errorf("unexpected ID %s", s.ID())
+	}
+
+	cfg.LiquidityReference = "trough"
+	if _, err := FromConfig(cfg); !errors.Is(err, ErrInvalidLiquidityReference) {
+		t.Errorf("expected ErrInvalidLiquidityReference, got %v", err)
+	}
+}
+
 func TestFromConfig_MissingParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -186,3 +270,43 @@ func TestFromConfig_UnknownType(t *testing.T) {
 func ptrFloat(f float64) *float64 {
 	return &f
 }
+
+func TestFromConfig_MatchesCatalog(t *testing.T) {
+	catalog := domain.NewCatalog("test")
+	for _, spec := range catalog.Strategies {
+		for _, entry := range catalog.EntryEventTypes {
+			cfg := spec.DefaultConfig(entry)
+			if err := catalog.ValidateStrategyConfig(cfg); err != nil {
+				t.Errorf("%s/%s: catalog rejects its own defaults: %v", spec.Type, entry, err)
+			}
+			s, err := FromConfig(cfg)
+			if err != nil {
+				t.Errorf("%s/%s: catalog strategy is not registered: %v", spec.Type, entry, err)
+				continue
+			}
+			if s.BaseType() != spec.Type {
+				t.Errorf("%s: FromConfig built a %s", spec.Type, s.BaseType())
+			}
+		}
+
+		// Every parameter the catalog requires is required by FromConfig too
+		for _, p := range spec.Parameters {
+			if !p.Required {
+				continue
+			}
+			reduced := domain.StrategySpec{Type: spec.Type}
+			for _, q := range spec.Parameters {
+				if q.Name != p.Name {
+					reduced.Parameters = append(reduced.Parameters, q)
+				}
+			}
+			if _, err := FromConfig(reduced.DefaultConfig("NEW_TOKEN")); err == nil {
+				t.Errorf("%s: FromConfig accepts a config without %s", spec.Type, p.Name)
+			}
+		}
+	}
+
+	if _, err := FromConfig(domain.StrategyConfig{StrategyType: "MOMENTUM", EntryEventType: "NEW_TOKEN"}); !errors.Is(err, ErrUnknownStrategyType) {
+		t.Errorf("expected a type outside the catalog to be unregistered, got %v", err)
+	}
+}
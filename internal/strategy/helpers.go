@@ -29,12 +29,68 @@ func applyExitExecution(signalTime int64, signalPrice float64, scenario domain.S
 	return
 }
 
+// DataEndStalenessMs is how long before an intended exit the price data may
+// end and the exit still be priced at the last point. Past it, the token is
+// treated as dead: the trade exits at the last point with ExitReasonDataEnd.
+// Part of the simulation semantics, so changing it changes replayed trades.
+const DataEndStalenessMs int64 = 10 * 60 * 1000
+
+// dataEndExit returns the forced exit of a trade whose price data ends more
+// than DataEndStalenessMs before exitTime: the last point, no earlier than the
+// entry signal. ok is false when a point exists at or after exitTime or the
+// last point is recent enough. prices must be non-empty and sorted by time.
+func dataEndExit(entrySignalTime, exitTime int64, prices []*domain.PriceTimeseriesPoint) (exitSignalTime int64, exitSignalPrice float64, ok bool) {
+	last := prices[len(prices)-1]
+	if last.TimestampMs >= exitTime || exitTime-last.TimestampMs <= DataEndStalenessMs {
+		return 0, 0, false
+	}
+	return max(last.TimestampMs, entrySignalTime), last.Price, true
+}
+
+// sandwichPurpose labels the trade_id draw deciding whether an entry is sandwiched.
+const sandwichPurpose = "sandwich"
+
+// applyMEV returns the MEV cost of a trade and whether it was sandwiched.
+// Without a sandwich model the flat MEVPenaltyPct of the position value applies.
+// With one, the entry is sandwiched when the trade_id draw falls below the
+// model's probability at the entry's impact on pool liquidity.
+func applyMEV(tradeID string, positionValue float64, entryLiquidity *float64, scenario domain.ScenarioConfig) (cost float64, sandwiched bool) {
+	m := scenario.Sandwich
+	if m == nil {
+		return positionValue * (scenario.MEVPenaltyPct / 100), false
+	}
+	impact := m.Impact(positionValue, entryLiquidity)
+	loss := m.LossFraction(impact)
+	if loss <= 0 || idhash.UnitInterval(tradeID, sandwichPurpose) >= m.Probability(impact) {
+		return 0, false
+	}
+	return positionValue * loss, true
+}
+
 // computeTradeID generates deterministic trade ID.
 // Delegates to idhash.ComputeTradeID for single-source-of-truth.
 func computeTradeID(candidateID, strategyID, scenarioID string, entrySignalTime int64) string {
 	return idhash.ComputeTradeID(candidateID, strategyID, scenarioID, entrySignalTime)
 }
 
+// RecostTrade re-derives the execution prices, costs and outcome of a stored trade
+// under scenario, keeping its entry and exit signals. The result is stored under
+// scenarioID (see domain.VersionedScenarioID), which also determines its trade_id.
+// The original trade is not modified.
+func RecostTrade(t *domain.TradeRecord, scenario domain.ScenarioConfig, scenarioID string) *domain.TradeRecord {
+	trade := buildTradeRecord(
+		t.CandidateID, t.StrategyID, scenarioID,
+		t.EntrySignalTime, t.EntrySignalPrice, t.EntryLiquidity,
+		t.ExitSignalTime, t.ExitSignalPrice, t.ExitReason,
+		scenario,
+		t.PeakPrice, t.MinLiquidity,
+	)
+	trade.PeakLiquidity = t.PeakLiquidity
+	trade.ArmedAt = t.ArmedAt
+	trade.EntryContext = t.EntryContext
+	return trade
+}
+
 // buildTradeRecord constructs a complete TradeRecord from execution details.
 func buildTradeRecord(
 	candidateID, strategyID, scenarioID string,
@@ -53,13 +109,26 @@ func buildTradeRecord(
 	positionSize := 1.0
 	positionValue := entryActualPrice * positionSize
 
+	// Generate trade ID
+	tradeID := computeTradeID(candidateID, strategyID, scenarioID, entrySignalTime)
+
 	// Calculate MEV cost
-	mevCost := positionValue * (scenario.MEVPenaltyPct / 100)
+	mevCost, sandwiched := applyMEV(tradeID, positionValue, entryLiquidity, scenario)
 
 	// Calculate total costs
 	totalCost := entryCost + exitCost + mevCost
 	totalCostPct := totalCost / positionValue
 
+	// Itemize costs for the audit trail; totals above stay the source of truth
+	costBreakdown := &domain.CostBreakdown{
+		EntrySlippageSOL: (entryActualPrice - entrySignalPrice) * positionSize,
+		ExitSlippageSOL:  (exitSignalPrice - exitActualPrice) * positionSize,
+		NetworkFeeSOL:    2 * scenario.FeeSOL,
+		PriorityFeeSOL:   2 * scenario.PriorityFeeSOL,
+		MEVSOL:           mevCost,
+		Sandwiched:       sandwiched,
+	}
+
 	// Calculate outcome
 	grossReturn := (exitActualPrice - entryActualPrice) / entryActualPrice
 	outcome := grossReturn - totalCostPct
@@ -73,9 +142,6 @@ func buildTradeRecord(
 	// Calculate hold duration
 	holdDurationMs := exitActualTime - entryActualTime
 
-	// Generate trade ID
-	tradeID := computeTradeID(candidateID, strategyID, scenarioID, entrySignalTime)
-
 	return &domain.TradeRecord{
 		TradeID:     tradeID,
 		CandidateID: candidateID,
@@ -102,6 +168,8 @@ func buildTradeRecord(
 		TotalCostSOL: totalCost,
 		TotalCostPct: totalCostPct,
 
+		CostBreakdown: costBreakdown,
+
 		GrossReturn:  grossReturn,
 		Outcome:      outcome,
 		OutcomeClass: outcomeClass,
@@ -19,6 +19,7 @@ type LiquidityGuardStrategy struct {
 	EntryEventType    string  // "NEW_TOKEN" or "ACTIVE_TOKEN"
 	LiquidityDropPct  float64 // liquidity drop threshold (e.g., 0.30 = 30% drop)
 	MaxHoldDurationMs int64   // maximum hold time in milliseconds
+	ReferenceMode     string  // domain.LiquidityReferenceEntry (default) or domain.LiquidityReferencePeak
 }
 
 // NewLiquidityGuardStrategy creates a new LiquidityGuardStrategy.
@@ -30,12 +31,29 @@ func NewLiquidityGuardStrategy(entryEventType string, liquidityDropPct float64,
 	}
 }
 
+// WithReferenceMode sets the liquidity the drop is measured from: the entry
+// liquidity, or the running peak since entry. Empty means entry.
+func (s *LiquidityGuardStrategy) WithReferenceMode(mode string) *LiquidityGuardStrategy {
+	s.ReferenceMode = mode
+	return s
+}
+
+// peakMode reports whether the drop is measured from the running peak.
+func (s *LiquidityGuardStrategy) peakMode() bool {
+	return s.ReferenceMode == domain.LiquidityReferencePeak
+}
+
 // ID returns the strategy identifier including parameters.
+// Peak mode is suffixed "_peak"; entry mode keeps the original ID.
 func (s *LiquidityGuardStrategy) ID() string {
-	return fmt.Sprintf("LIQUIDITY_GUARD_%s_drop%.0f_%dms",
+	id := fmt.Sprintf("LIQUIDITY_GUARD_%s_drop%.0f_%dms",
 		s.EntryEventType,
 		s.LiquidityDropPct*100,
 		s.MaxHoldDurationMs)
+	if s.peakMode() {
+		id += "_peak"
+	}
+	return id
 }
 
 // BaseType returns the canonical base strategy type.
@@ -89,8 +107,10 @@ func mergePriceAndLiquidity(prices []*domain.PriceTimeseriesPoint, liq []*domain
 // Execute runs the strategy on price and liquidity time series.
 // Per SIMULATION_SPEC.md and REPLAY_PROTOCOL.md:
 //   - liquidity_threshold = entry_liquidity * (1 - liquidity_drop_pct)
+//   - in peak mode the threshold follows peak_liquidity, the running max since entry
 //   - Iterate merged events (price + liquidity) ordered by (timestamp_ms, slot)
 //   - At each event: compute liquidity_at and price_at, check exits
+//   - No exit and the price data ends more than DataEndStalenessMs before max duration: DATA_END
 func (s *LiquidityGuardStrategy) Execute(_ context.Context, input *StrategyInput) (*domain.TradeRecord, error) {
 	// Validate input
 	if err := input.Validate(); err != nil {
@@ -118,6 +138,7 @@ func (s *LiquidityGuardStrategy) Execute(_ context.Context, input *StrategyInput
 	entryLiquidity := *entryLiquidityPtr
 	liquidityThreshold := entryLiquidity * (1 - s.LiquidityDropPct)
 	minLiquidity := entryLiquidity
+	peakLiquidity := entryLiquidity
 
 	var exitSignalTime int64
 	var exitSignalPrice float64
@@ -149,6 +170,12 @@ func (s *LiquidityGuardStrategy) Execute(_ context.Context, input *StrategyInput
 			minLiquidity = *currentLiqPtr
 		}
 
+		// In peak mode, raise the threshold with the running peak before checking
+		if s.peakMode() && currentLiqPtr != nil && *currentLiqPtr > peakLiquidity {
+			peakLiquidity = *currentLiqPtr
+			liquidityThreshold = peakLiquidity * (1 - s.LiquidityDropPct)
+		}
+
 		// Check liquidity drop (only if we have liquidity data)
 		if currentLiqPtr != nil && *currentLiqPtr < liquidityThreshold {
 			exitSignalTime = t
@@ -166,21 +193,25 @@ func (s *LiquidityGuardStrategy) Execute(_ context.Context, input *StrategyInput
 		}
 	}
 
-	// If no exit triggered, use max duration at calculated time
+	// If no exit triggered, exit where the price data ends or at max duration
 	if exitReason == "" {
 		maxExitTime := input.EntrySignalTime + s.MaxHoldDurationMs
-		exitSignalTime = maxExitTime
-		price, err := lookup.PriceAt(maxExitTime, input.PriceTimeseries)
-		if err != nil {
-			return nil, err
+		if t, price, ok := dataEndExit(input.EntrySignalTime, maxExitTime, input.PriceTimeseries); ok {
+			exitSignalTime, exitSignalPrice, exitReason = t, price, domain.ExitReasonDataEnd
+		} else {
+			exitSignalTime = maxExitTime
+			price, err := lookup.PriceAt(maxExitTime, input.PriceTimeseries)
+			if err != nil {
+				return nil, err
+			}
+			exitSignalPrice = price
+			exitReason = domain.ExitReasonMaxDuration
 		}
-		exitSignalPrice = price
-		exitReason = domain.ExitReasonMaxDuration
 	}
 
 	minLiquidityPtr := &minLiquidity
 
-	return buildTradeRecord(
+	trade := buildTradeRecord(
 		input.CandidateID,
 		s.ID(),
 		input.Scenario.ScenarioID,
@@ -193,7 +224,11 @@ func (s *LiquidityGuardStrategy) Execute(_ context.Context, input *StrategyInput
 		input.Scenario,
 		nil, // no peak price tracking
 		minLiquidityPtr,
-	), nil
+	)
+	if s.peakMode() {
+		trade.PeakLiquidity = &peakLiquidity
+	}
+	return trade, nil
 }
 
 // Ensure LiquidityGuardStrategy implements Strategy
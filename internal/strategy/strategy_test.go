@@ -3,6 +3,8 @@ package strategy
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"solana-token-lab/internal/domain"
@@ -242,6 +244,120 @@ func TestTrailingStopStrategy_MaxDuration(t *testing.T) {
 	}
 }
 
+func TestTrailingStopStrategy_ActivationGain_NeverArmed(t *testing.T) {
+	ctx := context.Background()
+	strategy := NewTrailingStopStrategy("NEW_TOKEN", 0.10, 0.10, 600000).WithActivationGain(0.20)
+	if strategy.ID() != "TRAILING_STOP_NEW_TOKEN_trail10_stop10_600000ms_arm20" {
+		t.Errorf("unexpected ID %s", strategy.ID())
+	}
+
+	// A 13% pullback from 1.15 trails out when armed at entry, but 1.15 never arms a 20% gain
+	input := &StrategyInput{
+		CandidateID:      "candidate-1",
+		EntrySignalTime:  1000000,
+		EntrySignalPrice: 1.0,
+		PriceTimeseries: makePriceTimeseries(
+			[]float64{1.0, 1.15, 1.0, 1.05},
+			1000000, 60000,
+		),
+		Scenario: domain.ScenarioConfigRealistic,
+	}
+	unarmed, err := NewTrailingStopStrategy("NEW_TOKEN", 0.10, 0.10, 600000).Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if unarmed.ExitReason != domain.ExitReasonTrailingStop {
+		t.Errorf("without activation: expected TRAILING_STOP, got %s", unarmed.ExitReason)
+	}
+
+	result, err := strategy.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.ExitReason != domain.ExitReasonMaxDuration {
+		t.Errorf("expected MAX_DURATION, got %s", result.ExitReason)
+	}
+	if result.ArmedAt != nil {
+		t.Errorf("expected never armed, armed at %d", *result.ArmedAt)
+	}
+
+	// Before activation the initial stop still applies
+	input.PriceTimeseries = makePriceTimeseries([]float64{1.0, 1.1, 0.85}, 1000000, 60000)
+	result, err = strategy.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.ExitReason != domain.ExitReasonInitialStop {
+		t.Errorf("expected INITIAL_STOP, got %s", result.ExitReason)
+	}
+	if result.ArmedAt != nil {
+		t.Errorf("expected never armed, armed at %d", *result.ArmedAt)
+	}
+}
+
+func TestTrailingStopStrategy_ActivationGain_ArmedThenStopped(t *testing.T) {
+	strategy := NewTrailingStopStrategy("NEW_TOKEN", 0.10, 0.10, 3600000).WithActivationGain(0.20)
+
+	// 1.25 exceeds the 20% activation gain; peak 1.3, then 1.15 is below 1.17
+	input := &StrategyInput{
+		CandidateID:      "candidate-1",
+		EntrySignalTime:  1000000,
+		EntrySignalPrice: 1.0,
+		PriceTimeseries: makePriceTimeseries(
+			[]float64{1.0, 1.1, 1.25, 1.3, 1.15},
+			1000000, 60000,
+		),
+		Scenario: domain.ScenarioConfigRealistic,
+	}
+
+	result, err := strategy.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.ExitReason != domain.ExitReasonTrailingStop {
+		t.Errorf("expected TRAILING_STOP, got %s", result.ExitReason)
+	}
+	if result.ExitSignalTime != 1240000 {
+		t.Errorf("expected exit at 1240000, got %d", result.ExitSignalTime)
+	}
+	if result.ArmedAt == nil || *result.ArmedAt != 1120000 {
+		t.Errorf("expected armed at 1120000, got %v", result.ArmedAt)
+	}
+}
+
+func TestTrailingStopStrategy_ZeroActivationGainUnchanged(t *testing.T) {
+	ctx := context.Background()
+	series := [][]float64{
+		{1.0, 1.1, 1.2, 1.15, 1.05, 1.0},
+		{1.0, 0.85},
+		{1.0, 1.2, 1.3, 1.4, 1.25},
+		{1.0, 1.02, 1.01, 1.03},
+	}
+	for i, prices := range series {
+		input := &StrategyInput{
+			CandidateID:      "candidate-1",
+			EntrySignalTime:  1000000,
+			EntrySignalPrice: 1.0,
+			PriceTimeseries:  makePriceTimeseries(prices, 1000000, 60000),
+			Scenario:         domain.ScenarioConfigRealistic,
+		}
+		want, err := NewTrailingStopStrategy("NEW_TOKEN", 0.10, 0.10, 3600000).Execute(ctx, input)
+		if err != nil {
+			t.Fatalf("series %d: Execute failed: %v", i, err)
+		}
+		got, err := NewTrailingStopStrategy("NEW_TOKEN", 0.10, 0.10, 3600000).WithActivationGain(0).Execute(ctx, input)
+		if err != nil {
+			t.Fatalf("series %d: Execute failed: %v", i, err)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("series %d: ActivationGainPct=0 changed the trade", i)
+		}
+		if got.ArmedAt != nil {
+			t.Errorf("series %d: expected no ArmedAt without activation gain", i)
+		}
+	}
+}
+
 func TestLiquidityGuardStrategy_Deterministic(t *testing.T) {
 	for run := 0; run < 5; run++ {
 		strategy := NewLiquidityGuardStrategy("NEW_TOKEN", 0.30, 1800000)
@@ -312,6 +428,83 @@ func TestLiquidityGuardStrategy_LiquidityDrop(t *testing.T) {
 	}
 }
 
+// peakThenHalfInput triples liquidity after entry, then halves it: a 50% drop
+// from the peak that stays above the entry liquidity.
+func peakThenHalfInput() *StrategyInput {
+	entryLiq := 1000.0
+	return &StrategyInput{
+		CandidateID:      "candidate-1",
+		EntrySignalTime:  1000000,
+		EntrySignalPrice: 1.0,
+		EntryLiquidity:   &entryLiq,
+		PriceTimeseries: makePriceTimeseries(
+			[]float64{1.0, 1.2, 1.5, 1.3, 1.1},
+			1000000, 60000,
+		),
+		LiquidityTimeseries: makeLiquidityTimeseries(
+			[]float64{1000, 2000, 3000, 1500, 1500},
+			1000000, 60000,
+		),
+		Scenario: domain.ScenarioConfigRealistic,
+	}
+}
+
+func TestLiquidityGuardStrategy_PeakReference(t *testing.T) {
+	ctx := context.Background()
+
+	entry, err := NewLiquidityGuardStrategy("NEW_TOKEN", 0.30, 600000).Execute(ctx, peakThenHalfInput())
+	if err != nil {
+		t.Fatalf("entry mode: Execute failed: %v", err)
+	}
+	if entry.ExitReason != domain.ExitReasonMaxDuration {
+		t.Errorf("entry mode: expected MAX_DURATION, got %s", entry.ExitReason)
+	}
+	if entry.PeakLiquidity != nil {
+		t.Errorf("entry mode: expected no peak liquidity, got %v", *entry.PeakLiquidity)
+	}
+
+	strategy := NewLiquidityGuardStrategy("NEW_TOKEN", 0.30, 600000).WithReferenceMode(domain.LiquidityReferencePeak)
+	peak, err := strategy.Execute(ctx, peakThenHalfInput())
+	if err != nil {
+		t.Fatalf("peak mode: Execute failed: %v", err)
+	}
+	if peak.ExitReason != domain.ExitReasonLiquidityDrop {
+		t.Errorf("peak mode: expected LIQUIDITY_DROP, got %s", peak.ExitReason)
+	}
+	if peak.ExitSignalTime != 1180000 {
+		t.Errorf("peak mode: expected exit at 1180000, got %d", peak.ExitSignalTime)
+	}
+	if peak.PeakLiquidity == nil || *peak.PeakLiquidity != 3000 {
+		t.Errorf("peak mode: expected peak liquidity 3000, got %v", peak.PeakLiquidity)
+	}
+
+	if strategy.ID() != "LIQUIDITY_GUARD_NEW_TOKEN_drop30_600000ms_peak" {
+		t.Errorf("unexpected peak mode ID %s", strategy.ID())
+	}
+	if peak.StrategyID == entry.StrategyID || peak.TradeID == entry.TradeID {
+		t.Error("peak and entry mode trades must not share an ID")
+	}
+}
+
+func TestLiquidityGuardStrategy_PeakReference_Deterministic(t *testing.T) {
+	ctx := context.Background()
+	strategy := NewLiquidityGuardStrategy("NEW_TOKEN", 0.30, 600000).WithReferenceMode(domain.LiquidityReferencePeak)
+
+	first, err := strategy.Execute(ctx, peakThenHalfInput())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	for run := 1; run < 5; run++ {
+		result, err := strategy.Execute(ctx, peakThenHalfInput())
+		if err != nil {
+			t.Fatalf("Run %d: Execute failed: %v", run, err)
+		}
+		if !reflect.DeepEqual(first, result) {
+			t.Errorf("Run %d: trade differs from run 0", run)
+		}
+	}
+}
+
 func TestLiquidityGuardStrategy_MaxDuration(t *testing.T) {
 	strategy := NewLiquidityGuardStrategy("NEW_TOKEN", 0.30, 120000) // 2 minutes max
 
@@ -496,6 +689,80 @@ func TestLiquidityGuardStrategy_MergedEvents_MaxDuration(t *testing.T) {
 	}
 }
 
+func TestStrategies_DataEnd(t *testing.T) {
+	const entry = int64(1000000)
+	const hold = int64(3600000) // 1 hour
+	entryLiq := 1000.0
+
+	// Price data ending at offset, 5 points one minute apart up to it, stable
+	// enough that no stop or liquidity drop fires
+	truncated := func(lastOffset int64) *StrategyInput {
+		return &StrategyInput{
+			CandidateID:      "candidate-1",
+			EntrySignalTime:  entry,
+			EntrySignalPrice: 1.0,
+			EntryLiquidity:   &entryLiq,
+			PriceTimeseries: makePriceTimeseries(
+				[]float64{1.0, 1.05, 1.03, 1.04, 0.97},
+				entry+lastOffset-4*60000, 60000,
+			),
+			LiquidityTimeseries: makeLiquidityTimeseries(
+				[]float64{1000, 990, 980, 970, 960},
+				entry+lastOffset-4*60000, 60000,
+			),
+			Scenario: domain.ScenarioConfigRealistic,
+		}
+	}
+
+	strategies := []struct {
+		strategy   Strategy
+		horizonEnd string
+	}{
+		{NewTimeExitStrategy("NEW_TOKEN", hold), domain.ExitReasonTimeExit},
+		{NewTrailingStopStrategy("NEW_TOKEN", 0.10, 0.10, hold), domain.ExitReasonMaxDuration},
+		{NewLiquidityGuardStrategy("NEW_TOKEN", 0.30, hold), domain.ExitReasonMaxDuration},
+	}
+	for _, tt := range strategies {
+		t.Run(tt.strategy.BaseType(), func(t *testing.T) {
+			ctx := context.Background()
+
+			// The token dies 20 minutes in: forced out at the last point
+			input := truncated(20 * 60000)
+			trade, err := tt.strategy.Execute(ctx, input)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			last := input.PriceTimeseries[len(input.PriceTimeseries)-1]
+			if trade.ExitReason != domain.ExitReasonDataEnd || !trade.IsDataEnd() {
+				t.Errorf("expected DATA_END, got %s", trade.ExitReason)
+			}
+			if trade.ExitSignalTime != last.TimestampMs || trade.ExitSignalPrice != last.Price {
+				t.Errorf("expected exit at the last point (%d, %f), got (%d, %f)",
+					last.TimestampMs, last.Price, trade.ExitSignalTime, trade.ExitSignalPrice)
+			}
+
+			// Data ending within the staleness threshold of the horizon keeps the usual exit
+			trade, err = tt.strategy.Execute(ctx, truncated(hold-DataEndStalenessMs))
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if trade.ExitReason != tt.horizonEnd || trade.ExitSignalTime != entry+hold {
+				t.Errorf("expected %s at %d, got %s at %d", tt.horizonEnd, entry+hold, trade.ExitReason, trade.ExitSignalTime)
+			}
+		})
+	}
+
+	// No point after entry at all: the exit is clamped to the entry signal
+	input := truncated(0)
+	trade, err := NewTimeExitStrategy("NEW_TOKEN", hold).Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if trade.ExitReason != domain.ExitReasonDataEnd || trade.ExitSignalTime != entry {
+		t.Errorf("expected DATA_END at entry, got %s at %d", trade.ExitReason, trade.ExitSignalTime)
+	}
+}
+
 func TestCanonicalType(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -609,3 +876,131 @@ func TestStrategyInput_Validate(t *testing.T) {
 		t.Errorf("expected ErrEmptyScenarioID, got %v", err)
 	}
 }
+
+func TestBuildTradeRecord_CostBreakdown(t *testing.T) {
+	scenarios := []domain.ScenarioConfig{
+		domain.ScenarioConfigOptimistic,
+		domain.ScenarioConfigRealistic,
+		domain.ScenarioConfigPessimistic,
+		domain.ScenarioConfigDegraded,
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.ScenarioID, func(t *testing.T) {
+			trade := buildTradeRecord("cand", "TIME_EXIT", sc.ScenarioID,
+				1000, 2.0, nil,
+				61000, 2.5, domain.ExitReasonTimeExit,
+				sc, nil, nil)
+
+			// Totals are computed exactly as before the breakdown existed
+			entryActual := 2.0 * (1 + sc.SlippagePct/200)
+			wantEntryCost := sc.FeeSOL + sc.PriorityFeeSOL
+			wantMEV := entryActual * (sc.MEVPenaltyPct / 100)
+			wantTotal := wantEntryCost + wantEntryCost + wantMEV
+			if trade.TotalCostSOL != wantTotal || trade.EntryCostSOL != wantEntryCost ||
+				trade.ExitCostSOL != wantEntryCost || trade.MEVCostSOL != wantMEV {
+				t.Errorf("totals changed: entry %v exit %v mev %v total %v, want %v/%v/%v/%v",
+					trade.EntryCostSOL, trade.ExitCostSOL, trade.MEVCostSOL, trade.TotalCostSOL,
+					wantEntryCost, wantEntryCost, wantMEV, wantTotal)
+			}
+
+			b := trade.CostBreakdown
+			if b == nil {
+				t.Fatal("expected cost breakdown")
+			}
+			if d := b.ChargedSOL() - trade.TotalCostSOL; d > 1e-12 || d < -1e-12 {
+				t.Errorf("breakdown fees+MEV = %v, TotalCostSOL = %v", b.ChargedSOL(), trade.TotalCostSOL)
+			}
+			if b.MEVSOL != trade.MEVCostSOL {
+				t.Errorf("MEVSOL = %v, want %v", b.MEVSOL, trade.MEVCostSOL)
+			}
+
+			wantEntrySlip := (trade.EntryActualPrice - trade.EntrySignalPrice) * trade.PositionSize
+			wantExitSlip := (trade.ExitSignalPrice - trade.ExitActualPrice) * trade.PositionSize
+			if b.EntrySlippageSOL != wantEntrySlip || b.ExitSlippageSOL != wantExitSlip {
+				t.Errorf("slippage = %v/%v, want %v/%v", b.EntrySlippageSOL, b.ExitSlippageSOL, wantEntrySlip, wantExitSlip)
+			}
+			if sc.SlippagePct > 0 && (b.EntrySlippageSOL <= 0 || b.ExitSlippageSOL <= 0) {
+				t.Errorf("expected positive slippage costs, got %+v", b)
+			}
+		})
+	}
+}
+
+func TestBuildTradeRecord_SandwichModel(t *testing.T) {
+	model := domain.SandwichModelRealistic
+	sc := domain.ScenarioConfigRealistic
+	sc.Sandwich = &model
+	thinPool := 1000.0
+
+	// Mean MEV cost as a fraction of position value over many candidates
+	meanCostPct := func(entryPrice float64) (float64, int) {
+		var sum float64
+		sandwiched := 0
+		const n = 2000
+		for i := 0; i < n; i++ {
+			trade := buildTradeRecord(fmt.Sprintf("cand-%d", i), "TIME_EXIT", sc.ScenarioID,
+				1000, entryPrice, &thinPool,
+				61000, entryPrice, domain.ExitReasonTimeExit,
+				sc, nil, nil)
+			sum += trade.MEVCostSOL / trade.PositionValue
+			if trade.CostBreakdown.Sandwiched {
+				sandwiched++
+				if trade.MEVCostSOL <= 0 {
+					t.Fatalf("sandwiched trade without MEV cost: %+v", trade.CostBreakdown)
+				}
+			} else if trade.MEVCostSOL != 0 {
+				t.Fatalf("MEV cost %v charged without a sandwich", trade.MEVCostSOL)
+			}
+			if trade.CostBreakdown.MEVSOL != trade.MEVCostSOL {
+				t.Fatalf("breakdown MEV %v, trade MEV %v", trade.CostBreakdown.MEVSOL, trade.MEVCostSOL)
+			}
+		}
+		return sum / n, sandwiched
+	}
+
+	smallPct, smallHits := meanCostPct(1)  // 0.1% of the pool
+	largePct, largeHits := meanCostPct(20) // 2% of the pool
+	if largeHits <= smallHits*3 {
+		t.Errorf("expected large positions sandwiched far more often: small %d, large %d of 2000", smallHits, largeHits)
+	}
+	if largePct <= smallPct*10 {
+		t.Errorf("expected large positions to lose a much larger share: small %.5f, large %.5f", smallPct, largePct)
+	}
+
+	// Expected values follow the model within sampling error
+	for _, c := range []struct {
+		impact float64
+		got    float64
+	}{{0.001, smallPct}, {0.02, largePct}} {
+		want := model.Probability(c.impact) * model.LossFraction(c.impact)
+		if c.got < want*0.8 || c.got > want*1.2 {
+			t.Errorf("impact %v: mean MEV %.5f of position, model expects %.5f", c.impact, c.got, want)
+		}
+	}
+}
+
+func TestBuildTradeRecord_SandwichDeterministic(t *testing.T) {
+	model := domain.SandwichModelPessimistic
+	sc := domain.ScenarioConfigPessimistic
+	sc.Sandwich = &model
+	liquidity := 500.0
+
+	for i := 0; i < 50; i++ {
+		candidateID := fmt.Sprintf("cand-%d", i)
+		first := buildTradeRecord(candidateID, "TIME_EXIT", sc.ScenarioID,
+			1000, 5.0, &liquidity, 61000, 6.0, domain.ExitReasonTimeExit, sc, nil, nil)
+		second := buildTradeRecord(candidateID, "TIME_EXIT", sc.ScenarioID,
+			1000, 5.0, &liquidity, 61000, 6.0, domain.ExitReasonTimeExit, sc, nil, nil)
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("%s: re-simulation differs:\n%+v\n%+v", candidateID, first.CostBreakdown, second.CostBreakdown)
+		}
+	}
+
+	// Unknown liquidity falls back to the base probability with no loss
+	trade := buildTradeRecord("cand-0", "TIME_EXIT", sc.ScenarioID,
+		1000, 5.0, nil, 61000, 6.0, domain.ExitReasonTimeExit, sc, nil, nil)
+	if trade.MEVCostSOL != 0 {
+		t.Errorf("expected no sandwich loss without liquidity, got %v", trade.MEVCostSOL)
+	}
+}
@@ -38,6 +38,9 @@ func (s *TimeExitStrategy) BaseType() string {
 //   - exit_signal_time = entry_signal_time + hold_duration_ms
 //   - exit_signal_price = price_at(exit_signal_time)
 //   - exit_reason = "TIME_EXIT"
+//
+// If the price data ends more than DataEndStalenessMs before exit_signal_time,
+// the trade exits at the last point with exit_reason = "DATA_END".
 func (s *TimeExitStrategy) Execute(_ context.Context, input *StrategyInput) (*domain.TradeRecord, error) {
 	// Validate input
 	if err := input.Validate(); err != nil {
@@ -47,10 +50,18 @@ func (s *TimeExitStrategy) Execute(_ context.Context, input *StrategyInput) (*do
 	// Calculate exit signal time
 	exitSignalTime := input.EntrySignalTime + s.HoldDurationMs
 
-	// Get exit price at target time
-	exitSignalPrice, err := lookup.PriceAt(exitSignalTime, input.PriceTimeseries)
-	if err != nil {
-		return nil, err
+	exitReason := domain.ExitReasonTimeExit
+
+	// Get exit price at target time, or exit where the data ends
+	var exitSignalPrice float64
+	if t, price, ok := dataEndExit(input.EntrySignalTime, exitSignalTime, input.PriceTimeseries); ok {
+		exitSignalTime, exitSignalPrice, exitReason = t, price, domain.ExitReasonDataEnd
+	} else {
+		price, err := lookup.PriceAt(exitSignalTime, input.PriceTimeseries)
+		if err != nil {
+			return nil, err
+		}
+		exitSignalPrice = price
 	}
 
 	// Build trade record
@@ -63,7 +74,7 @@ func (s *TimeExitStrategy) Execute(_ context.Context, input *StrategyInput) (*do
 		input.EntryLiquidity,
 		exitSignalTime,
 		exitSignalPrice,
-		domain.ExitReasonTimeExit,
+		exitReason,
 		input.Scenario,
 		nil, // no peak price tracking
 		nil, // no min liquidity tracking
@@ -3,6 +3,8 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 
 	"solana-token-lab/internal/domain"
 	"solana-token-lab/internal/lookup"
@@ -15,6 +17,7 @@ type TrailingStopStrategy struct {
 	TrailPct          float64 // trailing stop percentage (e.g., 0.10 = 10%)
 	InitialStopPct    float64 // initial stop loss percentage (e.g., 0.10 = 10%)
 	MaxHoldDurationMs int64   // maximum hold time in milliseconds
+	ActivationGainPct float64 // trailing stop arms once price exceeds entry by this much (0 = armed at entry)
 }
 
 // NewTrailingStopStrategy creates a new TrailingStopStrategy.
@@ -27,13 +30,27 @@ func NewTrailingStopStrategy(entryEventType string, trailPct, initialStopPct flo
 	}
 }
 
+// WithActivationGain sets the gain over the entry price past which the trailing
+// stop arms. Until then only the initial stop and max duration apply.
+func (s *TrailingStopStrategy) WithActivationGain(pct float64) *TrailingStopStrategy {
+	s.ActivationGainPct = pct
+	return s
+}
+
 // ID returns the strategy identifier including parameters.
+// An activation gain is suffixed "_arm<pct>"; without one the original ID is kept.
 func (s *TrailingStopStrategy) ID() string {
-	return fmt.Sprintf("TRAILING_STOP_%s_trail%.0f_stop%.0f_%dms",
+	id := fmt.Sprintf("TRAILING_STOP_%s_trail%.0f_stop%.0f_%dms",
 		s.EntryEventType,
 		s.TrailPct*100,
 		s.InitialStopPct*100,
 		s.MaxHoldDurationMs)
+	if s.ActivationGainPct > 0 {
+		// Percent rounded to 4 decimals, which drops float noise (0.2*100 = 20.000000000000004)
+		// while keeping sub-percent gains apart: 0.20 is "_arm20", 0.004 "_arm0.4"
+		id += "_arm" + strconv.FormatFloat(math.Round(s.ActivationGainPct*1e6)/1e4, 'f', -1, 64)
+	}
+	return id
 }
 
 // BaseType returns the canonical base strategy type.
@@ -46,8 +63,10 @@ func (s *TrailingStopStrategy) BaseType() string {
 //   - initial_stop = entry_signal_price * (1 - initial_stop_pct)
 //   - For each price event after entry:
 //   - Update peak_price
+//   - Arm once price > entry_signal_price * (1 + activation_gain_pct)
 //   - trailing_stop = peak_price * (1 - trail_pct)
-//   - Check exits: INITIAL_STOP, TRAILING_STOP, MAX_DURATION
+//   - Check exits: INITIAL_STOP, TRAILING_STOP (armed only), MAX_DURATION
+//   - No exit and the data ends more than DataEndStalenessMs before max duration: DATA_END
 func (s *TrailingStopStrategy) Execute(_ context.Context, input *StrategyInput) (*domain.TradeRecord, error) {
 	// Validate input
 	if err := input.Validate(); err != nil {
@@ -59,6 +78,11 @@ func (s *TrailingStopStrategy) Execute(_ context.Context, input *StrategyInput)
 	peakPrice := entryPrice
 	maxExitTime := input.EntrySignalTime + s.MaxHoldDurationMs
 
+	// Without an activation gain the trailing stop is armed from entry
+	armed := s.ActivationGainPct <= 0
+	activationPrice := entryPrice * (1 + s.ActivationGainPct)
+	var armedAt *int64
+
 	var exitSignalTime int64
 	var exitSignalPrice float64
 	var exitReason string
@@ -77,6 +101,13 @@ func (s *TrailingStopStrategy) Execute(_ context.Context, input *StrategyInput)
 			peakPrice = price
 		}
 
+		// Arm the trailing stop once the activation gain is exceeded
+		if !armed && price > activationPrice {
+			armed = true
+			armedTime := t
+			armedAt = &armedTime
+		}
+
 		// Calculate trailing stop
 		trailingStop := peakPrice * (1 - s.TrailPct)
 
@@ -88,7 +119,7 @@ func (s *TrailingStopStrategy) Execute(_ context.Context, input *StrategyInput)
 			break
 		}
 
-		if price <= trailingStop {
+		if armed && price <= trailingStop {
 			exitSignalTime = t
 			exitSignalPrice = price
 			exitReason = domain.ExitReasonTrailingStop
@@ -103,20 +134,24 @@ func (s *TrailingStopStrategy) Execute(_ context.Context, input *StrategyInput)
 		}
 	}
 
-	// If no exit triggered, use max duration
+	// If no exit triggered, exit where the data ends or at max duration
 	if exitReason == "" {
-		exitSignalTime = maxExitTime
-		price, err := lookup.PriceAt(maxExitTime, input.PriceTimeseries)
-		if err != nil {
-			return nil, err
+		if t, price, ok := dataEndExit(input.EntrySignalTime, maxExitTime, input.PriceTimeseries); ok {
+			exitSignalTime, exitSignalPrice, exitReason = t, price, domain.ExitReasonDataEnd
+		} else {
+			exitSignalTime = maxExitTime
+			price, err := lookup.PriceAt(maxExitTime, input.PriceTimeseries)
+			if err != nil {
+				return nil, err
+			}
+			exitSignalPrice = price
+			exitReason = domain.ExitReasonMaxDuration
 		}
-		exitSignalPrice = price
-		exitReason = domain.ExitReasonMaxDuration
 	}
 
 	peakPricePtr := &peakPrice
 
-	return buildTradeRecord(
+	trade := buildTradeRecord(
 		input.CandidateID,
 		s.ID(),
 		input.Scenario.ScenarioID,
@@ -129,7 +164,9 @@ func (s *TrailingStopStrategy) Execute(_ context.Context, input *StrategyInput)
 		input.Scenario,
 		peakPricePtr,
 		nil, // no min liquidity tracking
-	), nil
+	)
+	trade.ArmedAt = armedAt
+	return trade, nil
 }
 
 // Ensure TrailingStopStrategy implements Strategy
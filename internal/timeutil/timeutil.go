@@ -0,0 +1,42 @@
+// Package timeutil buckets Unix times into UTC days and fixed intervals.
+//
+// Every day boundary is UTC midnight, whatever the local time zone, so day
+// buckets are 24 hours long and never shift with daylight saving time.
+package timeutil
+
+import "time"
+
+// DayMs is the length of a UTC day in milliseconds.
+const DayMs = int64(24 * time.Hour / time.Millisecond)
+
+// dayKeyLayout formats a day as YYYY-MM-DD with a numeric month.
+const dayKeyLayout = "2006-01-02"
+
+// TruncateToUTCDay returns midnight UTC of the UTC day containing t.
+func TruncateToUTCDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DayKey returns the UTC day containing t as YYYY-MM-DD, e.g. "2024-02-01".
+func DayKey(t time.Time) string {
+	return t.UTC().Format(dayKeyLayout)
+}
+
+// DaysBetween returns the number of UTC day boundaries from from to to:
+// 0 on the same UTC day, 1 from one day to the next, negative if to is on an
+// earlier day.
+func DaysBetween(from, to time.Time) int {
+	return int(TruncateToUTCDay(to).Sub(TruncateToUTCDay(from)) / (24 * time.Hour))
+}
+
+// IntervalAlign returns the start of the intervalMs-wide bucket containing ts
+// (Unix ms): floor(ts / intervalMs) * intervalMs, rounding down for negative
+// ts as well. intervalMs must be positive.
+func IntervalAlign(ts, intervalMs int64) int64 {
+	r := ts % intervalMs
+	if r < 0 {
+		r += intervalMs
+	}
+	return ts - r
+}
@@ -0,0 +1,75 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayKey(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC), "2024-01-31"},
+		{time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), "2024-02-01"},
+		{time.Date(2024, time.December, 9, 12, 0, 0, 0, time.UTC), "2024-12-09"},
+		// 23:30 in New York is already the next day in UTC
+		{time.Date(2024, time.March, 9, 23, 30, 0, 0, time.FixedZone("EST", -5*3600)), "2024-03-10"},
+	}
+	for _, tt := range tests {
+		if got := DayKey(tt.t); got != tt.want {
+			t.Errorf("DayKey(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateToUTCDay(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone database: %v", err)
+	}
+	// The night US clocks spring forward
+	in := time.Date(2024, time.March, 10, 3, 30, 0, 0, ny)
+	want := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	if got := TruncateToUTCDay(in); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("TruncateToUTCDay(%v) = %v, want %v", in, got, want)
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"same day", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC), 0},
+		{"across midnight", time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 1, 0, 0, time.UTC), 1},
+		{"across a month", time.Date(2024, 1, 28, 12, 0, 0, 0, time.UTC), time.Date(2024, 2, 3, 1, 0, 0, 0, time.UTC), 6},
+		{"leap day", time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 2},
+		{"across a year", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1},
+		{"backwards", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC), -2},
+	}
+	for _, tt := range tests {
+		if got := DaysBetween(tt.from, tt.to); got != tt.want {
+			t.Errorf("%s: DaysBetween = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIntervalAlign(t *testing.T) {
+	tests := []struct {
+		ts, interval, want int64
+	}{
+		{0, 60000, 0},
+		{59999, 60000, 0},
+		{60000, 60000, 60000},
+		{DayMs + 5, DayMs, DayMs},
+		{-1, 60000, -60000},
+		{-60000, 60000, -60000},
+	}
+	for _, tt := range tests {
+		if got := IntervalAlign(tt.ts, tt.interval); got != tt.want {
+			t.Errorf("IntervalAlign(%d, %d) = %d, want %d", tt.ts, tt.interval, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+// Package tradeimport imports trade records produced by an external simulator,
+// so their results can be compared through the aggregation and reporting
+// machinery next to the strategies simulated here.
+package tradeimport
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/idhash"
+	"solana-token-lab/internal/metrics"
+	"solana-token-lab/internal/storage"
+)
+
+var (
+	// ErrInvalidFile is returned for a file missing required columns or holding
+	// a row that cannot be imported. Nothing is stored in that case.
+	ErrInvalidFile = errors.New("invalid trade file")
+	// ErrInvalidNamespace is returned for an empty namespace or one containing ':'.
+	ErrInvalidNamespace = errors.New("invalid strategy namespace")
+
+	// errUnresolved marks a row whose candidate is not stored.
+	errUnresolved = errors.New("candidate not found")
+)
+
+// requiredColumns must be present in the header of an imported file. The
+// other trade_records.csv columns are optional: costs default to zero,
+// position_size to 1 and hold_duration_ms to exit minus entry actual time.
+// Candidates are identified by candidate_id or, when it is empty, by mint.
+var requiredColumns = []string{
+	"strategy_id", "scenario_id",
+	"entry_signal_time", "entry_signal_price", "entry_actual_time", "entry_actual_price",
+	"exit_signal_time", "exit_signal_price", "exit_actual_time", "exit_actual_price",
+	"exit_reason", "gross_return", "outcome", "outcome_class",
+}
+
+var knownScenarioIDs = map[string]bool{
+	domain.ScenarioOptimistic:  true,
+	domain.ScenarioRealistic:   true,
+	domain.ScenarioPessimistic: true,
+	domain.ScenarioDegraded:    true,
+}
+
+var knownExitReasons = map[string]bool{
+	domain.ExitReasonTimeExit:      true,
+	domain.ExitReasonInitialStop:   true,
+	domain.ExitReasonTrailingStop:  true,
+	domain.ExitReasonMaxDuration:   true,
+	domain.ExitReasonLiquidityDrop: true,
+	domain.ExitReasonDataEnd:       true,
+}
+
+// Options configures an Importer.
+type Options struct {
+	CandidateStore storage.CandidateStore
+	TradeStore     storage.TradeRecordStore
+	AggregateStore storage.StrategyAggregateStore // nil skips aggregates
+
+	// Namespace is put in front of every imported strategy_id (see
+	// domain.ExternalStrategyID), so that imports of different collaborators
+	// never collide with each other or with simulated strategies.
+	Namespace string
+}
+
+// Result summarizes an import.
+type Result struct {
+	Rows        int      // data rows read
+	Imported    int      // trades stored
+	Duplicates  int      // trades already stored by an earlier import
+	StrategyIDs []string // external strategy IDs of the file, sorted
+	Aggregates  int      // aggregates computed for them
+}
+
+// Importer reads trade_records.csv-compatible files into the trade store.
+type Importer struct {
+	opts Options
+}
+
+// NewImporter creates an Importer.
+func NewImporter(opts Options) *Importer {
+	return &Importer{opts: opts}
+}
+
+// Import validates every row of r, stores the trades and refreshes the
+// aggregates of their (strategy, scenario) pairs for NEW_TOKEN and
+// ACTIVE_TOKEN. A file with any invalid row is rejected as a whole
+// (ErrInvalidFile). Trade IDs are derived like those of simulated trades, so
+// importing the same file again stores nothing and counts every trade as a
+// duplicate; the trade_id column of the file is ignored.
+func (im *Importer) Import(ctx context.Context, r io.Reader) (*Result, error) {
+	ns := im.opts.Namespace
+	if ns == "" || strings.Contains(ns, ":") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNamespace, ns)
+	}
+
+	trades, err := im.parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Rows: len(trades)}
+	keys := make(map[[2]string]struct{})
+	strategies := make(map[string]struct{})
+	for _, t := range trades {
+		switch err := im.opts.TradeStore.Insert(ctx, t); {
+		case err == nil:
+			result.Imported++
+		case errors.Is(err, storage.ErrDuplicateKey):
+			result.Duplicates++
+		default:
+			return result, fmt.Errorf("store trade %s: %w", t.TradeID, err)
+		}
+		keys[[2]string{t.StrategyID, t.ScenarioID}] = struct{}{}
+		strategies[t.StrategyID] = struct{}{}
+	}
+	for id := range strategies {
+		result.StrategyIDs = append(result.StrategyIDs, id)
+	}
+	sort.Strings(result.StrategyIDs)
+
+	if im.opts.AggregateStore == nil {
+		return result, nil
+	}
+	sorted := make([][2]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+	agg := metrics.NewAggregator(im.opts.TradeStore, im.opts.AggregateStore, im.opts.CandidateStore)
+	for _, k := range sorted {
+		for _, entry := range []string{string(domain.SourceNewToken), string(domain.SourceActiveToken)} {
+			a, err := agg.ComputeAggregate(ctx, k[0], k[1], entry)
+			if errors.Is(err, metrics.ErrNoTrades) {
+				continue
+			}
+			if err != nil {
+				return result, fmt.Errorf("compute aggregate %s/%s/%s: %w", k[0], k[1], entry, err)
+			}
+			if err := im.opts.AggregateStore.Upsert(ctx, a); err != nil {
+				return result, fmt.Errorf("store aggregate %s/%s/%s: %w", k[0], k[1], entry, err)
+			}
+			result.Aggregates++
+		}
+	}
+	return result, nil
+}
+
+// parse reads and validates all rows of r.
+func (im *Importer) parse(ctx context.Context, r io.Reader) ([]*domain.TradeRecord, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	var missing []string
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	_, hasCandidate := columns["candidate_id"]
+	_, hasMint := columns["mint"]
+	if !hasCandidate && !hasMint {
+		missing = append(missing, "candidate_id or mint")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing columns %s", ErrInvalidFile, strings.Join(missing, ", "))
+	}
+
+	resolver := &candidateResolver{store: im.opts.CandidateStore, byMint: make(map[string][]*domain.TokenCandidate)}
+	var trades []*domain.TradeRecord
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		row := csvRow{columns: columns, record: record}
+		t, err := im.parseTrade(row)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidFile, line, err)
+		}
+		t.CandidateID, err = resolver.resolve(ctx, row.get("candidate_id"), row.get("mint"), t.EntrySignalTime)
+		if errors.Is(err, errUnresolved) {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidFile, line, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: resolve candidate: %w", line, err)
+		}
+		t.TradeID = idhash.ComputeTradeID(t.CandidateID, t.StrategyID, t.ScenarioID, t.EntrySignalTime)
+		trades = append(trades, t)
+	}
+	return trades, nil
+}
+
+// parseTrade builds the trade of one row, without candidate and trade IDs.
+func (im *Importer) parseTrade(row csvRow) (*domain.TradeRecord, error) {
+	t := &domain.TradeRecord{
+		StrategyID:   row.get("strategy_id"),
+		ScenarioID:   row.get("scenario_id"),
+		ExitReason:   row.get("exit_reason"),
+		OutcomeClass: row.get("outcome_class"),
+	}
+	if t.StrategyID == "" {
+		return nil, errors.New("empty strategy_id")
+	}
+	t.StrategyID = domain.ExternalStrategyID(im.opts.Namespace, t.StrategyID)
+	if !knownScenarioIDs[t.ScenarioID] {
+		return nil, fmt.Errorf("unknown scenario_id %q", t.ScenarioID)
+	}
+	if !knownExitReasons[t.ExitReason] {
+		return nil, fmt.Errorf("unknown exit_reason %q", t.ExitReason)
+	}
+	if t.OutcomeClass != domain.OutcomeClassWin && t.OutcomeClass != domain.OutcomeClassLoss {
+		return nil, fmt.Errorf("unknown outcome_class %q", t.OutcomeClass)
+	}
+
+	p := &rowParser{row: row}
+	t.EntrySignalTime = p.int("entry_signal_time", 0)
+	t.EntrySignalPrice = p.float("entry_signal_price", 0)
+	t.EntryActualTime = p.int("entry_actual_time", 0)
+	t.EntryActualPrice = p.float("entry_actual_price", 0)
+	t.EntryLiquidity = p.optionalFloat("entry_liquidity")
+	t.PositionSize = p.float("position_size", 1)
+	t.PositionValue = p.float("position_value", t.EntryActualPrice*t.PositionSize)
+	t.ExitSignalTime = p.int("exit_signal_time", 0)
+	t.ExitSignalPrice = p.float("exit_signal_price", 0)
+	t.ExitActualTime = p.int("exit_actual_time", 0)
+	t.ExitActualPrice = p.float("exit_actual_price", 0)
+	t.EntryCostSOL = p.float("entry_cost_sol", 0)
+	t.ExitCostSOL = p.float("exit_cost_sol", 0)
+	t.MEVCostSOL = p.float("mev_cost_sol", 0)
+	t.TotalCostSOL = p.float("total_cost_sol", t.EntryCostSOL+t.ExitCostSOL+t.MEVCostSOL)
+	t.TotalCostPct = p.float("total_cost_pct", 0)
+	t.GrossReturn = p.float("gross_return", 0)
+	t.Outcome = p.float("outcome", 0)
+	t.HoldDurationMs = p.int("hold_duration_ms", t.ExitActualTime-t.EntryActualTime)
+	t.PeakPrice = p.optionalFloat("peak_price")
+	t.MinLiquidity = p.optionalFloat("min_liquidity")
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if (t.OutcomeClass == domain.OutcomeClassWin) != (t.Outcome > 0) {
+		return nil, fmt.Errorf("outcome_class %s with outcome %.6f", t.OutcomeClass, t.Outcome)
+	}
+	return t, nil
+}
+
+// csvRow looks up the fields of one record by column name.
+type csvRow struct {
+	columns map[string]int
+	record  []string
+}
+
+// get returns the trimmed field of column, or "" if the file has no such column.
+func (r csvRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// rowParser parses numeric fields, keeping the first error.
+type rowParser struct {
+	row csvRow
+	err error
+}
+
+func (p *rowParser) int(column string, def int64) int64 {
+	s := p.row.get(column)
+	if s == "" || p.err != nil {
+		return def
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		p.err = fmt.Errorf("%s: invalid integer %q", column, s)
+	}
+	return v
+}
+
+func (p *rowParser) float(column string, def float64) float64 {
+	if v := p.optionalFloat(column); v != nil {
+		return *v
+	}
+	return def
+}
+
+func (p *rowParser) optionalFloat(column string) *float64 {
+	s := p.row.get(column)
+	if s == "" || p.err != nil {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		p.err = fmt.Errorf("%s: invalid number %q", column, s)
+		return nil
+	}
+	return &v
+}
+
+// candidateResolver maps imported rows to stored candidates, caching lookups by mint.
+type candidateResolver struct {
+	store  storage.CandidateStore
+	byMint map[string][]*domain.TokenCandidate
+}
+
+// resolve returns candidateID if it is stored, or else the latest candidate of
+// mint discovered at or before entrySignalTime.
+func (c *candidateResolver) resolve(ctx context.Context, candidateID, mint string, entrySignalTime int64) (string, error) {
+	if candidateID != "" {
+		if _, err := c.store.GetByID(ctx, candidateID); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return "", fmt.Errorf("%w: unknown candidate_id %q", errUnresolved, candidateID)
+			}
+			return "", err
+		}
+		return candidateID, nil
+	}
+	if mint == "" {
+		return "", fmt.Errorf("%w: neither candidate_id nor mint set", errUnresolved)
+	}
+
+	candidates, ok := c.byMint[mint]
+	if !ok {
+		var err error
+		if candidates, err = c.store.GetByMint(ctx, mint); err != nil {
+			return "", err
+		}
+		c.byMint[mint] = candidates
+	}
+	// Ordered by discovered_at ASC
+	var found string
+	for _, cand := range candidates {
+		if cand.DiscoveredAt > entrySignalTime {
+			break
+		}
+		found = cand.CandidateID
+	}
+	if found == "" {
+		return "", fmt.Errorf("%w: no candidate of mint %s discovered by %d", errUnresolved, mint, entrySignalTime)
+	}
+	return found, nil
+}
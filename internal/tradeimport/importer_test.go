@@ -0,0 +1,186 @@
+package tradeimport
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/storage/memory"
+)
+
+const header = "trade_id,candidate_id,mint,strategy_id,scenario_id," +
+	"entry_signal_time,entry_signal_price,entry_actual_time,entry_actual_price," +
+	"exit_signal_time,exit_signal_price,exit_actual_time,exit_actual_price," +
+	"exit_reason,total_cost_sol,gross_return,outcome,outcome_class\n"
+
+// validFile has one row per way of identifying the candidate: by ID and by mint.
+const validFile = header +
+	"t1,c-new,,momentum,realistic,2000,1.0,2100,1.01,5000,1.2,5100,1.19,TIME_EXIT,0.001,0.18,0.17,WIN\n" +
+	"t2,,mint-active,momentum,realistic,6000,2.0,6100,2.02,9000,1.8,9100,1.79,INITIAL_STOP,0.001,-0.11,-0.12,LOSS\n"
+
+type testStores struct {
+	candidates *memory.CandidateStore
+	trades     *memory.TradeRecordStore
+	aggregates *memory.StrategyAggregateStore
+}
+
+func newTestStores(t *testing.T) *testStores {
+	t.Helper()
+	s := &testStores{
+		candidates: memory.NewCandidateStore(),
+		trades:     memory.NewTradeRecordStore(),
+		aggregates: memory.NewStrategyAggregateStore(),
+	}
+	ctx := context.Background()
+	for _, c := range []*domain.TokenCandidate{
+		{CandidateID: "c-new", Source: domain.SourceNewToken, Mint: "mint-new", TxSignature: "tx1", DiscoveredAt: 1000},
+		{CandidateID: "c-active-old", Source: domain.SourceActiveToken, Mint: "mint-active", TxSignature: "tx2", DiscoveredAt: 1000},
+		{CandidateID: "c-active", Source: domain.SourceActiveToken, Mint: "mint-active", TxSignature: "tx3", DiscoveredAt: 5000},
+		{CandidateID: "c-active-late", Source: domain.SourceActiveToken, Mint: "mint-active", TxSignature: "tx4", DiscoveredAt: 7000},
+	} {
+		if err := s.candidates.Insert(ctx, c); err != nil {
+			t.Fatalf("insert candidate: %v", err)
+		}
+	}
+	return s
+}
+
+func (s *testStores) importer(namespace string) *Importer {
+	return NewImporter(Options{
+		CandidateStore: s.candidates,
+		TradeStore:     s.trades,
+		AggregateStore: s.aggregates,
+		Namespace:      namespace,
+	})
+}
+
+func TestImport_ValidFile(t *testing.T) {
+	s := newTestStores(t)
+	ctx := context.Background()
+
+	result, err := s.importer("alice").Import(ctx, strings.NewReader(validFile))
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Rows != 2 || result.Imported != 2 || result.Duplicates != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	const strategyID = "EXT:alice:momentum"
+	if len(result.StrategyIDs) != 1 || result.StrategyIDs[0] != strategyID {
+		t.Errorf("expected strategy IDs [%s], got %v", strategyID, result.StrategyIDs)
+	}
+
+	trades, err := s.trades.GetByStrategyScenario(ctx, strategyID, domain.ScenarioRealistic)
+	if err != nil {
+		t.Fatalf("get trades: %v", err)
+	}
+	byCandidate := make(map[string]*domain.TradeRecord)
+	for _, tr := range trades {
+		byCandidate[tr.CandidateID] = tr
+	}
+	// The mint resolves to its latest candidate discovered by the entry signal
+	if len(byCandidate) != 2 || byCandidate["c-new"] == nil || byCandidate["c-active"] == nil {
+		t.Fatalf("expected trades of c-new and c-active, got %+v", byCandidate)
+	}
+	win := byCandidate["c-new"]
+	if win.TradeID == "t1" || win.PositionSize != 1 || win.HoldDurationMs != 3000 || win.TotalCostSOL != 0.001 {
+		t.Errorf("unexpected imported trade %+v", win)
+	}
+
+	// Aggregates are computed per entry type of the candidates
+	if result.Aggregates != 2 {
+		t.Errorf("expected 2 aggregates, got %d", result.Aggregates)
+	}
+	for _, entry := range []string{"NEW_TOKEN", "ACTIVE_TOKEN"} {
+		agg, err := s.aggregates.GetByKey(ctx, strategyID, domain.ScenarioRealistic, entry)
+		if err != nil {
+			t.Fatalf("get %s aggregate: %v", entry, err)
+		}
+		if agg.TotalTrades != 1 {
+			t.Errorf("%s: expected 1 trade, got %d", entry, agg.TotalTrades)
+		}
+	}
+}
+
+func TestImport_BadOutcomeClass(t *testing.T) {
+	s := newTestStores(t)
+	ctx := context.Background()
+
+	file := validFile + "t3,c-new,,momentum,realistic,3000,1.0,3100,1.0,4000,1.1,4100,1.1,TIME_EXIT,0,0.1,0.09,BIG_WIN\n"
+	_, err := s.importer("alice").Import(ctx, strings.NewReader(file))
+	if !errors.Is(err, ErrInvalidFile) {
+		t.Fatalf("expected ErrInvalidFile, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "line 4") || !strings.Contains(err.Error(), "BIG_WIN") {
+		t.Errorf("expected the offending line and value in %q", err)
+	}
+
+	// The valid rows before it are not stored either
+	all, err := s.trades.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("get trades: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("expected no trades stored, got %d", len(all))
+	}
+}
+
+func TestImport_DuplicateRun(t *testing.T) {
+	s := newTestStores(t)
+	ctx := context.Background()
+
+	if _, err := s.importer("alice").Import(ctx, strings.NewReader(validFile)); err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+	result, err := s.importer("alice").Import(ctx, strings.NewReader(validFile))
+	if err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if result.Imported != 0 || result.Duplicates != 2 {
+		t.Errorf("expected all rows duplicate, got %+v", result)
+	}
+
+	// Another namespace does not collide
+	result, err = s.importer("bob").Import(ctx, strings.NewReader(validFile))
+	if err != nil {
+		t.Fatalf("import as bob: %v", err)
+	}
+	if result.Imported != 2 {
+		t.Errorf("expected 2 trades imported for bob, got %+v", result)
+	}
+	all, _ := s.trades.GetAll(ctx)
+	if len(all) != 4 {
+		t.Errorf("expected 4 trades stored, got %d", len(all))
+	}
+}
+
+func TestImport_Validation(t *testing.T) {
+	s := newTestStores(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		namespace string
+		file      string
+		wantErr   error
+	}{
+		{"missing column", "alice", "candidate_id,strategy_id\nc-new,momentum\n", ErrInvalidFile},
+		{"unknown scenario", "alice", header + "t1,c-new,,m,typical,2000,1,2100,1,5000,1,5100,1,TIME_EXIT,0,0.1,0.1,WIN\n", ErrInvalidFile},
+		{"unknown exit reason", "alice", header + "t1,c-new,,m,realistic,2000,1,2100,1,5000,1,5100,1,SOLD,0,0.1,0.1,WIN\n", ErrInvalidFile},
+		{"class contradicts outcome", "alice", header + "t1,c-new,,m,realistic,2000,1,2100,1,5000,1,5100,1,TIME_EXIT,0,0.1,-0.1,WIN\n", ErrInvalidFile},
+		{"unknown candidate", "alice", header + "t1,c-missing,,m,realistic,2000,1,2100,1,5000,1,5100,1,TIME_EXIT,0,0.1,0.1,WIN\n", ErrInvalidFile},
+		{"mint discovered after entry", "alice", header + "t1,,mint-new,m,realistic,500,1,600,1,5000,1,5100,1,TIME_EXIT,0,0.1,0.1,WIN\n", ErrInvalidFile},
+		{"empty namespace", "", validFile, ErrInvalidNamespace},
+		{"namespace with separator", "a:b", validFile, ErrInvalidNamespace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.importer(tt.namespace).Import(ctx, strings.NewReader(tt.file))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
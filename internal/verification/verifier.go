@@ -280,6 +280,22 @@ func CompareTradeRecords(stored, replayed *domain.TradeRecord) []FieldDivergence
 		})
 	}
 
+	if !floatPtrEquals(stored.PeakLiquidity, replayed.PeakLiquidity) {
+		divergences = append(divergences, FieldDivergence{
+			Field:    "PeakLiquidity",
+			Expected: stored.PeakLiquidity,
+			Actual:   replayed.PeakLiquidity,
+		})
+	}
+
+	if !int64PtrEquals(stored.ArmedAt, replayed.ArmedAt) {
+		divergences = append(divergences, FieldDivergence{
+			Field:    "ArmedAt",
+			Expected: stored.ArmedAt,
+			Actual:   replayed.ArmedAt,
+		})
+	}
+
 	return divergences
 }
 
@@ -300,3 +316,12 @@ func floatPtrEquals(a, b *float64) bool {
 	}
 	return floatEquals(*a, *b)
 }
+
+// int64PtrEquals compares two *int64 values exactly.
+// Returns true if both are nil, or both are non-nil and equal.
+func int64PtrEquals(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,406 @@
+// Package client is a Go client for the solana-token-lab REST API (/api/v1)
+// served by cmd/server.
+//
+// All calls are context-aware. Requests failing with a transport error or a
+// 5xx status are retried with exponential backoff. Every response is checked
+// against APIVersion through the server's X-API-Version header.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersion is the API version this client is built against.
+const APIVersion = "1"
+
+// VersionHeader is the response header carrying the server's API version.
+const VersionHeader = "X-API-Version"
+
+// Default configuration values.
+const (
+	DefaultTimeout     = 30 * time.Second
+	DefaultMaxRetries  = 3
+	DefaultRetryDelay  = 500 * time.Millisecond
+	DefaultMaxDelay    = 5 * time.Second
+	DefaultBackoffMult = 2.0
+)
+
+// ErrNotFound is returned (wrapped in *APIError) for 404 responses.
+var ErrNotFound = errors.New("not found")
+
+// ErrIncompatibleVersion is returned (wrapped in *VersionError) when the server
+// reports an API version other than APIVersion.
+var ErrIncompatibleVersion = errors.New("incompatible API version")
+
+// APIError is a non-2xx response.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns ErrNotFound for 404 responses.
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// VersionError reports an API version mismatch.
+type VersionError struct {
+	Server string
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("server API version %q, client expects %q", e.Server, APIVersion)
+}
+
+// Unwrap returns ErrIncompatibleVersion.
+func (e *VersionError) Unwrap() error {
+	return ErrIncompatibleVersion
+}
+
+// Client calls the REST API.
+type Client struct {
+	baseURL     string
+	client      *http.Client
+	maxRetries  int
+	retryDelay  time.Duration
+	maxDelay    time.Duration
+	backoffMult float64
+}
+
+// Option configures Client.
+type Option func(*Client)
+
+// WithHTTPClient sets custom http.Client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// WithMaxRetries sets maximum retry attempts.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithRetryDelay sets initial retry delay.
+func WithRetryDelay(d time.Duration) Option {
+	return func(c *Client) {
+		c.retryDelay = d
+	}
+}
+
+// WithMaxDelay sets maximum retry delay.
+func WithMaxDelay(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxDelay = d
+	}
+}
+
+// New creates a client for the server at baseURL (e.g. "http://localhost:9090").
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		client:      &http.Client{Timeout: DefaultTimeout},
+		maxRetries:  DefaultMaxRetries,
+		retryDelay:  DefaultRetryDelay,
+		maxDelay:    DefaultMaxDelay,
+		backoffMult: DefaultBackoffMult,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CheckVersion fetches the server's API version and returns a *VersionError
+// if it is not APIVersion.
+func (c *Client) CheckVersion(ctx context.Context) error {
+	var v struct {
+		Version string `json:"version"`
+	}
+	if err := c.get(ctx, "/api/v1/version", nil, &v); err != nil {
+		return err
+	}
+	if v.Version != APIVersion {
+		return &VersionError{Server: v.Version}
+	}
+	return nil
+}
+
+// ListCandidates returns one page of candidates ordered by candidate ID.
+func (c *Client) ListCandidates(ctx context.Context, q CandidateQuery) (*CandidatePage, error) {
+	var page CandidatePage
+	if err := c.get(ctx, "/api/v1/candidates", q.values(), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ForEachCandidate calls fn for every candidate matching q, fetching pages as needed.
+// q.Cursor is the starting point; q.Limit is the page size. An error from fn stops
+// the iteration and is returned.
+func (c *Client) ForEachCandidate(ctx context.Context, q CandidateQuery, fn func(Candidate) error) error {
+	for {
+		page, err := c.ListCandidates(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, cand := range page.Candidates {
+			if err := fn(cand); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+// GetCandidate returns a candidate with its watchlist flag and latest holder snapshot.
+// A missing candidate returns an error wrapping ErrNotFound.
+func (c *Client) GetCandidate(ctx context.Context, candidateID string) (*Candidate, error) {
+	var cand Candidate
+	if err := c.get(ctx, "/api/v1/candidates/"+url.PathEscape(candidateID), nil, &cand); err != nil {
+		return nil, err
+	}
+	return &cand, nil
+}
+
+// GetDossier returns everything known about a candidate.
+// A missing candidate returns an error wrapping ErrNotFound.
+func (c *Client) GetDossier(ctx context.Context, candidateID string) (*Dossier, error) {
+	var d Dossier
+	if err := c.get(ctx, "/api/v1/candidates/"+url.PathEscape(candidateID)+"/dossier", nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListTrades returns one page of trades ordered by trade ID.
+func (c *Client) ListTrades(ctx context.Context, q TradeQuery) (*TradePage, error) {
+	var page TradePage
+	if err := c.get(ctx, "/api/v1/trades", q.values(), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ForEachTrade calls fn for every trade matching q, fetching pages as needed.
+// q.Cursor is the starting point; q.Limit is the page size. An error from fn stops
+// the iteration and is returned.
+func (c *Client) ForEachTrade(ctx context.Context, q TradeQuery, fn func(Trade) error) error {
+	for {
+		page, err := c.ListTrades(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, t := range page.Trades {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+// ListAggregates returns the strategy aggregates matching q, ordered by
+// (strategy_id, scenario_id, entry_event_type).
+func (c *Client) ListAggregates(ctx context.Context, q AggregateQuery) ([]Aggregate, error) {
+	var aggs []Aggregate
+	if err := c.get(ctx, "/api/v1/aggregates", q.values(), &aggs); err != nil {
+		return nil, err
+	}
+	return aggs, nil
+}
+
+// GetLatestSufficiency returns the data sufficiency checks of the most recent
+// pipeline run. Before the first run it returns an error wrapping ErrNotFound.
+func (c *Client) GetLatestSufficiency(ctx context.Context) (*SufficiencyRun, error) {
+	var run SufficiencyRun
+	if err := c.get(ctx, "/api/v1/sufficiency/latest", nil, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns returns the manifests of the latest runs matching q, newest first.
+func (c *Client) ListRuns(ctx context.Context, q RunQuery) ([]Run, error) {
+	var resp struct {
+		Runs []Run `json:"runs"`
+	}
+	if err := c.get(ctx, "/api/v1/runs", q.values(), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Runs, nil
+}
+
+// GetLatestRun returns the manifest of the most recent run of kind (pipeline
+// or report; empty for any). Before the first run it returns an error wrapping
+// ErrNotFound.
+func (c *Client) GetLatestRun(ctx context.Context, kind string) (*Run, error) {
+	q := url.Values{}
+	setString(q, "kind", kind)
+	var run Run
+	if err := c.get(ctx, "/api/v1/runs/latest", q, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetRun returns the manifest of one run, or an error wrapping ErrNotFound.
+func (c *Client) GetRun(ctx context.Context, runID string) (*Run, error) {
+	var run Run
+	if err := c.get(ctx, "/api/v1/runs/"+url.PathEscape(runID), nil, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetPreview returns the report preview of the latest pipeline run. It is not
+// authoritative. Before the first run it returns an error wrapping ErrNotFound.
+func (c *Client) GetPreview(ctx context.Context) (*Preview, error) {
+	var p Preview
+	if err := c.get(ctx, "/api/v1/preview", nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetCatalog returns the strategies and scenarios the server's build supports.
+func (c *Client) GetCatalog(ctx context.Context) (*Catalog, error) {
+	var cat Catalog
+	if err := c.get(ctx, "/api/v1/catalog", nil, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// GetStatus returns the server's /status: scheduler state, schema versions
+// and ingestion counters. /status is outside /api/v1 and not versioned.
+func (c *Client) GetStatus(ctx context.Context) (*Status, error) {
+	var st Status
+	if err := c.get(ctx, "/status", nil, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// get performs a GET with retries and decodes the JSON response into out.
+// Transport failures and 5xx responses are retried with exponential backoff;
+// other non-2xx responses are returned as *APIError. Cancellation of ctx aborts
+// the call immediately with ctx.Err().
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	delay := c.retryDelay
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			// Exponential backoff
+			delay = time.Duration(float64(delay) * c.backoffMult)
+			if delay > c.maxDelay {
+				delay = c.maxDelay
+			}
+		}
+
+		resp, err := c.send(ctx, path, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			lastErr = fmt.Errorf("read response: %w", err)
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			lastErr = &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+			continue
+		}
+		if err := checkVersion(resp); err != nil {
+			return err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+		}
+
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("GET %s: max retries exceeded: %w", path, lastErr)
+}
+
+// send issues one GET request.
+func (c *Client) send(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	return c.client.Do(req)
+}
+
+// checkVersion compares the response's API version with APIVersion.
+// Responses without the header (e.g. from a proxy) are accepted.
+func checkVersion(resp *http.Response) error {
+	v := resp.Header.Get(VersionHeader)
+	if v != "" && v != APIVersion {
+		return &VersionError{Server: v}
+	}
+	return nil
+}
+
+// setInt sets key to n if n is positive.
+func setInt(v url.Values, key string, n int64) {
+	if n > 0 {
+		v.Set(key, strconv.FormatInt(n, 10))
+	}
+}
+
+// setString sets key to s if s is not empty.
+func setString(v url.Values, key, s string) {
+	if s != "" {
+		v.Set(key, s)
+	}
+}
@@ -0,0 +1,525 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"solana-token-lab/internal/api"
+	"solana-token-lab/internal/domain"
+	"solana-token-lab/internal/reporting"
+	"solana-token-lab/internal/storage"
+	"solana-token-lab/internal/storage/memory"
+)
+
+// testServer serves the real /api/v1 handlers over memory stores.
+type testServer struct {
+	*httptest.Server
+	candidates  *memory.CandidateStore
+	trades      *memory.TradeRecordStore
+	aggregates  *memory.StrategyAggregateStore
+	watchlist   *memory.WatchlistStore
+	holders     *memory.TokenHolderSnapshotStore
+	sufficiency *memory.SufficiencyRunStore
+	runs        *memory.RunManifestStore
+	preview     atomic.Pointer[reporting.Preview]
+}
+
+// newTestServer starts the API with 5 candidates, 2 trades each and 2 aggregates.
+// wrap, if set, wraps the API handler.
+func newTestServer(t *testing.T, wrap func(http.Handler) http.Handler) *testServer {
+	t.Helper()
+	ctx := context.Background()
+
+	s := &testServer{
+		candidates:  memory.NewCandidateStore(),
+		trades:      memory.NewTradeRecordStore(),
+		aggregates:  memory.NewStrategyAggregateStore(),
+		watchlist:   memory.NewWatchlistStore(),
+		holders:     memory.NewTokenHolderSnapshotStore(),
+		sufficiency: memory.NewSufficiencyRunStore(),
+		runs:        memory.NewRunManifestStore(),
+	}
+
+	for i := 1; i <= 5; i++ {
+		source := domain.SourceNewToken
+		if i%2 == 0 {
+			source = domain.SourceActiveToken
+		}
+		c := &domain.TokenCandidate{
+			CandidateID:  fmt.Sprintf("cand%d", i),
+			Source:       source,
+			Mint:         fmt.Sprintf("mint%d", i),
+			TxSignature:  fmt.Sprintf("sig%d", i),
+			Slot:         int64(100 + i),
+			DiscoveredAt: int64(1000 * i),
+		}
+		if err := s.candidates.Insert(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		for _, scenario := range []string{"optimistic", "realistic"} {
+			tr := &domain.TradeRecord{
+				TradeID:      fmt.Sprintf("trade-%s-%s", c.CandidateID, scenario),
+				CandidateID:  c.CandidateID,
+				StrategyID:   "TIME_EXIT",
+				ScenarioID:   scenario,
+				Outcome:      0.1,
+				OutcomeClass: "WIN",
+			}
+			if err := s.trades.Insert(ctx, tr); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for _, scenario := range []string{"optimistic", "realistic"} {
+		a := &domain.StrategyAggregate{StrategyID: "TIME_EXIT", ScenarioID: scenario, EntryEventType: "NEW_TOKEN", TotalTrades: 6}
+		if err := s.aggregates.Insert(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	api.NewHandler(api.Stores{
+		Candidates:      s.candidates,
+		Trades:          s.trades,
+		Aggregates:      s.aggregates,
+		Watchlist:       s.watchlist,
+		HolderSnapshots: s.holders,
+		Sufficiency:     s.sufficiency,
+		RunManifests:    s.runs,
+	}).WithStreamInterval(20 * time.Millisecond).WithPreview(s.preview.Load).
+		WithCatalog(domain.NewCatalog("v-test")).
+		Register(mux)
+
+	var h http.Handler = mux
+	if wrap != nil {
+		h = wrap(mux)
+	}
+	s.Server = httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestClient(url string) *Client {
+	return New(url, WithRetryDelay(time.Millisecond), WithMaxDelay(5*time.Millisecond))
+}
+
+func TestClient_CheckVersion(t *testing.T) {
+	if APIVersion != api.Version || VersionHeader != api.VersionHeader {
+		t.Fatalf("client built against API %s (%s), server serves %s (%s)", APIVersion, VersionHeader, api.Version, api.VersionHeader)
+	}
+
+	srv := newTestServer(t, nil)
+	if err := newTestClient(srv.URL).CheckVersion(context.Background()); err != nil {
+		t.Fatalf("CheckVersion: %v", err)
+	}
+}
+
+func TestClient_ListCandidates(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	if err := srv.watchlist.Add(ctx, &domain.WatchlistEntry{Mint: "mint2", Reason: "manual"}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := c.ListCandidates(ctx, CandidateQuery{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Candidates) != 2 || page.Candidates[0].CandidateID != "cand1" || page.NextCursor != "cand2" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	if page.Candidates[0].Watchlisted || !page.Candidates[1].Watchlisted {
+		t.Errorf("expected only cand2 watchlisted, got %+v", page.Candidates)
+	}
+
+	page, err = c.ListCandidates(ctx, CandidateQuery{Source: "ACTIVE_TOKEN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Candidates) != 2 || page.NextCursor != "" {
+		t.Fatalf("expected 2 ACTIVE_TOKEN candidates on one page, got %+v", page)
+	}
+
+	var ids []string
+	err = c.ForEachCandidate(ctx, CandidateQuery{Limit: 2}, func(cand Candidate) error {
+		ids = append(ids, cand.CandidateID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[cand1 cand2 cand3 cand4 cand5]" {
+		t.Errorf("ForEachCandidate visited %v", ids)
+	}
+
+	var apiErr *APIError
+	if _, err := c.ListCandidates(ctx, CandidateQuery{Source: "BOGUS"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid source, got %v", err)
+	}
+}
+
+func TestClient_GetCandidate(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	if err := srv.watchlist.Add(ctx, &domain.WatchlistEntry{Mint: "mint3", Reason: "manual"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.holders.Insert(ctx, &domain.TokenHolderSnapshot{CandidateID: "cand3", Mint: "mint3", SnapshotAt: 5000, HolderCount: 42, Top10Pct: 61.5}); err != nil {
+		t.Fatal(err)
+	}
+
+	cand, err := c.GetCandidate(ctx, "cand3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cand.Mint != "mint3" || cand.Source != "NEW_TOKEN" || !cand.Watchlisted {
+		t.Errorf("unexpected candidate: %+v", cand)
+	}
+	if cand.HolderSnapshot == nil || cand.HolderSnapshot.HolderCount != 42 {
+		t.Errorf("expected holder snapshot, got %+v", cand.HolderSnapshot)
+	}
+
+	if _, err := c.GetCandidate(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_SoftDeletedCandidate(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	if err := srv.candidates.SoftDelete(ctx, &storage.CandidateDeletion{
+		CandidateID: "cand3", DeletedAt: 9000, Reason: "test token", Requester: "ops",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := c.ListCandidates(ctx, CandidateQuery{Source: "NEW_TOKEN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cand := range page.Candidates {
+		if cand.CandidateID == "cand3" {
+			t.Errorf("deleted candidate listed: %+v", page.Candidates)
+		}
+	}
+
+	cand, err := c.GetCandidate(ctx, "cand3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cand.DeletedAt == nil || *cand.DeletedAt != 9000 || cand.DeletedReason != "test token" {
+		t.Errorf("expected deletion on the candidate, got %+v", cand)
+	}
+}
+
+func TestClient_GetDossier(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	d, err := c.GetDossier(ctx, "cand2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Candidate == nil || d.Candidate.CandidateID != "cand2" {
+		t.Fatalf("unexpected dossier candidate: %+v", d.Candidate)
+	}
+	if len(d.Trades) != 2 {
+		t.Errorf("expected 2 dossier trades, got %d", len(d.Trades))
+	}
+
+	if _, err := c.GetDossier(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_GetLatestSufficiency(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	if _, err := c.GetLatestSufficiency(ctx); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before the first run, got %v", err)
+	}
+
+	for _, run := range []*storage.SufficiencyRun{
+		{RunAt: 1000, DataVersion: "v1", Checks: []storage.SufficiencyCheckRecord{{Name: "unique_new_token_candidates", Threshold: ">= 300", Actual: "12", Pass: false}}, ErrorCount: 1, Errors: []string{"e"}},
+		{RunAt: 2000, DataVersion: "v2", AllPass: true, Checks: []storage.SufficiencyCheckRecord{{Name: "unique_new_token_candidates", Threshold: ">= 300", Actual: "312", Pass: true}}},
+	} {
+		if err := srv.sufficiency.Insert(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run, err := c.GetLatestSufficiency(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.RunAt != 2000 || run.DataVersion != "v2" || !run.AllPass || run.ErrorCount != 0 || len(run.Errors) != 0 {
+		t.Errorf("unexpected latest run: %+v", run)
+	}
+	if len(run.Checks) != 1 || run.Checks[0].Actual != "312" || !run.Checks[0].Pass {
+		t.Errorf("unexpected checks: %+v", run.Checks)
+	}
+}
+
+func TestClient_Runs(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	if _, err := c.GetLatestRun(ctx, ""); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before the first run, got %v", err)
+	}
+
+	for _, m := range []*storage.RunManifest{
+		{RunID: "pipeline-1", Kind: storage.RunKindPipeline, StartedAt: 1000, FinishedAt: 1500, Candidates: 5, Trades: 10},
+		{RunID: "report-1", Kind: storage.RunKindReport, StartedAt: 2000, FinishedAt: 2500, GitCommit: "abc123",
+			Config:   map[string]string{"postgres-dsn": "postgres://lab:REDACTED@db/lab"},
+			Backends: []storage.RunBackend{{Name: "postgres", Version: "16.2"}},
+			Decision: "GO", DataVersion: "dv1", Errors: []string{"e"}, ErrorCount: 3},
+		{RunID: "pipeline-2", Kind: storage.RunKindPipeline, StartedAt: 3000, FinishedAt: 3500},
+	} {
+		if err := srv.runs.Insert(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := c.ListRuns(ctx, RunQuery{Kind: storage.RunKindPipeline})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].RunID != "pipeline-2" || runs[1].RunID != "pipeline-1" {
+		t.Errorf("expected pipeline runs newest first, got %+v", runs)
+	}
+	if runs, err := c.ListRuns(ctx, RunQuery{Limit: 1}); err != nil || len(runs) != 1 || runs[0].RunID != "pipeline-2" {
+		t.Errorf("expected the newest run only, got %+v (%v)", runs, err)
+	}
+
+	latest, err := c.GetLatestRun(ctx, storage.RunKindReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.RunID != "report-1" || latest.Decision != "GO" || latest.DataVersion != "dv1" || latest.GitCommit != "abc123" ||
+		latest.ErrorCount != 3 || len(latest.Errors) != 1 || latest.Config["postgres-dsn"] != "postgres://lab:REDACTED@db/lab" ||
+		len(latest.Backends) != 1 || latest.Backends[0].Version != "16.2" {
+		t.Errorf("unexpected latest report run: %+v", latest)
+	}
+
+	run, err := c.GetRun(ctx, "pipeline-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Kind != storage.RunKindPipeline || run.Candidates != 5 || run.Trades != 10 || run.FinishedAt != 1500 {
+		t.Errorf("unexpected run: %+v", run)
+	}
+	if _, err := c.GetRun(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown run, got %v", err)
+	}
+	if _, err := c.ListRuns(ctx, RunQuery{Kind: "weekly"}); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
+
+func TestClient_GetPreview(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	if _, err := c.GetPreview(ctx); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before the first preview, got %v", err)
+	}
+
+	srv.preview.Store(&reporting.Preview{
+		Notice:           reporting.PreviewNotice,
+		ExecutiveSummary: reporting.PreviewSummary{BestStrategy: "TIME_EXIT", NewTokenCount: 3},
+		StrategyMetrics:  []reporting.PreviewMetricRow{{StrategyID: "TIME_EXIT", ScenarioID: "realistic", TotalTrades: 6}},
+	})
+	p, err := c.GetPreview(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Authoritative || p.Notice != reporting.PreviewNotice {
+		t.Errorf("preview must be non-authoritative: %+v", p)
+	}
+	if p.ExecutiveSummary.BestStrategy != "TIME_EXIT" || len(p.StrategyMetrics) != 1 || p.StrategyMetrics[0].TotalTrades != 6 {
+		t.Errorf("unexpected preview: %+v", p)
+	}
+}
+
+func TestClient_GetCatalog(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+
+	cat, err := c.GetCatalog(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cat.StrategyVersion != "v-test" || len(cat.Strategies) != 3 || len(cat.Scenarios) != 4 {
+		t.Fatalf("unexpected catalog: %+v", cat)
+	}
+	trailing := cat.Strategies[1]
+	if trailing.Type != "TRAILING_STOP" || len(trailing.Parameters) != 4 || trailing.Parameters[0].Name != "trail_pct" {
+		t.Errorf("unexpected TRAILING_STOP spec: %+v", trailing)
+	}
+	realistic := cat.Scenarios[1]
+	if realistic.ScenarioID != "realistic" || realistic.DelayMs != 500 || realistic.SandwichModel.MaxProbability != 0.8 {
+		t.Errorf("unexpected realistic scenario: %+v", realistic)
+	}
+}
+
+func TestClient_ListTrades(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	page, err := c.ListTrades(ctx, TradeQuery{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Trades) != 3 || page.NextCursor != page.Trades[2].TradeID {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+
+	count := 0
+	err = c.ForEachTrade(ctx, TradeQuery{Limit: 3}, func(Trade) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 10 {
+		t.Errorf("ForEachTrade: visited %d trades, err %v", count, err)
+	}
+
+	var scenarios []string
+	err = c.ForEachTrade(ctx, TradeQuery{CandidateID: "cand4", Limit: 1}, func(tr Trade) error {
+		if tr.CandidateID != "cand4" {
+			t.Errorf("trade %s of %s", tr.TradeID, tr.CandidateID)
+		}
+		scenarios = append(scenarios, tr.ScenarioID)
+		return nil
+	})
+	if err != nil || fmt.Sprint(scenarios) != "[optimistic realistic]" {
+		t.Errorf("ForEachTrade by candidate: %v, err %v", scenarios, err)
+	}
+
+	// fn errors stop the iteration
+	stop := errors.New("stop")
+	if err := c.ForEachTrade(ctx, TradeQuery{}, func(Trade) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("expected fn error, got %v", err)
+	}
+}
+
+func TestClient_ListAggregates(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+
+	aggs, err := c.ListAggregates(context.Background(), AggregateQuery{ScenarioID: "realistic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aggs) != 1 || aggs[0].StrategyID != "TIME_EXIT" || aggs[0].TotalTrades != 6 {
+		t.Errorf("unexpected aggregates: %+v", aggs)
+	}
+}
+
+func TestClient_StreamCandidates(t *testing.T) {
+	srv := newTestServer(t, nil)
+	c := newTestClient(srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Existing candidates from since onwards come first, then new ones as they are inserted
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		srv.candidates.Insert(ctx, &domain.TokenCandidate{
+			CandidateID: "cand6", Source: domain.SourceNewToken, Mint: "mint6", DiscoveredAt: 6000,
+		})
+	}()
+
+	if err := srv.watchlist.Add(ctx, &domain.WatchlistEntry{Mint: "mint6", Reason: "manual"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	err := c.StreamCandidates(ctx, 4000, func(cand Candidate) error {
+		if cand.Watchlisted != (cand.Mint == "mint6") {
+			t.Errorf("%s: watchlisted = %v", cand.CandidateID, cand.Watchlisted)
+		}
+		ids = append(ids, cand.CandidateID)
+		if len(ids) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if fmt.Sprint(ids) != "[cand4 cand5 cand6]" {
+		t.Errorf("streamed %v", ids)
+	}
+}
+
+func TestClient_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := newTestServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= 2 {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	cand, err := newTestClient(srv.URL).GetCandidate(context.Background(), "cand1")
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if cand.CandidateID != "cand1" || calls.Load() != 3 {
+		t.Errorf("got %s after %d calls", cand.CandidateID, calls.Load())
+	}
+
+	// Exhausted retries surface the last server error
+	calls.Store(-100)
+	_, err = New(srv.URL, WithMaxRetries(1), WithRetryDelay(time.Millisecond)).GetCandidate(context.Background(), "cand1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 APIError, got %v", err)
+	}
+}
+
+func TestClient_IncompatibleVersion(t *testing.T) {
+	srv := newTestServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, r)
+			for k, v := range rec.Header() {
+				w.Header()[k] = v
+			}
+			w.Header().Set(api.VersionHeader, "2")
+			w.WriteHeader(rec.Code)
+			w.Write(rec.Body.Bytes())
+		})
+	})
+	c := newTestClient(srv.URL)
+
+	var verr *VersionError
+	if _, err := c.GetCandidate(context.Background(), "cand1"); !errors.As(err, &verr) || verr.Server != "2" {
+		t.Errorf("expected VersionError, got %v", err)
+	}
+	if err := c.CheckVersion(context.Background()); !errors.Is(err, ErrIncompatibleVersion) {
+		t.Errorf("expected ErrIncompatibleVersion, got %v", err)
+	}
+}
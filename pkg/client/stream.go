@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrStreamClosed is returned by StreamCandidates when the server ends the stream.
+var ErrStreamClosed = errors.New("candidate stream closed by server")
+
+// StreamCandidates subscribes to newly discovered candidates (Server-Sent Events)
+// and calls fn for each, in discovery order. Candidates discovered at or after
+// since (Unix ms) are delivered; since <= 0 means from now.
+//
+// It blocks until ctx is cancelled (returning ctx.Err()), fn returns an error
+// (returned as is) or the stream ends (ErrStreamClosed or the server's error).
+// The connection is not retried; resume with since set to the last DiscoveredAt.
+func (c *Client) StreamCandidates(ctx context.Context, since int64, fn func(Candidate) error) error {
+	query := url.Values{}
+	setInt(query, "since", since)
+
+	// The response body is read for the lifetime of the stream, so the
+	// client's overall timeout must not apply
+	hc := *c.client
+	hc.Timeout = 0
+
+	u := c.baseURL + "/api/v1/candidates/stream"
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkVersion(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+
+	err = readEvents(resp.Body, func(event, data string) error {
+		switch event {
+		case "candidate":
+			var cand Candidate
+			if err := json.Unmarshal([]byte(data), &cand); err != nil {
+				return fmt.Errorf("decode candidate event: %w", err)
+			}
+			return fn(cand)
+		case "error":
+			msg, uerr := strconv.Unquote(data)
+			if uerr != nil {
+				msg = data
+			}
+			return fmt.Errorf("candidate stream: %s", msg)
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		return ErrStreamClosed
+	}
+	return err
+}
+
+// readEvents parses a text/event-stream body and calls fn per dispatched event.
+// Comments and unknown fields are ignored; multi-line data is joined with "\n".
+func readEvents(r io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				if err := fn(event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,428 @@
+package client
+
+import (
+	"net/url"
+	"time"
+)
+
+// Candidate is a discovered token candidate.
+type Candidate struct {
+	CandidateID  string  `json:"candidate_id"`
+	Source       string  `json:"source"` // NEW_TOKEN | ACTIVE_TOKEN
+	Mint         string  `json:"mint"`
+	Pool         *string `json:"pool,omitempty"`
+	TxSignature  string  `json:"tx_signature"`
+	EventIndex   int     `json:"event_index"`
+	Slot         int64   `json:"slot"`
+	DiscoveredAt int64   `json:"discovered_at"`       // Unix ms
+	Status       string  `json:"status,omitempty"`    // OPEN | CLOSED
+	ClosedAt     *int64  `json:"closed_at,omitempty"` // Unix ms, nil while OPEN
+
+	// Set on soft-deleted candidates, which only GetCandidate returns
+	DeletedAt     *int64 `json:"deleted_at,omitempty"` // Unix ms
+	DeletedReason string `json:"deleted_reason,omitempty"`
+
+	// Set by GetCandidate only
+	Watchlisted    bool            `json:"watchlisted"`
+	HolderSnapshot *HolderSnapshot `json:"holder_snapshot,omitempty"`
+
+	// Set in dossiers only; nil for backfilled/replayed candidates
+	DetectedAt *int64 `json:"detected_at,omitempty"`
+}
+
+// HolderSnapshot is the latest holder concentration snapshot of a candidate.
+type HolderSnapshot struct {
+	SnapshotAt    int64   `json:"snapshot_at"`
+	HolderCount   int     `json:"holder_count"`
+	Top10Pct      float64 `json:"top10_pct"`
+	TotalSupply   float64 `json:"total_supply"`
+	LargestHolder string  `json:"largest_holder,omitempty"`
+}
+
+// CandidateQuery selects candidates. Zero fields are not sent.
+type CandidateQuery struct {
+	Source string // NEW_TOKEN | ACTIVE_TOKEN; empty for both
+	Limit  int    // page size; server default if 0
+	Cursor string // NextCursor of the previous page
+}
+
+func (q CandidateQuery) values() url.Values {
+	v := url.Values{}
+	setString(v, "source", q.Source)
+	setInt(v, "limit", int64(q.Limit))
+	setString(v, "cursor", q.Cursor)
+	return v
+}
+
+// CandidatePage is one page of candidates. NextCursor is empty on the last page.
+type CandidatePage struct {
+	Candidates []Candidate `json:"candidates"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// Dossier is everything known about one candidate.
+// Sections that could not be loaded are nil and explained in Warnings.
+type Dossier struct {
+	Candidate         *Candidate      `json:"candidate"`
+	Metadata          *TokenMetadata  `json:"metadata,omitempty"`
+	Swaps             *EventSummary   `json:"swaps,omitempty"`
+	LiquidityEvents   *EventSummary   `json:"liquidity_events,omitempty"`
+	Traders           *TraderSummary  `json:"traders,omitempty"`
+	LatestPrice       *PricePoint     `json:"latest_price,omitempty"`
+	LatestLiquidity   *LiquidityPoint `json:"latest_liquidity,omitempty"`
+	Trades            []DossierTrade  `json:"trades"`
+	ReplayFingerprint string          `json:"replay_fingerprint,omitempty"`
+	Warnings          []string        `json:"warnings"`
+}
+
+// TraderSummary counts the distinct traders (swap tx fee payers) of a candidate's mint.
+type TraderSummary struct {
+	UniqueTraders            int `json:"unique_traders"`
+	UniqueTradersAtDiscovery int `json:"unique_traders_at_discovery"` // within an hour either side of discovery
+	UnattributedSwaps        int `json:"unattributed_swaps"`
+}
+
+// TokenMetadata is the on-chain metadata of a candidate's mint.
+type TokenMetadata struct {
+	Name            *string  `json:"name,omitempty"`
+	Symbol          *string  `json:"symbol,omitempty"`
+	URI             *string  `json:"uri,omitempty"`
+	Decimals        int      `json:"decimals"`
+	Supply          *float64 `json:"supply,omitempty"`
+	MintAuthority   *string  `json:"mint_authority,omitempty"`
+	FreezeAuthority *string  `json:"freeze_authority,omitempty"`
+	FetchedAt       int64    `json:"fetched_at"`
+}
+
+// EventSummary counts a candidate's raw events and their time range.
+type EventSummary struct {
+	Count     int   `json:"count"`
+	FirstTime int64 `json:"first_time,omitempty"`
+	LastTime  int64 `json:"last_time,omitempty"`
+}
+
+// PricePoint is the most recent price timeseries point.
+type PricePoint struct {
+	TimestampMs int64   `json:"timestamp_ms"`
+	Price       float64 `json:"price"`
+}
+
+// LiquidityPoint is the most recent liquidity timeseries point.
+type LiquidityPoint struct {
+	TimestampMs int64   `json:"timestamp_ms"`
+	Liquidity   float64 `json:"liquidity"`
+}
+
+// DossierTrade is the summary of a trade listed in a dossier.
+type DossierTrade struct {
+	TradeID         string  `json:"trade_id"`
+	StrategyID      string  `json:"strategy_id"`
+	ScenarioID      string  `json:"scenario_id"`
+	EntryActualTime int64   `json:"entry_actual_time"`
+	ExitActualTime  int64   `json:"exit_actual_time"`
+	ExitReason      string  `json:"exit_reason"`
+	Outcome         float64 `json:"outcome"`
+	OutcomeClass    string  `json:"outcome_class"`
+}
+
+// Trade is a simulated trade record.
+type Trade struct {
+	TradeID     string `json:"trade_id"`
+	CandidateID string `json:"candidate_id"`
+	StrategyID  string `json:"strategy_id"`
+	ScenarioID  string `json:"scenario_id"`
+
+	EntrySignalTime  int64    `json:"entry_signal_time"`
+	EntrySignalPrice float64  `json:"entry_signal_price"`
+	EntryActualTime  int64    `json:"entry_actual_time"`
+	EntryActualPrice float64  `json:"entry_actual_price"`
+	EntryLiquidity   *float64 `json:"entry_liquidity,omitempty"`
+	PositionSize     float64  `json:"position_size"`
+	PositionValue    float64  `json:"position_value"`
+
+	ExitSignalTime  int64   `json:"exit_signal_time"`
+	ExitSignalPrice float64 `json:"exit_signal_price"`
+	ExitActualTime  int64   `json:"exit_actual_time"`
+	ExitActualPrice float64 `json:"exit_actual_price"`
+	ExitReason      string  `json:"exit_reason"`
+
+	TotalCostSOL float64 `json:"total_cost_sol"`
+	TotalCostPct float64 `json:"total_cost_pct"`
+
+	GrossReturn  float64 `json:"gross_return"`
+	Outcome      float64 `json:"outcome"`
+	OutcomeClass string  `json:"outcome_class"` // WIN | LOSS
+
+	HoldDurationMs int64    `json:"hold_duration_ms"`
+	PeakPrice      *float64 `json:"peak_price,omitempty"`
+	MinLiquidity   *float64 `json:"min_liquidity,omitempty"`
+	PeakLiquidity  *float64 `json:"peak_liquidity,omitempty"`
+	ArmedAt        *int64   `json:"armed_at,omitempty"` // ms
+}
+
+// TradeQuery selects trades. Zero fields are not sent.
+type TradeQuery struct {
+	CandidateID string // only this candidate's trades
+	Limit       int    // page size; server default if 0
+	Cursor      string // NextCursor of the previous page
+}
+
+func (q TradeQuery) values() url.Values {
+	v := url.Values{}
+	setString(v, "candidate_id", q.CandidateID)
+	setInt(v, "limit", int64(q.Limit))
+	setString(v, "cursor", q.Cursor)
+	return v
+}
+
+// TradePage is one page of trades. NextCursor is empty on the last page.
+type TradePage struct {
+	Trades     []Trade `json:"trades"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// Aggregate is the outcome statistics of one strategy/scenario/entry event type.
+type Aggregate struct {
+	StrategyID     string `json:"strategy_id"`
+	ScenarioID     string `json:"scenario_id"`
+	EntryEventType string `json:"entry_event_type"`
+
+	TotalTrades  int     `json:"total_trades"`
+	TotalTokens  int     `json:"total_tokens"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinRate      float64 `json:"win_rate"`
+	TokenWinRate float64 `json:"token_win_rate"`
+
+	OutcomeMean   float64 `json:"outcome_mean"`
+	OutcomeMedian float64 `json:"outcome_median"`
+	OutcomeP10    float64 `json:"outcome_p10"`
+	OutcomeP25    float64 `json:"outcome_p25"`
+	OutcomeP75    float64 `json:"outcome_p75"`
+	OutcomeP90    float64 `json:"outcome_p90"`
+	OutcomeMin    float64 `json:"outcome_min"`
+	OutcomeMax    float64 `json:"outcome_max"`
+	OutcomeStddev float64 `json:"outcome_stddev"`
+
+	MaxDrawdown          float64 `json:"max_drawdown"`
+	MaxConsecutiveLosses int     `json:"max_consecutive_losses"`
+
+	OutcomeRealistic   *float64 `json:"outcome_realistic,omitempty"`
+	OutcomePessimistic *float64 `json:"outcome_pessimistic,omitempty"`
+	OutcomeDegraded    *float64 `json:"outcome_degraded,omitempty"`
+
+	DataEndExits int     `json:"data_end_exits"` // trades forced out when the price data ended
+	DataEndShare float64 `json:"data_end_share"` // DataEndExits / TotalTrades
+
+	ComputedAt int64 `json:"computed_at,omitempty"` // Unix ms, 0 if unknown
+}
+
+// SufficiencyCheck is one data sufficiency check.
+type SufficiencyCheck struct {
+	Name      string `json:"name"`
+	Threshold string `json:"threshold"`
+	Actual    string `json:"actual"`
+	Pass      bool   `json:"pass"`
+}
+
+// SufficiencyRun is the result of the data sufficiency checks of one pipeline run.
+type SufficiencyRun struct {
+	RunAt       int64              `json:"run_at"` // Unix ms
+	DataVersion string             `json:"data_version"`
+	AllPass     bool               `json:"all_pass"`
+	Checks      []SufficiencyCheck `json:"checks"`
+	Errors      []string           `json:"errors"`      // truncated integrity errors
+	ErrorCount  int                `json:"error_count"` // total integrity errors
+}
+
+// Preview is the non-authoritative report preview written after a pipeline run:
+// executive summary and strategy metrics, without sufficiency checks or a decision.
+type Preview struct {
+	Authoritative    bool               `json:"authoritative"` // always false
+	Notice           string             `json:"notice"`
+	GeneratedAt      time.Time          `json:"generated_at"`
+	ExecutiveSummary PreviewSummary     `json:"executive_summary"`
+	StrategyMetrics  []PreviewMetricRow `json:"strategy_metrics"`
+}
+
+// PreviewSummary is the executive summary of a preview.
+type PreviewSummary struct {
+	BestStrategy      string  `json:"best_strategy,omitempty"`
+	BestEntryType     string  `json:"best_entry_type,omitempty"`
+	WinRateRealistic  float64 `json:"win_rate_realistic"`
+	MedianRealistic   float64 `json:"median_realistic"`
+	MedianPessimistic float64 `json:"median_pessimistic"`
+	DataPeriodStart   int64   `json:"data_period_start,omitempty"` // Unix ms
+	DataPeriodEnd     int64   `json:"data_period_end,omitempty"`   // Unix ms
+	NewTokenCount     int     `json:"new_token_count"`
+	ActiveTokenCount  int     `json:"active_token_count"`
+}
+
+// PreviewMetricRow is one strategy metrics row of a preview.
+type PreviewMetricRow struct {
+	StrategyID     string  `json:"strategy_id"`
+	ScenarioID     string  `json:"scenario_id"`
+	EntryEventType string  `json:"entry_event_type"`
+	TotalTrades    int     `json:"total_trades"`
+	WinRate        float64 `json:"win_rate"`
+	OutcomeMean    float64 `json:"outcome_mean"`
+	OutcomeMedian  float64 `json:"outcome_median"`
+}
+
+// Catalog lists the strategies, parameter defaults and execution scenarios the
+// server's build supports.
+type Catalog struct {
+	StrategyVersion string            `json:"strategy_version"`
+	EntryEventTypes []string          `json:"entry_event_types"`
+	Strategies      []CatalogStrategy `json:"strategies"`
+	Scenarios       []CatalogScenario `json:"scenarios"`
+}
+
+// CatalogStrategy is one strategy type of a Catalog.
+type CatalogStrategy struct {
+	Type       string             `json:"type"`
+	Parameters []CatalogParameter `json:"parameters"`
+}
+
+// CatalogParameter is one strategy parameter of a Catalog.
+type CatalogParameter struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"` // int64 | float64 | string
+	Unit     string   `json:"unit"` // ms | fraction
+	Required bool     `json:"required"`
+	Default  float64  `json:"default"`
+	Values   []string `json:"values,omitempty"` // string parameters only, default first
+}
+
+// CatalogScenario is one predefined execution scenario of a Catalog.
+type CatalogScenario struct {
+	ScenarioID     string          `json:"scenario_id"`
+	DelayMs        int64           `json:"delay_ms"`
+	SlippagePct    float64         `json:"slippage_pct"`
+	FeeSOL         float64         `json:"fee_sol"`
+	PriorityFeeSOL float64         `json:"priority_fee_sol"`
+	MEVPenaltyPct  float64         `json:"mev_penalty_pct"`
+	SandwichModel  CatalogSandwich `json:"sandwich_model"`
+}
+
+// CatalogSandwich is the sandwich model calibration of a scenario.
+type CatalogSandwich struct {
+	BaseProbability      float64 `json:"base_probability"`
+	ProbabilityPerImpact float64 `json:"probability_per_impact"`
+	MaxProbability       float64 `json:"max_probability"`
+	LossPerImpact        float64 `json:"loss_per_impact"`
+	MaxLossFraction      float64 `json:"max_loss_fraction"`
+}
+
+// RunBackend is a storage backend of a run.
+type RunBackend struct {
+	Name    string `json:"name"`              // postgres | clickhouse | memory
+	Version string `json:"version,omitempty"` // server version, empty if unknown
+}
+
+// Run is the manifest of one pipeline or report run: what ran, on which code,
+// configuration and backends, and what it produced.
+type Run struct {
+	RunID       string            `json:"run_id"`
+	Kind        string            `json:"kind"`        // pipeline | report
+	StartedAt   int64             `json:"started_at"`  // Unix ms
+	FinishedAt  int64             `json:"finished_at"` // Unix ms
+	GitCommit   string            `json:"git_commit"`
+	Config      map[string]string `json:"config"` // credentials redacted
+	Backends    []RunBackend      `json:"backends"`
+	Candidates  int               `json:"candidates"`
+	Trades      int               `json:"trades"`
+	Aggregates  int               `json:"aggregates"`
+	DataVersion string            `json:"data_version,omitempty"` // report runs only
+	Decision    string            `json:"decision,omitempty"`     // report runs only
+	Errors      []string          `json:"errors"`                 // truncated errors
+	ErrorCount  int               `json:"error_count"`            // total errors
+}
+
+// AggregateQuery selects aggregates. Zero fields are not sent.
+type AggregateQuery struct {
+	StrategyID     string
+	ScenarioID     string
+	EntryEventType string // base type; matches plain aggregates and their cohorts
+	Cohort         string // entry filter label
+	ComputedAfter  int64  // Unix ms
+}
+
+func (q AggregateQuery) values() url.Values {
+	v := url.Values{}
+	setString(v, "strategy_id", q.StrategyID)
+	setString(v, "scenario_id", q.ScenarioID)
+	setString(v, "entry_event_type", q.EntryEventType)
+	setString(v, "cohort", q.Cohort)
+	setInt(v, "computed_after", q.ComputedAfter)
+	return v
+}
+
+// RunQuery selects runs. Zero fields are not sent.
+type RunQuery struct {
+	Kind  string // pipeline | report
+	Limit int    // page size, server default if 0
+}
+
+func (q RunQuery) values() url.Values {
+	v := url.Values{}
+	setString(v, "kind", q.Kind)
+	setInt(v, "limit", int64(q.Limit))
+	return v
+}
+
+// Status is the server's /status response.
+type Status struct {
+	Status           string    `json:"status"`
+	Uptime           string    `json:"uptime"`
+	IngestionStarted time.Time `json:"ingestion_started"`
+	LastPipelineRun  time.Time `json:"last_pipeline_run"` // zero before the first run
+	LastReportRun    time.Time `json:"last_report_run"`
+	NextPipelineRun  time.Time `json:"next_pipeline_run"` // zero before the scheduler starts
+	NextReportRun    time.Time `json:"next_report_run"`
+	PipelineRuns     int       `json:"pipeline_runs"`
+	ReportRuns       int       `json:"report_runs"`
+	PipelineRunning  bool      `json:"pipeline_running"`
+	ReportRunning    bool      `json:"report_running"`
+	DirtyCandidates  int       `json:"dirty_candidates"`
+
+	LatestPipelineManifest string `json:"latest_pipeline_manifest,omitempty"`
+	LatestReportManifest   string `json:"latest_report_manifest,omitempty"`
+
+	Programs  []ProgramStatus  `json:"programs,omitempty"`
+	Ingestion *IngestionStatus `json:"ingestion,omitempty"` // nil without live ingestion
+	Schema    []SchemaStatus   `json:"schema,omitempty"`
+}
+
+// ProgramStatus holds the ingestion counters of one DEX program.
+type ProgramStatus struct {
+	Program       string `json:"program"`
+	Active        bool   `json:"active"`
+	Events        int64  `json:"events"`
+	Candidates    int64  `json:"candidates"`
+	ParseFailures int64  `json:"parse_failures"`
+	Duplicates    int64  `json:"duplicates_suppressed"`
+	Transactions  int64  `json:"transactions"`
+	FailedTxs     int64  `json:"failed_transactions"`
+	LastEventAt   int64  `json:"last_event_at,omitempty"` // Unix ms, 0 before the first event
+}
+
+// IngestionStatus holds ingestion counters since the server started.
+type IngestionStatus struct {
+	SwapEvents             int64 `json:"swap_events"`
+	LiquidityEvents        int64 `json:"liquidity_events"`
+	NewTokens              int64 `json:"new_tokens_discovered"`
+	ActiveTokens           int64 `json:"active_tokens_discovered"`
+	WSHealthProbe          bool  `json:"ws_health_probe"`
+	WSEndpointSwitches     int64 `json:"ws_endpoint_switches"`
+	WSEndpointSwitchErrors int64 `json:"ws_endpoint_switch_errors"`
+	GapBackfills           int64 `json:"gap_backfills"`
+	GapBackfillErrors      int64 `json:"gap_backfill_errors"`
+}
+
+// SchemaStatus is the schema version of one database detected at startup.
+type SchemaStatus struct {
+	Backend  string `json:"backend"`
+	Version  string `json:"version"`
+	Expected string `json:"expected_version"`
+}
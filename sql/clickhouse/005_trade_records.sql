@@ -0,0 +1,54 @@
+-- Migration: 005_trade_records
+-- Description: Analytical mirror of PostgreSQL trade_records for database-side aggregation
+-- Engine: ReplacingMergeTree so repeated replication of the same trade_id is idempotent
+--
+-- PostgreSQL remains the source of truth. Rows are tagged at replication time with:
+--   strategy_type    - canonical base strategy type (strategy.CanonicalType)
+--   entry_event_type - NEW_TOKEN / ACTIVE_TOKEN, resolved from the candidate source
+
+CREATE TABLE IF NOT EXISTS trade_records (
+    trade_id            String,
+    candidate_id        String,
+    strategy_id         String,
+    strategy_type       String,
+    scenario_id         String,
+    entry_event_type    String,
+
+    -- Entry
+    entry_signal_time   Int64,              -- Unix timestamp (ms)
+    entry_signal_price  Float64,
+    entry_actual_time   Int64,
+    entry_actual_price  Float64,
+    entry_liquidity     Nullable(Float64),
+    position_size       Float64,
+    position_value      Float64,
+
+    -- Exit
+    exit_signal_time    Int64,
+    exit_signal_price   Float64,
+    exit_actual_time    Int64,
+    exit_actual_price   Float64,
+    exit_reason         String,
+
+    -- Costs
+    entry_cost_sol      Float64,
+    exit_cost_sol       Float64,
+    mev_cost_sol        Float64,
+    total_cost_sol      Float64,
+    total_cost_pct      Float64,
+
+    -- Outcome
+    gross_return        Float64,
+    outcome             Float64,
+    outcome_class       String,             -- WIN / LOSS
+
+    -- Metadata
+    hold_duration_ms    Int64,
+    peak_price          Nullable(Float64),
+    min_liquidity       Nullable(Float64),
+
+    replicated_at       DateTime DEFAULT now()
+)
+ENGINE = ReplacingMergeTree(replicated_at)
+ORDER BY (scenario_id, strategy_type, entry_event_type, strategy_id, trade_id)
+SETTINGS index_granularity = 8192;
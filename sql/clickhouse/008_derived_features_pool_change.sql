@@ -0,0 +1,9 @@
+-- Migration: 008_derived_features_pool_change
+-- Description: Pool change marker on derived features
+--
+-- A candidate's liquidity can move to another pool, e.g. a pump.fun bonding
+-- curve graduating to Raydium. Its timeseries stay continuous across pools;
+-- pool_change marks the first feature point at or after the first event of
+-- each later pool, where deltas compare values of different pools.
+
+ALTER TABLE derived_features ADD COLUMN IF NOT EXISTS pool_change Bool DEFAULT false;
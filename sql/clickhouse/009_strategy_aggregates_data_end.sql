@@ -0,0 +1,10 @@
+-- Migration: 009_strategy_aggregates_data_end
+-- Description: DATA_END exits on strategy aggregates
+--
+-- data_end_exits counts the trades forced out because the price data ended
+-- before the strategy's exit (exit_reason DATA_END); data_end_share is their
+-- share of total_trades. Both are 0 for rows written before these columns
+-- existed.
+
+ALTER TABLE strategy_aggregates ADD COLUMN IF NOT EXISTS data_end_exits UInt32 DEFAULT 0;
+ALTER TABLE strategy_aggregates ADD COLUMN IF NOT EXISTS data_end_share Float64 DEFAULT 0;
@@ -0,0 +1,15 @@
+-- Migration: 010_trade_sync_state
+-- Description: Watermark of the incremental trade_records mirror sync
+--
+-- The sync mirrors the PostgreSQL trade records written (inserted or
+-- upserted) since the watermark, the trade_records.updated_at up to which
+-- the mirror is complete. Keeping the watermark next to the mirror makes a
+-- recreated ClickHouse database sync from scratch.
+
+CREATE TABLE IF NOT EXISTS trade_sync_state (
+    mirror      String,                     -- mirrored table, e.g. 'trade_records'
+    watermark   Int64,                      -- Unix timestamp (ms) of PostgreSQL trade_records.updated_at
+    synced_at   DateTime64(3) DEFAULT now64(3)
+)
+ENGINE = ReplacingMergeTree(synced_at)
+ORDER BY mirror;
@@ -0,0 +1,32 @@
+-- Migration: 013_token_holder_snapshots
+-- Description: Timestamped holder concentration snapshots per candidate
+-- Append-only: No UPDATE/DELETE allowed
+
+CREATE TABLE IF NOT EXISTS token_holder_snapshots (
+    candidate_id        TEXT NOT NULL REFERENCES token_candidates(candidate_id),
+    mint                TEXT NOT NULL,
+    snapshot_at         BIGINT NOT NULL,            -- Unix timestamp (ms)
+    holder_count        INTEGER NOT NULL,           -- lower bound from largest accounts
+    top10_pct           DOUBLE PRECISION NOT NULL,  -- 0-100
+    total_supply        DOUBLE PRECISION NOT NULL,  -- UI units
+    largest_holder      TEXT,
+
+    PRIMARY KEY (candidate_id, snapshot_at)
+);
+
+-- Append-only enforcement: raise exception on UPDATE and DELETE
+DROP TRIGGER IF EXISTS token_holder_snapshots_no_update ON token_holder_snapshots;
+CREATE TRIGGER token_holder_snapshots_no_update
+    BEFORE UPDATE ON token_holder_snapshots
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+DROP TRIGGER IF EXISTS token_holder_snapshots_no_delete ON token_holder_snapshots;
+CREATE TRIGGER token_holder_snapshots_no_delete
+    BEFORE DELETE ON token_holder_snapshots
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+COMMENT ON TABLE token_holder_snapshots IS 'Holder concentration snapshots from getTokenLargestAccounts. Append-only.';
+COMMENT ON COLUMN token_holder_snapshots.holder_count IS 'Non-empty accounts among the largest accounts (lower bound, max 20)';
+COMMENT ON COLUMN token_holder_snapshots.top10_pct IS 'Percent of supply held by the 10 largest accounts';
+COMMENT ON COLUMN token_holder_snapshots.total_supply IS 'Total supply in UI units at snapshot time';
+COMMENT ON COLUMN token_holder_snapshots.largest_holder IS 'Largest token account address (may be NULL if no holders)';
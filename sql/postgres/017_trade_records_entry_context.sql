@@ -0,0 +1,19 @@
+-- Migration: 017_trade_records_entry_context
+-- Description: Entry signal context snapshot on simulated trades
+--
+-- entry_context holds the full domain.EntryContext as JSONB. The scalar columns
+-- duplicate the fields most used for entry-filter research so they can be
+-- indexed and filtered without JSON operators. All NULL for trades simulated
+-- before this migration.
+
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS entry_context JSONB;
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS entry_volume_1h DOUBLE PRECISION;
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS token_age_ms BIGINT;
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS swaps_prior_count INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_trade_records_token_age ON trade_records(token_age_ms);
+
+COMMENT ON COLUMN trade_records.entry_context IS 'Market context at entry signal: liquidity, volume_1h, swaps_per_minute, swaps_prior, token_age_ms';
+COMMENT ON COLUMN trade_records.entry_volume_1h IS 'Volume in the hour ending at the entry signal';
+COMMENT ON COLUMN trade_records.token_age_ms IS 'Entry signal time minus first observed swap (ms)';
+COMMENT ON COLUMN trade_records.swaps_prior_count IS 'Swaps up to and including the entry signal';
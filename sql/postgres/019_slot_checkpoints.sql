@@ -0,0 +1,9 @@
+-- Per-slot completion of block-based backfills (--backfill-strategy=blocks).
+-- A row means every transaction of the slot mentioning a configured program was
+-- parsed and stored; slots without a row in a backfilled range are gaps.
+CREATE TABLE IF NOT EXISTS slot_checkpoints (
+    slot BIGINT PRIMARY KEY,
+    skipped BOOLEAN NOT NULL DEFAULT FALSE,
+    transactions INTEGER NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
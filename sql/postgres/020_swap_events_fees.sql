@@ -0,0 +1,13 @@
+-- Migration: 020_swap_events_fees
+-- Description: Transaction fee telemetry on discovery swap events
+--
+-- Both columns come from the swap transaction: fee_lamports from meta.fee,
+-- priority_fee_lamports from its ComputeBudget instructions (unit price x
+-- unit limit). Every event of a transaction carries the same values. NULL for
+-- events ingested before this migration or without a fetched transaction.
+
+ALTER TABLE swap_events ADD COLUMN IF NOT EXISTS fee_lamports BIGINT;
+ALTER TABLE swap_events ADD COLUMN IF NOT EXISTS priority_fee_lamports BIGINT;
+
+COMMENT ON COLUMN swap_events.fee_lamports IS 'Total transaction fee paid in lamports (base + priority)';
+COMMENT ON COLUMN swap_events.priority_fee_lamports IS 'ComputeBudget prioritization fee in lamports';
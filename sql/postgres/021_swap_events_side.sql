@@ -0,0 +1,13 @@
+-- Migration: 021_swap_events_side
+-- Description: Buy/sell side on discovery swap events
+--
+-- Set by parsers that can tell the direction (pump.fun Buy/Sell instructions,
+-- Raydium WSOL in/out). NULL when unknown, including all events ingested
+-- before this migration. Used by the ACTIVE_TOKEN buy/sell imbalance gate.
+
+ALTER TABLE swap_events ADD COLUMN IF NOT EXISTS side TEXT;
+
+ALTER TABLE swap_events DROP CONSTRAINT IF EXISTS chk_swap_events_side;
+ALTER TABLE swap_events ADD CONSTRAINT chk_swap_events_side CHECK (side IS NULL OR side IN ('buy', 'sell'));
+
+COMMENT ON COLUMN swap_events.side IS 'Swap side: buy | sell, NULL if unknown';
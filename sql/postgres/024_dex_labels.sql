@@ -0,0 +1,20 @@
+-- Migration: 024_dex_labels
+-- Description: DEX label on parsed events and candidates
+--
+-- Records which DEX program an event was parsed from ('raydium', 'pumpfun').
+-- A candidate inherits the label of its triggering event so aggregates can be
+-- segmented by discovery DEX. NULL when unknown, including all rows written
+-- before this migration. token_candidates.dex is set at insert, so the
+-- append-only closure trigger is unaffected.
+
+ALTER TABLE token_candidates ADD COLUMN IF NOT EXISTS dex TEXT;
+ALTER TABLE swaps ADD COLUMN IF NOT EXISTS dex TEXT;
+ALTER TABLE swap_events ADD COLUMN IF NOT EXISTS dex TEXT;
+ALTER TABLE liquidity_events ADD COLUMN IF NOT EXISTS dex TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_token_candidates_dex ON token_candidates(dex);
+
+COMMENT ON COLUMN token_candidates.dex IS 'DEX of the triggering event: raydium | pumpfun, NULL if unknown';
+COMMENT ON COLUMN swaps.dex IS 'DEX the swap was parsed from, NULL if unknown';
+COMMENT ON COLUMN swap_events.dex IS 'DEX the swap event was parsed from, NULL if unknown';
+COMMENT ON COLUMN liquidity_events.dex IS 'DEX the liquidity event was parsed from, NULL if unknown';
@@ -0,0 +1,76 @@
+-- Migration: 025_finality
+-- Description: Finality verification of ingested events
+--
+-- Events are ingested at confirmed commitment. A confirmed slot can still be
+-- skipped by a fork, leaving events whose transactions never finalize. The
+-- finality verifier records finalized transactions in finalized_signatures and
+-- deletes the events of dropped ones, auditing each deleted event in
+-- finality_corrections. The event tables stay append-only otherwise: DELETE is
+-- only permitted for an event with a matching correction row.
+
+CREATE TABLE IF NOT EXISTS finality_corrections (
+    id                  BIGSERIAL PRIMARY KEY,
+    event_table         TEXT NOT NULL,              -- 'swaps' | 'swap_events' | 'liquidity_events'
+    tx_signature        TEXT NOT NULL,
+    event_index         INTEGER NOT NULL,
+    candidate_id        TEXT,                       -- NULL for swap_events and unassociated liquidity events
+    mint                TEXT,                       -- NULL for swaps
+    slot                BIGINT NOT NULL,
+    timestamp           BIGINT NOT NULL,            -- event timestamp (ms)
+    detected_at         BIGINT NOT NULL,            -- when the transaction was found missing (ms)
+
+    CONSTRAINT chk_finality_event_table CHECK (event_table IN ('swaps', 'swap_events', 'liquidity_events'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_finality_corrections_detected_at ON finality_corrections(detected_at);
+CREATE INDEX IF NOT EXISTS idx_finality_corrections_tx ON finality_corrections(tx_signature);
+
+-- Events stored before verification existed are treated as final (verified_at = 0).
+-- The backfill runs only when the table is first created, since migrations re-run on every start.
+DO $$
+BEGIN
+    IF to_regclass('finalized_signatures') IS NULL THEN
+        CREATE TABLE finalized_signatures (
+            tx_signature    TEXT PRIMARY KEY,
+            verified_at     BIGINT NOT NULL         -- Unix timestamp (ms), 0 for pre-existing events
+        );
+        INSERT INTO finalized_signatures (tx_signature, verified_at)
+        SELECT tx_signature, 0 FROM swaps
+        UNION SELECT tx_signature, 0 FROM swap_events
+        UNION SELECT tx_signature, 0 FROM liquidity_events;
+    END IF;
+END $$;
+
+CREATE OR REPLACE FUNCTION allow_finality_correction_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM finality_corrections fc
+        WHERE fc.event_table = TG_TABLE_NAME
+          AND fc.tx_signature = OLD.tx_signature
+          AND fc.event_index = OLD.event_index
+    ) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'Table % is append-only. Only events audited in finality_corrections may be deleted.', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS swaps_no_delete ON swaps;
+CREATE TRIGGER swaps_no_delete
+    BEFORE DELETE ON swaps
+    FOR EACH ROW EXECUTE FUNCTION allow_finality_correction_delete();
+
+DROP TRIGGER IF EXISTS swap_events_no_delete ON swap_events;
+CREATE TRIGGER swap_events_no_delete
+    BEFORE DELETE ON swap_events
+    FOR EACH ROW EXECUTE FUNCTION allow_finality_correction_delete();
+
+DROP TRIGGER IF EXISTS liquidity_events_no_delete ON liquidity_events;
+CREATE TRIGGER liquidity_events_no_delete
+    BEFORE DELETE ON liquidity_events
+    FOR EACH ROW EXECUTE FUNCTION allow_finality_correction_delete();
+
+COMMENT ON TABLE finality_corrections IS 'Audit of events deleted because their transaction never finalized. Append-only by convention.';
+COMMENT ON TABLE finalized_signatures IS 'Transactions verified at finalized commitment; events of other transactions are pending';
+COMMENT ON FUNCTION allow_finality_correction_delete() IS 'Append-only guard that permits deleting events audited in finality_corrections only';
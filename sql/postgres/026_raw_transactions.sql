@@ -0,0 +1,74 @@
+-- Migration: 026_raw_transactions
+-- Description: Raw transaction archive and reparse corrections
+--
+-- Ingestion can archive each fetched transaction in raw_transactions so a
+-- later parser fix can be re-run over the archive without RPC. The archive is
+-- size-capped by the writer (oldest slots evicted first). Re-parsing replaces
+-- stored swap_events and liquidity_events whose parsed fields changed and
+-- inserts events the old parser missed; every change is audited in
+-- reparse_corrections, and DELETE on the event tables is permitted for events
+-- with either a finality correction (025) or a reparse correction.
+
+CREATE TABLE IF NOT EXISTS raw_transactions (
+    tx_signature        TEXT PRIMARY KEY,
+    slot                BIGINT NOT NULL,
+    block_time          BIGINT NOT NULL,            -- Unix timestamp (seconds)
+    data                BYTEA NOT NULL,             -- transaction JSON in the getTransaction response shape
+    size_bytes          BIGINT NOT NULL,            -- length(data), summed for the size cap
+    archived_at         BIGINT NOT NULL             -- Unix timestamp (ms)
+);
+
+CREATE INDEX IF NOT EXISTS idx_raw_transactions_slot ON raw_transactions(slot, tx_signature);
+
+CREATE TABLE IF NOT EXISTS reparse_corrections (
+    id                  BIGSERIAL PRIMARY KEY,
+    event_table         TEXT NOT NULL,              -- 'swap_events' | 'liquidity_events'
+    tx_signature        TEXT NOT NULL,
+    event_index         INTEGER NOT NULL,
+    change              TEXT NOT NULL,              -- 'ADDED' | 'CHANGED'
+    before_json         TEXT,                       -- stored event, NULL for ADDED
+    after_json          TEXT NOT NULL,              -- re-parsed event
+    corrected_at        BIGINT NOT NULL,            -- Unix timestamp (ms)
+
+    CONSTRAINT chk_reparse_event_table CHECK (event_table IN ('swap_events', 'liquidity_events')),
+    CONSTRAINT chk_reparse_change CHECK (change IN ('ADDED', 'CHANGED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reparse_corrections_corrected_at ON reparse_corrections(corrected_at);
+CREATE INDEX IF NOT EXISTS idx_reparse_corrections_tx ON reparse_corrections(tx_signature);
+
+CREATE OR REPLACE FUNCTION allow_audited_event_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM finality_corrections fc
+        WHERE fc.event_table = TG_TABLE_NAME
+          AND fc.tx_signature = OLD.tx_signature
+          AND fc.event_index = OLD.event_index
+    ) OR EXISTS (
+        SELECT 1 FROM reparse_corrections rc
+        WHERE rc.event_table = TG_TABLE_NAME
+          AND rc.tx_signature = OLD.tx_signature
+          AND rc.event_index = OLD.event_index
+          AND rc.change = 'CHANGED'
+    ) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'Table % is append-only. Only events audited in finality_corrections or reparse_corrections may be deleted.', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+-- swaps are derived and not re-parsed, so they keep the finality-only guard from 025.
+DROP TRIGGER IF EXISTS swap_events_no_delete ON swap_events;
+CREATE TRIGGER swap_events_no_delete
+    BEFORE DELETE ON swap_events
+    FOR EACH ROW EXECUTE FUNCTION allow_audited_event_delete();
+
+DROP TRIGGER IF EXISTS liquidity_events_no_delete ON liquidity_events;
+CREATE TRIGGER liquidity_events_no_delete
+    BEFORE DELETE ON liquidity_events
+    FOR EACH ROW EXECUTE FUNCTION allow_audited_event_delete();
+
+COMMENT ON TABLE raw_transactions IS 'Size-capped archive of fetched transactions for audit and reparse; oldest slots evicted first';
+COMMENT ON TABLE reparse_corrections IS 'Audit of events added or replaced by re-parsing archived transactions. Append-only by convention.';
+COMMENT ON FUNCTION allow_audited_event_delete() IS 'Append-only guard that permits deleting events audited in finality_corrections or as CHANGED in reparse_corrections';
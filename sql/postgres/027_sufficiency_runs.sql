@@ -0,0 +1,31 @@
+-- Migration: 027_sufficiency_runs
+-- Description: History of data sufficiency check runs
+--
+-- Each pipeline run records its sufficiency checks so the latest data quality
+-- status can be served by the API and exported as metrics without re-running
+-- the checks. Append-only like the research tables.
+
+CREATE TABLE IF NOT EXISTS sufficiency_runs (
+    id              BIGSERIAL PRIMARY KEY,
+    run_at          BIGINT NOT NULL,            -- Unix timestamp (ms)
+    data_version    TEXT NOT NULL DEFAULT '',
+    all_pass        BOOLEAN NOT NULL,
+    checks          JSONB NOT NULL,             -- [{name, threshold, actual, pass}]
+    errors          JSONB NOT NULL,             -- first 100 integrity errors
+    error_count     INTEGER NOT NULL            -- total integrity errors
+);
+
+CREATE INDEX IF NOT EXISTS idx_sufficiency_runs_run_at ON sufficiency_runs(run_at DESC, id DESC);
+
+DROP TRIGGER IF EXISTS sufficiency_runs_no_update ON sufficiency_runs;
+CREATE TRIGGER sufficiency_runs_no_update
+    BEFORE UPDATE ON sufficiency_runs
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+DROP TRIGGER IF EXISTS sufficiency_runs_no_delete ON sufficiency_runs;
+CREATE TRIGGER sufficiency_runs_no_delete
+    BEFORE DELETE ON sufficiency_runs
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+COMMENT ON TABLE sufficiency_runs IS 'Data sufficiency check results per pipeline run. Append-only.';
+COMMENT ON COLUMN sufficiency_runs.errors IS 'Integrity errors, truncated; error_count holds the full count';
@@ -0,0 +1,27 @@
+-- Migration: 029_provisional_mints
+-- Description: Mints discovered at creation (PRE_POOL), ahead of their first pool
+--
+-- Operational state like the watchlist: rows move from PENDING to UPGRADED
+-- when a pool makes the mint a NEW_TOKEN candidate, or to EXPIRED when no pool
+-- appears within the provisional TTL. created_at keeps the mint creation time
+-- for latency analysis; candidate_id is not a foreign key so a mint can be
+-- recorded before or without its candidate being stored.
+
+CREATE TABLE IF NOT EXISTS provisional_mints (
+    mint            TEXT PRIMARY KEY,
+    tx_signature    TEXT NOT NULL,
+    slot            BIGINT NOT NULL,
+    created_at      BIGINT NOT NULL,            -- Unix timestamp (ms) of the mint creation block
+    detected_at     BIGINT,                     -- Unix timestamp (ms), NULL for backfilled mints
+    status          TEXT NOT NULL DEFAULT 'PENDING',
+    candidate_id    TEXT,                       -- NEW_TOKEN candidate, set on upgrade
+    resolved_at     BIGINT,                     -- candidate discovered_at on upgrade, expiry time on expiry
+
+    CONSTRAINT chk_provisional_status CHECK (status IN ('PENDING', 'UPGRADED', 'EXPIRED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_provisional_mints_status_created ON provisional_mints(status, created_at);
+
+COMMENT ON TABLE provisional_mints IS 'Mints seen at InitializeMint before any pool. Status changes on upgrade or expiry.';
+COMMENT ON COLUMN provisional_mints.created_at IS 'Block time (ms) of the mint creation, the earliest NEW_TOKEN signal';
+COMMENT ON COLUMN provisional_mints.resolved_at IS 'Candidate discovered_at (UPGRADED) or expiry time (EXPIRED), ms';
@@ -0,0 +1,18 @@
+-- Migration: 031_event_origin
+-- Description: Ingestion origin on swap and liquidity events
+--
+-- Records how an event reached storage: 'live_ws' (WebSocket subscription),
+-- 'backfill' (RPC backfill) or 'replay' (reparsed from the raw transaction
+-- archive). Rows written before this migration are 'unknown'. The decision
+-- gate uses it to require a minimum share of live-discovered candidates.
+
+ALTER TABLE swap_events ADD COLUMN IF NOT EXISTS origin TEXT NOT NULL DEFAULT 'unknown';
+ALTER TABLE liquidity_events ADD COLUMN IF NOT EXISTS origin TEXT NOT NULL DEFAULT 'unknown';
+
+ALTER TABLE swap_events DROP CONSTRAINT IF EXISTS chk_swap_events_origin;
+ALTER TABLE swap_events ADD CONSTRAINT chk_swap_events_origin CHECK (origin IN ('live_ws', 'backfill', 'replay', 'unknown'));
+ALTER TABLE liquidity_events DROP CONSTRAINT IF EXISTS chk_liquidity_events_origin;
+ALTER TABLE liquidity_events ADD CONSTRAINT chk_liquidity_events_origin CHECK (origin IN ('live_ws', 'backfill', 'replay', 'unknown'));
+
+COMMENT ON COLUMN swap_events.origin IS 'Ingestion origin: live_ws | backfill | replay | unknown';
+COMMENT ON COLUMN liquidity_events.origin IS 'Ingestion origin: live_ws | backfill | replay | unknown';
@@ -0,0 +1,25 @@
+-- Migration: 032_candidate_event_counts
+-- Description: Per-candidate event counts of capped ingestion
+--
+-- With per-candidate caps, live ingestion stores every event of a candidate's
+-- observation window up to the cap and only 1 in sample_every beyond it
+-- (liquidity removals are always kept). The true totals are kept here so
+-- simulation, sufficiency checks and reports can account for downsampling.
+-- Rows are upserted as ingestion proceeds.
+
+CREATE TABLE IF NOT EXISTS candidate_event_counts (
+    candidate_id     TEXT PRIMARY KEY,
+    swaps_seen       BIGINT NOT NULL DEFAULT 0,   -- swap events observed in the window
+    swaps_stored     BIGINT NOT NULL DEFAULT 0,   -- swap events stored
+    liquidity_seen   BIGINT NOT NULL DEFAULT 0,   -- liquidity events observed in the window
+    liquidity_stored BIGINT NOT NULL DEFAULT 0,   -- liquidity events stored
+    sample_every     BIGINT NOT NULL DEFAULT 1,   -- downsampling factor past a cap
+    updated_at       BIGINT NOT NULL              -- Unix timestamp (ms) of the last update
+);
+
+ALTER TABLE candidate_event_counts DROP CONSTRAINT IF EXISTS chk_candidate_event_counts_stored;
+ALTER TABLE candidate_event_counts ADD CONSTRAINT chk_candidate_event_counts_stored
+    CHECK (swaps_stored <= swaps_seen AND liquidity_stored <= liquidity_seen);
+
+COMMENT ON TABLE candidate_event_counts IS 'True event totals per candidate under per-candidate ingestion caps. Upserted by the ingestion runner.';
+COMMENT ON COLUMN candidate_event_counts.sample_every IS 'Past a cap, 1 event in sample_every is stored; liquidity removals are always stored';
@@ -0,0 +1,10 @@
+-- Migration: 033_trade_peak_liquidity
+-- Description: Running peak liquidity on trade records
+--
+-- LIQUIDITY_GUARD in peak reference mode measures the liquidity drop from the
+-- running max liquidity since entry instead of the entry liquidity, and records
+-- that peak here. NULL for other strategies, entry mode and older trades.
+
+ALTER TABLE trade_records ADD COLUMN IF NOT EXISTS peak_liquidity DOUBLE PRECISION;
+
+COMMENT ON COLUMN trade_records.peak_liquidity IS 'Max liquidity during hold (LIQUIDITY_GUARD peak mode)';
@@ -0,0 +1,28 @@
+-- Migration: 035_pool_links
+-- Description: Pools attributed to a mint's canonical candidate over time
+--
+-- A token can trade in several pools over its life: a pump.fun bonding curve
+-- that graduates to a Raydium pool, or a second pool created on another DEX.
+-- Each pool is linked to the candidate of its mint for [valid_from, valid_to),
+-- so ingestion attributes every pool's events to one candidate. valid_to is
+-- NULL while the pool is live. candidate_id is not a foreign key, like
+-- provisional_mints, so links can be recorded ahead of the candidate row.
+
+CREATE TABLE IF NOT EXISTS pool_links (
+    pool            TEXT PRIMARY KEY,
+    mint            TEXT NOT NULL,
+    candidate_id    TEXT NOT NULL,
+    dex             TEXT NOT NULL DEFAULT '',
+    reason          TEXT NOT NULL,              -- 'discovery' | 'pool_creation' | 'graduation'
+    tx_signature    TEXT NOT NULL DEFAULT '',   -- event that opened the link
+    valid_from      BIGINT NOT NULL,            -- Unix timestamp (ms), inclusive
+    valid_to        BIGINT,                     -- Unix timestamp (ms), exclusive; NULL while live
+    created_at      BIGINT NOT NULL,
+
+    CONSTRAINT chk_pool_link_reason CHECK (reason IN ('discovery', 'pool_creation', 'graduation'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_pool_links_mint_valid_from ON pool_links(mint, valid_from);
+
+COMMENT ON TABLE pool_links IS 'Pool to candidate attribution with validity ranges, across DEX migrations';
+COMMENT ON COLUMN pool_links.valid_to IS 'End of the link (ms, exclusive), e.g. bonding curve migration time; NULL while live';
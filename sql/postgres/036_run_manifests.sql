@@ -0,0 +1,41 @@
+-- Migration: 036_run_manifests
+-- Description: History of pipeline and report run manifests
+--
+-- Each orchestrator and report run records which code (git commit),
+-- configuration (credentials redacted), backends and data produced its
+-- numbers, so the API can answer "which run produced this" without logs.
+-- Append-only like sufficiency_runs.
+
+CREATE TABLE IF NOT EXISTS run_manifests (
+    id              BIGSERIAL PRIMARY KEY,
+    run_id          TEXT NOT NULL UNIQUE,
+    kind            TEXT NOT NULL,              -- pipeline | report
+    started_at      BIGINT NOT NULL,            -- Unix timestamp (ms)
+    finished_at     BIGINT NOT NULL,            -- Unix timestamp (ms)
+    git_commit      TEXT NOT NULL DEFAULT '',
+    config          JSONB NOT NULL,             -- {flag: value}, credentials redacted
+    backends        JSONB NOT NULL,             -- [{name, version}]
+    candidates      INTEGER NOT NULL DEFAULT 0,
+    trades          INTEGER NOT NULL DEFAULT 0,
+    aggregates      INTEGER NOT NULL DEFAULT 0,
+    data_version    TEXT NOT NULL DEFAULT '',
+    decision        TEXT NOT NULL DEFAULT '',
+    errors          JSONB NOT NULL,             -- first 100 errors
+    error_count     INTEGER NOT NULL            -- total errors
+);
+
+CREATE INDEX IF NOT EXISTS idx_run_manifests_started_at ON run_manifests(started_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_run_manifests_kind ON run_manifests(kind, started_at DESC, id DESC);
+
+DROP TRIGGER IF EXISTS run_manifests_no_update ON run_manifests;
+CREATE TRIGGER run_manifests_no_update
+    BEFORE UPDATE ON run_manifests
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+DROP TRIGGER IF EXISTS run_manifests_no_delete ON run_manifests;
+CREATE TRIGGER run_manifests_no_delete
+    BEFORE DELETE ON run_manifests
+    FOR EACH ROW EXECUTE FUNCTION raise_append_only_violation();
+
+COMMENT ON TABLE run_manifests IS 'Code, configuration and data behind each pipeline and report run. Append-only.';
+COMMENT ON COLUMN run_manifests.config IS 'Effective configuration with DSN passwords and API keys redacted';
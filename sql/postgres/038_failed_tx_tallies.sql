@@ -0,0 +1,24 @@
+-- Migration: 038_failed_tx_tallies
+-- Description: Hourly tallies of failed transactions per program
+--
+-- Ingestion skips transactions that failed on chain, as they carry no events.
+-- Their share per program and hour is the observed on-chain failure rate of
+-- the collection period, shown in the report's data summary next to the
+-- degraded scenario assumptions. Counts are added to as ingestion proceeds.
+
+CREATE TABLE IF NOT EXISTS failed_tx_tallies (
+    program      TEXT NOT NULL,
+    source       TEXT NOT NULL,               -- ws-swap | ws-liquidity | rpc-swap | rpc-liquidity | rpc-blocks
+    hour_start   BIGINT NOT NULL,             -- Unix timestamp (ms), start of the hour
+    transactions BIGINT NOT NULL DEFAULT 0,   -- observed, failed ones included
+    failed       BIGINT NOT NULL DEFAULT 0,   -- failed on chain, skipped
+    PRIMARY KEY (program, source, hour_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_failed_tx_tallies_hour ON failed_tx_tallies(hour_start);
+
+ALTER TABLE failed_tx_tallies DROP CONSTRAINT IF EXISTS chk_failed_tx_tallies_counts;
+ALTER TABLE failed_tx_tallies ADD CONSTRAINT chk_failed_tx_tallies_counts
+    CHECK (failed >= 0 AND failed <= transactions);
+
+COMMENT ON TABLE failed_tx_tallies IS 'Transactions observed and failed on chain per program, ingestion source and hour. Added to by ingestion.';